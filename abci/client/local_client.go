@@ -15,13 +15,29 @@ import (
 type localClient struct {
 	service.BaseService
 
-	mtx *tmsync.Mutex
+	mtx appLock
 	types.Application
 	Callback
 }
 
 var _ Client = (*localClient)(nil)
 
+// appLock serializes the calls to the app of its local clients.
+type appLock interface {
+	Lock()
+	Unlock()
+	// LockPriority is used by the consensus methods.
+	LockPriority()
+}
+
+type mutexAppLock struct {
+	*tmsync.Mutex
+}
+
+func (l mutexAppLock) LockPriority() {
+	l.Lock()
+}
+
 // NewLocalClient creates a local client, which will be directly calling the
 // methods of the given app.
 //
@@ -30,6 +46,21 @@ func NewLocalClient(mtx *tmsync.Mutex, app types.Application) Client {
 	if mtx == nil {
 		mtx = new(tmsync.Mutex)
 	}
+	return newLocalClient(mutexAppLock{mtx}, app)
+}
+
+// NewPriorityLocalClient creates a local client like NewLocalClient, except
+// that its calls to the consensus methods of the app (InitChain, BeginBlock,
+// DeliverTx, EndBlock and Commit) are made before the calls of the clients
+// sharing the mutex waiting to call the other methods, e.g. CheckTx.
+func NewPriorityLocalClient(mtx *tmsync.PriorityMutex, app types.Application) Client {
+	if mtx == nil {
+		mtx = new(tmsync.PriorityMutex)
+	}
+	return newLocalClient(mtx, app)
+}
+
+func newLocalClient(mtx appLock, app types.Application) Client {
 	cli := &localClient{
 		mtx:         mtx,
 		Application: app,
@@ -76,7 +107,7 @@ func (app *localClient) InfoAsync(ctx context.Context, req types.RequestInfo) (*
 }
 
 func (app *localClient) DeliverTxAsync(ctx context.Context, params types.RequestDeliverTx) (*ReqRes, error) {
-	app.mtx.Lock()
+	app.mtx.LockPriority()
 	defer app.mtx.Unlock()
 
 	res := app.Application.DeliverTx(params)
@@ -109,7 +140,7 @@ func (app *localClient) QueryAsync(ctx context.Context, req types.RequestQuery)
 }
 
 func (app *localClient) CommitAsync(ctx context.Context) (*ReqRes, error) {
-	app.mtx.Lock()
+	app.mtx.LockPriority()
 	defer app.mtx.Unlock()
 
 	res := app.Application.Commit()
@@ -120,7 +151,7 @@ func (app *localClient) CommitAsync(ctx context.Context) (*ReqRes, error) {
 }
 
 func (app *localClient) InitChainAsync(ctx context.Context, req types.RequestInitChain) (*ReqRes, error) {
-	app.mtx.Lock()
+	app.mtx.LockPriority()
 	defer app.mtx.Unlock()
 
 	res := app.Application.InitChain(req)
@@ -131,7 +162,7 @@ func (app *localClient) InitChainAsync(ctx context.Context, req types.RequestIni
 }
 
 func (app *localClient) BeginBlockAsync(ctx context.Context, req types.RequestBeginBlock) (*ReqRes, error) {
-	app.mtx.Lock()
+	app.mtx.LockPriority()
 	defer app.mtx.Unlock()
 
 	res := app.Application.BeginBlock(req)
@@ -142,7 +173,7 @@ func (app *localClient) BeginBlockAsync(ctx context.Context, req types.RequestBe
 }
 
 func (app *localClient) EndBlockAsync(ctx context.Context, req types.RequestEndBlock) (*ReqRes, error) {
-	app.mtx.Lock()
+	app.mtx.LockPriority()
 	defer app.mtx.Unlock()
 
 	res := app.Application.EndBlock(req)
@@ -225,7 +256,7 @@ func (app *localClient) DeliverTxSync(
 	req types.RequestDeliverTx,
 ) (*types.ResponseDeliverTx, error) {
 
-	app.mtx.Lock()
+	app.mtx.LockPriority()
 	defer app.mtx.Unlock()
 
 	res := app.Application.DeliverTx(req)
@@ -255,7 +286,7 @@ func (app *localClient) QuerySync(
 }
 
 func (app *localClient) CommitSync(ctx context.Context) (*types.ResponseCommit, error) {
-	app.mtx.Lock()
+	app.mtx.LockPriority()
 	defer app.mtx.Unlock()
 
 	res := app.Application.Commit()
@@ -267,7 +298,7 @@ func (app *localClient) InitChainSync(
 	req types.RequestInitChain,
 ) (*types.ResponseInitChain, error) {
 
-	app.mtx.Lock()
+	app.mtx.LockPriority()
 	defer app.mtx.Unlock()
 
 	res := app.Application.InitChain(req)
@@ -279,7 +310,7 @@ func (app *localClient) BeginBlockSync(
 	req types.RequestBeginBlock,
 ) (*types.ResponseBeginBlock, error) {
 
-	app.mtx.Lock()
+	app.mtx.LockPriority()
 	defer app.mtx.Unlock()
 
 	res := app.Application.BeginBlock(req)
@@ -291,7 +322,7 @@ func (app *localClient) EndBlockSync(
 	req types.RequestEndBlock,
 ) (*types.ResponseEndBlock, error) {
 
-	app.mtx.Lock()
+	app.mtx.LockPriority()
 	defer app.mtx.Unlock()
 
 	res := app.Application.EndBlock(req)
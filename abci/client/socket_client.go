@@ -26,6 +26,16 @@ const (
 	flushThrottleMS = 20
 )
 
+// SocketClientOption sets an optional parameter on the socket client.
+type SocketClientOption func(*socketClient)
+
+// SocketFlushThrottle sets the maximum time the async requests are buffered
+// before being flushed to the app, so that the requests of each connection
+// can be batched according to their latency requirements.
+func SocketFlushThrottle(d time.Duration) SocketClientOption {
+	return func(cli *socketClient) { cli.flushThrottle = d }
+}
+
 type reqResWithContext struct {
 	R *ReqRes
 	C context.Context // if context.Err is not nil, reqRes will be thrown away (ignored)
@@ -40,8 +50,9 @@ type socketClient struct {
 	mustConnect bool
 	conn        net.Conn
 
-	reqQueue   chan *reqResWithContext
-	flushTimer *timer.ThrottleTimer
+	reqQueue      chan *reqResWithContext
+	flushThrottle time.Duration
+	flushTimer    *timer.ThrottleTimer
 
 	mtx     tmsync.Mutex
 	err     error
@@ -54,16 +65,20 @@ var _ Client = (*socketClient)(nil)
 // NewSocketClient creates a new socket client, which connects to a given
 // address. If mustConnect is true, the client will return an error upon start
 // if it fails to connect.
-func NewSocketClient(addr string, mustConnect bool) Client {
+func NewSocketClient(addr string, mustConnect bool, options ...SocketClientOption) Client {
 	cli := &socketClient{
-		reqQueue:    make(chan *reqResWithContext, reqQueueSize),
-		flushTimer:  timer.NewThrottleTimer("socketClient", flushThrottleMS),
-		mustConnect: mustConnect,
+		reqQueue:      make(chan *reqResWithContext, reqQueueSize),
+		flushThrottle: flushThrottleMS,
+		mustConnect:   mustConnect,
 
 		addr:    addr,
 		reqSent: list.New(),
 		resCb:   nil,
 	}
+	for _, option := range options {
+		option(cli)
+	}
+	cli.flushTimer = timer.NewThrottleTimer("socketClient", cli.flushThrottle)
 	cli.BaseService = *service.NewBaseService(nil, "socketClient", cli)
 	return cli
 }
@@ -124,3 +124,45 @@ func (slowApp) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock
 	time.Sleep(200 * time.Millisecond)
 	return types.ResponseBeginBlock{}
 }
+
+func TestSocketFlushThrottle(t *testing.T) {
+	addr := startSocketServer(t, types.NewBaseApplication())
+	newClient := func(throttle time.Duration) abcicli.Client {
+		c := abcicli.NewSocketClient(addr, true, abcicli.SocketFlushThrottle(throttle))
+		require.NoError(t, c.Start())
+		t.Cleanup(func() {
+			if err := c.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		return c
+	}
+
+	// the async requests are flushed once the throttle elapses
+	reqres, err := newClient(time.Millisecond).EchoAsync(ctx, "hello")
+	require.NoError(t, err)
+	reqres.Wait()
+
+	// or by the sync ones, which are flushed right away
+	c := newClient(time.Hour)
+	reqres, err = c.EchoAsync(ctx, "hello")
+	require.NoError(t, err)
+	_, err = c.EchoSync(ctx, "hello")
+	require.NoError(t, err)
+	reqres.Wait()
+	assert.Equal(t, "hello", reqres.Response.GetEcho().Message)
+}
+
+func startSocketServer(t *testing.T, app types.Application) string {
+	port := 20000 + tmrand.Int32()%10000
+	addr := fmt.Sprintf("localhost:%d", port)
+	s, err := server.NewServer(addr, "socket", app)
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return addr
+}
@@ -29,7 +29,7 @@ type SocketServer struct {
 	conns      map[int]net.Conn
 	nextConnID int
 
-	appMtx tmsync.Mutex
+	appMtx tmsync.PriorityMutex // acquired first by the consensus requests
 	app    types.Application
 }
 
@@ -184,13 +184,30 @@ func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, resp
 			}
 			return
 		}
-		s.appMtx.Lock()
+		if isConsensusRequest(req) {
+			s.appMtx.LockPriority()
+		} else {
+			s.appMtx.Lock()
+		}
 		count++
 		s.handleRequest(req, responses)
 		s.appMtx.Unlock()
 	}
 }
 
+// isConsensusRequest returns true for the requests of the consensus
+// connection, handled before the requests of the other connections waiting
+// for the app (e.g. a flood of CheckTx requests).
+func isConsensusRequest(req *types.Request) bool {
+	switch req.Value.(type) {
+	case *types.Request_InitChain, *types.Request_BeginBlock, *types.Request_DeliverTx,
+		*types.Request_EndBlock, *types.Request_Commit:
+		return true
+	default:
+		return false
+	}
+}
+
 func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types.Response) {
 	switch r := req.Value.(type) {
 	case *types.Request_Echo:
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestIsConsensusRequest(t *testing.T) {
+	consensus := []*types.Request{
+		types.ToRequestInitChain(types.RequestInitChain{}),
+		types.ToRequestBeginBlock(types.RequestBeginBlock{}),
+		types.ToRequestDeliverTx(types.RequestDeliverTx{}),
+		types.ToRequestEndBlock(types.RequestEndBlock{}),
+		types.ToRequestCommit(),
+	}
+	for _, req := range consensus {
+		assert.True(t, isConsensusRequest(req), "%T", req.Value)
+	}
+
+	others := []*types.Request{
+		types.ToRequestEcho(""),
+		types.ToRequestFlush(),
+		types.ToRequestInfo(types.RequestInfo{}),
+		types.ToRequestCheckTx(types.RequestCheckTx{}),
+		types.ToRequestQuery(types.RequestQuery{}),
+		types.ToRequestListSnapshots(types.RequestListSnapshots{}),
+	}
+	for _, req := range others {
+		assert.False(t, isConsensusRequest(req), "%T", req.Value)
+	}
+}
@@ -58,6 +58,37 @@ func (CheckTxType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_252557cfdd89a31a, []int{0}
 }
 
+type TxSource int32
+
+const (
+	// The origin of the tx isn't known, e.g. sent by an older version.
+	TxSource_UnknownSource TxSource = 0
+	// Submitted to this node, e.g. via the RPC.
+	TxSource_Local TxSource = 1
+	// Gossiped by a peer.
+	TxSource_Peer TxSource = 2
+)
+
+var TxSource_name = map[int32]string{
+	0: "UNKNOWN_SOURCE",
+	1: "LOCAL",
+	2: "PEER",
+}
+
+var TxSource_value = map[string]int32{
+	"UNKNOWN_SOURCE": 0,
+	"LOCAL":          1,
+	"PEER":           2,
+}
+
+func (x TxSource) String() string {
+	return proto.EnumName(TxSource_name, int32(x))
+}
+
+func (TxSource) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{1}
+}
+
 type EvidenceType int32
 
 const (
@@ -83,7 +114,7 @@ func (x EvidenceType) String() string {
 }
 
 func (EvidenceType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{1}
+	return fileDescriptor_252557cfdd89a31a, []int{2}
 }
 
 type ResponseOfferSnapshot_Result int32
@@ -120,7 +151,7 @@ func (x ResponseOfferSnapshot_Result) String() string {
 }
 
 func (ResponseOfferSnapshot_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{28, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{29, 0}
 }
 
 type ResponseApplySnapshotChunk_Result int32
@@ -157,7 +188,7 @@ func (x ResponseApplySnapshotChunk_Result) String() string {
 }
 
 func (ResponseApplySnapshotChunk_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{31, 0}
 }
 
 type Request struct {
@@ -706,6 +737,10 @@ type RequestBeginBlock struct {
 	Header              types1.Header  `protobuf:"bytes,2,opt,name=header,proto3" json:"header"`
 	LastCommitInfo      LastCommitInfo `protobuf:"bytes,3,opt,name=last_commit_info,json=lastCommitInfo,proto3" json:"last_commit_info"`
 	ByzantineValidators []Evidence     `protobuf:"bytes,4,rep,name=byzantine_validators,json=byzantineValidators,proto3" json:"byzantine_validators"`
+	// Random seed of the block, derived from the signatures of its last commit
+	// (see types.RandomSeed). It can be biased by the proposer, see
+	// docs/app-dev/random-seed.md.
+	RandomSeed []byte `protobuf:"bytes,5,opt,name=random_seed,json=randomSeed,proto3" json:"random_seed,omitempty"`
 }
 
 func (m *RequestBeginBlock) Reset()         { *m = RequestBeginBlock{} }
@@ -769,9 +804,17 @@ func (m *RequestBeginBlock) GetByzantineValidators() []Evidence {
 	return nil
 }
 
+func (m *RequestBeginBlock) GetRandomSeed() []byte {
+	if m != nil {
+		return m.RandomSeed
+	}
+	return nil
+}
+
 type RequestCheckTx struct {
-	Tx   []byte      `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
-	Type CheckTxType `protobuf:"varint,2,opt,name=type,proto3,enum=tendermint.abci.CheckTxType" json:"type,omitempty"`
+	Tx     []byte      `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Type   CheckTxType `protobuf:"varint,2,opt,name=type,proto3,enum=tendermint.abci.CheckTxType" json:"type,omitempty"`
+	Origin TxOrigin    `protobuf:"bytes,3,opt,name=origin,proto3" json:"origin"`
 }
 
 func (m *RequestCheckTx) Reset()         { *m = RequestCheckTx{} }
@@ -821,6 +864,77 @@ func (m *RequestCheckTx) GetType() CheckTxType {
 	return CheckTxType_New
 }
 
+func (m *RequestCheckTx) GetOrigin() TxOrigin {
+	if m != nil {
+		return m.Origin
+	}
+	return TxOrigin{}
+}
+
+// TxOrigin tells the application where a tx given to CheckTx comes from, e.g.
+// to apply stricter limits to the txs gossiped by the peers. The origin of a
+// rechecked tx is the one of its first check.
+type TxOrigin struct {
+	Source TxSource `protobuf:"varint,1,opt,name=source,proto3,enum=tendermint.abci.TxSource" json:"source,omitempty"`
+	// The p2p ID of the peer which sent the tx, if the source is PEER.
+	PeerId     string    `protobuf:"bytes,2,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	ReceivedAt time.Time `protobuf:"bytes,3,opt,name=received_at,json=receivedAt,proto3,stdtime" json:"received_at"`
+}
+
+func (m *TxOrigin) Reset()         { *m = TxOrigin{} }
+func (m *TxOrigin) String() string { return proto.CompactTextString(m) }
+func (*TxOrigin) ProtoMessage()    {}
+func (*TxOrigin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{8}
+}
+func (m *TxOrigin) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TxOrigin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TxOrigin.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TxOrigin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxOrigin.Merge(m, src)
+}
+func (m *TxOrigin) XXX_Size() int {
+	return m.Size()
+}
+func (m *TxOrigin) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxOrigin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxOrigin proto.InternalMessageInfo
+
+func (m *TxOrigin) GetSource() TxSource {
+	if m != nil {
+		return m.Source
+	}
+	return TxSource_UnknownSource
+}
+
+func (m *TxOrigin) GetPeerId() string {
+	if m != nil {
+		return m.PeerId
+	}
+	return ""
+}
+
+func (m *TxOrigin) GetReceivedAt() time.Time {
+	if m != nil {
+		return m.ReceivedAt
+	}
+	return time.Time{}
+}
+
 type RequestDeliverTx struct {
 	Tx []byte `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
 }
@@ -829,7 +943,7 @@ func (m *RequestDeliverTx) Reset()         { *m = RequestDeliverTx{} }
 func (m *RequestDeliverTx) String() string { return proto.CompactTextString(m) }
 func (*RequestDeliverTx) ProtoMessage()    {}
 func (*RequestDeliverTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{8}
+	return fileDescriptor_252557cfdd89a31a, []int{9}
 }
 func (m *RequestDeliverTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -873,7 +987,7 @@ func (m *RequestEndBlock) Reset()         { *m = RequestEndBlock{} }
 func (m *RequestEndBlock) String() string { return proto.CompactTextString(m) }
 func (*RequestEndBlock) ProtoMessage()    {}
 func (*RequestEndBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{9}
+	return fileDescriptor_252557cfdd89a31a, []int{10}
 }
 func (m *RequestEndBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -916,7 +1030,7 @@ func (m *RequestCommit) Reset()         { *m = RequestCommit{} }
 func (m *RequestCommit) String() string { return proto.CompactTextString(m) }
 func (*RequestCommit) ProtoMessage()    {}
 func (*RequestCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{10}
+	return fileDescriptor_252557cfdd89a31a, []int{11}
 }
 func (m *RequestCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -953,7 +1067,7 @@ func (m *RequestListSnapshots) Reset()         { *m = RequestListSnapshots{} }
 func (m *RequestListSnapshots) String() string { return proto.CompactTextString(m) }
 func (*RequestListSnapshots) ProtoMessage()    {}
 func (*RequestListSnapshots) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{11}
+	return fileDescriptor_252557cfdd89a31a, []int{12}
 }
 func (m *RequestListSnapshots) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -992,7 +1106,7 @@ func (m *RequestOfferSnapshot) Reset()         { *m = RequestOfferSnapshot{} }
 func (m *RequestOfferSnapshot) String() string { return proto.CompactTextString(m) }
 func (*RequestOfferSnapshot) ProtoMessage()    {}
 func (*RequestOfferSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{12}
+	return fileDescriptor_252557cfdd89a31a, []int{13}
 }
 func (m *RequestOfferSnapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1046,7 +1160,7 @@ func (m *RequestLoadSnapshotChunk) Reset()         { *m = RequestLoadSnapshotChu
 func (m *RequestLoadSnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*RequestLoadSnapshotChunk) ProtoMessage()    {}
 func (*RequestLoadSnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{13}
+	return fileDescriptor_252557cfdd89a31a, []int{14}
 }
 func (m *RequestLoadSnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1107,7 +1221,7 @@ func (m *RequestApplySnapshotChunk) Reset()         { *m = RequestApplySnapshotC
 func (m *RequestApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*RequestApplySnapshotChunk) ProtoMessage()    {}
 func (*RequestApplySnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{14}
+	return fileDescriptor_252557cfdd89a31a, []int{15}
 }
 func (m *RequestApplySnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1181,7 +1295,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{15}
+	return fileDescriptor_252557cfdd89a31a, []int{16}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1420,7 +1534,7 @@ func (m *ResponseException) Reset()         { *m = ResponseException{} }
 func (m *ResponseException) String() string { return proto.CompactTextString(m) }
 func (*ResponseException) ProtoMessage()    {}
 func (*ResponseException) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{16}
+	return fileDescriptor_252557cfdd89a31a, []int{17}
 }
 func (m *ResponseException) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1464,7 +1578,7 @@ func (m *ResponseEcho) Reset()         { *m = ResponseEcho{} }
 func (m *ResponseEcho) String() string { return proto.CompactTextString(m) }
 func (*ResponseEcho) ProtoMessage()    {}
 func (*ResponseEcho) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{17}
+	return fileDescriptor_252557cfdd89a31a, []int{18}
 }
 func (m *ResponseEcho) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1507,7 +1621,7 @@ func (m *ResponseFlush) Reset()         { *m = ResponseFlush{} }
 func (m *ResponseFlush) String() string { return proto.CompactTextString(m) }
 func (*ResponseFlush) ProtoMessage()    {}
 func (*ResponseFlush) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{18}
+	return fileDescriptor_252557cfdd89a31a, []int{19}
 }
 func (m *ResponseFlush) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1549,7 +1663,7 @@ func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
 func (m *ResponseInfo) String() string { return proto.CompactTextString(m) }
 func (*ResponseInfo) ProtoMessage()    {}
 func (*ResponseInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{19}
+	return fileDescriptor_252557cfdd89a31a, []int{20}
 }
 func (m *ResponseInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1623,7 +1737,7 @@ func (m *ResponseInitChain) Reset()         { *m = ResponseInitChain{} }
 func (m *ResponseInitChain) String() string { return proto.CompactTextString(m) }
 func (*ResponseInitChain) ProtoMessage()    {}
 func (*ResponseInitChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{20}
+	return fileDescriptor_252557cfdd89a31a, []int{21}
 }
 func (m *ResponseInitChain) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1690,7 +1804,7 @@ func (m *ResponseQuery) Reset()         { *m = ResponseQuery{} }
 func (m *ResponseQuery) String() string { return proto.CompactTextString(m) }
 func (*ResponseQuery) ProtoMessage()    {}
 func (*ResponseQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{21}
+	return fileDescriptor_252557cfdd89a31a, []int{22}
 }
 func (m *ResponseQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1790,7 +1904,7 @@ func (m *ResponseBeginBlock) Reset()         { *m = ResponseBeginBlock{} }
 func (m *ResponseBeginBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseBeginBlock) ProtoMessage()    {}
 func (*ResponseBeginBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{22}
+	return fileDescriptor_252557cfdd89a31a, []int{23}
 }
 func (m *ResponseBeginBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1835,13 +1949,22 @@ type ResponseCheckTx struct {
 	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
 	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
 	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// price paid per unit of gas, in app-defined units. Used by the mempool's
+	// minimum gas price admission control.
+	GasPrice int64 `protobuf:"varint,9,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	// sender and nonce of the tx, used by the mempool's "sender-nonce" ordering.
+	Sender string `protobuf:"bytes,10,opt,name=sender,proto3" json:"sender,omitempty"`
+	Nonce  uint64 `protobuf:"varint,11,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// category of the tx, used by the mempool's per-category metrics, events
+	// and reaping quotas. Empty if uncategorized.
+	Category string `protobuf:"bytes,12,opt,name=category,proto3" json:"category,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
 func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseCheckTx) ProtoMessage()    {}
 func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{23}
+	return fileDescriptor_252557cfdd89a31a, []int{24}
 }
 func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1926,6 +2049,34 @@ func (m *ResponseCheckTx) GetCodespace() string {
 	return ""
 }
 
+func (m *ResponseCheckTx) GetGasPrice() int64 {
+	if m != nil {
+		return m.GasPrice
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *ResponseCheckTx) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
 type ResponseDeliverTx struct {
 	Code      uint32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Data      []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -1941,7 +2092,7 @@ func (m *ResponseDeliverTx) Reset()         { *m = ResponseDeliverTx{} }
 func (m *ResponseDeliverTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseDeliverTx) ProtoMessage()    {}
 func (*ResponseDeliverTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{24}
+	return fileDescriptor_252557cfdd89a31a, []int{25}
 }
 func (m *ResponseDeliverTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2027,16 +2178,17 @@ func (m *ResponseDeliverTx) GetCodespace() string {
 }
 
 type ResponseEndBlock struct {
-	ValidatorUpdates      []ValidatorUpdate `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates"`
-	ConsensusParamUpdates *ConsensusParams  `protobuf:"bytes,2,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
-	Events                []Event           `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	ValidatorUpdates      []ValidatorUpdate  `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates"`
+	ConsensusParamUpdates *ConsensusParams   `protobuf:"bytes,2,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
+	Events                []Event            `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	AppVersionUpgrade     *AppVersionUpgrade `protobuf:"bytes,4,opt,name=app_version_upgrade,json=appVersionUpgrade,proto3" json:"app_version_upgrade,omitempty"`
 }
 
 func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
 func (m *ResponseEndBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseEndBlock) ProtoMessage()    {}
 func (*ResponseEndBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{25}
+	return fileDescriptor_252557cfdd89a31a, []int{26}
 }
 func (m *ResponseEndBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2086,6 +2238,13 @@ func (m *ResponseEndBlock) GetEvents() []Event {
 	return nil
 }
 
+func (m *ResponseEndBlock) GetAppVersionUpgrade() *AppVersionUpgrade {
+	if m != nil {
+		return m.AppVersionUpgrade
+	}
+	return nil
+}
+
 type ResponseCommit struct {
 	// reserve 1
 	Data         []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -2096,7 +2255,7 @@ func (m *ResponseCommit) Reset()         { *m = ResponseCommit{} }
 func (m *ResponseCommit) String() string { return proto.CompactTextString(m) }
 func (*ResponseCommit) ProtoMessage()    {}
 func (*ResponseCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{26}
+	return fileDescriptor_252557cfdd89a31a, []int{27}
 }
 func (m *ResponseCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2147,7 +2306,7 @@ func (m *ResponseListSnapshots) Reset()         { *m = ResponseListSnapshots{} }
 func (m *ResponseListSnapshots) String() string { return proto.CompactTextString(m) }
 func (*ResponseListSnapshots) ProtoMessage()    {}
 func (*ResponseListSnapshots) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{27}
+	return fileDescriptor_252557cfdd89a31a, []int{28}
 }
 func (m *ResponseListSnapshots) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2191,7 +2350,7 @@ func (m *ResponseOfferSnapshot) Reset()         { *m = ResponseOfferSnapshot{} }
 func (m *ResponseOfferSnapshot) String() string { return proto.CompactTextString(m) }
 func (*ResponseOfferSnapshot) ProtoMessage()    {}
 func (*ResponseOfferSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{28}
+	return fileDescriptor_252557cfdd89a31a, []int{29}
 }
 func (m *ResponseOfferSnapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2235,7 +2394,7 @@ func (m *ResponseLoadSnapshotChunk) Reset()         { *m = ResponseLoadSnapshotC
 func (m *ResponseLoadSnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseLoadSnapshotChunk) ProtoMessage()    {}
 func (*ResponseLoadSnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{29}
+	return fileDescriptor_252557cfdd89a31a, []int{30}
 }
 func (m *ResponseLoadSnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2281,7 +2440,7 @@ func (m *ResponseApplySnapshotChunk) Reset()         { *m = ResponseApplySnapsho
 func (m *ResponseApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseApplySnapshotChunk) ProtoMessage()    {}
 func (*ResponseApplySnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30}
+	return fileDescriptor_252557cfdd89a31a, []int{31}
 }
 func (m *ResponseApplySnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2344,7 +2503,7 @@ func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
 func (m *ConsensusParams) String() string { return proto.CompactTextString(m) }
 func (*ConsensusParams) ProtoMessage()    {}
 func (*ConsensusParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{31}
+	return fileDescriptor_252557cfdd89a31a, []int{32}
 }
 func (m *ConsensusParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2413,7 +2572,7 @@ func (m *BlockParams) Reset()         { *m = BlockParams{} }
 func (m *BlockParams) String() string { return proto.CompactTextString(m) }
 func (*BlockParams) ProtoMessage()    {}
 func (*BlockParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{32}
+	return fileDescriptor_252557cfdd89a31a, []int{33}
 }
 func (m *BlockParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2456,6 +2615,63 @@ func (m *BlockParams) GetMaxGas() int64 {
 	return 0
 }
 
+// AppVersionUpgrade schedules an app version upgrade: blocks from the given
+// height on must carry the given app version. Before executing the block at
+// that height, the app must report (in ResponseInfo) an app version greater or
+// equal to the new one, otherwise the node halts.
+type AppVersionUpgrade struct {
+	AppVersion uint64 `protobuf:"varint,1,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+	// Note: must be greater than the height of the block scheduling the upgrade
+	Height int64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *AppVersionUpgrade) Reset()         { *m = AppVersionUpgrade{} }
+func (m *AppVersionUpgrade) String() string { return proto.CompactTextString(m) }
+func (*AppVersionUpgrade) ProtoMessage()    {}
+func (*AppVersionUpgrade) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{34}
+}
+func (m *AppVersionUpgrade) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AppVersionUpgrade) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AppVersionUpgrade.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AppVersionUpgrade) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppVersionUpgrade.Merge(m, src)
+}
+func (m *AppVersionUpgrade) XXX_Size() int {
+	return m.Size()
+}
+func (m *AppVersionUpgrade) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppVersionUpgrade.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppVersionUpgrade proto.InternalMessageInfo
+
+func (m *AppVersionUpgrade) GetAppVersion() uint64 {
+	if m != nil {
+		return m.AppVersion
+	}
+	return 0
+}
+
+func (m *AppVersionUpgrade) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 type LastCommitInfo struct {
 	Round int32      `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
 	Votes []VoteInfo `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes"`
@@ -2465,7 +2681,7 @@ func (m *LastCommitInfo) Reset()         { *m = LastCommitInfo{} }
 func (m *LastCommitInfo) String() string { return proto.CompactTextString(m) }
 func (*LastCommitInfo) ProtoMessage()    {}
 func (*LastCommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{33}
+	return fileDescriptor_252557cfdd89a31a, []int{35}
 }
 func (m *LastCommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2520,7 +2736,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{34}
+	return fileDescriptor_252557cfdd89a31a, []int{36}
 }
 func (m *Event) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2574,7 +2790,7 @@ func (m *EventAttribute) Reset()         { *m = EventAttribute{} }
 func (m *EventAttribute) String() string { return proto.CompactTextString(m) }
 func (*EventAttribute) ProtoMessage()    {}
 func (*EventAttribute) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{35}
+	return fileDescriptor_252557cfdd89a31a, []int{37}
 }
 func (m *EventAttribute) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2638,7 +2854,7 @@ func (m *TxResult) Reset()         { *m = TxResult{} }
 func (m *TxResult) String() string { return proto.CompactTextString(m) }
 func (*TxResult) ProtoMessage()    {}
 func (*TxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{36}
+	return fileDescriptor_252557cfdd89a31a, []int{38}
 }
 func (m *TxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2706,7 +2922,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{37}
+	return fileDescriptor_252557cfdd89a31a, []int{39}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2759,7 +2975,7 @@ func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
 func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorUpdate) ProtoMessage()    {}
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{38}
+	return fileDescriptor_252557cfdd89a31a, []int{40}
 }
 func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2812,7 +3028,7 @@ func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
 func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
 func (*VoteInfo) ProtoMessage()    {}
 func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{39}
+	return fileDescriptor_252557cfdd89a31a, []int{41}
 }
 func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2873,7 +3089,7 @@ func (m *Evidence) Reset()         { *m = Evidence{} }
 func (m *Evidence) String() string { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()    {}
 func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{40}
+	return fileDescriptor_252557cfdd89a31a, []int{42}
 }
 func (m *Evidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2949,7 +3165,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{41}
+	return fileDescriptor_252557cfdd89a31a, []int{43}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3015,6 +3231,7 @@ func (m *Snapshot) GetMetadata() []byte {
 
 func init() {
 	proto.RegisterEnum("tendermint.abci.CheckTxType", CheckTxType_name, CheckTxType_value)
+	proto.RegisterEnum("tendermint.abci.TxSource", TxSource_name, TxSource_value)
 	proto.RegisterEnum("tendermint.abci.EvidenceType", EvidenceType_name, EvidenceType_value)
 	proto.RegisterEnum("tendermint.abci.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
 	proto.RegisterEnum("tendermint.abci.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
@@ -3026,6 +3243,7 @@ func init() {
 	proto.RegisterType((*RequestQuery)(nil), "tendermint.abci.RequestQuery")
 	proto.RegisterType((*RequestBeginBlock)(nil), "tendermint.abci.RequestBeginBlock")
 	proto.RegisterType((*RequestCheckTx)(nil), "tendermint.abci.RequestCheckTx")
+	proto.RegisterType((*TxOrigin)(nil), "tendermint.abci.TxOrigin")
 	proto.RegisterType((*RequestDeliverTx)(nil), "tendermint.abci.RequestDeliverTx")
 	proto.RegisterType((*RequestEndBlock)(nil), "tendermint.abci.RequestEndBlock")
 	proto.RegisterType((*RequestCommit)(nil), "tendermint.abci.RequestCommit")
@@ -3051,6 +3269,7 @@ func init() {
 	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "tendermint.abci.ResponseApplySnapshotChunk")
 	proto.RegisterType((*ConsensusParams)(nil), "tendermint.abci.ConsensusParams")
 	proto.RegisterType((*BlockParams)(nil), "tendermint.abci.BlockParams")
+	proto.RegisterType((*AppVersionUpgrade)(nil), "tendermint.abci.AppVersionUpgrade")
 	proto.RegisterType((*LastCommitInfo)(nil), "tendermint.abci.LastCommitInfo")
 	proto.RegisterType((*Event)(nil), "tendermint.abci.Event")
 	proto.RegisterType((*EventAttribute)(nil), "tendermint.abci.EventAttribute")
@@ -3065,176 +3284,191 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 2691 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x4b, 0x73, 0x1b, 0xc7,
-	0x11, 0xc6, 0xe2, 0xbd, 0x0d, 0xe2, 0xc1, 0x11, 0x2d, 0xc3, 0xb0, 0x4c, 0x4a, 0xab, 0x92, 0x63,
-	0xc9, 0x36, 0x19, 0x53, 0x25, 0x45, 0x2a, 0x3b, 0xb1, 0x09, 0x08, 0x32, 0x68, 0x32, 0x24, 0xb3,
-	0x84, 0xe4, 0xbc, 0xac, 0xf5, 0x02, 0x3b, 0x04, 0xd6, 0x02, 0x76, 0xd7, 0xd8, 0x01, 0x45, 0xfa,
-	0x98, 0xc7, 0x45, 0xb9, 0xe8, 0x98, 0x8b, 0xab, 0xf2, 0x0f, 0x72, 0xcd, 0x29, 0x97, 0x5c, 0x5c,
-	0x95, 0x4a, 0x95, 0x8f, 0x39, 0x39, 0x29, 0xe9, 0x96, 0x3f, 0x90, 0x53, 0x2a, 0xa9, 0x79, 0xec,
-	0x0b, 0xc0, 0x12, 0x60, 0x9c, 0x5b, 0x6e, 0x3b, 0xbd, 0xdd, 0x8d, 0x99, 0xde, 0xe9, 0xaf, 0xbf,
-	0xe9, 0x01, 0xbc, 0x4a, 0xb0, 0x65, 0xe0, 0xd1, 0xd0, 0xb4, 0xc8, 0x86, 0xde, 0xe9, 0x9a, 0x1b,
-	0xe4, 0xd4, 0xc1, 0xee, 0xba, 0x33, 0xb2, 0x89, 0x8d, 0xca, 0xc1, 0xcb, 0x75, 0xfa, 0xb2, 0xf6,
-	0x5a, 0x48, 0xbb, 0x3b, 0x3a, 0x75, 0x88, 0xbd, 0xe1, 0x8c, 0x6c, 0xfb, 0x88, 0xeb, 0xd7, 0x2e,
-	0x85, 0x5e, 0x33, 0x3f, 0x61, 0x6f, 0x91, 0xb7, 0xc2, 0xf8, 0x31, 0x3e, 0xf5, 0xde, 0xbe, 0x36,
-	0x65, 0xeb, 0xe8, 0x23, 0x7d, 0xe8, 0xbd, 0x5e, 0xeb, 0xd9, 0x76, 0x6f, 0x80, 0x37, 0xd8, 0xa8,
-	0x33, 0x3e, 0xda, 0x20, 0xe6, 0x10, 0xbb, 0x44, 0x1f, 0x3a, 0x42, 0x61, 0xa5, 0x67, 0xf7, 0x6c,
-	0xf6, 0xb8, 0x41, 0x9f, 0xb8, 0x54, 0xf9, 0x4b, 0x0e, 0x72, 0x2a, 0xfe, 0x7c, 0x8c, 0x5d, 0x82,
-	0x36, 0x21, 0x8d, 0xbb, 0x7d, 0xbb, 0x2a, 0x5d, 0x96, 0xde, 0x28, 0x6c, 0x5e, 0x5a, 0x9f, 0x58,
-	0xdc, 0xba, 0xd0, 0x6b, 0x76, 0xfb, 0x76, 0x2b, 0xa1, 0x32, 0x5d, 0x74, 0x0b, 0x32, 0x47, 0x83,
-	0xb1, 0xdb, 0xaf, 0x26, 0x99, 0xd1, 0x6b, 0x71, 0x46, 0xf7, 0xa9, 0x52, 0x2b, 0xa1, 0x72, 0x6d,
-	0xfa, 0x53, 0xa6, 0x75, 0x64, 0x57, 0x53, 0x67, 0xff, 0xd4, 0xb6, 0x75, 0xc4, 0x7e, 0x8a, 0xea,
-	0xa2, 0x3a, 0x80, 0x69, 0x99, 0x44, 0xeb, 0xf6, 0x75, 0xd3, 0xaa, 0xa6, 0x99, 0xe5, 0x95, 0x78,
-	0x4b, 0x93, 0x34, 0xa8, 0x62, 0x2b, 0xa1, 0xca, 0xa6, 0x37, 0xa0, 0xd3, 0xfd, 0x7c, 0x8c, 0x47,
-	0xa7, 0xd5, 0xcc, 0xd9, 0xd3, 0xfd, 0x11, 0x55, 0xa2, 0xd3, 0x65, 0xda, 0xa8, 0x09, 0x85, 0x0e,
-	0xee, 0x99, 0x96, 0xd6, 0x19, 0xd8, 0xdd, 0xc7, 0xd5, 0x2c, 0x33, 0x56, 0xe2, 0x8c, 0xeb, 0x54,
-	0xb5, 0x4e, 0x35, 0x5b, 0x09, 0x15, 0x3a, 0xfe, 0x08, 0xbd, 0x07, 0xf9, 0x6e, 0x1f, 0x77, 0x1f,
-	0x6b, 0xe4, 0xa4, 0x9a, 0x63, 0x3e, 0xd6, 0xe2, 0x7c, 0x34, 0xa8, 0x5e, 0xfb, 0xa4, 0x95, 0x50,
-	0x73, 0x5d, 0xfe, 0x48, 0xd7, 0x6f, 0xe0, 0x81, 0x79, 0x8c, 0x47, 0xd4, 0x3e, 0x7f, 0xf6, 0xfa,
-	0xef, 0x71, 0x4d, 0xe6, 0x41, 0x36, 0xbc, 0x01, 0x7a, 0x1f, 0x64, 0x6c, 0x19, 0x62, 0x19, 0x32,
-	0x73, 0x71, 0x39, 0xf6, 0x3b, 0x5b, 0x86, 0xb7, 0x88, 0x3c, 0x16, 0xcf, 0xe8, 0x0e, 0x64, 0xbb,
-	0xf6, 0x70, 0x68, 0x92, 0x2a, 0x30, 0xeb, 0xd5, 0xd8, 0x05, 0x30, 0xad, 0x56, 0x42, 0x15, 0xfa,
-	0x68, 0x0f, 0x4a, 0x03, 0xd3, 0x25, 0x9a, 0x6b, 0xe9, 0x8e, 0xdb, 0xb7, 0x89, 0x5b, 0x2d, 0x30,
-	0x0f, 0xd7, 0xe2, 0x3c, 0xec, 0x9a, 0x2e, 0x39, 0xf4, 0x94, 0x5b, 0x09, 0xb5, 0x38, 0x08, 0x0b,
-	0xa8, 0x3f, 0xfb, 0xe8, 0x08, 0x8f, 0x7c, 0x87, 0xd5, 0xa5, 0xb3, 0xfd, 0xed, 0x53, 0x6d, 0xcf,
-	0x9e, 0xfa, 0xb3, 0xc3, 0x02, 0xf4, 0x33, 0xb8, 0x30, 0xb0, 0x75, 0xc3, 0x77, 0xa7, 0x75, 0xfb,
-	0x63, 0xeb, 0x71, 0xb5, 0xc8, 0x9c, 0x5e, 0x8f, 0x9d, 0xa4, 0xad, 0x1b, 0x9e, 0x8b, 0x06, 0x35,
-	0x68, 0x25, 0xd4, 0xe5, 0xc1, 0xa4, 0x10, 0x3d, 0x82, 0x15, 0xdd, 0x71, 0x06, 0xa7, 0x93, 0xde,
-	0x4b, 0xcc, 0xfb, 0x8d, 0x38, 0xef, 0x5b, 0xd4, 0x66, 0xd2, 0x3d, 0xd2, 0xa7, 0xa4, 0xf5, 0x1c,
-	0x64, 0x8e, 0xf5, 0xc1, 0x18, 0x2b, 0xdf, 0x81, 0x42, 0x28, 0x4d, 0x51, 0x15, 0x72, 0x43, 0xec,
-	0xba, 0x7a, 0x0f, 0xb3, 0xac, 0x96, 0x55, 0x6f, 0xa8, 0x94, 0x60, 0x29, 0x9c, 0x9a, 0xca, 0x33,
-	0xc9, 0xb7, 0xa4, 0x59, 0x47, 0x2d, 0x8f, 0xf1, 0xc8, 0x35, 0x6d, 0xcb, 0xb3, 0x14, 0x43, 0x74,
-	0x15, 0x8a, 0x6c, 0xff, 0x68, 0xde, 0x7b, 0x9a, 0xfa, 0x69, 0x75, 0x89, 0x09, 0x1f, 0x0a, 0xa5,
-	0x35, 0x28, 0x38, 0x9b, 0x8e, 0xaf, 0x92, 0x62, 0x2a, 0xe0, 0x6c, 0x3a, 0x9e, 0xc2, 0x15, 0x58,
-	0xa2, 0x2b, 0xf5, 0x35, 0xd2, 0xec, 0x47, 0x0a, 0x54, 0x26, 0x54, 0x94, 0x3f, 0x27, 0xa1, 0x32,
-	0x99, 0xce, 0xe8, 0x0e, 0xa4, 0x29, 0xb2, 0x09, 0x90, 0xaa, 0xad, 0x73, 0xd8, 0x5b, 0xf7, 0x60,
-	0x6f, 0xbd, 0xed, 0xc1, 0x5e, 0x3d, 0xff, 0xd5, 0x37, 0x6b, 0x89, 0x67, 0x7f, 0x5b, 0x93, 0x54,
-	0x66, 0x81, 0x5e, 0xa1, 0xd9, 0xa7, 0x9b, 0x96, 0x66, 0x1a, 0x6c, 0xca, 0x32, 0x4d, 0x2d, 0xdd,
-	0xb4, 0xb6, 0x0d, 0xb4, 0x03, 0x95, 0xae, 0x6d, 0xb9, 0xd8, 0x72, 0xc7, 0xae, 0xc6, 0x61, 0x55,
-	0x40, 0xd3, 0x74, 0x76, 0x34, 0x3c, 0xc5, 0x03, 0xa6, 0xa7, 0x96, 0xbb, 0x51, 0x01, 0xba, 0x0f,
-	0x70, 0xac, 0x0f, 0x4c, 0x43, 0x27, 0xf6, 0xc8, 0xad, 0xa6, 0x2f, 0xa7, 0x66, 0xba, 0x79, 0xe8,
-	0xa9, 0x3c, 0x70, 0x0c, 0x9d, 0xe0, 0x7a, 0x9a, 0xce, 0x56, 0x0d, 0x59, 0xa2, 0xd7, 0xa1, 0xac,
-	0x3b, 0x8e, 0xe6, 0x12, 0x9d, 0x60, 0xad, 0x73, 0x4a, 0xb0, 0xcb, 0x50, 0x6b, 0x49, 0x2d, 0xea,
-	0x8e, 0x73, 0x48, 0xa5, 0x75, 0x2a, 0x44, 0xd7, 0xa0, 0x44, 0x01, 0xce, 0xd4, 0x07, 0x5a, 0x1f,
-	0x9b, 0xbd, 0x3e, 0x61, 0xf8, 0x94, 0x52, 0x8b, 0x42, 0xda, 0x62, 0x42, 0xc5, 0xf0, 0x3f, 0x38,
-	0x03, 0x37, 0x84, 0x20, 0x6d, 0xe8, 0x44, 0x67, 0x81, 0x5c, 0x52, 0xd9, 0x33, 0x95, 0x39, 0x3a,
-	0xe9, 0x8b, 0xf0, 0xb0, 0x67, 0x74, 0x11, 0xb2, 0xc2, 0x6d, 0x8a, 0xb9, 0x15, 0x23, 0xb4, 0x02,
-	0x19, 0x67, 0x64, 0x1f, 0x63, 0xf6, 0xe5, 0xf2, 0x2a, 0x1f, 0x28, 0xbf, 0x4a, 0xc2, 0xf2, 0x14,
-	0x0c, 0x52, 0xbf, 0x7d, 0xdd, 0xed, 0x7b, 0xbf, 0x45, 0x9f, 0xd1, 0x6d, 0xea, 0x57, 0x37, 0xf0,
-	0x48, 0x94, 0x8e, 0x6a, 0x38, 0x44, 0xbc, 0x2c, 0xb6, 0xd8, 0x7b, 0x11, 0x1a, 0xa1, 0x8d, 0xf6,
-	0xa1, 0x32, 0xd0, 0x5d, 0xa2, 0x71, 0x58, 0xd1, 0x42, 0x65, 0x64, 0x1a, 0x4c, 0x77, 0x75, 0x0f,
-	0x88, 0xe8, 0x9e, 0x16, 0x8e, 0x4a, 0x83, 0x88, 0x14, 0xa9, 0xb0, 0xd2, 0x39, 0xfd, 0x42, 0xb7,
-	0x88, 0x69, 0x61, 0x6d, 0xea, 0xcb, 0xbd, 0x32, 0xe5, 0xb4, 0x79, 0x6c, 0x1a, 0xd8, 0xea, 0x7a,
-	0x9f, 0xec, 0x82, 0x6f, 0xec, 0x7f, 0x52, 0x57, 0x51, 0xa1, 0x14, 0x05, 0x72, 0x54, 0x82, 0x24,
-	0x39, 0x11, 0x01, 0x48, 0x92, 0x13, 0xf4, 0x5d, 0x48, 0xd3, 0x45, 0xb2, 0xc5, 0x97, 0x66, 0x54,
-	0x40, 0x61, 0xd7, 0x3e, 0x75, 0xb0, 0xca, 0x34, 0x15, 0xc5, 0xcf, 0x06, 0x1f, 0xdc, 0x27, 0xbd,
-	0x2a, 0xd7, 0xa1, 0x3c, 0x81, 0xde, 0xa1, 0xef, 0x27, 0x85, 0xbf, 0x9f, 0x52, 0x86, 0x62, 0x04,
-	0xaa, 0x95, 0x8b, 0xb0, 0x32, 0x0b, 0x79, 0x95, 0xbe, 0x2f, 0x8f, 0x20, 0x28, 0xba, 0x05, 0x79,
-	0x1f, 0x7a, 0x79, 0x36, 0x4e, 0xc7, 0xca, 0x53, 0x56, 0x7d, 0x55, 0x9a, 0x86, 0x74, 0x5b, 0xb3,
-	0xfd, 0x90, 0x64, 0x13, 0xcf, 0xe9, 0x8e, 0xd3, 0xd2, 0xdd, 0xbe, 0xf2, 0x29, 0x54, 0xe3, 0x60,
-	0x75, 0x62, 0x19, 0x69, 0x7f, 0x1b, 0x5e, 0x84, 0xec, 0x91, 0x3d, 0x1a, 0xea, 0x84, 0x39, 0x2b,
-	0xaa, 0x62, 0x44, 0xb7, 0x27, 0x87, 0xd8, 0x14, 0x13, 0xf3, 0x81, 0xa2, 0xc1, 0x2b, 0xb1, 0xd0,
-	0x4a, 0x4d, 0x4c, 0xcb, 0xc0, 0x3c, 0x9e, 0x45, 0x95, 0x0f, 0x02, 0x47, 0x7c, 0xb2, 0x7c, 0x40,
-	0x7f, 0xd6, 0x65, 0x6b, 0x65, 0xfe, 0x65, 0x55, 0x8c, 0x94, 0xdf, 0xe5, 0x21, 0xaf, 0x62, 0xd7,
-	0xa1, 0x98, 0x80, 0xea, 0x20, 0xe3, 0x93, 0x2e, 0x76, 0x88, 0x87, 0xa2, 0xb3, 0x49, 0x03, 0xd7,
-	0x6e, 0x7a, 0x9a, 0xb4, 0x62, 0xfb, 0x66, 0xe8, 0xa6, 0x20, 0x65, 0xf1, 0xfc, 0x4a, 0x98, 0x87,
-	0x59, 0xd9, 0x6d, 0x8f, 0x95, 0xa5, 0x62, 0x8b, 0x34, 0xb7, 0x9a, 0xa0, 0x65, 0x37, 0x05, 0x2d,
-	0x4b, 0xcf, 0xf9, 0xb1, 0x08, 0x2f, 0x6b, 0x44, 0x78, 0x59, 0x66, 0xce, 0x32, 0x63, 0x88, 0xd9,
-	0x6d, 0x8f, 0x98, 0x65, 0xe7, 0xcc, 0x78, 0x82, 0x99, 0xdd, 0x8f, 0x32, 0x33, 0xce, 0xaa, 0xae,
-	0xc6, 0x5a, 0xc7, 0x52, 0xb3, 0xef, 0x87, 0xa8, 0x59, 0x3e, 0x96, 0x17, 0x71, 0x27, 0x33, 0xb8,
-	0x59, 0x23, 0xc2, 0xcd, 0xe4, 0x39, 0x31, 0x88, 0x21, 0x67, 0x1f, 0x84, 0xc9, 0x19, 0xc4, 0xf2,
-	0x3b, 0xf1, 0xbd, 0x67, 0xb1, 0xb3, 0xbb, 0x3e, 0x3b, 0x2b, 0xc4, 0xd2, 0x4b, 0xb1, 0x86, 0x49,
-	0x7a, 0xb6, 0x3f, 0x45, 0xcf, 0x38, 0x9d, 0x7a, 0x3d, 0xd6, 0xc5, 0x1c, 0x7e, 0xb6, 0x3f, 0xc5,
-	0xcf, 0x8a, 0x73, 0x1c, 0xce, 0x21, 0x68, 0x3f, 0x9f, 0x4d, 0xd0, 0xe2, 0x29, 0x94, 0x98, 0xe6,
-	0x62, 0x0c, 0x4d, 0x8b, 0x61, 0x68, 0x65, 0xe6, 0xfe, 0xcd, 0x58, 0xf7, 0xe7, 0xa7, 0x68, 0xd7,
-	0x69, 0x85, 0x9c, 0xc8, 0x79, 0x8a, 0x32, 0x78, 0x34, 0xb2, 0x47, 0x82, 0x6c, 0xf1, 0x81, 0xf2,
-	0x06, 0xad, 0xd9, 0x41, 0x7e, 0x9f, 0x41, 0xe7, 0x18, 0x9a, 0x87, 0x72, 0x5a, 0xf9, 0x83, 0x14,
-	0xd8, 0xb2, 0x32, 0x17, 0xae, 0xf7, 0xb2, 0xa8, 0xf7, 0x21, 0x92, 0x97, 0x8c, 0x92, 0xbc, 0x35,
-	0x28, 0x50, 0x94, 0x9e, 0xe0, 0x6f, 0xba, 0xe3, 0xf3, 0xb7, 0x1b, 0xb0, 0xcc, 0xca, 0x30, 0xa7,
-	0x82, 0x02, 0x9a, 0xd3, 0xac, 0xc2, 0x94, 0xe9, 0x0b, 0xbe, 0x39, 0x39, 0x46, 0xbf, 0x0d, 0x17,
-	0x42, 0xba, 0x3e, 0xfa, 0x73, 0x36, 0x53, 0xf1, 0xb5, 0xb7, 0x44, 0x19, 0xf8, 0x93, 0x14, 0x44,
-	0x28, 0x20, 0x7e, 0xb3, 0x38, 0x9a, 0xf4, 0xbf, 0xe1, 0x68, 0xc9, 0xff, 0x9a, 0xa3, 0x85, 0x8b,
-	0x59, 0x2a, 0x5a, 0xcc, 0xfe, 0x29, 0x05, 0x9f, 0xc4, 0x67, 0x5c, 0x5d, 0xdb, 0xc0, 0xa2, 0xbc,
-	0xb0, 0x67, 0x54, 0x81, 0xd4, 0xc0, 0xee, 0x89, 0x22, 0x42, 0x1f, 0xa9, 0x96, 0x8f, 0xc1, 0xb2,
-	0x80, 0x58, 0xbf, 0x32, 0x65, 0x58, 0x80, 0x45, 0x65, 0xaa, 0x40, 0xea, 0x31, 0xe6, 0x88, 0xb9,
-	0xa4, 0xd2, 0x47, 0xaa, 0xc7, 0xf6, 0x18, 0xc3, 0xc1, 0x25, 0x95, 0x0f, 0xd0, 0x1d, 0x90, 0x59,
-	0x13, 0x42, 0xb3, 0x1d, 0x57, 0x80, 0xdb, 0xab, 0xe1, 0xb5, 0xf2, 0x5e, 0xc3, 0xfa, 0x01, 0xd5,
-	0xd9, 0x77, 0x5c, 0x35, 0xef, 0x88, 0xa7, 0x50, 0xd1, 0x95, 0x23, 0xdc, 0xef, 0x12, 0xc8, 0x74,
-	0xf6, 0xae, 0xa3, 0x77, 0x31, 0x43, 0x2a, 0x59, 0x0d, 0x04, 0xca, 0x23, 0x40, 0xd3, 0x78, 0x8b,
-	0x5a, 0x90, 0xc5, 0xc7, 0xd8, 0x22, 0xf4, 0xab, 0xd1, 0x70, 0x5f, 0x9c, 0x41, 0xac, 0xb0, 0x45,
-	0xea, 0x55, 0x1a, 0xe4, 0x7f, 0x7c, 0xb3, 0x56, 0xe1, 0xda, 0x6f, 0xd9, 0x43, 0x93, 0xe0, 0xa1,
-	0x43, 0x4e, 0x55, 0x61, 0xaf, 0xfc, 0x32, 0x49, 0x59, 0x4e, 0x04, 0x8b, 0x67, 0xc6, 0xd6, 0xdb,
-	0xf1, 0xc9, 0x10, 0xc3, 0x5d, 0x2c, 0xde, 0xab, 0x00, 0x3d, 0xdd, 0xd5, 0x9e, 0xe8, 0x16, 0xc1,
-	0x86, 0x08, 0x7a, 0x48, 0x82, 0x6a, 0x90, 0xa7, 0xa3, 0xb1, 0x8b, 0x0d, 0x41, 0xb6, 0xfd, 0x71,
-	0x68, 0x9d, 0xb9, 0x6f, 0xb7, 0xce, 0x68, 0x94, 0xf3, 0x93, 0x51, 0xfe, 0x75, 0x32, 0xc8, 0x92,
-	0x80, 0x10, 0xfe, 0xff, 0xc5, 0xe1, 0x37, 0xec, 0x94, 0x18, 0x2d, 0x8a, 0xe8, 0x10, 0x96, 0xfd,
-	0x2c, 0xd5, 0xc6, 0x2c, 0x7b, 0xbd, 0x7d, 0xb7, 0x68, 0x9a, 0x57, 0x8e, 0xa3, 0x62, 0x17, 0xfd,
-	0x18, 0x5e, 0x9e, 0x40, 0x20, 0xdf, 0x75, 0x72, 0x41, 0x20, 0x7a, 0x29, 0x0a, 0x44, 0x9e, 0xe7,
-	0x20, 0x56, 0xa9, 0x6f, 0x99, 0x1b, 0xdb, 0xf4, 0xe0, 0x11, 0x2e, 0xf1, 0x33, 0xbf, 0xfe, 0x55,
-	0x28, 0x8e, 0x30, 0xa1, 0x67, 0xe1, 0xc8, 0xd1, 0x6e, 0x89, 0x0b, 0xc5, 0x81, 0xf1, 0x00, 0x5e,
-	0x9a, 0x59, 0xea, 0xd1, 0xf7, 0x40, 0x0e, 0x58, 0x82, 0x14, 0x73, 0x4a, 0xf2, 0x99, 0x7f, 0xa0,
-	0xab, 0xfc, 0x51, 0x0a, 0x5c, 0x46, 0xcf, 0x12, 0x4d, 0xc8, 0x8e, 0xb0, 0x3b, 0x1e, 0x70, 0x76,
-	0x5f, 0xda, 0x7c, 0x7b, 0x31, 0x92, 0x40, 0xa5, 0xe3, 0x01, 0x51, 0x85, 0xb1, 0xf2, 0x08, 0xb2,
-	0x5c, 0x82, 0x0a, 0x90, 0x7b, 0xb0, 0xb7, 0xb3, 0xb7, 0xff, 0xf1, 0x5e, 0x25, 0x81, 0x00, 0xb2,
-	0x5b, 0x8d, 0x46, 0xf3, 0xa0, 0x5d, 0x91, 0x90, 0x0c, 0x99, 0xad, 0xfa, 0xbe, 0xda, 0xae, 0x24,
-	0xa9, 0x58, 0x6d, 0x7e, 0xd4, 0x6c, 0xb4, 0x2b, 0x29, 0xb4, 0x0c, 0x45, 0xfe, 0xac, 0xdd, 0xdf,
-	0x57, 0x7f, 0xb8, 0xd5, 0xae, 0xa4, 0x43, 0xa2, 0xc3, 0xe6, 0xde, 0xbd, 0xa6, 0x5a, 0xc9, 0x28,
-	0xef, 0xd0, 0xe3, 0x43, 0x0c, 0xad, 0x08, 0x0e, 0x0a, 0x52, 0xe8, 0xa0, 0xa0, 0xfc, 0x36, 0x09,
-	0xb5, 0x78, 0xae, 0x80, 0x3e, 0x9a, 0x58, 0xf8, 0xe6, 0x39, 0x88, 0xc6, 0xc4, 0xea, 0xd1, 0x35,
-	0x28, 0x8d, 0xf0, 0x11, 0x26, 0xdd, 0x3e, 0xe7, 0x2e, 0xbc, 0xb0, 0x15, 0xd5, 0xa2, 0x90, 0x32,
-	0x23, 0x97, 0xab, 0x7d, 0x86, 0xbb, 0x44, 0xe3, 0x67, 0x16, 0xbe, 0xe9, 0x64, 0xaa, 0x46, 0xa5,
-	0x87, 0x5c, 0xa8, 0x7c, 0x7a, 0xae, 0x58, 0xca, 0x90, 0x51, 0x9b, 0x6d, 0xf5, 0x27, 0x95, 0x14,
-	0x42, 0x50, 0x62, 0x8f, 0xda, 0xe1, 0xde, 0xd6, 0xc1, 0x61, 0x6b, 0x9f, 0xc6, 0xf2, 0x02, 0x94,
-	0xbd, 0x58, 0x7a, 0xc2, 0x8c, 0xf2, 0x6f, 0x09, 0xca, 0x13, 0x09, 0x82, 0x36, 0x21, 0xc3, 0xf9,
-	0x6f, 0x5c, 0x13, 0x9a, 0xe5, 0xb7, 0xc8, 0x26, 0xae, 0x8a, 0xde, 0x83, 0x3c, 0x16, 0x67, 0xf2,
-	0x59, 0x89, 0xc8, 0x7b, 0x09, 0xde, 0xa9, 0x5d, 0x98, 0xfa, 0x16, 0xe8, 0x7d, 0x90, 0xfd, 0x4c,
-	0x17, 0xe7, 0xa5, 0x2b, 0xd3, 0xe6, 0x3e, 0x46, 0x08, 0xfb, 0xc0, 0x06, 0xdd, 0x0d, 0x48, 0x54,
-	0x7a, 0x9a, 0x75, 0x0b, 0x73, 0xae, 0x20, 0x8c, 0x3d, 0x7d, 0xa5, 0x01, 0x85, 0xd0, 0x7a, 0xd0,
-	0xab, 0x20, 0x0f, 0xf5, 0x13, 0xd1, 0xeb, 0xe1, 0xa7, 0xf5, 0xfc, 0x50, 0x3f, 0xe1, 0x6d, 0x9e,
-	0x97, 0x21, 0x47, 0x5f, 0xf6, 0x74, 0x8e, 0x36, 0x29, 0x35, 0x3b, 0xd4, 0x4f, 0x3e, 0xd4, 0x5d,
-	0xe5, 0x13, 0x28, 0x45, 0xfb, 0x1c, 0x74, 0x27, 0x8e, 0xec, 0xb1, 0x65, 0x30, 0x1f, 0x19, 0x95,
-	0x0f, 0xd0, 0x2d, 0xc8, 0x1c, 0xdb, 0x1c, 0xac, 0x66, 0xa7, 0xec, 0x43, 0x9b, 0xe0, 0x50, 0x9f,
-	0x84, 0x6b, 0x2b, 0x5f, 0x40, 0x86, 0x81, 0x0f, 0x05, 0x12, 0xd6, 0xb1, 0x10, 0x04, 0x92, 0x3e,
-	0xa3, 0x4f, 0x00, 0x74, 0x42, 0x46, 0x66, 0x67, 0x1c, 0x38, 0x5e, 0x9b, 0x0d, 0x5e, 0x5b, 0x9e,
-	0x5e, 0xfd, 0x92, 0x40, 0xb1, 0x95, 0xc0, 0x34, 0x84, 0x64, 0x21, 0x87, 0xca, 0x1e, 0x94, 0xa2,
-	0xb6, 0x1e, 0xe7, 0x91, 0x66, 0x70, 0x9e, 0x64, 0x98, 0xf3, 0xf8, 0x8c, 0x29, 0xc5, 0xbb, 0x53,
-	0x6c, 0xa0, 0x3c, 0x95, 0x20, 0xdf, 0x3e, 0x11, 0xdb, 0x3a, 0xa6, 0x31, 0x12, 0x98, 0x26, 0xc3,
-	0x6d, 0x00, 0xde, 0x69, 0x49, 0xf9, 0xfd, 0x9b, 0x0f, 0xfc, 0xc4, 0x4d, 0x2f, 0x7a, 0xda, 0xf3,
-	0x1a, 0x59, 0x02, 0xac, 0xde, 0x05, 0xd9, 0xdf, 0x55, 0x94, 0x89, 0xeb, 0x86, 0x31, 0xc2, 0xae,
-	0x2b, 0xd6, 0xe6, 0x0d, 0x59, 0x9f, 0xcd, 0x7e, 0x22, 0x1a, 0x0d, 0x29, 0x95, 0x0f, 0x14, 0x03,
-	0xca, 0x13, 0x65, 0x0b, 0xbd, 0x0b, 0x39, 0x67, 0xdc, 0xd1, 0xbc, 0xf0, 0x4c, 0x24, 0x8f, 0x47,
-	0xf2, 0xc6, 0x9d, 0x81, 0xd9, 0xdd, 0xc1, 0xa7, 0xde, 0x64, 0x9c, 0x71, 0x67, 0x87, 0x47, 0x91,
-	0xff, 0x4a, 0x32, 0xfc, 0x2b, 0xc7, 0x90, 0xf7, 0x36, 0x05, 0xfa, 0x41, 0x38, 0x4f, 0xbc, 0xee,
-	0x6b, 0x6c, 0x29, 0x15, 0xee, 0x43, 0x69, 0x72, 0x03, 0x96, 0x5d, 0xb3, 0x67, 0x61, 0x43, 0x0b,
-	0xce, 0x02, 0xec, 0xd7, 0xf2, 0x6a, 0x99, 0xbf, 0xd8, 0xf5, 0x0e, 0x02, 0xca, 0xbf, 0x24, 0xc8,
-	0x7b, 0x09, 0x8b, 0xde, 0x09, 0xed, 0xbb, 0xd2, 0x8c, 0xa6, 0x84, 0xa7, 0x18, 0xb4, 0xca, 0xa2,
-	0x73, 0x4d, 0x9e, 0x7f, 0xae, 0x71, 0x3d, 0x4f, 0xaf, 0xf9, 0x9c, 0x3e, 0x77, 0xf3, 0xf9, 0x2d,
-	0x40, 0xc4, 0x26, 0xfa, 0x40, 0x3b, 0xb6, 0x89, 0x69, 0xf5, 0x34, 0x1e, 0x6c, 0xce, 0xa8, 0x2a,
-	0xec, 0xcd, 0x43, 0xf6, 0xe2, 0x80, 0xc5, 0xfd, 0x17, 0x12, 0xe4, 0xfd, 0xda, 0x78, 0xde, 0xce,
-	0xd7, 0x45, 0xc8, 0x0a, 0xf8, 0xe7, 0xad, 0x2f, 0x31, 0xf2, 0x9b, 0xb0, 0xe9, 0x50, 0x13, 0xb6,
-	0x06, 0xf9, 0x21, 0x26, 0x3a, 0x23, 0x08, 0xfc, 0x38, 0xe6, 0x8f, 0x6f, 0xdc, 0x85, 0x42, 0xa8,
-	0x09, 0x49, 0x33, 0x6f, 0xaf, 0xf9, 0x71, 0x25, 0x51, 0xcb, 0x3d, 0xfd, 0xf2, 0x72, 0x6a, 0x0f,
-	0x3f, 0xa1, 0x7b, 0x56, 0x6d, 0x36, 0x5a, 0xcd, 0xc6, 0x4e, 0x45, 0xaa, 0x15, 0x9e, 0x7e, 0x79,
-	0x39, 0xa7, 0x62, 0xd6, 0x10, 0xb9, 0xd1, 0x82, 0xa5, 0xf0, 0x57, 0x89, 0x56, 0x10, 0x04, 0xa5,
-	0x7b, 0x0f, 0x0e, 0x76, 0xb7, 0x1b, 0x5b, 0xed, 0xa6, 0xf6, 0x70, 0xbf, 0xdd, 0xac, 0x48, 0xe8,
-	0x65, 0xb8, 0xb0, 0xbb, 0xfd, 0x61, 0xab, 0xad, 0x35, 0x76, 0xb7, 0x9b, 0x7b, 0x6d, 0x6d, 0xab,
-	0xdd, 0xde, 0x6a, 0xec, 0x54, 0x92, 0x9b, 0xbf, 0x97, 0xa1, 0xbc, 0x55, 0x6f, 0x6c, 0xd3, 0xea,
-	0x67, 0x76, 0x75, 0x76, 0x56, 0x6e, 0x40, 0x9a, 0x9d, 0x86, 0xcf, 0xbc, 0xa1, 0xac, 0x9d, 0xdd,
-	0x2a, 0x43, 0xf7, 0x21, 0xc3, 0x0e, 0xca, 0xe8, 0xec, 0x2b, 0xcb, 0xda, 0x9c, 0xde, 0x19, 0x9d,
-	0x0c, 0x4b, 0x8f, 0x33, 0xef, 0x30, 0x6b, 0x67, 0xb7, 0xd2, 0x90, 0x0a, 0x72, 0x40, 0xe1, 0xe7,
-	0xdf, 0xe9, 0xd5, 0x16, 0x00, 0x1b, 0xb4, 0x0b, 0x39, 0xef, 0x70, 0x34, 0xef, 0x96, 0xb1, 0x36,
-	0xb7, 0xd7, 0x45, 0xc3, 0xc5, 0x0f, 0xb1, 0x67, 0x5f, 0x99, 0xd6, 0xe6, 0x34, 0xee, 0xd0, 0x36,
-	0x64, 0x05, 0x2f, 0x9d, 0x73, 0x73, 0x58, 0x9b, 0xd7, 0xbb, 0xa2, 0x41, 0x0b, 0xba, 0x03, 0xf3,
-	0x2f, 0x82, 0x6b, 0x0b, 0xf4, 0x24, 0xd1, 0x03, 0x80, 0xd0, 0x91, 0x75, 0x81, 0x1b, 0xde, 0xda,
-	0x22, 0xbd, 0x46, 0xb4, 0x0f, 0x79, 0xff, 0x68, 0x32, 0xf7, 0xbe, 0xb5, 0x36, 0xbf, 0xe9, 0x87,
-	0x1e, 0x41, 0x31, 0xca, 0xc9, 0x17, 0xbb, 0x45, 0xad, 0x2d, 0xd8, 0xcd, 0xa3, 0xfe, 0xa3, 0x04,
-	0x7d, 0xb1, 0x5b, 0xd5, 0xda, 0x82, 0xcd, 0x3d, 0xf4, 0x19, 0x2c, 0x4f, 0x13, 0xe8, 0xc5, 0x2f,
-	0x59, 0x6b, 0xe7, 0x68, 0xf7, 0xa1, 0x21, 0xa0, 0x19, 0xc4, 0xfb, 0x1c, 0x77, 0xae, 0xb5, 0xf3,
-	0x74, 0xff, 0xea, 0xcd, 0xaf, 0x9e, 0xaf, 0x4a, 0x5f, 0x3f, 0x5f, 0x95, 0xfe, 0xfe, 0x7c, 0x55,
-	0x7a, 0xf6, 0x62, 0x35, 0xf1, 0xf5, 0x8b, 0xd5, 0xc4, 0x5f, 0x5f, 0xac, 0x26, 0x7e, 0xfa, 0x66,
-	0xcf, 0x24, 0xfd, 0x71, 0x67, 0xbd, 0x6b, 0x0f, 0x37, 0xc2, 0x7f, 0xe6, 0x98, 0xf5, 0x07, 0x93,
-	0x4e, 0x96, 0x15, 0x95, 0x9b, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0xb5, 0x1b, 0xc1, 0x1e, 0x80,
-	0x22, 0x00, 0x00,
+	// 2938 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcb, 0x73, 0xe3, 0xc6,
+	0xd1, 0x27, 0xf8, 0x66, 0x53, 0xa4, 0xa8, 0x59, 0x79, 0x97, 0x4b, 0xaf, 0x25, 0x19, 0x2e, 0xfb,
+	0xb3, 0xd7, 0xb6, 0xf4, 0x59, 0x2e, 0xbf, 0xca, 0x79, 0x98, 0xa2, 0xb9, 0xa6, 0xbc, 0x8a, 0xa8,
+	0x8c, 0xa8, 0x75, 0x12, 0xc7, 0x0b, 0x83, 0xc0, 0x88, 0x84, 0x97, 0x04, 0x60, 0x00, 0xd4, 0x4a,
+	0x3e, 0xa6, 0x2a, 0x97, 0xcd, 0xc5, 0xc7, 0x5c, 0xb6, 0x2a, 0x97, 0x9c, 0x73, 0xcd, 0x29, 0x97,
+	0x5c, 0x5c, 0x95, 0x4a, 0x95, 0x8f, 0x39, 0xd9, 0x29, 0xfb, 0xe6, 0x7f, 0x20, 0xa7, 0x54, 0x52,
+	0xf3, 0x02, 0xc1, 0x07, 0x44, 0x2a, 0xce, 0x2d, 0xb7, 0xe9, 0x46, 0x77, 0x63, 0xa6, 0x31, 0xdd,
+	0xfd, 0x9b, 0x1e, 0xc0, 0x93, 0x01, 0xb1, 0x4d, 0xe2, 0x0d, 0x2d, 0x3b, 0xd8, 0xd1, 0xbb, 0x86,
+	0xb5, 0x13, 0x5c, 0xb8, 0xc4, 0xdf, 0x76, 0x3d, 0x27, 0x70, 0xd0, 0xea, 0xf8, 0xe1, 0x36, 0x7d,
+	0x58, 0x7b, 0x2a, 0x22, 0x6d, 0x78, 0x17, 0x6e, 0xe0, 0xec, 0xb8, 0x9e, 0xe3, 0x9c, 0x72, 0xf9,
+	0xda, 0xad, 0xc8, 0x63, 0x66, 0x27, 0x6a, 0xad, 0x76, 0x6b, 0x56, 0xf9, 0x01, 0xb9, 0x90, 0x4f,
+	0x9f, 0x9a, 0xd1, 0x75, 0x75, 0x4f, 0x1f, 0xca, 0xc7, 0x9b, 0x3d, 0xc7, 0xe9, 0x0d, 0xc8, 0x0e,
+	0xa3, 0xba, 0xa3, 0xd3, 0x9d, 0xc0, 0x1a, 0x12, 0x3f, 0xd0, 0x87, 0xae, 0x10, 0x58, 0xef, 0x39,
+	0x3d, 0x87, 0x0d, 0x77, 0xe8, 0x88, 0x73, 0xd5, 0xbf, 0xe6, 0x20, 0x87, 0xc9, 0xa7, 0x23, 0xe2,
+	0x07, 0x68, 0x17, 0xd2, 0xc4, 0xe8, 0x3b, 0x55, 0x65, 0x4b, 0x79, 0xbe, 0xb8, 0x7b, 0x6b, 0x7b,
+	0x6a, 0x71, 0xdb, 0x42, 0xae, 0x69, 0xf4, 0x9d, 0x56, 0x02, 0x33, 0x59, 0xf4, 0x1a, 0x64, 0x4e,
+	0x07, 0x23, 0xbf, 0x5f, 0x4d, 0x32, 0xa5, 0xa7, 0xe2, 0x94, 0xee, 0x50, 0xa1, 0x56, 0x02, 0x73,
+	0x69, 0xfa, 0x2a, 0xcb, 0x3e, 0x75, 0xaa, 0xa9, 0xcb, 0x5f, 0xb5, 0x6f, 0x9f, 0xb2, 0x57, 0x51,
+	0x59, 0xb4, 0x07, 0x60, 0xd9, 0x56, 0xa0, 0x19, 0x7d, 0xdd, 0xb2, 0xab, 0x69, 0xa6, 0xf9, 0x74,
+	0xbc, 0xa6, 0x15, 0x34, 0xa8, 0x60, 0x2b, 0x81, 0x0b, 0x96, 0x24, 0xe8, 0x74, 0x3f, 0x1d, 0x11,
+	0xef, 0xa2, 0x9a, 0xb9, 0x7c, 0xba, 0x3f, 0xa5, 0x42, 0x74, 0xba, 0x4c, 0x1a, 0x35, 0xa1, 0xd8,
+	0x25, 0x3d, 0xcb, 0xd6, 0xba, 0x03, 0xc7, 0x78, 0x50, 0xcd, 0x32, 0x65, 0x35, 0x4e, 0x79, 0x8f,
+	0x8a, 0xee, 0x51, 0xc9, 0x56, 0x02, 0x43, 0x37, 0xa4, 0xd0, 0x0f, 0x20, 0x6f, 0xf4, 0x89, 0xf1,
+	0x40, 0x0b, 0xce, 0xab, 0x39, 0x66, 0x63, 0x33, 0xce, 0x46, 0x83, 0xca, 0x75, 0xce, 0x5b, 0x09,
+	0x9c, 0x33, 0xf8, 0x90, 0xae, 0xdf, 0x24, 0x03, 0xeb, 0x8c, 0x78, 0x54, 0x3f, 0x7f, 0xf9, 0xfa,
+	0xdf, 0xe5, 0x92, 0xcc, 0x42, 0xc1, 0x94, 0x04, 0xfa, 0x31, 0x14, 0x88, 0x6d, 0x8a, 0x65, 0x14,
+	0x98, 0x89, 0xad, 0xd8, 0xef, 0x6c, 0x9b, 0x72, 0x11, 0x79, 0x22, 0xc6, 0xe8, 0x4d, 0xc8, 0x1a,
+	0xce, 0x70, 0x68, 0x05, 0x55, 0x60, 0xda, 0x1b, 0xb1, 0x0b, 0x60, 0x52, 0xad, 0x04, 0x16, 0xf2,
+	0xe8, 0x10, 0xca, 0x03, 0xcb, 0x0f, 0x34, 0xdf, 0xd6, 0x5d, 0xbf, 0xef, 0x04, 0x7e, 0xb5, 0xc8,
+	0x2c, 0x3c, 0x1b, 0x67, 0xe1, 0xc0, 0xf2, 0x83, 0x63, 0x29, 0xdc, 0x4a, 0xe0, 0xd2, 0x20, 0xca,
+	0xa0, 0xf6, 0x9c, 0xd3, 0x53, 0xe2, 0x85, 0x06, 0xab, 0x2b, 0x97, 0xdb, 0x6b, 0x53, 0x69, 0xa9,
+	0x4f, 0xed, 0x39, 0x51, 0x06, 0xfa, 0x10, 0xae, 0x0d, 0x1c, 0xdd, 0x0c, 0xcd, 0x69, 0x46, 0x7f,
+	0x64, 0x3f, 0xa8, 0x96, 0x98, 0xd1, 0x17, 0x62, 0x27, 0xe9, 0xe8, 0xa6, 0x34, 0xd1, 0xa0, 0x0a,
+	0xad, 0x04, 0x5e, 0x1b, 0x4c, 0x33, 0xd1, 0x7d, 0x58, 0xd7, 0x5d, 0x77, 0x70, 0x31, 0x6d, 0xbd,
+	0xcc, 0xac, 0xdf, 0x8e, 0xb3, 0x5e, 0xa7, 0x3a, 0xd3, 0xe6, 0x91, 0x3e, 0xc3, 0xdd, 0xcb, 0x41,
+	0xe6, 0x4c, 0x1f, 0x8c, 0x88, 0xfa, 0x7f, 0x50, 0x8c, 0x84, 0x29, 0xaa, 0x42, 0x6e, 0x48, 0x7c,
+	0x5f, 0xef, 0x11, 0x16, 0xd5, 0x05, 0x2c, 0x49, 0xb5, 0x0c, 0x2b, 0xd1, 0xd0, 0x54, 0x3f, 0x57,
+	0xa0, 0x18, 0x89, 0x3a, 0xaa, 0x79, 0x46, 0x3c, 0xdf, 0x72, 0x6c, 0xa9, 0x29, 0x48, 0xf4, 0x0c,
+	0x94, 0xd8, 0xfe, 0xd1, 0xe4, 0x73, 0x1a, 0xfa, 0x69, 0xbc, 0xc2, 0x98, 0xf7, 0x84, 0xd0, 0x26,
+	0x14, 0xdd, 0x5d, 0x37, 0x14, 0x49, 0x31, 0x11, 0x70, 0x77, 0x5d, 0x29, 0xf0, 0x34, 0xac, 0xd0,
+	0x95, 0x86, 0x12, 0x69, 0xf6, 0x92, 0x22, 0xe5, 0x09, 0x11, 0xf5, 0x2f, 0x49, 0xa8, 0x4c, 0x87,
+	0x33, 0x7a, 0x13, 0xd2, 0x34, 0xb3, 0x89, 0x24, 0x55, 0xdb, 0xe6, 0x69, 0x6f, 0x5b, 0xa6, 0xbd,
+	0xed, 0x8e, 0x4c, 0x7b, 0x7b, 0xf9, 0x2f, 0xbe, 0xda, 0x4c, 0x7c, 0xfe, 0xf5, 0xa6, 0x82, 0x99,
+	0x06, 0xba, 0x49, 0xa3, 0x4f, 0xb7, 0x6c, 0xcd, 0x32, 0xd9, 0x94, 0x0b, 0x34, 0xb4, 0x74, 0xcb,
+	0xde, 0x37, 0xd1, 0x5d, 0xa8, 0x18, 0x8e, 0xed, 0x13, 0xdb, 0x1f, 0xf9, 0x1a, 0x4f, 0xab, 0xd5,
+	0x54, 0x4c, 0x74, 0x34, 0xa4, 0xe0, 0x11, 0x93, 0xc3, 0xab, 0xc6, 0x24, 0x03, 0xdd, 0x01, 0x38,
+	0xd3, 0x07, 0x96, 0xa9, 0x07, 0x8e, 0xe7, 0x57, 0xd3, 0x5b, 0xa9, 0xb9, 0x66, 0xee, 0x49, 0x91,
+	0x13, 0xd7, 0xd4, 0x03, 0xb2, 0x97, 0xa6, 0xb3, 0xc5, 0x11, 0x4d, 0xf4, 0x1c, 0xac, 0xea, 0xae,
+	0xab, 0xf9, 0x81, 0x1e, 0x10, 0xad, 0x7b, 0x11, 0x10, 0x9f, 0x65, 0xad, 0x15, 0x5c, 0xd2, 0x5d,
+	0xf7, 0x98, 0x72, 0xf7, 0x28, 0x13, 0x3d, 0x0b, 0x65, 0x9a, 0xe0, 0x2c, 0x7d, 0xa0, 0xf5, 0x89,
+	0xd5, 0xeb, 0x07, 0x2c, 0x3f, 0xa5, 0x70, 0x49, 0x70, 0x5b, 0x8c, 0xa9, 0x9a, 0xb0, 0x12, 0x4d,
+	0x6e, 0x08, 0x41, 0xda, 0xd4, 0x03, 0x9d, 0x39, 0x72, 0x05, 0xb3, 0x31, 0xe5, 0xb9, 0x7a, 0xd0,
+	0x17, 0xee, 0x61, 0x63, 0x74, 0x1d, 0xb2, 0xc2, 0x6c, 0x8a, 0x99, 0x15, 0x14, 0x5a, 0x87, 0x8c,
+	0xeb, 0x39, 0x67, 0x84, 0x7d, 0xb9, 0x3c, 0xe6, 0x84, 0xfa, 0xfb, 0x24, 0xac, 0xcd, 0xa4, 0x41,
+	0x6a, 0xb7, 0xaf, 0xfb, 0x7d, 0xf9, 0x2e, 0x3a, 0x46, 0xaf, 0x53, 0xbb, 0xba, 0x49, 0x3c, 0x51,
+	0x3a, 0xaa, 0x51, 0x17, 0xf1, 0xb2, 0xd8, 0x62, 0xcf, 0x85, 0x6b, 0x84, 0x34, 0x6a, 0x43, 0x65,
+	0xa0, 0xfb, 0x81, 0xc6, 0xd3, 0x8a, 0x16, 0x29, 0x23, 0xb3, 0xc9, 0xf4, 0x40, 0x97, 0x89, 0x88,
+	0xee, 0x69, 0x61, 0xa8, 0x3c, 0x98, 0xe0, 0x22, 0x0c, 0xeb, 0xdd, 0x8b, 0xcf, 0x74, 0x3b, 0xb0,
+	0x6c, 0xa2, 0xcd, 0x7c, 0xb9, 0x9b, 0x33, 0x46, 0x9b, 0x67, 0x96, 0x49, 0x6c, 0x43, 0x7e, 0xb2,
+	0x6b, 0xa1, 0xf2, 0xbd, 0xf1, 0xb7, 0xdb, 0x84, 0xa2, 0xa7, 0xdb, 0xa6, 0x33, 0xd4, 0x7c, 0x42,
+	0x4c, 0xf1, 0xdd, 0x80, 0xb3, 0x8e, 0x09, 0x31, 0xd5, 0xdf, 0x28, 0x50, 0x9e, 0x4c, 0xf5, 0xa8,
+	0x0c, 0xc9, 0xe0, 0x5c, 0xb8, 0x28, 0x19, 0x9c, 0xa3, 0xff, 0x87, 0x34, 0x75, 0x03, 0x73, 0x4f,
+	0x79, 0x4e, 0x8d, 0x14, 0x7a, 0x9d, 0x0b, 0x97, 0x60, 0x26, 0x89, 0xde, 0x80, 0xac, 0xe3, 0x59,
+	0x3d, 0xcb, 0x16, 0x0e, 0x99, 0x9d, 0x7b, 0xe7, 0xbc, 0xcd, 0x04, 0xa4, 0x4f, 0xb9, 0xb8, 0xfa,
+	0x58, 0x81, 0xbc, 0x7c, 0x84, 0x5e, 0x81, 0xac, 0xef, 0x8c, 0x3c, 0x83, 0xc7, 0x58, 0x79, 0xae,
+	0x95, 0x63, 0x26, 0x80, 0x85, 0x20, 0xba, 0x01, 0x39, 0x97, 0x10, 0x6f, 0x1c, 0x59, 0x59, 0x4a,
+	0xee, 0x9b, 0xb4, 0x70, 0x7a, 0xc4, 0x20, 0xd6, 0x19, 0x31, 0x35, 0x3d, 0xa8, 0xa6, 0xae, 0x10,
+	0xb4, 0x20, 0x15, 0xeb, 0x81, 0xaa, 0x42, 0x65, 0xba, 0xae, 0x4d, 0xbb, 0x4b, 0x7d, 0x01, 0x56,
+	0xa7, 0x0a, 0x57, 0x64, 0xeb, 0x2a, 0xd1, 0xad, 0xab, 0xae, 0x42, 0x69, 0xa2, 0x4a, 0xa9, 0xd7,
+	0x61, 0x7d, 0x5e, 0xd1, 0x51, 0xfb, 0xb0, 0x3e, 0xaf, 0x78, 0xa0, 0xd7, 0x20, 0x1f, 0x56, 0x1d,
+	0x25, 0xc6, 0xd5, 0x52, 0x18, 0x87, 0xa2, 0x34, 0x03, 0xd1, 0x88, 0x66, 0xa1, 0x90, 0x64, 0x13,
+	0xcf, 0xe9, 0xae, 0xdb, 0xd2, 0xfd, 0xbe, 0xfa, 0x31, 0x54, 0xe3, 0x2a, 0xca, 0xd4, 0x32, 0xd2,
+	0x61, 0x04, 0x5e, 0x87, 0xec, 0xa9, 0xe3, 0x0d, 0xf5, 0x80, 0x19, 0x2b, 0x61, 0x41, 0xd1, 0xc8,
+	0xe4, 0xd5, 0x25, 0xc5, 0xd8, 0x9c, 0x50, 0x35, 0xb8, 0x19, 0x5b, 0x55, 0xa8, 0x8a, 0x65, 0x9b,
+	0x84, 0xfb, 0xb3, 0x84, 0x39, 0x31, 0x36, 0xc4, 0x27, 0xcb, 0x09, 0xfa, 0x5a, 0x9f, 0xad, 0x95,
+	0xd9, 0x2f, 0x60, 0x41, 0xa9, 0xbf, 0xcb, 0x43, 0x1e, 0x13, 0xdf, 0xa5, 0xe9, 0x10, 0xed, 0x41,
+	0x81, 0x9c, 0x1b, 0xc4, 0x0d, 0x64, 0x01, 0x99, 0x8f, 0x97, 0xb8, 0x74, 0x53, 0x4a, 0x52, 0xb0,
+	0x12, 0xaa, 0xa1, 0x57, 0x05, 0x1e, 0x8d, 0x87, 0x96, 0x42, 0x3d, 0x0a, 0x48, 0x5f, 0x97, 0x80,
+	0x34, 0x15, 0x8b, 0x4f, 0xb8, 0xd6, 0x14, 0x22, 0x7d, 0x55, 0x20, 0xd2, 0xf4, 0x82, 0x97, 0x4d,
+	0x40, 0xd2, 0xc6, 0x04, 0x24, 0xcd, 0x2c, 0x58, 0x66, 0x0c, 0x26, 0x7d, 0x5d, 0x62, 0xd2, 0xec,
+	0x82, 0x19, 0x4f, 0x81, 0xd2, 0x3b, 0x93, 0xa0, 0x94, 0x03, 0xca, 0x67, 0x62, 0xb5, 0x63, 0x51,
+	0xe9, 0x0f, 0x23, 0xa8, 0x34, 0x1f, 0x0b, 0x09, 0xb9, 0x91, 0x39, 0xb0, 0xb4, 0x31, 0x01, 0x4b,
+	0x0b, 0x0b, 0x7c, 0x10, 0x83, 0x4b, 0xdf, 0x89, 0xe2, 0x52, 0x88, 0x85, 0xb6, 0xe2, 0x7b, 0xcf,
+	0x03, 0xa6, 0x6f, 0x85, 0xc0, 0xb4, 0x18, 0x8b, 0xac, 0xc5, 0x1a, 0xa6, 0x91, 0x69, 0x7b, 0x06,
+	0x99, 0x72, 0x24, 0xf9, 0x5c, 0xac, 0x89, 0x05, 0xd0, 0xb4, 0x3d, 0x03, 0x4d, 0x4b, 0x0b, 0x0c,
+	0x2e, 0xc0, 0xa6, 0xbf, 0x9c, 0x8f, 0x4d, 0xe3, 0xd1, 0xa3, 0x98, 0xe6, 0x72, 0xe0, 0x54, 0x8b,
+	0x01, 0xa7, 0xab, 0xcc, 0xfc, 0x8b, 0xb1, 0xe6, 0xaf, 0x8e, 0x4e, 0x5f, 0x80, 0x35, 0xa9, 0x1c,
+	0xc6, 0x3c, 0xcd, 0x32, 0xc4, 0xf3, 0x1c, 0x4f, 0xe0, 0x4c, 0x4e, 0xa8, 0xcf, 0xc3, 0x4a, 0x28,
+	0x7a, 0x39, 0x92, 0x65, 0xd9, 0x3c, 0x12, 0xd3, 0xea, 0x1f, 0x15, 0x58, 0x89, 0x86, 0xeb, 0x04,
+	0xd4, 0x29, 0x08, 0xa8, 0x13, 0xc1, 0xb7, 0xc9, 0x49, 0x7c, 0xbb, 0x09, 0x45, 0x9a, 0xa5, 0xa7,
+	0xa0, 0xab, 0xee, 0x86, 0xd0, 0xf5, 0x36, 0xac, 0x31, 0x04, 0xc2, 0x51, 0xb0, 0x48, 0xcd, 0x69,
+	0x56, 0x61, 0x56, 0xe9, 0x03, 0xbe, 0x39, 0x19, 0x1b, 0xbd, 0x0c, 0xd7, 0x22, 0xb2, 0x61, 0xf6,
+	0xe7, 0x80, 0xa0, 0x12, 0x4a, 0xd7, 0x45, 0x19, 0xf8, 0xb3, 0x02, 0x6b, 0x33, 0xe9, 0x62, 0x2e,
+	0x3c, 0x55, 0xfe, 0x3b, 0xf0, 0x34, 0xf9, 0x1f, 0xc3, 0xd3, 0x68, 0x31, 0x4b, 0x4d, 0x16, 0xb3,
+	0x7f, 0x28, 0x50, 0x9a, 0x48, 0x5a, 0xf4, 0x0b, 0x18, 0x8e, 0x49, 0x44, 0x79, 0x61, 0x63, 0x54,
+	0x81, 0xd4, 0xc0, 0xe9, 0x89, 0x22, 0x42, 0x87, 0x54, 0x2a, 0xcc, 0xc1, 0x05, 0x91, 0x62, 0xc3,
+	0xca, 0x94, 0x61, 0x0e, 0xe6, 0x04, 0xd5, 0x7d, 0x40, 0x78, 0xc6, 0x5c, 0xc1, 0x74, 0x88, 0xd6,
+	0xc5, 0x1e, 0x63, 0x79, 0x70, 0x05, 0x73, 0x02, 0xbd, 0x09, 0x05, 0xd6, 0x7f, 0xd1, 0x1c, 0xd7,
+	0x17, 0xc9, 0xed, 0xc9, 0xe8, 0x5a, 0x79, 0x9b, 0x65, 0xfb, 0x88, 0xca, 0xb4, 0x5d, 0x1f, 0xe7,
+	0x5d, 0x31, 0x8a, 0x14, 0xdd, 0xc2, 0x04, 0xec, 0xbd, 0x05, 0x05, 0x3a, 0x7b, 0xdf, 0xd5, 0x0d,
+	0xc2, 0x32, 0x55, 0x01, 0x8f, 0x19, 0xea, 0x7d, 0x40, 0xb3, 0xf9, 0x16, 0xb5, 0x20, 0x4b, 0xce,
+	0x88, 0x1d, 0xd0, 0xaf, 0x46, 0xdd, 0x7d, 0x7d, 0x0e, 0xa6, 0x24, 0x76, 0xb0, 0x57, 0xa5, 0x4e,
+	0xfe, 0xee, 0xab, 0xcd, 0x0a, 0x97, 0x7e, 0xc9, 0x19, 0x5a, 0x01, 0x19, 0xba, 0xc1, 0x05, 0x16,
+	0xfa, 0xea, 0x77, 0x49, 0x58, 0x95, 0x2f, 0x90, 0xb8, 0x71, 0x9e, 0x6f, 0xe5, 0x8e, 0x4f, 0x46,
+	0xc0, 0xfd, 0x72, 0xfe, 0xde, 0x00, 0xe8, 0xe9, 0xbe, 0xf6, 0x50, 0xb7, 0x03, 0x01, 0x5c, 0x53,
+	0x38, 0xc2, 0x41, 0x35, 0xc8, 0x53, 0x6a, 0xe4, 0x13, 0x53, 0x9c, 0x33, 0x42, 0x3a, 0xb2, 0xce,
+	0xdc, 0xf7, 0x5b, 0xe7, 0xa4, 0x97, 0xf3, 0x53, 0x5e, 0x46, 0x4f, 0x42, 0x81, 0xbe, 0xd3, 0xf5,
+	0x2c, 0x83, 0x54, 0x0b, 0xe1, 0x24, 0x8e, 0x28, 0x1d, 0x81, 0x27, 0x10, 0x85, 0x27, 0x74, 0x83,
+	0xd8, 0x8e, 0x6d, 0x10, 0x56, 0x1f, 0xd2, 0x98, 0x13, 0x74, 0x39, 0x86, 0x1e, 0x90, 0x9e, 0xe3,
+	0x5d, 0xb0, 0xac, 0x5f, 0xc0, 0x21, 0xad, 0xfe, 0x3a, 0x09, 0x6b, 0x33, 0x75, 0xeb, 0x7f, 0xcf,
+	0xdd, 0xea, 0xd7, 0xec, 0x1c, 0x3e, 0x59, 0x7b, 0xd1, 0x31, 0xac, 0x85, 0xc9, 0x40, 0x1b, 0xb1,
+	0x24, 0x21, 0xb7, 0xf7, 0xb2, 0xd9, 0xa4, 0x72, 0x36, 0xc9, 0xf6, 0xd1, 0xcf, 0xe0, 0xc6, 0x54,
+	0xa2, 0x0b, 0x4d, 0x27, 0x97, 0xcc, 0x77, 0x4f, 0x4c, 0xe6, 0x3b, 0x69, 0x79, 0xec, 0xab, 0xd4,
+	0xf7, 0xf4, 0x15, 0x86, 0x6b, 0x91, 0xf2, 0xa0, 0x8d, 0xdc, 0x9e, 0xa7, 0x9b, 0xa4, 0x9a, 0x8e,
+	0x01, 0x3e, 0xf5, 0xb0, 0x6e, 0x9c, 0x70, 0x49, 0xbc, 0xa6, 0x4f, 0xb3, 0xd4, 0x7d, 0x28, 0x4b,
+	0x07, 0x73, 0x74, 0x32, 0x77, 0x47, 0x3d, 0x03, 0x25, 0x8f, 0x04, 0xb4, 0x83, 0x31, 0x71, 0x20,
+	0x5f, 0xe1, 0x4c, 0x71, 0xcc, 0x3f, 0x82, 0x27, 0xe6, 0xa2, 0x14, 0xf4, 0x06, 0x14, 0xc6, 0x00,
+	0x47, 0x89, 0x39, 0xdb, 0x4a, 0x71, 0x3c, 0x96, 0x55, 0xff, 0xa4, 0xc0, 0x13, 0x73, 0x71, 0x0a,
+	0x6a, 0x42, 0xd6, 0x23, 0xfe, 0x68, 0x10, 0x88, 0x93, 0xe2, 0xcb, 0xcb, 0xe1, 0x1b, 0xca, 0x1d,
+	0x0d, 0x02, 0x2c, 0x94, 0xd5, 0xfb, 0x90, 0xe5, 0x1c, 0x54, 0x84, 0xdc, 0xc9, 0xe1, 0xdd, 0xc3,
+	0xf6, 0x07, 0x87, 0x95, 0x04, 0x02, 0xc8, 0xd6, 0x1b, 0x8d, 0xe6, 0x51, 0xa7, 0xa2, 0xa0, 0x02,
+	0x64, 0xea, 0x7b, 0x6d, 0xdc, 0xa9, 0x24, 0x29, 0x1b, 0x37, 0xdf, 0x6f, 0x36, 0x3a, 0x95, 0x14,
+	0x5a, 0x83, 0x12, 0x1f, 0x6b, 0x77, 0xda, 0xf8, 0x27, 0xf5, 0x4e, 0x25, 0x1d, 0x61, 0x1d, 0x37,
+	0x0f, 0xdf, 0x6d, 0xe2, 0x4a, 0x46, 0x7d, 0x05, 0x6e, 0xca, 0x79, 0xcc, 0x1e, 0xae, 0xc2, 0x33,
+	0x8e, 0x12, 0x39, 0xe3, 0xa8, 0xbf, 0x4d, 0x42, 0x2d, 0x1e, 0xe6, 0xa0, 0xf7, 0xa7, 0x16, 0xbe,
+	0x7b, 0x05, 0x8c, 0x34, 0xb5, 0x7a, 0xda, 0xbe, 0xf1, 0xc8, 0x29, 0x09, 0x8c, 0x3e, 0x87, 0x5d,
+	0xbc, 0x26, 0x97, 0x70, 0x49, 0x70, 0x99, 0x92, 0xcf, 0xc5, 0x3e, 0x21, 0x46, 0xa0, 0xf1, 0x7c,
+	0xc6, 0x37, 0x72, 0x01, 0x97, 0x38, 0xf7, 0x98, 0x33, 0xd5, 0x8f, 0xaf, 0xe4, 0xcb, 0x02, 0x64,
+	0x70, 0xb3, 0x83, 0x7f, 0x5e, 0x49, 0x21, 0x04, 0x65, 0x36, 0xd4, 0x8e, 0x0f, 0xeb, 0x47, 0xc7,
+	0xad, 0x36, 0xf5, 0xe5, 0x35, 0x58, 0x95, 0xbe, 0x94, 0xcc, 0x8c, 0xfa, 0x2f, 0x05, 0x56, 0xa7,
+	0x82, 0x0e, 0xed, 0x42, 0x86, 0x43, 0xf7, 0xb8, 0xab, 0x03, 0x96, 0x33, 0x44, 0x84, 0x66, 0xba,
+	0xb2, 0x19, 0x4e, 0x44, 0x27, 0x65, 0x5e, 0x70, 0xf3, 0x0e, 0x90, 0xec, 0xb5, 0x08, 0xd5, 0x50,
+	0x83, 0x36, 0xb2, 0xc3, 0xec, 0x51, 0x4d, 0xcd, 0x1e, 0x18, 0xb8, 0x7a, 0x98, 0x77, 0x84, 0xfe,
+	0x58, 0x07, 0xbd, 0x35, 0xc6, 0x7f, 0xe9, 0xd9, 0x03, 0x83, 0x50, 0xe7, 0x02, 0x42, 0x59, 0xca,
+	0xab, 0x0d, 0x28, 0x46, 0xd6, 0x43, 0xab, 0xd1, 0x50, 0x3f, 0x17, 0x1d, 0x3a, 0xde, 0x68, 0xc8,
+	0x0f, 0xf5, 0x73, 0xde, 0x9c, 0xbb, 0x01, 0x39, 0xfa, 0xb0, 0xa7, 0xf3, 0x0c, 0x96, 0xc2, 0xd9,
+	0xa1, 0x7e, 0xfe, 0x9e, 0xee, 0xab, 0x07, 0xb0, 0x36, 0x93, 0x1a, 0xa6, 0xa1, 0xa7, 0x32, 0x03,
+	0x3d, 0xc7, 0xa8, 0x24, 0x39, 0xd1, 0xd1, 0xf8, 0x08, 0xca, 0x93, 0xbd, 0x2e, 0xba, 0xaf, 0x3d,
+	0x67, 0x64, 0x9b, 0xcc, 0x48, 0x06, 0x73, 0x82, 0xde, 0x7f, 0x9c, 0x39, 0x3c, 0x9d, 0xce, 0x4f,
+	0x00, 0xf7, 0x9c, 0x80, 0x44, 0x7a, 0x65, 0x5c, 0x5a, 0xfd, 0x0c, 0x32, 0x2c, 0x3d, 0xd2, 0xb4,
+	0xc4, 0x7a, 0x52, 0x02, 0x49, 0xd3, 0x31, 0xfa, 0x08, 0x40, 0x0f, 0x02, 0xcf, 0xea, 0x8e, 0xc6,
+	0x86, 0x37, 0xe7, 0xa7, 0xd7, 0xba, 0x94, 0xdb, 0xbb, 0x25, 0xf2, 0xec, 0xfa, 0x58, 0x35, 0x92,
+	0x6b, 0x23, 0x06, 0xd5, 0x43, 0x28, 0x4f, 0xea, 0x4a, 0xf0, 0xa7, 0xcc, 0x01, 0x7f, 0xc9, 0x28,
+	0xf8, 0x0b, 0xa1, 0x63, 0x8a, 0x77, 0x28, 0x19, 0xa1, 0x3e, 0x62, 0xbd, 0x2e, 0x11, 0x24, 0x31,
+	0x1d, 0xa2, 0xb1, 0x6a, 0x32, 0xda, 0x0f, 0xe1, 0x2d, 0xa7, 0x54, 0xd8, 0xa1, 0x7b, 0x27, 0x4c,
+	0x03, 0xe9, 0x65, 0x8f, 0xbd, 0xb2, 0xf1, 0x26, 0x52, 0xdf, 0xdb, 0x50, 0x08, 0xf7, 0x28, 0x3d,
+	0x92, 0xe8, 0xa6, 0xe9, 0x11, 0xdf, 0x17, 0x6b, 0x93, 0x24, 0x9d, 0x8e, 0xeb, 0x3c, 0x14, 0x1d,
+	0x97, 0x14, 0xe6, 0x84, 0x6a, 0xc2, 0xea, 0x54, 0x61, 0x45, 0x6f, 0x43, 0xce, 0x1d, 0x75, 0x35,
+	0xe9, 0x9e, 0xa9, 0x50, 0x94, 0x68, 0x77, 0xd4, 0x1d, 0x58, 0xc6, 0x5d, 0x72, 0x21, 0x27, 0xe3,
+	0x8e, 0xba, 0x77, 0xb9, 0x17, 0xf9, 0x5b, 0x92, 0xd1, 0xb7, 0x9c, 0x41, 0x5e, 0x6e, 0x0a, 0xf4,
+	0xa3, 0x68, 0xd4, 0xc9, 0x0e, 0x7c, 0x6c, 0xb1, 0x17, 0xe6, 0xc7, 0x2a, 0xf4, 0xe4, 0xe4, 0x5b,
+	0x3d, 0x9b, 0x98, 0xda, 0xf8, 0x50, 0xc4, 0xde, 0x96, 0xc7, 0xab, 0xfc, 0xc1, 0x81, 0x3c, 0x11,
+	0xa9, 0xff, 0x54, 0x20, 0x2f, 0xc3, 0x1f, 0xbd, 0x12, 0xd9, 0x77, 0xe5, 0x39, 0xdd, 0x19, 0x29,
+	0x18, 0x69, 0x86, 0x4e, 0xcc, 0x35, 0x79, 0xf5, 0xb9, 0xc6, 0xf5, 0xbd, 0xe5, 0x05, 0x44, 0xfa,
+	0xca, 0x17, 0x10, 0x2f, 0x01, 0x0a, 0x9c, 0x40, 0x1f, 0x68, 0x67, 0x4e, 0x60, 0xd9, 0x3d, 0x8d,
+	0x3b, 0x9b, 0x63, 0xbe, 0x0a, 0x7b, 0x72, 0x8f, 0x3d, 0x38, 0x62, 0x7e, 0xff, 0x95, 0x02, 0xf9,
+	0xb0, 0xd2, 0x5e, 0xb5, 0x05, 0x78, 0x1d, 0xb2, 0xa2, 0x98, 0xf0, 0x1e, 0xa0, 0xa0, 0xc2, 0x46,
+	0x7c, 0x3a, 0xd2, 0x88, 0xaf, 0x41, 0x7e, 0x48, 0x02, 0x9d, 0xc1, 0x0d, 0x7e, 0x2e, 0x0d, 0xe9,
+	0xdb, 0x6f, 0x41, 0x31, 0xd2, 0x66, 0xa6, 0x91, 0x77, 0xd8, 0xfc, 0xa0, 0x92, 0xa8, 0xe5, 0x1e,
+	0x3d, 0xde, 0x4a, 0x1d, 0x92, 0x87, 0x74, 0xcf, 0xe2, 0x66, 0xa3, 0xd5, 0x6c, 0xdc, 0xad, 0x28,
+	0xb5, 0xe2, 0xa3, 0xc7, 0x5b, 0x39, 0x4c, 0x58, 0x67, 0xe8, 0xf6, 0x87, 0x90, 0x97, 0x7d, 0x62,
+	0x5a, 0xbc, 0x44, 0x2d, 0xd2, 0x8e, 0xdb, 0x27, 0xb8, 0xd1, 0xac, 0x24, 0x6a, 0x6b, 0x8f, 0x1e,
+	0x6f, 0x95, 0x4e, 0xec, 0x07, 0xb6, 0xf3, 0xd0, 0x16, 0x62, 0xeb, 0x90, 0x39, 0x68, 0x37, 0xea,
+	0x07, 0x15, 0xa5, 0x56, 0x78, 0xf4, 0x78, 0x2b, 0x73, 0xe0, 0x18, 0xfa, 0x80, 0xce, 0xf9, 0xa8,
+	0xd9, 0xc4, 0x95, 0x64, 0x2d, 0xff, 0xe8, 0xf1, 0x56, 0xfa, 0x88, 0x10, 0xef, 0x76, 0x0b, 0x56,
+	0xa2, 0x9f, 0x7c, 0xb2, 0xd8, 0x21, 0x28, 0xbf, 0x7b, 0x72, 0x74, 0xb0, 0xdf, 0xa8, 0x77, 0x9a,
+	0xda, 0xbd, 0x76, 0xa7, 0x59, 0x51, 0xd0, 0x0d, 0xb8, 0x76, 0xb0, 0xff, 0x5e, 0xab, 0xa3, 0x35,
+	0x0e, 0xf6, 0x9b, 0x87, 0x1d, 0xad, 0xde, 0xe9, 0xd4, 0x1b, 0x77, 0x2b, 0xc9, 0xdd, 0x3f, 0x14,
+	0x60, 0xb5, 0xbe, 0xd7, 0xd8, 0xa7, 0x85, 0xda, 0x32, 0x74, 0xd6, 0x91, 0x68, 0x40, 0x9a, 0xf5,
+	0x1c, 0x2e, 0xbd, 0x02, 0xaf, 0x5d, 0xde, 0x90, 0x44, 0x77, 0x20, 0xc3, 0xda, 0x11, 0xe8, 0xf2,
+	0x3b, 0xf1, 0xda, 0x82, 0x0e, 0x25, 0x9d, 0x0c, 0x8b, 0xbd, 0x4b, 0x2f, 0xc9, 0x6b, 0x97, 0x37,
+	0x2c, 0x11, 0x86, 0xc2, 0xf8, 0x04, 0xb3, 0xf8, 0xd2, 0xb8, 0xb6, 0x44, 0x26, 0x43, 0x07, 0x90,
+	0x93, 0x47, 0xd0, 0x45, 0xd7, 0xd8, 0xb5, 0x85, 0x1d, 0x45, 0xea, 0x2e, 0xde, 0x2a, 0xb8, 0xfc,
+	0x4e, 0xbe, 0xb6, 0xa0, 0x3d, 0x8a, 0xf6, 0x21, 0x2b, 0x20, 0xf4, 0x82, 0xab, 0xe9, 0xda, 0xa2,
+	0x0e, 0x21, 0x75, 0xda, 0xb8, 0x07, 0xb3, 0xf8, 0x4f, 0x83, 0xda, 0x12, 0x9d, 0x5f, 0x74, 0x02,
+	0x10, 0x69, 0x0c, 0x2c, 0xf1, 0x0b, 0x41, 0x6d, 0x99, 0x8e, 0x2e, 0x6a, 0x43, 0x3e, 0x3c, 0x99,
+	0x2d, 0xbc, 0xd0, 0xaf, 0x2d, 0x6e, 0xad, 0xa2, 0xfb, 0x50, 0x9a, 0x3c, 0x3e, 0x2c, 0x77, 0x4d,
+	0x5f, 0x5b, 0xb2, 0x67, 0x4a, 0xed, 0x4f, 0x9e, 0x25, 0x96, 0xbb, 0xb6, 0xaf, 0x2d, 0xd9, 0x42,
+	0x45, 0x9f, 0xc0, 0xda, 0x2c, 0xd6, 0x5f, 0xfe, 0x16, 0xbf, 0x76, 0x85, 0xa6, 0x2a, 0x1a, 0x02,
+	0x9a, 0x73, 0x46, 0xb8, 0xc2, 0xa5, 0x7e, 0xed, 0x2a, 0x3d, 0xd6, 0xbd, 0xe6, 0x17, 0xdf, 0x6c,
+	0x28, 0x5f, 0x7e, 0xb3, 0xa1, 0xfc, 0xfd, 0x9b, 0x0d, 0xe5, 0xf3, 0x6f, 0x37, 0x12, 0x5f, 0x7e,
+	0xbb, 0x91, 0xf8, 0xdb, 0xb7, 0x1b, 0x89, 0x5f, 0xbc, 0xd8, 0xb3, 0x82, 0xfe, 0xa8, 0xbb, 0x6d,
+	0x38, 0xc3, 0x9d, 0xe8, 0xdf, 0x42, 0xf3, 0xfe, 0x60, 0xea, 0x66, 0x59, 0xc5, 0x7a, 0xf5, 0xdf,
+	0x03, 0x00, 0xb5, 0x35, 0xdb, 0xfe, 0xe1, 0x24, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4359,6 +4593,13 @@ func (m *RequestBeginBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.RandomSeed) > 0 {
+		i -= len(m.RandomSeed)
+		copy(dAtA[i:], m.RandomSeed)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.RandomSeed)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.ByzantineValidators) > 0 {
 		for iNdEx := len(m.ByzantineValidators) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -4423,6 +4664,16 @@ func (m *RequestCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	{
+		size, err := m.Origin.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
 	if m.Type != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
 		i--
@@ -4438,6 +4689,49 @@ func (m *RequestCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *TxOrigin) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxOrigin) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TxOrigin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n20, err20 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ReceivedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ReceivedAt):])
+	if err20 != nil {
+		return 0, err20
+	}
+	i -= n20
+	i = encodeVarintTypes(dAtA, i, uint64(n20))
+	i--
+	dAtA[i] = 0x1a
+	if len(m.PeerId) > 0 {
+		i -= len(m.PeerId)
+		copy(dAtA[i:], m.PeerId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.PeerId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Source != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Source))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *RequestDeliverTx) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5346,6 +5640,30 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Category) > 0 {
+		i -= len(m.Category)
+		copy(dAtA[i:], m.Category)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Category)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.Nonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Nonce))
+		i--
+		dAtA[i] = 0x58
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.GasPrice != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.GasPrice))
+		i--
+		dAtA[i] = 0x48
+	}
 	if len(m.Codespace) > 0 {
 		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
@@ -5506,6 +5824,18 @@ func (m *ResponseEndBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AppVersionUpgrade != nil {
+		{
+			size, err := m.AppVersionUpgrade.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.Events) > 0 {
 		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -5709,20 +6039,20 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA39 := make([]byte, len(m.RefetchChunks)*10)
-		var j38 int
+		dAtA42 := make([]byte, len(m.RefetchChunks)*10)
+		var j41 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA39[j38] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA42[j41] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j38++
+				j41++
 			}
-			dAtA39[j38] = uint8(num)
-			j38++
+			dAtA42[j41] = uint8(num)
+			j41++
 		}
-		i -= j38
-		copy(dAtA[i:], dAtA39[:j38])
-		i = encodeVarintTypes(dAtA, i, uint64(j38))
+		i -= j41
+		copy(dAtA[i:], dAtA42[:j41])
+		i = encodeVarintTypes(dAtA, i, uint64(j41))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -5838,7 +6168,7 @@ func (m *BlockParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *LastCommitInfo) Marshal() (dAtA []byte, err error) {
+func (m *AppVersionUpgrade) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5848,17 +6178,50 @@ func (m *LastCommitInfo) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LastCommitInfo) MarshalTo(dAtA []byte) (int, error) {
+func (m *AppVersionUpgrade) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *LastCommitInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *AppVersionUpgrade) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Votes) > 0 {
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.AppVersion != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.AppVersion))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LastCommitInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LastCommitInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LastCommitInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Votes) > 0 {
 		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
 			{
 				size, err := m.Votes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
@@ -6162,12 +6525,12 @@ func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n47, err47 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err47 != nil {
-		return 0, err47
+	n50, err50 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err50 != nil {
+		return 0, err50
 	}
-	i -= n47
-	i = encodeVarintTypes(dAtA, i, uint64(n47))
+	i -= n50
+	i = encodeVarintTypes(dAtA, i, uint64(n50))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -6556,6 +6919,10 @@ func (m *RequestBeginBlock) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	l = len(m.RandomSeed)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -6572,6 +6939,26 @@ func (m *RequestCheckTx) Size() (n int) {
 	if m.Type != 0 {
 		n += 1 + sovTypes(uint64(m.Type))
 	}
+	l = m.Origin.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
+func (m *TxOrigin) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Source != 0 {
+		n += 1 + sovTypes(uint64(m.Source))
+	}
+	l = len(m.PeerId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ReceivedAt)
+	n += 1 + l + sovTypes(uint64(l))
 	return n
 }
 
@@ -7044,6 +7431,20 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.GasPrice != 0 {
+		n += 1 + sovTypes(uint64(m.GasPrice))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Nonce != 0 {
+		n += 1 + sovTypes(uint64(m.Nonce))
+	}
+	l = len(m.Category)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -7109,6 +7510,10 @@ func (m *ResponseEndBlock) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	if m.AppVersionUpgrade != nil {
+		l = m.AppVersionUpgrade.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -7233,6 +7638,21 @@ func (m *BlockParams) Size() (n int) {
 	return n
 }
 
+func (m *AppVersionUpgrade) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AppVersion != 0 {
+		n += 1 + sovTypes(uint64(m.AppVersion))
+	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	return n
+}
+
 func (m *LastCommitInfo) Size() (n int) {
 	if m == nil {
 		return 0
@@ -8807,6 +9227,40 @@ func (m *RequestBeginBlock) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RandomSeed", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RandomSeed = append(m.RandomSeed[:0], dAtA[iNdEx:postIndex]...)
+			if m.RandomSeed == nil {
+				m.RandomSeed = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -8913,6 +9367,176 @@ func (m *RequestCheckTx) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Origin", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Origin.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TxOrigin) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TxOrigin: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TxOrigin: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			m.Source = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Source |= TxSource(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceivedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.ReceivedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11367,6 +11991,108 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasPrice", wireType)
+			}
+			m.GasPrice = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasPrice |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nonce", wireType)
+			}
+			m.Nonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Nonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Category", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Category = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11798,6 +12524,42 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppVersionUpgrade", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AppVersionUpgrade == nil {
+				m.AppVersionUpgrade = &AppVersionUpgrade{}
+			}
+			if err := m.AppVersionUpgrade.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12642,6 +13404,97 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *AppVersionUpgrade) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AppVersionUpgrade: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AppVersionUpgrade: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppVersion", wireType)
+			}
+			m.AppVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -6,6 +6,8 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 
+	"github.com/tendermint/tendermint/libs/protoio"
+	"github.com/tendermint/tendermint/p2p"
 	bcproto "github.com/tendermint/tendermint/proto/tendermint/blockchain"
 	"github.com/tendermint/tendermint/types"
 )
@@ -46,6 +48,44 @@ func EncodeMsg(pb proto.Message) ([]byte, error) {
 	return bz, nil
 }
 
+// blockResponsePath is the path of the block in the encoded messages.
+var blockResponsePath = []int32{3, 1}
+
+// EncodeMsgFor encodes a Protobuf message for the peer, converting its block,
+// if any, to the proto schema of the peer's p2p protocol version (see
+// types.WireCodecFor).
+func EncodeMsgFor(peer p2p.Peer, pb proto.Message) ([]byte, error) {
+	bz, err := EncodeMsg(pb)
+	if err != nil {
+		return nil, err
+	}
+	codec := peerWireCodec(peer)
+	if codec == nil {
+		return bz, nil
+	}
+	return protoio.TranscodeField(bz, blockResponsePath, codec.DowngradeBlock)
+}
+
+// DecodeMsgFrom decodes a Protobuf message received from the peer, converting
+// its block, if any, from the proto schema of the peer's p2p protocol version.
+func DecodeMsgFrom(peer p2p.Peer, bz []byte) (proto.Message, error) {
+	if codec := peerWireCodec(peer); codec != nil {
+		var err error
+		if bz, err = protoio.TranscodeField(bz, blockResponsePath, codec.UpgradeBlock); err != nil {
+			return nil, err
+		}
+	}
+	return DecodeMsg(bz)
+}
+
+func peerWireCodec(peer p2p.Peer) types.WireCodec {
+	nodeInfo, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	if !ok {
+		return nil
+	}
+	return types.WireCodecFor(nodeInfo.ProtocolVersion.P2P)
+}
+
 // DecodeMsg decodes a Protobuf message.
 func DecodeMsg(bz []byte) (proto.Message, error) {
 	pb := &bcproto.Message{}
@@ -2,15 +2,21 @@ package blockchain
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"math"
+	"sync"
 	"testing"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/mock"
 	bcproto "github.com/tendermint/tendermint/proto/tendermint/blockchain"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
 )
 
 func TestBcBlockRequestMessageValidateBasic(t *testing.T) {
@@ -123,3 +129,77 @@ func TestBlockchainMessageVectors(t *testing.T) {
 		})
 	}
 }
+
+// jsonWireCodec is the codec of a legacy schema encoding the blocks as JSON.
+type jsonWireCodec struct{}
+
+func (jsonWireCodec) UpgradeBlock(bz []byte) ([]byte, error) {
+	block := &tmproto.Block{}
+	if err := json.Unmarshal(bz, block); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(block)
+}
+
+func (jsonWireCodec) DowngradeBlock(bz []byte) ([]byte, error) {
+	block := &tmproto.Block{}
+	if err := proto.Unmarshal(bz, block); err != nil {
+		return nil, err
+	}
+	return json.Marshal(block)
+}
+
+func (jsonWireCodec) UpgradeVote(bz []byte) ([]byte, error)   { return bz, nil }
+func (jsonWireCodec) DowngradeVote(bz []byte) ([]byte, error) { return bz, nil }
+
+type versionedPeer struct {
+	*mock.Peer
+	p2pVersion uint64
+}
+
+func (p versionedPeer) NodeInfo() p2p.NodeInfo {
+	return p2p.DefaultNodeInfo{ProtocolVersion: p2p.NewProtocolVersion(p.p2pVersion, version.BlockProtocol, 0)}
+}
+
+// registerJSONWireCodec registers the codec once per test binary, as the
+// registry can't be reset from outside the types package.
+var registerJSONWireCodec sync.Once
+
+func TestEncodeMsgFor(t *testing.T) {
+	legacy := version.P2PProtocol - 1
+	registerJSONWireCodec.Do(func() { types.RegisterWireCodec(legacy, jsonWireCodec{}) })
+	var (
+		legacyPeer  = versionedPeer{Peer: mock.NewPeer(nil), p2pVersion: legacy}
+		currentPeer = versionedPeer{Peer: mock.NewPeer(nil), p2pVersion: version.P2PProtocol}
+	)
+
+	block := types.MakeBlock(int64(3), []types.Tx{types.Tx("Hello World")}, nil, nil)
+	bpb, err := block.ToProto()
+	require.NoError(t, err)
+	msg := &bcproto.BlockResponse{Block: bpb}
+	current, err := EncodeMsg(msg)
+	require.NoError(t, err)
+
+	bz, err := EncodeMsgFor(currentPeer, msg)
+	require.NoError(t, err)
+	assert.Equal(t, current, bz)
+
+	// the block is sent to the legacy peer as JSON, and converted back
+	bz, err = EncodeMsgFor(legacyPeer, msg)
+	require.NoError(t, err)
+	assert.NotEqual(t, current, bz)
+	decoded, err := DecodeMsgFrom(legacyPeer, bz)
+	require.NoError(t, err)
+	bz, err = EncodeMsg(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, current, bz)
+	bz, err = EncodeMsgFor(legacyPeer, msg)
+	require.NoError(t, err)
+	_, err = DecodeMsgFrom(currentPeer, bz)
+	assert.Error(t, err)
+
+	// the other messages are the same in both schemas
+	bz, err = EncodeMsgFor(legacyPeer, &bcproto.StatusResponse{Height: 1, Base: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "2a0408011002", hex.EncodeToString(bz))
+}
@@ -57,6 +57,8 @@ type BlockchainReactor struct {
 	pool      *BlockPool
 	fastSync  bool
 
+	checkpoints types.Checkpoints
+
 	requestsCh <-chan BlockRequest
 	errorsCh   <-chan peerError
 }
@@ -100,6 +102,12 @@ func (bcR *BlockchainReactor) SetLogger(l log.Logger) {
 	bcR.pool.Logger = l
 }
 
+// SetCheckpoints sets the checkpoints the synced blocks must match. It must be
+// called before the reactor is started.
+func (bcR *BlockchainReactor) SetCheckpoints(checkpoints types.Checkpoints) {
+	bcR.checkpoints = checkpoints
+}
+
 // OnStart implements service.Service.
 func (bcR *BlockchainReactor) OnStart() error {
 	if bcR.fastSync {
@@ -151,7 +159,7 @@ func (bcR *BlockchainReactor) GetChannels() []*p2p.ChannelDescriptor {
 // AddPeer implements Reactor by sending our state to peer.
 func (bcR *BlockchainReactor) AddPeer(peer p2p.Peer) {
 	msgBytes, err := bc.EncodeMsg(&bcproto.StatusResponse{
-		Base:   bcR.store.Base(),
+		Base:   bcR.store.PartsBase(),
 		Height: bcR.store.Height()})
 	if err != nil {
 		bcR.Logger.Error("could not convert msg to protobuf", "err", err)
@@ -183,7 +191,7 @@ func (bcR *BlockchainReactor) respondToPeer(msg *bcproto.BlockRequest,
 			return false
 		}
 
-		msgBytes, err := bc.EncodeMsg(&bcproto.BlockResponse{Block: bl})
+		msgBytes, err := bc.EncodeMsgFor(src, &bcproto.BlockResponse{Block: bl})
 		if err != nil {
 			bcR.Logger.Error("could not marshal msg", "err", err)
 			return false
@@ -207,7 +215,7 @@ func (bcR *BlockchainReactor) respondToPeer(msg *bcproto.BlockRequest,
 // XXX: do not call any methods that can block or incur heavy processing.
 // https://github.com/tendermint/tendermint/issues/2888
 func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
-	msg, err := bc.DecodeMsg(msgBytes)
+	msg, err := bc.DecodeMsgFrom(src, msgBytes)
 	if err != nil {
 		bcR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
 		bcR.Switch.StopPeerForError(src, err)
@@ -233,11 +241,12 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 			return
 		}
 		bcR.pool.AddBlock(src.ID(), bi, len(msgBytes))
+		bcR.Switch.RecordUsefulBlock(src, bi.Height)
 	case *bcproto.StatusRequest:
 		// Send peer our state.
 		msgBytes, err := bc.EncodeMsg(&bcproto.StatusResponse{
 			Height: bcR.store.Height(),
-			Base:   bcR.store.Base(),
+			Base:   bcR.store.PartsBase(),
 		})
 		if err != nil {
 			bcR.Logger.Error("could not convert msg to protobut", "err", err)
@@ -365,8 +374,11 @@ FOR_LOOP:
 			// NOTE: we can probably make this more efficient, but note that calling
 			// first.Hash() doesn't verify the tx contents, so MakePartSet() is
 			// currently necessary.
-			err := state.Validators.VerifyCommitLight(
-				chainID, firstID, first.Height, second.LastCommit)
+			err := bcR.checkpoints.VerifyBlock(first.Height, firstID.Hash)
+			if err == nil {
+				err = state.Validators.VerifyCommitLight(
+					chainID, firstID, first.Height, second.LastCommit)
+			}
 			if err != nil {
 				bcR.Logger.Error("Error in validation", "err", err)
 				peerID := bcR.pool.RedoRequest(first.Height)
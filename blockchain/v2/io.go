@@ -91,7 +91,7 @@ func (sio *switchIO) sendBlockToPeer(block *types.Block, peerID p2p.ID) error {
 		return err
 	}
 
-	msgBytes, err := bc.EncodeMsg(&bcproto.BlockResponse{Block: bpb})
+	msgBytes, err := bc.EncodeMsgFor(peer, &bcproto.BlockResponse{Block: bpb})
 	if err != nil {
 		return err
 	}
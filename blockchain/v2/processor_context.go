@@ -16,9 +16,10 @@ type processorContext interface {
 }
 
 type pContext struct {
-	store   blockStore
-	applier blockApplier
-	state   state.State
+	store       blockStore
+	applier     blockApplier
+	state       state.State
+	checkpoints types.Checkpoints
 }
 
 func newProcessorContext(st blockStore, ex blockApplier, s state.State) *pContext {
@@ -44,6 +45,9 @@ func (pc *pContext) setState(state state.State) {
 }
 
 func (pc pContext) verifyCommit(chainID string, blockID types.BlockID, height int64, commit *types.Commit) error {
+	if err := pc.checkpoints.VerifyBlock(height, blockID.Hash); err != nil {
+		return err
+	}
 	return pc.state.Validators.VerifyCommitLight(chainID, blockID, height, commit)
 }
 
@@ -24,6 +24,7 @@ type blockStore interface {
 	LoadBlock(height int64) *types.Block
 	SaveBlock(*types.Block, *types.PartSet, *types.Commit)
 	Base() int64
+	PartsBase() int64
 	Height() int64
 }
 
@@ -45,6 +46,7 @@ type BlockchainReactor struct {
 	reporter behaviour.Reporter
 	io       iIO
 	store    blockStore
+	pContext *pContext
 }
 
 //nolint:unused,deadcode
@@ -76,6 +78,7 @@ func newReactor(state state.State, store blockStore, reporter behaviour.Reporter
 		reporter:  reporter,
 		logger:    log.NewNopLogger(),
 		fastSync:  fastSync,
+		pContext:  pContext,
 	}
 }
 
@@ -89,6 +92,12 @@ func NewBlockchainReactor(
 	return newReactor(state, store, reporter, blockApplier, fastSync)
 }
 
+// SetCheckpoints sets the checkpoints the synced blocks must match. It must be
+// called before the reactor is started.
+func (r *BlockchainReactor) SetCheckpoints(checkpoints types.Checkpoints) {
+	r.pContext.checkpoints = checkpoints
+}
+
 // SetSwitch implements Reactor interface.
 func (r *BlockchainReactor) SetSwitch(sw *p2p.Switch) {
 	r.Switch = sw
@@ -458,7 +467,7 @@ func (r *BlockchainReactor) Stop() error {
 // XXX: do not call any methods that can block or incur heavy processing.
 // https://github.com/tendermint/tendermint/issues/2888
 func (r *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
-	msg, err := bc.DecodeMsg(msgBytes)
+	msg, err := bc.DecodeMsgFrom(src, msgBytes)
 	if err != nil {
 		r.logger.Error("error decoding message",
 			"src", src.ID(), "chId", chID, "msg", msg, "err", err)
@@ -476,7 +485,7 @@ func (r *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 
 	switch msg := msg.(type) {
 	case *bcproto.StatusRequest:
-		if err := r.io.sendStatusResponse(r.store.Base(), r.store.Height(), src.ID()); err != nil {
+		if err := r.io.sendStatusResponse(r.store.PartsBase(), r.store.Height(), src.ID()); err != nil {
 			r.logger.Error("Could not send status message to peer", "src", src)
 		}
 
@@ -530,7 +539,7 @@ func (r *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 
 // AddPeer implements Reactor interface
 func (r *BlockchainReactor) AddPeer(peer p2p.Peer) {
-	err := r.io.sendStatusResponse(r.store.Base(), r.store.Height(), peer.ID())
+	err := r.io.sendStatusResponse(r.store.PartsBase(), r.store.Height(), peer.ID())
 	if err != nil {
 		r.logger.Error("Could not send status message to peer new", "src", peer.ID, "height", r.SyncHeight())
 	}
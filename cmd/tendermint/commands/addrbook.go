@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/p2p/pex"
+)
+
+// AddrBookCmd groups the commands to share the address book between nodes.
+var AddrBookCmd = &cobra.Command{
+	Use:   "addrbook",
+	Short: "Export or import the addresses of the address book",
+	Long: `Export or import the addresses of the address book, in a simple format
+with one id@host:port address per line, so curated peer lists can be shared
+between nodes. The node must not be running.`,
+}
+
+// AddrBookExportCmd writes the addresses of the address book to a file or the
+// standard output.
+var AddrBookExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export the addresses of the address book (to stdout if no file is given)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  exportAddrBook,
+}
+
+// AddrBookImportCmd merges the addresses read from a file or the standard
+// input into the address book.
+var AddrBookImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Merge addresses into the address book (from stdin if no file is given)",
+	Long: `Merge addresses into the address book (from stdin if no file is given).
+Empty lines and lines starting with # are ignored. Addresses already in the
+address book are kept as they are.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: importAddrBook,
+}
+
+var exportOnlyGood bool
+
+func init() {
+	AddrBookExportCmd.Flags().BoolVar(&exportOnlyGood, "only-good", false,
+		"only export the addresses this node successfully connected to")
+	AddrBookCmd.AddCommand(AddrBookExportCmd, AddrBookImportCmd)
+}
+
+func exportAddrBook(cmd *cobra.Command, args []string) error {
+	var w io.Writer = os.Stdout
+	if len(args) == 1 {
+		f, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	n, err := pex.ExportAddresses(config.P2P.AddrBookFile(), w, exportOnlyGood)
+	if err != nil {
+		return err
+	}
+	// the logger writes to stdout, which may be the export's destination
+	if len(args) == 1 {
+		logger.Info("Exported addresses", "n", n, "file", args[0])
+	}
+	return nil
+}
+
+func importAddrBook(cmd *cobra.Command, args []string) error {
+	var r io.Reader = os.Stdin
+	if len(args) == 1 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	added, skipped, err := pex.ImportAddresses(config.P2P.AddrBookFile(), config.P2P.AddrBookStrict, r)
+	if err != nil {
+		return fmt.Errorf("failed to import addresses: %w", err)
+	}
+	logger.Info("Imported addresses", "added", added, "skipped", skipped, "file", config.P2P.AddrBookFile())
+	return nil
+}
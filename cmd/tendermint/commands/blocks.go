@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	dbm "github.com/tendermint/tm-db"
+
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ExportBlocksCmd writes blocks of the block store to a file or the standard
+// output.
+var ExportBlocksCmd = &cobra.Command{
+	Use:   "export-blocks [file]",
+	Short: "Export blocks of the block store (to stdout if no file is given)",
+	Long: `Export blocks of the block store, along with the commits for them (to stdout
+if no file is given), for archival, off-line analysis, or to bootstrap the block
+store of another node with import-blocks. The node must not be running.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: exportBlocks,
+}
+
+// ImportBlocksCmd saves the blocks read from a file or the standard input in
+// the block store.
+var ImportBlocksCmd = &cobra.Command{
+	Use:   "import-blocks [file]",
+	Short: "Import blocks into the block store (from stdin if no file is given)",
+	Long: `Import blocks written by export-blocks into the block store (from stdin if
+no file is given). The blocks must follow the last block of the store, and their
+commits are verified against the validators of the state store, unless
+--skip-commit-verification is set. The blocks already in the store are skipped.
+The blocks are only stored, not executed. The node must not be running.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: importBlocks,
+}
+
+var (
+	exportFromHeight       int64
+	exportToHeight         int64
+	blocksFormat           string
+	skipCommitVerification bool
+)
+
+func init() {
+	ExportBlocksCmd.Flags().Int64Var(&exportFromHeight, "from", 0,
+		"first height to export (0 - the first complete block of the block store)")
+	ExportBlocksCmd.Flags().Int64Var(&exportToHeight, "to", 0,
+		"last height to export (0 - the height of the block store)")
+	for _, cmd := range []*cobra.Command{ExportBlocksCmd, ImportBlocksCmd} {
+		cmd.Flags().StringVar(&blocksFormat, "format", store.ExportFormatProto,
+			fmt.Sprintf("format of the blocks: %s or %s", store.ExportFormatProto, store.ExportFormatJSON))
+	}
+	ImportBlocksCmd.Flags().BoolVar(&skipCommitVerification, "skip-commit-verification", false,
+		"don't verify the signatures of the commits (e.g. if the state store doesn't have the validators)")
+}
+
+func exportBlocks(cmd *cobra.Command, args []string) error {
+	blockStoreDB, err := dbm.NewDB("blockstore", dbm.BackendType(config.DBBackend), config.DBDirFor("blockstore"))
+	if err != nil {
+		return err
+	}
+	defer blockStoreDB.Close()
+
+	var w io.Writer = os.Stdout
+	if len(args) == 1 {
+		f, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	n, err := store.ExportBlocks(store.NewBlockStore(blockStoreDB), w, blocksFormat, exportFromHeight, exportToHeight)
+	if err != nil {
+		return fmt.Errorf("failed to export blocks: %w", err)
+	}
+	// the logger writes to stdout, which may be the export's destination
+	if len(args) == 1 {
+		logger.Info("Exported blocks", "n", n, "file", args[0])
+	}
+	return nil
+}
+
+func importBlocks(cmd *cobra.Command, args []string) error {
+	var r io.Reader = os.Stdin
+	if len(args) == 1 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var verify store.CommitVerifier
+	if !skipCommitVerification {
+		genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+		if err != nil {
+			return err
+		}
+		stateDB, err := dbm.NewDB("state", dbm.BackendType(config.DBBackend), config.DBDirFor("state"))
+		if err != nil {
+			return err
+		}
+		defer stateDB.Close()
+		verify = verifyCommitWithStateStore(genDoc.ChainID, sm.NewStore(stateDB))
+	}
+
+	blockStoreDB, err := dbm.NewDB("blockstore", dbm.BackendType(config.DBBackend), config.DBDirFor("blockstore"))
+	if err != nil {
+		return err
+	}
+	defer blockStoreDB.Close()
+	blockStore := store.NewBlockStore(blockStoreDB)
+
+	n, err := store.ImportBlocks(blockStore, r, blocksFormat, verify)
+	if err != nil {
+		return fmt.Errorf("failed to import blocks (%d imported): %w", n, err)
+	}
+	logger.Info("Imported blocks", "n", n, "base", blockStore.Base(), "height", blockStore.Height())
+	return nil
+}
+
+// verifyCommitWithStateStore returns a verifier of the commits against the
+// validators of the state store.
+func verifyCommitWithStateStore(chainID string, stateStore sm.Store) store.CommitVerifier {
+	return func(block *types.Block, blockID types.BlockID, commit *types.Commit) error {
+		if block.ChainID != chainID {
+			return fmt.Errorf("block is for chain %q, expected %q", block.ChainID, chainID)
+		}
+		vals, err := stateStore.LoadValidators(block.Height)
+		if err != nil {
+			return fmt.Errorf("can't load the validators (see --skip-commit-verification): %w", err)
+		}
+		if !bytes.Equal(vals.Hash(), block.ValidatorsHash) {
+			return fmt.Errorf("block validators hash %X differs from the hash %X of the stored validators",
+				block.ValidatorsHash, vals.Hash())
+		}
+		return vals.VerifyCommit(chainID, blockID, block.Height, commit)
+	}
+}
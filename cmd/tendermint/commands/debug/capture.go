@@ -0,0 +1,159 @@
+package debug
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/spf13/cobra"
+
+	bc "github.com/tendermint/tendermint/blockchain/v0"
+	cs "github.com/tendermint/tendermint/consensus"
+	"github.com/tendermint/tendermint/evidence"
+	"github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/operator"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/pex"
+	bcproto "github.com/tendermint/tendermint/proto/tendermint/blockchain"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	tmevidence "github.com/tendermint/tendermint/proto/tendermint/evidence"
+	protomem "github.com/tendermint/tendermint/proto/tendermint/mempool"
+	tmoperator "github.com/tendermint/tendermint/proto/tendermint/operator"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/statesync"
+)
+
+var (
+	capturePeer    string
+	captureChannel string
+	captureDecode  bool
+
+	flagCapturePeer    = "peer"
+	flagCaptureChannel = "channel"
+	flagCaptureDecode  = "decode"
+)
+
+// captureEnvelopes is the envelope of the messages of each channel, used to
+// name the message types of the capture records and decode their payloads.
+var captureEnvelopes = map[byte]proto.Message{
+	pex.PexChannel:                    &tmp2p.Message{},
+	cs.StateChannel:                   &tmcons.Message{},
+	cs.DataChannel:                    &tmcons.Message{},
+	cs.VoteChannel:                    &tmcons.Message{},
+	cs.VoteSetBitsChannel:             &tmcons.Message{},
+	mempool.MempoolChannel:            &protomem.Message{},
+	evidence.EvidenceChannel:          &tmproto.EvidenceList{},
+	evidence.CommittedEvidenceChannel: &tmevidence.CommittedEvidence{},
+	bc.BlockchainChannel:              &bcproto.Message{},
+	statesync.SnapshotChannel:         &ssproto.Message{},
+	statesync.ChunkChannel:            &ssproto.Message{},
+	operator.OperatorChannel:          &tmoperator.Message{},
+}
+
+var captureCmd = &cobra.Command{
+	Use:   "capture [capture-file]",
+	Short: "Print the envelopes recorded into a p2p capture file",
+	Long: `Print the envelopes recorded into a p2p capture file (see the p2p.capture_file
+config parameter), including its rotated files, from the oldest. Each line shows
+the time, the direction, the peer, the channel, the message type and the size of
+an envelope, and its decoded payload with --decode if the payloads were recorded.`,
+	Args: cobra.ExactArgs(1),
+	RunE: captureCmdHandler,
+}
+
+func init() {
+	captureCmd.Flags().StringVar(
+		&capturePeer,
+		flagCapturePeer,
+		"",
+		"only print the envelopes exchanged with the peer of this ID",
+	)
+
+	captureCmd.Flags().StringVar(
+		&captureChannel,
+		flagCaptureChannel,
+		"",
+		"only print the envelopes of this channel (e.g. 0x20)",
+	)
+
+	captureCmd.Flags().BoolVar(
+		&captureDecode,
+		flagCaptureDecode,
+		false,
+		"print the decoded payloads of the envelopes",
+	)
+}
+
+func captureCmdHandler(cmd *cobra.Command, args []string) error {
+	chID := -1
+	if captureChannel != "" {
+		id, err := strconv.ParseUint(captureChannel, 0, 8)
+		if err != nil {
+			return fmt.Errorf("invalid channel ID %q: %w", captureChannel, err)
+		}
+		chID = int(id)
+	}
+
+	r, err := p2p.OpenCaptureReader(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open the capture file: %w", err)
+	}
+	defer r.Close()
+
+	out := cmd.OutOrStdout()
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read the capture file: %w", err)
+		}
+		if (capturePeer != "" && string(rec.PeerID) != capturePeer) || (chID >= 0 && int(rec.Channel) != chID) {
+			continue
+		}
+
+		fmt.Fprintf(out, "%s %-3s %s %#x %s %d\n", rec.Time.Format("2006-01-02T15:04:05.000000Z07:00"),
+			rec.Dir, rec.PeerID, rec.Channel, captureTypeName(rec.Channel, rec.Type), rec.Size)
+		if captureDecode && len(rec.Payload) > 0 {
+			fmt.Fprintf(out, "  %s\n", decodeCapturePayload(rec.Channel, rec.Payload))
+		}
+	}
+}
+
+// captureTypeName returns the name of the message type of the given field
+// number in the envelope of the channel.
+func captureTypeName(chID byte, field int) string {
+	envelope, ok := captureEnvelopes[chID]
+	if !ok {
+		return fmt.Sprintf("unknown(%d)", field)
+	}
+	props := proto.GetProperties(reflect.TypeOf(envelope).Elem())
+	if len(props.OneofTypes) == 0 {
+		return proto.MessageName(envelope)
+	}
+	for _, oneof := range props.OneofTypes {
+		if oneof.Prop.Tag == field {
+			return oneof.Prop.OrigName
+		}
+	}
+	return fmt.Sprintf("unknown(%d)", field)
+}
+
+// decodeCapturePayload returns the text representation of the payload of an
+// envelope of the channel.
+func decodeCapturePayload(chID byte, payload []byte) string {
+	envelope, ok := captureEnvelopes[chID]
+	if !ok {
+		return fmt.Sprintf("%X", payload)
+	}
+	msg := reflect.New(reflect.TypeOf(envelope).Elem()).Interface().(proto.Message)
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return fmt.Sprintf("%X (%v)", payload, err)
+	}
+	return proto.CompactTextString(msg)
+}
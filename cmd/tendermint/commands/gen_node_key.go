@@ -27,6 +27,9 @@ func genNodeKey(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Println(nodeKey.ID())
-	return nil
+	id := nodeKey.ID()
+	if err := nodeKey.Destroy(); err != nil {
+		return err
+	}
+	return printOutput(cmd, string(id), nodeIDOutput{ID: id})
 }
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+// GenP2PTLSCertCmd generates the certificate of the p2p TLS connections. It
+// prints the pin of the certificate, to be added to the tls_pinned_certs of the
+// peers.
+var GenP2PTLSCertCmd = &cobra.Command{
+	Use:   "gen-p2p-tls-cert",
+	Short: "Generate the p2p TLS certificate of this node and print its pin",
+	Long: `Generate the self-signed certificate and key of the p2p TLS connections, at the
+paths set in the config, and print the pin of the certificate
+(<node ID>@<fingerprint>), to be added to the tls_pinned_certs of the peers.`,
+	RunE: genP2PTLSCert,
+}
+
+// tlsCertOutput is the JSON output of gen-p2p-tls-cert.
+type tlsCertOutput struct {
+	ID          p2p.ID `json:"id"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+func genP2PTLSCert(cmd *cobra.Command, args []string) error {
+	certFile, keyFile := config.P2P.TLSCertPath(), config.P2P.TLSKeyPath()
+	for _, file := range []string{certFile, keyFile} {
+		if tmos.FileExists(file) {
+			return fmt.Errorf("%s already exists", file)
+		}
+	}
+
+	nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	if err != nil {
+		return err
+	}
+	id := nodeKey.ID()
+	if err := nodeKey.Destroy(); err != nil {
+		return err
+	}
+	fingerprint, err := p2p.GenTLSCertificate(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	logger.Info("Generated p2p TLS certificate", "cert", certFile, "key", keyFile)
+	return printOutput(cmd, fmt.Sprintf("%s@%s", id, fingerprint), tlsCertOutput{ID: id, Fingerprint: fingerprint})
+}
@@ -10,8 +10,8 @@ import (
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/privval"
-	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/types/genesis"
 	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
@@ -73,25 +73,20 @@ func initFilesWithConfig(config *cfg.Config) error {
 		logger.Info("Found genesis file", "path", genFile)
 	} else {
 
-		genDoc := types.GenesisDoc{
-			ChainID:         fmt.Sprintf("test-chain-%v", tmrand.Str(6)),
-			GenesisTime:     tmtime.Now(),
-			ConsensusParams: types.DefaultConsensusParams(),
+		pubKey, err := pv.GetPubKey()
+		if err != nil {
+			return fmt.Errorf("can't get pubkey: %w", err)
 		}
+		genesisBuilder := genesis.NewBuilder(fmt.Sprintf("test-chain-%v", tmrand.Str(6))).
+			GenesisTime(tmtime.Now()).
+			AddValidator(pubKey, 10, "")
 		if keyType == "secp256k1" {
-			genDoc.ConsensusParams.Validator = tmproto.ValidatorParams{
-				PubKeyTypes: []string{types.ABCIPubKeyTypeSecp256k1},
-			}
+			genesisBuilder.PubKeyTypes(types.ABCIPubKeyTypeSecp256k1)
 		}
-		pubKey, err := pv.GetPubKey()
+		genDoc, err := genesisBuilder.Build()
 		if err != nil {
-			return fmt.Errorf("can't get pubkey: %w", err)
+			return err
 		}
-		genDoc.Validators = []types.GenesisValidator{{
-			Address: pubKey.Address(),
-			PubKey:  pubKey,
-			Power:   10,
-		}}
 
 		if err := genDoc.SaveAs(genFile); err != nil {
 			return err
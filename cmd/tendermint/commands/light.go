@@ -12,6 +12,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 
 	dbm "github.com/tendermint/tm-db"
@@ -72,6 +74,9 @@ var (
 
 	verbose bool
 
+	prometheusAddr  string
+	shutdownTimeout time.Duration
+
 	primaryKey   = []byte("primary")
 	witnessesKey = []byte("witnesses")
 )
@@ -101,6 +106,10 @@ func init() {
 	LightCmd.Flags().BoolVar(&sequential, "sequential", false,
 		"sequential verification. Verify all headers sequentially as opposed to using skipping verification",
 	)
+	LightCmd.Flags().StringVar(&prometheusAddr, "prometheus-laddr", "",
+		"serve the Prometheus metrics on the given address, e.g. :26660 (disabled if empty)")
+	LightCmd.Flags().DurationVar(&shutdownTimeout, "shutdown-timeout", 10*time.Second,
+		"maximum time to wait for the requests in progress to complete when stopping")
 }
 
 func runProxy(cmd *cobra.Command, args []string) error {
@@ -169,6 +178,11 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		}),
 	}
 
+	if prometheusAddr != "" {
+		options = append(options, light.WithMetrics(light.PrometheusMetrics(
+			config.Instrumentation.Namespace, "chain_id", chainID)))
+	}
+
 	if sequential {
 		options = append(options, light.SequentialVerification())
 	} else {
@@ -225,21 +239,73 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		Config: cfg,
 		Client: lrpc.NewClient(rpcClient, c, lrpc.KeyPathFn(defaultMerkleKeyPathFn())),
 		Logger: logger,
+		OnReady: func() {
+			// Notify systemd if run as a Type=notify service.
+			if _, err := tmos.SdNotify("READY=1"); err != nil {
+				logger.Error("Failed to notify the service manager", "err", err)
+			}
+		},
 	}
-	// Stop upon receiving SIGTERM or CTRL-C.
+
+	var prometheusSrv *http.Server
+	if prometheusAddr != "" {
+		prometheusSrv = startPrometheusServer(prometheusAddr, maxOpenConnections, logger)
+	}
+
+	// Stop gracefully upon receiving SIGTERM or CTRL-C.
+	stopped := make(chan struct{})
 	tmos.TrapSignal(logger, func() {
-		p.Listener.Close()
+		defer close(stopped)
+		if _, err := tmos.SdNotify("STOPPING=1"); err != nil {
+			logger.Error("Failed to notify the service manager", "err", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := p.Shutdown(ctx); err != nil {
+			logger.Error("Failed to shut down the proxy gracefully", "err", err)
+		}
+		if prometheusSrv != nil {
+			if err := prometheusSrv.Shutdown(ctx); err != nil {
+				logger.Error("Prometheus HTTP server Shutdown", "err", err)
+			}
+		}
+		if err := db.Close(); err != nil {
+			logger.Error("Failed to close the light client DB", "err", err)
+		}
 	})
 
 	logger.Info("Starting proxy...", "laddr", listenAddr)
 	if err := p.ListenAndServe(); err != http.ErrServerClosed {
 		// Error starting or closing listener:
 		logger.Error("proxy ListenAndServe", "err", err)
+		return nil
 	}
 
+	// Wait for the requests in progress to complete, see Proxy.Shutdown.
+	<-stopped
 	return nil
 }
 
+func startPrometheusServer(addr string, maxOpenConnections int, logger log.Logger) *http.Server {
+	srv := &http.Server{
+		Addr: addr,
+		Handler: promhttp.InstrumentMetricHandler(
+			prometheus.DefaultRegisterer, promhttp.HandlerFor(
+				prometheus.DefaultGatherer,
+				promhttp.HandlerOpts{MaxRequestsInFlight: maxOpenConnections},
+			),
+		),
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			// Error starting or closing listener:
+			logger.Error("Prometheus HTTP server ListenAndServe", "err", err)
+		}
+	}()
+	return srv
+}
+
 func checkForExistingProviders(db dbm.DB) (string, []string, error) {
 	primaryBytes, err := db.Get(primaryKey)
 	if err != nil {
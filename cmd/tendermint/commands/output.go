@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/libs/cli"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+// Output formats of the commands printing info, set with the --output flag.
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+var outputFormats = []string{outputText, outputJSON}
+
+func registerOutputFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(cli.OutputFlag, outputText,
+		"output format of the commands printing info (text|json)")
+	err := cmd.RegisterFlagCompletionFunc(cli.OutputFlag,
+		func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return outputFormats, cobra.ShellCompDirectiveNoFileComp
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// validateOutput returns an error if the output format is unknown.
+func validateOutput() error {
+	switch output := viper.GetString(cli.OutputFlag); output {
+	case "", outputText, outputJSON:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q, must be one of %v", output, outputFormats)
+	}
+}
+
+// printOutput prints the text, or the value as JSON with --output json, to
+// the standard output of the command.
+func printOutput(cmd *cobra.Command, text string, v interface{}) error {
+	if viper.GetString(cli.OutputFlag) != outputJSON {
+		fmt.Fprintln(cmd.OutOrStdout(), text)
+		return nil
+	}
+	bz, err := tmjson.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the output: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+	return nil
+}
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/cli"
+)
+
+func TestPrintOutput(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	cmd := &cobra.Command{}
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	out := nodeIDOutput{ID: "b1616b9f3cca2c5d46e47a83e1bde762513652a6"}
+
+	require.NoError(t, validateOutput())
+	require.NoError(t, printOutput(cmd, string(out.ID), out))
+	assert.Equal(t, "b1616b9f3cca2c5d46e47a83e1bde762513652a6\n", buf.String())
+
+	buf.Reset()
+	viper.Set(cli.OutputFlag, outputJSON)
+	require.NoError(t, validateOutput())
+	require.NoError(t, printOutput(cmd, string(out.ID), out))
+	assert.Equal(t, `{"id":"b1616b9f3cca2c5d46e47a83e1bde762513652a6"}`+"\n", buf.String())
+
+	viper.Set(cli.OutputFlag, "yaml")
+	assert.Error(t, validateOutput())
+}
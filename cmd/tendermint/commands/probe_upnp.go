@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
+	"github.com/tendermint/tendermint/libs/cli"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/p2p/upnp"
 )
@@ -18,6 +20,12 @@ var ProbeUpnpCmd = &cobra.Command{
 
 func probeUpnp(cmd *cobra.Command, args []string) error {
 	capabilities, err := upnp.Probe(logger)
+	if viper.GetString(cli.OutputFlag) == outputJSON {
+		if err != nil {
+			return fmt.Errorf("probe failed: %w", err)
+		}
+		return printOutput(cmd, "", capabilities)
+	}
 	if err != nil {
 		fmt.Println("Probe failed: ", err)
 	} else {
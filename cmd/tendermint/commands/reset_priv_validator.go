@@ -2,6 +2,10 @@ package commands
 
 import (
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -19,37 +23,173 @@ var ResetAllCmd = &cobra.Command{
 	Run:   resetAll,
 }
 
-var keepAddrBook bool
+var (
+	keepAddrBook bool
+	resetDryRun  bool
+)
 
 func init() {
 	ResetAllCmd.Flags().BoolVar(&keepAddrBook, "keep-addr-book", false, "keep the address book intact")
 	ResetPrivValidatorCmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519,
 		"Key type to generate privval file with. Options: ed25519, secp256k1")
+	for _, cmd := range []*cobra.Command{ResetAllCmd, ResetBlockStoreCmd, ResetStateCmd, ResetPeersCmd,
+		ResetPrivValidatorCmd} {
+		cmd.Flags().BoolVar(&resetDryRun, "dry-run", false, "only print what would be removed or reset")
+	}
 }
 
 // ResetPrivValidatorCmd resets the private validator files.
 var ResetPrivValidatorCmd = &cobra.Command{
 	Use:   "unsafe_reset_priv_validator",
 	Short: "(unsafe) Reset this node's validator to genesis state",
-	Run:   resetPrivValidator,
+	Long: `Reset the last sign state of this node's validator (priv_validator_state_file),
+generating the validator if it doesn't exist.
+
+WARNING: the last sign state is what prevents the validator from signing twice
+the same height and round. Once reset, the validator double signs, and is
+slashed, if it signs again the heights it already signed: only reset it
+along with the chain itself.`,
+	Run: resetPrivValidator,
+}
+
+// ResetBlockStoreCmd removes the blockstore of this node.
+var ResetBlockStoreCmd = &cobra.Command{
+	Use:   "unsafe_reset_blockstore",
+	Short: "(unsafe) Remove the blockstore",
+	Long: `Remove the blockstore database, keeping the state, the address book and the
+validator files. The node can only start again if the state and the app are
+reset too, or restored by state sync.`,
+	Run: resetBlockStore,
+}
+
+// ResetStateCmd removes the state of this node.
+var ResetStateCmd = &cobra.Command{
+	Use:   "unsafe_reset_state",
+	Short: "(unsafe) Remove the state database and the consensus WAL",
+	Long: `Remove the state database and the consensus WAL, keeping the blockstore, the
+address book and the validator files. The node can only start again if the
+blockstore and the app are reset too, or restored by state sync.`,
+	Run: resetState,
+}
+
+// ResetPeersCmd removes the address book of this node.
+var ResetPeersCmd = &cobra.Command{
+	Use:   "unsafe_reset_peers",
+	Short: "(unsafe) Remove the address book",
+	Long: `Remove the address book, i.e. the known peers and their stats, keeping the
+blockchain data. The node discovers the peers again from the seeds and the
+persistent peers.`,
+	Run: resetPeers,
 }
 
 // XXX: this is totally unsafe.
 // it's only suitable for testnets.
 func resetAll(cmd *cobra.Command, args []string) {
-	ResetAll(config.DBDir(), config.P2P.AddrBookFile(), config.PrivValidatorKeyFile(),
-		config.PrivValidatorStateFile(), logger)
+	separateDirs := config.SeparateDBDirs()
+	ids := make([]string, 0, len(separateDirs))
+	for id := range separateDirs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		resetDB(id, separateDirs[id], logger)
+	}
+	if !resetDryRun {
+		ResetAll(config.DBDir(), config.P2P.AddrBookFile(), config.PrivValidatorKeyFile(),
+			config.PrivValidatorStateFile(), logger)
+		return
+	}
+
+	keyFile, stateFile := config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()
+	warnSignStateReset(keyFile, stateFile, logger)
+	if keepAddrBook {
+		logger.Info("The address book remains intact")
+	} else {
+		logger.Info("Would remove address book", "file", config.P2P.AddrBookFile())
+	}
+	logger.Info("Would remove all blockchain history", "dir", config.DBDir())
+	logger.Info("Would reset private validator file to genesis state", "keyFile", keyFile, "stateFile", stateFile)
 }
 
 // XXX: this is totally unsafe.
 // it's only suitable for testnets.
 func resetPrivValidator(cmd *cobra.Command, args []string) {
-	resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), logger)
+	keyFile, stateFile := config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()
+	warnSignStateReset(keyFile, stateFile, logger)
+	if resetDryRun {
+		logger.Info("Would reset private validator file to genesis state", "keyFile", keyFile, "stateFile", stateFile)
+		return
+	}
+	resetFilePV(keyFile, stateFile, logger)
+}
+
+func resetBlockStore(cmd *cobra.Command, args []string) {
+	resetDB("blockstore", config.DBDirFor("blockstore"), logger)
+}
+
+func resetState(cmd *cobra.Command, args []string) {
+	resetDB("state", config.DBDirFor("state"), logger)
+	resetWAL(config.Consensus.WalFile(), logger)
+}
+
+// walFileIndex matches the suffix of the rotated files of a WAL, see
+// autofile.Group.
+var walFileIndex = regexp.MustCompile(`^\.[0-9]{3,}$`)
+
+// resetWAL removes the files of the WAL with the given head file, i.e. the head
+// and its rotated files, leaving the rest of its directory, which can be
+// shared with other data, intact. It only logs them if --dry-run is set.
+func resetWAL(walFile string, logger log.Logger) {
+	matches, err := filepath.Glob(walFile + ".*")
+	if err != nil {
+		logger.Error("Error listing consensus WAL files", "file", walFile, "err", err)
+		return
+	}
+	files := []string{walFile}
+	for _, file := range matches {
+		if walFileIndex.MatchString(strings.TrimPrefix(file, walFile)) {
+			files = append(files, file)
+		}
+	}
+	for _, file := range files {
+		if !tmos.FileExists(file) {
+			continue
+		}
+		if resetDryRun {
+			logger.Info("Would remove consensus WAL file", "file", file)
+			continue
+		}
+		if err := os.Remove(file); err == nil {
+			logger.Info("Removed consensus WAL file", "file", file)
+		} else {
+			logger.Error("Error removing consensus WAL file", "file", file, "err", err)
+		}
+	}
+}
+
+func resetPeers(cmd *cobra.Command, args []string) {
+	addrBookFile := config.P2P.AddrBookFile()
+	if resetDryRun {
+		logger.Info("Would remove address book", "file", addrBookFile)
+		return
+	}
+	removeAddrBook(addrBookFile, logger)
+}
+
+// resetDB removes the database with the given ID from dir, or only logs it if
+// --dry-run is set.
+func resetDB(id, dir string, logger log.Logger) {
+	if resetDryRun {
+		logger.Info("Would remove database", "db", id, "dir", filepath.Join(dir, id+".db"))
+		return
+	}
+	removeDB(id, dir, logger)
 }
 
 // ResetAll removes address book files plus all data, and resets the privValdiator data.
 // Exported so other CLI tools can use it.
 func ResetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logger log.Logger) {
+	warnSignStateReset(privValKeyFile, privValStateFile, logger)
 	if keepAddrBook {
 		logger.Info("The address book remains intact")
 	} else {
@@ -67,6 +207,24 @@ func ResetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logg
 	resetFilePV(privValKeyFile, privValStateFile, logger)
 }
 
+// warnSignStateReset warns that the last sign state of the validator, if it
+// exists, is reset, along with the last height and round it signed.
+func warnSignStateReset(privValKeyFile, privValStateFile string, logger log.Logger) {
+	if !tmos.FileExists(privValKeyFile) {
+		return
+	}
+	var (
+		height int64
+		round  int32
+	)
+	if tmos.FileExists(privValStateFile) {
+		lss := privval.LoadFilePV(privValKeyFile, privValStateFile).LastSignState
+		height, round = lss.Height, lss.Round
+	}
+	logger.Error("Resetting the last sign state: the validator double signs if it signs the heights it "+
+		"already signed again", "height", height, "round", round, "stateFile", privValStateFile)
+}
+
 func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
 	if _, err := os.Stat(privValKeyFile); err == nil {
 		pv := privval.LoadFilePVEmptyState(privValKeyFile, privValStateFile)
@@ -84,6 +242,17 @@ func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
 	}
 }
 
+// removeDB removes the database with the given ID from dir, leaving the rest
+// of dir, which can be shared with other data, intact.
+func removeDB(id, dir string, logger log.Logger) {
+	path := filepath.Join(dir, id+".db")
+	if err := os.RemoveAll(path); err == nil {
+		logger.Info("Removed database", "db", id, "dir", path)
+	} else {
+		logger.Error("Error removing database", "db", id, "dir", path, "err", err)
+	}
+}
+
 func removeAddrBook(addrBookFile string, logger log.Logger) {
 	if err := os.Remove(addrBookFile); err == nil {
 		logger.Info("Removed existing address book", "file", addrBookFile)
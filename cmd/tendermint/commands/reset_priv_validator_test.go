@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/privval"
+)
+
+// setupResetRoot creates a home directory with the databases, a consensus WAL
+// sharing the data directory, the address book and a validator which signed
+// height 5, and returns the paths of all of them. The package config and
+// logger are restored once the test is done.
+func setupResetRoot(t *testing.T) []string {
+	prevConfig, prevLogger := config, logger
+	config = cfg.ResetTestRoot("reset_test")
+	rootDir := config.RootDir
+	t.Cleanup(func() {
+		os.RemoveAll(rootDir)
+		config, logger = prevConfig, prevLogger
+	})
+	logger = log.TestingLogger()
+	config.Consensus.WalPath = "data/wal"
+
+	pv := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	pv.LastSignState.Height = 5
+	pv.LastSignState.Save()
+
+	dataDir := config.DBDir()
+	for _, db := range []string{"blockstore.db", "state.db", "evidence.db"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dataDir, db), 0700))
+	}
+	wal := config.Consensus.WalFile()
+	for _, file := range []string{wal, wal + ".000", wal + ".001", wal + ".bak", config.P2P.AddrBookFile()} {
+		require.NoError(t, ioutil.WriteFile(file, []byte("data"), 0600))
+	}
+	return []string{
+		filepath.Join(dataDir, "blockstore.db"),
+		filepath.Join(dataDir, "state.db"),
+		filepath.Join(dataDir, "evidence.db"),
+		wal, wal + ".000", wal + ".001", wal + ".bak",
+		config.P2P.AddrBookFile(),
+		config.PrivValidatorKeyFile(),
+		config.PrivValidatorStateFile(),
+	}
+}
+
+func TestResetCommands(t *testing.T) {
+	testCases := []struct {
+		cmd     *cobra.Command
+		run     func(*cobra.Command, []string)
+		removed func() []string
+	}{
+		{ResetAllCmd, resetAll, func() []string {
+			dataDir, wal := config.DBDir(), config.Consensus.WalFile()
+			return []string{
+				filepath.Join(dataDir, "blockstore.db"),
+				filepath.Join(dataDir, "state.db"),
+				filepath.Join(dataDir, "evidence.db"),
+				wal, wal + ".000", wal + ".001", wal + ".bak",
+				config.P2P.AddrBookFile(),
+			}
+		}},
+		{ResetBlockStoreCmd, resetBlockStore, func() []string {
+			return []string{filepath.Join(config.DBDir(), "blockstore.db")}
+		}},
+		{ResetStateCmd, resetState, func() []string {
+			wal := config.Consensus.WalFile()
+			return []string{filepath.Join(config.DBDir(), "state.db"), wal, wal + ".000", wal + ".001"}
+		}},
+		{ResetPeersCmd, resetPeers, func() []string {
+			return []string{config.P2P.AddrBookFile()}
+		}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.cmd.Use, func(t *testing.T) {
+			for _, dryRun := range []bool{true, false} {
+				paths := setupResetRoot(t)
+				resetDryRun = dryRun
+				tc.run(tc.cmd, nil)
+				resetDryRun = false
+
+				removed := make(map[string]bool)
+				if !dryRun {
+					for _, path := range tc.removed() {
+						removed[path] = true
+					}
+				}
+				for _, path := range paths {
+					assert.Equal(t, !removed[path], tmos.FileExists(path), "dry run: %v, path: %s", dryRun, path)
+				}
+			}
+		})
+	}
+}
+
+func TestResetPrivValidatorDryRun(t *testing.T) {
+	setupResetRoot(t)
+	stateFile := config.PrivValidatorStateFile()
+	lastSignHeight := func() int64 {
+		return privval.LoadFilePV(config.PrivValidatorKeyFile(), stateFile).LastSignState.Height
+	}
+
+	resetDryRun = true
+	resetPrivValidator(ResetPrivValidatorCmd, nil)
+	resetDryRun = false
+	assert.EqualValues(t, 5, lastSignHeight())
+
+	resetPrivValidator(ResetPrivValidatorCmd, nil)
+	assert.EqualValues(t, 0, lastSignHeight())
+}
@@ -24,6 +24,7 @@ func init() {
 
 func registerFlagsRootCmd(cmd *cobra.Command) {
 	cmd.PersistentFlags().String("log_level", config.LogLevel, "log level")
+	registerOutputFlag(cmd)
 }
 
 // ParseConfig retrieves the default environment configuration,
@@ -47,6 +48,9 @@ var RootCmd = &cobra.Command{
 	Use:   "tendermint",
 	Short: "Tendermint Core (BFT Consensus) in Go",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
+		if err := validateOutput(); err != nil {
+			return err
+		}
 		if cmd.Name() == VersionCmd.Name() {
 			return nil
 		}
@@ -57,6 +61,10 @@ var RootCmd = &cobra.Command{
 		if config.LogFormat == cfg.LogFormatJSON {
 			logger = log.NewTMJSONLogger(log.NewSyncWriter(os.Stdout))
 		}
+		if config.LogSampleRate > 1 {
+			logger = log.NewSamplingLogger(logger, config.LogSampleRate, config.LogSampleWindow,
+				config.LogSampledMessages...)
+		}
 		logger, err = tmflags.ParseLogLevel(config.LogLevel, logger, cfg.DefaultLogLevel())
 		if err != nil {
 			return err
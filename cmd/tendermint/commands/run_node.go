@@ -16,6 +16,7 @@ import (
 
 var (
 	genesisHash []byte
+	replayFrom  int64
 )
 
 // AddNodeFlags exposes some common configuration options on the command-line
@@ -37,6 +38,12 @@ func AddNodeFlags(cmd *cobra.Command) {
 		"genesis_hash",
 		[]byte{},
 		"optional SHA-256 hash of the genesis file")
+	cmd.Flags().Int64Var(
+		&replayFrom,
+		"replay-from",
+		0,
+		"replay blocks starting at this height during the ABCI handshake, regardless of the app's reported height. "+
+			"Only use after restoring the app's state to this height minus one")
 	cmd.Flags().Int64("consensus.double_sign_check_height", config.Consensus.DoubleSignCheckHeight,
 		"how many blocks to look back to check existence of the node's "+
 			"consensus votes before joining consensus")
@@ -83,6 +90,16 @@ func AddNodeFlags(cmd *cobra.Command) {
 		"consensus.create_empty_blocks_interval",
 		config.Consensus.CreateEmptyBlocksInterval.String(),
 		"the possible interval between empty blocks")
+	cmd.Flags().String(
+		"consensus.target_block_time",
+		config.Consensus.TargetBlockTime.String(),
+		"the minimum time between committing a block and proposing the next one, if it would be empty")
+
+	// mempool flags
+	cmd.Flags().String(
+		"mempool.export_file",
+		config.Mempool.ExportFile,
+		"file the mempool txs are exported to on stop, and imported from on start (e.g. to migrate a node)")
 
 	// db flags
 	cmd.Flags().String(
@@ -105,6 +122,12 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 			if err := checkGenesisHash(config); err != nil {
 				return err
 			}
+			if replayFrom != 0 {
+				if replayFrom < 0 {
+					return fmt.Errorf("--replay-from=%d can't be negative", replayFrom)
+				}
+				config.Consensus.ReplayFromHeight = replayFrom
+			}
 
 			n, err := nodeProvider(config, logger)
 			if err != nil {
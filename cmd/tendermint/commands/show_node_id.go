@@ -1,14 +1,13 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	"github.com/tendermint/tendermint/p2p"
 )
 
-// ShowNodeIDCmd dumps node's ID to the standard output.
+// ShowNodeIDCmd dumps node's ID to the standard output, or {"id": ID} with
+// --output json.
 var ShowNodeIDCmd = &cobra.Command{
 	Use:   "show_node_id",
 	Short: "Show this node's ID",
@@ -20,7 +19,15 @@ func showNodeID(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	id := nodeKey.ID()
+	if err := nodeKey.Destroy(); err != nil {
+		return err
+	}
+
+	return printOutput(cmd, string(id), nodeIDOutput{ID: id})
+}
 
-	fmt.Println(nodeKey.ID())
-	return nil
+// nodeIDOutput is the JSON output of show_node_id and gen_node_key.
+type nodeIDOutput struct {
+	ID p2p.ID `json:"id"`
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/tendermint/tendermint/crypto"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/privval"
@@ -29,12 +30,20 @@ func showValidator(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("can't get pubkey: %w", err)
 	}
+	if err := pv.Destroy(); err != nil {
+		return fmt.Errorf("can't destroy private key: %w", err)
+	}
 
 	bz, err := tmjson.Marshal(pubKey)
 	if err != nil {
 		return fmt.Errorf("failed to marshal private validator pubkey: %w", err)
 	}
 
-	fmt.Println(string(bz))
-	return nil
+	return printOutput(cmd, string(bz), validatorOutput{Address: pubKey.Address(), PubKey: pubKey})
+}
+
+// validatorOutput is the JSON output of show_validator.
+type validatorOutput struct {
+	Address crypto.Address `json:"address"`
+	PubKey  crypto.PubKey  `json:"pub_key"`
 }
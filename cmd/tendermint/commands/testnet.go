@@ -15,8 +15,8 @@ import (
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/privval"
-	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/types/genesis"
 	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
@@ -121,7 +121,12 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	genVals := make([]types.GenesisValidator, nValidators)
+	genesisBuilder := genesis.NewBuilder("chain-" + tmrand.Str(6)).
+		GenesisTime(tmtime.Now()).
+		InitialHeight(initialHeight)
+	if keyType == "secp256k1" {
+		genesisBuilder.PubKeyTypes(types.ABCIPubKeyTypeSecp256k1)
+	}
 
 	for i := 0; i < nValidators; i++ {
 		nodeDirName := fmt.Sprintf("%s%d", nodeDirPrefix, i)
@@ -151,12 +156,7 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("can't get pubkey: %w", err)
 		}
-		genVals[i] = types.GenesisValidator{
-			Address: pubKey.Address(),
-			PubKey:  pubKey,
-			Power:   1,
-			Name:    nodeDirName,
-		}
+		genesisBuilder.AddValidator(pubKey, 1, nodeDirName)
 	}
 
 	for i := 0; i < nNonValidators; i++ {
@@ -181,17 +181,10 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 	}
 
 	// Generate genesis doc from generated validators
-	genDoc := &types.GenesisDoc{
-		ChainID:         "chain-" + tmrand.Str(6),
-		GenesisTime:     tmtime.Now(),
-		InitialHeight:   initialHeight,
-		Validators:      genVals,
-		ConsensusParams: types.DefaultConsensusParams(),
-	}
-	if keyType == "secp256k1" {
-		genDoc.ConsensusParams.Validator = tmproto.ValidatorParams{
-			PubKeyTypes: []string{types.ABCIPubKeyTypeSecp256k1},
-		}
+	genDoc, err := genesisBuilder.Build()
+	if err != nil {
+		_ = os.RemoveAll(outputDir)
+		return err
 	}
 
 	// Write genesis file.
@@ -204,10 +197,7 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 	}
 
 	// Gather persistent peer addresses.
-	var (
-		persistentPeers string
-		err             error
-	)
+	var persistentPeers string
 	if populatePersistentPeers {
 		persistentPeers, err = persistentPeersString(config)
 		if err != nil {
@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	dbm "github.com/tendermint/tm-db"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/p2p"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+// ExportValidatorsCmd writes the fork bundle of a height to a file or the
+// standard output.
+var ExportValidatorsCmd = &cobra.Command{
+	Use:   "export-validators [file]",
+	Short: "Export the validator set, consensus params and app hash at a height, to fork the chain",
+	Long: `Export the validator set, consensus params and app hash of the chain once the
+block at --height is committed (to stdout if no file is given), signed with the
+node key, to seed the genesis of a fork of the chain starting at the next height
+with import-validators. The app state must be exported by the app. The node must
+not be running.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: exportValidators,
+}
+
+// ImportValidatorsCmd applies a fork bundle to the genesis file.
+var ImportValidatorsCmd = &cobra.Command{
+	Use:   "import-validators <file>",
+	Short: "Seed the genesis of a fork of a chain with the output of export-validators",
+	Long: `Verify the bundle written by export-validators, then set its validators,
+consensus params and app hash, and the next height as the initial height, in the
+genesis file (created if missing). The bundle must be signed by the node given
+with --signer, unless --skip-signer-verification is set.`,
+	Args: cobra.ExactArgs(1),
+	RunE: importValidators,
+}
+
+var (
+	exportValidatorsHeight int64
+	forkSigner             string
+	skipSignerVerification bool
+	forkChainID            string
+)
+
+func init() {
+	ExportValidatorsCmd.Flags().Int64Var(&exportValidatorsHeight, "height", 0,
+		"height of the last block of the chain before the fork (0 - the height of the state)")
+	ImportValidatorsCmd.Flags().StringVar(&forkSigner, "signer", "",
+		"ID of the node which must have signed the bundle")
+	ImportValidatorsCmd.Flags().BoolVar(&skipSignerVerification, "skip-signer-verification", false,
+		"accept the bundle whoever signed it")
+	ImportValidatorsCmd.Flags().StringVar(&forkChainID, "chain-id", "",
+		"chain ID of the fork (default: the chain ID of the genesis file, or of the bundle if missing)")
+}
+
+func exportValidators(cmd *cobra.Command, args []string) error {
+	stateDB, err := dbm.NewDB("state", dbm.BackendType(config.DBBackend), config.DBDirFor("state"))
+	if err != nil {
+		return err
+	}
+	defer stateDB.Close()
+	stateStore := sm.NewStore(stateDB)
+	state, err := stateStore.Load()
+	if err != nil {
+		return err
+	}
+
+	height := exportValidatorsHeight
+	if height == 0 {
+		height = state.LastBlockHeight
+	}
+	if height <= 0 || height > state.LastBlockHeight {
+		return fmt.Errorf("height %d isn't committed (last height %d)", height, state.LastBlockHeight)
+	}
+
+	vals, err := stateStore.LoadValidators(height + 1)
+	if err != nil {
+		return fmt.Errorf("failed to load the validators: %w", err)
+	}
+	params, err := stateStore.LoadConsensusParams(height + 1)
+	if err != nil {
+		return fmt.Errorf("failed to load the consensus params: %w", err)
+	}
+	appHash := state.AppHash
+	if height < state.LastBlockHeight {
+		// the app hash after height is in the header of the next block
+		blockStoreDB, err := dbm.NewDB("blockstore", dbm.BackendType(config.DBBackend), config.DBDirFor("blockstore"))
+		if err != nil {
+			return err
+		}
+		defer blockStoreDB.Close()
+		meta := store.NewBlockStore(blockStoreDB).LoadBlockMeta(height + 1)
+		if meta == nil {
+			return fmt.Errorf("block %d isn't in the block store", height+1)
+		}
+		appHash = meta.Header.AppHash
+	}
+
+	bundle := types.NewForkBundle(state.ChainID, height, appHash, vals, params)
+	nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	if err != nil {
+		return err
+	}
+	err = bundle.Sign(nodeKey.PrivKey)
+	if dErr := nodeKey.Destroy(); err == nil {
+		err = dErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to sign the bundle: %w", err)
+	}
+
+	if len(args) == 0 {
+		bz, err := tmjson.MarshalIndent(bundle, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(os.Stdout, string(bz))
+		return err
+	}
+	if err := bundle.SaveAs(args[0]); err != nil {
+		return err
+	}
+	logger.Info("Exported validators", "height", height, "validators", len(bundle.Validators), "file", args[0])
+	return nil
+}
+
+func importValidators(cmd *cobra.Command, args []string) error {
+	bundle, err := types.ForkBundleFromFile(args[0])
+	if err != nil {
+		return err
+	}
+	if err := bundle.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid bundle: %w", err)
+	}
+	switch {
+	case forkSigner != "":
+		signer, err := hex.DecodeString(forkSigner)
+		if err != nil {
+			return fmt.Errorf("invalid --signer: %w", err)
+		}
+		if err := bundle.VerifySigner(signer); err != nil {
+			return err
+		}
+	case !skipSignerVerification:
+		return errors.New("--signer or --skip-signer-verification must be set")
+	}
+
+	genFile := config.GenesisFile()
+	genDoc := &types.GenesisDoc{ChainID: bundle.ChainID, GenesisTime: tmtime.Now()}
+	if tmos.FileExists(genFile) {
+		if genDoc, err = types.GenesisDocFromFile(genFile); err != nil {
+			return err
+		}
+	}
+	if forkChainID != "" {
+		genDoc.ChainID = forkChainID
+	}
+	bundle.ApplyToGenesis(genDoc)
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return fmt.Errorf("invalid genesis: %w", err)
+	}
+	if err := genDoc.SaveAs(genFile); err != nil {
+		return err
+	}
+	logger.Info("Imported validators", "chain_id", genDoc.ChainID, "initial_height", genDoc.InitialHeight,
+		"validators", len(genDoc.Validators), "genesis", genFile)
+	return nil
+}
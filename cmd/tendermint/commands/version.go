@@ -1,18 +1,30 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	"github.com/tendermint/tendermint/version"
 )
 
-// VersionCmd ...
+// VersionCmd shows the version of Tendermint Core, and of the protocols with
+// --output json.
 var VersionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version info",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println(version.TMCoreSemVer)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printOutput(cmd, version.TMCoreSemVer, versionOutput{
+			TMCore:        version.TMCoreSemVer,
+			ABCI:          version.ABCIVersion,
+			BlockProtocol: version.BlockProtocol,
+			P2PProtocol:   version.P2PProtocol,
+		})
 	},
 }
+
+// versionOutput is the JSON output of version.
+type versionOutput struct {
+	TMCore        string `json:"tendermint"`
+	ABCI          string `json:"abci"`
+	BlockProtocol uint64 `json:"block_protocol"`
+	P2PProtocol   uint64 `json:"p2p_protocol"`
+}
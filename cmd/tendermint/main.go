@@ -14,6 +14,11 @@ import (
 func main() {
 	rootCmd := cmd.RootCmd
 	rootCmd.AddCommand(
+		cmd.AddrBookCmd,
+		cmd.ExportBlocksCmd,
+		cmd.ImportBlocksCmd,
+		cmd.ExportValidatorsCmd,
+		cmd.ImportValidatorsCmd,
 		cmd.GenValidatorCmd,
 		cmd.InitFilesCmd,
 		cmd.ProbeUpnpCmd,
@@ -21,14 +26,18 @@ func main() {
 		cmd.ReplayCmd,
 		cmd.ReplayConsoleCmd,
 		cmd.ResetAllCmd,
+		cmd.ResetBlockStoreCmd,
+		cmd.ResetStateCmd,
+		cmd.ResetPeersCmd,
 		cmd.ResetPrivValidatorCmd,
 		cmd.ShowValidatorCmd,
 		cmd.TestnetFilesCmd,
 		cmd.ShowNodeIDCmd,
 		cmd.GenNodeKeyCmd,
+		cmd.GenP2PTLSCertCmd,
 		cmd.VersionCmd,
 		debug.DebugCmd,
-		cli.NewCompletionCmd(rootCmd, true),
+		cli.NewCompletionCmd(rootCmd, false),
 	)
 
 	// NOTE:
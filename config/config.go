@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -20,6 +23,20 @@ const (
 	LogFormatPlain = "plain"
 	// LogFormatJSON is a format for json output
 	LogFormatJSON = "json"
+
+	// MempoolOrderFIFO reaps txs in the order they were added to the mempool
+	MempoolOrderFIFO = "fifo"
+	// MempoolOrderPriority reaps txs by decreasing gas price
+	MempoolOrderPriority = "priority"
+	// MempoolOrderSenderNonce reaps txs grouped by sender, by increasing nonce
+	MempoolOrderSenderNonce = "sender-nonce"
+
+	// BlockPartSelectionRandom sends the block parts a peer lacks in random
+	// order
+	BlockPartSelectionRandom = "random"
+	// BlockPartSelectionRarestFirst sends the block parts a peer lacks held by
+	// the fewest peers first
+	BlockPartSelectionRarestFirst = "rarest_first"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -49,6 +66,12 @@ var (
 
 	defaultNodeKeyPath  = filepath.Join(defaultConfigDir, defaultNodeKeyName)
 	defaultAddrBookPath = filepath.Join(defaultConfigDir, defaultAddrBookName)
+	defaultSnapshotDir  = filepath.Join(defaultDataDir, "snapshots")
+
+	defaultMempoolTxRelayFilePath = filepath.Join(defaultDataDir, "mempool_tx_relay.json")
+
+	defaultP2PTLSCertPath = filepath.Join(defaultConfigDir, "p2p_tls_cert.pem")
+	defaultP2PTLSKeyPath  = filepath.Join(defaultConfigDir, "p2p_tls_key.pem")
 )
 
 // Config defines the top level configuration for a Tendermint node
@@ -62,9 +85,12 @@ type Config struct {
 	Mempool         *MempoolConfig         `mapstructure:"mempool"`
 	StateSync       *StateSyncConfig       `mapstructure:"statesync"`
 	FastSync        *FastSyncConfig        `mapstructure:"fastsync"`
+	Replica         *ReplicaConfig         `mapstructure:"replica"`
 	Consensus       *ConsensusConfig       `mapstructure:"consensus"`
 	TxIndex         *TxIndexConfig         `mapstructure:"tx_index"`
 	Instrumentation *InstrumentationConfig `mapstructure:"instrumentation"`
+	Alerts          *AlertsConfig          `mapstructure:"alerts"`
+	Operator        *OperatorConfig        `mapstructure:"operator"`
 }
 
 // DefaultConfig returns a default configuration for a Tendermint node
@@ -76,9 +102,12 @@ func DefaultConfig() *Config {
 		Mempool:         DefaultMempoolConfig(),
 		StateSync:       DefaultStateSyncConfig(),
 		FastSync:        DefaultFastSyncConfig(),
+		Replica:         DefaultReplicaConfig(),
 		Consensus:       DefaultConsensusConfig(),
 		TxIndex:         DefaultTxIndexConfig(),
 		Instrumentation: DefaultInstrumentationConfig(),
+		Alerts:          DefaultAlertsConfig(),
+		Operator:        DefaultOperatorConfig(),
 	}
 }
 
@@ -91,9 +120,12 @@ func TestConfig() *Config {
 		Mempool:         TestMempoolConfig(),
 		StateSync:       TestStateSyncConfig(),
 		FastSync:        TestFastSyncConfig(),
+		Replica:         TestReplicaConfig(),
 		Consensus:       TestConsensusConfig(),
 		TxIndex:         TestTxIndexConfig(),
 		Instrumentation: TestInstrumentationConfig(),
+		Alerts:          TestAlertsConfig(),
+		Operator:        TestOperatorConfig(),
 	}
 }
 
@@ -104,6 +136,7 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.P2P.RootDir = root
 	cfg.Mempool.RootDir = root
 	cfg.Consensus.RootDir = root
+	cfg.StateSync.RootDir = root
 	return cfg
 }
 
@@ -128,12 +161,24 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.FastSync.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [fastsync] section: %w", err)
 	}
+	if err := cfg.Replica.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [replica] section: %w", err)
+	}
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [tx_index] section: %w", err)
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
+	if err := cfg.Alerts.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [alerts] section: %w", err)
+	}
+	if err := cfg.Operator.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [operator] section: %w", err)
+	}
 	return nil
 }
 
@@ -185,12 +230,65 @@ type BaseConfig struct { //nolint: maligned
 	// Database directory
 	DBPath string `mapstructure:"db_dir"`
 
+	// Directories of the blockstore, state and tx_index databases, e.g. to keep
+	// the blockstore on a cheaper disk. Empty means db_dir. The node refuses to
+	// start if a database is still in the former directory: move it first.
+	BlockStoreDBPath string `mapstructure:"blockstore_db_dir"`
+	StateDBPath      string `mapstructure:"state_db_dir"`
+	TxIndexDBPath    string `mapstructure:"tx_index_db_dir"`
+
+	// Daily off-peak window, in UTC (e.g. "02:00-04:00"), during which the
+	// goleveldb databases are compacted, reclaiming the disk space freed by
+	// pruning. Empty disables the compaction.
+	DBCompactionWindow string `mapstructure:"db_compaction_window"`
+
+	// Minimum interval between two compactions.
+	DBCompactionInterval time.Duration `mapstructure:"db_compaction_interval"`
+
+	// Free space of the disk of the data directory, or of one of the *_db_dir
+	// directories, in MB, below which the databases are compacted and the
+	// snapshot archive is pruned down to the latest snapshot, every 10 minutes at
+	// most (0 - disabled).
+	DiskLowFreeMB int64 `mapstructure:"disk_low_free_mb"`
+
+	// Free space, in MB, below which the node also stops accepting and dialing
+	// new peers, until it's back above disk_low_free_mb, rather than letting the
+	// databases run out of space (0 - disabled).
+	DiskCriticalFreeMB int64 `mapstructure:"disk_critical_free_mb"`
+
+	// Number of heights for which the state store keeps the validator sets and
+	// the consensus params in memory, saving the database reads of the block
+	// verifications and RPC calls (0 - disabled).
+	StateStoreCacheSize int `mapstructure:"state_store_cache_size"`
+
+	// Parts of the ABCI responses kept, compactly, when the app prunes them
+	// (see ResponseCommit.RetainHeight) and returned by /block_results: the
+	// events of the listed types, and the validator and consensus param
+	// updates if "validator_updates" and "consensus_param_updates" are listed.
+	PruningExemptions []string `mapstructure:"pruning_exemptions"`
+
+	// Number of latest blocks whose block parts (i.e. transactions) are kept
+	// by the blockstore, the headers and commits of the older blocks being kept
+	// until the app prunes them (0 - all of them). The parts are pruned in the
+	// background once a block is committed, so only the blocks the app already
+	// committed are pruned: if the app loses its state, the node can't replay
+	// them to it on restart. It must also cover the blocks not yet indexed, and
+	// the ones the peers fast sync from this node.
+	BlockPartsRetainHeights int64 `mapstructure:"block_parts_retain_heights"`
+
 	// Output level for logging
 	LogLevel string `mapstructure:"log_level"`
 
 	// Output format: 'plain' (colored text) or 'json'
 	LogFormat string `mapstructure:"log_format"`
 
+	// Only 1 of every log_sample_rate occurrences of the log_sampled_messages
+	// is logged, after the first one of each log_sample_window, e.g. to make
+	// the p2p debug logs usable on busy nodes. 0 or 1 logs all of them.
+	LogSampleRate      int           `mapstructure:"log_sample_rate"`
+	LogSampleWindow    time.Duration `mapstructure:"log_sample_window"`
+	LogSampledMessages []string      `mapstructure:"log_sampled_messages"`
+
 	// Path to the JSON file containing the initial validator set and other meta data
 	Genesis string `mapstructure:"genesis_file"`
 
@@ -202,14 +300,31 @@ type BaseConfig struct { //nolint: maligned
 
 	// TCP or UNIX socket address for Tendermint to listen on for
 	// connections from an external PrivValidator process
+	// or, prefixed with grpc://, the address of the gRPC server of the external
+	// PrivValidator process to dial
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
 
+	// Certificate authority used to verify the gRPC server of the external
+	// PrivValidator process, and the certificate and key this node presents to
+	// it. If empty, the connection isn't encrypted, so the process must be on
+	// the same host or on a private network.
+	PrivValidatorGRPCCAFile   string `mapstructure:"priv_validator_grpc_ca_file"`
+	PrivValidatorGRPCCertFile string `mapstructure:"priv_validator_grpc_cert_file"`
+	PrivValidatorGRPCKeyFile  string `mapstructure:"priv_validator_grpc_key_file"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node_key_file"`
 
 	// Mechanism to connect to the ABCI application: socket | grpc
 	ABCI string `mapstructure:"abci"`
 
+	// Maximum time the async requests to the app are buffered before being
+	// flushed, on the consensus and the mempool connections of the socket
+	// transport (0 - the client's default), so that block execution isn't
+	// delayed by the batching of the CheckTx requests.
+	ABCIConsensusFlushThrottle time.Duration `mapstructure:"abci_consensus_flush_throttle"`
+	ABCIMempoolFlushThrottle   time.Duration `mapstructure:"abci_mempool_flush_throttle"`
+
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter_peers"` // false
@@ -227,10 +342,19 @@ func DefaultBaseConfig() BaseConfig {
 		ABCI:               "socket",
 		LogLevel:           DefaultPackageLogLevels(),
 		LogFormat:          LogFormatPlain,
+		LogSampleRate:      0,
+		LogSampleWindow:    time.Second,
+		LogSampledMessages: DefaultLogSampledMessages(),
 		FastSyncMode:       true,
 		FilterPeers:        false,
 		DBBackend:          "goleveldb",
 		DBPath:             "data",
+
+		DBCompactionWindow:   "",
+		DBCompactionInterval: 24 * time.Hour,
+		DiskLowFreeMB:        0,
+		DiskCriticalFreeMB:   0,
+		StateStoreCacheSize:  100,
 	}
 }
 
@@ -263,6 +387,33 @@ func (cfg BaseConfig) PrivValidatorStateFile() string {
 	return rootify(cfg.PrivValidatorState, cfg.RootDir)
 }
 
+// PrivValidatorGRPCCAPath returns the full path to the CA file of the gRPC
+// remote signer, or "" if not set.
+func (cfg BaseConfig) PrivValidatorGRPCCAPath() string {
+	if cfg.PrivValidatorGRPCCAFile == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorGRPCCAFile, cfg.RootDir)
+}
+
+// PrivValidatorGRPCCertPath returns the full path to the certificate file
+// presented to the gRPC remote signer, or "" if not set.
+func (cfg BaseConfig) PrivValidatorGRPCCertPath() string {
+	if cfg.PrivValidatorGRPCCertFile == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorGRPCCertFile, cfg.RootDir)
+}
+
+// PrivValidatorGRPCKeyPath returns the full path to the key file of the
+// certificate presented to the gRPC remote signer, or "" if not set.
+func (cfg BaseConfig) PrivValidatorGRPCKeyPath() string {
+	if cfg.PrivValidatorGRPCKeyFile == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorGRPCKeyFile, cfg.RootDir)
+}
+
 // NodeKeyFile returns the full path to the node_key.json file
 func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
@@ -273,6 +424,35 @@ func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
 }
 
+// DBDirFor returns the full path to the directory of the database with the
+// given ID (e.g. "blockstore"), DBDir unless another directory is set for it.
+func (cfg BaseConfig) DBDirFor(id string) string {
+	if path := cfg.dbPaths()[id]; path != "" {
+		return rootify(path, cfg.RootDir)
+	}
+	return cfg.DBDir()
+}
+
+// SeparateDBDirs returns the full paths to the directories set for the
+// databases stored outside of DBDir, by database ID.
+func (cfg BaseConfig) SeparateDBDirs() map[string]string {
+	dirs := make(map[string]string)
+	for id, path := range cfg.dbPaths() {
+		if path != "" && rootify(path, cfg.RootDir) != cfg.DBDir() {
+			dirs[id] = rootify(path, cfg.RootDir)
+		}
+	}
+	return dirs
+}
+
+func (cfg BaseConfig) dbPaths() map[string]string {
+	return map[string]string{
+		"blockstore": cfg.BlockStoreDBPath,
+		"state":      cfg.StateDBPath,
+		"tx_index":   cfg.TxIndexDBPath,
+	}
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg BaseConfig) ValidateBasic() error {
@@ -281,9 +461,95 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+	if cfg.LogSampleRate < 0 {
+		return errors.New("log_sample_rate can't be negative")
+	}
+	if cfg.LogSampleRate > 1 && cfg.LogSampleWindow <= 0 {
+		return errors.New("log_sample_window must be positive")
+	}
+	for _, msg := range cfg.LogSampledMessages {
+		if msg == "" {
+			return errors.New("found empty log_sampled_messages entry")
+		}
+	}
+	if cfg.DBCompactionWindow != "" {
+		if _, _, err := cfg.DBCompactionWindowBounds(); err != nil {
+			return err
+		}
+	}
+	if cfg.DBCompactionInterval < 0 {
+		return errors.New("db_compaction_interval can't be negative")
+	}
+	if cfg.DiskLowFreeMB < 0 {
+		return errors.New("disk_low_free_mb can't be negative")
+	}
+	if cfg.DiskCriticalFreeMB < 0 {
+		return errors.New("disk_critical_free_mb can't be negative")
+	}
+	if cfg.DiskCriticalFreeMB > cfg.DiskLowFreeMB {
+		return errors.New("disk_critical_free_mb can't be greater than disk_low_free_mb")
+	}
+	if cfg.StateStoreCacheSize < 0 {
+		return errors.New("state_store_cache_size can't be negative")
+	}
+	for _, exemption := range cfg.PruningExemptions {
+		if exemption == "" {
+			return errors.New("found empty pruning_exemptions entry")
+		}
+	}
+	if cfg.BlockPartsRetainHeights < 0 {
+		return errors.New("block_parts_retain_heights can't be negative")
+	}
+	if cfg.ABCIConsensusFlushThrottle < 0 {
+		return errors.New("abci_consensus_flush_throttle can't be negative")
+	}
+	if cfg.ABCIMempoolFlushThrottle < 0 {
+		return errors.New("abci_mempool_flush_throttle can't be negative")
+	}
+	if (cfg.PrivValidatorGRPCCertFile == "") != (cfg.PrivValidatorGRPCKeyFile == "") {
+		return errors.New("priv_validator_grpc_cert_file and priv_validator_grpc_key_file must be set together")
+	}
+	if cfg.PrivValidatorGRPCCertFile != "" && cfg.PrivValidatorGRPCCAFile == "" {
+		return errors.New("priv_validator_grpc_cert_file requires priv_validator_grpc_ca_file")
+	}
 	return nil
 }
 
+// DBCompactionWindowBounds returns the start and end of the compaction window,
+// as durations since midnight UTC.
+func (cfg BaseConfig) DBCompactionWindowBounds() (start, end time.Duration, err error) {
+	bounds := strings.Split(cfg.DBCompactionWindow, "-")
+	if len(bounds) != 2 {
+		return 0, 0, fmt.Errorf("db_compaction_window should be HH:MM-HH:MM, got %q", cfg.DBCompactionWindow)
+	}
+	parse := func(s string) (time.Duration, error) {
+		t, err := time.Parse("15:04", strings.TrimSpace(s))
+		if err != nil {
+			return 0, fmt.Errorf("invalid db_compaction_window bound %q: %w", s, err)
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+	}
+	if start, err = parse(bounds[0]); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parse(bounds[1]); err != nil {
+		return 0, 0, err
+	}
+	if start == end {
+		return 0, 0, errors.New("db_compaction_window can't be empty")
+	}
+	return start, end, nil
+}
+
+// DefaultLogSampledMessages returns the messages sampled by default when
+// log_sample_rate is set: the debug messages logged for each p2p message.
+func DefaultLogSampledMessages() []string {
+	return []string{
+		"Send", "Send failed", "TrySend", "Flush", "Broadcast", "Received bytes", "Read PacketMsg",
+		"Receive", "Dropping duplicate message",
+	}
+}
+
 // DefaultLogLevel returns a default log level of "error"
 func DefaultLogLevel() string {
 	return "error"
@@ -328,6 +594,22 @@ type RPCConfig struct {
 	// 0 - unlimited.
 	GRPCMaxOpenConnections int `mapstructure:"grpc_max_open_connections"`
 
+	// After this duration without any activity, the gRPC server pings the
+	// client to check the connection is still alive.
+	GRPCKeepaliveTime time.Duration `mapstructure:"grpc_keepalive_time"`
+
+	// How long the gRPC server waits for a keepalive ping to be acknowledged
+	// before closing the connection.
+	GRPCKeepaliveTimeout time.Duration `mapstructure:"grpc_keepalive_timeout"`
+
+	// Minimum time between keepalive pings sent by gRPC clients. Clients
+	// pinging more often are disconnected.
+	GRPCKeepaliveMinTime time.Duration `mapstructure:"grpc_keepalive_min_time"`
+
+	// Enable the gRPC server reflection service, which lets clients (e.g.
+	// grpcurl) discover the available services.
+	GRPCReflection bool `mapstructure:"grpc_reflection"`
+
 	// Activate unsafe RPC commands like /dial_persistent_peers and /unsafe_flush_mempool
 	Unsafe bool `mapstructure:"unsafe"`
 
@@ -350,18 +632,52 @@ type RPCConfig struct {
 	// to the estimated maximum number of broadcast_tx_commit calls per block.
 	MaxSubscriptionsPerClient int `mapstructure:"max_subscriptions_per_client"`
 
+	// Maximum number of queries a single /subscribe call can combine with OR
+	// (see the queries param), each matched against every published event.
+	// 0 - unlimited.
+	MaxQueriesPerSubscription int `mapstructure:"max_queries_per_subscription"`
+
+	// Maximum number of simultaneous WebSocket connections.
+	// 0 - unlimited (bounded by max_open_connections).
+	MaxWebsocketConnections int `mapstructure:"max_websocket_connections"`
+
+	// Maximum number of events per second sent to a given client (i.e.
+	// WebSocket connection), over all of its subscriptions. Further events
+	// are dropped, and the client is notified with a "Quota exceeded" error.
+	// 0 - unlimited.
+	MaxEventsPerSecondPerClient int `mapstructure:"max_events_per_second_per_client"`
+
+	// How often a keepalive comment is sent to the /subscribe_sse clients,
+	// so that proxies don't close idle streams.
+	// 0 - no keepalives.
+	SSEKeepAliveInterval time.Duration `mapstructure:"sse_keepalive_interval"`
+
 	// How long to wait for a tx to be committed during /broadcast_tx_commit
 	// WARNING: Using a value larger than 10s will result in increasing the
 	// global HTTP write timeout, which applies to all connections and endpoints.
 	// See https://github.com/tendermint/tendermint/issues/3435
 	TimeoutBroadcastTxCommit time.Duration `mapstructure:"timeout_broadcast_tx_commit"`
 
+	// Maximum number of idempotency keys of /broadcast_tx_* requests
+	// remembered, along with their results, so that the retries of a request
+	// get the original result instead of submitting the tx again.
+	// 0 - disables the idempotency keys.
+	IdempotencyCacheSize int `mapstructure:"idempotency_cache_size"`
+
+	// How long an idempotency key is remembered after its request completed.
+	IdempotencyKeyTTL time.Duration `mapstructure:"idempotency_key_ttl"`
+
 	// Maximum size of request body, in bytes
 	MaxBodyBytes int64 `mapstructure:"max_body_bytes"`
 
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max_header_bytes"`
 
+	// Maximum size of the cache of responses to queries for immutable data
+	// (/block, /block_results, /commit and /validators at finalized heights),
+	// in bytes. 0 disables the cache.
+	ResponseCacheSize int64 `mapstructure:"response_cache_size"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Migth be either absolute path or path related to tendermint's config directory.
 	//
@@ -393,17 +709,30 @@ func DefaultRPCConfig() *RPCConfig {
 		CORSAllowedHeaders:     []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time"},
 		GRPCListenAddress:      "",
 		GRPCMaxOpenConnections: 900,
+		GRPCKeepaliveTime:      2 * time.Hour,
+		GRPCKeepaliveTimeout:   20 * time.Second,
+		GRPCKeepaliveMinTime:   5 * time.Minute,
+		GRPCReflection:         false,
 
 		Unsafe:             false,
 		MaxOpenConnections: 900,
 
 		MaxSubscriptionClients:    100,
 		MaxSubscriptionsPerClient: 5,
+		MaxQueriesPerSubscription: 100,
 		TimeoutBroadcastTxCommit:  10 * time.Second,
+		IdempotencyCacheSize:      10000,
+		IdempotencyKeyTTL:         10 * time.Minute,
+
+		MaxWebsocketConnections:     0,
+		MaxEventsPerSecondPerClient: 0,
+		SSEKeepAliveInterval:        15 * time.Second,
 
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		ResponseCacheSize: 0,
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
 	}
@@ -424,6 +753,15 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.GRPCMaxOpenConnections < 0 {
 		return errors.New("grpc_max_open_connections can't be negative")
 	}
+	if cfg.GRPCKeepaliveTime < 0 {
+		return errors.New("grpc_keepalive_time can't be negative")
+	}
+	if cfg.GRPCKeepaliveTimeout < 0 {
+		return errors.New("grpc_keepalive_timeout can't be negative")
+	}
+	if cfg.GRPCKeepaliveMinTime < 0 {
+		return errors.New("grpc_keepalive_min_time can't be negative")
+	}
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max_open_connections can't be negative")
 	}
@@ -433,15 +771,36 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxSubscriptionsPerClient < 0 {
 		return errors.New("max_subscriptions_per_client can't be negative")
 	}
+	if cfg.MaxQueriesPerSubscription < 0 {
+		return errors.New("max_queries_per_subscription can't be negative")
+	}
+	if cfg.MaxWebsocketConnections < 0 {
+		return errors.New("max_websocket_connections can't be negative")
+	}
+	if cfg.MaxEventsPerSecondPerClient < 0 {
+		return errors.New("max_events_per_second_per_client can't be negative")
+	}
+	if cfg.SSEKeepAliveInterval < 0 {
+		return errors.New("sse_keepalive_interval can't be negative")
+	}
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return errors.New("timeout_broadcast_tx_commit can't be negative")
 	}
+	if cfg.IdempotencyCacheSize < 0 {
+		return errors.New("idempotency_cache_size can't be negative")
+	}
+	if cfg.IdempotencyKeyTTL < 0 {
+		return errors.New("idempotency_key_ttl can't be negative")
+	}
 	if cfg.MaxBodyBytes < 0 {
 		return errors.New("max_body_bytes can't be negative")
 	}
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max_header_bytes can't be negative")
 	}
+	if cfg.ResponseCacheSize < 0 {
+		return errors.New("response_cache_size can't be negative")
+	}
 	return nil
 }
 
@@ -509,9 +868,58 @@ type P2PConfig struct { //nolint: maligned
 	// List of node IDs, to which a connection will be (re)established ignoring any existing limits
 	UnconditionalPeerIDs string `mapstructure:"unconditional_peer_ids"`
 
+	// Comma separated list of node IDs of the high-priority peers, e.g. the
+	// other validators and sentries. They are unconditional peers, never
+	// disconnected for slot pressure (including by seed nodes), get send queues
+	// PrioritySendQueueFactor times larger, and, if persistent, are reconnected
+	// to every PriorityReconnectInterval without ever giving up.
+	PriorityPeerIDs           string        `mapstructure:"priority_peer_ids"`
+	PrioritySendQueueFactor   int           `mapstructure:"priority_send_queue_factor"`
+	PriorityReconnectInterval time.Duration `mapstructure:"priority_reconnect_interval"`
+
+	// Comma separated list of node IDs allowed to connect to this node (if
+	// empty, all peers are allowed), and of node IDs denied. They apply to all
+	// peers, including the persistent and unconditional ones, and can be
+	// reloaded at runtime via the unsafe /set_peer_lists RPC endpoint.
+	AllowedPeers string `mapstructure:"allowed_peers"`
+	DeniedPeers  string `mapstructure:"denied_peers"`
+
 	// Maximum pause when redialing a persistent peer (if zero, exponential backoff is used)
 	PersistentPeersMaxDialPeriod time.Duration `mapstructure:"persistent_peers_max_dial_period"`
 
+	// Reconnection to a persistent peer: first reconnect_attempts attempts,
+	// reconnect_interval apart, then reconnect_backoff_attempts attempts with
+	// an exponential backoff, starting at reconnect_backoff_interval and
+	// multiplied by reconnect_backoff_multiplier after each attempt. A random
+	// delay of up to dial_jitter is added to each pause, and before dialing any
+	// peer.
+	ReconnectAttempts          int           `mapstructure:"reconnect_attempts"`
+	ReconnectInterval          time.Duration `mapstructure:"reconnect_interval"`
+	ReconnectBackoffAttempts   int           `mapstructure:"reconnect_backoff_attempts"`
+	ReconnectBackoffInterval   time.Duration `mapstructure:"reconnect_backoff_interval"`
+	ReconnectBackoffMultiplier float64       `mapstructure:"reconnect_backoff_multiplier"`
+	DialJitter                 time.Duration `mapstructure:"dial_jitter"`
+
+	// Maximum number of attempts to reconnect to a persistent peer, after
+	// which it's reported as unreachable (0 - reconnect_attempts +
+	// reconnect_backoff_attempts). It can still be redialed via the unsafe
+	// /dial_peers RPC endpoint.
+	PersistentPeersMaxReconnectAttempts int `mapstructure:"persistent_peers_max_reconnect_attempts"`
+
+	// Maximum number of times a given peer is dialed per hour, so that a
+	// flapping peer doesn't monopolize the dialer (0 - unlimited).
+	MaxDialsPerPeerPerHour int `mapstructure:"max_dials_per_peer_per_hour"`
+
+	// Minimum versions of the block, p2p and app protocols of the peers (0 -
+	// any version). The peers below them are rejected, unless
+	// MinPeerVersionWarnOnly is set, in which case they are only logged and
+	// counted by the peers_below_min_version metric. Note the block version
+	// of the peers must already match ours.
+	MinPeerBlockVersion    uint64 `mapstructure:"min_peer_block_version"`
+	MinPeerP2PVersion      uint64 `mapstructure:"min_peer_p2p_version"`
+	MinPeerAppVersion      uint64 `mapstructure:"min_peer_app_version"`
+	MinPeerVersionWarnOnly bool   `mapstructure:"min_peer_version_warn_only"`
+
 	// Time to wait before flushing messages out on the connection
 	FlushThrottleTimeout time.Duration `mapstructure:"flush_throttle_timeout"`
 
@@ -540,13 +948,93 @@ type P2PConfig struct { //nolint: maligned
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow_duplicate_ip"`
 
-	// Peer connection configuration.
+	// Peer connection configuration. The handshake timeout applies to each
+	// step of the handshake (key exchange, then NodeInfo exchange).
 	HandshakeTimeout time.Duration `mapstructure:"handshake_timeout"`
 	DialTimeout      time.Duration `mapstructure:"dial_timeout"`
 
+	// Maximum number of incoming connections being handshaked at the same
+	// time. Further connections are closed right away, so that a flood of slow
+	// handshakes can't exhaust the file descriptors.
+	// 0 - unlimited.
+	MaxConcurrentHandshakes int `mapstructure:"max_concurrent_handshakes"`
+
+	// Maximum number of incoming connections accepted from a given IP per
+	// second. Further connections are closed right away.
+	// 0 - unlimited.
+	MaxAcceptRatePerIP int `mapstructure:"max_accept_rate_per_ip"`
+
+	// Rotate the keys of the encrypted peer connections, with a fresh key
+	// exchange, after sending this many bytes or after this interval
+	// (0 - disabled). Peers which don't support rekeying drop the connection.
+	SecretConnRekeyBytes    int64         `mapstructure:"secret_conn_rekey_bytes"`
+	SecretConnRekeyInterval time.Duration `mapstructure:"secret_conn_rekey_interval"`
+
+	// Sign the messages sent on the channels listed in MessageAuthChannels with
+	// the node key, so that the misbehavior of a peer observed on those
+	// channels can be attributed to it by third parties, not just punished
+	// with a local ban. The messages are only signed for the peers which
+	// enabled it on the same channels.
+	MessageAuth bool `mapstructure:"message_auth"`
+	// Comma separated list of channel IDs (e.g. "0x20,0x21") whose messages
+	// are signed
+	MessageAuthChannels string `mapstructure:"message_auth_channels"`
+
+	// Run the connections over mutually-authenticated TLS, below the secret
+	// connections, only accepting the peers presenting one of the certificates
+	// listed in TLSPinnedCerts. The peers must enable it too.
+	TLS bool `mapstructure:"tls"`
+	// Certificate and key PEM files of the node, relative to the home
+	// directory (see the gen-p2p-tls-cert command)
+	TLSCertFile string `mapstructure:"tls_cert_file"`
+	TLSKeyFile  string `mapstructure:"tls_key_file"`
+	// Comma separated list of <node ID>@<SHA-256 fingerprint of the
+	// certificate> of the peers. A peer is only accepted if its certificate is
+	// listed, and pinned to its node ID.
+	TLSPinnedCerts string `mapstructure:"tls_pinned_certs"`
+
+	// Record the envelopes sent to and received from the peers (time,
+	// direction, peer, channel, message type and size) into this file,
+	// relative to the home directory, for debugging. The records are read
+	// with the debug capture command.
+	// Empty - disabled.
+	CaptureFile string `mapstructure:"capture_file"`
+	// Comma separated list of channel IDs (e.g. "0x20,0x21") whose envelopes
+	// are recorded. If empty, all channels are recorded.
+	CaptureChannels string `mapstructure:"capture_channels"`
+	// Comma separated list of IDs of the peers whose envelopes are recorded.
+	// If empty, all peers are recorded.
+	CapturePeerIDs string `mapstructure:"capture_peer_ids"`
+	// Record only 1 of every capture_sample_rate envelopes matching the
+	// filters above
+	CaptureSampleRate int `mapstructure:"capture_sample_rate"`
+	// Record the payloads of the envelopes too
+	CapturePayload bool `mapstructure:"capture_payload"`
+	// Maximum total size in bytes of the capture files. The file is rotated,
+	// and the oldest files are deleted beyond this size.
+	CaptureMaxSize int64 `mapstructure:"capture_max_size"`
+
 	// Testing params.
 	// Force dial to fail
 	TestDialFail bool `mapstructure:"test_dial_fail"`
+
+	// Inject faults into the messages sent to peers, to exercise the
+	// robustness of reactors. The faults are chosen using a RNG seeded with
+	// TestChaosSeed, so that runs can be reproduced. NEVER enable in production.
+	TestChaos bool `mapstructure:"test_chaos"`
+	// Seed of the RNG used to choose the faults
+	TestChaosSeed int64 `mapstructure:"test_chaos_seed"`
+	// Comma separated list of channel IDs (e.g. "0x20,0x21") to inject faults
+	// into. If empty, all channels are affected.
+	TestChaosChannels string `mapstructure:"test_chaos_channels"`
+	// Maximum latency added to every message
+	TestChaosMaxLatency time.Duration `mapstructure:"test_chaos_max_latency"`
+	// Probability for a message to be sent after the next one on the channel
+	TestChaosReorderRate float64 `mapstructure:"test_chaos_reorder_rate"`
+	// Probability for a message to be sent twice
+	TestChaosDuplicateRate float64 `mapstructure:"test_chaos_duplicate_rate"`
+	// Probability for a message to have a random bit flipped
+	TestChaosCorruptRate float64 `mapstructure:"test_chaos_corrupt_rate"`
 }
 
 // DefaultP2PConfig returns a default configuration for the peer-to-peer layer
@@ -560,6 +1048,20 @@ func DefaultP2PConfig() *P2PConfig {
 		MaxNumInboundPeers:           40,
 		MaxNumOutboundPeers:          10,
 		PersistentPeersMaxDialPeriod: 0 * time.Second,
+		ReconnectAttempts:            20,
+		ReconnectInterval:            5 * time.Second,
+		ReconnectBackoffAttempts:     10,
+		ReconnectBackoffInterval:     1 * time.Second,
+		ReconnectBackoffMultiplier:   3,
+		DialJitter:                   3 * time.Second,
+		MaxDialsPerPeerPerHour:       0,
+		PriorityPeerIDs:              "",
+		PrioritySendQueueFactor:      4,
+		PriorityReconnectInterval:    1 * time.Second,
+		MinPeerBlockVersion:          0,
+		MinPeerP2PVersion:            0,
+		MinPeerAppVersion:            0,
+		MinPeerVersionWarnOnly:       false,
 		FlushThrottleTimeout:         100 * time.Millisecond,
 		MaxPacketMsgPayloadSize:      1024,    // 1 kB
 		SendRate:                     5120000, // 5 mB/s
@@ -567,8 +1069,22 @@ func DefaultP2PConfig() *P2PConfig {
 		PexReactor:                   true,
 		SeedMode:                     false,
 		AllowDuplicateIP:             false,
-		HandshakeTimeout:             20 * time.Second,
-		DialTimeout:                  3 * time.Second,
+		HandshakeTimeout:             3 * time.Second,
+		DialTimeout:                  time.Second,
+		MaxConcurrentHandshakes:      50,
+		MaxAcceptRatePerIP:           10,
+		MessageAuth:                  false,
+		MessageAuthChannels:          "0x20,0x21,0x22,0x23", // consensus channels
+		TLS:                          false,
+		TLSCertFile:                  defaultP2PTLSCertPath,
+		TLSKeyFile:                   defaultP2PTLSKeyPath,
+		TLSPinnedCerts:               "",
+		CaptureFile:                  "",
+		CaptureChannels:              "",
+		CapturePeerIDs:               "",
+		CaptureSampleRate:            1,
+		CapturePayload:               false,
+		CaptureMaxSize:               104857600, // 100 MB
 		TestDialFail:                 false,
 	}
 }
@@ -602,6 +1118,36 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.PersistentPeersMaxDialPeriod < 0 {
 		return errors.New("persistent_peers_max_dial_period can't be negative")
 	}
+	if cfg.ReconnectAttempts < 0 {
+		return errors.New("reconnect_attempts can't be negative")
+	}
+	if cfg.ReconnectInterval < 0 {
+		return errors.New("reconnect_interval can't be negative")
+	}
+	if cfg.ReconnectBackoffAttempts < 0 {
+		return errors.New("reconnect_backoff_attempts can't be negative")
+	}
+	if cfg.ReconnectBackoffInterval < 0 {
+		return errors.New("reconnect_backoff_interval can't be negative")
+	}
+	if cfg.ReconnectBackoffMultiplier < 1 {
+		return errors.New("reconnect_backoff_multiplier can't be less than 1")
+	}
+	if cfg.DialJitter < 0 {
+		return errors.New("dial_jitter can't be negative")
+	}
+	if cfg.PersistentPeersMaxReconnectAttempts < 0 {
+		return errors.New("persistent_peers_max_reconnect_attempts can't be negative")
+	}
+	if cfg.MaxDialsPerPeerPerHour < 0 {
+		return errors.New("max_dials_per_peer_per_hour can't be negative")
+	}
+	if cfg.PrioritySendQueueFactor < 1 {
+		return errors.New("priority_send_queue_factor can't be less than 1")
+	}
+	if cfg.PriorityReconnectInterval <= 0 {
+		return errors.New("priority_reconnect_interval must be positive")
+	}
 	if cfg.MaxPacketMsgPayloadSize < 0 {
 		return errors.New("max_packet_msg_payload_size can't be negative")
 	}
@@ -611,9 +1157,144 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv_rate can't be negative")
 	}
+	if cfg.HandshakeTimeout <= 0 {
+		return errors.New("handshake_timeout must be positive")
+	}
+	if cfg.DialTimeout <= 0 {
+		return errors.New("dial_timeout must be positive")
+	}
+	if cfg.MaxConcurrentHandshakes < 0 {
+		return errors.New("max_concurrent_handshakes can't be negative")
+	}
+	if cfg.MaxAcceptRatePerIP < 0 {
+		return errors.New("max_accept_rate_per_ip can't be negative")
+	}
+	if cfg.SecretConnRekeyBytes < 0 {
+		return errors.New("secret_conn_rekey_bytes can't be negative")
+	}
+	if cfg.SecretConnRekeyInterval < 0 {
+		return errors.New("secret_conn_rekey_interval can't be negative")
+	}
+	if cfg.MessageAuth {
+		if _, err := cfg.MessageAuthChannelIDs(); err != nil {
+			return err
+		}
+	}
+	if cfg.TLS {
+		pins, err := cfg.TLSPinnedCertIDs()
+		if err != nil {
+			return err
+		}
+		if len(pins) == 0 {
+			return errors.New("tls_pinned_certs can't be empty if tls is enabled")
+		}
+	}
+	if cfg.CaptureFile != "" {
+		if _, err := cfg.CaptureChannelIDs(); err != nil {
+			return err
+		}
+		if cfg.CaptureSampleRate < 1 {
+			return errors.New("capture_sample_rate can't be less than 1")
+		}
+		if cfg.CaptureMaxSize <= 0 {
+			return errors.New("capture_max_size must be positive")
+		}
+	}
+	if cfg.TestChaos {
+		if _, err := cfg.TestChaosChannelIDs(); err != nil {
+			return err
+		}
+		if cfg.TestChaosMaxLatency < 0 {
+			return errors.New("test_chaos_max_latency can't be negative")
+		}
+		if cfg.TestChaosReorderRate < 0 || cfg.TestChaosReorderRate > 1 {
+			return errors.New("test_chaos_reorder_rate must be between 0 and 1")
+		}
+		if cfg.TestChaosDuplicateRate < 0 || cfg.TestChaosDuplicateRate > 1 {
+			return errors.New("test_chaos_duplicate_rate must be between 0 and 1")
+		}
+		if cfg.TestChaosCorruptRate < 0 || cfg.TestChaosCorruptRate > 1 {
+			return errors.New("test_chaos_corrupt_rate must be between 0 and 1")
+		}
+	}
 	return nil
 }
 
+// TestChaosChannelIDs returns the IDs of the channels listed in
+// TestChaosChannels.
+func (cfg *P2PConfig) TestChaosChannelIDs() ([]byte, error) {
+	return parseChannelIDs(cfg.TestChaosChannels, "test_chaos_channels")
+}
+
+// MessageAuthChannelIDs returns the IDs of the channels listed in
+// MessageAuthChannels.
+func (cfg *P2PConfig) MessageAuthChannelIDs() ([]byte, error) {
+	return parseChannelIDs(cfg.MessageAuthChannels, "message_auth_channels")
+}
+
+// CaptureChannelIDs returns the IDs of the channels listed in CaptureChannels.
+func (cfg *P2PConfig) CaptureChannelIDs() ([]byte, error) {
+	return parseChannelIDs(cfg.CaptureChannels, "capture_channels")
+}
+
+// CaptureFilePath returns the full path to the capture file.
+func (cfg *P2PConfig) CaptureFilePath() string {
+	return rootify(cfg.CaptureFile, cfg.RootDir)
+}
+
+// TLSCertPath returns the full path to the TLS certificate file.
+func (cfg *P2PConfig) TLSCertPath() string {
+	return rootify(cfg.TLSCertFile, cfg.RootDir)
+}
+
+// TLSKeyPath returns the full path to the TLS key file.
+func (cfg *P2PConfig) TLSKeyPath() string {
+	return rootify(cfg.TLSKeyFile, cfg.RootDir)
+}
+
+// TLSPinnedCertIDs returns the node IDs the certificates listed in
+// TLSPinnedCerts are pinned to, by fingerprint.
+func (cfg *P2PConfig) TLSPinnedCertIDs() (map[string]string, error) {
+	pins := make(map[string]string)
+	for _, s := range strings.Split(cfg.TLSPinnedCerts, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		parts := strings.Split(s, "@")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid pin %q in tls_pinned_certs, expected <node ID>@<fingerprint>", s)
+		}
+		id, fingerprint := strings.ToLower(parts[0]), strings.ToLower(parts[1])
+		if bz, err := hex.DecodeString(id); err != nil || len(bz) != 20 {
+			return nil, fmt.Errorf("invalid node ID %q in tls_pinned_certs", parts[0])
+		}
+		if bz, err := hex.DecodeString(fingerprint); err != nil || len(bz) != 32 {
+			return nil, fmt.Errorf("invalid certificate fingerprint %q in tls_pinned_certs", parts[1])
+		}
+		pins[fingerprint] = id
+	}
+	return pins, nil
+}
+
+// parseChannelIDs parses the comma separated list of channel IDs of the given
+// parameter.
+func parseChannelIDs(list, param string) ([]byte, error) {
+	var ids []byte
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(s, 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel ID %q in %s: %w", s, param, err)
+		}
+		ids = append(ids, byte(id))
+	}
+	return ids, nil
+}
+
 //-----------------------------------------------------------------------------
 // MempoolConfig
 
@@ -637,6 +1318,26 @@ type MempoolConfig struct {
 	// Maximum size of a batch of transactions to send to a peer
 	// Including space needed by encoding (one varint per transaction).
 	MaxBatchBytes int `mapstructure:"max_batch_bytes"`
+	// Minimum gas price (as reported by the app in ResponseCheckTx.GasPrice)
+	// a tx must pay to be admitted into the mempool. 0 disables the check.
+	MinGasPrice int64 `mapstructure:"min_gas_price"`
+	// If > 0, the minimum gas price is adjusted after each block, increasing
+	// when blocks use more than TargetBlockGas and decreasing (down to
+	// MinGasPrice) when they use less.
+	TargetBlockGas int64 `mapstructure:"target_block_gas"`
+	// Order in which txs are reaped into block proposals: "fifo", "priority"
+	// or "sender-nonce". See the mempool package documentation.
+	OrderBy string `mapstructure:"order_by"`
+	// Comma separated list of <category>:<max bytes>, limiting the total size
+	// of the txs of a category (as reported by the app in
+	// ResponseCheckTx.Category) reaped into a block proposal.
+	CategoryQuotas string `mapstructure:"category_quotas"`
+	// File the txs in the mempool are exported to when the node stops, and
+	// imported from (then removed) when it starts. Empty disables it.
+	ExportFile string `mapstructure:"export_file"`
+	// File the peers whose tx relay is disabled with /set_peer_tx_relay are
+	// persisted in. Empty disables the persistence.
+	TxRelayFile string `mapstructure:"tx_relay_file"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool
@@ -652,6 +1353,8 @@ func DefaultMempoolConfig() *MempoolConfig {
 		CacheSize:     10000,
 		MaxTxBytes:    1024 * 1024,      // 1MB
 		MaxBatchBytes: 10 * 1024 * 1024, // 10MB
+		OrderBy:       MempoolOrderFIFO,
+		TxRelayFile:   defaultMempoolTxRelayFilePath,
 	}
 }
 
@@ -659,6 +1362,7 @@ func DefaultMempoolConfig() *MempoolConfig {
 func TestMempoolConfig() *MempoolConfig {
 	cfg := DefaultMempoolConfig()
 	cfg.CacheSize = 1000
+	cfg.TxRelayFile = ""
 	return cfg
 }
 
@@ -672,6 +1376,43 @@ func (cfg *MempoolConfig) WalEnabled() bool {
 	return cfg.WalPath != ""
 }
 
+// ExportFilePath returns the full path to the file the txs are exported to.
+func (cfg *MempoolConfig) ExportFilePath() string {
+	return rootify(cfg.ExportFile, cfg.RootDir)
+}
+
+// TxRelayFilePath returns the full path to the file the tx relay settings of
+// the peers are persisted in.
+func (cfg *MempoolConfig) TxRelayFilePath() string {
+	return rootify(cfg.TxRelayFile, cfg.RootDir)
+}
+
+// CategoryMaxBytes returns the maximum total size of the txs reaped into a
+// block proposal, by category, as listed in CategoryQuotas.
+func (cfg *MempoolConfig) CategoryMaxBytes() (map[string]int64, error) {
+	quotas := make(map[string]int64)
+	for _, s := range strings.Split(cfg.CategoryQuotas, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		i := strings.LastIndex(s, ":")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid quota %q in category_quotas, expected <category>:<max bytes>", s)
+		}
+		category := s[:i]
+		maxBytes, err := strconv.ParseInt(s[i+1:], 10, 64)
+		if err != nil || maxBytes < 0 {
+			return nil, fmt.Errorf("invalid max bytes %q of category %q in category_quotas", s[i+1:], category)
+		}
+		if _, ok := quotas[category]; ok {
+			return nil, fmt.Errorf("duplicate category %q in category_quotas", category)
+		}
+		quotas[category] = maxBytes
+	}
+	return quotas, nil
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
@@ -693,6 +1434,20 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxBatchBytes <= cfg.MaxTxBytes {
 		return errors.New("max_batch_bytes can't be less or equal to max_tx_bytes")
 	}
+	if cfg.MinGasPrice < 0 {
+		return errors.New("min_gas_price can't be negative")
+	}
+	if cfg.TargetBlockGas < 0 {
+		return errors.New("target_block_gas can't be negative")
+	}
+	switch cfg.OrderBy {
+	case MempoolOrderFIFO, MempoolOrderPriority, MempoolOrderSenderNonce:
+	default:
+		return fmt.Errorf("unknown order_by %q", cfg.OrderBy)
+	}
+	if _, err := cfg.CategoryMaxBytes(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -701,6 +1456,7 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 
 // StateSyncConfig defines the configuration for the Tendermint state sync service
 type StateSyncConfig struct {
+	RootDir       string        `mapstructure:"home"`
 	Enable        bool          `mapstructure:"enable"`
 	TempDir       string        `mapstructure:"temp_dir"`
 	RPCServers    []string      `mapstructure:"rpc_servers"`
@@ -708,6 +1464,33 @@ type StateSyncConfig struct {
 	TrustHeight   int64         `mapstructure:"trust_height"`
 	TrustHash     string        `mapstructure:"trust_hash"`
 	DiscoveryTime time.Duration `mapstructure:"discovery_time"`
+
+	// Order in which the discovered snapshots are attempted: "height" (the
+	// greatest first), "format" (the preferred first) or "peers" (advertised
+	// by the most peers first).
+	SnapshotPreference string `mapstructure:"snapshot_preference"`
+	// Comma separated list of snapshot formats in order of preference.
+	PreferredSnapshotFormats string `mapstructure:"preferred_snapshot_formats"`
+
+	// Number of witnesses (the rpc_servers after the first) which must return
+	// the same light blocks as the primary for the heights of a snapshot.
+	MinWitnesses int `mapstructure:"min_witnesses"`
+	// Maximum time the verified headers can be in the future.
+	MaxClockDrift time.Duration `mapstructure:"max_clock_drift"`
+
+	// Interval in heights at which to archive the snapshots taken by the app,
+	// along with the light blocks needed to restore them. 0 disables archiving.
+	SnapshotInterval uint64 `mapstructure:"snapshot_interval"`
+	// Number of most recent archived snapshots to keep.
+	SnapshotKeepRecent uint32 `mapstructure:"snapshot_keep_recent"`
+	// Directory to archive snapshots in.
+	SnapshotDir string `mapstructure:"snapshot_dir"`
+
+	// Maximum random delay before advertising the local snapshots to a peer
+	// requesting them, spreading the load when many peers request at once.
+	AdvertiseJitter time.Duration `mapstructure:"advertise_jitter"`
+	// Minimum interval between snapshot advertisements to the same peer.
+	AdvertiseInterval time.Duration `mapstructure:"advertise_interval"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -722,9 +1505,39 @@ func (cfg *StateSyncConfig) TrustHashBytes() []byte {
 // DefaultStateSyncConfig returns a default configuration for the state sync service
 func DefaultStateSyncConfig() *StateSyncConfig {
 	return &StateSyncConfig{
-		TrustPeriod:   168 * time.Hour,
-		DiscoveryTime: 15 * time.Second,
+		TrustPeriod:        168 * time.Hour,
+		DiscoveryTime:      15 * time.Second,
+		SnapshotPreference: "height",
+		MinWitnesses:       1,
+		MaxClockDrift:      10 * time.Second,
+		SnapshotKeepRecent: 2,
+		SnapshotDir:        defaultSnapshotDir,
+		AdvertiseJitter:    2 * time.Second,
+		AdvertiseInterval:  30 * time.Second,
+	}
+}
+
+// PreferredSnapshotFormatList returns the formats listed in
+// PreferredSnapshotFormats.
+func (cfg *StateSyncConfig) PreferredSnapshotFormatList() ([]uint32, error) {
+	var formats []uint32
+	for _, s := range strings.Split(cfg.PreferredSnapshotFormats, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		format, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid format %q in preferred_snapshot_formats: %w", s, err)
+		}
+		formats = append(formats, uint32(format))
 	}
+	return formats, nil
+}
+
+// SnapshotDirPath returns the full path to the snapshot archive directory.
+func (cfg *StateSyncConfig) SnapshotDirPath() string {
+	return rootify(cfg.SnapshotDir, cfg.RootDir)
 }
 
 // TestFastSyncConfig returns a default configuration for the state sync service
@@ -759,6 +1572,33 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 		if err != nil {
 			return fmt.Errorf("invalid trusted_hash: %w", err)
 		}
+		if cfg.MinWitnesses < 1 {
+			return errors.New("min_witnesses must be positive")
+		}
+		if cfg.MinWitnesses > len(cfg.RPCServers)-1 {
+			return fmt.Errorf("min_witnesses (%d) can't exceed the number of rpc_servers after the first (%d)",
+				cfg.MinWitnesses, len(cfg.RPCServers)-1)
+		}
+	}
+	if cfg.MaxClockDrift < 0 {
+		return errors.New("max_clock_drift can't be negative")
+	}
+	if cfg.SnapshotInterval > 0 && cfg.SnapshotKeepRecent == 0 {
+		return errors.New("snapshot_keep_recent must be positive when snapshot_interval is set")
+	}
+	switch cfg.SnapshotPreference {
+	case "height", "format", "peers":
+	default:
+		return fmt.Errorf("unknown snapshot_preference %q, must be height, format or peers", cfg.SnapshotPreference)
+	}
+	if _, err := cfg.PreferredSnapshotFormatList(); err != nil {
+		return err
+	}
+	if cfg.AdvertiseJitter < 0 {
+		return errors.New("advertise_jitter can't be negative")
+	}
+	if cfg.AdvertiseInterval < 0 {
+		return errors.New("advertise_interval can't be negative")
 	}
 	return nil
 }
@@ -769,6 +1609,11 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 // FastSyncConfig defines the configuration for the Tendermint fast sync service
 type FastSyncConfig struct {
 	Version string `mapstructure:"version"`
+
+	// Known-good blocks, as "height:hash" pins (the hash in hex): fast sync and
+	// the replay of the stored blocks refuse the chains contradicting them, e.g.
+	// a forged fork served to a fresh node by its peers.
+	Checkpoints []string `mapstructure:"checkpoints"`
 }
 
 // DefaultFastSyncConfig returns a default configuration for the fast sync service
@@ -786,13 +1631,97 @@ func TestFastSyncConfig() *FastSyncConfig {
 // ValidateBasic performs basic validation.
 func (cfg *FastSyncConfig) ValidateBasic() error {
 	switch cfg.Version {
-	case "v0":
-		return nil
-	case "v2":
-		return nil
+	case "v0", "v2":
 	default:
 		return fmt.Errorf("unknown fastsync version %s", cfg.Version)
 	}
+	if _, err := cfg.ParseCheckpoints(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ParseCheckpoints returns the hashes of the Checkpoints by height.
+func (cfg *FastSyncConfig) ParseCheckpoints() (map[int64][]byte, error) {
+	checkpoints := make(map[int64][]byte, len(cfg.Checkpoints))
+	for _, pin := range cfg.Checkpoints {
+		parts := strings.SplitN(pin, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid checkpoint %q (want height:hash)", pin)
+		}
+		height, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || height <= 0 {
+			return nil, fmt.Errorf("invalid height of checkpoint %q", pin)
+		}
+		hash, err := hex.DecodeString(parts[1])
+		if err != nil || len(hash) != 32 {
+			return nil, fmt.Errorf("invalid hash of checkpoint %q", pin)
+		}
+		if _, ok := checkpoints[height]; ok {
+			return nil, fmt.Errorf("duplicate checkpoint at height %d", height)
+		}
+		checkpoints[height] = hash
+	}
+	return checkpoints, nil
+}
+
+//-----------------------------------------------------------------------------
+// ReplicaConfig
+
+// ReplicaConfig defines the configuration for the read replica mode, in which
+// the node follows the chain of trusted upstream nodes over RPC, verifying the
+// commits of their blocks, instead of taking part in the p2p network.
+type ReplicaConfig struct {
+	Enable bool `mapstructure:"enable"`
+
+	// RPC addresses of the trusted upstream nodes, only the next one being
+	// used once one fails.
+	Upstreams []string `mapstructure:"upstreams"`
+
+	// Interval at which the upstreams are polled for the next block, once
+	// caught up with the chain.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// Maximum time to fetch a block and its commit.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// DefaultReplicaConfig returns a default configuration for the read replica
+// mode, which is disabled.
+func DefaultReplicaConfig() *ReplicaConfig {
+	return &ReplicaConfig{
+		Enable:       false,
+		PollInterval: 500 * time.Millisecond,
+		Timeout:      10 * time.Second,
+	}
+}
+
+// TestReplicaConfig returns a configuration for testing the read replica
+// mode.
+func TestReplicaConfig() *ReplicaConfig {
+	cfg := DefaultReplicaConfig()
+	cfg.PollInterval = 10 * time.Millisecond
+	return cfg
+}
+
+// ValidateBasic performs basic validation.
+func (cfg *ReplicaConfig) ValidateBasic() error {
+	if cfg.Enable {
+		if len(cfg.Upstreams) == 0 {
+			return errors.New("upstreams is required")
+		}
+		for _, upstream := range cfg.Upstreams {
+			if len(upstream) == 0 {
+				return errors.New("found empty upstreams entry")
+			}
+		}
+	}
+	if cfg.PollInterval <= 0 {
+		return errors.New("poll_interval must be positive")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
 }
 
 //-----------------------------------------------------------------------------
@@ -830,11 +1759,89 @@ type ConsensusConfig struct {
 	CreateEmptyBlocks         bool          `mapstructure:"create_empty_blocks"`
 	CreateEmptyBlocksInterval time.Duration `mapstructure:"create_empty_blocks_interval"`
 
+	// Minimum time between the previous commit and the proposal of an empty
+	// block (0 disables). Unlike TimeoutCommit, it does not delay blocks with
+	// txs.
+	TargetBlockTime time.Duration `mapstructure:"target_block_time"`
+
 	// Reactor sleep duration parameters
 	PeerGossipSleepDuration     time.Duration `mapstructure:"peer_gossip_sleep_duration"`
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer_query_maj23_sleep_duration"`
 
+	// A peer which is at least PeerCatchupLagThreshold heights behind us
+	// (0 disables) only gets catch-up commits and block parts, at most one
+	// message per PeerCatchupSleepDuration for each of them.
+	PeerCatchupLagThreshold  int64         `mapstructure:"peer_catchup_lag_threshold"`
+	PeerCatchupSleepDuration time.Duration `mapstructure:"peer_catchup_sleep_duration"`
+
+	// Max number of peers each block part of the current round is sent to (0 -
+	// all peers), the other peers getting it from them. A part is sent to more
+	// peers BlockPartFanoutTimeout after reaching the fan-out, in case they
+	// don't relay it. BlockPartSelection is the order in which the parts a
+	// peer lacks are sent (BlockPartSelectionRandom or
+	// BlockPartSelectionRarestFirst).
+	BlockPartFanout        int           `mapstructure:"block_part_fanout"`
+	BlockPartFanoutTimeout time.Duration `mapstructure:"block_part_fanout_timeout"`
+	BlockPartSelection     string        `mapstructure:"block_part_selection"`
+
+	// Number of the last vote and data messages received from each peer during
+	// the current step whose hash is remembered (0 disables), so that the exact
+	// duplicates are dropped before being decoded. A peer is disconnected once it sent more than
+	// PeerMaxDuplicates duplicates during a step (0 - never).
+	PeerReplayWindow  int `mapstructure:"peer_replay_window"`
+	PeerMaxDuplicates int `mapstructure:"peer_max_duplicates"`
+
+	// Number of the last votes and block parts added to the consensus state which
+	// are remembered (0 disables), so that the identical messages relayed by the
+	// other peers are dropped before being verified and processed again.
+	MsgCacheSize int `mapstructure:"msg_cache_size"`
+
+	// Maximum number of proposals, block parts and votes received for the next
+	// height, or for a future round of the current height, which are buffered
+	// until consensus reaches their height and round (0 - they're dropped).
+	// Once full, the messages of the furthest heights and rounds are dropped.
+	// Only the messages whose signature (or, for the block parts, proof
+	// against a buffered proposal) is valid are buffered.
+	FutureMsgBufferSize int `mapstructure:"future_msg_buffer_size"`
+
+	// Maximum number of the buffered future messages received from a given
+	// peer (0 - unlimited).
+	FutureMsgPeerQuota int `mapstructure:"future_msg_peer_quota"`
+
 	DoubleSignCheckHeight int64 `mapstructure:"double_sign_check_height"`
+
+	// Consider the local validator down once it has missed this many
+	// consecutive blocks (0 disables): log an error, POST an alert to
+	// LivenessWebhookURL if set, and exit the process if LivenessExit.
+	LivenessMissedBlocks int    `mapstructure:"liveness_missed_blocks"`
+	LivenessWebhookURL   string `mapstructure:"liveness_webhook_url"`
+	LivenessExit         bool   `mapstructure:"liveness_exit"`
+
+	// Address of the external block builder (e.g. "http://127.0.0.1:26680")
+	// the txs of the blocks proposed by this node are requested from, over
+	// JSON-RPC (build_block method). If it doesn't answer within
+	// BlockBuilderTimeout, or returns an invalid payload, the txs are reaped
+	// from the mempool. Empty - disabled.
+	BlockBuilderAddress string        `mapstructure:"block_builder_address"`
+	BlockBuilderTimeout time.Duration `mapstructure:"block_builder_timeout"`
+
+	// Directory of the forensic bundles written when +2/3 commit a different
+	// app hash than the one computed by the app. The node then stops its p2p
+	// layer, keeping the RPC up for the investigation. Empty - disabled.
+	ForensicsDir string `mapstructure:"forensics_dir"`
+
+	// Record the timestamps of the last TimestampAuditHeights blocks committed
+	// by consensus (the proposal timestamp, the local time at which the block
+	// was received and the block time), to diagnose the validators with a
+	// broken clock with the /timestamp_drift RPC endpoint. 0 - disabled.
+	TimestampAuditHeights int64 `mapstructure:"timestamp_audit_heights"`
+
+	// ReplayFromHeight, if > 0, makes the handshake replay blocks starting at
+	// this height, regardless of the height reported by the app. It is meant
+	// to be set once via `tendermint start --replay-from` after the operator
+	// has restored the app's state to ReplayFromHeight-1, and is therefore not
+	// written to config.toml.
+	ReplayFromHeight int64 `mapstructure:"replay_from_height"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
@@ -851,9 +1858,24 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		SkipTimeoutCommit:           false,
 		CreateEmptyBlocks:           true,
 		CreateEmptyBlocksInterval:   0 * time.Second,
+		TargetBlockTime:             0 * time.Second,
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
+		PeerCatchupLagThreshold:     0,
+		PeerCatchupSleepDuration:    10 * time.Millisecond,
+		BlockPartFanout:             0,
+		BlockPartFanoutTimeout:      300 * time.Millisecond,
+		BlockPartSelection:          BlockPartSelectionRandom,
+		PeerReplayWindow:            512,
+		PeerMaxDuplicates:           100,
+		MsgCacheSize:                4096,
+		FutureMsgBufferSize:         256,
+		FutureMsgPeerQuota:          64,
 		DoubleSignCheckHeight:       int64(0),
+		BlockBuilderAddress:         "",
+		BlockBuilderTimeout:         500 * time.Millisecond,
+		ForensicsDir:                filepath.Join(defaultDataDir, "forensics"),
+		TimestampAuditHeights:       0,
 	}
 }
 
@@ -871,13 +1893,37 @@ func TestConsensusConfig() *ConsensusConfig {
 	cfg.SkipTimeoutCommit = true
 	cfg.PeerGossipSleepDuration = 5 * time.Millisecond
 	cfg.PeerQueryMaj23SleepDuration = 250 * time.Millisecond
+	cfg.PeerCatchupSleepDuration = 1 * time.Millisecond
+	cfg.BlockPartFanoutTimeout = 15 * time.Millisecond
 	cfg.DoubleSignCheckHeight = int64(0)
 	return cfg
 }
 
 // WaitForTxs returns true if the consensus should wait for transactions before entering the propose step
 func (cfg *ConsensusConfig) WaitForTxs() bool {
-	return !cfg.CreateEmptyBlocks || cfg.CreateEmptyBlocksInterval > 0
+	return !cfg.CreateEmptyBlocks || cfg.CreateEmptyBlocksInterval > 0 || cfg.TargetBlockTime > 0
+}
+
+// EmptyBlockDelay returns how long to wait for transactions at time now,
+// after having committed the previous block at commitTime, before proposing
+// an empty block. ok is false if empty blocks must not be created at all, in
+// which case the consensus waits for transactions indefinitely.
+//
+// CreateEmptyBlocksInterval is measured from the start of the round (i.e. after
+// TimeoutCommit), while TargetBlockTime is measured from the previous commit.
+// If both are set, the longest delay wins.
+func (cfg *ConsensusConfig) EmptyBlockDelay(commitTime, now time.Time) (delay time.Duration, ok bool) {
+	if !cfg.CreateEmptyBlocks && cfg.CreateEmptyBlocksInterval == 0 {
+		return 0, false
+	}
+
+	delay = cfg.CreateEmptyBlocksInterval
+	if cfg.TargetBlockTime > 0 && !commitTime.IsZero() {
+		if d := commitTime.Add(cfg.TargetBlockTime).Sub(now); d > delay {
+			delay = d
+		}
+	}
+	return delay, true
 }
 
 // Propose returns the amount of time to wait for a proposal
@@ -920,6 +1966,15 @@ func (cfg *ConsensusConfig) SetWalFile(walFile string) {
 	cfg.walFile = walFile
 }
 
+// ForensicsDirPath returns the full path to the forensics directory, or an
+// empty string if the forensic bundles are disabled.
+func (cfg *ConsensusConfig) ForensicsDirPath() string {
+	if cfg.ForensicsDir == "" {
+		return ""
+	}
+	return rootify(cfg.ForensicsDir, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *ConsensusConfig) ValidateBasic() error {
@@ -947,15 +2002,72 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.CreateEmptyBlocksInterval < 0 {
 		return errors.New("create_empty_blocks_interval can't be negative")
 	}
+	if cfg.TargetBlockTime < 0 {
+		return errors.New("target_block_time can't be negative")
+	}
 	if cfg.PeerGossipSleepDuration < 0 {
 		return errors.New("peer_gossip_sleep_duration can't be negative")
 	}
 	if cfg.PeerQueryMaj23SleepDuration < 0 {
 		return errors.New("peer_query_maj23_sleep_duration can't be negative")
 	}
+	if cfg.PeerCatchupLagThreshold < 0 {
+		return errors.New("peer_catchup_lag_threshold can't be negative")
+	}
+	if cfg.PeerCatchupSleepDuration < 0 {
+		return errors.New("peer_catchup_sleep_duration can't be negative")
+	}
+	if cfg.BlockPartFanout < 0 {
+		return errors.New("block_part_fanout can't be negative")
+	}
+	if cfg.BlockPartFanoutTimeout < 0 {
+		return errors.New("block_part_fanout_timeout can't be negative")
+	}
+	switch cfg.BlockPartSelection {
+	case BlockPartSelectionRandom, BlockPartSelectionRarestFirst:
+	default:
+		return fmt.Errorf("unknown block_part_selection %q", cfg.BlockPartSelection)
+	}
+	if cfg.PeerReplayWindow < 0 {
+		return errors.New("peer_replay_window can't be negative")
+	}
+	if cfg.PeerMaxDuplicates < 0 {
+		return errors.New("peer_max_duplicates can't be negative")
+	}
+	if cfg.MsgCacheSize < 0 {
+		return errors.New("msg_cache_size can't be negative")
+	}
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double_sign_check_height can't be negative")
 	}
+	if cfg.LivenessMissedBlocks < 0 {
+		return errors.New("liveness_missed_blocks can't be negative")
+	}
+	if cfg.LivenessWebhookURL != "" {
+		if _, err := url.ParseRequestURI(cfg.LivenessWebhookURL); err != nil {
+			return fmt.Errorf("invalid liveness_webhook_url: %w", err)
+		}
+	}
+	if cfg.BlockBuilderAddress != "" {
+		if _, err := url.ParseRequestURI(cfg.BlockBuilderAddress); err != nil {
+			return fmt.Errorf("invalid block_builder_address: %w", err)
+		}
+		if cfg.BlockBuilderTimeout <= 0 {
+			return errors.New("block_builder_timeout must be positive")
+		}
+	}
+	if cfg.FutureMsgBufferSize < 0 {
+		return errors.New("future_msg_buffer_size can't be negative")
+	}
+	if cfg.FutureMsgPeerQuota < 0 {
+		return errors.New("future_msg_peer_quota can't be negative")
+	}
+	if cfg.TimestampAuditHeights < 0 {
+		return errors.New("timestamp_audit_heights can't be negative")
+	}
+	if cfg.ReplayFromHeight < 0 {
+		return errors.New("replay_from_height can't be negative")
+	}
 	return nil
 }
 
@@ -978,12 +2090,25 @@ type TxIndexConfig struct {
 	//   2) "kv" (default) - the simplest possible indexer,
 	//      backed by key-value storage (defaults to levelDB; see DBBackend).
 	Indexer string `mapstructure:"indexer"`
+
+	// Composite keys (`type.key`) of the event attributes to index, each
+	// attribute still having to be marked for indexing by the app. Patterns
+	// can use `*` wildcards (e.g. "transfer.*"), and patterns prefixed with
+	// "!" exclude the matching keys (e.g. "!debug.*"). If only exclusions are
+	// given, all the other keys are indexed. Empty means all keys are indexed.
+	IndexEvents []string `mapstructure:"index_events"`
+
+	// Number of blocks waiting to be indexed, off the commit path, past which
+	// the commits wait for the indexer to catch up (0 - the blocks are indexed
+	// as they are committed).
+	QueueSize int `mapstructure:"queue_size"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
 func DefaultTxIndexConfig() *TxIndexConfig {
 	return &TxIndexConfig{
-		Indexer: "kv",
+		Indexer:   "kv",
+		QueueSize: 100,
 	}
 }
 
@@ -992,6 +2117,20 @@ func TestTxIndexConfig() *TxIndexConfig {
 	return DefaultTxIndexConfig()
 }
 
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	for _, pattern := range cfg.IndexEvents {
+		if strings.TrimPrefix(pattern, "!") == "" {
+			return fmt.Errorf("invalid index_events pattern %q", pattern)
+		}
+	}
+	if cfg.QueueSize < 0 {
+		return errors.New("queue_size can't be negative")
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 // InstrumentationConfig
 
@@ -1013,16 +2152,31 @@ type InstrumentationConfig struct {
 
 	// Instrumentation namespace.
 	Namespace string `mapstructure:"namespace"`
+
+	// ABCI calls taking at least this long are logged, with the height of
+	// the block and the hash of the tx they are made for (0 - disabled).
+	SlowABCICallThreshold time.Duration `mapstructure:"slow_abci_call_threshold"`
+
+	// Cumulative metrics whose values are checkpointed into the node's DB, and
+	// restored when it restarts, instead of starting from 0 (e.g.
+	// consensus_missed_rounds). See the documentation for the list of the
+	// metrics which can be checkpointed.
+	CheckpointedMetrics []string `mapstructure:"checkpointed_metrics"`
+
+	// How often the checkpointed metrics are saved.
+	MetricsCheckpointInterval time.Duration `mapstructure:"metrics_checkpoint_interval"`
 }
 
 // DefaultInstrumentationConfig returns a default configuration for metrics
 // reporting.
 func DefaultInstrumentationConfig() *InstrumentationConfig {
 	return &InstrumentationConfig{
-		Prometheus:           false,
-		PrometheusListenAddr: ":26660",
-		MaxOpenConnections:   3,
-		Namespace:            "tendermint",
+		Prometheus:                false,
+		PrometheusListenAddr:      ":26660",
+		MaxOpenConnections:        3,
+		Namespace:                 "tendermint",
+		CheckpointedMetrics:       []string{},
+		MetricsCheckpointInterval: time.Minute,
 	}
 }
 
@@ -1038,9 +2192,189 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max_open_connections can't be negative")
 	}
+	if cfg.SlowABCICallThreshold < 0 {
+		return errors.New("slow_abci_call_threshold can't be negative")
+	}
+	if len(cfg.CheckpointedMetrics) > 0 && cfg.MetricsCheckpointInterval <= 0 {
+		return errors.New("metrics_checkpoint_interval must be positive")
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// AlertsConfig
+
+// AlertsConfig defines the sinks notified of critical node events.
+type AlertsConfig struct {
+	// URLs to POST the alerts to, as JSON.
+	Webhooks []string `mapstructure:"webhooks"`
+
+	// Shell command run for each alert, with the alert as JSON on its
+	// standard input and in the ALERT_TYPE and ALERT_MESSAGE environment
+	// variables.
+	Command string `mapstructure:"command"`
+
+	// Types of the alerts to send. Empty means all types.
+	Types []string `mapstructure:"types"`
+
+	// Maximum time to deliver an alert to each sink.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Alert when the free space of the data directory's disk, or of one of
+	// the *_db_dir directories' disks, drops below this many megabytes
+	// (0 - disabled).
+	MinFreeDiskMB int64 `mapstructure:"min_free_disk_mb"`
+
+	// Alert when the node has had no peers for this long (0 - disabled).
+	NoPeersTimeout time.Duration `mapstructure:"no_peers_timeout"`
+}
+
+// DefaultAlertsConfig returns a default configuration for the alerts, which
+// are disabled as long as no sink is set.
+func DefaultAlertsConfig() *AlertsConfig {
+	return &AlertsConfig{
+		Webhooks:       []string{},
+		Types:          []string{},
+		Timeout:        10 * time.Second,
+		MinFreeDiskMB:  1024,
+		NoPeersTimeout: 1 * time.Minute,
+	}
+}
+
+// TestAlertsConfig returns a configuration for testing the alerts.
+func TestAlertsConfig() *AlertsConfig {
+	return DefaultAlertsConfig()
+}
+
+// Enabled returns true if any sink is set.
+func (cfg *AlertsConfig) Enabled() bool {
+	return len(cfg.Webhooks) > 0 || cfg.Command != ""
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *AlertsConfig) ValidateBasic() error {
+	for _, webhook := range cfg.Webhooks {
+		if _, err := url.ParseRequestURI(webhook); err != nil {
+			return fmt.Errorf("invalid webhook %q: %w", webhook, err)
+		}
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("timeout can't be negative")
+	}
+	if cfg.MinFreeDiskMB < 0 {
+		return errors.New("min_free_disk_mb can't be negative")
+	}
+	if cfg.NoPeersTimeout < 0 {
+		return errors.New("no_peers_timeout can't be negative")
+	}
 	return nil
 }
 
+//-----------------------------------------------------------------------------
+// OperatorConfig
+
+// Maximum lengths of the operator info fields.
+const (
+	MaxOperatorWebsiteLength         = 140
+	MaxOperatorSecurityContactLength = 140
+	MaxOperatorDetailsLength         = 280
+	MaxOperatorSentryNodeIDs         = 16
+)
+
+// OperatorConfig defines the contact info of the validator operator, signed
+// with the validator key and gossiped to the other nodes, which expose the
+// info of the validators via the /validator_info RPC endpoint.
+type OperatorConfig struct {
+	// If true, the node gossips the operator info of the validators.
+	Gossip bool `mapstructure:"gossip"`
+
+	// Contact info of the operator of this node's validator, alongside the
+	// moniker.
+	Website         string `mapstructure:"website"`
+	SecurityContact string `mapstructure:"security_contact"`
+	Details         string `mapstructure:"details"`
+
+	// Comma separated list of the node IDs of the sentries of this node's
+	// validator, attested in its signed info.
+	SentryNodeIDs string `mapstructure:"sentry_node_ids"`
+
+	// Hex address of the validator this node is a sentry of, attested with
+	// the node key, so that the other nodes trust the validator's
+	// SentryNodeIDs listing this node.
+	SentryOf string `mapstructure:"sentry_of"`
+
+	// If true, the sentries listed by the validators which they attested are
+	// made priority peers (see P2PConfig.PriorityPeerIDs).
+	PrioritizeSentries bool `mapstructure:"prioritize_sentries"`
+
+	// Minimum time between two updates of the info of a validator. The
+	// updates received sooner are dropped.
+	MinUpdateInterval time.Duration `mapstructure:"min_update_interval"`
+}
+
+// DefaultOperatorConfig returns a default configuration for the operator
+// info, which isn't gossiped.
+func DefaultOperatorConfig() *OperatorConfig {
+	return &OperatorConfig{
+		Gossip:            false,
+		MinUpdateInterval: 1 * time.Minute,
+	}
+}
+
+// TestOperatorConfig returns a configuration for testing the operator info.
+func TestOperatorConfig() *OperatorConfig {
+	cfg := DefaultOperatorConfig()
+	cfg.MinUpdateInterval = 100 * time.Millisecond
+	return cfg
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *OperatorConfig) ValidateBasic() error {
+	if len(cfg.Website) > MaxOperatorWebsiteLength {
+		return fmt.Errorf("website is longer than %d characters", MaxOperatorWebsiteLength)
+	}
+	if len(cfg.SecurityContact) > MaxOperatorSecurityContactLength {
+		return fmt.Errorf("security_contact is longer than %d characters", MaxOperatorSecurityContactLength)
+	}
+	if len(cfg.Details) > MaxOperatorDetailsLength {
+		return fmt.Errorf("details is longer than %d characters", MaxOperatorDetailsLength)
+	}
+	if n := len(cfg.SentryNodeIDList()); n > MaxOperatorSentryNodeIDs {
+		return fmt.Errorf("sentry_node_ids lists %d node IDs, more than %d", n, MaxOperatorSentryNodeIDs)
+	}
+	if cfg.SentryOf != "" {
+		if _, err := cfg.SentryOfAddress(); err != nil {
+			return err
+		}
+	}
+	if cfg.MinUpdateInterval < 0 {
+		return errors.New("min_update_interval can't be negative")
+	}
+	return nil
+}
+
+// SentryOfAddress returns the validator address decoded from SentryOf.
+func (cfg *OperatorConfig) SentryOfAddress() ([]byte, error) {
+	address, err := hex.DecodeString(cfg.SentryOf)
+	if err != nil || len(address) != 20 {
+		return nil, fmt.Errorf("sentry_of %q isn't a hex validator address", cfg.SentryOf)
+	}
+	return address, nil
+}
+
+// SentryNodeIDList returns the node IDs listed in SentryNodeIDs.
+func (cfg *OperatorConfig) SentryNodeIDList() []string {
+	var ids []string
+	for _, s := range strings.Split(cfg.SentryNodeIDs, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			ids = append(ids, s)
+		}
+	}
+	return ids
+}
+
 //-----------------------------------------------------------------------------
 // Utils
 
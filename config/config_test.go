@@ -1,7 +1,10 @@
 package config
 
 import (
+	"bytes"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,6 +33,22 @@ func TestDefaultConfig(t *testing.T) {
 
 }
 
+func TestBaseConfigDBDirFor(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SetRoot("/foo")
+	assert.Equal(t, "/foo/data", cfg.DBDirFor("blockstore"))
+	assert.Empty(t, cfg.SeparateDBDirs())
+
+	cfg.BlockStoreDBPath = "/mnt/hdd"
+	cfg.StateDBPath = "data" // same as db_dir
+	cfg.TxIndexDBPath = "index"
+	assert.Equal(t, "/mnt/hdd", cfg.DBDirFor("blockstore"))
+	assert.Equal(t, "/foo/data", cfg.DBDirFor("state"))
+	assert.Equal(t, "/foo/index", cfg.DBDirFor("tx_index"))
+	assert.Equal(t, "/foo/data", cfg.DBDirFor("evidence"))
+	assert.Equal(t, map[string]string{"blockstore": "/mnt/hdd", "tx_index": "/foo/index"}, cfg.SeparateDBDirs())
+}
+
 func TestConfigValidateBasic(t *testing.T) {
 	cfg := DefaultConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -62,6 +81,53 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.LogFormat = LogFormatPlain
+
+	// tamper with the compaction window
+	for _, window := range []string{"02:00", "02:00-25:00", "2am-4am", "03:00-03:00"} {
+		cfg.DBCompactionWindow = window
+		assert.Error(t, cfg.ValidateBasic(), window)
+	}
+	cfg.DBCompactionWindow = ""
+	cfg.ABCIMempoolFlushThrottle = -time.Millisecond
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ABCIMempoolFlushThrottle = 0
+	cfg.StateStoreCacheSize = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.StateStoreCacheSize = 0
+	cfg.DiskLowFreeMB = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.DiskLowFreeMB, cfg.DiskCriticalFreeMB = 256, 1024
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.DiskLowFreeMB, cfg.DiskCriticalFreeMB = 0, 0
+	cfg.PruningExemptions = []string{"validator_updates", ""}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PruningExemptions = nil
+	cfg.BlockPartsRetainHeights = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.BlockPartsRetainHeights = 0
+	cfg.PrivValidatorGRPCCertFile = "cert.pem"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorGRPCKeyFile = "key.pem"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorGRPCCAFile = "ca.pem"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.PrivValidatorGRPCCAFile, cfg.PrivValidatorGRPCCertFile, cfg.PrivValidatorGRPCKeyFile = "", "", ""
+	cfg.LogSampleRate = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.LogSampleRate, cfg.LogSampleWindow = 100, 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.LogSampleWindow = time.Second
+	cfg.LogSampledMessages = []string{""}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.LogSampleRate, cfg.LogSampledMessages = 0, DefaultLogSampledMessages()
+
+	cfg.DBCompactionWindow = "23:30-01:15"
+	require.NoError(t, cfg.ValidateBasic())
+	start, end, err := cfg.DBCompactionWindowBounds()
+	require.NoError(t, err)
+	assert.Equal(t, 23*time.Hour+30*time.Minute, start)
+	assert.Equal(t, time.Hour+15*time.Minute, end)
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -70,12 +136,22 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 
 	fieldsToTest := []string{
 		"GRPCMaxOpenConnections",
+		"GRPCKeepaliveTime",
+		"GRPCKeepaliveTimeout",
+		"GRPCKeepaliveMinTime",
 		"MaxOpenConnections",
 		"MaxSubscriptionClients",
 		"MaxSubscriptionsPerClient",
+		"MaxQueriesPerSubscription",
+		"MaxWebsocketConnections",
+		"MaxEventsPerSecondPerClient",
+		"SSEKeepAliveInterval",
 		"TimeoutBroadcastTxCommit",
+		"IdempotencyCacheSize",
+		"IdempotencyKeyTTL",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
+		"ResponseCacheSize",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -96,6 +172,17 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"SecretConnRekeyBytes",
+		"SecretConnRekeyInterval",
+		"ReconnectAttempts",
+		"ReconnectInterval",
+		"ReconnectBackoffAttempts",
+		"ReconnectBackoffInterval",
+		"DialJitter",
+		"PersistentPeersMaxReconnectAttempts",
+		"MaxDialsPerPeerPerHour",
+		"MaxConcurrentHandshakes",
+		"MaxAcceptRatePerIP",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -103,6 +190,99 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.ReconnectBackoffMultiplier = 0.5
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestP2PConfig()
+	cfg.PrioritySendQueueFactor = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestP2PConfig()
+	cfg.PriorityReconnectInterval = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestP2PConfig()
+	cfg.HandshakeTimeout = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestP2PConfig()
+	cfg.DialTimeout = 0
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestP2PConfigValidateBasicChaos(t *testing.T) {
+	cfg := TestP2PConfig()
+	cfg.TestChaos = true
+	cfg.TestChaosChannels = "0x20, 0x21,48"
+	assert.NoError(t, cfg.ValidateBasic())
+	ids, err := cfg.TestChaosChannelIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x20, 0x21, 0x30}, ids)
+
+	cfg.TestChaosChannels = "0x100"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TestChaosChannels = ""
+
+	for _, fieldName := range []string{"TestChaosReorderRate", "TestChaosDuplicateRate", "TestChaosCorruptRate"} {
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetFloat(1.5)
+		assert.Error(t, cfg.ValidateBasic())
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetFloat(-0.5)
+		assert.Error(t, cfg.ValidateBasic())
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetFloat(0.5)
+		assert.NoError(t, cfg.ValidateBasic())
+	}
+}
+
+func TestP2PConfigValidateBasicMessageAuth(t *testing.T) {
+	cfg := TestP2PConfig()
+	cfg.MessageAuth = true
+	assert.NoError(t, cfg.ValidateBasic())
+	ids, err := cfg.MessageAuthChannelIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x20, 0x21, 0x22, 0x23}, ids)
+
+	cfg.MessageAuthChannels = "0x20,consensus"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestP2PConfigValidateBasicCapture(t *testing.T) {
+	cfg := TestP2PConfig()
+	cfg.CaptureFile = "data/p2p.capture"
+	cfg.CaptureChannels = "0x20,0x30"
+	assert.NoError(t, cfg.ValidateBasic())
+	ids, err := cfg.CaptureChannelIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x20, 0x30}, ids)
+
+	cfg.CaptureChannels = "0x20,mempool"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.CaptureChannels = ""
+
+	cfg.CaptureSampleRate = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.CaptureSampleRate = 1
+
+	cfg.CaptureMaxSize = 0
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestP2PConfigValidateBasicTLS(t *testing.T) {
+	cfg := TestP2PConfig()
+	cfg.TLS = true
+	assert.Error(t, cfg.ValidateBasic(), "no pinned certificate")
+
+	id, fingerprint := strings.Repeat("ab", 20), strings.Repeat("CD", 32)
+	cfg.TLSPinnedCerts = id + "@" + fingerprint + ", "
+	assert.NoError(t, cfg.ValidateBasic())
+	pins, err := cfg.TLSPinnedCertIDs()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{strings.ToLower(fingerprint): id}, pins)
+
+	for _, pins := range []string{id, id + "@" + fingerprint[2:], "ab@" + fingerprint} {
+		cfg.TLSPinnedCerts = pins
+		assert.Error(t, cfg.ValidateBasic(), pins)
+	}
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -114,6 +294,8 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"MinGasPrice",
+		"TargetBlockGas",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -121,11 +303,111 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	for _, orderBy := range []string{MempoolOrderFIFO, MempoolOrderPriority, MempoolOrderSenderNonce} {
+		cfg.OrderBy = orderBy
+		assert.NoError(t, cfg.ValidateBasic())
+	}
+	cfg.OrderBy = "invalid"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestMempoolConfigCategoryQuotas(t *testing.T) {
+	cfg := TestMempoolConfig()
+	quotas, err := cfg.CategoryMaxBytes()
+	require.NoError(t, err)
+	assert.Empty(t, quotas)
+
+	cfg.CategoryQuotas = "oracle:100, ibc:transfer:0,"
+	assert.NoError(t, cfg.ValidateBasic())
+	quotas, err = cfg.CategoryMaxBytes()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"oracle": 100, "ibc:transfer": 0}, quotas)
+
+	for _, quotas := range []string{"oracle", ":100", "oracle:-1", "oracle:abc", "oracle:1,oracle:2"} {
+		cfg.CategoryQuotas = quotas
+		assert.Error(t, cfg.ValidateBasic(), quotas)
+	}
 }
 
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.SnapshotInterval = 100
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.SnapshotKeepRecent = 0
+	require.Error(t, cfg.ValidateBasic())
+
+	cfg = TestStateSyncConfig()
+	cfg.AdvertiseJitter = -time.Second
+	require.Error(t, cfg.ValidateBasic())
+	cfg = TestStateSyncConfig()
+	cfg.AdvertiseInterval = -time.Second
+	require.Error(t, cfg.ValidateBasic())
+	cfg = TestStateSyncConfig()
+	cfg.MaxClockDrift = -time.Second
+	require.Error(t, cfg.ValidateBasic())
+
+	cfg = TestStateSyncConfig()
+	cfg.SnapshotPreference = "format"
+	cfg.PreferredSnapshotFormats = "2, 1"
+	require.NoError(t, cfg.ValidateBasic())
+	formats, err := cfg.PreferredSnapshotFormatList()
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{2, 1}, formats)
+	cfg.PreferredSnapshotFormats = "2,v1"
+	require.Error(t, cfg.ValidateBasic())
+	cfg = TestStateSyncConfig()
+	cfg.SnapshotPreference = "newest"
+	require.Error(t, cfg.ValidateBasic())
+
+	cfg = TestStateSyncConfig()
+	cfg.Enable = true
+	cfg.RPCServers = []string{"127.0.0.1:26657", "127.0.0.1:26658", "127.0.0.1:26659"}
+	cfg.TrustHeight = 1
+	cfg.TrustHash = "0123456789ABCDEF"
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.MinWitnesses = 2
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.MinWitnesses = 3
+	require.Error(t, cfg.ValidateBasic())
+	cfg.MinWitnesses = 0
+	require.Error(t, cfg.ValidateBasic())
+}
+
+func TestReplicaConfigValidateBasic(t *testing.T) {
+	cfg := TestReplicaConfig()
+	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.Enable = true
+	require.Error(t, cfg.ValidateBasic())
+	cfg.Upstreams = []string{"tcp://127.0.0.1:26657", ""}
+	require.Error(t, cfg.ValidateBasic())
+	cfg.Upstreams = []string{"tcp://127.0.0.1:26657", "tcp://127.0.0.1:36657"}
+	require.NoError(t, cfg.ValidateBasic())
+
+	cfg = TestReplicaConfig()
+	cfg.PollInterval = 0
+	require.Error(t, cfg.ValidateBasic())
+	cfg = TestReplicaConfig()
+	cfg.Timeout = -time.Second
+	require.Error(t, cfg.ValidateBasic())
+}
+
+func TestTxIndexConfigValidateBasic(t *testing.T) {
+	cfg := TestTxIndexConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.IndexEvents = []string{"transfer.*", "!transfer.memo"}
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.IndexEvents = []string{"transfer.*", "!"}
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestTxIndexConfig()
+	cfg.QueueSize = -1
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestFastSyncConfigValidateBasic(t *testing.T) {
@@ -140,6 +422,69 @@ func TestFastSyncConfigValidateBasic(t *testing.T) {
 	assert.Error(t, cfg.ValidateBasic())
 }
 
+func TestFastSyncConfigParseCheckpoints(t *testing.T) {
+	hash := strings.Repeat("AB", 32)
+	cfg := TestFastSyncConfig()
+	cfg.Checkpoints = []string{"10:" + hash, "20:" + strings.ToLower(hash)}
+	checkpoints, err := cfg.ParseCheckpoints()
+	require.NoError(t, err)
+	assert.Len(t, checkpoints, 2)
+	assert.Equal(t, bytes.Repeat([]byte{0xAB}, 32), checkpoints[10])
+	assert.NoError(t, cfg.ValidateBasic())
+
+	for _, pin := range []string{hash, "0:" + hash, "x:" + hash, "10:AB", "10:" + hash[:62] + "ZZ"} {
+		cfg.Checkpoints = []string{pin}
+		assert.Error(t, cfg.ValidateBasic(), pin)
+	}
+	cfg.Checkpoints = []string{"10:" + hash, "10:" + hash}
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestConsensusConfig_EmptyBlockDelay(t *testing.T) {
+	now := time.Now()
+	testcases := map[string]struct {
+		modify     func(*ConsensusConfig)
+		commitTime time.Time
+		delay      time.Duration
+		ok         bool
+	}{
+		"default":                {func(c *ConsensusConfig) {}, now, 0, true},
+		"no empty blocks":        {func(c *ConsensusConfig) { c.CreateEmptyBlocks = false }, now, 0, false},
+		"interval":               {func(c *ConsensusConfig) { c.CreateEmptyBlocksInterval = time.Second }, now, time.Second, true},
+		"target":                 {func(c *ConsensusConfig) { c.TargetBlockTime = 5 * time.Second }, now.Add(-time.Second), 4 * time.Second, true},
+		"target elapsed":         {func(c *ConsensusConfig) { c.TargetBlockTime = time.Second }, now.Add(-2 * time.Second), 0, true},
+		"target no commit time":  {func(c *ConsensusConfig) { c.TargetBlockTime = time.Second }, time.Time{}, 0, true},
+		"target no empty blocks": {func(c *ConsensusConfig) { c.CreateEmptyBlocks = false; c.TargetBlockTime = time.Second }, now, 0, false},
+		"interval and target": {func(c *ConsensusConfig) {
+			c.CreateEmptyBlocksInterval = 3 * time.Second
+			c.TargetBlockTime = 2 * time.Second
+		}, now, 3 * time.Second, true},
+	}
+	for desc, tc := range testcases {
+		tc := tc // appease linter
+		t.Run(desc, func(t *testing.T) {
+			cfg := DefaultConsensusConfig()
+			tc.modify(cfg)
+
+			delay, ok := cfg.EmptyBlockDelay(tc.commitTime, now)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.delay, delay)
+		})
+	}
+}
+
+func TestConsensusConfig_ForensicsDirPath(t *testing.T) {
+	cfg := DefaultConsensusConfig()
+	cfg.RootDir = "/home/tendermint"
+	assert.Equal(t, filepath.Join("/home/tendermint", "data", "forensics"), cfg.ForensicsDirPath())
+
+	cfg.ForensicsDir = "/var/forensics"
+	assert.Equal(t, "/var/forensics", cfg.ForensicsDirPath())
+
+	cfg.ForensicsDir = ""
+	assert.Equal(t, "", cfg.ForensicsDirPath())
+}
+
 func TestConsensusConfig_ValidateBasic(t *testing.T) {
 	// nolint: lll
 	testcases := map[string]struct {
@@ -164,7 +509,34 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerGossipSleepDuration negative":     {func(c *ConsensusConfig) { c.PeerGossipSleepDuration = -1 }, true},
 		"PeerQueryMaj23SleepDuration":          {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
+		"PeerCatchupLagThreshold":              {func(c *ConsensusConfig) { c.PeerCatchupLagThreshold = 5 }, false},
+		"PeerCatchupLagThreshold negative":     {func(c *ConsensusConfig) { c.PeerCatchupLagThreshold = -1 }, true},
+		"PeerCatchupSleepDuration":             {func(c *ConsensusConfig) { c.PeerCatchupSleepDuration = time.Second }, false},
+		"PeerCatchupSleepDuration negative":    {func(c *ConsensusConfig) { c.PeerCatchupSleepDuration = -1 }, true},
+		"BlockPartFanout":                      {func(c *ConsensusConfig) { c.BlockPartFanout = 4 }, false},
+		"BlockPartFanout negative":             {func(c *ConsensusConfig) { c.BlockPartFanout = -1 }, true},
+		"BlockPartFanoutTimeout negative":      {func(c *ConsensusConfig) { c.BlockPartFanoutTimeout = -1 }, true},
+		"BlockPartSelection rarest_first":      {func(c *ConsensusConfig) { c.BlockPartSelection = "rarest_first" }, false},
+		"BlockPartSelection unknown":           {func(c *ConsensusConfig) { c.BlockPartSelection = "sequential" }, true},
+		"PeerReplayWindow negative":            {func(c *ConsensusConfig) { c.PeerReplayWindow = -1 }, true},
+		"PeerMaxDuplicates negative":           {func(c *ConsensusConfig) { c.PeerMaxDuplicates = -1 }, true},
+		"MsgCacheSize negative":                {func(c *ConsensusConfig) { c.MsgCacheSize = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"TargetBlockTime":                      {func(c *ConsensusConfig) { c.TargetBlockTime = time.Second }, false},
+		"TargetBlockTime negative":             {func(c *ConsensusConfig) { c.TargetBlockTime = -1 }, true},
+		"LivenessMissedBlocks":                 {func(c *ConsensusConfig) { c.LivenessMissedBlocks = 10 }, false},
+		"LivenessMissedBlocks negative":        {func(c *ConsensusConfig) { c.LivenessMissedBlocks = -1 }, true},
+		"LivenessWebhookURL":                   {func(c *ConsensusConfig) { c.LivenessWebhookURL = "http://localhost:8080/alert" }, false},
+		"LivenessWebhookURL invalid":           {func(c *ConsensusConfig) { c.LivenessWebhookURL = "localhost" }, true},
+		"BlockBuilderAddress":                  {func(c *ConsensusConfig) { c.BlockBuilderAddress = "http://127.0.0.1:26680" }, false},
+		"BlockBuilderAddress invalid":          {func(c *ConsensusConfig) { c.BlockBuilderAddress = "builder" }, true},
+		"TimestampAuditHeights negative":       {func(c *ConsensusConfig) { c.TimestampAuditHeights = -1 }, true},
+		"FutureMsgBufferSize negative":         {func(c *ConsensusConfig) { c.FutureMsgBufferSize = -1 }, true},
+		"FutureMsgPeerQuota negative":          {func(c *ConsensusConfig) { c.FutureMsgPeerQuota = -1 }, true},
+		"BlockBuilderTimeout zero": {func(c *ConsensusConfig) {
+			c.BlockBuilderAddress = "http://127.0.0.1:26680"
+			c.BlockBuilderTimeout = 0
+		}, true},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
@@ -189,4 +561,57 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	// tamper with maximum open connections
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.MaxOpenConnections = 0
+
+	cfg.SlowABCICallThreshold = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SlowABCICallThreshold = 0
+
+	cfg.CheckpointedMetrics = []string{"consensus_missed_rounds"}
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.MetricsCheckpointInterval = 0
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestAlertsConfigValidateBasic(t *testing.T) {
+	cfg := TestAlertsConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.False(t, cfg.Enabled())
+
+	cfg.Webhooks = []string{"http://localhost:8080/alerts"}
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.True(t, cfg.Enabled())
+
+	cfg.Webhooks = []string{"localhost"}
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestAlertsConfig()
+	cfg.MinFreeDiskMB = -1
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestOperatorConfigValidateBasic(t *testing.T) {
+	cfg := TestOperatorConfig()
+	cfg.Website = "https://validator.example.com"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.Details = strings.Repeat("x", MaxOperatorDetailsLength+1)
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestOperatorConfig()
+	cfg.MinUpdateInterval = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestOperatorConfig()
+	cfg.SentryNodeIDs = " id1, id2,,"
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, []string{"id1", "id2"}, cfg.SentryNodeIDList())
+	cfg.SentryNodeIDs = strings.Repeat("id,", MaxOperatorSentryNodeIDs+1)
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestOperatorConfig()
+	cfg.SentryOf = strings.Repeat("AB", 20)
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.SentryOf = "AB"
+	assert.Error(t, cfg.ValidateBasic())
 }
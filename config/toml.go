@@ -117,12 +117,66 @@ db_backend = "{{ .BaseConfig.DBBackend }}"
 # Database directory
 db_dir = "{{ js .BaseConfig.DBPath }}"
 
+# Directories of the blockstore, state and tx_index databases, e.g. to keep
+# the blockstore on a cheaper disk. Empty means db_dir. The node refuses to
+# start if a database is still in the former directory: move it first.
+blockstore_db_dir = "{{ js .BaseConfig.BlockStoreDBPath }}"
+state_db_dir = "{{ js .BaseConfig.StateDBPath }}"
+tx_index_db_dir = "{{ js .BaseConfig.TxIndexDBPath }}"
+
+# Daily off-peak window, in UTC (e.g. "02:00-04:00"), during which the
+# goleveldb databases (blockstore, state, tx_index, evidence) are compacted,
+# reclaiming the disk space freed by pruning. Empty disables the compaction.
+db_compaction_window = "{{ .BaseConfig.DBCompactionWindow }}"
+
+# Minimum interval between two compactions
+db_compaction_interval = "{{ .BaseConfig.DBCompactionInterval }}"
+
+# Free space of the disk of the data directory, or of one of the *_db_dir
+# directories, in MB, below which the databases are compacted and the
+# snapshot archive is pruned down to the latest snapshot, every 10 minutes at
+# most (0 - disabled)
+disk_low_free_mb = {{ .BaseConfig.DiskLowFreeMB }}
+
+# Free space, in MB, below which the node also stops accepting and dialing new
+# peers, until it's back above disk_low_free_mb, rather than letting the
+# databases run out of space (0 - disabled). Must not exceed disk_low_free_mb.
+disk_critical_free_mb = {{ .BaseConfig.DiskCriticalFreeMB }}
+
+# Number of heights for which the state store keeps the validator sets and the
+# consensus params in memory, saving the database reads of the block
+# verifications and RPC calls (0 - disabled)
+state_store_cache_size = {{ .BaseConfig.StateStoreCacheSize }}
+
+# Comma-separated list of the parts of the ABCI responses kept, compactly, when
+# the app prunes them (see ResponseCommit.RetainHeight) and still returned by
+# /block_results: the events of the listed types, and the validator and
+# consensus param updates if "validator_updates" and "consensus_param_updates"
+# are listed. E.g. "validator_updates,consensus_param_updates,proposal_passed".
+pruning_exemptions = "{{ StringsJoin .BaseConfig.PruningExemptions "," }}"
+
+# Number of latest blocks whose block parts (i.e. transactions) are kept by
+# the blockstore, the headers and commits of the older blocks being kept until
+# the app prunes them (0 - all of them). The parts are pruned in the background
+# once a block is committed, so only the blocks the app already committed are
+# pruned: if the app loses its state, the node can't replay them to it on
+# restart. It must also cover the blocks not yet indexed, and the ones the
+# peers fast sync from this node.
+block_parts_retain_heights = {{ .BaseConfig.BlockPartsRetainHeights }}
+
 # Output level for logging, including package level options
 log_level = "{{ .BaseConfig.LogLevel }}"
 
 # Output format: 'plain' (colored text) or 'json'
 log_format = "{{ .BaseConfig.LogFormat }}"
 
+# Only 1 of every log_sample_rate occurrences of the log_sampled_messages
+# is logged, after the first one of each log_sample_window, e.g. to make
+# the p2p debug logs usable on busy nodes. 0 or 1 logs all of them.
+log_sample_rate = {{ .BaseConfig.LogSampleRate }}
+log_sample_window = "{{ .BaseConfig.LogSampleWindow }}"
+log_sampled_messages = "{{ StringsJoin .BaseConfig.LogSampledMessages "," }}"
+
 ##### additional base config options #####
 
 # Path to the JSON file containing the initial validator set and other meta data
@@ -136,14 +190,31 @@ priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 
 # TCP or UNIX socket address for Tendermint to listen on for
 # connections from an external PrivValidator process
+# or, prefixed with grpc://, the address of the gRPC server of the external
+# PrivValidator process to dial
 priv_validator_laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
 
+# Certificate authority used to verify the gRPC server of the external
+# PrivValidator process, and the certificate and key this node presents to
+# it. If empty, the connection isn't encrypted, so the process must be on
+# the same host or on a private network.
+priv_validator_grpc_ca_file = "{{ js .BaseConfig.PrivValidatorGRPCCAFile }}"
+priv_validator_grpc_cert_file = "{{ js .BaseConfig.PrivValidatorGRPCCertFile }}"
+priv_validator_grpc_key_file = "{{ js .BaseConfig.PrivValidatorGRPCKeyFile }}"
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node_key_file = "{{ js .BaseConfig.NodeKey }}"
 
 # Mechanism to connect to the ABCI application: socket | grpc
 abci = "{{ .BaseConfig.ABCI }}"
 
+# Maximum time the async requests to the app are buffered before being flushed, on the
+# consensus and the mempool connections of the socket transport (0 - the client's default),
+# so that block execution isn't delayed by the batching of the CheckTx requests. The
+# consensus requests are also handled first by the app servers of this repository.
+abci_consensus_flush_throttle = "{{ .BaseConfig.ABCIConsensusFlushThrottle }}"
+abci_mempool_flush_throttle = "{{ .BaseConfig.ABCIMempoolFlushThrottle }}"
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter_peers = {{ .BaseConfig.FilterPeers }}
@@ -185,6 +256,22 @@ grpc_laddr = "{{ .RPC.GRPCListenAddress }}"
 # 1024 - 40 - 10 - 50 = 924 = ~900
 grpc_max_open_connections = {{ .RPC.GRPCMaxOpenConnections }}
 
+# After this duration without any activity, the gRPC server pings the client
+# to check the connection is still alive.
+grpc_keepalive_time = "{{ .RPC.GRPCKeepaliveTime }}"
+
+# How long the gRPC server waits for a keepalive ping to be acknowledged before
+# closing the connection.
+grpc_keepalive_timeout = "{{ .RPC.GRPCKeepaliveTimeout }}"
+
+# Minimum time between keepalive pings sent by gRPC clients. Clients pinging
+# more often are disconnected.
+grpc_keepalive_min_time = "{{ .RPC.GRPCKeepaliveMinTime }}"
+
+# Enable the gRPC server reflection service, which lets clients (e.g. grpcurl)
+# discover the available services.
+grpc_reflection = {{ .RPC.GRPCReflection }}
+
 # Activate unsafe RPC commands like /dial_seeds and /unsafe_flush_mempool
 unsafe = {{ .RPC.Unsafe }}
 
@@ -207,18 +294,54 @@ max_subscription_clients = {{ .RPC.MaxSubscriptionClients }}
 # the estimated # maximum number of broadcast_tx_commit calls per block.
 max_subscriptions_per_client = {{ .RPC.MaxSubscriptionsPerClient }}
 
+# Maximum number of queries a single /subscribe call can combine with OR (see the queries param),
+# each being matched against every published event.
+# 0 - unlimited.
+max_queries_per_subscription = {{ .RPC.MaxQueriesPerSubscription }}
+
+# Maximum number of simultaneous WebSocket connections.
+# 0 - unlimited (bounded by max_open_connections).
+max_websocket_connections = {{ .RPC.MaxWebsocketConnections }}
+
+# Maximum number of events per second sent to a given client (i.e. WebSocket
+# connection), over all of its subscriptions. Further events are dropped, and
+# the client is notified with a "Quota exceeded" error.
+# 0 - unlimited.
+max_events_per_second_per_client = {{ .RPC.MaxEventsPerSecondPerClient }}
+
+# How often a keepalive comment is sent to the /subscribe_sse clients, so that
+# proxies don't close idle streams.
+# 0 - no keepalives.
+sse_keepalive_interval = "{{ .RPC.SSEKeepAliveInterval }}"
+
 # How long to wait for a tx to be committed during /broadcast_tx_commit.
 # WARNING: Using a value larger than 10s will result in increasing the
 # global HTTP write timeout, which applies to all connections and endpoints.
 # See https://github.com/tendermint/tendermint/issues/3435
 timeout_broadcast_tx_commit = "{{ .RPC.TimeoutBroadcastTxCommit }}"
 
+# Maximum number of idempotency keys of /broadcast_tx_* requests remembered,
+# along with their results, so that the retries of a request get the original
+# result instead of submitting the tx again.
+# 0 - disables the idempotency keys.
+idempotency_cache_size = {{ .RPC.IdempotencyCacheSize }}
+
+# How long an idempotency key is remembered after its request completed.
+idempotency_key_ttl = "{{ .RPC.IdempotencyKeyTTL }}"
+
 # Maximum size of request body, in bytes
 max_body_bytes = {{ .RPC.MaxBodyBytes }}
 
 # Maximum size of request header, in bytes
 max_header_bytes = {{ .RPC.MaxHeaderBytes }}
 
+# Maximum size of the cache of responses to queries for immutable data
+# (/block, /block_results, /commit and /validators at finalized heights), in bytes.
+# Public RPC nodes may want to enable it, so popular blocks are not loaded
+# from the database and marshaled again on every request.
+# 0 disables the cache.
+response_cache_size = {{ .RPC.ResponseCacheSize }}
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Migth be either absolute path or path related to tendermint's config directory.
 # If the certificate is signed by a certificate authority,
@@ -276,9 +399,57 @@ max_num_outbound_peers = {{ .P2P.MaxNumOutboundPeers }}
 # List of node IDs, to which a connection will be (re)established ignoring any existing limits
 unconditional_peer_ids = "{{ .P2P.UnconditionalPeerIDs }}"
 
+# Comma separated list of node IDs of the high-priority peers, e.g. the other
+# validators and sentries. They are unconditional peers, never disconnected for
+# slot pressure (including by seed nodes), get send queues
+# priority_send_queue_factor times larger, and, if persistent, are reconnected
+# to every priority_reconnect_interval without ever giving up.
+priority_peer_ids = "{{ .P2P.PriorityPeerIDs }}"
+priority_send_queue_factor = {{ .P2P.PrioritySendQueueFactor }}
+priority_reconnect_interval = "{{ .P2P.PriorityReconnectInterval }}"
+
+# Comma separated list of node IDs allowed to connect to this node (if empty,
+# all peers are allowed), and of node IDs denied. They apply to all peers,
+# including the persistent and unconditional ones, and can be reloaded at
+# runtime via the unsafe /set_peer_lists RPC endpoint.
+allowed_peers = "{{ .P2P.AllowedPeers }}"
+denied_peers = "{{ .P2P.DeniedPeers }}"
+
 # Maximum pause when redialing a persistent peer (if zero, exponential backoff is used)
 persistent_peers_max_dial_period = "{{ .P2P.PersistentPeersMaxDialPeriod }}"
 
+# Reconnection to a persistent peer: first reconnect_attempts attempts, reconnect_interval
+# apart, then reconnect_backoff_attempts attempts with an exponential backoff, starting at
+# reconnect_backoff_interval and multiplied by reconnect_backoff_multiplier after each attempt.
+reconnect_attempts = {{ .P2P.ReconnectAttempts }}
+reconnect_interval = "{{ .P2P.ReconnectInterval }}"
+reconnect_backoff_attempts = {{ .P2P.ReconnectBackoffAttempts }}
+reconnect_backoff_interval = "{{ .P2P.ReconnectBackoffInterval }}"
+reconnect_backoff_multiplier = {{ .P2P.ReconnectBackoffMultiplier }}
+
+# Maximum random delay added to each pause between reconnection attempts, and before dialing
+# any peer, so that nodes don't all dial at once
+dial_jitter = "{{ .P2P.DialJitter }}"
+
+# Maximum number of attempts to reconnect to a persistent peer, after which it's reported as
+# unreachable (0 - reconnect_attempts + reconnect_backoff_attempts). It can still be redialed
+# via the unsafe /dial_peers RPC endpoint.
+persistent_peers_max_reconnect_attempts = {{ .P2P.PersistentPeersMaxReconnectAttempts }}
+
+# Maximum number of times a given peer is dialed per hour, so that a flapping peer doesn't
+# monopolize the dialer (0 - unlimited)
+max_dials_per_peer_per_hour = {{ .P2P.MaxDialsPerPeerPerHour }}
+
+# Minimum versions of the block, p2p and app protocols of the peers (0 - any version).
+# The peers below them are rejected, unless min_peer_version_warn_only is set, in which
+# case they are only logged and counted by the peers_below_min_version metric, e.g. to
+# phase out old versions first by watching the metric, then by refusing them.
+# Note the block version of the peers must already match ours.
+min_peer_block_version = {{ .P2P.MinPeerBlockVersion }}
+min_peer_p2p_version = {{ .P2P.MinPeerP2PVersion }}
+min_peer_app_version = {{ .P2P.MinPeerAppVersion }}
+min_peer_version_warn_only = {{ .P2P.MinPeerVersionWarnOnly }}
+
 # Time to wait before flushing messages out on the connection
 flush_throttle_timeout = "{{ .P2P.FlushThrottleTimeout }}"
 
@@ -306,10 +477,101 @@ private_peer_ids = "{{ .P2P.PrivatePeerIDs }}"
 # Toggle to disable guard against peers connecting from the same ip.
 allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
 
-# Peer connection configuration.
+# Peer connection configuration. The handshake timeout applies to each step of
+# the handshake (key exchange, then NodeInfo exchange).
 handshake_timeout = "{{ .P2P.HandshakeTimeout }}"
 dial_timeout = "{{ .P2P.DialTimeout }}"
 
+# Maximum number of incoming connections being handshaked at the same time.
+# Further connections are closed right away, so that a flood of slow
+# handshakes can't exhaust the file descriptors.
+# 0 - unlimited.
+max_concurrent_handshakes = {{ .P2P.MaxConcurrentHandshakes }}
+
+# Maximum number of incoming connections accepted from a given IP per second.
+# Further connections are closed right away.
+# 0 - unlimited.
+max_accept_rate_per_ip = {{ .P2P.MaxAcceptRatePerIP }}
+
+# Rotate the keys of the encrypted peer connections, with a fresh key exchange,
+# after sending this many bytes or after this interval (0 - disabled).
+# Peers which don't support rekeying drop the connection.
+secret_conn_rekey_bytes = {{ .P2P.SecretConnRekeyBytes }}
+secret_conn_rekey_interval = "{{ .P2P.SecretConnRekeyInterval }}"
+
+# Sign the messages sent on the channels listed in message_auth_channels with
+# the node key, so that the misbehavior of a peer observed on those channels
+# can be attributed to it by third parties, not just punished with a local
+# ban. The messages are only signed for the peers which enabled it on the same
+# channels. It adds about 150 bytes and a signature to every message. The last
+# signed messages of a peer disconnected for misbehaving are kept in its peer
+# stats (see net_info).
+message_auth = {{ .P2P.MessageAuth }}
+
+# Comma separated list of channel IDs (e.g. "0x20,0x21") whose messages are
+# signed (default: the consensus channels)
+message_auth_channels = "{{ .P2P.MessageAuthChannels }}"
+
+# Run the connections over mutually-authenticated TLS, below the secret
+# connections, only accepting the peers presenting one of the certificates
+# listed in tls_pinned_certs. The peers must enable it too.
+tls = {{ .P2P.TLS }}
+
+# Certificate and key PEM files of the node, relative to the home directory
+# (generated with "tendermint gen-p2p-tls-cert")
+tls_cert_file = "{{ js .P2P.TLSCertFile }}"
+tls_key_file = "{{ js .P2P.TLSKeyFile }}"
+
+# Comma separated list of <node ID>@<SHA-256 fingerprint of the certificate>
+# of the peers. A peer is only accepted if its certificate is listed, and
+# pinned to its node ID.
+tls_pinned_certs = "{{ .P2P.TLSPinnedCerts }}"
+
+# Record the envelopes sent to and received from the peers (time, direction,
+# peer, channel, message type and size) into this file, relative to the home
+# directory, for debugging. The records are read with the
+# "tendermint debug capture" command.
+# Empty - disabled.
+capture_file = "{{ .P2P.CaptureFile }}"
+
+# Comma separated list of channel IDs (e.g. "0x20,0x21") whose envelopes are
+# recorded. If empty, all channels are recorded.
+capture_channels = "{{ .P2P.CaptureChannels }}"
+
+# Comma separated list of IDs of the peers whose envelopes are recorded.
+# If empty, all peers are recorded.
+capture_peer_ids = "{{ .P2P.CapturePeerIDs }}"
+
+# Record only 1 of every capture_sample_rate envelopes matching the filters
+# above.
+capture_sample_rate = {{ .P2P.CaptureSampleRate }}
+
+# Record the payloads of the envelopes too.
+capture_payload = {{ .P2P.CapturePayload }}
+
+# Maximum total size in bytes of the capture files. The file is rotated, and
+# the oldest files are deleted beyond this size.
+capture_max_size = {{ .P2P.CaptureMaxSize }}
+
+# Testing only: inject latency, reordering, duplication and corruption into the
+# messages sent to peers, choosing the faults with a RNG seeded with
+# test_chaos_seed. NEVER enable in production.
+test_chaos = {{ .P2P.TestChaos }}
+test_chaos_seed = {{ .P2P.TestChaosSeed }}
+
+# Comma separated list of channel IDs (e.g. "0x20,0x21") to inject faults into.
+# If empty, all channels are affected.
+test_chaos_channels = "{{ .P2P.TestChaosChannels }}"
+
+# Maximum latency added to every message
+test_chaos_max_latency = "{{ .P2P.TestChaosMaxLatency }}"
+
+# Probabilities for a message to be sent after the next one on the channel, to
+# be sent twice, and to have a random bit flipped
+test_chaos_reorder_rate = {{ .P2P.TestChaosReorderRate }}
+test_chaos_duplicate_rate = {{ .P2P.TestChaosDuplicateRate }}
+test_chaos_corrupt_rate = {{ .P2P.TestChaosCorruptRate }}
+
 #######################################################
 ###          Mempool Configurattion Option          ###
 #######################################################
@@ -338,6 +600,47 @@ max_tx_bytes = {{ .Mempool.MaxTxBytes }}
 # Including space needed by encoding (one varint per transaction).
 max_batch_bytes = {{ .Mempool.MaxBatchBytes }}
 
+# Minimum gas price (as reported by the app in ResponseCheckTx.GasPrice) a
+# transaction must pay to be admitted into the mempool. 0 disables the check.
+min_gas_price = {{ .Mempool.MinGasPrice }}
+
+# If > 0, the minimum gas price is adjusted after each block: it increases
+# when blocks use more gas than target_block_gas and decreases (down to
+# min_gas_price) when they use less. The current value is exposed via /status.
+target_block_gas = {{ .Mempool.TargetBlockGas }}
+
+# Order in which transactions are reaped from the mempool into block proposals:
+#   1) "fifo" - in the order they were added to the mempool
+#   2) "priority" - by decreasing gas price (ResponseCheckTx.GasPrice), ties
+#   are broken by the order they were added
+#   3) "sender-nonce" - grouped by sender (ResponseCheckTx.Sender), senders
+#   ordered by their first tx added, and by increasing nonce
+#   (ResponseCheckTx.Nonce) within each sender
+# The order only depends on the txs in the mempool and the order they were
+# added, so it can differ between nodes.
+order_by = "{{ .Mempool.OrderBy }}"
+
+# Comma separated list of <category>:<max bytes>, limiting the total size of
+# the txs of each category (ResponseCheckTx.Category) reaped into a block
+# proposal, e.g. "oracle:65536,airdrop:1048576". The txs over the quota of
+# their category are left in the mempool for the next blocks, along with the
+# later txs of their sender with order_by = "sender-nonce", and the
+# uncategorized txs are only limited by the block size.
+category_quotas = "{{ .Mempool.CategoryQuotas }}"
+
+# File (relative to the home directory, unless absolute) the txs in the mempool
+# are exported to when the node stops, and imported from when it starts, e.g. to
+# carry the pending txs over when migrating the node to a new machine. The
+# imported txs are checked with CheckTx again, and the file is removed once
+# imported. Empty disables the export.
+export_file = "{{ js .Mempool.ExportFile }}"
+
+# File (relative to the home directory, unless absolute) the peers whose tx
+# relay is disabled with the unsafe /set_peer_tx_relay RPC endpoint are
+# persisted in, so that the setting survives restarts. Empty disables the
+# persistence.
+tx_relay_file = "{{ js .Mempool.TxRelayFile }}"
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -360,13 +663,52 @@ trust_height = {{ .StateSync.TrustHeight }}
 trust_hash = "{{ .StateSync.TrustHash }}"
 trust_period = "{{ .StateSync.TrustPeriod }}"
 
+# Number of rpc_servers, after the first (the primary), which must return the same headers as the
+# primary for the height of a snapshot, and those around it, for the snapshot to be trusted. Any
+# server returning a different header aborts state sync. The headers of a snapshot must also be
+# within the trust_period.
+min_witnesses = {{ .StateSync.MinWitnesses }}
+
+# Maximum time the verified headers can be in the future, to account for clock drift.
+max_clock_drift = "{{ .StateSync.MaxClockDrift }}"
+
 # Time to spend discovering snapshots before initiating a restore.
 discovery_time = "{{ .StateSync.DiscoveryTime }}"
 
+# Order in which the discovered snapshots are attempted:
+#   1) "height" - the greatest height first (default)
+#   2) "format" - the preferred format first (see preferred_snapshot_formats)
+#   3) "peers" - the snapshots advertised by the most peers first
+# Ties are broken by the other criteria, in that order.
+snapshot_preference = "{{ .StateSync.SnapshotPreference }}"
+
+# Comma separated list of snapshot formats in order of preference (e.g. "2,1").
+# The unlisted formats come last, the greatest first.
+preferred_snapshot_formats = "{{ .StateSync.PreferredSnapshotFormats }}"
+
 # Temporary directory for state sync snapshot chunks, defaults to the OS tempdir (typically /tmp).
 # Will create a new, randomly named directory within, and remove it when done.
 temp_dir = "{{ .StateSync.TempDir }}"
 
+# Interval in heights at which to archive the snapshots taken by the app (as listed by the ABCI
+# ListSnapshots method) in snapshot_dir, along with the light blocks and consensus params needed
+# to restore them, so they don't depend on the app's own snapshot retention. 0 disables archiving.
+snapshot_interval = {{ .StateSync.SnapshotInterval }}
+
+# Number of most recent archived snapshots to keep.
+snapshot_keep_recent = {{ .StateSync.SnapshotKeepRecent }}
+
+# Directory to archive snapshots in.
+snapshot_dir = "{{ js .StateSync.SnapshotDir }}"
+
+# Maximum random delay before advertising the local snapshots to a peer requesting them, spreading
+# the load when many peers request snapshots at once (e.g. after a new snapshot height).
+advertise_jitter = "{{ .StateSync.AdvertiseJitter }}"
+
+# Minimum interval between snapshot advertisements to the same peer. Requests received in between
+# are answered once the interval has elapsed.
+advertise_interval = "{{ .StateSync.AdvertiseInterval }}"
+
 #######################################################
 ###       Fast Sync Configuration Connections       ###
 #######################################################
@@ -377,6 +719,32 @@ temp_dir = "{{ .StateSync.TempDir }}"
 #   2) "v2" - complete redesign of v0, optimized for testability & readability
 version = "{{ .FastSync.Version }}"
 
+# Comma separated list of known-good blocks, as "height:hash" pins (the hash in
+# hex): fast sync and the replay of the stored blocks refuse the chains
+# contradicting them, e.g. a forged fork served to a fresh node by its peers.
+checkpoints = "{{ StringsJoin .FastSync.Checkpoints "," }}"
+
+#######################################################
+###          Read Replica Configuration Options     ###
+#######################################################
+[replica]
+
+# In the read replica mode, the node doesn't take part in the p2p network. It
+# follows the chain of trusted upstream nodes over RPC instead, verifying the
+# commits of their blocks with its validator set, applying them to the local app
+# and serving the RPC, e.g. to scale the query infrastructure.
+enable = {{ .Replica.Enable }}
+
+# Comma-separated list of the RPC addresses of the trusted upstream nodes, the
+# next one being used when one fails.
+upstreams = "{{ StringsJoin .Replica.Upstreams "," }}"
+
+# Interval at which the upstreams are polled for the next block, once caught up.
+poll_interval = "{{ .Replica.PollInterval }}"
+
+# Maximum time to fetch a block and its commit.
+timeout = "{{ .Replica.Timeout }}"
+
 #######################################################
 ###         Consensus Configuration Options         ###
 #######################################################
@@ -414,10 +782,95 @@ skip_timeout_commit = {{ .Consensus.SkipTimeoutCommit }}
 create_empty_blocks = {{ .Consensus.CreateEmptyBlocks }}
 create_empty_blocks_interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
 
+# Minimum time between committing a block and proposing the next one, if the
+# next block would be empty. Unlike timeout_commit, blocks with txs are not
+# delayed. E.g. "5s" targets 5 second blocks while still including txs as soon
+# as they arrive. Has no effect if create_empty_blocks = false and
+# create_empty_blocks_interval = "0s".
+target_block_time = "{{ .Consensus.TargetBlockTime }}"
+
 # Reactor sleep duration parameters
 peer_gossip_sleep_duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# Peers which are at least this many heights behind are only sent catch-up
+# commits and block parts (0 disables).
+peer_catchup_lag_threshold = {{ .Consensus.PeerCatchupLagThreshold }}
+
+# Minimum time between two catch-up messages sent to a lagging peer.
+peer_catchup_sleep_duration = "{{ .Consensus.PeerCatchupSleepDuration }}"
+
+# Max number of peers each block part of the current round is sent to (0 - all
+# peers), the other peers getting it from them. A part is sent to more peers
+# block_part_fanout_timeout after reaching the fan-out, in case they don't
+# relay it.
+block_part_fanout = {{ .Consensus.BlockPartFanout }}
+block_part_fanout_timeout = "{{ .Consensus.BlockPartFanoutTimeout }}"
+
+# Order in which the block parts a peer lacks are sent:
+#   1) "random" - in random order
+#   2) "rarest_first" - the parts held by the fewest peers first, improving the
+#   propagation of large blocks in sparse topologies
+block_part_selection = "{{ .Consensus.BlockPartSelection }}"
+
+# Number of the last vote and data messages received from each peer during the
+# current consensus step which are remembered (0 disables), so that the exact
+# duplicates are dropped before being decoded. A peer is disconnected once it sent more than peer_max_duplicates
+# duplicates during a step (0 - never).
+peer_replay_window = {{ .Consensus.PeerReplayWindow }}
+peer_max_duplicates = {{ .Consensus.PeerMaxDuplicates }}
+
+# Number of the last votes and block parts added to the consensus state which
+# are remembered (0 disables), so that the identical messages relayed by the
+# other peers are dropped before being verified and processed again.
+msg_cache_size = {{ .Consensus.MsgCacheSize }}
+
+# Maximum number of proposals, block parts and votes received for the next
+# height, or for a future round of the current height, which are buffered until
+# consensus reaches their height and round, so that the peers ahead don't have
+# to send them again (0 - they're dropped). Once full, the messages of the
+# furthest heights and rounds are dropped first. Only the messages whose
+# signature (or, for the block parts, proof against a buffered proposal) is
+# valid are buffered.
+future_msg_buffer_size = {{ .Consensus.FutureMsgBufferSize }}
+
+# Maximum number of the buffered future messages received from a given peer
+# (0 - unlimited).
+future_msg_peer_quota = {{ .Consensus.FutureMsgPeerQuota }}
+
+# Consider the local validator down once it has missed this many consecutive
+# blocks while in the validator set (0 disables), e.g. because of privval errors,
+# an unreachable remote signer or a node behind the head of the chain. An error
+# is then logged and, if set, a JSON alert is POSTed to liveness_webhook_url.
+liveness_missed_blocks = {{ .Consensus.LivenessMissedBlocks }}
+liveness_webhook_url = "{{ .Consensus.LivenessWebhookURL }}"
+
+# Exit the process when the validator is down, so that external tooling can
+# fail over to another node.
+liveness_exit = {{ .Consensus.LivenessExit }}
+
+# Address of the external block builder (e.g. "http://127.0.0.1:26680") the
+# txs of the blocks proposed by this node are requested from, over JSON-RPC
+# (build_block method). If it doesn't answer within block_builder_timeout, or
+# returns an invalid payload, the txs are reaped from the mempool. The timeout
+# must be well below timeout_propose. Empty - disabled.
+block_builder_address = "{{ .Consensus.BlockBuilderAddress }}"
+block_builder_timeout = "{{ .Consensus.BlockBuilderTimeout }}"
+
+# Directory of the forensic bundles (the last block, its ABCI responses, the
+# last WAL messages and both app hashes) written when +2/3 commit a different
+# app hash than the one computed by the app. The node then stops its p2p layer,
+# keeping the RPC up so that the operator can investigate. Empty - disabled.
+forensics_dir = "{{ js .Consensus.ForensicsDir }}"
+
+# Record the timestamps of the last timestamp_audit_heights blocks committed by
+# consensus: the timestamp of the proposal (from the clock of the proposer), the
+# local time at which the block was received, and the block time (the median
+# of the precommit timestamps). The /timestamp_drift RPC endpoint then reports
+# the drift statistics, to diagnose the validators with a broken clock before
+# they cause round failures. 0 - disabled.
+timestamp_audit_heights = {{ .Consensus.TimestampAuditHeights }}
+
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
 #######################################################
@@ -434,6 +887,18 @@ peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 # 		- When "kv" is chosen "tx.height" and "tx.hash" will always be indexed.
 indexer = "{{ .TxIndex.Indexer }}"
 
+# Composite keys (type.key) of the event attributes to index with the "kv" indexer, each attribute
+# still having to be marked for indexing by the app. Patterns can use "*" wildcards
+# (e.g. "transfer.*"), and patterns prefixed with "!" exclude the matching keys (e.g. "!debug.*").
+# If only exclusions are given, all the other keys are indexed. Empty means all keys are indexed.
+# Example: ["transfer.*", "message.sender", "!transfer.memo"]
+index_events = [{{ range .TxIndex.IndexEvents }}{{ printf "%q, " . }}{{end}}]
+
+# Number of blocks waiting to be indexed, off the commit path, past which the commits wait
+# for the indexer to catch up (0 - the blocks are indexed as they are committed). The blocks
+# committed but not indexed before the node stopped are indexed on start.
+queue_size = {{ .TxIndex.QueueSize }}
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -455,6 +920,89 @@ max_open_connections = {{ .Instrumentation.MaxOpenConnections }}
 
 # Instrumentation namespace
 namespace = "{{ .Instrumentation.Namespace }}"
+
+# ABCI calls taking at least this long (e.g. "100ms") are logged, with the
+# height of the block and the hash of the tx they are made for.
+# 0 - disabled.
+slow_abci_call_threshold = "{{ .Instrumentation.SlowABCICallThreshold }}"
+
+# Cumulative metrics whose values are checkpointed into the node's DB, and
+# restored when it restarts, instead of starting from 0, e.g.
+# "consensus_missed_rounds,consensus_validator_missed_blocks". See the
+# documentation for the list of the metrics which can be checkpointed.
+checkpointed_metrics = "{{ StringsJoin .Instrumentation.CheckpointedMetrics "," }}"
+
+# How often the checkpointed metrics are saved.
+metrics_checkpoint_interval = "{{ .Instrumentation.MetricsCheckpointInterval }}"
+
+#######################################################
+###             Alerts Configuration Options        ###
+#######################################################
+[alerts]
+
+# URLs to POST alerts to, as JSON objects with the type, message, moniker and
+# time of the alert.
+# Example: ["https://alerts.example.com/tendermint"]
+webhooks = [{{ range .Alerts.Webhooks }}{{ printf "%q, " . }}{{end}}]
+
+# Shell command run for each alert, with the alert as JSON on its standard input
+# and in the ALERT_TYPE and ALERT_MESSAGE environment variables.
+command = "{{ .Alerts.Command }}"
+
+# Types of the alerts to send, among consensus_failure, app_hash_mismatch,
+# double_sign_prevented, disk_nearly_full, disk_full (see disk_critical_free_mb),
+# no_peers, validator_down (see consensus.liveness_missed_blocks) and
+# persistent_peer_unreachable (see p2p.persistent_peers_max_reconnect_attempts).
+# Empty means all types.
+types = [{{ range .Alerts.Types }}{{ printf "%q, " . }}{{end}}]
+
+# Maximum time to deliver an alert to each webhook or command.
+timeout = "{{ .Alerts.Timeout }}"
+
+# Alert when the free space of the data directory's disk, or of one of the
+# *_db_dir directories' disks, drops below this many megabytes (0 - disabled).
+min_free_disk_mb = {{ .Alerts.MinFreeDiskMB }}
+
+# Alert when the node has had no peers for this long (0 - disabled).
+no_peers_timeout = "{{ .Alerts.NoPeersTimeout }}"
+
+#######################################################
+###            Operator Configuration Options       ###
+#######################################################
+[operator]
+
+# If true, the node gossips the contact info of the validator operators, signed
+# with their validator keys, and exposes it via the /validator_info RPC endpoint.
+# The info of this node's validator is only signed with a local validator key
+# (not with priv_validator_laddr).
+gossip = {{ .Operator.Gossip }}
+
+# Contact info of the operator of this node's validator, gossiped alongside the
+# moniker (at most 140, 140 and 280 characters).
+website = "{{ .Operator.Website }}"
+security_contact = "{{ .Operator.SecurityContact }}"
+details = "{{ .Operator.Details }}"
+
+# Comma separated list of the node IDs of the sentries of this node's validator
+# (at most 16), attested in its signed info: the other nodes can then tell the
+# sentries of the validators apart, and the /node_attestation RPC endpoint
+# returns the validators attesting a node. Each sentry must attest it too, see
+# sentry_of.
+sentry_node_ids = "{{ .Operator.SentryNodeIDs }}"
+
+# Hex address of the validator this node is a sentry of. The node attests it
+# with its node key, so that the other nodes trust the sentry_node_ids of the
+# validator listing this node.
+sentry_of = "{{ .Operator.SentryOf }}"
+
+# If true, the sentries listed by the validators of the current validator set,
+# which attested them, are made priority peers (see p2p.priority_peer_ids), at
+# most 64 in total.
+prioritize_sentries = {{ .Operator.PrioritizeSentries }}
+
+# Minimum time between two updates of the info of a validator. The updates
+# received sooner are dropped.
+min_update_interval = "{{ .Operator.MinUpdateInterval }}"
 `
 
 /****** these are for test settings ***********/
@@ -178,6 +178,20 @@ func (vss ValidatorStubsByPower) Swap(i, j int) {
 //-------------------------------------------------------------------------------
 // Functions for transitioning the consensus state
 
+// startTestState starts the state, with a WAL in a temporary directory, and
+// stops it at the end of the test.
+func startTestState(t *testing.T, cs *State) {
+	config := *cs.config
+	config.WalPath = filepath.Join(t.TempDir(), "wal")
+	cs.config = &config
+	require.NoError(t, cs.Start())
+	t.Cleanup(func() {
+		if err := cs.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
 func startTestRound(cs *State, height int64, round int32) {
 	cs.enterNewRound(height, round)
 	cs.startRoutines(0)
@@ -0,0 +1,150 @@
+package consensus
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+const (
+	// the max number of WAL messages written to a forensic bundle, the last
+	// ones being kept.
+	maxForensicWALMessages = 1000
+)
+
+// StateForensics makes the State write a forensic bundle into a new directory
+// of dir when +2/3 commit a block with a different app hash than the one
+// computed by the app, and then call inspect with the path of the bundle, e.g.
+// to stop the p2p layer and keep the RPC up for the investigation.
+func StateForensics(dir string, inspect func(bundle string)) StateOption {
+	return func(cs *State) {
+		cs.forensicsDir = dir
+		cs.inspect = inspect
+	}
+}
+
+// investigateAppHashMismatch writes the forensic bundle of the mismatch, if
+// enabled, and enters the inspect-only mode.
+func (cs *State) investigateAppHashMismatch(next *types.Block, mismatch sm.ErrAppHashMismatch) {
+	if cs.forensicsDir == "" {
+		return
+	}
+	bundle, err := cs.writeForensicBundle(next, mismatch)
+	if err != nil {
+		cs.Logger.Error("Failed to write the forensic bundle of the app hash mismatch", "err", err)
+	} else {
+		cs.Logger.Error("Wrote the forensic bundle of the app hash mismatch", "path", bundle)
+	}
+	if cs.inspect != nil {
+		cs.inspect(bundle)
+	}
+}
+
+// ForensicSummary is the summary of an app hash mismatch, written to the
+// summary.json file of the forensic bundle.
+type ForensicSummary struct {
+	ChainID string `json:"chain_id"`
+	// Height of the last block executed by the node.
+	Height    int64            `json:"height"`
+	BlockHash tmbytes.HexBytes `json:"block_hash"`
+	// App hash computed by the app after the last block.
+	AppHash tmbytes.HexBytes `json:"app_hash"`
+	// Header of the next block committed by +2/3, with their app hash.
+	CommittedHeader types.Header `json:"committed_header"`
+	Time            time.Time    `json:"time"`
+	// Errors while collecting the other files of the bundle.
+	Errors []string `json:"errors"`
+}
+
+// writeForensicBundle writes the summary of the app hash mismatch, the last
+// block and its ABCI responses, and the WAL messages since the block into a
+// new directory of cs.forensicsDir. It returns the path of the directory.
+func (cs *State) writeForensicBundle(next *types.Block, mismatch sm.ErrAppHashMismatch) (string, error) {
+	height, now := cs.state.LastBlockHeight, tmtime.Now()
+	bundle := filepath.Join(cs.forensicsDir,
+		fmt.Sprintf("app-hash-mismatch-%d-%s", height, now.Format("20060102T150405Z")))
+	if err := tmos.EnsureDir(bundle, 0700); err != nil {
+		return "", err
+	}
+
+	summary := ForensicSummary{
+		ChainID:         cs.state.ChainID,
+		Height:          height,
+		BlockHash:       cs.state.LastBlockID.Hash,
+		AppHash:         mismatch.Expected,
+		CommittedHeader: next.Header,
+		Time:            now,
+		Errors:          make([]string, 0),
+	}
+	collect := func(name string, v interface{}, err error) {
+		if err == nil {
+			err = writeJSONFile(filepath.Join(bundle, name), v)
+		}
+		if err != nil {
+			summary.Errors = append(summary.Errors, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+
+	block := cs.blockStore.LoadBlock(height)
+	var err error
+	if block == nil {
+		err = fmt.Errorf("block %d not found", height)
+	}
+	collect("block.json", block, err)
+
+	abciResponses, err := cs.blockExec.Store().LoadABCIResponses(height)
+	collect("abci_responses.json", abciResponses, err)
+
+	walMsgs, err := cs.lastWALMessages(height)
+	collect("wal.json", walMsgs, err)
+
+	return bundle, writeJSONFile(filepath.Join(bundle, "summary.json"), summary)
+}
+
+// lastWALMessages returns the last maxForensicWALMessages WAL messages
+// written after the given height ended.
+func (cs *State) lastWALMessages(height int64) ([]*TimedWALMessage, error) {
+	if err := cs.wal.FlushAndSync(); err != nil {
+		return nil, err
+	}
+	rd, found, err := cs.wal.SearchForEndHeight(height, &WALSearchOptions{IgnoreDataCorruptionErrors: true})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("end of height %d not found in the WAL", height)
+	}
+	defer rd.Close()
+
+	msgs := make([]*TimedWALMessage, 0)
+	dec := NewWALDecoder(rd)
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			return msgs, nil
+		} else if err != nil {
+			return msgs, err
+		}
+		if len(msgs) == maxForensicWALMessages {
+			msgs = msgs[1:]
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+func writeJSONFile(path string, v interface{}) error {
+	bz, err := tmjson.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, bz, 0600)
+}
@@ -0,0 +1,65 @@
+package consensus
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestStateForensicBundle(t *testing.T) {
+	cs, _ := randState(1)
+	inspected := make([]string, 0)
+	StateForensics(t.TempDir(), func(bundle string) { inspected = append(inspected, bundle) })(cs)
+
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestState(t, cs)
+	ensureNewBlock(newBlockCh, 1)
+
+	cs.mtx.Lock()
+	state := cs.state.Copy()
+	next := &types.Block{Header: types.Header{ChainID: state.ChainID, Height: 2, AppHash: []byte("bad")}}
+	cs.investigateAppHashMismatch(next, sm.ErrAppHashMismatch{Expected: []byte("local"), Got: next.AppHash})
+	cs.mtx.Unlock()
+	require.Len(t, inspected, 1)
+	bundle := inspected[0]
+
+	bz, err := ioutil.ReadFile(filepath.Join(bundle, "summary.json"))
+	require.NoError(t, err)
+	var summary ForensicSummary
+	require.NoError(t, tmjson.Unmarshal(bz, &summary))
+	assert.Equal(t, state.LastBlockHeight, summary.Height)
+	assert.Equal(t, state.LastBlockID.Hash, summary.BlockHash)
+	assert.EqualValues(t, "local", summary.AppHash)
+	assert.EqualValues(t, 2, summary.CommittedHeader.Height)
+	assert.Equal(t, next.AppHash, summary.CommittedHeader.AppHash)
+	assert.Empty(t, summary.Errors)
+
+	bz, err = ioutil.ReadFile(filepath.Join(bundle, "block.json"))
+	require.NoError(t, err)
+	var block types.Block
+	require.NoError(t, tmjson.Unmarshal(bz, &block))
+	assert.Equal(t, state.LastBlockID.Hash, block.Hash())
+
+	bz, err = ioutil.ReadFile(filepath.Join(bundle, "wal.json"))
+	require.NoError(t, err)
+	var walMsgs []*TimedWALMessage
+	require.NoError(t, tmjson.Unmarshal(bz, &walMsgs))
+	assert.NotEmpty(t, walMsgs)
+	assert.FileExists(t, filepath.Join(bundle, "abci_responses.json"))
+}
+
+func TestStateForensicsDisabled(t *testing.T) {
+	cs, _ := randState(1)
+	inspected := false
+	StateForensics("", func(string) { inspected = true })(cs)
+
+	cs.investigateAppHashMismatch(&types.Block{}, sm.ErrAppHashMismatch{})
+	assert.False(t, inspected)
+}
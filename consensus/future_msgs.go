@@ -0,0 +1,357 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/tendermint/tendermint/p2p"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Kinds of the buffered messages, in order of priority: the proposal of a
+// round must be replayed before its block parts, which are more urgent than
+// the votes.
+const (
+	futureProposal = iota
+	futureBlockPart
+	futureVote
+)
+
+// futureMsgTypes are the values of the "type" label of the future message
+// metrics, by kind.
+var futureMsgTypes = [...]string{
+	futureProposal:  "proposal",
+	futureBlockPart: "block_part",
+	futureVote:      "vote",
+}
+
+// futureMsgBuffer buffers the proposals, block parts and votes received for
+// the next height, or for a future round of the current height, until
+// consensus reaches their height and round. It holds at most size messages:
+// once full, the ones of the furthest height and round (then the least urgent
+// kind, then the last received) are dropped. Each peer has at most peerQuota
+// messages buffered, so that a single peer can't fill the buffer. The same
+// message relayed by several peers is only buffered once. It is not
+// thread-safe: the State accesses it with its mutex held.
+//
+// The messages are verified before being buffered (see verifyFutureMsg). They
+// aren't written to the WAL of their height until replayed, so the buffered
+// messages are lost on restart, and have to be gossiped again.
+type futureMsgBuffer struct {
+	size      int
+	peerQuota int
+	msgs      []*futureMsg
+	keys      map[futureMsgKey]struct{}
+	peerMsgs  map[p2p.ID]int // number of buffered messages by peer
+	seq       uint64         // of the last buffered message
+}
+
+type futureMsg struct {
+	mi  msgInfo
+	key futureMsgKey
+	seq uint64
+}
+
+type futureMsgKey struct {
+	height int64
+	round  int32
+	kind   int
+	typ    tmproto.SignedMsgType // votes only
+	index  int32                 // validator index of the votes, index of the block parts
+	hash   string                // signature of the proposals and votes, leaf hash of the block parts
+}
+
+func newFutureMsgBuffer(size, peerQuota int) *futureMsgBuffer {
+	return &futureMsgBuffer{
+		size:      size,
+		peerQuota: peerQuota,
+		keys:      make(map[futureMsgKey]struct{}),
+		peerMsgs:  make(map[p2p.ID]int),
+	}
+}
+
+// futureMsgKeyOf returns the key of a proposal, block part or vote message.
+func futureMsgKeyOf(msg Message) (futureMsgKey, bool) {
+	switch msg := msg.(type) {
+	case *ProposalMessage:
+		return futureMsgKey{
+			height: msg.Proposal.Height,
+			round:  msg.Proposal.Round,
+			kind:   futureProposal,
+			hash:   string(msg.Proposal.Signature),
+		}, true
+	case *BlockPartMessage:
+		return futureMsgKey{
+			height: msg.Height,
+			round:  msg.Round,
+			kind:   futureBlockPart,
+			index:  int32(msg.Part.Index),
+			hash:   string(msg.Part.Proof.LeafHash),
+		}, true
+	case *VoteMessage:
+		return futureMsgKey{
+			height: msg.Vote.Height,
+			round:  msg.Vote.Round,
+			kind:   futureVote,
+			typ:    msg.Vote.Type,
+			index:  msg.Vote.ValidatorIndex,
+			hash:   string(msg.Vote.Signature),
+		}, true
+	default:
+		return futureMsgKey{}, false
+	}
+}
+
+// less returns whether a must be replayed before b, i.e. dropped after it.
+func (a *futureMsg) less(b *futureMsg) bool {
+	switch {
+	case a.key.height != b.key.height:
+		return a.key.height < b.key.height
+	case a.key.round != b.key.round:
+		return a.key.round < b.key.round
+	case a.key.kind != b.key.kind:
+		return a.key.kind < b.key.kind
+	default:
+		return a.seq < b.seq
+	}
+}
+
+// len returns the number of buffered messages.
+func (b *futureMsgBuffer) len() int {
+	return len(b.msgs)
+}
+
+// add buffers the message with the given key. It returns whether it was
+// buffered, and the message dropped to make room for it, if any.
+func (b *futureMsgBuffer) add(mi msgInfo, key futureMsgKey) (added bool, dropped *futureMsg) {
+	if b.size == 0 {
+		return false, nil
+	}
+	if _, ok := b.keys[key]; ok {
+		return false, nil
+	}
+	if b.peerQuota > 0 && mi.PeerID != "" && b.peerMsgs[mi.PeerID] >= b.peerQuota {
+		return false, nil
+	}
+	b.seq++
+	msg := &futureMsg{mi: mi, key: key, seq: b.seq}
+
+	if len(b.msgs) < b.size {
+		b.msgs = append(b.msgs, msg)
+	} else {
+		worst := 0
+		for i, m := range b.msgs {
+			if b.msgs[worst].less(m) {
+				worst = i
+			}
+		}
+		if b.msgs[worst].less(msg) {
+			return false, nil
+		}
+		dropped = b.msgs[worst]
+		b.remove(dropped)
+		b.msgs[worst] = msg
+	}
+	b.keys[key] = struct{}{}
+	if mi.PeerID != "" {
+		b.peerMsgs[mi.PeerID]++
+	}
+	return true, dropped
+}
+
+// remove forgets the key and peer of a message taken out of msgs.
+func (b *futureMsgBuffer) remove(msg *futureMsg) {
+	delete(b.keys, msg.key)
+	if peerID := msg.mi.PeerID; peerID != "" {
+		if b.peerMsgs[peerID]--; b.peerMsgs[peerID] <= 0 {
+			delete(b.peerMsgs, peerID)
+		}
+	}
+}
+
+// proposals returns the buffered proposals of the given height and round.
+func (b *futureMsgBuffer) proposals(height int64, round int32) []*types.Proposal {
+	var proposals []*types.Proposal
+	for _, msg := range b.msgs {
+		if msg.key.kind == futureProposal && msg.key.height == height && msg.key.round == round {
+			proposals = append(proposals, msg.mi.Msg.(*ProposalMessage).Proposal)
+		}
+	}
+	return proposals
+}
+
+// due removes the messages of the given height and of a round up to the given
+// one, which are returned in order of priority, and drops the messages of the
+// lower heights, which are returned too.
+func (b *futureMsgBuffer) due(height int64, round int32) (due, stale []*futureMsg) {
+	kept := b.msgs[:0]
+	for _, msg := range b.msgs {
+		switch {
+		case msg.key.height < height:
+			stale = append(stale, msg)
+		case msg.key.height == height && msg.key.round <= round:
+			due = append(due, msg)
+		default:
+			kept = append(kept, msg)
+			continue
+		}
+		b.remove(msg)
+	}
+	for i := len(kept); i < len(b.msgs); i++ {
+		b.msgs[i] = nil
+	}
+	b.msgs = kept
+
+	sort.Slice(due, func(i, j int) bool { return due[i].less(due[j]) })
+	return due, stale
+}
+
+// deferMsg buffers the message if it's a proposal, block part or vote for the
+// next height, or a proposal or block part for a future round of the current
+// height (the votes of the future rounds are already kept by the height vote
+// set), and returns whether it was deferred. A block part for a future round
+// is only deferred here if no proposal block is expected yet, as it may as
+// well be a part of the proposal block of this round: otherwise, it's deferred
+// by processMsg if it didn't add to it. cs.mtx must be held.
+func (cs *State) deferMsg(mi msgInfo) bool {
+	var height int64
+	var round int32
+	switch msg := mi.Msg.(type) {
+	case *ProposalMessage:
+		height, round = msg.Proposal.Height, msg.Proposal.Round
+	case *BlockPartMessage:
+		height, round = msg.Height, msg.Round
+		if height == cs.Height && cs.ProposalBlockParts != nil {
+			return false
+		}
+	case *VoteMessage:
+		if msg.Vote.Height != cs.Height+1 {
+			return false
+		}
+		return cs.bufferFutureMsg(mi)
+	default:
+		return false
+	}
+	if height == cs.Height+1 || (height == cs.Height && round > cs.Round) {
+		return cs.bufferFutureMsg(mi)
+	}
+	return false
+}
+
+// bufferFutureMsg adds the message to the future messages, returning whether
+// it was added, i.e. if it's valid. cs.mtx must be held.
+func (cs *State) bufferFutureMsg(mi msgInfo) bool {
+	key, ok := futureMsgKeyOf(mi.Msg)
+	if !ok {
+		return false
+	}
+	if err := cs.verifyFutureMsg(mi.Msg); err != nil {
+		cs.Logger.Debug("Dropping invalid message for a future height or round", "height", key.height,
+			"round", key.round, "type", futureMsgTypes[key.kind], "peer", mi.PeerID, "err", err)
+		return false
+	}
+	added, dropped := cs.futureMsgs.add(mi, key)
+	if dropped != nil {
+		cs.metrics.FutureMsgsDropped.With("type", futureMsgTypes[dropped.key.kind]).Add(1)
+	}
+	if added {
+		cs.Logger.Debug("Deferring message for a future height or round", "height", key.height, "round", key.round,
+			"type", futureMsgTypes[key.kind], "peer", mi.PeerID)
+		cs.metrics.FutureMsgsDeferred.With("type", futureMsgTypes[key.kind]).Add(1)
+		cs.metrics.FutureMsgs.Set(float64(cs.futureMsgs.len()))
+	}
+	return added
+}
+
+// verifyFutureMsg verifies the signature of a proposal or vote for the next
+// height or a future round of the current height, whose signers are known in
+// advance, and the proof of a block part against the buffered proposals of
+// its round: the block parts are only buffered once their proposal is.
+// cs.mtx must be held.
+func (cs *State) verifyFutureMsg(msg Message) error {
+	switch msg := msg.(type) {
+	case *ProposalMessage:
+		proposal := msg.Proposal
+		proposer, err := cs.futureProposer(proposal.Height, proposal.Round)
+		if err != nil {
+			return err
+		}
+		if !proposer.PubKey.VerifySignature(types.ProposalSignBytes(cs.state.ChainID, proposal.ToProto()),
+			proposal.Signature) {
+			return ErrInvalidProposalSignature
+		}
+		return nil
+	case *BlockPartMessage:
+		for _, proposal := range cs.futureMsgs.proposals(msg.Height, msg.Round) {
+			psh := proposal.BlockID.PartSetHeader
+			if msg.Part.Index < psh.Total && msg.Part.Proof.Verify(psh.Hash, msg.Part.Bytes) == nil {
+				return nil
+			}
+		}
+		return errors.New("no buffered proposal the block part is part of")
+	case *VoteMessage:
+		vote := msg.Vote
+		if vote.Height != cs.Height+1 {
+			return fmt.Errorf("unexpected vote height %d", vote.Height)
+		}
+		_, val := cs.state.NextValidators.GetByIndex(vote.ValidatorIndex)
+		if val == nil {
+			return fmt.Errorf("unknown validator index %d", vote.ValidatorIndex)
+		}
+		return vote.Verify(cs.state.ChainID, val.PubKey)
+	default:
+		return fmt.Errorf("unexpected message type %T", msg)
+	}
+}
+
+// futureProposer returns the proposer of the given round of the next height,
+// or of a future round of the current height. cs.mtx must be held.
+func (cs *State) futureProposer(height int64, round int32) (*types.Validator, error) {
+	var (
+		vals  *types.ValidatorSet
+		times int32
+	)
+	switch {
+	case height == cs.Height && round > cs.Round:
+		vals, times = cs.Validators, round-cs.Round
+	case height == cs.Height+1 && round >= 0:
+		// the validators of the next height, as of its round 0
+		vals, times = cs.state.NextValidators, round
+	default:
+		return nil, fmt.Errorf("unexpected proposal height %d and round %d", height, round)
+	}
+	if times > 0 {
+		vals = vals.CopyIncrementProposerPriority(times)
+	}
+	return vals.GetProposer(), nil
+}
+
+// replayFutureMsgs processes the future messages of the current height and
+// round, if consensus reached them, and drops the ones of the past heights.
+// cs.mtx must be held.
+func (cs *State) replayFutureMsgs() {
+	for cs.futureMsgs.len() > 0 {
+		due, stale := cs.futureMsgs.due(cs.Height, cs.Round)
+		for _, msg := range stale {
+			cs.metrics.FutureMsgsDropped.With("type", futureMsgTypes[msg.key.kind]).Add(1)
+		}
+		cs.metrics.FutureMsgs.Set(float64(cs.futureMsgs.len()))
+		if len(due) == 0 {
+			return
+		}
+		for _, msg := range due {
+			cs.metrics.FutureMsgsReplayed.With("type", futureMsgTypes[msg.key.kind]).Add(1)
+			// the message was written to the WAL of an earlier height, so a
+			// replay of this height wouldn't have it otherwise
+			if !cs.replayMode {
+				if err := cs.wal.Write(msg.mi); err != nil {
+					cs.Logger.Error("Error writing to wal", "err", err)
+				}
+			}
+			// may move to the next round or height, making more messages due
+			cs.processMsg(msg.mi)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package consensus
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/p2p"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestFutureMsgBuffer(t *testing.T) {
+	vote := func(height int64, round int32, valIndex int32) msgInfo {
+		return msgInfo{Msg: &VoteMessage{&types.Vote{
+			Type: tmproto.PrevoteType, Height: height, Round: round, ValidatorIndex: valIndex,
+		}}}
+	}
+	proposal := func(height int64, round int32) msgInfo {
+		return msgInfo{Msg: &ProposalMessage{&types.Proposal{Height: height, Round: round}}}
+	}
+	add := func(b *futureMsgBuffer, mi msgInfo) (bool, *futureMsg) {
+		key, ok := futureMsgKeyOf(mi.Msg)
+		require.True(t, ok)
+		return b.add(mi, key)
+	}
+
+	b := newFutureMsgBuffer(3, 0)
+	added, _ := add(b, vote(2, 1, 0))
+	assert.True(t, added)
+	added, _ = add(b, vote(2, 1, 0)) // duplicate
+	assert.False(t, added)
+	added, _ = add(b, vote(2, 0, 1))
+	assert.True(t, added)
+	added, _ = add(b, vote(3, 0, 0))
+	assert.True(t, added)
+
+	// full: the messages of the furthest height and round are dropped first
+	added, dropped := add(b, proposal(2, 1))
+	assert.True(t, added)
+	require.NotNil(t, dropped)
+	assert.EqualValues(t, 3, dropped.key.height)
+	added, dropped = add(b, vote(2, 1, 2))
+	assert.False(t, added)
+	assert.Nil(t, dropped)
+
+	due, stale := b.due(1, 5)
+	assert.Empty(t, due)
+	assert.Empty(t, stale)
+	assert.Equal(t, 3, b.len())
+
+	// the proposal of a round is replayed before its votes
+	due, stale = b.due(2, 1)
+	assert.Empty(t, stale)
+	require.Len(t, due, 3)
+	assert.Equal(t, vote(2, 0, 1), due[0].mi)
+	assert.Equal(t, proposal(2, 1), due[1].mi)
+	assert.Equal(t, vote(2, 1, 0), due[2].mi)
+	assert.Zero(t, b.len())
+
+	add(b, vote(2, 2, 0))
+	add(b, vote(3, 0, 0))
+	due, stale = b.due(3, 0)
+	assert.Len(t, due, 1)
+	assert.Len(t, stale, 1)
+
+	disabled := newFutureMsgBuffer(0, 0)
+	added, _ = add(disabled, vote(2, 0, 0))
+	assert.False(t, added)
+
+	// a peer can't buffer more than its quota
+	b = newFutureMsgBuffer(3, 2)
+	fromPeer := func(mi msgInfo, peerID p2p.ID) msgInfo {
+		mi.PeerID = peerID
+		return mi
+	}
+	for i := int32(0); i < 2; i++ {
+		added, _ = add(b, fromPeer(vote(2, 0, i), "a"))
+		assert.True(t, added)
+	}
+	added, _ = add(b, fromPeer(vote(2, 0, 2), "a"))
+	assert.False(t, added)
+	added, _ = add(b, fromPeer(vote(2, 0, 2), "b"))
+	assert.True(t, added)
+	b.due(2, 0)
+	assert.Empty(t, b.peerMsgs)
+	added, _ = add(b, fromPeer(vote(3, 0, 0), "a"))
+	assert.True(t, added)
+}
+
+func TestStateVerifyFutureMsgs(t *testing.T) {
+	cs1, vss := randState(4)
+	height, round := cs1.Height, cs1.Round
+
+	cs1.mtx.Lock()
+	proposer, err := cs1.futureProposer(height, round+1)
+	cs1.mtx.Unlock()
+	require.NoError(t, err)
+	var proposerStub, otherStub *validatorStub
+	for _, vs := range vss {
+		pubKey, err := vs.GetPubKey()
+		require.NoError(t, err)
+		if bytes.Equal(pubKey.Address(), proposer.Address) {
+			proposerStub = vs
+		} else {
+			otherStub = vs
+		}
+	}
+	require.NotNil(t, proposerStub)
+
+	prop, propBlock := decideProposal(cs1, proposerStub, height, round+1)
+	wrongProp, _ := decideProposal(cs1, otherStub, height, round+1)
+	part := propBlock.MakePartSet(types.BlockPartSizeBytes).GetPart(0)
+	partMsg := &BlockPartMessage{Height: height, Round: round + 1, Part: part}
+
+	otherStub.Height = height + 1
+	vote := signVote(otherStub, tmproto.PrevoteType, nil, types.PartSetHeader{})
+	wrongVote := vote.Copy()
+	wrongVote.Round++
+
+	cs1.mtx.Lock()
+	defer cs1.mtx.Unlock()
+	assert.Error(t, cs1.verifyFutureMsg(&ProposalMessage{wrongProp}))
+	assert.NoError(t, cs1.verifyFutureMsg(&VoteMessage{vote}))
+	assert.Error(t, cs1.verifyFutureMsg(&VoteMessage{wrongVote}))
+
+	// the block parts are verified against the buffered proposals
+	assert.Error(t, cs1.verifyFutureMsg(partMsg))
+	assert.True(t, cs1.bufferFutureMsg(msgInfo{Msg: &ProposalMessage{prop}, PeerID: "peer"}))
+	assert.NoError(t, cs1.verifyFutureMsg(partMsg))
+	assert.False(t, cs1.bufferFutureMsg(msgInfo{Msg: &VoteMessage{wrongVote}, PeerID: "peer"}))
+}
+
+// 4 vals, the proposal of round 1 is received during round 0.
+// What we want:
+// P0 processes the proposal once in round 1, and prevotes for it
+func TestStateDeferFutureRoundProposal(t *testing.T) {
+	cs1, vss := randState(4)
+	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
+	height, round := cs1.Height, cs1.Round
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+	pv1, err := cs1.privValidator.GetPubKey()
+	require.NoError(t, err)
+	voteCh := subscribeToVoter(cs1, pv1.Address())
+
+	startTestRound(cs1, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	ensureNewProposal(proposalCh, height, round)
+	ensurePrevote(voteCh, height, round)
+
+	// the proposal of the next round arrives early
+	prop, propBlock := decideProposal(cs1, vs2, vs2.Height, vs2.Round+1)
+	propBlockParts := propBlock.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, cs1.SetProposalAndBlock(prop, propBlock, propBlockParts, "some peer"))
+
+	// +2/3 prevotes for nil from round 1 make P0 skip to it
+	incrementRound(vs2, vs3, vs4)
+	signAddVotes(cs1, tmproto.PrevoteType, nil, types.PartSetHeader{}, vs2, vs3, vs4)
+
+	round++
+	ensureNewRound(newRoundCh, height, round)
+	ensureNewProposal(proposalCh, height, round)
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], propBlock.Hash())
+}
@@ -0,0 +1,182 @@
+package consensus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	"github.com/tendermint/tendermint/libs/service"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	livenessSubscriber = "LivenessMonitor"
+
+	livenessWebhookTimeout = 5 * time.Second
+)
+
+// LivenessAlert describes a validator which has missed too many consecutive
+// blocks. It is the JSON body POSTed to the webhook, see LivenessWebhook.
+type LivenessAlert struct {
+	Address      crypto.Address `json:"address"`
+	Height       int64          `json:"height"`
+	MissedBlocks int            `json:"missed_blocks"`
+}
+
+// LivenessMonitorOption sets an optional parameter on the LivenessMonitor.
+type LivenessMonitorOption func(*LivenessMonitor)
+
+// LivenessWebhook makes the monitor POST a LivenessAlert, as JSON, to the given
+// URL when the validator is down.
+func LivenessWebhook(url string) LivenessMonitorOption {
+	return func(lm *LivenessMonitor) { lm.webhookURL = url }
+}
+
+// LivenessOnDown sets a function called when the validator is down, e.g. to
+// exit the process so that external tooling can fail over.
+func LivenessOnDown(onDown func(LivenessAlert)) LivenessMonitorOption {
+	return func(lm *LivenessMonitor) { lm.onDown = onDown }
+}
+
+// LivenessMonitor watches the commits of the new blocks for the signature of
+// the local validator. Once the validator hasn't signed maxMissed consecutive
+// blocks while being part of the validator set, whatever the reason (privval
+// errors, unreachable remote signer, node behind the head of the chain...),
+// it is considered down: an error is logged, and the webhook and the OnDown
+// function are fired, once until the validator signs again.
+type LivenessMonitor struct {
+	service.BaseService
+
+	address    crypto.Address
+	maxMissed  int
+	eventBus   *types.EventBus
+	stateStore sm.Store
+
+	webhookURL string
+	onDown     func(LivenessAlert)
+	httpClient *http.Client
+
+	missed int
+}
+
+// NewLivenessMonitor returns a LivenessMonitor for the validator with the
+// given address.
+func NewLivenessMonitor(
+	address crypto.Address,
+	maxMissed int,
+	eventBus *types.EventBus,
+	stateStore sm.Store,
+	options ...LivenessMonitorOption,
+) *LivenessMonitor {
+	lm := &LivenessMonitor{
+		address:    address,
+		maxMissed:  maxMissed,
+		eventBus:   eventBus,
+		stateStore: stateStore,
+		httpClient: &http.Client{Timeout: livenessWebhookTimeout},
+	}
+	lm.BaseService = *service.NewBaseService(nil, "LivenessMonitor", lm)
+	for _, option := range options {
+		option(lm)
+	}
+	return lm
+}
+
+// OnStart implements service.Service by subscribing to the new blocks.
+func (lm *LivenessMonitor) OnStart() error {
+	sub, err := lm.eventBus.Subscribe(context.Background(), livenessSubscriber, types.EventQueryNewBlock)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case msg := <-sub.Out():
+				lm.checkBlock(msg.Data().(types.EventDataNewBlock).Block)
+			case <-sub.Cancelled():
+				if sub.Err() != nil && sub.Err() != tmpubsub.ErrUnsubscribed {
+					lm.Logger.Error("Liveness monitor subscription was cancelled", "err", sub.Err())
+				}
+				return
+			case <-lm.Quit():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// OnStop implements service.Service by unsubscribing from the new blocks.
+func (lm *LivenessMonitor) OnStop() {
+	if lm.eventBus.IsRunning() {
+		_ = lm.eventBus.UnsubscribeAll(context.Background(), livenessSubscriber)
+	}
+}
+
+// checkBlock checks whether the validator signed the commit of the previous
+// height, included in the given block.
+func (lm *LivenessMonitor) checkBlock(block *types.Block) {
+	commit := block.LastCommit
+	if commit == nil || commit.Height == 0 {
+		return
+	}
+	vals, err := lm.stateStore.LoadValidators(commit.Height)
+	if err != nil {
+		lm.Logger.Error("Can't load the validators", "height", commit.Height, "err", err)
+		return
+	}
+	idx, _ := vals.GetByAddress(lm.address)
+	if idx < 0 {
+		// not expected to sign
+		return
+	}
+	if int(idx) < len(commit.Signatures) && !commit.Signatures[idx].Absent() {
+		if lm.missed >= lm.maxMissed {
+			lm.Logger.Info("Validator is signing blocks again", "height", commit.Height)
+		}
+		lm.missed = 0
+		return
+	}
+
+	lm.missed++
+	lm.Logger.Debug("Validator missed a block", "height", commit.Height, "missed", lm.missed)
+	if lm.missed != lm.maxMissed {
+		return
+	}
+
+	alert := LivenessAlert{Address: lm.address, Height: commit.Height, MissedBlocks: lm.missed}
+	lm.Logger.Error("Validator is down: missed too many consecutive blocks",
+		"height", alert.Height, "missed", alert.MissedBlocks)
+	// post synchronously, in case onDown exits
+	if lm.webhookURL != "" {
+		lm.postWebhook(alert)
+	}
+	if lm.onDown != nil {
+		lm.onDown(alert)
+	}
+}
+
+func (lm *LivenessMonitor) postWebhook(alert LivenessAlert) {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		lm.Logger.Error("Can't marshal the liveness alert", "err", err)
+		return
+	}
+	resp, err := lm.httpClient.Post(lm.webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		lm.Logger.Error("Can't post the liveness alert", "url", lm.webhookURL, "err", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		lm.Logger.Error("Can't post the liveness alert", "url", lm.webhookURL,
+			"err", fmt.Errorf("unexpected status %s", resp.Status))
+	}
+}
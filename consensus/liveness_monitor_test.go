@@ -0,0 +1,73 @@
+package consensus
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestLivenessMonitor(t *testing.T) {
+	state, _ := randGenesisState(2, false, 10)
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	require.NoError(t, stateStore.Save(state))
+	vals := state.Validators
+
+	webhookAlerts := make(chan LivenessAlert, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert LivenessAlert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		webhookAlerts <- alert
+	}))
+	defer server.Close()
+
+	var alerts []LivenessAlert
+	address := vals.Validators[0].Address
+	lm := NewLivenessMonitor(address, 3, types.NewEventBus(), stateStore,
+		LivenessWebhook(server.URL),
+		LivenessOnDown(func(alert LivenessAlert) { alerts = append(alerts, alert) }))
+	lm.SetLogger(log.TestingLogger())
+
+	block := func(signed bool) *types.Block {
+		sigs := []types.CommitSig{
+			types.NewCommitSigAbsent(),
+			{BlockIDFlag: types.BlockIDFlagCommit, ValidatorAddress: vals.Validators[1].Address},
+		}
+		if signed {
+			sigs[0] = types.CommitSig{BlockIDFlag: types.BlockIDFlagNil, ValidatorAddress: address}
+		}
+		return &types.Block{LastCommit: &types.Commit{Height: 1, Signatures: sigs}}
+	}
+
+	// the first block has no last commit
+	lm.checkBlock(&types.Block{LastCommit: &types.Commit{}})
+	for i := 0; i < 2; i++ {
+		lm.checkBlock(block(false))
+	}
+	assert.Empty(t, alerts)
+
+	// the alert fires once the validator has missed 3 blocks, and only once
+	lm.checkBlock(block(false))
+	lm.checkBlock(block(false))
+	expected := LivenessAlert{Address: address, Height: 1, MissedBlocks: 3}
+	assert.Equal(t, []LivenessAlert{expected}, alerts)
+	assert.Equal(t, expected, <-webhookAlerts)
+
+	// signing (even nil) resets the count
+	lm.checkBlock(block(true))
+	for i := 0; i < 2; i++ {
+		lm.checkBlock(block(false))
+	}
+	assert.Len(t, alerts, 1)
+	lm.checkBlock(block(false))
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, expected, <-webhookAlerts)
+}
@@ -62,6 +62,26 @@ func TestMempoolProgressAfterCreateEmptyBlocksInterval(t *testing.T) {
 	ensureNewEventOnChannel(newBlockCh)   // until the CreateEmptyBlocksInterval has passed
 }
 
+func TestMempoolProgressAfterTargetBlockTime(t *testing.T) {
+	config := ResetConfig("consensus_mempool_txs_available_test")
+	t.Cleanup(func() { _ = os.RemoveAll(config.RootDir) })
+
+	config.Consensus.TargetBlockTime = ensureTimeout
+	state, privVals := randGenesisState(1, false, 10)
+	cs := newStateWithConfig(config, state, privVals[0], NewCounterApplication())
+
+	assertMempool(cs.txNotifier).EnableTxsAvailable()
+
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, cs.Height, cs.Round)
+
+	ensureNewEventOnChannel(newBlockCh)   // first block gets committed
+	ensureNoNewEventOnChannel(newBlockCh) // then we dont make an empty block ...
+	ensureNewEventOnChannel(newBlockCh)   // until the TargetBlockTime has passed
+	deliverTxsRange(cs, 0, 1)
+	ensureNewEventOnChannel(newBlockCh) // but txs are committed right away
+}
+
 func TestMempoolProgressInHigherRound(t *testing.T) {
 	config := ResetConfig("consensus_mempool_txs_available_test")
 	t.Cleanup(func() { _ = os.RemoveAll(config.RootDir) })
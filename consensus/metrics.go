@@ -24,6 +24,8 @@ type Metrics struct {
 
 	// Number of rounds.
 	Rounds metrics.Gauge
+	// Number of rounds which didn't commit a block.
+	MissedRounds metrics.Counter
 
 	// Number of validators.
 	Validators metrics.Gauge
@@ -44,6 +46,8 @@ type Metrics struct {
 
 	// Time between this and the last block.
 	BlockIntervalSeconds metrics.Histogram
+	// Time spent on a block by processing stage (see types.BlockTimings).
+	BlockStageSeconds metrics.Histogram
 
 	// Number of transactions.
 	NumTxs metrics.Gauge
@@ -60,6 +64,28 @@ type Metrics struct {
 
 	// Number of blockparts transmitted by peer.
 	BlockParts metrics.Counter
+	// Number of exact duplicate messages dropped by peer.
+	DuplicateMessages metrics.Counter
+	// Number of votes and block parts dropped by peer because already received
+	// from another peer.
+	RedundantMessages metrics.Counter
+
+	// Number of proposals, block parts and votes buffered until consensus
+	// reaches their height and round.
+	FutureMsgs metrics.Gauge
+	// Number of messages buffered for a future height or round, by type.
+	FutureMsgsDeferred metrics.Counter
+	// Number of buffered messages processed once consensus reached their
+	// height and round, by type.
+	FutureMsgsReplayed metrics.Counter
+	// Number of buffered messages dropped because the buffer was full, or
+	// consensus moved past their height, by type.
+	FutureMsgsDropped metrics.Counter
+
+	// Height of the last block replayed during the handshake.
+	ReplayHeight metrics.Gauge
+	// Number of blocks left to replay during the handshake.
+	ReplayRemainingBlocks metrics.Gauge
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -83,6 +109,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "rounds",
 			Help:      "Number of rounds.",
 		}, labels).With(labelsAndValues...),
+		MissedRounds: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "missed_rounds",
+			Help:      "Number of rounds which didn't commit a block.",
+		}, labels).With(labelsAndValues...),
 
 		Validators: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
@@ -144,6 +176,13 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_interval_seconds",
 			Help:      "Time between this and the last block.",
 		}, labels).With(labelsAndValues...),
+		BlockStageSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_stage_seconds",
+			Help:      "Time spent on a block by processing stage.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 15),
+		}, append(labels, "stage")).With(labelsAndValues...),
 		NumTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -186,6 +225,54 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_parts",
 			Help:      "Number of blockparts transmitted by peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		DuplicateMessages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "duplicate_messages",
+			Help:      "Number of exact duplicate messages dropped by peer.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		RedundantMessages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "redundant_messages",
+			Help:      "Number of votes and block parts dropped by peer because already received from another peer.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		FutureMsgs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "future_msgs",
+			Help:      "Number of proposals, block parts and votes buffered until consensus reaches their height and round.",
+		}, labels).With(labelsAndValues...),
+		FutureMsgsDeferred: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "future_msgs_deferred",
+			Help:      "Number of messages buffered for a future height or round, by type.",
+		}, append(labels, "type")).With(labelsAndValues...),
+		FutureMsgsReplayed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "future_msgs_replayed",
+			Help:      "Number of buffered messages processed once consensus reached their height and round, by type.",
+		}, append(labels, "type")).With(labelsAndValues...),
+		FutureMsgsDropped: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "future_msgs_dropped",
+			Help:      "Number of buffered messages dropped because the buffer was full or consensus moved past their height, by type.",
+		}, append(labels, "type")).With(labelsAndValues...),
+		ReplayHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "replay_height",
+			Help:      "Height of the last block replayed during the handshake.",
+		}, labels).With(labelsAndValues...),
+		ReplayRemainingBlocks: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "replay_remaining_blocks",
+			Help:      "Number of blocks left to replay during the handshake.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -196,7 +283,8 @@ func NopMetrics() *Metrics {
 
 		ValidatorLastSignedHeight: discard.NewGauge(),
 
-		Rounds: discard.NewGauge(),
+		Rounds:       discard.NewGauge(),
+		MissedRounds: discard.NewCounter(),
 
 		Validators:               discard.NewGauge(),
 		ValidatorsPower:          discard.NewGauge(),
@@ -208,6 +296,7 @@ func NopMetrics() *Metrics {
 		ByzantineValidatorsPower: discard.NewGauge(),
 
 		BlockIntervalSeconds: discard.NewHistogram(),
+		BlockStageSeconds:    discard.NewHistogram(),
 
 		NumTxs:          discard.NewGauge(),
 		BlockSizeBytes:  discard.NewGauge(),
@@ -216,5 +305,16 @@ func NopMetrics() *Metrics {
 		FastSyncing:     discard.NewGauge(),
 		StateSyncing:    discard.NewGauge(),
 		BlockParts:      discard.NewCounter(),
+
+		DuplicateMessages: discard.NewCounter(),
+		RedundantMessages: discard.NewCounter(),
+
+		FutureMsgs:         discard.NewGauge(),
+		FutureMsgsDeferred: discard.NewCounter(),
+		FutureMsgsReplayed: discard.NewCounter(),
+		FutureMsgsDropped:  discard.NewCounter(),
+
+		ReplayHeight:          discard.NewGauge(),
+		ReplayRemainingBlocks: discard.NewGauge(),
 	}
 }
@@ -0,0 +1,309 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+const metricCheckpointPrefix = "mcp:"
+
+// maxCheckpointedLabelSets is the maximum number of label values checkpointed
+// per metric: beyond, the least recently updated ones are forgotten, so that
+// the checkpoints of the metrics labelled by peer don't grow without bound.
+const maxCheckpointedLabelSets = 1000
+
+// CheckpointableMetrics is the names of the metrics which can be checkpointed
+// by a MetricsCheckpointer: the counters and the gauges which are only ever
+// added to.
+var CheckpointableMetrics = []string{
+	MetricsSubsystem + "_missed_rounds",
+	MetricsSubsystem + "_validator_missed_blocks",
+	MetricsSubsystem + "_block_parts",
+	MetricsSubsystem + "_duplicate_messages",
+	MetricsSubsystem + "_redundant_messages",
+}
+
+// MetricsCheckpointer saves the values of a set of cumulative metrics into a
+// DB every interval and when stopped, so that they keep growing across the
+// restarts of the node instead of starting from 0, which breaks the
+// long-horizon dashboards. See Metrics.Checkpoint.
+type MetricsCheckpointer struct {
+	service.BaseService
+
+	db       dbm.DB
+	interval time.Duration
+
+	mtx       tmsync.Mutex
+	values    map[string]*checkpointedValue // by metricCheckpointKey
+	labelSets map[string]int                // number of values by metric name
+	deleted   map[string]struct{}           // keys forgotten since the last checkpoint
+	seq       uint64                        // of the last update
+	dirty     bool
+}
+
+type checkpointedValue struct {
+	name    string
+	value   float64
+	updated uint64 // seq of the last update
+}
+
+// NewMetricsCheckpointer returns a checkpointer saving the metrics into db
+// every interval, with the values of the last checkpoint loaded. The
+// checkpointer owns db, which it closes once stopped.
+func NewMetricsCheckpointer(db dbm.DB, interval time.Duration) (*MetricsCheckpointer, error) {
+	mc := &MetricsCheckpointer{
+		db:        db,
+		interval:  interval,
+		values:    make(map[string]*checkpointedValue),
+		labelSets: make(map[string]int),
+		deleted:   make(map[string]struct{}),
+	}
+	mc.BaseService = *service.NewBaseService(nil, "MetricsCheckpointer", mc)
+
+	iter, err := dbm.IteratePrefix(db, []byte(metricCheckpointPrefix))
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if len(iter.Value()) != 8 {
+			return nil, fmt.Errorf("invalid checkpoint of metric %q", iter.Key())
+		}
+		key := string(iter.Key())
+		name := strings.SplitN(strings.TrimPrefix(key, metricCheckpointPrefix), "\x00", 2)[0]
+		mc.values[key] = &checkpointedValue{
+			name:  name,
+			value: math.Float64frombits(binary.BigEndian.Uint64(iter.Value())),
+		}
+		mc.labelSets[name]++
+	}
+	return mc, iter.Error()
+}
+
+func metricCheckpointKey(name string, lvs []string) string {
+	return metricCheckpointPrefix + name + "\x00" + strings.Join(lvs, "\x00")
+}
+
+// OnStart implements service.Service.
+func (mc *MetricsCheckpointer) OnStart() error {
+	go mc.checkpointRoutine()
+	return nil
+}
+
+// OnStop implements service.Service by saving a last checkpoint, and closing
+// the DB.
+func (mc *MetricsCheckpointer) OnStop() {
+	if err := mc.Save(); err != nil {
+		mc.Logger.Error("Failed to checkpoint the metrics", "err", err)
+	}
+	if err := mc.db.Close(); err != nil {
+		mc.Logger.Error("Failed to close the metrics DB", "err", err)
+	}
+}
+
+func (mc *MetricsCheckpointer) checkpointRoutine() {
+	ticker := time.NewTicker(mc.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := mc.Save(); err != nil {
+				mc.Logger.Error("Failed to checkpoint the metrics", "err", err)
+			}
+		case <-mc.Quit():
+			return
+		}
+	}
+}
+
+// Save saves the values of the metrics, if they changed since the last
+// checkpoint.
+func (mc *MetricsCheckpointer) Save() error {
+	mc.mtx.Lock()
+	defer mc.mtx.Unlock()
+	if !mc.dirty {
+		return nil
+	}
+
+	batch := mc.db.NewBatch()
+	defer batch.Close()
+	for key := range mc.deleted {
+		if err := batch.Delete([]byte(key)); err != nil {
+			return err
+		}
+	}
+	for key, v := range mc.values {
+		bz := make([]byte, 8)
+		binary.BigEndian.PutUint64(bz, math.Float64bits(v.value))
+		if err := batch.Set([]byte(key), bz); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	mc.deleted = make(map[string]struct{})
+	mc.dirty = false
+	return nil
+}
+
+// restore adds the checkpointed values of the metric to it, for each of its
+// label values.
+func (mc *MetricsCheckpointer) restore(name string, add func(lvs []string, delta float64)) {
+	mc.mtx.Lock()
+	defer mc.mtx.Unlock()
+	prefix := metricCheckpointKey(name, nil)
+	var keys []string
+	for key := range mc.values {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var lvs []string
+		if key != prefix {
+			lvs = strings.Split(key[len(prefix):], "\x00")
+		}
+		add(lvs, mc.values[key].value)
+	}
+}
+
+func (mc *MetricsCheckpointer) add(name string, lvs []string, delta float64) {
+	mc.mtx.Lock()
+	defer mc.mtx.Unlock()
+	mc.value(name, lvs).value += delta
+	mc.dirty = true
+}
+
+func (mc *MetricsCheckpointer) set(name string, lvs []string, value float64) {
+	mc.mtx.Lock()
+	defer mc.mtx.Unlock()
+	mc.value(name, lvs).value = value
+	mc.dirty = true
+}
+
+// value returns the value of the metric with the given label values, marked
+// as updated, forgetting the least recently updated value of the metric if it
+// has too many. mc.mtx must be held.
+func (mc *MetricsCheckpointer) value(name string, lvs []string) *checkpointedValue {
+	mc.seq++
+	key := metricCheckpointKey(name, lvs)
+	v, ok := mc.values[key]
+	if !ok {
+		if mc.labelSets[name] >= maxCheckpointedLabelSets {
+			mc.forgetOldest(name)
+		}
+		v = &checkpointedValue{name: name}
+		mc.values[key] = v
+		mc.labelSets[name]++
+		delete(mc.deleted, key)
+	}
+	v.updated = mc.seq
+	return v
+}
+
+func (mc *MetricsCheckpointer) forgetOldest(name string) {
+	var oldest string
+	for key, v := range mc.values {
+		if v.name == name && (oldest == "" || v.updated < mc.values[oldest].updated) {
+			oldest = key
+		}
+	}
+	if oldest != "" {
+		delete(mc.values, oldest)
+		mc.labelSets[name]--
+		mc.deleted[oldest] = struct{}{}
+	}
+}
+
+// Checkpoint makes the metrics of the given names, among CheckpointableMetrics,
+// checkpointed by mc: their values are restored from the last checkpoint and
+// then tracked. It must be called before the metrics are used.
+func (m *Metrics) Checkpoint(mc *MetricsCheckpointer, names ...string) error {
+	for _, name := range names {
+		switch name {
+		case MetricsSubsystem + "_missed_rounds":
+			m.MissedRounds = checkpointCounter(mc, name, m.MissedRounds)
+		case MetricsSubsystem + "_validator_missed_blocks":
+			m.ValidatorMissedBlocks = checkpointGauge(mc, name, m.ValidatorMissedBlocks)
+		case MetricsSubsystem + "_block_parts":
+			m.BlockParts = checkpointCounter(mc, name, m.BlockParts)
+		case MetricsSubsystem + "_duplicate_messages":
+			m.DuplicateMessages = checkpointCounter(mc, name, m.DuplicateMessages)
+		case MetricsSubsystem + "_redundant_messages":
+			m.RedundantMessages = checkpointCounter(mc, name, m.RedundantMessages)
+		default:
+			return fmt.Errorf("metric %q can't be checkpointed (valid: %s)", name,
+				strings.Join(CheckpointableMetrics, ", "))
+		}
+	}
+	return nil
+}
+
+type checkpointedCounter struct {
+	metrics.Counter
+	mc   *MetricsCheckpointer
+	name string
+	lvs  []string
+}
+
+func checkpointCounter(mc *MetricsCheckpointer, name string, c metrics.Counter) metrics.Counter {
+	mc.restore(name, func(lvs []string, delta float64) { c.With(lvs...).Add(delta) })
+	return &checkpointedCounter{Counter: c, mc: mc, name: name}
+}
+
+func (c *checkpointedCounter) With(labelValues ...string) metrics.Counter {
+	return &checkpointedCounter{
+		Counter: c.Counter.With(labelValues...),
+		mc:      c.mc,
+		name:    c.name,
+		lvs:     append(append([]string{}, c.lvs...), labelValues...),
+	}
+}
+
+func (c *checkpointedCounter) Add(delta float64) {
+	c.Counter.Add(delta)
+	c.mc.add(c.name, c.lvs, delta)
+}
+
+type checkpointedGauge struct {
+	metrics.Gauge
+	mc   *MetricsCheckpointer
+	name string
+	lvs  []string
+}
+
+func checkpointGauge(mc *MetricsCheckpointer, name string, g metrics.Gauge) metrics.Gauge {
+	mc.restore(name, func(lvs []string, value float64) { g.With(lvs...).Set(value) })
+	return &checkpointedGauge{Gauge: g, mc: mc, name: name}
+}
+
+func (g *checkpointedGauge) With(labelValues ...string) metrics.Gauge {
+	return &checkpointedGauge{
+		Gauge: g.Gauge.With(labelValues...),
+		mc:    g.mc,
+		name:  g.name,
+		lvs:   append(append([]string{}, g.lvs...), labelValues...),
+	}
+}
+
+func (g *checkpointedGauge) Set(value float64) {
+	g.Gauge.Set(value)
+	g.mc.set(g.name, g.lvs, value)
+}
+
+func (g *checkpointedGauge) Add(delta float64) {
+	g.Gauge.Add(delta)
+	g.mc.add(g.name, g.lvs, delta)
+}
@@ -0,0 +1,107 @@
+package consensus
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// testGauge records the values by label values.
+type testGauge struct {
+	values map[string]float64
+	lvs    []string
+}
+
+func newTestGauge() *testGauge { return &testGauge{values: make(map[string]float64)} }
+
+func (g *testGauge) With(labelValues ...string) metrics.Gauge {
+	return &testGauge{values: g.values, lvs: append(append([]string{}, g.lvs...), labelValues...)}
+}
+func (g *testGauge) Set(value float64) { g.values[strings.Join(g.lvs, ",")] = value }
+func (g *testGauge) Add(delta float64) { g.values[strings.Join(g.lvs, ",")] += delta }
+
+type testCounter struct{ *testGauge }
+
+func (c testCounter) With(labelValues ...string) metrics.Counter {
+	return testCounter{c.testGauge.With(labelValues...).(*testGauge)}
+}
+
+func TestMetricsCheckpointer(t *testing.T) {
+	db := dbm.NewMemDB()
+	newMetrics := func() (*Metrics, *testGauge, *testGauge, *testGauge) {
+		m := NopMetrics()
+		rounds, parts, missed := newTestGauge(), newTestGauge(), newTestGauge()
+		m.MissedRounds = testCounter{rounds}
+		m.BlockParts = testCounter{parts}
+		m.ValidatorMissedBlocks = missed
+		return m, rounds, parts, missed
+	}
+
+	mc, err := NewMetricsCheckpointer(db, time.Hour)
+	require.NoError(t, err)
+	m, _, _, _ := newMetrics()
+	require.NoError(t, m.Checkpoint(mc, "consensus_missed_rounds", "consensus_block_parts",
+		"consensus_validator_missed_blocks"))
+	assert.Error(t, m.Checkpoint(mc, "consensus_rounds"))
+
+	m.MissedRounds.Add(2)
+	m.MissedRounds.Add(1)
+	m.BlockParts.With("peer_id", "a").Add(5)
+	m.BlockParts.With("peer_id", "b").Add(1)
+	m.ValidatorMissedBlocks.With("validator_address", "v").Add(1)
+	m.DuplicateMessages.With("peer_id", "a").Add(1) // not checkpointed
+	require.NoError(t, mc.Save())
+
+	// the values are restored after a restart, and keep growing
+	mc, err = NewMetricsCheckpointer(db, time.Hour)
+	require.NoError(t, err)
+	m, rounds, parts, missed := newMetrics()
+	require.NoError(t, m.Checkpoint(mc, "consensus_missed_rounds", "consensus_block_parts",
+		"consensus_validator_missed_blocks"))
+	assert.Equal(t, map[string]float64{"": 3}, rounds.values)
+	assert.Equal(t, map[string]float64{"peer_id,a": 5, "peer_id,b": 1}, parts.values)
+	assert.Equal(t, map[string]float64{"validator_address,v": 1}, missed.values)
+
+	m.MissedRounds.Add(1)
+	require.NoError(t, mc.Save())
+	mc, err = NewMetricsCheckpointer(db, time.Hour)
+	require.NoError(t, err)
+	m, rounds, _, _ = newMetrics()
+	require.NoError(t, m.Checkpoint(mc, "consensus_missed_rounds"))
+	assert.Equal(t, map[string]float64{"": 4}, rounds.values)
+}
+
+func TestMetricsCheckpointerLabelSets(t *testing.T) {
+	db := dbm.NewMemDB()
+	mc, err := NewMetricsCheckpointer(db, time.Hour)
+	require.NoError(t, err)
+	m := NopMetrics()
+	m.BlockParts = testCounter{newTestGauge()}
+	require.NoError(t, m.Checkpoint(mc, "consensus_block_parts"))
+
+	// the least recently updated peers are forgotten
+	for i := 0; i < maxCheckpointedLabelSets; i++ {
+		m.BlockParts.With("peer_id", fmt.Sprint(i)).Add(1)
+	}
+	require.NoError(t, mc.Save())
+	m.BlockParts.With("peer_id", "0").Add(1)
+	m.BlockParts.With("peer_id", "new").Add(1)
+	require.NoError(t, mc.Save())
+
+	mc, err = NewMetricsCheckpointer(db, time.Hour)
+	require.NoError(t, err)
+	m = NopMetrics()
+	parts := newTestGauge()
+	m.BlockParts = testCounter{parts}
+	require.NoError(t, m.Checkpoint(mc, "consensus_block_parts"))
+	assert.Len(t, parts.values, maxCheckpointedLabelSets)
+	assert.Equal(t, 2.0, parts.values["peer_id,0"])
+	assert.Equal(t, 1.0, parts.values["peer_id,new"])
+	assert.NotContains(t, parts.values, "peer_id,1")
+}
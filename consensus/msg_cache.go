@@ -0,0 +1,66 @@
+package consensus
+
+import (
+	"crypto/sha256"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// msgCache remembers the hashes of the last votes and block parts added to the
+// consensus state, so that the identical messages relayed by the other peers
+// are dropped before being verified, written to the WAL and processed again.
+// Only the messages added are remembered, since e.g. a block part received
+// before its proposal must be accepted again later. Unlike msgWindow, it is
+// shared by all the peers and thus hashes the messages with SHA-256, which
+// peers can't collide. It is thread-safe.
+type msgCache struct {
+	mtx    tmsync.Mutex
+	hashes [][sha256.Size]byte // ring buffer of the last hashes
+	next   int
+	known  map[[sha256.Size]byte]struct{} // the hashes in hashes
+}
+
+func newMsgCache(size int) *msgCache {
+	return &msgCache{
+		hashes: make([][sha256.Size]byte, 0, size),
+		known:  make(map[[sha256.Size]byte]struct{}, size),
+	}
+}
+
+func msgCacheKey(chID byte, msgBytes []byte) [sha256.Size]byte {
+	var sum [sha256.Size]byte
+	h := sha256.New()
+	_, _ = h.Write([]byte{chID})
+	_, _ = h.Write(msgBytes)
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// has returns whether the message received on the channel is in the cache.
+func (c *msgCache) has(chID byte, msgBytes []byte) bool {
+	sum := msgCacheKey(chID, msgBytes)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	_, ok := c.known[sum]
+	return ok
+}
+
+// add adds the message received on the channel to the cache, evicting the
+// oldest one if full.
+func (c *msgCache) add(chID byte, msgBytes []byte) {
+	sum := msgCacheKey(chID, msgBytes)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if _, ok := c.known[sum]; ok {
+		return
+	}
+
+	if len(c.hashes) < cap(c.hashes) {
+		c.hashes = append(c.hashes, sum)
+	} else {
+		delete(c.known, c.hashes[c.next])
+		c.hashes[c.next] = sum
+		c.next = (c.next + 1) % len(c.hashes)
+	}
+	c.known[sum] = struct{}{}
+}
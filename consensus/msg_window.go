@@ -0,0 +1,57 @@
+package consensus
+
+import (
+	"hash/maphash"
+)
+
+// msgWindow remembers the hashes of the last messages received from a peer, to
+// drop the exact duplicates before decoding them. It must be reset whenever the
+// consensus enters a new step, see Reactor.resetMsgWindows. The hashes are
+// seeded randomly, so that peers can't craft collisions. It isn't thread-safe.
+type msgWindow struct {
+	hash   maphash.Hash
+	hashes []uint64 // ring buffer of the last hashes
+	next   int
+	known  map[uint64]struct{} // the hashes in hashes
+}
+
+func newMsgWindow(size int) *msgWindow {
+	w := &msgWindow{
+		hashes: make([]uint64, 0, size),
+		known:  make(map[uint64]struct{}, size),
+	}
+	w.hash.SetSeed(maphash.MakeSeed())
+	return w
+}
+
+// add adds the message received on the channel to the window, evicting the
+// oldest one if full. It returns false, without adding it, if the message is
+// already in the window.
+func (w *msgWindow) add(chID byte, msgBytes []byte) bool {
+	w.hash.Reset()
+	_ = w.hash.WriteByte(chID)
+	_, _ = w.hash.Write(msgBytes)
+	sum := w.hash.Sum64()
+	if _, ok := w.known[sum]; ok {
+		return false
+	}
+
+	if len(w.hashes) < cap(w.hashes) {
+		w.hashes = append(w.hashes, sum)
+	} else {
+		delete(w.known, w.hashes[w.next])
+		w.hashes[w.next] = sum
+		w.next = (w.next + 1) % len(w.hashes)
+	}
+	w.known[sum] = struct{}{}
+	return true
+}
+
+// reset removes all the messages from the window.
+func (w *msgWindow) reset() {
+	for _, sum := range w.hashes {
+		delete(w.known, sum)
+	}
+	w.hashes = w.hashes[:0]
+	w.next = 0
+}
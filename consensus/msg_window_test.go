@@ -0,0 +1,27 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMsgWindow(t *testing.T) {
+	w := newMsgWindow(2)
+
+	assert.True(t, w.add(VoteChannel, []byte("a")))
+	assert.False(t, w.add(VoteChannel, []byte("a")))
+	// the channel is part of the message
+	assert.True(t, w.add(DataChannel, []byte("a")))
+
+	// "a" on the vote channel is evicted
+	assert.True(t, w.add(VoteChannel, []byte("b")))
+	assert.False(t, w.add(DataChannel, []byte("a")))
+	assert.True(t, w.add(VoteChannel, []byte("a")))
+	assert.False(t, w.add(VoteChannel, []byte("b")))
+
+	w.reset()
+	assert.True(t, w.add(VoteChannel, []byte("b")))
+	assert.True(t, w.add(DataChannel, []byte("a")))
+	assert.False(t, w.add(DataChannel, []byte("a")))
+}
@@ -136,6 +136,25 @@ func MsgToProto(msg Message) (*tmcons.Message, error) {
 			Sum: vsb,
 		}
 
+	case *HasVotesMessage:
+		bits := msg.Votes.ToProto()
+
+		hvs := &tmcons.Message_HasVotes{
+			HasVotes: &tmcons.HasVotes{
+				Height: msg.Height,
+				Round:  msg.Round,
+				Type:   msg.Type,
+			},
+		}
+
+		if bits != nil {
+			hvs.HasVotes.Votes = *bits
+		}
+
+		pb = tmcons.Message{
+			Sum: hvs,
+		}
+
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
 	}
@@ -258,6 +277,19 @@ func MsgFromProto(msg *tmcons.Message) (Message, error) {
 			BlockID: *bi,
 			Votes:   bits,
 		}
+	case *tmcons.Message_HasVotes:
+		bits := new(bits.BitArray)
+		err := bits.FromProto(&msg.HasVotes.Votes)
+		if err != nil {
+			return nil, fmt.Errorf("votes to proto error: %w", err)
+		}
+
+		pb = &HasVotesMessage{
+			Height: msg.HasVotes.Height,
+			Round:  msg.HasVotes.Round,
+			Type:   msg.HasVotes.Type,
+			Votes:  bits,
+		}
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
 	}
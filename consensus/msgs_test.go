@@ -184,6 +184,21 @@ func TestMsgToProto(t *testing.T) {
 				},
 			},
 		}, false},
+		{"successful HasVotes", &HasVotesMessage{
+			Height: 1,
+			Round:  1,
+			Type:   1,
+			Votes:  bits,
+		}, &tmcons.Message{
+			Sum: &tmcons.Message_HasVotes{
+				HasVotes: &tmcons.HasVotes{
+					Height: 1,
+					Round:  1,
+					Type:   1,
+					Votes:  *pbBits,
+				},
+			},
+		}, false},
 		{"failure", nil, &tmcons.Message{}, true},
 	}
 	for _, tt := range testsCases {
@@ -413,6 +428,9 @@ func TestConsMsgsVectors(t *testing.T) {
 		{"VoteSetBits", &tmcons.Message{Sum: &tmcons.Message_VoteSetBits{
 			VoteSetBits: &tmcons.VoteSetBits{Height: 1, Round: 1, Type: tmproto.PrevoteType, BlockID: pbBi, Votes: *pbBits}}},
 			"4a5708011001180122480a206164645f6d6f72655f6578636c616d6174696f6e5f6d61726b735f636f64652d1224080112206164645f6d6f72655f6578636c616d6174696f6e5f6d61726b735f636f64652d2a050801120100"},
+		{"HasVotes", &tmcons.Message{Sum: &tmcons.Message_HasVotes{
+			HasVotes: &tmcons.HasVotes{Height: 1, Round: 1, Type: tmproto.PrevoteType, Votes: *pbBits}}},
+			"520d08011001180122050801120100"},
 	}
 
 	for _, tc := range testCases {
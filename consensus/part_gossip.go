@@ -0,0 +1,126 @@
+package consensus
+
+import (
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/bits"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+// blockPartGossip selects the parts of the proposal block sent to the peers,
+// see config.ConsensusConfig.BlockPartFanout and BlockPartSelection. It tracks
+// the parts of a single part set, the one last sent.
+type blockPartGossip struct {
+	config *cfg.ConsensusConfig
+
+	mtx       tmsync.Mutex
+	header    types.PartSetHeader
+	sent      []int       // number of peers each part was sent to
+	saturated []time.Time // when each part reached the fan-out
+}
+
+func newBlockPartGossip(config *cfg.ConsensusConfig) *blockPartGossip {
+	return &blockPartGossip{config: config}
+}
+
+// pick returns the index of the part of the given part set to send to a peer
+// lacking the missing parts, or false if none can be sent now. holders returns
+// the number of peers holding each part, it's only called for the
+// BlockPartSelectionRarestFirst selection.
+func (g *blockPartGossip) pick(header types.PartSetHeader, missing *bits.BitArray,
+	holders func() []int, now time.Time) (int, bool) {
+	if g.config.BlockPartFanout == 0 && g.config.BlockPartSelection != cfg.BlockPartSelectionRarestFirst {
+		return missing.PickRandom()
+	}
+
+	candidates := make([]int, 0, missing.Size())
+	g.mtx.Lock()
+	g.reset(header)
+	for i := 0; i < missing.Size(); i++ {
+		if missing.GetIndex(i) && !g.isSaturated(i, now) {
+			candidates = append(candidates, i)
+		}
+	}
+	g.mtx.Unlock()
+	if len(candidates) == 0 {
+		return 0, false
+	}
+
+	if g.config.BlockPartSelection == cfg.BlockPartSelectionRarestFirst {
+		counts := holders()
+		rarest := make([]int, 0, len(candidates))
+		for _, i := range candidates {
+			switch {
+			case len(rarest) == 0 || counts[i] == counts[rarest[0]]:
+				rarest = append(rarest, i)
+			case counts[i] < counts[rarest[0]]:
+				rarest = append(rarest[:0], i)
+			}
+		}
+		candidates = rarest
+	}
+	return candidates[tmrand.Intn(len(candidates))], true
+}
+
+// markSent records that the part of the given part set was sent to a peer.
+func (g *blockPartGossip) markSent(header types.PartSetHeader, index int, now time.Time) {
+	if g.config.BlockPartFanout == 0 {
+		return
+	}
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	g.reset(header)
+	if index >= len(g.sent) {
+		return
+	}
+	g.sent[index]++
+	if g.sent[index] == g.config.BlockPartFanout {
+		g.saturated[index] = now
+	}
+}
+
+// isSaturated returns true if the part was sent to BlockPartFanout peers less
+// than BlockPartFanoutTimeout ago. The mutex must be held.
+func (g *blockPartGossip) isSaturated(index int, now time.Time) bool {
+	if g.config.BlockPartFanout == 0 || g.sent[index] < g.config.BlockPartFanout {
+		return false
+	}
+	return now.Sub(g.saturated[index]) < g.config.BlockPartFanoutTimeout
+}
+
+// reset starts tracking the given part set, if it isn't already. The mutex
+// must be held.
+func (g *blockPartGossip) reset(header types.PartSetHeader) {
+	if g.header.Equals(header) {
+		return
+	}
+	g.header = header
+	g.sent = make([]int, header.Total)
+	g.saturated = make([]time.Time, header.Total)
+}
+
+// blockPartHolders returns the number of peers holding each part of the given
+// part set, as far as we know.
+func blockPartHolders(peers []p2p.Peer, header types.PartSetHeader) []int {
+	holders := make([]int, header.Total)
+	for _, peer := range peers {
+		ps, ok := peer.Get(types.PeerStateKey).(*PeerState)
+		if !ok {
+			continue
+		}
+		prs := ps.GetRoundState()
+		if prs.ProposalBlockParts == nil || !prs.ProposalBlockPartSetHeader.Equals(header) {
+			continue
+		}
+		for i := range holders {
+			if prs.ProposalBlockParts.GetIndex(i) {
+				holders[i]++
+			}
+		}
+	}
+	return holders
+}
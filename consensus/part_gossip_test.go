@@ -0,0 +1,86 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/bits"
+	"github.com/tendermint/tendermint/types"
+)
+
+func newMissingParts(total int, indexes ...int) *bits.BitArray {
+	missing := bits.NewBitArray(total)
+	for _, i := range indexes {
+		missing.SetIndex(i, true)
+	}
+	return missing
+}
+
+func TestBlockPartGossipFanout(t *testing.T) {
+	config := cfg.TestConsensusConfig()
+	config.BlockPartFanout = 2
+	config.BlockPartFanoutTimeout = time.Second
+	g := newBlockPartGossip(config)
+	header := types.PartSetHeader{Total: 2, Hash: []byte("hash")}
+	now := time.Now()
+
+	g.markSent(header, 0, now)
+	index, ok := g.pick(header, newMissingParts(2, 0), nil, now)
+	require.True(t, ok)
+	assert.Equal(t, 0, index)
+
+	// part 0 reached the fan-out, only part 1 can be sent
+	g.markSent(header, 0, now)
+	_, ok = g.pick(header, newMissingParts(2, 0), nil, now)
+	assert.False(t, ok)
+	index, ok = g.pick(header, newMissingParts(2, 0, 1), nil, now)
+	require.True(t, ok)
+	assert.Equal(t, 1, index)
+
+	// until the fan-out timeout
+	_, ok = g.pick(header, newMissingParts(2, 0), nil, now.Add(time.Second))
+	assert.True(t, ok)
+
+	// the parts of another part set aren't saturated
+	other := types.PartSetHeader{Total: 2, Hash: []byte("other")}
+	_, ok = g.pick(other, newMissingParts(2, 0), nil, now)
+	assert.True(t, ok)
+}
+
+func TestBlockPartGossipRarestFirst(t *testing.T) {
+	config := cfg.TestConsensusConfig()
+	config.BlockPartSelection = cfg.BlockPartSelectionRarestFirst
+	g := newBlockPartGossip(config)
+	header := types.PartSetHeader{Total: 4, Hash: []byte("hash")}
+	holders := func() []int { return []int{1, 3, 0, 2} }
+
+	for i := 0; i < 10; i++ {
+		index, ok := g.pick(header, newMissingParts(4, 0, 1, 2, 3), holders, time.Now())
+		require.True(t, ok)
+		assert.Equal(t, 2, index)
+
+		index, ok = g.pick(header, newMissingParts(4, 1, 3), holders, time.Now())
+		require.True(t, ok)
+		assert.Equal(t, 3, index)
+	}
+
+	_, ok := g.pick(header, newMissingParts(4), holders, time.Now())
+	assert.False(t, ok)
+}
+
+func TestBlockPartGossipRandom(t *testing.T) {
+	g := newBlockPartGossip(cfg.TestConsensusConfig())
+	header := types.PartSetHeader{Total: 3, Hash: []byte("hash")}
+
+	picked := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		index, ok := g.pick(header, newMissingParts(3, 0, 2), nil, time.Now())
+		require.True(t, ok)
+		picked[index] = true
+	}
+	assert.Equal(t, map[int]bool{0: true, 2: true}, picked)
+}
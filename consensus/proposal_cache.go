@@ -0,0 +1,64 @@
+package consensus
+
+import (
+	"github.com/tendermint/tendermint/types"
+)
+
+// maxProposalCacheSize is the maximum number of blocks (and validation
+// results) cached per height. Once reached, the cache is cleared.
+const maxProposalCacheSize = 16
+
+// proposalCache caches, for the current height, the blocks assembled from
+// complete proposal part sets (keyed by the part set hash) and the results of
+// validating them (keyed by the block hash). When the same block is proposed
+// in several rounds, or validated in several steps of a round, this avoids
+// assembling, decoding and validating it again.
+//
+// NOTE: not goroutine-safe, it's only accessed under State.mtx.
+type proposalCache struct {
+	blocks      map[string]*types.Block
+	validations map[string]error
+}
+
+func newProposalCache() *proposalCache {
+	return &proposalCache{
+		blocks:      make(map[string]*types.Block),
+		validations: make(map[string]error),
+	}
+}
+
+// reset drops all entries. It must be called when moving to a new height.
+func (pc *proposalCache) reset() {
+	pc.blocks = make(map[string]*types.Block)
+	pc.validations = make(map[string]error)
+}
+
+// block returns the block previously assembled from a part set with the given
+// header, if any.
+func (pc *proposalCache) block(header types.PartSetHeader) (*types.Block, bool) {
+	block, ok := pc.blocks[string(header.Hash)]
+	return block, ok
+}
+
+// addBlock caches the block assembled from a part set with the given header.
+func (pc *proposalCache) addBlock(header types.PartSetHeader, block *types.Block) {
+	if len(pc.blocks) >= maxProposalCacheSize {
+		pc.blocks = make(map[string]*types.Block)
+	}
+	pc.blocks[string(header.Hash)] = block
+}
+
+// validation returns the result of a previous validation of the block with
+// the given hash, if any.
+func (pc *proposalCache) validation(blockHash []byte) (ok bool, err error) {
+	err, ok = pc.validations[string(blockHash)]
+	return ok, err
+}
+
+// addValidation caches the result of validating the block with the given hash.
+func (pc *proposalCache) addValidation(blockHash []byte, err error) {
+	if len(pc.validations) >= maxProposalCacheSize {
+		pc.validations = make(map[string]error)
+	}
+	pc.validations[string(blockHash)] = err
+}
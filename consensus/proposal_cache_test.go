@@ -0,0 +1,59 @@
+package consensus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestProposalCache(t *testing.T) {
+	pc := newProposalCache()
+	header := types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))}
+	blockHash := tmhash.Sum([]byte("block"))
+
+	_, ok := pc.block(header)
+	assert.False(t, ok)
+	ok, _ = pc.validation(blockHash)
+	assert.False(t, ok)
+
+	block := &types.Block{}
+	pc.addBlock(header, block)
+	got, ok := pc.block(header)
+	assert.True(t, ok)
+	assert.Equal(t, block, got)
+
+	// both successful and failed validations are cached
+	pc.addValidation(blockHash, nil)
+	ok, err := pc.validation(blockHash)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+
+	invalidHash := tmhash.Sum([]byte("invalid block"))
+	pc.addValidation(invalidHash, errors.New("invalid"))
+	ok, err = pc.validation(invalidHash)
+	assert.True(t, ok)
+	assert.Error(t, err)
+
+	// the cache is emptied on reset
+	pc.reset()
+	_, ok = pc.block(header)
+	assert.False(t, ok)
+	ok, _ = pc.validation(blockHash)
+	assert.False(t, ok)
+}
+
+func TestProposalCacheMaxSize(t *testing.T) {
+	pc := newProposalCache()
+	for i := 0; i < maxProposalCacheSize+1; i++ {
+		pc.addValidation(tmhash.Sum([]byte{byte(i)}), nil)
+	}
+	assert.LessOrEqual(t, len(pc.validations), maxProposalCacheSize)
+
+	// the most recent entry is kept
+	ok, _ := pc.validation(tmhash.Sum([]byte{byte(maxProposalCacheSize)}))
+	assert.True(t, ok)
+}
@@ -14,6 +14,7 @@ import (
 	tmevents "github.com/tendermint/tendermint/libs/events"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/protoio"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
 	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
@@ -33,6 +34,13 @@ const (
 
 	blocksToContributeToBecomeGoodPeer = 10000
 	votesToContributeToBecomeGoodPeer  = 10000
+
+	// hasVotesP2PVersion is the lowest P2P protocol version of peers that
+	// understand HasVotesMessage. Older peers get individual HasVoteMessages.
+	hasVotesP2PVersion = 9
+	// hasVotesFlushInterval is how often aggregated HasVotesMessages are
+	// broadcasted to peers.
+	hasVotesFlushInterval = 10 * time.Millisecond
 )
 
 //-----------------------------------------------------------------------------
@@ -47,18 +55,39 @@ type Reactor struct {
 	waitSync bool
 	eventBus *types.EventBus
 
+	// vote sets which changed since the last HasVotesMessage broadcast.
+	hasVotesMtx     tmsync.Mutex
+	pendingHasVotes map[hasVotesKey]struct{}
+
+	partGossip *blockPartGossip
+
+	// the last votes and block parts added to the state (nil if disabled)
+	msgCache *msgCache
+
 	Metrics *Metrics
 }
 
+// hasVotesKey identifies a vote set by height, round and type.
+type hasVotesKey struct {
+	height   int64
+	round    int32
+	voteType tmproto.SignedMsgType
+}
+
 type ReactorOption func(*Reactor)
 
 // NewReactor returns a new Reactor with the given
 // consensusState.
 func NewReactor(consensusState *State, waitSync bool, options ...ReactorOption) *Reactor {
 	conR := &Reactor{
-		conS:     consensusState,
-		waitSync: waitSync,
-		Metrics:  NopMetrics(),
+		conS:            consensusState,
+		waitSync:        waitSync,
+		pendingHasVotes: make(map[hasVotesKey]struct{}),
+		partGossip:      newBlockPartGossip(consensusState.config),
+		Metrics:         NopMetrics(),
+	}
+	if size := consensusState.config.MsgCacheSize; size > 0 {
+		conR.msgCache = newMsgCache(size)
 	}
 	conR.BaseReactor = *p2p.NewBaseReactor("Consensus", conR)
 
@@ -77,6 +106,9 @@ func (conR *Reactor) OnStart() error {
 	// start routine that computes peer statistics for evaluating peer quality
 	go conR.peerStatsRoutine()
 
+	// start routine that broadcasts aggregated HasVotesMessages
+	go conR.broadcastHasVotesRoutine()
+
 	conR.subscribeToBroadcastEvents()
 
 	if !conR.WaitSync() {
@@ -174,6 +206,9 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 // InitPeer implements Reactor by creating a state for the peer.
 func (conR *Reactor) InitPeer(peer p2p.Peer) p2p.Peer {
 	peerState := NewPeerState(peer).SetLogger(conR.Logger)
+	if size := conR.conS.config.PeerReplayWindow; size > 0 {
+		peerState.msgWindow = newMsgWindow(size)
+	}
 	peer.Set(types.PeerStateKey, peerState)
 	return peer
 }
@@ -228,6 +263,19 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 		return
 	}
 
+	if chID == VoteChannel {
+		var err error
+		if msgBytes, err = upgradeVoteMsg(src, msgBytes); err != nil {
+			conR.Logger.Error("Error converting vote", "src", src, "chId", chID, "err", err)
+			conR.Switch.StopPeerForError(src, err)
+			return
+		}
+	}
+
+	if (chID == DataChannel || chID == VoteChannel) && conR.dropDuplicate(chID, src, msgBytes) {
+		return
+	}
+
 	msg, err := decodeMsg(msgBytes)
 	if err != nil {
 		conR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
@@ -266,6 +314,14 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 			ps.ApplyNewValidBlockMessage(msg)
 		case *HasVoteMessage:
 			ps.ApplyHasVoteMessage(msg)
+		case *HasVotesMessage:
+			cs := conR.conS
+			cs.mtx.RLock()
+			height, valSize, lastCommitSize := cs.Height, cs.Validators.Size(), cs.LastCommit.Size()
+			cs.mtx.RUnlock()
+			ps.EnsureVoteBitArrays(height, valSize)
+			ps.EnsureVoteBitArrays(height-1, lastCommitSize)
+			ps.ApplyHasVotesMessage(msg)
 		case *VoteSetMaj23Message:
 			cs := conR.conS
 			cs.mtx.Lock()
@@ -316,6 +372,9 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 		case *BlockPartMessage:
 			ps.SetHasProposalBlockPart(msg.Height, msg.Round, int(msg.Part.Index))
 			conR.Metrics.BlockParts.With("peer_id", string(src.ID())).Add(1)
+			if conR.dropRedundant(chID, src, msg) {
+				return
+			}
 			conR.conS.peerMsgQueue <- msgInfo{msg, src.ID()}
 		default:
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
@@ -335,6 +394,18 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 			ps.EnsureVoteBitArrays(height, valSize)
 			ps.EnsureVoteBitArrays(height-1, lastCommitSize)
 			ps.SetHasVote(msg.Vote)
+			if conR.dropRedundant(chID, src, msg) {
+				return
+			}
+
+			if peerID, err := cs.checkConflictingVote(msg.Vote, src.ID()); err != nil {
+				if peer := conR.Switch.Peers().Get(peerID); peer != nil {
+					conR.Switch.StopPeerForError(peer, err)
+				}
+				if peerID == src.ID() {
+					return
+				}
+			}
 
 			cs.peerMsgQueue <- msgInfo{msg, src.ID()}
 
@@ -379,6 +450,42 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	}
 }
 
+// dropDuplicate returns true if the message is an exact duplicate of one of the
+// last messages received from the peer, see
+// config.ConsensusConfig.PeerReplayWindow. The peer is stopped once it sent
+// more than PeerMaxDuplicates duplicates during the current step.
+func (conR *Reactor) dropDuplicate(chID byte, src p2p.Peer, msgBytes []byte) bool {
+	ps, ok := src.Get(types.PeerStateKey).(*PeerState)
+	if !ok {
+		return false
+	}
+	duplicate, duplicates := ps.RecordMessage(chID, msgBytes)
+	if !duplicate {
+		return false
+	}
+
+	conR.Metrics.DuplicateMessages.With("peer_id", string(src.ID())).Add(1)
+	conR.Logger.Debug("Dropping duplicate message", "src", src, "chId", chID, "duplicates", duplicates)
+	if max := conR.conS.config.PeerMaxDuplicates; max > 0 && duplicates > max {
+		conR.Switch.StopPeerForError(src, fmt.Errorf("peer sent %d duplicate messages during the step", duplicates))
+	}
+	return true
+}
+
+// dropRedundant returns true if the vote or block part was already received
+// from a peer and added to the consensus state, see
+// config.ConsensusConfig.MsgCacheSize, in which case it must not be verified
+// and processed again. Unlike the duplicates, it isn't the fault of the peer.
+// The message is encoded again, like in cacheAddedMsg, since the peers running
+// other versions may encode it differently than this node.
+func (conR *Reactor) dropRedundant(chID byte, src p2p.Peer, msg Message) bool {
+	if conR.msgCache == nil || !conR.msgCache.has(chID, MustEncode(msg)) {
+		return false
+	}
+	conR.Metrics.RedundantMessages.With("peer_id", string(src.ID())).Add(1)
+	return true
+}
+
 // SetEventBus sets event bus.
 func (conR *Reactor) SetEventBus(b *types.EventBus) {
 	conR.eventBus = b
@@ -392,6 +499,15 @@ func (conR *Reactor) WaitSync() bool {
 	return conR.waitSync
 }
 
+// FinishSync marks the sync as finished without switching to consensus, e.g.
+// once a read replica caught up with its upstreams, so that WaitSync returns
+// false.
+func (conR *Reactor) FinishSync() {
+	conR.mtx.Lock()
+	conR.waitSync = false
+	conR.mtx.Unlock()
+}
+
 //--------------------------------------
 
 // subscribeToBroadcastEvents subscribes for new round steps and votes
@@ -402,6 +518,7 @@ func (conR *Reactor) subscribeToBroadcastEvents() {
 	if err := conR.conS.evsw.AddListenerForEvent(subscriber, types.EventNewRoundStep,
 		func(data tmevents.EventData) {
 			conR.broadcastNewRoundStepMessage(data.(*cstypes.RoundState))
+			conR.resetMsgWindows()
 		}); err != nil {
 		conR.Logger.Error("Error adding listener for events", "err", err)
 	}
@@ -432,6 +549,17 @@ func (conR *Reactor) broadcastNewRoundStepMessage(rs *cstypes.RoundState) {
 	conR.Switch.Broadcast(StateChannel, MustEncode(nrsMsg))
 }
 
+// resetMsgWindows forgets the last messages received from the peers, since the
+// messages of a later height, round or step which the consensus ignored, e.g.
+// the votes of the next height, can be useful once it entered a new step.
+func (conR *Reactor) resetMsgWindows() {
+	for _, peer := range conR.Switch.Peers().List() {
+		if ps, ok := peer.Get(types.PeerStateKey).(*PeerState); ok {
+			ps.ResetMessages()
+		}
+	}
+}
+
 func (conR *Reactor) broadcastNewValidBlockMessage(rs *cstypes.RoundState) {
 	csMsg := &NewValidBlockMessage{
 		Height:             rs.Height,
@@ -443,33 +571,135 @@ func (conR *Reactor) broadcastNewValidBlockMessage(rs *cstypes.RoundState) {
 	conR.Switch.Broadcast(StateChannel, MustEncode(csMsg))
 }
 
-// Broadcasts HasVoteMessage to peers that care.
+// broadcastHasVoteMessage broadcasts HasVoteMessage to peers which don't
+// support HasVotesMessage. For all other peers, the vote set is marked as
+// pending and will be included in the next aggregated HasVotesMessage.
 func (conR *Reactor) broadcastHasVoteMessage(vote *types.Vote) {
+	conR.hasVotesMtx.Lock()
+	conR.pendingHasVotes[hasVotesKey{vote.Height, vote.Round, vote.Type}] = struct{}{}
+	conR.hasVotesMtx.Unlock()
+
 	msg := &HasVoteMessage{
 		Height: vote.Height,
 		Round:  vote.Round,
 		Type:   vote.Type,
 		Index:  vote.ValidatorIndex,
 	}
-	conR.Switch.Broadcast(StateChannel, MustEncode(msg))
-	/*
-		// TODO: Make this broadcast more selective.
-		for _, peer := range conR.Switch.Peers().List() {
-			ps, ok := peer.Get(PeerStateKey).(*PeerState)
-			if !ok {
-				panic(fmt.Sprintf("Peer %v has no state", peer))
-			}
-			prs := ps.GetRoundState()
-			if prs.Height == vote.Height {
-				// TODO: Also filter on round?
-				peer.TrySend(StateChannel, struct{ ConsensusMessage }{msg})
-			} else {
-				// Height doesn't match
-				// TODO: check a field, maybe CatchupCommitRound?
-				// TODO: But that requires changing the struct field comment.
-			}
+	conR.broadcastToPeers(StateChannel, MustEncode(msg), func(peer p2p.Peer) bool {
+		return !peerSupportsHasVotes(peer) && !peerIsCatchingUp(peer)
+	})
+}
+
+// broadcastHasVotesRoutine periodically broadcasts a HasVotesMessage for
+// every vote set that changed since the last broadcast.
+func (conR *Reactor) broadcastHasVotesRoutine() {
+	ticker := time.NewTicker(hasVotesFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			conR.flushHasVotes()
+		case <-conR.Quit():
+			return
 		}
-	*/
+	}
+}
+
+// flushHasVotes broadcasts a HasVotesMessage with our votes bit-array for
+// every pending vote set to peers which support it.
+func (conR *Reactor) flushHasVotes() {
+	conR.hasVotesMtx.Lock()
+	if len(conR.pendingHasVotes) == 0 {
+		conR.hasVotesMtx.Unlock()
+		return
+	}
+	pending := conR.pendingHasVotes
+	conR.pendingHasVotes = make(map[hasVotesKey]struct{})
+	conR.hasVotesMtx.Unlock()
+
+	rs := conR.conS.GetRoundState()
+	for key := range pending {
+		var votes *types.VoteSet
+		switch {
+		case key.height == rs.Height && key.voteType == tmproto.PrevoteType:
+			votes = rs.Votes.Prevotes(key.round)
+		case key.height == rs.Height && key.voteType == tmproto.PrecommitType:
+			votes = rs.Votes.Precommits(key.round)
+		case key.height == rs.Height-1 && key.voteType == tmproto.PrecommitType &&
+			rs.LastCommit != nil && rs.LastCommit.GetRound() == key.round:
+			votes = rs.LastCommit
+		}
+		// the vote set is gone (e.g. we moved to a new height).
+		if votes == nil {
+			continue
+		}
+
+		msg := &HasVotesMessage{
+			Height: key.height,
+			Round:  key.round,
+			Type:   key.voteType,
+			Votes:  votes.BitArray(),
+		}
+		conR.broadcastToPeers(StateChannel, MustEncode(msg), func(peer p2p.Peer) bool {
+			return peerSupportsHasVotes(peer) && !peerIsCatchingUp(peer)
+		})
+	}
+}
+
+// broadcastToPeers sends msgBytes on the given channel to all peers for
+// which filter returns true. Like Switch.Broadcast, it does not block.
+func (conR *Reactor) broadcastToPeers(chID byte, msgBytes []byte, filter func(p2p.Peer) bool) {
+	for _, peer := range conR.Switch.Peers().List() {
+		if !filter(peer) {
+			continue
+		}
+		go peer.Send(chID, msgBytes)
+	}
+}
+
+// peerIsCatchingUp returns true if the peer is in catch-up mode.
+func peerIsCatchingUp(peer p2p.Peer) bool {
+	ps, ok := peer.Get(types.PeerStateKey).(*PeerState)
+	return ok && ps.IsCatchingUp()
+}
+
+// peerSupportsHasVotes returns true if the peer understands HasVotesMessage.
+func peerSupportsHasVotes(peer p2p.Peer) bool {
+	nodeInfo, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	return ok && nodeInfo.ProtocolVersion.P2P >= hasVotesP2PVersion
+}
+
+// voteMsgPath is the path of the vote in the encoded VoteMessages.
+var voteMsgPath = []int32{6, 1}
+
+// encodeVoteMsgFor encodes the message for the peer, converting the vote to the
+// proto schema of the peer's p2p protocol version (see types.WireCodecFor).
+func encodeVoteMsgFor(peer p2p.Peer, msg *VoteMessage) ([]byte, error) {
+	bz := MustEncode(msg)
+	codec := peerWireCodec(peer)
+	if codec == nil {
+		return bz, nil
+	}
+	return protoio.TranscodeField(bz, voteMsgPath, codec.DowngradeVote)
+}
+
+// upgradeVoteMsg converts the vote of an encoded VoteMessage received from the
+// peer to the current proto schema. Other messages are returned as is.
+func upgradeVoteMsg(peer p2p.Peer, bz []byte) ([]byte, error) {
+	codec := peerWireCodec(peer)
+	if codec == nil {
+		return bz, nil
+	}
+	return protoio.TranscodeField(bz, voteMsgPath, codec.UpgradeVote)
+}
+
+func peerWireCodec(peer p2p.Peer) types.WireCodec {
+	nodeInfo, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	if !ok {
+		return nil
+	}
+	return types.WireCodecFor(nodeInfo.ProtocolVersion.P2P)
 }
 
 func makeRoundStepMessage(rs *cstypes.RoundState) (nrsMsg *NewRoundStepMessage) {
@@ -501,10 +731,14 @@ OUTER_LOOP:
 		}
 		rs := conR.conS.GetRoundState()
 		prs := ps.GetRoundState()
+		catchingUp := conR.updatePeerCatchupMode(logger, ps, rs, prs)
 
 		// Send proposal Block parts?
 		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
-			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
+			header := rs.ProposalBlockParts.Header()
+			missing := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy())
+			holders := func() []int { return blockPartHolders(conR.Switch.Peers().List(), header) }
+			if index, ok := conR.partGossip.pick(header, missing, holders, time.Now()); ok {
 				part := rs.ProposalBlockParts.GetPart(index)
 				msg := &BlockPartMessage{
 					Height: rs.Height, // This tells peer that this part applies to us.
@@ -514,6 +748,7 @@ OUTER_LOOP:
 				logger.Debug("Sending block part", "height", prs.Height, "round", prs.Round)
 				if peer.Send(DataChannel, MustEncode(msg)) {
 					ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
+					conR.partGossip.markSent(header, index, time.Now())
 				}
 				continue OUTER_LOOP
 			}
@@ -537,6 +772,9 @@ OUTER_LOOP:
 				continue OUTER_LOOP
 			}
 			conR.gossipDataForCatchup(heightLogger, rs, prs, ps, peer)
+			if catchingUp {
+				time.Sleep(conR.conS.config.PeerCatchupSleepDuration)
+			}
 			continue OUTER_LOOP
 		}
 
@@ -629,6 +867,33 @@ func (conR *Reactor) gossipDataForCatchup(logger log.Logger, rs *cstypes.RoundSt
 	time.Sleep(conR.conS.config.PeerGossipSleepDuration)
 }
 
+// updatePeerCatchupMode switches the peer in and out of catch-up mode
+// depending on how many heights it is behind us and returns whether the peer
+// is catching up. In catch-up mode, the peer is only sent catch-up commits and
+// block parts (rate limited by PeerCatchupSleepDuration), but no HasVote
+// messages for our current height, which it can't use.
+func (conR *Reactor) updatePeerCatchupMode(logger log.Logger, ps *PeerState,
+	rs *cstypes.RoundState, prs *cstypes.PeerRoundState) bool {
+
+	catchingUp := isPeerLagging(conR.conS.config.PeerCatchupLagThreshold, rs.Height, prs.Height)
+	if ps.setCatchingUp(catchingUp) {
+		if catchingUp {
+			logger.Info("Peer is lagging, switching to catch-up gossip",
+				"height", rs.Height, "peerHeight", prs.Height)
+		} else {
+			logger.Info("Peer caught up, switching to regular gossip",
+				"height", rs.Height, "peerHeight", prs.Height)
+		}
+	}
+	return catchingUp
+}
+
+// isPeerLagging returns true if a peer at peerHeight is at least threshold
+// heights behind height. A threshold of 0 disables lag detection.
+func isPeerLagging(threshold, height, peerHeight int64) bool {
+	return threshold > 0 && peerHeight > 0 && height-peerHeight >= threshold
+}
+
 func (conR *Reactor) gossipVotesRoutine(peer p2p.Peer, ps *PeerState) {
 	logger := conR.Logger.With("peer", peer)
 
@@ -644,6 +909,7 @@ OUTER_LOOP:
 		}
 		rs := conR.conS.GetRoundState()
 		prs := ps.GetRoundState()
+		catchingUp := conR.updatePeerCatchupMode(logger, ps, rs, prs)
 
 		switch sleeping {
 		case 1: // First sleep
@@ -668,6 +934,9 @@ OUTER_LOOP:
 		if prs.Height != 0 && rs.Height == prs.Height+1 {
 			if ps.PickSendVote(rs.LastCommit) {
 				logger.Debug("Picked rs.LastCommit to send", "height", prs.Height)
+				if catchingUp {
+					time.Sleep(conR.conS.config.PeerCatchupSleepDuration)
+				}
 				continue OUTER_LOOP
 			}
 		}
@@ -680,6 +949,9 @@ OUTER_LOOP:
 			if commit := conR.conS.blockStore.LoadBlockCommit(prs.Height); commit != nil {
 				if ps.PickSendVote(commit) {
 					logger.Debug("Picked Catchup commit to send", "height", prs.Height)
+					if catchingUp {
+						time.Sleep(conR.conS.config.PeerCatchupSleepDuration)
+					}
 					continue OUTER_LOOP
 				}
 			}
@@ -859,6 +1131,7 @@ func (conR *Reactor) peerStatsRoutine() {
 
 		select {
 		case msg := <-conR.conS.statsMsgQueue:
+			conR.cacheAddedMsg(msg.Msg)
 			// Get peer
 			peer := conR.Switch.Peers().Get(msg.PeerID)
 			if peer == nil {
@@ -880,6 +1153,7 @@ func (conR *Reactor) peerStatsRoutine() {
 				if numParts := ps.RecordBlockPart(); numParts%blocksToContributeToBecomeGoodPeer == 0 {
 					conR.Switch.MarkPeerAsGood(peer)
 				}
+				conR.Switch.RecordUsefulBlock(peer, msg.Msg.(*BlockPartMessage).Height)
 			}
 		case <-conR.conS.Quit():
 			return
@@ -890,6 +1164,20 @@ func (conR *Reactor) peerStatsRoutine() {
 	}
 }
 
+// cacheAddedMsg adds the vote or block part added to the consensus state to the
+// msgCache.
+func (conR *Reactor) cacheAddedMsg(msg Message) {
+	if conR.msgCache == nil {
+		return
+	}
+	switch msg.(type) {
+	case *VoteMessage:
+		conR.msgCache.add(VoteChannel, MustEncode(msg))
+	case *BlockPartMessage:
+		conR.msgCache.add(DataChannel, MustEncode(msg))
+	}
+}
+
 // String returns a string representation of the Reactor.
 // NOTE: For now, it is just a hard-coded string to avoid accessing unprotected shared variables.
 // TODO: improve!
@@ -936,17 +1224,22 @@ type PeerState struct {
 	mtx   sync.Mutex             // NOTE: Modify below using setters, never directly.
 	PRS   cstypes.PeerRoundState `json:"round_state"` // Exposed.
 	Stats *peerStateStats        `json:"stats"`       // Exposed.
+
+	catchingUp       bool       // peer is too far behind for regular gossip
+	msgWindow        *msgWindow // last messages received from the peer, if enabled
+	windowDuplicates int        // duplicates received since the window was reset
 }
 
 // peerStateStats holds internal statistics for a peer.
 type peerStateStats struct {
 	Votes      int `json:"votes"`
 	BlockParts int `json:"block_parts"`
+	Duplicates int `json:"duplicates"`
 }
 
 func (pss peerStateStats) String() string {
-	return fmt.Sprintf("peerStateStats{votes: %d, blockParts: %d}",
-		pss.Votes, pss.BlockParts)
+	return fmt.Sprintf("peerStateStats{votes: %d, blockParts: %d, duplicates: %d}",
+		pss.Votes, pss.BlockParts, pss.Duplicates)
 }
 
 // NewPeerState returns a new PeerState for the given Peer
@@ -1054,7 +1347,12 @@ func (ps *PeerState) PickSendVote(votes types.VoteSetReader) bool {
 	if vote, ok := ps.PickVoteToSend(votes); ok {
 		msg := &VoteMessage{vote}
 		ps.logger.Debug("Sending vote message", "ps", ps, "vote", vote)
-		if ps.peer.Send(VoteChannel, MustEncode(msg)) {
+		msgBytes, err := encodeVoteMsgFor(ps.peer, msg)
+		if err != nil {
+			ps.logger.Error("Failed to convert the vote for the peer", "ps", ps, "vote", vote, "err", err)
+			return false
+		}
+		if ps.peer.Send(VoteChannel, msgBytes) {
 			ps.SetHasVote(vote)
 			return true
 		}
@@ -1199,6 +1497,24 @@ func (ps *PeerState) ensureVoteBitArrays(height int64, numValidators int) {
 	}
 }
 
+// IsCatchingUp returns true if the peer is in catch-up mode, i.e. it is too
+// far behind us for regular gossip.
+func (ps *PeerState) IsCatchingUp() bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	return ps.catchingUp
+}
+
+// setCatchingUp sets whether the peer is in catch-up mode and returns true
+// if the mode changed.
+func (ps *PeerState) setCatchingUp(catchingUp bool) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	changed := ps.catchingUp != catchingUp
+	ps.catchingUp = catchingUp
+	return changed
+}
+
 // RecordVote increments internal votes related statistics for this peer.
 // It returns the total number of added votes.
 func (ps *PeerState) RecordVote() int {
@@ -1229,6 +1545,34 @@ func (ps *PeerState) RecordBlockPart() int {
 	return ps.Stats.BlockParts
 }
 
+// RecordMessage records the message received from the peer on the channel,
+// returning true if it's an exact duplicate of one of the last messages, and
+// the number of duplicates received since the last ResetMessages. It's a no-op
+// if the peer state has no message window.
+func (ps *PeerState) RecordMessage(chID byte, msgBytes []byte) (bool, int) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.msgWindow == nil || ps.msgWindow.add(chID, msgBytes) {
+		return false, ps.windowDuplicates
+	}
+	ps.Stats.Duplicates++
+	ps.windowDuplicates++
+	return true, ps.windowDuplicates
+}
+
+// ResetMessages forgets the last messages received from the peer, see
+// RecordMessage.
+func (ps *PeerState) ResetMessages() {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.msgWindow != nil {
+		ps.msgWindow.reset()
+	}
+	ps.windowDuplicates = 0
+}
+
 // BlockPartsSent returns the number of useful block parts the peer has sent us.
 func (ps *PeerState) BlockPartsSent() int {
 	ps.mtx.Lock()
@@ -1359,6 +1703,23 @@ func (ps *PeerState) ApplyHasVoteMessage(msg *HasVoteMessage) {
 	ps.setHasVote(msg.Height, msg.Round, msg.Type, msg.Index)
 }
 
+// ApplyHasVotesMessage updates the peer state for the bit-array of votes it
+// claims to have. Votes the peer already had are kept. Messages whose
+// bit-array size doesn't match the validator set are ignored.
+func (ps *PeerState) ApplyHasVotesMessage(msg *HasVotesMessage) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	votes := ps.getVoteBitArray(msg.Height, msg.Round, msg.Type)
+	if votes == nil || votes.Size() != msg.Votes.Size() {
+		return
+	}
+
+	ps.logger.Debug("ApplyHasVotesMessage", "height", msg.Height, "round", msg.Round,
+		"type", msg.Type, "votes", msg.Votes)
+	votes.Update(votes.Or(msg.Votes))
+}
+
 // ApplyVoteSetBitsMessage updates the peer state for the bit-array of votes
 // it claims to have for the corresponding BlockID.
 // `ourVotes` is a BitArray of votes we have for msg.BlockID
@@ -1418,6 +1779,7 @@ func init() {
 	tmjson.RegisterType(&HasVoteMessage{}, "tendermint/HasVote")
 	tmjson.RegisterType(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23")
 	tmjson.RegisterType(&VoteSetBitsMessage{}, "tendermint/VoteSetBits")
+	tmjson.RegisterType(&HasVotesMessage{}, "tendermint/HasVotes")
 }
 
 func decodeMsg(bz []byte) (msg Message, err error) {
@@ -1659,6 +2021,39 @@ func (m *HasVoteMessage) String() string {
 
 //-------------------------------------
 
+// HasVotesMessage is sent to indicate the bit-array of votes received for a
+// given height, round and type. It aggregates multiple HasVoteMessages.
+type HasVotesMessage struct {
+	Height int64
+	Round  int32
+	Type   tmproto.SignedMsgType
+	Votes  *bits.BitArray
+}
+
+// ValidateBasic performs basic validation.
+func (m *HasVotesMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if !types.IsVoteTypeValid(m.Type) {
+		return errors.New("invalid Type")
+	}
+	if m.Votes.Size() > types.MaxVotesCount {
+		return fmt.Errorf("votes bit array is too big: %d, max: %d", m.Votes.Size(), types.MaxVotesCount)
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *HasVotesMessage) String() string {
+	return fmt.Sprintf("[HasVotes %v/%02d/%v %v]", m.Height, m.Round, m.Type, m.Votes)
+}
+
+//-------------------------------------
+
 // VoteSetMaj23Message is sent to indicate that a given BlockID has seen +2/3 votes.
 type VoteSetMaj23Message struct {
 	Height  int64
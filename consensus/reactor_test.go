@@ -2,6 +2,7 @@ package consensus
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
@@ -11,6 +12,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/generic"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -36,6 +39,8 @@ import (
 	statemocks "github.com/tendermint/tendermint/state/mocks"
 	"github.com/tendermint/tendermint/store"
 	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+	"github.com/tendermint/tendermint/version"
 )
 
 //----------------------------------------------
@@ -120,6 +125,25 @@ func TestReactorBasic(t *testing.T) {
 	}, css)
 }
 
+// Ensure the blocks propagate with a limited fan-out and the rarest-first
+// selection of the block parts
+func TestReactorBlockPartFanout(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter,
+		func(c *cfg.Config) {
+			c.Consensus.BlockPartFanout = 1
+			c.Consensus.BlockPartSelection = cfg.BlockPartSelectionRarestFirst
+		})
+	defer cleanup()
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+	for i := 0; i < 2; i++ {
+		timeoutWaitGroup(t, N, func(j int) {
+			<-blocksSubs[j].Out()
+		}, css)
+	}
+}
+
 // Ensure we can process blocks with evidence
 func TestReactorWithEvidence(t *testing.T) {
 	nValidators := 4
@@ -276,6 +300,188 @@ func TestReactorReceivePanicsIfInitPeerHasntBeenCalledYet(t *testing.T) {
 	})
 }
 
+// Ensure the exact duplicates are dropped, and the peer stopped once it sent
+// too many of them.
+func TestReactorDropsDuplicateMessages(t *testing.T) {
+	N := 1
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	css[0].config.PeerMaxDuplicates = 2
+	reactors, _, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	var (
+		reactor = reactors[0]
+		peer    = p2pmock.NewPeer(nil)
+		parts   = types.NewPartSetFromData([]byte("data"), types.BlockPartSizeBytes)
+		msg     = MustEncode(&BlockPartMessage{Height: 100, Round: 0, Part: parts.GetPart(0)})
+	)
+	reactor.InitPeer(peer)
+	reactor.AddPeer(peer)
+	ps := peer.Get(types.PeerStateKey).(*PeerState)
+
+	reactor.Receive(DataChannel, peer, msg)
+	reactor.Receive(DataChannel, peer, msg)
+	reactor.Receive(DataChannel, peer, msg)
+	assert.Equal(t, 2, ps.Stats.Duplicates)
+	assert.True(t, peer.IsRunning())
+
+	reactor.Receive(DataChannel, peer, msg)
+	assert.Equal(t, 3, ps.Stats.Duplicates)
+	assert.False(t, peer.IsRunning())
+}
+
+// Ensure the duplicates are counted per step, so that a long-lived peer isn't
+// stopped once it sent too many of them in total.
+func TestReactorCountsDuplicateMessagesPerStep(t *testing.T) {
+	N := 1
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	css[0].config.PeerMaxDuplicates = 2
+	reactors, _, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	var (
+		reactor = reactors[0]
+		peer    = p2pmock.NewPeer(nil)
+		parts   = types.NewPartSetFromData([]byte("data"), types.BlockPartSizeBytes)
+		msg     = MustEncode(&BlockPartMessage{Height: 100, Round: 0, Part: parts.GetPart(0)})
+	)
+	reactor.InitPeer(peer)
+	reactor.AddPeer(peer)
+	ps := peer.Get(types.PeerStateKey).(*PeerState)
+
+	for step := 0; step < 3; step++ {
+		reactor.Receive(DataChannel, peer, msg)
+		reactor.Receive(DataChannel, peer, msg)
+		reactor.Receive(DataChannel, peer, msg)
+		// the consensus entered a new step
+		ps.ResetMessages()
+	}
+	assert.Equal(t, 6, ps.Stats.Duplicates)
+	assert.True(t, peer.IsRunning())
+}
+
+func TestReactorDropsRedundantMessages(t *testing.T) {
+	N := 1
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	reactors, _, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	var (
+		reactor   = reactors[0]
+		peer1     = p2pmock.NewPeer(nil)
+		peer2     = p2pmock.NewPeer(nil)
+		peer3     = p2pmock.NewPeer(nil)
+		parts     = types.NewPartSetFromData([]byte("data"), types.BlockPartSizeBytes)
+		msg       = MustEncode(&BlockPartMessage{Height: 100, Round: 0, Part: parts.GetPart(0)})
+		redundant = generic.NewCounter("redundant")
+	)
+	reactor.Metrics.RedundantMessages = unlabeledCounter{redundant}
+	for _, peer := range []*p2pmock.Peer{peer1, peer2, peer3} {
+		reactor.InitPeer(peer)
+		reactor.AddPeer(peer)
+	}
+
+	// the block part isn't added to the state without its proposal, so it must
+	// be accepted again
+	reactor.Receive(DataChannel, peer1, msg)
+	reactor.Receive(DataChannel, peer2, msg)
+	assert.EqualValues(t, 0, redundant.Value())
+
+	decoded, err := decodeMsg(msg)
+	require.NoError(t, err)
+	reactor.cacheAddedMsg(decoded)
+	reactor.Receive(DataChannel, peer3, msg)
+	assert.EqualValues(t, 1, redundant.Value())
+
+	// the peers running other versions may encode it differently, e.g. with a
+	// field unknown to this version
+	legacyMsg := append(append([]byte{}, msg...), 15<<3, 1)
+	reactor.Receive(DataChannel, peer3, legacyMsg)
+	assert.EqualValues(t, 2, redundant.Value())
+
+	// it isn't a duplicate sent by the peer
+	assert.Equal(t, 0, peer3.Get(types.PeerStateKey).(*PeerState).Stats.Duplicates)
+	assert.True(t, peer3.IsRunning())
+}
+
+// unlabeledCounter is a counter ignoring the label values, so that all the
+// values are added to the same counter.
+type unlabeledCounter struct{ *generic.Counter }
+
+func (c unlabeledCounter) With(...string) metrics.Counter { return c }
+
+// jsonVoteWireCodec is the codec of a legacy schema encoding the votes as JSON.
+type jsonVoteWireCodec struct{}
+
+func (jsonVoteWireCodec) UpgradeBlock(bz []byte) ([]byte, error)   { return bz, nil }
+func (jsonVoteWireCodec) DowngradeBlock(bz []byte) ([]byte, error) { return bz, nil }
+
+func (jsonVoteWireCodec) UpgradeVote(bz []byte) ([]byte, error) {
+	vote := &tmproto.Vote{}
+	if err := json.Unmarshal(bz, vote); err != nil {
+		return nil, err
+	}
+	return vote.Marshal()
+}
+
+func (jsonVoteWireCodec) DowngradeVote(bz []byte) ([]byte, error) {
+	vote := &tmproto.Vote{}
+	if err := vote.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+	return json.Marshal(vote)
+}
+
+type versionedPeer struct {
+	*p2pmock.Peer
+	p2pVersion uint64
+}
+
+func (p versionedPeer) NodeInfo() p2p.NodeInfo {
+	return p2p.DefaultNodeInfo{ProtocolVersion: p2p.NewProtocolVersion(p.p2pVersion, version.BlockProtocol, 0)}
+}
+
+// registerJSONVoteWireCodec registers the codec once per test binary, as the
+// registry can't be reset from outside the types package.
+var registerJSONVoteWireCodec sync.Once
+
+// Ensure the votes are converted to and from the schema of the legacy peers.
+func TestReactorConvertsVotesOfLegacyPeers(t *testing.T) {
+	legacy := version.P2PProtocol - 1
+	registerJSONVoteWireCodec.Do(func() { types.RegisterWireCodec(legacy, jsonVoteWireCodec{}) })
+	var (
+		legacyPeer  = versionedPeer{Peer: p2pmock.NewPeer(nil), p2pVersion: legacy}
+		currentPeer = versionedPeer{Peer: p2pmock.NewPeer(nil), p2pVersion: version.P2PProtocol}
+		msg         = &VoteMessage{Vote: &types.Vote{Type: tmproto.PrecommitType, Height: 2, Round: 1,
+			Timestamp: tmtime.Now(), ValidatorAddress: tmhash.SumTruncated([]byte("validator")),
+			Signature: []byte("signature")}}
+		current = MustEncode(msg)
+	)
+
+	bz, err := encodeVoteMsgFor(currentPeer, msg)
+	require.NoError(t, err)
+	assert.Equal(t, current, bz)
+	bz, err = upgradeVoteMsg(currentPeer, bz)
+	require.NoError(t, err)
+	assert.Equal(t, current, bz)
+
+	bz, err = encodeVoteMsgFor(legacyPeer, msg)
+	require.NoError(t, err)
+	assert.NotEqual(t, current, bz)
+	bz, err = upgradeVoteMsg(legacyPeer, bz)
+	require.NoError(t, err)
+	assert.Equal(t, current, bz)
+
+	// the other messages are the same in both schemas
+	hasVote := MustEncode(&HasVoteMessage{Height: 2, Round: 1, Type: tmproto.PrecommitType, Index: 1})
+	bz, err = upgradeVoteMsg(legacyPeer, hasVote)
+	require.NoError(t, err)
+	assert.Equal(t, hasVote, bz)
+}
+
 // Test we record stats about votes and block parts from other peers.
 func TestReactorRecordsVotesAndBlockParts(t *testing.T) {
 	N := 4
@@ -887,6 +1093,93 @@ func TestHasVoteMessageValidateBasic(t *testing.T) {
 	}
 }
 
+func TestHasVotesMessageValidateBasic(t *testing.T) {
+	testCases := []struct {
+		malleateFn func(*HasVotesMessage)
+		expErr     string
+	}{
+		{func(msg *HasVotesMessage) {}, ""},
+		{func(msg *HasVotesMessage) { msg.Height = -1 }, "negative Height"},
+		{func(msg *HasVotesMessage) { msg.Round = -1 }, "negative Round"},
+		{func(msg *HasVotesMessage) { msg.Type = 0x03 }, "invalid Type"},
+		{func(msg *HasVotesMessage) { msg.Votes = bits.NewBitArray(types.MaxVotesCount + 1) },
+			"votes bit array is too big: 10001, max: 10000"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			msg := &HasVotesMessage{
+				Height: 1,
+				Round:  0,
+				Type:   0x01,
+				Votes:  bits.NewBitArray(1),
+			}
+
+			tc.malleateFn(msg)
+			err := msg.ValidateBasic()
+			if tc.expErr != "" && assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPeerStateApplyHasVotesMessage(t *testing.T) {
+	ps := NewPeerState(nil)
+	ps.ApplyNewRoundStepMessage(&NewRoundStepMessage{Height: 1, Round: 0, Step: cstypes.RoundStepPrevote})
+	ps.EnsureVoteBitArrays(1, 4)
+	ps.setHasVote(1, 0, tmproto.PrevoteType, 0)
+
+	votes := bits.NewBitArray(4)
+	votes.SetIndex(2, true)
+	ps.ApplyHasVotesMessage(&HasVotesMessage{Height: 1, Round: 0, Type: tmproto.PrevoteType, Votes: votes})
+
+	// previously known votes are kept and new ones are added
+	prevotes := ps.GetRoundState().Prevotes
+	assert.Equal(t, "BA{4:x_x_}", prevotes.String())
+	assert.Equal(t, "BA{4:____}", ps.GetRoundState().Precommits.String())
+
+	// bit-arrays of the wrong size are ignored
+	ps.ApplyHasVotesMessage(&HasVotesMessage{Height: 1, Round: 0, Type: tmproto.PrevoteType,
+		Votes: bits.NewBitArray(5)})
+	assert.Equal(t, "BA{4:x_x_}", ps.GetRoundState().Prevotes.String())
+}
+
+func TestIsPeerLagging(t *testing.T) {
+	testCases := []struct {
+		threshold, height, peerHeight int64
+		lagging                       bool
+	}{
+		{2, 10, 10, false},
+		{2, 10, 9, false},
+		{2, 10, 8, true},
+		{2, 10, 1, true},
+		{2, 10, 0, false}, // unknown peer height
+		{2, 10, 11, false},
+		{1, 10, 9, true},
+		{0, 10, 1, false}, // disabled
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.lagging, isPeerLagging(tc.threshold, tc.height, tc.peerHeight), "#%d", i)
+	}
+}
+
+func TestPeerStateSetCatchingUp(t *testing.T) {
+	ps := NewPeerState(nil)
+	assert.False(t, ps.IsCatchingUp())
+
+	assert.True(t, ps.setCatchingUp(true))
+	assert.False(t, ps.setCatchingUp(true))
+	assert.True(t, ps.IsCatchingUp())
+
+	assert.True(t, ps.setCatchingUp(false))
+	assert.False(t, ps.IsCatchingUp())
+}
+
 func TestVoteSetMaj23MessageValidateBasic(t *testing.T) {
 	const (
 		validSignedMsgType   tmproto.SignedMsgType = 0x01
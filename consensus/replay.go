@@ -11,6 +11,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/libs/alert"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
@@ -19,6 +20,10 @@ import (
 
 var crc32c = crc32.MakeTable(crc32.Castagnoli)
 
+// replayProgressInterval is the interval at which the progress of the
+// handshake replay is logged.
+const replayProgressInterval = 10 * time.Second
+
 // Functionality to replay blocks and messages on recovery from a crash.
 // There are two general failure scenarios:
 //
@@ -205,8 +210,30 @@ type Handshaker struct {
 	eventBus     types.BlockEventPublisher
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
+	metrics      *Metrics
+	alerter      *alert.Alerter
 
 	nBlocks int // number of blocks applied to the state
+
+	// replayFrom, if > 0, is the operator-confirmed height to start replaying
+	// blocks from, overriding the height reported by the app.
+	replayFrom int64
+
+	// the replayed blocks must match them
+	checkpoints types.Checkpoints
+
+	// bookkeeping for ReplayDiagnosis
+	appHeight       int64
+	appHash         []byte
+	firstReplayed   int64
+	lastReplayed    int64
+	comparedHeights int
+
+	// bookkeeping for the replay progress
+	replayTarget  int64
+	lastProgress  time.Time
+	progressStart time.Time
+	progressBase  int
 }
 
 func NewHandshaker(stateStore sm.Store, state sm.State,
@@ -219,6 +246,7 @@ func NewHandshaker(stateStore sm.Store, state sm.State,
 		eventBus:     types.NopEventBus{},
 		genDoc:       genDoc,
 		logger:       log.NewNopLogger(),
+		metrics:      NopMetrics(),
 		nBlocks:      0,
 	}
 }
@@ -233,11 +261,39 @@ func (h *Handshaker) SetEventBus(eventBus types.BlockEventPublisher) {
 	h.eventBus = eventBus
 }
 
+// SetMetrics sets the metrics reporting the progress of the replay.
+// If not called, it defaults to NopMetrics.
+func (h *Handshaker) SetMetrics(metrics *Metrics) {
+	h.metrics = metrics
+}
+
+// SetAlerter sets the alerter notified of app hash mismatches.
+// If not called, no alert is sent.
+func (h *Handshaker) SetAlerter(alerter *alert.Alerter) {
+	h.alerter = alerter
+}
+
 // NBlocks returns the number of blocks applied to the state.
 func (h *Handshaker) NBlocks() int {
 	return h.nBlocks
 }
 
+// SetReplayFrom instructs the handshaker to replay blocks starting at the
+// given height, regardless of the height and app hash reported by the app.
+//
+// This is a recovery path which must only be used after the operator has
+// confirmed that the application's state corresponds to height-1 (e.g. it was
+// restored from a backup). The app hash recorded by Tendermint for height-1 is
+// used as the starting point, so every replayed block is still verified.
+func (h *Handshaker) SetReplayFrom(height int64) {
+	h.replayFrom = height
+}
+
+// SetCheckpoints sets the checkpoints the replayed blocks must match.
+func (h *Handshaker) SetCheckpoints(checkpoints types.Checkpoints) {
+	h.checkpoints = checkpoints
+}
+
 // TODO: retry the handshake/replay if it fails ?
 func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 
@@ -260,6 +316,23 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 		"protocol-version", res.AppVersion,
 	)
 
+	h.appHeight = blockHeight
+	h.appHash = appHash
+
+	if h.replayFrom > 0 {
+		blockHeight, appHash, err = h.replayFromHeight(blockHeight)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Halt if the chain reached an app version upgrade not supported by the app.
+	err = sm.CheckAppVersionUpgrade(h.initialState.AppVersionUpgrade, h.initialState.LastBlockHeight+1,
+		res.AppVersion)
+	if err != nil {
+		return err
+	}
+
 	// Only set the version if there is no existing state.
 	if h.initialState.LastBlockHeight == 0 {
 		h.initialState.Version.Consensus.App = res.AppVersion
@@ -279,6 +352,59 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 	return nil
 }
 
+// replayFromHeight validates the operator-provided replay height against the
+// app, store and state heights, and returns the app height and app hash the
+// replay should start from.
+func (h *Handshaker) replayFromHeight(appHeight int64) (int64, []byte, error) {
+	var (
+		from        = h.replayFrom
+		storeBase   = h.store.Base()
+		storeHeight = h.store.Height()
+		stateHeight = h.initialState.LastBlockHeight
+	)
+
+	switch {
+	case from < h.initialState.InitialHeight:
+		return 0, nil, fmt.Errorf("cannot replay from height %d, below initial height %d",
+			from, h.initialState.InitialHeight)
+	case from > storeHeight+1 || from > stateHeight+1:
+		return 0, nil, fmt.Errorf("cannot replay from height %d, above store height %d and state height %d",
+			from, storeHeight, stateHeight)
+	case from > h.initialState.InitialHeight && from < storeBase:
+		return 0, nil, fmt.Errorf("cannot replay from height %d, below block store base %d", from, storeBase)
+	case from-1 > appHeight:
+		return 0, nil, fmt.Errorf("cannot replay from height %d, the app is at height %d and blocks %d-%d would be skipped",
+			from, appHeight, appHeight+1, from-1)
+	}
+
+	// Replaying from the initial height goes through InitChain, which returns
+	// the app hash to start from.
+	var (
+		startHeight = from - 1
+		appHash     []byte
+	)
+	switch {
+	case from == h.initialState.InitialHeight:
+		startHeight = 0
+	case from <= storeHeight:
+		meta := h.store.LoadBlockMeta(from)
+		if meta == nil {
+			return 0, nil, fmt.Errorf("cannot replay from height %d, block meta not found", from)
+		}
+		appHash = meta.Header.AppHash
+	default:
+		appHash = h.initialState.AppHash
+	}
+
+	h.logger.Error("Replaying blocks from operator-confirmed height, ignoring app reported height and hash",
+		"replayFrom", from,
+		"appHeight", appHeight,
+		"appHash", fmt.Sprintf("%X", h.appHash),
+		"assumedAppHash", fmt.Sprintf("%X", appHash))
+
+	return startHeight, appHash, nil
+}
+
 // ReplayBlocks replays all blocks since appBlockHeight and ensures the result
 // matches the current state.
 // Returns the final AppHash or an error.
@@ -299,6 +425,8 @@ func (h *Handshaker) ReplayBlocks(
 		storeBlockHeight,
 		"stateHeight",
 		stateBlockHeight)
+	h.replayTarget = storeBlockHeight
+	h.progressStart, h.lastProgress, h.progressBase = time.Now(), time.Now(), h.nBlocks
 
 	// If appBlockHeight == 0 it means that we are at genesis and hence should send InitChain.
 	if appBlockHeight == 0 {
@@ -359,7 +487,7 @@ func (h *Handshaker) ReplayBlocks(
 	// First handle edge cases and constraints on the storeBlockHeight and storeBlockBase.
 	switch {
 	case storeBlockHeight == 0:
-		assertAppHashEqualsOneFromState(appHash, state)
+		h.assertAppHashEqualsOneFromState(appHash, state)
 		return appHash, nil
 
 	case appBlockHeight == 0 && state.InitialHeight < storeBlockBase:
@@ -395,7 +523,7 @@ func (h *Handshaker) ReplayBlocks(
 
 		} else if appBlockHeight == storeBlockHeight {
 			// We're good!
-			assertAppHashEqualsOneFromState(appHash, state)
+			h.assertAppHashEqualsOneFromState(appHash, state)
 			return appHash, nil
 		}
 
@@ -461,12 +589,22 @@ func (h *Handshaker) replayBlocks(
 	if firstBlock == 1 {
 		firstBlock = state.InitialHeight
 	}
+	if err := h.verifyCheckpoints(firstBlock, finalBlock); err != nil {
+		return nil, err
+	}
+	if err := h.checkBlockParts(firstBlock); err != nil {
+		return nil, err
+	}
+
 	for i := firstBlock; i <= finalBlock; i++ {
-		h.logger.Info("Applying block", "height", i)
+		h.logger.Debug("Applying block", "height", i)
 		block := h.store.LoadBlock(i)
+		if block == nil {
+			return nil, fmt.Errorf("block %d to replay not found in the block store", i)
+		}
 		// Extra check to ensure the app was not changed in a way it shouldn't have.
 		if len(appHash) > 0 {
-			assertAppHashEqualsOneFromBlock(appHash, block)
+			h.assertAppHashEqualsOneFromBlock(appHash, block)
 		}
 
 		appHash, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, h.logger, h.stateStore, h.genDoc.InitialHeight)
@@ -474,7 +612,7 @@ func (h *Handshaker) replayBlocks(
 			return nil, err
 		}
 
-		h.nBlocks++
+		h.markReplayed(i)
 	}
 
 	if mutateState {
@@ -486,14 +624,38 @@ func (h *Handshaker) replayBlocks(
 		appHash = state.AppHash
 	}
 
-	assertAppHashEqualsOneFromState(appHash, state)
+	h.assertAppHashEqualsOneFromState(appHash, state)
 	return appHash, nil
 }
 
+// checkBlockParts returns an error if the parts of the blocks from the given
+// height were pruned (see store.BlockStore.PruneBlockParts), so they can't be
+// replayed to the app.
+func (h *Handshaker) checkBlockParts(height int64) error {
+	type partsStore interface {
+		PartsBase() int64
+	}
+	if store, ok := h.store.(partsStore); ok && height < store.PartsBase() {
+		return fmt.Errorf("can't replay the blocks from height %d to the app: their parts were pruned below "+
+			"height %d (see block_parts_retain_heights), the app must be restored to a later height",
+			height, store.PartsBase())
+	}
+	return nil
+}
+
 // ApplyBlock on the proxyApp with the last block.
 func (h *Handshaker) replayBlock(state sm.State, height int64, proxyApp proxy.AppConnConsensus) (sm.State, error) {
+	if err := h.checkBlockParts(height); err != nil {
+		return sm.State{}, err
+	}
 	block := h.store.LoadBlock(height)
 	meta := h.store.LoadBlockMeta(height)
+	if block == nil || meta == nil {
+		return sm.State{}, fmt.Errorf("block %d to replay not found in the block store", height)
+	}
+	if err := h.checkpoints.VerifyBlock(height, meta.BlockID.Hash); err != nil {
+		return sm.State{}, err
+	}
 
 	// Use stubs for both mempool and evidence pool since no transactions nor
 	// evidence are needed here - block already exists.
@@ -506,29 +668,184 @@ func (h *Handshaker) replayBlock(state sm.State, height int64, proxyApp proxy.Ap
 		return sm.State{}, err
 	}
 
-	h.nBlocks++
+	h.markReplayed(height)
 
 	return state, nil
 }
 
-func assertAppHashEqualsOneFromBlock(appHash []byte, block *types.Block) {
-	if !bytes.Equal(appHash, block.AppHash) {
-		panic(fmt.Sprintf(`block.AppHash does not match AppHash after replay. Got %X, expected %X.
+// verifyCheckpoints returns an error if a stored block between the heights
+// (inclusive) contradicts a checkpoint.
+func (h *Handshaker) verifyCheckpoints(from, to int64) error {
+	for height := range h.checkpoints {
+		if height < from || height > to {
+			continue
+		}
+		meta := h.store.LoadBlockMeta(height)
+		if meta == nil {
+			return fmt.Errorf("no block #%d to replay", height)
+		}
+		if err := h.checkpoints.VerifyBlock(height, meta.BlockID.Hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handshaker) markReplayed(height int64) {
+	if h.firstReplayed == 0 {
+		h.firstReplayed = height
+	}
+	h.lastReplayed = height
+	h.nBlocks++
+	h.reportProgress(height)
+}
+
+// reportProgress updates the replay metrics, and logs the progress of the
+// replay every replayProgressInterval and once the last block is replayed.
+func (h *Handshaker) reportProgress(height int64) {
+	remaining := h.replayTarget - height
+	if remaining < 0 {
+		remaining = 0
+	}
+	h.metrics.ReplayHeight.Set(float64(height))
+	h.metrics.ReplayRemainingBlocks.Set(float64(remaining))
+
+	now := time.Now()
+	if now.Sub(h.lastProgress) < replayProgressInterval && remaining > 0 {
+		return
+	}
+	h.lastProgress = now
+
+	rate := float64(h.nBlocks-h.progressBase) / now.Sub(h.progressStart).Seconds()
+	keyvals := []interface{}{"height", height, "target", h.replayTarget, "remaining", remaining,
+		"blocks/s", fmt.Sprintf("%.2f", rate)}
+	if remaining > 0 && rate > 0 {
+		eta := time.Duration(float64(remaining) / rate * float64(time.Second))
+		keyvals = append(keyvals, "eta", eta.Round(time.Second))
+	}
+	h.logger.Info("Replaying blocks", keyvals...)
+}
+
+func (h *Handshaker) assertAppHashEqualsOneFromBlock(appHash []byte, block *types.Block) {
+	// block.AppHash is the app hash after executing the previous block.
+	h.assertAppHashEquals(appHash, block.AppHash, block.Height-1, fmt.Sprintf("Block: %v", block))
+}
 
-Block: %v
-`,
-			appHash, block.AppHash, block))
+func (h *Handshaker) assertAppHashEqualsOneFromState(appHash []byte, state sm.State) {
+	h.assertAppHashEquals(appHash, state.AppHash, state.LastBlockHeight, fmt.Sprintf("State: %v", state))
+}
+
+// assertAppHashEquals panics with a ReplayDiagnosis if got != expected. The
+// diagnosis is also logged, so it ends up in the node's logs in a structured
+// form.
+func (h *Handshaker) assertAppHashEquals(got, expected []byte, height int64, details string) {
+	if bytes.Equal(got, expected) {
+		h.comparedHeights++
+		return
 	}
+
+	diag := h.diagnose(height, got, expected)
+	h.logger.Error("App hash mismatch during handshake replay", diag.keyvals()...)
+	h.alerter.Alert(alert.AppHashMismatch, "app hash mismatch at height %d during handshake replay: got %X, expected %X",
+		height, got, expected)
+	panic(fmt.Sprintf("%v\n\n%s", diag, details))
 }
 
-func assertAppHashEqualsOneFromState(appHash []byte, state sm.State) {
-	if !bytes.Equal(appHash, state.AppHash) {
-		panic(fmt.Sprintf(`state.AppHash does not match AppHash after replay. Got
-%X, expected %X.
+func (h *Handshaker) diagnose(height int64, got, expected []byte) ReplayDiagnosis {
+	diag := ReplayDiagnosis{
+		AppHeight:       h.appHeight,
+		AppHash:         h.appHash,
+		StoreBase:       h.store.Base(),
+		StoreHeight:     h.store.Height(),
+		StateHeight:     h.initialState.LastBlockHeight,
+		ReplayFrom:      h.replayFrom,
+		FirstReplayed:   h.firstReplayed,
+		LastReplayed:    h.lastReplayed,
+		ComparedHeights: h.comparedHeights,
+		MismatchHeight:  height,
+		GotAppHash:      got,
+		ExpectedAppHash: expected,
+	}
 
-State: %v
+	switch {
+	case h.firstReplayed == 0 && h.appHeight == 0 && height == 0:
+		diag.SuspectedCause = "the app hash returned by InitChain does not match the app_hash in the genesis file"
+	case h.firstReplayed == 0:
+		diag.SuspectedCause = "the app's data does not match Tendermint's: was Tendermint reset without " +
+			"resetting the app's data, or was the app restored from a different backup?"
+	case h.comparedHeights == 0 || height <= h.firstReplayed:
+		diag.SuspectedCause = "the app's state before replay diverges from Tendermint's: the app's data " +
+			"may have been reset, restored or migrated without resetting Tendermint"
+	default:
+		diag.SuspectedCause = "the app produced a different app hash for a block it previously executed: " +
+			"non-deterministic execution, or the app binary was changed without an upgrade"
+	}
+
+	return diag
+}
+
+// ReplayDiagnosis describes the handshake state at the point where the app hash
+// produced by the app diverged from the one recorded by Tendermint.
+type ReplayDiagnosis struct {
+	AppHeight   int64  // height reported by the app via Info
+	AppHash     []byte // app hash reported by the app via Info
+	StoreBase   int64
+	StoreHeight int64
+	StateHeight int64
+	ReplayFrom  int64 // operator-confirmed replay height, 0 if not set
+
+	FirstReplayed   int64 // first replayed height, 0 if no block was replayed
+	LastReplayed    int64 // last replayed height, 0 if no block was replayed
+	ComparedHeights int   // number of app hashes which matched before the mismatch
+
+	MismatchHeight  int64 // height after which the app hashes differ
+	GotAppHash      []byte
+	ExpectedAppHash []byte
+
+	SuspectedCause string
+}
 
-Did you reset Tendermint without resetting your application's data?`,
-			appHash, state.AppHash, state))
+func (d ReplayDiagnosis) keyvals() []interface{} {
+	return []interface{}{
+		"appHeight", d.AppHeight,
+		"appHash", fmt.Sprintf("%X", d.AppHash),
+		"storeBase", d.StoreBase,
+		"storeHeight", d.StoreHeight,
+		"stateHeight", d.StateHeight,
+		"replayFrom", d.ReplayFrom,
+		"replayed", d.replayedRange(),
+		"matchedHashes", d.ComparedHeights,
+		"mismatchHeight", d.MismatchHeight,
+		"got", fmt.Sprintf("%X", d.GotAppHash),
+		"expected", fmt.Sprintf("%X", d.ExpectedAppHash),
+		"suspectedCause", d.SuspectedCause,
 	}
 }
+
+func (d ReplayDiagnosis) replayedRange() string {
+	if d.FirstReplayed == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%d-%d", d.FirstReplayed, d.LastReplayed)
+}
+
+func (d ReplayDiagnosis) String() string {
+	return fmt.Sprintf(`app hash mismatch after height %d during handshake replay.
+Got %X, expected %X.
+
+App:    height %d, hash %X
+Store:  base %d, height %d
+State:  height %d
+Replay: blocks %s, %d matching app hashes before the mismatch
+
+Suspected cause: %s
+
+If you have restored the app's data to a known good height H-1, restart
+with --replay-from H to replay blocks from height H.`,
+		d.MismatchHeight, d.GotAppHash, d.ExpectedAppHash,
+		d.AppHeight, d.AppHash,
+		d.StoreBase, d.StoreHeight,
+		d.StateHeight,
+		d.replayedRange(), d.ComparedHeights,
+		d.SuspectedCause)
+}
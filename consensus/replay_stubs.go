@@ -1,6 +1,8 @@
 package consensus
 
 import (
+	"io"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/clist"
 	mempl "github.com/tendermint/tendermint/mempool"
@@ -37,6 +39,10 @@ func (emptyMempool) FlushAppConn() error           { return nil }
 func (emptyMempool) TxsAvailable() <-chan struct{} { return make(chan struct{}) }
 func (emptyMempool) EnableTxsAvailable()           {}
 func (emptyMempool) TxsBytes() int64               { return 0 }
+func (emptyMempool) MinGasPrice() int64            { return 0 }
+func (emptyMempool) Stats() mempl.Stats            { return mempl.Stats{} }
+func (emptyMempool) Export(io.Writer) (int, error) { return 0, nil }
+func (emptyMempool) Import(io.Reader) (int, error) { return 0, nil }
 
 func (emptyMempool) TxsFront() *clist.CElement    { return nil }
 func (emptyMempool) TxsWaitChan() <-chan struct{} { return nil }
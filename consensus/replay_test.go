@@ -948,6 +948,194 @@ func TestHandshakePanicsIfAppReturnsWrongAppHash(t *testing.T) {
 	}
 }
 
+func TestHandshakeDiagnosesAppHashMismatch(t *testing.T) {
+	config := ResetConfig("handshake_test_")
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	const appVersion = 0x0
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(config, pubKey, appVersion)
+	stateStore := sm.NewStore(stateDB)
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	state.LastValidators = state.Validators.Copy()
+	blocks := makeBlocks(3, &state, privVal)
+	store.chain = blocks
+
+	testCases := []struct {
+		name  string
+		app   *badApp
+		cause string
+	}{
+		{"first block", &badApp{numBlocks: 3, allHashesAreWrong: true}, "state before replay diverges"},
+		{"last block", &badApp{numBlocks: 3, onlyLastHashIsWrong: true}, "non-deterministic execution"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(tc.app))
+			require.NoError(t, proxyApp.Start())
+			t.Cleanup(func() {
+				if err := proxyApp.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			msg := func() (msg string) {
+				defer func() {
+					if r := recover(); r != nil {
+						msg = fmt.Sprint(r)
+					}
+				}()
+				h := NewHandshaker(stateStore, state, store, genDoc)
+				if err := h.Handshake(proxyApp); err != nil {
+					t.Log(err)
+				}
+				return ""
+			}()
+			require.NotEmpty(t, msg, "expected handshake to panic")
+			assert.Contains(t, msg, tc.cause)
+			assert.Contains(t, msg, "--replay-from")
+		})
+	}
+}
+
+func TestHandshakeReplayFrom(t *testing.T) {
+	config := ResetConfig("handshake_test_")
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	const appVersion = 0x0
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(config, pubKey, appVersion)
+	stateStore := sm.NewStore(stateDB)
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	state.LastValidators = state.Validators.Copy()
+	blocks := makeBlocks(3, &state, privVal)
+	store.chain = blocks
+
+	testCases := []struct {
+		name       string
+		replayFrom int64
+		expectErr  bool
+	}{
+		{"above store height", 5, true},
+		{"skips blocks the app does not have", 3, true},
+		{"from initial height", 1, false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// the app is at height 0 and returns the hashes recorded in the blocks
+			app := &badApp{numBlocks: 3, onlyLastHashIsWrong: true}
+			proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+			require.NoError(t, proxyApp.Start())
+			t.Cleanup(func() {
+				if err := proxyApp.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			h := NewHandshaker(stateStore, state, store, genDoc)
+			h.SetReplayFrom(tc.replayFrom)
+			if tc.expectErr {
+				assert.Error(t, h.Handshake(proxyApp))
+				return
+			}
+			// the last app hash is wrong, so the replay must still be verified
+			assert.Panics(t, func() {
+				_ = h.Handshake(proxyApp)
+			})
+			assert.EqualValues(t, 3, h.NBlocks())
+		})
+	}
+}
+
+func TestHandshakeReplayBelowPartsBase(t *testing.T) {
+	config := ResetConfig("handshake_test_")
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	const appVersion = 0x0
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(config, pubKey, appVersion)
+	stateStore := sm.NewStore(stateDB)
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	state.LastValidators = state.Validators.Copy()
+	store.chain = makeBlocks(3, &state, privVal)
+	store.partsBase = 2
+
+	// the app is at height 0, so block 1, whose parts were pruned, must be
+	// replayed
+	app := &badApp{numBlocks: 3, onlyLastHashIsWrong: true}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	h := NewHandshaker(stateStore, state, store, genDoc)
+	err = h.Handshake(proxyApp)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pruned")
+	assert.Zero(t, h.NBlocks())
+}
+
+func TestHandshakeVerifiesCheckpoints(t *testing.T) {
+	config := ResetConfig("handshake_test_")
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	const appVersion = 0x0
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(config, pubKey, appVersion)
+	stateStore := sm.NewStore(stateDB)
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	state.LastValidators = state.Validators.Copy()
+	blocks := makeBlocks(3, &state, privVal)
+	store.chain = blocks
+
+	testCases := []struct {
+		name      string
+		hash      []byte
+		expectErr bool
+	}{
+		{"matching checkpoint", blocks[1].Hash(), false},
+		{"contradicting checkpoint", tmrand.Bytes(32), true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// the app is at height 0 and returns the hashes recorded in the blocks
+			app := &badApp{numBlocks: 3, onlyLastHashIsWrong: true}
+			proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+			require.NoError(t, proxyApp.Start())
+			t.Cleanup(func() {
+				if err := proxyApp.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			h := NewHandshaker(stateStore, state, store, genDoc)
+			h.SetCheckpoints(types.Checkpoints{2: tc.hash})
+			if tc.expectErr {
+				err := h.Handshake(proxyApp)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "contradicts the checkpoint")
+				assert.Zero(t, h.NBlocks())
+				return
+			}
+			// the last app hash is wrong, so the blocks were replayed
+			assert.Panics(t, func() {
+				_ = h.Handshake(proxyApp)
+			})
+			assert.EqualValues(t, 3, h.NBlocks())
+		})
+	}
+}
+
 func makeBlocks(n int, state *sm.State, privVal types.PrivValidator) []*types.Block {
 	blocks := make([]*types.Block, 0)
 
@@ -1168,18 +1356,26 @@ type mockBlockStore struct {
 	chain   []*types.Block
 	commits []*types.Commit
 	base    int64
+	// the blocks below it have no parts, see store.BlockStore.PartsBase
+	partsBase int64
 }
 
 // TODO: NewBlockStore(db.NewMemDB) ...
 func newMockBlockStore(config *cfg.Config, params tmproto.ConsensusParams) *mockBlockStore {
-	return &mockBlockStore{config, params, nil, nil, 0}
+	return &mockBlockStore{config, params, nil, nil, 0, 0}
 }
 
-func (bs *mockBlockStore) Height() int64                       { return int64(len(bs.chain)) }
-func (bs *mockBlockStore) Base() int64                         { return bs.base }
-func (bs *mockBlockStore) Size() int64                         { return bs.Height() - bs.Base() + 1 }
-func (bs *mockBlockStore) LoadBaseMeta() *types.BlockMeta      { return bs.LoadBlockMeta(bs.base) }
-func (bs *mockBlockStore) LoadBlock(height int64) *types.Block { return bs.chain[height-1] }
+func (bs *mockBlockStore) Height() int64                  { return int64(len(bs.chain)) }
+func (bs *mockBlockStore) Base() int64                    { return bs.base }
+func (bs *mockBlockStore) Size() int64                    { return bs.Height() - bs.Base() + 1 }
+func (bs *mockBlockStore) LoadBaseMeta() *types.BlockMeta { return bs.LoadBlockMeta(bs.base) }
+func (bs *mockBlockStore) PartsBase() int64               { return bs.partsBase }
+func (bs *mockBlockStore) LoadBlock(height int64) *types.Block {
+	if height < bs.partsBase {
+		return nil
+	}
+	return bs.chain[height-1]
+}
 func (bs *mockBlockStore) LoadBlockByHash(hash []byte) *types.Block {
 	return bs.chain[int64(len(bs.chain))-1]
 }
@@ -8,6 +8,8 @@ import (
 	"os"
 	"reflect"
 	"runtime/debug"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -15,6 +17,8 @@ import (
 	cfg "github.com/tendermint/tendermint/config"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/alert"
+	"github.com/tendermint/tendermint/libs/clock"
 	tmevents "github.com/tendermint/tendermint/libs/events"
 	"github.com/tendermint/tendermint/libs/fail"
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -106,6 +110,12 @@ type State struct {
 	mtx tmsync.RWMutex
 	cstypes.RoundState
 	state sm.State // State until height-1.
+	// blocks assembled and validated at the current height
+	proposalCache *proposalCache
+	// votes received at the current and last heights, to detect equivocations
+	voteCache *voteCache
+	// messages received for the next height or a future round, see deferMsg
+	futureMsgs *futureMsgBuffer
 	// privValidator pubkey, memoized for the duration of one block
 	// to avoid extra requests to HSM
 	privValidatorPubKey crypto.PubKey
@@ -147,6 +157,24 @@ type State struct {
 
 	// for reporting metrics
 	metrics *Metrics
+	// time spent on the current height by stage, see updateRoundStep
+	blockTimings  types.BlockTimings
+	stepStartTime time.Time
+
+	// for notifying operators of critical events
+	alerter *alert.Alerter
+
+	// for investigating app hash mismatches, see StateForensics
+	forensicsDir string
+	inspect      func(bundle string)
+
+	// prevote signed along with the proposal of this node, see signProposal
+	presignedPrevote *types.Vote
+
+	// number of latest blocks whose parts are kept, see pruneBlockParts
+	partsRetainHeights int64
+	partsPruning       int32 // atomic, whether the block parts are being pruned
+	partsPruneWg       sync.WaitGroup
 }
 
 // StateOption sets an optional parameter on the State.
@@ -176,6 +204,9 @@ func NewState(
 		wal:              nilWAL{},
 		evpool:           evpool,
 		evsw:             tmevents.NewEventSwitch(),
+		proposalCache:    newProposalCache(),
+		voteCache:        newVoteCache(),
+		futureMsgs:       newFutureMsgBuffer(config.FutureMsgBufferSize, config.FutureMsgPeerQuota),
 		metrics:          NopMetrics(),
 	}
 	// set function defaults (may be overwritten before calling Start)
@@ -220,6 +251,26 @@ func StateMetrics(metrics *Metrics) StateOption {
 	return func(cs *State) { cs.metrics = metrics }
 }
 
+// StateClock sets the clock the timeouts are scheduled on, e.g. a clock.Mock
+// in tests.
+func StateClock(c clock.Clock) StateOption {
+	return func(cs *State) { cs.timeoutTicker = NewTimeoutTickerWithClock(c) }
+}
+
+// StateAlerter sets the alerter notified of consensus failures, app hash
+// mismatches and prevented double signs.
+func StateAlerter(alerter *alert.Alerter) StateOption {
+	return func(cs *State) { cs.alerter = alerter }
+}
+
+// StateBlockPartsRetainHeights makes the State prune the block parts of the
+// blocks older than the last n ones once a block is committed, keeping their
+// metas and commits (0 - the parts are kept). The block store must implement
+// PartsBase and PruneBlockParts, see store.BlockStore.
+func StateBlockPartsRetainHeights(n int64) StateOption {
+	return func(cs *State) { cs.partsRetainHeights = n }
+}
+
 // String returns a string.
 func (cs *State) String() string {
 	// better not to access shared variables
@@ -372,6 +423,11 @@ func (cs *State) OnStart() error {
 
 	// Double Signing Risk Reduction
 	if err := cs.checkDoubleSigningRisk(cs.Height); err != nil {
+		if err == ErrSignatureFoundInPastBlocks {
+			cs.alerter.Alert(alert.DoubleSignPrevented,
+				"signature of the validator found in the last %d blocks, refusing to start",
+				cs.config.DoubleSignCheckHeight)
+		}
 		return err
 	}
 
@@ -514,10 +570,30 @@ func (cs *State) updateHeight(height int64) {
 }
 
 func (cs *State) updateRoundStep(round int32, step cstypes.RoundStepType) {
+	cs.recordStepTime(time.Now())
 	cs.Round = round
 	cs.Step = step
 }
 
+// recordStepTime adds the time spent in the current step, since it was
+// entered, to the timings of the current height.
+func (cs *State) recordStepTime(now time.Time) {
+	if !cs.stepStartTime.IsZero() {
+		elapsed := now.Sub(cs.stepStartTime)
+		switch cs.Step {
+		case cstypes.RoundStepPropose:
+			cs.blockTimings.ProposalWait += elapsed
+		case cstypes.RoundStepPrevote, cstypes.RoundStepPrevoteWait:
+			cs.blockTimings.PrevoteWait += elapsed
+		case cstypes.RoundStepPrecommit, cstypes.RoundStepPrecommitWait:
+			cs.blockTimings.PrecommitWait += elapsed
+		case cstypes.RoundStepCommit:
+			cs.blockTimings.Save += elapsed
+		}
+	}
+	cs.stepStartTime = now
+}
+
 // enterNewRound(height, 0) at cs.StartTime.
 func (cs *State) scheduleRound0(rs *cstypes.RoundState) {
 	// cs.Logger.Info("scheduleRound0", "now", tmtime.Now(), "startTime", cs.StartTime)
@@ -628,6 +704,7 @@ func (cs *State) updateToState(state sm.State) {
 	// RoundState fields
 	cs.updateHeight(height)
 	cs.updateRoundStep(0, cstypes.RoundStepNewHeight)
+	cs.blockTimings = types.BlockTimings{}
 	if cs.CommitTime.IsZero() {
 		// "Now" makes it easier to sync up dev nodes.
 		// We add timeoutCommit to allow transactions
@@ -655,6 +732,7 @@ func (cs *State) updateToState(state sm.State) {
 	cs.TriggeredTimeoutPrecommit = false
 
 	cs.state = state
+	cs.proposalCache.reset()
 
 	// Finally, broadcast RoundState
 	cs.newStep()
@@ -695,12 +773,16 @@ func (cs *State) receiveRoutine(maxSteps int) {
 		}
 		cs.wal.Wait()
 
+		// the block parts are pruned from finalizeCommit, i.e. this routine
+		cs.partsPruneWg.Wait()
+
 		close(cs.done)
 	}
 
 	defer func() {
 		if r := recover(); r != nil {
 			cs.Logger.Error("CONSENSUS FAILURE!!!", "err", r, "stack", string(debug.Stack()))
+			cs.alerter.Alert(alert.ConsensusFailure, "consensus halted at height %d: %v", cs.Height, r)
 			// stop gracefully
 			//
 			// NOTE: We most probably shouldn't be running any further when there is
@@ -769,6 +851,17 @@ func (cs *State) handleMsg(mi msgInfo) {
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
 
+	cs.processMsg(mi)
+	cs.replayFutureMsgs()
+}
+
+// processMsg handles the message, or buffers it if it's for a future height
+// or round. cs.mtx must be held.
+func (cs *State) processMsg(mi msgInfo) {
+	if cs.deferMsg(mi) {
+		return
+	}
+
 	var (
 		added bool
 		err   error
@@ -786,6 +879,11 @@ func (cs *State) handleMsg(mi msgInfo) {
 			cs.statsMsgQueue <- mi
 		}
 
+		if !added && msg.Round > cs.Round && cs.bufferFutureMsg(mi) {
+			// not a new part of the proposal block of this round, but may be
+			// one of the proposal block of the future round
+			err = nil
+		}
 		if err != nil && msg.Round != cs.Round {
 			cs.Logger.Debug(
 				"Received block part from wrong round",
@@ -842,6 +940,7 @@ func (cs *State) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 	// the timeout will now cause a state transition
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
+	defer cs.replayFutureMsgs()
 
 	switch ti.Step {
 	case cstypes.RoundStepNewHeight:
@@ -931,6 +1030,7 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	if cs.Round < round {
 		validators = validators.Copy()
 		validators.IncrementProposerPriority(tmmath.SafeSubInt32(round, cs.Round))
+		cs.metrics.MissedRounds.Add(float64(round - cs.Round))
 	}
 
 	// Setup new round
@@ -960,13 +1060,21 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	// before we enterPropose in round 0. If the last block changed the app hash,
 	// we may need an empty "proof" block, and enterPropose immediately.
 	waitForTxs := cs.config.WaitForTxs() && round == 0 && !cs.needProofBlock(height)
-	if waitForTxs {
-		if cs.config.CreateEmptyBlocksInterval > 0 {
-			cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
-				cstypes.RoundStepNewRound)
-		}
-	} else {
+	if !waitForTxs {
+		cs.enterPropose(height, round)
+		return
+	}
+
+	// If no txs become available in the meantime, propose an empty block after
+	// the configured delay (if any).
+	delay, ok := cs.config.EmptyBlockDelay(cs.CommitTime, tmtime.Now())
+	switch {
+	case !ok:
+		// wait for txs indefinitely
+	case delay <= 0:
 		cs.enterPropose(height, round)
+	default:
+		cs.scheduleTimeout(delay, height, round, cstypes.RoundStepNewRound)
 	}
 }
 
@@ -987,6 +1095,8 @@ func (cs *State) needProofBlock(height int64) bool {
 // Enter (CreateEmptyBlocks): from enterNewRound(height,round)
 // Enter (CreateEmptyBlocks, CreateEmptyBlocksInterval > 0 ):
 // 		after enterNewRound(height,round), after timeout of CreateEmptyBlocksInterval
+// Enter (CreateEmptyBlocks, TargetBlockTime > 0 ):
+// 		after enterNewRound(height,round), once TargetBlockTime has passed since CommitTime
 // Enter (!CreateEmptyBlocks) : after enterNewRound(height,round), once txs are in the mempool
 func (cs *State) enterPropose(height int64, round int32) {
 	logger := cs.Logger.With("height", height, "round", round)
@@ -1065,17 +1175,28 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 	var blockParts *types.PartSet
 
 	// Decide on block
-	if cs.ValidBlock != nil {
+	switch {
+	case cs.ValidBlock != nil:
 		// If there is valid block, choose that.
 		block, blockParts = cs.ValidBlock, cs.ValidBlockParts
-	} else {
+	case cs.blockExec.HasBlockBuilder():
+		// The block builder is requested without holding the mutex, the
+		// block is proposed once it's built.
+		cs.createProposalBlockAsync(height, round)
+		return
+	default:
 		// Create a new proposal block from state/txs from the mempool.
 		block, blockParts = cs.createProposalBlock()
 		if block == nil {
 			return
 		}
 	}
+	cs.proposeBlock(height, round, block, blockParts)
+}
 
+// proposeBlock signs the proposal of the block and sends it, along with the
+// block parts, on the internal msg queue.
+func (cs *State) proposeBlock(height int64, round int32, block *types.Block, blockParts *types.PartSet) {
 	// Flush the WAL. Otherwise, we may not recompute the same proposal to sign,
 	// and the privValidator will refuse to sign anything.
 	if err := cs.wal.FlushAndSync(); err != nil {
@@ -1086,7 +1207,7 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 	propBlockID := types.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()}
 	proposal := types.NewProposal(height, round, cs.ValidRound, propBlockID)
 	p := proposal.ToProto()
-	if err := cs.privValidator.SignProposal(cs.state.ChainID, p); err == nil {
+	if err := cs.signProposal(p, propBlockID, block); err == nil {
 		proposal.Signature = p.Signature
 
 		// send proposal and block parts on internal msg queue
@@ -1102,6 +1223,47 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 	}
 }
 
+// signProposal signs the proposal of the block. If the privValidator is a
+// types.BatchSigner, and the node isn't locked on another block, the prevote
+// for the block is signed along with it (e.g. with a single round-trip to a
+// remote signer), so that signing it again in enterPrevote only checks it
+// against the last sign state of the signer.
+func (cs *State) signProposal(p *tmproto.Proposal, blockID types.BlockID, block *types.Block) error {
+	batchSigner, ok := cs.privValidator.(types.BatchSigner)
+	if !ok || cs.privValidatorPubKey == nil || (cs.LockedBlock != nil && !cs.LockedBlock.HashesTo(block.Hash())) {
+		return cs.privValidator.SignProposal(cs.state.ChainID, p)
+	}
+
+	addr := cs.privValidatorPubKey.Address()
+	valIdx, _ := cs.Validators.GetByAddress(addr)
+	// like voteTime once the proposal block is set
+	timestamp := tmtime.Now()
+	timeIota := time.Duration(cs.state.ConsensusParams.Block.TimeIotaMs) * time.Millisecond
+	if minVoteTime := block.Time.Add(timeIota); timestamp.Before(minVoteTime) {
+		timestamp = minVoteTime
+	}
+	prevote := &types.Vote{
+		ValidatorAddress: addr,
+		ValidatorIndex:   valIdx,
+		Height:           p.Height,
+		Round:            p.Round,
+		Timestamp:        timestamp,
+		Type:             tmproto.PrevoteType,
+		BlockID:          blockID,
+	}
+	v := prevote.ToProto()
+	err := batchSigner.SignBatch(cs.state.ChainID, p, []*tmproto.Vote{v})
+	if len(v.Signature) > 0 {
+		prevote.Timestamp = v.Timestamp
+		cs.presignedPrevote = prevote
+	}
+	if err != nil && len(p.Signature) > 0 {
+		cs.Logger.Error("Error signing the prevote along with the proposal", "err", err)
+		return nil
+	}
+	return err
+}
+
 // Returns true if the proposal block is complete &&
 // (if POLRound was proposed, we have +2/3 prevotes from there).
 func (cs *State) isProposalComplete() bool {
@@ -1126,6 +1288,43 @@ func (cs *State) isProposalComplete() bool {
 // NOTE: keep it side-effect free for clarity.
 // CONTRACT: cs.privValidator is not nil.
 func (cs *State) createProposalBlock() (block *types.Block, blockParts *types.PartSet) {
+	commit, proposerAddr := cs.proposalCommit()
+	if commit == nil {
+		return
+	}
+	return cs.blockExec.CreateProposalBlock(cs.Height, cs.state, commit, proposerAddr)
+}
+
+// createProposalBlockAsync creates the proposal block in a goroutine, so that
+// the mutex isn't held while the block builder is requested, and proposes it
+// if the state machine is still in the propose step of the round.
+func (cs *State) createProposalBlockAsync(height int64, round int32) {
+	commit, proposerAddr := cs.proposalCommit()
+	if commit == nil {
+		return
+	}
+	state := cs.state
+	go func() {
+		block, blockParts := cs.blockExec.CreateProposalBlock(height, state, commit, proposerAddr)
+		select {
+		case <-cs.Quit():
+			return
+		default:
+		}
+
+		cs.mtx.Lock()
+		defer cs.mtx.Unlock()
+		if cs.Height != height || cs.Round != round || cs.Step != cstypes.RoundStepPropose {
+			cs.Logger.Info("Not proposing the built block, the round moved on", "height", height, "round", round)
+			return
+		}
+		cs.proposeBlock(height, round, block, blockParts)
+	}()
+}
+
+// proposalCommit returns the commit of the last block and the proposer address
+// to create the proposal block with, or a nil commit if it can't be created.
+func (cs *State) proposalCommit() (*types.Commit, []byte) {
 	if cs.privValidator == nil {
 		panic("entered createProposalBlock with privValidator being nil")
 	}
@@ -1141,18 +1340,16 @@ func (cs *State) createProposalBlock() (block *types.Block, blockParts *types.Pa
 		commit = cs.LastCommit.MakeCommit()
 	default: // This shouldn't happen.
 		cs.Logger.Error("enterPropose: Cannot propose anything: No commit for the previous block")
-		return
+		return nil, nil
 	}
 
 	if cs.privValidatorPubKey == nil {
 		// If this node is a validator & proposer in the current round, it will
 		// miss the opportunity to create a block.
 		cs.Logger.Error(fmt.Sprintf("enterPropose: %v", errPubKeyIsNotSet))
-		return
+		return nil, nil
 	}
-	proposerAddr := cs.privValidatorPubKey.Address()
-
-	return cs.blockExec.CreateProposalBlock(cs.Height, cs.state, commit, proposerAddr)
+	return commit, cs.privValidatorPubKey.Address()
 }
 
 // Enter: `timeoutPropose` after entering Propose.
@@ -1204,7 +1401,7 @@ func (cs *State) defaultDoPrevote(height int64, round int32) {
 	}
 
 	// Validate proposal block
-	err := cs.blockExec.ValidateBlock(cs.state, cs.ProposalBlock)
+	err := cs.validateBlock(cs.ProposalBlock)
 	if err != nil {
 		// ProposalBlock is invalid, prevote nil.
 		logger.Error("enterPrevote: ProposalBlock is invalid", "err", err)
@@ -1335,7 +1532,7 @@ func (cs *State) enterPrecommit(height int64, round int32) {
 	if cs.ProposalBlock.HashesTo(blockID.Hash) {
 		logger.Info("enterPrecommit: +2/3 prevoted proposal block. Locking", "hash", blockID.Hash)
 		// Validate the block.
-		if err := cs.blockExec.ValidateBlock(cs.state, cs.ProposalBlock); err != nil {
+		if err := cs.validateBlock(cs.ProposalBlock); err != nil {
 			panic(fmt.Sprintf("enterPrecommit: +2/3 prevoted for an invalid block: %v", err))
 		}
 		cs.LockedRound = round
@@ -1511,7 +1708,13 @@ func (cs *State) finalizeCommit(height int64) {
 	if !block.HashesTo(blockID.Hash) {
 		panic("Cannot finalizeCommit, ProposalBlock does not hash to commit hash")
 	}
-	if err := cs.blockExec.ValidateBlock(cs.state, block); err != nil {
+	if err := cs.validateBlock(block); err != nil {
+		var mismatch sm.ErrAppHashMismatch
+		if errors.As(err, &mismatch) {
+			cs.alerter.Alert(alert.AppHashMismatch, "+2/3 committed block %d with app hash %X, expected %X",
+				height, mismatch.Got, mismatch.Expected)
+			cs.investigateAppHashMismatch(block, mismatch)
+		}
 		panic(fmt.Errorf("+2/3 committed an invalid block: %w", err))
 	}
 
@@ -1564,13 +1767,23 @@ func (cs *State) finalizeCommit(height int64) {
 
 	// Execute and commit the block, update and save the state, and update the mempool.
 	// NOTE The block.AppHash wont reflect these txs until the next block.
+	cs.recordStepTime(time.Now())
+	timings := cs.blockTimings
 	var err error
 	var retainHeight int64
-	stateCopy, retainHeight, err = cs.blockExec.ApplyBlock(
+	stateCopy, retainHeight, err = cs.blockExec.ApplyBlockWithTimings(
 		stateCopy,
 		types.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()},
-		block)
+		block,
+		&timings)
 	if err != nil {
+		var upgrade sm.ErrUpgradeRequired
+		if errors.As(err, &upgrade) {
+			// the node can't go on until the app binary is upgraded
+			cs.Logger.Error("Halting for the app version upgrade", "err", err)
+			cs.alerter.Alert(alert.ConsensusFailure, "consensus halted at height %d: %v", height, err)
+			return
+		}
 		cs.Logger.Error("Error on ApplyBlock", "err", err)
 		return
 	}
@@ -1586,9 +1799,11 @@ func (cs *State) finalizeCommit(height int64) {
 			cs.Logger.Info("Pruned blocks", "pruned", pruned, "retainHeight", retainHeight)
 		}
 	}
+	cs.pruneBlockParts(height)
 
 	// must be called before we update state
 	cs.recordMetrics(height, block)
+	cs.recordBlockTimings(timings)
 
 	// NewHeightStep!
 	cs.updateToState(stateCopy)
@@ -1626,6 +1841,54 @@ func (cs *State) pruneBlocks(retainHeight int64) (uint64, error) {
 	return pruned, nil
 }
 
+// pruneBlockParts prunes the parts of the blocks older than the last
+// partsRetainHeights ones, now that the block at the given height has been
+// committed by the app. As the first pruning may remove the parts of the whole
+// history, it runs in the background, and is skipped while the previous one is
+// still running.
+func (cs *State) pruneBlockParts(height int64) {
+	type partsPruner interface {
+		PartsBase() int64
+		PruneBlockParts(height int64) (uint64, error)
+	}
+
+	pruner, ok := cs.blockStore.(partsPruner)
+	if !ok || cs.partsRetainHeights <= 0 {
+		return
+	}
+	partsRetainHeight := height - cs.partsRetainHeights + 1
+	if partsRetainHeight <= pruner.PartsBase() || !atomic.CompareAndSwapInt32(&cs.partsPruning, 0, 1) {
+		return
+	}
+	cs.partsPruneWg.Add(1)
+	go func() {
+		defer cs.partsPruneWg.Done()
+		defer atomic.StoreInt32(&cs.partsPruning, 0)
+		pruned, err := pruner.PruneBlockParts(partsRetainHeight)
+		if err != nil {
+			cs.Logger.Error("Failed to prune block parts", "retainHeight", partsRetainHeight, "err", err)
+			return
+		}
+		cs.Logger.Info("Pruned block parts", "pruned", pruned, "retainHeight", partsRetainHeight)
+	}()
+}
+
+func (cs *State) recordBlockTimings(timings types.BlockTimings) {
+	for _, stage := range []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"proposal_wait", timings.ProposalWait},
+		{"prevote_wait", timings.PrevoteWait},
+		{"precommit_wait", timings.PrecommitWait},
+		{"save", timings.Save},
+		{"execution", timings.Execution},
+		{"commit", timings.Commit},
+	} {
+		cs.metrics.BlockStageSeconds.With("stage", stage.name).Observe(stage.duration.Seconds())
+	}
+}
+
 func (cs *State) recordMetrics(height int64, block *types.Block) {
 	cs.metrics.Validators.Set(float64(cs.Validators.Size()))
 	cs.metrics.ValidatorsPower.Set(float64(cs.Validators.TotalVotingPower()))
@@ -1785,18 +2048,7 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 		)
 	}
 	if added && cs.ProposalBlockParts.IsComplete() {
-		bz, err := ioutil.ReadAll(cs.ProposalBlockParts.GetReader())
-		if err != nil {
-			return added, err
-		}
-
-		var pbb = new(tmproto.Block)
-		err = proto.Unmarshal(bz, pbb)
-		if err != nil {
-			return added, err
-		}
-
-		block, err := types.BlockFromProto(pbb)
+		block, err := cs.assembleProposalBlock(cs.ProposalBlockParts)
 		if err != nil {
 			return added, err
 		}
@@ -1841,6 +2093,52 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 	return added, nil
 }
 
+// assembleProposalBlock decodes the block from the given complete part set.
+// Blocks are cached by part set hash, so a block proposed again in a later
+// round of the same height is only decoded once.
+func (cs *State) assembleProposalBlock(parts *types.PartSet) (*types.Block, error) {
+	header := parts.Header()
+	if block, ok := cs.proposalCache.block(header); ok {
+		return block, nil
+	}
+
+	bz, err := ioutil.ReadAll(parts.GetReader())
+	if err != nil {
+		return nil, err
+	}
+
+	var pbb = new(tmproto.Block)
+	err = proto.Unmarshal(bz, pbb)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := types.BlockFromProto(pbb)
+	if err != nil {
+		return nil, err
+	}
+
+	cs.proposalCache.addBlock(header, block)
+	return block, nil
+}
+
+// validateBlock validates the block against the current state. Results are
+// cached by block hash, so a block is only validated once per height, no
+// matter how many rounds or steps it is considered in.
+func (cs *State) validateBlock(block *types.Block) error {
+	hash := block.Hash()
+	if hash == nil {
+		return cs.blockExec.ValidateBlock(cs.state, block)
+	}
+	if ok, err := cs.proposalCache.validation(hash); ok {
+		return err
+	}
+
+	err := cs.blockExec.ValidateBlock(cs.state, block)
+	cs.proposalCache.addValidation(hash, err)
+	return err
+}
+
 // Attempt to add the vote. if its a duplicate signature, dupeout the validator
 func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
 	added, err := cs.addVote(vote, peerID)
@@ -1854,32 +2152,7 @@ func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
 				return false, errPubKeyIsNotSet
 			}
 
-			if bytes.Equal(vote.ValidatorAddress, cs.privValidatorPubKey.Address()) {
-				cs.Logger.Error(
-					"Found conflicting vote from ourselves. Did you unsafe_reset a validator?",
-					"height",
-					vote.Height,
-					"round",
-					vote.Round,
-					"type",
-					vote.Type)
-				return added, err
-			}
-			var timestamp time.Time
-			if voteErr.VoteA.Height == cs.state.InitialHeight {
-				timestamp = cs.state.LastBlockTime // genesis time
-			} else {
-				timestamp = sm.MedianTime(cs.LastCommit.MakeCommit(), cs.LastValidators)
-			}
-			// form duplicate vote evidence from the conflicting votes and send it across to the
-			// evidence pool
-			ev := types.NewDuplicateVoteEvidence(voteErr.VoteA, voteErr.VoteB, timestamp, cs.Validators)
-			evidenceErr := cs.evpool.AddEvidenceFromConsensus(ev)
-			if evidenceErr != nil {
-				cs.Logger.Error("Failed to add evidence to the evidence pool", "err", evidenceErr)
-			} else {
-				cs.Logger.Debug("Added evidence to the evidence pool", "ev", ev)
-			}
+			cs.reportConflictingVotes(voteErr.VoteA, voteErr.VoteB, cs.Validators)
 			return added, err
 		} else if err == types.ErrVoteNonDeterministicSignature {
 			cs.Logger.Debug("Vote has non-deterministic signature", "err", err)
@@ -1896,6 +2169,88 @@ func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
 	return added, nil
 }
 
+// reportConflictingVotes forms duplicate vote evidence from the conflicting
+// votes, signed by a validator of the valSet, and sends it across to the
+// evidence pool, unless it was already sent. It must be called with the mutex
+// held (at least for reading).
+func (cs *State) reportConflictingVotes(voteA, voteB *types.Vote, valSet *types.ValidatorSet) {
+	if cs.privValidatorPubKey != nil && bytes.Equal(voteA.ValidatorAddress, cs.privValidatorPubKey.Address()) {
+		cs.Logger.Error(
+			"Found conflicting vote from ourselves. Did you unsafe_reset a validator?",
+			"height",
+			voteA.Height,
+			"round",
+			voteA.Round,
+			"type",
+			voteA.Type)
+		return
+	}
+	if !cs.voteCache.markReported(voteA) {
+		return
+	}
+	var timestamp time.Time
+	if voteA.Height == cs.state.InitialHeight {
+		timestamp = cs.state.LastBlockTime // genesis time
+	} else {
+		timestamp = sm.MedianTime(cs.LastCommit.MakeCommit(), cs.LastValidators)
+	}
+	ev := types.NewDuplicateVoteEvidence(voteA, voteB, timestamp, valSet)
+	evidenceErr := cs.evpool.AddEvidenceFromConsensus(ev)
+	if evidenceErr != nil {
+		cs.Logger.Error("Failed to add evidence to the evidence pool", "err", evidenceErr)
+	} else {
+		cs.Logger.Debug("Added evidence to the evidence pool", "ev", ev)
+	}
+}
+
+// checkConflictingVote checks the vote received from the peer against the
+// votes cached for the current and last heights, as soon as it's received, so
+// that an equivocation is reported without waiting for the vote to be
+// processed by the state machine. If one of the conflicting votes has an
+// invalid signature, the error and the peer which sent it are returned.
+func (cs *State) checkConflictingVote(vote *types.Vote, peerID p2p.ID) (p2p.ID, error) {
+	// only cache the votes the state machine would accept, to bound the cache
+	var valSet *types.ValidatorSet
+	cs.mtx.RLock()
+	switch {
+	case vote.Height == cs.Height && vote.Round <= cs.Round+1:
+		valSet = cs.Validators
+	case vote.Height == cs.Height-1 && vote.Type == tmproto.PrecommitType &&
+		cs.LastCommit != nil && vote.Round == cs.LastCommit.GetRound():
+		valSet = cs.LastValidators
+	}
+	chainID, height := cs.state.ChainID, cs.Height
+	cs.mtx.RUnlock()
+	if valSet == nil {
+		return "", nil
+	}
+	addr, val := valSet.GetByIndex(vote.ValidatorIndex)
+	if val == nil || !bytes.Equal(addr, vote.ValidatorAddress) {
+		return "", nil // rejected by the state machine
+	}
+	cs.voteCache.prune(height - 1)
+
+	cached, cachedPeerID := cs.voteCache.add(vote, peerID)
+	if cached == nil {
+		return "", nil
+	}
+	// the signatures are verified without holding the mutex, so that the state
+	// machine isn't blocked
+	if err := cached.Verify(chainID, val.PubKey); err != nil {
+		cs.voteCache.replace(vote, peerID)
+		return cachedPeerID, err
+	}
+	if err := vote.Verify(chainID, val.PubKey); err != nil {
+		return peerID, err
+	}
+	cs.Logger.Info("Detected conflicting votes on receipt", "validator", addr, "height", vote.Height,
+		"round", vote.Round, "type", vote.Type, "peer", peerID)
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	cs.reportConflictingVotes(cached, vote, valSet)
+	return "", nil
+}
+
 //-----------------------------------------------------------------------------
 
 func (cs *State) addVote(
@@ -2091,6 +2446,14 @@ func (cs *State) signVote(
 		Type:             msgType,
 		BlockID:          types.BlockID{Hash: hash, PartSetHeader: header},
 	}
+	if pv := cs.presignedPrevote; pv != nil && pv.Height == vote.Height && pv.Round == vote.Round &&
+		pv.Type == vote.Type && pv.BlockID.Equals(vote.BlockID) {
+		// the presigned prevote still goes through the last sign state check
+		// of the signer, which returns its signature if it's the last one
+		// signed, without signing again
+		vote.Timestamp = pv.Timestamp
+	}
+	cs.presignedPrevote = nil
 	v := vote.ToProto()
 	err := cs.privValidator.SignVote(cs.state.ChainID, v)
 	vote.Signature = v.Signature
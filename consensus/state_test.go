@@ -10,14 +10,20 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/example/counter"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	mmock "github.com/tendermint/tendermint/mempool/mock"
 	p2pmock "github.com/tendermint/tendermint/p2p/mock"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -181,6 +187,47 @@ func TestStateEnterProposeYesPrivValidator(t *testing.T) {
 	ensureNoNewTimeout(timeoutCh, cs.config.TimeoutPropose.Nanoseconds())
 }
 
+type blockBuilderFunc func(ctx context.Context, req sm.BuildBlockRequest) (types.Txs, error)
+
+func (f blockBuilderFunc) BuildBlockTxs(ctx context.Context, req sm.BuildBlockRequest) (types.Txs, error) {
+	return f(ctx, req)
+}
+
+func TestStateEnterProposeBlockBuilder(t *testing.T) {
+	cs, _ := randState(1)
+	height, round := cs.Height, cs.Round
+
+	building, built := make(chan struct{}), make(chan struct{})
+	builder := blockBuilderFunc(func(ctx context.Context, req sm.BuildBlockRequest) (types.Txs, error) {
+		close(building)
+		<-built
+		return types.Txs{types.Tx("built")}, nil
+	})
+	proxyApp := proxy.NewAppConnConsensus(abcicli.NewLocalClient(new(tmsync.Mutex), counter.NewApplication(true)))
+	cs.blockExec = sm.NewBlockExecutor(cs.blockExec.Store(), log.TestingLogger(), proxyApp, mmock.Mempool{},
+		sm.EmptyEvidencePool{}, sm.BlockExecutorWithBlockBuilder(builder, time.Minute))
+
+	proposalCh := subscribe(cs.eventBus, types.EventQueryCompleteProposal)
+
+	cs.enterNewRound(height, round)
+	cs.startRoutines(3)
+
+	select {
+	case <-building:
+	case <-time.After(ensureTimeout):
+		t.Fatal("the block builder wasn't requested")
+	}
+	// the mutex isn't held while the block is built
+	rs := cs.GetRoundState()
+	assert.Nil(t, rs.Proposal)
+	close(built)
+
+	ensureNewProposal(proposalCh, height, round)
+	rs = cs.GetRoundState()
+	require.NotNil(t, rs.ProposalBlock)
+	assert.Equal(t, types.Txs{types.Tx("built")}, rs.ProposalBlock.Txs)
+}
+
 func TestStateBadProposal(t *testing.T) {
 	cs1, vss := randState(2)
 	height, round := cs1.Height, cs1.Round
@@ -340,6 +387,70 @@ func TestStateFullRound1(t *testing.T) {
 	validateLastPrecommit(t, cs, vss[0], propBlockHash)
 }
 
+// batchSignerPV is a PrivValidator implementing types.BatchSigner, which
+// records the votes signed alone, and in batches.
+type batchSignerPV struct {
+	types.PrivValidator
+
+	mtx          tmsync.Mutex
+	batches      int
+	votes        []tmproto.Vote
+	batchedVotes []tmproto.Vote
+}
+
+func (pv *batchSignerPV) SignVote(chainID string, vote *tmproto.Vote) error {
+	err := pv.PrivValidator.SignVote(chainID, vote)
+	pv.mtx.Lock()
+	pv.votes = append(pv.votes, *vote)
+	pv.mtx.Unlock()
+	return err
+}
+
+func (pv *batchSignerPV) SignBatch(chainID string, proposal *tmproto.Proposal, votes []*tmproto.Vote) error {
+	pv.mtx.Lock()
+	pv.batches++
+	pv.mtx.Unlock()
+	if err := pv.PrivValidator.SignProposal(chainID, proposal); err != nil {
+		return err
+	}
+	for _, vote := range votes {
+		if err := pv.PrivValidator.SignVote(chainID, vote); err != nil {
+			return err
+		}
+		pv.mtx.Lock()
+		pv.batchedVotes = append(pv.batchedVotes, *vote)
+		pv.mtx.Unlock()
+	}
+	return nil
+}
+
+// the prevote of the proposer is signed along with its proposal, and signed
+// again as is when cast, going through the last sign state check of the
+// signer
+func TestStateFullRoundBatchSigner(t *testing.T) {
+	cs, _ := randState(1)
+	pv := &batchSignerPV{PrivValidator: cs.privValidator}
+	cs.SetPrivValidator(pv)
+
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestState(t, cs)
+	ensureNewBlock(newBlockCh, 1)
+
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	assert.GreaterOrEqual(t, pv.batches, 1)
+	require.NotEmpty(t, pv.batchedVotes)
+	presigned := pv.batchedVotes[0]
+	var cast *tmproto.Vote
+	for i, vote := range pv.votes {
+		if vote.Height == 1 && vote.Round == presigned.Round && vote.Type == tmproto.PrevoteType {
+			cast = &pv.votes[i]
+		}
+	}
+	require.NotNil(t, cast)
+	assert.Equal(t, presigned, *cast)
+}
+
 // nil is proposed, so prevote and precommit nil
 func TestStateFullRoundNil(t *testing.T) {
 	cs, vss := randState(1)
@@ -1801,6 +1912,74 @@ func TestStateHalt1(t *testing.T) {
 	ensureNewRound(newRoundCh, height+1, 0)
 }
 
+func TestStateBlockTimings(t *testing.T) {
+	cs, _ := randState(1)
+	height, round := cs.Height, cs.Round
+
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, height, round)
+
+	select {
+	case msg := <-newBlockCh:
+		timings := msg.Data().(types.EventDataNewBlock).Timings
+		require.NotNil(t, timings)
+		assert.True(t, timings.PrevoteWait > 0)
+		assert.True(t, timings.PrecommitWait > 0)
+		assert.True(t, timings.Execution > 0)
+		assert.True(t, timings.Commit > 0)
+	case <-time.After(ensureTimeout):
+		t.Fatal("Timeout expired while waiting for NewBlock event")
+	}
+}
+
+func TestStatePrunesBlockParts(t *testing.T) {
+	cs, _ := randState(1)
+	cs.partsRetainHeights = 2
+	height, round := cs.Height, cs.Round
+	blockStore := cs.blockStore.(*store.BlockStore)
+
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, height, round)
+	for i := 0; i < 4; i++ {
+		ensureNewBlock(newBlockCh, height+int64(i))
+	}
+
+	// the parts are pruned in the background, keeping the last 2 blocks
+	require.Eventually(t, func() bool { return blockStore.PartsBase() >= 2 }, time.Second, 10*time.Millisecond)
+	assert.Nil(t, blockStore.LoadBlock(1))
+	assert.NotNil(t, blockStore.LoadBlockMeta(1))
+	assert.NotNil(t, blockStore.LoadBlock(4))
+}
+
+func TestStateRecordStepTime(t *testing.T) {
+	cs, _ := randState(1)
+	start := time.Now()
+
+	cs.Step = cstypes.RoundStepPropose
+	cs.stepStartTime = start
+	for i, step := range []cstypes.RoundStepType{
+		cstypes.RoundStepPrevote,
+		cstypes.RoundStepPrevoteWait,
+		cstypes.RoundStepPrecommit,
+		cstypes.RoundStepPrecommitWait,
+		cstypes.RoundStepPropose, // next round
+		cstypes.RoundStepPrevote,
+		cstypes.RoundStepPrecommit,
+		cstypes.RoundStepCommit,
+	} {
+		cs.recordStepTime(start.Add(time.Duration(i+1) * time.Second))
+		cs.Step = step
+	}
+	cs.recordStepTime(start.Add(10 * time.Second))
+
+	assert.Equal(t, types.BlockTimings{
+		ProposalWait:  2 * time.Second,
+		PrevoteWait:   3 * time.Second,
+		PrecommitWait: 3 * time.Second,
+		Save:          2 * time.Second,
+	}, cs.blockTimings)
+}
+
 func TestStateOutputsBlockPartsStats(t *testing.T) {
 	// create dummy peer
 	cs, _ := randState(1)
@@ -1894,3 +2073,55 @@ func subscribeUnBuffered(eventBus *types.EventBus, q tmpubsub.Query) <-chan tmpu
 	}
 	return sub.Out()
 }
+
+type recordingEvidencePool struct {
+	evidence []types.Evidence
+}
+
+func (p *recordingEvidencePool) AddEvidenceFromConsensus(ev types.Evidence) error {
+	p.evidence = append(p.evidence, ev)
+	return nil
+}
+
+func TestStateCheckConflictingVote(t *testing.T) {
+	cs1, vss := randState(4)
+	evpool := &recordingEvidencePool{}
+	cs1.evpool = evpool
+	vs2 := vss[1]
+
+	header := types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(tmhash.Size)}
+	voteA := signVote(vs2, tmproto.PrevoteType, tmrand.Bytes(tmhash.Size), header)
+	voteB := signVote(vs2, tmproto.PrevoteType, tmrand.Bytes(tmhash.Size), header)
+	forged := voteB.Copy()
+	forged.Signature = tmrand.Bytes(len(forged.Signature))
+
+	peerID, err := cs1.checkConflictingVote(voteA, "peerA")
+	require.NoError(t, err)
+	assert.Empty(t, peerID)
+
+	// the sender of a conflicting vote with an invalid signature is returned
+	peerID, err = cs1.checkConflictingVote(forged, "forger")
+	assert.Error(t, err)
+	assert.EqualValues(t, "forger", peerID)
+	assert.Empty(t, evpool.evidence)
+
+	// an equivocation is reported on receipt, only once
+	peerID, err = cs1.checkConflictingVote(voteB, "peerB")
+	require.NoError(t, err)
+	assert.Empty(t, peerID)
+	require.Len(t, evpool.evidence, 1)
+	ev := evpool.evidence[0].(*types.DuplicateVoteEvidence)
+	assert.Equal(t, vs2.Height, ev.Height())
+
+	_, err = cs1.checkConflictingVote(voteB, "peerC")
+	require.NoError(t, err)
+	cs1.reportConflictingVotes(voteA, voteB, cs1.Validators)
+	assert.Len(t, evpool.evidence, 1)
+
+	// the votes for the next heights aren't checked
+	vs2.Height++
+	voteC := signVote(vs2, tmproto.PrevoteType, tmrand.Bytes(tmhash.Size), header)
+	_, err = cs1.checkConflictingVote(voteC, "peerA")
+	require.NoError(t, err)
+	assert.Empty(t, cs1.voteCache.entries[voteCacheKeyOf(voteC)])
+}
@@ -3,6 +3,7 @@ package consensus
 import (
 	"time"
 
+	"github.com/tendermint/tendermint/libs/clock"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 )
@@ -23,24 +24,43 @@ type TimeoutTicker interface {
 	SetLogger(log.Logger)
 }
 
-// timeoutTicker wraps time.Timer,
+// timeoutTicker wraps a single clock.Timer,
 // scheduling timeouts only for greater height/round/step
 // than what it's already seen.
 // Timeouts are scheduled along the tickChan,
 // and fired on the tockChan.
+//
+// The deadline of a timeout is set when it's scheduled, from the monotonic
+// clock reading, so that neither the delay of the timeoutRoutine in picking
+// it up nor the wall clock adjustments shift it, which matters with timeouts
+// of a few tens of milliseconds.
 type timeoutTicker struct {
 	service.BaseService
 
-	timer    *time.Timer
-	tickChan chan timeoutInfo // for scheduling timeouts
-	tockChan chan timeoutInfo // for notifying about them
+	clock    clock.Clock
+	timer    clock.Timer
+	tickChan chan scheduledTimeout // for scheduling timeouts
+	tockChan chan timeoutInfo      // for notifying about them
+}
+
+// scheduledTimeout is a timeout along with its deadline.
+type scheduledTimeout struct {
+	timeoutInfo
+	deadline time.Time
 }
 
 // NewTimeoutTicker returns a new TimeoutTicker.
 func NewTimeoutTicker() TimeoutTicker {
+	return NewTimeoutTickerWithClock(clock.New())
+}
+
+// NewTimeoutTickerWithClock returns a new TimeoutTicker, timing out on the
+// given clock.
+func NewTimeoutTickerWithClock(c clock.Clock) TimeoutTicker {
 	tt := &timeoutTicker{
-		timer:    time.NewTimer(0),
-		tickChan: make(chan timeoutInfo, tickTockBufferSize),
+		clock:    c,
+		timer:    c.NewTimer(0),
+		tickChan: make(chan scheduledTimeout, tickTockBufferSize),
 		tockChan: make(chan timeoutInfo, tickTockBufferSize),
 	}
 	tt.BaseService = *service.NewBaseService(nil, "TimeoutTicker", tt)
@@ -70,8 +90,9 @@ func (t *timeoutTicker) Chan() <-chan timeoutInfo {
 // ScheduleTimeout schedules a new timeout by sending on the internal tickChan.
 // The timeoutRoutine is always available to read from tickChan, so this won't block.
 // The scheduling may fail if the timeoutRoutine has already scheduled a timeout for a later height/round/step.
+// The timeout fires ti.Duration after this call.
 func (t *timeoutTicker) ScheduleTimeout(ti timeoutInfo) {
-	t.tickChan <- ti
+	t.tickChan <- scheduledTimeout{timeoutInfo: ti, deadline: t.clock.Now().Add(ti.Duration)}
 }
 
 //-------------------------------------------------------------
@@ -81,7 +102,7 @@ func (t *timeoutTicker) stopTimer() {
 	// Stop() returns false if it was already fired or was stopped
 	if !t.timer.Stop() {
 		select {
-		case <-t.timer.C:
+		case <-t.timer.C():
 		default:
 			t.Logger.Debug("Timer already stopped")
 		}
@@ -93,8 +114,21 @@ func (t *timeoutTicker) stopTimer() {
 // timeouts of 0 on the tickChan will be immediately relayed to the tockChan
 func (t *timeoutTicker) timeoutRoutine() {
 	t.Logger.Debug("Starting timeout routine")
-	var ti timeoutInfo
+	var (
+		ti      scheduledTimeout
+		pending []timeoutInfo // fired timeouts not sent on the tockChan yet, in order
+	)
 	for {
+		// only try to send if there's a pending timeout: sending on a nil
+		// channel blocks forever
+		var (
+			tockChan chan timeoutInfo
+			next     timeoutInfo
+		)
+		if len(pending) > 0 {
+			tockChan, next = t.tockChan, pending[0]
+		}
+
 		select {
 		case newti := <-t.tickChan:
 			t.Logger.Debug("Received tick", "old_ti", ti, "new_ti", newti)
@@ -115,18 +149,20 @@ func (t *timeoutTicker) timeoutRoutine() {
 			// stop the last timer
 			t.stopTimer()
 
-			// update timeoutInfo and reset timer
-			// NOTE time.Timer allows duration to be non-positive
+			// update timeoutInfo and reset timer to the remaining time
+			// NOTE clock.Timer allows duration to be non-positive
 			ti = newti
-			t.timer.Reset(ti.Duration)
+			t.timer.Reset(ti.deadline.Sub(t.clock.Now()))
 			t.Logger.Debug("Scheduled timeout", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
-		case <-t.timer.C:
-			t.Logger.Info("Timed out", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
-			// go routine here guarantees timeoutRoutine doesn't block.
+		case now := <-t.timer.C():
+			t.Logger.Info("Timed out", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step,
+				"late", now.Sub(ti.deadline))
+			// queuing the timeout guarantees the timeoutRoutine doesn't block
+			// if the tockChan is full, while keeping the timeouts in order.
 			// Determinism comes from playback in the receiveRoutine.
-			// We can eliminate it by merging the timeoutRoutine into receiveRoutine
-			//  and managing the timeouts ourselves with a millisecond ticker
-			go func(toi timeoutInfo) { t.tockChan <- toi }(ti)
+			pending = append(pending, ti.timeoutInfo)
+		case tockChan <- next:
+			pending = pending[1:]
 		case <-t.Quit():
 			return
 		}
@@ -0,0 +1,119 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/libs/clock"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestTimeoutTickerWithClock(t *testing.T) {
+	clk := clock.NewMock(time.Unix(1600000000, 0))
+	ticker := NewTimeoutTickerWithClock(clk)
+	ticker.SetLogger(log.TestingLogger())
+	require.NoError(t, ticker.Start())
+	t.Cleanup(func() {
+		if err := ticker.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ti := timeoutInfo{Duration: time.Second, Height: 1, Round: 0, Step: cstypes.RoundStepPropose}
+	ticker.ScheduleTimeout(ti)
+	require.Eventually(t, func() bool { return clk.Timers() == 1 }, time.Second, time.Millisecond)
+
+	clk.Add(999 * time.Millisecond)
+	select {
+	case <-ticker.Chan():
+		t.Fatal("timed out early")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clk.Add(time.Millisecond)
+	select {
+	case fired := <-ticker.Chan():
+		require.Equal(t, ti, fired)
+	case <-time.After(time.Second):
+		t.Fatal("didn't time out")
+	}
+
+	// a later step replaces the scheduled timeout
+	ticker.ScheduleTimeout(timeoutInfo{Duration: time.Minute, Height: 1, Round: 0, Step: cstypes.RoundStepPrevoteWait})
+	ticker.ScheduleTimeout(timeoutInfo{Duration: time.Second, Height: 1, Round: 0, Step: cstypes.RoundStepPrecommitWait})
+	time.Sleep(10 * time.Millisecond) // let the ticker process both
+	clk.Add(time.Second)
+	select {
+	case fired := <-ticker.Chan():
+		require.Equal(t, cstypes.RoundStepPrecommitWait, fired.Step)
+	case <-time.After(time.Second):
+		t.Fatal("didn't time out")
+	}
+}
+
+func TestTimeoutTickerDeadlineFromSchedule(t *testing.T) {
+	clk := clock.NewMock(time.Unix(1600000000, 0))
+	ticker := NewTimeoutTickerWithClock(clk)
+	ticker.SetLogger(log.TestingLogger())
+
+	// the timeout is scheduled before the ticker picks it up
+	ti := timeoutInfo{Duration: time.Second, Height: 1, Round: 0, Step: cstypes.RoundStepPropose}
+	ticker.ScheduleTimeout(ti)
+	clk.Add(400 * time.Millisecond)
+
+	require.NoError(t, ticker.Start())
+	t.Cleanup(func() {
+		if err := ticker.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	require.Eventually(t, func() bool { return clk.Timers() == 1 }, time.Second, time.Millisecond)
+
+	// it still fires a second after it was scheduled
+	clk.Add(599 * time.Millisecond)
+	select {
+	case <-ticker.Chan():
+		t.Fatal("timed out early")
+	case <-time.After(10 * time.Millisecond):
+	}
+	clk.Add(time.Millisecond)
+	select {
+	case fired := <-ticker.Chan():
+		require.Equal(t, ti, fired)
+	case <-time.After(time.Second):
+		t.Fatal("didn't time out")
+	}
+}
+
+func TestTimeoutTickerKeepsOrderWhenFull(t *testing.T) {
+	clk := clock.NewMock(time.Unix(1600000000, 0))
+	ticker := NewTimeoutTickerWithClock(clk)
+	ticker.SetLogger(log.TestingLogger())
+	require.NoError(t, ticker.Start())
+	t.Cleanup(func() {
+		if err := ticker.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// fire more timeouts than the tockChan holds, without reading them
+	n := 3 * tickTockBufferSize
+	for h := int64(1); h <= int64(n); h++ {
+		ticker.ScheduleTimeout(timeoutInfo{Duration: time.Second, Height: h, Round: 0, Step: cstypes.RoundStepPropose})
+		require.Eventually(t, func() bool { return clk.Timers() == 1 }, time.Second, time.Millisecond)
+		clk.Add(time.Second)
+		require.Eventually(t, func() bool { return clk.Timers() == 0 }, time.Second, time.Millisecond)
+	}
+
+	for h := int64(1); h <= int64(n); h++ {
+		select {
+		case fired := <-ticker.Chan():
+			require.Equal(t, h, fired.Height)
+		case <-time.After(time.Second):
+			t.Fatalf("didn't time out at height %d", h)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package consensus
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	timestampAuditSubscriber = "TimestampAuditor"
+	// capacity of the subscriptions, so that a slow DB doesn't cancel them
+	// after a single event
+	timestampAuditBufferSize = 100
+)
+
+// TimestampRecord is the timestamps of a block committed by consensus,
+// recorded by the TimestampAuditor.
+type TimestampRecord struct {
+	Height int64 `json:"height"`
+	// Round of the proposal
+	Round    int32          `json:"round"`
+	Proposer crypto.Address `json:"proposer"`
+	// Timestamp of the proposal, from the clock of the proposer. Zero if the
+	// block was complete before the proposal was received.
+	ProposalTime time.Time `json:"proposal_time"`
+	// Local time at which the block was complete.
+	ReceivedTime time.Time `json:"received_time"`
+	// Time of the header: the median of the timestamps of the precommits for
+	// the previous block, weighted by voting power.
+	BlockTime time.Time `json:"block_time"`
+}
+
+// TimestampAuditStore stores the TimestampRecords by height, in a compact
+// binary encoding. It's safe for concurrent use.
+type TimestampAuditStore struct {
+	db dbm.DB
+}
+
+// NewTimestampAuditStore returns a store backed by db.
+func NewTimestampAuditStore(db dbm.DB) *TimestampAuditStore {
+	return &TimestampAuditStore{db: db}
+}
+
+func timestampRecordKey(height int64) []byte {
+	key := make([]byte, 12)
+	copy(key, "tsa:")
+	binary.BigEndian.PutUint64(key[4:], uint64(height))
+	return key
+}
+
+// Save stores the record, replacing the one of the same height.
+func (s *TimestampAuditStore) Save(rec TimestampRecord) error {
+	buf := make([]byte, 0, 4*binary.MaxVarintLen64+len(rec.Proposer))
+	buf = appendVarint(buf, int64(rec.Round))
+	buf = appendVarint(buf, int64(len(rec.Proposer)))
+	buf = append(buf, rec.Proposer...)
+	for _, t := range []time.Time{rec.ProposalTime, rec.ReceivedTime, rec.BlockTime} {
+		var nanos int64
+		if !t.IsZero() {
+			nanos = t.UnixNano()
+		}
+		buf = appendVarint(buf, nanos)
+	}
+	return s.db.Set(timestampRecordKey(rec.Height), buf)
+}
+
+// Range returns the records between minHeight and maxHeight (inclusive), in
+// ascending order of height.
+func (s *TimestampAuditStore) Range(minHeight, maxHeight int64) ([]TimestampRecord, error) {
+	if minHeight > maxHeight {
+		return nil, nil
+	}
+	iter, err := s.db.Iterator(timestampRecordKey(minHeight), timestampRecordKey(maxHeight+1))
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var recs []TimestampRecord
+	for ; iter.Valid(); iter.Next() {
+		rec, err := decodeTimestampRecord(iter.Key(), iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, iter.Error()
+}
+
+// LastHeight returns the height of the last record, or 0 if there's none.
+func (s *TimestampAuditStore) LastHeight() (int64, error) {
+	iter, err := s.db.ReverseIterator(timestampRecordKey(0), timestampRecordKey(1<<63-1))
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+	if !iter.Valid() {
+		return 0, iter.Error()
+	}
+	return int64(binary.BigEndian.Uint64(iter.Key()[4:])), nil
+}
+
+// Prune deletes the records below retainHeight.
+func (s *TimestampAuditStore) Prune(retainHeight int64) error {
+	iter, err := s.db.Iterator(timestampRecordKey(0), timestampRecordKey(retainHeight))
+	if err != nil {
+		return err
+	}
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	for ; iter.Valid(); iter.Next() {
+		if err := batch.Delete(iter.Key()); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		iter.Close()
+		return err
+	}
+	iter.Close()
+	return batch.Write()
+}
+
+func appendVarint(buf []byte, v int64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
+
+func decodeTimestampRecord(key, value []byte) (TimestampRecord, error) {
+	rec := TimestampRecord{Height: int64(binary.BigEndian.Uint64(key[4:]))}
+	next := func() (int64, error) {
+		v, n := binary.Varint(value)
+		if n <= 0 {
+			return 0, fmt.Errorf("invalid timestamp record at height %d", rec.Height)
+		}
+		value = value[n:]
+		return v, nil
+	}
+
+	round, err := next()
+	if err != nil {
+		return rec, err
+	}
+	rec.Round = int32(round)
+	size, err := next()
+	if err != nil {
+		return rec, err
+	}
+	if size < 0 || size > int64(len(value)) {
+		return rec, fmt.Errorf("invalid timestamp record at height %d", rec.Height)
+	}
+	rec.Proposer, value = append(crypto.Address{}, value[:size]...), value[size:]
+	for _, t := range []*time.Time{&rec.ProposalTime, &rec.ReceivedTime, &rec.BlockTime} {
+		nanos, err := next()
+		if err != nil {
+			return rec, err
+		}
+		if nanos != 0 {
+			*t = time.Unix(0, nanos).UTC()
+		}
+	}
+	return rec, nil
+}
+
+// TimestampAuditor records the timestamps of the blocks committed by
+// consensus into a TimestampAuditStore, keeping the last retainHeights
+// heights, to diagnose the validators with a broken clock. It only listens to
+// the events of consensus, and its errors are just logged. The blocks which
+// weren't proposed in a round seen by the node (e.g. fast sync) aren't
+// recorded.
+type TimestampAuditor struct {
+	service.BaseService
+
+	store         *TimestampAuditStore
+	eventBus      *types.EventBus
+	retainHeights int64
+
+	// complete proposals of the current height, by block hash
+	proposals map[string]types.EventDataCompleteProposal
+}
+
+// NewTimestampAuditor returns a TimestampAuditor saving the records into
+// store.
+func NewTimestampAuditor(store *TimestampAuditStore, eventBus *types.EventBus, retainHeights int64) *TimestampAuditor {
+	ta := &TimestampAuditor{
+		store:         store,
+		eventBus:      eventBus,
+		retainHeights: retainHeights,
+		proposals:     make(map[string]types.EventDataCompleteProposal),
+	}
+	ta.BaseService = *service.NewBaseService(nil, "TimestampAuditor", ta)
+	return ta
+}
+
+// Store returns the store of the records.
+func (ta *TimestampAuditor) Store() *TimestampAuditStore {
+	return ta.store
+}
+
+// OnStart implements service.Service by subscribing to the complete proposals
+// and the new blocks. The subscriptions cancelled by the event bus (e.g. when
+// they're full) are renewed.
+func (ta *TimestampAuditor) OnStart() error {
+	if ta.retainHeights <= 0 {
+		return errors.New("retainHeights must be positive")
+	}
+	proposalSub, err := ta.eventBus.Subscribe(context.Background(), timestampAuditSubscriber,
+		types.EventQueryCompleteProposal, timestampAuditBufferSize)
+	if err != nil {
+		return err
+	}
+	blockSub, err := ta.eventBus.Subscribe(context.Background(), timestampAuditSubscriber,
+		types.EventQueryNewBlock, timestampAuditBufferSize)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case msg := <-proposalSub.Out():
+				ta.addProposal(msg.Data().(types.EventDataCompleteProposal))
+			case msg := <-blockSub.Out():
+				// the proposal of the block is published before it, but may not
+				// have been received yet
+			drain:
+				for {
+					select {
+					case msg := <-proposalSub.Out():
+						ta.addProposal(msg.Data().(types.EventDataCompleteProposal))
+					default:
+						break drain
+					}
+				}
+				ta.recordBlock(msg.Data().(types.EventDataNewBlock).Block)
+			case <-proposalSub.Cancelled():
+				if proposalSub = ta.resubscribe(proposalSub, types.EventQueryCompleteProposal); proposalSub == nil {
+					return
+				}
+			case <-blockSub.Cancelled():
+				if blockSub = ta.resubscribe(blockSub, types.EventQueryNewBlock); blockSub == nil {
+					return
+				}
+			case <-ta.Quit():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// OnStop implements service.Service by unsubscribing from the events.
+func (ta *TimestampAuditor) OnStop() {
+	if ta.eventBus.IsRunning() {
+		_ = ta.eventBus.UnsubscribeAll(context.Background(), timestampAuditSubscriber)
+	}
+}
+
+// resubscribe renews the cancelled subscription to the query, retrying with
+// exponential backoff, or returns nil if it was unsubscribed or the auditor
+// was stopped. The events published in between are missed.
+func (ta *TimestampAuditor) resubscribe(sub types.Subscription, q tmpubsub.Query) types.Subscription {
+	if sub.Err() == tmpubsub.ErrUnsubscribed {
+		return nil
+	}
+	ta.Logger.Error("Timestamp audit subscription was cancelled, resubscribing...", "err", sub.Err(),
+		"query", q.String())
+	backoff := 10 * time.Millisecond
+	for {
+		if !ta.IsRunning() {
+			return nil
+		}
+		sub, err := ta.eventBus.Subscribe(context.Background(), timestampAuditSubscriber, q,
+			timestampAuditBufferSize)
+		if err == nil {
+			return sub
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ta.Quit():
+			return nil
+		}
+		if backoff < time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func (ta *TimestampAuditor) addProposal(proposal types.EventDataCompleteProposal) {
+	for hash, p := range ta.proposals {
+		if p.Height < proposal.Height {
+			delete(ta.proposals, hash)
+		}
+	}
+	ta.proposals[string(proposal.BlockID.Hash)] = proposal
+}
+
+func (ta *TimestampAuditor) recordBlock(block *types.Block) {
+	proposal, ok := ta.proposals[string(block.Hash())]
+	for hash, p := range ta.proposals {
+		if p.Height <= block.Height {
+			delete(ta.proposals, hash)
+		}
+	}
+	if !ok || proposal.Height != block.Height {
+		return
+	}
+
+	rec := TimestampRecord{
+		Height:       block.Height,
+		Round:        proposal.Round,
+		Proposer:     block.ProposerAddress,
+		ProposalTime: proposal.ProposalTimestamp,
+		ReceivedTime: proposal.ReceivedAt,
+		BlockTime:    block.Time,
+	}
+	if err := ta.store.Save(rec); err != nil {
+		ta.Logger.Error("Failed to save the timestamps of the block", "height", block.Height, "err", err)
+		return
+	}
+	if retainHeight := block.Height - ta.retainHeights + 1; retainHeight > 1 {
+		if err := ta.store.Prune(retainHeight); err != nil {
+			ta.Logger.Error("Failed to prune the timestamps of the blocks", "retain_height", retainHeight, "err", err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTimestampAuditStore(t *testing.T) {
+	store := NewTimestampAuditStore(dbm.NewMemDB())
+	last, err := store.LastHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, last)
+
+	now := time.Now().UTC()
+	var recs []TimestampRecord
+	for h := int64(1); h <= 5; h++ {
+		rec := TimestampRecord{
+			Height:       h,
+			Round:        int32(h % 2),
+			Proposer:     types.Address{byte(h), 2, 3},
+			ProposalTime: now.Add(time.Duration(h) * time.Second),
+			ReceivedTime: now.Add(time.Duration(h)*time.Second + time.Millisecond),
+			BlockTime:    now,
+		}
+		if h == 3 {
+			rec.ProposalTime = time.Time{}
+		}
+		require.NoError(t, store.Save(rec))
+		recs = append(recs, rec)
+	}
+
+	loaded, err := store.Range(2, 4)
+	require.NoError(t, err)
+	assert.Equal(t, recs[1:4], loaded)
+	last, err = store.LastHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, last)
+
+	require.NoError(t, store.Prune(3))
+	loaded, err = store.Range(0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, recs[2:], loaded)
+}
+
+func TestTimestampAuditor(t *testing.T) {
+	cs, _ := randState(1)
+	store := NewTimestampAuditStore(dbm.NewMemDB())
+	ta := NewTimestampAuditor(store, cs.eventBus, 2)
+	ta.SetLogger(log.TestingLogger())
+	require.NoError(t, ta.Start())
+
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	require.NoError(t, cs.Start())
+	t.Cleanup(func() { _ = cs.Stop() })
+	var blocks []*types.Block
+	for i := 0; i < 3; i++ {
+		select {
+		case msg := <-newBlockCh:
+			blocks = append(blocks, msg.Data().(types.EventDataNewBlock).Block)
+		case <-time.After(ensureTimeout):
+			t.Fatal("timed out waiting for a new block")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		last, err := store.LastHeight()
+		require.NoError(t, err)
+		return last >= 3
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, ta.Stop())
+
+	// only the last 2 heights are kept
+	recs, err := store.Range(0, 100)
+	require.NoError(t, err)
+	require.Len(t, recs, 2)
+	assert.Equal(t, recs[0].Height+1, recs[1].Height)
+	for _, rec := range recs {
+		if rec.Height > int64(len(blocks)) {
+			continue
+		}
+		block := blocks[rec.Height-1]
+		assert.Equal(t, block.Height, rec.Height)
+		assert.Equal(t, block.ProposerAddress, rec.Proposer)
+		assert.True(t, block.Time.Equal(rec.BlockTime))
+		assert.False(t, rec.ProposalTime.IsZero())
+		assert.False(t, rec.ReceivedTime.Before(rec.ProposalTime))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/p2p"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
@@ -258,6 +259,33 @@ func (hvs *HeightVoteSet) toAllRoundVotes() []roundVotes {
 	return allVotes
 }
 
+// dump returns the votes of the rounds selected by the filter.
+func (hvs *HeightVoteSet) dump(filter RoundStateFilter) []tmcons.RoundVotes {
+	hvs.mtx.Lock()
+	defer hvs.mtx.Unlock()
+	rounds := make([]int32, 0, hvs.round+1)
+	if filter.Round != nil {
+		// also a round added for a peer catching up, if any
+		if _, ok := hvs.roundVoteSets[*filter.Round]; ok {
+			rounds = append(rounds, *filter.Round)
+		}
+	} else {
+		for round := int32(0); round <= hvs.round; round++ {
+			rounds = append(rounds, round)
+		}
+	}
+	votes := make([]tmcons.RoundVotes, 0, len(rounds))
+	for _, round := range rounds {
+		rvs := hvs.roundVoteSets[round]
+		votes = append(votes, tmcons.RoundVotes{
+			Round:      round,
+			Prevotes:   filter.votes(rvs.Prevotes, hvs.valSet),
+			Precommits: filter.votes(rvs.Precommits, hvs.valSet),
+		})
+	}
+	return votes
+}
+
 type roundVotes struct {
 	Round              int32    `json:"round"`
 	Prevotes           []string `json:"prevotes"`
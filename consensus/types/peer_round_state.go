@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/tendermint/tendermint/libs/bits"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -41,6 +42,21 @@ type PeerRoundState struct {
 	CatchupCommit *bits.BitArray `json:"catchup_commit"`
 }
 
+// Summary returns a summary of the PeerRoundState for use in RPC.
+func (prs PeerRoundState) Summary() tmcons.PeerRoundStateSummary {
+	return tmcons.PeerRoundStateSummary{
+		Height:             prs.Height,
+		Round:              prs.Round,
+		Step:               uint32(prs.Step),
+		StartTime:          prs.StartTime,
+		Proposal:           prs.Proposal,
+		ProposalBlockParts: prs.ProposalBlockParts.ToProto(),
+		Prevotes:           prs.Prevotes.ToProto(),
+		Precommits:         prs.Precommits.ToProto(),
+		LastCommit:         prs.LastCommit.ToProto(),
+	}
+}
+
 // String returns a string representation of the PeerRoundState
 func (prs PeerRoundState) String() string {
 	return prs.StringIndented("")
@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"github.com/tendermint/tendermint/libs/bytes"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 //-----------------------------------------------------------------------------
@@ -128,6 +131,105 @@ func (rs *RoundState) RoundStateSimple() RoundStateSimple {
 	}
 }
 
+// RoundStateFilter selects the votes of a RoundStateDump.
+type RoundStateFilter struct {
+	// If set, only the votes of the validator with this address are dumped.
+	ValidatorAddress []byte
+	// If set, only the votes of this round are dumped.
+	Round *int32
+}
+
+// Dump returns a compact dump of the RoundState for use in RPC, with the IDs
+// of the blocks instead of the blocks, and the votes selected by the filter.
+func (rs *RoundState) Dump(filter RoundStateFilter) (*tmcons.RoundStateDump, error) {
+	validators, err := filter.validatorSet(rs.Validators)
+	if err != nil {
+		return nil, err
+	}
+	lastValidators, err := filter.validatorSet(rs.LastValidators)
+	if err != nil {
+		return nil, err
+	}
+	dump := &tmcons.RoundStateDump{
+		Height:                    rs.Height,
+		Round:                     rs.Round,
+		Step:                      uint32(rs.Step),
+		StartTime:                 rs.StartTime,
+		CommitTime:                rs.CommitTime,
+		Validators:                validators,
+		ProposalBlockId:           blockIDOf(rs.ProposalBlock, rs.ProposalBlockParts),
+		LockedRound:               rs.LockedRound,
+		LockedBlockId:             blockIDOf(rs.LockedBlock, rs.LockedBlockParts),
+		ValidRound:                rs.ValidRound,
+		ValidBlockId:              blockIDOf(rs.ValidBlock, rs.ValidBlockParts),
+		CommitRound:               rs.CommitRound,
+		LastCommit:                filter.votes(rs.LastCommit, rs.LastValidators),
+		LastValidators:            lastValidators,
+		TriggeredTimeoutPrecommit: rs.TriggeredTimeoutPrecommit,
+	}
+	if rs.Proposal != nil {
+		dump.Proposal = rs.Proposal.ToProto()
+	}
+	if rs.Votes != nil {
+		dump.Votes = rs.Votes.dump(filter)
+	}
+	return dump, nil
+}
+
+// validatorSet returns the validator set, with only the selected validator.
+func (filter RoundStateFilter) validatorSet(vals *types.ValidatorSet) (*tmproto.ValidatorSet, error) {
+	if vals == nil {
+		return nil, nil
+	}
+	pb, err := vals.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	if filter.ValidatorAddress != nil {
+		pb.Validators = nil
+		if _, val := vals.GetByAddress(filter.ValidatorAddress); val != nil {
+			pbVal, err := val.ToProto()
+			if err != nil {
+				return nil, err
+			}
+			pb.Validators = append(pb.Validators, pbVal)
+		}
+	}
+	return pb, nil
+}
+
+// votes returns the selected votes of the vote set, whose validators are vals.
+func (filter RoundStateFilter) votes(voteSet *types.VoteSet, vals *types.ValidatorSet) []*tmproto.Vote {
+	if voteSet == nil {
+		return nil
+	}
+	if filter.ValidatorAddress != nil {
+		if vals == nil {
+			return nil
+		}
+		idx, val := vals.GetByAddress(filter.ValidatorAddress)
+		if val == nil {
+			return nil
+		}
+		if vote := voteSet.GetByIndex(idx); vote != nil {
+			return []*tmproto.Vote{vote.ToProto()}
+		}
+		return nil
+	}
+	var votes []*tmproto.Vote
+	for idx := 0; idx < voteSet.Size(); idx++ {
+		if vote := voteSet.GetByIndex(int32(idx)); vote != nil {
+			votes = append(votes, vote.ToProto())
+		}
+	}
+	return votes
+}
+
+func blockIDOf(block *types.Block, parts *types.PartSet) tmproto.BlockID {
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+	return blockID.ToProto()
+}
+
 // NewRoundEvent returns the RoundState with proposer information as an event.
 func (rs *RoundState) NewRoundEvent() types.EventDataNewRound {
 	addr := rs.Validators.GetProposer().Address
@@ -153,11 +255,18 @@ func (rs *RoundState) CompleteProposalEvent() types.EventDataCompleteProposal {
 		PartSetHeader: rs.ProposalBlockParts.Header(),
 	}
 
+	var proposalTimestamp time.Time
+	if rs.Proposal != nil && rs.Proposal.BlockID.Equals(blockID) {
+		proposalTimestamp = rs.Proposal.Timestamp
+	}
+
 	return types.EventDataCompleteProposal{
-		Height:  rs.Height,
-		Round:   rs.Round,
-		Step:    rs.Step.String(),
-		BlockID: blockID,
+		Height:            rs.Height,
+		Round:             rs.Round,
+		Step:              rs.Step.String(),
+		BlockID:           blockID,
+		ProposalTimestamp: proposalTimestamp,
+		ReceivedAt:        tmtime.Now(),
 	}
 }
 
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestRoundStateDump(t *testing.T) {
+	valSet, privVals := types.RandValidatorSet(4, 1)
+	hvs := NewHeightVoteSet(config.ChainID(), 1, valSet)
+	hvs.SetRound(1)
+	for _, vote := range []*types.Vote{
+		makeVoteHR(t, 1, 0, 0, privVals),
+		makeVoteHR(t, 1, 1, 0, privVals),
+		makeVoteHR(t, 1, 1, 1, privVals),
+	} {
+		added, err := hvs.AddVote(vote, "peer1")
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	rs := &RoundState{Height: 1, Round: 1, Step: RoundStepPrecommit, Validators: valSet, Votes: hvs}
+	val1 := valSet.Validators[1].Address
+	round1 := int32(1)
+
+	dump, err := rs.Dump(RoundStateFilter{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, dump.Height)
+	assert.EqualValues(t, RoundStepPrecommit, dump.Step)
+	assert.Len(t, dump.Validators.Validators, 4)
+	require.Len(t, dump.Votes, 2)
+	assert.Len(t, dump.Votes[0].Precommits, 2)
+	assert.Empty(t, dump.Votes[0].Prevotes)
+	assert.Len(t, dump.Votes[1].Precommits, 1)
+	assert.Nil(t, dump.LastValidators)
+
+	dump, err = rs.Dump(RoundStateFilter{ValidatorAddress: val1})
+	require.NoError(t, err)
+	require.Len(t, dump.Validators.Validators, 1)
+	assert.EqualValues(t, val1, dump.Validators.Validators[0].Address)
+	assert.Equal(t, valSet.TotalVotingPower(), dump.Validators.TotalVotingPower)
+	require.Len(t, dump.Votes, 2)
+	for _, votes := range dump.Votes {
+		require.Len(t, votes.Precommits, 1)
+		assert.EqualValues(t, val1, votes.Precommits[0].ValidatorAddress)
+	}
+
+	dump, err = rs.Dump(RoundStateFilter{Round: &round1})
+	require.NoError(t, err)
+	require.Len(t, dump.Votes, 1)
+	assert.EqualValues(t, 1, dump.Votes[0].Round)
+
+	// an unknown validator has no votes
+	dump, err = rs.Dump(RoundStateFilter{ValidatorAddress: ed25519.GenPrivKey().PubKey().Address()})
+	require.NoError(t, err)
+	assert.Empty(t, dump.Validators.Validators)
+	for _, votes := range dump.Votes {
+		assert.Empty(t, votes.Precommits)
+	}
+}
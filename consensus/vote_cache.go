@@ -0,0 +1,107 @@
+package consensus
+
+import (
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/p2p"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// voteCache remembers the first vote received from each validator, by height,
+// round and type, so that the conflicting votes (i.e. equivocations) are
+// detected as soon as they are received, before being queued for the state
+// machine. The votes are cached unverified: their signatures are only verified
+// when they conflict.
+type voteCache struct {
+	mtx       tmsync.Mutex
+	entries   map[voteCacheKey]*voteCacheEntry
+	minHeight int64 // of the votes cached
+}
+
+type voteCacheKey struct {
+	height   int64
+	round    int32
+	typ      tmproto.SignedMsgType
+	valIndex int32
+}
+
+type voteCacheEntry struct {
+	vote     *types.Vote
+	peerID   p2p.ID // which sent the vote
+	reported bool   // whether an equivocation was reported for the key
+}
+
+func newVoteCache() *voteCache {
+	return &voteCache{entries: make(map[voteCacheKey]*voteCacheEntry)}
+}
+
+func voteCacheKeyOf(vote *types.Vote) voteCacheKey {
+	return voteCacheKey{
+		height:   vote.Height,
+		round:    vote.Round,
+		typ:      vote.Type,
+		valIndex: vote.ValidatorIndex,
+	}
+}
+
+// add caches the vote received from the peer, unless a vote was already cached
+// for the same validator, height, round and type. If this one is for another
+// block, it's returned, along with the peer which sent it. Nothing is returned
+// once an equivocation was reported for the key.
+func (vc *voteCache) add(vote *types.Vote, peerID p2p.ID) (*types.Vote, p2p.ID) {
+	vc.mtx.Lock()
+	defer vc.mtx.Unlock()
+
+	key := voteCacheKeyOf(vote)
+	entry, ok := vc.entries[key]
+	if !ok {
+		vc.entries[key] = &voteCacheEntry{vote: vote, peerID: peerID}
+		return nil, ""
+	}
+	if entry.reported || entry.vote.BlockID.Equals(vote.BlockID) {
+		return nil, ""
+	}
+	return entry.vote, entry.peerID
+}
+
+// replace replaces the vote cached for the same validator, height, round and
+// type, e.g. because its signature was invalid.
+func (vc *voteCache) replace(vote *types.Vote, peerID p2p.ID) {
+	vc.mtx.Lock()
+	defer vc.mtx.Unlock()
+	vc.entries[voteCacheKeyOf(vote)] = &voteCacheEntry{vote: vote, peerID: peerID}
+}
+
+// markReported records that an equivocation was reported for the validator,
+// height, round and type of the vote. It returns false if one already was.
+func (vc *voteCache) markReported(vote *types.Vote) bool {
+	vc.mtx.Lock()
+	defer vc.mtx.Unlock()
+
+	key := voteCacheKeyOf(vote)
+	entry, ok := vc.entries[key]
+	if !ok {
+		vc.entries[key] = &voteCacheEntry{vote: vote, reported: true}
+		return true
+	}
+	if entry.reported {
+		return false
+	}
+	entry.reported = true
+	return true
+}
+
+// prune drops the votes for the heights below minHeight.
+func (vc *voteCache) prune(minHeight int64) {
+	vc.mtx.Lock()
+	defer vc.mtx.Unlock()
+	if minHeight <= vc.minHeight {
+		return
+	}
+	vc.minHeight = minHeight
+	for key := range vc.entries {
+		if key.height < minHeight {
+			delete(vc.entries, key)
+		}
+	}
+}
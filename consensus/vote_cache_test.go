@@ -0,0 +1,46 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestVoteCache(t *testing.T) {
+	vc := newVoteCache()
+	vote := func(height int64, blockHash string) *types.Vote {
+		return &types.Vote{
+			Type:           tmproto.PrevoteType,
+			Height:         height,
+			ValidatorIndex: 1,
+			BlockID:        types.BlockID{Hash: []byte(blockHash)},
+		}
+	}
+
+	cached, _ := vc.add(vote(1, "a"), "peer1")
+	assert.Nil(t, cached)
+	cached, _ = vc.add(vote(1, "a"), "peer2")
+	assert.Nil(t, cached)
+
+	cached, peerID := vc.add(vote(1, "b"), "peer2")
+	assert.Equal(t, vote(1, "a"), cached)
+	assert.EqualValues(t, "peer1", peerID)
+
+	vc.replace(vote(1, "b"), "peer2")
+	cached, peerID = vc.add(vote(1, "a"), "peer3")
+	assert.Equal(t, vote(1, "b"), cached)
+	assert.EqualValues(t, "peer2", peerID)
+
+	// once reported, the conflicts are ignored
+	assert.True(t, vc.markReported(vote(1, "a")))
+	assert.False(t, vc.markReported(vote(1, "b")))
+	cached, _ = vc.add(vote(1, "c"), "peer3")
+	assert.Nil(t, cached)
+
+	vc.add(vote(2, "a"), "peer1")
+	vc.prune(2)
+	assert.Len(t, vc.entries, 1)
+}
@@ -0,0 +1,28 @@
+// +build !windows
+
+package keyutil
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// allocate allocates the buffer on pages of its own, and tries to lock them.
+// The buffer stays on the Go heap, whose objects never move, since the crypto
+// of the standard library can't use keys outside of it (e.g. ed25519 caches
+// its expanded keys with weak pointers).
+func allocate(size int) ([]byte, bool) {
+	pageSize := syscall.Getpagesize()
+	pages := (size + pageSize - 1) / pageSize
+	raw := make([]byte, (pages+1)*pageSize)
+	offset := pageSize - int(uintptr(unsafe.Pointer(&raw[0]))%uintptr(pageSize))
+	bz := raw[offset : offset+size : offset+size]
+	return bz, syscall.Mlock(bz) == nil
+}
+
+func free(bz []byte, locked bool) error {
+	if locked {
+		return syscall.Munlock(bz)
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package keyutil
+
+// allocate allocates the buffer, it can't be locked on Windows.
+func allocate(size int) ([]byte, bool) {
+	return make([]byte, size), false
+}
+
+func free(bz []byte, locked bool) error {
+	return nil
+}
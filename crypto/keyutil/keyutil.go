@@ -0,0 +1,106 @@
+// Package keyutil provides utilities for handling private keys: buffers
+// locked in memory and explicit zeroization.
+package keyutil
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// Zero overwrites b with zeros.
+func Zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// Buffer is a fixed-size buffer for secrets, allocated on memory pages of its
+// own which, where supported, are locked in memory so that they're never
+// swapped to disk. It must be destroyed once unused, to zero it and unlock its
+// pages.
+type Buffer struct {
+	mtx    tmsync.Mutex
+	bz     []byte
+	locked bool
+}
+
+// NewBuffer returns a new zeroed Buffer of the given size. The Buffer is still
+// returned, unlocked, if it can't be locked in memory (e.g. because of
+// RLIMIT_MEMLOCK), see Locked.
+func NewBuffer(size int) (*Buffer, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid buffer size %d", size)
+	}
+	bz, locked := allocate(size)
+	return &Buffer{bz: bz, locked: locked}, nil
+}
+
+// Bytes returns the content of the buffer, or nil once destroyed. It mustn't
+// be used after Destroy.
+func (b *Buffer) Bytes() []byte {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.bz
+}
+
+// Locked returns true if the buffer is locked in memory.
+func (b *Buffer) Locked() bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.locked
+}
+
+// Destroy zeroes and frees the buffer. It's a no-op if the buffer is already
+// destroyed.
+func (b *Buffer) Destroy() error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.bz == nil {
+		return nil
+	}
+	Zero(b.bz)
+	err := free(b.bz, b.locked)
+	b.bz, b.locked = nil, false
+	return err
+}
+
+// LockPrivKey copies the private key into a new Buffer, zeroes the given key,
+// and returns the copy backed by the Buffer. The ed25519, secp256k1 and
+// sr25519 keys are supported.
+func LockPrivKey(privKey crypto.PrivKey) (crypto.PrivKey, *Buffer, error) {
+	var bz []byte
+	switch k := privKey.(type) {
+	case ed25519.PrivKey:
+		bz = k
+	case secp256k1.PrivKey:
+		bz = k
+	case sr25519.PrivKey:
+		bz = k
+	case nil:
+		return nil, nil, errors.New("nil private key")
+	default:
+		return nil, nil, fmt.Errorf("unsupported private key type %T", privKey)
+	}
+
+	buf, err := NewBuffer(len(bz))
+	if err != nil {
+		return nil, nil, err
+	}
+	copy(buf.Bytes(), bz)
+	Zero(bz)
+
+	switch privKey.(type) {
+	case ed25519.PrivKey:
+		return ed25519.PrivKey(buf.Bytes()), buf, nil
+	case secp256k1.PrivKey:
+		return secp256k1.PrivKey(buf.Bytes()), buf, nil
+	default:
+		return sr25519.PrivKey(buf.Bytes()), buf, nil
+	}
+}
@@ -0,0 +1,58 @@
+package keyutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
+)
+
+func TestZero(t *testing.T) {
+	bz := []byte("secret")
+	Zero(bz)
+	assert.Equal(t, make([]byte, 6), bz)
+}
+
+func TestBuffer(t *testing.T) {
+	_, err := NewBuffer(0)
+	assert.Error(t, err)
+
+	buf, err := NewBuffer(32)
+	require.NoError(t, err)
+	assert.Equal(t, make([]byte, 32), buf.Bytes())
+	copy(buf.Bytes(), "secret")
+	assert.Equal(t, "secret", string(buf.Bytes()[:6]))
+
+	require.NoError(t, buf.Destroy())
+	assert.Nil(t, buf.Bytes())
+	assert.False(t, buf.Locked())
+	assert.NoError(t, buf.Destroy())
+}
+
+func TestLockPrivKey(t *testing.T) {
+	for _, privKey := range []crypto.PrivKey{ed25519.GenPrivKey(), secp256k1.GenPrivKey(), sr25519.GenPrivKey()} {
+		pubKey := privKey.PubKey()
+		original := append([]byte{}, privKey.Bytes()...)
+
+		locked, buf, err := LockPrivKey(privKey)
+		require.NoError(t, err)
+		assert.IsType(t, privKey, locked)
+		assert.Equal(t, original, locked.Bytes())
+		assert.Equal(t, pubKey, locked.PubKey())
+		assert.Equal(t, make([]byte, len(original)), privKey.Bytes(), "the original key must be zeroed")
+
+		sig, err := locked.Sign([]byte("msg"))
+		require.NoError(t, err)
+		assert.True(t, pubKey.VerifySignature([]byte("msg"), sig))
+
+		require.NoError(t, buf.Destroy())
+	}
+
+	_, _, err := LockPrivKey(nil)
+	assert.Error(t, err)
+}
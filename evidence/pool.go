@@ -23,6 +23,9 @@ import (
 const (
 	baseKeyCommitted = byte(0x00)
 	baseKeyPending   = byte(0x01)
+
+	// the number of blocks whose committed evidence can wait to be notified
+	committedEvidenceBufferSize = 16
 )
 
 // Pool maintains a pool of valid evidence to be broadcasted and committed
@@ -44,6 +47,15 @@ type Pool struct {
 
 	pruningHeight int64
 	pruningTime   time.Time
+
+	// evidence committed in the blocks, notified to the peers by the reactor
+	committed chan committedEvidence
+}
+
+// committedEvidence is the evidence committed in a block.
+type committedEvidence struct {
+	height   int64
+	evidence types.EvidenceList
 }
 
 // NewPool creates an evidence pool. If using an existing evidence store,
@@ -62,6 +74,7 @@ func NewPool(evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore) (*Pool,
 		logger:        log.NewNopLogger(),
 		evidenceStore: evidenceDB,
 		evidenceList:  clist.New(),
+		committed:     make(chan committedEvidence, committedEvidenceBufferSize),
 	}
 
 	// if pending evidence already in db, in event of prior failure, then check for expiration,
@@ -108,6 +121,14 @@ func (evpool *Pool) Update(state sm.State, ev types.EvidenceList) {
 	evpool.updateState(state)
 
 	evpool.markEvidenceAsCommitted(ev)
+	if len(ev) > 0 {
+		select {
+		case evpool.committed <- committedEvidence{height: state.LastBlockHeight, evidence: ev}:
+		default:
+			// the peers will keep gossiping the evidence until it expires
+			evpool.logger.Debug("Dropping the committed evidence notification", "height", state.LastBlockHeight)
+		}
+	}
 
 	// prune pending evidence when it has expired. This also updates when the next evidence will expire
 	if evpool.Size() > 0 && state.LastBlockHeight > evpool.pruningHeight &&
@@ -1,20 +1,31 @@
 package evidence
 
 import (
+	"container/list"
 	"fmt"
 	"time"
 
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	clist "github.com/tendermint/tendermint/libs/clist"
 	"github.com/tendermint/tendermint/libs/log"
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
+	tmevidence "github.com/tendermint/tendermint/proto/tendermint/evidence"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
 
 const (
 	EvidenceChannel = byte(0x38)
+	// CommittedEvidenceChannel is the channel of the notifications of the
+	// committed evidence.
+	CommittedEvidenceChannel = byte(0x39)
 
 	maxMsgSize = 1048576 // 1MB TODO make it configurable
+	// the committed evidence of a block is notified in chunks of hashes.
+	maxCommittedMsgSize      = 65536 // 64KB
+	maxCommittedMsgHashCount = 1024
 
 	// broadcast all uncommitted evidence this often. This sets when the reactor
 	// goes back to the start of the list and begins sending the evidence again.
@@ -23,6 +34,15 @@ const (
 	broadcastEvidenceIntervalS = 10
 	// If a message fails wait this much before sending it again
 	peerRetryMessageIntervalMS = 100
+
+	// peerKnownEvidenceKey is the key of the evidence known to a peer.
+	peerKnownEvidenceKey = "EvidenceReactor.knownEvidence"
+	// maxKnownEvidence is the number of hashes of the evidence known to a peer
+	// which are remembered, the least recently added being forgotten first.
+	maxKnownEvidence = 1024
+	// maxKnownEvidenceHeightAhead is how far above the current height the
+	// evidence known to a peer can be.
+	maxKnownEvidenceHeightAhead = 100
 )
 
 // Reactor handles evpool evidence broadcasting amongst peers.
@@ -56,9 +76,27 @@ func (evR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			Priority:            5,
 			RecvMessageCapacity: maxMsgSize,
 		},
+		{
+			ID:                  CommittedEvidenceChannel,
+			Priority:            1,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: maxCommittedMsgSize,
+		},
 	}
 }
 
+// OnStart implements Service.
+func (evR *Reactor) OnStart() error {
+	go evR.broadcastCommittedRoutine()
+	return nil
+}
+
+// InitPeer implements Reactor.
+func (evR *Reactor) InitPeer(peer p2p.Peer) p2p.Peer {
+	peer.Set(peerKnownEvidenceKey, newKnownEvidence())
+	return peer
+}
+
 // AddPeer implements Reactor.
 func (evR *Reactor) AddPeer(peer p2p.Peer) {
 	go evR.broadcastEvidenceRoutine(peer)
@@ -69,6 +107,25 @@ func (evR *Reactor) AddPeer(peer p2p.Peer) {
 // XXX: do not call any methods that can block or incur heavy processing.
 // https://github.com/tendermint/tendermint/issues/2888
 func (evR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
+	if chID == CommittedEvidenceChannel {
+		msg, err := decodeCommittedMsg(msgBytes)
+		if err != nil {
+			evR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
+			evR.Switch.StopPeerForError(src, err)
+			return
+		}
+		if maxHeight := evR.maxKnownEvidenceHeight(); msg.Height > maxHeight {
+			evR.Logger.Debug("Ignoring the evidence committed too far ahead", "src", src,
+				"height", msg.Height, "max", maxHeight)
+			return
+		}
+		known := peerKnownEvidence(src)
+		for _, hash := range msg.Hashes {
+			known.Add(hash)
+		}
+		return
+	}
+
 	evis, err := decodeMsg(msgBytes)
 	if err != nil {
 		evR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
@@ -76,7 +133,13 @@ func (evR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 		return
 	}
 
+	known := peerKnownEvidence(src)
+	maxHeight := evR.maxKnownEvidenceHeight()
 	for _, ev := range evis {
+		// the peer doesn't need the evidence back
+		if ev.Height() <= maxHeight {
+			known.Add(ev.Hash())
+		}
 		err := evR.evpool.AddEvidence(ev)
 		switch err.(type) {
 		case *types.ErrInvalidEvidence:
@@ -97,6 +160,38 @@ func (evR *Reactor) SetEventBus(b *types.EventBus) {
 	evR.eventBus = b
 }
 
+// broadcastCommittedRoutine notifies the peers of the evidence committed in
+// the blocks, so that they stop gossiping it.
+func (evR *Reactor) broadcastCommittedRoutine() {
+	for {
+		select {
+		case committed := <-evR.evpool.committed:
+			for len(committed.evidence) > 0 {
+				n := tmmath.MinInt(len(committed.evidence), maxCommittedMsgHashCount)
+				msg := tmevidence.CommittedEvidence{Height: committed.height}
+				for _, ev := range committed.evidence[:n] {
+					msg.Hashes = append(msg.Hashes, ev.Hash())
+				}
+				committed.evidence = committed.evidence[n:]
+
+				msgBytes, err := msg.Marshal()
+				if err != nil {
+					panic(err)
+				}
+				evR.Switch.Broadcast(CommittedEvidenceChannel, msgBytes)
+			}
+		case <-evR.Quit():
+			return
+		}
+	}
+}
+
+// maxKnownEvidenceHeight returns the height above which the evidence isn't
+// remembered as known to the peers.
+func (evR *Reactor) maxKnownEvidenceHeight() int64 {
+	return evR.evpool.State().LastBlockHeight + maxKnownEvidenceHeightAhead
+}
+
 // Modeled after the mempool routine.
 // - Evidence accumulates in a clist.
 // - Each peer has a routine that iterates through the clist,
@@ -199,10 +294,65 @@ func (evR Reactor) prepareEvidenceMessage(
 		return nil
 	}
 
+	// the peer sent us the evidence, or committed it
+	if peerKnownEvidence(peer).Has(ev.Hash()) {
+		return nil
+	}
+
 	// send evidence
 	return []types.Evidence{ev}
 }
 
+// knownEvidence is the evidence known to a peer, i.e. received from it or
+// committed by it, by hash. Only the maxKnownEvidence most recently added are
+// remembered.
+type knownEvidence struct {
+	mtx    tmsync.Mutex
+	hashes map[string]*list.Element
+	list   *list.List // of string hashes, least recently added first
+}
+
+func newKnownEvidence() *knownEvidence {
+	return &knownEvidence{
+		hashes: make(map[string]*list.Element, maxKnownEvidence),
+		list:   list.New(),
+	}
+}
+
+// peerKnownEvidence returns the evidence known to the peer.
+func peerKnownEvidence(peer p2p.Peer) *knownEvidence {
+	if known, ok := peer.Get(peerKnownEvidenceKey).(*knownEvidence); ok {
+		return known
+	}
+	// the reactor isn't initializing the peer, e.g. in tests
+	return newKnownEvidence()
+}
+
+// Add adds the evidence of the given hash, forgetting the least recently
+// added one if full.
+func (k *knownEvidence) Add(hash []byte) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	if e, ok := k.hashes[string(hash)]; ok {
+		k.list.MoveToBack(e)
+		return
+	}
+	if k.list.Len() >= maxKnownEvidence {
+		front := k.list.Front()
+		delete(k.hashes, front.Value.(string))
+		k.list.Remove(front)
+	}
+	k.hashes[string(hash)] = k.list.PushBack(string(hash))
+}
+
+// Has returns true if the evidence of the given hash is known.
+func (k *knownEvidence) Has(hash []byte) bool {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	_, ok := k.hashes[string(hash)]
+	return ok
+}
+
 // PeerState describes the state of a peer.
 type PeerState interface {
 	GetHeight() int64
@@ -251,3 +401,25 @@ func decodeMsg(bz []byte) (evis []types.Evidence, err error) {
 
 	return evis, nil
 }
+
+// decodeCommittedMsg decodes a committed evidence notification.
+func decodeCommittedMsg(bz []byte) (*tmevidence.CommittedEvidence, error) {
+	msg := &tmevidence.CommittedEvidence{}
+	if err := msg.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+	if len(msg.Hashes) > maxCommittedMsgHashCount {
+		return nil, fmt.Errorf("too many committed evidence hashes: %d, max %d",
+			len(msg.Hashes), maxCommittedMsgHashCount)
+	}
+	if msg.Height <= 0 {
+		return nil, fmt.Errorf("invalid committed evidence height %d", msg.Height)
+	}
+	for i, hash := range msg.Hashes {
+		if len(hash) != tmhash.Size {
+			return nil, fmt.Errorf("invalid committed evidence hash (#%d): expected %d bytes, got %d",
+				i, tmhash.Size, len(hash))
+		}
+	}
+	return msg, nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/tendermint/tendermint/evidence/mocks"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/p2p"
+	tmevidence "github.com/tendermint/tendermint/proto/tendermint/evidence"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
@@ -185,6 +186,80 @@ func TestReactorsGossipNoCommittedEvidence(t *testing.T) {
 	assert.EqualValues(t, []types.Evidence{evList[0], evList[1]}, peerEv)
 }
 
+// Reactor 1 notifies reactor 0 that it committed some evidence. Reactor 0
+// should stop gossiping it to reactor 1.
+func TestReactorsGossipNoEvidenceCommittedByPeer(t *testing.T) {
+	config := cfg.TestConfig()
+
+	val := types.NewMockPV()
+	var height int64 = 10
+
+	stateDB1 := initializeValidatorState(val, height)
+	stateDB2 := initializeValidatorState(val, height)
+
+	// make reactors from statedb
+	reactors, pools := makeAndConnectReactorsAndPools(config, []sm.Store{stateDB1, stateDB2})
+
+	evList := sendEvidence(t, pools[0], val, 2)
+
+	msg := tmevidence.CommittedEvidence{Height: height, Hashes: [][]byte{evList[0].Hash()}}
+	msgBytes, err := msg.Marshal()
+	require.NoError(t, err)
+	peer := reactors[1].Switch.Peers().List()[0]
+	require.True(t, peer.Send(evidence.CommittedEvidenceChannel, msgBytes))
+
+	time.Sleep(100 * time.Millisecond)
+
+	peer = reactors[0].Switch.Peers().List()[0]
+	peer.Set(types.PeerStateKey, peerState{height})
+
+	// only the evidence not committed by the second reactor is sent
+	waitForEvidence(t, evList[1:], pools[1:])
+	time.Sleep(300 * time.Millisecond)
+	peerEv, _ := pools[1].PendingEvidence(10000)
+	assert.EqualValues(t, []types.Evidence{evList[1]}, peerEv)
+}
+
+// Reactor 1 notifies reactor 0 of evidence committed too far ahead, and of
+// more evidence than reactor 0 remembers. Reactor 0 should still gossip all
+// of it to reactor 1.
+func TestReactorsGossipEvidenceNoLongerKnownByPeer(t *testing.T) {
+	config := cfg.TestConfig()
+
+	val := types.NewMockPV()
+	var height int64 = 10
+
+	stateDB1 := initializeValidatorState(val, height)
+	stateDB2 := initializeValidatorState(val, height)
+
+	// make reactors from statedb
+	reactors, pools := makeAndConnectReactorsAndPools(config, []sm.Store{stateDB1, stateDB2})
+
+	evList := sendEvidence(t, pools[0], val, 2)
+
+	peer := reactors[1].Switch.Peers().List()[0]
+	msgs := []tmevidence.CommittedEvidence{
+		{Height: height + 1000, Hashes: [][]byte{evList[0].Hash()}},
+		{Height: height, Hashes: [][]byte{evList[1].Hash()}},
+		{Height: height},
+	}
+	for i := 0; i < 1024; i++ {
+		msgs[2].Hashes = append(msgs[2].Hashes, tmhash.Sum([]byte{byte(i), byte(i >> 8)}))
+	}
+	for _, msg := range msgs {
+		msgBytes, err := msg.Marshal()
+		require.NoError(t, err)
+		require.True(t, peer.Send(evidence.CommittedEvidenceChannel, msgBytes))
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	peer = reactors[0].Switch.Peers().List()[0]
+	peer.Set(types.PeerStateKey, peerState{height})
+
+	waitForEvidence(t, evList, pools[1:])
+}
+
 // evidenceLogger is a TestingLogger which uses a different
 // color for each validator ("validator" key must exist).
 func evidenceLogger() log.Logger {
@@ -0,0 +1,139 @@
+// Package alert notifies operators of critical node events, by invoking
+// webhooks or commands.
+package alert
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// Alert types.
+const (
+	// The consensus state machine panicked and halted.
+	ConsensusFailure = "consensus_failure"
+	// The app hash computed by the app differs from the one agreed upon.
+	AppHashMismatch = "app_hash_mismatch"
+	// The node refused to start signing, as the validator key was found to
+	// have signed recent blocks (see consensus.double_sign_check_height).
+	DoubleSignPrevented = "double_sign_prevented"
+	// The free space of the data directory's disk is below the threshold.
+	DiskNearlyFull = "disk_nearly_full"
+	// The free space of the data directory's disk is below the critical
+	// threshold, and the node refuses new peers (see disk_critical_free_mb).
+	DiskFull = "disk_full"
+	// The node has had no peers for a while.
+	NoPeers = "no_peers"
+	// The local validator has missed too many consecutive blocks.
+	ValidatorDown = "validator_down"
+	// A persistent peer couldn't be reconnected to after the max number of
+	// attempts (see p2p.persistent_peers_max_reconnect_attempts).
+	PersistentPeerUnreachable = "persistent_peer_unreachable"
+)
+
+// Types lists all the alert types.
+var Types = []string{
+	ConsensusFailure,
+	AppHashMismatch,
+	DoubleSignPrevented,
+	DiskNearlyFull,
+	DiskFull,
+	NoPeers,
+	ValidatorDown,
+	PersistentPeerUnreachable,
+}
+
+// Alert is sent to the sinks, encoded as JSON.
+type Alert struct {
+	Type    string    `json:"type"`
+	Message string    `json:"message"`
+	Moniker string    `json:"moniker"`
+	Time    time.Time `json:"time"`
+}
+
+// Sink delivers alerts.
+type Sink interface {
+	Send(ctx context.Context, alert Alert) error
+}
+
+// Alerter sends the alerts of the selected types to a set of sinks. A nil
+// *Alerter discards all alerts.
+type Alerter struct {
+	moniker string
+	sinks   []Sink
+	types   map[string]bool // nil means all types
+	timeout time.Duration
+	logger  log.Logger
+}
+
+// NewAlerter returns an Alerter sending the alerts of the given types (all
+// if empty) to the given sinks, each send being bounded by the timeout.
+func NewAlerter(moniker string, sinks []Sink, types []string, timeout time.Duration) (*Alerter, error) {
+	a := &Alerter{
+		moniker: moniker,
+		sinks:   sinks,
+		timeout: timeout,
+		logger:  log.NewNopLogger(),
+	}
+	for _, typ := range types {
+		if !isType(typ) {
+			return nil, fmt.Errorf("unknown alert type %q", typ)
+		}
+		if a.types == nil {
+			a.types = make(map[string]bool)
+		}
+		a.types[typ] = true
+	}
+	return a, nil
+}
+
+// SetLogger sets the logger used to report the failures to send alerts.
+func (a *Alerter) SetLogger(l log.Logger) {
+	a.logger = l
+}
+
+// Alert sends an alert of the given type to all the sinks, and blocks until
+// they have all been tried, so that alerts are delivered even when the process
+// is about to exit.
+func (a *Alerter) Alert(typ, format string, args ...interface{}) {
+	if a == nil || (a.types != nil && !a.types[typ]) {
+		return
+	}
+	alert := Alert{
+		Type:    typ,
+		Message: fmt.Sprintf(format, args...),
+		Moniker: a.moniker,
+		Time:    time.Now().UTC(),
+	}
+
+	ctx := context.Background()
+	if a.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.timeout)
+		defer cancel()
+	}
+
+	done := make(chan struct{}, len(a.sinks))
+	for _, sink := range a.sinks {
+		go func(sink Sink) {
+			if err := sink.Send(ctx, alert); err != nil {
+				a.logger.Error("Failed to send alert", "type", typ, "sink", sink, "err", err)
+			}
+			done <- struct{}{}
+		}(sink)
+	}
+	for range a.sinks {
+		<-done
+	}
+}
+
+func isType(typ string) bool {
+	for _, t := range Types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSink struct {
+	alerts chan Alert
+	err    error
+}
+
+func newTestSink() *testSink {
+	return &testSink{alerts: make(chan Alert, 10)}
+}
+
+func (s *testSink) Send(ctx context.Context, alert Alert) error {
+	s.alerts <- alert
+	return s.err
+}
+
+func TestAlerter(t *testing.T) {
+	_, err := NewAlerter("node", nil, []string{"unknown"}, 0)
+	assert.Error(t, err)
+
+	failing, sink := newTestSink(), newTestSink()
+	failing.err = errors.New("failed")
+	a, err := NewAlerter("node", []Sink{failing, sink}, []string{ConsensusFailure, NoPeers}, time.Second)
+	require.NoError(t, err)
+
+	a.Alert(ConsensusFailure, "halted at height %d", 3)
+	for _, s := range []*testSink{failing, sink} {
+		alert := <-s.alerts
+		assert.Equal(t, ConsensusFailure, alert.Type)
+		assert.Equal(t, "halted at height 3", alert.Message)
+		assert.Equal(t, "node", alert.Moniker)
+	}
+
+	// the types which weren't selected are discarded
+	a.Alert(DiskNearlyFull, "full")
+	assert.Empty(t, sink.alerts)
+
+	// a nil alerter discards everything
+	var nilAlerter *Alerter
+	nilAlerter.Alert(ConsensusFailure, "halted")
+}
+
+func TestWebhookSink(t *testing.T) {
+	alerts := make(chan Alert, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/alerts" {
+			http.NotFound(w, r)
+			return
+		}
+		var alert Alert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		alerts <- alert
+	}))
+	defer server.Close()
+
+	alert := Alert{Type: NoPeers, Message: "no peers", Moniker: "node", Time: time.Now().UTC()}
+	require.NoError(t, NewWebhookSink(server.URL+"/alerts").Send(context.Background(), alert))
+	got := <-alerts
+	assert.True(t, alert.Time.Equal(got.Time))
+	got.Time = alert.Time
+	assert.Equal(t, alert, got)
+
+	// non-2xx statuses are errors
+	assert.Error(t, NewWebhookSink(server.URL+"/missing").Send(context.Background(), alert))
+}
+
+func TestCommandSink(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "alert")
+	sink := CommandSink{Command: `echo "$ALERT_TYPE" > ` + out + ` && cat >> ` + out}
+	alert := Alert{Type: NoPeers, Message: "no peers"}
+	require.NoError(t, sink.Send(context.Background(), alert))
+	bz, err := ioutil.ReadFile(out)
+	require.NoError(t, err)
+	body, err := json.Marshal(alert)
+	require.NoError(t, err)
+	assert.Equal(t, NoPeers+"\n"+string(body), string(bz))
+
+	assert.Error(t, CommandSink{Command: "exit 1"}.Send(context.Background(), alert))
+}
@@ -0,0 +1,15 @@
+// +build !windows
+
+package alert
+
+import "syscall"
+
+// FreeDiskSpace returns the number of bytes available to unprivileged users
+// on the filesystem of the given path.
+func FreeDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil // nolint: unconvert
+}
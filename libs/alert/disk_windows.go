@@ -0,0 +1,8 @@
+package alert
+
+import "errors"
+
+// FreeDiskSpace is not supported on Windows.
+func FreeDiskSpace(path string) (uint64, error) {
+	return 0, errors.New("free disk space is not supported on windows")
+}
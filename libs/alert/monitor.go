@@ -0,0 +1,104 @@
+package alert
+
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+// MonitorOption sets an optional parameter on the Monitor.
+type MonitorOption func(*Monitor)
+
+// MonitorDiskSpace makes the monitor send a DiskNearlyFull alert when less
+// than minFree bytes are available on the filesystem of one of the given
+// paths.
+func MonitorDiskSpace(paths []string, minFree uint64) MonitorOption {
+	return func(m *Monitor) {
+		m.diskPaths = paths
+		m.minFreeDisk = minFree
+		m.diskAlerted = make(map[string]bool, len(paths))
+	}
+}
+
+// MonitorPeers makes the monitor send a NoPeers alert when numPeers has
+// returned 0 for the given duration.
+func MonitorPeers(numPeers func() int, timeout time.Duration) MonitorOption {
+	return func(m *Monitor) {
+		m.numPeers = numPeers
+		m.noPeersTimeout = timeout
+	}
+}
+
+// Monitor periodically checks conditions which can't be hooked into, and
+// alerts once each time one of them becomes critical.
+type Monitor struct {
+	service.BaseService
+
+	alerter  *Alerter
+	interval time.Duration
+
+	diskPaths   []string
+	minFreeDisk uint64
+	diskAlerted map[string]bool // by path
+
+	numPeers       func() int
+	noPeersTimeout time.Duration
+	lastPeerTime   time.Time
+	peersAlerted   bool
+}
+
+// NewMonitor returns a Monitor running its checks at the given interval.
+func NewMonitor(alerter *Alerter, interval time.Duration, options ...MonitorOption) *Monitor {
+	m := &Monitor{
+		alerter:  alerter,
+		interval: interval,
+	}
+	m.BaseService = *service.NewBaseService(nil, "AlertMonitor", m)
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+// OnStart implements service.Service.
+func (m *Monitor) OnStart() error {
+	m.lastPeerTime = time.Now()
+	go func() {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				m.check(now)
+			case <-m.Quit():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (m *Monitor) check(now time.Time) {
+	for _, path := range m.diskPaths {
+		free, err := FreeDiskSpace(path)
+		switch {
+		case err != nil:
+			m.Logger.Error("Can't get the free disk space", "path", path, "err", err)
+		case free < m.minFreeDisk && !m.diskAlerted[path]:
+			m.diskAlerted[path] = true
+			m.alerter.Alert(DiskNearlyFull, "only %d MB left on the disk of %s", free>>20, path)
+		case free >= m.minFreeDisk:
+			m.diskAlerted[path] = false
+		}
+	}
+
+	if m.numPeers != nil {
+		if m.numPeers() > 0 {
+			m.lastPeerTime = now
+			m.peersAlerted = false
+		} else if now.Sub(m.lastPeerTime) >= m.noPeersTimeout && !m.peersAlerted {
+			m.peersAlerted = true
+			m.alerter.Alert(NoPeers, "no peers since %v", m.lastPeerTime.UTC())
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitorPeers(t *testing.T) {
+	sink := newTestSink()
+	a, err := NewAlerter("node", []Sink{sink}, nil, time.Second)
+	require.NoError(t, err)
+
+	numPeers := 0
+	m := NewMonitor(a, time.Second, MonitorPeers(func() int { return numPeers }, time.Minute))
+	start := time.Now()
+	m.lastPeerTime = start
+
+	m.check(start.Add(30 * time.Second))
+	assert.Empty(t, sink.alerts)
+
+	// the alert fires once the timeout has passed, and only once
+	m.check(start.Add(time.Minute))
+	m.check(start.Add(2 * time.Minute))
+	assert.Equal(t, NoPeers, (<-sink.alerts).Type)
+	assert.Empty(t, sink.alerts)
+
+	// and again after the node lost its peers once more
+	numPeers = 1
+	m.check(start.Add(3 * time.Minute))
+	numPeers = 0
+	m.check(start.Add(3*time.Minute + 30*time.Second))
+	assert.Empty(t, sink.alerts)
+	m.check(start.Add(4 * time.Minute))
+	assert.Equal(t, NoPeers, (<-sink.alerts).Type)
+}
+
+func TestMonitorDiskSpace(t *testing.T) {
+	dir := t.TempDir()
+	free, err := FreeDiskSpace(dir)
+	require.NoError(t, err)
+	require.NotZero(t, free)
+
+	sink := newTestSink()
+	a, err := NewAlerter("node", []Sink{sink}, nil, time.Second)
+	require.NoError(t, err)
+
+	m := NewMonitor(a, time.Second, MonitorDiskSpace([]string{dir}, free/2))
+	m.check(time.Now())
+	assert.Empty(t, sink.alerts)
+
+	m = NewMonitor(a, time.Second, MonitorDiskSpace([]string{dir, t.TempDir()}, ^uint64(0)))
+	m.check(time.Now())
+	m.check(time.Now())
+	// once per path
+	assert.Equal(t, DiskNearlyFull, (<-sink.alerts).Type)
+	assert.Equal(t, DiskNearlyFull, (<-sink.alerts).Type)
+	assert.Empty(t, sink.alerts)
+}
@@ -0,0 +1,78 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+)
+
+// WebhookSink POSTs the alerts, as JSON, to a URL.
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+var _ Sink = WebhookSink{}
+
+// NewWebhookSink returns a WebhookSink using the default HTTP client.
+func NewWebhookSink(url string) WebhookSink {
+	return WebhookSink{URL: url, Client: http.DefaultClient}
+}
+
+// Send implements Sink.
+func (s WebhookSink) Send(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (s WebhookSink) String() string {
+	return "webhook " + s.URL
+}
+
+// CommandSink runs a shell command for each alert, with the alert as JSON on
+// its standard input and its type and message in the ALERT_TYPE and
+// ALERT_MESSAGE environment variables.
+type CommandSink struct {
+	Command string
+}
+
+var _ Sink = CommandSink{}
+
+// Send implements Sink.
+func (s CommandSink) Send(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "sh", "-c", s.Command)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(), "ALERT_TYPE="+alert.Type, "ALERT_MESSAGE="+alert.Message)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, out)
+	}
+	return nil
+}
+
+func (s CommandSink) String() string {
+	return "command " + s.Command
+}
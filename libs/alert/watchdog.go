@@ -0,0 +1,183 @@
+package alert
+
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+// minPruneInterval is the minimum interval between two runs of the pruning
+// hooks of the DiskWatchdog, as the compactions are expensive.
+const minPruneInterval = 10 * time.Minute
+
+// DiskLevel is the level of the free disk space, as seen by the DiskWatchdog.
+type DiskLevel int
+
+// Disk levels.
+const (
+	DiskOK DiskLevel = iota
+	DiskLow
+	DiskCritical
+)
+
+// String implements fmt.Stringer.
+func (l DiskLevel) String() string {
+	switch l {
+	case DiskOK:
+		return "ok"
+	case DiskLow:
+		return "low"
+	case DiskCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// DiskWatchdogOption sets an optional parameter on the DiskWatchdog.
+type DiskWatchdogOption func(*DiskWatchdog)
+
+// DiskWatchdogPrune adds a hook run while the free disk space is low, to
+// reclaim some (e.g. by pruning or compacting databases): when the level
+// escalates, and then at most every 10 minutes.
+func DiskWatchdogPrune(prune func()) DiskWatchdogOption {
+	return func(w *DiskWatchdog) {
+		w.pruneHooks = append(w.pruneHooks, prune)
+	}
+}
+
+// DiskWatchdogOnCritical sets the hook called with true when the free disk
+// space becomes critical, and with false once it's back above the low
+// threshold.
+func DiskWatchdogOnCritical(onCritical func(critical bool)) DiskWatchdogOption {
+	return func(w *DiskWatchdog) {
+		w.onCritical = onCritical
+	}
+}
+
+// DiskWatchdog periodically checks the free disk space of some paths, and
+// escalates as the lowest one decreases: below the low threshold, the pruning hooks are run and a
+// DiskNearlyFull alert is sent; below the critical threshold, a DiskFull alert
+// is sent and the critical hook is called, so that the node stops writing
+// faster than it reclaims, rather than letting the databases run out of space
+// and corrupt themselves. It only recovers once the free space is back above
+// the low threshold.
+type DiskWatchdog struct {
+	service.BaseService
+
+	paths        []string
+	lowFree      uint64
+	criticalFree uint64
+	interval     time.Duration
+	alerter      *Alerter
+	freeSpace    func(path string) (uint64, error)
+
+	pruneHooks []func()
+	onCritical func(critical bool)
+
+	level     DiskLevel
+	lastPrune time.Time
+}
+
+// NewDiskWatchdog returns a DiskWatchdog checking the free space of the
+// filesystems of paths at the given interval, against the lowFree and
+// criticalFree thresholds, in bytes. criticalFree may be 0 to never escalate to
+// the critical level.
+func NewDiskWatchdog(paths []string, lowFree, criticalFree uint64, interval time.Duration, alerter *Alerter,
+	options ...DiskWatchdogOption) *DiskWatchdog {
+	w := &DiskWatchdog{
+		paths:        paths,
+		lowFree:      lowFree,
+		criticalFree: criticalFree,
+		interval:     interval,
+		alerter:      alerter,
+		freeSpace:    FreeDiskSpace,
+	}
+	w.BaseService = *service.NewBaseService(nil, "DiskWatchdog", w)
+	for _, option := range options {
+		option(w)
+	}
+	return w
+}
+
+// OnStart implements service.Service.
+func (w *DiskWatchdog) OnStart() error {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		w.check(time.Now())
+		for {
+			select {
+			case now := <-ticker.C:
+				w.check(now)
+			case <-w.Quit():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (w *DiskWatchdog) check(now time.Time) {
+	// the level is the one of the path with the least free space
+	var (
+		path  string
+		free  uint64
+		found bool
+	)
+	for _, p := range w.paths {
+		pathFree, err := w.freeSpace(p)
+		if err != nil {
+			w.Logger.Error("Can't get the free disk space", "path", p, "err", err)
+			continue
+		}
+		if !found || pathFree < free {
+			path, free, found = p, pathFree, true
+		}
+	}
+	if !found {
+		return
+	}
+
+	level := DiskOK
+	switch {
+	case w.criticalFree > 0 && free < w.criticalFree:
+		level = DiskCritical
+	case free < w.lowFree:
+		level = DiskLow
+		if w.level == DiskCritical {
+			// only recover once back above the low threshold, so that the node
+			// doesn't flap between refusing and accepting new peers
+			level = DiskCritical
+		}
+	}
+
+	escalated := level > w.level
+	if level != w.level {
+		w.Logger.Info("Free disk space level changed", "path", path, "freeMB", free>>20,
+			"from", w.level, "to", level)
+		switch level {
+		case DiskLow:
+			if w.level == DiskOK {
+				w.alerter.Alert(DiskNearlyFull, "only %d MB left on the disk of %s", free>>20, path)
+			}
+		case DiskCritical:
+			w.alerter.Alert(DiskFull, "only %d MB left on the disk of %s, below the critical threshold",
+				free>>20, path)
+		}
+		if w.onCritical != nil && (level == DiskCritical) != (w.level == DiskCritical) {
+			w.onCritical(level == DiskCritical)
+		}
+		w.level = level
+	}
+
+	// prune again on escalation, the last run not having been enough
+	if len(w.pruneHooks) > 0 && level != DiskOK &&
+		(escalated || now.Sub(w.lastPrune) >= minPruneInterval) {
+		w.lastPrune = now
+		for _, prune := range w.pruneHooks {
+			prune()
+		}
+		w.Logger.Info("Pruned to reclaim disk space", "path", path, "took", time.Since(now))
+	}
+}
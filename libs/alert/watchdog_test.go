@@ -0,0 +1,88 @@
+package alert
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskWatchdog(t *testing.T) {
+	sink := newTestSink()
+	a, err := NewAlerter("node", []Sink{sink}, nil, time.Second)
+	require.NoError(t, err)
+
+	var (
+		prunes   int
+		critical []bool
+	)
+	w := NewDiskWatchdog([]string{"data"}, 100, 10, time.Second, a,
+		DiskWatchdogPrune(func() { prunes++ }),
+		DiskWatchdogOnCritical(func(c bool) { critical = append(critical, c) }))
+	free := uint64(200)
+	w.freeSpace = func(string) (uint64, error) { return free, nil }
+	start := time.Now()
+
+	w.check(start)
+	assert.Equal(t, DiskOK, w.level)
+	assert.Zero(t, prunes)
+
+	// below the low threshold, it prunes at most every interval
+	free = 50
+	w.check(start.Add(time.Second))
+	assert.Equal(t, DiskLow, w.level)
+	assert.Equal(t, DiskNearlyFull, (<-sink.alerts).Type)
+	assert.Equal(t, 1, prunes)
+	w.check(start.Add(2 * time.Second))
+	assert.Equal(t, 1, prunes)
+	w.check(start.Add(time.Second + minPruneInterval))
+	assert.Equal(t, 2, prunes)
+	assert.Empty(t, sink.alerts)
+
+	// the escalation prunes again, and the critical state lasts until the free
+	// space is above the low threshold
+	free = 5
+	w.check(start.Add(minPruneInterval + 2*time.Second))
+	assert.Equal(t, DiskCritical, w.level)
+	assert.Equal(t, DiskFull, (<-sink.alerts).Type)
+	assert.Equal(t, 3, prunes)
+	assert.Equal(t, []bool{true}, critical)
+	free = 50
+	w.check(start.Add(minPruneInterval + 3*time.Second))
+	assert.Equal(t, DiskCritical, w.level)
+	assert.Equal(t, []bool{true}, critical)
+	free = 150
+	w.check(start.Add(minPruneInterval + 4*time.Second))
+	assert.Equal(t, DiskOK, w.level)
+	assert.Equal(t, []bool{true, false}, critical)
+	assert.Equal(t, 3, prunes)
+	assert.Empty(t, sink.alerts)
+}
+
+func TestDiskWatchdogPaths(t *testing.T) {
+	sink := newTestSink()
+	a, err := NewAlerter("node", []Sink{sink}, nil, time.Second)
+	require.NoError(t, err)
+
+	w := NewDiskWatchdog([]string{"data", "blocks", "index"}, 100, 10, time.Second, a)
+	free := map[string]uint64{"data": 200, "blocks": 200}
+	w.freeSpace = func(path string) (uint64, error) {
+		if f, ok := free[path]; ok {
+			return f, nil
+		}
+		return 0, errors.New("no such directory")
+	}
+
+	w.check(time.Now())
+	assert.Equal(t, DiskOK, w.level)
+
+	// the level is the one of the fullest disk, the others being ignored
+	free["blocks"] = 5
+	w.check(time.Now())
+	assert.Equal(t, DiskCritical, w.level)
+	alert := <-sink.alerts
+	assert.Equal(t, DiskFull, alert.Type)
+	assert.Contains(t, alert.Message, "blocks")
+}
@@ -88,15 +88,18 @@ func RunCaptureWithArgs(cmd Executable, args []string, env map[string]string) (s
 	return stdout, stderr, err
 }
 
-// NewCompletionCmd returns a cobra.Command that generates bash and zsh
-// completion scripts for the given root command. If hidden is true, the
-// command will not show up in the root command's list of available commands.
+// NewCompletionCmd returns a cobra.Command that generates bash, zsh, fish and
+// PowerShell completion scripts for the given root command. If hidden is true,
+// the command will not show up in the root command's list of available
+// commands.
 func NewCompletionCmd(rootCmd *cobra.Command, hidden bool) *cobra.Command {
 	flagZsh := "zsh"
+	shells := []string{"bash", "zsh", "fish", "powershell"}
 	cmd := &cobra.Command{
-		Use:   "completion",
+		Use:   "completion [bash|zsh|fish|powershell]",
 		Short: "Generate shell completion scripts",
-		Long: fmt.Sprintf(`Generate Bash and Zsh completion scripts and print them to STDOUT.
+		Long: fmt.Sprintf(`Generate Bash, Zsh, fish and PowerShell completion scripts and print them
+to STDOUT (default: Bash).
 
 Once saved to file, a completion script can be loaded in the shell's
 current session as shown:
@@ -108,21 +111,37 @@ your $HOME/.bashrc or $HOME/.profile the following instruction:
 
    . <(%s completion)
 `, rootCmd.Use, rootCmd.Use),
-		RunE: func(cmd *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			zsh, err := cmd.Flags().GetBool(flagZsh)
 			if err != nil {
 				return err
 			}
+			shell := "bash"
 			if zsh {
+				shell = "zsh"
+			}
+			if len(args) > 0 {
+				shell = args[0]
+			}
+			switch shell {
+			case "bash":
+				return rootCmd.GenBashCompletion(cmd.OutOrStdout())
+			case "zsh":
 				return rootCmd.GenZshCompletion(cmd.OutOrStdout())
+			case "fish":
+				return rootCmd.GenFishCompletion(cmd.OutOrStdout(), true)
+			case "powershell":
+				return rootCmd.GenPowerShellCompletion(cmd.OutOrStdout())
+			default:
+				return fmt.Errorf("unsupported shell %q, must be one of %v", shell, shells)
 			}
-			return rootCmd.GenBashCompletion(cmd.OutOrStdout())
 		},
-		Hidden: hidden,
-		Args:   cobra.NoArgs,
+		Hidden:    hidden,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: shells,
 	}
 
-	cmd.Flags().Bool(flagZsh, false, "Generate Zsh completion script")
+	cmd.Flags().Bool(flagZsh, false, "Generate Zsh completion script (same as the zsh argument)")
 
 	return cmd
 }
@@ -0,0 +1,50 @@
+// Package clock abstracts the time functions, so that the timing dependent
+// code can be driven by a Mock clock in tests and simulations.
+package clock
+
+import "time"
+
+// Clock tells the time and schedules timers.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+	// After returns a channel on which the time is sent after d.
+	After(d time.Duration) <-chan time.Time
+	// Sleep pauses the calling goroutine for d.
+	Sleep(d time.Duration)
+	// NewTimer returns a timer, sending the time on its channel after d.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event timer, like time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is sent when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, returning false if it has already
+	// fired or been stopped.
+	Stop() bool
+	// Reset changes the timer to fire after d, returning true if it was
+	// active.
+	Reset(d time.Duration) bool
+}
+
+// New returns the system clock.
+func New() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) NewTimer(d time.Duration) Timer         { return realTimer{time.NewTimer(d)} }
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
@@ -0,0 +1,128 @@
+package clock
+
+import (
+	"sort"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// Mock is a Clock whose time only moves when Add or Set is called, firing the
+// timers which are due.
+type Mock struct {
+	mtx    tmsync.Mutex
+	now    time.Time
+	timers []*mockTimer // active timers
+}
+
+var _ Clock = (*Mock)(nil)
+
+// NewMock returns a Mock clock set to now.
+func NewMock(now time.Time) *Mock {
+	return &Mock{now: now}
+}
+
+// Now implements Clock.
+func (m *Mock) Now() time.Time {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.now
+}
+
+// Since implements Clock.
+func (m *Mock) Since(t time.Time) time.Duration {
+	return m.Now().Sub(t)
+}
+
+// After implements Clock.
+func (m *Mock) After(d time.Duration) <-chan time.Time {
+	return m.NewTimer(d).C()
+}
+
+// Sleep implements Clock. It returns once the clock has been moved forward by
+// d.
+func (m *Mock) Sleep(d time.Duration) {
+	<-m.After(d)
+}
+
+// NewTimer implements Clock.
+func (m *Mock) NewTimer(d time.Duration) Timer {
+	t := &mockTimer{mock: m, c: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// Add moves the clock forward by d, firing the timers due by then in order.
+func (m *Mock) Add(d time.Duration) {
+	m.Set(m.Now().Add(d))
+}
+
+// Set sets the clock to now, firing the timers due by then in order.
+func (m *Mock) Set(now time.Time) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.now = now
+	sort.SliceStable(m.timers, func(i, j int) bool { return m.timers[i].deadline.Before(m.timers[j].deadline) })
+	for len(m.timers) > 0 && !m.timers[0].deadline.After(now) {
+		m.timers[0].fire(now)
+		m.timers = m.timers[1:]
+	}
+}
+
+// Timers returns the number of active timers, e.g. to wait for a goroutine to
+// sleep before moving the clock forward.
+func (m *Mock) Timers() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return len(m.timers)
+}
+
+// remove removes t from the active timers, returning false if it wasn't
+// active. The caller must hold m.mtx.
+func (m *Mock) remove(t *mockTimer) bool {
+	for i, timer := range m.timers {
+		if timer == t {
+			m.timers = append(m.timers[:i], m.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type mockTimer struct {
+	mock     *Mock
+	c        chan time.Time
+	deadline time.Time
+}
+
+func (t *mockTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *mockTimer) Stop() bool {
+	t.mock.mtx.Lock()
+	defer t.mock.mtx.Unlock()
+	return t.mock.remove(t)
+}
+
+func (t *mockTimer) Reset(d time.Duration) bool {
+	t.mock.mtx.Lock()
+	defer t.mock.mtx.Unlock()
+	active := t.mock.remove(t)
+	t.deadline = t.mock.now.Add(d)
+	if d <= 0 {
+		t.fire(t.mock.now)
+	} else {
+		t.mock.timers = append(t.mock.timers, t)
+	}
+	return active
+}
+
+// fire sends now on the channel, unless a previous time hasn't been received,
+// like time.Timer.
+func (t *mockTimer) fire(now time.Time) {
+	select {
+	case t.c <- now:
+	default:
+	}
+}
@@ -0,0 +1,70 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/clock"
+)
+
+func TestMockTimers(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	m := clock.NewMock(start)
+	assert.Equal(t, start, m.Now())
+
+	t1 := m.NewTimer(time.Second)
+	t2 := m.NewTimer(2 * time.Second)
+	t3 := m.NewTimer(3 * time.Second)
+	assert.True(t, t3.Stop())
+	assert.False(t, t3.Stop())
+	assert.Equal(t, 2, m.Timers())
+
+	m.Add(500 * time.Millisecond)
+	assertNotFired(t, t1)
+	m.Add(time.Second)
+	assert.Equal(t, start.Add(1500*time.Millisecond), <-t1.C())
+	assertNotFired(t, t2)
+
+	// a reset timer fires after the new duration
+	assert.True(t, t2.Reset(time.Second))
+	m.Add(time.Second)
+	assert.Equal(t, start.Add(2500*time.Millisecond), <-t2.C())
+	assertNotFired(t, t3)
+	assert.Zero(t, m.Timers())
+
+	// a non-positive duration fires right away
+	assert.False(t, t1.Reset(0))
+	assert.Equal(t, m.Now(), <-t1.C())
+	assert.Equal(t, 2*time.Second, m.Since(start.Add(500*time.Millisecond)))
+}
+
+func TestMockSleep(t *testing.T) {
+	m := clock.NewMock(time.Unix(0, 0))
+	done := make(chan struct{})
+	go func() {
+		m.Sleep(time.Minute)
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return m.Timers() == 1 }, time.Second, time.Millisecond)
+
+	m.Add(59 * time.Second)
+	select {
+	case <-done:
+		t.Fatal("woke up early")
+	default:
+	}
+	m.Add(time.Second)
+	<-done
+}
+
+func assertNotFired(t *testing.T, timer clock.Timer) {
+	t.Helper()
+	select {
+	case <-timer.C():
+		t.Fatal("timer fired")
+	default:
+	}
+}
@@ -0,0 +1,55 @@
+package compaction
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "compaction"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Bytes reclaimed by the compactions, by database.
+	ReclaimedBytes metrics.Counter
+	// Time taken to compact a database.
+	CompactionTime metrics.Histogram
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		ReclaimedBytes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reclaimed_bytes",
+			Help:      "Bytes reclaimed by the compactions, by database.",
+		}, append(labels, "db")).With(labelsAndValues...),
+		CompactionTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compaction_seconds",
+			Help:      "Time taken to compact a database.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.1, 4, 8),
+		}, append(labels, "db")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		ReclaimedBytes: discard.NewCounter(),
+		CompactionTime: discard.NewHistogram(),
+	}
+}
@@ -0,0 +1,167 @@
+// Package compaction compacts the goleveldb databases during an off-peak
+// window, so that the disk space freed by pruning is reclaimed.
+package compaction
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// checkInterval is the interval at which the scheduler checks whether it's
+// time to compact.
+const checkInterval = time.Minute
+
+// Window is a daily time window, in UTC. It may span midnight.
+type Window struct {
+	Start time.Duration // since midnight
+	End   time.Duration // since midnight
+}
+
+// Contains returns true if t is within the window.
+func (w Window) Contains(t time.Time) bool {
+	t = t.UTC()
+	d := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+	if w.Start <= w.End {
+		return w.Start <= d && d < w.End
+	}
+	return d >= w.Start || d < w.End
+}
+
+// Scheduler compacts the databases added to it once per interval, during the
+// window. Only the goleveldb databases can be compacted.
+type Scheduler struct {
+	service.BaseService
+
+	window   Window
+	interval time.Duration
+	metrics  *Metrics
+
+	mtx     tmsync.Mutex
+	dbs     map[string]*leveldb.DB
+	lastRun time.Time
+}
+
+// NewScheduler returns a Scheduler compacting the databases once per interval,
+// during the window.
+func NewScheduler(window Window, interval time.Duration) *Scheduler {
+	s := &Scheduler{
+		window:   window,
+		interval: interval,
+		metrics:  NopMetrics(),
+		dbs:      make(map[string]*leveldb.DB),
+	}
+	s.BaseService = *service.NewBaseService(nil, "CompactionScheduler", s)
+	return s
+}
+
+// SetMetrics sets the metrics.
+func (s *Scheduler) SetMetrics(metrics *Metrics) {
+	s.metrics = metrics
+}
+
+// AddDB adds a database to compact. It returns an error if the database can't
+// be compacted, i.e. if it isn't a goleveldb one.
+func (s *Scheduler) AddDB(name string, db dbm.DB) error {
+	gdb, ok := db.(*dbm.GoLevelDB)
+	if !ok {
+		return fmt.Errorf("can't compact the %s database: only goleveldb is supported, got %T", name, db)
+	}
+	s.mtx.Lock()
+	s.dbs[name] = gdb.DB()
+	s.mtx.Unlock()
+	return nil
+}
+
+// OnStart implements service.Service.
+func (s *Scheduler) OnStart() error {
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				s.check(now)
+			case <-s.Quit():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// check compacts the databases if now is within the window, and they weren't
+// compacted for the interval.
+func (s *Scheduler) check(now time.Time) {
+	if !s.window.Contains(now) || (!s.lastRun.IsZero() && now.Sub(s.lastRun) < s.interval) {
+		return
+	}
+	s.lastRun = now
+	s.CompactAll()
+}
+
+// CompactAll compacts all the databases, and returns the number of bytes
+// reclaimed.
+func (s *Scheduler) CompactAll() int64 {
+	s.mtx.Lock()
+	dbs := make(map[string]*leveldb.DB, len(s.dbs))
+	for name, db := range s.dbs {
+		dbs[name] = db
+	}
+	s.mtx.Unlock()
+
+	total := int64(0)
+	for name, db := range dbs {
+		select {
+		case <-s.Quit():
+			return total
+		default:
+		}
+		start := time.Now()
+		reclaimed, err := compact(db)
+		if err != nil {
+			s.Logger.Error("Failed to compact database", "db", name, "err", err)
+			continue
+		}
+		s.Logger.Info("Compacted database", "db", name, "reclaimedBytes", reclaimed, "took", time.Since(start))
+		s.metrics.CompactionTime.With("db", name).Observe(time.Since(start).Seconds())
+		s.metrics.ReclaimedBytes.With("db", name).Add(float64(reclaimed))
+		total += reclaimed
+	}
+	return total
+}
+
+// compact compacts the whole database, and returns the decrease of the size of
+// its tables.
+func compact(db *leveldb.DB) (int64, error) {
+	before, err := tablesSize(db)
+	if err != nil {
+		return 0, err
+	}
+	if err := db.CompactRange(util.Range{}); err != nil {
+		return 0, err
+	}
+	after, err := tablesSize(db)
+	if err != nil {
+		return 0, err
+	}
+	if after > before {
+		return 0, nil
+	}
+	return before - after, nil
+}
+
+func tablesSize(db *leveldb.DB) (int64, error) {
+	var stats leveldb.DBStats
+	if err := db.Stats(&stats); err != nil {
+		return 0, err
+	}
+	return stats.LevelSizes.Sum(), nil
+}
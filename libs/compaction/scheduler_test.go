@@ -0,0 +1,74 @@
+package compaction
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestWindowContains(t *testing.T) {
+	at := func(hour, min int) time.Time { return time.Date(2021, 1, 1, hour, min, 0, 0, time.UTC) }
+
+	w := Window{Start: 2 * time.Hour, End: 4 * time.Hour}
+	assert.False(t, w.Contains(at(1, 59)))
+	assert.True(t, w.Contains(at(2, 0)))
+	assert.True(t, w.Contains(at(3, 59)))
+	assert.False(t, w.Contains(at(4, 0)))
+
+	// spanning midnight
+	w = Window{Start: 23 * time.Hour, End: time.Hour}
+	assert.True(t, w.Contains(at(23, 30)))
+	assert.True(t, w.Contains(at(0, 30)))
+	assert.False(t, w.Contains(at(12, 0)))
+}
+
+func TestSchedulerCompactAll(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compaction")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	db, err := dbm.NewGoLevelDB("test", dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := 0; i < 10000; i++ {
+		require.NoError(t, db.Set([]byte(fmt.Sprintf("key%05d", i)), make([]byte, 100)))
+	}
+	require.NoError(t, db.DB().CompactRange(util.Range{})) // flush to the tables
+	for i := 0; i < 10000; i++ {
+		require.NoError(t, db.Delete([]byte(fmt.Sprintf("key%05d", i))))
+	}
+
+	s := NewScheduler(Window{Start: 0, End: time.Hour}, 24*time.Hour)
+	s.SetLogger(log.TestingLogger())
+	require.NoError(t, s.AddDB("test", db))
+	assert.Error(t, s.AddDB("mem", dbm.NewMemDB()))
+
+	assert.True(t, s.CompactAll() > 0)
+	assert.EqualValues(t, 0, s.CompactAll())
+}
+
+func TestSchedulerCheck(t *testing.T) {
+	s := NewScheduler(Window{Start: 2 * time.Hour, End: 4 * time.Hour}, 24*time.Hour)
+	s.SetLogger(log.TestingLogger())
+
+	day := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.check(day.Add(time.Hour))
+	assert.True(t, s.lastRun.IsZero())
+	s.check(day.Add(2 * time.Hour))
+	assert.Equal(t, day.Add(2*time.Hour), s.lastRun)
+
+	// once per interval
+	s.check(day.Add(3 * time.Hour))
+	assert.Equal(t, day.Add(2*time.Hour), s.lastRun)
+	s.check(day.Add(26 * time.Hour))
+	assert.Equal(t, day.Add(26*time.Hour), s.lastRun)
+}
@@ -0,0 +1,115 @@
+package log
+
+import (
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// NewSamplingLogger returns a logger passing to next only a sample of the log
+// events of the given messages, e.g. the hot-path debug messages of the p2p
+// layer: within each window, the first occurrence of a message (at a level) is
+// logged, and then 1 of every rate occurrences, with a "dropped" key-value of
+// the number of occurrences dropped since the last one logged. The other
+// messages are all logged.
+//
+// The sampling is shared by the loggers returned by With, so that the
+// occurrences are counted across e.g. all the peers.
+func NewSamplingLogger(next Logger, rate int, window time.Duration, messages ...string) Logger {
+	s := &sampler{
+		rate:    rate,
+		window:  window,
+		now:     time.Now,
+		sampled: make(map[string]bool, len(messages)),
+		counts:  make(map[sampleKey]*sampleCount),
+	}
+	for _, msg := range messages {
+		s.sampled[msg] = true
+	}
+	return &samplingLogger{next: next, sampler: s}
+}
+
+type sampleKey struct {
+	level level
+	msg   string
+}
+
+type sampleCount struct {
+	windowStart time.Time
+	count       int // in the window
+	dropped     int // since the last occurrence logged
+}
+
+type sampler struct {
+	rate    int
+	window  time.Duration
+	now     func() time.Time
+	sampled map[string]bool // read-only
+
+	mtx    tmsync.Mutex
+	counts map[sampleKey]*sampleCount
+}
+
+// sample returns whether the occurrence is to be logged, and the number of
+// occurrences dropped since the last one logged.
+func (s *sampler) sample(lvl level, msg string) (bool, int) {
+	if !s.sampled[msg] {
+		return true, 0
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	now := s.now()
+	key := sampleKey{level: lvl, msg: msg}
+	c, ok := s.counts[key]
+	if !ok {
+		c = &sampleCount{windowStart: now}
+		s.counts[key] = c
+	} else if now.Sub(c.windowStart) >= s.window {
+		c.windowStart, c.count = now, 0
+	}
+	c.count++
+	if s.rate > 1 && (c.count-1)%s.rate != 0 {
+		c.dropped++
+		return false, 0
+	}
+	dropped := c.dropped
+	c.dropped = 0
+	return true, dropped
+}
+
+type samplingLogger struct {
+	next    Logger
+	sampler *sampler
+}
+
+var _ Logger = (*samplingLogger)(nil)
+
+func (l *samplingLogger) Debug(msg string, keyvals ...interface{}) {
+	if ok, dropped := l.sampler.sample(levelDebug, msg); ok {
+		l.next.Debug(msg, withDropped(keyvals, dropped)...)
+	}
+}
+
+func (l *samplingLogger) Info(msg string, keyvals ...interface{}) {
+	if ok, dropped := l.sampler.sample(levelInfo, msg); ok {
+		l.next.Info(msg, withDropped(keyvals, dropped)...)
+	}
+}
+
+func (l *samplingLogger) Error(msg string, keyvals ...interface{}) {
+	if ok, dropped := l.sampler.sample(levelError, msg); ok {
+		l.next.Error(msg, withDropped(keyvals, dropped)...)
+	}
+}
+
+func (l *samplingLogger) With(keyvals ...interface{}) Logger {
+	return &samplingLogger{next: l.next.With(keyvals...), sampler: l.sampler}
+}
+
+func withDropped(keyvals []interface{}, dropped int) []interface{} {
+	if dropped == 0 {
+		return keyvals
+	}
+	return append(keyvals[:len(keyvals):len(keyvals)], "dropped", dropped)
+}
@@ -0,0 +1,49 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplingLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSamplingLogger(NewTMJSONLogger(&buf), 3, time.Second, "Send failed")
+	now := time.Unix(1600000000, 0)
+	logger.(*samplingLogger).sampler.now = func() time.Time { return now }
+
+	lines := func() []string {
+		defer buf.Reset()
+		return strings.Split(strings.TrimSpace(buf.String()), "\n")
+	}
+
+	// 1 of 3 occurrences is logged, counted across the peers
+	for i := 0; i < 7; i++ {
+		logger.With("peer", i).Debug("Send failed", "channel", 1)
+	}
+	assert.Equal(t, []string{
+		`{"_msg":"Send failed","channel":1,"level":"debug","peer":0}`,
+		`{"_msg":"Send failed","channel":1,"dropped":2,"level":"debug","peer":3}`,
+		`{"_msg":"Send failed","channel":1,"dropped":2,"level":"debug","peer":6}`,
+	}, lines())
+
+	// the other messages and levels aren't sampled together
+	logger.Debug("Send", "channel", 1)
+	logger.Debug("Send", "channel", 1)
+	logger.Error("Send failed")
+	assert.Equal(t, []string{
+		`{"_msg":"Send","channel":1,"level":"debug"}`,
+		`{"_msg":"Send","channel":1,"level":"debug"}`,
+		`{"_msg":"Send failed","level":"error"}`,
+	}, lines())
+
+	// the first occurrence of each window is logged
+	logger.Debug("Send failed")
+	now = now.Add(time.Second)
+	logger.Debug("Send failed")
+	logger.Debug("Send failed")
+	assert.Equal(t, []string{`{"_msg":"Send failed","dropped":1,"level":"debug"}`}, lines())
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"syscall"
 	"testing"
 	"time"
@@ -67,6 +69,39 @@ func TestTrapSignal(t *testing.T) {
 	t.Fatal("this error should not be triggered")
 }
 
+func TestSdNotify(t *testing.T) {
+	os.Unsetenv("NOTIFY_SOCKET")
+	if sent, err := tmos.SdNotify("READY=1"); sent || err != nil {
+		t.Fatalf("want not sent without NOTIFY_SOCKET, got %v (err: %v)", sent, err)
+	}
+
+	dir, err := ioutil.TempDir("", "sd_notify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	os.Setenv("NOTIFY_SOCKET", socket)
+	defer os.Unsetenv("NOTIFY_SOCKET")
+
+	if sent, err := tmos.SdNotify("READY=1"); !sent || err != nil {
+		t.Fatalf("want sent, got %v (err: %v)", sent, err)
+	}
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); got != "READY=1" {
+		t.Fatalf("want %q, got %q", "READY=1", got)
+	}
+}
+
 type mockLogger struct{}
 
 func (ml mockLogger) Info(msg string, keyvals ...interface{}) {}
@@ -0,0 +1,27 @@
+package os
+
+import (
+	"net"
+	"os"
+)
+
+// SdNotify sends the state to the service manager following the sd_notify(3)
+// protocol of systemd, e.g. "READY=1" once the service is started, and returns
+// whether it was sent: it isn't, without an error, if the process wasn't
+// started by a service manager expecting notifications ($NOTIFY_SOCKET unset).
+func SdNotify(state string) (bool, error) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return false, nil
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	if _, err = conn.Write([]byte(state)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,82 @@
+package protoio
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// TranscodeField returns bz, an encoded message, with the message embedded at
+// the given path of field numbers replaced by the output of transcode, which
+// is given its encoding. The other fields are left untouched, so that a
+// message can be converted between two proto schemas which differ in one of
+// its nested messages only. bz is returned as is if the field isn't set.
+func TranscodeField(bz []byte, path []int32, transcode func([]byte) ([]byte, error)) ([]byte, error) {
+	if len(path) == 0 {
+		return nil, errors.New("empty field path")
+	}
+
+	var (
+		out      []byte
+		copyFrom int
+	)
+	for i := 0; i < len(bz); {
+		start := i
+		key, n := proto.DecodeVarint(bz[i:])
+		if n == 0 {
+			return nil, errors.New("invalid field key")
+		}
+		i += n
+		num, wireType := int32(key>>3), key&7
+
+		switch wireType {
+		case proto.WireVarint:
+			_, n = proto.DecodeVarint(bz[i:])
+			if n == 0 {
+				return nil, fmt.Errorf("invalid varint of field %d", num)
+			}
+			i += n
+		case proto.WireFixed64:
+			i += 8
+		case proto.WireFixed32:
+			i += 4
+		case proto.WireBytes:
+			size, n := proto.DecodeVarint(bz[i:])
+			if n == 0 || size > uint64(len(bz)-i-n) {
+				return nil, fmt.Errorf("invalid length of field %d", num)
+			}
+			i += n
+			value := bz[i : i+int(size)]
+			i += int(size)
+			if num != path[0] {
+				continue
+			}
+
+			var err error
+			if len(path) > 1 {
+				value, err = TranscodeField(value, path[1:], transcode)
+			} else {
+				value, err = transcode(value)
+			}
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, bz[copyFrom:start]...)
+			out = append(out, proto.EncodeVarint(key)...)
+			out = append(out, proto.EncodeVarint(uint64(len(value)))...)
+			out = append(out, value...)
+			copyFrom = i
+		default:
+			return nil, fmt.Errorf("unsupported wire type %d of field %d", wireType, num)
+		}
+		if i > len(bz) {
+			return nil, fmt.Errorf("truncated field %d", num)
+		}
+	}
+
+	if out == nil {
+		return bz, nil
+	}
+	return append(out, bz[copyFrom:]...), nil
+}
@@ -0,0 +1,51 @@
+package protoio_test
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/protoio"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestTranscodeField(t *testing.T) {
+	vote := &tmproto.Vote{Type: tmproto.PrecommitType, Height: 10, Round: 1, ValidatorIndex: 3,
+		Signature: []byte("signature")}
+	bz, err := proto.Marshal(&tmcons.Message{Sum: &tmcons.Message_Vote{Vote: &tmcons.Vote{Vote: vote}}})
+	require.NoError(t, err)
+
+	setHeight := func(height int64) func([]byte) ([]byte, error) {
+		return func(bz []byte) ([]byte, error) {
+			v := &tmproto.Vote{}
+			if err := proto.Unmarshal(bz, v); err != nil {
+				return nil, err
+			}
+			v.Height = height
+			return proto.Marshal(v)
+		}
+	}
+	transcoded, err := protoio.TranscodeField(bz, []int32{6, 1}, setHeight(1000))
+	require.NoError(t, err)
+	msg := &tmcons.Message{}
+	require.NoError(t, proto.Unmarshal(transcoded, msg))
+	expected := *vote
+	expected.Height = 1000
+	assert.Equal(t, &expected, msg.GetVote().Vote)
+
+	// the other messages are left as is
+	other, err := proto.Marshal(&tmcons.Message{Sum: &tmcons.Message_HasVote{
+		HasVote: &tmcons.HasVote{Height: 10, Round: 1, Type: tmproto.PrecommitType, Index: 3}}})
+	require.NoError(t, err)
+	transcoded, err = protoio.TranscodeField(other, []int32{6, 1}, setHeight(1000))
+	require.NoError(t, err)
+	assert.Equal(t, other, transcoded)
+
+	_, err = protoio.TranscodeField(bz[:len(bz)-1], []int32{6, 1}, setHeight(1000))
+	assert.Error(t, err)
+	_, err = protoio.TranscodeField(bz, nil, setHeight(1000))
+	assert.Error(t, err)
+}
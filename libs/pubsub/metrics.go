@@ -0,0 +1,92 @@
+package pubsub
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "pubsub"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Time from the publication of a message to it being pushed onto the
+	// channel of a subscription, by query.
+	DeliveryLag metrics.Histogram
+	// Number of messages waiting to be pulled by the slowest subscription, by
+	// query.
+	QueueSize metrics.Gauge
+	// Number of subscriptions cancelled because they weren't pulling messages
+	// fast enough.
+	DroppedSubscriptions metrics.Counter
+
+	// deletes the label sets of a query once it has no subscription left, so
+	// that the number of label sets stays bounded; nil if not supported.
+	deleteQuery func(query string)
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels, values := []string{}, []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+		values = append(values, labelsAndValues[i+1])
+	}
+	queryLabels := append(append([]string{}, labels...), "query")
+
+	// the vectors labelled by query are registered here, so that the label
+	// sets of the queries can be deleted
+	deliveryLag := stdprometheus.NewHistogramVec(stdprometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "delivery_lag_seconds",
+		Help:      "Time from the publication of a message to it being pushed onto the channel of a subscription, by query.",
+		Buckets:   stdprometheus.ExponentialBuckets(0.0001, 4, 10),
+	}, queryLabels)
+	queueSize := stdprometheus.NewGaugeVec(stdprometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "queue_size",
+		Help:      "Number of messages waiting to be pulled by the slowest subscription, by query.",
+	}, queryLabels)
+	stdprometheus.MustRegister(deliveryLag, queueSize)
+
+	return &Metrics{
+		DeliveryLag: prometheus.NewHistogram(deliveryLag).With(labelsAndValues...),
+		QueueSize:   prometheus.NewGauge(queueSize).With(labelsAndValues...),
+		DroppedSubscriptions: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "dropped_subscriptions",
+			Help:      "Number of subscriptions cancelled because they weren't pulling messages fast enough.",
+		}, labels).With(labelsAndValues...),
+		deleteQuery: func(query string) {
+			lvs := append(append([]string{}, values...), query)
+			deliveryLag.DeleteLabelValues(lvs...)
+			queueSize.DeleteLabelValues(lvs...)
+		},
+	}
+}
+
+// forgetQuery deletes the label sets of the query, if supported.
+func (m *Metrics) forgetQuery(query string) {
+	if m.deleteQuery != nil {
+		m.deleteQuery(query)
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		DeliveryLag:          discard.NewHistogram(),
+		QueueSize:            discard.NewGauge(),
+		DroppedSubscriptions: discard.NewCounter(),
+	}
+}
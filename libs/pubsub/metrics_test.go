@@ -0,0 +1,165 @@
+package pubsub_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/metrics"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+
+	"github.com/tendermint/tendermint/libs/pubsub"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+)
+
+func TestMetrics(t *testing.T) {
+	var (
+		lag     = newRecorder()
+		queue   = newRecorder()
+		dropped = newRecorder()
+	)
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	s.SetMetrics(&pubsub.Metrics{
+		DeliveryLag:          histogramRecorder{lag},
+		QueueSize:            gaugeRecorder{queue},
+		DroppedSubscriptions: counterRecorder{dropped},
+	})
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	q := query.MustParse("tm.events.type='NewBlock'")
+	subscription, err := s.Subscribe(ctx, clientID, q, 2)
+	require.NoError(t, err)
+	events := map[string][]string{"tm.events.type": {"NewBlock"}}
+
+	// the messages wait in the queue until pulled
+	require.NoError(t, s.PublishWithEvents(ctx, "Iceman", events))
+	require.NoError(t, s.PublishWithEvents(ctx, "Magneto", events))
+	// wait for the second message to be sent
+	require.NoError(t, s.PublishWithEvents(ctx, "Storm", map[string][]string{}))
+	assert.Equal(t, 2, lag.count("query", q.String()))
+	assert.Equal(t, float64(2), queue.value("query", q.String()))
+	assert.Equal(t, 0, lag.count("query", query.Empty{}.String()))
+
+	// the slow subscriptions are dropped
+	require.NoError(t, s.PublishWithEvents(ctx, "Rogue", events))
+	assertCancelled(t, subscription, pubsub.ErrOutOfCapacity)
+	assert.Equal(t, float64(1), dropped.value())
+}
+
+func TestPrometheusMetricsDeleteQuery(t *testing.T) {
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	s.SetMetrics(pubsub.PrometheusMetrics("pubsub_test"))
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	q := query.MustParse("tm.events.type='NewBlock'")
+	_, err := s.Subscribe(ctx, clientID, q, 1)
+	require.NoError(t, err)
+	require.NoError(t, s.PublishWithEvents(ctx, "Iceman", map[string][]string{"tm.events.type": {"NewBlock"}}))
+	// wait for the message to be sent
+	require.NoError(t, s.PublishWithEvents(ctx, "Storm", map[string][]string{}))
+	assert.True(t, hasQueryLabelSet(t, "pubsub_test_pubsub_queue_size", q.String()))
+	assert.True(t, hasQueryLabelSet(t, "pubsub_test_pubsub_delivery_lag_seconds", q.String()))
+
+	// the label sets are deleted once the query has no subscription left
+	require.NoError(t, s.Unsubscribe(ctx, clientID, q))
+	require.NoError(t, s.PublishWithEvents(ctx, "Storm", map[string][]string{}))
+	assert.False(t, hasQueryLabelSet(t, "pubsub_test_pubsub_queue_size", q.String()))
+	assert.False(t, hasQueryLabelSet(t, "pubsub_test_pubsub_delivery_lag_seconds", q.String()))
+}
+
+// hasQueryLabelSet returns true if the registered metric has a label set for
+// the query.
+func hasQueryLabelSet(t *testing.T, name, q string) bool {
+	families, err := stdprometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "query" && label.GetValue() == q {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// recorder implements the metrics interfaces, recording the values by label
+// values.
+type recorder struct {
+	mtx    *tmsync.Mutex
+	values map[string]float64
+	counts map[string]int
+	lvs    string
+}
+
+func newRecorder() *recorder {
+	return &recorder{mtx: new(tmsync.Mutex), values: make(map[string]float64), counts: make(map[string]int)}
+}
+
+func (r *recorder) with(labelValues ...string) *recorder {
+	return &recorder{mtx: r.mtx, values: r.values, counts: r.counts, lvs: strings.Join(labelValues, ",")}
+}
+
+func (r *recorder) record(f func()) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	f()
+	r.counts[r.lvs]++
+}
+
+func (r *recorder) value(labelValues ...string) float64 {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.values[strings.Join(labelValues, ",")]
+}
+
+func (r *recorder) count(labelValues ...string) int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.counts[strings.Join(labelValues, ",")]
+}
+
+type gaugeRecorder struct{ *recorder }
+
+func (g gaugeRecorder) With(labelValues ...string) metrics.Gauge {
+	return gaugeRecorder{g.with(labelValues...)}
+}
+func (g gaugeRecorder) Set(value float64) { g.record(func() { g.values[g.lvs] = value }) }
+func (g gaugeRecorder) Add(delta float64) { g.record(func() { g.values[g.lvs] += delta }) }
+
+type counterRecorder struct{ *recorder }
+
+func (c counterRecorder) With(labelValues ...string) metrics.Counter {
+	return counterRecorder{c.with(labelValues...)}
+}
+func (c counterRecorder) Add(delta float64) { c.record(func() { c.values[c.lvs] += delta }) }
+
+type histogramRecorder struct{ *recorder }
+
+func (h histogramRecorder) With(labelValues ...string) metrics.Histogram {
+	return histogramRecorder{h.with(labelValues...)}
+}
+func (h histogramRecorder) Observe(value float64) { h.record(func() { h.values[h.lvs] = value }) }
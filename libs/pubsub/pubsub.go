@@ -38,6 +38,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/tendermint/tendermint/libs/service"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
@@ -82,8 +83,9 @@ type cmd struct {
 	clientID     string
 
 	// publish
-	msg    interface{}
-	events map[string][]string
+	msg       interface{}
+	events    map[string][]string
+	published time.Time
 }
 
 // Server allows clients to subscribe/unsubscribe for messages, publishing
@@ -98,6 +100,8 @@ type Server struct {
 	// subscribing or unsubscribing
 	mtx           tmsync.RWMutex
 	subscriptions map[string]map[string]struct{} // subscriber -> query (string) -> empty struct
+
+	metrics *Metrics
 }
 
 // Option sets a parameter for the server.
@@ -109,6 +113,7 @@ type Option func(*Server)
 func NewServer(options ...Option) *Server {
 	s := &Server{
 		subscriptions: make(map[string]map[string]struct{}),
+		metrics:       NopMetrics(),
 	}
 	s.BaseService = *service.NewBaseService(nil, "PubSub", s)
 
@@ -134,6 +139,12 @@ func BufferCapacity(cap int) Option {
 	}
 }
 
+// SetMetrics sets the metrics of the subscriptions. It must be called before
+// the server is started.
+func (s *Server) SetMetrics(metrics *Metrics) {
+	s.metrics = metrics
+}
+
 // BufferCapacity returns capacity of the internal server's queue.
 func (s *Server) BufferCapacity() int {
 	return s.cmdsCap
@@ -276,7 +287,7 @@ func (s *Server) Publish(ctx context.Context, msg interface{}) error {
 // the client.
 func (s *Server) PublishWithEvents(ctx context.Context, msg interface{}, events map[string][]string) error {
 	select {
-	case s.cmds <- cmd{op: pub, msg: msg, events: events}:
+	case s.cmds <- cmd{op: pub, msg: msg, events: events, published: time.Now()}:
 		return nil
 	case <-ctx.Done():
 		return ctx.Err()
@@ -296,6 +307,8 @@ type state struct {
 	subscriptions map[string]map[string]*Subscription
 	// query string -> queryPlusRefCount
 	queries map[string]*queryPlusRefCount
+
+	metrics *Metrics
 }
 
 // queryPlusRefCount holds a pointer to a query and reference counter. When
@@ -310,6 +323,7 @@ func (s *Server) OnStart() error {
 	go s.loop(state{
 		subscriptions: make(map[string]map[string]*Subscription),
 		queries:       make(map[string]*queryPlusRefCount),
+		metrics:       s.metrics,
 	})
 	return nil
 }
@@ -335,7 +349,7 @@ loop:
 		case sub:
 			state.add(cmd.clientID, cmd.query, cmd.subscription)
 		case pub:
-			if err := state.send(cmd.msg, cmd.events); err != nil {
+			if err := state.send(cmd.msg, cmd.events, cmd.published); err != nil {
 				s.Logger.Error("Error querying for events", "err", err)
 			}
 		}
@@ -385,6 +399,7 @@ func (state *state) remove(clientID string, qStr string, reason error) {
 	// remove the query if nobody else is using it
 	if state.queries[qStr].refCount == 0 {
 		delete(state.queries, qStr)
+		state.metrics.forgetQuery(qStr)
 	}
 }
 
@@ -404,7 +419,7 @@ func (state *state) removeAll(reason error) {
 	}
 }
 
-func (state *state) send(msg interface{}, events map[string][]string) error {
+func (state *state) send(msg interface{}, events map[string][]string, published time.Time) error {
 	for qStr, clientSubscriptions := range state.subscriptions {
 		q := state.queries[qStr].q
 
@@ -414,6 +429,7 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 		}
 
 		if match {
+			maxQueued := 0
 			for clientID, subscription := range clientSubscriptions {
 				if cap(subscription.out) == 0 {
 					// block on unbuffered channel
@@ -424,8 +440,17 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 					case subscription.out <- NewMessage(msg, events):
 					default:
 						state.remove(clientID, qStr, ErrOutOfCapacity)
+						state.metrics.DroppedSubscriptions.Add(1)
+						continue
 					}
 				}
+				state.metrics.DeliveryLag.With("query", qStr).Observe(time.Since(published).Seconds())
+				if queued := len(subscription.out); queued > maxQueued {
+					maxQueued = queued
+				}
+			}
+			if _, ok := state.queries[qStr]; ok {
+				state.metrics.QueueSize.With("query", qStr).Set(float64(maxQueued))
 			}
 		}
 	}
@@ -0,0 +1,96 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Or is a query matching the events matched by any of its queries.
+type Or struct {
+	str     string
+	queries []*Query
+}
+
+// NewOr returns a query matching the events matched by any of the given
+// queries. Its string is made of the queries, each in parentheses, separated
+// by OR, and can be parsed back with ParseOr.
+func NewOr(queries ...*Query) *Or {
+	strs := make([]string, len(queries))
+	for i, q := range queries {
+		strs[i] = "(" + q.String() + ")"
+	}
+	return &Or{str: strings.Join(strs, " OR "), queries: queries}
+}
+
+// ParseOr parses a string of queries, each in parentheses, separated by OR:
+//
+//	(tm.event = 'Tx' AND transfer.sender = 'alice') OR (tm.event = 'Tx' AND transfer.recipient = 'alice')
+//
+// A single query in parentheses is valid too.
+func ParseOr(s string) (*Or, error) {
+	var (
+		queries []*Query
+		rest    = strings.TrimSpace(s)
+	)
+	for {
+		if !strings.HasPrefix(rest, "(") {
+			return nil, fmt.Errorf("expected a query in parentheses at %q", rest)
+		}
+		end := closingParen(rest)
+		if end < 0 {
+			return nil, errors.New("unbalanced parentheses")
+		}
+		q, err := New(strings.TrimSpace(rest[1:end]))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+
+		rest = strings.TrimSpace(rest[end+1:])
+		if rest == "" {
+			break
+		}
+		if !strings.HasPrefix(rest, "OR") {
+			return nil, fmt.Errorf("expected OR at %q", rest)
+		}
+		rest = strings.TrimSpace(rest[len("OR"):])
+	}
+	return &Or{str: s, queries: queries}, nil
+}
+
+// closingParen returns the index of the first closing parenthesis of s which
+// isn't part of a quoted value, or -1.
+func closingParen(s string) int {
+	quoted := false
+	for i, c := range s {
+		switch {
+		case c == '\'':
+			quoted = !quoted
+		case c == ')' && !quoted:
+			return i
+		}
+	}
+	return -1
+}
+
+// Queries returns the queries combined by q.
+func (q *Or) Queries() []*Query {
+	return q.queries
+}
+
+// Matches returns true if any of the queries matches the given set of events.
+func (q *Or) Matches(events map[string][]string) (bool, error) {
+	for _, query := range q.queries {
+		match, err := query.Matches(events)
+		if err != nil || match {
+			return match, err
+		}
+	}
+	return false, nil
+}
+
+// String returns the original string.
+func (q *Or) String() string {
+	return q.str
+}
@@ -0,0 +1,71 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+)
+
+func TestOrQuery(t *testing.T) {
+	q := query.NewOr(
+		query.MustParse("transfer.sender = 'alice'"),
+		query.MustParse("transfer.recipient = 'bob' AND tx.height > 5"),
+	)
+	assert.Equal(t, "(transfer.sender = 'alice') OR (transfer.recipient = 'bob' AND tx.height > 5)", q.String())
+
+	testCases := []struct {
+		events  map[string][]string
+		matches bool
+	}{
+		{map[string][]string{"transfer.sender": {"alice"}}, true},
+		{map[string][]string{"transfer.recipient": {"bob"}, "tx.height": {"6"}}, true},
+		{map[string][]string{"transfer.recipient": {"bob"}, "tx.height": {"5"}}, false},
+		{map[string][]string{"transfer.sender": {"bob"}}, false},
+		{map[string][]string{}, false},
+	}
+	for _, tc := range testCases {
+		match, err := q.Matches(tc.events)
+		require.NoError(t, err)
+		assert.Equal(t, tc.matches, match, tc.events)
+	}
+}
+
+func TestParseOr(t *testing.T) {
+	testCases := []struct {
+		s       string
+		queries []string
+	}{
+		{"(a = 1)", []string{"a = 1"}},
+		{"(a = 1) OR (b = 'x')", []string{"a = 1", "b = 'x'"}},
+		{" ( a = 1 AND b = 2 )  OR(c = 'x) OR (y')", []string{"a = 1 AND b = 2", "c = 'x) OR (y'"}},
+		{"a = 1", nil},
+		{"(a = 1", nil},
+		{"(a = 1) (b = 2)", nil},
+		{"(a = 1) OR", nil},
+		{"(a = 1) OR (b ==)", nil},
+		{"", nil},
+	}
+	for _, tc := range testCases {
+		q, err := query.ParseOr(tc.s)
+		if tc.queries == nil {
+			assert.Error(t, err, tc.s)
+			continue
+		}
+		require.NoError(t, err, tc.s)
+		assert.Equal(t, tc.s, q.String())
+		strs := make([]string, len(q.Queries()))
+		for i, q := range q.Queries() {
+			strs[i] = q.String()
+		}
+		assert.Equal(t, tc.queries, strs)
+	}
+
+	// the string of NewOr can be parsed back
+	or := query.NewOr(query.MustParse("a = 1"), query.MustParse("b CONTAINS ')'"))
+	parsed, err := query.ParseOr(or.String())
+	require.NoError(t, err)
+	assert.Len(t, parsed.Queries(), 2)
+}
@@ -6,6 +6,10 @@
 // More: https://github.com/PhilippeSigaud/Pegged/wiki/PEG-Basics
 //
 // It has a support for numbers (integer and floating point), dates and times.
+//
+// Queries can be combined with OR, each in parentheses (see Or):
+//
+//	(abci.invoice.owner=Ivan) OR (abci.invoice.payer=Ivan)
 package query
 
 import (
@@ -191,6 +195,18 @@ func (q *Query) Conditions() ([]Condition, error) {
 	return conditions, nil
 }
 
+// ConditionStrings returns the strings of the conditions, in the same order as
+// Conditions, e.g. to make a query out of some of them.
+func (q *Query) ConditionStrings() []string {
+	strs := make([]string, 0)
+	for token := range q.parser.Tokens() {
+		if token.pegRule == rulecondition {
+			strs = append(strs, q.parser.Buffer[token.begin:token.end])
+		}
+	}
+	return strs
+}
+
 // Matches returns true if the query matches against any event in the given set
 // of events, false otherwise. For each event, a match exists if the query is
 // matched against *any* value in a slice of values. An error is returned if
@@ -359,6 +375,37 @@ func (q *Query) Matches(events map[string][]string) (bool, error) {
 	return true, nil
 }
 
+// CountMatches returns the number of values of the given events matching the
+// condition. An EXISTS condition matches all the values of the attribute, or
+// of all the attributes of the event type if it has no attribute.
+func (c Condition) CountMatches(events map[string][]string) (int, error) {
+	if c.Op == OpExists {
+		if strings.Contains(c.CompositeKey, ".") {
+			return len(events[c.CompositeKey]), nil
+		}
+		n := 0
+		for compositeKey, values := range events {
+			if strings.Index(compositeKey, c.CompositeKey) == 0 {
+				n += len(values)
+			}
+		}
+		return n, nil
+	}
+
+	n := 0
+	operand := reflect.ValueOf(c.Operand)
+	for _, value := range events[c.CompositeKey] {
+		match, err := matchValue(value, c.Op, operand)
+		if err != nil {
+			return 0, err
+		}
+		if match {
+			n++
+		}
+	}
+	return n, nil
+}
+
 // match returns true if the given triplet (attribute, operator, operand) matches
 // any value in an event for that attribute. If any match fails with an error,
 // that error is returned.
@@ -220,3 +220,38 @@ func TestConditions(t *testing.T) {
 		assert.Equal(t, tc.conditions, c)
 	}
 }
+
+func TestConditionStrings(t *testing.T) {
+	q := query.MustParse("tm.event = 'Tx' AND tx.gas > 7 AND memo='a AND b' AND slashing EXISTS")
+	assert.Equal(t, []string{"tm.event = 'Tx'", "tx.gas > 7", "memo='a AND b'", "slashing EXISTS"},
+		q.ConditionStrings())
+}
+
+func TestConditionCountMatches(t *testing.T) {
+	events := map[string][]string{
+		"transfer.recipient": {"alice", "bob", "alice"},
+		"transfer.amount":    {"5", "10", "20"},
+		"message.sender":     {"carol"},
+	}
+
+	testCases := []struct {
+		s     string
+		count int
+	}{
+		{"transfer.recipient = 'alice'", 2},
+		{"transfer.recipient CONTAINS 'b'", 1},
+		{"transfer.amount >= 10", 2},
+		{"transfer.amount > 100", 0},
+		{"transfer.recipient EXISTS", 3},
+		{"transfer EXISTS", 6},
+		{"message.receiver = 'alice'", 0},
+	}
+
+	for _, tc := range testCases {
+		c, err := query.MustParse(tc.s).Conditions()
+		require.NoError(t, err)
+		count, err := c[0].CountMatches(events)
+		require.NoError(t, err, tc.s)
+		assert.Equal(t, tc.count, count, tc.s)
+	}
+}
@@ -0,0 +1,61 @@
+package sync
+
+import "sync"
+
+// A PriorityMutex is a mutual exclusion lock, acquired by the callers of
+// LockPriority before the callers of Lock waiting for it, e.g. so that the
+// consensus requests to the app never wait behind a flood of mempool requests.
+// The callers of Lock may starve while LockPriority is called continuously.
+//
+// The zero value is an unlocked mutex.
+type PriorityMutex struct {
+	mtx             sync.Mutex
+	cond            *sync.Cond
+	locked          bool
+	waiters         int // callers of Lock waiting
+	priorityWaiters int // callers of LockPriority waiting
+}
+
+// Lock locks m, once no caller of LockPriority is waiting for it.
+func (m *PriorityMutex) Lock() {
+	m.mtx.Lock()
+	m.init()
+	m.waiters++
+	for m.locked || m.priorityWaiters > 0 {
+		m.cond.Wait()
+	}
+	m.waiters--
+	m.locked = true
+	m.mtx.Unlock()
+}
+
+// LockPriority locks m, before the callers of Lock waiting for it.
+func (m *PriorityMutex) LockPriority() {
+	m.mtx.Lock()
+	m.init()
+	m.priorityWaiters++
+	for m.locked {
+		m.cond.Wait()
+	}
+	m.priorityWaiters--
+	m.locked = true
+	m.mtx.Unlock()
+}
+
+// Unlock unlocks m. It panics if m is not locked.
+func (m *PriorityMutex) Unlock() {
+	m.mtx.Lock()
+	if !m.locked {
+		m.mtx.Unlock()
+		panic("sync: unlock of unlocked PriorityMutex")
+	}
+	m.locked = false
+	m.mtx.Unlock()
+	m.cond.Broadcast()
+}
+
+func (m *PriorityMutex) init() {
+	if m.cond == nil {
+		m.cond = sync.NewCond(&m.mtx)
+	}
+}
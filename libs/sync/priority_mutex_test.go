@@ -0,0 +1,61 @@
+package sync
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityMutex(t *testing.T) {
+	var (
+		m     PriorityMutex
+		mtx   sync.Mutex
+		order []string
+		wg    sync.WaitGroup
+	)
+	record := func(s string) {
+		mtx.Lock()
+		order = append(order, s)
+		mtx.Unlock()
+	}
+	// waitFor yields until the given numbers of callers wait for m
+	waitFor := func(waiters, priorityWaiters int) {
+		for {
+			m.mtx.Lock()
+			done := m.waiters == waiters && m.priorityWaiters == priorityWaiters
+			m.mtx.Unlock()
+			if done {
+				return
+			}
+			runtime.Gosched()
+		}
+	}
+
+	m.Lock()
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Lock()
+			record("low")
+			m.Unlock()
+		}()
+	}
+	// let the low priority callers wait first
+	waitFor(3, 0)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		m.LockPriority()
+		record("high")
+		m.Unlock()
+	}()
+	waitFor(3, 1)
+	m.Unlock()
+	wg.Wait()
+
+	assert.Equal(t, []string{"high", "low", "low", "low"}, order)
+	assert.Panics(t, m.Unlock)
+}
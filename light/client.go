@@ -90,6 +90,13 @@ func Logger(l log.Logger) Option {
 	}
 }
 
+// WithMetrics option can be used to set the metrics of the client.
+func WithMetrics(m *Metrics) Option {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
 // MaxRetryAttempts option can be used to set max attempts before replacing
 // primary with a witness.
 func MaxRetryAttempts(max uint16) Option {
@@ -130,6 +137,8 @@ type Client struct {
 	trustedStore store.Store
 	// Highest trusted light block from the store (height=H).
 	latestTrustedBlock *types.LightBlock
+	// Next validators of the last light block imported by ImportTrustedState.
+	importedNextVals *types.ValidatorSet
 
 	// See RemoveNoLongerTrustedHeadersPeriod option
 	pruningSize uint16
@@ -138,7 +147,8 @@ type Client struct {
 
 	quit chan struct{}
 
-	logger log.Logger
+	logger  log.Logger
+	metrics *Metrics
 }
 
 // NewClient returns a new light client. It returns an error if it fails to
@@ -211,6 +221,7 @@ func NewClientFromTrustedStore(
 		confirmationFn:   func(action string) bool { return true },
 		quit:             make(chan struct{}),
 		logger:           log.NewNopLogger(),
+		metrics:          NopMetrics(),
 	}
 
 	for _, o := range options {
@@ -230,6 +241,10 @@ func NewClientFromTrustedStore(
 	if err := c.restoreTrustedLightBlock(); err != nil {
 		return nil, err
 	}
+	if c.latestTrustedBlock != nil {
+		c.metrics.TrustedHeight.Set(float64(c.latestTrustedBlock.Height))
+	}
+	c.metrics.Witnesses.Set(float64(len(c.witnesses)))
 
 	return c, nil
 }
@@ -572,8 +587,10 @@ func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.Ligh
 	}
 	if err != nil {
 		c.logger.Error("Can't verify", "err", err)
+		c.metrics.VerificationFailures.Add(1)
 		return err
 	}
+	c.metrics.VerifiedBlocks.Add(1)
 
 	// Once verified, save and return
 	return c.updateTrustedLightBlock(newLightBlock)
@@ -824,6 +841,14 @@ func (c *Client) ChainID() string {
 	return c.chainID
 }
 
+// TrustingPeriod returns the trusting period the light client was configured
+// with, see TrustOptions.Period.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) TrustingPeriod() time.Duration {
+	return c.trustingPeriod
+}
+
 // Primary returns the primary provider.
 //
 // NOTE: provider may be not safe for concurrent access.
@@ -894,6 +919,7 @@ func (c *Client) updateTrustedLightBlock(l *types.LightBlock) error {
 
 	if c.latestTrustedBlock == nil || l.Height > c.latestTrustedBlock.Height {
 		c.latestTrustedBlock = l
+		c.metrics.TrustedHeight.Set(float64(l.Height))
 	}
 
 	return nil
@@ -951,6 +977,7 @@ func (c *Client) removeWitness(idx int) {
 		c.witnesses[idx] = c.witnesses[len(c.witnesses)-1]
 		c.witnesses = c.witnesses[:len(c.witnesses)-1]
 	}
+	c.metrics.Witnesses.Set(float64(len(c.witnesses)))
 }
 
 // replaceProvider takes the first alternative provider and promotes it as the
@@ -965,6 +992,8 @@ func (c *Client) replacePrimaryProvider() error {
 	c.primary = c.witnesses[0]
 	c.witnesses = c.witnesses[1:]
 	c.logger.Info("Replacing primary with the first witness", "new_primary", c.primary)
+	c.metrics.PrimaryReplacements.Add(1)
+	c.metrics.Witnesses.Set(float64(len(c.witnesses)))
 
 	return nil
 }
@@ -0,0 +1,81 @@
+package light
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "light"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Height of the latest trusted light block.
+	TrustedHeight metrics.Gauge
+	// Number of light blocks verified.
+	VerifiedBlocks metrics.Counter
+	// Number of light blocks which failed verification.
+	VerificationFailures metrics.Counter
+	// Number of witnesses.
+	Witnesses metrics.Gauge
+	// Number of times the primary was replaced with a witness.
+	PrimaryReplacements metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		TrustedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "trusted_height",
+			Help:      "Height of the latest trusted light block.",
+		}, labels).With(labelsAndValues...),
+		VerifiedBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "verified_blocks",
+			Help:      "Number of light blocks verified.",
+		}, labels).With(labelsAndValues...),
+		VerificationFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "verification_failures",
+			Help:      "Number of light blocks which failed verification.",
+		}, labels).With(labelsAndValues...),
+		Witnesses: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "witnesses",
+			Help:      "Number of witnesses.",
+		}, labels).With(labelsAndValues...),
+		PrimaryReplacements: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "primary_replacements",
+			Help:      "Number of times the primary was replaced with a witness.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		TrustedHeight:        discard.NewGauge(),
+		VerifiedBlocks:       discard.NewCounter(),
+		VerificationFailures: discard.NewCounter(),
+		Witnesses:            discard.NewGauge(),
+		PrimaryReplacements:  discard.NewCounter(),
+	}
+}
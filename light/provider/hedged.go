@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// hedged is a provider sending hedged requests to several providers.
+type hedged struct {
+	providers []Provider
+	delay     time.Duration
+}
+
+// NewHedged returns a provider which requests light blocks from the first of
+// the given providers and, whenever no response arrived after delay (or the
+// last request failed), from the next one, returning the first successful
+// response. This reduces the tail latency caused by slow providers, at the
+// cost of additional requests.
+//
+// If all the providers fail, the error of the first one is returned.
+// Evidence is reported to all the providers.
+func NewHedged(delay time.Duration, providers ...Provider) Provider {
+	if len(providers) == 0 {
+		panic("no providers given")
+	}
+	return &hedged{
+		providers: providers,
+		delay:     delay,
+	}
+}
+
+func (h *hedged) String() string {
+	strs := make([]string, len(h.providers))
+	for i, p := range h.providers {
+		strs[i] = fmt.Sprintf("%v", p)
+	}
+	return fmt.Sprintf("hedged{%s}", strings.Join(strs, ", "))
+}
+
+type hedgedResult struct {
+	index int
+	lb    *types.LightBlock
+	err   error
+}
+
+// LightBlock implements Provider.
+func (h *hedged) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	// cancel the outstanding requests once we got a response
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgedResult, len(h.providers))
+	next, pending := 0, 0
+	request := func() {
+		i := next
+		next++
+		pending++
+		go func() {
+			lb, err := h.providers[i].LightBlock(ctx, height)
+			results <- hedgedResult{index: i, lb: lb, err: err}
+		}()
+	}
+
+	timer := time.NewTimer(h.delay)
+	defer timer.Stop()
+	errs := make([]error, len(h.providers))
+	request()
+	for pending > 0 {
+		select {
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				return res.lb, nil
+			}
+			errs[res.index] = res.err
+			if next < len(h.providers) {
+				request()
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(h.delay)
+			}
+
+		case <-timer.C:
+			if next < len(h.providers) {
+				request()
+				timer.Reset(h.delay)
+			}
+		}
+	}
+
+	return nil, errs[0]
+}
+
+// ReportEvidence implements Provider. It only returns an error if the evidence
+// couldn't be reported to any provider.
+func (h *hedged) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	var firstErr error
+	reported := false
+	for _, p := range h.providers {
+		err := p.ReportEvidence(ctx, ev)
+		if err == nil {
+			reported = true
+		} else if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if reported {
+		return nil
+	}
+	return firstErr
+}
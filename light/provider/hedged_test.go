@@ -0,0 +1,105 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/types"
+)
+
+// delayedProvider returns lb or err after delay.
+type delayedProvider struct {
+	delay time.Duration
+	lb    *types.LightBlock
+	err   error
+}
+
+func (p *delayedProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	select {
+	case <-time.After(p.delay):
+		return p.lb, p.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *delayedProvider) ReportEvidence(context.Context, types.Evidence) error {
+	return p.err
+}
+
+func TestHedgedProvider(t *testing.T) {
+	lb1 := &types.LightBlock{}
+	lb2 := &types.LightBlock{}
+	errFail := errors.New("fail")
+	testCases := map[string]struct {
+		providers []provider.Provider
+		lb        *types.LightBlock
+		err       error
+		maxTime   time.Duration
+	}{
+		"first responds": {
+			[]provider.Provider{
+				&delayedProvider{delay: 0, lb: lb1},
+				&delayedProvider{delay: 0, lb: lb2},
+			},
+			lb1, nil, 50 * time.Millisecond,
+		},
+		"first is slow": {
+			[]provider.Provider{
+				&delayedProvider{delay: time.Hour, lb: lb1},
+				&delayedProvider{delay: 0, lb: lb2},
+			},
+			lb2, nil, 200 * time.Millisecond,
+		},
+		"first fails": {
+			[]provider.Provider{
+				&delayedProvider{delay: 0, err: errFail},
+				&delayedProvider{delay: 0, lb: lb2},
+			},
+			lb2, nil, 50 * time.Millisecond,
+		},
+		"all fail": {
+			[]provider.Provider{
+				&delayedProvider{delay: 0, err: errFail},
+				&delayedProvider{delay: 0, err: provider.ErrNoResponse},
+			},
+			nil, errFail, 50 * time.Millisecond,
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			p := provider.NewHedged(100*time.Millisecond, tc.providers...)
+			start := time.Now()
+			lb, err := p.LightBlock(context.Background(), 1)
+			assert.Less(t, int64(time.Since(start)), int64(tc.maxTime))
+			if tc.err != nil {
+				require.Equal(t, tc.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, lb == tc.lb)
+		})
+	}
+}
+
+func TestHedgedProviderReportEvidence(t *testing.T) {
+	errFail := errors.New("fail")
+	p := provider.NewHedged(time.Second,
+		&delayedProvider{err: errFail},
+		&delayedProvider{},
+	)
+	assert.NoError(t, p.ReportEvidence(context.Background(), nil))
+
+	p = provider.NewHedged(time.Second,
+		&delayedProvider{err: errFail},
+		&delayedProvider{err: provider.ErrNoResponse},
+	)
+	assert.Equal(t, errFail, p.ReportEvidence(context.Background(), nil))
+}
@@ -2,8 +2,11 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
+	gohttp "net/http"
 	"regexp"
 	"strings"
 	"time"
@@ -11,44 +14,114 @@ import (
 	"github.com/tendermint/tendermint/light/provider"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
 )
 
 // This is very brittle, see: https://github.com/tendermint/tendermint/issues/4740
-var (
-	regexpMissingHeight = regexp.MustCompile(`height \d+ (must be less than or equal to|is not available)`)
-	maxRetryAttempts    = 10
+var regexpMissingHeight = regexp.MustCompile(`height \d+ (must be less than or equal to|is not available)`)
+
+const (
+	defaultMaxRetryAttempts = 10
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxJitter   = time.Second
+	// DefaultMaxResponseSize is the default maximum size of the responses
+	// accepted from the RPC server, in bytes.
+	DefaultMaxResponseSize = 32 * 1024 * 1024 // 32 MB
 )
 
+// ErrResponseTooLarge is returned (wrapped in a provider.ErrBadLightBlock)
+// when the RPC server sends a response larger than the maximum response size.
+var ErrResponseTooLarge = errors.New("response too large")
+
 // http provider uses an RPC client to obtain the necessary information.
 type http struct {
 	chainID string
 	client  rpcclient.RemoteClient
+
+	maxRetryAttempts int
+	retryBaseDelay   time.Duration
+	retryMaxJitter   time.Duration
+	maxResponseSize  int64
+}
+
+// Option sets an optional parameter on the http provider.
+type Option func(*http)
+
+// MaxRetryAttempts sets the maximum number of attempts made for each request
+// to the RPC server before giving up with provider.ErrNoResponse. Only
+// transient errors (e.g. network errors) are retried. Default: 10.
+func MaxRetryAttempts(attempts int) Option {
+	return func(p *http) { p.maxRetryAttempts = attempts }
+}
+
+// RetryBackoff sets the delay before retrying a request: baseDelay * n^2 plus
+// a random jitter up to maxJitter, where n is the number of failed attempts.
+// Default: 500ms and 1s.
+func RetryBackoff(baseDelay, maxJitter time.Duration) Option {
+	return func(p *http) {
+		p.retryBaseDelay = baseDelay
+		p.retryMaxJitter = maxJitter
+	}
+}
+
+// MaxResponseSize sets the maximum size of the responses accepted from the RPC
+// server, in bytes. 0 means no limit. It's ignored by NewWithClient.
+// Default: DefaultMaxResponseSize.
+func MaxResponseSize(bytes int64) Option {
+	return func(p *http) { p.maxResponseSize = bytes }
 }
 
 // New creates a HTTP provider, which is using the rpchttp.HTTP client under
 // the hood. If no scheme is provided in the remote URL, http will be used by
 // default.
-func New(chainID, remote string) (provider.Provider, error) {
+func New(chainID, remote string, options ...Option) (provider.Provider, error) {
 	// Ensure URL scheme is set (default HTTP) when not provided.
 	if !strings.Contains(remote, "://") {
 		remote = "http://" + remote
 	}
 
-	httpClient, err := rpchttp.New(remote, "/websocket")
+	p := newHTTP(chainID, nil, options)
+
+	client, err := jsonrpcclient.DefaultHTTPClient(remote)
 	if err != nil {
 		return nil, err
 	}
+	if p.maxResponseSize > 0 {
+		client.Transport = &limitedTransport{
+			RoundTripper: client.Transport,
+			maxSize:      p.maxResponseSize,
+		}
+	}
 
-	return NewWithClient(chainID, httpClient), nil
+	p.client, err = rpchttp.NewWithClient(remote, "/websocket", client)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
 }
 
 // NewWithClient allows you to provide a custom client.
-func NewWithClient(chainID string, client rpcclient.RemoteClient) provider.Provider {
-	return &http{
-		client:  client,
-		chainID: chainID,
+func NewWithClient(chainID string, client rpcclient.RemoteClient, options ...Option) provider.Provider {
+	return newHTTP(chainID, client, options)
+}
+
+func newHTTP(chainID string, client rpcclient.RemoteClient, options []Option) *http {
+	p := &http{
+		chainID:          chainID,
+		client:           client,
+		maxRetryAttempts: defaultMaxRetryAttempts,
+		retryBaseDelay:   defaultRetryBaseDelay,
+		retryMaxJitter:   defaultRetryMaxJitter,
+		maxResponseSize:  DefaultMaxResponseSize,
+	}
+	for _, option := range options {
+		option(p)
 	}
+	return p
 }
 
 func (p *http) String() string {
@@ -100,32 +173,19 @@ func (p *http) validatorSet(ctx context.Context, height *int64) (*types.Validato
 	)
 
 	for len(vals)%maxPerPage == 0 {
-		for attempt := 1; attempt <= maxRetryAttempts; attempt++ {
-			res, err := p.client.Validators(ctx, height, &page, &maxPerPage)
-			if err != nil {
-				// TODO: standardize errors on the RPC side
-				if regexpMissingHeight.MatchString(err.Error()) {
-					return nil, provider.ErrLightBlockNotFound
-				}
-				// if we have exceeded retry attempts then return no response error
-				if attempt == maxRetryAttempts {
-					return nil, provider.ErrNoResponse
-				}
-				// else we wait and try again with exponential backoff
-				time.Sleep(backoffTimeout(uint16(attempt)))
-				continue
-			}
-			if len(res.Validators) == 0 { // no more validators left
-				valSet, err := types.ValidatorSetFromExistingValidators(vals)
-				if err != nil {
-					return nil, provider.ErrBadLightBlock{Reason: err}
-				}
-				return valSet, nil
-			}
-			vals = append(vals, res.Validators...)
-			page++
+		var res *ctypes.ResultValidators
+		err := p.retry(ctx, func() (err error) {
+			res, err = p.client.Validators(ctx, height, &page, &maxPerPage)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(res.Validators) == 0 { // no more validators left
 			break
 		}
+		vals = append(vals, res.Validators...)
+		page++
 	}
 	valSet, err := types.ValidatorSetFromExistingValidators(vals)
 	if err != nil {
@@ -135,20 +195,68 @@ func (p *http) validatorSet(ctx context.Context, height *int64) (*types.Validato
 }
 
 func (p *http) signedHeader(ctx context.Context, height *int64) (*types.SignedHeader, error) {
-	for attempt := 1; attempt <= maxRetryAttempts; attempt++ {
-		commit, err := p.client.Commit(ctx, height)
-		if err != nil {
-			// TODO: standardize errors on the RPC side
-			if regexpMissingHeight.MatchString(err.Error()) {
-				return nil, provider.ErrLightBlockNotFound
-			}
-			// we wait and try again with exponential backoff
-			time.Sleep(backoffTimeout(uint16(attempt)))
-			continue
+	var res *ctypes.ResultCommit
+	err := p.retry(ctx, func() (err error) {
+		res, err = p.client.Commit(ctx, height)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res.SignedHeader, nil
+}
+
+// retry calls f until it succeeds, fails with a permanent error or the
+// maximum number of attempts is reached, in which case it returns
+// provider.ErrNoResponse. It waits before each retry, see RetryBackoff.
+func (p *http) retry(ctx context.Context, f func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		switch {
+		// TODO: standardize errors on the RPC side
+		case regexpMissingHeight.MatchString(err.Error()):
+			return provider.ErrLightBlockNotFound
+		case errors.Is(err, ErrResponseTooLarge):
+			return provider.ErrBadLightBlock{Reason: err}
+		case !isTransient(err):
+			return err
+		case attempt >= p.maxRetryAttempts:
+			return provider.ErrNoResponse
+		}
+
+		timer := time.NewTimer(p.backoffTimeout(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
 		}
-		return &commit.SignedHeader, nil
 	}
-	return nil, provider.ErrNoResponse
+}
+
+// isTransient returns whether the request failing with the given error may
+// succeed if retried. RPC errors returned by the server are permanent, unless
+// the server is overloaded or failed internally.
+func isTransient(err error) bool {
+	var rpcErr *rpctypes.RPCError
+	if errors.As(err, &rpcErr) {
+		switch rpcErr.Code {
+		case -32001, // quota exceeded
+			-32603: // internal error
+			return true
+		default:
+			return false
+		}
+	}
+	// network errors, unexpected responses (e.g. from a proxy), etc.
+	return true
 }
 
 func validateHeight(height int64) (*int64, error) {
@@ -164,8 +272,54 @@ func validateHeight(height int64) (*int64, error) {
 }
 
 // exponential backoff (with jitter)
-// 0.5s -> 2s -> 4.5s -> 8s -> 12.5 with 1s variation
-func backoffTimeout(attempt uint16) time.Duration {
-	// nolint:gosec // G404: Use of weak random number generator
-	return time.Duration(500*attempt*attempt)*time.Millisecond + time.Duration(rand.Intn(1000))*time.Millisecond
+// 0.5s -> 2s -> 4.5s -> 8s -> 12.5 with 1s variation by default
+func (p *http) backoffTimeout(attempt int) time.Duration {
+	timeout := p.retryBaseDelay * time.Duration(attempt*attempt)
+	if p.retryMaxJitter > 0 {
+		// nolint:gosec // G404: Use of weak random number generator
+		timeout += time.Duration(rand.Int63n(int64(p.retryMaxJitter)))
+	}
+	return timeout
+}
+
+// limitedTransport fails reading responses larger than maxSize with
+// ErrResponseTooLarge.
+type limitedTransport struct {
+	gohttp.RoundTripper
+	maxSize int64
+}
+
+func (t *limitedTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.ContentLength > t.maxSize {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %d bytes", ErrResponseTooLarge, resp.ContentLength)
+	}
+	resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: t.maxSize}
+	return resp, nil
+}
+
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// check whether the body has more data
+		var buf [1]byte
+		if n, _ := b.ReadCloser.Read(buf[:]); n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
 }
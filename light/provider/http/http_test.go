@@ -2,9 +2,15 @@ package http_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	gohttp "net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -88,3 +94,83 @@ func TestProvider(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, provider.ErrLightBlockNotFound, err)
 }
+
+func TestProviderRetries(t *testing.T) {
+	var (
+		mtx   sync.Mutex
+		calls int
+		reply func(w gohttp.ResponseWriter)
+	)
+	srv := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		calls++
+		reply(w)
+	}))
+	defer srv.Close()
+
+	setReply := func(f func(w gohttp.ResponseWriter)) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		calls = 0
+		reply = f
+	}
+	getCalls := func() int {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return calls
+	}
+
+	p, err := lighthttp.New("chain-test", srv.URL,
+		lighthttp.MaxRetryAttempts(3),
+		lighthttp.RetryBackoff(time.Millisecond, time.Millisecond),
+		lighthttp.MaxResponseSize(1024))
+	require.NoError(t, err)
+
+	// transient errors are retried
+	setReply(func(w gohttp.ResponseWriter) {
+		w.WriteHeader(gohttp.StatusBadGateway)
+	})
+	_, err = p.LightBlock(context.Background(), 1)
+	assert.Equal(t, provider.ErrNoResponse, err)
+	assert.Equal(t, 3, getCalls())
+
+	// permanent errors are not
+	setReply(func(w gohttp.ResponseWriter) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":-1,"error":{"code":-32601,"message":"Method not found"}}`)
+	})
+	_, err = p.LightBlock(context.Background(), 1)
+	assert.Error(t, err)
+	assert.NotEqual(t, provider.ErrNoResponse, err)
+	assert.Equal(t, 1, getCalls())
+
+	setReply(func(w gohttp.ResponseWriter) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error",`+
+			`"data":"height 10 must be less than or equal to the current blockchain height 5"}}`)
+	})
+	_, err = p.LightBlock(context.Background(), 10)
+	assert.Equal(t, provider.ErrLightBlockNotFound, err)
+	assert.Equal(t, 1, getCalls())
+
+	// responses larger than the limit are rejected
+	setReply(func(w gohttp.ResponseWriter) {
+		fmt.Fprint(w, strings.Repeat(" ", 2048))
+	})
+	_, err = p.LightBlock(context.Background(), 1)
+	var errBad provider.ErrBadLightBlock
+	require.True(t, errors.As(err, &errBad), err)
+	assert.True(t, errors.Is(errBad.Reason, lighthttp.ErrResponseTooLarge))
+	assert.Equal(t, 1, getCalls())
+
+	// retries stop when the context is done
+	setReply(func(w gohttp.ResponseWriter) {
+		w.WriteHeader(gohttp.StatusBadGateway)
+	})
+	p, err = lighthttp.New("chain-test", srv.URL, lighthttp.RetryBackoff(time.Hour, 0))
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = p.LightBlock(ctx, 1)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 1, getCalls())
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
 )
@@ -19,6 +20,13 @@ type Proxy struct {
 	Client   *lrpc.Client
 	Logger   log.Logger
 	Listener net.Listener
+
+	// OnReady, if set, is called once the proxy listens for connections, e.g.
+	// to notify the service manager.
+	OnReady func()
+
+	mtx    tmsync.Mutex
+	server *http.Server
 }
 
 // ListenAndServe configures the rpcserver.WebsocketManager, sets up the RPC
@@ -26,41 +34,56 @@ type Proxy struct {
 // address p.Addr.
 // See http#Server#ListenAndServe.
 func (p *Proxy) ListenAndServe() error {
-	listener, mux, err := p.listen()
+	listener, server, err := p.listen()
 	if err != nil {
 		return err
 	}
-	p.Listener = listener
 
-	return rpcserver.Serve(
-		listener,
-		mux,
-		p.Logger,
-		p.Config,
-	)
+	p.Logger.Info(fmt.Sprintf("Starting RPC HTTP server on %s", listener.Addr()))
+	err = server.Serve(listener)
+	p.Logger.Info("RPC HTTP server stopped", "err", err)
+	return err
 }
 
 // ListenAndServeTLS acts identically to ListenAndServe, except that it expects
 // HTTPS connections.
 // See http#Server#ListenAndServeTLS.
 func (p *Proxy) ListenAndServeTLS(certFile, keyFile string) error {
-	listener, mux, err := p.listen()
+	listener, server, err := p.listen()
 	if err != nil {
 		return err
 	}
-	p.Listener = listener
-
-	return rpcserver.ServeTLS(
-		listener,
-		mux,
-		certFile,
-		keyFile,
-		p.Logger,
-		p.Config,
-	)
+
+	p.Logger.Info(fmt.Sprintf("Starting RPC HTTPS server on %s (cert: %q, key: %q)",
+		listener.Addr(), certFile, keyFile))
+	err = server.ServeTLS(listener, certFile, keyFile)
+	p.Logger.Info("RPC HTTPS server stopped", "err", err)
+	return err
+}
+
+// Shutdown gracefully stops the proxy: it stops listening, waits until ctx is
+// done for the requests in progress to complete, and then stops the client,
+// unsubscribing the websocket connections from the events. ListenAndServe and
+// ListenAndServeTLS return http.ErrServerClosed immediately, so the caller
+// must wait for Shutdown to return before exiting.
+func (p *Proxy) Shutdown(ctx context.Context) error {
+	p.mtx.Lock()
+	server := p.server
+	p.mtx.Unlock()
+	if server == nil {
+		return nil
+	}
+
+	err := server.Shutdown(ctx)
+	if p.Client.IsRunning() {
+		if stopErr := p.Client.Stop(); stopErr != nil && err == nil {
+			err = fmt.Errorf("can't stop client: %w", stopErr)
+		}
+	}
+	return err
 }
 
-func (p *Proxy) listen() (net.Listener, *http.ServeMux, error) {
+func (p *Proxy) listen() (net.Listener, *http.Server, error) {
 	mux := http.NewServeMux()
 
 	// 1) Register regular routes.
@@ -84,15 +107,22 @@ func (p *Proxy) listen() (net.Listener, *http.ServeMux, error) {
 	// 3) Start a client.
 	if !p.Client.IsRunning() {
 		if err := p.Client.Start(); err != nil {
-			return nil, mux, fmt.Errorf("can't start client: %w", err)
+			return nil, nil, fmt.Errorf("can't start client: %w", err)
 		}
 	}
 
 	// 4) Start listening for new connections.
 	listener, err := rpcserver.Listen(p.Addr, p.Config)
 	if err != nil {
-		return nil, mux, err
+		return nil, nil, err
 	}
+	server := rpcserver.NewServer(mux, p.Logger, p.Config)
+	p.mtx.Lock()
+	p.Listener, p.server = listener, server
+	p.mtx.Unlock()
 
-	return listener, mux, nil
+	if p.OnReady != nil {
+		p.OnReady()
+	}
+	return listener, server, nil
 }
@@ -14,6 +14,7 @@ import (
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	service "github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/light"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -43,6 +44,8 @@ type Client struct {
 	// Proof runtime used to verify values returned by ABCIQuery
 	prt       *merkle.ProofRuntime
 	keyPathFn KeyPathFunc
+	// Hasher used to check the hash of the txs returned by Tx
+	txHasher types.TxHasher
 }
 
 var _ rpcclient.Client = (*Client)(nil)
@@ -59,12 +62,22 @@ func KeyPathFn(fn KeyPathFunc) Option {
 	}
 }
 
+// TxHasher option can be used to set the hasher of the txs, which must be the
+// one configured by the genesis of the chain (see types.GenesisDoc.TxHasher).
+// Default: types.DefaultTxHasher.
+func TxHasher(h types.TxHasher) Option {
+	return func(c *Client) {
+		c.txHasher = h
+	}
+}
+
 // NewClient returns a new client.
 func NewClient(next rpcclient.Client, lc LightClient, opts ...Option) *Client {
 	c := &Client{
-		next: next,
-		lc:   lc,
-		prt:  merkle.DefaultProofRuntime(),
+		next:     next,
+		lc:       lc,
+		prt:      merkle.DefaultProofRuntime(),
+		txHasher: types.DefaultTxHasher,
 	}
 	c.BaseService = *service.NewBaseService(nil, "Client", c)
 	for _, o := range opts {
@@ -166,6 +179,45 @@ func (c *Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.Re
 	return c.next.BroadcastTxCommit(ctx, tx)
 }
 
+// BroadcastTxCommitWithProof is like BroadcastTxCommit, but also verifies the
+// inclusion of tx in the committed block against a trusted header. The next
+// client must support it (e.g. the http client).
+func (c *Client) BroadcastTxCommitWithProof(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	next, ok := c.next.(interface {
+		BroadcastTxCommitWithProof(context.Context, types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
+	})
+	if !ok {
+		return nil, errors.New("the next client doesn't support broadcasting txs with their inclusion proof")
+	}
+	res, err := next.BroadcastTxCommitWithProof(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if res.CheckTx.IsErr() {
+		return res, nil // not committed
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, errNegOrZeroHeight
+	}
+	if res.Proof == nil {
+		return nil, errors.New("missing inclusion proof")
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the proof.
+	if err := light.VerifyTxInclusion(l.Header, tx, *res.Proof); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (c *Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return c.next.BroadcastTxAsync(ctx, tx)
 }
@@ -227,7 +279,19 @@ func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.Re
 	return res, nil
 }
 
+// Health checks the light client has a trusted light block, still within its
+// trusting period if the light client exposes it (see
+// light.Client.TrustingPeriod), and then calls rpcclient#Health.
 func (c *Client) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
+	l, err := c.lc.TrustedLightBlock(0)
+	if err != nil {
+		return nil, fmt.Errorf("no trusted light block: %w", err)
+	}
+	if lc, ok := c.lc.(interface{ TrustingPeriod() time.Duration }); ok &&
+		light.HeaderExpired(l.SignedHeader, lc.TrustingPeriod(), time.Now()) {
+		return nil, fmt.Errorf("trusted light block #%d is expired (trusting period: %v)", l.Height, lc.TrustingPeriod())
+	}
+
 	return c.next.Health(ctx)
 }
 
@@ -420,6 +484,17 @@ func (c *Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommi
 	}, nil
 }
 
+// CommitWithSignBytes is like Commit, but the result also includes the
+// canonical bytes signed by each signature, computed from the verified commit.
+func (c *Client) CommitWithSignBytes(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	res, err := c.Commit(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	res.SignBytes = res.Commit.SignBytes(res.ChainID)
+	return res, nil
+}
+
 // Tx calls rpcclient#Tx method and then verifies the proof if such was
 // requested.
 func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
@@ -432,6 +507,9 @@ func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.Resul
 	if res.Height <= 0 {
 		return nil, errNegOrZeroHeight
 	}
+	if txHash := c.txHasher.Hash(res.Tx); !bytes.Equal(txHash, hash) {
+		return nil, fmt.Errorf("tx hash %X doesn't match the requested hash %X", txHash, hash)
+	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, res.Height)
@@ -440,7 +518,10 @@ func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.Resul
 	}
 
 	// Validate the proof.
-	return res, res.Proof.Validate(l.DataHash)
+	if err := light.VerifyTxInclusion(l.Header, res.Tx, res.Proof); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 func (c *Client) TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (
@@ -3,8 +3,10 @@ package rpc
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	ics23 "github.com/confio/ics23/go"
 	"github.com/cosmos/iavl"
@@ -86,6 +88,63 @@ func TestABCIQuery(t *testing.T) {
 	assert.NotNil(t, res)
 }
 
+// expiringLightClient is a light client with a trusting period.
+type expiringLightClient struct {
+	*lcmock.LightClient
+	trustingPeriod time.Duration
+}
+
+func (lc expiringLightClient) TrustingPeriod() time.Duration { return lc.trustingPeriod }
+
+func TestHealth(t *testing.T) {
+	next := &rpcmock.Client{}
+	next.On("Health", context.Background()).Return(&ctypes.ResultHealth{}, nil)
+	block := &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: &types.Header{Height: 2, Time: time.Now().Add(-time.Hour)},
+		},
+	}
+
+	// no trusted light block
+	lc := &lcmock.LightClient{}
+	lc.On("TrustedLightBlock", int64(0)).Return(nil, errors.New("no light blocks"))
+	_, err := NewClient(next, lc).Health(context.Background())
+	assert.Error(t, err)
+
+	lc = &lcmock.LightClient{}
+	lc.On("TrustedLightBlock", int64(0)).Return(block, nil)
+	_, err = NewClient(next, lc).Health(context.Background())
+	assert.NoError(t, err)
+	_, err = NewClient(next, expiringLightClient{lc, 2 * time.Hour}).Health(context.Background())
+	assert.NoError(t, err)
+
+	// the trusted light block is expired
+	_, err = NewClient(next, expiringLightClient{lc, time.Minute}).Health(context.Background())
+	assert.Error(t, err)
+}
+
+func TestTxHash(t *testing.T) {
+	hasher, err := types.NewTxHasher(types.TxHashSHA3256, "")
+	require.NoError(t, err)
+	tx := types.Tx("tx")
+	hash := hasher.Hash(tx)
+
+	next := &rpcmock.Client{}
+	next.On("Tx", context.Background(), hash, true).Return(&ctypes.ResultTx{Height: 1, Tx: tx}, nil)
+	lc := &lcmock.LightClient{}
+	lc.On("VerifyLightBlockAtHeight", context.Background(), int64(1), mock.Anything).
+		Return(nil, errors.New("no light blocks"))
+
+	// the tx is hashed with SHA-256 by default
+	_, err = NewClient(next, lc).Tx(context.Background(), hash, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't match the requested hash")
+
+	_, err = NewClient(next, lc, TxHasher(hasher)).Tx(context.Background(), hash, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to update light client")
+}
+
 type testOp struct {
 	Spec  *ics23.ProofSpec
 	Key   []byte
@@ -0,0 +1,169 @@
+package light
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	lightproto "github.com/tendermint/tendermint/proto/tendermint/light"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ExportTrustedState returns the trusted light block at the given height (0 -
+// the latest), along with the validator set of the next block, as a compact
+// bundle which the light client can be restored from, see
+// ImportTrustedState. The header is signed by +2/3 of its validators, and
+// commits to the next validator set, but the bundle must still be verified
+// from a trust root when imported. The next validator set is taken from the
+// trusted store if it holds the next light block, or from the last imported
+// bundle, and fetched from the primary otherwise: if the next block isn't
+// available yet, an error is returned and a lower height must be exported.
+func (c *Client) ExportTrustedState(ctx context.Context, height int64) ([]byte, error) {
+	l, err := c.TrustedLightBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	nextVals, err := c.nextValidators(ctx, l)
+	if err != nil {
+		return nil, fmt.Errorf("can't get the next validators: %w", err)
+	}
+	if !bytes.Equal(nextVals.Hash(), l.NextValidatorsHash) {
+		return nil, fmt.Errorf("expected next validators hash %X, got %X",
+			l.NextValidatorsHash, nextVals.Hash())
+	}
+
+	lpb, err := l.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	nextValsPb, err := nextVals.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	pb := &lightproto.TrustedState{LightBlock: lpb, NextValidators: nextValsPb}
+	return pb.Marshal()
+}
+
+// nextValidators returns the validator set of the block following the given
+// trusted light block.
+func (c *Client) nextValidators(ctx context.Context, l *types.LightBlock) (*types.ValidatorSet, error) {
+	if next, err := c.trustedStore.LightBlock(l.Height + 1); err == nil {
+		return next.ValidatorSet, nil
+	}
+	if c.importedNextVals != nil && bytes.Equal(c.importedNextVals.Hash(), l.NextValidatorsHash) {
+		return c.importedNextVals, nil
+	}
+	next, err := c.lightBlockFromPrimary(ctx, l.Height+1)
+	if err != nil {
+		return nil, err
+	}
+	return next.ValidatorSet, nil
+}
+
+// ImportTrustedState verifies the bundle exported by ExportTrustedState and
+// saves its light block to the trusted store, as a new trust root: the light
+// blocks are then verified from it, without the primary and the witnesses
+// being asked for the initial one. The light block is trusted if its hash is
+// trustedHash, like TrustOptions.Hash, or if it's already in the trusted
+// store. Otherwise, trustedHash may be nil, and the light block is verified
+// from the latest trusted light block with skipping verification, which
+// requires it to be higher and within the trusting period. The next
+// validators of the bundle are kept, so that the bundle can be exported again
+// without the primary.
+//
+// It returns the imported light block, or an error if the bundle is invalid,
+// belongs to another chain, has expired according to the trusting period,
+// can't be verified, or conflicts with the trusted light block stored at the
+// same height (see Cleanup).
+func (c *Client) ImportTrustedState(bundle []byte, trustedHash []byte, now time.Time) (*types.LightBlock, error) {
+	l, nextVals, err := decodeTrustedState(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted state: %w", err)
+	}
+
+	if err := l.ValidateBasic(c.chainID); err != nil {
+		return nil, fmt.Errorf("invalid trusted state: %w", err)
+	}
+	if err := nextVals.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid next validators: %w", err)
+	}
+	if !bytes.Equal(nextVals.Hash(), l.NextValidatorsHash) {
+		return nil, fmt.Errorf("expected next validators hash %X, got %X", l.NextValidatorsHash, nextVals.Hash())
+	}
+	if err := l.ValidatorSet.VerifyCommitLight(c.chainID, l.Commit.BlockID, l.Height, l.Commit); err != nil {
+		return nil, fmt.Errorf("invalid commit: %w", err)
+	}
+	if HeaderExpired(l.SignedHeader, c.trustingPeriod, now) {
+		return nil, ErrOldHeaderExpired{l.Time.Add(c.trustingPeriod), now}
+	}
+	if !l.Time.Before(now.Add(c.maxClockDrift)) {
+		return nil, fmt.Errorf("trusted header has a time from the future %v (now: %v; max clock drift: %v)",
+			l.Time, now, c.maxClockDrift)
+	}
+
+	if err := c.verifyTrustedState(l, trustedHash, now); err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("Importing trusted state", "height", l.Height, "hash", hash2str(l.Hash()))
+	if err := c.updateTrustedLightBlock(l); err != nil {
+		return nil, err
+	}
+	c.importedNextVals = nextVals
+	return l, nil
+}
+
+// verifyTrustedState checks that the imported light block can be trusted, see
+// ImportTrustedState.
+func (c *Client) verifyTrustedState(l *types.LightBlock, trustedHash []byte, now time.Time) error {
+	if len(trustedHash) > 0 && !bytes.Equal(trustedHash, l.Hash()) {
+		return fmt.Errorf("expected trusted state hash %X, got %X", trustedHash, l.Hash())
+	}
+
+	stored, err := c.trustedStore.LightBlock(l.Height)
+	switch {
+	case err == nil:
+		if !bytes.Equal(stored.Hash(), l.Hash()) {
+			return fmt.Errorf("trusted state hash %X conflicts with the stored light block %X at height %d",
+				l.Hash(), stored.Hash(), l.Height)
+		}
+		return nil
+
+	case len(trustedHash) > 0:
+		return nil
+
+	case c.latestTrustedBlock != nil && c.latestTrustedBlock.Height < l.Height:
+		err := Verify(c.latestTrustedBlock.SignedHeader, c.latestTrustedBlock.ValidatorSet,
+			l.SignedHeader, l.ValidatorSet, c.trustingPeriod, now, c.maxClockDrift, c.trustLevel)
+		if err != nil {
+			return fmt.Errorf("can't verify the trusted state from the trusted light block at height %d: %w",
+				c.latestTrustedBlock.Height, err)
+		}
+		return nil
+
+	default:
+		return errors.New("the trusted state can't be verified from the trusted light blocks, its hash is required")
+	}
+}
+
+func decodeTrustedState(bundle []byte) (*types.LightBlock, *types.ValidatorSet, error) {
+	pb := new(lightproto.TrustedState)
+	if err := pb.Unmarshal(bundle); err != nil {
+		return nil, nil, err
+	}
+	if pb.LightBlock == nil || pb.NextValidators == nil {
+		return nil, nil, errors.New("missing light block or next validators")
+	}
+	l, err := types.LightBlockFromProto(pb.LightBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+	nextVals, err := types.ValidatorSetFromProto(pb.NextValidators)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l, nextVals, nil
+}
@@ -0,0 +1,139 @@
+package light_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	dbs "github.com/tendermint/tendermint/light/store/db"
+	lightproto "github.com/tendermint/tendermint/proto/tendermint/light"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestClientExportImportTrustedState(t *testing.T) {
+	now := bTime.Add(2 * time.Hour)
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		fullNode,
+		[]provider.Provider{fullNode},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+	_, err = c.VerifyLightBlockAtHeight(ctx, 2, now)
+	require.NoError(t, err)
+
+	// the next validators of the latest trusted block are fetched from the
+	// primary, but it doesn't have the next block of the latest block yet
+	bundle, err := c.ExportTrustedState(ctx, 0)
+	require.NoError(t, err)
+	_, err = c.VerifyLightBlockAtHeight(ctx, 3, now)
+	require.NoError(t, err)
+	_, err = c.ExportTrustedState(ctx, 0)
+	assert.Error(t, err)
+
+	restored, err := light.NewClientFromTrustedStore(
+		chainID,
+		trustPeriod,
+		fullNode,
+		[]provider.Provider{fullNode},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	// corrupted, expired, from another chain, or not trusted
+	_, err = restored.ImportTrustedState(bundle[:len(bundle)-1], l2.Hash(), now)
+	assert.Error(t, err)
+	_, err = restored.ImportTrustedState(bundle, l2.Hash(), bTime.Add(trustPeriod+time.Hour))
+	assert.IsType(t, light.ErrOldHeaderExpired{}, err)
+	other, err := light.NewClientFromTrustedStore("other", trustPeriod, fullNode,
+		[]provider.Provider{fullNode}, dbs.New(dbm.NewMemDB(), "other"))
+	require.NoError(t, err)
+	_, err = other.ImportTrustedState(bundle, l2.Hash(), now)
+	assert.Error(t, err)
+	_, err = restored.ImportTrustedState(bundle, nil, now)
+	assert.Error(t, err)
+	_, err = restored.ImportTrustedState(bundle, l1.Hash(), now)
+	assert.Error(t, err)
+
+	l, err := restored.ImportTrustedState(bundle, l2.Hash(), now)
+	require.NoError(t, err)
+	assert.Equal(t, l2.Hash(), l.Hash())
+	height, err := restored.LastTrustedHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, height)
+
+	// the light blocks are verified from the imported one
+	l, err = restored.VerifyLightBlockAtHeight(ctx, 3, now)
+	require.NoError(t, err)
+	assert.Equal(t, h3.Hash(), l.Hash())
+
+	// the next validators of a trusted block are taken from the store, and
+	// the light blocks already trusted don't need their hash
+	bundle, err = restored.ExportTrustedState(ctx, 2)
+	require.NoError(t, err)
+	_, err = c.ImportTrustedState(bundle, nil, now)
+	assert.NoError(t, err)
+
+	// the next validators of the imported bundle are kept
+	offline, err := light.NewClientFromTrustedStore(chainID, trustPeriod, deadNode,
+		[]provider.Provider{deadNode}, dbs.New(dbm.NewMemDB(), chainID))
+	require.NoError(t, err)
+	_, err = offline.ImportTrustedState(bundle, l2.Hash(), now)
+	require.NoError(t, err)
+	_, err = offline.ExportTrustedState(ctx, 2)
+	assert.NoError(t, err)
+}
+
+func TestClientImportTrustedStateVerifiesFromTrustRoot(t *testing.T) {
+	now := bTime.Add(2 * time.Hour)
+	newClient := func() *light.Client {
+		c, err := light.NewClient(ctx, chainID, trustOptions, fullNode, []provider.Provider{fullNode},
+			dbs.New(dbm.NewMemDB(), chainID), light.Logger(log.TestingLogger()))
+		require.NoError(t, err)
+		return c
+	}
+	exporter := newClient()
+	_, err := exporter.VerifyLightBlockAtHeight(ctx, 2, now)
+	require.NoError(t, err)
+	_, err = exporter.VerifyLightBlockAtHeight(ctx, 3, now)
+	require.NoError(t, err)
+	bundle, err := exporter.ExportTrustedState(ctx, 2)
+	require.NoError(t, err)
+
+	// verified with skipping verification from the trusted light block at 1
+	c := newClient()
+	l, err := c.ImportTrustedState(bundle, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, l2.Hash(), l.Hash())
+
+	// a chain signed by other validators is consistent, but not trusted
+	forgedKeys := genPrivKeys(4)
+	forgedVals := forgedKeys.ToValidators(20, 10)
+	forged := &types.LightBlock{
+		SignedHeader: forgedKeys.GenSignedHeader(chainID, 3, bTime.Add(time.Hour), nil, forgedVals, forgedVals,
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(forgedKeys)),
+		ValidatorSet: forgedVals,
+	}
+	lpb, err := forged.ToProto()
+	require.NoError(t, err)
+	nextVals, err := forgedVals.ToProto()
+	require.NoError(t, err)
+	forgedBundle, err := (&lightproto.TrustedState{LightBlock: lpb, NextValidators: nextVals}).Marshal()
+	require.NoError(t, err)
+	_, err = c.ImportTrustedState(forgedBundle, nil, now)
+	assert.Error(t, err)
+	height, err := c.LastTrustedHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, height)
+}
@@ -275,3 +275,17 @@ func checkRequiredHeaderFields(h *types.SignedHeader) {
 		panic("chain ID in trusted header must be set")
 	}
 }
+
+// VerifyTxInclusion verifies that tx is included in the block of the trusted
+// header, using its Merkle inclusion proof (see the prove parameter of the /tx
+// and /broadcast_tx_commit RPC endpoints): the proof must be of tx, and lead
+// to the DataHash of the header.
+func VerifyTxInclusion(trustedHeader *types.Header, tx types.Tx, proof types.TxProof) error {
+	if !bytes.Equal(proof.Data, tx) {
+		return errors.New("inclusion proof is of a different tx")
+	}
+	if err := proof.Validate(trustedHeader.DataHash); err != nil {
+		return fmt.Errorf("invalid inclusion proof: %w", err)
+	}
+	return nil
+}
@@ -333,3 +333,18 @@ func TestValidateTrustLevel(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifyTxInclusion(t *testing.T) {
+	txs := types.Txs{types.Tx("tx0"), types.Tx("tx1"), types.Tx("tx2")}
+	header := &types.Header{DataHash: txs.Hash()}
+	proof := txs.Proof(1)
+
+	assert.NoError(t, light.VerifyTxInclusion(header, txs[1], proof))
+	// the proof is of another tx
+	assert.Error(t, light.VerifyTxInclusion(header, txs[0], proof))
+	// the proof leads to another data hash
+	assert.Error(t, light.VerifyTxInclusion(&types.Header{DataHash: types.Txs{txs[1]}.Hash()}, txs[1], proof))
+	// the proof is tampered with
+	proof.Proof.Index = 0
+	assert.Error(t, light.VerifyTxInclusion(header, txs[1], proof))
+}
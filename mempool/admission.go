@@ -0,0 +1,69 @@
+package mempool
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+// AdmissionPolicy decides which txs are admitted into the mempool, on top of
+// the app's CheckTx, e.g. to apply node-local rules without forking the
+// mempool. Unlike the PreCheckFunc and PostCheckFunc given to Update, which
+// are derived from the state, the policy is kept across blocks, and is given
+// the sender of the tx.
+//
+// The txs submitted over RPC have an empty TxInfo.SenderP2PID. The policy
+// isn't applied when the txs are rechecked.
+type AdmissionPolicy interface {
+	// PreCheck is called before the tx is sent to the app. If it returns an
+	// error, the tx is rejected with an ErrPreCheck.
+	PreCheck(tx types.Tx, txInfo TxInfo) error
+	// PostCheck is called once the app accepted the tx. If it returns an
+	// error, the tx is rejected as a bad transaction.
+	PostCheck(tx types.Tx, txInfo TxInfo, res *abci.ResponseCheckTx) error
+}
+
+// AdmissionPolicyFuncs is an AdmissionPolicy calling the given functions, which
+// may be nil.
+type AdmissionPolicyFuncs struct {
+	PreCheckFunc  func(tx types.Tx, txInfo TxInfo) error
+	PostCheckFunc func(tx types.Tx, txInfo TxInfo, res *abci.ResponseCheckTx) error
+}
+
+var _ AdmissionPolicy = AdmissionPolicyFuncs{}
+
+// PreCheck implements AdmissionPolicy.
+func (p AdmissionPolicyFuncs) PreCheck(tx types.Tx, txInfo TxInfo) error {
+	if p.PreCheckFunc == nil {
+		return nil
+	}
+	return p.PreCheckFunc(tx, txInfo)
+}
+
+// PostCheck implements AdmissionPolicy.
+func (p AdmissionPolicyFuncs) PostCheck(tx types.Tx, txInfo TxInfo, res *abci.ResponseCheckTx) error {
+	if p.PostCheckFunc == nil {
+		return nil
+	}
+	return p.PostCheckFunc(tx, txInfo, res)
+}
+
+// PeerMaxTxBytesPolicy returns an AdmissionPolicy rejecting the txs larger than
+// maxBytes received from the peers, except from those in allowlist. The txs
+// submitted over RPC aren't limited.
+func PeerMaxTxBytesPolicy(maxBytes int, allowlist []p2p.ID) AdmissionPolicy {
+	allowed := make(map[p2p.ID]bool, len(allowlist))
+	for _, id := range allowlist {
+		allowed[id] = true
+	}
+	return AdmissionPolicyFuncs{
+		PreCheckFunc: func(tx types.Tx, txInfo TxInfo) error {
+			if txInfo.SenderP2PID == "" || allowed[txInfo.SenderP2PID] || len(tx) <= maxBytes {
+				return nil
+			}
+			return fmt.Errorf("tx size %d from peer %v is greater than max %d", len(tx), txInfo.SenderP2PID, maxBytes)
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package mempool
+
+import (
+	"sort"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// categoryCounters count the txs in the mempool and their total size, by
+// category (as tagged by the app in ResponseCheckTx.Category). The
+// uncategorized txs aren't counted.
+type categoryCounters struct {
+	mtx    tmsync.Mutex
+	counts map[string]*types.MempoolCategory
+}
+
+func newCategoryCounters() *categoryCounters {
+	return &categoryCounters{counts: make(map[string]*types.MempoolCategory)}
+}
+
+// update adds delta txs of the given total size to the category, and returns
+// its new counts. A category is forgotten once it has no tx left.
+func (c *categoryCounters) update(category string, delta int, bytes int64) types.MempoolCategory {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	count, ok := c.counts[category]
+	if !ok {
+		count = &types.MempoolCategory{Category: category}
+		c.counts[category] = count
+	}
+	count.Txs += delta
+	count.Bytes += bytes
+	if count.Txs <= 0 {
+		delete(c.counts, category)
+		return types.MempoolCategory{Category: category}
+	}
+	return *count
+}
+
+// reset forgets all the categories, returning them.
+func (c *categoryCounters) reset() []string {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	categories := make([]string, 0, len(c.counts))
+	for category := range c.counts {
+		categories = append(categories, category)
+	}
+	c.counts = make(map[string]*types.MempoolCategory)
+	return categories
+}
+
+// list returns the counts of the categories, sorted by category.
+func (c *categoryCounters) list() []types.MempoolCategory {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	list := make([]types.MempoolCategory, 0, len(c.counts))
+	for _, count := range c.counts {
+		list = append(list, *count)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Category < list[j].Category })
+	return list
+}
+
+// updateCategory accounts for delta txs of the given total size added to (or
+// removed from) the mempool, if they're categorized.
+func (mem *CListMempool) updateCategory(category string, delta int, bytes int64) {
+	if category == "" {
+		return
+	}
+	count := mem.categories.update(category, delta, bytes)
+	if count.Txs == 0 {
+		mem.metrics.forgetCategory(category)
+		return
+	}
+	mem.metrics.CategoryTxs.With("category", category).Set(float64(count.Txs))
+	mem.metrics.CategoryBytes.With("category", category).Set(float64(count.Bytes))
+}
+
+// publishCategories publishes the counts of the categories left in the
+// mempool after the block at the given height.
+func (mem *CListMempool) publishCategories(height int64) {
+	err := mem.eventBus.PublishEventMempoolCategories(types.EventDataMempoolCategories{
+		Height:     height,
+		Categories: mem.categories.list(),
+	})
+	if err != nil {
+		mem.logger.Error("Failed to publish the mempool categories", "height", height, "err", err)
+	}
+}
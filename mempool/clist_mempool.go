@@ -8,11 +8,13 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
 	auto "github.com/tendermint/tendermint/libs/autofile"
 	"github.com/tendermint/tendermint/libs/clist"
+	"github.com/tendermint/tendermint/libs/clock"
 	"github.com/tendermint/tendermint/libs/log"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmos "github.com/tendermint/tendermint/libs/os"
@@ -39,6 +41,12 @@ type CListMempool struct {
 	height   int64 // the last block Update()'d to
 	txsBytes int64 // total size of mempool, in bytes
 
+	// Eviction counters, see Evictions.
+	evictedFull        int64
+	evictedInvalidated int64
+	evictedRemoved     int64
+	evictedFlushed     int64
+
 	// notify listeners (ie. consensus) when txs are available
 	notifiedTxsAvailable bool
 	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
@@ -51,6 +59,9 @@ type CListMempool struct {
 	preCheck  PreCheckFunc
 	postCheck PostCheckFunc
 
+	// Optional policy set by the embedder, kept across Updates.
+	admissionPolicy AdmissionPolicy
+
 	wal          *auto.AutoFile // a log of mempool txs
 	txs          *clist.CList   // concurrent linked-list of good txs
 	proxyAppConn proxy.AppConnMempool
@@ -69,6 +80,21 @@ type CListMempool struct {
 	// This reduces the pressure on the proxyApp.
 	cache txCache
 
+	// Minimum gas price for txs to be admitted.
+	gasPrice *gasPriceFloor
+
+	// Hash identifying txs in logs. The txsMap and cache use TxKey.
+	txHasher types.TxHasher
+
+	// Clock timestamping the txs, from which their ages are measured.
+	clock clock.Clock
+
+	// Txs in the mempool by category, and maximum total size of the txs of a
+	// category reaped into a block.
+	categories     *categoryCounters
+	categoryQuotas map[string]int64
+	eventBus       types.MempoolEventPublisher
+
 	logger log.Logger
 
 	metrics *Metrics
@@ -93,9 +119,16 @@ func NewCListMempool(
 		height:        height,
 		recheckCursor: nil,
 		recheckEnd:    nil,
+		gasPrice:      newGasPriceFloor(config.MinGasPrice, config.TargetBlockGas),
+		txHasher:      types.DefaultTxHasher,
+		clock:         clock.New(),
+		categories:    newCategoryCounters(),
+		eventBus:      types.NopEventBus{},
 		logger:        log.NewNopLogger(),
 		metrics:       NopMetrics(),
 	}
+	// NOTE: the quotas are validated by config.ValidateBasic.
+	mempool.categoryQuotas, _ = config.CategoryMaxBytes()
 	if config.CacheSize > 0 {
 		mempool.cache = newMapTxCache(config.CacheSize)
 	} else {
@@ -105,6 +138,7 @@ func NewCListMempool(
 	for _, option := range options {
 		option(mempool)
 	}
+	mempool.metrics.MinGasPrice.Set(float64(mempool.gasPrice.Price()))
 	return mempool
 }
 
@@ -132,11 +166,41 @@ func WithPostCheck(f PostCheckFunc) CListMempoolOption {
 	return func(mem *CListMempool) { mem.postCheck = f }
 }
 
+// WithAdmissionPolicy sets the policy deciding which txs are admitted, on top
+// of CheckTx, see AdmissionPolicy. Unlike WithPreCheck and WithPostCheck, it
+// isn't overwritten by Update.
+func WithAdmissionPolicy(policy AdmissionPolicy) CListMempoolOption {
+	return func(mem *CListMempool) { mem.admissionPolicy = policy }
+}
+
+// SetAdmissionPolicy sets the admission policy, see WithAdmissionPolicy.
+// NOTE: not thread safe - should only be called once, on startup
+func (mem *CListMempool) SetAdmissionPolicy(policy AdmissionPolicy) {
+	mem.admissionPolicy = policy
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
 }
 
+// WithTxHasher sets the TxHasher computing the tx hashes logged by the
+// mempool. Defaults to types.DefaultTxHasher.
+func WithTxHasher(hasher types.TxHasher) CListMempoolOption {
+	return func(mem *CListMempool) { mem.txHasher = hasher }
+}
+
+// WithClock sets the clock timestamping the txs, e.g. a clock.Mock in tests.
+func WithClock(c clock.Clock) CListMempoolOption {
+	return func(mem *CListMempool) { mem.clock = c }
+}
+
+// WithEventBus sets the event bus the MempoolCategories events are published
+// to, after each Update.
+func WithEventBus(eventBus types.MempoolEventPublisher) CListMempoolOption {
+	return func(mem *CListMempool) { mem.eventBus = eventBus }
+}
+
 func (mem *CListMempool) InitWAL() error {
 	var (
 		walDir  = mem.config.WalDir()
@@ -184,6 +248,49 @@ func (mem *CListMempool) TxsBytes() int64 {
 	return atomic.LoadInt64(&mem.txsBytes)
 }
 
+// MinGasPrice returns the minimum gas price a tx must pay to be admitted.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) MinGasPrice() int64 {
+	return mem.gasPrice.Price()
+}
+
+// Stats returns a summary of the txs in the mempool, and of the txs evicted
+// from it.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) Stats() Stats {
+	stats := Stats{
+		MaxSize:     mem.config.Size,
+		MaxTxsBytes: mem.config.MaxTxsBytes,
+		TxSizes:     newHistogram(statsTxSizeBounds),
+		GasWanted:   newHistogram(statsGasWantedBounds),
+		TxAges:      newHistogram(statsTxAgeBounds),
+		Categories:  mem.categories.list(),
+		Evictions: Evictions{
+			Full:        atomic.LoadInt64(&mem.evictedFull),
+			Invalidated: atomic.LoadInt64(&mem.evictedInvalidated),
+			Removed:     atomic.LoadInt64(&mem.evictedRemoved),
+			Flushed:     atomic.LoadInt64(&mem.evictedFlushed),
+		},
+	}
+	now := mem.clock.Now()
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		age := now.Sub(memTx.timestamp)
+		stats.Size++
+		stats.TxsBytes += int64(len(memTx.tx))
+		stats.TotalGasWanted += memTx.gasWanted
+		stats.TxSizes.observe(int64(len(memTx.tx)))
+		stats.GasWanted.observe(memTx.gasWanted)
+		stats.TxAges.observe(int64(age / time.Second))
+		if age > stats.OldestTxAge {
+			stats.OldestTxAge = age
+		}
+	}
+	return stats
+}
+
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) FlushAppConn() error {
 	return mem.proxyAppConn.FlushSync(context.Background())
@@ -195,12 +302,16 @@ func (mem *CListMempool) Flush() {
 	defer mem.updateMtx.RUnlock()
 
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
+	atomic.AddInt64(&mem.evictedFlushed, int64(mem.txs.Len()))
 	mem.cache.Reset()
 
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		mem.txs.Remove(e)
 		e.DetachPrev()
 	}
+	for _, category := range mem.categories.reset() {
+		mem.metrics.forgetCategory(category)
+	}
 
 	mem.txsMap.Range(func(key, _ interface{}) bool {
 		mem.txsMap.Delete(key)
@@ -240,6 +351,7 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 	txSize := len(tx)
 
 	if err := mem.isFull(txSize); err != nil {
+		atomic.AddInt64(&mem.evictedFull, 1)
 		return err
 	}
 
@@ -252,6 +364,11 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 			return ErrPreCheck{err}
 		}
 	}
+	if mem.admissionPolicy != nil {
+		if err := mem.admissionPolicy.PreCheck(tx, txInfo); err != nil {
+			return ErrPreCheck{err}
+		}
+	}
 
 	// NOTE: writing to the WAL and calling proxy must be done before adding tx
 	// to the cache. otherwise, if either of them fails, next time CheckTx is
@@ -291,12 +408,21 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 		ctx = txInfo.Context
 	}
 
-	reqRes, err := mem.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{Tx: tx})
+	origin := abci.TxOrigin{Source: abci.TxSource_Local, ReceivedAt: txInfo.ReceivedAt}
+	if txInfo.SenderID != UnknownPeerID {
+		origin.Source = abci.TxSource_Peer
+		origin.PeerId = string(txInfo.SenderP2PID)
+	}
+	if origin.ReceivedAt.IsZero() {
+		origin.ReceivedAt = mem.clock.Now()
+	}
+
+	reqRes, err := mem.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{Tx: tx, Origin: origin})
 	if err != nil {
 		mem.cache.Remove(tx)
 		return err
 	}
-	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderP2PID, cb))
+	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderP2PID, origin, cb))
 
 	return nil
 }
@@ -335,6 +461,7 @@ func (mem *CListMempool) reqResCb(
 	tx []byte,
 	peerID uint16,
 	peerP2PID p2p.ID,
+	origin abci.TxOrigin,
 	externalCb func(*abci.Response),
 ) func(res *abci.Response) {
 	return func(res *abci.Response) {
@@ -343,7 +470,7 @@ func (mem *CListMempool) reqResCb(
 			panic("recheck cursor is not nil in reqResCb")
 		}
 
-		mem.resCbFirstTime(tx, peerID, peerP2PID, res)
+		mem.resCbFirstTime(tx, peerID, peerP2PID, origin, res)
 
 		// update metrics
 		mem.metrics.Size.Set(float64(mem.Size()))
@@ -361,6 +488,7 @@ func (mem *CListMempool) addTx(memTx *mempoolTx) {
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(TxKey(memTx.tx), e)
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
+	mem.updateCategory(memTx.category, 1, int64(len(memTx.tx)))
 	mem.metrics.TxSizeBytes.Observe(float64(len(memTx.tx)))
 }
 
@@ -372,6 +500,7 @@ func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromC
 	elem.DetachPrev()
 	mem.txsMap.Delete(TxKey(tx))
 	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
+	mem.updateCategory(elem.Value.(*mempoolTx).category, -1, int64(-len(tx)))
 
 	if removeFromCache {
 		mem.cache.Remove(tx)
@@ -384,6 +513,7 @@ func (mem *CListMempool) RemoveTxByKey(txKey [TxKeySize]byte, removeFromCache bo
 		memTx := e.(*clist.CElement).Value.(*mempoolTx)
 		if memTx != nil {
 			mem.removeTx(memTx.tx, e.(*clist.CElement), removeFromCache)
+			atomic.AddInt64(&mem.evictedRemoved, 1)
 		}
 	}
 }
@@ -412,6 +542,7 @@ func (mem *CListMempool) resCbFirstTime(
 	tx []byte,
 	peerID uint16,
 	peerP2PID p2p.ID,
+	origin abci.TxOrigin,
 	res *abci.Response,
 ) {
 	switch r := res.Value.(type) {
@@ -420,6 +551,13 @@ func (mem *CListMempool) resCbFirstTime(
 		if mem.postCheck != nil {
 			postCheckErr = mem.postCheck(tx, r.CheckTx)
 		}
+		if postCheckErr == nil {
+			postCheckErr = mem.checkGasPrice(r.CheckTx)
+		}
+		if r.CheckTx.Code == abci.CodeTypeOK && postCheckErr == nil && mem.admissionPolicy != nil {
+			txInfo := TxInfo{SenderID: peerID, SenderP2PID: peerP2PID}
+			postCheckErr = mem.admissionPolicy.PostCheck(tx, txInfo, r.CheckTx)
+		}
 		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
 			// Check mempool isn't full again to reduce the chance of exceeding the
 			// limits.
@@ -427,18 +565,25 @@ func (mem *CListMempool) resCbFirstTime(
 				// remove from cache (mempool might have a space later)
 				mem.cache.Remove(tx)
 				mem.logger.Error(err.Error())
+				atomic.AddInt64(&mem.evictedFull, 1)
 				return
 			}
 
 			memTx := &mempoolTx{
 				height:    mem.height,
 				gasWanted: r.CheckTx.GasWanted,
+				gasPrice:  r.CheckTx.GasPrice,
+				sender:    r.CheckTx.Sender,
+				nonce:     r.CheckTx.Nonce,
+				category:  r.CheckTx.Category,
 				tx:        tx,
+				timestamp: mem.clock.Now(),
+				origin:    origin,
 			}
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
 			mem.logger.Info("Added good transaction",
-				"tx", txID(tx),
+				"tx", mem.txID(tx),
 				"res", r,
 				"height", memTx.height,
 				"total", mem.Size(),
@@ -447,7 +592,7 @@ func (mem *CListMempool) resCbFirstTime(
 		} else {
 			// ignore bad transaction
 			mem.logger.Info("Rejected bad transaction",
-				"tx", txID(tx), "peerID", peerP2PID, "res", r, "err", postCheckErr)
+				"tx", mem.txID(tx), "peerID", peerP2PID, "res", r, "err", postCheckErr)
 			mem.metrics.FailedTxs.Add(1)
 			// remove from cache (it might be good later)
 			mem.cache.Remove(tx)
@@ -457,6 +602,15 @@ func (mem *CListMempool) resCbFirstTime(
 	}
 }
 
+// checkGasPrice returns an error if the tx pays less than the minimum gas
+// price.
+func (mem *CListMempool) checkGasPrice(res *abci.ResponseCheckTx) error {
+	if minGasPrice := mem.gasPrice.Price(); res.GasPrice < minGasPrice {
+		return ErrGasPriceTooLow{Min: minGasPrice, Actual: res.GasPrice}
+	}
+	return nil
+}
+
 // callback, which is called after the app rechecked the tx.
 //
 // The case where the app checks the tx for the first time is handled by the
@@ -480,9 +634,10 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 			// Good, nothing to do.
 		} else {
 			// Tx became invalidated due to newly committed block.
-			mem.logger.Info("Tx is no longer valid", "tx", txID(tx), "res", r, "err", postCheckErr)
+			mem.logger.Info("Tx is no longer valid", "tx", mem.txID(tx), "res", r, "err", postCheckErr)
 			// NOTE: we remove tx from the cache because it might be good later
 			mem.removeTx(tx, mem.recheckCursor, true)
+			atomic.AddInt64(&mem.evictedInvalidated, 1)
 		}
 		if mem.recheckCursor == mem.recheckEnd {
 			mem.recheckCursor = nil
@@ -528,13 +683,29 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	defer mem.updateMtx.RUnlock()
 
 	var totalGas int64
+	categoryBytes := make(map[string]int64)
+	// senders with a tx skipped for quota, whose later txs would leave a nonce
+	// gap if reaped, see cfg.MempoolOrderSenderNonce
+	skippedSenders := make(map[string]struct{})
+	bySenderNonce := mem.config.OrderBy == cfg.MempoolOrderSenderNonce
 
 	// TODO: we will get a performance boost if we have a good estimate of avg
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, tmmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
 	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
+	for _, memTx := range mem.orderedTxs() {
+		if _, ok := skippedSenders[memTx.sender]; ok {
+			continue
+		}
+		// Skip the txs over the quota of their category, leaving room for
+		// the txs of the other categories.
+		newCategoryBytes := categoryBytes[memTx.category] + int64(len(memTx.tx))
+		if maxBytes, ok := mem.categoryQuotas[memTx.category]; ok && newCategoryBytes > maxBytes {
+			if bySenderNonce && memTx.sender != "" {
+				skippedSenders[memTx.sender] = struct{}{}
+			}
+			continue
+		}
 
 		dataSize := types.ComputeProtoSizeForTxs(append(txs, memTx.tx))
 
@@ -551,6 +722,7 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 			return txs
 		}
 		totalGas = newTotalGas
+		categoryBytes[memTx.category] = newCategoryBytes
 		txs = append(txs, memTx.tx)
 	}
 	return txs
@@ -566,13 +738,27 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 	}
 
 	txs := make([]types.Tx, 0, tmmath.MinInt(mem.txs.Len(), max))
-	for e := mem.txs.Front(); e != nil && len(txs) <= max; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
+	for _, memTx := range mem.orderedTxs() {
+		if len(txs) > max {
+			break
+		}
 		txs = append(txs, memTx.tx)
 	}
 	return txs
 }
 
+// orderedTxs returns the txs in the mempool in the order they should be
+// reaped, as configured by OrderBy.
+// updateMtx must be held by the caller.
+func (mem *CListMempool) orderedTxs() []*mempoolTx {
+	memTxs := make([]*mempoolTx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTxs = append(memTxs, e.Value.(*mempoolTx))
+	}
+	orderTxs(mem.config.OrderBy, memTxs)
+	return memTxs
+}
+
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) Update(
 	height int64,
@@ -592,6 +778,13 @@ func (mem *CListMempool) Update(
 		mem.postCheck = postCheck
 	}
 
+	var blockGasUsed int64
+	for _, res := range deliverTxResponses {
+		blockGasUsed += res.GasUsed
+	}
+	minGasPrice := mem.gasPrice.Update(blockGasUsed)
+	mem.metrics.MinGasPrice.Set(float64(minGasPrice))
+
 	for i, tx := range txs {
 		if deliverTxResponses[i].Code == abci.CodeTypeOK {
 			// Add valid committed tx to the cache (if missing).
@@ -632,6 +825,7 @@ func (mem *CListMempool) Update(
 
 	// Update metrics
 	mem.metrics.Size.Set(float64(mem.Size()))
+	mem.publishCategories(height)
 
 	return nil
 }
@@ -651,8 +845,9 @@ func (mem *CListMempool) recheckTxs() {
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		memTx := e.Value.(*mempoolTx)
 		_, err := mem.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{
-			Tx:   memTx.tx,
-			Type: abci.CheckTxType_Recheck,
+			Tx:     memTx.tx,
+			Type:   abci.CheckTxType_Recheck,
+			Origin: memTx.origin,
 		})
 		if err != nil {
 			// No need in retrying since memTx will be rechecked after next block.
@@ -670,9 +865,15 @@ func (mem *CListMempool) recheckTxs() {
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height    int64         // height that this tx had been validated in
+	gasWanted int64         // amount of gas this tx states it will require
+	gasPrice  int64         // price per unit of gas this tx pays
+	sender    string        // sender of this tx, as reported by the app
+	nonce     uint64        // nonce of this tx for its sender
+	category  string        // category of this tx, as reported by the app
+	tx        types.Tx      //
+	timestamp time.Time     // time this tx was added to the mempool
+	origin    abci.TxOrigin // origin of this tx, given to the rechecks
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
@@ -775,6 +976,6 @@ func TxKey(tx types.Tx) [TxKeySize]byte {
 }
 
 // txID is the hex encoded hash of the bytes as a types.Tx.
-func txID(tx []byte) string {
-	return fmt.Sprintf("%X", types.Tx(tx).Hash())
+func (mem *CListMempool) txID(tx []byte) string {
+	return fmt.Sprintf("%X", mem.txHasher.Hash(tx))
 }
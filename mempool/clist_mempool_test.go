@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	mrand "math/rand"
@@ -23,9 +24,11 @@ import (
 	abciserver "github.com/tendermint/tendermint/abci/server"
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/clock"
 	"github.com/tendermint/tendermint/libs/log"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
 )
@@ -38,14 +41,15 @@ func newMempoolWithApp(cc proxy.ClientCreator) (*CListMempool, cleanupFunc) {
 	return newMempoolWithAppAndConfig(cc, cfg.ResetTestRoot("mempool_test"))
 }
 
-func newMempoolWithAppAndConfig(cc proxy.ClientCreator, config *cfg.Config) (*CListMempool, cleanupFunc) {
+func newMempoolWithAppAndConfig(cc proxy.ClientCreator, config *cfg.Config,
+	options ...CListMempoolOption) (*CListMempool, cleanupFunc) {
 	appConnMem, _ := cc.NewABCIClient()
 	appConnMem.SetLogger(log.TestingLogger().With("module", "abci-client", "connection", "mempool"))
 	err := appConnMem.Start()
 	if err != nil {
 		panic(err)
 	}
-	mempool := NewCListMempool(config.Mempool, appConnMem, 0)
+	mempool := NewCListMempool(config.Mempool, appConnMem, 0, options...)
 	mempool.SetLogger(log.TestingLogger())
 	return mempool, func() { os.RemoveAll(config.RootDir) }
 }
@@ -179,6 +183,48 @@ func TestMempoolFilters(t *testing.T) {
 	}
 }
 
+func TestMempoolAdmissionPolicy(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	var postChecked []TxInfo
+	mempool.SetAdmissionPolicy(AdmissionPolicyFuncs{
+		PreCheckFunc: PeerMaxTxBytesPolicy(2, []p2p.ID{"allowed"}).PreCheck,
+		PostCheckFunc: func(tx types.Tx, txInfo TxInfo, res *abci.ResponseCheckTx) error {
+			postChecked = append(postChecked, txInfo)
+			if tx[0] == 'b' {
+				return errors.New("bad tx")
+			}
+			return nil
+		},
+	})
+
+	// the large txs are only rejected if received from other peers
+	err := mempool.CheckTx(types.Tx("abc"), nil, TxInfo{SenderID: 1, SenderP2PID: "peer"})
+	assert.True(t, IsPreCheckError(err), err)
+	require.NoError(t, mempool.CheckTx(types.Tx("abcd"), nil, TxInfo{SenderID: 2, SenderP2PID: "allowed"}))
+	require.NoError(t, mempool.CheckTx(types.Tx("abcde"), nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(types.Tx("ab"), nil, TxInfo{SenderID: 1, SenderP2PID: "peer"}))
+	// rejected after CheckTx
+	require.NoError(t, mempool.CheckTx(types.Tx("b"), nil, TxInfo{SenderID: 1, SenderP2PID: "peer"}))
+
+	assert.Equal(t, 3, mempool.Size())
+	assert.Equal(t, []TxInfo{
+		{SenderID: 2, SenderP2PID: "allowed"},
+		{},
+		{SenderID: 1, SenderP2PID: "peer"},
+		{SenderID: 1, SenderP2PID: "peer"},
+	}, postChecked)
+
+	// the policy is kept by Update
+	err = mempool.Update(1, []types.Tx{types.Tx("ab")}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	require.NoError(t, err)
+	err = mempool.CheckTx(types.Tx("xyz"), nil, TxInfo{SenderID: 1, SenderP2PID: "peer"})
+	assert.True(t, IsPreCheckError(err), err)
+}
+
 func TestMempoolUpdate(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -217,6 +263,160 @@ func TestMempoolUpdate(t *testing.T) {
 	}
 }
 
+// gasPriceApp pays a gas price equal to the first byte of the tx.
+type gasPriceApp struct {
+	abci.BaseApplication
+}
+
+func (gasPriceApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, GasWanted: 1, GasPrice: int64(req.Tx[0])}
+}
+
+func TestMempoolMinGasPrice(t *testing.T) {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.MinGasPrice = 10
+	config.Mempool.TargetBlockGas = 100
+	cc := proxy.NewLocalClientCreator(gasPriceApp{})
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	require.EqualValues(t, 10, mempool.MinGasPrice())
+
+	// txs paying less than the minimum gas price are rejected
+	require.NoError(t, mempool.CheckTx([]byte{9}, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx([]byte{10}, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx([]byte{20}, nil, TxInfo{}))
+	assert.Equal(t, 2, mempool.Size())
+
+	// a full block increases the minimum gas price
+	err := mempool.Update(1, []types.Tx{[]byte{10}},
+		[]*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK, GasUsed: 200}}, nil, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 11, mempool.MinGasPrice())
+
+	require.NoError(t, mempool.CheckTx([]byte{10, 1}, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx([]byte{11, 1}, nil, TxInfo{}))
+	assert.Equal(t, 2, mempool.Size())
+
+	// empty blocks decrease it, down to the configured minimum
+	for i := int64(2); i < 10; i++ {
+		err = mempool.Update(i, []types.Tx{}, []*abci.ResponseDeliverTx{}, nil, nil)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 10, mempool.MinGasPrice())
+}
+
+func TestReapOrderByPriority(t *testing.T) {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.OrderBy = cfg.MempoolOrderPriority
+	cc := proxy.NewLocalClientCreator(gasPriceApp{})
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	for _, tx := range []types.Tx{{1}, {3}, {2}, {3, 1}} {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	exp := types.Txs{{3}, {3, 1}, {2}, {1}}
+	assert.Equal(t, exp, mempool.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(t, exp[:2], mempool.ReapMaxBytesMaxGas(-1, 2))
+	assert.Equal(t, exp, mempool.ReapMaxTxs(-1))
+}
+
+// categoryApp tags the txs with their first letter as category, except the
+// ones starting with a '-'.
+type categoryApp struct {
+	abci.BaseApplication
+}
+
+func (categoryApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	res := abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+	if req.Tx[0] != '-' {
+		res.Category = string(req.Tx[:1])
+	}
+	return res
+}
+
+type categoriesRecorder struct {
+	events []types.EventDataMempoolCategories
+}
+
+func (r *categoriesRecorder) PublishEventMempoolCategories(data types.EventDataMempoolCategories) error {
+	r.events = append(r.events, data)
+	return nil
+}
+
+func TestMempoolCategories(t *testing.T) {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.CategoryQuotas = "a:3"
+	cc := proxy.NewLocalClientCreator(categoryApp{})
+	recorder := &categoriesRecorder{}
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config, WithEventBus(recorder))
+	defer cleanup()
+	var forgotten []string
+	mempool.metrics.deleteCategory = func(category string) { forgotten = append(forgotten, category) }
+
+	for _, tx := range []types.Tx{types.Tx("a1"), types.Tx("a22"), types.Tx("b1"), types.Tx("-x")} {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+	assert.Equal(t, []types.MempoolCategory{
+		{Category: "a", Txs: 2, Bytes: 5},
+		{Category: "b", Txs: 1, Bytes: 2},
+	}, mempool.Stats().Categories)
+
+	// the txs over the quota of their category are skipped
+	exp := types.Txs{types.Tx("a1"), types.Tx("b1"), types.Tx("-x")}
+	assert.Equal(t, exp, mempool.ReapMaxBytesMaxGas(-1, -1))
+
+	err := mempool.Update(1, []types.Tx{types.Tx("a1"), types.Tx("b1")},
+		abciResponses(2, abci.CodeTypeOK), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, recorder.events, 1)
+	assert.Equal(t, types.EventDataMempoolCategories{
+		Height:     1,
+		Categories: []types.MempoolCategory{{Category: "a", Txs: 1, Bytes: 3}},
+	}, recorder.events[0])
+	assert.Equal(t, []string{"b"}, forgotten)
+	assert.Equal(t, types.Txs{types.Tx("a22"), types.Tx("-x")}, mempool.ReapMaxBytesMaxGas(-1, -1))
+
+	mempool.Flush()
+	assert.Empty(t, mempool.Stats().Categories)
+	assert.Equal(t, []string{"b", "a"}, forgotten)
+}
+
+// senderCategoryApp reads the category, the sender and the nonce of the txs
+// from their first three bytes.
+type senderCategoryApp struct {
+	abci.BaseApplication
+}
+
+func (senderCategoryApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{
+		Code:     abci.CodeTypeOK,
+		Category: string(req.Tx[:1]),
+		Sender:   string(req.Tx[1:2]),
+		Nonce:    uint64(req.Tx[2] - '0'),
+	}
+}
+
+func TestMempoolCategoriesSenderNonce(t *testing.T) {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.OrderBy = cfg.MempoolOrderSenderNonce
+	config.Mempool.CategoryQuotas = "a:3"
+	cc := proxy.NewLocalClientCreator(senderCategoryApp{})
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	for _, tx := range []types.Tx{types.Tx("aX0"), types.Tx("bY0"), types.Tx("aX1"), types.Tx("bX2"), types.Tx("aY1")} {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	// X's nonce 1 is over the quota, so its nonce 2 is skipped too even though
+	// the quota of its category isn't reached
+	exp := types.Txs{types.Tx("aX0"), types.Tx("bY0")}
+	assert.Equal(t, exp, mempool.ReapMaxBytesMaxGas(-1, -1))
+}
+
 func TestTxsAvailable(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -546,6 +746,102 @@ func TestMempoolTxsBytes(t *testing.T) {
 
 }
 
+func TestMempoolStats(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Size = 3
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	stats := mempool.Stats()
+	assert.Equal(t, 0, stats.Size)
+	assert.Equal(t, 3, stats.MaxSize)
+	assert.Equal(t, config.Mempool.MaxTxsBytes, stats.MaxTxsBytes)
+	assert.Len(t, stats.TxSizes.Counts, len(stats.TxSizes.Bounds)+1)
+
+	txs := []types.Tx{make([]byte, 10), make([]byte, 100), make([]byte, 2000)}
+	for _, tx := range txs {
+		tx[0] = byte(len(tx))
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+	err := mempool.CheckTx([]byte{0x01}, nil, TxInfo{})
+	assert.IsType(t, ErrMempoolIsFull{}, err)
+	mempool.RemoveTxByKey(TxKey(txs[0]), true)
+
+	stats = mempool.Stats()
+	assert.Equal(t, 2, stats.Size)
+	assert.EqualValues(t, 2100, stats.TxsBytes)
+	assert.Equal(t, []int{0, 1, 0, 1, 0, 0, 0, 0, 0}, stats.TxSizes.Counts)
+	assert.Equal(t, 2, stats.TxAges.Counts[0])
+	assert.Less(t, int64(stats.OldestTxAge), int64(time.Second))
+	assert.Equal(t, Evictions{Full: 1, Removed: 1}, stats.Evictions)
+
+	mempool.Flush()
+	stats = mempool.Stats()
+	assert.Equal(t, 0, stats.Size)
+	assert.Equal(t, Evictions{Full: 1, Removed: 1, Flushed: 2}, stats.Evictions)
+}
+
+func TestMempoolStatsTxAgesWithClock(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	clk := clock.NewMock(time.Unix(1600000000, 0))
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, cfg.ResetTestRoot("mempool_test"), WithClock(clk))
+	defer cleanup()
+
+	require.NoError(t, mempool.CheckTx([]byte{0x01}, nil, TxInfo{}))
+	clk.Add(10 * time.Second)
+	require.NoError(t, mempool.CheckTx([]byte{0x02}, nil, TxInfo{}))
+	clk.Add(time.Minute)
+
+	stats := mempool.Stats()
+	assert.Equal(t, 70*time.Second, stats.OldestTxAge)
+	assert.Equal(t, []int{0, 0, 0, 1, 1, 0, 0, 0, 0}, stats.TxAges.Counts)
+}
+
+type originRecordingApp struct {
+	*kvstore.Application
+	requests []abci.RequestCheckTx
+}
+
+func (app *originRecordingApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	app.requests = append(app.requests, req)
+	return app.Application.CheckTx(req)
+}
+
+func TestMempoolCheckTxOrigin(t *testing.T) {
+	app := &originRecordingApp{Application: kvstore.NewApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	now := time.Unix(1600000000, 0).UTC()
+	clk := clock.NewMock(now)
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, cfg.ResetTestRoot("mempool_test"), WithClock(clk))
+	defer cleanup()
+
+	received := now.Add(-time.Second)
+	require.NoError(t, mempool.CheckTx([]byte{0x01}, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx([]byte{0x02}, nil, TxInfo{SenderID: 1, SenderP2PID: "peer"}))
+	require.NoError(t, mempool.CheckTx([]byte{0x03}, nil,
+		TxInfo{SenderID: 1, SenderP2PID: "peer", ReceivedAt: received}))
+
+	local := abci.TxOrigin{Source: abci.TxSource_Local, ReceivedAt: now}
+	peer := abci.TxOrigin{Source: abci.TxSource_Peer, PeerId: "peer", ReceivedAt: now}
+	peerReceived := abci.TxOrigin{Source: abci.TxSource_Peer, PeerId: "peer", ReceivedAt: received}
+	require.Len(t, app.requests, 3)
+	assert.Equal(t, []abci.TxOrigin{local, peer, peerReceived},
+		[]abci.TxOrigin{app.requests[0].Origin, app.requests[1].Origin, app.requests[2].Origin})
+
+	// the rechecks keep the origin of the first check
+	clk.Add(time.Minute)
+	err := mempool.Update(1, []types.Tx{{0x01}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, app.requests, 5)
+	for i, origin := range []abci.TxOrigin{peer, peerReceived} {
+		assert.Equal(t, abci.CheckTxType_Recheck, app.requests[3+i].Type)
+		assert.Equal(t, origin, app.requests[3+i].Origin)
+	}
+}
+
 // This will non-deterministically catch some concurrency failures like
 // https://github.com/tendermint/tendermint/issues/3509
 // TODO: all of the tests should probably also run using the remote proxy app
@@ -19,5 +19,22 @@
 // DetachPrev() call, which makes old elements not reachable by peer
 // broadcastTxRoutine().
 
+// Txs are reaped for block proposals in the order given by the order_by
+// config option, without changing the order of the linked-list (which is also
+// the order txs are gossiped in):
+// 1. "fifo": in the order txs were added to the mempool.
+// 2. "priority": by decreasing ResponseCheckTx.GasPrice. Txs with the same
+//    gas price are kept in the order they were added.
+// 3. "sender-nonce": grouped by ResponseCheckTx.Sender, with senders ordered by
+//    their first tx added, and txs of a sender ordered by increasing
+//    ResponseCheckTx.Nonce (txs with the same nonce are kept in the order they
+//    were added). Txs without a sender are each treated as a separate sender.
+// The ordering is deterministic: it only depends on the txs in the mempool and
+// the order they were added in, which may differ between nodes. Since only
+// the proposer reaps txs, the order within a block is the proposer's order.
+// Note the app sees txs in this order in DeliverTx, but txs that depend on
+// each other (e.g. nonces from the same sender) are only guaranteed to be in
+// order with "sender-nonce".
+
 // TODO: Better handle abci client errors. (make it automatically handle connection errors)
 package mempool
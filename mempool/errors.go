@@ -36,6 +36,16 @@ func (e ErrMempoolIsFull) Error() string {
 		e.txsBytes, e.maxTxsBytes)
 }
 
+// ErrGasPriceTooLow means the tx pays less than the mempool's minimum gas price
+type ErrGasPriceTooLow struct {
+	Min    int64
+	Actual int64
+}
+
+func (e ErrGasPriceTooLow) Error() string {
+	return fmt.Sprintf("gas price too low: min %d, got %d", e.Min, e.Actual)
+}
+
 // ErrPreCheck is returned when tx is too big
 type ErrPreCheck struct {
 	Reason error
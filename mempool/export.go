@@ -0,0 +1,71 @@
+package mempool
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tendermint/tendermint/libs/protoio"
+	protomem "github.com/tendermint/tendermint/proto/tendermint/mempool"
+	"github.com/tendermint/tendermint/types"
+)
+
+// exportedTxOverhead is the maximum size of the encoding of an exported tx,
+// beyond the tx itself.
+const exportedTxOverhead = 16
+
+// Export writes the txs of the mempool to w, in the order they were added, so
+// they can be imported into the mempool of another node (e.g. when migrating a
+// node to a new machine). It returns the number of txs written.
+//
+// The txs are written as length-delimited tendermint.mempool.Txs messages.
+func (mem *CListMempool) Export(w io.Writer) (int, error) {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	pw := protoio.NewDelimitedWriter(w)
+	n := 0
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if _, err := pw.WriteMsg(&protomem.Txs{Txs: [][]byte{memTx.tx}}); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// Import checks the txs read from r, as written by Export, adding the valid
+// ones to the mempool. The txs already in the mempool, or rejected by CheckTx,
+// are skipped. It returns the number of txs added.
+func (mem *CListMempool) Import(r io.Reader) (int, error) {
+	pr := protoio.NewDelimitedReader(r, mem.config.MaxTxBytes+exportedTxOverhead)
+	var checked []types.Tx
+	for {
+		var msg protomem.Txs
+		err := pr.ReadMsg(&msg)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, fmt.Errorf("failed to read tx %d: %w", len(checked), err)
+		}
+		for _, tx := range msg.Txs {
+			if err := mem.CheckTx(tx, nil, TxInfo{}); err != nil {
+				mem.logger.Debug("Skipping imported tx", "tx", mem.txID(tx), "err", err)
+				continue
+			}
+			checked = append(checked, tx)
+		}
+	}
+
+	// wait for the responses to CheckTx
+	if err := mem.FlushAppConn(); err != nil {
+		return 0, err
+	}
+	added := 0
+	for _, tx := range checked {
+		if _, ok := mem.txsMap.Load(TxKey(tx)); ok {
+			added++
+		}
+	}
+	return added, nil
+}
@@ -0,0 +1,40 @@
+package mempool
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestMempoolExportImport(t *testing.T) {
+	src, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(kvstore.NewApplication()))
+	defer cleanup()
+	txs := []types.Tx{[]byte("a=1"), []byte("b=2"), []byte("c=3")}
+	for _, tx := range txs {
+		require.NoError(t, src.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	var buf bytes.Buffer
+	n, err := src.Export(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	exported := buf.Bytes()
+
+	dst, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(kvstore.NewApplication()))
+	defer cleanup()
+	require.NoError(t, dst.CheckTx(txs[1], nil, TxInfo{}))
+	n, err = dst.Import(bytes.NewReader(exported))
+	require.NoError(t, err)
+	// the txs already in the mempool are skipped
+	assert.Equal(t, 2, n)
+	assert.Equal(t, types.Txs{txs[1], txs[0], txs[2]}, dst.ReapMaxTxs(-1))
+
+	_, err = dst.Import(bytes.NewReader(exported[:len(exported)-1]))
+	assert.Error(t, err)
+}
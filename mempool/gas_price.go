@@ -0,0 +1,81 @@
+package mempool
+
+import (
+	"math"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// gasPriceMaxChangeDenominator bounds how much the minimum gas price can
+// change after a single block: at most 1/8 (12.5%) of its current value.
+const gasPriceMaxChangeDenominator = 8
+
+// gasPriceFloor tracks the minimum gas price a tx must pay to be admitted into
+// the mempool. If targetBlockGas is > 0, the floor is adjusted after each
+// block, similarly to EIP-1559's base fee, but computed locally: it increases
+// when the block used more than targetBlockGas and decreases when it used
+// less, never going below minGasPrice.
+type gasPriceFloor struct {
+	minGasPrice    int64
+	targetBlockGas int64
+
+	mtx   tmsync.RWMutex
+	price int64
+}
+
+func newGasPriceFloor(minGasPrice, targetBlockGas int64) *gasPriceFloor {
+	return &gasPriceFloor{
+		minGasPrice:    minGasPrice,
+		targetBlockGas: targetBlockGas,
+		price:          minGasPrice,
+	}
+}
+
+// Price returns the current minimum gas price.
+func (f *gasPriceFloor) Price() int64 {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+	return f.price
+}
+
+// Update adjusts the minimum gas price given the gas used by the last block
+// and returns the new price. It's a no-op if targetBlockGas is 0.
+func (f *gasPriceFloor) Update(blockGasUsed int64) int64 {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.targetBlockGas == 0 || blockGasUsed == f.targetBlockGas {
+		return f.price
+	}
+
+	if blockGasUsed > f.targetBlockGas {
+		delta := gasPriceDelta(f.price, blockGasUsed-f.targetBlockGas, f.targetBlockGas)
+		// always increase by at least 1, so the price can grow from 0.
+		if delta < 1 {
+			delta = 1
+		}
+		if f.price > math.MaxInt64-delta {
+			f.price = math.MaxInt64
+		} else {
+			f.price += delta
+		}
+	} else {
+		delta := gasPriceDelta(f.price, f.targetBlockGas-blockGasUsed, f.targetBlockGas)
+		f.price -= delta
+		if f.price < f.minGasPrice {
+			f.price = f.minGasPrice
+		}
+	}
+
+	return f.price
+}
+
+// gasPriceDelta returns price * gasDelta / targetGas / 8, with gasDelta capped
+// at targetGas so a single block changes the price by at most 1/8.
+func gasPriceDelta(price, gasDelta, targetGas int64) int64 {
+	if gasDelta > targetGas {
+		gasDelta = targetGas
+	}
+	// use floats to avoid overflowing price * gasDelta
+	return int64(float64(price) * float64(gasDelta) / float64(targetGas) / gasPriceMaxChangeDenominator)
+}
@@ -0,0 +1,41 @@
+package mempool
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGasPriceFloorUpdate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		minGasPrice    int64
+		targetBlockGas int64
+		price          int64
+		blockGasUsed   int64
+		expPrice       int64
+	}{
+		{"disabled", 10, 0, 10, 1000, 10},
+		{"on target", 10, 100, 80, 100, 80},
+		{"full block", 10, 100, 80, 200, 90},
+		{"over full block", 10, 100, 80, 1000, 90},
+		{"half full block", 10, 100, 80, 150, 85},
+		{"empty block", 10, 100, 80, 0, 70},
+		{"empty block at floor", 10, 100, 10, 0, 10},
+		{"empty block near floor", 10, 100, 11, 0, 10},
+		{"grows from 0", 0, 100, 0, 200, 1},
+		{"small price grows", 0, 100, 4, 200, 5},
+		{"no overflow", 0, 100, math.MaxInt64, 200, math.MaxInt64},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			f := newGasPriceFloor(tc.minGasPrice, tc.targetBlockGas)
+			f.price = tc.price
+			assert.Equal(t, tc.expPrice, f.Update(tc.blockGasUsed))
+			assert.Equal(t, tc.expPrice, f.Price())
+		})
+	}
+}
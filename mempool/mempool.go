@@ -3,6 +3,8 @@ package mempool
 import (
 	"context"
 	"fmt"
+	"io"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/p2p"
@@ -70,6 +72,21 @@ type Mempool interface {
 	// TxsBytes returns the total size of all txs in the mempool.
 	TxsBytes() int64
 
+	// MinGasPrice returns the minimum gas price a tx must pay to be admitted
+	// into the mempool.
+	MinGasPrice() int64
+
+	// Stats returns a summary of the txs in the mempool, and of the txs
+	// evicted from it.
+	Stats() Stats
+
+	// Export writes the txs in the mempool to w, returning their number.
+	Export(w io.Writer) (int, error)
+
+	// Import checks the txs read from r, as written by Export, adding the
+	// valid ones to the mempool. It returns the number of txs added.
+	Import(r io.Reader) (int, error)
+
 	// InitWAL creates a directory for the WAL file and opens a file itself. If
 	// there is an error, it will be of type *PathError.
 	InitWAL() error
@@ -101,6 +118,8 @@ type TxInfo struct {
 	SenderP2PID p2p.ID
 	// Context is the optional context to cancel CheckTx
 	Context context.Context
+	// ReceivedAt is the time the tx was received, the time of CheckTx if zero.
+	ReceivedAt time.Time
 }
 
 //--------------------------------------------------------------------------------
@@ -24,16 +24,46 @@ type Metrics struct {
 	FailedTxs metrics.Counter
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
+	// Minimum gas price for a transaction to be admitted into the mempool.
+	MinGasPrice metrics.Gauge
+	// Number of transactions in the mempool, by category.
+	CategoryTxs metrics.Gauge
+	// Total size of the transactions in the mempool, by category.
+	CategoryBytes metrics.Gauge
+
+	// deletes the label sets of a category once it has no tx left in the
+	// mempool, so that the number of label sets stays bounded; nil if not
+	// supported.
+	deleteCategory func(category string)
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
 // Optionally, labels can be provided along with their values ("foo",
 // "fooValue").
 func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
-	labels := []string{}
+	labels, values := []string{}, []string{}
 	for i := 0; i < len(labelsAndValues); i += 2 {
 		labels = append(labels, labelsAndValues[i])
+		values = append(values, labelsAndValues[i+1])
 	}
+	categoryLabels := append(append([]string{}, labels...), "category")
+
+	// the vectors labelled by category are registered here, so that the label
+	// sets of the categories can be deleted
+	categoryTxs := stdprometheus.NewGaugeVec(stdprometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "category_txs",
+		Help:      "Number of transactions in the mempool, by category.",
+	}, categoryLabels)
+	categoryBytes := stdprometheus.NewGaugeVec(stdprometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "category_bytes",
+		Help:      "Total size of the transactions in the mempool, by category.",
+	}, categoryLabels)
+	stdprometheus.MustRegister(categoryTxs, categoryBytes)
+
 	return &Metrics{
 		Size: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
@@ -60,15 +90,38 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "recheck_times",
 			Help:      "Number of times transactions are rechecked in the mempool.",
 		}, labels).With(labelsAndValues...),
+		MinGasPrice: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "min_gas_price",
+			Help:      "Minimum gas price for a transaction to be admitted into the mempool.",
+		}, labels).With(labelsAndValues...),
+		CategoryTxs:   prometheus.NewGauge(categoryTxs).With(labelsAndValues...),
+		CategoryBytes: prometheus.NewGauge(categoryBytes).With(labelsAndValues...),
+		deleteCategory: func(category string) {
+			lvs := append(append([]string{}, values...), category)
+			categoryTxs.DeleteLabelValues(lvs...)
+			categoryBytes.DeleteLabelValues(lvs...)
+		},
+	}
+}
+
+// forgetCategory deletes the label sets of the category, if supported.
+func (m *Metrics) forgetCategory(category string) {
+	if m.deleteCategory != nil {
+		m.deleteCategory(category)
 	}
 }
 
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Size:         discard.NewGauge(),
-		TxSizeBytes:  discard.NewHistogram(),
-		FailedTxs:    discard.NewCounter(),
-		RecheckTimes: discard.NewCounter(),
+		Size:          discard.NewGauge(),
+		TxSizeBytes:   discard.NewHistogram(),
+		FailedTxs:     discard.NewCounter(),
+		RecheckTimes:  discard.NewCounter(),
+		MinGasPrice:   discard.NewGauge(),
+		CategoryTxs:   discard.NewGauge(),
+		CategoryBytes: discard.NewGauge(),
 	}
 }
@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"io"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/clist"
 	mempl "github.com/tendermint/tendermint/mempool"
@@ -34,6 +36,10 @@ func (Mempool) FlushAppConn() error           { return nil }
 func (Mempool) TxsAvailable() <-chan struct{} { return make(chan struct{}) }
 func (Mempool) EnableTxsAvailable()           {}
 func (Mempool) TxsBytes() int64               { return 0 }
+func (Mempool) MinGasPrice() int64            { return 0 }
+func (Mempool) Stats() mempl.Stats            { return mempl.Stats{} }
+func (Mempool) Export(io.Writer) (int, error) { return 0, nil }
+func (Mempool) Import(io.Reader) (int, error) { return 0, nil }
 
 func (Mempool) TxsFront() *clist.CElement    { return nil }
 func (Mempool) TxsWaitChan() <-chan struct{} { return nil }
@@ -0,0 +1,62 @@
+package mempool
+
+import (
+	"sort"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+// orderTxs sorts txs, given in the order they were added to the mempool, in
+// place according to orderBy (one of the cfg.MempoolOrder* values).
+// See the package documentation for the guarantees of each order.
+func orderTxs(orderBy string, txs []*mempoolTx) {
+	switch orderBy {
+	case cfg.MempoolOrderPriority:
+		sort.SliceStable(txs, func(i, j int) bool {
+			return txs[i].gasPrice > txs[j].gasPrice
+		})
+
+	case cfg.MempoolOrderSenderNonce:
+		// position of the first tx of each sender; txs without a sender are
+		// their own group.
+		groups := make([]int, len(txs))
+		firstBySender := make(map[string]int)
+		for i, memTx := range txs {
+			if memTx.sender == "" {
+				groups[i] = i
+				continue
+			}
+			first, ok := firstBySender[memTx.sender]
+			if !ok {
+				first = i
+				firstBySender[memTx.sender] = i
+			}
+			groups[i] = first
+		}
+
+		sort.Stable(senderNonceOrder{txs: txs, groups: groups})
+
+	default: // cfg.MempoolOrderFIFO
+	}
+}
+
+// senderNonceOrder sorts txs by group, then by nonce. groups is swapped
+// together with txs.
+type senderNonceOrder struct {
+	txs    []*mempoolTx
+	groups []int
+}
+
+func (o senderNonceOrder) Len() int { return len(o.txs) }
+
+func (o senderNonceOrder) Less(i, j int) bool {
+	if o.groups[i] != o.groups[j] {
+		return o.groups[i] < o.groups[j]
+	}
+	return o.txs[i].nonce < o.txs[j].nonce
+}
+
+func (o senderNonceOrder) Swap(i, j int) {
+	o.txs[i], o.txs[j] = o.txs[j], o.txs[i]
+	o.groups[i], o.groups[j] = o.groups[j], o.groups[i]
+}
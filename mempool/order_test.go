@@ -0,0 +1,47 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestOrderTxs(t *testing.T) {
+	// txs in the order they were added
+	newTxs := func() []*mempoolTx {
+		return []*mempoolTx{
+			{tx: types.Tx("a1"), sender: "a", nonce: 1, gasPrice: 1},
+			{tx: types.Tx("b2"), sender: "b", nonce: 2, gasPrice: 3},
+			{tx: types.Tx("x"), gasPrice: 2},
+			{tx: types.Tx("a0"), sender: "a", nonce: 0, gasPrice: 3},
+			{tx: types.Tx("b1"), sender: "b", nonce: 1, gasPrice: 1},
+			{tx: types.Tx("y"), gasPrice: 2},
+		}
+	}
+
+	testCases := []struct {
+		orderBy string
+		exp     []string
+	}{
+		{cfg.MempoolOrderFIFO, []string{"a1", "b2", "x", "a0", "b1", "y"}},
+		{cfg.MempoolOrderPriority, []string{"b2", "a0", "x", "y", "a1", "b1"}},
+		{cfg.MempoolOrderSenderNonce, []string{"a0", "a1", "b1", "b2", "x", "y"}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.orderBy, func(t *testing.T) {
+			txs := newTxs()
+			orderTxs(tc.orderBy, txs)
+
+			got := make([]string, len(txs))
+			for i, memTx := range txs {
+				got[i] = string(memTx.tx)
+			}
+			assert.Equal(t, tc.exp, got)
+		})
+	}
+}
@@ -1,15 +1,22 @@
 package mempool
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math"
+	"os"
+	"sort"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/clist"
 	"github.com/tendermint/tendermint/libs/log"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/libs/tempfile"
 	"github.com/tendermint/tendermint/p2p"
 	protomem "github.com/tendermint/tendermint/proto/tendermint/mempool"
 	"github.com/tendermint/tendermint/types"
@@ -24,6 +31,10 @@ const (
 	// no peer (e.g. RPC)
 	UnknownPeerID uint16 = 0
 
+	// maxTxRelayDisabledPeers is the maximum number of peers whose tx relay
+	// can be disabled, see SetPeerTxRelay.
+	maxTxRelayDisabledPeers = 1000
+
 	maxActiveIDs = math.MaxUint16
 )
 
@@ -35,6 +46,9 @@ type Reactor struct {
 	config  *cfg.MempoolConfig
 	mempool *CListMempool
 	ids     *mempoolIDs
+
+	relayMtx      tmsync.RWMutex
+	relayDisabled map[p2p.ID]struct{} // peers whose txs aren't relayed, see SetPeerTxRelay
 }
 
 type mempoolIDs struct {
@@ -103,9 +117,10 @@ func newMempoolIDs() *mempoolIDs {
 // NewReactor returns a new Reactor with the given config and mempool.
 func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool) *Reactor {
 	memR := &Reactor{
-		config:  config,
-		mempool: mempool,
-		ids:     newMempoolIDs(),
+		config:        config,
+		mempool:       mempool,
+		ids:           newMempoolIDs(),
+		relayDisabled: make(map[p2p.ID]struct{}),
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
 	return memR
@@ -123,11 +138,30 @@ func (memR *Reactor) SetLogger(l log.Logger) {
 	memR.mempool.SetLogger(l)
 }
 
-// OnStart implements p2p.BaseReactor.
+// OnStart implements p2p.BaseReactor by loading the tx relay settings of the
+// peers.
 func (memR *Reactor) OnStart() error {
 	if !memR.config.Broadcast {
 		memR.Logger.Info("Tx broadcasting is disabled")
 	}
+	if memR.config.TxRelayFile == "" {
+		return nil
+	}
+	bz, err := ioutil.ReadFile(memR.config.TxRelayFilePath())
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read the tx relay settings: %w", err)
+	}
+	var disabled []p2p.ID
+	if err := json.Unmarshal(bz, &disabled); err != nil {
+		return fmt.Errorf("invalid tx relay settings: %w", err)
+	}
+	memR.relayMtx.Lock()
+	defer memR.relayMtx.Unlock()
+	for _, id := range disabled {
+		memR.relayDisabled[id] = struct{}{}
+	}
 	return nil
 }
 
@@ -158,6 +192,54 @@ func (memR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 	// broadcast routine checks if peer is gone and returns
 }
 
+// SetPeerTxRelay enables or disables the relaying of txs to and from the peer
+// with the given ID, whether it's connected or not, without disconnecting it:
+// the txs it sends are then ignored. The setting is persisted in
+// MempoolConfig.TxRelayFile, if set. It returns an error if the relay of too
+// many peers is disabled.
+func (memR *Reactor) SetPeerTxRelay(peerID p2p.ID, enable bool) error {
+	memR.relayMtx.Lock()
+	defer memR.relayMtx.Unlock()
+	if _, ok := memR.relayDisabled[peerID]; ok == !enable {
+		return nil
+	}
+	if enable {
+		delete(memR.relayDisabled, peerID)
+	} else {
+		if len(memR.relayDisabled) >= maxTxRelayDisabledPeers {
+			return fmt.Errorf("the tx relay of too many peers is disabled (max: %d)", maxTxRelayDisabledPeers)
+		}
+		memR.relayDisabled[peerID] = struct{}{}
+	}
+	memR.Logger.Info("Set peer tx relay", "peer", peerID, "enable", enable)
+
+	if memR.config.TxRelayFile == "" {
+		return nil
+	}
+	disabled := make([]p2p.ID, 0, len(memR.relayDisabled))
+	for id := range memR.relayDisabled {
+		disabled = append(disabled, id)
+	}
+	sort.Slice(disabled, func(i, j int) bool { return disabled[i] < disabled[j] })
+	bz, err := json.Marshal(disabled)
+	if err != nil {
+		return err
+	}
+	if err := tempfile.WriteFileAtomic(memR.config.TxRelayFilePath(), bz, 0644); err != nil {
+		return fmt.Errorf("failed to persist the tx relay settings: %w", err)
+	}
+	return nil
+}
+
+// PeerTxRelay returns whether txs are relayed to and from the peer with the
+// given ID, see SetPeerTxRelay.
+func (memR *Reactor) PeerTxRelay(peerID p2p.ID) bool {
+	memR.relayMtx.RLock()
+	defer memR.relayMtx.RUnlock()
+	_, disabled := memR.relayDisabled[peerID]
+	return !disabled
+}
+
 // Receive implements Reactor.
 // It adds any received transactions to the mempool.
 // XXX: do not call any methods that can block or incur heavy processing.
@@ -170,15 +252,19 @@ func (memR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 		return
 	}
 	memR.Logger.Debug("Receive", "src", src, "chId", chID, "msg", msg)
+	if src != nil && !memR.PeerTxRelay(src.ID()) {
+		memR.Logger.Debug("Ignoring txs from peer with tx relay disabled", "src", src)
+		return
+	}
 
-	txInfo := TxInfo{SenderID: memR.ids.GetForPeer(src)}
+	txInfo := TxInfo{SenderID: memR.ids.GetForPeer(src), ReceivedAt: memR.mempool.clock.Now()}
 	if src != nil {
 		txInfo.SenderP2PID = src.ID()
 	}
 	for _, tx := range msg.Txs {
 		err = memR.mempool.CheckTx(tx, nil, txInfo)
 		if err != nil {
-			memR.Logger.Info("Could not check tx", "tx", txID(tx), "err", err)
+			memR.Logger.Info("Could not check tx", "tx", memR.mempool.txID(tx), "err", err)
 		}
 	}
 	// broadcasting happens from go routines per peer
@@ -227,6 +313,11 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 			continue
 		}
 
+		if !memR.PeerTxRelay(peer.ID()) {
+			time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
+			continue
+		}
+
 		// Allow for a lag of 1 block.
 		memTx := next.Value.(*mempoolTx)
 		if peerState.GetHeight() < memTx.Height()-1 {
@@ -238,12 +329,7 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 
 		// send txs
 		if len(txs) > 0 {
-			msg := protomem.Message{
-				Sum: &protomem.Message_Txs{
-					Txs: &protomem.Txs{Txs: txs},
-				},
-			}
-			bz, err := msg.Marshal()
+			bz, err := mustWrapMsg(&protomem.Txs{Txs: txs}).Marshal()
 			if err != nil {
 				panic(err)
 			}
@@ -278,11 +364,7 @@ func (memR *Reactor) txs(next *clist.CElement, peerID uint16, peerHeight int64)
 
 		if _, ok := memTx.senders.Load(peerID); !ok {
 			// If current batch + this tx size is greater than max => return.
-			batchMsg := protomem.Message{
-				Sum: &protomem.Message_Txs{
-					Txs: &protomem.Txs{Txs: append(batch, memTx.tx)},
-				},
-			}
+			batchMsg := mustWrapMsg(&protomem.Txs{Txs: append(batch, memTx.tx)})
 			if batchMsg.Size() > memR.config.MaxBatchBytes {
 				return batch
 			}
@@ -310,8 +392,12 @@ func (memR *Reactor) decodeMsg(bz []byte) (TxsMessage, error) {
 
 	var message TxsMessage
 
-	if i, ok := msg.Sum.(*protomem.Message_Txs); ok {
-		txs := i.Txs.GetTxs()
+	pb, err := msg.Unwrap()
+	if err != nil {
+		return message, err
+	}
+	if i, ok := pb.(*protomem.Txs); ok {
+		txs := i.GetTxs()
 
 		if len(txs) == 0 {
 			return message, errors.New("empty TxsMessage")
@@ -327,7 +413,16 @@ func (memR *Reactor) decodeMsg(bz []byte) (TxsMessage, error) {
 		}
 		return message, nil
 	}
-	return message, fmt.Errorf("msg type: %T is not supported", msg)
+	return message, fmt.Errorf("msg type: %T is not supported", pb)
+}
+
+// mustWrapMsg wraps the mempool message into a protomem.Message.
+func mustWrapMsg(pb proto.Message) *protomem.Message {
+	msg, err := protomem.Wrap(pb)
+	if err != nil {
+		panic(err)
+	}
+	return msg
 }
 
 //-------------------------------------
@@ -3,6 +3,7 @@ package mempool
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"net"
 	"sync"
 	"testing"
@@ -149,6 +150,74 @@ func TestReactorNoBroadcastToSender(t *testing.T) {
 	ensureNoTxs(t, reactors[peerID], 100*time.Millisecond)
 }
 
+func TestReactorPeerTxRelay(t *testing.T) {
+	config := cfg.TestConfig()
+	const N = 2
+	reactors := makeAndConnectReactors(config, N)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			peer.Set(types.PeerStateKey, peerState{1})
+		}
+	}
+	id0, id1 := reactors[0].Switch.NodeInfo().ID(), reactors[1].Switch.NodeInfo().ID()
+
+	// the txs aren't relayed to the peer
+	require.NoError(t, reactors[0].SetPeerTxRelay(id1, false))
+	assert.False(t, reactors[0].PeerTxRelay(id1))
+	assert.True(t, reactors[0].PeerTxRelay(id0))
+	checkTxs(t, reactors[0].mempool, 5, UnknownPeerID)
+	ensureNoTxs(t, reactors[1], 100*time.Millisecond)
+
+	// nor accepted from it
+	require.NoError(t, reactors[1].SetPeerTxRelay(id0, false))
+	require.NoError(t, reactors[0].SetPeerTxRelay(id1, true))
+	ensureNoTxs(t, reactors[1], 500*time.Millisecond)
+
+	require.NoError(t, reactors[1].SetPeerTxRelay(id0, true))
+	txs := checkTxs(t, reactors[0].mempool, 1, UnknownPeerID)
+	waitForTxsOnReactor(t, txs, reactors[1], 1)
+}
+
+func TestReactorPeerTxRelayPersistence(t *testing.T) {
+	config := cfg.TestMempoolConfig()
+	config.RootDir = t.TempDir()
+	config.TxRelayFile = "tx_relay.json"
+	newReactor := func() *Reactor {
+		cc := proxy.NewLocalClientCreator(kvstore.NewApplication())
+		mempool, cleanup := newMempoolWithApp(cc)
+		t.Cleanup(cleanup)
+		memR := NewReactor(config, mempool)
+		memR.SetLogger(log.TestingLogger())
+		require.NoError(t, memR.Start())
+		t.Cleanup(func() { _ = memR.Stop() })
+		return memR
+	}
+
+	memR := newReactor()
+	require.NoError(t, memR.SetPeerTxRelay("a", false))
+	require.NoError(t, memR.SetPeerTxRelay("b", false))
+	require.NoError(t, memR.SetPeerTxRelay("a", true))
+
+	// the setting survives restarts
+	memR = newReactor()
+	assert.True(t, memR.PeerTxRelay("a"))
+	assert.False(t, memR.PeerTxRelay("b"))
+
+	// and the number of disabled peers is bounded
+	for i := 1; i < maxTxRelayDisabledPeers; i++ {
+		require.NoError(t, memR.SetPeerTxRelay(p2p.ID(fmt.Sprint(i)), false))
+	}
+	assert.Error(t, memR.SetPeerTxRelay("c", false))
+	assert.True(t, memR.PeerTxRelay("c"))
+}
+
 func TestReactor_MaxBatchBytes(t *testing.T) {
 	config := cfg.TestConfig()
 	config.Mempool.MaxBatchBytes = 1024
@@ -0,0 +1,74 @@
+package mempool
+
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	// Bucket bounds of the Stats histograms. Each histogram has an extra
+	// bucket, for the values above the last bound.
+	statsTxSizeBounds    = []int64{64, 256, 1024, 4096, 16384, 65536, 262144, 1048576}
+	statsGasWantedBounds = []int64{1e3, 1e4, 1e5, 1e6, 1e7, 1e8}
+	statsTxAgeBounds     = []int64{1, 5, 15, 60, 300, 900, 3600, 21600}
+)
+
+// Histogram counts values in buckets. Counts[i] is the number of values in
+// (Bounds[i-1], Bounds[i]], and the last count is the number of values above
+// the last bound.
+type Histogram struct {
+	Bounds []int64
+	Counts []int
+}
+
+func newHistogram(bounds []int64) Histogram {
+	return Histogram{Bounds: bounds, Counts: make([]int, len(bounds)+1)}
+}
+
+func (h Histogram) observe(value int64) {
+	for i, bound := range h.Bounds {
+		if value <= bound {
+			h.Counts[i]++
+			return
+		}
+	}
+	h.Counts[len(h.Bounds)]++
+}
+
+// Evictions counts the txs evicted from the mempool, or rejected by it, since
+// it was started.
+type Evictions struct {
+	// Txs rejected because the mempool was full.
+	Full int64
+	// Txs removed because they were no longer valid when rechecked.
+	Invalidated int64
+	// Txs removed with RemoveTxByKey.
+	Removed int64
+	// Txs removed by Flush.
+	Flushed int64
+}
+
+// Stats summarizes the txs in the mempool, to help tune its size limits.
+type Stats struct {
+	Size           int
+	MaxSize        int
+	TxsBytes       int64
+	MaxTxsBytes    int64
+	TotalGasWanted int64
+
+	// Histogram of the tx sizes, in bytes.
+	TxSizes Histogram
+	// Histogram of the gas wanted by the txs.
+	GasWanted Histogram
+	// Histogram of the time the txs have spent in the mempool, in seconds.
+	TxAges Histogram
+	// Time spent in the mempool by the oldest tx.
+	OldestTxAge time.Duration
+
+	// Txs by category, sorted by category. The uncategorized txs aren't
+	// listed.
+	Categories []types.MempoolCategory
+
+	Evictions Evictions
+}
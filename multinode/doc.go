@@ -0,0 +1,29 @@
+/*
+Package multinode hosts several independent nodes, running different chains,
+in a single process.
+
+Each node has its own config and home directory, and thus its own databases,
+keys and p2p listener, while the Host provides the shared infrastructure: a
+single logger (each node logging with its chain_id) and a single Prometheus
+server, exposing the metrics of all the nodes labeled with their chain_id.
+
+	host := multinode.NewHost(instrumentationConfig, logger)
+	for _, config := range configs {
+		if _, err := host.AddNode(config, clientCreator(config)); err != nil {
+			return err
+		}
+	}
+	if err := host.Start(); err != nil {
+		return err
+	}
+
+Note that the RPC environment of the rpc/core package is global, so at most
+one of the hosted nodes can run the RPC server (rpc.laddr); the others must
+disable it.
+
+The metrics of the hosted nodes are provided by the Host, so the nodes must
+not enable Prometheus themselves (instrumentation.prometheus). The metrics
+created internally by node.NewNode (e.g. the RPC response cache metrics) are
+not reported.
+*/
+package multinode
@@ -0,0 +1,254 @@
+package multinode
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	cfg "github.com/tendermint/tendermint/config"
+	cs "github.com/tendermint/tendermint/consensus"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Host hosts several independent nodes in a single process. See the package
+// documentation.
+type Host struct {
+	service.BaseService
+
+	instrumentation *cfg.InstrumentationConfig
+	prometheusSrv   *http.Server
+
+	metricsOnce  sync.Once
+	csMetrics    *cs.Metrics
+	p2pMetrics   *p2p.Metrics
+	memplMetrics *mempl.Metrics
+	smMetrics    *sm.Metrics
+
+	mtx        tmsync.Mutex
+	nodes      []*node.Node
+	chainIDs   map[string]bool
+	p2pAddrs   map[string]string // p2p listen address -> chain ID
+	rpcChainID string            // chain ID of the node running the RPC server
+}
+
+// NewHost returns a Host, serving the metrics of the nodes according to the
+// given instrumentation config.
+func NewHost(instrumentation *cfg.InstrumentationConfig, logger log.Logger) *Host {
+	h := &Host{
+		instrumentation: instrumentation,
+		chainIDs:        make(map[string]bool),
+		p2pAddrs:        make(map[string]string),
+	}
+	h.BaseService = *service.NewBaseService(logger, "MultiNodeHost", h)
+	return h
+}
+
+// AddNode creates a node from the given config, using the app created by
+// clientCreator, and adds it to the host. The node is started right away if
+// the host is running.
+//
+// The node key, private validator and genesis are loaded as in
+// node.DefaultNewNode. The config must not enable Prometheus, and must not
+// share its chain ID or p2p listen address with another node of the host.
+func (h *Host) AddNode(config *cfg.Config, clientCreator proxy.ClientCreator, options ...node.Option) (*node.Node, error) {
+	if config.Instrumentation.Prometheus {
+		return nil, errors.New("nodes must not enable prometheus, the host serves their metrics")
+	}
+
+	genDoc, err := node.DefaultGenesisDocProviderFunc(config)()
+	if err != nil {
+		return nil, err
+	}
+	chainID := genDoc.ChainID
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
+	}
+	pval, err := privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	if err != nil {
+		return nil, err
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if h.chainIDs[chainID] {
+		return nil, fmt.Errorf("a node for chain %q was already added", chainID)
+	}
+	if other, ok := h.p2pAddrs[config.P2P.ListenAddress]; ok {
+		return nil, fmt.Errorf("p2p listen address %v is already used by chain %q", config.P2P.ListenAddress, other)
+	}
+	if config.RPC.ListenAddress != "" && h.rpcChainID != "" {
+		return nil, fmt.Errorf("only one node can run the RPC server, and chain %q already does", h.rpcChainID)
+	}
+
+	n, err := node.NewNode(config,
+		pval,
+		nodeKey,
+		clientCreator,
+		func() (*types.GenesisDoc, error) { return genDoc, nil },
+		node.DefaultDBProvider,
+		h.metricsProvider,
+		h.Logger.With("chain_id", chainID),
+		options...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create node for chain %q: %w", chainID, err)
+	}
+
+	if h.IsRunning() {
+		if err := n.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start node for chain %q: %w", chainID, err)
+		}
+	}
+
+	h.nodes = append(h.nodes, n)
+	h.chainIDs[chainID] = true
+	h.p2pAddrs[config.P2P.ListenAddress] = chainID
+	if config.RPC.ListenAddress != "" {
+		h.rpcChainID = chainID
+	}
+	return n, nil
+}
+
+// Nodes returns the nodes of the host, in the order they were added.
+func (h *Host) Nodes() []*node.Node {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return append([]*node.Node(nil), h.nodes...)
+}
+
+// Node returns the node running the given chain, if any.
+func (h *Host) Node(chainID string) (*node.Node, bool) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	for _, n := range h.nodes {
+		if n.GenesisDoc().ChainID == chainID {
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// OnStart implements service.Service by starting the Prometheus server, if
+// enabled, and all the nodes.
+func (h *Host) OnStart() error {
+	if h.instrumentation.Prometheus && h.instrumentation.PrometheusListenAddr != "" {
+		h.prometheusSrv = h.startPrometheusServer(h.instrumentation.PrometheusListenAddr)
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	for i, n := range h.nodes {
+		if err := n.Start(); err != nil {
+			for _, started := range h.nodes[:i] {
+				if err := started.Stop(); err != nil {
+					h.Logger.Error("Error stopping node", "chain_id", started.GenesisDoc().ChainID, "err", err)
+				}
+			}
+			return fmt.Errorf("failed to start node for chain %q: %w", n.GenesisDoc().ChainID, err)
+		}
+	}
+	return nil
+}
+
+// OnStop implements service.Service by stopping all the nodes, and the
+// Prometheus server.
+func (h *Host) OnStop() {
+	h.mtx.Lock()
+	for i := len(h.nodes) - 1; i >= 0; i-- {
+		n := h.nodes[i]
+		if err := n.Stop(); err != nil {
+			h.Logger.Error("Error stopping node", "chain_id", n.GenesisDoc().ChainID, "err", err)
+		}
+		n.Wait()
+	}
+	h.mtx.Unlock()
+
+	if h.prometheusSrv != nil {
+		if err := h.prometheusSrv.Close(); err != nil {
+			h.Logger.Error("Prometheus HTTP server Close", "err", err)
+		}
+	}
+}
+
+// metricsProvider implements node.MetricsProvider. Prometheus metrics can only
+// be registered once, so they're created for the first node, and labeled with
+// the chain ID of each node.
+func (h *Host) metricsProvider(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics) {
+	if !h.instrumentation.Prometheus {
+		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics()
+	}
+
+	h.metricsOnce.Do(func() {
+		namespace := h.instrumentation.Namespace
+		h.csMetrics = cs.PrometheusMetrics(namespace, "chain_id", "")
+		h.p2pMetrics = p2p.PrometheusMetrics(namespace, "chain_id", "")
+		h.memplMetrics = mempl.PrometheusMetrics(namespace, "chain_id", "")
+		h.smMetrics = sm.PrometheusMetrics(namespace, "chain_id", "")
+	})
+	return withLabels(h.csMetrics, "chain_id", chainID).(*cs.Metrics),
+		withLabels(h.p2pMetrics, "chain_id", chainID).(*p2p.Metrics),
+		withLabels(h.memplMetrics, "chain_id", chainID).(*mempl.Metrics),
+		withLabels(h.smMetrics, "chain_id", chainID).(*sm.Metrics)
+}
+
+// withLabels returns a copy of the given pointer to a Metrics struct, with
+// the given labels added to all its metrics.
+func withLabels(m interface{}, labelsAndValues ...string) interface{} {
+	v := reflect.ValueOf(m).Elem()
+	cp := reflect.New(v.Type())
+	cp.Elem().Set(v)
+	for i := 0; i < v.NumField(); i++ {
+		field := cp.Elem().Field(i)
+		if !field.CanSet() || !field.CanInterface() {
+			continue
+		}
+		switch metric := field.Interface().(type) {
+		case metrics.Counter:
+			field.Set(reflect.ValueOf(metric.With(labelsAndValues...)))
+		case metrics.Gauge:
+			field.Set(reflect.ValueOf(metric.With(labelsAndValues...)))
+		case metrics.Histogram:
+			field.Set(reflect.ValueOf(metric.With(labelsAndValues...)))
+		}
+	}
+	return cp.Interface()
+}
+
+// startPrometheusServer starts a Prometheus HTTP server, listening for metrics
+// collectors on addr.
+func (h *Host) startPrometheusServer(addr string) *http.Server {
+	srv := &http.Server{
+		Addr: addr,
+		Handler: promhttp.InstrumentMetricHandler(
+			prometheus.DefaultRegisterer, promhttp.HandlerFor(
+				prometheus.DefaultGatherer,
+				promhttp.HandlerOpts{MaxRequestsInFlight: h.instrumentation.MaxOpenConnections},
+			),
+		),
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			// Error starting or closing listener:
+			h.Logger.Error("Prometheus HTTP server ListenAndServe", "err", err)
+		}
+	}()
+	return srv
+}
@@ -0,0 +1,133 @@
+package multinode
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+func testConfig(t *testing.T, chainID string) *cfg.Config {
+	config := cfg.ResetTestRootWithChainID("multinode_host_test", chainID)
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	port, err := tmnet.GetFreePort()
+	require.NoError(t, err)
+	config.P2P.ListenAddress = fmt.Sprintf("tcp://127.0.0.1:%d", port)
+	config.RPC.ListenAddress = ""
+	config.RPC.GRPCListenAddress = ""
+	return config
+}
+
+func TestHost(t *testing.T) {
+	host := NewHost(cfg.TestInstrumentationConfig(), log.TestingLogger())
+
+	configA := testConfig(t, "chain-a")
+	configA.RPC = cfg.TestRPCConfig()
+	configB := testConfig(t, "chain-b")
+
+	_, err := host.AddNode(configA, proxy.NewLocalClientCreator(kvstore.NewApplication()))
+	require.NoError(t, err)
+	_, err = host.AddNode(configB, proxy.NewLocalClientCreator(kvstore.NewApplication()))
+	require.NoError(t, err)
+
+	require.NoError(t, host.Start())
+	defer func() {
+		require.NoError(t, host.Stop())
+	}()
+
+	for _, chainID := range []string{"chain-a", "chain-b"} {
+		n, ok := host.Node(chainID)
+		require.True(t, ok)
+
+		blocksSub, err := n.EventBus().Subscribe(context.Background(), "multinode_test", types.EventQueryNewBlock)
+		require.NoError(t, err)
+		select {
+		case msg := <-blocksSub.Out():
+			block := msg.Data().(types.EventDataNewBlock).Block
+			assert.Equal(t, chainID, block.ChainID)
+		case <-blocksSub.Cancelled():
+			t.Fatal("blocksSub was cancelled")
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for chain %v to produce a block", chainID)
+		}
+	}
+	assert.Len(t, host.Nodes(), 2)
+	_, ok := host.Node("chain-c")
+	assert.False(t, ok)
+}
+
+func TestHostAddNodeErrors(t *testing.T) {
+	host := NewHost(cfg.TestInstrumentationConfig(), log.TestingLogger())
+
+	configA := testConfig(t, "chain-a")
+	configA.RPC = cfg.TestRPCConfig()
+	_, err := host.AddNode(configA, proxy.NewLocalClientCreator(kvstore.NewApplication()))
+	require.NoError(t, err)
+
+	testCases := map[string]func(*cfg.Config){
+		"duplicate chain ID": func(config *cfg.Config) {
+			genFile := config.GenesisFile()
+			genDoc, err := types.GenesisDocFromFile(genFile)
+			require.NoError(t, err)
+			genDoc.ChainID = "chain-a"
+			require.NoError(t, genDoc.SaveAs(genFile))
+		},
+		"duplicate p2p listen address": func(config *cfg.Config) {
+			config.P2P.ListenAddress = configA.P2P.ListenAddress
+		},
+		"second RPC server": func(config *cfg.Config) {
+			config.RPC = cfg.TestRPCConfig()
+		},
+		"prometheus enabled": func(config *cfg.Config) {
+			config.Instrumentation.Prometheus = true
+		},
+	}
+	for name, malleate := range testCases {
+		malleate := malleate
+		t.Run(name, func(t *testing.T) {
+			config := testConfig(t, "chain-b")
+			malleate(config)
+			_, err := host.AddNode(config, proxy.NewLocalClientCreator(kvstore.NewApplication()))
+			assert.Error(t, err)
+		})
+	}
+	assert.Len(t, host.Nodes(), 1)
+}
+
+func TestHostMetricsProvider(t *testing.T) {
+	host := NewHost(&cfg.InstrumentationConfig{Prometheus: true, Namespace: "multinode_test"}, log.TestingLogger())
+	csA, _, _, _ := host.metricsProvider("chain-a")
+	csB, _, _, _ := host.metricsProvider("chain-b")
+	csA.Height.Set(1)
+	csB.Height.Set(2)
+
+	// the metrics are only registered once, and labeled per chain
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	heights := make(map[string]float64)
+	for _, family := range families {
+		if family.GetName() != "multinode_test_consensus_height" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "chain_id" {
+					heights[label.GetValue()] = m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"chain-a": 1, "chain-b": 2}, heights)
+}
@@ -3,17 +3,23 @@ package node
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	_ "net/http/pprof" // nolint: gosec // securely exposed on separate, optional port
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	dbm "github.com/tendermint/tm-db"
 
@@ -24,16 +30,23 @@ import (
 	cs "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/evidence"
+	"github.com/tendermint/tendermint/libs/alert"
+	"github.com/tendermint/tendermint/libs/compaction"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/light"
 	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/operator"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/p2p/pex"
 	"github.com/tendermint/tendermint/privval"
+	privvalgrpc "github.com/tendermint/tendermint/privval/grpc"
 	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/replica"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	rpccore "github.com/tendermint/tendermint/rpc/core"
 	grpccore "github.com/tendermint/tendermint/rpc/grpc"
 	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
@@ -63,7 +76,45 @@ type DBProvider func(*DBContext) (dbm.DB, error)
 // specified in the ctx.Config.
 func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
 	dbType := dbm.BackendType(ctx.Config.DBBackend)
-	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+	dir := ctx.Config.DBDirFor(ctx.ID)
+	if err := checkDBMoved(ctx.ID, ctx.Config.DBDir(), dir); err != nil {
+		return nil, err
+	}
+	return dbm.NewDB(ctx.ID, dbType, dir)
+}
+
+// checkDBMoved returns an error if the database with the given ID, set to be
+// in dir, is still in dbDir, e.g. because its directory was changed in the
+// config without moving it, which would otherwise start it from scratch.
+func checkDBMoved(id, dbDir, dir string) error {
+	if dir == dbDir {
+		return nil
+	}
+	oldPath, newPath := filepath.Join(dbDir, id+".db"), filepath.Join(dir, id+".db")
+	if !tmos.FileExists(oldPath) {
+		return nil
+	}
+	if tmos.FileExists(newPath) {
+		return fmt.Errorf("the %s database is both in %s and %s: remove the stale one", id, oldPath, newPath)
+	}
+	return fmt.Errorf("the %s database is still in %s: move it to %s (e.g. mv %s %s) or unset %s_db_dir",
+		id, dbDir, dir, oldPath, newPath, id)
+}
+
+// dbDirs returns the directories of the databases: db_dir, followed by the
+// separate directories set for some of them, sorted.
+func dbDirs(config *cfg.Config) []string {
+	dirs := []string{config.DBDir()}
+	seen := map[string]bool{config.DBDir(): true}
+	separate := make([]string, 0)
+	for _, dir := range config.SeparateDBDirs() {
+		if !seen[dir] {
+			seen[dir] = true
+			separate = append(separate, dir)
+		}
+	}
+	sort.Strings(separate)
+	return append(dirs, separate...)
 }
 
 // GenesisDocProvider returns a GenesisDoc.
@@ -99,7 +150,9 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 	return NewNode(config,
 		pval,
 		nodeKey,
-		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir(),
+			proxy.ConsensusFlushThrottle(config.ABCIConsensusFlushThrottle),
+			proxy.MempoolFlushThrottle(config.ABCIMempoolFlushThrottle)),
 		DefaultGenesisDocProviderFunc(config),
 		DefaultDBProvider,
 		DefaultMetricsProvider(config.Instrumentation),
@@ -145,6 +198,7 @@ type fastSyncReactor interface {
 //  - EVIDENCE
 //  - PEX
 //  - STATESYNC
+//  - OPERATOR
 func CustomReactors(reactors map[string]p2p.Reactor) Option {
 	return func(n *Node) {
 		for name, reactor := range reactors {
@@ -167,6 +221,56 @@ func StateProvider(stateProvider statesync.StateProvider) Option {
 	}
 }
 
+// IndexerEventTransformer sets the transformer applied to the events of the
+// txs before they are indexed, e.g. to normalize or redact them (see
+// txindex.EventTransformer). It applies from the first block committed after
+// the node is created, i.e. not to the blocks replayed during the handshake.
+func IndexerEventTransformer(transformer txindex.EventTransformer) Option {
+	return func(n *Node) {
+		n.indexerService.SetEventTransformer(transformer)
+	}
+}
+
+// MempoolAdmissionPolicy sets the policy deciding which txs are admitted into
+// the mempool, on top of the app's CheckTx, e.g. to reject the large txs of
+// some peers (see mempool.AdmissionPolicy).
+func MempoolAdmissionPolicy(policy mempl.AdmissionPolicy) Option {
+	return func(n *Node) {
+		if mempool, ok := n.mempool.(*mempl.CListMempool); ok {
+			mempool.SetAdmissionPolicy(policy)
+		}
+	}
+}
+
+// NodeInfoExtension registers application metadata (e.g. the app version or
+// feature flags) advertised to the peers in the handshake, under
+// node_info.other.extensions. It's also returned by /status, and by /net_info
+// for the peers, so that they can decide whether to stay connected based on
+// the app-level compatibility. An invalid extension (see
+// p2p.ValidateNodeInfoExtension) is logged and ignored.
+func NodeInfoExtension(key, value string) Option {
+	return func(n *Node) {
+		nodeInfo, ok := n.nodeInfo.(p2p.DefaultNodeInfo)
+		if !ok {
+			return
+		}
+		extensions := make(map[string]string, len(nodeInfo.Other.Extensions)+1)
+		for k, v := range nodeInfo.Other.Extensions {
+			extensions[k] = v
+		}
+		extensions[key] = value
+		nodeInfo.Other.Extensions = extensions
+		if err := nodeInfo.Validate(); err != nil {
+			n.Logger.Error("Ignoring invalid node info extension", "key", key, "err", err)
+			return
+		}
+
+		n.nodeInfo = nodeInfo
+		n.sw.SetNodeInfo(nodeInfo)
+		n.transport.SetNodeInfo(nodeInfo)
+	}
+}
+
 //------------------------------------------------------------------------------
 
 // Node is the highest level interface to a full Tendermint node.
@@ -201,12 +305,67 @@ type Node struct {
 	consensusState    *cs.State               // latest consensus state
 	consensusReactor  *cs.Reactor             // for participating in the consensus
 	pexReactor        *pex.Reactor            // for exchanging peer addresses
+	operatorReactor   *operator.Reactor       // for gossiping the operator info, nil if disabled
 	evidencePool      *evidence.Pool          // tracking evidence
 	proxyApp          proxy.AppConns          // connection to the application
 	rpcListeners      []net.Listener          // rpc servers
+	grpcServer        *grpccore.Server        // nil if disabled
+	grpcServeDone     chan struct{}           // closed once grpcServer stopped serving
 	txIndexer         txindex.TxIndexer
 	indexerService    *txindex.IndexerService
 	prometheusSrv     *http.Server
+
+	// archives the app's snapshots, nil if disabled
+	snapshotArchiver *statesync.SnapshotArchiver
+	livenessMonitor  *cs.LivenessMonitor
+	// records the timestamps of the blocks, nil if disabled
+	timestampAuditor *cs.TimestampAuditor
+	// saves the checkpointed metrics, nil if disabled
+	metricsCheckpointer *cs.MetricsCheckpointer
+	// checks the disk space and the peers, nil if alerts are disabled
+	alertMonitor *alert.Monitor
+	// compacts the databases, nil if disabled
+	compactionScheduler *compaction.Scheduler
+	// escalates from pruning to refusing new peers as the disk fills up, nil if
+	// disabled
+	diskWatchdog *alert.DiskWatchdog
+	// follows the upstreams in the read replica mode, nil if disabled
+	replicaFollower *replica.Follower
+}
+
+// createCompactionScheduler returns the scheduler compacting the databases, or
+// nil if the compaction is disabled. The disk watchdog needs one to compact
+// the databases on demand, which never compacts by itself if there's no
+// window.
+func createCompactionScheduler(config *cfg.Config, logger log.Logger) (*compaction.Scheduler, error) {
+	if config.DBCompactionWindow == "" && config.DiskLowFreeMB == 0 {
+		return nil, nil
+	}
+	var start, end time.Duration
+	if config.DBCompactionWindow != "" {
+		var err error
+		if start, end, err = config.DBCompactionWindowBounds(); err != nil {
+			return nil, err
+		}
+	}
+	scheduler := compaction.NewScheduler(compaction.Window{Start: start, End: end}, config.DBCompactionInterval)
+	scheduler.SetLogger(logger.With("module", "compaction"))
+	return scheduler, nil
+}
+
+// compactedDBProvider wraps the dbProvider to add the databases it opens to
+// the compaction scheduler.
+func compactedDBProvider(dbProvider DBProvider, scheduler *compaction.Scheduler, logger log.Logger) DBProvider {
+	return func(ctx *DBContext) (dbm.DB, error) {
+		db, err := dbProvider(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := scheduler.AddDB(ctx.ID, db); err != nil {
+			logger.Info("Database won't be compacted", "err", err)
+		}
+		return db, nil
+	}
 }
 
 func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.BlockStore, stateDB dbm.DB, err error) {
@@ -225,8 +384,20 @@ func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.Block
 	return
 }
 
-func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger) (proxy.AppConns, error) {
-	proxyApp := proxy.NewAppConns(clientCreator)
+func createAndStartProxyAppConns(
+	clientCreator proxy.ClientCreator,
+	config *cfg.Config,
+	genDoc *types.GenesisDoc,
+	logger log.Logger,
+) (proxy.AppConns, error) {
+	options := []proxy.MultiAppConnOption{
+		proxy.WithSlowCallLogging(config.Instrumentation.SlowABCICallThreshold, genDoc.TxHasher()),
+	}
+	if config.Instrumentation.Prometheus {
+		options = append(options, proxy.WithMetrics(
+			proxy.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", genDoc.ChainID)))
+	}
+	proxyApp := proxy.NewAppConns(clientCreator, options...)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
@@ -234,32 +405,50 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.L
 	return proxyApp, nil
 }
 
-func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
+func createAndStartEventBus(config *cfg.Config, genDoc *types.GenesisDoc,
+	logger log.Logger) (*types.EventBus, error) {
 	eventBus := types.NewEventBus()
 	eventBus.SetLogger(logger.With("module", "events"))
+	eventBus.SetTxHasher(genDoc.TxHasher())
+	if config.Instrumentation.Prometheus {
+		eventBus.SetMetrics(tmpubsub.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", genDoc.ChainID))
+	}
 	if err := eventBus.Start(); err != nil {
 		return nil, err
 	}
 	return eventBus, nil
 }
 
-func createAndStartIndexerService(config *cfg.Config, dbProvider DBProvider,
-	eventBus *types.EventBus, logger log.Logger) (*txindex.IndexerService, txindex.TxIndexer, error) {
+func createAndStartIndexerService(config *cfg.Config, dbProvider DBProvider, eventBus *types.EventBus,
+	blockStore sm.BlockStore, stateStore sm.Store, genDoc *types.GenesisDoc,
+	logger log.Logger) (*txindex.IndexerService, txindex.TxIndexer, error) {
 
-	var txIndexer txindex.TxIndexer
+	var (
+		txIndexer    txindex.TxIndexer
+		checkpointDB dbm.DB
+	)
 	switch config.TxIndex.Indexer {
 	case "kv":
 		store, err := dbProvider(&DBContext{"tx_index", config})
 		if err != nil {
 			return nil, nil, err
 		}
-		txIndexer = kv.NewTxIndex(store)
+		txIndexer = kv.NewTxIndex(store, kv.WithTxHasher(genDoc.TxHasher()),
+			kv.WithEventFilter(txindex.NewEventFilter(config.TxIndex.IndexEvents)))
+		checkpointDB = store
 	default:
 		txIndexer = &null.TxIndex{}
 	}
 
 	indexerService := txindex.NewIndexerService(txIndexer, eventBus)
 	indexerService.SetLogger(logger.With("module", "txindex"))
+	indexerService.SetQueueSize(config.TxIndex.QueueSize)
+	if checkpointDB != nil {
+		indexerService.SetCheckpoint(checkpointDB, blockStore, stateStore)
+	}
+	if config.Instrumentation.Prometheus {
+		indexerService.SetMetrics(txindex.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", genDoc.ChainID))
+	}
 	if err := indexerService.Start(); err != nil {
 		return nil, nil, err
 	}
@@ -273,11 +462,19 @@ func doHandshake(
 	genDoc *types.GenesisDoc,
 	eventBus types.BlockEventPublisher,
 	proxyApp proxy.AppConns,
+	replayFrom int64,
+	checkpoints types.Checkpoints,
+	csMetrics *cs.Metrics,
+	alerter *alert.Alerter,
 	consensusLogger log.Logger) error {
 
 	handshaker := cs.NewHandshaker(stateStore, state, blockStore, genDoc)
 	handshaker.SetLogger(consensusLogger)
 	handshaker.SetEventBus(eventBus)
+	handshaker.SetMetrics(csMetrics)
+	handshaker.SetReplayFrom(replayFrom)
+	handshaker.SetCheckpoints(checkpoints)
+	handshaker.SetAlerter(alerter)
 	if err := handshaker.Handshake(proxyApp); err != nil {
 		return fmt.Errorf("error during handshake: %v", err)
 	}
@@ -317,8 +514,9 @@ func onlyValidatorIsUs(state sm.State, pubKey crypto.PubKey) bool {
 	return bytes.Equal(pubKey.Address(), addr)
 }
 
-func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
-	state sm.State, memplMetrics *mempl.Metrics, logger log.Logger) (*mempl.Reactor, *mempl.CListMempool) {
+func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns, state sm.State,
+	txHasher types.TxHasher, eventBus *types.EventBus, memplMetrics *mempl.Metrics,
+	logger log.Logger) (*mempl.Reactor, *mempl.CListMempool) {
 
 	mempool := mempl.NewCListMempool(
 		config.Mempool,
@@ -327,6 +525,8 @@ func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
 		mempl.WithMetrics(memplMetrics),
 		mempl.WithPreCheck(sm.TxPreCheck(state)),
 		mempl.WithPostCheck(sm.TxPostCheck(state)),
+		mempl.WithTxHasher(txHasher),
+		mempl.WithEventBus(eventBus),
 	)
 	mempoolLogger := logger.With("module", "mempool")
 	mempoolReactor := mempl.NewReactor(config.Mempool, mempool)
@@ -339,14 +539,14 @@ func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
 }
 
 func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
-	stateDB dbm.DB, blockStore *store.BlockStore, logger log.Logger) (*evidence.Reactor, *evidence.Pool, error) {
+	stateStore sm.Store, blockStore *store.BlockStore, logger log.Logger) (*evidence.Reactor, *evidence.Pool, error) {
 
 	evidenceDB, err := dbProvider(&DBContext{"evidence", config})
 	if err != nil {
 		return nil, nil, err
 	}
 	evidenceLogger := logger.With("module", "evidence")
-	evidencePool, err := evidence.NewPool(evidenceDB, sm.NewStore(stateDB), blockStore)
+	evidencePool, err := evidence.NewPool(evidenceDB, stateStore, blockStore)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -360,13 +560,18 @@ func createBlockchainReactor(config *cfg.Config,
 	blockExec *sm.BlockExecutor,
 	blockStore *store.BlockStore,
 	fastSync bool,
+	checkpoints types.Checkpoints,
 	logger log.Logger) (bcReactor p2p.Reactor, err error) {
 
 	switch config.FastSync.Version {
 	case "v0":
-		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+		r := bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+		r.SetCheckpoints(checkpoints)
+		bcReactor = r
 	case "v2":
-		bcReactor = bcv2.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+		r := bcv2.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+		r.SetCheckpoints(checkpoints)
+		bcReactor = r
 	default:
 		return nil, fmt.Errorf("unknown fastsync version %s", config.FastSync.Version)
 	}
@@ -383,10 +588,13 @@ func createConsensusReactor(config *cfg.Config,
 	evidencePool *evidence.Pool,
 	privValidator types.PrivValidator,
 	csMetrics *cs.Metrics,
+	alerter *alert.Alerter,
 	waitSync bool,
 	eventBus *types.EventBus,
-	consensusLogger log.Logger) (*cs.Reactor, *cs.State) {
+	consensusLogger log.Logger,
+	options ...cs.StateOption) (*cs.Reactor, *cs.State) {
 
+	options = append([]cs.StateOption{cs.StateMetrics(csMetrics), cs.StateAlerter(alerter)}, options...)
 	consensusState := cs.NewState(
 		config.Consensus,
 		state.Copy(),
@@ -394,7 +602,7 @@ func createConsensusReactor(config *cfg.Config,
 		blockStore,
 		mempool,
 		evidencePool,
-		cs.StateMetrics(csMetrics),
+		options...,
 	)
 	consensusState.SetLogger(consensusLogger)
 	if privValidator != nil {
@@ -413,9 +621,11 @@ func createTransport(
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
 	proxyApp proxy.AppConns,
+	p2pMetrics *p2p.Metrics,
 ) (
 	*p2p.MultiplexTransport,
 	[]p2p.PeerFilterFunc,
+	error,
 ) {
 	var (
 		mConnConfig = p2p.MConnConfig(config.P2P)
@@ -471,10 +681,133 @@ func createTransport(
 	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
 
 	// Limit the number of incoming connections.
-	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
+	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " ")) +
+		len(splitAndTrimEmpty(config.P2P.PriorityPeerIDs, ",", " "))
 	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
+	p2p.MultiplexTransportSecretConnRekey(config.P2P.SecretConnRekeyBytes, config.P2P.SecretConnRekeyInterval)(transport)
+	p2p.MultiplexTransportDialTimeout(config.P2P.DialTimeout)(transport)
+	p2p.MultiplexTransportHandshakeTimeout(config.P2P.HandshakeTimeout)(transport)
+	p2p.MultiplexTransportMaxConcurrentHandshakes(config.P2P.MaxConcurrentHandshakes)(transport)
+	p2p.MultiplexTransportMaxAcceptRatePerIP(config.P2P.MaxAcceptRatePerIP)(transport)
+	p2p.MultiplexTransportMetrics(p2pMetrics)(transport)
+
+	if config.P2P.TLS {
+		cert, _, err := p2p.LoadTLSCertificate(config.P2P.TLSCertPath(), config.P2P.TLSKeyPath())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load the p2p TLS certificate: %w", err)
+		}
+		pinnedIDs, err := config.P2P.TLSPinnedCertIDs()
+		if err != nil {
+			return nil, nil, err
+		}
+		pins := make(map[string]p2p.ID, len(pinnedIDs))
+		for fingerprint, id := range pinnedIDs {
+			pins[fingerprint] = p2p.ID(id)
+		}
+		p2p.MultiplexTransportTLS(cert, pins)(transport)
+	}
+
+	return transport, peerFilters, nil
+}
+
+func createLivenessMonitor(config *cfg.Config, pubKey crypto.PubKey, eventBus *types.EventBus,
+	stateStore sm.Store, alerter *alert.Alerter, consensusLogger log.Logger) *cs.LivenessMonitor {
+	options := []cs.LivenessMonitorOption{}
+	if config.Consensus.LivenessWebhookURL != "" {
+		options = append(options, cs.LivenessWebhook(config.Consensus.LivenessWebhookURL))
+	}
+	if alerter != nil || config.Consensus.LivenessExit {
+		options = append(options, cs.LivenessOnDown(func(la cs.LivenessAlert) {
+			msg := fmt.Sprintf("validator is down: missed %d consecutive blocks at height %d",
+				la.MissedBlocks, la.Height)
+			alerter.Alert(alert.ValidatorDown, "%s", msg)
+			if config.Consensus.LivenessExit {
+				tmos.Exit(msg)
+			}
+		}))
+	}
+	livenessMonitor := cs.NewLivenessMonitor(pubKey.Address(), config.Consensus.LivenessMissedBlocks,
+		eventBus, stateStore, options...)
+	livenessMonitor.SetLogger(consensusLogger)
+	return livenessMonitor
+}
 
-	return transport, peerFilters
+// createAlerter returns the alerter notifying the sinks of the [alerts]
+// section, or nil if there is none.
+func createAlerter(config *cfg.Config, logger log.Logger) (*alert.Alerter, error) {
+	if !config.Alerts.Enabled() {
+		return nil, nil
+	}
+	sinks := make([]alert.Sink, 0, len(config.Alerts.Webhooks)+1)
+	for _, webhook := range config.Alerts.Webhooks {
+		sinks = append(sinks, alert.NewWebhookSink(webhook))
+	}
+	if config.Alerts.Command != "" {
+		sinks = append(sinks, alert.CommandSink{Command: config.Alerts.Command})
+	}
+	alerter, err := alert.NewAlerter(config.Moniker, sinks, config.Alerts.Types, config.Alerts.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	alerter.SetLogger(logger)
+	return alerter, nil
+}
+
+// interval of the disk space and peers checks of the alert monitor
+const alertMonitorInterval = 10 * time.Second
+
+func createAlertMonitor(config *cfg.Config, alerter *alert.Alerter, sw *p2p.Switch,
+	logger log.Logger) *alert.Monitor {
+	options := []alert.MonitorOption{}
+	if config.Alerts.MinFreeDiskMB > 0 {
+		options = append(options, alert.MonitorDiskSpace(dbDirs(config), uint64(config.Alerts.MinFreeDiskMB)<<20))
+	}
+	if config.Alerts.NoPeersTimeout > 0 {
+		options = append(options, alert.MonitorPeers(func() int { return sw.Peers().Size() },
+			config.Alerts.NoPeersTimeout))
+	}
+	monitor := alert.NewMonitor(alerter, alertMonitorInterval, options...)
+	monitor.SetLogger(logger)
+	return monitor
+}
+
+// interval of the free disk space checks of the disk watchdog
+const diskWatchdogInterval = 10 * time.Second
+
+func createDiskWatchdog(config *cfg.Config, alerter *alert.Alerter, sw *p2p.Switch,
+	compactionScheduler *compaction.Scheduler, snapshotArchiver *statesync.SnapshotArchiver,
+	logger log.Logger) *alert.DiskWatchdog {
+	options := []alert.DiskWatchdogOption{
+		alert.DiskWatchdogPrune(func() { compactionScheduler.CompactAll() }),
+		alert.DiskWatchdogOnCritical(sw.SetRefuseNewPeers),
+	}
+	if snapshotArchiver != nil {
+		options = append(options, alert.DiskWatchdogPrune(func() {
+			if err := snapshotArchiver.PruneAllButLatest(); err != nil {
+				logger.Error("Failed to prune the snapshot archive", "err", err)
+			}
+		}))
+	}
+	watchdog := alert.NewDiskWatchdog(dbDirs(config), uint64(config.DiskLowFreeMB)<<20,
+		uint64(config.DiskCriticalFreeMB)<<20, diskWatchdogInterval, alerter, options...)
+	watchdog.SetLogger(logger)
+	return watchdog
+}
+
+func createReplicaFollower(config *cfg.Config, state sm.State, blockExec *sm.BlockExecutor,
+	blockStore *store.BlockStore, logger log.Logger) (*replica.Follower, error) {
+	upstreams := make([]replica.Upstream, 0, len(config.Replica.Upstreams))
+	for _, addr := range config.Replica.Upstreams {
+		upstream, err := rpchttp.New(addr, "/websocket")
+		if err != nil {
+			return nil, fmt.Errorf("invalid upstream %q: %w", addr, err)
+		}
+		upstreams = append(upstreams, upstream)
+	}
+	follower := replica.NewFollower(state, blockExec, blockStore, upstreams,
+		config.Replica.PollInterval, config.Replica.Timeout)
+	follower.SetLogger(logger.With("module", "replica"))
+	return follower, nil
 }
 
 func createSwitch(config *cfg.Config,
@@ -488,13 +821,45 @@ func createSwitch(config *cfg.Config,
 	evidenceReactor *evidence.Reactor,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
-	p2pLogger log.Logger) *p2p.Switch {
+	alerter *alert.Alerter,
+	p2pLogger log.Logger) (*p2p.Switch, error) {
+
+	chaos, err := p2p.ChaosConfigFromP2PConfig(config.P2P)
+	if err != nil {
+		return nil, err
+	}
+	if chaos != nil {
+		p2pLogger.Error("Chaos mode is enabled, faults will be injected into the messages sent to peers")
+	}
+	msgAuth, err := p2p.MessageAuthConfigFromP2PConfig(config.P2P, nodeKey)
+	if err != nil {
+		return nil, err
+	}
+	var capture *p2p.TrafficCapture
+	captureCfg, err := p2p.CaptureConfigFromP2PConfig(config.P2P)
+	if err != nil {
+		return nil, err
+	}
+	if captureCfg != nil {
+		if capture, err = p2p.NewTrafficCapture(captureCfg); err != nil {
+			return nil, fmt.Errorf("failed to open the capture file: %w", err)
+		}
+		capture.SetLogger(p2pLogger)
+		p2pLogger.Info("Recording the envelopes exchanged with the peers", "file", captureCfg.Path)
+	}
 
 	sw := p2p.NewSwitch(
 		config.P2P,
 		transport,
 		p2p.WithMetrics(p2pMetrics),
 		p2p.SwitchPeerFilters(peerFilters...),
+		p2p.SwitchChaos(chaos),
+		p2p.SwitchMessageAuth(msgAuth),
+		p2p.SwitchTrafficCapture(capture),
+		p2p.SwitchPersistentPeerUnreachable(func(addr *p2p.NetAddress, attempts int) {
+			alerter.Alert(alert.PersistentPeerUnreachable,
+				"gave up reconnecting to persistent peer %v after %d attempts", addr, attempts)
+		}),
 	)
 	sw.SetLogger(p2pLogger)
 	sw.AddReactor("MEMPOOL", mempoolReactor)
@@ -507,7 +872,7 @@ func createSwitch(config *cfg.Config,
 	sw.SetNodeKey(nodeKey)
 
 	p2pLogger.Info("P2P Node ID", "ID", nodeKey.ID(), "file", config.NodeKeyFile())
-	return sw
+	return sw, nil
 }
 
 func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
@@ -558,6 +923,20 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 	return pexReactor
 }
 
+func createOperatorReactorAndAddToSwitch(config *cfg.Config, genDoc *types.GenesisDoc, stateStore sm.Store,
+	privValidator types.PrivValidator, nodeKey *p2p.NodeKey, sw *p2p.Switch, logger log.Logger) *operator.Reactor {
+	operatorLogger := logger.With("module", "operator")
+	var own *operator.Info
+	if privValidator != nil {
+		own = operator.NewOwnInfo(genDoc.ChainID, config.Moniker, config.Operator, privValidator, operatorLogger)
+	}
+	ownAttestation := operator.NewOwnSentryAttestation(genDoc.ChainID, config.Operator, nodeKey, operatorLogger)
+	operatorReactor := operator.NewReactor(config.Operator, genDoc.ChainID, stateStore, own, ownAttestation)
+	operatorReactor.SetLogger(operatorLogger)
+	sw.AddReactor("OPERATOR", operatorReactor)
+	return operatorReactor
+}
+
 // startStateSync starts an asynchronous state sync process, then switches to fast sync mode.
 func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reactor,
 	stateProvider statesync.StateProvider, config *cfg.StateSyncConfig, fastSync bool,
@@ -575,7 +954,9 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 				Period: config.TrustPeriod,
 				Height: config.TrustHeight,
 				Hash:   config.TrustHashBytes(),
-			}, ssR.Logger.With("module", "light"))
+			}, ssR.Logger.With("module", "light"),
+			statesync.MinWitnesses(config.MinWitnesses),
+			statesync.MaxClockDrift(config.MaxClockDrift))
 		if err != nil {
 			return fmt.Errorf("failed to set up light client state provider: %w", err)
 		}
@@ -625,20 +1006,48 @@ func NewNode(config *cfg.Config,
 	logger log.Logger,
 	options ...Option) (*Node, error) {
 
-	blockStore, stateDB, err := initDBs(config, dbProvider)
+	compactionScheduler, err := createCompactionScheduler(config, logger)
 	if err != nil {
 		return nil, err
 	}
+	if compactionScheduler != nil {
+		dbProvider = compactedDBProvider(dbProvider, compactionScheduler, logger)
+	}
 
-	stateStore := sm.NewStore(stateDB)
+	blockStore, stateDB, err := initDBs(config, dbProvider)
+	if err != nil {
+		return nil, err
+	}
 
 	state, genDoc, err := LoadStateFromDBOrGenesisDocProvider(stateDB, genesisDocProvider)
 	if err != nil {
 		return nil, err
 	}
 
+	csMetrics, p2pMetrics, memplMetrics, smMetrics := metricsProvider(genDoc.ChainID)
+
+	// Restore the checkpointed metrics, if any.
+	var metricsCheckpointer *cs.MetricsCheckpointer
+	if len(config.Instrumentation.CheckpointedMetrics) > 0 {
+		metricsCheckpointer, err = createMetricsCheckpointer(config, dbProvider, csMetrics, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stateStore := sm.NewStore(stateDB, sm.StoreCacheSize(config.StateStoreCacheSize), sm.StoreMetrics(smMetrics),
+		sm.StorePruningExemptions(config.PruningExemptions))
+
+	if config.Instrumentation.Prometheus {
+		blockStore.SetMetrics(store.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", genDoc.ChainID))
+		if compactionScheduler != nil {
+			compactionScheduler.SetMetrics(
+				compaction.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", genDoc.ChainID))
+		}
+	}
+
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger)
+	proxyApp, err := createAndStartProxyAppConns(clientCreator, config, genDoc, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -647,20 +1056,26 @@ func NewNode(config *cfg.Config,
 	// we might need to index the txs of the replayed block as this might not have happened
 	// when the node stopped last time (i.e. the node stopped after it saved the block
 	// but before it indexed the txs, or, endblocker panicked)
-	eventBus, err := createAndStartEventBus(logger)
+	eventBus, err := createAndStartEventBus(config, genDoc, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	// Transaction indexing
-	indexerService, txIndexer, err := createAndStartIndexerService(config, dbProvider, eventBus, logger)
+	indexerService, txIndexer, err := createAndStartIndexerService(config, dbProvider, eventBus, blockStore, stateStore,
+		genDoc, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	// If an address is provided, listen on the socket for a connection from an
-	// external signing process.
-	if config.PrivValidatorListenAddr != "" {
+	// external signing process, or dial its gRPC server.
+	if strings.HasPrefix(config.PrivValidatorListenAddr, privvalgrpc.AddrPrefix) {
+		privValidator, err = createPrivValidatorGRPCClient(config, genDoc.ChainID, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error with private validator grpc client: %w", err)
+		}
+	} else if config.PrivValidatorListenAddr != "" {
 		// FIXME: we should start services inside OnStart
 		privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID, logger)
 		if err != nil {
@@ -673,18 +1088,31 @@ func NewNode(config *cfg.Config,
 		return nil, fmt.Errorf("can't get pubkey: %w", err)
 	}
 
-	// Determine whether we should attempt state sync.
-	stateSync := config.StateSync.Enable && !onlyValidatorIsUs(state, pubKey)
+	// Determine whether we should attempt state sync. A read replica only
+	// syncs from its upstreams.
+	stateSync := config.StateSync.Enable && !config.Replica.Enable && !onlyValidatorIsUs(state, pubKey)
 	if stateSync && state.LastBlockHeight > 0 {
 		logger.Info("Found local state with non-zero height, skipping state sync")
 		stateSync = false
 	}
 
+	alertLogger := logger.With("module", "alert")
+	alerter, err := createAlerter(config, alertLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoints, err := config.FastSync.ParseCheckpoints()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the handshaker, which calls RequestInfo, sets the AppVersion on the state,
 	// and replays any blocks as necessary to sync tendermint with the app.
 	consensusLogger := logger.With("module", "consensus")
 	if !stateSync {
-		if err := doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp, consensusLogger); err != nil {
+		if err := doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp,
+			config.Consensus.ReplayFromHeight, checkpoints, csMetrics, alerter, consensusLogger); err != nil {
 			return nil, err
 		}
 
@@ -699,56 +1127,138 @@ func NewNode(config *cfg.Config,
 
 	// Determine whether we should do fast sync. This must happen after the handshake, since the
 	// app may modify the validator set, specifying ourself as the only validator.
-	fastSync := config.FastSyncMode && !onlyValidatorIsUs(state, pubKey)
+	fastSync := config.FastSyncMode && !config.Replica.Enable && !onlyValidatorIsUs(state, pubKey)
 
 	logNodeStartupInfo(state, pubKey, logger, consensusLogger)
 
-	csMetrics, p2pMetrics, memplMetrics, smMetrics := metricsProvider(genDoc.ChainID)
-
 	// Make MempoolReactor
-	mempoolReactor, mempool := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, logger)
+	mempoolReactor, mempool := createMempoolAndMempoolReactor(config, proxyApp, state, genDoc.TxHasher(), eventBus,
+		memplMetrics, logger)
 
 	// Make Evidence Reactor
-	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateDB, blockStore, logger)
+	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateStore, blockStore, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	// make block executor for consensus and blockchain reactors to execute blocks
+	blockExecOptions := []sm.BlockExecutorOption{
+		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.BlockExecutorWithQueryApp(proxyApp.Query()),
+	}
+	if addr := config.Consensus.BlockBuilderAddress; addr != "" {
+		blockBuilder, err := sm.NewHTTPBlockBuilder(addr)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Requesting the txs of the proposed blocks from the block builder", "addr", addr)
+		blockExecOptions = append(blockExecOptions,
+			sm.BlockExecutorWithBlockBuilder(blockBuilder, config.Consensus.BlockBuilderTimeout))
+	}
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
 		logger.With("module", "state"),
 		proxyApp.Consensus(),
 		mempool,
 		evidencePool,
-		sm.BlockExecutorWithMetrics(smMetrics),
+		blockExecOptions...,
 	)
 
 	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
-	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, fastSync && !stateSync, logger)
+	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, fastSync && !stateSync,
+		checkpoints, logger)
 	if err != nil {
 		return nil, fmt.Errorf("could not create blockchain reactor: %w", err)
 	}
 
-	// Make ConsensusReactor. Don't enable fully if doing a state sync and/or fast sync first.
+	// Set up the read replica follower, if enabled.
+	var replicaFollower *replica.Follower
+	if config.Replica.Enable {
+		replicaFollower, err = createReplicaFollower(config, state, blockExec, blockStore, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Make ConsensusReactor. Don't enable fully if doing a state sync and/or
+	// fast sync first, nor at all in the read replica mode.
 	// FIXME We need to update metrics here, since other reactors don't have access to them.
 	if stateSync {
 		csMetrics.StateSyncing.Set(1)
 	} else if fastSync {
 		csMetrics.FastSyncing.Set(1)
 	}
+	// After an app hash mismatch, the node enters the inspect-only mode: the
+	// p2p layer (sw, set below) is stopped and the RPC stays up.
+	var sw *p2p.Switch
+	inspect := func(bundle string) {
+		consensusLogger.Error("Entering the inspect-only mode, stopping the p2p layer", "forensics", bundle)
+		go func() {
+			if err := sw.Stop(); err != nil {
+				consensusLogger.Error("Failed to stop the p2p layer", "err", err)
+			}
+		}()
+	}
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool, evidencePool,
-		privValidator, csMetrics, stateSync || fastSync, eventBus, consensusLogger,
+		privValidator, csMetrics, alerter, stateSync || fastSync || config.Replica.Enable, eventBus, consensusLogger,
+		cs.StateForensics(config.Consensus.ForensicsDirPath(), inspect),
+		cs.StateBlockPartsRetainHeights(config.BlockPartsRetainHeights),
 	)
+	if replicaFollower != nil {
+		// the replica is no longer catching up once it reached its upstream
+		replicaFollower.SetOnCaughtUp(consensusReactor.FinishSync)
+	}
 
 	// Set up state sync reactor, and schedule a sync if requested.
 	// FIXME The way we do phased startups (e.g. replay -> fast sync -> consensus) is very messy,
 	// we should clean this whole thing up. See:
 	// https://github.com/tendermint/tendermint/issues/4644
+	ssMetrics := statesync.NopMetrics()
+	if config.Instrumentation.Prometheus {
+		ssMetrics = statesync.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", genDoc.ChainID)
+	}
+	snapshotFormats, err := config.StateSync.PreferredSnapshotFormatList()
+	if err != nil {
+		return nil, err
+	}
+	snapshotPolicy, err := statesync.NewRuleSnapshotPolicy(config.StateSync.SnapshotPreference, snapshotFormats)
+	if err != nil {
+		return nil, err
+	}
 	stateSyncReactor := statesync.NewReactor(proxyApp.Snapshot(), proxyApp.Query(),
-		config.StateSync.TempDir)
+		config.StateSync.TempDir, statesync.WithMetrics(ssMetrics),
+		statesync.WithAdvertisePacing(config.StateSync.AdvertiseJitter, config.StateSync.AdvertiseInterval),
+		statesync.WithSnapshotPolicy(snapshotPolicy))
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
+	stateSyncReactor.SetEventBus(eventBus)
+
+	// Set up the snapshot archiver, if enabled.
+	var snapshotArchiver *statesync.SnapshotArchiver
+	if config.StateSync.SnapshotInterval > 0 {
+		snapshotArchiver = statesync.NewSnapshotArchiver(config.StateSync.SnapshotDirPath(),
+			config.StateSync.SnapshotInterval, config.StateSync.SnapshotKeepRecent,
+			proxyApp.Snapshot(), stateStore, blockStore, eventBus)
+		snapshotArchiver.SetLogger(logger.With("module", "statesync"))
+	}
+
+	// Set up the validator liveness monitor, if enabled.
+	var livenessMonitor *cs.LivenessMonitor
+	if config.Consensus.LivenessMissedBlocks > 0 {
+		livenessMonitor = createLivenessMonitor(config, pubKey, eventBus, stateStore, alerter, consensusLogger)
+	}
+
+	// Set up the block timestamp audit, if enabled.
+	var timestampAuditor *cs.TimestampAuditor
+	if config.Consensus.TimestampAuditHeights > 0 {
+		timestampDB, err := dbProvider(&DBContext{"timestamps", config})
+		if err != nil {
+			return nil, err
+		}
+		timestampAuditor = cs.NewTimestampAuditor(cs.NewTimestampAuditStore(timestampDB), eventBus,
+			config.Consensus.TimestampAuditHeights)
+		timestampAuditor.SetLogger(consensusLogger)
+	}
 
 	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state)
 	if err != nil {
@@ -756,14 +1266,20 @@ func NewNode(config *cfg.Config,
 	}
 
 	// Setup Transport.
-	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	transport, peerFilters, err := createTransport(config, nodeInfo, nodeKey, proxyApp, p2pMetrics)
+	if err != nil {
+		return nil, err
+	}
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
-	sw := createSwitch(
+	sw, err = createSwitch(
 		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
-		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
+		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, alerter, p2pLogger,
 	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create switch: %w", err)
+	}
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
 	if err != nil {
@@ -775,6 +1291,30 @@ func NewNode(config *cfg.Config,
 		return nil, fmt.Errorf("could not add peer ids from unconditional_peer_ids field: %w", err)
 	}
 
+	err = sw.AddPriorityPeerIDs(splitAndTrimEmpty(config.P2P.PriorityPeerIDs, ",", " "))
+	if err != nil {
+		return nil, fmt.Errorf("could not add peer ids from priority_peer_ids field: %w", err)
+	}
+
+	err = sw.SetPeerIDLists(splitAndTrimEmpty(config.P2P.AllowedPeers, ",", " "),
+		splitAndTrimEmpty(config.P2P.DeniedPeers, ",", " "))
+	if err != nil {
+		return nil, fmt.Errorf("could not set peer ids from allowed_peers/denied_peers fields: %w", err)
+	}
+
+	// Set up the disk space and peers checks, if alerts are enabled.
+	var alertMonitor *alert.Monitor
+	if alerter != nil {
+		alertMonitor = createAlertMonitor(config, alerter, sw, alertLogger)
+	}
+
+	// Set up the disk watchdog, if enabled.
+	var diskWatchdog *alert.DiskWatchdog
+	if config.DiskLowFreeMB > 0 {
+		diskWatchdog = createDiskWatchdog(config, alerter, sw, compactionScheduler, snapshotArchiver,
+			logger.With("module", "diskwatchdog"))
+	}
+
 	addrBook, err := createAddrBookAndSetOnSwitch(config, sw, p2pLogger, nodeKey)
 	if err != nil {
 		return nil, fmt.Errorf("could not create addrbook: %w", err)
@@ -797,10 +1337,19 @@ func NewNode(config *cfg.Config,
 		pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, logger)
 	}
 
+	var operatorReactor *operator.Reactor
+	if config.Operator.Gossip {
+		operatorReactor = createOperatorReactorAndAddToSwitch(config, genDoc, stateStore, privValidator, nodeKey, sw,
+			logger)
+	}
+
 	if config.RPC.PprofListenAddress != "" {
 		go func() {
 			logger.Info("Starting pprof server", "laddr", config.RPC.PprofListenAddress)
-			logger.Error("pprof server error", "err", http.ListenAndServe(config.RPC.PprofListenAddress, nil))
+			mux := http.NewServeMux()
+			mux.Handle("/debug/p2p/channels", channelStatsHandler(sw))
+			mux.Handle("/", http.DefaultServeMux) // pprof
+			logger.Error("pprof server error", "err", http.ListenAndServe(config.RPC.PprofListenAddress, mux))
 		}()
 	}
 
@@ -815,22 +1364,32 @@ func NewNode(config *cfg.Config,
 		nodeInfo:  nodeInfo,
 		nodeKey:   nodeKey,
 
-		stateStore:       stateStore,
-		blockStore:       blockStore,
-		bcReactor:        bcReactor,
-		mempoolReactor:   mempoolReactor,
-		mempool:          mempool,
-		consensusState:   consensusState,
-		consensusReactor: consensusReactor,
-		stateSyncReactor: stateSyncReactor,
-		stateSync:        stateSync,
-		stateSyncGenesis: state, // Shouldn't be necessary, but need a way to pass the genesis state
-		pexReactor:       pexReactor,
-		evidencePool:     evidencePool,
-		proxyApp:         proxyApp,
-		txIndexer:        txIndexer,
-		indexerService:   indexerService,
-		eventBus:         eventBus,
+		stateStore:          stateStore,
+		blockStore:          blockStore,
+		bcReactor:           bcReactor,
+		mempoolReactor:      mempoolReactor,
+		mempool:             mempool,
+		consensusState:      consensusState,
+		consensusReactor:    consensusReactor,
+		stateSyncReactor:    stateSyncReactor,
+		stateSync:           stateSync,
+		stateSyncGenesis:    state, // Shouldn't be necessary, but need a way to pass the genesis state
+		snapshotArchiver:    snapshotArchiver,
+		livenessMonitor:     livenessMonitor,
+		timestampAuditor:    timestampAuditor,
+		alertMonitor:        alertMonitor,
+		metricsCheckpointer: metricsCheckpointer,
+		pexReactor:          pexReactor,
+		operatorReactor:     operatorReactor,
+		evidencePool:        evidencePool,
+		proxyApp:            proxyApp,
+		txIndexer:           txIndexer,
+		indexerService:      indexerService,
+		eventBus:            eventBus,
+
+		compactionScheduler: compactionScheduler,
+		diskWatchdog:        diskWatchdog,
+		replicaFollower:     replicaFollower,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -868,6 +1427,67 @@ func (n *Node) OnStart() error {
 		n.prometheusSrv = n.startPrometheusServer(n.config.Instrumentation.PrometheusListenAddr)
 	}
 
+	if n.config.Mempool.WalEnabled() {
+		err := n.mempool.InitWAL()
+		if err != nil {
+			return fmt.Errorf("init mempool WAL: %w", err)
+		}
+	}
+	if n.config.Mempool.ExportFile != "" {
+		if err := n.importMempool(); err != nil {
+			return fmt.Errorf("failed to import mempool txs: %w", err)
+		}
+	}
+
+	if n.snapshotArchiver != nil {
+		if err := n.snapshotArchiver.Start(); err != nil {
+			return fmt.Errorf("failed to start snapshot archiver: %w", err)
+		}
+	}
+
+	if n.livenessMonitor != nil {
+		if err := n.livenessMonitor.Start(); err != nil {
+			return fmt.Errorf("failed to start liveness monitor: %w", err)
+		}
+	}
+
+	if n.timestampAuditor != nil {
+		if err := n.timestampAuditor.Start(); err != nil {
+			return fmt.Errorf("failed to start timestamp auditor: %w", err)
+		}
+	}
+
+	if n.metricsCheckpointer != nil {
+		if err := n.metricsCheckpointer.Start(); err != nil {
+			return fmt.Errorf("failed to start metrics checkpointer: %w", err)
+		}
+	}
+
+	if n.alertMonitor != nil {
+		if err := n.alertMonitor.Start(); err != nil {
+			return fmt.Errorf("failed to start alert monitor: %w", err)
+		}
+	}
+
+	if n.compactionScheduler != nil {
+		if err := n.compactionScheduler.Start(); err != nil {
+			return fmt.Errorf("failed to start compaction scheduler: %w", err)
+		}
+	}
+
+	if n.diskWatchdog != nil {
+		if err := n.diskWatchdog.Start(); err != nil {
+			return fmt.Errorf("failed to start disk watchdog: %w", err)
+		}
+	}
+
+	// In the read replica mode, follow the upstreams instead of starting the
+	// p2p layer.
+	if n.replicaFollower != nil {
+		n.Logger.Info("Starting in the read replica mode", "upstreams", n.config.Replica.Upstreams)
+		return n.replicaFollower.Start()
+	}
+
 	// Start the transport.
 	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(n.nodeKey.ID(), n.config.P2P.ListenAddress))
 	if err != nil {
@@ -879,13 +1499,6 @@ func (n *Node) OnStart() error {
 
 	n.isListening = true
 
-	if n.config.Mempool.WalEnabled() {
-		err = n.mempool.InitWAL()
-		if err != nil {
-			return fmt.Errorf("init mempool WAL: %w", err)
-		}
-	}
-
 	// Start the switch (the P2P server).
 	err = n.sw.Start()
 	if err != nil {
@@ -914,6 +1527,48 @@ func (n *Node) OnStart() error {
 	return nil
 }
 
+// importMempool checks the txs of the mempool export file, if any, adding the
+// valid ones to the mempool, then removes the file.
+func (n *Node) importMempool() error {
+	path := n.config.Mempool.ExportFilePath()
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	added, err := n.mempool.Import(f)
+	if err != nil {
+		return err
+	}
+	n.Logger.Info("Imported mempool txs", "added", added, "file", path)
+	return os.Remove(path)
+}
+
+// exportMempool writes the txs of the mempool to the mempool export file.
+func (n *Node) exportMempool() error {
+	path := n.config.Mempool.ExportFilePath()
+	// write to a temporary file, so a failed export doesn't leave a truncated
+	// file to be imported
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	exported, err := n.mempool.Export(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	n.Logger.Info("Exported mempool txs", "exported", exported, "file", path)
+	return os.Rename(tmp, path)
+}
+
 // OnStop stops the Node. It implements service.Service.
 func (n *Node) OnStop() {
 	n.BaseService.OnStop()
@@ -927,10 +1582,59 @@ func (n *Node) OnStop() {
 	if err := n.indexerService.Stop(); err != nil {
 		n.Logger.Error("Error closing indexerService", "err", err)
 	}
+	if n.snapshotArchiver != nil {
+		if err := n.snapshotArchiver.Stop(); err != nil {
+			n.Logger.Error("Error closing snapshotArchiver", "err", err)
+		}
+	}
+	if n.livenessMonitor != nil {
+		if err := n.livenessMonitor.Stop(); err != nil {
+			n.Logger.Error("Error closing livenessMonitor", "err", err)
+		}
+	}
+	if n.timestampAuditor != nil {
+		if err := n.timestampAuditor.Stop(); err != nil {
+			n.Logger.Error("Error closing timestampAuditor", "err", err)
+		}
+	}
+	if n.metricsCheckpointer != nil {
+		if err := n.metricsCheckpointer.Stop(); err != nil {
+			n.Logger.Error("Error closing metricsCheckpointer", "err", err)
+		}
+	}
+	if n.alertMonitor != nil {
+		if err := n.alertMonitor.Stop(); err != nil {
+			n.Logger.Error("Error closing alertMonitor", "err", err)
+		}
+	}
+	if n.diskWatchdog != nil {
+		if err := n.diskWatchdog.Stop(); err != nil {
+			n.Logger.Error("Error closing diskWatchdog", "err", err)
+		}
+	}
+	if n.compactionScheduler != nil {
+		if err := n.compactionScheduler.Stop(); err != nil {
+			n.Logger.Error("Error closing compactionScheduler", "err", err)
+		}
+	}
+
+	if n.replicaFollower != nil {
+		if err := n.replicaFollower.Stop(); err != nil {
+			n.Logger.Error("Error closing replicaFollower", "err", err)
+		}
+	}
 
 	// now stop the reactors
-	if err := n.sw.Stop(); err != nil {
-		n.Logger.Error("Error closing switch", "err", err)
+	if n.sw.IsRunning() {
+		if err := n.sw.Stop(); err != nil {
+			n.Logger.Error("Error closing switch", "err", err)
+		}
+	}
+
+	if n.config.Mempool.ExportFile != "" {
+		if err := n.exportMempool(); err != nil {
+			n.Logger.Error("Failed to export mempool txs", "err", err)
+		}
 	}
 
 	// stop mempool WAL
@@ -945,6 +1649,11 @@ func (n *Node) OnStop() {
 	n.isListening = false
 
 	// finally stop the listeners / external services
+	if n.grpcServer != nil {
+		n.Logger.Info("Stopping gRPC server")
+		n.grpcServer.Stop()
+		<-n.grpcServeDone
+	}
 	for _, l := range n.rpcListeners {
 		n.Logger.Info("Closing rpc listener", "listener", l)
 		if err := l.Close(); err != nil {
@@ -957,6 +1666,21 @@ func (n *Node) OnStop() {
 			n.Logger.Error("Error closing private validator", "err", err)
 		}
 	}
+	if pvsc, ok := n.privValidator.(*privvalgrpc.SignerClient); ok {
+		if err := pvsc.Close(); err != nil {
+			n.Logger.Error("Error closing private validator", "err", err)
+		}
+	}
+
+	// zero the private keys held in memory
+	if pv, ok := n.privValidator.(interface{ Destroy() error }); ok {
+		if err := pv.Destroy(); err != nil {
+			n.Logger.Error("Error destroying private validator key", "err", err)
+		}
+	}
+	if err := n.nodeKey.Destroy(); err != nil {
+		n.Logger.Error("Error destroying node key", "err", err)
+	}
 
 	if n.prometheusSrv != nil {
 		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
@@ -972,6 +1696,10 @@ func (n *Node) ConfigureRPC() error {
 	if err != nil {
 		return fmt.Errorf("can't get pubkey: %w", err)
 	}
+	var timestampAudit *cs.TimestampAuditStore
+	if n.timestampAuditor != nil {
+		timestampAudit = n.timestampAuditor.Store()
+	}
 	rpccore.SetEnvironment(&rpccore.Environment{
 		ProxyAppQuery:   n.proxyApp.Query(),
 		ProxyAppMempool: n.proxyApp.Mempool(),
@@ -987,8 +1715,13 @@ func (n *Node) ConfigureRPC() error {
 		GenDoc:           n.genesisDoc,
 		TxIndexer:        n.txIndexer,
 		ConsensusReactor: n.consensusReactor,
+		OperatorReactor:  n.operatorReactor,
 		EventBus:         n.eventBus,
 		Mempool:          n.mempool,
+		MempoolReactor:   n.mempoolReactor,
+		IdempotencyCache: rpccore.NewIdempotencyCache(n.config.RPC.IdempotencyCacheSize,
+			n.config.RPC.IdempotencyKeyTTL),
+		TimestampAudit: timestampAudit,
 
 		Logger: n.Logger.With("module", "rpc"),
 
@@ -1020,13 +1753,24 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
+	var responseCache *rpcserver.ResponseCache
+	if n.config.RPC.ResponseCacheSize > 0 {
+		cacheMetrics := rpcserver.NopCacheMetrics()
+		if n.config.Instrumentation.Prometheus {
+			cacheMetrics = rpcserver.PrometheusCacheMetrics(n.config.Instrumentation.Namespace,
+				"chain_id", n.genesisDoc.ChainID)
+		}
+		responseCache = rpcserver.NewResponseCache(n.config.RPC.ResponseCacheSize, cacheMetrics)
+	}
+	routes := rpcserver.WithResponseCache(rpccore.Routes, responseCache)
+
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, listenAddr := range listenAddrs {
 		mux := http.NewServeMux()
 		rpcLogger := n.Logger.With("module", "rpc-server")
 		wmLogger := rpcLogger.With("protocol", "websocket")
-		wm := rpcserver.NewWebsocketManager(rpccore.Routes,
+		wm := rpcserver.NewWebsocketManager(routes,
 			rpcserver.OnDisconnect(func(remoteAddr string) {
 				err := n.eventBus.UnsubscribeAll(context.Background(), remoteAddr)
 				if err != nil && err != tmpubsub.ErrSubscriptionNotFound {
@@ -1036,8 +1780,10 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 			rpcserver.ReadLimit(config.MaxBodyBytes),
 		)
 		wm.SetLogger(wmLogger)
+		wm.SetMaxConnections(n.config.RPC.MaxWebsocketConnections)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
-		rpcserver.RegisterRPCFuncs(mux, rpccore.Routes, rpcLogger)
+		mux.HandleFunc("/subscribe_sse", rpccore.SubscribeSSE)
+		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
 		listener, err := rpcserver.Listen(
 			listenAddr,
 			config,
@@ -1102,13 +1848,22 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		if err != nil {
 			return nil, err
 		}
+		grpcConfig := grpccore.Config{
+			MaxOpenConnections: n.config.RPC.GRPCMaxOpenConnections,
+			KeepaliveTime:      n.config.RPC.GRPCKeepaliveTime,
+			KeepaliveTimeout:   n.config.RPC.GRPCKeepaliveTimeout,
+			KeepaliveMinTime:   n.config.RPC.GRPCKeepaliveMinTime,
+			Reflection:         n.config.RPC.GRPCReflection,
+		}
+		// the listener is closed by the server, see OnStop
+		n.grpcServer = grpccore.NewServer(grpcConfig)
+		n.grpcServeDone = make(chan struct{})
 		go func() {
-			if err := grpccore.StartGRPCServer(listener); err != nil {
+			defer close(n.grpcServeDone)
+			if err := n.grpcServer.Serve(listener); err != nil {
 				n.Logger.Error("Error starting gRPC server", "err", err)
 			}
 		}()
-		listeners = append(listeners, listener)
-
 	}
 
 	return listeners, nil
@@ -1136,6 +1891,24 @@ func (n *Node) startPrometheusServer(addr string) *http.Server {
 	return srv
 }
 
+// channelStatsHandler serves the live per-peer, per-channel stats of the
+// switch as JSON, for debugging, see p2p.Switch.ChannelStats. The peer_id
+// query parameter restricts them to a peer.
+func channelStatsHandler(sw *p2p.Switch) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		stats := sw.ChannelStats(p2p.ID(r.URL.Query().Get("peer_id")))
+		if stats == nil {
+			stats = []p2p.PeerChannelStats{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(stats); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
 // Switch returns the Node's Switch.
 func (n *Node) Switch() *p2p.Switch {
 	return n.sw
@@ -1254,7 +2027,7 @@ func makeNodeInfo(
 			bcChannel,
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
 			mempl.MempoolChannel,
-			evidence.EvidenceChannel,
+			evidence.EvidenceChannel, evidence.CommittedEvidenceChannel,
 			statesync.SnapshotChannel, statesync.ChunkChannel,
 		},
 		Moniker: config.Moniker,
@@ -1267,6 +2040,16 @@ func makeNodeInfo(
 	if config.P2P.PexReactor {
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
+	if config.Operator.Gossip {
+		nodeInfo.Channels = append(nodeInfo.Channels, operator.OperatorChannel)
+	}
+	if config.P2P.MessageAuth {
+		msgAuth, err := p2p.MessageAuthConfigFromP2PConfig(config.P2P, nodeKey)
+		if err != nil {
+			return nil, err
+		}
+		nodeInfo.Other.Extensions = map[string]string{p2p.MessageAuthExtension: msgAuth.Extension()}
+	}
 
 	lAddr := config.P2P.ExternalAddress
 
@@ -1374,6 +2157,62 @@ func createAndStartPrivValidatorSocketClient(
 	return pvscWithRetries, nil
 }
 
+func createMetricsCheckpointer(
+	config *cfg.Config,
+	dbProvider DBProvider,
+	csMetrics *cs.Metrics,
+	logger log.Logger,
+) (*cs.MetricsCheckpointer, error) {
+	metricsDB, err := dbProvider(&DBContext{"metrics", config})
+	if err != nil {
+		return nil, err
+	}
+	checkpointer, err := cs.NewMetricsCheckpointer(metricsDB, config.Instrumentation.MetricsCheckpointInterval)
+	if err != nil {
+		metricsDB.Close()
+		return nil, fmt.Errorf("failed to load the metrics checkpoint: %w", err)
+	}
+	if err := csMetrics.Checkpoint(checkpointer, config.Instrumentation.CheckpointedMetrics...); err != nil {
+		metricsDB.Close()
+		return nil, fmt.Errorf("invalid instrumentation.checkpointed_metrics: %w", err)
+	}
+	checkpointer.SetLogger(logger.With("module", "metrics"))
+	return checkpointer, nil
+}
+
+func createPrivValidatorGRPCClient(
+	config *cfg.Config,
+	chainID string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	creds := grpc.WithInsecure()
+	if caFile := config.PrivValidatorGRPCCAPath(); caFile != "" {
+		tlsConfig, err := privvalgrpc.ClientTLSConfig(caFile, config.PrivValidatorGRPCCertPath(),
+			config.PrivValidatorGRPCKeyPath())
+		if err != nil {
+			return nil, fmt.Errorf("invalid private validator TLS config: %w", err)
+		}
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	} else {
+		logger.Info("The connection to the private validator isn't encrypted, " +
+			"the signer must be on the same host or on a private network")
+	}
+	pvsc, err := privvalgrpc.DialRemoteSigner(config.PrivValidatorListenAddr, chainID,
+		logger.With("module", "privval"), creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial private validator: %w", err)
+	}
+
+	// try to get a pubkey from private validate first time
+	_, err = pvsc.GetPubKey()
+	if err != nil {
+		pvsc.Close()
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+
+	return pvsc, nil
+}
+
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
 // slice of the string s with all leading and trailing Unicode code points
 // contained in cutset removed. If sep is empty, SplitAndTrim splits after each
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"syscall"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
 	dbm "github.com/tendermint/tm-db"
 
@@ -24,6 +26,8 @@ import (
 	"github.com/tendermint/tendermint/p2p"
 	p2pmock "github.com/tendermint/tendermint/p2p/mock"
 	"github.com/tendermint/tendermint/privval"
+	privvalgrpc "github.com/tendermint/tendermint/privval/grpc"
+	privvalv1 "github.com/tendermint/tendermint/proto/tendermint/privval/v1"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/store"
@@ -74,6 +78,42 @@ func TestNodeStartStop(t *testing.T) {
 	}
 }
 
+func TestNodeReadReplica(t *testing.T) {
+	config := cfg.ResetTestRoot("node_read_replica_test")
+	defer os.RemoveAll(config.RootDir)
+	// slow enough for the replica to catch up
+	config.Consensus.CreateEmptyBlocksInterval = 200 * time.Millisecond
+	upstream, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, upstream.Start())
+	defer upstream.Stop() //nolint:errcheck // ignore for tests
+
+	// the replica shares the genesis of the upstream, and doesn't serve the
+	// RPC, whose environment is global
+	replicaConfig := cfg.ResetTestRoot("node_read_replica_test")
+	defer os.RemoveAll(replicaConfig.RootDir)
+	require.NoError(t, upstream.GenesisDoc().SaveAs(replicaConfig.GenesisFile()))
+	replicaConfig.RPC.ListenAddress = ""
+	replicaConfig.RPC.GRPCListenAddress = ""
+	replicaConfig.Replica.Enable = true
+	replicaConfig.Replica.Upstreams = []string{config.RPC.ListenAddress}
+	replica, err := DefaultNewNode(replicaConfig, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, replica.Start())
+
+	assert.False(t, replica.IsListening())
+	assert.False(t, replica.Switch().IsRunning())
+	require.Eventually(t, func() bool { return replica.BlockStore().Height() >= 3 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, upstream.BlockStore().LoadBlock(3).Hash(), replica.BlockStore().LoadBlock(3).Hash())
+	// and is no longer catching up once it reached the upstream
+	require.Eventually(t, func() bool { return !replica.ConsensusReactor().WaitSync() }, 10*time.Second,
+		10*time.Millisecond)
+
+	// the replica no longer requests the upstream once stopped
+	require.NoError(t, replica.Stop())
+	replica.Wait()
+}
+
 func TestSplitAndTrimEmpty(t *testing.T) {
 	testCases := []struct {
 		s        string
@@ -164,6 +204,49 @@ func TestNodeSetPrivValTCP(t *testing.T) {
 	assert.IsType(t, &privval.RetrySignerClient{}, n.PrivValidator())
 }
 
+func TestDefaultDBProviderSeparateDir(t *testing.T) {
+	config := cfg.ResetTestRoot("node_db_provider_test")
+	defer os.RemoveAll(config.RootDir)
+	config.DBBackend = string(dbm.GoLevelDBBackend)
+
+	db, err := DefaultDBProvider(&DBContext{"blockstore", config})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	// the database must be moved before changing its directory
+	config.BlockStoreDBPath = "blocks"
+	_, err = DefaultDBProvider(&DBContext{"blockstore", config})
+	assert.Error(t, err)
+
+	require.NoError(t, os.MkdirAll(config.DBDirFor("blockstore"), 0700))
+	require.NoError(t, os.Rename(filepath.Join(config.DBDir(), "blockstore.db"),
+		filepath.Join(config.DBDirFor("blockstore"), "blockstore.db")))
+	db, err = DefaultDBProvider(&DBContext{"blockstore", config})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+}
+
+func TestNodeSetPrivValGRPC(t *testing.T) {
+	laddr := testFreeAddr(t)
+
+	config := cfg.ResetTestRoot("node_priv_val_grpc_test")
+	defer os.RemoveAll(config.RootDir)
+	config.BaseConfig.PrivValidatorListenAddr = privvalgrpc.AddrPrefix + laddr
+
+	ln, err := net.Listen("tcp", laddr)
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	ss := privvalgrpc.NewSignerServer(config.ChainID(), types.NewMockPV(), log.TestingLogger())
+	ss.SetAllowUnauthenticated(true)
+	privvalv1.RegisterPrivValidatorServiceServer(srv, ss)
+	go srv.Serve(ln) //nolint:errcheck // ignore for tests
+	defer srv.Stop()
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	assert.IsType(t, &privvalgrpc.SignerClient{}, n.PrivValidator())
+}
+
 // address without a protocol must result in error
 func TestPrivValidatorListenAddrNoProtocol(t *testing.T) {
 	addrNoPrefix := testFreeAddr(t)
@@ -410,6 +493,59 @@ func TestNodeNewNodeCustomReactors(t *testing.T) {
 	assert.Equal(t, customBlockchainReactor, n.Switch().Reactor("BLOCKCHAIN"))
 }
 
+func TestNodeMempoolAdmissionPolicy(t *testing.T) {
+	config := cfg.ResetTestRoot("node_mempool_admission_policy_test")
+	defer os.RemoveAll(config.RootDir)
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	pval, err := privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	require.NoError(t, err)
+
+	n, err := NewNode(config,
+		pval,
+		nodeKey,
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		DefaultGenesisDocProviderFunc(config),
+		DefaultDBProvider,
+		DefaultMetricsProvider(config.Instrumentation),
+		log.TestingLogger(),
+		MempoolAdmissionPolicy(mempl.PeerMaxTxBytesPolicy(1, nil)),
+	)
+	require.NoError(t, err)
+
+	err = n.Mempool().CheckTx(types.Tx("large"), nil, mempl.TxInfo{SenderID: 1, SenderP2PID: "peer"})
+	assert.True(t, mempl.IsPreCheckError(err), err)
+}
+
+func TestNodeNewNodeInfoExtension(t *testing.T) {
+	config := cfg.ResetTestRoot("node_new_node_info_extension_test")
+	defer os.RemoveAll(config.RootDir)
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	pval, err := privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	require.NoError(t, err)
+
+	n, err := NewNode(config,
+		pval,
+		nodeKey,
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		DefaultGenesisDocProviderFunc(config),
+		DefaultDBProvider,
+		DefaultMetricsProvider(config.Instrumentation),
+		log.TestingLogger(),
+		NodeInfoExtension("app_version", "1.2.0"),
+		NodeInfoExtension("features", "a,b"),
+		NodeInfoExtension("invalid key", "1"),
+	)
+	require.NoError(t, err)
+
+	expected := map[string]string{"app_version": "1.2.0", "features": "a,b"}
+	assert.Equal(t, expected, n.NodeInfo().(p2p.DefaultNodeInfo).Other.Extensions)
+	assert.Equal(t, expected, n.Switch().NodeInfo().(p2p.DefaultNodeInfo).Other.Extensions)
+}
+
 func state(nVals int, height int64) (sm.State, dbm.DB, []types.PrivValidator) {
 	privVals := make([]types.PrivValidator, nVals)
 	vals := make([]types.GenesisValidator, nVals)
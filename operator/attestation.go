@@ -0,0 +1,149 @@
+package operator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	tmoperator "github.com/tendermint/tendermint/proto/tendermint/operator"
+	"github.com/tendermint/tendermint/types"
+)
+
+// SentryAttestation is the attestation by a node that it's a sentry of the
+// validator, signed with its node key. The validator lists its sentries in
+// its Info, but only the sentries which attested it are trusted, so that a
+// validator can't claim the nodes of the others.
+type SentryAttestation struct {
+	ChainID          string           `json:"chain_id"`
+	ValidatorAddress tmbytes.HexBytes `json:"validator_address"`
+	Timestamp        time.Time        `json:"timestamp"`
+	PubKey           crypto.PubKey    `json:"pub_key"`
+	Signature        []byte           `json:"signature"`
+}
+
+// NewSentryAttestation returns the attestation that the node with the given
+// key is a sentry of the validator, signed with the key.
+func NewSentryAttestation(chainID string, validatorAddress []byte, nodeKey crypto.PrivKey,
+	timestamp time.Time) (*SentryAttestation, error) {
+	a := &SentryAttestation{
+		ChainID:          chainID,
+		ValidatorAddress: validatorAddress,
+		Timestamp:        timestamp.UTC(),
+		PubKey:           nodeKey.PubKey(),
+	}
+	sig, err := nodeKey.Sign(a.SignBytes())
+	if err != nil {
+		return nil, fmt.Errorf("can't sign the sentry attestation: %w", err)
+	}
+	a.Signature = sig
+	return a, a.ValidateBasic()
+}
+
+// NewOwnSentryAttestation returns the attestation that this node is a sentry
+// of the validator set in config.OperatorConfig.SentryOf, or nil if not set.
+func NewOwnSentryAttestation(chainID string, cfg *config.OperatorConfig, nodeKey *p2p.NodeKey,
+	logger log.Logger) *SentryAttestation {
+	if cfg.SentryOf == "" {
+		return nil
+	}
+	address, err := cfg.SentryOfAddress()
+	if err == nil {
+		var a *SentryAttestation
+		if a, err = NewSentryAttestation(chainID, address, nodeKey.PrivKey, time.Now()); err == nil {
+			return a
+		}
+	}
+	logger.Error("Not attesting the validator of this sentry", "err", err)
+	return nil
+}
+
+// NodeID returns the ID of the sentry.
+func (a *SentryAttestation) NodeID() p2p.ID {
+	return p2p.PubKeyToID(a.PubKey)
+}
+
+// SignBytes returns the bytes of the attestation to sign, i.e. without the
+// key and the signature.
+func (a *SentryAttestation) SignBytes() []byte {
+	pb := a.ToProto()
+	bz, err := pb.Attestation.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Verify returns an error if the attestation isn't signed with its key, part
+// of the given chain.
+func (a *SentryAttestation) Verify(chainID string) error {
+	if a.ChainID != chainID {
+		return fmt.Errorf("attestation is for chain %q, expected %q", a.ChainID, chainID)
+	}
+	if !a.PubKey.VerifySignature(a.SignBytes(), a.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation.
+func (a *SentryAttestation) ValidateBasic() error {
+	if a.ChainID == "" {
+		return errors.New("empty chain ID")
+	}
+	if len(a.ValidatorAddress) != crypto.AddressSize {
+		return fmt.Errorf("expected validator address size to be %d bytes, got %d bytes",
+			crypto.AddressSize, len(a.ValidatorAddress))
+	}
+	if a.PubKey == nil {
+		return errors.New("node key is missing")
+	}
+	if len(a.Signature) == 0 {
+		return errors.New("signature is missing")
+	}
+	if len(a.Signature) > types.MaxSignatureSize {
+		return fmt.Errorf("signature is too big (max: %d)", types.MaxSignatureSize)
+	}
+	return nil
+}
+
+// ToProto converts the attestation to protobuf.
+func (a *SentryAttestation) ToProto() *tmoperator.SignedSentryAttestation {
+	pb := &tmoperator.SignedSentryAttestation{
+		Attestation: tmoperator.SentryAttestation{
+			ChainId:          a.ChainID,
+			ValidatorAddress: a.ValidatorAddress,
+			Timestamp:        a.Timestamp,
+		},
+		Signature: a.Signature,
+	}
+	if a.PubKey != nil {
+		pb.PubKey = a.PubKey.Bytes()
+	}
+	return pb
+}
+
+// SentryAttestationFromProto converts the protobuf attestation, returning an
+// error if it's invalid. The node keys are ed25519 keys.
+func SentryAttestationFromProto(pb *tmoperator.SignedSentryAttestation) (*SentryAttestation, error) {
+	if pb == nil {
+		return nil, errors.New("nil sentry attestation")
+	}
+	if len(pb.PubKey) != ed25519.PubKeySize {
+		return nil, fmt.Errorf("expected node key size to be %d bytes, got %d bytes",
+			ed25519.PubKeySize, len(pb.PubKey))
+	}
+	a := &SentryAttestation{
+		ChainID:          pb.Attestation.ChainId,
+		ValidatorAddress: pb.Attestation.ValidatorAddress,
+		Timestamp:        pb.Attestation.Timestamp,
+		PubKey:           ed25519.PubKey(pb.PubKey),
+		Signature:        pb.Signature,
+	}
+	return a, a.ValidateBasic()
+}
@@ -0,0 +1,177 @@
+package operator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmstrings "github.com/tendermint/tendermint/libs/strings"
+	"github.com/tendermint/tendermint/p2p"
+	tmoperator "github.com/tendermint/tendermint/proto/tendermint/operator"
+	"github.com/tendermint/tendermint/types"
+)
+
+// MaxMonikerLength is the maximum length of the moniker of an Info.
+const MaxMonikerLength = 70
+
+// Info is the contact info of a validator operator, and the node IDs of the
+// sentries of the validator, signed with the validator key.
+// The signature binds the sentries to the validator key, so that the other
+// nodes can tell the sentries of the validators apart.
+type Info struct {
+	ChainID          string           `json:"chain_id"`
+	ValidatorAddress tmbytes.HexBytes `json:"validator_address"`
+	Moniker          string           `json:"moniker"`
+	Website          string           `json:"website"`
+	SecurityContact  string           `json:"security_contact"`
+	Details          string           `json:"details"`
+	Timestamp        time.Time        `json:"timestamp"`
+	SentryNodeIDs    []p2p.ID         `json:"sentry_node_ids"`
+	Signature        []byte           `json:"signature"`
+}
+
+// NewInfo returns the info of the validator with the given key, from the
+// operator config, signed with the key.
+func NewInfo(chainID, moniker string, cfg *config.OperatorConfig, privKey crypto.PrivKey,
+	timestamp time.Time) (*Info, error) {
+	info := &Info{
+		ChainID:          chainID,
+		ValidatorAddress: privKey.PubKey().Address(),
+		Moniker:          moniker,
+		Website:          cfg.Website,
+		SecurityContact:  cfg.SecurityContact,
+		Details:          cfg.Details,
+		Timestamp:        timestamp.UTC(),
+	}
+	for _, id := range cfg.SentryNodeIDList() {
+		info.SentryNodeIDs = append(info.SentryNodeIDs, p2p.ID(id))
+	}
+	sig, err := privKey.Sign(info.SignBytes())
+	if err != nil {
+		return nil, fmt.Errorf("can't sign the operator info: %w", err)
+	}
+	info.Signature = sig
+	return info, info.ValidateBasic()
+}
+
+// SignBytes returns the bytes of the info to sign, i.e. without the
+// signature.
+func (info *Info) SignBytes() []byte {
+	pb := info.ToProto()
+	bz, err := pb.Info.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Verify returns an error if the info isn't signed with the given key, part of
+// the given chain.
+func (info *Info) Verify(chainID string, pubKey crypto.PubKey) error {
+	if info.ChainID != chainID {
+		return fmt.Errorf("info is for chain %q, expected %q", info.ChainID, chainID)
+	}
+	if !bytes.Equal(pubKey.Address(), info.ValidatorAddress) {
+		return errors.New("key doesn't match the validator address")
+	}
+	if !pubKey.VerifySignature(info.SignBytes(), info.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation.
+func (info *Info) ValidateBasic() error {
+	if info.ChainID == "" {
+		return errors.New("empty chain ID")
+	}
+	if len(info.ValidatorAddress) != crypto.AddressSize {
+		return fmt.Errorf("expected validator address size to be %d bytes, got %d bytes",
+			crypto.AddressSize, len(info.ValidatorAddress))
+	}
+	if len(info.Moniker) > MaxMonikerLength || !tmstrings.IsASCIIText(info.Moniker) {
+		return fmt.Errorf("moniker must be ASCII text of at most %d characters", MaxMonikerLength)
+	}
+	if len(info.Website) > config.MaxOperatorWebsiteLength {
+		return fmt.Errorf("website is longer than %d characters", config.MaxOperatorWebsiteLength)
+	}
+	if len(info.SecurityContact) > config.MaxOperatorSecurityContactLength {
+		return fmt.Errorf("security contact is longer than %d characters", config.MaxOperatorSecurityContactLength)
+	}
+	if len(info.Details) > config.MaxOperatorDetailsLength {
+		return fmt.Errorf("details is longer than %d characters", config.MaxOperatorDetailsLength)
+	}
+	if len(info.SentryNodeIDs) > config.MaxOperatorSentryNodeIDs {
+		return fmt.Errorf("too many sentry node IDs (max: %d)", config.MaxOperatorSentryNodeIDs)
+	}
+	for i, id := range info.SentryNodeIDs {
+		if err := p2p.ValidateID(id); err != nil {
+			return fmt.Errorf("wrong sentry node ID #%d: %w", i, err)
+		}
+	}
+	if len(info.Signature) == 0 {
+		return errors.New("signature is missing")
+	}
+	if len(info.Signature) > types.MaxSignatureSize {
+		return fmt.Errorf("signature is too big (max: %d)", types.MaxSignatureSize)
+	}
+	return nil
+}
+
+// AttestsSentry returns true if the info attests the node with the given ID
+// as a sentry of the validator.
+func (info *Info) AttestsSentry(nodeID p2p.ID) bool {
+	for _, id := range info.SentryNodeIDs {
+		if id == nodeID {
+			return true
+		}
+	}
+	return false
+}
+
+// ToProto converts the info to protobuf.
+func (info *Info) ToProto() *tmoperator.SignedInfo {
+	var sentryNodeIDs []string
+	for _, id := range info.SentryNodeIDs {
+		sentryNodeIDs = append(sentryNodeIDs, string(id))
+	}
+	return &tmoperator.SignedInfo{
+		Info: tmoperator.Info{
+			ChainId:          info.ChainID,
+			ValidatorAddress: info.ValidatorAddress,
+			Moniker:          info.Moniker,
+			Website:          info.Website,
+			SecurityContact:  info.SecurityContact,
+			Details:          info.Details,
+			Timestamp:        info.Timestamp,
+			SentryNodeIds:    sentryNodeIDs,
+		},
+		Signature: info.Signature,
+	}
+}
+
+// InfoFromProto converts the protobuf info, returning an error if it's
+// invalid.
+func InfoFromProto(pb *tmoperator.SignedInfo) (*Info, error) {
+	if pb == nil {
+		return nil, errors.New("nil operator info")
+	}
+	info := &Info{
+		ChainID:          pb.Info.ChainId,
+		ValidatorAddress: pb.Info.ValidatorAddress,
+		Moniker:          pb.Info.Moniker,
+		Website:          pb.Info.Website,
+		SecurityContact:  pb.Info.SecurityContact,
+		Details:          pb.Info.Details,
+		Timestamp:        pb.Info.Timestamp,
+		Signature:        pb.Signature,
+	}
+	for _, id := range pb.Info.SentryNodeIds {
+		info.SentryNodeIDs = append(info.SentryNodeIDs, p2p.ID(id))
+	}
+	return info, info.ValidateBasic()
+}
@@ -0,0 +1,495 @@
+package operator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	tmoperator "github.com/tendermint/tendermint/proto/tendermint/operator"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// OperatorChannel is the channel of the operator info.
+	OperatorChannel = byte(0x70)
+
+	maxMsgSize = 2048 // 2KB
+
+	// the infos can't be dated further in the future than this.
+	maxClockDrift = 1 * time.Minute
+
+	// reload the validators from the state store this often.
+	validatorsRefreshInterval = 10 * time.Second
+
+	// maximum number of sentries made priority peers, see
+	// config.OperatorConfig.PrioritizeSentries.
+	maxPrioritySentries = 64
+)
+
+// Reactor gossips the signed operator info of the validators and the
+// attestations of their sentries, and caches the last info of each validator
+// of the current validator set, and the last attestation of each of their
+// sentries.
+type Reactor struct {
+	p2p.BaseReactor
+
+	config         *config.OperatorConfig
+	chainID        string
+	stateStore     sm.Store
+	own            *Info
+	ownAttestation *SentryAttestation
+
+	mtx                 tmsync.RWMutex
+	infos               map[string]*Info              // by validator address
+	updated             map[string]time.Time          // local time of the last update
+	attestations        map[p2p.ID]*SentryAttestation // by sentry node ID
+	attestationsUpdated map[p2p.ID]time.Time          // local time of the last update
+	validators          *types.ValidatorSet
+	validatorsTime      time.Time
+	validatorsError     error
+
+	prioritizedMtx tmsync.Mutex
+	prioritized    map[p2p.ID]struct{} // the sentries made priority peers
+}
+
+// NewReactor returns a new Reactor, which gossips the given info of this
+// node's validator, and the given attestation of the validator this node is a
+// sentry of, too, if not nil.
+func NewReactor(cfg *config.OperatorConfig, chainID string, stateStore sm.Store, own *Info,
+	ownAttestation *SentryAttestation) *Reactor {
+	r := &Reactor{
+		config:              cfg,
+		chainID:             chainID,
+		stateStore:          stateStore,
+		own:                 own,
+		ownAttestation:      ownAttestation,
+		infos:               make(map[string]*Info),
+		updated:             make(map[string]time.Time),
+		attestations:        make(map[p2p.ID]*SentryAttestation),
+		attestationsUpdated: make(map[p2p.ID]time.Time),
+		prioritized:         make(map[p2p.ID]struct{}),
+	}
+	r.BaseReactor = *p2p.NewBaseReactor("Operator", r)
+	return r
+}
+
+// NewOwnInfo returns the info of this node's validator, if the given private
+// validator holds its key locally, or nil.
+func NewOwnInfo(chainID, moniker string, cfg *config.OperatorConfig, privValidator types.PrivValidator,
+	logger log.Logger) *Info {
+	pv, ok := privValidator.(*privval.FilePV)
+	if !ok {
+		logger.Info("Not gossiping the operator info: the validator key isn't local")
+		return nil
+	}
+	info, err := NewInfo(chainID, moniker, cfg, pv.Key.PrivKey, time.Now())
+	if err != nil {
+		logger.Error("Not gossiping the operator info", "err", err)
+		return nil
+	}
+	return info
+}
+
+// OnStart implements Service. It caches the info of this node's validator,
+// and the attestation of the validator this node is a sentry of, if they're
+// part of the validator set.
+func (r *Reactor) OnStart() error {
+	if r.own != nil {
+		r.Logger.Info("Gossiping the operator info", "validator", r.own.ValidatorAddress)
+		if _, err := r.AddInfo(r.own); err != nil {
+			return fmt.Errorf("invalid operator info: %w", err)
+		}
+	}
+	if r.ownAttestation != nil {
+		r.Logger.Info("Gossiping the sentry attestation", "validator", r.ownAttestation.ValidatorAddress)
+		if _, err := r.AddSentryAttestation(r.ownAttestation); err != nil {
+			return fmt.Errorf("invalid sentry attestation: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetChannels implements Reactor.
+func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
+	return []*p2p.ChannelDescriptor{
+		{
+			ID:                  OperatorChannel,
+			Priority:            1,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: maxMsgSize,
+		},
+	}
+}
+
+// AddPeer implements Reactor. It sends the cached infos and attestations to
+// the peer.
+func (r *Reactor) AddPeer(peer p2p.Peer) {
+	var msgs []interface{}
+	for _, info := range r.Infos() {
+		msgs = append(msgs, info)
+	}
+	for _, a := range r.sentryAttestations() {
+		msgs = append(msgs, a)
+	}
+	// this node's validator may not be part of the validator set yet
+	if r.own != nil && r.Info(r.own.ValidatorAddress) != r.own {
+		msgs = append(msgs, r.own)
+	}
+	if a := r.ownAttestation; a != nil && r.SentryAttestation(a.NodeID()) != a {
+		msgs = append(msgs, a)
+	}
+	go func() {
+		for _, msg := range msgs {
+			if !peer.Send(OperatorChannel, mustEncodeMsg(msg)) {
+				return
+			}
+		}
+	}()
+}
+
+// Receive implements Reactor. It caches and relays the new infos of the
+// validators and attestations of their sentries, at most once per
+// config.MinUpdateInterval for each validator or sentry.
+func (r *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		r.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
+		r.Switch.StopPeerForError(src, err)
+		return
+	}
+	var added bool
+	switch msg := msg.(type) {
+	case *Info:
+		added, err = r.AddInfo(msg)
+	case *SentryAttestation:
+		added, err = r.AddSentryAttestation(msg)
+	}
+	if err != nil {
+		r.Logger.Error("Invalid operator message", "src", src, "err", err)
+		r.Switch.StopPeerForError(src, err)
+		return
+	}
+	if added {
+		r.Switch.Broadcast(OperatorChannel, msgBytes)
+	}
+}
+
+// AddInfo caches the info if it's newer than the cached info of its
+// validator, returning true if it was added, or an error if it's invalid. The
+// infos of unknown validators, dated too far in the future, or received too
+// soon after the last update of their validator, are dropped. With config.PrioritizeSentries, the priority
+// sentries are updated, see updatePrioritySentries.
+func (r *Reactor) AddInfo(info *Info) (bool, error) {
+	key := string(info.ValidatorAddress)
+	now := time.Now()
+
+	r.mtx.RLock()
+	cached, updated := r.infos[key], r.updated[key]
+	r.mtx.RUnlock()
+	if cached != nil && !info.Timestamp.After(cached.Timestamp) {
+		return false, nil
+	}
+	if now.Sub(updated) < r.config.MinUpdateInterval {
+		r.Logger.Debug("Dropping operator info, updated too soon", "validator", info.ValidatorAddress)
+		return false, nil
+	}
+
+	vals, err := r.currentValidators(now)
+	if err != nil {
+		return false, nil
+	}
+	_, val := vals.GetByAddress(info.ValidatorAddress)
+	if val == nil {
+		r.Logger.Debug("Dropping operator info of an unknown validator", "validator", info.ValidatorAddress)
+		return false, nil
+	}
+	if err := info.Verify(r.chainID, val.PubKey); err != nil {
+		return false, err
+	}
+	if info.Timestamp.After(now.Add(maxClockDrift)) {
+		// the clock of either node may be off, it's not the peer's fault
+		r.Logger.Debug("Dropping operator info, too far in the future", "validator", info.ValidatorAddress,
+			"timestamp", info.Timestamp)
+		return false, nil
+	}
+
+	r.mtx.Lock()
+	// a concurrent call may have added it already
+	if cached := r.infos[key]; cached != nil && !info.Timestamp.After(cached.Timestamp) {
+		r.mtx.Unlock()
+		return false, nil
+	}
+	r.infos[key] = info
+	r.updated[key] = now
+	r.mtx.Unlock()
+
+	r.updatePrioritySentries()
+	return true, nil
+}
+
+// AddSentryAttestation caches the attestation if it's newer than the cached
+// attestation of its sentry, returning true if it was added, or an error if
+// it's invalid. The attestations of the validators which aren't part of the
+// validator set, dated too far in the future, or received too soon after the
+// last update of their sentry, are dropped, as well as the attestations of new sentries once there are
+// config.MaxOperatorSentryNodeIDs of them per validator.
+func (r *Reactor) AddSentryAttestation(a *SentryAttestation) (bool, error) {
+	nodeID := a.NodeID()
+	now := time.Now()
+
+	r.mtx.RLock()
+	cached, updated := r.attestations[nodeID], r.attestationsUpdated[nodeID]
+	r.mtx.RUnlock()
+	if cached != nil && !a.Timestamp.After(cached.Timestamp) {
+		return false, nil
+	}
+	if now.Sub(updated) < r.config.MinUpdateInterval {
+		r.Logger.Debug("Dropping sentry attestation, updated too soon", "sentry", nodeID)
+		return false, nil
+	}
+
+	vals, err := r.currentValidators(now)
+	if err != nil {
+		return false, nil
+	}
+	if !vals.HasAddress(a.ValidatorAddress) {
+		r.Logger.Debug("Dropping sentry attestation of an unknown validator", "sentry", nodeID,
+			"validator", a.ValidatorAddress)
+		return false, nil
+	}
+	if err := a.Verify(r.chainID); err != nil {
+		return false, err
+	}
+	if a.Timestamp.After(now.Add(maxClockDrift)) {
+		r.Logger.Debug("Dropping sentry attestation, too far in the future", "sentry", nodeID,
+			"timestamp", a.Timestamp)
+		return false, nil
+	}
+
+	r.mtx.Lock()
+	// a concurrent call may have added it already
+	if cached := r.attestations[nodeID]; cached != nil && !a.Timestamp.After(cached.Timestamp) {
+		r.mtx.Unlock()
+		return false, nil
+	}
+	if r.attestations[nodeID] == nil && len(r.attestations) >= config.MaxOperatorSentryNodeIDs*vals.Size() {
+		r.mtx.Unlock()
+		r.Logger.Debug("Dropping sentry attestation, too many sentries", "sentry", nodeID)
+		return false, nil
+	}
+	r.attestations[nodeID] = a
+	r.attestationsUpdated[nodeID] = now
+	r.mtx.Unlock()
+
+	r.updatePrioritySentries()
+	return true, nil
+}
+
+// prioritySentries returns the node IDs of the sentries listed by the cached
+// infos of the validators which attested them, by decreasing voting power of
+// their validator, at most maxPrioritySentries.
+func (r *Reactor) prioritySentries() map[p2p.ID]struct{} {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	ids := make(map[p2p.ID]struct{})
+	if r.validators == nil {
+		return ids
+	}
+	for _, val := range r.validators.Validators {
+		info := r.infos[string(val.Address)]
+		if info == nil {
+			continue
+		}
+		for _, id := range info.SentryNodeIDs {
+			if a := r.attestations[id]; a != nil && bytes.Equal(a.ValidatorAddress, val.Address) {
+				if len(ids) == maxPrioritySentries {
+					return ids
+				}
+				ids[id] = struct{}{}
+			}
+		}
+	}
+	return ids
+}
+
+// updatePrioritySentries makes the priority sentries (see prioritySentries)
+// priority peers with config.PrioritizeSentries, and reverts it for the
+// sentries which no longer are. The peers which already are unconditional,
+// e.g. set in config.P2PConfig.UnconditionalPeerIDs, are left alone.
+func (r *Reactor) updatePrioritySentries() {
+	if !r.config.PrioritizeSentries || r.Switch == nil {
+		return
+	}
+	sentries := r.prioritySentries()
+
+	r.prioritizedMtx.Lock()
+	defer r.prioritizedMtx.Unlock()
+	var removed, added []string
+	for id := range r.prioritized {
+		if _, ok := sentries[id]; !ok {
+			removed = append(removed, string(id))
+			delete(r.prioritized, id)
+		}
+	}
+	for id := range sentries {
+		if _, ok := r.prioritized[id]; !ok && !r.Switch.IsPeerUnconditional(id) {
+			added = append(added, string(id))
+			r.prioritized[id] = struct{}{}
+		}
+	}
+
+	if len(removed) > 0 {
+		r.Logger.Info("Deprioritizing the sentries", "ids", removed)
+		r.Switch.RemovePriorityPeerIDs(removed)
+	}
+	if len(added) > 0 {
+		r.Logger.Info("Prioritizing the attested sentries", "ids", added)
+		if err := r.Switch.AddPriorityPeerIDs(added); err != nil {
+			// can't happen, the IDs are validated by Info.ValidateBasic
+			r.Logger.Error("Can't prioritize the attested sentries", "err", err)
+		}
+	}
+}
+
+// currentValidators returns the validators of the state store, reloaded at
+// most once per validatorsRefreshInterval, and drops the infos and the sentry
+// attestations of the validators which left the set.
+func (r *Reactor) currentValidators(now time.Time) (*types.ValidatorSet, error) {
+	r.mtx.RLock()
+	vals, loaded, err := r.validators, r.validatorsTime, r.validatorsError
+	r.mtx.RUnlock()
+	if now.Sub(loaded) < validatorsRefreshInterval {
+		return vals, err
+	}
+
+	state, err := r.stateStore.Load()
+	if err == nil && state.Validators == nil {
+		err = errors.New("no validators in the state store")
+	}
+	if err != nil {
+		r.Logger.Error("Can't load the validators", "err", err)
+	}
+
+	r.mtx.Lock()
+	r.validators, r.validatorsTime, r.validatorsError = state.Validators, now, err
+	if err == nil {
+		for key := range r.infos {
+			if !state.Validators.HasAddress([]byte(key)) {
+				delete(r.infos, key)
+				delete(r.updated, key)
+			}
+		}
+		for id, a := range r.attestations {
+			if !state.Validators.HasAddress(a.ValidatorAddress) {
+				delete(r.attestations, id)
+				delete(r.attestationsUpdated, id)
+			}
+		}
+	}
+	r.mtx.Unlock()
+
+	// the validators which left the set may have had priority sentries
+	r.updatePrioritySentries()
+	return state.Validators, err
+}
+
+// Infos returns the cached infos, sorted by validator address.
+func (r *Reactor) Infos() []*Info {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	infos := make([]*Info, 0, len(r.infos))
+	for _, info := range r.infos {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ValidatorAddress.String() < infos[j].ValidatorAddress.String()
+	})
+	return infos
+}
+
+// Info returns the cached info of the validator with the given address, or
+// nil.
+func (r *Reactor) Info(address []byte) *Info {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.infos[string(address)]
+}
+
+// SentryAttestation returns the cached attestation of the sentry with the
+// given node ID, or nil.
+func (r *Reactor) SentryAttestation(nodeID p2p.ID) *SentryAttestation {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.attestations[nodeID]
+}
+
+func (r *Reactor) sentryAttestations() []*SentryAttestation {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	attestations := make([]*SentryAttestation, 0, len(r.attestations))
+	for _, a := range r.attestations {
+		attestations = append(attestations, a)
+	}
+	return attestations
+}
+
+// Attesting returns the cached infos attesting the node with the given ID as
+// a sentry, if the node attested their validator too, sorted by validator
+// address.
+func (r *Reactor) Attesting(nodeID p2p.ID) []*Info {
+	infos := []*Info{}
+	a := r.SentryAttestation(nodeID)
+	if a == nil {
+		return infos
+	}
+	for _, info := range r.Infos() {
+		if info.AttestsSentry(nodeID) && bytes.Equal(info.ValidatorAddress, a.ValidatorAddress) {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+// mustEncodeMsg encodes the info or sentry attestation, panicking on error.
+func mustEncodeMsg(msg interface{}) []byte {
+	pb := tmoperator.Message{}
+	switch msg := msg.(type) {
+	case *Info:
+		pb.Sum = &tmoperator.Message_SignedInfo{SignedInfo: msg.ToProto()}
+	case *SentryAttestation:
+		pb.Sum = &tmoperator.Message_SignedSentryAttestation{SignedSentryAttestation: msg.ToProto()}
+	default:
+		panic(fmt.Errorf("unknown message type %T", msg))
+	}
+	bz, err := pb.Marshal()
+	if err != nil {
+		panic(fmt.Errorf("unable to marshal %T: %w", msg, err))
+	}
+	return bz
+}
+
+// decodeMsg decodes a Protobuf message into an *Info or a *SentryAttestation.
+func decodeMsg(bz []byte) (interface{}, error) {
+	pb := &tmoperator.Message{}
+	if err := proto.Unmarshal(bz, pb); err != nil {
+		return nil, err
+	}
+	switch msg := pb.Sum.(type) {
+	case *tmoperator.Message_SignedInfo:
+		return InfoFromProto(msg.SignedInfo)
+	case *tmoperator.Message_SignedSentryAttestation:
+		return SentryAttestationFromProto(msg.SignedSentryAttestation)
+	default:
+		return nil, fmt.Errorf("unknown message type %T", msg)
+	}
+}
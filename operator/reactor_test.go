@@ -0,0 +1,350 @@
+package operator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+const chainID = "operator-chain"
+
+// makeStateStore returns a state store whose validators have the given keys.
+func makeStateStore(t *testing.T, keys ...crypto.PrivKey) sm.Store {
+	genDoc := &types.GenesisDoc{ChainID: chainID}
+	for _, key := range keys {
+		genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{PubKey: key.PubKey(), Power: 10})
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	require.NoError(t, stateStore.Save(state))
+	return stateStore
+}
+
+func newTestInfo(t *testing.T, key crypto.PrivKey, website string, timestamp time.Time) *Info {
+	cfg := config.TestOperatorConfig()
+	cfg.Website = website
+	info, err := NewInfo(chainID, "validator", cfg, key, timestamp)
+	require.NoError(t, err)
+	return info
+}
+
+func TestInfoSignature(t *testing.T) {
+	key := ed25519.GenPrivKey()
+	info := newTestInfo(t, key, "https://validator.example.com", time.Now())
+	assert.NoError(t, info.Verify(chainID, key.PubKey()))
+	assert.Error(t, info.Verify("other-chain", key.PubKey()))
+	assert.Error(t, info.Verify(chainID, ed25519.GenPrivKey().PubKey()))
+
+	msg, err := decodeMsg(mustEncodeMsg(info))
+	require.NoError(t, err)
+	decoded := msg.(*Info)
+	assert.Equal(t, info, decoded)
+
+	decoded.Website = "https://phishing.example.com"
+	assert.Error(t, decoded.Verify(chainID, key.PubKey()))
+
+	decoded.Signature = nil
+	assert.Error(t, decoded.ValidateBasic())
+}
+
+func TestReactorAddInfo(t *testing.T) {
+	key := ed25519.GenPrivKey()
+	r := NewReactor(config.TestOperatorConfig(), chainID, makeStateStore(t, key), nil, nil)
+	r.SetLogger(log.TestingLogger())
+
+	now := time.Now()
+	info := newTestInfo(t, key, "https://validator.example.com", now)
+	added, err := r.AddInfo(info)
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, []*Info{info}, r.Infos())
+
+	// the older and too frequent updates are dropped
+	added, err = r.AddInfo(newTestInfo(t, key, "https://old.example.com", now.Add(-time.Second)))
+	require.NoError(t, err)
+	assert.False(t, added)
+	added, err = r.AddInfo(newTestInfo(t, key, "https://new.example.com", now.Add(time.Second)))
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, info, r.Info(key.PubKey().Address()))
+
+	time.Sleep(r.config.MinUpdateInterval)
+	update := newTestInfo(t, key, "https://new.example.com", now.Add(time.Second))
+	added, err = r.AddInfo(update)
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, update, r.Info(key.PubKey().Address()))
+
+	// the infos of other validators are dropped, and the forged ones rejected
+	added, err = r.AddInfo(newTestInfo(t, ed25519.GenPrivKey(), "", now))
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	time.Sleep(r.config.MinUpdateInterval)
+	forged := newTestInfo(t, ed25519.GenPrivKey(), "https://phishing.example.com", now.Add(2*time.Second))
+	forged.ValidatorAddress = key.PubKey().Address()
+	_, err = r.AddInfo(forged)
+	assert.Error(t, err)
+
+	// dropped, the clock of either node may be off
+	added, err = r.AddInfo(newTestInfo(t, key, "", now.Add(time.Hour)))
+	assert.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, update, r.Info(key.PubKey().Address()))
+}
+
+func TestReactorGossip(t *testing.T) {
+	key := ed25519.GenPrivKey()
+	stateStore := makeStateStore(t, key)
+	own := newTestInfo(t, key, "https://validator.example.com", time.Now())
+
+	reactors := []*Reactor{
+		NewReactor(config.TestOperatorConfig(), chainID, stateStore, own, nil),
+		NewReactor(config.TestOperatorConfig(), chainID, stateStore, nil, nil),
+	}
+	switches := p2p.MakeConnectedSwitches(config.TestP2PConfig(), len(reactors),
+		func(i int, sw *p2p.Switch) *p2p.Switch {
+			reactors[i].SetLogger(log.TestingLogger().With("validator", i))
+			sw.AddReactor("OPERATOR", reactors[i])
+			return sw
+		}, p2p.Connect2Switches)
+	t.Cleanup(func() {
+		for _, sw := range switches {
+			if err := sw.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	assert.Eventually(t, func() bool {
+		return reactors[1].Info(key.PubKey().Address()) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, own, reactors[1].Info(key.PubKey().Address()))
+	assert.Equal(t, own, reactors[0].Info(key.PubKey().Address()))
+}
+
+func TestInfoSentryNodeIDs(t *testing.T) {
+	key := ed25519.GenPrivKey()
+	sentry1 := p2p.PubKeyToID(ed25519.GenPrivKey().PubKey())
+	sentry2 := p2p.PubKeyToID(ed25519.GenPrivKey().PubKey())
+	cfg := config.TestOperatorConfig()
+	cfg.SentryNodeIDs = string(sentry1) + ", " + string(sentry2)
+	info, err := NewInfo(chainID, "validator", cfg, key, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []p2p.ID{sentry1, sentry2}, info.SentryNodeIDs)
+	assert.True(t, info.AttestsSentry(sentry2))
+	assert.False(t, info.AttestsSentry(p2p.PubKeyToID(key.PubKey())))
+
+	msg, err := decodeMsg(mustEncodeMsg(info))
+	require.NoError(t, err)
+	decoded := msg.(*Info)
+	assert.Equal(t, info, decoded)
+
+	// the sentries are signed
+	decoded.SentryNodeIDs = append(decoded.SentryNodeIDs, p2p.PubKeyToID(ed25519.GenPrivKey().PubKey()))
+	assert.Error(t, decoded.Verify(chainID, key.PubKey()))
+
+	decoded.SentryNodeIDs = []p2p.ID{"sentry"}
+	assert.Error(t, decoded.ValidateBasic())
+}
+
+func newTestAttestation(t *testing.T, sentryKey crypto.PrivKey, validatorAddress []byte,
+	timestamp time.Time) *SentryAttestation {
+	a, err := NewSentryAttestation(chainID, validatorAddress, sentryKey, timestamp)
+	require.NoError(t, err)
+	return a
+}
+
+func TestSentryAttestationSignature(t *testing.T) {
+	sentryKey := ed25519.GenPrivKey()
+	validator := ed25519.GenPrivKey().PubKey().Address()
+	a := newTestAttestation(t, sentryKey, validator, time.Now())
+	assert.Equal(t, p2p.PubKeyToID(sentryKey.PubKey()), a.NodeID())
+	assert.NoError(t, a.Verify(chainID))
+	assert.Error(t, a.Verify("other-chain"))
+
+	msg, err := decodeMsg(mustEncodeMsg(a))
+	require.NoError(t, err)
+	decoded := msg.(*SentryAttestation)
+	assert.Equal(t, a, decoded)
+
+	decoded.ValidatorAddress = ed25519.GenPrivKey().PubKey().Address()
+	assert.Error(t, decoded.Verify(chainID))
+}
+
+func TestReactorPrioritizeSentries(t *testing.T) {
+	key := ed25519.GenPrivKey()
+	stateStore := makeStateStore(t, key)
+	sentryKey := ed25519.GenPrivKey()
+	sentry := p2p.PubKeyToID(sentryKey.PubKey())
+	ownCfg := config.TestOperatorConfig()
+	ownCfg.SentryNodeIDs = string(sentry)
+	own, err := NewInfo(chainID, "validator", ownCfg, key, time.Now())
+	require.NoError(t, err)
+	attestation := newTestAttestation(t, sentryKey, key.PubKey().Address(), time.Now())
+
+	cfg := config.TestOperatorConfig()
+	cfg.PrioritizeSentries = true
+	reactors := []*Reactor{
+		NewReactor(config.TestOperatorConfig(), chainID, stateStore, own, attestation),
+		NewReactor(cfg, chainID, stateStore, nil, nil),
+	}
+	switches := p2p.MakeConnectedSwitches(config.TestP2PConfig(), len(reactors),
+		func(i int, sw *p2p.Switch) *p2p.Switch {
+			reactors[i].SetLogger(log.TestingLogger().With("validator", i))
+			sw.AddReactor("OPERATOR", reactors[i])
+			return sw
+		}, p2p.Connect2Switches)
+	t.Cleanup(func() {
+		for _, sw := range switches {
+			if err := sw.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	assert.Eventually(t, func() bool {
+		return switches[1].IsPeerPriority(sentry)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, switches[1].IsPeerUnconditional(sentry))
+	assert.False(t, switches[0].IsPeerPriority(sentry))
+	assert.Equal(t, []*Info{own}, reactors[1].Attesting(sentry))
+	assert.Empty(t, reactors[1].Attesting(p2p.PubKeyToID(key.PubKey())))
+}
+
+// newPrioritizingReactor returns a reactor prioritizing the sentries, with a
+// switch which isn't started.
+func newPrioritizingReactor(t *testing.T, stateStore sm.Store) (*Reactor, *p2p.Switch) {
+	cfg := config.TestOperatorConfig()
+	cfg.PrioritizeSentries = true
+	r := NewReactor(cfg, chainID, stateStore, nil, nil)
+	r.SetLogger(log.TestingLogger())
+	sw := p2p.MakeSwitch(config.TestP2PConfig(), 0, "127.0.0.1", "123.123.123", func(i int, sw *p2p.Switch) *p2p.Switch {
+		sw.AddReactor("OPERATOR", r)
+		return sw
+	})
+	return r, sw
+}
+
+func TestReactorPrioritizeAttestedSentriesOnly(t *testing.T) {
+	key, otherKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	r, sw := newPrioritizingReactor(t, makeStateStore(t, key, otherKey))
+
+	// the validator claims the sentry of the other validator, and its own
+	sentryKeys := []crypto.PrivKey{ed25519.GenPrivKey(), ed25519.GenPrivKey()}
+	sentries := []p2p.ID{p2p.PubKeyToID(sentryKeys[0].PubKey()), p2p.PubKeyToID(sentryKeys[1].PubKey())}
+	cfg := config.TestOperatorConfig()
+	cfg.SentryNodeIDs = string(sentries[0]) + "," + string(sentries[1])
+	now := time.Now()
+	info, err := NewInfo(chainID, "validator", cfg, key, now)
+	require.NoError(t, err)
+	_, err = r.AddInfo(info)
+	require.NoError(t, err)
+	assert.False(t, sw.IsPeerPriority(sentries[0]))
+
+	added, err := r.AddSentryAttestation(newTestAttestation(t, sentryKeys[0], key.PubKey().Address(), now))
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = r.AddSentryAttestation(newTestAttestation(t, sentryKeys[1], otherKey.PubKey().Address(), now))
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.True(t, sw.IsPeerPriority(sentries[0]))
+	assert.False(t, sw.IsPeerPriority(sentries[1]))
+	added, err = r.AddSentryAttestation(newTestAttestation(t, sentryKeys[0], key.PubKey().Address(),
+		now.Add(time.Hour)))
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	// the sentries are replaced by the updates of the info
+	time.Sleep(r.config.MinUpdateInterval)
+	cfg.SentryNodeIDs = string(sentries[1])
+	info, err = NewInfo(chainID, "validator", cfg, key, now.Add(time.Second))
+	require.NoError(t, err)
+	_, err = r.AddInfo(info)
+	require.NoError(t, err)
+	assert.False(t, sw.IsPeerPriority(sentries[0]))
+	assert.False(t, sw.IsPeerUnconditional(sentries[0]))
+}
+
+func TestReactorDeprioritizeSentriesOfLeavingValidators(t *testing.T) {
+	key, otherKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	stateStore := makeStateStore(t, key, otherKey)
+	r, sw := newPrioritizingReactor(t, stateStore)
+
+	sentryKey := ed25519.GenPrivKey()
+	sentry := p2p.PubKeyToID(sentryKey.PubKey())
+	cfg := config.TestOperatorConfig()
+	cfg.SentryNodeIDs = string(sentry)
+	info, err := NewInfo(chainID, "validator", cfg, key, time.Now())
+	require.NoError(t, err)
+	_, err = r.AddInfo(info)
+	require.NoError(t, err)
+	_, err = r.AddSentryAttestation(newTestAttestation(t, sentryKey, key.PubKey().Address(), time.Now()))
+	require.NoError(t, err)
+	require.True(t, sw.IsPeerPriority(sentry))
+
+	// the validator leaves the set
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	state.Validators = types.NewValidatorSet([]*types.Validator{types.NewValidator(otherKey.PubKey(), 10)})
+	require.NoError(t, stateStore.Save(state))
+	_, err = r.currentValidators(time.Now().Add(validatorsRefreshInterval))
+	require.NoError(t, err)
+	assert.False(t, sw.IsPeerPriority(sentry))
+	assert.Nil(t, r.SentryAttestation(sentry))
+	assert.Nil(t, r.Info(key.PubKey().Address()))
+}
+
+func TestReactorCapPrioritySentries(t *testing.T) {
+	keys := make([]crypto.PrivKey, maxPrioritySentries/config.MaxOperatorSentryNodeIDs+1)
+	for i := range keys {
+		keys[i] = ed25519.GenPrivKey()
+	}
+	r, sw := newPrioritizingReactor(t, makeStateStore(t, keys...))
+
+	var sentries []p2p.ID
+	now := time.Now()
+	for _, key := range keys {
+		var ids []string
+		for i := 0; i < config.MaxOperatorSentryNodeIDs; i++ {
+			sentryKey := ed25519.GenPrivKey()
+			_, err := r.AddSentryAttestation(newTestAttestation(t, sentryKey, key.PubKey().Address(), now))
+			require.NoError(t, err)
+			sentries = append(sentries, p2p.PubKeyToID(sentryKey.PubKey()))
+			ids = append(ids, string(sentries[len(sentries)-1]))
+		}
+		cfg := config.TestOperatorConfig()
+		cfg.SentryNodeIDs = strings.Join(ids, ",")
+		info, err := NewInfo(chainID, "validator", cfg, key, now)
+		require.NoError(t, err)
+		_, err = r.AddInfo(info)
+		require.NoError(t, err)
+	}
+
+	prioritized := 0
+	for _, id := range sentries {
+		if sw.IsPeerPriority(id) {
+			prioritized++
+		}
+	}
+	assert.Equal(t, maxPrioritySentries, prioritized)
+
+	// and the attestations are bounded by the validators
+	_, err := r.AddSentryAttestation(newTestAttestation(t, ed25519.GenPrivKey(), keys[0].PubKey().Address(), now))
+	require.NoError(t, err)
+	assert.Len(t, r.sentryAttestations(), len(sentries))
+}
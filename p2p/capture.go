@@ -0,0 +1,310 @@
+package p2p
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/autofile"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/libs/service"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+const (
+	// CaptureIn is the direction of the envelopes received from a peer.
+	CaptureIn = "in"
+	// CaptureOut is the direction of the envelopes sent to a peer.
+	CaptureOut = "out"
+
+	// captureFlushInterval is how often the recorded envelopes are flushed to
+	// the capture file.
+	captureFlushInterval = time.Second
+	// captureFilesCount is the number of files the capture is rotated across.
+	captureFilesCount = 10
+	// captureQueueSize is the number of records waiting to be written to the
+	// capture file, beyond which the new records are dropped.
+	captureQueueSize = 1000
+)
+
+// CaptureRecord is the record of an envelope sent to or received from a peer
+// by a TrafficCapture. The records are stored as JSON lines.
+type CaptureRecord struct {
+	Time    time.Time `json:"time"`
+	Dir     string    `json:"dir"` // CaptureIn or CaptureOut
+	PeerID  ID        `json:"peer"`
+	Channel byte      `json:"channel"`
+	// Field number of the message in the oneof envelope of the channel (e.g.
+	// 1 for a NewRoundStep on the consensus state channel), or 0 if the
+	// envelope can't be decoded.
+	Type int `json:"type"`
+	Size int `json:"size"`
+	// Only recorded if CaptureConfig.Payload is set.
+	Payload tmbytes.HexBytes `json:"payload,omitempty"`
+}
+
+// CaptureConfig configures the recording of the envelopes exchanged with the
+// peers, see config.P2PConfig.CaptureFile.
+type CaptureConfig struct {
+	// Path of the capture file.
+	Path string
+	// Channels whose envelopes are recorded. If empty, all channels are.
+	Channels []byte
+	// Peers whose envelopes are recorded. If empty, all peers are.
+	PeerIDs []ID
+	// Only 1 of every SampleRate envelopes matching the filters is recorded.
+	SampleRate int
+	// Whether the payloads of the envelopes are recorded.
+	Payload bool
+	// Maximum total size of the capture files.
+	MaxSize int64
+}
+
+// CaptureConfigFromP2PConfig returns the CaptureConfig set in the given
+// config, or nil if the capture is disabled.
+func CaptureConfigFromP2PConfig(cfg *config.P2PConfig) (*CaptureConfig, error) {
+	if cfg.CaptureFile == "" {
+		return nil, nil
+	}
+	channels, err := cfg.CaptureChannelIDs()
+	if err != nil {
+		return nil, err
+	}
+	var peerIDs []ID
+	for _, s := range strings.Split(cfg.CapturePeerIDs, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			peerIDs = append(peerIDs, ID(s))
+		}
+	}
+	return &CaptureConfig{
+		Path:       cfg.CaptureFilePath(),
+		Channels:   channels,
+		PeerIDs:    peerIDs,
+		SampleRate: cfg.CaptureSampleRate,
+		Payload:    cfg.CapturePayload,
+		MaxSize:    cfg.CaptureMaxSize,
+	}, nil
+}
+
+// TrafficCapture records the envelopes exchanged with the peers into a
+// rotating capture file, for debugging. See OpenCaptureReader to read them.
+type TrafficCapture struct {
+	service.BaseService
+
+	cfg      *CaptureConfig
+	channels map[byte]bool // nil - all channels
+	peers    map[ID]bool   // nil - all peers
+	group    *autofile.Group
+	records  chan CaptureRecord
+	stop     chan struct{} // closed by OnStop, Quit is only closed after it
+	done     chan struct{} // closed when writeRoutine returns
+
+	matched uint64 // envelopes matching the filters, accessed atomically
+	dropped uint64 // records dropped because the queue was full, accessed atomically
+}
+
+// NewTrafficCapture returns a TrafficCapture recording the envelopes into the
+// capture file described by cfg. It must be started before being used.
+func NewTrafficCapture(cfg *CaptureConfig) (*TrafficCapture, error) {
+	if err := tmos.EnsureDir(filepath.Dir(cfg.Path), 0700); err != nil {
+		return nil, err
+	}
+	headSizeLimit := cfg.MaxSize / captureFilesCount
+	if headSizeLimit == 0 {
+		headSizeLimit = cfg.MaxSize
+	}
+	group, err := autofile.OpenGroup(cfg.Path,
+		autofile.GroupHeadSizeLimit(headSizeLimit),
+		autofile.GroupTotalSizeLimit(cfg.MaxSize))
+	if err != nil {
+		return nil, err
+	}
+
+	tc := &TrafficCapture{
+		cfg:     cfg,
+		group:   group,
+		records: make(chan CaptureRecord, captureQueueSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if len(cfg.Channels) > 0 {
+		tc.channels = make(map[byte]bool, len(cfg.Channels))
+		for _, chID := range cfg.Channels {
+			tc.channels[chID] = true
+		}
+	}
+	if len(cfg.PeerIDs) > 0 {
+		tc.peers = make(map[ID]bool, len(cfg.PeerIDs))
+		for _, id := range cfg.PeerIDs {
+			tc.peers[id] = true
+		}
+	}
+	tc.BaseService = *service.NewBaseService(nil, "TrafficCapture", tc)
+	return tc, nil
+}
+
+// OnStart implements service.Service.
+func (tc *TrafficCapture) OnStart() error {
+	if err := tc.group.Start(); err != nil {
+		return err
+	}
+	go tc.writeRoutine()
+	return nil
+}
+
+// OnStop implements service.Service by writing the queued records, then
+// flushing and closing the capture file.
+func (tc *TrafficCapture) OnStop() {
+	close(tc.stop)
+	<-tc.done
+	if err := tc.group.Stop(); err != nil {
+		tc.Logger.Error("Error stopping the capture file group", "err", err)
+	}
+	tc.group.Close()
+}
+
+// writeRoutine writes the queued records to the capture file, so that the
+// file I/O stays off the send and receive paths of the peers.
+func (tc *TrafficCapture) writeRoutine() {
+	defer close(tc.done)
+	ticker := time.NewTicker(captureFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case rec := <-tc.records:
+			tc.write(rec)
+		case <-ticker.C:
+			if n := atomic.SwapUint64(&tc.dropped, 0); n > 0 {
+				tc.Logger.Info("Dropped capture records, the capture file can't keep up", "count", n)
+			}
+			if err := tc.group.FlushAndSync(); err != nil {
+				tc.Logger.Error("Failed to flush the capture file", "err", err)
+			}
+		case <-tc.stop:
+			for {
+				select {
+				case rec := <-tc.records:
+					tc.write(rec)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (tc *TrafficCapture) write(rec CaptureRecord) {
+	bz, err := json.Marshal(rec)
+	if err != nil {
+		tc.Logger.Error("Failed to encode a capture record", "err", err)
+		return
+	}
+	if err := tc.group.WriteLine(string(bz)); err != nil {
+		tc.Logger.Error("Failed to write a capture record", "err", err)
+	}
+}
+
+// Record records the envelope msgBytes sent to (CaptureOut) or received from
+// (CaptureIn) the peer on the channel chID, if it matches the filters and is
+// sampled. The record is queued and written to the capture file in the
+// background; it's dropped if the queue is full.
+func (tc *TrafficCapture) Record(dir string, peerID ID, chID byte, msgBytes []byte) {
+	if !tc.IsRunning() {
+		return
+	}
+	if tc.channels != nil && !tc.channels[chID] {
+		return
+	}
+	if tc.peers != nil && !tc.peers[peerID] {
+		return
+	}
+	if n := atomic.AddUint64(&tc.matched, 1); tc.cfg.SampleRate > 1 && (n-1)%uint64(tc.cfg.SampleRate) != 0 {
+		return
+	}
+
+	rec := CaptureRecord{
+		Time:    tmtime.Now(),
+		Dir:     dir,
+		PeerID:  peerID,
+		Channel: chID,
+		Type:    envelopeType(msgBytes),
+		Size:    len(msgBytes),
+	}
+	if tc.cfg.Payload {
+		// the connection reuses its buffers once the envelope is handled
+		rec.Payload = append([]byte(nil), msgBytes...)
+	}
+	select {
+	case tc.records <- rec:
+	default:
+		atomic.AddUint64(&tc.dropped, 1)
+	}
+}
+
+// envelopeType returns the field number of the first field of the encoded
+// message, i.e. the type of the message in a oneof envelope.
+func envelopeType(msgBytes []byte) int {
+	key, n := proto.DecodeVarint(msgBytes)
+	if n == 0 {
+		return 0
+	}
+	return int(key >> 3)
+}
+
+// PeerTrafficCapture makes the peer record the envelopes it sends and
+// receives with tc. It's a no-op if tc is nil.
+func PeerTrafficCapture(tc *TrafficCapture) PeerOption {
+	return func(p *peer) {
+		p.capture = tc
+	}
+}
+
+// CaptureReader reads the records of a capture file, see TrafficCapture.
+type CaptureReader struct {
+	group  *autofile.Group
+	reader *autofile.GroupReader
+	dec    *json.Decoder
+}
+
+// OpenCaptureReader returns a reader of the records of the capture file at
+// path, starting from the oldest rotated file.
+func OpenCaptureReader(path string) (*CaptureReader, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	group, err := autofile.OpenGroup(path)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := group.NewReader(group.MinIndex())
+	if err != nil {
+		_ = group.Head.Close()
+		return nil, err
+	}
+	return &CaptureReader{group: group, reader: reader, dec: json.NewDecoder(reader)}, nil
+}
+
+// Read returns the next record, or io.EOF if there are no more records.
+func (r *CaptureReader) Read() (*CaptureRecord, error) {
+	rec := new(CaptureRecord)
+	if err := r.dec.Decode(rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// Close closes the capture files.
+func (r *CaptureReader) Close() error {
+	err := r.reader.Close()
+	if cerr := r.group.Head.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,81 @@
+package p2p
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
+
+func readCapture(t *testing.T, path string) []*CaptureRecord {
+	r, err := OpenCaptureReader(path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	var recs []*CaptureRecord
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return recs
+		}
+		require.NoError(t, err)
+		recs = append(recs, rec)
+	}
+}
+
+func TestTrafficCapture(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := config.DefaultP2PConfig()
+	cfg.RootDir = dir
+	cfg.CaptureFile = "data/p2p.capture"
+	cfg.CaptureChannels = "0x00,0x20"
+	cfg.CapturePeerIDs = "peer1, peer2"
+	cfg.CaptureSampleRate = 2
+	cfg.CapturePayload = true
+	captureCfg, err := CaptureConfigFromP2PConfig(cfg)
+	require.NoError(t, err)
+	tc, err := NewTrafficCapture(captureCfg)
+	require.NoError(t, err)
+	require.NoError(t, tc.Start())
+
+	msg := &tmp2p.Message{}
+	msg.Sum = &tmp2p.Message_PexAddrs{PexAddrs: &tmp2p.PexAddrs{}}
+	msgBytes, err := msg.Marshal()
+	require.NoError(t, err)
+
+	tc.Record(CaptureOut, "peer1", 0x00, msgBytes)
+	tc.Record(CaptureOut, "peer3", 0x00, msgBytes) // filtered out by peer
+	tc.Record(CaptureIn, "peer2", 0x30, msgBytes)  // filtered out by channel
+	tc.Record(CaptureIn, "peer2", 0x20, []byte{0x0a, 0x00})
+	tc.Record(CaptureIn, "peer2", 0x00, msgBytes)
+	require.NoError(t, tc.Stop())
+	tc.Record(CaptureIn, "peer2", 0x00, msgBytes) // stopped
+
+	recs := readCapture(t, filepath.Join(dir, "data", "p2p.capture"))
+	require.Len(t, recs, 2) // 1 of every 2 matching envelopes
+	assert.Equal(t, CaptureOut, recs[0].Dir)
+	assert.EqualValues(t, "peer1", recs[0].PeerID)
+	assert.EqualValues(t, 0x00, recs[0].Channel)
+	assert.Equal(t, 2, recs[0].Type)
+	assert.Equal(t, len(msgBytes), recs[0].Size)
+	assert.EqualValues(t, msgBytes, recs[0].Payload)
+	assert.False(t, recs[0].Time.IsZero())
+	assert.Equal(t, CaptureIn, recs[1].Dir)
+	assert.EqualValues(t, "peer2", recs[1].PeerID)
+}
+
+func TestCaptureConfigFromP2PConfigDisabled(t *testing.T) {
+	captureCfg, err := CaptureConfigFromP2PConfig(config.DefaultP2PConfig())
+	require.NoError(t, err)
+	assert.Nil(t, captureCfg)
+}
@@ -0,0 +1,106 @@
+package p2p
+
+import (
+	"sort"
+	"sync/atomic"
+
+	tmconn "github.com/tendermint/tendermint/p2p/conn"
+)
+
+// ChannelStats are live counters of the traffic with a peer on a channel,
+// since the peer connected. Unlike the Prometheus metrics, which are
+// aggregated over time, they tell which peer is flooding which channel right
+// now.
+type ChannelStats struct {
+	ChannelID     byte  `json:"channel_id"`
+	MsgsSent      int64 `json:"msgs_sent"`
+	BytesSent     int64 `json:"bytes_sent"`
+	MsgsReceived  int64 `json:"msgs_received"`
+	BytesReceived int64 `json:"bytes_received"`
+	// Messages received but not delivered to the reactor, e.g. because they
+	// failed authentication.
+	ReceiveErrors int64 `json:"receive_errors"`
+	// Messages which couldn't be sent, the send queue being full: Send timed
+	// out, or TrySend gave up.
+	SendStalls int64 `json:"send_stalls"`
+	// Number of messages waiting in the send queue.
+	SendQueueSize int `json:"send_queue_size"`
+}
+
+// PeerChannelStats are the ChannelStats of a connected peer, by channel ID.
+type PeerChannelStats struct {
+	PeerID   ID             `json:"peer_id"`
+	Channels []ChannelStats `json:"channels"`
+}
+
+// channelCounters are the counters of a channel, updated atomically.
+type channelCounters struct {
+	msgsSent      int64
+	bytesSent     int64
+	msgsReceived  int64
+	bytesReceived int64
+	receiveErrors int64
+	sendStalls    int64
+}
+
+// newChannelCounters returns the counters of the given channels. The map is
+// never modified afterwards, so it's safe for concurrent reads.
+func newChannelCounters(chDescs []*tmconn.ChannelDescriptor) map[byte]*channelCounters {
+	counters := make(map[byte]*channelCounters, len(chDescs))
+	for _, desc := range chDescs {
+		counters[desc.ID] = &channelCounters{}
+	}
+	return counters
+}
+
+func (c *channelCounters) sent(msgBytes []byte, ok bool) {
+	if !ok {
+		atomic.AddInt64(&c.sendStalls, 1)
+		return
+	}
+	atomic.AddInt64(&c.msgsSent, 1)
+	atomic.AddInt64(&c.bytesSent, int64(len(msgBytes)))
+}
+
+func (c *channelCounters) received(msgBytes []byte) {
+	atomic.AddInt64(&c.msgsReceived, 1)
+	atomic.AddInt64(&c.bytesReceived, int64(len(msgBytes)))
+}
+
+func (c *channelCounters) receiveError() {
+	atomic.AddInt64(&c.receiveErrors, 1)
+}
+
+func (c *channelCounters) stats(chID byte) ChannelStats {
+	return ChannelStats{
+		ChannelID:     chID,
+		MsgsSent:      atomic.LoadInt64(&c.msgsSent),
+		BytesSent:     atomic.LoadInt64(&c.bytesSent),
+		MsgsReceived:  atomic.LoadInt64(&c.msgsReceived),
+		BytesReceived: atomic.LoadInt64(&c.bytesReceived),
+		ReceiveErrors: atomic.LoadInt64(&c.receiveErrors),
+		SendStalls:    atomic.LoadInt64(&c.sendStalls),
+	}
+}
+
+// ChannelStats returns the live per-channel stats of the connected peers,
+// sorted by peer ID, or only of the given peer if not empty.
+func (sw *Switch) ChannelStats(peerID ID) []PeerChannelStats {
+	type statsPeer interface {
+		channelStats() []ChannelStats
+	}
+
+	var stats []PeerChannelStats
+	for _, peer := range sw.peers.List() {
+		if peerID != "" && peer.ID() != peerID {
+			continue
+		}
+		sp, ok := peer.(statsPeer)
+		if !ok {
+			continue
+		}
+		stats = append(stats, PeerChannelStats{PeerID: peer.ID(), Channels: sp.channelStats()})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].PeerID < stats[j].PeerID })
+	return stats
+}
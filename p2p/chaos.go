@@ -0,0 +1,171 @@
+package p2p
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// chaosReorderTimeout is the maximum time a message held back for reordering
+// waits for the next message on its channel before being sent anyway.
+const chaosReorderTimeout = 500 * time.Millisecond
+
+// ChaosConfig configures the faults injected into the messages sent to a peer.
+// It's only meant for testing the robustness of reactors, see
+// config.P2PConfig.TestChaos.
+type ChaosConfig struct {
+	// Seed of the RNG used to choose the faults. Each peer uses its own RNG,
+	// seeded with Seed and the peer ID, so the faults injected into the
+	// messages sent to a peer only depend on the order of those messages.
+	Seed int64
+	// Channels to inject faults into. If empty, all channels are affected.
+	Channels []byte
+	// Maximum latency added to every message.
+	MaxLatency time.Duration
+	// Probability for a message to be sent after the next one on its channel.
+	ReorderRate float64
+	// Probability for a message to be sent twice.
+	DuplicateRate float64
+	// Probability for a message to have a random bit flipped.
+	CorruptRate float64
+}
+
+// ChaosConfigFromP2PConfig returns the ChaosConfig set in the given config, or
+// nil if chaos mode is disabled.
+func ChaosConfigFromP2PConfig(cfg *config.P2PConfig) (*ChaosConfig, error) {
+	if !cfg.TestChaos {
+		return nil, nil
+	}
+	channels, err := cfg.TestChaosChannelIDs()
+	if err != nil {
+		return nil, err
+	}
+	return &ChaosConfig{
+		Seed:          cfg.TestChaosSeed,
+		Channels:      channels,
+		MaxLatency:    cfg.TestChaosMaxLatency,
+		ReorderRate:   cfg.TestChaosReorderRate,
+		DuplicateRate: cfg.TestChaosDuplicateRate,
+		CorruptRate:   cfg.TestChaosCorruptRate,
+	}, nil
+}
+
+// PeerChaos makes the peer inject the faults described by cfg into the
+// messages it sends. It's a no-op if cfg is nil.
+func PeerChaos(cfg *ChaosConfig) PeerOption {
+	return func(p *peer) {
+		if cfg != nil {
+			p.chaos = newChaosSender(cfg, p.ID())
+		}
+	}
+}
+
+// heldMsg is a message held back so it's sent after the next message on its
+// channel.
+type heldMsg struct {
+	msgBytes []byte
+	send     func(byte, []byte) bool
+}
+
+// chaosSender injects faults into the messages sent to a single peer.
+type chaosSender struct {
+	cfg *ChaosConfig
+
+	mtx  tmsync.Mutex
+	rng  *rand.Rand
+	held map[byte]*heldMsg
+}
+
+func newChaosSender(cfg *ChaosConfig, id ID) *chaosSender {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(id))
+	return &chaosSender{
+		cfg:  cfg,
+		rng:  rand.New(rand.NewSource(cfg.Seed ^ int64(h.Sum64()))), //nolint:gosec
+		held: make(map[byte]*heldMsg),
+	}
+}
+
+func (c *chaosSender) affects(chID byte) bool {
+	if len(c.cfg.Channels) == 0 {
+		return true
+	}
+	for _, ch := range c.cfg.Channels {
+		if ch == chID {
+			return true
+		}
+	}
+	return false
+}
+
+// Send sends the message using send, after corrupting, duplicating, delaying
+// and/or holding it back according to the config. Delayed and held back
+// messages are reported as sent.
+func (c *chaosSender) Send(chID byte, msgBytes []byte, send func(byte, []byte) bool) bool {
+	if !c.affects(chID) {
+		return send(chID, msgBytes)
+	}
+
+	c.mtx.Lock()
+	if c.rng.Float64() < c.cfg.CorruptRate && len(msgBytes) > 0 {
+		// the caller may be sending the same slice to other peers
+		corrupted := make([]byte, len(msgBytes))
+		copy(corrupted, msgBytes)
+		bit := c.rng.Intn(len(corrupted) * 8)
+		corrupted[bit/8] ^= 1 << uint(bit%8)
+		msgBytes = corrupted
+	}
+	duplicate := c.rng.Float64() < c.cfg.DuplicateRate
+	reorder := c.rng.Float64() < c.cfg.ReorderRate
+	var latency time.Duration
+	if c.cfg.MaxLatency > 0 {
+		latency = time.Duration(c.rng.Int63n(int64(c.cfg.MaxLatency) + 1))
+	}
+
+	msgs := []*heldMsg{{msgBytes: msgBytes, send: send}}
+	if prev, ok := c.held[chID]; ok {
+		delete(c.held, chID)
+		msgs = append(msgs, prev)
+	} else if reorder {
+		msg := msgs[0]
+		c.held[chID] = msg
+		c.mtx.Unlock()
+		time.AfterFunc(chaosReorderTimeout, func() { c.release(chID, msg) })
+		return true
+	}
+	if duplicate {
+		msgs = append(msgs, msgs[0])
+	}
+	c.mtx.Unlock()
+
+	if latency == 0 {
+		ok := true
+		for _, msg := range msgs {
+			ok = msg.send(chID, msg.msgBytes) && ok
+		}
+		return ok
+	}
+	time.AfterFunc(latency, func() {
+		for _, msg := range msgs {
+			msg.send(chID, msg.msgBytes)
+		}
+	})
+	return true
+}
+
+// release sends the held back message if no other message was sent on its
+// channel in the meantime.
+func (c *chaosSender) release(chID byte, msg *heldMsg) {
+	c.mtx.Lock()
+	if c.held[chID] != msg {
+		c.mtx.Unlock()
+		return
+	}
+	delete(c.held, chID)
+	c.mtx.Unlock()
+
+	msg.send(chID, msg.msgBytes)
+}
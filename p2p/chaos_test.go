@@ -0,0 +1,160 @@
+package p2p
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+type sentMsgs struct {
+	mtx  tmsync.Mutex
+	msgs []string
+}
+
+func (s *sentMsgs) send(chID byte, msgBytes []byte) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.msgs = append(s.msgs, fmt.Sprintf("%#x:%x", chID, msgBytes))
+	return true
+}
+
+func (s *sentMsgs) get() []string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([]string(nil), s.msgs...)
+}
+
+func sendChaos(c *chaosSender, n int) []string {
+	sent := &sentMsgs{}
+	for i := 0; i < n; i++ {
+		c.Send(0x01, []byte{byte(i), 0xff}, sent.send)
+	}
+	return sent.get()
+}
+
+func TestChaosSenderDeterministic(t *testing.T) {
+	cfg := &ChaosConfig{
+		Seed:          42,
+		ReorderRate:   0.2,
+		DuplicateRate: 0.2,
+		CorruptRate:   0.2,
+	}
+
+	msgs := sendChaos(newChaosSender(cfg, "a"), 100)
+	assert.Equal(t, msgs, sendChaos(newChaosSender(cfg, "a"), 100))
+	assert.NotEqual(t, msgs, sendChaos(newChaosSender(cfg, "b"), 100))
+
+	cfg2 := *cfg
+	cfg2.Seed = 43
+	assert.NotEqual(t, msgs, sendChaos(newChaosSender(&cfg2, "a"), 100))
+}
+
+func TestChaosSenderFaults(t *testing.T) {
+	sent := &sentMsgs{}
+
+	// unaffected channels
+	c := newChaosSender(&ChaosConfig{Channels: []byte{0x02}, DuplicateRate: 1}, "a")
+	assert.True(t, c.Send(0x01, []byte{0x01}, sent.send))
+	assert.Equal(t, []string{"0x1:01"}, sent.get())
+
+	// duplication
+	sent = &sentMsgs{}
+	assert.True(t, c.Send(0x02, []byte{0x01}, sent.send))
+	assert.Equal(t, []string{"0x2:01", "0x2:01"}, sent.get())
+
+	// corruption flips a single bit, without modifying the original message
+	msg := []byte{0x00, 0x00, 0x00}
+	var corrupted []byte
+	c = newChaosSender(&ChaosConfig{CorruptRate: 1}, "a")
+	c.Send(0x01, msg, func(_ byte, msgBytes []byte) bool {
+		corrupted = msgBytes
+		return true
+	})
+	assert.Equal(t, []byte{0x00, 0x00, 0x00}, msg)
+	require.Len(t, corrupted, 3)
+	flipped := 0
+	for _, b := range corrupted {
+		for ; b > 0; b &= b - 1 {
+			flipped++
+		}
+	}
+	assert.Equal(t, 1, flipped)
+
+	// reordering holds a message back until the next one is sent
+	sent = &sentMsgs{}
+	c = newChaosSender(&ChaosConfig{ReorderRate: 1}, "a")
+	assert.True(t, c.Send(0x01, []byte{0x01}, sent.send))
+	assert.Empty(t, sent.get())
+	assert.True(t, c.Send(0x01, []byte{0x02}, sent.send))
+	assert.Equal(t, []string{"0x1:02", "0x1:01"}, sent.get())
+
+	// or until the reorder timeout
+	sent = &sentMsgs{}
+	assert.True(t, c.Send(0x01, []byte{0x03}, sent.send))
+	assert.Eventually(t, func() bool {
+		return len(sent.get()) == 1
+	}, 2*chaosReorderTimeout, 10*time.Millisecond)
+
+	// latency
+	sent = &sentMsgs{}
+	c = newChaosSender(&ChaosConfig{MaxLatency: 50 * time.Millisecond}, "a")
+	for i := 0; i < 10; i++ {
+		assert.True(t, c.Send(0x01, []byte{byte(i)}, sent.send))
+	}
+	assert.Eventually(t, func() bool {
+		return len(sent.get()) == 10
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestChaosConfigFromP2PConfig(t *testing.T) {
+	p2pConfig := config.DefaultP2PConfig()
+	chaos, err := ChaosConfigFromP2PConfig(p2pConfig)
+	require.NoError(t, err)
+	assert.Nil(t, chaos)
+
+	p2pConfig.TestChaos = true
+	p2pConfig.TestChaosSeed = 1
+	p2pConfig.TestChaosChannels = "0x20,0x21"
+	p2pConfig.TestChaosReorderRate = 0.1
+	chaos, err = ChaosConfigFromP2PConfig(p2pConfig)
+	require.NoError(t, err)
+	assert.Equal(t, &ChaosConfig{Seed: 1, Channels: []byte{0x20, 0x21}, ReorderRate: 0.1}, chaos)
+}
+
+func TestSwitchChaos(t *testing.T) {
+	chaos := &ChaosConfig{DuplicateRate: 1}
+
+	s1, s2 := MakeSwitchPair(t, func(i int, sw *Switch) *Switch {
+		sw = initSwitchFunc(i, sw)
+		if i == 0 {
+			SwitchChaos(chaos)(sw)
+		}
+		return sw
+	})
+	t.Cleanup(func() {
+		if err := s1.Stop(); err != nil {
+			t.Error(err)
+		}
+		if err := s2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ch0Msg := []byte("channel zero")
+	s1.Broadcast(byte(0x00), ch0Msg)
+
+	r := s2.Reactor("foo").(*TestReactor)
+	assert.Eventually(t, func() bool {
+		return len(r.getMsgs(byte(0x00))) == 2
+	}, time.Second, 10*time.Millisecond)
+	for _, msg := range r.getMsgs(byte(0x00)) {
+		assert.True(t, bytes.Equal(ch0Msg, msg.Bytes))
+	}
+}
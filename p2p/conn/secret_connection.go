@@ -49,6 +49,7 @@ var (
 	labelSecretConnectionMac     = []byte("SECRET_CONNECTION_MAC")
 
 	secretConnKeyAndChallengeGen = []byte("TENDERMINT_SECRET_CONNECTION_KEY_AND_CHALLENGE_GEN")
+	secretConnRekeyGen           = []byte("TENDERMINT_SECRET_CONNECTION_REKEY_GEN")
 )
 
 // SecretConnection implements net.Conn.
@@ -82,6 +83,24 @@ type SecretConnection struct {
 
 	sendMtx   tmsync.Mutex
 	sendNonce *[aeadNonceSize]byte
+
+	// Rekeying state, see EnableRekeying. The current keys salt the
+	// derivation of the next ones.
+	recvKey      *[aeadKeySize]byte
+	recvNextAead cipher.AEAD
+	recvNextKey  *[aeadKeySize]byte
+
+	sendKey       *[aeadKeySize]byte
+	sendEphPriv   *[32]byte // set while a rekey of the send key is in flight
+	sendBytes     int64
+	sendKeyTime   time.Time
+	rekeyBytes    int64
+	rekeyInterval time.Duration
+
+	// Control frames to be sent by the next Write, set by Read.
+	ctrlMtx       tmsync.Mutex
+	ctrlAckPub    *[32]byte // acks the rekey of the receive key
+	ctrlRemEphPub *[32]byte // acked the rekey of the send key
 }
 
 // MakeSecretConnection performs handshake and returns a new authenticated
@@ -146,12 +165,15 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 	}
 
 	sc := &SecretConnection{
-		conn:       conn,
-		recvBuffer: nil,
-		recvNonce:  new([aeadNonceSize]byte),
-		sendNonce:  new([aeadNonceSize]byte),
-		recvAead:   recvAead,
-		sendAead:   sendAead,
+		conn:        conn,
+		recvBuffer:  nil,
+		recvNonce:   new([aeadNonceSize]byte),
+		sendNonce:   new([aeadNonceSize]byte),
+		recvAead:    recvAead,
+		sendAead:    sendAead,
+		recvKey:     recvSecret,
+		sendKey:     sendSecret,
+		sendKeyTime: time.Now(),
 	}
 
 	// Sign the challenge bytes for authentication.
@@ -190,14 +212,14 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 	sc.sendMtx.Lock()
 	defer sc.sendMtx.Unlock()
 
+	if err := sc.rekeySend(); err != nil {
+		return 0, err
+	}
+
 	for 0 < len(data) {
 		if err := func() error {
-			var sealedFrame = pool.Get(aeadSizeOverhead + totalFrameSize)
 			var frame = pool.Get(totalFrameSize)
-			defer func() {
-				pool.Put(sealedFrame)
-				pool.Put(frame)
-			}()
+			defer pool.Put(frame)
 			var chunk []byte
 			if dataMaxSize < len(data) {
 				chunk = data[:dataMaxSize]
@@ -210,13 +232,7 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 			binary.LittleEndian.PutUint32(frame, uint32(chunkLength))
 			copy(frame[dataLenSize:], chunk)
 
-			// encrypt the frame
-			sc.sendAead.Seal(sealedFrame[:0], sc.sendNonce[:], frame, nil)
-			incrNonce(sc.sendNonce)
-			// end encryption
-
-			_, err = sc.conn.Write(sealedFrame)
-			if err != nil {
+			if err := sc.writeFrame(frame); err != nil {
 				return err
 			}
 			n += len(chunk)
@@ -240,28 +256,39 @@ func (sc *SecretConnection) Read(data []byte) (n int, err error) {
 		return
 	}
 
-	// read off the conn
 	var sealedFrame = pool.Get(aeadSizeOverhead + totalFrameSize)
 	defer pool.Put(sealedFrame)
-	_, err = io.ReadFull(sc.conn, sealedFrame)
-	if err != nil {
-		return
-	}
-
-	// decrypt the frame.
-	// reads and updates the sc.recvNonce
 	var frame = pool.Get(totalFrameSize)
 	defer pool.Put(frame)
-	_, err = sc.recvAead.Open(frame[:0], sc.recvNonce[:], sealedFrame, nil)
-	if err != nil {
-		return n, fmt.Errorf("failed to decrypt SecretConnection: %w", err)
+
+	var chunkLength uint32
+	for {
+		// read off the conn
+		_, err = io.ReadFull(sc.conn, sealedFrame)
+		if err != nil {
+			return
+		}
+
+		// decrypt the frame.
+		// reads and updates the sc.recvNonce
+		_, err = sc.recvAead.Open(frame[:0], sc.recvNonce[:], sealedFrame, nil)
+		if err != nil {
+			return n, fmt.Errorf("failed to decrypt SecretConnection: %w", err)
+		}
+		incrNonce(sc.recvNonce)
+		// end decryption
+
+		chunkLength = binary.LittleEndian.Uint32(frame) // read the first four bytes
+		if chunkLength&frameFlagControl == 0 {
+			break
+		}
+		if err := sc.handleControlFrame(chunkLength&^frameFlagControl, frame[dataLenSize:]); err != nil {
+			return 0, err
+		}
 	}
-	incrNonce(sc.recvNonce)
-	// end decryption
 
 	// copy checkLength worth into data,
 	// set recvBuffer to the rest.
-	var chunkLength = binary.LittleEndian.Uint32(frame) // read the first four bytes
 	if chunkLength > dataMaxSize {
 		return 0, errors.New("chunkLength is greater than dataMaxSize")
 	}
@@ -0,0 +1,193 @@
+package conn
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	pool "github.com/libp2p/go-buffer-pool"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Control frames have frameFlagControl set in their length field, which holds
+// the control frame type instead of a length.
+//
+// The key used to send in one direction is rotated with a X25519 exchange of
+// fresh ephemeral keys, carried in control frames encrypted with the current
+// keys:
+//
+//	sender                                receiver
+//	rekeyInit (sender eph. pub key)   ->
+//	                                  <-  rekeyAck (receiver eph. pub key)
+//	rekeySwitch                       ->
+//	frames sealed with the new key    ->
+//
+// Both sides derive the new key from their shared secret, salted with the
+// current key, and reset the nonce. The ephemeral private keys are discarded
+// as soon as the new key is derived, so a compromise of the current key
+// doesn't expose the frames sent with the previous ones.
+const (
+	frameFlagControl = 1 << 31
+
+	rekeyInit   = 1
+	rekeyAck    = 2
+	rekeySwitch = 3
+)
+
+// EnableRekeying makes the connection rotate the key used to send frames,
+// once the given number of bytes have been sent with it, or once it has been
+// used for the given interval (checked when writing). Zero disables either
+// limit.
+//
+// The remote peer must support rekeying: older versions close the connection
+// when they receive the first rekeying frame.
+func (sc *SecretConnection) EnableRekeying(bytes int64, interval time.Duration) {
+	sc.sendMtx.Lock()
+	defer sc.sendMtx.Unlock()
+	sc.rekeyBytes = bytes
+	sc.rekeyInterval = interval
+}
+
+// writeFrame encrypts and writes a frame of totalFrameSize. sendMtx must be
+// held.
+func (sc *SecretConnection) writeFrame(frame []byte) error {
+	var sealedFrame = pool.Get(aeadSizeOverhead + totalFrameSize)
+	defer pool.Put(sealedFrame)
+
+	sc.sendAead.Seal(sealedFrame[:0], sc.sendNonce[:], frame, nil)
+	incrNonce(sc.sendNonce)
+
+	_, err := sc.conn.Write(sealedFrame)
+	sc.sendBytes += int64(len(sealedFrame))
+	return err
+}
+
+func (sc *SecretConnection) writeControlFrame(ctrlType uint32, pubKey *[32]byte) error {
+	var frame = make([]byte, totalFrameSize)
+	binary.LittleEndian.PutUint32(frame, frameFlagControl|ctrlType)
+	if pubKey != nil {
+		copy(frame[dataLenSize:], pubKey[:])
+	}
+	return sc.writeFrame(frame)
+}
+
+// rekeySend writes the pending control frames, and switches to the next send
+// key once acked by the remote peer. sendMtx must be held.
+func (sc *SecretConnection) rekeySend() error {
+	sc.ctrlMtx.Lock()
+	ackPub, remEphPub := sc.ctrlAckPub, sc.ctrlRemEphPub
+	sc.ctrlAckPub, sc.ctrlRemEphPub = nil, nil
+	sc.ctrlMtx.Unlock()
+
+	if ackPub != nil {
+		if err := sc.writeControlFrame(rekeyAck, ackPub); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case remEphPub != nil:
+		if sc.sendEphPriv == nil {
+			return errors.New("received an unexpected rekey ack")
+		}
+		dhSecret, err := computeDHSecret(remEphPub, sc.sendEphPriv)
+		*sc.sendEphPriv = [32]byte{}
+		sc.sendEphPriv = nil
+		if err != nil {
+			return fmt.Errorf("rekey failed: %w", err)
+		}
+		key := deriveRekeyedSecret(dhSecret, sc.sendKey)
+		aead, err := chacha20poly1305.New(key[:])
+		if err != nil {
+			return errors.New("invalid rekeyed send SecretConnection Key")
+		}
+
+		if err := sc.writeControlFrame(rekeySwitch, nil); err != nil {
+			return err
+		}
+		*sc.sendKey = [aeadKeySize]byte{}
+		sc.sendAead, sc.sendKey = aead, key
+		sc.sendNonce = new([aeadNonceSize]byte)
+		sc.sendBytes = 0
+		sc.sendKeyTime = time.Now()
+
+	case sc.sendEphPriv == nil && sc.rekeyDue():
+		ephPub, ephPriv := genEphKeys()
+		if err := sc.writeControlFrame(rekeyInit, ephPub); err != nil {
+			return err
+		}
+		sc.sendEphPriv = ephPriv
+	}
+	return nil
+}
+
+func (sc *SecretConnection) rekeyDue() bool {
+	return (sc.rekeyBytes > 0 && sc.sendBytes >= sc.rekeyBytes) ||
+		(sc.rekeyInterval > 0 && time.Since(sc.sendKeyTime) >= sc.rekeyInterval)
+}
+
+// handleControlFrame handles a control frame read off the connection. recvMtx
+// must be held.
+func (sc *SecretConnection) handleControlFrame(ctrlType uint32, payload []byte) error {
+	switch ctrlType {
+	case rekeyInit:
+		if sc.recvNextAead != nil {
+			return errors.New("received a rekey init while rekeying")
+		}
+		var remEphPub [32]byte
+		copy(remEphPub[:], payload)
+		ephPub, ephPriv := genEphKeys()
+		dhSecret, err := computeDHSecret(&remEphPub, ephPriv)
+		*ephPriv = [32]byte{}
+		if err != nil {
+			return fmt.Errorf("rekey failed: %w", err)
+		}
+		key := deriveRekeyedSecret(dhSecret, sc.recvKey)
+		aead, err := chacha20poly1305.New(key[:])
+		if err != nil {
+			return errors.New("invalid rekeyed receive SecretConnection Key")
+		}
+		sc.recvNextAead, sc.recvNextKey = aead, key
+
+		sc.ctrlMtx.Lock()
+		sc.ctrlAckPub = ephPub
+		sc.ctrlMtx.Unlock()
+
+	case rekeyAck:
+		var remEphPub [32]byte
+		copy(remEphPub[:], payload)
+
+		sc.ctrlMtx.Lock()
+		sc.ctrlRemEphPub = &remEphPub
+		sc.ctrlMtx.Unlock()
+
+	case rekeySwitch:
+		if sc.recvNextAead == nil {
+			return errors.New("received a rekey switch without a rekey init")
+		}
+		*sc.recvKey = [aeadKeySize]byte{}
+		sc.recvAead, sc.recvKey = sc.recvNextAead, sc.recvNextKey
+		sc.recvNextAead, sc.recvNextKey = nil, nil
+		sc.recvNonce = new([aeadNonceSize]byte)
+
+	default:
+		return fmt.Errorf("unknown control frame type %d", ctrlType)
+	}
+	return nil
+}
+
+// deriveRekeyedSecret derives the next key of a direction from the shared
+// secret of the rekeying exchange, salted with the current key.
+func deriveRekeyedSecret(dhSecret *[32]byte, key *[aeadKeySize]byte) *[aeadKeySize]byte {
+	hkdf := hkdf.New(sha256.New, dhSecret[:], key[:], secretConnRekeyGen)
+	res := new([aeadKeySize]byte)
+	if _, err := io.ReadFull(hkdf, res[:]); err != nil {
+		panic(err)
+	}
+	*dhSecret = [32]byte{}
+	return res
+}
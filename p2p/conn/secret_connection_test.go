@@ -14,6 +14,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -223,6 +224,55 @@ func TestSecretConnectionReadWrite(t *testing.T) {
 	compareWritesReads(barWrites, fooReads)
 }
 
+func TestSecretConnectionRekeying(t *testing.T) {
+	fooSecConn, barSecConn := makeSecretConnPair(t)
+	fooSendKey, barSendKey := *fooSecConn.sendKey, *barSecConn.sendKey
+	fooSecConn.EnableRekeying(4*totalFrameSize, 0)
+	barSecConn.EnableRekeying(0, time.Nanosecond)
+
+	const n = 100
+	conns := []struct {
+		name   string
+		writer *SecretConnection
+		reader *SecretConnection
+	}{
+		{"foo", fooSecConn, barSecConn},
+		{"bar", barSecConn, fooSecConn},
+	}
+	wg := new(sync.WaitGroup)
+	for _, c := range conns {
+		c := c
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			readBuffer := make([]byte, dataMaxSize)
+			for i := 0; i < n; i++ {
+				m, err := c.reader.Read(readBuffer)
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.True(t, strings.HasPrefix(string(readBuffer[:m]), fmt.Sprintf("%s %d ", c.name, i)))
+			}
+		}()
+	}
+	// interleave the writes, so that the rekeying exchanges complete
+	for i := 0; i < n; i++ {
+		for _, c := range conns {
+			_, err := c.writer.Write([]byte(fmt.Sprintf("%s %d %s", c.name, i, tmrand.Str(dataMaxSize/2))))
+			require.NoError(t, err)
+		}
+	}
+	wg.Wait()
+
+	assert.NotEqual(t, fooSendKey, *fooSecConn.sendKey)
+	assert.Equal(t, *fooSecConn.sendKey, *barSecConn.recvKey)
+	assert.NotEqual(t, barSendKey, *barSecConn.sendKey)
+	assert.Equal(t, *barSecConn.sendKey, *fooSecConn.recvKey)
+
+	require.NoError(t, fooSecConn.Close())
+	require.NoError(t, barSecConn.Close())
+}
+
 func TestDeriveSecretsAndChallengeGolden(t *testing.T) {
 	goldenFilepath := filepath.Join("testdata", t.Name()+".golden")
 	if *update {
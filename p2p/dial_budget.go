@@ -0,0 +1,52 @@
+package p2p
+
+import (
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// dialBudgetWindow is the window over which the dials of each peer are
+// limited.
+const dialBudgetWindow = time.Hour
+
+// dialBudget limits the number of times each peer is dialed over
+// dialBudgetWindow.
+type dialBudget struct {
+	max int // 0 means unlimited
+
+	mtx   tmsync.Mutex
+	dials map[ID][]time.Time // in the window, oldest first
+}
+
+func newDialBudget(max int) *dialBudget {
+	return &dialBudget{max: max, dials: make(map[ID][]time.Time)}
+}
+
+// reserve records a dial of the peer at now, unless its budget is exhausted,
+// in which case it returns false and the time to wait for the next dial.
+func (b *dialBudget) reserve(id ID, now time.Time) (bool, time.Duration) {
+	if b.max == 0 {
+		return true, 0
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	dials := b.dials[id]
+	for len(dials) > 0 && now.Sub(dials[0]) >= dialBudgetWindow {
+		dials = dials[1:]
+	}
+	if len(dials) >= b.max {
+		b.dials[id] = dials
+		return false, dials[0].Add(dialBudgetWindow).Sub(now)
+	}
+	b.dials[id] = append(dials, now)
+
+	// forget the peers which weren't dialed in the window
+	for otherID, otherDials := range b.dials {
+		if now.Sub(otherDials[len(otherDials)-1]) >= dialBudgetWindow {
+			delete(b.dials, otherID)
+		}
+	}
+	return true, 0
+}
@@ -0,0 +1,44 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialBudget(t *testing.T) {
+	b := newDialBudget(2)
+	now := time.Now()
+
+	ok, _ := b.reserve("a", now)
+	assert.True(t, ok)
+	ok, _ = b.reserve("a", now.Add(time.Minute))
+	assert.True(t, ok)
+	ok, retryAfter := b.reserve("a", now.Add(2*time.Minute))
+	assert.False(t, ok)
+	assert.Equal(t, 58*time.Minute, retryAfter)
+
+	// the budget is per peer
+	ok, _ = b.reserve("b", now.Add(2*time.Minute))
+	assert.True(t, ok)
+
+	// the dials older than the window don't count
+	ok, _ = b.reserve("a", now.Add(dialBudgetWindow))
+	assert.True(t, ok)
+	ok, _ = b.reserve("a", now.Add(dialBudgetWindow))
+	assert.False(t, ok)
+
+	// the idle peers are forgotten
+	ok, _ = b.reserve("c", now.Add(3*dialBudgetWindow))
+	assert.True(t, ok)
+	assert.Len(t, b.dials, 1)
+}
+
+func TestDialBudgetUnlimited(t *testing.T) {
+	b := newDialBudget(0)
+	for i := 0; i < 100; i++ {
+		ok, _ := b.reserve("a", time.Now())
+		assert.True(t, ok)
+	}
+}
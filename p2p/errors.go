@@ -3,6 +3,7 @@ package p2p
 import (
 	"fmt"
 	"net"
+	"time"
 )
 
 // ErrFilterTimeout indicates that a filter operation timed out.
@@ -182,3 +183,24 @@ type ErrCurrentlyDialingOrExistingAddress struct {
 func (e ErrCurrentlyDialingOrExistingAddress) Error() string {
 	return fmt.Sprintf("connection with %s has been established or dialed", e.Addr)
 }
+
+// ErrSwitchRefusingNewPeers indicates that the switch refuses the new peers
+// (see Switch.SetRefuseNewPeers).
+type ErrSwitchRefusingNewPeers struct {
+	Addr string
+}
+
+func (e ErrSwitchRefusingNewPeers) Error() string {
+	return fmt.Sprintf("not dialing %s: refusing new peers", e.Addr)
+}
+
+// ErrDialBudgetExceeded indicates that the address was dialed too many times
+// in the last hour (see max_dials_per_peer_per_hour).
+type ErrDialBudgetExceeded struct {
+	Addr       string
+	RetryAfter time.Duration
+}
+
+func (e ErrDialBudgetExceeded) Error() string {
+	return fmt.Sprintf("dial budget of %s exceeded, retry after %v", e.Addr, e.RetryAfter)
+}
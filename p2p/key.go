@@ -6,6 +6,7 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/keyutil"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmos "github.com/tendermint/tendermint/libs/os"
 )
@@ -25,6 +26,10 @@ const IDByteLength = crypto.AddressSize
 // It contains the nodes private key for authentication.
 type NodeKey struct {
 	PrivKey crypto.PrivKey `json:"priv_key"` // our priv key
+
+	// holds the private key locked in memory, if loaded or generated by
+	// LoadOrGenNodeKey or LoadNodeKey
+	privKeyBuf *keyutil.Buffer
 }
 
 // ID returns the peer's canonical ID - the hash of its public key.
@@ -37,6 +42,26 @@ func (nodeKey *NodeKey) PubKey() crypto.PubKey {
 	return nodeKey.PrivKey.PubKey()
 }
 
+// Destroy zeroes the private key, if it was loaded or generated by
+// LoadOrGenNodeKey or LoadNodeKey, and unlocks its memory. The NodeKey mustn't
+// be used afterwards.
+func (nodeKey *NodeKey) Destroy() error {
+	if nodeKey.privKeyBuf == nil {
+		return nil
+	}
+	return nodeKey.privKeyBuf.Destroy()
+}
+
+// lockPrivKey moves the private key into a keyutil.Buffer, keeping it as is if
+// its type isn't supported.
+func (nodeKey *NodeKey) lockPrivKey() {
+	privKey, buf, err := keyutil.LockPrivKey(nodeKey.PrivKey)
+	if err != nil {
+		return
+	}
+	nodeKey.PrivKey, nodeKey.privKeyBuf = privKey, buf
+}
+
 // PubKeyToID returns the ID corresponding to the given PubKey.
 // It's the hex-encoding of the pubKey.Address().
 func PubKeyToID(pubKey crypto.PubKey) ID {
@@ -58,6 +83,7 @@ func LoadOrGenNodeKey(filePath string) (*NodeKey, error) {
 	nodeKey := &NodeKey{
 		PrivKey: privKey,
 	}
+	nodeKey.lockPrivKey()
 
 	if err := nodeKey.SaveAs(filePath); err != nil {
 		return nil, err
@@ -74,9 +100,11 @@ func LoadNodeKey(filePath string) (*NodeKey, error) {
 	}
 	nodeKey := new(NodeKey)
 	err = tmjson.Unmarshal(jsonBytes, nodeKey)
+	keyutil.Zero(jsonBytes)
 	if err != nil {
 		return nil, err
 	}
+	nodeKey.lockPrivKey()
 	return nodeKey, nil
 }
 
@@ -87,6 +115,7 @@ func (nodeKey *NodeKey) SaveAs(filePath string) error {
 		return err
 	}
 	err = ioutil.WriteFile(filePath, jsonBytes, 0600)
+	keyutil.Zero(jsonBytes)
 	if err != nil {
 		return err
 	}
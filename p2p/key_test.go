@@ -51,3 +51,25 @@ func TestNodeKeySaveAs(t *testing.T) {
 	assert.NoError(t, err)
 	assert.FileExists(t, filePath)
 }
+
+func TestNodeKeyDestroy(t *testing.T) {
+	filePath := filepath.Join(os.TempDir(), tmrand.Str(12)+"_peer_id.json")
+
+	nodeKey, err := LoadOrGenNodeKey(filePath)
+	require.NoError(t, err)
+
+	nodeKey, err = LoadNodeKey(filePath)
+	require.NoError(t, err)
+	require.NotNil(t, nodeKey.privKeyBuf)
+	id := nodeKey.ID()
+	privKey := nodeKey.PrivKey.(ed25519.PrivKey)
+
+	require.NoError(t, nodeKey.Destroy())
+	assert.Equal(t, make([]byte, len(privKey)), []byte(privKey))
+	assert.NoError(t, nodeKey.Destroy())
+
+	// the key file is unchanged
+	nodeKey, err = LoadNodeKey(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, id, nodeKey.ID())
+}
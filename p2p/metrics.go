@@ -25,6 +25,16 @@ type Metrics struct {
 	PeerPendingSendBytes metrics.Gauge
 	// Number of transactions submitted by each peer.
 	NumTxs metrics.Gauge
+	// Number of persistent peers which couldn't be reconnected to.
+	PersistentPeersUnreachable metrics.Gauge
+	// Number of peers whose version of the protocol is below the minimum
+	// version, accepted in the warn-only mode.
+	PeersBelowMinVersion metrics.Gauge
+	// Number of incoming connections being handshaked.
+	HandshakesInProgress metrics.Gauge
+	// Number of incoming connections closed before their handshake, because
+	// of the given limit.
+	RejectedConnections metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -66,16 +76,44 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "num_txs",
 			Help:      "Number of transactions submitted by each peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PersistentPeersUnreachable: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "persistent_peers_unreachable",
+			Help:      "Number of persistent peers which couldn't be reconnected to.",
+		}, labels).With(labelsAndValues...),
+		PeersBelowMinVersion: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peers_below_min_version",
+			Help:      "Number of peers whose version of the protocol is below the minimum version.",
+		}, append(labels, "protocol")).With(labelsAndValues...),
+		HandshakesInProgress: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "handshakes_in_progress",
+			Help:      "Number of incoming connections being handshaked.",
+		}, labels).With(labelsAndValues...),
+		RejectedConnections: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rejected_connections",
+			Help:      "Number of incoming connections closed before their handshake, because of the given limit.",
+		}, append(labels, "reason")).With(labelsAndValues...),
 	}
 }
 
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Peers:                 discard.NewGauge(),
-		PeerReceiveBytesTotal: discard.NewCounter(),
-		PeerSendBytesTotal:    discard.NewCounter(),
-		PeerPendingSendBytes:  discard.NewGauge(),
-		NumTxs:                discard.NewGauge(),
+		Peers:                      discard.NewGauge(),
+		PeerReceiveBytesTotal:      discard.NewCounter(),
+		PeerSendBytesTotal:         discard.NewCounter(),
+		PeerPendingSendBytes:       discard.NewGauge(),
+		NumTxs:                     discard.NewGauge(),
+		PersistentPeersUnreachable: discard.NewGauge(),
+		PeersBelowMinVersion:       discard.NewGauge(),
+		HandshakesInProgress:       discard.NewGauge(),
+		RejectedConnections:        discard.NewCounter(),
 	}
 }
@@ -0,0 +1,322 @@
+package p2p
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	tmconn "github.com/tendermint/tendermint/p2p/conn"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
+
+// MessageAuthExtension is the node info extension listing the channels whose
+// messages the node signs, as hex-encoded channel IDs (e.g. "20212223").
+const MessageAuthExtension = "message_auth"
+
+// MessageAuthConfig configures the signing of the messages exchanged with the
+// peers, see config.P2PConfig.MessageAuth. The messages are only signed on the
+// channels listed by both the node and the peer.
+type MessageAuthConfig struct {
+	// Channels whose messages are signed.
+	Channels []byte
+	// Key signing the messages, i.e. the node key.
+	PrivKey crypto.PrivKey
+}
+
+// MessageAuthConfigFromP2PConfig returns the MessageAuthConfig set in the
+// given config, or nil if message authentication is disabled.
+func MessageAuthConfigFromP2PConfig(cfg *config.P2PConfig, nodeKey *NodeKey) (*MessageAuthConfig, error) {
+	if !cfg.MessageAuth {
+		return nil, nil
+	}
+	channels, err := cfg.MessageAuthChannelIDs()
+	if err != nil {
+		return nil, err
+	}
+	return &MessageAuthConfig{Channels: channels, PrivKey: nodeKey.PrivKey}, nil
+}
+
+// Extension returns the value of the MessageAuthExtension of the node.
+func (cfg *MessageAuthConfig) Extension() string {
+	return hex.EncodeToString(cfg.Channels)
+}
+
+// PeerMessageAuth makes the peer sign the messages it sends, and verify the
+// messages it receives, on the channels listed by cfg and by the peer's
+// MessageAuthExtension. It's a no-op if cfg is nil.
+func PeerMessageAuth(cfg *MessageAuthConfig) PeerOption {
+	return func(p *peer) {
+		if cfg == nil {
+			return
+		}
+		secretConn, ok := p.conn.(*tmconn.SecretConnection)
+		if !ok {
+			return
+		}
+		ni, ok := p.nodeInfo.(DefaultNodeInfo)
+		if !ok {
+			return
+		}
+		peerChannels, err := hex.DecodeString(ni.Other.Extensions[MessageAuthExtension])
+		if err != nil {
+			return
+		}
+		channels := make(map[byte]bool)
+		for _, ch := range cfg.Channels {
+			for _, peerCh := range peerChannels {
+				if ch == peerCh {
+					channels[ch] = true
+				}
+			}
+		}
+		if len(channels) == 0 {
+			return
+		}
+		p.msgAuth = newMsgAuthenticator(cfg.PrivKey, secretConn.RemotePubKey(), channels)
+	}
+}
+
+// AuthenticatedPeer is a peer whose messages are signed, see
+// MessageAuthConfig.
+type AuthenticatedPeer interface {
+	Peer
+
+	// LastAuthenticatedMessage returns the last message received from the peer
+	// on the channel, if its messages are signed, or nil.
+	LastAuthenticatedMessage(chID byte) *AuthenticatedMessage
+}
+
+// AuthenticatedMessage is a message sent to a peer, signed with the node key
+// of its sender. It proves to third parties that the sender sent the payload
+// to the receiver, e.g. to attribute misbehavior to the sender.
+type AuthenticatedMessage struct {
+	SenderID   ID   `json:"sender_id"`
+	ReceiverID ID   `json:"receiver_id"`
+	ChannelID  byte `json:"channel_id"`
+	// Sequence of the message on the channel, for the connection.
+	Sequence  uint64 `json:"sequence"`
+	Payload   []byte `json:"payload"`
+	Signature []byte `json:"signature"`
+}
+
+// SignBytes returns the bytes of the message to sign, i.e. without the
+// signature.
+func (m *AuthenticatedMessage) SignBytes() []byte {
+	pb := m.ToProto()
+	pb.Signature = nil
+	bz, err := pb.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Verify returns an error if the message isn't signed with the given key of
+// its sender.
+func (m *AuthenticatedMessage) Verify(pubKey crypto.PubKey) error {
+	if PubKeyToID(pubKey) != m.SenderID {
+		return errors.New("key doesn't match the sender ID")
+	}
+	if !pubKey.VerifySignature(m.SignBytes(), m.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// ToProto converts the message to protobuf.
+func (m *AuthenticatedMessage) ToProto() *tmp2p.AuthenticatedMessage {
+	return &tmp2p.AuthenticatedMessage{
+		SenderID:   string(m.SenderID),
+		ReceiverID: string(m.ReceiverID),
+		ChannelID:  int32(m.ChannelID),
+		Sequence:   m.Sequence,
+		Payload:    m.Payload,
+		Signature:  m.Signature,
+	}
+}
+
+// AuthenticatedMessageFromProto converts the protobuf message.
+func AuthenticatedMessageFromProto(pb *tmp2p.AuthenticatedMessage) (*AuthenticatedMessage, error) {
+	if pb == nil {
+		return nil, errors.New("nil authenticated message")
+	}
+	if pb.ChannelID < 0 || pb.ChannelID > 0xff {
+		return nil, fmt.Errorf("invalid channel ID %d", pb.ChannelID)
+	}
+	return &AuthenticatedMessage{
+		SenderID:   ID(pb.SenderID),
+		ReceiverID: ID(pb.ReceiverID),
+		ChannelID:  byte(pb.ChannelID),
+		Sequence:   pb.Sequence,
+		Payload:    pb.Payload,
+		Signature:  pb.Signature,
+	}, nil
+}
+
+// msgAuthenticator signs the messages sent to a single peer, and verifies the
+// messages received from it.
+type msgAuthenticator struct {
+	privKey    crypto.PrivKey
+	localID    ID
+	peerPubKey crypto.PubKey
+	peerID     ID
+
+	// the send locks only guard the sequences: the messages are sent without
+	// holding them, so they may reach the send queues out of order
+	send map[byte]*msgAuthSendChannel
+
+	mtx  tmsync.Mutex
+	recv map[byte]*msgAuthReplayWindow
+	last map[byte]*AuthenticatedMessage
+}
+
+type msgAuthSendChannel struct {
+	mtx tmsync.Mutex
+	seq uint64
+}
+
+// msgAuthReplayWindowSize is the number of sequences below the highest one
+// received on a channel which are still accepted, since concurrent sends can
+// reorder the messages.
+const msgAuthReplayWindowSize = 64
+
+// msgAuthReplayWindow tracks the sequences received on a channel, rejecting
+// the replayed ones and those too far behind the highest one.
+type msgAuthReplayWindow struct {
+	max  uint64
+	seen uint64 // bit i is set if the sequence max-i was received
+}
+
+// accept records the sequence, returning an error if it can't be accepted.
+func (w *msgAuthReplayWindow) accept(seq uint64) error {
+	switch {
+	case seq == 0:
+		return errors.New("authenticated message sequence 0")
+	case seq > w.max:
+		if shift := seq - w.max; shift < msgAuthReplayWindowSize {
+			w.seen = w.seen<<shift | 1
+		} else {
+			w.seen = 1
+		}
+		w.max = seq
+	case w.max-seq >= msgAuthReplayWindowSize:
+		return fmt.Errorf("authenticated message sequence %d is too old, the highest received is %d",
+			seq, w.max)
+	case w.seen&(1<<(w.max-seq)) != 0:
+		return fmt.Errorf("authenticated message sequence %d was already received", seq)
+	default:
+		w.seen |= 1 << (w.max - seq)
+	}
+	return nil
+}
+
+func newMsgAuthenticator(privKey crypto.PrivKey, peerPubKey crypto.PubKey,
+	channels map[byte]bool) *msgAuthenticator {
+	a := &msgAuthenticator{
+		privKey:    privKey,
+		localID:    PubKeyToID(privKey.PubKey()),
+		peerPubKey: peerPubKey,
+		peerID:     PubKeyToID(peerPubKey),
+		send:       make(map[byte]*msgAuthSendChannel, len(channels)),
+		recv:       make(map[byte]*msgAuthReplayWindow, len(channels)),
+		last:       make(map[byte]*AuthenticatedMessage, len(channels)),
+	}
+	for ch := range channels {
+		a.send[ch] = &msgAuthSendChannel{}
+		a.recv[ch] = &msgAuthReplayWindow{}
+	}
+	return a
+}
+
+// Send signs the message if its channel is authenticated, and sends it using
+// the given send function. The send function is called without holding any
+// lock, so that a blocking send doesn't block the others.
+func (a *msgAuthenticator) Send(chID byte, msgBytes []byte, send func(byte, []byte) bool) bool {
+	ch, ok := a.send[chID]
+	if !ok {
+		return send(chID, msgBytes)
+	}
+	return send(chID, a.seal(ch, chID, msgBytes))
+}
+
+// seal signs the message with the next sequence of the channel, returning
+// the encoded authenticated message. The sequence is used even if the message
+// isn't sent.
+func (a *msgAuthenticator) seal(ch *msgAuthSendChannel, chID byte, msgBytes []byte) []byte {
+	ch.mtx.Lock()
+	ch.seq++
+	seq := ch.seq
+	ch.mtx.Unlock()
+
+	msg := &AuthenticatedMessage{
+		SenderID:   a.localID,
+		ReceiverID: a.peerID,
+		ChannelID:  chID,
+		Sequence:   seq,
+		Payload:    msgBytes,
+	}
+	sig, err := a.privKey.Sign(msg.SignBytes())
+	if err != nil {
+		panic(fmt.Errorf("can't sign the message: %w", err))
+	}
+	msg.Signature = sig
+	bz, err := msg.ToProto().Marshal()
+	if err != nil {
+		panic(fmt.Errorf("unable to marshal %T: %w", msg, err))
+	}
+	return bz
+}
+
+// Open verifies the message if its channel is authenticated, returning its
+// payload.
+func (a *msgAuthenticator) Open(chID byte, msgBytes []byte) ([]byte, error) {
+	if _, ok := a.send[chID]; !ok {
+		return msgBytes, nil
+	}
+	pb := &tmp2p.AuthenticatedMessage{}
+	if err := proto.Unmarshal(msgBytes, pb); err != nil {
+		return nil, fmt.Errorf("can't decode the authenticated message: %w", err)
+	}
+	msg, err := AuthenticatedMessageFromProto(pb)
+	if err != nil {
+		return nil, err
+	}
+	if msg.ReceiverID != a.localID || msg.ChannelID != chID {
+		return nil, fmt.Errorf("authenticated message sent to %v on channel %#x, expected %v on channel %#x",
+			msg.ReceiverID, msg.ChannelID, a.localID, chID)
+	}
+	if err := msg.Verify(a.peerPubKey); err != nil {
+		return nil, fmt.Errorf("invalid authenticated message: %w", err)
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if err := a.recv[chID].accept(msg.Sequence); err != nil {
+		return nil, fmt.Errorf("on channel %#x: %w", chID, err)
+	}
+	a.last[chID] = msg
+	return msg.Payload, nil
+}
+
+// LastReceived returns the last message received on the channel, or nil.
+func (a *msgAuthenticator) LastReceived(chID byte) *AuthenticatedMessage {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.last[chID]
+}
+
+// LastReceivedAll returns the last message received on each channel.
+func (a *msgAuthenticator) LastReceivedAll() []*AuthenticatedMessage {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	msgs := make([]*AuthenticatedMessage, 0, len(a.last))
+	for _, msg := range a.last {
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
@@ -0,0 +1,159 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestMsgAuthenticator(t *testing.T) {
+	key1, key2 := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	channels := map[byte]bool{0x20: true, 0x21: true}
+	a1 := newMsgAuthenticator(key1, key2.PubKey(), channels)
+	a2 := newMsgAuthenticator(key2, key1.PubKey(), channels)
+
+	var sent [][]byte
+	send := func(chID byte, msgBytes []byte) bool {
+		sent = append(sent, msgBytes)
+		return true
+	}
+	require.True(t, a1.Send(0x20, []byte("vote"), send))
+	require.True(t, a1.Send(0x20, []byte("proposal"), send))
+	require.True(t, a1.Send(0x30, []byte("tx"), send))
+	require.Len(t, sent, 3)
+	assert.Equal(t, []byte("tx"), sent[2])
+
+	// the messages are verified in order
+	payload, err := a2.Open(0x20, sent[0])
+	require.NoError(t, err)
+	assert.Equal(t, []byte("vote"), payload)
+	payload, err = a2.Open(0x30, sent[2])
+	require.NoError(t, err)
+	assert.Equal(t, []byte("tx"), payload)
+	_, err = a2.Open(0x21, sent[1])
+	assert.Error(t, err)
+	payload, err = a2.Open(0x20, sent[1])
+	require.NoError(t, err)
+	assert.Equal(t, []byte("proposal"), payload)
+	_, err = a2.Open(0x20, sent[0])
+	assert.Error(t, err, "replayed message")
+
+	// the last message proves what the peer sent
+	last := a2.LastReceived(0x20)
+	require.NotNil(t, last)
+	assert.EqualValues(t, 2, last.Sequence)
+	assert.NoError(t, last.Verify(key1.PubKey()))
+	assert.Error(t, last.Verify(key2.PubKey()))
+	last.Payload = []byte("forged")
+	assert.Error(t, last.Verify(key1.PubKey()))
+
+	// a send blocking in the send function doesn't block the others
+	blocked, unblock := make(chan struct{}), make(chan struct{})
+	done := make(chan bool)
+	go func() {
+		done <- a1.Send(0x20, []byte("blocked"), func(chID byte, msgBytes []byte) bool {
+			close(blocked)
+			<-unblock
+			sent = append(sent, msgBytes)
+			return true
+		})
+	}()
+	<-blocked
+	require.True(t, a1.Send(0x20, []byte("unblocked"), send))
+	close(unblock)
+	require.True(t, <-done)
+	require.Len(t, sent, 5)
+
+	// messages reordered by concurrent sends are accepted, but only once
+	payload, err = a2.Open(0x20, sent[3])
+	require.NoError(t, err)
+	assert.Equal(t, []byte("unblocked"), payload)
+	payload, err = a2.Open(0x20, sent[4])
+	require.NoError(t, err)
+	assert.Equal(t, []byte("blocked"), payload)
+	_, err = a2.Open(0x20, sent[4])
+	assert.Error(t, err, "replayed message")
+
+	// the messages sent to another peer are rejected
+	a3 := newMsgAuthenticator(ed25519.GenPrivKey(), key1.PubKey(), map[byte]bool{0x20: true})
+	_, err = a3.Open(0x20, sent[0])
+	assert.Error(t, err)
+}
+
+func TestMsgAuthReplayWindow(t *testing.T) {
+	w := &msgAuthReplayWindow{}
+	assert.Error(t, w.accept(0))
+	require.NoError(t, w.accept(2))
+	require.NoError(t, w.accept(1))
+	assert.Error(t, w.accept(1))
+	require.NoError(t, w.accept(msgAuthReplayWindowSize+1))
+	assert.Error(t, w.accept(1), "too old")
+	require.NoError(t, w.accept(3))
+	assert.Error(t, w.accept(3))
+	assert.Error(t, w.accept(2))
+
+	// a jump beyond the window forgets all the sequences
+	require.NoError(t, w.accept(10*msgAuthReplayWindowSize))
+	require.NoError(t, w.accept(10*msgAuthReplayWindowSize-1))
+	assert.Error(t, w.accept(10*msgAuthReplayWindowSize))
+}
+
+func TestMessageAuthConfigFromP2PConfig(t *testing.T) {
+	nodeKey := &NodeKey{PrivKey: ed25519.GenPrivKey()}
+	p2pConfig := config.DefaultP2PConfig()
+	msgAuth, err := MessageAuthConfigFromP2PConfig(p2pConfig, nodeKey)
+	require.NoError(t, err)
+	assert.Nil(t, msgAuth)
+
+	p2pConfig.MessageAuth = true
+	msgAuth, err = MessageAuthConfigFromP2PConfig(p2pConfig, nodeKey)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x20, 0x21, 0x22, 0x23}, msgAuth.Channels)
+	assert.Equal(t, "20212223", msgAuth.Extension())
+}
+
+func TestSwitchMessageAuth(t *testing.T) {
+	// the messages are only signed on the channels enabled by both switches
+	channels := [][]byte{{0x00, 0x01}, {0x00, 0x02}}
+	switches := MakeConnectedSwitches(cfg, 2, initSwitchFunc, func(switches []*Switch, i, j int) {
+		for k, sw := range switches {
+			msgAuth := &MessageAuthConfig{Channels: channels[k], PrivKey: sw.nodeKey.PrivKey}
+			SwitchMessageAuth(msgAuth)(sw)
+			ni := sw.nodeInfo.(DefaultNodeInfo)
+			ni.Other.Extensions = map[string]string{MessageAuthExtension: msgAuth.Extension()}
+			sw.SetNodeInfo(ni)
+		}
+		Connect2Switches(switches, i, j)
+	})
+	s1, s2 := switches[0], switches[1]
+	t.Cleanup(func() {
+		if err := s1.Stop(); err != nil {
+			t.Error(err)
+		}
+		if err := s2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	s1.Broadcast(byte(0x00), []byte("channel zero"))
+	s1.Broadcast(byte(0x01), []byte("channel one"))
+
+	r := s2.Reactor("foo").(*TestReactor)
+	assert.Eventually(t, func() bool {
+		return len(r.getMsgs(byte(0x00))) == 1 && len(r.getMsgs(byte(0x01))) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []byte("channel zero"), r.getMsgs(byte(0x00))[0].Bytes)
+	assert.Equal(t, []byte("channel one"), r.getMsgs(byte(0x01))[0].Bytes)
+
+	peer := s2.Peers().Get(s1.NodeInfo().ID()).(AuthenticatedPeer)
+	msg := peer.LastAuthenticatedMessage(0x00)
+	require.NotNil(t, msg)
+	assert.Equal(t, []byte("channel zero"), msg.Payload)
+	assert.NoError(t, msg.Verify(s1.nodeKey.PubKey()))
+	assert.Nil(t, peer.LastAuthenticatedMessage(0x01))
+}
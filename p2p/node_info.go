@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/tendermint/tendermint/libs/bytes"
 	tmstrings "github.com/tendermint/tendermint/libs/strings"
@@ -14,6 +15,11 @@ import (
 const (
 	maxNodeInfoSize = 10240 // 10KB
 	maxNumChannels  = 16    // plenty of room for upgrades, for now
+
+	// limits of the application metadata, so that it fits in maxNodeInfoSize
+	maxNumNodeInfoExtensions      = 16
+	maxNodeInfoExtensionKeyLength = 64
+	maxNodeInfoExtensionValueSize = 256
 )
 
 // Max size of the NodeInfo struct
@@ -98,6 +104,27 @@ type DefaultNodeInfo struct {
 type DefaultNodeInfoOther struct {
 	TxIndex    string `json:"tx_index"`
 	RPCAddress string `json:"rpc_address"`
+
+	// Extensions is the metadata registered by the application (e.g. its
+	// version or feature flags), which peers can use to decide whether to stay
+	// connected. See ValidateNodeInfoExtension for the limits.
+	Extensions map[string]string `json:"extensions,omitempty"`
+}
+
+// ValidateNodeInfoExtension returns an error if the key, or the value, can't
+// be advertised in the node info. The key must be non-empty ASCII text without
+// spaces of at most 64 bytes, and the value ASCII text of at most 256 bytes.
+func ValidateNodeInfoExtension(key, value string) error {
+	if key == "" || len(key) > maxNodeInfoExtensionKeyLength ||
+		!tmstrings.IsASCIIText(key) || strings.ContainsRune(key, ' ') {
+		return fmt.Errorf("node info extension key %q must be non-empty ASCII text without spaces, "+
+			"at most %d bytes long", key, maxNodeInfoExtensionKeyLength)
+	}
+	if len(value) > maxNodeInfoExtensionValueSize || (value != "" && !tmstrings.IsASCIIText(value)) {
+		return fmt.Errorf("node info extension %q must be ASCII text without tabs, at most %d bytes long",
+			key, maxNodeInfoExtensionValueSize)
+	}
+	return nil
 }
 
 // ID returns the node's peer ID.
@@ -168,6 +195,15 @@ func (info DefaultNodeInfo) Validate() error {
 	if len(rpcAddr) > 0 && (!tmstrings.IsASCIIText(rpcAddr) || tmstrings.ASCIITrim(rpcAddr) == "") {
 		return fmt.Errorf("info.Other.RPCAddress=%v must be valid ASCII text without tabs", rpcAddr)
 	}
+	if len(other.Extensions) > maxNumNodeInfoExtensions {
+		return fmt.Errorf("info.Other.Extensions is too long (%v). Max is %v",
+			len(other.Extensions), maxNumNodeInfoExtensions)
+	}
+	for key, value := range other.Extensions {
+		if err := ValidateNodeInfoExtension(key, value); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -240,6 +276,7 @@ func (info DefaultNodeInfo) ToProto() *tmp2p.DefaultNodeInfo {
 	dni.Other = tmp2p.DefaultNodeInfoOther{
 		TxIndex:    info.Other.TxIndex,
 		RPCAddress: info.Other.RPCAddress,
+		Extensions: info.Other.Extensions,
 	}
 
 	return dni
@@ -264,6 +301,7 @@ func DefaultNodeInfoFromToProto(pb *tmp2p.DefaultNodeInfo) (DefaultNodeInfo, err
 		Other: DefaultNodeInfoOther{
 			TxIndex:    pb.Other.TxIndex,
 			RPCAddress: pb.Other.RPCAddress,
+			Extensions: pb.Other.Extensions,
 		},
 	}
 
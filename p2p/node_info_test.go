@@ -1,11 +1,15 @@
 package p2p
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
 )
 
 func TestNodeInfoValidate(t *testing.T) {
@@ -64,6 +68,22 @@ func TestNodeInfoValidate(t *testing.T) {
 		{"Empty space RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = emptySpace }, true},
 		{"Empty RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = "" }, false},
 		{"Good RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = "0.0.0.0:26657" }, false},
+
+		{"Empty Extension Key", func(ni *DefaultNodeInfo) { ni.Other.Extensions = map[string]string{"": "1"} }, true},
+		{"Space in Extension Key", func(ni *DefaultNodeInfo) { ni.Other.Extensions = map[string]string{"a b": "1"} }, true},
+		{"Non-ASCII Extension", func(ni *DefaultNodeInfo) { ni.Other.Extensions = map[string]string{"a": nonASCII} }, true},
+		{"Too Long Extension", func(ni *DefaultNodeInfo) {
+			ni.Other.Extensions = map[string]string{"a": strings.Repeat("a", maxNodeInfoExtensionValueSize+1)}
+		}, true},
+		{"Too Many Extensions", func(ni *DefaultNodeInfo) {
+			ni.Other.Extensions = make(map[string]string)
+			for i := 0; i <= maxNumNodeInfoExtensions; i++ {
+				ni.Other.Extensions[fmt.Sprintf("key%d", i)] = "1"
+			}
+		}, true},
+		{"Good Extensions", func(ni *DefaultNodeInfo) {
+			ni.Other.Extensions = map[string]string{"app_version": "1.2.0", "features": "a,b", "empty": ""}
+		}, false},
 	}
 
 	nodeKey := NodeKey{PrivKey: ed25519.GenPrivKey()}
@@ -88,6 +108,20 @@ func TestNodeInfoValidate(t *testing.T) {
 
 }
 
+func TestNodeInfoProtoExtensions(t *testing.T) {
+	nodeKey := NodeKey{PrivKey: ed25519.GenPrivKey()}
+	ni := testNodeInfo(nodeKey.ID(), "testing").(DefaultNodeInfo)
+	ni.Other.Extensions = map[string]string{"app_version": "1.2.0"}
+
+	bz, err := ni.ToProto().Marshal()
+	require.NoError(t, err)
+	var pb tmp2p.DefaultNodeInfo
+	require.NoError(t, pb.Unmarshal(bz))
+	ni2, err := DefaultNodeInfoFromToProto(&pb)
+	require.NoError(t, err)
+	assert.Equal(t, ni.Other.Extensions, ni2.Other.Extensions)
+}
+
 func TestNodeInfoCompatible(t *testing.T) {
 
 	nodeKey1 := NodeKey{PrivKey: ed25519.GenPrivKey()}
@@ -3,6 +3,7 @@ package p2p
 import (
 	"fmt"
 	"net"
+	"sort"
 	"time"
 
 	"github.com/tendermint/tendermint/libs/cmap"
@@ -117,6 +118,18 @@ type peer struct {
 
 	metrics       *Metrics
 	metricsTicker *time.Ticker
+
+	// live counters of the traffic on each channel, see Switch.ChannelStats
+	chCounters map[byte]*channelCounters
+
+	// injects faults into sent messages, see PeerChaos
+	chaos *chaosSender
+
+	// signs and verifies the messages, see PeerMessageAuth
+	msgAuth *msgAuthenticator
+
+	// records the envelopes sent and received, see PeerTrafficCapture
+	capture *TrafficCapture
 }
 
 type PeerOption func(*peer)
@@ -137,6 +150,7 @@ func newPeer(
 		Data:          cmap.NewCMap(),
 		metricsTicker: time.NewTicker(metricsTickerDuration),
 		metrics:       NopMetrics(),
+		chCounters:    newChannelCounters(chDescs),
 	}
 
 	p.mconn = createMConnection(
@@ -251,13 +265,19 @@ func (p *peer) Send(chID byte, msgBytes []byte) bool {
 	} else if !p.hasChannel(chID) {
 		return false
 	}
-	res := p.mconn.Send(chID, msgBytes)
+	res := p.send(chID, msgBytes, p.mconn.Send)
+	if counters, ok := p.chCounters[chID]; ok {
+		counters.sent(msgBytes, res)
+	}
 	if res {
 		labels := []string{
 			"peer_id", string(p.ID()),
 			"chID", fmt.Sprintf("%#x", chID),
 		}
 		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+		if p.capture != nil {
+			p.capture.Record(CaptureOut, p.ID(), chID, msgBytes)
+		}
 	}
 	return res
 }
@@ -270,17 +290,69 @@ func (p *peer) TrySend(chID byte, msgBytes []byte) bool {
 	} else if !p.hasChannel(chID) {
 		return false
 	}
-	res := p.mconn.TrySend(chID, msgBytes)
+	res := p.send(chID, msgBytes, p.mconn.TrySend)
+	if counters, ok := p.chCounters[chID]; ok {
+		counters.sent(msgBytes, res)
+	}
 	if res {
 		labels := []string{
 			"peer_id", string(p.ID()),
 			"chID", fmt.Sprintf("%#x", chID),
 		}
 		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+		if p.capture != nil {
+			p.capture.Record(CaptureOut, p.ID(), chID, msgBytes)
+		}
 	}
 	return res
 }
 
+// send sends the message using the given MConnection method, through the
+// message authenticator and the chaos sender if any.
+func (p *peer) send(chID byte, msgBytes []byte, send func(byte, []byte) bool) bool {
+	if p.chaos != nil {
+		mconnSend := send
+		send = func(chID byte, msgBytes []byte) bool {
+			return p.chaos.Send(chID, msgBytes, mconnSend)
+		}
+	}
+	if p.msgAuth != nil {
+		return p.msgAuth.Send(chID, msgBytes, send)
+	}
+	return send(chID, msgBytes)
+}
+
+// LastAuthenticatedMessage implements AuthenticatedPeer.
+func (p *peer) LastAuthenticatedMessage(chID byte) *AuthenticatedMessage {
+	if p.msgAuth == nil {
+		return nil
+	}
+	return p.msgAuth.LastReceived(chID)
+}
+
+// lastAuthenticatedMessages returns the last message received on each
+// authenticated channel.
+func (p *peer) lastAuthenticatedMessages() []*AuthenticatedMessage {
+	if p.msgAuth == nil {
+		return nil
+	}
+	return p.msgAuth.LastReceivedAll()
+}
+
+// channelStats returns the live stats of the channels, sorted by ID.
+func (p *peer) channelStats() []ChannelStats {
+	stats := make([]ChannelStats, 0, len(p.chCounters))
+	for _, chStatus := range p.mconn.Status().Channels {
+		if counters, ok := p.chCounters[chStatus.ID]; ok {
+			chStats := counters.stats(chStatus.ID)
+			chStats.SendQueueSize = chStatus.SendQueueSize
+			stats = append(stats, chStats)
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].ChannelID < stats[j].ChannelID })
+	return stats
+}
+
 // Get the data for a given key.
 func (p *peer) Get(key string) interface{} {
 	return p.Data.Get(key)
@@ -387,6 +459,23 @@ func createMConnection(
 			"chID", fmt.Sprintf("%#x", chID),
 		}
 		p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+		counters := p.chCounters[chID]
+		if counters != nil {
+			counters.received(msgBytes)
+		}
+		if p.msgAuth != nil {
+			var err error
+			if msgBytes, err = p.msgAuth.Open(chID, msgBytes); err != nil {
+				if counters != nil {
+					counters.receiveError()
+				}
+				onPeerError(p, err)
+				return
+			}
+		}
+		if p.capture != nil {
+			p.capture.Record(CaptureIn, p.ID(), chID, msgBytes)
+		}
 		reactor.Receive(chID, p, msgBytes)
 	}
 
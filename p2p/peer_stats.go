@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"time"
+
+	tmconn "github.com/tendermint/tendermint/p2p/conn"
+)
+
+// PeerStats are historical statistics about a peer, accumulated over all the
+// connections to it. They are persisted by the address book, so they survive
+// restarts and can be used to tell good peers from bad ones.
+type PeerStats struct {
+	// When we first connected to the peer.
+	FirstConnected time.Time `json:"first_connected"`
+	// Total time we've been connected to the peer, excluding the current
+	// connection.
+	ConnectedTime time.Duration `json:"connected_time"`
+	// Total bytes sent to and received from the peer, excluding the current
+	// connection.
+	BytesSent     int64 `json:"bytes_sent"`
+	BytesReceived int64 `json:"bytes_received"`
+	// Number of times we've disconnected from the peer because it misbehaved.
+	Misbehaviors int64 `json:"misbehaviors"`
+	// The last messages the peer signed on each authenticated channel (see
+	// p2p.message_auth) the last time it misbehaved, which attribute the
+	// misbehavior to it.
+	MisbehaviorMessages []*AuthenticatedMessage `json:"misbehavior_messages,omitempty"`
+	// Height of the last block the peer sent us.
+	LastUsefulBlock int64 `json:"last_useful_block"`
+}
+
+// RecordConnection adds the duration and traffic of a connection to the
+// stats.
+func (s *PeerStats) RecordConnection(status tmconn.ConnectionStatus) {
+	s.ConnectedTime += status.Duration
+	s.BytesSent += status.SendMonitor.Bytes
+	s.BytesReceived += status.RecvMonitor.Bytes
+}
+
+// Uptime returns the fraction (0 to 1) of the time since we first connected
+// to the peer during which we've been connected to it.
+func (s PeerStats) Uptime(now time.Time) float64 {
+	if s.FirstConnected.IsZero() {
+		return 0
+	}
+	total := now.Sub(s.FirstConnected)
+	if total <= 0 || s.ConnectedTime >= total {
+		return 1
+	}
+	return float64(s.ConnectedTime) / float64(total)
+}
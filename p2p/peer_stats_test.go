@@ -0,0 +1,48 @@
+package p2p
+
+import (
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/libs/flowrate"
+	tmconn "github.com/tendermint/tendermint/p2p/conn"
+)
+
+func TestPeerStatsRecordConnection(t *testing.T) {
+	var stats PeerStats
+	for i := 0; i < 2; i++ {
+		stats.RecordConnection(tmconn.ConnectionStatus{
+			Duration:    time.Minute,
+			SendMonitor: flowrate.Status{Bytes: 10},
+			RecvMonitor: flowrate.Status{Bytes: 20},
+		})
+	}
+	assert.Equal(t, 2*time.Minute, stats.ConnectedTime)
+	assert.EqualValues(t, 20, stats.BytesSent)
+	assert.EqualValues(t, 40, stats.BytesReceived)
+}
+
+func TestPeerStatsUptime(t *testing.T) {
+	now := time.Now()
+
+	assert.Zero(t, PeerStats{}.Uptime(now))
+
+	stats := PeerStats{FirstConnected: now.Add(-4 * time.Hour), ConnectedTime: time.Hour}
+	assert.Equal(t, 0.25, stats.Uptime(now))
+
+	stats.ConnectedTime = 5 * time.Hour
+	assert.Equal(t, 1.0, stats.Uptime(now))
+}
+
+func TestIsConnectionError(t *testing.T) {
+	assert.True(t, isConnectionError(io.EOF))
+	assert.True(t, isConnectionError(&net.OpError{Op: "read", Err: errors.New("reset")}))
+	assert.False(t, isConnectionError(errors.New("invalid message")))
+	assert.False(t, isConnectionError("invalid message"))
+	assert.False(t, isConnectionError(nil))
+}
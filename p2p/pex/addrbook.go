@@ -69,6 +69,10 @@ type AddrBook interface {
 	IsGood(*p2p.NetAddress) bool
 	IsBanned(*p2p.NetAddress) bool
 
+	// Historical peer stats
+	PeerStats(p2p.ID) (p2p.PeerStats, bool)
+	UpdatePeerStats(p2p.ID, func(*p2p.PeerStats))
+
 	// Send a selection of addresses to peers
 	GetSelection() []*p2p.NetAddress
 	// Send a selection of addresses with bias
@@ -334,6 +338,33 @@ func (a *addrBook) MarkGood(id p2p.ID) {
 	}
 }
 
+// PeerStats implements AddrBook - it returns the stats of the peer with the
+// given ID, if it's in the book.
+func (a *addrBook) PeerStats(id p2p.ID) (p2p.PeerStats, bool) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[id]
+	if ka == nil {
+		return p2p.PeerStats{}, false
+	}
+	return ka.Stats, true
+}
+
+// UpdatePeerStats implements AddrBook - it calls update with the stats of the
+// peer with the given ID, if it's in the book. They're persisted along with
+// the rest of the book.
+func (a *addrBook) UpdatePeerStats(id p2p.ID, update func(*p2p.PeerStats)) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[id]
+	if ka == nil {
+		return
+	}
+	update(&ka.Stats)
+}
+
 // MarkAttempt implements AddrBook - it marks that an attempt was made to connect to the address.
 func (a *addrBook) MarkAttempt(addr *p2p.NetAddress) {
 	a.mtx.Lock()
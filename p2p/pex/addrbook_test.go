@@ -91,6 +91,38 @@ func TestAddrBookSaveLoad(t *testing.T) {
 	assert.Equal(t, 100, book.Size())
 }
 
+func TestAddrBookPeerStatsSaveLoad(t *testing.T) {
+	fname := createTempFileName(t, "addrbook_test")
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+
+	addrSrc := randNetAddressPairs(t, 1)[0]
+	require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+
+	// unknown peers have no stats
+	_, ok := book.PeerStats("unknown")
+	assert.False(t, ok)
+	book.UpdatePeerStats("unknown", func(*p2p.PeerStats) { t.Fatal("unexpected update") })
+
+	book.UpdatePeerStats(addrSrc.addr.ID, func(stats *p2p.PeerStats) {
+		stats.BytesSent = 100
+		stats.Misbehaviors = 2
+		stats.LastUsefulBlock = 10
+	})
+	book.Save()
+
+	book = NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+
+	stats, ok := book.PeerStats(addrSrc.addr.ID)
+	require.True(t, ok)
+	assert.EqualValues(t, 100, stats.BytesSent)
+	assert.EqualValues(t, 2, stats.Misbehaviors)
+	assert.EqualValues(t, 10, stats.LastUsefulBlock)
+}
+
 func TestAddrBookLookup(t *testing.T) {
 	fname := createTempFileName(t, "addrbook_test")
 	randAddrs := randNetAddressPairs(t, 100)
@@ -0,0 +1,94 @@
+package pex
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+// ExportAddresses writes the addresses of the address book stored at filePath
+// to w, one id@host:port per line, sorted by ID. If onlyGood is true, only the
+// addresses we've successfully connected to (i.e. in the old buckets) are
+// written. It returns the number of addresses written.
+//
+// NOTE: the book must not be in use by a running node.
+func ExportAddresses(filePath string, w io.Writer, onlyGood bool) (int, error) {
+	a := loadAddrBook(filePath, false)
+
+	addrs := make([]*knownAddress, 0, len(a.addrLookup))
+	for _, ka := range a.addrLookup {
+		if onlyGood && !ka.isOld() {
+			continue
+		}
+		addrs = append(addrs, ka)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].ID() < addrs[j].ID() })
+
+	bw := bufio.NewWriter(w)
+	for _, ka := range addrs {
+		if _, err := fmt.Fprintln(bw, ka.Addr.String()); err != nil {
+			return 0, err
+		}
+	}
+	return len(addrs), bw.Flush()
+}
+
+// ImportAddresses merges the addresses read from r, one id@host:port per line,
+// into the address book stored at filePath (which is created if needed).
+// Empty lines and lines starting with # are ignored. Addresses already in the
+// book are kept as they are; the others are added as new addresses. Nothing is
+// saved if any line is not a valid address. It returns the number of addresses
+// added and skipped (already known or rejected by the book, e.g. if they're not
+// routable and routabilityStrict is true).
+//
+// NOTE: the book must not be in use by a running node.
+func ImportAddresses(filePath string, routabilityStrict bool, r io.Reader) (added, skipped int, err error) {
+	var addrs []*p2p.NetAddress
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		addr, err := p2p.NewNetAddressString(line)
+		if err != nil {
+			return 0, 0, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		addrs = append(addrs, addr)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	a := loadAddrBook(filePath, routabilityStrict)
+	for _, addr := range addrs {
+		if a.HasAddress(addr) {
+			skipped++
+			continue
+		}
+		if err := a.AddAddress(addr, addr); err != nil {
+			skipped++
+			continue
+		}
+		added++
+	}
+	if err := a.writeFile(filePath); err != nil {
+		return 0, 0, err
+	}
+
+	return added, skipped, nil
+}
+
+// loadAddrBook loads the address book stored at filePath, if any, without
+// starting it.
+func loadAddrBook(filePath string, routabilityStrict bool) *addrBook {
+	a := NewAddrBook(filePath, routabilityStrict).(*addrBook)
+	a.SetLogger(log.NewNopLogger())
+	a.loadFromFile(filePath)
+	return a
+}
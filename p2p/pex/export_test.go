@@ -0,0 +1,62 @@
+package pex
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestExportImportAddresses(t *testing.T) {
+	fname := createTempFileName(t, "addrbook_test")
+
+	book := NewAddrBook(fname, false)
+	book.SetLogger(log.TestingLogger())
+	randAddrs := randNetAddressPairs(t, 10)
+	for _, addrSrc := range randAddrs {
+		require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+	}
+	book.MarkGood(randAddrs[0].addr.ID)
+	book.Save()
+
+	buf := new(bytes.Buffer)
+	n, err := ExportAddresses(fname, buf, false)
+	require.NoError(t, err)
+	assert.Equal(t, 10, n)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 10)
+
+	buf.Reset()
+	n, err = ExportAddresses(fname, buf, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, randAddrs[0].addr.String()+"\n", buf.String())
+
+	// import into a new book, merging with its own addresses
+	fname2 := createTempFileName(t, "addrbook_test")
+	book2 := NewAddrBook(fname2, false)
+	book2.SetLogger(log.TestingLogger())
+	require.NoError(t, book2.AddAddress(randAddrs[1].addr, randAddrs[1].src))
+	other := randNetAddressPairs(t, 1)[0]
+	require.NoError(t, book2.AddAddress(other.addr, other.src))
+	book2.Save()
+
+	input := "# curated peers\n\n" + strings.Join(lines, "\n") + "\n"
+	added, skipped, err := ImportAddresses(fname2, false, strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, 9, added)
+	assert.Equal(t, 1, skipped)
+
+	buf.Reset()
+	n, err = ExportAddresses(fname2, buf, false)
+	require.NoError(t, err)
+	assert.Equal(t, 11, n)
+
+	// invalid lines abort the import
+	_, _, err = ImportAddresses(fname2, false, strings.NewReader("not an address\n"))
+	assert.Error(t, err)
+}
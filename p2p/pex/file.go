@@ -16,11 +16,17 @@ type addrBookJSON struct {
 }
 
 func (a *addrBook) saveToFile(filePath string) {
+	a.Logger.Info("Saving AddrBook to file", "size", a.Size())
+
+	if err := a.writeFile(filePath); err != nil {
+		a.Logger.Error("Failed to save AddrBook to file", "file", filePath, "err", err)
+	}
+}
+
+func (a *addrBook) writeFile(filePath string) error {
 	a.mtx.Lock()
 	defer a.mtx.Unlock()
 
-	a.Logger.Info("Saving AddrBook to file", "size", a.size())
-
 	addrs := make([]*knownAddress, 0, len(a.addrLookup))
 	for _, ka := range a.addrLookup {
 		addrs = append(addrs, ka)
@@ -32,13 +38,9 @@ func (a *addrBook) saveToFile(filePath string) {
 
 	jsonBytes, err := json.MarshalIndent(aJSON, "", "\t")
 	if err != nil {
-		a.Logger.Error("Failed to save AddrBook to file", "err", err)
-		return
-	}
-	err = tempfile.WriteFileAtomic(filePath, jsonBytes, 0644)
-	if err != nil {
-		a.Logger.Error("Failed to save AddrBook to file", "file", filePath, "err", err)
+		return err
 	}
+	return tempfile.WriteFileAtomic(filePath, jsonBytes, 0644)
 }
 
 // Returns false if file does not exist.
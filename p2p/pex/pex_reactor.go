@@ -394,7 +394,8 @@ func (r *Reactor) ReceiveAddrs(addrs []*p2p.NetAddress, src Peer) error {
 				err := r.dialPeer(addr)
 				if err != nil {
 					switch err.(type) {
-					case errMaxAttemptsToDial, errTooEarlyToDial, p2p.ErrCurrentlyDialingOrExistingAddress:
+					case errMaxAttemptsToDial, errTooEarlyToDial, p2p.ErrCurrentlyDialingOrExistingAddress,
+						p2p.ErrDialBudgetExceeded, p2p.ErrSwitchRefusingNewPeers:
 						r.Logger.Debug(err.Error(), "addr", addr)
 					default:
 						r.Logger.Error(err.Error(), "addr", addr)
@@ -503,7 +504,7 @@ func (r *Reactor) ensurePeers() {
 			err := r.dialPeer(addr)
 			if err != nil {
 				switch err.(type) {
-				case errMaxAttemptsToDial, errTooEarlyToDial:
+				case errMaxAttemptsToDial, errTooEarlyToDial, p2p.ErrDialBudgetExceeded, p2p.ErrSwitchRefusingNewPeers:
 					r.Logger.Debug(err.Error(), "addr", addr)
 				default:
 					r.Logger.Error(err.Error(), "addr", addr)
@@ -567,7 +568,11 @@ func (r *Reactor) dialPeer(addr *p2p.NetAddress) error {
 
 	err := r.Switch.DialPeerWithAddress(addr)
 	if err != nil {
-		if _, ok := err.(p2p.ErrCurrentlyDialingOrExistingAddress); ok {
+		switch err.(type) {
+		case p2p.ErrCurrentlyDialingOrExistingAddress:
+			return err
+		case p2p.ErrDialBudgetExceeded, p2p.ErrSwitchRefusingNewPeers:
+			// the dial is only deferred, it doesn't count as a failed attempt
 			return err
 		}
 
@@ -716,7 +721,8 @@ func (r *Reactor) crawlPeers(addrs []*p2p.NetAddress) {
 		err := r.dialPeer(addr)
 		if err != nil {
 			switch err.(type) {
-			case errMaxAttemptsToDial, errTooEarlyToDial, p2p.ErrCurrentlyDialingOrExistingAddress:
+			case errMaxAttemptsToDial, errTooEarlyToDial, p2p.ErrCurrentlyDialingOrExistingAddress,
+				p2p.ErrDialBudgetExceeded, p2p.ErrSwitchRefusingNewPeers:
 				r.Logger.Debug(err.Error(), "addr", addr)
 			default:
 				r.Logger.Error(err.Error(), "addr", addr)
@@ -751,7 +757,7 @@ func (r *Reactor) attemptDisconnects() {
 		if peer.Status().Duration < r.config.SeedDisconnectWaitPeriod {
 			continue
 		}
-		if peer.IsPersistent() {
+		if peer.IsPersistent() || r.Switch.IsPeerPriority(peer.ID()) {
 			continue
 		}
 		r.Switch.StopPeerGracefully(peer)
@@ -495,6 +495,32 @@ func TestPEXReactorDialPeer(t *testing.T) {
 	}
 }
 
+func TestPEXReactorDialPeerDeferred(t *testing.T) {
+	pexR, book := createReactor(t, &ReactorConfig{})
+	swCfg := *cfg
+	swCfg.MaxDialsPerPeerPerHour = 1
+	sw := p2p.MakeSwitch(&swCfg, 0, "127.0.0.1", "123.123.123", func(i int, sw *p2p.Switch) *p2p.Switch { return sw })
+	sw.SetLogger(log.TestingLogger())
+	sw.AddReactor(pexR.String(), pexR)
+	pexR.SetSwitch(sw)
+	sw.SetAddrBook(book)
+
+	peer := mock.NewPeer(nil)
+	addr := peer.SocketAddr()
+
+	// the dials denied by the dial budget aren't counted as failed attempts
+	require.Error(t, sw.DialPeerWithAddress(addr))
+	err := pexR.dialPeer(addr)
+	require.IsType(t, p2p.ErrDialBudgetExceeded{}, err)
+	assert.Equal(t, 0, pexR.AttemptsToDial(addr))
+
+	// nor are those while refusing new peers
+	sw.SetRefuseNewPeers(true)
+	err = pexR.dialPeer(addr)
+	require.IsType(t, p2p.ErrSwitchRefusingNewPeers{}, err)
+	assert.Equal(t, 0, pexR.AttemptsToDial(addr))
+}
+
 func assertPeersWithTimeout(
 	t *testing.T,
 	switches []*p2p.Switch,
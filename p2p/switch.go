@@ -1,34 +1,24 @@
 package p2p
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"math"
+	"net"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/clock"
 	"github.com/tendermint/tendermint/libs/cmap"
 	"github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/p2p/conn"
 )
 
-const (
-	// wait a random amount of time from this interval
-	// before dialing peers or reconnecting to help prevent DoS
-	dialRandomizerIntervalMilliseconds = 3000
-
-	// repeatedly try to reconnect for a few minutes
-	// ie. 5 * 20 = 100s
-	reconnectAttempts = 20
-	reconnectInterval = 5 * time.Second
-
-	// then move into exponential backoff mode for ~1day
-	// ie. 3**10 = 16hrs
-	reconnectBackOffAttempts    = 10
-	reconnectBackOffBaseSeconds = 3
-)
-
 // MConnConfig returns an MConnConfig with fields updated
 // from the P2PConfig.
 func MConnConfig(cfg *config.P2PConfig) conn.MConnConfig {
@@ -53,6 +43,12 @@ type AddrBook interface {
 	RemoveAddress(*NetAddress)
 	HasAddress(*NetAddress) bool
 	Save()
+
+	// PeerStats returns the stats of the peer, if it's in the address book.
+	PeerStats(ID) (PeerStats, bool)
+	// UpdatePeerStats calls update with the stats of the peer, if it's in the
+	// address book.
+	UpdatePeerStats(ID, func(*PeerStats))
 }
 
 // PeerFilterFunc to be implemented by filter hooks after a new Peer has been
@@ -75,12 +71,22 @@ type Switch struct {
 	peers        *PeerSet
 	dialing      *cmap.CMap
 	reconnecting *cmap.CMap
+	unreachable  *cmap.CMap // persistent peers which couldn't be reconnected to
+	dialBudget   *dialBudget
 	nodeInfo     NodeInfo // our node info
 	nodeKey      *NodeKey // our node privkey
 	addrBook     AddrBook
 	// peers addresses with whom we'll maintain constant connection
 	persistentPeersAddrs []*NetAddress
+
+	// the priority peers may be added at runtime (see the operator reactor)
+	peerIDsMtx           sync.RWMutex
 	unconditionalPeerIDs map[ID]struct{}
+	priorityPeerIDs      map[ID]struct{} // also unconditional
+
+	peerIDListsMtx sync.RWMutex
+	allowedPeerIDs map[ID]struct{} // if not empty, only these peers are accepted
+	deniedPeerIDs  map[ID]struct{}
 
 	transport Transport
 
@@ -89,7 +95,19 @@ type Switch struct {
 
 	rng *rand.Rand // seed for randomizing dial times and orders
 
+	clock clock.Clock // for the reconnection backoff and dial budgets
+
 	metrics *Metrics
+
+	chaos *ChaosConfig
+
+	msgAuth *MessageAuthConfig
+
+	capture *TrafficCapture
+
+	onPersistentPeerUnreachable func(addr *NetAddress, attempts int)
+
+	refuseNewPeers uint32 // set by SetRefuseNewPeers, accessed atomically
 }
 
 // NetAddress returns the address the switch is listening on.
@@ -115,11 +133,15 @@ func NewSwitch(
 		peers:                NewPeerSet(),
 		dialing:              cmap.NewCMap(),
 		reconnecting:         cmap.NewCMap(),
+		unreachable:          cmap.NewCMap(),
+		dialBudget:           newDialBudget(cfg.MaxDialsPerPeerPerHour),
 		metrics:              NopMetrics(),
 		transport:            transport,
 		filterTimeout:        defaultFilterTimeout,
 		persistentPeersAddrs: make([]*NetAddress, 0),
 		unconditionalPeerIDs: make(map[ID]struct{}),
+		priorityPeerIDs:      make(map[ID]struct{}),
+		clock:                clock.New(),
 	}
 
 	// Ensure we have a completely undeterministic PRNG.
@@ -144,6 +166,39 @@ func SwitchPeerFilters(filters ...PeerFilterFunc) SwitchOption {
 	return func(sw *Switch) { sw.peerFilters = filters }
 }
 
+// SwitchChaos makes the switch inject the faults described by cfg into the
+// messages sent to peers. It must only be used for testing.
+func SwitchChaos(cfg *ChaosConfig) SwitchOption {
+	return func(sw *Switch) { sw.chaos = cfg }
+}
+
+// SwitchMessageAuth makes the switch sign the messages exchanged with the
+// peers, as described by cfg. It's a no-op if cfg is nil.
+func SwitchMessageAuth(cfg *MessageAuthConfig) SwitchOption {
+	return func(sw *Switch) { sw.msgAuth = cfg }
+}
+
+// SwitchTrafficCapture makes the switch record the envelopes exchanged with
+// the peers with tc, which is started and stopped with the switch. It's a
+// no-op if tc is nil.
+func SwitchTrafficCapture(tc *TrafficCapture) SwitchOption {
+	return func(sw *Switch) { sw.capture = tc }
+}
+
+// SwitchPersistentPeerUnreachable sets a callback invoked when the switch
+// gives up reconnecting to a persistent peer, after the given number of
+// attempts (see persistent_peers_max_reconnect_attempts). It's invoked from the
+// reconnection routine of the peer.
+func SwitchPersistentPeerUnreachable(cb func(addr *NetAddress, attempts int)) SwitchOption {
+	return func(sw *Switch) { sw.onPersistentPeerUnreachable = cb }
+}
+
+// SwitchClock sets the clock the reconnections are paced and the dials
+// budgeted with, e.g. a clock.Mock in tests.
+func SwitchClock(c clock.Clock) SwitchOption {
+	return func(sw *Switch) { sw.clock = c }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) SwitchOption {
 	return func(sw *Switch) { sw.metrics = metrics }
@@ -221,6 +276,12 @@ func (sw *Switch) SetNodeKey(nodeKey *NodeKey) {
 
 // OnStart implements BaseService. It starts all the reactors and peers.
 func (sw *Switch) OnStart() error {
+	if sw.capture != nil {
+		if err := sw.capture.Start(); err != nil {
+			return fmt.Errorf("failed to start the traffic capture: %w", err)
+		}
+	}
+
 	// Start reactors
 	for _, reactor := range sw.reactors {
 		err := reactor.Start()
@@ -249,6 +310,12 @@ func (sw *Switch) OnStop() {
 			sw.Logger.Error("error while stopped reactor", "reactor", reactor, "error", err)
 		}
 	}
+
+	if sw.capture != nil {
+		if err := sw.capture.Stop(); err != nil {
+			sw.Logger.Error("error while stopping the traffic capture", "error", err)
+		}
+	}
 }
 
 //---------------------------------------------------------------------
@@ -304,10 +371,21 @@ func (sw *Switch) NumPeers() (outbound, inbound, dialing int) {
 }
 
 func (sw *Switch) IsPeerUnconditional(id ID) bool {
+	sw.peerIDsMtx.RLock()
+	defer sw.peerIDsMtx.RUnlock()
 	_, ok := sw.unconditionalPeerIDs[id]
 	return ok
 }
 
+// IsPeerPriority returns true if the peer is a high-priority peer, see
+// AddPriorityPeerIDs.
+func (sw *Switch) IsPeerPriority(id ID) bool {
+	sw.peerIDsMtx.RLock()
+	defer sw.peerIDsMtx.RUnlock()
+	_, ok := sw.priorityPeerIDs[id]
+	return ok
+}
+
 // MaxNumOutboundPeers returns a maximum number of outbound peers.
 func (sw *Switch) MaxNumOutboundPeers() int {
 	return sw.config.MaxNumOutboundPeers
@@ -329,6 +407,16 @@ func (sw *Switch) StopPeerForError(peer Peer, reason interface{}) {
 	sw.Logger.Error("Stopping peer for error", "peer", peer, "err", reason)
 	sw.stopAndRemovePeer(peer, reason)
 
+	if !isConnectionError(reason) {
+		msgs := lastAuthenticatedMessages(peer)
+		sw.updatePeerStats(peer.ID(), func(stats *PeerStats) {
+			stats.Misbehaviors++
+			if len(msgs) > 0 {
+				stats.MisbehaviorMessages = msgs
+			}
+		})
+	}
+
 	if peer.IsPersistent() {
 		var addr *NetAddress
 		if peer.IsOutbound() { // socket address for outbound peers
@@ -346,6 +434,19 @@ func (sw *Switch) StopPeerForError(peer Peer, reason interface{}) {
 	}
 }
 
+// lastAuthenticatedMessages returns the last messages received from the peer
+// on the authenticated channels, signed by the peer, so that its misbehavior
+// can be proven to third parties.
+func lastAuthenticatedMessages(peer Peer) []*AuthenticatedMessage {
+	ap, ok := peer.(interface {
+		lastAuthenticatedMessages() []*AuthenticatedMessage
+	})
+	if !ok {
+		return nil
+	}
+	return ap.lastAuthenticatedMessages()
+}
+
 // StopPeerGracefully disconnects from a peer gracefully.
 // TODO: handle graceful disconnects.
 func (sw *Switch) StopPeerGracefully(peer Peer) {
@@ -363,18 +464,57 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 		reactor.RemovePeer(peer, reason)
 	}
 
+	status := peer.Status()
+	sw.updatePeerStats(peer.ID(), func(stats *PeerStats) {
+		stats.RecordConnection(status)
+	})
+
 	// Removing a peer should go last to avoid a situation where a peer
 	// reconnect to our node and the switch calls InitPeer before
 	// RemovePeer is finished.
 	// https://github.com/tendermint/tendermint/issues/3338
 	if sw.peers.Remove(peer) {
 		sw.metrics.Peers.Add(float64(-1))
+		for _, protocol := range sw.outdatedProtocols(peer) {
+			sw.metrics.PeersBelowMinVersion.With("protocol", protocol).Add(-1)
+		}
+	}
+}
+
+// priorityChDescs returns the channel descriptors of the high-priority peers,
+// with larger send queues.
+func (sw *Switch) priorityChDescs() []*conn.ChannelDescriptor {
+	chDescs := make([]*conn.ChannelDescriptor, len(sw.chDescs))
+	for i, chDesc := range sw.chDescs {
+		filled := chDesc.FillDefaults()
+		filled.SendQueueCapacity *= sw.config.PrioritySendQueueFactor
+		chDescs[i] = &filled
+	}
+	return chDescs
+}
+
+// reconnectToPriorityPeer tries to reconnect to the addr of the high-priority
+// peer every config.PriorityReconnectInterval, until the switch stops.
+func (sw *Switch) reconnectToPriorityPeer(addr *NetAddress) {
+	start := sw.clock.Now()
+	sw.Logger.Info("Reconnecting to priority peer", "addr", addr)
+	for i := 0; sw.IsRunning(); i++ {
+		done, err := sw.redialPeer(addr)
+		if done {
+			return
+		}
+		sw.Logger.Info("Error reconnecting to priority peer. Trying again", "tries", i, "err", err,
+			"addr", addr, "elapsed", sw.clock.Since(start))
+		sw.randomSleep(sw.config.PriorityReconnectInterval)
 	}
 }
 
 // reconnectToPeer tries to reconnect to the addr, first repeatedly
-// with a fixed interval, then with exponential backoff.
-// If no success after all that, it stops trying, and leaves it
+// with a fixed interval, then with exponential backoff (see the reconnect_*
+// params of the P2PConfig). The attempts denied by the dial budget of the
+// peer are postponed until it allows them, without being counted.
+// If no success after all that, or after persistent_peers_max_reconnect_attempts
+// attempts, it stops trying, reports the peer as unreachable, and leaves it
 // to the PEX/Addrbook to find the peer with the addr again
 // NOTE: this will keep trying even if the handshake or auth fails.
 // TODO: be more explicit with error types so we only retry on certain failures
@@ -387,46 +527,137 @@ func (sw *Switch) reconnectToPeer(addr *NetAddress) {
 	sw.reconnecting.Set(string(addr.ID), addr)
 	defer sw.reconnecting.Delete(string(addr.ID))
 
-	start := time.Now()
+	if sw.IsPeerPriority(addr.ID) {
+		sw.reconnectToPriorityPeer(addr)
+		return
+	}
+
+	maxAttempts := sw.config.PersistentPeersMaxReconnectAttempts
+	if maxAttempts == 0 {
+		maxAttempts = sw.config.ReconnectAttempts + sw.config.ReconnectBackoffAttempts
+	}
+	attempts := 0
+
+	start := sw.clock.Now()
 	sw.Logger.Info("Reconnecting to peer", "addr", addr)
-	for i := 0; i < reconnectAttempts; i++ {
+	for i := 0; i < sw.config.ReconnectAttempts && attempts < maxAttempts; i++ {
 		if !sw.IsRunning() {
 			return
 		}
 
-		err := sw.DialPeerWithAddress(addr)
-		if err == nil {
-			return // success
-		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
+		done, err := sw.redialPeer(addr)
+		if done {
 			return
 		}
+		attempts++
 
 		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
 		// sleep a set amount
-		sw.randomSleep(reconnectInterval)
+		sw.randomSleep(sw.config.ReconnectInterval)
 		continue
 	}
 
-	sw.Logger.Error("Failed to reconnect to peer. Beginning exponential backoff",
-		"addr", addr, "elapsed", time.Since(start))
-	for i := 0; i < reconnectBackOffAttempts; i++ {
+	if attempts < maxAttempts {
+		sw.Logger.Error("Failed to reconnect to peer. Beginning exponential backoff",
+			"addr", addr, "elapsed", sw.clock.Since(start))
+	}
+	for i := 0; attempts < maxAttempts; i++ {
 		if !sw.IsRunning() {
 			return
 		}
 
-		// sleep an exponentially increasing amount
-		sleepIntervalSeconds := math.Pow(reconnectBackOffBaseSeconds, float64(i))
-		sw.randomSleep(time.Duration(sleepIntervalSeconds) * time.Second)
+		// sleep an exponentially increasing amount, up to
+		// persistent_peers_max_dial_period if set
+		multiplier := math.Pow(sw.config.ReconnectBackoffMultiplier, float64(i))
+		interval := time.Duration(float64(sw.config.ReconnectBackoffInterval) * multiplier)
+		if max := sw.config.PersistentPeersMaxDialPeriod; max > 0 && (interval > max || interval < 0) {
+			interval = max
+		}
+		sw.randomSleep(interval)
 
-		err := sw.DialPeerWithAddress(addr)
-		if err == nil {
-			return // success
-		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
+		done, err := sw.redialPeer(addr)
+		if done {
 			return
 		}
+		attempts++
 		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
 	}
-	sw.Logger.Error("Failed to reconnect to peer. Giving up", "addr", addr, "elapsed", time.Since(start))
+	sw.Logger.Error("Failed to reconnect to peer. Giving up", "addr", addr, "attempts", attempts,
+		"elapsed", sw.clock.Since(start))
+	sw.markPersistentPeerUnreachable(addr, attempts)
+}
+
+// markPersistentPeerUnreachable records that the persistent peer couldn't be
+// reconnected to, until it's connected again (e.g. after being redialed via
+// DialPeersAsync).
+func (sw *Switch) markPersistentPeerUnreachable(addr *NetAddress, attempts int) {
+	sw.unreachable.Set(string(addr.ID), addr)
+	sw.metrics.PersistentPeersUnreachable.Set(float64(sw.unreachable.Size()))
+	if sw.onPersistentPeerUnreachable != nil {
+		sw.onPersistentPeerUnreachable(addr, attempts)
+	}
+}
+
+// UnreachablePersistentPeers returns the addresses of the persistent peers
+// which couldn't be reconnected to, and haven't been connected to since.
+func (sw *Switch) UnreachablePersistentPeers() []*NetAddress {
+	values := sw.unreachable.Values()
+	addrs := make([]*NetAddress, 0, len(values))
+	for _, v := range values {
+		addrs = append(addrs, v.(*NetAddress))
+	}
+	return addrs
+}
+
+// redialPeer dials the addr, waiting for its dial budget if needed. It
+// returns true if the peer is connected, being dialed by someone else, or
+// denied by the peer ID lists, in which case it isn't dialed.
+func (sw *Switch) redialPeer(addr *NetAddress) (bool, error) {
+	for {
+		// the peer ID lists may have changed since the reconnection started
+		if err := sw.checkPeerID(addr.ID); err != nil {
+			sw.Logger.Info("Not reconnecting to peer", "addr", addr, "err", err)
+			return true, err
+		}
+		err := sw.DialPeerWithAddress(addr)
+		switch e := err.(type) {
+		case nil, ErrCurrentlyDialingOrExistingAddress:
+			return true, err
+		case ErrDialBudgetExceeded:
+			sw.Logger.Debug("Dial budget of peer exceeded, postponing reconnection",
+				"addr", addr, "retryAfter", e.RetryAfter)
+			if !sw.sleep(e.RetryAfter) {
+				return true, err
+			}
+		case ErrSwitchRefusingNewPeers:
+			sw.Logger.Debug("Refusing new peers, postponing reconnection", "addr", addr)
+			if !sw.sleep(sw.config.ReconnectInterval) {
+				return true, err
+			}
+		default:
+			return false, err
+		}
+	}
+}
+
+// SetRefuseNewPeers makes the switch refuse the inbound connections and stop
+// dialing peers (returning ErrSwitchRefusingNewPeers), keeping the existing
+// peers, e.g. while the disk is nearly full. The persistent peers are
+// reconnected to once the new peers are accepted again.
+func (sw *Switch) SetRefuseNewPeers(refuse bool) {
+	var v uint32
+	if refuse {
+		v = 1
+	}
+	if atomic.SwapUint32(&sw.refuseNewPeers, v) != v {
+		sw.Logger.Info("Updated the acceptance of new peers", "refused", refuse)
+	}
+}
+
+// RefusesNewPeers returns true if the switch refuses the new peers (see
+// SetRefuseNewPeers).
+func (sw *Switch) RefusesNewPeers() bool {
+	return atomic.LoadUint32(&sw.refuseNewPeers) == 1
 }
 
 // SetAddrBook allows to set address book on Switch.
@@ -442,6 +673,43 @@ func (sw *Switch) MarkPeerAsGood(peer Peer) {
 	}
 }
 
+// RecordUsefulBlock records that the given peer sent us the block at the
+// given height (or part of it), in its stats.
+func (sw *Switch) RecordUsefulBlock(peer Peer, height int64) {
+	sw.updatePeerStats(peer.ID(), func(stats *PeerStats) {
+		if height > stats.LastUsefulBlock {
+			stats.LastUsefulBlock = height
+		}
+	})
+}
+
+// PeerStats returns the historical stats of the peer with the given ID, if
+// it's in the address book. They don't include the current connection, if
+// any.
+func (sw *Switch) PeerStats(id ID) (PeerStats, bool) {
+	if sw.addrBook == nil {
+		return PeerStats{}, false
+	}
+	return sw.addrBook.PeerStats(id)
+}
+
+func (sw *Switch) updatePeerStats(id ID, update func(*PeerStats)) {
+	if sw.addrBook != nil {
+		sw.addrBook.UpdatePeerStats(id, update)
+	}
+}
+
+// isConnectionError returns true if the reason a peer is stopped for is an
+// I/O error, rather than the peer misbehaving.
+func isConnectionError(reason interface{}) bool {
+	err, ok := reason.(error)
+	if !ok {
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.As(err, &netErr)
+}
+
 //---------------------------------------------------------------------
 // Dialing
 
@@ -519,7 +787,8 @@ func (sw *Switch) dialPeersAsync(netAddrs []*NetAddress) {
 			err := sw.DialPeerWithAddress(addr)
 			if err != nil {
 				switch err.(type) {
-				case ErrSwitchConnectToSelf, ErrSwitchDuplicatePeerID, ErrCurrentlyDialingOrExistingAddress:
+				case ErrSwitchConnectToSelf, ErrSwitchDuplicatePeerID, ErrCurrentlyDialingOrExistingAddress,
+					ErrDialBudgetExceeded, ErrSwitchRefusingNewPeers:
 					sw.Logger.Debug("Error dialing peer", "err", err)
 				default:
 					sw.Logger.Error("Error dialing peer", "err", err)
@@ -532,11 +801,19 @@ func (sw *Switch) dialPeersAsync(netAddrs []*NetAddress) {
 // DialPeerWithAddress dials the given peer and runs sw.addPeer if it connects
 // and authenticates successfully.
 // If we're currently dialing this address or it belongs to an existing peer,
-// ErrCurrentlyDialingOrExistingAddress is returned.
+// ErrCurrentlyDialingOrExistingAddress is returned. If the peer was dialed too
+// many times in the last hour, ErrDialBudgetExceeded is returned, and if the
+// new peers are refused, ErrSwitchRefusingNewPeers.
 func (sw *Switch) DialPeerWithAddress(addr *NetAddress) error {
+	if sw.RefusesNewPeers() {
+		return ErrSwitchRefusingNewPeers{Addr: addr.String()}
+	}
 	if sw.IsDialingOrExistingAddress(addr) {
 		return ErrCurrentlyDialingOrExistingAddress{addr.String()}
 	}
+	if ok, retryAfter := sw.dialBudget.reserve(addr.ID, sw.clock.Now()); !ok {
+		return ErrDialBudgetExceeded{Addr: addr.String(), RetryAfter: retryAfter}
+	}
 
 	sw.dialing.Set(string(addr.ID), addr)
 	defer sw.dialing.Delete(string(addr.ID))
@@ -544,10 +821,26 @@ func (sw *Switch) DialPeerWithAddress(addr *NetAddress) error {
 	return sw.addOutboundPeerWithConfig(addr, sw.config)
 }
 
-// sleep for interval plus some random amount of ms on [0, dialRandomizerIntervalMilliseconds]
+// sleep for interval plus some random amount of time on [0, DialJitter)
 func (sw *Switch) randomSleep(interval time.Duration) {
-	r := time.Duration(sw.rng.Int63n(dialRandomizerIntervalMilliseconds)) * time.Millisecond
-	time.Sleep(r + interval)
+	var r time.Duration
+	if sw.config.DialJitter > 0 {
+		r = time.Duration(sw.rng.Int63n(int64(sw.config.DialJitter)))
+	}
+	sw.sleep(r + interval)
+}
+
+// sleep sleeps for the given duration, returning false if the switch was
+// stopped in the meantime.
+func (sw *Switch) sleep(d time.Duration) bool {
+	timer := sw.clock.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return true
+	case <-sw.Quit():
+		return false
+	}
 }
 
 // IsDialingOrExistingAddress returns true if switch has a peer with the given
@@ -582,19 +875,58 @@ func (sw *Switch) AddPersistentPeers(addrs []string) error {
 func (sw *Switch) AddUnconditionalPeerIDs(ids []string) error {
 	sw.Logger.Info("Adding unconditional peer ids", "ids", ids)
 	for i, id := range ids {
-		err := validateID(ID(id))
+		err := ValidateID(ID(id))
+		if err != nil {
+			return fmt.Errorf("wrong ID #%d: %w", i, err)
+		}
+	}
+	sw.peerIDsMtx.Lock()
+	defer sw.peerIDsMtx.Unlock()
+	for _, id := range ids {
+		sw.unconditionalPeerIDs[ID(id)] = struct{}{}
+	}
+	return nil
+}
+
+// AddPriorityPeerIDs marks the peers as high-priority: they're unconditional
+// peers, get send queues config.PrioritySendQueueFactor times larger and, if
+// persistent, are reconnected to every config.PriorityReconnectInterval
+// without ever giving up. It's safe to call while the switch is running, the
+// peers already connected keeping their send queues.
+func (sw *Switch) AddPriorityPeerIDs(ids []string) error {
+	sw.Logger.Info("Adding priority peer ids", "ids", ids)
+	for i, id := range ids {
+		err := ValidateID(ID(id))
 		if err != nil {
 			return fmt.Errorf("wrong ID #%d: %w", i, err)
 		}
+	}
+	sw.peerIDsMtx.Lock()
+	defer sw.peerIDsMtx.Unlock()
+	for _, id := range ids {
+		sw.priorityPeerIDs[ID(id)] = struct{}{}
 		sw.unconditionalPeerIDs[ID(id)] = struct{}{}
 	}
 	return nil
 }
 
+// RemovePriorityPeerIDs reverts AddPriorityPeerIDs: the peers are no longer
+// high-priority nor unconditional. The peers already connected keep their
+// send queues.
+func (sw *Switch) RemovePriorityPeerIDs(ids []string) {
+	sw.Logger.Info("Removing priority peer ids", "ids", ids)
+	sw.peerIDsMtx.Lock()
+	defer sw.peerIDsMtx.Unlock()
+	for _, id := range ids {
+		delete(sw.priorityPeerIDs, ID(id))
+		delete(sw.unconditionalPeerIDs, ID(id))
+	}
+}
+
 func (sw *Switch) AddPrivatePeerIDs(ids []string) error {
 	validIDs := make([]string, 0, len(ids))
 	for i, id := range ids {
-		err := validateID(ID(id))
+		err := ValidateID(ID(id))
 		if err != nil {
 			return fmt.Errorf("wrong ID #%d: %w", i, err)
 		}
@@ -606,6 +938,118 @@ func (sw *Switch) AddPrivatePeerIDs(ids []string) error {
 	return nil
 }
 
+// SetPeerIDLists replaces the lists of node IDs allowed (all of them are, if
+// the list is empty) and denied. The connected peers which are no longer
+// allowed are disconnected.
+func (sw *Switch) SetPeerIDLists(allowed, denied []string) error {
+	allowedIDs, err := peerIDSet(allowed)
+	if err != nil {
+		return fmt.Errorf("wrong allowed peer IDs: %w", err)
+	}
+	deniedIDs, err := peerIDSet(denied)
+	if err != nil {
+		return fmt.Errorf("wrong denied peer IDs: %w", err)
+	}
+
+	sw.Logger.Info("Setting peer ID lists", "allowed", allowed, "denied", denied)
+	sw.peerIDListsMtx.Lock()
+	sw.allowedPeerIDs = allowedIDs
+	sw.deniedPeerIDs = deniedIDs
+	sw.peerIDListsMtx.Unlock()
+
+	for _, peer := range sw.peers.List() {
+		if err := sw.checkPeerID(peer.ID()); err != nil {
+			sw.Logger.Info("Disconnecting peer", "peer", peer, "err", err)
+			sw.stopAndRemovePeer(peer, err)
+		}
+	}
+	return nil
+}
+
+// PeerIDLists returns the sorted lists of node IDs allowed and denied.
+func (sw *Switch) PeerIDLists() (allowed, denied []string) {
+	sw.peerIDListsMtx.RLock()
+	defer sw.peerIDListsMtx.RUnlock()
+	return sortedPeerIDs(sw.allowedPeerIDs), sortedPeerIDs(sw.deniedPeerIDs)
+}
+
+// checkPeerID returns an error if the peer with the given ID is denied, or not
+// allowed.
+func (sw *Switch) checkPeerID(id ID) error {
+	sw.peerIDListsMtx.RLock()
+	defer sw.peerIDListsMtx.RUnlock()
+	if _, ok := sw.deniedPeerIDs[id]; ok {
+		return fmt.Errorf("peer %v is denied", id)
+	}
+	if _, ok := sw.allowedPeerIDs[id]; len(sw.allowedPeerIDs) > 0 && !ok {
+		return fmt.Errorf("peer %v is not allowed", id)
+	}
+	return nil
+}
+
+// outdatedProtocols returns the protocols (block, p2p or app) for which the
+// peer's version is below the minimum version of the config.
+func (sw *Switch) outdatedProtocols(p Peer) []string {
+	ni, ok := p.NodeInfo().(DefaultNodeInfo)
+	if !ok {
+		return nil
+	}
+	var protocols []string
+	if ni.ProtocolVersion.Block < sw.config.MinPeerBlockVersion {
+		protocols = append(protocols, "block")
+	}
+	if ni.ProtocolVersion.P2P < sw.config.MinPeerP2PVersion {
+		protocols = append(protocols, "p2p")
+	}
+	if ni.ProtocolVersion.App < sw.config.MinPeerAppVersion {
+		protocols = append(protocols, "app")
+	}
+	return protocols
+}
+
+// checkPeerProtocolVersion returns an error if the peer's protocol versions
+// are below the minimum versions of the config, unless the config only warns
+// about such peers, in which case it logs them.
+func (sw *Switch) checkPeerProtocolVersion(p Peer) error {
+	protocols := sw.outdatedProtocols(p)
+	if len(protocols) == 0 {
+		return nil
+	}
+	version := p.NodeInfo().(DefaultNodeInfo).ProtocolVersion
+	minVersion := ProtocolVersion{
+		Block: sw.config.MinPeerBlockVersion,
+		P2P:   sw.config.MinPeerP2PVersion,
+		App:   sw.config.MinPeerAppVersion,
+	}
+	if sw.config.MinPeerVersionWarnOnly {
+		sw.Logger.Info("Peer's protocol version is below the minimum version, it will be rejected once "+
+			"min_peer_version_warn_only is disabled",
+			"peer", p.ID(), "protocols", protocols, "version", version, "min", minVersion)
+		return nil
+	}
+	return fmt.Errorf("%v protocol version %+v is below the minimum version %+v", protocols, version, minVersion)
+}
+
+func peerIDSet(ids []string) (map[ID]struct{}, error) {
+	set := make(map[ID]struct{}, len(ids))
+	for i, id := range ids {
+		if err := ValidateID(ID(id)); err != nil {
+			return nil, fmt.Errorf("wrong ID #%d: %w", i, err)
+		}
+		set[ID(id)] = struct{}{}
+	}
+	return set, nil
+}
+
+func sortedPeerIDs(set map[ID]struct{}) []string {
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 func (sw *Switch) IsPeerPersistent(na *NetAddress) bool {
 	for _, pa := range sw.persistentPeersAddrs {
 		if pa.Equals(na) {
@@ -618,11 +1062,16 @@ func (sw *Switch) IsPeerPersistent(na *NetAddress) bool {
 func (sw *Switch) acceptRoutine() {
 	for {
 		p, err := sw.transport.Accept(peerConfig{
-			chDescs:      sw.chDescs,
-			onPeerError:  sw.StopPeerForError,
-			reactorsByCh: sw.reactorsByCh,
-			metrics:      sw.metrics,
-			isPersistent: sw.IsPeerPersistent,
+			chDescs:         sw.chDescs,
+			isPriority:      sw.IsPeerPriority,
+			priorityChDescs: sw.priorityChDescs(),
+			onPeerError:     sw.StopPeerForError,
+			reactorsByCh:    sw.reactorsByCh,
+			metrics:         sw.metrics,
+			isPersistent:    sw.IsPeerPersistent,
+			chaos:           sw.chaos,
+			msgAuth:         sw.msgAuth,
+			capture:         sw.capture,
 		})
 		if err != nil {
 			switch err := err.(type) {
@@ -671,6 +1120,12 @@ func (sw *Switch) acceptRoutine() {
 			break
 		}
 
+		if sw.RefusesNewPeers() {
+			sw.Logger.Info("Ignoring inbound connection: refusing new peers", "address", p.SocketAddr())
+			sw.transport.Cleanup(p)
+			continue
+		}
+
 		if !sw.IsPeerUnconditional(p.NodeInfo().ID()) {
 			// Ignore connection if we already have enough peers.
 			_, in, _ := sw.NumPeers()
@@ -721,11 +1176,16 @@ func (sw *Switch) addOutboundPeerWithConfig(
 	}
 
 	p, err := sw.transport.Dial(*addr, peerConfig{
-		chDescs:      sw.chDescs,
-		onPeerError:  sw.StopPeerForError,
-		isPersistent: sw.IsPeerPersistent,
-		reactorsByCh: sw.reactorsByCh,
-		metrics:      sw.metrics,
+		chDescs:         sw.chDescs,
+		isPriority:      sw.IsPeerPriority,
+		priorityChDescs: sw.priorityChDescs(),
+		onPeerError:     sw.StopPeerForError,
+		isPersistent:    sw.IsPeerPersistent,
+		reactorsByCh:    sw.reactorsByCh,
+		metrics:         sw.metrics,
+		chaos:           sw.chaos,
+		msgAuth:         sw.msgAuth,
+		capture:         sw.capture,
 	})
 	if err != nil {
 		if e, ok := err.(ErrRejected); ok {
@@ -765,6 +1225,14 @@ func (sw *Switch) filterPeer(p Peer) error {
 		return ErrRejected{id: p.ID(), isDuplicate: true}
 	}
 
+	if err := sw.checkPeerID(p.ID()); err != nil {
+		return ErrRejected{id: p.ID(), err: err, isFiltered: true}
+	}
+
+	if err := sw.checkPeerProtocolVersion(p); err != nil {
+		return ErrRejected{id: p.ID(), err: err, isIncompatible: true}
+	}
+
 	errc := make(chan error, len(sw.peerFilters))
 
 	for _, f := range sw.peerFilters {
@@ -826,6 +1294,19 @@ func (sw *Switch) addPeer(p Peer) error {
 		return err
 	}
 	sw.metrics.Peers.Add(float64(1))
+	for _, protocol := range sw.outdatedProtocols(p) {
+		sw.metrics.PeersBelowMinVersion.With("protocol", protocol).Add(1)
+	}
+	if sw.unreachable.Has(string(p.ID())) {
+		sw.unreachable.Delete(string(p.ID()))
+		sw.metrics.PersistentPeersUnreachable.Set(float64(sw.unreachable.Size()))
+	}
+
+	sw.updatePeerStats(p.ID(), func(stats *PeerStats) {
+		if stats.FirstConnected.IsZero() {
+			stats.FirstConnected = sw.clock.Now()
+		}
+	})
 
 	// Start all the reactor protocols on the peer.
 	for _, reactor := range sw.reactors {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/clock"
 	"github.com/tendermint/tendermint/libs/log"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p/conn"
@@ -158,6 +159,47 @@ func TestSwitches(t *testing.T) {
 		s2.Reactor("bar").(*TestReactor), 10*time.Millisecond, 5*time.Second)
 }
 
+func TestSwitchChannelStats(t *testing.T) {
+	s1, s2 := MakeSwitchPair(t, initSwitchFunc)
+	t.Cleanup(func() {
+		if err := s1.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Cleanup(func() {
+		if err := s2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	msg := []byte("channel foo")
+	s1.Broadcast(byte(0x01), msg)
+	assertMsgReceivedWithTimeout(t, msg, byte(0x01), s2.Reactor("foo").(*TestReactor),
+		10*time.Millisecond, 5*time.Second)
+
+	channel := func(stats []PeerChannelStats, peerID ID, chID byte) ChannelStats {
+		require.Len(t, stats, 1)
+		require.Equal(t, peerID, stats[0].PeerID)
+		for _, chStats := range stats[0].Channels {
+			if chStats.ChannelID == chID {
+				return chStats
+			}
+		}
+		t.Fatalf("no stats for channel %#x", chID)
+		return ChannelStats{}
+	}
+	sent := channel(s1.ChannelStats(""), s2.NodeInfo().ID(), 0x01)
+	assert.EqualValues(t, 1, sent.MsgsSent)
+	assert.EqualValues(t, len(msg), sent.BytesSent)
+	assert.Zero(t, sent.MsgsReceived)
+	received := channel(s2.ChannelStats(s1.NodeInfo().ID()), s1.NodeInfo().ID(), 0x01)
+	assert.EqualValues(t, 1, received.MsgsReceived)
+	assert.EqualValues(t, len(msg), received.BytesReceived)
+	assert.Zero(t, received.ReceiveErrors)
+
+	assert.Empty(t, s1.ChannelStats("unknown"))
+}
+
 func assertMsgReceivedWithTimeout(
 	t *testing.T,
 	msgBytes []byte,
@@ -260,6 +302,34 @@ func TestSwitchPeerFilter(t *testing.T) {
 	}
 }
 
+func TestSwitchPeerProtocolVersion(t *testing.T) {
+	c := *cfg
+	c.MinPeerP2PVersion = defaultProtocolVersion.P2P + 1
+	c.MinPeerAppVersion = 2
+	sw := NewSwitch(&c, nil)
+	sw.SetLogger(log.TestingLogger())
+
+	newPeer := func(version ProtocolVersion) Peer {
+		ni := testNodeInfo(PubKeyToID(ed25519.GenPrivKey().PubKey()), "peer").(DefaultNodeInfo)
+		ni.ProtocolVersion = version
+		return &peer{nodeInfo: ni, metrics: NopMetrics()}
+	}
+	current := newPeer(ProtocolVersion{P2P: c.MinPeerP2PVersion, Block: defaultProtocolVersion.Block, App: 2})
+	old := newPeer(ProtocolVersion{P2P: defaultProtocolVersion.P2P, Block: defaultProtocolVersion.Block, App: 1})
+
+	assert.Empty(t, sw.outdatedProtocols(current))
+	assert.Equal(t, []string{"p2p", "app"}, sw.outdatedProtocols(old))
+	assert.NoError(t, sw.filterPeer(current))
+	err := sw.filterPeer(old)
+	if assert.IsType(t, ErrRejected{}, err) {
+		assert.True(t, err.(ErrRejected).IsIncompatible())
+	}
+
+	// the warn-only mode accepts them
+	c.MinPeerVersionWarnOnly = true
+	assert.NoError(t, sw.filterPeer(old))
+}
+
 func TestSwitchPeerFilterTimeout(t *testing.T) {
 	var (
 		filters = []PeerFilterFunc{
@@ -346,6 +416,71 @@ func TestSwitchPeerFilterDuplicate(t *testing.T) {
 	}
 }
 
+func TestSwitchPeerIDLists(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// simulate remote peers
+	rp1 := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp1.Start()
+	t.Cleanup(rp1.Stop)
+	rp2 := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp2.Start()
+	t.Cleanup(rp2.Stop)
+
+	addPeer := func(rp *remotePeer) error {
+		p, err := sw.transport.Dial(*rp.Addr(), peerConfig{
+			chDescs:      sw.chDescs,
+			onPeerError:  sw.StopPeerForError,
+			isPersistent: sw.IsPeerPersistent,
+			reactorsByCh: sw.reactorsByCh,
+		})
+		require.NoError(t, err)
+		err = sw.addPeer(p)
+		if err != nil {
+			sw.transport.Cleanup(p)
+		}
+		return err
+	}
+
+	// only the allowed peers are accepted
+	err = sw.SetPeerIDLists([]string{string(rp1.ID())}, nil)
+	require.NoError(t, err)
+	require.NoError(t, addPeer(rp1))
+	err = addPeer(rp2)
+	if err, ok := err.(ErrRejected); assert.True(t, ok) {
+		assert.True(t, err.IsFiltered())
+	}
+
+	// denied peers are disconnected
+	err = sw.SetPeerIDLists(nil, []string{string(rp1.ID())})
+	require.NoError(t, err)
+	assert.False(t, sw.Peers().Has(rp1.ID()))
+	require.NoError(t, addPeer(rp2))
+
+	allowed, denied := sw.PeerIDLists()
+	assert.Empty(t, allowed)
+	assert.Equal(t, []string{string(rp1.ID())}, denied)
+
+	// nor reconnected to, even if persistent
+	require.NoError(t, sw.AddPersistentPeers([]string{rp1.Addr().String()}))
+	done, err := sw.redialPeer(rp1.Addr())
+	assert.True(t, done)
+	assert.Error(t, err)
+	assert.False(t, sw.Peers().Has(rp1.ID()))
+
+	// invalid IDs are rejected
+	assert.Error(t, sw.SetPeerIDLists([]string{"foo"}, nil))
+	_, denied = sw.PeerIDLists()
+	assert.Equal(t, []string{string(rp1.ID())}, denied)
+}
+
 func assertNoPeersAfterTimeout(t *testing.T, sw *Switch, timeout time.Duration) {
 	time.Sleep(timeout)
 	if sw.Peers().Size() != 0 {
@@ -526,6 +661,180 @@ func TestSwitchReconnectsToInboundPersistentPeer(t *testing.T) {
 	assert.Equal(t, 1, sw.Peers().Size())
 }
 
+func TestSwitchReportsUnreachablePersistentPeer(t *testing.T) {
+	conf := config.DefaultP2PConfig()
+	conf.ReconnectAttempts = 2
+	conf.ReconnectInterval = time.Millisecond
+	conf.ReconnectBackoffAttempts = 5
+	conf.ReconnectBackoffInterval = time.Millisecond
+	conf.ReconnectBackoffMultiplier = 1
+	conf.DialJitter = 0
+	conf.PersistentPeersMaxReconnectAttempts = 3
+
+	var (
+		reported *NetAddress
+		attempts int
+	)
+	sw := MakeSwitch(conf, 1, "testing", "123.123.123", initSwitchFunc,
+		SwitchPersistentPeerUnreachable(func(addr *NetAddress, n int) {
+			reported, attempts = addr, n
+		}))
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	addr := rp.Addr()
+	rp.Stop()
+
+	sw.reconnectToPeer(addr)
+	require.NotNil(t, reported)
+	assert.Equal(t, addr.ID, reported.ID)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []*NetAddress{addr}, sw.UnreachablePersistentPeers())
+
+	// a peer is no longer unreachable once connected to, e.g. after being
+	// redialed manually
+	rp = &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+	sw.markPersistentPeerUnreachable(rp.Addr(), 3)
+	require.Len(t, sw.UnreachablePersistentPeers(), 2)
+
+	err = sw.DialPeerWithAddress(rp.Addr())
+	require.NoError(t, err)
+	assert.Equal(t, []*NetAddress{addr}, sw.UnreachablePersistentPeers())
+}
+
+func TestSwitchReconnectBackoffWithClock(t *testing.T) {
+	conf := config.DefaultP2PConfig()
+	conf.ReconnectAttempts = 1
+	conf.ReconnectInterval = time.Hour
+	conf.ReconnectBackoffAttempts = 1
+	conf.ReconnectBackoffInterval = 2 * time.Hour
+	conf.DialJitter = 0
+
+	clk := clock.NewMock(time.Unix(1600000000, 0))
+	gaveUp := make(chan int, 1)
+	sw := MakeSwitch(conf, 1, "testing", "123.123.123", initSwitchFunc, SwitchClock(clk),
+		SwitchPersistentPeerUnreachable(func(addr *NetAddress, n int) { gaveUp <- n }))
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	addr := rp.Addr()
+	rp.Stop()
+
+	go sw.reconnectToPeer(addr)
+	// the switch sleeps for the reconnect interval, then the backoff interval,
+	// only giving up once the clock has moved forward by both
+	for _, d := range []time.Duration{time.Hour, 2 * time.Hour} {
+		require.Eventually(t, func() bool { return clk.Timers() == 1 }, 5*time.Second, time.Millisecond)
+		select {
+		case <-gaveUp:
+			t.Fatal("gave up early")
+		default:
+		}
+		clk.Add(d)
+	}
+	select {
+	case n := <-gaveUp:
+		assert.Equal(t, 2, n)
+	case <-time.After(5 * time.Second):
+		t.Fatal("didn't give up")
+	}
+}
+
+func TestSwitchReconnectStopsWithSwitch(t *testing.T) {
+	conf := config.DefaultP2PConfig()
+	conf.ReconnectInterval = time.Hour
+	conf.MaxDialsPerPeerPerHour = 1
+	conf.DialJitter = 0
+
+	clk := clock.NewMock(time.Unix(1600000000, 0))
+	sw := MakeSwitch(conf, 1, "testing", "123.123.123", initSwitchFunc, SwitchClock(clk))
+	require.NoError(t, sw.Start())
+
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	addr := rp.Addr()
+	rp.Stop()
+
+	// the reconnection waits for the dial budget, until the switch stops
+	require.Error(t, sw.DialPeerWithAddress(addr))
+	done := make(chan struct{})
+	go func() {
+		sw.reconnectToPeer(addr)
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return clk.Timers() == 1 }, 5*time.Second, time.Millisecond)
+	require.NoError(t, sw.Stop())
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the reconnection didn't stop with the switch")
+	}
+}
+
+func TestSwitchPriorityPeer(t *testing.T) {
+	conf := config.DefaultP2PConfig()
+	conf.ReconnectAttempts = 1
+	conf.ReconnectInterval = time.Millisecond
+	conf.ReconnectBackoffAttempts = 1
+	conf.ReconnectBackoffInterval = time.Millisecond
+	conf.PriorityReconnectInterval = 10 * time.Millisecond
+	conf.DialJitter = 0
+
+	gaveUp := make(chan int, 1)
+	sw := MakeSwitch(conf, 1, "testing", "123.123.123", initSwitchFunc,
+		SwitchPersistentPeerUnreachable(func(addr *NetAddress, n int) { gaveUp <- n }))
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	addr := rp.Addr()
+	rp.Stop()
+
+	require.NoError(t, sw.AddPriorityPeerIDs([]string{string(addr.ID)}))
+	assert.True(t, sw.IsPeerPriority(addr.ID))
+	assert.True(t, sw.IsPeerUnconditional(addr.ID))
+	for _, chDesc := range sw.priorityChDescs() {
+		assert.Equal(t, 4, chDesc.SendQueueCapacity)
+	}
+
+	// the priority peers are reconnected to until they're back
+	go sw.reconnectToPeer(addr)
+	time.Sleep(100 * time.Millisecond)
+	select {
+	case <-gaveUp:
+		t.Fatal("gave up reconnecting to the priority peer")
+	default:
+	}
+	rp = &remotePeer{PrivKey: rp.PrivKey, Config: cfg, listenAddr: addr.DialString()}
+	rp.Start()
+	defer rp.Stop()
+	assert.Eventually(t, func() bool {
+		return sw.Peers().Get(addr.ID) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func TestSwitchDialPeersAsync(t *testing.T) {
 	if testing.Short() {
 		return
@@ -546,7 +855,7 @@ func TestSwitchDialPeersAsync(t *testing.T) {
 
 	err = sw.DialPeersAsync([]string{rp.Addr().String()})
 	require.NoError(t, err)
-	time.Sleep(dialRandomizerIntervalMilliseconds * time.Millisecond)
+	time.Sleep(cfg.DialJitter)
 	require.NotNil(t, sw.Peers().Get(rp.ID()))
 }
 
@@ -839,3 +1148,51 @@ func BenchmarkSwitchBroadcast(b *testing.B) {
 
 	b.Logf("success: %v, failure: %v", numSuccess, numFailure)
 }
+
+func TestSwitchRefuseNewPeers(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// an existing peer is kept
+	peer := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	peer.Start()
+	t.Cleanup(peer.Stop)
+	c, err := peer.Dial(sw.NetAddress())
+	require.NoError(t, err)
+	go func() {
+		one := make([]byte, 1)
+		for {
+			if _, err := c.Read(one); err != nil {
+				return
+			}
+		}
+	}()
+	waitUntilSwitchHasAtLeastNPeers(sw, 1)
+	sw.SetRefuseNewPeers(true)
+	assert.True(t, sw.RefusesNewPeers())
+
+	// the new inbound connections are closed
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	t.Cleanup(rp.Stop)
+	conn, err := rp.Dial(sw.NetAddress())
+	require.NoError(t, err)
+	one := make([]byte, 1)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+	_, err = conn.Read(one)
+	assert.Equal(t, io.EOF, err)
+
+	// the peers aren't dialed
+	err = sw.DialPeerWithAddress(rp.Addr())
+	assert.IsType(t, ErrSwitchRefusingNewPeers{}, err)
+	assert.Equal(t, 1, sw.Peers().Size())
+
+	sw.SetRefuseNewPeers(false)
+	require.NoError(t, sw.DialPeerWithAddress(rp.Addr()))
+	assert.Equal(t, 2, sw.Peers().Size())
+}
@@ -151,6 +151,8 @@ func (sw *Switch) addPeerWithConnection(conn net.Conn) error {
 		sw.reactorsByCh,
 		sw.chDescs,
 		sw.StopPeerForError,
+		PeerChaos(sw.chaos),
+		PeerMessageAuth(sw.msgAuth),
 	)
 
 	if err = sw.addPeer(p); err != nil {
@@ -306,3 +308,5 @@ func (book *AddrBookMock) AddPrivateIDs(addrs []string) {
 		book.PrivateAddrs[addr] = struct{}{}
 	}
 }
+func (book *AddrBookMock) PeerStats(ID) (PeerStats, bool)       { return PeerStats{}, false }
+func (book *AddrBookMock) UpdatePeerStats(ID, func(*PeerStats)) {}
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"time"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/protoio"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p/conn"
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
 )
@@ -50,6 +52,16 @@ type peerConfig struct {
 	isPersistent func(*NetAddress) bool
 	reactorsByCh map[byte]Reactor
 	metrics      *Metrics
+	// chaos, if not nil, injects faults into the messages sent to the peer
+	chaos *ChaosConfig
+	// msgAuth, if not nil, signs the messages exchanged with the peer
+	msgAuth *MessageAuthConfig
+	// capture, if not nil, records the envelopes exchanged with the peer
+	capture *TrafficCapture
+	// isPriority, if set, tells if the peer is a high-priority peer, using the
+	// priorityChDescs (larger send queues) instead of the chDescs
+	isPriority      func(ID) bool
+	priorityChDescs []*conn.ChannelDescriptor
 }
 
 // Transport emits and connects to Peers. The implementation of Peer is left to
@@ -130,6 +142,54 @@ func MultiplexTransportMaxIncomingConnections(n int) MultiplexTransportOption {
 	return func(mt *MultiplexTransport) { mt.maxIncomingConnections = n }
 }
 
+// MultiplexTransportDialTimeout sets the timeout to establish the outgoing
+// connections. Default: 1s
+func MultiplexTransportDialTimeout(timeout time.Duration) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.dialTimeout = timeout }
+}
+
+// MultiplexTransportHandshakeTimeout sets the timeout of each step of the
+// handshake of the connections (the secret connection key exchange, then the
+// NodeInfo exchange). Default: 3s
+func MultiplexTransportHandshakeTimeout(timeout time.Duration) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.handshakeTimeout = timeout }
+}
+
+// MultiplexTransportMaxConcurrentHandshakes sets the maximum number of
+// incoming connections being filtered and upgraded at the same time. Further
+// connections are closed right away. Default: 0 (unlimited)
+func MultiplexTransportMaxConcurrentHandshakes(n int) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) {
+		mt.handshakeSlots = nil
+		if n > 0 {
+			mt.handshakeSlots = make(chan struct{}, n)
+		}
+	}
+}
+
+// MultiplexTransportMaxAcceptRatePerIP sets the maximum number of incoming
+// connections accepted from a given IP per second. Further connections are
+// closed right away. Default: 0 (unlimited)
+func MultiplexTransportMaxAcceptRatePerIP(n int) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.acceptLimiter = newAcceptRateLimiter(n) }
+}
+
+// MultiplexTransportMetrics sets the metrics of the incoming connections.
+// Default: NopMetrics()
+func MultiplexTransportMetrics(metrics *Metrics) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.metrics = metrics }
+}
+
+// MultiplexTransportSecretConnRekey makes the secret connections rotate their
+// send keys after the given number of bytes or interval, see
+// conn.SecretConnection.EnableRekeying. Default: 0 (disabled)
+func MultiplexTransportSecretConnRekey(bytes int64, interval time.Duration) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) {
+		mt.rekeyBytes = bytes
+		mt.rekeyInterval = interval
+	}
+}
+
 // MultiplexTransport accepts and dials tcp connections and upgrades them to
 // multiplexed peers.
 type MultiplexTransport struct {
@@ -150,6 +210,15 @@ type MultiplexTransport struct {
 	nodeInfo         NodeInfo
 	nodeKey          NodeKey
 	resolver         IPResolver
+	rekeyBytes       int64
+	rekeyInterval    time.Duration
+
+	handshakeSlots chan struct{}      // nil if unlimited, see MaxConcurrentHandshakes
+	acceptLimiter  *acceptRateLimiter // nil if unlimited, see MaxAcceptRatePerIP
+	metrics        *Metrics
+
+	tlsCert *tls.Certificate // nil if TLS is disabled, see MultiplexTransportTLS
+	tlsPins map[string]ID    // certificate fingerprint -> node ID
 
 	// TODO(xla): This config is still needed as we parameterise peerConn and
 	// peer currently. All relevant configuration should be refactored into options
@@ -178,9 +247,16 @@ func NewMultiplexTransport(
 		nodeKey:          nodeKey,
 		conns:            NewConnSet(),
 		resolver:         net.DefaultResolver,
+		metrics:          NopMetrics(),
 	}
 }
 
+// SetNodeInfo replaces the NodeInfo sent to the peers in the handshake.
+// NOTE: Not goroutine safe, it must be called before Listen.
+func (mt *MultiplexTransport) SetNodeInfo(nodeInfo NodeInfo) {
+	mt.nodeInfo = nodeInfo
+}
+
 // NetAddress implements Transport.
 func (mt *MultiplexTransport) NetAddress() NetAddress {
 	return mt.netAddr
@@ -279,6 +355,19 @@ func (mt *MultiplexTransport) acceptPeers() {
 			return
 		}
 
+		// Drop the floods of connections right away, before spending any
+		// resources on them.
+		if !mt.acceptLimiter.allow(c.RemoteAddr(), time.Now()) {
+			mt.metrics.RejectedConnections.With("reason", "max_accept_rate_per_ip").Add(1)
+			_ = c.Close()
+			continue
+		}
+		if !mt.acquireHandshakeSlot() {
+			mt.metrics.RejectedConnections.With("reason", "max_concurrent_handshakes").Add(1)
+			_ = c.Close()
+			continue
+		}
+
 		// Connection upgrade and filtering should be asynchronous to avoid
 		// Head-of-line blocking[0].
 		// Reference:  https://github.com/tendermint/tendermint/issues/2047
@@ -308,15 +397,18 @@ func (mt *MultiplexTransport) acceptPeers() {
 				netAddr    *NetAddress
 			)
 
-			err := mt.filterConn(c)
-			if err == nil {
-				secretConn, nodeInfo, err = mt.upgrade(c, nil)
+			err := mt.handshakeIncoming(c, func() error {
+				err := mt.filterConn(c)
 				if err == nil {
-					addr := c.RemoteAddr()
-					id := PubKeyToID(secretConn.RemotePubKey())
-					netAddr = NewNetAddress(id, addr)
+					secretConn, nodeInfo, err = mt.upgrade(c, nil)
+					if err == nil {
+						addr := c.RemoteAddr()
+						id := PubKeyToID(secretConn.RemotePubKey())
+						netAddr = NewNetAddress(id, addr)
+					}
 				}
-			}
+				return err
+			})
 
 			select {
 			case mt.acceptc <- accept{netAddr, secretConn, nodeInfo, err}:
@@ -330,6 +422,34 @@ func (mt *MultiplexTransport) acceptPeers() {
 	}
 }
 
+// acquireHandshakeSlot returns false if the maximum number of concurrent
+// handshakes is reached. Otherwise, the slot must be released once the
+// handshake is over, see handshakeIncoming.
+func (mt *MultiplexTransport) acquireHandshakeSlot() bool {
+	if mt.handshakeSlots == nil {
+		return true
+	}
+	select {
+	case mt.handshakeSlots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// handshakeIncoming runs the handshake of the incoming connection c, which
+// holds a handshake slot, then releases the slot.
+func (mt *MultiplexTransport) handshakeIncoming(c net.Conn, handshakeFn func() error) error {
+	mt.metrics.HandshakesInProgress.Add(1)
+	defer func() {
+		mt.metrics.HandshakesInProgress.Add(-1)
+		if mt.handshakeSlots != nil {
+			<-mt.handshakeSlots
+		}
+	}()
+	return handshakeFn()
+}
+
 // Cleanup removes the given address from the connections set and
 // closes the connection.
 func (mt *MultiplexTransport) Cleanup(p Peer) {
@@ -396,7 +516,23 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}()
 
-	secretConn, err = upgradeSecretConn(c, mt.handshakeTimeout, mt.nodeKey.PrivKey)
+	// the secret connection runs over TLS if enabled
+	var (
+		transportConn = c
+		pinnedID      ID
+	)
+	if mt.tlsCert != nil {
+		transportConn, pinnedID, err = mt.upgradeTLS(c, dialedAddr != nil)
+		if err != nil {
+			return nil, nil, ErrRejected{
+				conn:          c,
+				err:           fmt.Errorf("tls handshake failed: %v", err),
+				isAuthFailure: true,
+			}
+		}
+	}
+
+	secretConn, err = upgradeSecretConn(transportConn, mt.handshakeTimeout, mt.nodeKey.PrivKey)
 	if err != nil {
 		return nil, nil, ErrRejected{
 			conn:          c,
@@ -404,9 +540,20 @@ func (mt *MultiplexTransport) upgrade(
 			isAuthFailure: true,
 		}
 	}
+	if mt.rekeyBytes > 0 || mt.rekeyInterval > 0 {
+		secretConn.EnableRekeying(mt.rekeyBytes, mt.rekeyInterval)
+	}
 
 	// For outgoing conns, ensure connection key matches dialed key.
 	connID := PubKeyToID(secretConn.RemotePubKey())
+	if mt.tlsCert != nil && connID != pinnedID {
+		return nil, nil, ErrRejected{
+			conn:          c,
+			id:            connID,
+			err:           fmt.Errorf("conn.ID (%v) doesn't match the ID (%v) the certificate is pinned to", connID, pinnedID),
+			isAuthFailure: true,
+		}
+	}
 	if dialedAddr != nil {
 		if dialedID := dialedAddr.ID; connID != dialedID {
 			return nil, nil, ErrRejected{
@@ -501,14 +648,22 @@ func (mt *MultiplexTransport) wrapPeer(
 		socketAddr,
 	)
 
+	chDescs := cfg.chDescs
+	if cfg.isPriority != nil && cfg.isPriority(ni.ID()) {
+		chDescs = cfg.priorityChDescs
+	}
+
 	p := newPeer(
 		peerConn,
 		mt.mConfig,
 		ni,
 		cfg.reactorsByCh,
-		cfg.chDescs,
+		chDescs,
 		cfg.onPeerError,
 		PeerMetrics(cfg.metrics),
+		PeerChaos(cfg.chaos),
+		PeerMessageAuth(cfg.msgAuth),
+		PeerTrafficCapture(cfg.capture),
 	)
 
 	return p
@@ -592,3 +747,49 @@ func resolveIPs(resolver IPResolver, c net.Conn) ([]net.IP, error) {
 
 	return ips, nil
 }
+
+// acceptRateLimiter limits the number of connections accepted from each IP per
+// second, using fixed one second windows.
+type acceptRateLimiter struct {
+	limit int
+
+	mtx         tmsync.Mutex
+	windowStart time.Time
+	counts      map[string]int // IP -> connections accepted in the window
+}
+
+// newAcceptRateLimiter returns a limiter allowing limit connections per IP per
+// second, or nil if limit is 0 (unlimited).
+func newAcceptRateLimiter(limit int) *acceptRateLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return &acceptRateLimiter{limit: limit, counts: make(map[string]int)}
+}
+
+// allow returns true if one more connection can be accepted from the given
+// remote address at the given time.
+func (l *acceptRateLimiter) allow(addr net.Addr, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+
+	ip := addr.String()
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+		ip = tcpAddr.IP.String()
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if now.Sub(l.windowStart) >= time.Second {
+		l.windowStart = now
+		// drop all the IPs of the previous window, so the map doesn't grow
+		l.counts = make(map[string]int, len(l.counts))
+	}
+	if l.counts[ip] >= l.limit {
+		return false
+	}
+	l.counts[ip]++
+	return true
+}
@@ -10,6 +10,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/protoio"
 	"github.com/tendermint/tendermint/p2p/conn"
@@ -186,6 +189,69 @@ func TestTransportMultiplexMaxIncomingConnections(t *testing.T) {
 	}
 }
 
+func TestTransportMultiplexMaxConcurrentHandshakes(t *testing.T) {
+	pv := ed25519.GenPrivKey()
+	id := PubKeyToID(pv.PubKey())
+	mt := newMultiplexTransport(testNodeInfo(id, "transport"), NodeKey{PrivKey: pv})
+	MultiplexTransportMaxConcurrentHandshakes(1)(mt)
+
+	addr, err := NewNetAddressString(IDAddressString(id, "127.0.0.1:0"))
+	require.NoError(t, err)
+	require.NoError(t, mt.Listen(*addr))
+	t.Cleanup(func() { _ = mt.Close() })
+	laddr := NewNetAddress(mt.nodeKey.ID(), mt.listener.Addr())
+
+	// handshaking returns true if the transport started the handshake of the
+	// connection, i.e. sent its ephemeral key, false if it closed it.
+	handshaking := func(c net.Conn) bool {
+		require.NoError(t, c.SetReadDeadline(time.Now().Add(time.Second)))
+		_, err := c.Read(make([]byte, 1))
+		return err == nil
+	}
+
+	// the slow peer holds the only handshake slot
+	slow, err := laddr.Dial()
+	require.NoError(t, err)
+	require.True(t, handshaking(slow))
+
+	c, err := laddr.Dial()
+	require.NoError(t, err)
+	assert.False(t, handshaking(c))
+
+	// the slot is released once the handshake failed
+	require.NoError(t, slow.Close())
+	require.Eventually(t, func() bool {
+		c, err := laddr.Dial()
+		require.NoError(t, err)
+		defer c.Close()
+		return handshaking(c)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestAcceptRateLimiter(t *testing.T) {
+	l := newAcceptRateLimiter(2)
+	var (
+		now = time.Now()
+		a   = &net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 1}
+		a2  = &net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 2}
+		b   = &net.TCPAddr{IP: net.IPv4(5, 6, 7, 8), Port: 1}
+	)
+
+	assert.True(t, l.allow(a, now))
+	assert.True(t, l.allow(a2, now.Add(100*time.Millisecond)))
+	// the limit is per IP, regardless of the port
+	assert.False(t, l.allow(a, now.Add(200*time.Millisecond)))
+	assert.True(t, l.allow(b, now.Add(200*time.Millisecond)))
+
+	// a new window starts
+	assert.True(t, l.allow(a, now.Add(time.Second)))
+
+	// nil means unlimited
+	assert.Nil(t, newAcceptRateLimiter(0))
+	var nilLimiter *acceptRateLimiter
+	assert.True(t, nilLimiter.allow(a, now))
+}
+
 func TestTransportMultiplexAcceptMultiple(t *testing.T) {
 	mt := testSetupMultiplexTransport(t)
 	laddr := NewNetAddress(mt.nodeKey.ID(), mt.listener.Addr())
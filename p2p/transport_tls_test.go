@@ -0,0 +1,93 @@
+package p2p
+
+import (
+	"crypto/tls"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+type testTLSNode struct {
+	nodeKey     NodeKey
+	cert        tls.Certificate
+	fingerprint string
+}
+
+func newTestTLSNode(t *testing.T) testTLSNode {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	fingerprint, err := GenTLSCertificate(certFile, keyFile)
+	require.NoError(t, err)
+	cert, loadedFingerprint, err := LoadTLSCertificate(certFile, keyFile)
+	require.NoError(t, err)
+	require.Equal(t, fingerprint, loadedFingerprint)
+	return testTLSNode{nodeKey: NodeKey{PrivKey: ed25519.GenPrivKey()}, cert: cert, fingerprint: fingerprint}
+}
+
+// transport returns the transport of the node, with TLS enabled if pins isn't
+// nil.
+func (n testTLSNode) transport(t *testing.T, pins map[string]ID) *MultiplexTransport {
+	mt := newMultiplexTransport(testNodeInfo(n.nodeKey.ID(), "tls"), n.nodeKey)
+	if pins != nil {
+		MultiplexTransportTLS(n.cert, pins)(mt)
+	}
+	return mt
+}
+
+func TestTransportMultiplexTLS(t *testing.T) {
+	var (
+		listener = newTestTLSNode(t)
+		dialer   = newTestTLSNode(t)
+		unpinned = newTestTLSNode(t)
+	)
+	mt := listener.transport(t, map[string]ID{
+		dialer.fingerprint: dialer.nodeKey.ID(),
+		// its certificate is pinned to the ID of another node
+		unpinned.fingerprint: dialer.nodeKey.ID(),
+	})
+	addr, err := NewNetAddressString(IDAddressString(listener.nodeKey.ID(), "127.0.0.1:0"))
+	require.NoError(t, err)
+	require.NoError(t, mt.Listen(*addr))
+	t.Cleanup(func() { _ = mt.Close() })
+	laddr := NewNetAddress(listener.nodeKey.ID(), mt.listener.Addr())
+
+	accepted := make(chan error, 1)
+	go func() {
+		for {
+			p, err := mt.Accept(peerConfig{})
+			if _, ok := err.(ErrTransportClosed); ok {
+				return
+			}
+			if err == nil {
+				_ = p.CloseConn()
+			}
+			accepted <- err
+		}
+	}()
+
+	pins := map[string]ID{listener.fingerprint: listener.nodeKey.ID()}
+	p, err := dialer.transport(t, pins).Dial(*laddr, peerConfig{})
+	require.NoError(t, err)
+	_ = p.CloseConn()
+	require.NoError(t, <-accepted)
+
+	testCases := map[string]*MultiplexTransport{
+		"pinned to another ID": unpinned.transport(t, pins),
+		"the listener isn't pinned": dialer.transport(t, map[string]ID{
+			unpinned.fingerprint: listener.nodeKey.ID(),
+		}),
+		"TLS disabled": dialer.transport(t, nil),
+	}
+	for name, transport := range testCases {
+		_, err := transport.Dial(*laddr, peerConfig{})
+		assert.Error(t, err, name)
+		err = <-accepted
+		if assert.IsType(t, ErrRejected{}, err, name) {
+			assert.True(t, err.(ErrRejected).IsAuthFailure(), name)
+		}
+	}
+}
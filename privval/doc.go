@@ -25,5 +25,11 @@ SignerClient handles remote validator connections that provide signing services.
 In production, it's recommended to wrap it with RetrySignerClient to avoid
 termination in case of temporary errors.
 
+SignerClient implements types.BatchSigner: the proposal of the node and its
+prevote are signed together, pipelining their requests without waiting for
+the first response. The remote signers must therefore reply to the requests in
+the order they are received on a connection. The prevote is signed again when
+cast, which the signer answers from its last sign state without signing.
+
 */
 package privval
@@ -11,6 +11,7 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/keyutil"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -51,6 +52,19 @@ type FilePVKey struct {
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
 	filePath string
+	// holds the private key locked in memory, if loaded or generated by the
+	// FilePV
+	privKeyBuf *keyutil.Buffer
+}
+
+// lockPrivKey moves the private key into a keyutil.Buffer, keeping it as is if
+// its type isn't supported.
+func (pvKey *FilePVKey) lockPrivKey() {
+	privKey, buf, err := keyutil.LockPrivKey(pvKey.PrivKey)
+	if err != nil {
+		return
+	}
+	pvKey.PrivKey, pvKey.privKeyBuf = privKey, buf
 }
 
 // Save persists the FilePVKey to its filePath.
@@ -65,6 +79,7 @@ func (pvKey FilePVKey) Save() {
 		panic(err)
 	}
 	err = tempfile.WriteFileAtomic(outFile, jsonBytes, 0600)
+	keyutil.Zero(jsonBytes)
 	if err != nil {
 		panic(err)
 	}
@@ -172,14 +187,17 @@ func NewFilePV(privKey crypto.PrivKey, keyFilePath, stateFilePath string) *FileP
 // GenFilePV generates a new validator with randomly generated private key
 // and sets the filePaths, but does not call Save().
 func GenFilePV(keyFilePath, stateFilePath, keyType string) (*FilePV, error) {
+	var pv *FilePV
 	switch keyType {
 	case types.ABCIPubKeyTypeSecp256k1:
-		return NewFilePV(secp256k1.GenPrivKey(), keyFilePath, stateFilePath), nil
+		pv = NewFilePV(secp256k1.GenPrivKey(), keyFilePath, stateFilePath)
 	case "", types.ABCIPubKeyTypeEd25519:
-		return NewFilePV(ed25519.GenPrivKey(), keyFilePath, stateFilePath), nil
+		pv = NewFilePV(ed25519.GenPrivKey(), keyFilePath, stateFilePath)
 	default:
 		return nil, fmt.Errorf("key type: %s is not supported", keyType)
 	}
+	pv.Key.lockPrivKey()
+	return pv, nil
 }
 
 // LoadFilePV loads a FilePV from the filePaths.  The FilePV handles double
@@ -203,9 +221,11 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 	}
 	pvKey := FilePVKey{}
 	err = tmjson.Unmarshal(keyJSONBytes, &pvKey)
+	keyutil.Zero(keyJSONBytes)
 	if err != nil {
 		tmos.Exit(fmt.Sprintf("Error reading PrivValidator key from %v: %v\n", keyFilePath, err))
 	}
+	pvKey.lockPrivKey()
 
 	// overwrite pubkey and address for convenience
 	pvKey.PubKey = pvKey.PrivKey.PubKey()
@@ -297,6 +317,15 @@ func (pv *FilePV) Reset() {
 	pv.Save()
 }
 
+// Destroy zeroes the private key, if it was loaded or generated by the FilePV,
+// and unlocks its memory. The FilePV mustn't be used afterwards.
+func (pv *FilePV) Destroy() error {
+	if pv.Key.privKeyBuf == nil {
+		return nil
+	}
+	return pv.Key.privKeyBuf.Destroy()
+}
+
 // String returns a string representation of the FilePV.
 func (pv *FilePV) String() string {
 	return fmt.Sprintf(
@@ -41,6 +41,27 @@ func TestGenLoadValidator(t *testing.T) {
 	assert.Equal(height, privVal.LastSignState.Height, "expected privval.LastHeight to have been saved")
 }
 
+func TestFilePVDestroy(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.Nil(t, err)
+
+	privVal, err := GenFilePV(tempKeyFile.Name(), tempStateFile.Name(), "")
+	require.NoError(t, err)
+	privVal.Save()
+
+	privVal = LoadFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	require.NotNil(t, privVal.Key.privKeyBuf)
+	vote := newVote(privVal.Key.Address, 0, 10, 1, tmproto.PrevoteType, types.BlockID{})
+	require.NoError(t, privVal.SignVote("mychainid", vote.ToProto()))
+	privKey := privVal.Key.PrivKey.(ed25519.PrivKey)
+
+	require.NoError(t, privVal.Destroy())
+	assert.Equal(t, make([]byte, len(privKey)), []byte(privKey))
+	assert.NoError(t, privVal.Destroy())
+}
+
 func TestResetValidator(t *testing.T) {
 	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
 	require.Nil(t, err)
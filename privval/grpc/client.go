@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	grpc "google.golang.org/grpc"
+
+	"github.com/tendermint/tendermint/crypto"
+	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/libs/log"
+	tmprivval "github.com/tendermint/tendermint/privval"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
+	privvalv1 "github.com/tendermint/tendermint/proto/tendermint/privval/v1"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// DefaultRequestTimeout is the default timeout of a request to the remote
+// signer, retries included.
+const DefaultRequestTimeout = 5 * time.Second
+
+// SignerClient implements PrivValidator over the PrivValidatorService gRPC
+// service of a remote signer.
+type SignerClient struct {
+	logger log.Logger
+
+	conn    *grpc.ClientConn
+	client  privvalv1.PrivValidatorServiceClient
+	chainID string
+	timeout time.Duration
+}
+
+var _ types.PrivValidator = (*SignerClient)(nil)
+
+// NewSignerClient returns a SignerClient sending its requests on conn, which
+// the client owns.
+func NewSignerClient(conn *grpc.ClientConn, chainID string, logger log.Logger) *SignerClient {
+	return &SignerClient{
+		logger:  logger,
+		conn:    conn,
+		client:  privvalv1.NewPrivValidatorServiceClient(conn),
+		chainID: chainID,
+		timeout: DefaultRequestTimeout,
+	}
+}
+
+// SetRequestTimeout sets the timeout of the requests.
+func (sc *SignerClient) SetRequestTimeout(timeout time.Duration) {
+	sc.timeout = timeout
+}
+
+// Close closes the underlying connection.
+func (sc *SignerClient) Close() error {
+	return sc.conn.Close()
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+// GetPubKey retrieves a public key from a remote signer.
+func (sc *SignerClient) GetPubKey() (crypto.PubKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.GetPubKey(ctx, &privvalproto.PubKeyRequest{ChainId: sc.chainID})
+	if err != nil {
+		sc.logger.Error("SignerClient::GetPubKey", "err", err)
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, remoteSignerError(resp.Error)
+	}
+
+	return cryptoenc.PubKeyFromProto(resp.PubKey)
+}
+
+// SignVote requests a remote signer to sign a vote.
+func (sc *SignerClient) SignVote(chainID string, vote *tmproto.Vote) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.SignVote(ctx, &privvalproto.SignVoteRequest{Vote: vote, ChainId: chainID})
+	if err != nil {
+		sc.logger.Error("SignerClient::SignVote", "err", err)
+		return err
+	}
+	if resp.Error != nil {
+		return remoteSignerError(resp.Error)
+	}
+
+	*vote = resp.Vote
+	return nil
+}
+
+// SignProposal requests a remote signer to sign a proposal.
+func (sc *SignerClient) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.SignProposal(ctx, &privvalproto.SignProposalRequest{Proposal: proposal, ChainId: chainID})
+	if err != nil {
+		sc.logger.Error("SignerClient::SignProposal", "err", err)
+		return err
+	}
+	if resp.Error != nil {
+		return remoteSignerError(resp.Error)
+	}
+
+	*proposal = resp.Proposal
+	return nil
+}
+
+func remoteSignerError(err *privvalproto.RemoteSignerError) error {
+	return &tmprivval.RemoteSignerError{Code: int(err.Code), Description: err.Description}
+}
@@ -0,0 +1,21 @@
+/*
+Package grpc implements the PrivValidatorService gRPC service, an alternative to
+the socket protocol of the remote signers which doesn't require implementing
+the secret connection: the node dials the signer's gRPC server when
+priv_validator_laddr is a grpc:// address.
+
+SignerServer exposes a types.PrivValidator as a PrivValidatorServiceServer, with
+the same validation of the requests as the socket protocol. Signers written in
+other languages implement the tendermint.privval.v1.PrivValidatorService service
+instead.
+
+The connections use mutual TLS: ServerTLSConfig requires a client certificate
+signed by the given CA, and ClientTLSConfig verifies the signer's certificate.
+SignerServer refuses the requests of the unauthenticated clients unless
+SetAllowUnauthenticated is used, e.g. for a signer on a local socket.
+
+SignerClient is the types.PrivValidator used by the node. DialRemoteSigner dials
+the server with DefaultDialOptions, which retry the requests while the signer
+is unavailable (see RetryUnaryInterceptor).
+*/
+package grpc
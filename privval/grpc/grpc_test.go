@@ -0,0 +1,223 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmprivval "github.com/tendermint/tendermint/privval"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
+	privvalv1 "github.com/tendermint/tendermint/proto/tendermint/privval/v1"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+const chainID = "test-chain"
+
+// testCerts are the PEM files of a CA and of the server and client
+// certificates it signed.
+type testCerts struct {
+	ca, serverCert, serverKey, clientCert, clientKey string
+}
+
+func genTestCerts(t *testing.T) testCerts {
+	dir := t.TempDir()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	writeCert := func(name string, template *x509.Certificate, key *ecdsa.PrivateKey) (string, string) {
+		derCert, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		derKey, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		certFile, keyFile := filepath.Join(dir, name+"_cert.pem"), filepath.Join(dir, name+"_key.pem")
+		require.NoError(t, ioutil.WriteFile(certFile,
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derCert}), 0600))
+		require.NoError(t, ioutil.WriteFile(keyFile,
+			pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: derKey}), 0600))
+		return certFile, keyFile
+	}
+	leaf := func(serial int64, name string, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		return writeCert(name, &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     []string{"bufnet"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}, key)
+	}
+
+	var certs testCerts
+	certs.ca, _ = writeCert("ca", ca, caKey)
+	certs.serverCert, certs.serverKey = leaf(2, "server", x509.ExtKeyUsageServerAuth)
+	certs.clientCert, certs.clientKey = leaf(3, "client", x509.ExtKeyUsageClientAuth)
+	return certs
+}
+
+func newTestServer(t *testing.T, srv *grpc.Server, ss *SignerServer) *bufconn.Listener {
+	ln := bufconn.Listen(1 << 20)
+	privvalv1.RegisterPrivValidatorServiceServer(srv, ss)
+	go srv.Serve(ln) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+	return ln
+}
+
+func dialTestServer(t *testing.T, ln *bufconn.Listener, creds grpc.DialOption) *SignerClient {
+	sc, err := DialRemoteSigner(AddrPrefix+"bufnet", chainID, log.TestingLogger(), creds,
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return ln.Dial() }))
+	require.NoError(t, err)
+	t.Cleanup(func() { sc.Close() })
+	return sc
+}
+
+func newTestClient(t *testing.T, privVal types.PrivValidator) *SignerClient {
+	certs := genTestCerts(t)
+	serverTLS, err := ServerTLSConfig(certs.serverCert, certs.serverKey, certs.ca)
+	require.NoError(t, err)
+	clientTLS, err := ClientTLSConfig(certs.ca, certs.clientCert, certs.clientKey)
+	require.NoError(t, err)
+
+	ln := newTestServer(t, grpc.NewServer(grpc.Creds(credentials.NewTLS(serverTLS))),
+		NewSignerServer(chainID, privVal, log.TestingLogger()))
+	return dialTestServer(t, ln, grpc.WithTransportCredentials(credentials.NewTLS(clientTLS)))
+}
+
+func TestSignerClient(t *testing.T) {
+	mockPV := types.NewMockPV()
+	sc := newTestClient(t, mockPV)
+
+	pubKey, err := sc.GetPubKey()
+	require.NoError(t, err)
+	expected, err := mockPV.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, expected, pubKey)
+
+	hash := tmrand.Bytes(tmhash.Size)
+	blockID := tmproto.BlockID{Hash: hash, PartSetHeader: tmproto.PartSetHeader{Hash: hash, Total: 2}}
+	ts := time.Now().UTC()
+	have := &tmproto.Vote{Type: tmproto.PrecommitType, Height: 1, Round: 2, BlockID: blockID, Timestamp: ts}
+	want := &tmproto.Vote{Type: tmproto.PrecommitType, Height: 1, Round: 2, BlockID: blockID, Timestamp: ts}
+	require.NoError(t, sc.SignVote(chainID, have))
+	require.NoError(t, mockPV.SignVote(chainID, want))
+	assert.Equal(t, want.Signature, have.Signature)
+
+	haveProposal := &tmproto.Proposal{Type: tmproto.ProposalType, Height: 1, Round: 2, PolRound: -1,
+		BlockID: blockID, Timestamp: ts}
+	wantProposal := &tmproto.Proposal{Type: tmproto.ProposalType, Height: 1, Round: 2, PolRound: -1,
+		BlockID: blockID, Timestamp: ts}
+	require.NoError(t, sc.SignProposal(chainID, haveProposal))
+	require.NoError(t, mockPV.SignProposal(chainID, wantProposal))
+	assert.Equal(t, wantProposal.Signature, haveProposal.Signature)
+
+	// the requests for another chain are refused by the signer
+	err = sc.SignVote("other-chain", have)
+	var remoteErr *tmprivval.RemoteSignerError
+	assert.True(t, errors.As(err, &remoteErr))
+}
+
+func TestSignerClientRemoteError(t *testing.T) {
+	sc := newTestClient(t, types.NewErroringMockPV())
+
+	vote := &tmproto.Vote{Type: tmproto.PrecommitType, Height: 1}
+	err := sc.SignVote(chainID, vote)
+	var remoteErr *tmprivval.RemoteSignerError
+	require.True(t, errors.As(err, &remoteErr))
+	assert.Contains(t, remoteErr.Description, types.ErroringMockPVErr.Error())
+}
+
+func TestSignerServerAuthentication(t *testing.T) {
+	ss := NewSignerServer(chainID, types.NewMockPV(), log.TestingLogger())
+	sc := dialTestServer(t, newTestServer(t, grpc.NewServer(), ss), grpc.WithInsecure())
+
+	_, err := sc.GetPubKey()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ss.SetAllowUnauthenticated(true)
+	_, err = sc.GetPubKey()
+	assert.NoError(t, err)
+
+	// the server refuses the clients without a certificate signed by the CA
+	certs := genTestCerts(t)
+	serverTLS, err := ServerTLSConfig(certs.serverCert, certs.serverKey, certs.ca)
+	require.NoError(t, err)
+	clientTLS, err := ClientTLSConfig(certs.ca, "", "")
+	require.NoError(t, err)
+	ln := newTestServer(t, grpc.NewServer(grpc.Creds(credentials.NewTLS(serverTLS))),
+		NewSignerServer(chainID, types.NewMockPV(), log.TestingLogger()))
+	sc = dialTestServer(t, ln, grpc.WithTransportCredentials(credentials.NewTLS(clientTLS)))
+	sc.SetRequestTimeout(time.Second)
+	_, err = sc.GetPubKey()
+	assert.Error(t, err)
+}
+
+func TestSignerServerCanceledRequest(t *testing.T) {
+	ss := NewSignerServer(chainID, types.NewMockPV(), log.TestingLogger())
+	ss.SetAllowUnauthenticated(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ss.SignVote(ctx, &privvalproto.SignVoteRequest{
+		Vote: &tmproto.Vote{Type: tmproto.PrecommitType, Height: 1}, ChainId: chainID})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestRetryUnaryInterceptor(t *testing.T) {
+	interceptor := RetryUnaryInterceptor(3, time.Millisecond)
+	invoke := func(codes ...codes.Code) (int, error) {
+		attempts := 0
+		err := interceptor(context.Background(), "/method", nil, nil, nil,
+			func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+				code := codes[attempts]
+				attempts++
+				return status.Error(code, "error")
+			})
+		return attempts, err
+	}
+
+	attempts, err := invoke(codes.Unavailable, codes.OK)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+
+	attempts, err = invoke(codes.Unavailable, codes.Unavailable, codes.Unavailable)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, attempts)
+
+	// the other errors aren't retried
+	attempts, err = invoke(codes.PermissionDenied)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, 1, attempts)
+}
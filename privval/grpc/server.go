@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/tendermint/tendermint/libs/log"
+	tmprivval "github.com/tendermint/tendermint/privval"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
+	privvalv1 "github.com/tendermint/tendermint/proto/tendermint/privval/v1"
+	"github.com/tendermint/tendermint/types"
+)
+
+// SignerServer implements PrivValidatorServiceServer by handling the requests
+// like the socket protocol's SignerServer: a refused request, e.g. for another
+// chain, gets a response with a RemoteSignerError rather than a gRPC error.
+//
+// The requests must come from a client authenticated with a verified TLS
+// certificate (see ServerTLSConfig), unless SetAllowUnauthenticated is used.
+type SignerServer struct {
+	logger log.Logger
+
+	chainID              string
+	privVal              types.PrivValidator
+	handler              tmprivval.ValidationRequestHandlerFunc
+	allowUnauthenticated bool
+}
+
+var _ privvalv1.PrivValidatorServiceServer = (*SignerServer)(nil)
+
+// NewSignerServer returns a SignerServer signing with privVal for chainID.
+func NewSignerServer(chainID string, privVal types.PrivValidator, logger log.Logger) *SignerServer {
+	return &SignerServer{
+		logger:  logger,
+		chainID: chainID,
+		privVal: privVal,
+		handler: tmprivval.DefaultValidationRequestHandler,
+	}
+}
+
+// SetRequestHandler overrides the default function used to handle the
+// requests.
+func (ss *SignerServer) SetRequestHandler(handler tmprivval.ValidationRequestHandlerFunc) {
+	ss.handler = handler
+}
+
+// SetAllowUnauthenticated allows the requests of the clients without a
+// verified TLS certificate, e.g. when the server listens on a local socket.
+func (ss *SignerServer) SetAllowUnauthenticated(allow bool) {
+	ss.allowUnauthenticated = allow
+}
+
+// GetPubKey implements PrivValidatorServiceServer.
+func (ss *SignerServer) GetPubKey(
+	ctx context.Context, req *privvalproto.PubKeyRequest) (*privvalproto.PubKeyResponse, error) {
+	res, err := ss.handle(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp := res.GetPubKeyResponse(); resp != nil {
+		return resp, nil
+	}
+	return nil, status.Errorf(codes.Internal, "unexpected response %T", res.Sum)
+}
+
+// SignVote implements PrivValidatorServiceServer.
+func (ss *SignerServer) SignVote(
+	ctx context.Context, req *privvalproto.SignVoteRequest) (*privvalproto.SignedVoteResponse, error) {
+	res, err := ss.handle(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp := res.GetSignedVoteResponse(); resp != nil {
+		return resp, nil
+	}
+	return nil, status.Errorf(codes.Internal, "unexpected response %T", res.Sum)
+}
+
+// SignProposal implements PrivValidatorServiceServer.
+func (ss *SignerServer) SignProposal(
+	ctx context.Context, req *privvalproto.SignProposalRequest) (*privvalproto.SignedProposalResponse, error) {
+	res, err := ss.handle(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp := res.GetSignedProposalResponse(); resp != nil {
+		return resp, nil
+	}
+	return nil, status.Errorf(codes.Internal, "unexpected response %T", res.Sum)
+}
+
+func (ss *SignerServer) handle(ctx context.Context, req proto.Message) (privvalproto.Message, error) {
+	// the client gave up on the request, don't sign it
+	if err := ctx.Err(); err != nil {
+		return privvalproto.Message{}, status.FromContextError(err).Err()
+	}
+	if err := ss.authenticate(ctx); err != nil {
+		return privvalproto.Message{}, err
+	}
+	msg, err := privvalproto.Wrap(req)
+	if err != nil {
+		return privvalproto.Message{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	res, err := ss.handler(ss.privVal, *msg, ss.chainID)
+	if err != nil {
+		// the response carries the RemoteSignerError
+		ss.logger.Error("SignerServer: handleMessage", "err", err)
+	}
+	return res, nil
+}
+
+func (ss *SignerServer) authenticate(ctx context.Context) error {
+	if ss.allowUnauthenticated {
+		return nil
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "unknown peer")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return status.Error(codes.Unauthenticated, "client certificate required")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// AddrPrefix is the prefix of the priv_validator_laddr addresses of the gRPC
+// remote signers, e.g. grpc://127.0.0.1:26659.
+const AddrPrefix = "grpc://"
+
+const (
+	defaultMaxAttempts = 50 // 50 * 100ms = 5s total
+	defaultBackoff     = 100 * time.Millisecond
+)
+
+// RetryUnaryInterceptor returns a client interceptor retrying the requests
+// failing with codes.Unavailable, e.g. while the signer restarts, up to
+// maxAttempts times in total and waiting backoff between the attempts. It
+// stops early once the request's context is done. The requests are safe to
+// retry: signing the same vote or proposal again returns the same signature.
+func RetryUnaryInterceptor(maxAttempts int, backoff time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.Unavailable || attempt >= maxAttempts {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff):
+			}
+		}
+	}
+}
+
+// DefaultDialOptions returns the options used by DialRemoteSigner: the
+// connections are kept alive, and the unavailable requests retried for 5s.
+// The transport credentials aren't part of the defaults and must be added.
+func DefaultDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                10 * time.Second,
+			Timeout:             5 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithUnaryInterceptor(RetryUnaryInterceptor(defaultMaxAttempts, defaultBackoff)),
+	}
+}
+
+// DialRemoteSigner dials the remote signer at addr, with or without
+// AddrPrefix, using DefaultDialOptions followed by opts, which must include
+// the transport credentials, e.g. grpc.WithTransportCredentials with a
+// ClientTLSConfig. It doesn't wait for the connection to be established.
+func DialRemoteSigner(addr, chainID string, logger log.Logger, opts ...grpc.DialOption) (*SignerClient, error) {
+	conn, err := grpc.Dial(strings.TrimPrefix(addr, AddrPrefix), append(DefaultDialOptions(), opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return NewSignerClient(conn, chainID, logger), nil
+}
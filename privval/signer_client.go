@@ -18,7 +18,10 @@ type SignerClient struct {
 	chainID  string
 }
 
-var _ types.PrivValidator = (*SignerClient)(nil)
+var (
+	_ types.PrivValidator = (*SignerClient)(nil)
+	_ types.BatchSigner   = (*SignerClient)(nil)
+)
 
 // NewSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started)
@@ -97,6 +100,50 @@ func (sc *SignerClient) SignVote(chainID string, vote *tmproto.Vote) error {
 		return err
 	}
 
+	return signedVoteFromResponse(response, vote)
+}
+
+// SignProposal requests a remote signer to sign a proposal
+func (sc *SignerClient) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(
+		&privvalproto.SignProposalRequest{Proposal: proposal, ChainId: chainID},
+	))
+	if err != nil {
+		return err
+	}
+
+	return signedProposalFromResponse(response, proposal)
+}
+
+// SignBatch implements types.BatchSigner by pipelining the sign requests of
+// the proposal and votes: they are all sent to the remote signer before
+// waiting for the first response.
+func (sc *SignerClient) SignBatch(chainID string, proposal *tmproto.Proposal, votes []*tmproto.Vote) error {
+	requests := make([]privvalproto.Message, 0, len(votes)+1)
+	if proposal != nil {
+		requests = append(requests, mustWrapMsg(&privvalproto.SignProposalRequest{Proposal: proposal, ChainId: chainID}))
+	}
+	for _, vote := range votes {
+		requests = append(requests, mustWrapMsg(&privvalproto.SignVoteRequest{Vote: vote, ChainId: chainID}))
+	}
+
+	// the responses received before an error are still used
+	responses, err := sc.endpoint.SendRequests(requests...)
+	if proposal != nil && len(responses) > 0 {
+		if perr := signedProposalFromResponse(responses[0], proposal); perr != nil {
+			return perr
+		}
+		responses = responses[1:]
+	}
+	for i, response := range responses {
+		if verr := signedVoteFromResponse(response, votes[i]); verr != nil {
+			return verr
+		}
+	}
+	return err
+}
+
+func signedVoteFromResponse(response *privvalproto.Message, vote *tmproto.Vote) error {
 	resp := response.GetSignedVoteResponse()
 	if resp == nil {
 		return ErrUnexpectedResponse
@@ -110,15 +157,7 @@ func (sc *SignerClient) SignVote(chainID string, vote *tmproto.Vote) error {
 	return nil
 }
 
-// SignProposal requests a remote signer to sign a proposal
-func (sc *SignerClient) SignProposal(chainID string, proposal *tmproto.Proposal) error {
-	response, err := sc.endpoint.SendRequest(mustWrapMsg(
-		&privvalproto.SignProposalRequest{Proposal: proposal, ChainId: chainID},
-	))
-	if err != nil {
-		return err
-	}
-
+func signedProposalFromResponse(response *privvalproto.Message, proposal *tmproto.Proposal) error {
 	resp := response.GetSignedProposalResponse()
 	if resp == nil {
 		return ErrUnexpectedResponse
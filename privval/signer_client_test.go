@@ -305,6 +305,67 @@ func TestSignerVoteKeepAlive(t *testing.T) {
 	}
 }
 
+func TestSignerBatch(t *testing.T) {
+	for _, tc := range getSignerTestCases(t) {
+		ts := time.Now()
+		hash := tmrand.Bytes(tmhash.Size)
+		blockID := types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}}
+		proposal := &types.Proposal{
+			Type:      tmproto.ProposalType,
+			Height:    1,
+			Round:     2,
+			POLRound:  -1,
+			BlockID:   blockID,
+			Timestamp: ts,
+		}
+		valAddr := tmrand.Bytes(crypto.AddressSize)
+		votes := make([]*types.Vote, 0)
+		for _, typ := range []tmproto.SignedMsgType{tmproto.PrevoteType, tmproto.PrecommitType} {
+			votes = append(votes, &types.Vote{
+				Type:             typ,
+				Height:           1,
+				Round:            2,
+				BlockID:          blockID,
+				Timestamp:        ts,
+				ValidatorAddress: valAddr,
+				ValidatorIndex:   1,
+			})
+		}
+
+		tc := tc
+		t.Cleanup(func() {
+			if err := tc.signerServer.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := tc.signerClient.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		want := proposal.ToProto()
+		require.NoError(t, tc.mockPV.SignProposal(tc.chainID, want))
+		have := proposal.ToProto()
+		haveVotes := []*tmproto.Vote{votes[0].ToProto(), votes[1].ToProto()}
+		require.NoError(t, tc.signerClient.SignBatch(tc.chainID, have, haveVotes))
+		assert.Equal(t, want.Signature, have.Signature)
+		for i, vote := range votes {
+			wantVote := vote.ToProto()
+			require.NoError(t, tc.mockPV.SignVote(tc.chainID, wantVote))
+			assert.Equal(t, wantVote.Signature, haveVotes[i].Signature)
+		}
+
+		// the connection is still usable after the batch
+		require.NoError(t, tc.signerClient.SignBatch(tc.chainID, nil, []*tmproto.Vote{votes[0].ToProto()}))
+		_, err := tc.signerClient.GetPubKey()
+		require.NoError(t, err)
+
+		// the signer errors are returned
+		require.Error(t, tc.signerClient.SignBatch("other-chain", nil, []*tmproto.Vote{votes[0].ToProto()}))
+	}
+}
+
 func TestSignerSignProposalErrors(t *testing.T) {
 	for _, tc := range getSignerTestCases(t) {
 		// Replace service with a mock that always fails
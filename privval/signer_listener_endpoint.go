@@ -129,6 +129,43 @@ func (sl *SignerListenerEndpoint) SendRequest(request privvalproto.Message) (*pr
 	return &res, nil
 }
 
+// SendRequests ensures there is a connection, and pipelines the requests: they
+// are all sent before waiting for the responses, which the signer sends in the
+// same order. The connection is dropped on errors, since the next responses
+// couldn't be matched with their requests anymore.
+func (sl *SignerListenerEndpoint) SendRequests(requests ...privvalproto.Message) ([]*privvalproto.Message, error) {
+	sl.instanceMtx.Lock()
+	defer sl.instanceMtx.Unlock()
+
+	err := sl.ensureConnection(sl.timeoutAccept)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, request := range requests {
+		err = sl.WriteMessage(request)
+		if err != nil {
+			sl.DropConnection()
+			return nil, err
+		}
+	}
+
+	responses := make([]*privvalproto.Message, 0, len(requests))
+	for range requests {
+		res, err := sl.ReadMessage()
+		if err != nil {
+			sl.DropConnection()
+			return responses, err
+		}
+		responses = append(responses, &res)
+	}
+
+	// Reset pingTimer to avoid sending unnecessary pings.
+	sl.pingTimer.Reset(sl.pingInterval)
+
+	return responses, nil
+}
+
 func (sl *SignerListenerEndpoint) ensureConnection(maxWait time.Duration) error {
 	if sl.IsConnected() {
 		return nil
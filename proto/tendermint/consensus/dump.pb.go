@@ -0,0 +1,2276 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: tendermint/consensus/dump.proto
+
+package consensus
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	bits "github.com/tendermint/tendermint/proto/tendermint/libs/bits"
+	types1 "github.com/tendermint/tendermint/proto/tendermint/types"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// RoundStateDump is a compact dump of the consensus round state, returned by
+// the /dump_consensus_state RPC endpoint. It only has the IDs of the blocks,
+// and the votes may be filtered by validator and round.
+type RoundStateDump struct {
+	Height     int64     `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round      int32     `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Step       uint32    `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
+	StartTime  time.Time `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3,stdtime" json:"start_time"`
+	CommitTime time.Time `protobuf:"bytes,5,opt,name=commit_time,json=commitTime,proto3,stdtime" json:"commit_time"`
+	// Only the filtered validator, if any, with the proposer and total voting power.
+	Validators      *types1.ValidatorSet `protobuf:"bytes,6,opt,name=validators,proto3" json:"validators,omitempty"`
+	Proposal        *types1.Proposal     `protobuf:"bytes,7,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	ProposalBlockId types1.BlockID       `protobuf:"bytes,8,opt,name=proposal_block_id,json=proposalBlockId,proto3" json:"proposal_block_id"`
+	LockedRound     int32                `protobuf:"varint,9,opt,name=locked_round,json=lockedRound,proto3" json:"locked_round,omitempty"`
+	LockedBlockId   types1.BlockID       `protobuf:"bytes,10,opt,name=locked_block_id,json=lockedBlockId,proto3" json:"locked_block_id"`
+	ValidRound      int32                `protobuf:"varint,11,opt,name=valid_round,json=validRound,proto3" json:"valid_round,omitempty"`
+	ValidBlockId    types1.BlockID       `protobuf:"bytes,12,opt,name=valid_block_id,json=validBlockId,proto3" json:"valid_block_id"`
+	CommitRound     int32                `protobuf:"varint,13,opt,name=commit_round,json=commitRound,proto3" json:"commit_round,omitempty"`
+	Votes           []RoundVotes         `protobuf:"bytes,14,rep,name=votes,proto3" json:"votes"`
+	// Precommits for the last height.
+	LastCommit                []*types1.Vote       `protobuf:"bytes,15,rep,name=last_commit,json=lastCommit,proto3" json:"last_commit,omitempty"`
+	LastValidators            *types1.ValidatorSet `protobuf:"bytes,16,opt,name=last_validators,json=lastValidators,proto3" json:"last_validators,omitempty"`
+	TriggeredTimeoutPrecommit bool                 `protobuf:"varint,17,opt,name=triggered_timeout_precommit,json=triggeredTimeoutPrecommit,proto3" json:"triggered_timeout_precommit,omitempty"`
+}
+
+func (m *RoundStateDump) Reset()         { *m = RoundStateDump{} }
+func (m *RoundStateDump) String() string { return proto.CompactTextString(m) }
+func (*RoundStateDump) ProtoMessage()    {}
+func (*RoundStateDump) Descriptor() ([]byte, []int) {
+	return fileDescriptor_54267bf4928a71d0, []int{0}
+}
+func (m *RoundStateDump) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RoundStateDump) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RoundStateDump.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RoundStateDump) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundStateDump.Merge(m, src)
+}
+func (m *RoundStateDump) XXX_Size() int {
+	return m.Size()
+}
+func (m *RoundStateDump) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundStateDump.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoundStateDump proto.InternalMessageInfo
+
+func (m *RoundStateDump) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RoundStateDump) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *RoundStateDump) GetStep() uint32 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+func (m *RoundStateDump) GetStartTime() time.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return time.Time{}
+}
+
+func (m *RoundStateDump) GetCommitTime() time.Time {
+	if m != nil {
+		return m.CommitTime
+	}
+	return time.Time{}
+}
+
+func (m *RoundStateDump) GetValidators() *types1.ValidatorSet {
+	if m != nil {
+		return m.Validators
+	}
+	return nil
+}
+
+func (m *RoundStateDump) GetProposal() *types1.Proposal {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+func (m *RoundStateDump) GetProposalBlockId() types1.BlockID {
+	if m != nil {
+		return m.ProposalBlockId
+	}
+	return types1.BlockID{}
+}
+
+func (m *RoundStateDump) GetLockedRound() int32 {
+	if m != nil {
+		return m.LockedRound
+	}
+	return 0
+}
+
+func (m *RoundStateDump) GetLockedBlockId() types1.BlockID {
+	if m != nil {
+		return m.LockedBlockId
+	}
+	return types1.BlockID{}
+}
+
+func (m *RoundStateDump) GetValidRound() int32 {
+	if m != nil {
+		return m.ValidRound
+	}
+	return 0
+}
+
+func (m *RoundStateDump) GetValidBlockId() types1.BlockID {
+	if m != nil {
+		return m.ValidBlockId
+	}
+	return types1.BlockID{}
+}
+
+func (m *RoundStateDump) GetCommitRound() int32 {
+	if m != nil {
+		return m.CommitRound
+	}
+	return 0
+}
+
+func (m *RoundStateDump) GetVotes() []RoundVotes {
+	if m != nil {
+		return m.Votes
+	}
+	return nil
+}
+
+func (m *RoundStateDump) GetLastCommit() []*types1.Vote {
+	if m != nil {
+		return m.LastCommit
+	}
+	return nil
+}
+
+func (m *RoundStateDump) GetLastValidators() *types1.ValidatorSet {
+	if m != nil {
+		return m.LastValidators
+	}
+	return nil
+}
+
+func (m *RoundStateDump) GetTriggeredTimeoutPrecommit() bool {
+	if m != nil {
+		return m.TriggeredTimeoutPrecommit
+	}
+	return false
+}
+
+// RoundVotes are the votes received for a round.
+type RoundVotes struct {
+	Round      int32          `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Prevotes   []*types1.Vote `protobuf:"bytes,2,rep,name=prevotes,proto3" json:"prevotes,omitempty"`
+	Precommits []*types1.Vote `protobuf:"bytes,3,rep,name=precommits,proto3" json:"precommits,omitempty"`
+}
+
+func (m *RoundVotes) Reset()         { *m = RoundVotes{} }
+func (m *RoundVotes) String() string { return proto.CompactTextString(m) }
+func (*RoundVotes) ProtoMessage()    {}
+func (*RoundVotes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_54267bf4928a71d0, []int{1}
+}
+func (m *RoundVotes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RoundVotes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RoundVotes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RoundVotes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundVotes.Merge(m, src)
+}
+func (m *RoundVotes) XXX_Size() int {
+	return m.Size()
+}
+func (m *RoundVotes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundVotes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoundVotes proto.InternalMessageInfo
+
+func (m *RoundVotes) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *RoundVotes) GetPrevotes() []*types1.Vote {
+	if m != nil {
+		return m.Prevotes
+	}
+	return nil
+}
+
+func (m *RoundVotes) GetPrecommits() []*types1.Vote {
+	if m != nil {
+		return m.Precommits
+	}
+	return nil
+}
+
+// PeerRoundStateSummary summarizes the known round state of a peer.
+type PeerRoundStateSummary struct {
+	NodeAddress        string         `protobuf:"bytes,1,opt,name=node_address,json=nodeAddress,proto3" json:"node_address,omitempty"`
+	Height             int64          `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Round              int32          `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Step               uint32         `protobuf:"varint,4,opt,name=step,proto3" json:"step,omitempty"`
+	StartTime          time.Time      `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3,stdtime" json:"start_time"`
+	Proposal           bool           `protobuf:"varint,6,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	ProposalBlockParts *bits.BitArray `protobuf:"bytes,7,opt,name=proposal_block_parts,json=proposalBlockParts,proto3" json:"proposal_block_parts,omitempty"`
+	Prevotes           *bits.BitArray `protobuf:"bytes,8,opt,name=prevotes,proto3" json:"prevotes,omitempty"`
+	Precommits         *bits.BitArray `protobuf:"bytes,9,opt,name=precommits,proto3" json:"precommits,omitempty"`
+	LastCommit         *bits.BitArray `protobuf:"bytes,10,opt,name=last_commit,json=lastCommit,proto3" json:"last_commit,omitempty"`
+}
+
+func (m *PeerRoundStateSummary) Reset()         { *m = PeerRoundStateSummary{} }
+func (m *PeerRoundStateSummary) String() string { return proto.CompactTextString(m) }
+func (*PeerRoundStateSummary) ProtoMessage()    {}
+func (*PeerRoundStateSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_54267bf4928a71d0, []int{2}
+}
+func (m *PeerRoundStateSummary) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerRoundStateSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerRoundStateSummary.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PeerRoundStateSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerRoundStateSummary.Merge(m, src)
+}
+func (m *PeerRoundStateSummary) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerRoundStateSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerRoundStateSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerRoundStateSummary proto.InternalMessageInfo
+
+func (m *PeerRoundStateSummary) GetNodeAddress() string {
+	if m != nil {
+		return m.NodeAddress
+	}
+	return ""
+}
+
+func (m *PeerRoundStateSummary) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *PeerRoundStateSummary) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *PeerRoundStateSummary) GetStep() uint32 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+func (m *PeerRoundStateSummary) GetStartTime() time.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return time.Time{}
+}
+
+func (m *PeerRoundStateSummary) GetProposal() bool {
+	if m != nil {
+		return m.Proposal
+	}
+	return false
+}
+
+func (m *PeerRoundStateSummary) GetProposalBlockParts() *bits.BitArray {
+	if m != nil {
+		return m.ProposalBlockParts
+	}
+	return nil
+}
+
+func (m *PeerRoundStateSummary) GetPrevotes() *bits.BitArray {
+	if m != nil {
+		return m.Prevotes
+	}
+	return nil
+}
+
+func (m *PeerRoundStateSummary) GetPrecommits() *bits.BitArray {
+	if m != nil {
+		return m.Precommits
+	}
+	return nil
+}
+
+func (m *PeerRoundStateSummary) GetLastCommit() *bits.BitArray {
+	if m != nil {
+		return m.LastCommit
+	}
+	return nil
+}
+
+// ConsensusStateDump is the round state of the node, with the summaries of
+// the round states of its peers.
+type ConsensusStateDump struct {
+	RoundState *RoundStateDump         `protobuf:"bytes,1,opt,name=round_state,json=roundState,proto3" json:"round_state,omitempty"`
+	Peers      []PeerRoundStateSummary `protobuf:"bytes,2,rep,name=peers,proto3" json:"peers"`
+}
+
+func (m *ConsensusStateDump) Reset()         { *m = ConsensusStateDump{} }
+func (m *ConsensusStateDump) String() string { return proto.CompactTextString(m) }
+func (*ConsensusStateDump) ProtoMessage()    {}
+func (*ConsensusStateDump) Descriptor() ([]byte, []int) {
+	return fileDescriptor_54267bf4928a71d0, []int{3}
+}
+func (m *ConsensusStateDump) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConsensusStateDump) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConsensusStateDump.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConsensusStateDump) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusStateDump.Merge(m, src)
+}
+func (m *ConsensusStateDump) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConsensusStateDump) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusStateDump.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsensusStateDump proto.InternalMessageInfo
+
+func (m *ConsensusStateDump) GetRoundState() *RoundStateDump {
+	if m != nil {
+		return m.RoundState
+	}
+	return nil
+}
+
+func (m *ConsensusStateDump) GetPeers() []PeerRoundStateSummary {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*RoundStateDump)(nil), "tendermint.consensus.RoundStateDump")
+	proto.RegisterType((*RoundVotes)(nil), "tendermint.consensus.RoundVotes")
+	proto.RegisterType((*PeerRoundStateSummary)(nil), "tendermint.consensus.PeerRoundStateSummary")
+	proto.RegisterType((*ConsensusStateDump)(nil), "tendermint.consensus.ConsensusStateDump")
+}
+
+func init() { proto.RegisterFile("tendermint/consensus/dump.proto", fileDescriptor_54267bf4928a71d0) }
+
+var fileDescriptor_54267bf4928a71d0 = []byte{
+	// 782 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xcf, 0x6b, 0xe3, 0x46,
+	0x14, 0xb6, 0xe2, 0x1f, 0xb1, 0x9f, 0x12, 0xa7, 0x19, 0xdc, 0xa0, 0xb8, 0xc5, 0x56, 0x4d, 0x0f,
+	0x86, 0x82, 0x0c, 0x2e, 0xa4, 0xd0, 0x96, 0x94, 0x38, 0x09, 0xa1, 0xf4, 0x62, 0x94, 0x34, 0x87,
+	0x5e, 0x84, 0x6c, 0x4d, 0x15, 0x51, 0xcb, 0x23, 0x66, 0x46, 0x81, 0xfc, 0x13, 0x25, 0xe7, 0x3d,
+	0xef, 0x1f, 0x93, 0x63, 0x8e, 0x7b, 0xda, 0x5d, 0x92, 0xeb, 0xfe, 0x11, 0xcb, 0xcc, 0xe8, 0x97,
+	0xd7, 0xda, 0xe0, 0xdd, 0x8b, 0x18, 0xcd, 0x7c, 0xdf, 0xf7, 0xe6, 0xcd, 0x37, 0xef, 0x0d, 0xf4,
+	0x39, 0x5e, 0x7a, 0x98, 0x86, 0xc1, 0x92, 0x8f, 0xe6, 0x64, 0xc9, 0xf0, 0x92, 0xc5, 0x6c, 0xe4,
+	0xc5, 0x61, 0x64, 0x45, 0x94, 0x70, 0x82, 0x3a, 0x39, 0xc0, 0xca, 0x00, 0xdd, 0x8e, 0x4f, 0x7c,
+	0x22, 0x01, 0x23, 0x31, 0x52, 0xd8, 0x6e, 0xdf, 0x27, 0xc4, 0x5f, 0xe0, 0x91, 0xfc, 0x9b, 0xc5,
+	0xff, 0x8e, 0x78, 0x10, 0x62, 0xc6, 0xdd, 0x54, 0xac, 0xfb, 0x7d, 0x21, 0x1a, 0xbf, 0x8b, 0x30,
+	0x53, 0xdf, 0x64, 0xd5, 0x5c, 0x5b, 0xbd, 0x75, 0x17, 0x81, 0xe7, 0x72, 0x42, 0x4b, 0x10, 0x8b,
+	0x60, 0xc6, 0x46, 0xb3, 0x80, 0xaf, 0x68, 0x0c, 0x5e, 0x6d, 0x43, 0xdb, 0x26, 0xf1, 0xd2, 0xbb,
+	0xe4, 0x2e, 0xc7, 0x67, 0x71, 0x18, 0xa1, 0x03, 0x68, 0xdc, 0xe0, 0xc0, 0xbf, 0xe1, 0x86, 0x66,
+	0x6a, 0xc3, 0xaa, 0x9d, 0xfc, 0xa1, 0x0e, 0xd4, 0xa9, 0x40, 0x1a, 0x5b, 0xa6, 0x36, 0xac, 0xdb,
+	0xea, 0x07, 0x21, 0xa8, 0x31, 0x8e, 0x23, 0xa3, 0x6a, 0x6a, 0xc3, 0x5d, 0x5b, 0x8e, 0xd1, 0x29,
+	0x00, 0xe3, 0x2e, 0xe5, 0x8e, 0xc8, 0xc7, 0xa8, 0x99, 0xda, 0x50, 0x1f, 0x77, 0x2d, 0x95, 0xac,
+	0x95, 0x26, 0x6b, 0x5d, 0xa5, 0xc9, 0x4e, 0x9a, 0x0f, 0x6f, 0xfb, 0x95, 0xfb, 0x77, 0x7d, 0xcd,
+	0x6e, 0x49, 0x9e, 0x58, 0x41, 0xe7, 0xa0, 0xcf, 0x49, 0x18, 0x06, 0x89, 0x4a, 0xfd, 0x0b, 0x54,
+	0x40, 0x11, 0xa5, 0xcc, 0x31, 0x40, 0x76, 0x2a, 0xcc, 0x68, 0x48, 0x95, 0x9e, 0x55, 0x30, 0x49,
+	0x9d, 0xc6, 0x75, 0x8a, 0xb9, 0xc4, 0xdc, 0x2e, 0x30, 0xd0, 0x11, 0x34, 0x23, 0x4a, 0x22, 0xc2,
+	0xdc, 0x85, 0xb1, 0x9d, 0xec, 0x61, 0x8d, 0x3d, 0x4d, 0x10, 0x76, 0x86, 0x45, 0x7f, 0xc1, 0x7e,
+	0x3a, 0x76, 0x66, 0x0b, 0x32, 0xff, 0xcf, 0x09, 0x3c, 0xa3, 0x29, 0x05, 0x0e, 0xd7, 0x05, 0x26,
+	0x02, 0xf1, 0xe7, 0xd9, 0xa4, 0x26, 0x72, 0xb0, 0xf7, 0x52, 0xa6, 0x9a, 0xf6, 0xd0, 0x0f, 0xb0,
+	0x23, 0x46, 0xd8, 0x73, 0x94, 0x03, 0x2d, 0xe9, 0x80, 0xae, 0xe6, 0xa4, 0x7d, 0xe8, 0x02, 0xf6,
+	0x12, 0x48, 0x16, 0x0d, 0x36, 0x8b, 0xb6, 0xab, 0x78, 0x69, 0xac, 0x3e, 0xe8, 0x32, 0xfd, 0x24,
+	0x94, 0x2e, 0x43, 0xa9, 0x13, 0x51, 0x91, 0xce, 0xa1, 0xad, 0x00, 0x59, 0xa0, 0x9d, 0xcd, 0x02,
+	0xed, 0x48, 0x5a, 0x21, 0xa7, 0xc4, 0x5f, 0x15, 0x68, 0x57, 0xe5, 0xa4, 0xe6, 0x54, 0xa4, 0xdf,
+	0xa1, 0x7e, 0x4b, 0x38, 0x66, 0x46, 0xdb, 0xac, 0x0e, 0xf5, 0xb1, 0x69, 0x95, 0xd5, 0x96, 0x25,
+	0xb1, 0xd7, 0x02, 0x97, 0xc4, 0x51, 0x24, 0xf4, 0x0b, 0xe8, 0x0b, 0x97, 0x71, 0x47, 0x29, 0x1a,
+	0x7b, 0x52, 0xe3, 0xa0, 0xc4, 0x7a, 0xc2, 0xb1, 0x0d, 0x02, 0x7a, 0x2a, 0x91, 0xf2, 0x28, 0x05,
+	0xb1, 0x70, 0x6f, 0xbe, 0xd9, 0xe8, 0xde, 0xb4, 0x05, 0xed, 0x3a, 0xbf, 0x3b, 0xc7, 0xf0, 0x1d,
+	0xa7, 0x81, 0xef, 0x63, 0x8a, 0x3d, 0x79, 0x8b, 0x49, 0xcc, 0x9d, 0x88, 0xe2, 0x64, 0x47, 0xfb,
+	0xa6, 0x36, 0x6c, 0xda, 0x87, 0x19, 0xe4, 0x4a, 0x21, 0xa6, 0x29, 0x60, 0xf0, 0xbf, 0x06, 0x90,
+	0x67, 0x97, 0x17, 0xa0, 0x56, 0x2c, 0xc0, 0xb1, 0xb8, 0xa0, 0x58, 0x9d, 0xd3, 0xd6, 0x8b, 0x39,
+	0x66, 0x38, 0x74, 0x04, 0x90, 0x6d, 0x83, 0x19, 0xd5, 0x97, 0x4f, 0x26, 0x47, 0x0e, 0x3e, 0x54,
+	0xe1, 0xdb, 0x29, 0xc6, 0x34, 0xef, 0x18, 0x97, 0x71, 0x18, 0xba, 0xf4, 0x4e, 0xb8, 0xb9, 0x24,
+	0x1e, 0x76, 0x5c, 0xcf, 0xa3, 0x98, 0x31, 0xb9, 0xc5, 0x96, 0xad, 0x8b, 0xb9, 0x13, 0x35, 0x55,
+	0xe8, 0x2b, 0x5b, 0xe5, 0x7d, 0xa5, 0x5a, 0xd6, 0x57, 0x6a, 0x9f, 0xed, 0x2b, 0xf5, 0xaf, 0xeb,
+	0x2b, 0xdd, 0x42, 0x41, 0x37, 0xa4, 0x03, 0x79, 0xd1, 0x4e, 0xa1, 0xf3, 0x49, 0xd1, 0x46, 0x2e,
+	0xe5, 0xcc, 0xd8, 0x5e, 0xb7, 0x5f, 0xb4, 0x53, 0x4b, 0xb4, 0x53, 0x6b, 0x12, 0xf0, 0x13, 0x4a,
+	0xdd, 0x3b, 0x1b, 0xad, 0x94, 0xed, 0x54, 0x30, 0xd1, 0xaf, 0x05, 0x77, 0x9a, 0x1b, 0xa9, 0xe4,
+	0x2e, 0x1d, 0xaf, 0xb8, 0xd4, 0xda, 0x88, 0x5d, 0x60, 0xa0, 0x3f, 0x56, 0x0b, 0x00, 0x36, 0x13,
+	0xc8, 0x0b, 0x61, 0xf0, 0x5a, 0x03, 0x74, 0x9a, 0xd6, 0x59, 0xfe, 0x40, 0x9c, 0x83, 0x2e, 0x3d,
+	0x72, 0x98, 0x98, 0x92, 0x56, 0xeb, 0xe3, 0x1f, 0x5f, 0x28, 0xce, 0x8c, 0x6a, 0x03, 0xcd, 0xfe,
+	0xd1, 0x05, 0xd4, 0x23, 0x8c, 0x69, 0x7a, 0x6b, 0x7f, 0x2a, 0x17, 0x28, 0xbd, 0x6e, 0x69, 0xa1,
+	0x4b, 0xfe, 0xe4, 0xef, 0x87, 0xa7, 0x9e, 0xf6, 0xf8, 0xd4, 0xd3, 0xde, 0x3f, 0xf5, 0xb4, 0xfb,
+	0xe7, 0x5e, 0xe5, 0xf1, 0xb9, 0x57, 0x79, 0xf3, 0xdc, 0xab, 0xfc, 0xf3, 0x9b, 0x1f, 0xf0, 0x9b,
+	0x78, 0x66, 0xcd, 0x49, 0x38, 0x2a, 0x3e, 0x96, 0xf9, 0x50, 0xbd, 0xc9, 0x65, 0x8f, 0xfa, 0xac,
+	0x21, 0xd7, 0x7e, 0xfe, 0x38, 0x00, 0xb0, 0xdc, 0x3c, 0xb5, 0xf3, 0x07, 0x00, 0x00,
+}
+
+func (m *RoundStateDump) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoundStateDump) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RoundStateDump) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TriggeredTimeoutPrecommit {
+		i--
+		if m.TriggeredTimeoutPrecommit {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.LastValidators != nil {
+		{
+			size, err := m.LastValidators.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDump(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if len(m.LastCommit) > 0 {
+		for iNdEx := len(m.LastCommit) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.LastCommit[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDump(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x7a
+		}
+	}
+	if len(m.Votes) > 0 {
+		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Votes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDump(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x72
+		}
+	}
+	if m.CommitRound != 0 {
+		i = encodeVarintDump(dAtA, i, uint64(m.CommitRound))
+		i--
+		dAtA[i] = 0x68
+	}
+	{
+		size, err := m.ValidBlockId.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintDump(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x62
+	if m.ValidRound != 0 {
+		i = encodeVarintDump(dAtA, i, uint64(m.ValidRound))
+		i--
+		dAtA[i] = 0x58
+	}
+	{
+		size, err := m.LockedBlockId.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintDump(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x52
+	if m.LockedRound != 0 {
+		i = encodeVarintDump(dAtA, i, uint64(m.LockedRound))
+		i--
+		dAtA[i] = 0x48
+	}
+	{
+		size, err := m.ProposalBlockId.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintDump(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x42
+	if m.Proposal != nil {
+		{
+			size, err := m.Proposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDump(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Validators != nil {
+		{
+			size, err := m.Validators.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDump(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.CommitTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.CommitTime):])
+	if err7 != nil {
+		return 0, err7
+	}
+	i -= n7
+	i = encodeVarintDump(dAtA, i, uint64(n7))
+	i--
+	dAtA[i] = 0x2a
+	n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime):])
+	if err8 != nil {
+		return 0, err8
+	}
+	i -= n8
+	i = encodeVarintDump(dAtA, i, uint64(n8))
+	i--
+	dAtA[i] = 0x22
+	if m.Step != 0 {
+		i = encodeVarintDump(dAtA, i, uint64(m.Step))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Round != 0 {
+		i = encodeVarintDump(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintDump(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RoundVotes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoundVotes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RoundVotes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Precommits) > 0 {
+		for iNdEx := len(m.Precommits) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Precommits[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDump(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Prevotes) > 0 {
+		for iNdEx := len(m.Prevotes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Prevotes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDump(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Round != 0 {
+		i = encodeVarintDump(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerRoundStateSummary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerRoundStateSummary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerRoundStateSummary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastCommit != nil {
+		{
+			size, err := m.LastCommit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDump(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.Precommits != nil {
+		{
+			size, err := m.Precommits.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDump(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Prevotes != nil {
+		{
+			size, err := m.Prevotes.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDump(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.ProposalBlockParts != nil {
+		{
+			size, err := m.ProposalBlockParts.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDump(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Proposal {
+		i--
+		if m.Proposal {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	n13, err13 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime):])
+	if err13 != nil {
+		return 0, err13
+	}
+	i -= n13
+	i = encodeVarintDump(dAtA, i, uint64(n13))
+	i--
+	dAtA[i] = 0x2a
+	if m.Step != 0 {
+		i = encodeVarintDump(dAtA, i, uint64(m.Step))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Round != 0 {
+		i = encodeVarintDump(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Height != 0 {
+		i = encodeVarintDump(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.NodeAddress) > 0 {
+		i -= len(m.NodeAddress)
+		copy(dAtA[i:], m.NodeAddress)
+		i = encodeVarintDump(dAtA, i, uint64(len(m.NodeAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConsensusStateDump) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConsensusStateDump) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConsensusStateDump) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDump(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.RoundState != nil {
+		{
+			size, err := m.RoundState.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDump(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintDump(dAtA []byte, offset int, v uint64) int {
+	offset -= sovDump(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *RoundStateDump) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovDump(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovDump(uint64(m.Round))
+	}
+	if m.Step != 0 {
+		n += 1 + sovDump(uint64(m.Step))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)
+	n += 1 + l + sovDump(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.CommitTime)
+	n += 1 + l + sovDump(uint64(l))
+	if m.Validators != nil {
+		l = m.Validators.Size()
+		n += 1 + l + sovDump(uint64(l))
+	}
+	if m.Proposal != nil {
+		l = m.Proposal.Size()
+		n += 1 + l + sovDump(uint64(l))
+	}
+	l = m.ProposalBlockId.Size()
+	n += 1 + l + sovDump(uint64(l))
+	if m.LockedRound != 0 {
+		n += 1 + sovDump(uint64(m.LockedRound))
+	}
+	l = m.LockedBlockId.Size()
+	n += 1 + l + sovDump(uint64(l))
+	if m.ValidRound != 0 {
+		n += 1 + sovDump(uint64(m.ValidRound))
+	}
+	l = m.ValidBlockId.Size()
+	n += 1 + l + sovDump(uint64(l))
+	if m.CommitRound != 0 {
+		n += 1 + sovDump(uint64(m.CommitRound))
+	}
+	if len(m.Votes) > 0 {
+		for _, e := range m.Votes {
+			l = e.Size()
+			n += 1 + l + sovDump(uint64(l))
+		}
+	}
+	if len(m.LastCommit) > 0 {
+		for _, e := range m.LastCommit {
+			l = e.Size()
+			n += 1 + l + sovDump(uint64(l))
+		}
+	}
+	if m.LastValidators != nil {
+		l = m.LastValidators.Size()
+		n += 2 + l + sovDump(uint64(l))
+	}
+	if m.TriggeredTimeoutPrecommit {
+		n += 3
+	}
+	return n
+}
+
+func (m *RoundVotes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 1 + sovDump(uint64(m.Round))
+	}
+	if len(m.Prevotes) > 0 {
+		for _, e := range m.Prevotes {
+			l = e.Size()
+			n += 1 + l + sovDump(uint64(l))
+		}
+	}
+	if len(m.Precommits) > 0 {
+		for _, e := range m.Precommits {
+			l = e.Size()
+			n += 1 + l + sovDump(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PeerRoundStateSummary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeAddress)
+	if l > 0 {
+		n += 1 + l + sovDump(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovDump(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovDump(uint64(m.Round))
+	}
+	if m.Step != 0 {
+		n += 1 + sovDump(uint64(m.Step))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)
+	n += 1 + l + sovDump(uint64(l))
+	if m.Proposal {
+		n += 2
+	}
+	if m.ProposalBlockParts != nil {
+		l = m.ProposalBlockParts.Size()
+		n += 1 + l + sovDump(uint64(l))
+	}
+	if m.Prevotes != nil {
+		l = m.Prevotes.Size()
+		n += 1 + l + sovDump(uint64(l))
+	}
+	if m.Precommits != nil {
+		l = m.Precommits.Size()
+		n += 1 + l + sovDump(uint64(l))
+	}
+	if m.LastCommit != nil {
+		l = m.LastCommit.Size()
+		n += 1 + l + sovDump(uint64(l))
+	}
+	return n
+}
+
+func (m *ConsensusStateDump) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RoundState != nil {
+		l = m.RoundState.Size()
+		n += 1 + l + sovDump(uint64(l))
+	}
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovDump(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovDump(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozDump(x uint64) (n int) {
+	return sovDump(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RoundStateDump) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDump
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoundStateDump: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoundStateDump: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			m.Step = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Step |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.StartTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.CommitTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Validators == nil {
+				m.Validators = &types1.ValidatorSet{}
+			}
+			if err := m.Validators.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proposal == nil {
+				m.Proposal = &types1.Proposal{}
+			}
+			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalBlockId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ProposalBlockId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockedRound", wireType)
+			}
+			m.LockedRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LockedRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockedBlockId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.LockedBlockId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidRound", wireType)
+			}
+			m.ValidRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValidRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidBlockId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ValidBlockId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitRound", wireType)
+			}
+			m.CommitRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Votes = append(m.Votes, RoundVotes{})
+			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCommit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastCommit = append(m.LastCommit, &types1.Vote{})
+			if err := m.LastCommit[len(m.LastCommit)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastValidators", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastValidators == nil {
+				m.LastValidators = &types1.ValidatorSet{}
+			}
+			if err := m.LastValidators.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TriggeredTimeoutPrecommit", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TriggeredTimeoutPrecommit = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDump(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDump
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDump
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RoundVotes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDump
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoundVotes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoundVotes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prevotes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prevotes = append(m.Prevotes, &types1.Vote{})
+			if err := m.Prevotes[len(m.Prevotes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Precommits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Precommits = append(m.Precommits, &types1.Vote{})
+			if err := m.Precommits[len(m.Precommits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDump(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDump
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDump
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerRoundStateSummary) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDump
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerRoundStateSummary: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerRoundStateSummary: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			m.Step = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Step |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.StartTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Proposal = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalBlockParts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ProposalBlockParts == nil {
+				m.ProposalBlockParts = &bits.BitArray{}
+			}
+			if err := m.ProposalBlockParts.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prevotes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Prevotes == nil {
+				m.Prevotes = &bits.BitArray{}
+			}
+			if err := m.Prevotes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Precommits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Precommits == nil {
+				m.Precommits = &bits.BitArray{}
+			}
+			if err := m.Precommits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCommit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastCommit == nil {
+				m.LastCommit = &bits.BitArray{}
+			}
+			if err := m.LastCommit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDump(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDump
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDump
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConsensusStateDump) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDump
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConsensusStateDump: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConsensusStateDump: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoundState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RoundState == nil {
+				m.RoundState = &RoundStateDump{}
+			}
+			if err := m.RoundState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDump
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDump
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, PeerRoundStateSummary{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDump(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDump
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDump
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipDump(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowDump
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowDump
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthDump
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupDump
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthDump
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthDump        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowDump          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupDump = fmt.Errorf("proto: unexpected end of group")
+)
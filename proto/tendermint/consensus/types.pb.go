@@ -463,6 +463,76 @@ func (m *HasVote) GetIndex() int32 {
 	return 0
 }
 
+// HasVotes is sent to indicate the bit-array of votes received for a given
+// height, round and type. It aggregates multiple HasVote messages.
+type HasVotes struct {
+	Height int64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32               `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Type   types.SignedMsgType `protobuf:"varint,3,opt,name=type,proto3,enum=tendermint.types.SignedMsgType" json:"type,omitempty"`
+	Votes  bits.BitArray       `protobuf:"bytes,4,opt,name=votes,proto3" json:"votes"`
+}
+
+func (m *HasVotes) Reset()         { *m = HasVotes{} }
+func (m *HasVotes) String() string { return proto.CompactTextString(m) }
+func (*HasVotes) ProtoMessage()    {}
+func (*HasVotes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{7}
+}
+func (m *HasVotes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HasVotes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HasVotes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *HasVotes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HasVotes.Merge(m, src)
+}
+func (m *HasVotes) XXX_Size() int {
+	return m.Size()
+}
+func (m *HasVotes) XXX_DiscardUnknown() {
+	xxx_messageInfo_HasVotes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HasVotes proto.InternalMessageInfo
+
+func (m *HasVotes) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *HasVotes) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *HasVotes) GetType() types.SignedMsgType {
+	if m != nil {
+		return m.Type
+	}
+	return types.UnknownType
+}
+
+func (m *HasVotes) GetVotes() bits.BitArray {
+	if m != nil {
+		return m.Votes
+	}
+	return bits.BitArray{}
+}
+
 // VoteSetMaj23 is sent to indicate that a given BlockID has seen +2/3 votes.
 type VoteSetMaj23 struct {
 	Height  int64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
@@ -475,7 +545,7 @@ func (m *VoteSetMaj23) Reset()         { *m = VoteSetMaj23{} }
 func (m *VoteSetMaj23) String() string { return proto.CompactTextString(m) }
 func (*VoteSetMaj23) ProtoMessage()    {}
 func (*VoteSetMaj23) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{7}
+	return fileDescriptor_81a22d2efc008981, []int{8}
 }
 func (m *VoteSetMaj23) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -545,7 +615,7 @@ func (m *VoteSetBits) Reset()         { *m = VoteSetBits{} }
 func (m *VoteSetBits) String() string { return proto.CompactTextString(m) }
 func (*VoteSetBits) ProtoMessage()    {}
 func (*VoteSetBits) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{8}
+	return fileDescriptor_81a22d2efc008981, []int{9}
 }
 func (m *VoteSetBits) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -620,6 +690,7 @@ type Message struct {
 	//	*Message_HasVote
 	//	*Message_VoteSetMaj23
 	//	*Message_VoteSetBits
+	//	*Message_HasVotes
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -627,7 +698,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{9}
+	return fileDescriptor_81a22d2efc008981, []int{10}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -689,6 +760,9 @@ type Message_VoteSetMaj23 struct {
 type Message_VoteSetBits struct {
 	VoteSetBits *VoteSetBits `protobuf:"bytes,9,opt,name=vote_set_bits,json=voteSetBits,proto3,oneof" json:"vote_set_bits,omitempty"`
 }
+type Message_HasVotes struct {
+	HasVotes *HasVotes `protobuf:"bytes,10,opt,name=has_votes,json=hasVotes,proto3,oneof" json:"has_votes,omitempty"`
+}
 
 func (*Message_NewRoundStep) isMessage_Sum()  {}
 func (*Message_NewValidBlock) isMessage_Sum() {}
@@ -699,6 +773,7 @@ func (*Message_Vote) isMessage_Sum()          {}
 func (*Message_HasVote) isMessage_Sum()       {}
 func (*Message_VoteSetMaj23) isMessage_Sum()  {}
 func (*Message_VoteSetBits) isMessage_Sum()   {}
+func (*Message_HasVotes) isMessage_Sum()      {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -770,6 +845,13 @@ func (m *Message) GetVoteSetBits() *VoteSetBits {
 	return nil
 }
 
+func (m *Message) GetHasVotes() *HasVotes {
+	if x, ok := m.GetSum().(*Message_HasVotes); ok {
+		return x.HasVotes
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -782,6 +864,7 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_HasVote)(nil),
 		(*Message_VoteSetMaj23)(nil),
 		(*Message_VoteSetBits)(nil),
+		(*Message_HasVotes)(nil),
 	}
 }
 
@@ -793,6 +876,7 @@ func init() {
 	proto.RegisterType((*BlockPart)(nil), "tendermint.consensus.BlockPart")
 	proto.RegisterType((*Vote)(nil), "tendermint.consensus.Vote")
 	proto.RegisterType((*HasVote)(nil), "tendermint.consensus.HasVote")
+	proto.RegisterType((*HasVotes)(nil), "tendermint.consensus.HasVotes")
 	proto.RegisterType((*VoteSetMaj23)(nil), "tendermint.consensus.VoteSetMaj23")
 	proto.RegisterType((*VoteSetBits)(nil), "tendermint.consensus.VoteSetBits")
 	proto.RegisterType((*Message)(nil), "tendermint.consensus.Message")
@@ -801,61 +885,62 @@ func init() {
 func init() { proto.RegisterFile("tendermint/consensus/types.proto", fileDescriptor_81a22d2efc008981) }
 
 var fileDescriptor_81a22d2efc008981 = []byte{
-	// 853 bytes of a gzipped FileDescriptorProto
+	// 879 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0x4f, 0x8f, 0xdb, 0x44,
-	0x14, 0xb7, 0x59, 0x67, 0x93, 0x7d, 0xde, 0xec, 0xc2, 0x68, 0x5b, 0x85, 0x00, 0x49, 0x30, 0x97,
-	0x15, 0x42, 0x0e, 0xca, 0x1e, 0x90, 0x0a, 0x12, 0x60, 0xfe, 0xd4, 0xad, 0x9a, 0x36, 0x72, 0x4a,
-	0x85, 0xb8, 0x58, 0x4e, 0x3c, 0x4a, 0x86, 0xc6, 0x1e, 0xcb, 0x33, 0xc9, 0xb2, 0x57, 0x3e, 0x01,
-	0x1f, 0x80, 0xaf, 0x81, 0xc4, 0x47, 0xe8, 0xb1, 0x47, 0x4e, 0x15, 0xca, 0x7e, 0x04, 0x04, 0x67,
-	0x34, 0xe3, 0x49, 0x3c, 0xa1, 0xde, 0x85, 0xbd, 0x20, 0xf5, 0x36, 0xe3, 0xf7, 0xde, 0x6f, 0xde,
-	0xfc, 0xde, 0x7b, 0x3f, 0x0f, 0xf4, 0x38, 0x4e, 0x63, 0x9c, 0x27, 0x24, 0xe5, 0xfd, 0x29, 0x4d,
-	0x19, 0x4e, 0xd9, 0x92, 0xf5, 0xf9, 0x45, 0x86, 0x99, 0x9b, 0xe5, 0x94, 0x53, 0x74, 0x52, 0x7a,
-	0xb8, 0x5b, 0x8f, 0xf6, 0xc9, 0x8c, 0xce, 0xa8, 0x74, 0xe8, 0x8b, 0x55, 0xe1, 0xdb, 0x7e, 0x5b,
-	0x43, 0x93, 0x18, 0x3a, 0x52, 0x5b, 0x3f, 0x6b, 0x41, 0x26, 0xac, 0x3f, 0x21, 0x7c, 0xc7, 0xc3,
-	0xf9, 0xc5, 0x84, 0xc3, 0x87, 0xf8, 0x3c, 0xa0, 0xcb, 0x34, 0x1e, 0x73, 0x9c, 0xa1, 0xdb, 0xb0,
-	0x3f, 0xc7, 0x64, 0x36, 0xe7, 0x2d, 0xb3, 0x67, 0x9e, 0xee, 0x05, 0x6a, 0x87, 0x4e, 0xa0, 0x96,
-	0x0b, 0xa7, 0xd6, 0x6b, 0x3d, 0xf3, 0xb4, 0x16, 0x14, 0x1b, 0x84, 0xc0, 0x62, 0x1c, 0x67, 0xad,
-	0xbd, 0x9e, 0x79, 0xda, 0x0c, 0xe4, 0x1a, 0x7d, 0x04, 0x2d, 0x86, 0xa7, 0x34, 0x8d, 0x59, 0xc8,
-	0x48, 0x3a, 0xc5, 0x21, 0xe3, 0x51, 0xce, 0x43, 0x4e, 0x12, 0xdc, 0xb2, 0x24, 0xe6, 0x2d, 0x65,
-	0x1f, 0x0b, 0xf3, 0x58, 0x58, 0x1f, 0x93, 0x04, 0xa3, 0xf7, 0xe1, 0x8d, 0x45, 0xc4, 0x78, 0x38,
-	0xa5, 0x49, 0x42, 0x78, 0x58, 0x1c, 0x57, 0x93, 0xc7, 0x1d, 0x0b, 0xc3, 0x17, 0xf2, 0xbb, 0x4c,
-	0xd5, 0xf9, 0xd3, 0x84, 0xe6, 0x43, 0x7c, 0xfe, 0x24, 0x5a, 0x90, 0xd8, 0x5b, 0xd0, 0xe9, 0xd3,
-	0x1b, 0x26, 0xfe, 0x2d, 0xdc, 0x9a, 0x88, 0xb0, 0x30, 0x13, 0xb9, 0x31, 0xcc, 0xc3, 0x39, 0x8e,
-	0x62, 0x9c, 0xcb, 0x9b, 0xd8, 0x83, 0xae, 0xab, 0xd5, 0xa0, 0xe0, 0x6b, 0x14, 0xe5, 0x7c, 0x8c,
-	0xb9, 0x2f, 0xdd, 0x3c, 0xeb, 0xd9, 0x8b, 0xae, 0x11, 0x20, 0x89, 0xb1, 0x63, 0x41, 0x9f, 0x82,
-	0x5d, 0x22, 0x33, 0x79, 0x63, 0x7b, 0xd0, 0xd1, 0xf1, 0x44, 0x25, 0x5c, 0x51, 0x09, 0xd7, 0x23,
-	0xfc, 0xf3, 0x3c, 0x8f, 0x2e, 0x02, 0xd8, 0x02, 0x31, 0xf4, 0x16, 0x1c, 0x10, 0xa6, 0x48, 0x90,
-	0xd7, 0x6f, 0x04, 0x0d, 0xc2, 0x8a, 0xcb, 0x3b, 0x3e, 0x34, 0x46, 0x39, 0xcd, 0x28, 0x8b, 0x16,
-	0xe8, 0x13, 0x68, 0x64, 0x6a, 0x2d, 0xef, 0x6c, 0x0f, 0xda, 0x15, 0x69, 0x2b, 0x0f, 0x95, 0xf1,
-	0x36, 0xc2, 0xf9, 0xd9, 0x04, 0x7b, 0x63, 0x1c, 0x3d, 0x7a, 0x70, 0x25, 0x7f, 0x1f, 0x00, 0xda,
-	0xc4, 0x84, 0x19, 0x5d, 0x84, 0x3a, 0x99, 0xaf, 0x6f, 0x2c, 0x23, 0xba, 0x90, 0x75, 0x41, 0x77,
-	0xe1, 0x50, 0xf7, 0x56, 0x74, 0xfe, 0xcb, 0xf5, 0x55, 0x6e, 0xb6, 0x86, 0xe6, 0x3c, 0x85, 0x03,
-	0x6f, 0xc3, 0xc9, 0x0d, 0x6b, 0xfb, 0x21, 0x58, 0x82, 0x7b, 0x75, 0xf6, 0xed, 0xea, 0x52, 0xaa,
-	0x33, 0xa5, 0xa7, 0x33, 0x00, 0xeb, 0x09, 0xe5, 0xa2, 0x03, 0xad, 0x15, 0xe5, 0x58, 0xb1, 0x59,
-	0x11, 0x29, 0xbc, 0x02, 0xe9, 0xe3, 0xfc, 0x68, 0x42, 0xdd, 0x8f, 0x98, 0x8c, 0xbb, 0x59, 0x7e,
-	0x67, 0x60, 0x09, 0x34, 0x99, 0xdf, 0x51, 0x55, 0xab, 0x8d, 0xc9, 0x2c, 0xc5, 0xf1, 0x90, 0xcd,
-	0x1e, 0x5f, 0x64, 0x38, 0x90, 0xce, 0x02, 0x8a, 0xa4, 0x31, 0xfe, 0x41, 0x36, 0x54, 0x2d, 0x28,
-	0x36, 0xce, 0xaf, 0x26, 0x1c, 0x8a, 0x0c, 0xc6, 0x98, 0x0f, 0xa3, 0xef, 0x07, 0x67, 0xff, 0x47,
-	0x26, 0x5f, 0x41, 0xa3, 0x68, 0x70, 0x12, 0xab, 0xee, 0x7e, 0xf3, 0xe5, 0x40, 0x59, 0xbb, 0x7b,
-	0x5f, 0x7a, 0xc7, 0x82, 0xe5, 0xf5, 0x8b, 0x6e, 0x5d, 0x7d, 0x08, 0xea, 0x32, 0xf6, 0x5e, 0xec,
-	0xfc, 0x61, 0x82, 0xad, 0x52, 0xf7, 0x08, 0x67, 0xaf, 0x4e, 0xe6, 0xe8, 0x0e, 0xd4, 0x44, 0x07,
-	0x30, 0x39, 0x9c, 0xff, 0xb5, 0xb9, 0x8b, 0x10, 0xe7, 0x2f, 0x0b, 0xea, 0x43, 0xcc, 0x58, 0x34,
-	0xc3, 0xe8, 0x3e, 0x1c, 0xa5, 0xf8, 0xbc, 0x18, 0xa8, 0x50, 0xca, 0x68, 0xd1, 0x77, 0x8e, 0x5b,
-	0xf5, 0x03, 0x70, 0x75, 0x99, 0xf6, 0x8d, 0xe0, 0x30, 0xd5, 0x65, 0x7b, 0x08, 0xc7, 0x02, 0x6b,
-	0x25, 0xf4, 0x30, 0x94, 0x89, 0x4a, 0xbe, 0xec, 0xc1, 0x7b, 0x57, 0x82, 0x95, 0xda, 0xe9, 0x1b,
-	0x41, 0x33, 0xdd, 0x11, 0x53, 0x5d, 0x5a, 0x2a, 0x46, 0xb8, 0xc4, 0xd9, 0x28, 0x88, 0xaf, 0x49,
-	0x0b, 0xfa, 0xfa, 0x1f, 0x22, 0x50, 0x70, 0xfd, 0xee, 0xf5, 0x08, 0xa3, 0x47, 0x0f, 0xfc, 0x5d,
-	0x0d, 0x40, 0x9f, 0x01, 0x94, 0x52, 0xaa, 0xd8, 0xee, 0x56, 0xa3, 0x6c, 0xb5, 0xc2, 0x37, 0x82,
-	0x83, 0xad, 0x98, 0x0a, 0x29, 0x90, 0x03, 0xbd, 0xff, 0xb2, 0x3c, 0x96, 0xb1, 0xa2, 0x0b, 0x7d,
-	0xa3, 0x18, 0x6b, 0x74, 0x07, 0x1a, 0xf3, 0x88, 0x85, 0x32, 0xaa, 0x2e, 0xa3, 0xde, 0xa9, 0x8e,
-	0x52, 0xb3, 0xef, 0x1b, 0x41, 0x7d, 0xae, 0x64, 0xe0, 0x3e, 0x1c, 0x89, 0x38, 0xf9, 0x3b, 0x49,
-	0xc4, 0x38, 0xb6, 0x1a, 0xd7, 0x15, 0x54, 0x1f, 0x5c, 0x51, 0xd0, 0x95, 0x3e, 0xc8, 0x77, 0xa1,
-	0xb9, 0xc5, 0x12, 0xfd, 0xd4, 0x3a, 0xb8, 0x8e, 0x44, 0x6d, 0x90, 0x04, 0x89, 0xab, 0x72, 0xeb,
-	0xd5, 0x60, 0x8f, 0x2d, 0x13, 0xef, 0x9b, 0x67, 0xeb, 0x8e, 0xf9, 0x7c, 0xdd, 0x31, 0x7f, 0x5f,
-	0x77, 0xcc, 0x9f, 0x2e, 0x3b, 0xc6, 0xf3, 0xcb, 0x8e, 0xf1, 0xdb, 0x65, 0xc7, 0xf8, 0xee, 0xe3,
-	0x19, 0xe1, 0xf3, 0xe5, 0xc4, 0x9d, 0xd2, 0xa4, 0xaf, 0xbf, 0x26, 0xca, 0x65, 0xf1, 0xea, 0xa8,
-	0x7a, 0xb7, 0x4c, 0xf6, 0xa5, 0xed, 0xec, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc7, 0x5c, 0x91,
-	0x04, 0xd6, 0x08, 0x00, 0x00,
+	0x14, 0xb7, 0xd9, 0x78, 0xe3, 0x3c, 0xef, 0x76, 0x61, 0xb4, 0xad, 0xcc, 0x02, 0xd9, 0xc5, 0x5c,
+	0x56, 0x08, 0x39, 0x28, 0x7b, 0x40, 0x2a, 0x20, 0xc0, 0xfc, 0xa9, 0x8b, 0xba, 0x6d, 0xe4, 0x94,
+	0x0a, 0x71, 0xb1, 0x9c, 0x78, 0x94, 0x0c, 0x8d, 0x3d, 0x96, 0x67, 0x76, 0x97, 0xbd, 0xf2, 0x09,
+	0xf8, 0x00, 0x7c, 0x01, 0x3e, 0x40, 0x25, 0x3e, 0x42, 0x8f, 0x3d, 0x72, 0xaa, 0x50, 0xf6, 0x23,
+	0x20, 0xee, 0x68, 0xc6, 0x93, 0x78, 0x42, 0xbd, 0x51, 0x73, 0xa9, 0xd4, 0xdb, 0x8c, 0xdf, 0x7b,
+	0xbf, 0xf9, 0xbd, 0xf7, 0xe6, 0xfd, 0x3c, 0x70, 0xc4, 0x71, 0x9e, 0xe2, 0x32, 0x23, 0x39, 0xef,
+	0x8d, 0x69, 0xce, 0x70, 0xce, 0xce, 0x58, 0x8f, 0x5f, 0x16, 0x98, 0xf9, 0x45, 0x49, 0x39, 0x45,
+	0xfb, 0xb5, 0x87, 0xbf, 0xf4, 0x38, 0xd8, 0x9f, 0xd0, 0x09, 0x95, 0x0e, 0x3d, 0xb1, 0xaa, 0x7c,
+	0x0f, 0xde, 0xd5, 0xd0, 0x24, 0x86, 0x8e, 0x74, 0xa0, 0x9f, 0x35, 0x23, 0x23, 0xd6, 0x1b, 0x11,
+	0xbe, 0xe2, 0xe1, 0x3d, 0x31, 0x61, 0xe7, 0x3e, 0xbe, 0x88, 0xe8, 0x59, 0x9e, 0x0e, 0x39, 0x2e,
+	0xd0, 0x2d, 0xd8, 0x9e, 0x62, 0x32, 0x99, 0x72, 0xd7, 0x3c, 0x32, 0x8f, 0xb7, 0x22, 0xb5, 0x43,
+	0xfb, 0x60, 0x95, 0xc2, 0xc9, 0x7d, 0xe3, 0xc8, 0x3c, 0xb6, 0xa2, 0x6a, 0x83, 0x10, 0xb4, 0x18,
+	0xc7, 0x85, 0xbb, 0x75, 0x64, 0x1e, 0xef, 0x46, 0x72, 0x8d, 0x3e, 0x01, 0x97, 0xe1, 0x31, 0xcd,
+	0x53, 0x16, 0x33, 0x92, 0x8f, 0x71, 0xcc, 0x78, 0x52, 0xf2, 0x98, 0x93, 0x0c, 0xbb, 0x2d, 0x89,
+	0x79, 0x53, 0xd9, 0x87, 0xc2, 0x3c, 0x14, 0xd6, 0x87, 0x24, 0xc3, 0xe8, 0x43, 0x78, 0x6b, 0x96,
+	0x30, 0x1e, 0x8f, 0x69, 0x96, 0x11, 0x1e, 0x57, 0xc7, 0x59, 0xf2, 0xb8, 0x3d, 0x61, 0xf8, 0x5a,
+	0x7e, 0x97, 0x54, 0xbd, 0x7f, 0x4d, 0xd8, 0xbd, 0x8f, 0x2f, 0x1e, 0x25, 0x33, 0x92, 0x06, 0x33,
+	0x3a, 0x7e, 0xbc, 0x21, 0xf1, 0x1f, 0xe1, 0xe6, 0x48, 0x84, 0xc5, 0x85, 0xe0, 0xc6, 0x30, 0x8f,
+	0xa7, 0x38, 0x49, 0x71, 0x29, 0x33, 0x71, 0xfa, 0x87, 0xbe, 0xd6, 0x83, 0xaa, 0x5e, 0x83, 0xa4,
+	0xe4, 0x43, 0xcc, 0x43, 0xe9, 0x16, 0xb4, 0x9e, 0x3e, 0x3f, 0x34, 0x22, 0x24, 0x31, 0x56, 0x2c,
+	0xe8, 0x0b, 0x70, 0x6a, 0x64, 0x26, 0x33, 0x76, 0xfa, 0x5d, 0x1d, 0x4f, 0x74, 0xc2, 0x17, 0x9d,
+	0xf0, 0x03, 0xc2, 0xbf, 0x2a, 0xcb, 0xe4, 0x32, 0x82, 0x25, 0x10, 0x43, 0xef, 0x40, 0x87, 0x30,
+	0x55, 0x04, 0x99, 0xbe, 0x1d, 0xd9, 0x84, 0x55, 0xc9, 0x7b, 0x21, 0xd8, 0x83, 0x92, 0x16, 0x94,
+	0x25, 0x33, 0xf4, 0x19, 0xd8, 0x85, 0x5a, 0xcb, 0x9c, 0x9d, 0xfe, 0x41, 0x03, 0x6d, 0xe5, 0xa1,
+	0x18, 0x2f, 0x23, 0xbc, 0xdf, 0x4d, 0x70, 0x16, 0xc6, 0xc1, 0x83, 0x7b, 0xd7, 0xd6, 0xef, 0x23,
+	0x40, 0x8b, 0x98, 0xb8, 0xa0, 0xb3, 0x58, 0x2f, 0xe6, 0x9b, 0x0b, 0xcb, 0x80, 0xce, 0x64, 0x5f,
+	0xd0, 0x1d, 0xd8, 0xd1, 0xbd, 0xdd, 0xad, 0x97, 0x49, 0x5f, 0x71, 0x73, 0x34, 0x34, 0xef, 0x31,
+	0x74, 0x82, 0x45, 0x4d, 0x36, 0xec, 0xed, 0xc7, 0xd0, 0x12, 0xb5, 0x57, 0x67, 0xdf, 0x6a, 0x6e,
+	0xa5, 0x3a, 0x53, 0x7a, 0x7a, 0x7d, 0x68, 0x3d, 0xa2, 0x5c, 0xdc, 0xc0, 0xd6, 0x39, 0xe5, 0xd8,
+	0x35, 0xaf, 0x8b, 0x14, 0x5e, 0x91, 0xf4, 0xf1, 0x7e, 0x35, 0xa1, 0x1d, 0x26, 0x4c, 0xc6, 0x6d,
+	0xc6, 0xef, 0x04, 0x5a, 0x02, 0x4d, 0xf2, 0xbb, 0xd1, 0x74, 0xd5, 0x86, 0x64, 0x92, 0xe3, 0xf4,
+	0x94, 0x4d, 0x1e, 0x5e, 0x16, 0x38, 0x92, 0xce, 0x02, 0x8a, 0xe4, 0x29, 0xfe, 0x45, 0x5e, 0x28,
+	0x2b, 0xaa, 0x36, 0xde, 0x1f, 0x26, 0xd8, 0x8a, 0x04, 0x7b, 0x15, 0x2c, 0x6e, 0x83, 0x25, 0x92,
+	0x7f, 0xc9, 0x6b, 0xad, 0x6a, 0x5c, 0x85, 0x78, 0x7f, 0x9a, 0xb0, 0x23, 0x88, 0x0e, 0x31, 0x3f,
+	0x4d, 0x7e, 0xee, 0x9f, 0xbc, 0x0a, 0xbe, 0xdf, 0x82, 0x5d, 0x0d, 0x23, 0x49, 0x15, 0xe5, 0xb7,
+	0x5f, 0x0c, 0x94, 0xf7, 0xec, 0xee, 0x37, 0xc1, 0x9e, 0x60, 0x3b, 0x7f, 0x7e, 0xd8, 0x56, 0x1f,
+	0xa2, 0xb6, 0x8c, 0xbd, 0x9b, 0x7a, 0xff, 0x98, 0xe0, 0x28, 0xea, 0x01, 0xe1, 0xec, 0xf5, 0x61,
+	0x5e, 0x37, 0xcc, 0xda, 0xbc, 0x61, 0x4f, 0x2c, 0x68, 0x9f, 0x62, 0xc6, 0x92, 0x09, 0x46, 0xdf,
+	0xc3, 0x8d, 0x1c, 0x5f, 0x54, 0xc3, 0x1f, 0x4b, 0xc9, 0xaf, 0x66, 0xc4, 0xf3, 0x9b, 0x7e, 0x56,
+	0xbe, 0xfe, 0x4b, 0x09, 0x8d, 0x68, 0x27, 0xd7, 0xf6, 0xe8, 0x14, 0xf6, 0x04, 0xd6, 0xb9, 0xd0,
+	0xee, 0x58, 0x12, 0x95, 0xf5, 0x72, 0xfa, 0x1f, 0x5c, 0x0b, 0x56, 0xeb, 0x7c, 0x68, 0x44, 0xbb,
+	0xb9, 0xfe, 0x61, 0x45, 0x06, 0x1b, 0xe4, 0xa6, 0xc6, 0x59, 0xa8, 0x5d, 0xa8, 0xc9, 0x20, 0xfa,
+	0xee, 0x7f, 0x82, 0x55, 0xd5, 0xfa, 0xfd, 0xf5, 0x08, 0x83, 0x07, 0xf7, 0xc2, 0x55, 0xbd, 0x42,
+	0x5f, 0x02, 0xd4, 0xb2, 0xaf, 0xaa, 0x7d, 0xd8, 0x8c, 0xb2, 0xd4, 0xb5, 0xd0, 0x88, 0x3a, 0x4b,
+	0xe1, 0x17, 0xb2, 0x25, 0xc5, 0x67, 0xfb, 0x45, 0x29, 0xaf, 0x63, 0xc5, 0x2d, 0x0c, 0x8d, 0x4a,
+	0x82, 0xd0, 0x6d, 0xb0, 0xa7, 0x09, 0x8b, 0x65, 0x54, 0x5b, 0x46, 0xbd, 0xd7, 0x1c, 0xa5, 0x24,
+	0x22, 0x34, 0xa2, 0xf6, 0xb4, 0x5a, 0x8a, 0x86, 0x8a, 0x38, 0xf9, 0xeb, 0xcb, 0xc4, 0x38, 0xba,
+	0xf6, 0xba, 0x86, 0xea, 0x83, 0x2b, 0x1a, 0x7a, 0xae, 0x0f, 0xf2, 0x1d, 0xd8, 0x5d, 0x62, 0x89,
+	0xfb, 0xe4, 0x76, 0xd6, 0x15, 0x51, 0x1b, 0x24, 0x51, 0xc4, 0xf3, 0x7a, 0x8b, 0x3e, 0x87, 0xce,
+	0x22, 0x21, 0xe6, 0xc2, 0xba, 0x5e, 0x2e, 0x44, 0x4f, 0xf4, 0x52, 0xa5, 0xc4, 0x02, 0x0b, 0xb6,
+	0xd8, 0x59, 0x16, 0xfc, 0xf0, 0x74, 0xde, 0x35, 0x9f, 0xcd, 0xbb, 0xe6, 0xdf, 0xf3, 0xae, 0xf9,
+	0xdb, 0x55, 0xd7, 0x78, 0x76, 0xd5, 0x35, 0xfe, 0xba, 0xea, 0x1a, 0x3f, 0x7d, 0x3a, 0x21, 0x7c,
+	0x7a, 0x36, 0xf2, 0xc7, 0x34, 0xeb, 0xe9, 0x0f, 0xa7, 0x7a, 0x59, 0x3d, 0xb0, 0x9a, 0x9e, 0x68,
+	0xa3, 0x6d, 0x69, 0x3b, 0xf9, 0x6f, 0x00, 0xe6, 0xca, 0xd6, 0xe9, 0xc1, 0x09, 0x00, 0x00,
 }
 
 func (m *NewRoundStep) Marshal() (dAtA []byte, err error) {
@@ -1168,6 +1253,54 @@ func (m *HasVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *HasVotes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HasVotes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HasVotes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Votes.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	if m.Type != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *VoteSetMaj23) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1495,6 +1628,27 @@ func (m *Message_VoteSetBits) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_HasVotes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_HasVotes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.HasVotes != nil {
+		{
+			size, err := m.HasVotes.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -1633,6 +1787,26 @@ func (m *HasVote) Size() (n int) {
 	return n
 }
 
+func (m *HasVotes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if m.Type != 0 {
+		n += 1 + sovTypes(uint64(m.Type))
+	}
+	l = m.Votes.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
 func (m *VoteSetMaj23) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1795,6 +1969,18 @@ func (m *Message_VoteSetBits) Size() (n int) {
 	}
 	return n
 }
+func (m *Message_HasVotes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HasVotes != nil {
+		l = m.HasVotes.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -2682,6 +2868,149 @@ func (m *HasVote) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *HasVotes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HasVotes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HasVotes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= types.SignedMsgType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Votes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *VoteSetMaj23) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3345,6 +3674,41 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_VoteSetBits{v}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasVotes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &HasVotes{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_HasVotes{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
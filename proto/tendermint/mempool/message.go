@@ -0,0 +1,30 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// Wrap wraps a mempool message (Txs) into a Message, the envelope of the
+// messages gossiped on the mempool channel.
+func Wrap(pb proto.Message) (*Message, error) {
+	msg := &Message{}
+	switch pb := pb.(type) {
+	case *Txs:
+		msg.Sum = &Message_Txs{Txs: pb}
+	default:
+		return nil, fmt.Errorf("unknown message type %T", pb)
+	}
+	return msg, nil
+}
+
+// Unwrap returns the mempool message wrapped into m.
+func (m *Message) Unwrap() (proto.Message, error) {
+	switch msg := m.Sum.(type) {
+	case *Message_Txs:
+		return msg.Txs, nil
+	default:
+		return nil, fmt.Errorf("unknown message type %T", msg)
+	}
+}
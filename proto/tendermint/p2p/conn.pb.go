@@ -306,43 +306,137 @@ func (m *AuthSigMessage) GetSig() []byte {
 	return nil
 }
 
+// AuthenticatedMessage is a message sent to a peer, signed with the node key
+// of its sender so that it can be attributed to the sender.
+type AuthenticatedMessage struct {
+	SenderID   string `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	ReceiverID string `protobuf:"bytes,2,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
+	ChannelID  int32  `protobuf:"varint,3,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Sequence   uint64 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Payload    []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature  []byte `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *AuthenticatedMessage) Reset()         { *m = AuthenticatedMessage{} }
+func (m *AuthenticatedMessage) String() string { return proto.CompactTextString(m) }
+func (*AuthenticatedMessage) ProtoMessage()    {}
+func (*AuthenticatedMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_22474b5527c8fa9f, []int{5}
+}
+func (m *AuthenticatedMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AuthenticatedMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AuthenticatedMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AuthenticatedMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthenticatedMessage.Merge(m, src)
+}
+func (m *AuthenticatedMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *AuthenticatedMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthenticatedMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthenticatedMessage proto.InternalMessageInfo
+
+func (m *AuthenticatedMessage) GetSenderID() string {
+	if m != nil {
+		return m.SenderID
+	}
+	return ""
+}
+
+func (m *AuthenticatedMessage) GetReceiverID() string {
+	if m != nil {
+		return m.ReceiverID
+	}
+	return ""
+}
+
+func (m *AuthenticatedMessage) GetChannelID() int32 {
+	if m != nil {
+		return m.ChannelID
+	}
+	return 0
+}
+
+func (m *AuthenticatedMessage) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *AuthenticatedMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *AuthenticatedMessage) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PacketPing)(nil), "tendermint.p2p.PacketPing")
 	proto.RegisterType((*PacketPong)(nil), "tendermint.p2p.PacketPong")
 	proto.RegisterType((*PacketMsg)(nil), "tendermint.p2p.PacketMsg")
 	proto.RegisterType((*Packet)(nil), "tendermint.p2p.Packet")
 	proto.RegisterType((*AuthSigMessage)(nil), "tendermint.p2p.AuthSigMessage")
+	proto.RegisterType((*AuthenticatedMessage)(nil), "tendermint.p2p.AuthenticatedMessage")
 }
 
 func init() { proto.RegisterFile("tendermint/p2p/conn.proto", fileDescriptor_22474b5527c8fa9f) }
 
 var fileDescriptor_22474b5527c8fa9f = []byte{
-	// 395 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x3d, 0x8f, 0xd3, 0x40,
-	0x10, 0xf5, 0xe2, 0xbb, 0x1c, 0x99, 0x84, 0x13, 0x5a, 0x51, 0x24, 0xd1, 0xc9, 0x89, 0x5c, 0xa5,
-	0x40, 0xb6, 0x64, 0x44, 0x03, 0xa2, 0xc0, 0x7c, 0x88, 0xd3, 0x29, 0xba, 0xc8, 0x74, 0x34, 0x96,
-	0x3f, 0x96, 0xf5, 0x2a, 0xe7, 0xdd, 0x55, 0x76, 0x5d, 0xf8, 0x5f, 0xf0, 0xb3, 0x8e, 0xee, 0x4a,
-	0xaa, 0x08, 0x39, 0x7f, 0x04, 0x79, 0x1d, 0x88, 0x23, 0x71, 0xdd, 0x7b, 0x33, 0xf3, 0xe6, 0x43,
-	0xf3, 0x60, 0xaa, 0x09, 0xcf, 0xc9, 0xb6, 0x64, 0x5c, 0xfb, 0x32, 0x90, 0x7e, 0x26, 0x38, 0xf7,
-	0xe4, 0x56, 0x68, 0x81, 0x2f, 0x8f, 0x29, 0x4f, 0x06, 0x72, 0xf6, 0x82, 0x0a, 0x2a, 0x4c, 0xca,
-	0x6f, 0x51, 0x57, 0x35, 0xbb, 0xea, 0x35, 0xc8, 0xb6, 0xb5, 0xd4, 0xc2, 0xdf, 0x90, 0x5a, 0x75,
-	0x59, 0x77, 0x0c, 0xb0, 0x4e, 0xb2, 0x0d, 0xd1, 0x6b, 0xc6, 0x69, 0x8f, 0x09, 0x4e, 0xdd, 0x02,
-	0x86, 0x1d, 0x5b, 0x29, 0x8a, 0x5f, 0x02, 0x64, 0x45, 0xc2, 0x39, 0xb9, 0x8b, 0x59, 0x3e, 0x41,
-	0x0b, 0xb4, 0x3c, 0x0f, 0x9f, 0x35, 0xbb, 0xf9, 0xf0, 0x43, 0x17, 0xbd, 0xfe, 0x18, 0x0d, 0x0f,
-	0x05, 0xd7, 0x39, 0x9e, 0x82, 0x4d, 0xc4, 0xf7, 0xc9, 0x93, 0x05, 0x5a, 0x3e, 0x0d, 0x2f, 0x9a,
-	0xdd, 0xdc, 0xfe, 0x74, 0xfb, 0x39, 0x6a, 0x63, 0x18, 0xc3, 0x59, 0x9e, 0xe8, 0x64, 0x62, 0x2f,
-	0xd0, 0x72, 0x1c, 0x19, 0xec, 0xfe, 0x44, 0x30, 0xe8, 0x46, 0xe1, 0x77, 0x30, 0x92, 0x06, 0xc5,
-	0x92, 0x71, 0x6a, 0x06, 0x8d, 0x82, 0x99, 0x77, 0x7a, 0xaa, 0x77, 0xdc, 0xf9, 0x8b, 0x15, 0x81,
-	0xfc, 0xc7, 0xfa, 0x72, 0xc1, 0xa9, 0x59, 0xe0, 0x71, 0xb9, 0x38, 0x91, 0x0b, 0x4e, 0xf1, 0x1b,
-	0x38, 0xb0, 0xb8, 0x54, 0xd4, 0xac, 0x38, 0x0a, 0xa6, 0xff, 0x57, 0xaf, 0x54, 0x2b, 0x1e, 0xca,
-	0xbf, 0x24, 0x3c, 0x07, 0x5b, 0x55, 0xa5, 0x1b, 0xc3, 0xe5, 0xfb, 0x4a, 0x17, 0x5f, 0x19, 0x5d,
-	0x11, 0xa5, 0x12, 0x4a, 0xf0, 0x5b, 0xb8, 0x90, 0x55, 0x1a, 0x6f, 0x48, 0x7d, 0x38, 0xe7, 0xaa,
-	0xdf, 0xb1, 0xfb, 0x89, 0xb7, 0xae, 0xd2, 0x3b, 0x96, 0xdd, 0x90, 0x3a, 0x3c, 0xbb, 0xdf, 0xcd,
-	0xad, 0x68, 0x20, 0xab, 0xf4, 0x86, 0xd4, 0xf8, 0x39, 0xd8, 0x8a, 0x75, 0x87, 0x8c, 0xa3, 0x16,
-	0x86, 0xb7, 0xf7, 0x8d, 0x83, 0x1e, 0x1a, 0x07, 0xfd, 0x6e, 0x1c, 0xf4, 0x63, 0xef, 0x58, 0x0f,
-	0x7b, 0xc7, 0xfa, 0xb5, 0x77, 0xac, 0x6f, 0xaf, 0x29, 0xd3, 0x45, 0x95, 0x7a, 0x99, 0x28, 0xfd,
-	0xde, 0xd7, 0xfb, 0x0e, 0x32, 0xee, 0x38, 0xb5, 0x54, 0x3a, 0x30, 0xd1, 0x57, 0x7f, 0x02, 0x00,
-	0x00, 0xff, 0xff, 0x30, 0xfd, 0xb2, 0x8d, 0x6b, 0x02, 0x00, 0x00,
+	// 502 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x4f, 0x8f, 0xd2, 0x40,
+	0x14, 0xa7, 0x5b, 0x96, 0xa5, 0x0f, 0x24, 0x66, 0xb2, 0x87, 0x42, 0x48, 0x21, 0x9c, 0x30, 0x31,
+	0x6d, 0x82, 0xf1, 0xa2, 0xf1, 0x60, 0x45, 0x63, 0xb3, 0x21, 0x4b, 0xba, 0x37, 0x2f, 0xa4, 0xb4,
+	0xe3, 0x30, 0x01, 0x66, 0x46, 0x66, 0x6a, 0xd2, 0x6f, 0xe1, 0xc7, 0x5a, 0x6f, 0x7b, 0xf4, 0x44,
+	0x4c, 0xf9, 0x0a, 0x7e, 0x00, 0xc3, 0x14, 0x96, 0x62, 0x8c, 0xde, 0xde, 0xef, 0xdf, 0x7b, 0x7d,
+	0x79, 0x1d, 0x68, 0x2b, 0xcc, 0x12, 0xbc, 0x59, 0x53, 0xa6, 0x3c, 0x31, 0x12, 0x5e, 0xcc, 0x19,
+	0x73, 0xc5, 0x86, 0x2b, 0x8e, 0x5a, 0x27, 0xc9, 0x15, 0x23, 0xd1, 0xb9, 0x26, 0x9c, 0x70, 0x2d,
+	0x79, 0xfb, 0xaa, 0x70, 0x75, 0xba, 0xa5, 0x06, 0xf1, 0x26, 0x13, 0x8a, 0x7b, 0x4b, 0x9c, 0xc9,
+	0x42, 0x1d, 0x34, 0x01, 0xa6, 0x51, 0xbc, 0xc4, 0x6a, 0x4a, 0x19, 0x29, 0x21, 0xce, 0xc8, 0x60,
+	0x01, 0x56, 0x81, 0x26, 0x92, 0xa0, 0xe7, 0x00, 0xf1, 0x22, 0x62, 0x0c, 0xaf, 0x66, 0x34, 0xb1,
+	0x8d, 0xbe, 0x31, 0xbc, 0xf4, 0x9f, 0xe4, 0xdb, 0x9e, 0xf5, 0xae, 0x60, 0x83, 0x71, 0x68, 0x1d,
+	0x0c, 0x41, 0x82, 0xda, 0x60, 0x62, 0xfe, 0xd9, 0xbe, 0xe8, 0x1b, 0xc3, 0xba, 0x7f, 0x95, 0x6f,
+	0x7b, 0xe6, 0xfb, 0xdb, 0x0f, 0xe1, 0x9e, 0x43, 0x08, 0xaa, 0x49, 0xa4, 0x22, 0xdb, 0xec, 0x1b,
+	0xc3, 0x66, 0xa8, 0xeb, 0xc1, 0x77, 0x03, 0x6a, 0xc5, 0x28, 0xf4, 0x06, 0x1a, 0x42, 0x57, 0x33,
+	0x41, 0x19, 0xd1, 0x83, 0x1a, 0xa3, 0x8e, 0x7b, 0xbe, 0xaa, 0x7b, 0xfa, 0xe6, 0x8f, 0x95, 0x10,
+	0xc4, 0x23, 0x2a, 0xc7, 0x39, 0x23, 0xf6, 0xc5, 0x3f, 0xe3, 0xfc, 0x2c, 0xce, 0x19, 0x41, 0xaf,
+	0xe0, 0x80, 0x66, 0x6b, 0x49, 0xf4, 0x27, 0x36, 0x46, 0xed, 0xbf, 0xa7, 0x27, 0x72, 0x1f, 0xb6,
+	0xc4, 0x11, 0xf8, 0x97, 0x60, 0xca, 0x74, 0x3d, 0x98, 0x41, 0xeb, 0x6d, 0xaa, 0x16, 0x77, 0x94,
+	0x4c, 0xb0, 0x94, 0x11, 0xc1, 0xe8, 0x35, 0x5c, 0x89, 0x74, 0x3e, 0x5b, 0xe2, 0xec, 0xb0, 0x4e,
+	0xb7, 0xdc, 0xb1, 0xb8, 0x89, 0x3b, 0x4d, 0xe7, 0x2b, 0x1a, 0xdf, 0xe0, 0xcc, 0xaf, 0xde, 0x6f,
+	0x7b, 0x95, 0xb0, 0x26, 0xd2, 0xf9, 0x0d, 0xce, 0xd0, 0x53, 0x30, 0x25, 0x2d, 0x16, 0x69, 0x86,
+	0xfb, 0x72, 0xf0, 0xcb, 0x80, 0xeb, 0xfd, 0x04, 0xcc, 0x14, 0x8d, 0x23, 0x85, 0x93, 0xe3, 0x9c,
+	0x67, 0x60, 0x49, 0xdd, 0xf7, 0x78, 0x21, 0xcb, 0x6f, 0xe6, 0xdb, 0x5e, 0xfd, 0x4e, 0x93, 0xc1,
+	0x38, 0xac, 0x17, 0x72, 0x90, 0x20, 0x0f, 0x1a, 0x1b, 0x1c, 0x63, 0xfa, 0xb5, 0x30, 0x5f, 0x68,
+	0x73, 0x2b, 0xdf, 0xf6, 0x20, 0x3c, 0xd0, 0xc1, 0x38, 0x84, 0xa3, 0x25, 0x48, 0xfe, 0x38, 0xbf,
+	0xf9, 0x9f, 0xf3, 0x77, 0xa0, 0x2e, 0xf1, 0x97, 0x14, 0xb3, 0x18, 0xdb, 0xd5, 0xbe, 0x31, 0xac,
+	0x86, 0x8f, 0x18, 0xd9, 0x70, 0x25, 0xa2, 0x6c, 0xc5, 0xa3, 0xc4, 0xbe, 0xd4, 0x4b, 0x1d, 0x21,
+	0xea, 0x82, 0x25, 0x29, 0x61, 0x91, 0x4a, 0x37, 0xd8, 0xae, 0x69, 0xed, 0x44, 0xf8, 0xb7, 0xf7,
+	0xb9, 0x63, 0x3c, 0xe4, 0x8e, 0xf1, 0x33, 0x77, 0x8c, 0x6f, 0x3b, 0xa7, 0xf2, 0xb0, 0x73, 0x2a,
+	0x3f, 0x76, 0x4e, 0xe5, 0xd3, 0x4b, 0x42, 0xd5, 0x22, 0x9d, 0xbb, 0x31, 0x5f, 0x7b, 0xa5, 0x9f,
+	0xbd, 0x54, 0x16, 0x8f, 0xe2, 0xfc, 0x25, 0xcd, 0x6b, 0x9a, 0x7d, 0xf1, 0x7b, 0x00, 0x77, 0xd5,
+	0x96, 0x77, 0x62, 0x03, 0x00, 0x00,
 }
 
 func (m *PacketPing) Marshal() (dAtA []byte, err error) {
@@ -571,6 +665,67 @@ func (m *AuthSigMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *AuthenticatedMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AuthenticatedMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AuthenticatedMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintConn(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintConn(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Sequence != 0 {
+		i = encodeVarintConn(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.ChannelID != 0 {
+		i = encodeVarintConn(dAtA, i, uint64(m.ChannelID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ReceiverID) > 0 {
+		i -= len(m.ReceiverID)
+		copy(dAtA[i:], m.ReceiverID)
+		i = encodeVarintConn(dAtA, i, uint64(len(m.ReceiverID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SenderID) > 0 {
+		i -= len(m.SenderID)
+		copy(dAtA[i:], m.SenderID)
+		i = encodeVarintConn(dAtA, i, uint64(len(m.SenderID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintConn(dAtA []byte, offset int, v uint64) int {
 	offset -= sovConn(v)
 	base := offset
@@ -682,6 +837,37 @@ func (m *AuthSigMessage) Size() (n int) {
 	return n
 }
 
+func (m *AuthenticatedMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SenderID)
+	if l > 0 {
+		n += 1 + l + sovConn(uint64(l))
+	}
+	l = len(m.ReceiverID)
+	if l > 0 {
+		n += 1 + l + sovConn(uint64(l))
+	}
+	if m.ChannelID != 0 {
+		n += 1 + sovConn(uint64(m.ChannelID))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovConn(uint64(m.Sequence))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovConn(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovConn(uint64(l))
+	}
+	return n
+}
+
 func sovConn(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1198,6 +1384,229 @@ func (m *AuthSigMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *AuthenticatedMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConn
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AuthenticatedMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AuthenticatedMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SenderID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConn
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConn
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConn
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SenderID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceiverID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConn
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConn
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConn
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReceiverID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			m.ChannelID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConn
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChannelID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConn
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConn
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConn
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConn
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConn
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConn
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConn
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConn(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConn
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConn
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipConn(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -246,6 +246,8 @@ func (m *DefaultNodeInfo) GetOther() DefaultNodeInfoOther {
 type DefaultNodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
 	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
+	// application metadata, e.g. the app version or feature flags
+	Extensions map[string]string `protobuf:"bytes,3,rep,name=extensions,proto3" json:"extensions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (m *DefaultNodeInfoOther) Reset()         { *m = DefaultNodeInfoOther{} }
@@ -295,47 +297,59 @@ func (m *DefaultNodeInfoOther) GetRPCAddress() string {
 	return ""
 }
 
+func (m *DefaultNodeInfoOther) GetExtensions() map[string]string {
+	if m != nil {
+		return m.Extensions
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*NetAddress)(nil), "tendermint.p2p.NetAddress")
 	proto.RegisterType((*ProtocolVersion)(nil), "tendermint.p2p.ProtocolVersion")
 	proto.RegisterType((*DefaultNodeInfo)(nil), "tendermint.p2p.DefaultNodeInfo")
 	proto.RegisterType((*DefaultNodeInfoOther)(nil), "tendermint.p2p.DefaultNodeInfoOther")
+	proto.RegisterMapType((map[string]string)(nil), "tendermint.p2p.DefaultNodeInfoOther.ExtensionsEntry")
 }
 
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 479 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x3d, 0x8f, 0xda, 0x40,
-	0x10, 0xc5, 0xc6, 0x7c, 0xdc, 0x10, 0x8e, 0xcb, 0x0a, 0x45, 0x3e, 0x0a, 0x1b, 0xa1, 0x14, 0x54,
-	0x20, 0x39, 0x4a, 0x91, 0x2e, 0x21, 0x34, 0x34, 0x77, 0xd6, 0x2a, 0x4a, 0x91, 0xc6, 0x02, 0xef,
-	0x1e, 0xac, 0x30, 0xbb, 0xab, 0xf5, 0x5e, 0x42, 0xfe, 0x45, 0x7e, 0xd6, 0x95, 0x57, 0xa6, 0xb2,
-	0x22, 0x53, 0xe6, 0x4f, 0x44, 0x5e, 0xfb, 0x12, 0x1f, 0x4a, 0x37, 0x6f, 0xbe, 0xde, 0xcc, 0xd3,
-	0x83, 0x91, 0xa6, 0x9c, 0x50, 0x75, 0x60, 0x5c, 0xcf, 0x65, 0x20, 0xe7, 0xfa, 0xbb, 0xa4, 0xe9,
-	0x4c, 0x2a, 0xa1, 0x05, 0xba, 0xfc, 0x57, 0x9b, 0xc9, 0x40, 0x8e, 0x86, 0x5b, 0xb1, 0x15, 0xa6,
-	0x34, 0x2f, 0xa2, 0xb2, 0x6b, 0x12, 0x02, 0xdc, 0x50, 0xfd, 0x81, 0x10, 0x45, 0xd3, 0x14, 0xbd,
-	0x02, 0x9b, 0x11, 0xd7, 0x1a, 0x5b, 0xd3, 0x8b, 0x45, 0x3b, 0xcf, 0x7c, 0x7b, 0xb5, 0xc4, 0x36,
-	0x23, 0x26, 0x2f, 0x5d, 0xbb, 0x96, 0x0f, 0xb1, 0xcd, 0x24, 0x42, 0xe0, 0x48, 0xa1, 0xb4, 0xdb,
-	0x1c, 0x5b, 0xd3, 0x3e, 0x36, 0xf1, 0xe4, 0x13, 0x0c, 0xc2, 0x62, 0x75, 0x2c, 0x92, 0xcf, 0x54,
-	0xa5, 0x4c, 0x70, 0x74, 0x0d, 0x4d, 0x19, 0x48, 0xb3, 0xd7, 0x59, 0x74, 0xf2, 0xcc, 0x6f, 0x86,
-	0x41, 0x88, 0x8b, 0x1c, 0x1a, 0x42, 0x6b, 0x93, 0x88, 0x78, 0x6f, 0x96, 0x3b, 0xb8, 0x04, 0xe8,
-	0x0a, 0x9a, 0x6b, 0x29, 0xcd, 0x5a, 0x07, 0x17, 0xe1, 0xe4, 0xb7, 0x0d, 0x83, 0x25, 0xbd, 0x5b,
-	0xdf, 0x27, 0xfa, 0x46, 0x10, 0xba, 0xe2, 0x77, 0x02, 0x85, 0x70, 0x25, 0x2b, 0xa6, 0xe8, 0x6b,
-	0x49, 0x65, 0x38, 0x7a, 0x81, 0x3f, 0x7b, 0xfe, 0xfc, 0xec, 0xec, 0xa2, 0x85, 0xf3, 0x90, 0xf9,
-	0x0d, 0x3c, 0x90, 0x67, 0x87, 0xbe, 0x83, 0x01, 0x29, 0x49, 0x22, 0x2e, 0x08, 0x8d, 0x18, 0xa9,
-	0x9e, 0x7e, 0x99, 0x67, 0x7e, 0xbf, 0xce, 0xbf, 0xc4, 0x7d, 0x52, 0x83, 0x04, 0xf9, 0xd0, 0x4b,
-	0x58, 0xaa, 0x29, 0x8f, 0xd6, 0x84, 0x28, 0x73, 0xfa, 0x05, 0x86, 0x32, 0x55, 0xc8, 0x8b, 0x5c,
-	0xe8, 0x70, 0xaa, 0xbf, 0x09, 0xb5, 0x77, 0x1d, 0x53, 0x7c, 0x82, 0x45, 0xe5, 0xe9, 0xfc, 0x56,
-	0x59, 0xa9, 0x20, 0x1a, 0x41, 0x37, 0xde, 0xad, 0x39, 0xa7, 0x49, 0xea, 0xb6, 0xc7, 0xd6, 0xf4,
-	0x05, 0xfe, 0x8b, 0x8b, 0xa9, 0x83, 0xe0, 0x6c, 0x4f, 0x95, 0xdb, 0x29, 0xa7, 0x2a, 0x88, 0xde,
-	0x43, 0x4b, 0xe8, 0x1d, 0x55, 0x6e, 0xd7, 0x88, 0xf1, 0xfa, 0x5c, 0x8c, 0x33, 0x1d, 0x6f, 0x8b,
-	0xde, 0x4a, 0x91, 0x72, 0x70, 0xb2, 0x81, 0xe1, 0xff, 0x9a, 0xd0, 0x35, 0x74, 0xf5, 0x31, 0x62,
-	0x9c, 0xd0, 0x63, 0xe9, 0x12, 0xdc, 0xd1, 0xc7, 0x55, 0x01, 0xd1, 0x1c, 0x7a, 0x4a, 0xc6, 0xe6,
-	0x79, 0x9a, 0xa6, 0x95, 0x6c, 0x97, 0x79, 0xe6, 0x03, 0x0e, 0x3f, 0x56, 0xfe, 0xc2, 0xa0, 0x64,
-	0x5c, 0xc5, 0x8b, 0xdb, 0x87, 0xdc, 0xb3, 0x1e, 0x73, 0xcf, 0xfa, 0x95, 0x7b, 0xd6, 0x8f, 0x93,
-	0xd7, 0x78, 0x3c, 0x79, 0x8d, 0x9f, 0x27, 0xaf, 0xf1, 0xe5, 0xed, 0x96, 0xe9, 0xdd, 0xfd, 0x66,
-	0x16, 0x8b, 0xc3, 0xbc, 0x66, 0xf0, 0xba, 0xd7, 0x8d, 0x8d, 0x9f, 0x9b, 0x7f, 0xd3, 0x36, 0xd9,
-	0x37, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x6c, 0xb3, 0x68, 0x97, 0x15, 0x03, 0x00, 0x00,
+	// 540 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x3d, 0x6f, 0xdb, 0x30,
+	0x10, 0xb5, 0x3e, 0x12, 0x27, 0xe7, 0x26, 0x4e, 0x89, 0xa0, 0x50, 0x3c, 0x48, 0x86, 0xd1, 0xc1,
+	0x93, 0x0c, 0xa8, 0x2d, 0xd0, 0x16, 0x28, 0xd0, 0xba, 0xc9, 0x90, 0x25, 0x11, 0x88, 0xa0, 0x43,
+	0x17, 0xc3, 0x11, 0x99, 0x44, 0xb0, 0x42, 0x12, 0x14, 0x93, 0xda, 0xff, 0xa2, 0x3f, 0x2b, 0x63,
+	0xc6, 0x4e, 0x46, 0x21, 0x8f, 0xfd, 0x01, 0x5d, 0x0b, 0x92, 0x4a, 0xaa, 0x18, 0x1d, 0xba, 0xdd,
+	0xbb, 0xc7, 0xbb, 0x7b, 0xf7, 0x70, 0x84, 0x9e, 0xa2, 0x8c, 0x50, 0x79, 0x9d, 0x33, 0x35, 0x12,
+	0x89, 0x18, 0xa9, 0x85, 0xa0, 0x65, 0x2c, 0x24, 0x57, 0x1c, 0xed, 0xfe, 0xe5, 0x62, 0x91, 0x88,
+	0xde, 0xfe, 0x25, 0xbf, 0xe4, 0x86, 0x1a, 0xe9, 0xc8, 0xbe, 0x1a, 0xa4, 0x00, 0x27, 0x54, 0x7d,
+	0x22, 0x44, 0xd2, 0xb2, 0x44, 0x2f, 0xc0, 0xcd, 0x49, 0xe0, 0xf4, 0x9d, 0xe1, 0xf6, 0x78, 0xb3,
+	0x5a, 0x46, 0xee, 0xf1, 0x21, 0x76, 0x73, 0x62, 0xf2, 0x22, 0x70, 0x1b, 0xf9, 0x14, 0xbb, 0xb9,
+	0x40, 0x08, 0x7c, 0xc1, 0xa5, 0x0a, 0xbc, 0xbe, 0x33, 0xdc, 0xc1, 0x26, 0x1e, 0x9c, 0x41, 0x37,
+	0xd5, 0xad, 0x33, 0x5e, 0x7c, 0xa1, 0xb2, 0xcc, 0x39, 0x43, 0x07, 0xe0, 0x89, 0x44, 0x98, 0xbe,
+	0xfe, 0xb8, 0x5d, 0x2d, 0x23, 0x2f, 0x4d, 0x52, 0xac, 0x73, 0x68, 0x1f, 0x36, 0xce, 0x0b, 0x9e,
+	0xcd, 0x4c, 0x73, 0x1f, 0x5b, 0x80, 0xf6, 0xc0, 0x9b, 0x0a, 0x61, 0xda, 0xfa, 0x58, 0x87, 0x83,
+	0x5f, 0x2e, 0x74, 0x0f, 0xe9, 0xc5, 0xf4, 0xa6, 0x50, 0x27, 0x9c, 0xd0, 0x63, 0x76, 0xc1, 0x51,
+	0x0a, 0x7b, 0xa2, 0x9e, 0x34, 0xb9, 0xb5, 0xa3, 0xcc, 0x8c, 0x4e, 0x12, 0xc5, 0x4f, 0x97, 0x8f,
+	0xd7, 0x14, 0x8d, 0xfd, 0xbb, 0x65, 0xd4, 0xc2, 0x5d, 0xb1, 0x26, 0xf4, 0x1d, 0x74, 0x89, 0x1d,
+	0x32, 0x61, 0x9c, 0xd0, 0x49, 0x4e, 0xea, 0xa5, 0x9f, 0x57, 0xcb, 0x68, 0xa7, 0x39, 0xff, 0x10,
+	0xef, 0x90, 0x06, 0x24, 0x28, 0x82, 0x4e, 0x91, 0x97, 0x8a, 0xb2, 0xc9, 0x94, 0x10, 0x69, 0xa4,
+	0x6f, 0x63, 0xb0, 0x29, 0x6d, 0x2f, 0x0a, 0xa0, 0xcd, 0xa8, 0xfa, 0xc6, 0xe5, 0x2c, 0xf0, 0x0d,
+	0xf9, 0x00, 0x35, 0xf3, 0x20, 0x7f, 0xc3, 0x32, 0x35, 0x44, 0x3d, 0xd8, 0xca, 0xae, 0xa6, 0x8c,
+	0xd1, 0xa2, 0x0c, 0x36, 0xfb, 0xce, 0xf0, 0x19, 0x7e, 0xc4, 0xba, 0xea, 0x9a, 0xb3, 0x7c, 0x46,
+	0x65, 0xd0, 0xb6, 0x55, 0x35, 0x44, 0x1f, 0x61, 0x83, 0xab, 0x2b, 0x2a, 0x83, 0x2d, 0x63, 0xc6,
+	0xcb, 0x75, 0x33, 0xd6, 0x7c, 0x3c, 0xd5, 0x6f, 0x6b, 0x47, 0x6c, 0xe1, 0xe0, 0xb7, 0x03, 0xfb,
+	0xff, 0x7a, 0x85, 0x0e, 0x60, 0x4b, 0xcd, 0x27, 0x39, 0x23, 0x74, 0x6e, 0xcf, 0x04, 0xb7, 0xd5,
+	0xfc, 0x58, 0x43, 0x34, 0x82, 0x8e, 0x14, 0x99, 0xd9, 0x9e, 0x96, 0x65, 0xed, 0xdb, 0x6e, 0xb5,
+	0x8c, 0x00, 0xa7, 0x9f, 0xeb, 0x03, 0xc3, 0x20, 0x45, 0x56, 0xc7, 0xe8, 0x0c, 0x80, 0xce, 0x15,
+	0x65, 0x7a, 0xd3, 0x32, 0xf0, 0xfa, 0xde, 0xb0, 0x93, 0xbc, 0xfe, 0x1f, 0xad, 0xf1, 0xd1, 0x63,
+	0xd9, 0x11, 0x53, 0x72, 0x81, 0x1b, 0x7d, 0x7a, 0x1f, 0xa0, 0xbb, 0x46, 0xeb, 0x6b, 0x9a, 0xd1,
+	0x45, 0xad, 0x57, 0x87, 0xfa, 0xea, 0x6e, 0xa7, 0xc5, 0x0d, 0xb5, 0x2a, 0xb1, 0x05, 0xef, 0xdd,
+	0xb7, 0xce, 0xf8, 0xf4, 0xae, 0x0a, 0x9d, 0xfb, 0x2a, 0x74, 0x7e, 0x56, 0xa1, 0xf3, 0x7d, 0x15,
+	0xb6, 0xee, 0x57, 0x61, 0xeb, 0xc7, 0x2a, 0x6c, 0x7d, 0x7d, 0x73, 0x99, 0xab, 0xab, 0x9b, 0xf3,
+	0x38, 0xe3, 0xd7, 0xa3, 0xc6, 0xb7, 0x6b, 0x84, 0xf6, 0x73, 0x3d, 0xfd, 0x92, 0xe7, 0x9b, 0x26,
+	0xfb, 0xea, 0xcf, 0x00, 0x2c, 0x1b, 0xdb, 0x90, 0xab, 0x03, 0x00, 0x00,
 }
 
 func (m *NetAddress) Marshal() (dAtA []byte, err error) {
@@ -523,6 +537,25 @@ func (m *DefaultNodeInfoOther) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Extensions) > 0 {
+		for k := range m.Extensions {
+			v := m.Extensions[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintTypes(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintTypes(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintTypes(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.RPCAddress) > 0 {
 		i -= len(m.RPCAddress)
 		copy(dAtA[i:], m.RPCAddress)
@@ -640,6 +673,14 @@ func (m *DefaultNodeInfoOther) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.Extensions) > 0 {
+		for k, v := range m.Extensions {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovTypes(uint64(len(k))) + 1 + len(v) + sovTypes(uint64(len(v)))
+			n += mapEntrySize + 1 + sovTypes(uint64(mapEntrySize))
+		}
+	}
 	return n
 }
 
@@ -1301,6 +1342,133 @@ func (m *DefaultNodeInfoOther) Unmarshal(dAtA []byte) error {
 			}
 			m.RPCAddress = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extensions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Extensions == nil {
+				m.Extensions = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthTypes
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthTypes
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthTypes
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthTypes
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipTypes(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthTypes
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Extensions[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
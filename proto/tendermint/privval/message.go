@@ -0,0 +1,58 @@
+package privval
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// Wrap wraps a remote signer request or response into a Message, the envelope
+// of the messages of the socket protocol.
+func Wrap(pb proto.Message) (*Message, error) {
+	msg := &Message{}
+	switch pb := pb.(type) {
+	case *PubKeyRequest:
+		msg.Sum = &Message_PubKeyRequest{PubKeyRequest: pb}
+	case *PubKeyResponse:
+		msg.Sum = &Message_PubKeyResponse{PubKeyResponse: pb}
+	case *SignVoteRequest:
+		msg.Sum = &Message_SignVoteRequest{SignVoteRequest: pb}
+	case *SignedVoteResponse:
+		msg.Sum = &Message_SignedVoteResponse{SignedVoteResponse: pb}
+	case *SignProposalRequest:
+		msg.Sum = &Message_SignProposalRequest{SignProposalRequest: pb}
+	case *SignedProposalResponse:
+		msg.Sum = &Message_SignedProposalResponse{SignedProposalResponse: pb}
+	case *PingRequest:
+		msg.Sum = &Message_PingRequest{PingRequest: pb}
+	case *PingResponse:
+		msg.Sum = &Message_PingResponse{PingResponse: pb}
+	default:
+		return nil, fmt.Errorf("unknown message type %T", pb)
+	}
+	return msg, nil
+}
+
+// Unwrap returns the remote signer request or response wrapped into m.
+func (m *Message) Unwrap() (proto.Message, error) {
+	switch msg := m.Sum.(type) {
+	case *Message_PubKeyRequest:
+		return msg.PubKeyRequest, nil
+	case *Message_PubKeyResponse:
+		return msg.PubKeyResponse, nil
+	case *Message_SignVoteRequest:
+		return msg.SignVoteRequest, nil
+	case *Message_SignedVoteResponse:
+		return msg.SignedVoteResponse, nil
+	case *Message_SignProposalRequest:
+		return msg.SignProposalRequest, nil
+	case *Message_SignedProposalResponse:
+		return msg.SignedProposalResponse, nil
+	case *Message_PingRequest:
+		return msg.PingRequest, nil
+	case *Message_PingResponse:
+		return msg.PingResponse, nil
+	default:
+		return nil, fmt.Errorf("unknown message type %T", msg)
+	}
+}
@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: tendermint/privval/v1/service.proto
+
+package privvalv1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	privval "github.com/tendermint/tendermint/proto/tendermint/privval"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("tendermint/privval/v1/service.proto", fileDescriptor_e978f4afea78544b)
+}
+
+var fileDescriptor_e978f4afea78544b = []byte{
+	// 258 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x2e, 0x49, 0xcd, 0x4b,
+	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x28, 0xca, 0x2c, 0x2b, 0x4b, 0xcc, 0xd1, 0x2f,
+	0x33, 0xd4, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17,
+	0x12, 0x45, 0x28, 0xd2, 0x83, 0x2a, 0xd2, 0x2b, 0x33, 0x94, 0x92, 0xc3, 0xa2, 0xb7, 0xa4, 0xb2,
+	0x20, 0xb5, 0x18, 0xa2, 0xcd, 0x68, 0x05, 0x13, 0x97, 0x48, 0x40, 0x51, 0x66, 0x59, 0x58, 0x62,
+	0x4e, 0x66, 0x4a, 0x62, 0x49, 0x7e, 0x51, 0x30, 0xc4, 0x54, 0xa1, 0x20, 0x2e, 0x4e, 0xf7, 0xd4,
+	0x92, 0x80, 0xd2, 0x24, 0xef, 0xd4, 0x4a, 0x21, 0x45, 0x3d, 0x2c, 0xa6, 0x43, 0xe4, 0x82, 0x52,
+	0x0b, 0x4b, 0x53, 0x8b, 0x4b, 0xa4, 0x94, 0xf0, 0x29, 0x29, 0x2e, 0xc8, 0xcf, 0x2b, 0x4e, 0x15,
+	0x0a, 0xe7, 0xe2, 0x08, 0xce, 0x4c, 0xcf, 0x0b, 0xcb, 0x2f, 0x49, 0x15, 0x52, 0xc6, 0xa6, 0x1e,
+	0x26, 0x0b, 0x33, 0x54, 0x0d, 0x97, 0xa2, 0xd4, 0x14, 0x88, 0x32, 0xa8, 0xc1, 0xc9, 0x5c, 0x3c,
+	0x20, 0xd1, 0x80, 0xa2, 0xfc, 0x82, 0xfc, 0xe2, 0xc4, 0x1c, 0x21, 0x75, 0x5c, 0xfa, 0x60, 0x2a,
+	0x60, 0x16, 0x68, 0xe1, 0xb6, 0x00, 0xa1, 0x14, 0x62, 0x89, 0x53, 0xc2, 0x89, 0x47, 0x72, 0x8c,
+	0x17, 0x1e, 0xc9, 0x31, 0x3e, 0x78, 0x24, 0xc7, 0x38, 0xe1, 0xb1, 0x1c, 0xc3, 0x85, 0xc7, 0x72,
+	0x0c, 0x37, 0x1e, 0xcb, 0x31, 0x44, 0xb9, 0xa5, 0x67, 0x96, 0x64, 0x94, 0x26, 0xe9, 0x25, 0xe7,
+	0xe7, 0xea, 0x23, 0x85, 0x37, 0x4a, 0xd0, 0xe7, 0x97, 0xe4, 0xeb, 0x63, 0x8d, 0x47, 0x6b, 0x28,
+	0xb3, 0xcc, 0x30, 0x89, 0x0d, 0xac, 0xcc, 0x18, 0x30, 0x00, 0x23, 0x26, 0xb2, 0x48, 0xf1, 0x01,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PrivValidatorServiceClient is the client API for PrivValidatorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PrivValidatorServiceClient interface {
+	GetPubKey(ctx context.Context, in *privval.PubKeyRequest, opts ...grpc.CallOption) (*privval.PubKeyResponse, error)
+	SignVote(ctx context.Context, in *privval.SignVoteRequest, opts ...grpc.CallOption) (*privval.SignedVoteResponse, error)
+	SignProposal(ctx context.Context, in *privval.SignProposalRequest, opts ...grpc.CallOption) (*privval.SignedProposalResponse, error)
+}
+
+type privValidatorServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPrivValidatorServiceClient(cc *grpc.ClientConn) PrivValidatorServiceClient {
+	return &privValidatorServiceClient{cc}
+}
+
+func (c *privValidatorServiceClient) GetPubKey(ctx context.Context, in *privval.PubKeyRequest, opts ...grpc.CallOption) (*privval.PubKeyResponse, error) {
+	out := new(privval.PubKeyResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.v1.PrivValidatorService/GetPubKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorServiceClient) SignVote(ctx context.Context, in *privval.SignVoteRequest, opts ...grpc.CallOption) (*privval.SignedVoteResponse, error) {
+	out := new(privval.SignedVoteResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.v1.PrivValidatorService/SignVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorServiceClient) SignProposal(ctx context.Context, in *privval.SignProposalRequest, opts ...grpc.CallOption) (*privval.SignedProposalResponse, error) {
+	out := new(privval.SignedProposalResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.v1.PrivValidatorService/SignProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrivValidatorServiceServer is the server API for PrivValidatorService service.
+type PrivValidatorServiceServer interface {
+	GetPubKey(context.Context, *privval.PubKeyRequest) (*privval.PubKeyResponse, error)
+	SignVote(context.Context, *privval.SignVoteRequest) (*privval.SignedVoteResponse, error)
+	SignProposal(context.Context, *privval.SignProposalRequest) (*privval.SignedProposalResponse, error)
+}
+
+// UnimplementedPrivValidatorServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedPrivValidatorServiceServer struct {
+}
+
+func (*UnimplementedPrivValidatorServiceServer) GetPubKey(ctx context.Context, req *privval.PubKeyRequest) (*privval.PubKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPubKey not implemented")
+}
+func (*UnimplementedPrivValidatorServiceServer) SignVote(ctx context.Context, req *privval.SignVoteRequest) (*privval.SignedVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignVote not implemented")
+}
+func (*UnimplementedPrivValidatorServiceServer) SignProposal(ctx context.Context, req *privval.SignProposalRequest) (*privval.SignedProposalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignProposal not implemented")
+}
+
+func RegisterPrivValidatorServiceServer(s *grpc.Server, srv PrivValidatorServiceServer) {
+	s.RegisterService(&_PrivValidatorService_serviceDesc, srv)
+}
+
+func _PrivValidatorService_GetPubKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(privval.PubKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorServiceServer).GetPubKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.v1.PrivValidatorService/GetPubKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorServiceServer).GetPubKey(ctx, req.(*privval.PubKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorService_SignVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(privval.SignVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorServiceServer).SignVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.v1.PrivValidatorService/SignVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorServiceServer).SignVote(ctx, req.(*privval.SignVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorService_SignProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(privval.SignProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorServiceServer).SignProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.v1.PrivValidatorService/SignProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorServiceServer).SignProposal(ctx, req.(*privval.SignProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PrivValidatorService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.privval.v1.PrivValidatorService",
+	HandlerType: (*PrivValidatorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPubKey",
+			Handler:    _PrivValidatorService_GetPubKey_Handler,
+		},
+		{
+			MethodName: "SignVote",
+			Handler:    _PrivValidatorService_SignVote_Handler,
+		},
+		{
+			MethodName: "SignProposal",
+			Handler:    _PrivValidatorService_SignProposal_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tendermint/privval/v1/service.proto",
+}
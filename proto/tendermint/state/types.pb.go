@@ -93,6 +93,85 @@ func (m *ABCIResponses) GetBeginBlock() *types.ResponseBeginBlock {
 	return nil
 }
 
+// RetainedABCIResponses are the parts of the ABCIResponses of a height which
+// are kept once they're pruned: the events of the exempted types, and the
+// validator and consensus param updates if exempted.
+type RetainedABCIResponses struct {
+	BeginBlockEvents      []types.Event           `protobuf:"bytes,1,rep,name=begin_block_events,json=beginBlockEvents,proto3" json:"begin_block_events"`
+	DeliverTxEvents       []types.Event           `protobuf:"bytes,2,rep,name=deliver_tx_events,json=deliverTxEvents,proto3" json:"deliver_tx_events"`
+	EndBlockEvents        []types.Event           `protobuf:"bytes,3,rep,name=end_block_events,json=endBlockEvents,proto3" json:"end_block_events"`
+	ValidatorUpdates      []types.ValidatorUpdate `protobuf:"bytes,4,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates"`
+	ConsensusParamUpdates *types.ConsensusParams  `protobuf:"bytes,5,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
+}
+
+func (m *RetainedABCIResponses) Reset()         { *m = RetainedABCIResponses{} }
+func (m *RetainedABCIResponses) String() string { return proto.CompactTextString(m) }
+func (*RetainedABCIResponses) ProtoMessage()    {}
+func (*RetainedABCIResponses) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ccfacf933f22bf93, []int{1}
+}
+func (m *RetainedABCIResponses) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RetainedABCIResponses) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RetainedABCIResponses.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RetainedABCIResponses) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetainedABCIResponses.Merge(m, src)
+}
+func (m *RetainedABCIResponses) XXX_Size() int {
+	return m.Size()
+}
+func (m *RetainedABCIResponses) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetainedABCIResponses.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetainedABCIResponses proto.InternalMessageInfo
+
+func (m *RetainedABCIResponses) GetBeginBlockEvents() []types.Event {
+	if m != nil {
+		return m.BeginBlockEvents
+	}
+	return nil
+}
+
+func (m *RetainedABCIResponses) GetDeliverTxEvents() []types.Event {
+	if m != nil {
+		return m.DeliverTxEvents
+	}
+	return nil
+}
+
+func (m *RetainedABCIResponses) GetEndBlockEvents() []types.Event {
+	if m != nil {
+		return m.EndBlockEvents
+	}
+	return nil
+}
+
+func (m *RetainedABCIResponses) GetValidatorUpdates() []types.ValidatorUpdate {
+	if m != nil {
+		return m.ValidatorUpdates
+	}
+	return nil
+}
+
+func (m *RetainedABCIResponses) GetConsensusParamUpdates() *types.ConsensusParams {
+	if m != nil {
+		return m.ConsensusParamUpdates
+	}
+	return nil
+}
+
 // ValidatorsInfo represents the latest validator set, or the last height it changed
 type ValidatorsInfo struct {
 	ValidatorSet      *types1.ValidatorSet `protobuf:"bytes,1,opt,name=validator_set,json=validatorSet,proto3" json:"validator_set,omitempty"`
@@ -103,7 +182,7 @@ func (m *ValidatorsInfo) Reset()         { *m = ValidatorsInfo{} }
 func (m *ValidatorsInfo) String() string { return proto.CompactTextString(m) }
 func (*ValidatorsInfo) ProtoMessage()    {}
 func (*ValidatorsInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ccfacf933f22bf93, []int{1}
+	return fileDescriptor_ccfacf933f22bf93, []int{2}
 }
 func (m *ValidatorsInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -156,7 +235,7 @@ func (m *ConsensusParamsInfo) Reset()         { *m = ConsensusParamsInfo{} }
 func (m *ConsensusParamsInfo) String() string { return proto.CompactTextString(m) }
 func (*ConsensusParamsInfo) ProtoMessage()    {}
 func (*ConsensusParamsInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ccfacf933f22bf93, []int{2}
+	return fileDescriptor_ccfacf933f22bf93, []int{3}
 }
 func (m *ConsensusParamsInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -208,7 +287,7 @@ func (m *Version) Reset()         { *m = Version{} }
 func (m *Version) String() string { return proto.CompactTextString(m) }
 func (*Version) ProtoMessage()    {}
 func (*Version) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ccfacf933f22bf93, []int{3}
+	return fileDescriptor_ccfacf933f22bf93, []int{4}
 }
 func (m *Version) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -278,13 +357,15 @@ type State struct {
 	LastResultsHash []byte `protobuf:"bytes,12,opt,name=last_results_hash,json=lastResultsHash,proto3" json:"last_results_hash,omitempty"`
 	// the latest AppHash we've received from calling abci.Commit()
 	AppHash []byte `protobuf:"bytes,13,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	// the last app version upgrade scheduled by the app in EndBlock
+	AppVersionUpgrade types.AppVersionUpgrade `protobuf:"bytes,15,opt,name=app_version_upgrade,json=appVersionUpgrade,proto3" json:"app_version_upgrade"`
 }
 
 func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ccfacf933f22bf93, []int{4}
+	return fileDescriptor_ccfacf933f22bf93, []int{5}
 }
 func (m *State) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -411,8 +492,16 @@ func (m *State) GetAppHash() []byte {
 	return nil
 }
 
+func (m *State) GetAppVersionUpgrade() types.AppVersionUpgrade {
+	if m != nil {
+		return m.AppVersionUpgrade
+	}
+	return types.AppVersionUpgrade{}
+}
+
 func init() {
 	proto.RegisterType((*ABCIResponses)(nil), "tendermint.state.ABCIResponses")
+	proto.RegisterType((*RetainedABCIResponses)(nil), "tendermint.state.RetainedABCIResponses")
 	proto.RegisterType((*ValidatorsInfo)(nil), "tendermint.state.ValidatorsInfo")
 	proto.RegisterType((*ConsensusParamsInfo)(nil), "tendermint.state.ConsensusParamsInfo")
 	proto.RegisterType((*Version)(nil), "tendermint.state.Version")
@@ -422,55 +511,65 @@ func init() {
 func init() { proto.RegisterFile("tendermint/state/types.proto", fileDescriptor_ccfacf933f22bf93) }
 
 var fileDescriptor_ccfacf933f22bf93 = []byte{
-	// 763 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xcf, 0x6f, 0xd3, 0x30,
-	0x14, 0x6e, 0xe8, 0xb6, 0xb6, 0xce, 0xda, 0x0e, 0x8f, 0x43, 0xd6, 0xb1, 0xb4, 0x2b, 0x3f, 0x34,
-	0x71, 0x48, 0xa5, 0x71, 0x40, 0x5c, 0x26, 0x2d, 0x2d, 0x62, 0x95, 0x26, 0x04, 0xd9, 0xb4, 0x03,
-	0x97, 0xc8, 0x6d, 0xbc, 0x24, 0xa2, 0x4d, 0xa2, 0xd8, 0x2d, 0xe3, 0x0f, 0xe0, 0xbe, 0x2b, 0xff,
-	0xd1, 0x8e, 0x3b, 0x22, 0x0e, 0x03, 0xba, 0x7f, 0x04, 0xd9, 0xce, 0x0f, 0xb7, 0x65, 0xd2, 0x10,
-	0x37, 0xfb, 0x7d, 0xdf, 0xfb, 0xfc, 0xf9, 0xf9, 0x3d, 0x19, 0x3c, 0xa6, 0x38, 0x70, 0x70, 0x3c,
-	0xf6, 0x03, 0xda, 0x21, 0x14, 0x51, 0xdc, 0xa1, 0x5f, 0x22, 0x4c, 0x8c, 0x28, 0x0e, 0x69, 0x08,
-	0x37, 0x72, 0xd4, 0xe0, 0x68, 0xe3, 0x91, 0x1b, 0xba, 0x21, 0x07, 0x3b, 0x6c, 0x25, 0x78, 0x8d,
-	0x6d, 0x49, 0x05, 0x0d, 0x86, 0xbe, 0x2c, 0xd2, 0x90, 0x8f, 0xe0, 0xf1, 0x39, 0xb4, 0xb5, 0x84,
-	0x4e, 0xd1, 0xc8, 0x77, 0x10, 0x0d, 0xe3, 0x84, 0xb1, 0xb3, 0xc4, 0x88, 0x50, 0x8c, 0xc6, 0xa9,
-	0x80, 0x2e, 0xc1, 0x53, 0x1c, 0x13, 0x3f, 0x0c, 0xe6, 0x0e, 0x68, 0xba, 0x61, 0xe8, 0x8e, 0x70,
-	0x87, 0xef, 0x06, 0x93, 0xf3, 0x0e, 0xf5, 0xc7, 0x98, 0x50, 0x34, 0x8e, 0x04, 0xa1, 0xfd, 0x43,
-	0x01, 0xd5, 0x43, 0xb3, 0xdb, 0xb7, 0x30, 0x89, 0xc2, 0x80, 0x60, 0x02, 0xbb, 0x40, 0x75, 0xf0,
-	0xc8, 0x9f, 0xe2, 0xd8, 0xa6, 0x17, 0x44, 0x53, 0x5a, 0xc5, 0x3d, 0x75, 0xbf, 0x6d, 0x48, 0xc5,
-	0x60, 0x97, 0x34, 0xd2, 0x84, 0x9e, 0xe0, 0x9e, 0x5e, 0x58, 0xc0, 0x49, 0x97, 0x04, 0x1e, 0x80,
-	0x0a, 0x0e, 0x1c, 0x7b, 0x30, 0x0a, 0x87, 0x9f, 0xb4, 0x07, 0x2d, 0x65, 0x4f, 0xdd, 0xdf, 0xbd,
-	0x53, 0xe2, 0x4d, 0xe0, 0x98, 0x8c, 0x68, 0x95, 0x71, 0xb2, 0x82, 0x3d, 0xa0, 0x0e, 0xb0, 0xeb,
-	0x07, 0x89, 0x42, 0x91, 0x2b, 0x3c, 0xb9, 0x53, 0xc1, 0x64, 0x5c, 0xa1, 0x01, 0x06, 0xd9, 0xba,
-	0xfd, 0x55, 0x01, 0xb5, 0xb3, 0xb4, 0xa0, 0xa4, 0x1f, 0x9c, 0x87, 0xb0, 0x0b, 0xaa, 0x59, 0x89,
-	0x6d, 0x82, 0xa9, 0xa6, 0x70, 0x69, 0x5d, 0x96, 0x16, 0x05, 0xcc, 0x12, 0x4f, 0x30, 0xb5, 0xd6,
-	0xa7, 0xd2, 0x0e, 0x1a, 0x60, 0x73, 0x84, 0x08, 0xb5, 0x3d, 0xec, 0xbb, 0x1e, 0xb5, 0x87, 0x1e,
-	0x0a, 0x5c, 0xec, 0xf0, 0x7b, 0x16, 0xad, 0x87, 0x0c, 0x3a, 0xe2, 0x48, 0x57, 0x00, 0xed, 0x6f,
-	0x0a, 0xd8, 0xec, 0x32, 0x9f, 0x01, 0x99, 0x90, 0xf7, 0xfc, 0xfd, 0xb8, 0x19, 0x0b, 0x6c, 0x0c,
-	0xd3, 0xb0, 0x2d, 0xde, 0x35, 0xf1, 0xb3, 0xbb, 0xec, 0x67, 0x41, 0xc0, 0x5c, 0xb9, 0xba, 0x69,
-	0x16, 0xac, 0xfa, 0x70, 0x3e, 0xfc, 0xcf, 0xde, 0x3c, 0x50, 0x3a, 0x13, 0x8d, 0x03, 0x0f, 0x41,
-	0x25, 0x53, 0x4b, 0x7c, 0xec, 0xc8, 0x3e, 0x92, 0x06, 0xcb, 0x9d, 0x24, 0x1e, 0xf2, 0x2c, 0xd8,
-	0x00, 0x65, 0x12, 0x9e, 0xd3, 0xcf, 0x28, 0xc6, 0xfc, 0xc8, 0x8a, 0x95, 0xed, 0xdb, 0xbf, 0xd7,
-	0xc0, 0xea, 0x09, 0x9b, 0x23, 0xf8, 0x1a, 0x94, 0x12, 0xad, 0xe4, 0x98, 0x2d, 0x63, 0x71, 0xd6,
-	0x8c, 0xc4, 0x54, 0x72, 0x44, 0xca, 0x87, 0xcf, 0x41, 0x79, 0xe8, 0x21, 0x3f, 0xb0, 0x7d, 0x71,
-	0xa7, 0x8a, 0xa9, 0xce, 0x6e, 0x9a, 0xa5, 0x2e, 0x8b, 0xf5, 0x7b, 0x56, 0x89, 0x83, 0x7d, 0x07,
-	0x3e, 0x03, 0x35, 0x3f, 0xf0, 0xa9, 0x8f, 0x46, 0x49, 0x25, 0xb4, 0x1a, 0xaf, 0x40, 0x35, 0x89,
-	0x8a, 0x22, 0xc0, 0x17, 0x80, 0x97, 0x44, 0xb4, 0x59, 0xca, 0x2c, 0x72, 0x66, 0x9d, 0x01, 0xbc,
-	0x8f, 0x12, 0xae, 0x05, 0xaa, 0x12, 0xd7, 0x77, 0xb4, 0x95, 0x65, 0xef, 0xe2, 0xa9, 0x78, 0x56,
-	0xbf, 0x67, 0x6e, 0x32, 0xef, 0xb3, 0x9b, 0xa6, 0x7a, 0x9c, 0x4a, 0xf5, 0x7b, 0x96, 0x9a, 0xe9,
-	0xf6, 0x1d, 0x78, 0x0c, 0xea, 0x92, 0x26, 0x1b, 0x4e, 0x6d, 0x95, 0xab, 0x36, 0x0c, 0x31, 0xb9,
-	0x46, 0x3a, 0xb9, 0xc6, 0x69, 0x3a, 0xb9, 0x66, 0x99, 0xc9, 0x5e, 0xfe, 0x6c, 0x2a, 0x56, 0x35,
-	0xd3, 0x62, 0x28, 0x7c, 0x0b, 0xea, 0x01, 0xbe, 0xa0, 0x76, 0xd6, 0xac, 0x44, 0x5b, 0xbb, 0x57,
-	0x7b, 0xd7, 0x58, 0x5a, 0x3e, 0x29, 0xf0, 0x00, 0x00, 0x49, 0xa3, 0x74, 0x2f, 0x0d, 0x29, 0x83,
-	0x19, 0xe1, 0xd7, 0x92, 0x44, 0xca, 0xf7, 0x33, 0xc2, 0xd2, 0x24, 0x23, 0x5d, 0xa0, 0xcb, 0xdd,
-	0x9c, 0xeb, 0x65, 0x8d, 0x5d, 0xe1, 0x8f, 0xb5, 0x9d, 0x37, 0x76, 0x9e, 0x9d, 0xb4, 0xf8, 0x5f,
-	0xc7, 0x0c, 0xfc, 0xe7, 0x98, 0xbd, 0x03, 0x4f, 0xe7, 0xc6, 0x6c, 0x41, 0x3f, 0xb3, 0xa7, 0x72,
-	0x7b, 0x2d, 0x69, 0xee, 0xe6, 0x85, 0x52, 0x8f, 0x69, 0x23, 0xc6, 0x98, 0x4c, 0x46, 0x94, 0xd8,
-	0x1e, 0x22, 0x9e, 0xb6, 0xde, 0x52, 0xf6, 0xd6, 0x45, 0x23, 0x5a, 0x22, 0x7e, 0x84, 0x88, 0x07,
-	0xb7, 0x40, 0x19, 0x45, 0x91, 0xa0, 0x54, 0x39, 0xa5, 0x84, 0xa2, 0x88, 0x41, 0xe6, 0x87, 0xab,
-	0x99, 0xae, 0x5c, 0xcf, 0x74, 0xe5, 0xd7, 0x4c, 0x57, 0x2e, 0x6f, 0xf5, 0xc2, 0xf5, 0xad, 0x5e,
-	0xf8, 0x7e, 0xab, 0x17, 0x3e, 0xbe, 0x72, 0x7d, 0xea, 0x4d, 0x06, 0xc6, 0x30, 0x1c, 0x77, 0xe4,
-	0x3f, 0x25, 0x5f, 0x8a, 0x8f, 0x6d, 0xf1, 0x4b, 0x1c, 0xac, 0xf1, 0xf8, 0xcb, 0x3f, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0xa5, 0x17, 0xac, 0x23, 0x2d, 0x07, 0x00, 0x00,
+	// 914 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x96, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0xc7, 0xcd, 0xc8, 0x89, 0xe4, 0x91, 0x25, 0xd9, 0xeb, 0xa6, 0x65, 0x94, 0x86, 0x52, 0xd5,
+	0x0f, 0x18, 0x3d, 0x50, 0x40, 0x7a, 0x28, 0x7a, 0x29, 0x60, 0x49, 0x69, 0xad, 0x22, 0x28, 0x5a,
+	0x3a, 0x09, 0x82, 0x5e, 0x88, 0x95, 0xb8, 0x26, 0x89, 0x4a, 0x24, 0xc1, 0x5d, 0xa9, 0xee, 0x03,
+	0xf4, 0x9e, 0x6b, 0xef, 0x7d, 0x8b, 0xbe, 0x40, 0x8e, 0x39, 0x16, 0x3d, 0xb8, 0x85, 0xfc, 0x22,
+	0xc5, 0x7e, 0x91, 0x2b, 0xc9, 0x01, 0x54, 0xe4, 0x46, 0xcd, 0xfc, 0xf7, 0xb7, 0xff, 0x19, 0xce,
+	0xae, 0x08, 0x1f, 0x32, 0x92, 0x04, 0x24, 0x9f, 0xc7, 0x09, 0xeb, 0x53, 0x86, 0x19, 0xe9, 0xb3,
+	0x5f, 0x33, 0x42, 0xdd, 0x2c, 0x4f, 0x59, 0x8a, 0x8e, 0xca, 0xac, 0x2b, 0xb2, 0xed, 0xf7, 0xc2,
+	0x34, 0x4c, 0x45, 0xb2, 0xcf, 0x9f, 0xa4, 0xae, 0xfd, 0xd0, 0xa0, 0xe0, 0xc9, 0x34, 0x36, 0x21,
+	0x6d, 0x73, 0x0b, 0x11, 0x5f, 0xcb, 0x76, 0xb7, 0xb2, 0x4b, 0x3c, 0x8b, 0x03, 0xcc, 0xd2, 0x5c,
+	0x29, 0x1e, 0x6d, 0x29, 0x32, 0x9c, 0xe3, 0xb9, 0x06, 0x38, 0x46, 0x7a, 0x49, 0x72, 0x1a, 0xa7,
+	0xc9, 0xda, 0x06, 0x9d, 0x30, 0x4d, 0xc3, 0x19, 0xe9, 0x8b, 0x5f, 0x93, 0xc5, 0x65, 0x9f, 0xc5,
+	0x73, 0x42, 0x19, 0x9e, 0x67, 0x52, 0xd0, 0xfb, 0xdb, 0x82, 0xc6, 0xd9, 0x60, 0x38, 0xf6, 0x08,
+	0xcd, 0xd2, 0x84, 0x12, 0x8a, 0x86, 0x50, 0x0f, 0xc8, 0x2c, 0x5e, 0x92, 0xdc, 0x67, 0x57, 0xd4,
+	0xb6, 0xba, 0x95, 0xd3, 0xfa, 0xe3, 0x9e, 0x6b, 0x34, 0x83, 0x17, 0xe9, 0xea, 0x05, 0x23, 0xa9,
+	0x7d, 0x76, 0xe5, 0x41, 0xa0, 0x1f, 0x29, 0xfa, 0x1a, 0x0e, 0x48, 0x12, 0xf8, 0x93, 0x59, 0x3a,
+	0xfd, 0xd9, 0xbe, 0xd3, 0xb5, 0x4e, 0xeb, 0x8f, 0x3f, 0x7a, 0x2b, 0xe2, 0x49, 0x12, 0x0c, 0xb8,
+	0xd0, 0xab, 0x11, 0xf5, 0x84, 0x46, 0x50, 0x9f, 0x90, 0x30, 0x4e, 0x14, 0xa1, 0x22, 0x08, 0x1f,
+	0xbf, 0x95, 0x30, 0xe0, 0x5a, 0xc9, 0x80, 0x49, 0xf1, 0xdc, 0xfb, 0xa3, 0x02, 0xf7, 0x3d, 0xc2,
+	0x70, 0x9c, 0x90, 0x60, 0xbd, 0xc8, 0xef, 0x00, 0x19, 0x7c, 0x9f, 0x2c, 0x49, 0xc2, 0x74, 0xad,
+	0xef, 0x6f, 0x6d, 0xf3, 0x84, 0xa7, 0x07, 0xfb, 0xaf, 0xaf, 0x3b, 0x7b, 0xde, 0x51, 0xc9, 0x17,
+	0x61, 0x8a, 0xce, 0xe1, 0xb8, 0x6c, 0x98, 0x46, 0xdd, 0xd9, 0x01, 0xd5, 0x2a, 0x1a, 0xa6, 0x48,
+	0xdf, 0xc0, 0x51, 0xd1, 0x35, 0x0d, 0xaa, 0xec, 0x00, 0x6a, 0xea, 0xbe, 0x29, 0xce, 0x05, 0x1c,
+	0x17, 0x73, 0xe4, 0x2f, 0xb2, 0x00, 0x33, 0x42, 0xed, 0x7d, 0x01, 0xea, 0x6e, 0x81, 0x5e, 0x68,
+	0xe5, 0x73, 0x21, 0xd4, 0x65, 0x2e, 0xd7, 0xc3, 0x14, 0xbd, 0x84, 0x0f, 0xa6, 0xbc, 0x79, 0x09,
+	0x5d, 0x50, 0x5f, 0x0c, 0x61, 0x81, 0xbe, 0xdb, 0xb5, 0x6e, 0x45, 0x0f, 0xb5, 0xfe, 0x07, 0x2e,
+	0xa7, 0xde, 0xfd, 0xe9, 0x5a, 0x40, 0x91, 0x7b, 0xbf, 0x59, 0xd0, 0x2c, 0x5c, 0xd0, 0x71, 0x72,
+	0x99, 0xa2, 0x21, 0x34, 0xca, 0x0a, 0x28, 0x61, 0xb6, 0x25, 0xb6, 0x70, 0xcc, 0x2d, 0xe4, 0x9c,
+	0x17, 0x0b, 0x2f, 0x08, 0xf3, 0x0e, 0x97, 0xc6, 0x2f, 0xe4, 0xc2, 0xc9, 0x0c, 0x53, 0xe6, 0x47,
+	0x24, 0x0e, 0x23, 0xe6, 0x4f, 0x23, 0x9c, 0x84, 0x24, 0x10, 0xe3, 0x58, 0xf1, 0x8e, 0x79, 0xea,
+	0x5c, 0x64, 0x86, 0x32, 0xd1, 0xfb, 0xdd, 0x82, 0x93, 0x0d, 0xcb, 0xc2, 0x8c, 0x07, 0x47, 0x1b,
+	0x95, 0x53, 0xdb, 0xda, 0x9e, 0x69, 0xe9, 0x67, 0x03, 0xa0, 0x5f, 0xf5, 0x7a, 0xe5, 0xf4, 0x7f,
+	0x7b, 0x8b, 0xa0, 0xfa, 0x42, 0x9e, 0x6f, 0x74, 0x06, 0x07, 0x05, 0x4d, 0xf9, 0x78, 0x64, 0xfa,
+	0x50, 0xf7, 0x40, 0xe9, 0x44, 0x79, 0x28, 0x57, 0xa1, 0x36, 0xd4, 0x68, 0x7a, 0xc9, 0x7e, 0xc1,
+	0x39, 0x11, 0x5b, 0x1e, 0x78, 0xc5, 0xef, 0xde, 0x9f, 0x55, 0xb8, 0x7b, 0xc1, 0x30, 0x23, 0xe8,
+	0x2b, 0xa8, 0x2a, 0x96, 0xda, 0xe6, 0x81, 0xbb, 0x79, 0x25, 0xba, 0xca, 0x94, 0xda, 0x42, 0xeb,
+	0xd1, 0x67, 0x50, 0x9b, 0x46, 0x38, 0x4e, 0xfc, 0x58, 0xd6, 0x74, 0x30, 0xa8, 0xaf, 0xae, 0x3b,
+	0xd5, 0x21, 0x8f, 0x8d, 0x47, 0x5e, 0x55, 0x24, 0xc7, 0x01, 0xfa, 0x14, 0x9a, 0x71, 0x12, 0xb3,
+	0x18, 0xcf, 0x54, 0x27, 0xec, 0xa6, 0xe8, 0x40, 0x43, 0x45, 0x65, 0x13, 0xd0, 0xe7, 0x20, 0x5a,
+	0xa2, 0x4e, 0x86, 0x52, 0x56, 0x84, 0xb2, 0xc5, 0x13, 0x62, 0xf8, 0x95, 0xd6, 0x83, 0x86, 0xa1,
+	0x8d, 0x03, 0x7b, 0x7f, 0xdb, 0xbb, 0x7c, 0x55, 0x62, 0xd5, 0x78, 0x34, 0x38, 0xe1, 0xde, 0x57,
+	0xd7, 0x9d, 0xfa, 0x53, 0x8d, 0x1a, 0x8f, 0xbc, 0x7a, 0xc1, 0x1d, 0x07, 0xe8, 0x29, 0xb4, 0x0c,
+	0x26, 0xbf, 0x43, 0xd5, 0xcc, 0xb7, 0x5d, 0x79, 0xc1, 0xba, 0xfa, 0x82, 0x75, 0x9f, 0xe9, 0x0b,
+	0x76, 0x50, 0xe3, 0xd8, 0x57, 0xff, 0x74, 0x2c, 0xaf, 0x51, 0xb0, 0x78, 0x16, 0x7d, 0x0b, 0xad,
+	0x84, 0x5c, 0x31, 0xbf, 0x18, 0x56, 0x6a, 0xdf, 0xdb, 0x69, 0xbc, 0x9b, 0x7c, 0x59, 0x11, 0xe1,
+	0xb7, 0x2c, 0x18, 0x8c, 0xea, 0x4e, 0x0c, 0x63, 0x05, 0x37, 0x22, 0xca, 0x32, 0x20, 0xb5, 0xdd,
+	0x8c, 0xf0, 0x65, 0x86, 0x91, 0x21, 0x38, 0xe6, 0x34, 0x97, 0xbc, 0x62, 0xb0, 0x0f, 0xc4, 0xcb,
+	0x7a, 0x58, 0x0e, 0x76, 0xb9, 0x5a, 0x8d, 0xf8, 0xad, 0xc7, 0x0c, 0xde, 0xf1, 0x98, 0x7d, 0x0f,
+	0x9f, 0xac, 0x1d, 0xb3, 0x0d, 0x7e, 0x61, 0xaf, 0x2e, 0xec, 0x75, 0x8d, 0x73, 0xb7, 0x0e, 0xd2,
+	0x1e, 0xf5, 0x20, 0xe6, 0x84, 0x2e, 0x66, 0x8c, 0xfa, 0x11, 0xa6, 0x91, 0x7d, 0xd8, 0xb5, 0x4e,
+	0x0f, 0xe5, 0x20, 0x7a, 0x32, 0x7e, 0x8e, 0x69, 0x84, 0x1e, 0x40, 0x0d, 0x67, 0x99, 0x94, 0x34,
+	0x84, 0xa4, 0x8a, 0xb3, 0x4c, 0xa4, 0x5e, 0xc2, 0x09, 0x4f, 0xa9, 0xd3, 0xe2, 0x2f, 0xb2, 0x30,
+	0xc7, 0x01, 0xb1, 0x5b, 0x5d, 0xeb, 0xd6, 0xff, 0xda, 0xb3, 0x2c, 0x53, 0xe7, 0xec, 0xb9, 0x54,
+	0xaa, 0x72, 0x8f, 0xf1, 0x56, 0xe2, 0xc7, 0xd7, 0x2b, 0xc7, 0x7a, 0xb3, 0x72, 0xac, 0x7f, 0x57,
+	0x8e, 0xf5, 0xea, 0xc6, 0xd9, 0x7b, 0x73, 0xe3, 0xec, 0xfd, 0x75, 0xe3, 0xec, 0xfd, 0xf4, 0x65,
+	0x18, 0xb3, 0x68, 0x31, 0x71, 0xa7, 0xe9, 0xbc, 0x6f, 0x7e, 0x54, 0x94, 0x8f, 0xf2, 0xcb, 0x66,
+	0xf3, 0x9b, 0x68, 0x72, 0x4f, 0xc4, 0xbf, 0xf8, 0x6f, 0x00, 0xff, 0xa6, 0x52, 0x26, 0x2e, 0x09,
+	0x00, 0x00,
 }
 
 func (m *ABCIResponses) Marshal() (dAtA []byte, err error) {
@@ -534,6 +633,97 @@ func (m *ABCIResponses) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RetainedABCIResponses) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RetainedABCIResponses) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RetainedABCIResponses) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ConsensusParamUpdates != nil {
+		{
+			size, err := m.ConsensusParamUpdates.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.ValidatorUpdates) > 0 {
+		for iNdEx := len(m.ValidatorUpdates) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ValidatorUpdates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.EndBlockEvents) > 0 {
+		for iNdEx := len(m.EndBlockEvents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.EndBlockEvents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.DeliverTxEvents) > 0 {
+		for iNdEx := len(m.DeliverTxEvents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DeliverTxEvents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.BeginBlockEvents) > 0 {
+		for iNdEx := len(m.BeginBlockEvents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BeginBlockEvents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ValidatorsInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -672,6 +862,16 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	{
+		size, err := m.AppVersionUpgrade.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x7a
 	if m.InitialHeight != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.InitialHeight))
 		i--
@@ -747,12 +947,12 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x32
 	}
-	n10, err10 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastBlockTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastBlockTime):])
-	if err10 != nil {
-		return 0, err10
+	n12, err12 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastBlockTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastBlockTime):])
+	if err12 != nil {
+		return 0, err12
 	}
-	i -= n10
-	i = encodeVarintTypes(dAtA, i, uint64(n10))
+	i -= n12
+	i = encodeVarintTypes(dAtA, i, uint64(n12))
 	i--
 	dAtA[i] = 0x2a
 	{
@@ -824,6 +1024,43 @@ func (m *ABCIResponses) Size() (n int) {
 	return n
 }
 
+func (m *RetainedABCIResponses) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.BeginBlockEvents) > 0 {
+		for _, e := range m.BeginBlockEvents {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.DeliverTxEvents) > 0 {
+		for _, e := range m.DeliverTxEvents {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.EndBlockEvents) > 0 {
+		for _, e := range m.EndBlockEvents {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.ValidatorUpdates) > 0 {
+		for _, e := range m.ValidatorUpdates {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.ConsensusParamUpdates != nil {
+		l = m.ConsensusParamUpdates.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *ValidatorsInfo) Size() (n int) {
 	if m == nil {
 		return 0
@@ -919,6 +1156,8 @@ func (m *State) Size() (n int) {
 	if m.InitialHeight != 0 {
 		n += 1 + sovTypes(uint64(m.InitialHeight))
 	}
+	l = m.AppVersionUpgrade.Size()
+	n += 1 + l + sovTypes(uint64(l))
 	return n
 }
 
@@ -1087,6 +1326,231 @@ func (m *ABCIResponses) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RetainedABCIResponses) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RetainedABCIResponses: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RetainedABCIResponses: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlockEvents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BeginBlockEvents = append(m.BeginBlockEvents, types.Event{})
+			if err := m.BeginBlockEvents[len(m.BeginBlockEvents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTxEvents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeliverTxEvents = append(m.DeliverTxEvents, types.Event{})
+			if err := m.DeliverTxEvents[len(m.DeliverTxEvents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlockEvents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EndBlockEvents = append(m.EndBlockEvents, types.Event{})
+			if err := m.EndBlockEvents[len(m.EndBlockEvents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorUpdates = append(m.ValidatorUpdates, types.ValidatorUpdate{})
+			if err := m.ValidatorUpdates[len(m.ValidatorUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConsensusParamUpdates == nil {
+				m.ConsensusParamUpdates = &types.ConsensusParams{}
+			}
+			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ValidatorsInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1863,6 +2327,39 @@ func (m *State) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppVersionUpgrade", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.AppVersionUpgrade.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,43 @@
+package statesync
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// Wrap wraps a state sync message (ChunkRequest, ChunkResponse,
+// SnapshotsRequest or SnapshotsResponse) into a Message, the envelope of the
+// messages sent on the state sync channels.
+func Wrap(pb proto.Message) (*Message, error) {
+	msg := &Message{}
+	switch pb := pb.(type) {
+	case *ChunkRequest:
+		msg.Sum = &Message_ChunkRequest{ChunkRequest: pb}
+	case *ChunkResponse:
+		msg.Sum = &Message_ChunkResponse{ChunkResponse: pb}
+	case *SnapshotsRequest:
+		msg.Sum = &Message_SnapshotsRequest{SnapshotsRequest: pb}
+	case *SnapshotsResponse:
+		msg.Sum = &Message_SnapshotsResponse{SnapshotsResponse: pb}
+	default:
+		return nil, fmt.Errorf("unknown message type %T", pb)
+	}
+	return msg, nil
+}
+
+// Unwrap returns the state sync message wrapped into m.
+func (m *Message) Unwrap() (proto.Message, error) {
+	switch msg := m.Sum.(type) {
+	case *Message_ChunkRequest:
+		return msg.ChunkRequest, nil
+	case *Message_ChunkResponse:
+		return msg.ChunkResponse, nil
+	case *Message_SnapshotsRequest:
+		return msg.SnapshotsRequest, nil
+	case *Message_SnapshotsResponse:
+		return msg.SnapshotsResponse, nil
+	default:
+		return nil, fmt.Errorf("unknown message type %T", msg)
+	}
+}
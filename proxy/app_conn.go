@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"context"
+	"time"
 
 	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/types"
@@ -57,15 +58,28 @@ type AppConnSnapshot interface {
 
 type appConnConsensus struct {
 	appConn abcicli.Client
+	timer   *callTimer
 }
 
 func NewAppConnConsensus(appConn abcicli.Client) AppConnConsensus {
+	return newAppConnConsensus(appConn, nil)
+}
+
+func newAppConnConsensus(appConn abcicli.Client, timer *callTimer) AppConnConsensus {
+	if timer != nil {
+		appConn.SetResponseCallback(timer.onResponse)
+	}
 	return &appConnConsensus{
 		appConn: appConn,
+		timer:   timer,
 	}
 }
 
 func (app *appConnConsensus) SetResponseCallback(cb abcicli.Callback) {
+	if app.timer != nil {
+		app.timer.setCallback(cb)
+		return
+	}
 	app.appConn.SetResponseCallback(cb)
 }
 
@@ -77,6 +91,7 @@ func (app *appConnConsensus) InitChainSync(
 	ctx context.Context,
 	req types.RequestInitChain,
 ) (*types.ResponseInitChain, error) {
+	defer app.timer.observe("init_chain", time.Now())
 	return app.appConn.InitChainSync(ctx, req)
 }
 
@@ -84,21 +99,32 @@ func (app *appConnConsensus) BeginBlockSync(
 	ctx context.Context,
 	req types.RequestBeginBlock,
 ) (*types.ResponseBeginBlock, error) {
+	app.timer.setHeight(req.Header.Height)
+	defer app.timer.observe("begin_block", time.Now(), "height", req.Header.Height)
 	return app.appConn.BeginBlockSync(ctx, req)
 }
 
 func (app *appConnConsensus) DeliverTxAsync(ctx context.Context, req types.RequestDeliverTx) (*abcicli.ReqRes, error) {
-	return app.appConn.DeliverTxAsync(ctx, req)
+	app.timer.send("deliver_tx", req.Tx)
+	reqRes, err := app.appConn.DeliverTxAsync(ctx, req)
+	if err != nil {
+		app.timer.cancel("deliver_tx", req.Tx)
+	}
+	return reqRes, err
 }
 
 func (app *appConnConsensus) EndBlockSync(
 	ctx context.Context,
 	req types.RequestEndBlock,
 ) (*types.ResponseEndBlock, error) {
+	defer app.timer.observe("end_block", time.Now(), "height", req.Height)
 	return app.appConn.EndBlockSync(ctx, req)
 }
 
 func (app *appConnConsensus) CommitSync(ctx context.Context) (*types.ResponseCommit, error) {
+	if app.timer != nil {
+		defer app.timer.observe("commit", time.Now(), "height", app.timer.getHeight())
+	}
 	return app.appConn.CommitSync(ctx)
 }
 
@@ -107,15 +133,28 @@ func (app *appConnConsensus) CommitSync(ctx context.Context) (*types.ResponseCom
 
 type appConnMempool struct {
 	appConn abcicli.Client
+	timer   *callTimer
 }
 
 func NewAppConnMempool(appConn abcicli.Client) AppConnMempool {
+	return newAppConnMempool(appConn, nil)
+}
+
+func newAppConnMempool(appConn abcicli.Client, timer *callTimer) AppConnMempool {
+	if timer != nil {
+		appConn.SetResponseCallback(timer.onResponse)
+	}
 	return &appConnMempool{
 		appConn: appConn,
+		timer:   timer,
 	}
 }
 
 func (app *appConnMempool) SetResponseCallback(cb abcicli.Callback) {
+	if app.timer != nil {
+		app.timer.setCallback(cb)
+		return
+	}
 	app.appConn.SetResponseCallback(cb)
 }
 
@@ -128,14 +167,21 @@ func (app *appConnMempool) FlushAsync(ctx context.Context) (*abcicli.ReqRes, err
 }
 
 func (app *appConnMempool) FlushSync(ctx context.Context) error {
+	defer app.timer.observe("flush", time.Now())
 	return app.appConn.FlushSync(ctx)
 }
 
 func (app *appConnMempool) CheckTxAsync(ctx context.Context, req types.RequestCheckTx) (*abcicli.ReqRes, error) {
-	return app.appConn.CheckTxAsync(ctx, req)
+	app.timer.send("check_tx", req.Tx)
+	reqRes, err := app.appConn.CheckTxAsync(ctx, req)
+	if err != nil {
+		app.timer.cancel("check_tx", req.Tx)
+	}
+	return reqRes, err
 }
 
 func (app *appConnMempool) CheckTxSync(ctx context.Context, req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	defer app.timer.observeTx("check_tx", time.Now(), req.Tx)
 	return app.appConn.CheckTxSync(ctx, req)
 }
 
@@ -144,11 +190,17 @@ func (app *appConnMempool) CheckTxSync(ctx context.Context, req types.RequestChe
 
 type appConnQuery struct {
 	appConn abcicli.Client
+	timer   *callTimer
 }
 
 func NewAppConnQuery(appConn abcicli.Client) AppConnQuery {
+	return newAppConnQuery(appConn, nil)
+}
+
+func newAppConnQuery(appConn abcicli.Client, timer *callTimer) AppConnQuery {
 	return &appConnQuery{
 		appConn: appConn,
+		timer:   timer,
 	}
 }
 
@@ -157,14 +209,17 @@ func (app *appConnQuery) Error() error {
 }
 
 func (app *appConnQuery) EchoSync(ctx context.Context, msg string) (*types.ResponseEcho, error) {
+	defer app.timer.observe("echo", time.Now())
 	return app.appConn.EchoSync(ctx, msg)
 }
 
 func (app *appConnQuery) InfoSync(ctx context.Context, req types.RequestInfo) (*types.ResponseInfo, error) {
+	defer app.timer.observe("info", time.Now())
 	return app.appConn.InfoSync(ctx, req)
 }
 
 func (app *appConnQuery) QuerySync(ctx context.Context, reqQuery types.RequestQuery) (*types.ResponseQuery, error) {
+	defer app.timer.observe("query", time.Now())
 	return app.appConn.QuerySync(ctx, reqQuery)
 }
 
@@ -173,11 +228,17 @@ func (app *appConnQuery) QuerySync(ctx context.Context, reqQuery types.RequestQu
 
 type appConnSnapshot struct {
 	appConn abcicli.Client
+	timer   *callTimer
 }
 
 func NewAppConnSnapshot(appConn abcicli.Client) AppConnSnapshot {
+	return newAppConnSnapshot(appConn, nil)
+}
+
+func newAppConnSnapshot(appConn abcicli.Client, timer *callTimer) AppConnSnapshot {
 	return &appConnSnapshot{
 		appConn: appConn,
+		timer:   timer,
 	}
 }
 
@@ -189,6 +250,7 @@ func (app *appConnSnapshot) ListSnapshotsSync(
 	ctx context.Context,
 	req types.RequestListSnapshots,
 ) (*types.ResponseListSnapshots, error) {
+	defer app.timer.observe("list_snapshots", time.Now())
 	return app.appConn.ListSnapshotsSync(ctx, req)
 }
 
@@ -196,17 +258,20 @@ func (app *appConnSnapshot) OfferSnapshotSync(
 	ctx context.Context,
 	req types.RequestOfferSnapshot,
 ) (*types.ResponseOfferSnapshot, error) {
+	defer app.timer.observe("offer_snapshot", time.Now())
 	return app.appConn.OfferSnapshotSync(ctx, req)
 }
 
 func (app *appConnSnapshot) LoadSnapshotChunkSync(
 	ctx context.Context,
 	req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	defer app.timer.observe("load_snapshot_chunk", time.Now())
 	return app.appConn.LoadSnapshotChunkSync(ctx, req)
 }
 
 func (app *appConnSnapshot) ApplySnapshotChunkSync(
 	ctx context.Context,
 	req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	defer app.timer.observe("apply_snapshot_chunk", time.Now())
 	return app.appConn.ApplySnapshotChunkSync(ctx, req)
 }
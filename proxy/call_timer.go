@@ -0,0 +1,193 @@
+package proxy
+
+import (
+	"fmt"
+	"time"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// callTimer measures the ABCI calls made on a connection, recording their
+// duration in the metrics and logging the ones slower than the threshold. A
+// nil callTimer measures nothing.
+//
+// The sync calls are measured from start to end. The async ones (DeliverTx and
+// CheckTx) are measured up to their response, from the time they were sent or
+// the time the app answered the previous request if later, as the app handles
+// the requests of a connection one at a time.
+type callTimer struct {
+	conn      string
+	metrics   *Metrics
+	logger    log.Logger
+	threshold time.Duration // 0 disables the logging
+	txHasher  types.TxHasher
+
+	mtx          tmsync.Mutex
+	cb           abcicli.Callback
+	pending      map[string][]time.Time // start of the async calls, by pendingKey
+	lastResponse time.Time
+	height       int64 // of the block being executed
+}
+
+func newCallTimer(
+	conn string,
+	metrics *Metrics,
+	logger log.Logger,
+	threshold time.Duration,
+	txHasher types.TxHasher,
+) *callTimer {
+	return &callTimer{
+		conn:      conn,
+		metrics:   metrics,
+		logger:    logger,
+		threshold: threshold,
+		txHasher:  txHasher,
+		pending:   make(map[string][]time.Time),
+	}
+}
+
+// observe records a call of the method which started at start.
+func (t *callTimer) observe(method string, start time.Time, keyvals ...interface{}) {
+	if t == nil {
+		return
+	}
+	t.record(method, time.Since(start), nil, keyvals...)
+}
+
+// observeTx records a call of the method for the tx which started at start.
+func (t *callTimer) observeTx(method string, start time.Time, tx []byte) {
+	if t == nil {
+		return
+	}
+	t.record(method, time.Since(start), tx)
+}
+
+// record records a call of the method, logging it with the hash of the tx (if
+// any) and the keyvals if slow.
+func (t *callTimer) record(method string, d time.Duration, tx []byte, keyvals ...interface{}) {
+	t.metrics.MethodTiming.With("connection", t.conn, "method", method).Observe(d.Seconds())
+	if t.threshold > 0 && d >= t.threshold {
+		keyvals = append([]interface{}{"method", method, "duration", d}, keyvals...)
+		if tx != nil {
+			keyvals = append(keyvals, "tx", fmt.Sprintf("%X", t.txHasher.Hash(tx)))
+		}
+		t.logger.Info("Slow ABCI call", keyvals...)
+	}
+}
+
+// setHeight sets the height of the block being executed, logged with the
+// slow calls.
+func (t *callTimer) setHeight(height int64) {
+	if t == nil {
+		return
+	}
+	t.mtx.Lock()
+	t.height = height
+	t.mtx.Unlock()
+}
+
+func (t *callTimer) getHeight() int64 {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.height
+}
+
+// send must be called before sending an async call of the method for the tx.
+// If it fails to be sent, cancel must be called.
+func (t *callTimer) send(method string, tx []byte) {
+	if t == nil {
+		return
+	}
+	key := pendingKey(method, tx)
+	t.mtx.Lock()
+	t.pending[key] = append(t.pending[key], time.Now())
+	t.mtx.Unlock()
+}
+
+func (t *callTimer) cancel(method string, tx []byte) {
+	if t == nil {
+		return
+	}
+	key := pendingKey(method, tx)
+	t.mtx.Lock()
+	t.popPending(key, true)
+	t.mtx.Unlock()
+}
+
+// popPending removes the oldest (or last) start of the key. It must be called
+// with the mutex held.
+func (t *callTimer) popPending(key string, last bool) (time.Time, bool) {
+	starts := t.pending[key]
+	if len(starts) == 0 {
+		return time.Time{}, false
+	}
+	var start time.Time
+	if last {
+		start, starts = starts[len(starts)-1], starts[:len(starts)-1]
+	} else {
+		start, starts = starts[0], starts[1:]
+	}
+	if len(starts) == 0 {
+		delete(t.pending, key)
+	} else {
+		t.pending[key] = starts
+	}
+	return start, true
+}
+
+// setCallback sets the response callback of the connection, which is called
+// after the response is measured.
+func (t *callTimer) setCallback(cb abcicli.Callback) {
+	t.mtx.Lock()
+	t.cb = cb
+	t.mtx.Unlock()
+}
+
+// onResponse is the response callback of the client.
+func (t *callTimer) onResponse(req *abci.Request, res *abci.Response) {
+	now := time.Now()
+	var (
+		method string
+		tx     []byte
+	)
+	switch r := req.Value.(type) {
+	case *abci.Request_DeliverTx:
+		method, tx = "deliver_tx", r.DeliverTx.Tx
+	case *abci.Request_CheckTx:
+		method, tx = "check_tx", r.CheckTx.Tx
+	}
+
+	t.mtx.Lock()
+	var (
+		start   time.Time
+		pending bool
+	)
+	if method != "" {
+		start, pending = t.popPending(pendingKey(method, tx), false)
+		if start.Before(t.lastResponse) {
+			start = t.lastResponse
+		}
+	}
+	t.lastResponse = now
+	height, cb := t.height, t.cb
+	t.mtx.Unlock()
+
+	if pending {
+		if method == "deliver_tx" {
+			t.record(method, now.Sub(start), tx, "height", height)
+		} else {
+			t.record(method, now.Sub(start), tx)
+		}
+	}
+	if cb != nil {
+		cb(req, res)
+	}
+}
+
+func pendingKey(method string, tx []byte) string {
+	return method + "/" + string(tx)
+}
@@ -0,0 +1,78 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+type slowApp struct {
+	abci.BaseApplication
+}
+
+func (slowApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	if string(req.Tx) == "slow" {
+		time.Sleep(20 * time.Millisecond)
+	}
+	return abci.ResponseDeliverTx{}
+}
+
+func TestCallTimerSlowCalls(t *testing.T) {
+	var buf bytes.Buffer
+	timer := newCallTimer(connConsensus, NopMetrics(), log.NewTMLogger(log.NewSyncWriter(&buf)),
+		10*time.Millisecond, types.DefaultTxHasher)
+	conn := newAppConnConsensus(abcicli.NewLocalClient(new(tmsync.Mutex), slowApp{}), timer)
+
+	responses := 0
+	conn.SetResponseCallback(func(*abci.Request, *abci.Response) { responses++ })
+
+	ctx := context.Background()
+	_, err := conn.BeginBlockSync(ctx, abci.RequestBeginBlock{Header: tmproto.Header{Height: 7}})
+	require.NoError(t, err)
+	for _, tx := range []string{"fast", "slow", "fast"} {
+		_, err := conn.DeliverTxAsync(ctx, abci.RequestDeliverTx{Tx: []byte(tx)})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, responses)
+	assert.Empty(t, timer.pending)
+
+	// only the slow DeliverTx is logged
+	logs := buf.String()
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("Slow ABCI call")), logs)
+	assert.Contains(t, logs, "method=deliver_tx")
+	assert.Contains(t, logs, "height=7")
+	assert.Contains(t, logs, fmt.Sprintf("tx=%X", types.Tx("slow").Hash()))
+}
+
+func TestCallTimerPipelinedCalls(t *testing.T) {
+	timer := newCallTimer(connConsensus, NopMetrics(), log.NewNopLogger(), time.Hour, types.DefaultTxHasher)
+	req1 := abci.ToRequestDeliverTx(abci.RequestDeliverTx{Tx: []byte("tx1")})
+	req2 := abci.ToRequestDeliverTx(abci.RequestDeliverTx{Tx: []byte("tx2")})
+
+	// both sent at once, the second is measured from the response to the first
+	timer.send("deliver_tx", []byte("tx1"))
+	timer.send("deliver_tx", []byte("tx2"))
+	sent := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	timer.onResponse(req1, nil)
+	assert.True(t, timer.lastResponse.Sub(sent) >= 10*time.Millisecond)
+	timer.onResponse(req2, nil)
+	assert.Empty(t, timer.pending)
+
+	// the unsent calls are forgotten
+	timer.send("deliver_tx", []byte("tx1"))
+	timer.cancel("deliver_tx", []byte("tx1"))
+	assert.Empty(t, timer.pending)
+}
@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"fmt"
+	"time"
 
 	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/example/counter"
@@ -17,10 +18,11 @@ type ClientCreator interface {
 }
 
 //----------------------------------------------------
-// local proxy uses a mutex on an in-proc app
+// local proxy uses a mutex on an in-proc app, acquired first by the consensus
+// connection
 
 type localClientCreator struct {
-	mtx *tmsync.Mutex
+	mtx *tmsync.PriorityMutex
 	app types.Application
 }
 
@@ -28,13 +30,13 @@ type localClientCreator struct {
 // which will be running locally.
 func NewLocalClientCreator(app types.Application) ClientCreator {
 	return &localClientCreator{
-		mtx: new(tmsync.Mutex),
+		mtx: new(tmsync.PriorityMutex),
 		app: app,
 	}
 }
 
 func (l *localClientCreator) NewABCIClient() (abcicli.Client, error) {
-	return abcicli.NewLocalClient(l.mtx, l.app), nil
+	return abcicli.NewPriorityLocalClient(l.mtx, l.app), nil
 }
 
 //---------------------------------------------------------------
@@ -44,17 +46,42 @@ type remoteClientCreator struct {
 	addr        string
 	transport   string
 	mustConnect bool
+
+	flushThrottles map[string]time.Duration // by connection
+}
+
+// RemoteClientOption sets an optional parameter on the clients created by a
+// remote ClientCreator.
+type RemoteClientOption func(*remoteClientCreator)
+
+// ConsensusFlushThrottle sets the maximum time the async requests of the
+// consensus connection are buffered before being sent, if the transport is
+// "socket" (0 - the client's default).
+func ConsensusFlushThrottle(d time.Duration) RemoteClientOption {
+	return func(r *remoteClientCreator) { r.flushThrottles[connConsensus] = d }
+}
+
+// MempoolFlushThrottle sets the maximum time the async requests of the
+// mempool connection (i.e. CheckTx) are buffered before being sent, if the
+// transport is "socket" (0 - the client's default).
+func MempoolFlushThrottle(d time.Duration) RemoteClientOption {
+	return func(r *remoteClientCreator) { r.flushThrottles[connMempool] = d }
 }
 
 // NewRemoteClientCreator returns a ClientCreator for the given address (e.g.
 // "192.168.0.1") and transport (e.g. "tcp"). Set mustConnect to true if you
 // want the client to connect before reporting success.
-func NewRemoteClientCreator(addr, transport string, mustConnect bool) ClientCreator {
-	return &remoteClientCreator{
-		addr:        addr,
-		transport:   transport,
-		mustConnect: mustConnect,
+func NewRemoteClientCreator(addr, transport string, mustConnect bool, options ...RemoteClientOption) ClientCreator {
+	r := &remoteClientCreator{
+		addr:           addr,
+		transport:      transport,
+		mustConnect:    mustConnect,
+		flushThrottles: make(map[string]time.Duration),
 	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
 }
 
 func (r *remoteClientCreator) NewABCIClient() (abcicli.Client, error) {
@@ -66,10 +93,25 @@ func (r *remoteClientCreator) NewABCIClient() (abcicli.Client, error) {
 	return remoteApp, nil
 }
 
+// newABCIClientFor implements connClientCreator.
+func (r *remoteClientCreator) newABCIClientFor(conn string) (abcicli.Client, error) {
+	if d := r.flushThrottles[conn]; d > 0 && r.transport == "socket" {
+		return abcicli.NewSocketClient(r.addr, r.mustConnect, abcicli.SocketFlushThrottle(d)), nil
+	}
+	return r.NewABCIClient()
+}
+
+// connClientCreator is implemented by the ClientCreators configuring the
+// client of each connection ("consensus", "mempool", "query" or "snapshot").
+type connClientCreator interface {
+	newABCIClientFor(conn string) (abcicli.Client, error)
+}
+
 // DefaultClientCreator returns a default ClientCreator, which will create a
 // local client if addr is one of: 'counter', 'counter_serial', 'kvstore',
-// 'persistent_kvstore' or 'noop', otherwise - a remote client.
-func DefaultClientCreator(addr, transport, dbDir string) ClientCreator {
+// 'persistent_kvstore' or 'noop', otherwise - a remote client, configured by
+// the given options.
+func DefaultClientCreator(addr, transport, dbDir string, options ...RemoteClientOption) ClientCreator {
 	switch addr {
 	case "counter":
 		return NewLocalClientCreator(counter.NewApplication(false))
@@ -83,6 +125,6 @@ func DefaultClientCreator(addr, transport, dbDir string) ClientCreator {
 		return NewLocalClientCreator(types.NewBaseApplication())
 	default:
 		mustConnect := false // loop retrying
-		return NewRemoteClientCreator(addr, transport, mustConnect)
+		return NewRemoteClientCreator(addr, transport, mustConnect, options...)
 	}
 }
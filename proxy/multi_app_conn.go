@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"os"
 	"syscall"
+	"time"
 
 	abcicli "github.com/tendermint/tendermint/abci/client"
 	tmlog "github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
 )
 
 const (
@@ -33,8 +35,26 @@ type AppConns interface {
 }
 
 // NewAppConns calls NewMultiAppConn.
-func NewAppConns(clientCreator ClientCreator) AppConns {
-	return NewMultiAppConn(clientCreator)
+func NewAppConns(clientCreator ClientCreator, options ...MultiAppConnOption) AppConns {
+	return NewMultiAppConn(clientCreator, options...)
+}
+
+// MultiAppConnOption sets an optional parameter on the multiAppConn.
+type MultiAppConnOption func(*multiAppConn)
+
+// WithMetrics sets the metrics recording the duration of the ABCI calls.
+func WithMetrics(metrics *Metrics) MultiAppConnOption {
+	return func(app *multiAppConn) { app.metrics = metrics }
+}
+
+// WithSlowCallLogging logs the ABCI calls taking at least the threshold, with
+// the height of the block and the hash of the tx (computed by the txHasher)
+// they are made for.
+func WithSlowCallLogging(threshold time.Duration, txHasher types.TxHasher) MultiAppConnOption {
+	return func(app *multiAppConn) {
+		app.slowCallThreshold = threshold
+		app.txHasher = txHasher
+	}
 }
 
 // multiAppConn implements AppConns.
@@ -56,14 +76,21 @@ type multiAppConn struct {
 	snapshotConnClient  abcicli.Client
 
 	clientCreator ClientCreator
+
+	metrics           *Metrics // nil if the calls aren't measured
+	slowCallThreshold time.Duration
+	txHasher          types.TxHasher
 }
 
 // NewMultiAppConn makes all necessary abci connections to the application.
-func NewMultiAppConn(clientCreator ClientCreator) AppConns {
+func NewMultiAppConn(clientCreator ClientCreator, options ...MultiAppConnOption) AppConns {
 	multiAppConn := &multiAppConn{
 		clientCreator: clientCreator,
 	}
 	multiAppConn.BaseService = *service.NewBaseService(nil, "multiAppConn", multiAppConn)
+	for _, option := range options {
+		option(multiAppConn)
+	}
 	return multiAppConn
 }
 
@@ -89,7 +116,7 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.queryConnClient = c
-	app.queryConn = NewAppConnQuery(c)
+	app.queryConn = newAppConnQuery(c, app.callTimerFor(connQuery))
 
 	c, err = app.abciClientFor(connSnapshot)
 	if err != nil {
@@ -97,7 +124,7 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.snapshotConnClient = c
-	app.snapshotConn = NewAppConnSnapshot(c)
+	app.snapshotConn = newAppConnSnapshot(c, app.callTimerFor(connSnapshot))
 
 	c, err = app.abciClientFor(connMempool)
 	if err != nil {
@@ -105,7 +132,7 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.mempoolConnClient = c
-	app.mempoolConn = NewAppConnMempool(c)
+	app.mempoolConn = newAppConnMempool(c, app.callTimerFor(connMempool))
 
 	c, err = app.abciClientFor(connConsensus)
 	if err != nil {
@@ -113,7 +140,7 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.consensusConnClient = c
-	app.consensusConn = NewAppConnConsensus(c)
+	app.consensusConn = newAppConnConsensus(c, app.callTimerFor(connConsensus))
 
 	// Kill Tendermint if the ABCI application crashes.
 	go app.killTMOnClientError()
@@ -179,7 +206,15 @@ func (app *multiAppConn) stopAllClients() {
 }
 
 func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
-	c, err := app.clientCreator.NewABCIClient()
+	var (
+		c   abcicli.Client
+		err error
+	)
+	if cc, ok := app.clientCreator.(connClientCreator); ok {
+		c, err = cc.newABCIClientFor(conn)
+	} else {
+		c, err = app.clientCreator.NewABCIClient()
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error creating ABCI client (%s connection): %w", conn, err)
 	}
@@ -190,6 +225,23 @@ func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
 	return c, nil
 }
 
+// callTimerFor returns the timer of the calls of the connection, or nil if they
+// aren't measured.
+func (app *multiAppConn) callTimerFor(conn string) *callTimer {
+	if app.metrics == nil && app.slowCallThreshold == 0 {
+		return nil
+	}
+	metrics := app.metrics
+	if metrics == nil {
+		metrics = NopMetrics()
+	}
+	txHasher := app.txHasher
+	if txHasher == nil {
+		txHasher = types.DefaultTxHasher
+	}
+	return newCallTimer(conn, metrics, app.Logger.With("connection", conn), app.slowCallThreshold, txHasher)
+}
+
 func kill() error {
 	p, err := os.FindProcess(os.Getpid())
 	if err != nil {
@@ -0,0 +1,209 @@
+// Package replica implements the read replica mode, in which a node follows
+// the chain of trusted upstream nodes over RPC instead of taking part in the
+// p2p network.
+package replica
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/service"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Upstream is a trusted node the blocks are fetched from, e.g. an
+// rpc/client/http.HTTP client.
+type Upstream interface {
+	Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error)
+	Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
+}
+
+// Follower is a service which fetches the blocks following the local state
+// from the upstreams, one at a time, verifies their commit with the local
+// validator set, like fast sync, then saves and applies them. It falls back to
+// the next upstream whenever one fails or returns an invalid block, and polls
+// them every pollInterval once it caught up with the chain.
+type Follower struct {
+	service.BaseService
+
+	blockExec  *sm.BlockExecutor
+	blockStore sm.BlockStore
+	upstreams  []Upstream
+
+	pollInterval time.Duration
+	timeout      time.Duration
+	onCaughtUp   func() // see SetOnCaughtUp
+
+	// cancels the requests to the upstreams, and closes done once
+	// followRoutine returned, see OnStop
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// only accessed by followRoutine
+	state    sm.State
+	upstream int  // index of the upstream in use
+	caughtUp bool // whether onCaughtUp was called
+}
+
+// NewFollower returns a new Follower applying the blocks to state. timeout is
+// the maximum time to fetch a block and its commit.
+func NewFollower(
+	state sm.State,
+	blockExec *sm.BlockExecutor,
+	blockStore sm.BlockStore,
+	upstreams []Upstream,
+	pollInterval time.Duration,
+	timeout time.Duration,
+) *Follower {
+	f := &Follower{
+		state:        state,
+		blockExec:    blockExec,
+		blockStore:   blockStore,
+		upstreams:    upstreams,
+		pollInterval: pollInterval,
+		timeout:      timeout,
+	}
+	f.BaseService = *service.NewBaseService(nil, "Follower", f)
+	return f
+}
+
+// SetOnCaughtUp sets a function called once the follower first caught up with
+// the chain of an upstream, i.e. the next block isn't committed yet. It must
+// be called before the follower is started.
+func (f *Follower) SetOnCaughtUp(fn func()) {
+	f.onCaughtUp = fn
+}
+
+// OnStart implements service.Service.
+func (f *Follower) OnStart() error {
+	if len(f.upstreams) == 0 {
+		return errors.New("no upstreams")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	f.cancel = cancel
+	f.done = make(chan struct{})
+	go f.followRoutine(ctx)
+	return nil
+}
+
+// OnStop implements service.Service. It cancels the pending requests to the
+// upstreams, and waits for followRoutine to return.
+func (f *Follower) OnStop() {
+	f.cancel()
+	<-f.done
+}
+
+// followRoutine applies the blocks until the service is stopped.
+func (f *Follower) followRoutine(ctx context.Context) {
+	defer close(f.done)
+	for {
+		err := f.applyNextBlock(ctx)
+		if err == nil {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				continue
+			}
+		}
+
+		var verifyErr verificationError
+		if errors.As(err, &verifyErr) {
+			f.Logger.Error("Upstream returned an invalid block", "upstream", f.upstream, "err", err)
+		} else {
+			// most likely the block isn't committed yet
+			f.Logger.Debug("Failed to fetch the next block", "upstream", f.upstream, "err", err)
+			if !f.caughtUp && f.isCaughtUp(ctx) {
+				f.Logger.Info("Caught up with the upstream", "upstream", f.upstream, "height", f.state.LastBlockHeight)
+				f.caughtUp = true
+				if f.onCaughtUp != nil {
+					f.onCaughtUp()
+				}
+			}
+		}
+		f.upstream = (f.upstream + 1) % len(f.upstreams)
+
+		select {
+		case <-time.After(f.pollInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// verificationError is returned by applyNextBlock when the upstream returned
+// an invalid block or commit.
+type verificationError struct {
+	err error
+}
+
+func (e verificationError) Error() string { return e.err.Error() }
+
+func (e verificationError) Unwrap() error { return e.err }
+
+// isCaughtUp returns whether the last block committed by the upstream in use
+// is the one of the state.
+func (f *Follower) isCaughtUp(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+	resCommit, err := f.upstreams[f.upstream].Commit(ctx, nil)
+	return err == nil && resCommit.Header != nil && resCommit.Height <= f.state.LastBlockHeight
+}
+
+// applyNextBlock fetches, verifies and applies the block following the state.
+// It panics if the app fails to apply a valid block.
+func (f *Follower) applyNextBlock(ctx context.Context) error {
+	height := f.state.LastBlockHeight + 1
+	if f.state.LastBlockHeight == 0 {
+		height = f.state.InitialHeight
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+	upstream := f.upstreams[f.upstream]
+
+	resCommit, err := upstream.Commit(ctx, &height)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the commit for height %d: %w", height, err)
+	}
+	commit := resCommit.Commit
+	if commit == nil || commit.Height != height {
+		return verificationError{fmt.Errorf("missing commit for height %d", height)}
+	}
+	err = f.state.Validators.VerifyCommitLight(f.state.ChainID, commit.BlockID, height, commit)
+	if err != nil {
+		return verificationError{fmt.Errorf("invalid commit for height %d: %w", height, err)}
+	}
+
+	resBlock, err := upstream.Block(ctx, &height)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the block at height %d: %w", height, err)
+	}
+	block := resBlock.Block
+	if block == nil {
+		return verificationError{fmt.Errorf("missing block at height %d", height)}
+	}
+	// NOTE: block.Hash() doesn't cover the txs, so the part set hash must be
+	// checked as well.
+	parts := block.MakePartSet(types.BlockPartSizeBytes)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+	if !blockID.Equals(commit.BlockID) {
+		return verificationError{fmt.Errorf("block %v doesn't match the commit for %v", blockID, commit.BlockID)}
+	}
+	if err := f.blockExec.ValidateBlock(f.state, block); err != nil {
+		return verificationError{fmt.Errorf("invalid block at height %d: %w", height, err)}
+	}
+
+	f.blockStore.SaveBlock(block, parts, commit)
+	state, _, err := f.blockExec.ApplyBlock(f.state, blockID, block)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", height, block.Hash(), err))
+	}
+	f.state = state
+	f.Logger.Debug("Applied block", "height", height, "hash", block.Hash())
+	return nil
+}
@@ -0,0 +1,135 @@
+package replica
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/mempool/mock"
+	"github.com/tendermint/tendermint/proxy"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+// testUpstream serves the blocks and commits of a chain.
+type testUpstream struct {
+	blocks  map[int64]*types.Block
+	commits map[int64]*types.Commit
+}
+
+func (u *testUpstream) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	block, ok := u.blocks[*height]
+	if !ok {
+		return nil, fmt.Errorf("no block at height %d", *height)
+	}
+	return &ctypes.ResultBlock{Block: block}, nil
+}
+
+func (u *testUpstream) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	if height == nil {
+		last := int64(len(u.blocks))
+		height = &last
+	}
+	commit, ok := u.commits[*height]
+	if !ok {
+		return nil, fmt.Errorf("no commit for height %d", *height)
+	}
+	return &ctypes.ResultCommit{SignedHeader: types.SignedHeader{Header: &u.blocks[*height].Header, Commit: commit}}, nil
+}
+
+func newTestExecutor(t *testing.T, genDoc *types.GenesisDoc) (sm.State, sm.Store, *store.BlockStore, *sm.BlockExecutor) {
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewApplication()))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() { require.NoError(t, proxyApp.Stop()) })
+
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	state, err := stateStore.LoadFromDBOrGenesisDoc(genDoc)
+	require.NoError(t, err)
+	require.NoError(t, stateStore.Save(state))
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mock.Mempool{}, sm.EmptyEvidencePool{})
+	return state, stateStore, store.NewBlockStore(dbm.NewMemDB()), blockExec
+}
+
+// makeChain commits the given number of blocks, signed by privVal, returning
+// the upstream serving them and the last state.
+func makeChain(t *testing.T, genDoc *types.GenesisDoc, privVal types.PrivValidator,
+	blocks int64) (*testUpstream, sm.State) {
+	state, _, _, blockExec := newTestExecutor(t, genDoc)
+	upstream := &testUpstream{blocks: map[int64]*types.Block{}, commits: map[int64]*types.Commit{}}
+
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for height := int64(1); height <= blocks; height++ {
+		txs := []types.Tx{types.Tx(fmt.Sprintf("height=%d", height))}
+		block, parts := state.MakeBlock(height, txs, lastCommit, nil, state.Validators.GetProposer().Address)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+
+		var err error
+		state, _, err = blockExec.ApplyBlock(state, blockID, block)
+		require.NoError(t, err)
+
+		vote, err := types.MakeVote(height, blockID, state.LastValidators, privVal, genDoc.ChainID, tmtime.Now())
+		require.NoError(t, err)
+		lastCommit = types.NewCommit(height, 0, blockID, []types.CommitSig{vote.CommitSig()})
+
+		upstream.blocks[height] = block
+		upstream.commits[height] = lastCommit
+	}
+	return upstream, state
+}
+
+func TestFollower(t *testing.T) {
+	val, privVal := types.RandValidator(false, 10)
+	genDoc := &types.GenesisDoc{
+		GenesisTime: tmtime.Now(),
+		ChainID:     "replica-test",
+		Validators:  []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}},
+	}
+	upstream, upstreamState := makeChain(t, genDoc, privVal, 5)
+	// the same blocks, with commits signed by another validator
+	invalid := &testUpstream{blocks: upstream.blocks, commits: map[int64]*types.Commit{}}
+	otherVals, otherPrivVals := types.RandValidatorSet(1, 10)
+	for height, commit := range upstream.commits {
+		vote, err := types.MakeVote(height, commit.BlockID, otherVals, otherPrivVals[0], genDoc.ChainID, tmtime.Now())
+		require.NoError(t, err)
+		invalid.commits[height] = types.NewCommit(height, 0, commit.BlockID, []types.CommitSig{vote.CommitSig()})
+	}
+
+	state, stateStore, blockStore, blockExec := newTestExecutor(t, genDoc)
+	follower := NewFollower(state, blockExec, blockStore, []Upstream{invalid, upstream},
+		10*time.Millisecond, time.Second)
+	follower.SetLogger(log.TestingLogger())
+	caughtUp := make(chan struct{})
+	follower.SetOnCaughtUp(func() { close(caughtUp) })
+	require.NoError(t, follower.Start())
+	t.Cleanup(func() { require.NoError(t, follower.Stop()) })
+
+	select {
+	case <-caughtUp:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the follower to catch up")
+	}
+	assert.EqualValues(t, 5, blockStore.Height())
+	require.Eventually(t, func() bool {
+		state, err := stateStore.Load()
+		require.NoError(t, err)
+		return state.LastBlockHeight == 5
+	}, time.Second, 10*time.Millisecond)
+
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, upstreamState.AppHash, state.AppHash)
+	assert.Equal(t, upstream.blocks[3].Hash(), blockStore.LoadBlock(3).Hash())
+	assert.Equal(t, upstream.commits[5].BlockID, blockStore.LoadSeenCommit(5).BlockID)
+}
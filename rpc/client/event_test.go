@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
@@ -156,6 +157,101 @@ func testTxEventsSent(t *testing.T, broadcastMethod string) {
 	}
 }
 
+func TestHTTPBroadcastTxSubscribe(t *testing.T) {
+	c := getHTTPClient()
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	k, _, tx := MakeTxKV()
+	query := fmt.Sprintf("tm.event = 'Tx' AND app.key = '%s'", k)
+
+	ctx, cancel := context.WithTimeout(context.Background(), waitForEventTimeout)
+	defer cancel()
+	res, eventCh, err := c.BroadcastTxSubscribe(ctx, tx, query)
+	require.NoError(t, err)
+	assert.Equal(t, abci.CodeTypeOK, res.Code)
+	t.Cleanup(func() {
+		if err := c.Unsubscribe(context.Background(), "", query); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// the subscription isn't replaced by another one to the same query
+	_, _, err = c.BroadcastTxSubscribe(ctx, types.Tx("other=tx"), query)
+	assert.Equal(t, tmpubsub.ErrAlreadySubscribed, err)
+
+	select {
+	case event := <-eventCh:
+		txe, ok := event.Data.(types.EventDataTx)
+		require.True(t, ok)
+		require.EqualValues(t, tx, txe.Tx)
+		require.True(t, txe.Result.IsOK())
+	case <-time.After(waitForEventTimeout):
+		t.Fatal("did not receive the tx event")
+	}
+}
+
+func TestOrSubscription(t *testing.T) {
+	for _, c := range GetClients() {
+		c := c
+		t.Run(reflect.TypeOf(c).String(), func(t *testing.T) {
+			if !c.IsRunning() {
+				require.NoError(t, c.Start())
+				t.Cleanup(func() {
+					if err := c.Stop(); err != nil {
+						t.Error(err)
+					}
+				})
+			}
+
+			k, _, tx := MakeTxKV()
+			query := fmt.Sprintf("(tm.event = 'NewBlockHeader') OR (tm.event = 'Tx' AND app.key = '%s')", k)
+
+			ctx, cancel := context.WithTimeout(context.Background(), waitForEventTimeout)
+			defer cancel()
+			eventCh, err := c.Subscribe(ctx, "TestOrSubscription", query, 100)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				if err := c.Unsubscribe(context.Background(), "TestOrSubscription", query); err != nil {
+					t.Error(err)
+				}
+			})
+
+			// broadcast the tx once a header is received, so that the subscription
+			// is known to be active
+			deadline := time.After(waitForEventTimeout)
+			var broadcast bool
+			for {
+				select {
+				case event := <-eventCh:
+					assert.Equal(t, query, event.Query)
+					switch data := event.Data.(type) {
+					case types.EventDataNewBlockHeader:
+						if !broadcast {
+							broadcast = true
+							go func() {
+								_, err := c.BroadcastTxAsync(context.Background(), tx)
+								assert.NoError(t, err)
+							}()
+						}
+					case types.EventDataTx:
+						require.EqualValues(t, tx, data.Tx)
+						return
+					default:
+						t.Fatalf("unexpected event %T", event.Data)
+					}
+				case <-deadline:
+					t.Fatal("did not receive the tx event")
+				}
+			}
+		})
+	}
+}
+
 // Test HTTPClient resubscribes upon disconnect && subscription error.
 // Test Local client resubscribes upon subscription error.
 func TestClientsResubscribe(t *testing.T) {
@@ -2,11 +2,13 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"strings"
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/bytes"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
@@ -16,6 +18,7 @@ import (
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	jsonrpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -258,6 +261,20 @@ func (c *baseRPCClient) BroadcastTxCommit(
 	return result, nil
 }
 
+// BroadcastTxCommitWithProof is like BroadcastTxCommit, but the result also
+// includes the inclusion proof of tx, see light.VerifyTxInclusion.
+func (c *baseRPCClient) BroadcastTxCommitWithProof(
+	ctx context.Context,
+	tx types.Tx,
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	result := new(ctypes.ResultBroadcastTxCommit)
+	_, err := c.caller.Call(ctx, "broadcast_tx_commit", map[string]interface{}{"tx": tx, "prove": true}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BroadcastTxAsync(
 	ctx context.Context,
 	tx types.Tx,
@@ -310,6 +327,15 @@ func (c *baseRPCClient) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUn
 	return result, nil
 }
 
+func (c *baseRPCClient) MempoolStats(ctx context.Context) (*ctypes.ResultMempoolStats, error) {
+	result := new(ctypes.ResultMempoolStats)
+	_, err := c.caller.Call(ctx, "mempool_stats", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	result := new(ctypes.ResultCheckTx)
 	_, err := c.caller.Call(ctx, "check_tx", map[string]interface{}{"tx": tx}, result)
@@ -337,6 +363,29 @@ func (c *baseRPCClient) DumpConsensusState(ctx context.Context) (*ctypes.ResultD
 	return result, nil
 }
 
+// DumpConsensusStateFiltered dumps the consensus state with the votes of the
+// given validator and round only, if set, in the given format (see
+// DumpConsensusState in rpc/core).
+func (c *baseRPCClient) DumpConsensusStateFiltered(ctx context.Context, validator []byte, round *int32,
+	format string, peerSummary bool) (*ctypes.ResultDumpConsensusState, error) {
+	result := new(ctypes.ResultDumpConsensusState)
+	params := map[string]interface{}{
+		"format":       format,
+		"peer_summary": peerSummary,
+	}
+	if validator != nil {
+		params["validator"] = validator
+	}
+	if round != nil {
+		params["round"] = round
+	}
+	_, err := c.caller.Call(ctx, "dump_consensus_state", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
 	result := new(ctypes.ResultConsensusState)
 	_, err := c.caller.Call(ctx, "consensus_state", map[string]interface{}{}, result)
@@ -362,6 +411,18 @@ func (c *baseRPCClient) ConsensusParams(
 	return result, nil
 }
 
+func (c *baseRPCClient) SimulateParamChange(
+	ctx context.Context,
+	updates *abci.ConsensusParams,
+) (*ctypes.ResultSimulateParamChange, error) {
+	result := new(ctypes.ResultSimulateParamChange)
+	_, err := c.caller.Call(ctx, "simulate_param_change", map[string]interface{}{"updates": updates}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
 	result := new(ctypes.ResultHealth)
 	_, err := c.caller.Call(ctx, "health", map[string]interface{}{}, result)
@@ -420,6 +481,36 @@ func (c *baseRPCClient) BlockByHash(ctx context.Context, hash []byte) (*ctypes.R
 	return result, nil
 }
 
+func (c *baseRPCClient) BlockRaw(ctx context.Context, height *int64, compress bool) (*ctypes.ResultBlockRaw, error) {
+	result := new(ctypes.ResultBlockRaw)
+	params := map[string]interface{}{
+		"compress": compress,
+	}
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "block_raw", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) BlockPart(ctx context.Context, height *int64, index int) (*ctypes.ResultBlockPart, error) {
+	result := new(ctypes.ResultBlockPart)
+	params := map[string]interface{}{
+		"index": index,
+	}
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "block_part", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BlockResults(
 	ctx context.Context,
 	height *int64,
@@ -449,6 +540,21 @@ func (c *baseRPCClient) Commit(ctx context.Context, height *int64) (*ctypes.Resu
 	return result, nil
 }
 
+// CommitWithSignBytes is like Commit, but the result also includes the
+// canonical bytes signed by each signature, see types.Commit.SignBytes.
+func (c *baseRPCClient) CommitWithSignBytes(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	result := new(ctypes.ResultCommit)
+	params := map[string]interface{}{"sign_bytes": true}
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "commit", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	result := new(ctypes.ResultTx)
 	params := map[string]interface{}{
@@ -490,6 +596,52 @@ func (c *baseRPCClient) TxSearch(
 	return result, nil
 }
 
+// TxSearchCount returns the number of txs matching the query, without loading
+// them.
+func (c *baseRPCClient) TxSearchCount(ctx context.Context, query string) (int, error) {
+	result := new(ctypes.ResultTxSearch)
+	params := map[string]interface{}{
+		"query":      query,
+		"count_only": true,
+	}
+	_, err := c.caller.Call(ctx, "tx_search", params, result)
+	if err != nil {
+		return 0, err
+	}
+	return result.TotalCount, nil
+}
+
+func (c *baseRPCClient) Events(
+	ctx context.Context,
+	query string,
+	fromHeight,
+	toHeight *int64,
+	page,
+	perPage *int,
+) (*ctypes.ResultEvents, error) {
+	result := new(ctypes.ResultEvents)
+	params := map[string]interface{}{
+		"query": query,
+	}
+	if fromHeight != nil {
+		params["from_height"] = fromHeight
+	}
+	if toHeight != nil {
+		params["to_height"] = toHeight
+	}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, "events", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Validators(
 	ctx context.Context,
 	height *int64,
@@ -514,6 +666,48 @@ func (c *baseRPCClient) Validators(
 	return result, nil
 }
 
+func (c *baseRPCClient) ValidatorInfo(ctx context.Context, address []byte) (*ctypes.ResultValidatorInfo, error) {
+	result := new(ctypes.ResultValidatorInfo)
+	params := make(map[string]interface{})
+	if address != nil {
+		params["address"] = address
+	}
+	_, err := c.caller.Call(ctx, "validator_info", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) NodeAttestation(ctx context.Context, nodeID string) (*ctypes.ResultNodeAttestation, error) {
+	result := new(ctypes.ResultNodeAttestation)
+	_, err := c.caller.Call(ctx, "node_attestation", map[string]interface{}{"node_id": nodeID}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) TimestampDrift(
+	ctx context.Context,
+	minHeight,
+	maxHeight *int64,
+) (*ctypes.ResultTimestampDrift, error) {
+	result := new(ctypes.ResultTimestampDrift)
+	params := make(map[string]interface{})
+	if minHeight != nil {
+		params["min_height"] = minHeight
+	}
+	if maxHeight != nil {
+		params["max_height"] = maxHeight
+	}
+	_, err := c.caller.Call(ctx, "timestamp_drift", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BroadcastEvidence(
 	ctx context.Context,
 	ev types.Evidence,
@@ -539,7 +733,8 @@ type WSEvents struct {
 	ws       *jsonrpcclient.WSClient
 
 	mtx           tmsync.RWMutex
-	subscriptions map[string]chan ctypes.ResultEvent // query -> chan
+	subscriptions map[string]chan ctypes.ResultEvent                          // query -> chan
+	pending       map[jsonrpctypes.JSONRPCIntID]chan jsonrpctypes.RPCResponse // request ID -> chan
 }
 
 func newWSEvents(remote, endpoint string) (*WSEvents, error) {
@@ -547,6 +742,7 @@ func newWSEvents(remote, endpoint string) (*WSEvents, error) {
 		endpoint:      endpoint,
 		remote:        remote,
 		subscriptions: make(map[string]chan ctypes.ResultEvent),
+		pending:       make(map[jsonrpctypes.JSONRPCIntID]chan jsonrpctypes.RPCResponse),
 	}
 	w.BaseService = *service.NewBaseService(nil, "WSEvents", w)
 
@@ -614,6 +810,80 @@ func (w *WSEvents) Subscribe(ctx context.Context, subscriber, query string,
 	return outc, nil
 }
 
+// BroadcastTxSubscribe subscribes to query and broadcasts tx in a single
+// request, so that no event matching query can be missed in between, and
+// returns the CheckTx response. Events are delivered on the returned channel,
+// which behaves like the one returned by Subscribe. The subscription is kept
+// until Unsubscribe is called.
+//
+// It returns an error if WSEvents is not running, or is already subscribed to
+// query.
+func (w *WSEvents) BroadcastTxSubscribe(ctx context.Context, tx types.Tx, query string,
+	outCapacity ...int) (*ctypes.ResultBroadcastTx, <-chan ctypes.ResultEvent, error) {
+
+	if !w.IsRunning() {
+		return nil, nil, errNotRunning
+	}
+
+	outCap := 1
+	if len(outCapacity) > 0 {
+		outCap = outCapacity[0]
+	}
+
+	var (
+		id   = w.ws.NextRequestID()
+		outc = make(chan ctypes.ResultEvent, outCap)
+		resc = make(chan jsonrpctypes.RPCResponse, 1)
+	)
+	req, err := jsonrpctypes.MapToRequest(id, "broadcast_tx_subscribe",
+		map[string]interface{}{"tx": tx, "query": query})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Register the subscription before sending the request, since events may
+	// arrive before the response.
+	w.mtx.Lock()
+	if _, ok := w.subscriptions[query]; ok {
+		w.mtx.Unlock()
+		return nil, nil, tmpubsub.ErrAlreadySubscribed
+	}
+	w.subscriptions[query] = outc
+	w.pending[id] = resc
+	w.mtx.Unlock()
+
+	ok := false
+	defer func() {
+		w.mtx.Lock()
+		delete(w.pending, id)
+		if !ok {
+			delete(w.subscriptions, query)
+		}
+		w.mtx.Unlock()
+	}()
+
+	if err := w.ws.Send(ctx, req); err != nil {
+		return nil, nil, err
+	}
+
+	select {
+	case resp := <-resc:
+		if resp.Error != nil {
+			return nil, nil, resp.Error
+		}
+		result := new(ctypes.ResultBroadcastTx)
+		if err := tmjson.Unmarshal(resp.Result, result); err != nil {
+			return nil, nil, err
+		}
+		ok = true
+		return result, outc, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case <-w.Quit():
+		return nil, nil, errNotRunning
+	}
+}
+
 // Unsubscribe implements EventsClient by using WSClient to unsubscribe given
 // subscriber from query.
 //
@@ -676,6 +946,38 @@ func isErrAlreadySubscribed(err error) bool {
 	return strings.Contains(err.Error(), tmpubsub.ErrAlreadySubscribed.Error())
 }
 
+// resolvePending delivers resp to the pending request with the same ID, if
+// any. Events of a subscription made by a request share the request's ID, so
+// they are told apart by their query.
+func (w *WSEvents) resolvePending(resp jsonrpctypes.RPCResponse) bool {
+	id, ok := resp.ID.(jsonrpctypes.JSONRPCIntID)
+	if !ok {
+		return false
+	}
+
+	w.mtx.RLock()
+	resc, ok := w.pending[id]
+	w.mtx.RUnlock()
+	if !ok {
+		return false
+	}
+
+	if resp.Error == nil {
+		var event struct {
+			Query string `json:"query"`
+		}
+		if err := json.Unmarshal(resp.Result, &event); err == nil && event.Query != "" {
+			return false
+		}
+	}
+
+	select {
+	case resc <- resp:
+	default:
+	}
+	return true
+}
+
 func (w *WSEvents) eventListener() {
 	for {
 		select {
@@ -684,6 +986,10 @@ func (w *WSEvents) eventListener() {
 				return
 			}
 
+			if w.resolvePending(resp) {
+				continue
+			}
+
 			if resp.Error != nil {
 				w.Logger.Error("WS error", "err", resp.Error.Error())
 				// Error can be ErrAlreadySubscribed or max client (subscriptions per
@@ -3,8 +3,10 @@ package local
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
@@ -88,15 +90,21 @@ func (c *Local) ABCIQueryWithOptions(
 }
 
 func (c *Local) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
-	return core.BroadcastTxCommit(c.ctx, tx)
+	return core.BroadcastTxCommit(c.ctx, tx, "", false)
+}
+
+// BroadcastTxCommitWithProof is like BroadcastTxCommit, but the result also
+// includes the inclusion proof of tx, see light.VerifyTxInclusion.
+func (c *Local) BroadcastTxCommitWithProof(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	return core.BroadcastTxCommit(c.ctx, tx, "", true)
 }
 
 func (c *Local) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	return core.BroadcastTxAsync(c.ctx, tx)
+	return core.BroadcastTxAsync(c.ctx, tx, "")
 }
 
 func (c *Local) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	return core.BroadcastTxSync(c.ctx, tx)
+	return core.BroadcastTxSync(c.ctx, tx, "")
 }
 
 func (c *Local) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
@@ -107,6 +115,10 @@ func (c *Local) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirme
 	return core.NumUnconfirmedTxs(c.ctx)
 }
 
+func (c *Local) MempoolStats(ctx context.Context) (*ctypes.ResultMempoolStats, error) {
+	return core.MempoolStats(c.ctx)
+}
+
 func (c *Local) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return core.CheckTx(c.ctx, tx)
 }
@@ -116,7 +128,12 @@ func (c *Local) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 }
 
 func (c *Local) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
-	return core.DumpConsensusState(c.ctx)
+	return core.DumpConsensusState(c.ctx, nil, nil, "", false)
+}
+
+func (c *Local) DumpConsensusStateFiltered(ctx context.Context, validator []byte, round *int32,
+	format string, peerSummary bool) (*ctypes.ResultDumpConsensusState, error) {
+	return core.DumpConsensusState(c.ctx, validator, round, format, peerSummary)
 }
 
 func (c *Local) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
@@ -127,6 +144,13 @@ func (c *Local) ConsensusParams(ctx context.Context, height *int64) (*ctypes.Res
 	return core.ConsensusParams(c.ctx, height)
 }
 
+func (c *Local) SimulateParamChange(
+	ctx context.Context,
+	updates *abci.ConsensusParams,
+) (*ctypes.ResultSimulateParamChange, error) {
+	return core.SimulateParamChange(c.ctx, updates)
+}
+
 func (c *Local) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
 	return core.Health(c.ctx)
 }
@@ -145,6 +169,14 @@ func (c *Local) DialPeers(
 	return core.UnsafeDialPeers(c.ctx, peers, persistent, unconditional, private)
 }
 
+func (c *Local) SetPeerTxRelay(ctx context.Context, peer string, enable bool) (*ctypes.ResultPeerTxRelay, error) {
+	return core.UnsafeSetPeerTxRelay(c.ctx, peer, enable)
+}
+
+func (c *Local) SetPeerLists(ctx context.Context, allowedPeers, deniedPeers []string) (*ctypes.ResultPeerLists, error) {
+	return core.UnsafeSetPeerLists(c.ctx, allowedPeers, deniedPeers)
+}
+
 func (c *Local) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 	return core.BlockchainInfo(c.ctx, minHeight, maxHeight)
 }
@@ -161,18 +193,44 @@ func (c *Local) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlo
 	return core.BlockByHash(c.ctx, hash)
 }
 
+func (c *Local) BlockRaw(ctx context.Context, height *int64, compress bool) (*ctypes.ResultBlockRaw, error) {
+	return core.BlockRaw(c.ctx, height, compress)
+}
+
+func (c *Local) BlockPart(ctx context.Context, height *int64, index int) (*ctypes.ResultBlockPart, error) {
+	return core.BlockPart(c.ctx, height, index)
+}
+
 func (c *Local) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
 	return core.BlockResults(c.ctx, height)
 }
 
 func (c *Local) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
-	return core.Commit(c.ctx, height)
+	return core.Commit(c.ctx, height, false)
+}
+
+// CommitWithSignBytes is like Commit, but the result also includes the
+// canonical bytes signed by each signature.
+func (c *Local) CommitWithSignBytes(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	return core.Commit(c.ctx, height, true)
 }
 
 func (c *Local) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
 	return core.Validators(c.ctx, height, page, perPage)
 }
 
+func (c *Local) ValidatorInfo(ctx context.Context, address []byte) (*ctypes.ResultValidatorInfo, error) {
+	return core.ValidatorInfo(c.ctx, address)
+}
+
+func (c *Local) NodeAttestation(ctx context.Context, nodeID string) (*ctypes.ResultNodeAttestation, error) {
+	return core.NodeAttestation(c.ctx, nodeID)
+}
+
+func (c *Local) TimestampDrift(ctx context.Context, minHeight, maxHeight *int64) (*ctypes.ResultTimestampDrift, error) {
+	return core.TimestampDrift(c.ctx, minHeight, maxHeight)
+}
+
 func (c *Local) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	return core.Tx(c.ctx, hash, prove)
 }
@@ -185,7 +243,28 @@ func (c *Local) TxSearch(
 	perPage *int,
 	orderBy string,
 ) (*ctypes.ResultTxSearch, error) {
-	return core.TxSearch(c.ctx, query, prove, page, perPage, orderBy)
+	return core.TxSearch(c.ctx, query, prove, page, perPage, orderBy, false)
+}
+
+// TxSearchCount returns the number of txs matching the query, without loading
+// them.
+func (c *Local) TxSearchCount(ctx context.Context, query string) (int, error) {
+	result, err := core.TxSearch(c.ctx, query, false, nil, nil, "", true)
+	if err != nil {
+		return 0, err
+	}
+	return result.TotalCount, nil
+}
+
+func (c *Local) Events(
+	ctx context.Context,
+	query string,
+	fromHeight,
+	toHeight *int64,
+	page,
+	perPage *int,
+) (*ctypes.ResultEvents, error) {
+	return core.Events(c.ctx, query, fromHeight, toHeight, page, perPage)
 }
 
 func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
@@ -197,7 +276,7 @@ func (c *Local) Subscribe(
 	subscriber,
 	query string,
 	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
-	q, err := tmquery.New(query)
+	q, err := parseQuery(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse query: %w", err)
 	}
@@ -276,13 +355,22 @@ func (c *Local) resubscribe(subscriber string, q tmpubsub.Query) types.Subscript
 }
 
 func (c *Local) Unsubscribe(ctx context.Context, subscriber, query string) error {
-	q, err := tmquery.New(query)
+	q, err := parseQuery(query)
 	if err != nil {
 		return fmt.Errorf("failed to parse query: %w", err)
 	}
 	return c.EventBus.Unsubscribe(ctx, subscriber, q)
 }
 
+// parseQuery parses a query, or an OR of queries in parentheses (see
+// tmquery.ParseOr).
+func parseQuery(query string) (tmpubsub.Query, error) {
+	if strings.HasPrefix(strings.TrimSpace(query), "(") {
+		return tmquery.ParseOr(query)
+	}
+	return tmquery.New(query)
+}
+
 func (c *Local) UnsubscribeAll(ctx context.Context, subscriber string) error {
 	return c.EventBus.UnsubscribeAll(ctx, subscriber)
 }
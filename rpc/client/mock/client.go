@@ -101,15 +101,15 @@ func (c Client) ABCIQueryWithOptions(
 }
 
 func (c Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
-	return core.BroadcastTxCommit(&rpctypes.Context{}, tx)
+	return core.BroadcastTxCommit(&rpctypes.Context{}, tx, "", false)
 }
 
 func (c Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	return core.BroadcastTxAsync(&rpctypes.Context{}, tx)
+	return core.BroadcastTxAsync(&rpctypes.Context{}, tx, "")
 }
 
 func (c Client) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	return core.BroadcastTxSync(&rpctypes.Context{}, tx)
+	return core.BroadcastTxSync(&rpctypes.Context{}, tx, "")
 }
 
 func (c Client) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
@@ -125,7 +125,12 @@ func (c Client) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusStat
 }
 
 func (c Client) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
-	return core.DumpConsensusState(&rpctypes.Context{})
+	return core.DumpConsensusState(&rpctypes.Context{}, nil, nil, "", false)
+}
+
+func (c Client) DumpConsensusStateFiltered(ctx context.Context, validator []byte, round *int32,
+	format string, peerSummary bool) (*ctypes.ResultDumpConsensusState, error) {
+	return core.DumpConsensusState(&rpctypes.Context{}, validator, round, format, peerSummary)
 }
 
 func (c Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
@@ -150,6 +155,14 @@ func (c Client) DialPeers(
 	return core.UnsafeDialPeers(&rpctypes.Context{}, peers, persistent, unconditional, private)
 }
 
+func (c Client) SetPeerTxRelay(ctx context.Context, peer string, enable bool) (*ctypes.ResultPeerTxRelay, error) {
+	return core.UnsafeSetPeerTxRelay(&rpctypes.Context{}, peer, enable)
+}
+
+func (c Client) SetPeerLists(ctx context.Context, allowedPeers, deniedPeers []string) (*ctypes.ResultPeerLists, error) {
+	return core.UnsafeSetPeerLists(&rpctypes.Context{}, allowedPeers, deniedPeers)
+}
+
 func (c Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 	return core.BlockchainInfo(&rpctypes.Context{}, minHeight, maxHeight)
 }
@@ -166,14 +179,38 @@ func (c Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlo
 	return core.BlockByHash(&rpctypes.Context{}, hash)
 }
 
+func (c Client) BlockRaw(ctx context.Context, height *int64, compress bool) (*ctypes.ResultBlockRaw, error) {
+	return core.BlockRaw(&rpctypes.Context{}, height, compress)
+}
+
+func (c Client) BlockPart(ctx context.Context, height *int64, index int) (*ctypes.ResultBlockPart, error) {
+	return core.BlockPart(&rpctypes.Context{}, height, index)
+}
+
 func (c Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
-	return core.Commit(&rpctypes.Context{}, height)
+	return core.Commit(&rpctypes.Context{}, height, false)
+}
+
+func (c Client) CommitWithSignBytes(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	return core.Commit(&rpctypes.Context{}, height, true)
 }
 
 func (c Client) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
 	return core.Validators(&rpctypes.Context{}, height, page, perPage)
 }
 
+func (c Client) ValidatorInfo(ctx context.Context, address []byte) (*ctypes.ResultValidatorInfo, error) {
+	return core.ValidatorInfo(&rpctypes.Context{}, address)
+}
+
+func (c Client) NodeAttestation(ctx context.Context, nodeID string) (*ctypes.ResultNodeAttestation, error) {
+	return core.NodeAttestation(&rpctypes.Context{}, nodeID)
+}
+
+func (c Client) TimestampDrift(ctx context.Context, minHeight, maxHeight *int64) (*ctypes.ResultTimestampDrift, error) {
+	return core.TimestampDrift(&rpctypes.Context{}, minHeight, maxHeight)
+}
+
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return core.BroadcastEvidence(&rpctypes.Context{}, ev)
 }
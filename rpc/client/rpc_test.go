@@ -16,7 +16,10 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	tmmath "github.com/tendermint/tendermint/libs/math"
+	"github.com/tendermint/tendermint/light"
 	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/privval"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/rpc/client"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	rpclocal "github.com/tendermint/tendermint/rpc/client/local"
@@ -141,6 +144,41 @@ func TestDumpConsensusState(t *testing.T) {
 	}
 }
 
+func TestDumpConsensusStateFiltered(t *testing.T) {
+	type dumpClient interface {
+		DumpConsensusStateFiltered(ctx context.Context, validator []byte, round *int32, format string,
+			peerSummary bool) (*ctypes.ResultDumpConsensusState, error)
+	}
+	pv := privval.LoadFilePV(rpctest.GetConfig().PrivValidatorKeyFile(), rpctest.GetConfig().PrivValidatorStateFile())
+	round := int32(0)
+
+	for i, c := range GetClients() {
+		dc, ok := c.(dumpClient)
+		require.True(t, ok, "%d", i)
+
+		cons, err := dc.DumpConsensusStateFiltered(context.Background(), pv.Key.Address, &round,
+			ctypes.DumpFormatProto, true)
+		require.NoError(t, err, "%d", i)
+		assert.Empty(t, cons.RoundState)
+		dump, err := cons.DecodeDump()
+		require.NoError(t, err, "%d", i)
+		assert.Greater(t, dump.RoundState.Height, int64(0))
+		require.Len(t, dump.RoundState.Validators.Validators, 1)
+		assert.EqualValues(t, pv.Key.Address, dump.RoundState.Validators.Validators[0].Address)
+		assert.LessOrEqual(t, len(dump.RoundState.Votes), 1)
+		assert.Empty(t, dump.Peers)
+
+		cons, err = dc.DumpConsensusStateFiltered(context.Background(), pv.Key.Address, nil,
+			ctypes.DumpFormatJSON, false)
+		require.NoError(t, err, "%d", i)
+		assert.Contains(t, string(cons.RoundState), `"height":`)
+		assert.Empty(t, cons.Dump)
+
+		_, err = dc.DumpConsensusStateFiltered(context.Background(), nil, nil, "xml", false)
+		assert.Error(t, err, "%d", i)
+	}
+}
+
 func TestConsensusState(t *testing.T) {
 	for i, c := range GetClients() {
 		// FIXME: fix server so it doesn't panic on invalid input
@@ -186,6 +224,51 @@ func TestGenesisAndValidators(t *testing.T) {
 	}
 }
 
+func TestSimulateParamChange(t *testing.T) {
+	type simulateClient interface {
+		client.Client
+		SimulateParamChange(ctx context.Context, updates *abci.ConsensusParams) (*ctypes.ResultSimulateParamChange, error)
+	}
+
+	for i, c := range []simulateClient{getHTTPClient(), getLocalClient()} {
+		res, err := c.SimulateParamChange(context.Background(), nil)
+		require.NoError(t, err, "%d", i)
+		assert.True(t, res.Valid, "%d", i)
+		assert.Empty(t, res.Changes, "%d", i)
+		params := res.ConsensusParams
+
+		res, err = c.SimulateParamChange(context.Background(), &abci.ConsensusParams{
+			Block:     &abci.BlockParams{MaxBytes: 2 * 1024 * 1024, MaxGas: params.Block.MaxGas},
+			Validator: &tmproto.ValidatorParams{PubKeyTypes: []string{types.ABCIPubKeyTypeEd25519}},
+		})
+		require.NoError(t, err, "%d", i)
+		assert.True(t, res.Valid, "%d", i)
+		assert.Empty(t, res.Warnings, "%d", i)
+		assert.EqualValues(t, 2*1024*1024, res.ConsensusParams.Block.MaxBytes, "%d", i)
+		assert.Equal(t, []types.ConsensusParamChange{{
+			Name: "block.max_bytes",
+			Old:  fmt.Sprint(params.Block.MaxBytes),
+			New:  fmt.Sprint(2 * 1024 * 1024),
+		}}, res.Changes, "%d", i)
+
+		// the validator key isn't allowed anymore
+		res, err = c.SimulateParamChange(context.Background(), &abci.ConsensusParams{
+			Validator: &tmproto.ValidatorParams{PubKeyTypes: []string{types.ABCIPubKeyTypeSecp256k1}},
+		})
+		require.NoError(t, err, "%d", i)
+		assert.True(t, res.Valid, "%d", i)
+		assert.Len(t, res.Warnings, 1, "%d", i)
+
+		// the evidence doesn't fit in the blocks anymore
+		res, err = c.SimulateParamChange(context.Background(), &abci.ConsensusParams{
+			Block: &abci.BlockParams{MaxBytes: params.Evidence.MaxBytes, MaxGas: -1},
+		})
+		require.NoError(t, err, "%d", i)
+		assert.False(t, res.Valid, "%d", i)
+		assert.NotEmpty(t, res.Error, "%d", i)
+	}
+}
+
 func TestABCIQuery(t *testing.T) {
 	for i, c := range GetClients() {
 		// write something
@@ -332,6 +415,52 @@ func TestBlockchainInfo(t *testing.T) {
 	}
 }
 
+func TestBlockRawAndBlockPart(t *testing.T) {
+	type rawClient interface {
+		client.Client
+		BlockRaw(ctx context.Context, height *int64, compress bool) (*ctypes.ResultBlockRaw, error)
+		BlockPart(ctx context.Context, height *int64, index int) (*ctypes.ResultBlockPart, error)
+	}
+
+	for i, c := range []rawClient{getHTTPClient(), getLocalClient()} {
+		err := client.WaitForHeight(c, 2, nil)
+		require.NoError(t, err)
+
+		h := int64(2)
+		block, err := c.Block(context.Background(), &h)
+		require.NoError(t, err, "%d", i)
+
+		for _, compress := range []bool{false, true} {
+			raw, err := c.BlockRaw(context.Background(), &h, compress)
+			require.NoError(t, err, "%d", i)
+			assert.Equal(t, block.BlockID, raw.BlockID)
+			if compress {
+				assert.Equal(t, ctypes.CompressionZstd, raw.Compression)
+			} else {
+				assert.Empty(t, raw.Compression)
+			}
+			decoded, err := raw.DecodeBlock()
+			require.NoError(t, err, "%d", i)
+			assert.Equal(t, block.Block.Hash(), decoded.Hash())
+		}
+
+		parts := types.NewPartSetFromHeader(block.BlockID.PartSetHeader)
+		for index := 0; index < int(block.BlockID.PartSetHeader.Total); index++ {
+			res, err := c.BlockPart(context.Background(), &h, index)
+			require.NoError(t, err, "%d", i)
+			part, err := res.DecodePart()
+			require.NoError(t, err, "%d", i)
+			added, err := parts.AddPart(part)
+			require.NoError(t, err, "%d", i)
+			assert.True(t, added)
+		}
+		assert.True(t, parts.IsComplete())
+
+		_, err = c.BlockPart(context.Background(), &h, int(block.BlockID.PartSetHeader.Total))
+		assert.Error(t, err)
+	}
+}
+
 func TestBroadcastTxSync(t *testing.T) {
 	require := require.New(t)
 
@@ -368,6 +497,45 @@ func TestBroadcastTxCommit(t *testing.T) {
 	}
 }
 
+func TestBroadcastTxCommitWithProof(t *testing.T) {
+	type proofClient interface {
+		BroadcastTxCommitWithProof(context.Context, types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
+	}
+	for i, c := range GetClients() {
+		_, _, tx := MakeTxKV()
+		bres, err := c.(proofClient).BroadcastTxCommitWithProof(context.Background(), tx)
+		require.NoError(t, err, "%d", i)
+		require.True(t, bres.DeliverTx.IsOK())
+		require.NotNil(t, bres.Proof)
+
+		block, err := c.Block(context.Background(), &bres.Height)
+		require.NoError(t, err)
+		assert.NoError(t, light.VerifyTxInclusion(&block.Block.Header, tx, *bres.Proof), "%d", i)
+	}
+}
+
+func TestCommitWithSignBytes(t *testing.T) {
+	for i, c := range GetClients() {
+		h := int64(2)
+		require.NoError(t, client.WaitForHeight(c, h+1, nil))
+		res, err := c.CommitWithSignBytes(context.Background(), &h)
+		require.NoError(t, err, "%d", i)
+		require.Len(t, res.SignBytes, len(res.Commit.Signatures))
+
+		vals, err := c.Validators(context.Background(), &h, nil, nil)
+		require.NoError(t, err)
+		valSet := types.NewValidatorSet(vals.Validators)
+		assert.NoError(t, valSet.VerifyCommitConcurrently(res.ChainID, res.Commit.BlockID, h, res.Commit, 4), "%d", i)
+		for idx, signBytes := range res.SignBytes {
+			assert.True(t, valSet.Validators[idx].PubKey.VerifySignature(signBytes, res.Commit.Signatures[idx].Signature))
+		}
+
+		res, err = c.Commit(context.Background(), &h)
+		require.NoError(t, err)
+		assert.Nil(t, res.SignBytes)
+	}
+}
+
 func TestUnconfirmedTxs(t *testing.T) {
 	_, _, tx := MakeTxKV()
 
@@ -428,6 +596,33 @@ func TestNumUnconfirmedTxs(t *testing.T) {
 	mempool.Flush()
 }
 
+func TestMempoolStats(t *testing.T) {
+	type statsClient interface {
+		client.Client
+		MempoolStats(ctx context.Context) (*ctypes.ResultMempoolStats, error)
+	}
+
+	_, _, tx := MakeTxKV()
+	mempool := node.Mempool()
+	err := mempool.CheckTx(tx, nil, mempl.TxInfo{})
+	require.NoError(t, err)
+
+	for i, c := range []statsClient{getHTTPClient(), getLocalClient()} {
+		res, err := c.MempoolStats(context.Background())
+		require.NoError(t, err, "%d", i)
+
+		stats := mempool.Stats()
+		assert.Equal(t, stats.Size, res.Count)
+		assert.Equal(t, stats.MaxSize, res.MaxCount)
+		assert.Equal(t, stats.TxsBytes, res.TotalBytes)
+		assert.Equal(t, stats.TxSizes.Bounds, res.TxSizes.Bounds)
+		assert.Equal(t, stats.TxSizes.Counts, res.TxSizes.Counts)
+		assert.Equal(t, stats.Evictions.Full, res.Evictions.Full)
+	}
+
+	mempool.Flush()
+}
+
 func TestCheckTx(t *testing.T) {
 	mempool := node.Mempool()
 
@@ -525,6 +720,9 @@ func TestTxSearch(t *testing.T) {
 	result, err := c.TxSearch(context.Background(), "tx.height >= 0", true, nil, nil, "asc")
 	require.NoError(t, err)
 	txCount := len(result.Txs)
+	count, err := c.TxSearchCount(context.Background(), "tx.height >= 0")
+	require.NoError(t, err)
+	assert.Equal(t, result.TotalCount, count)
 
 	// pick out the last tx to have something to search for in tests
 	find := result.Txs[len(result.Txs)-1]
@@ -633,6 +831,56 @@ func TestTxSearch(t *testing.T) {
 	}
 }
 
+func TestEvents(t *testing.T) {
+	type eventsClient interface {
+		client.Client
+		Events(ctx context.Context, query string, fromHeight, toHeight *int64, page, perPage *int) (
+			*ctypes.ResultEvents, error)
+	}
+
+	_, _, tx := MakeTxKV()
+	bres, err := getHTTPClient().BroadcastTxCommit(context.Background(), tx)
+	require.NoError(t, err)
+	height := bres.Height
+
+	for i, c := range []eventsClient{getHTTPClient(), getLocalClient()} {
+		query := "tm.event = 'Tx' AND app.creator = 'Cosmoshi Netowoko'"
+		res, err := c.Events(context.Background(), query, &height, &height, nil, nil)
+		require.NoError(t, err, "%d", i)
+		require.Len(t, res.Events, 1, "%d", i)
+		assert.Equal(t, 1, res.TotalCount, "%d", i)
+		assert.Equal(t, query, res.Events[0].Query, "%d", i)
+		data, ok := res.Events[0].Data.(types.EventDataTx)
+		require.True(t, ok, "%d", i)
+		assert.EqualValues(t, tx, data.Tx, "%d", i)
+		assert.Equal(t, []string{fmt.Sprintf("%X", bres.Hash)}, res.Events[0].Events[types.TxHashKey], "%d", i)
+
+		// the events are paginated in order
+		res, err = c.Events(context.Background(), query, nil, &height, nil, nil)
+		require.NoError(t, err, "%d", i)
+		require.Greater(t, res.TotalCount, 1, "%d", i)
+		for j := 1; j < len(res.Events); j++ {
+			prev, cur := res.Events[j-1].Data.(types.EventDataTx), res.Events[j].Data.(types.EventDataTx)
+			assert.True(t, prev.Height < cur.Height || (prev.Height == cur.Height && prev.Index < cur.Index), "%d", i)
+		}
+		page, perPage := 2, 1
+		paged, err := c.Events(context.Background(), query, nil, &height, &page, &perPage)
+		require.NoError(t, err, "%d", i)
+		require.Len(t, paged.Events, 1, "%d", i)
+		assert.Equal(t, res.TotalCount, paged.TotalCount, "%d", i)
+		assert.Equal(t, res.Events[1], paged.Events[0], "%d", i)
+
+		// only the Tx events are indexed
+		res, err = c.Events(context.Background(), "tm.event = 'NewBlock'", nil, nil, nil, nil)
+		require.NoError(t, err, "%d", i)
+		assert.Empty(t, res.Events, "%d", i)
+
+		fromHeight := height + 1
+		_, err = c.Events(context.Background(), query, &fromHeight, &height, nil, nil)
+		assert.Error(t, err, "%d", i)
+	}
+}
+
 func TestBatchedJSONRPCCalls(t *testing.T) {
 	c := getHTTPClient()
 	testBatchedJSONRPCCalls(t, c)
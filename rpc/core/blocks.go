@@ -6,6 +6,7 @@ import (
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -109,10 +110,79 @@ func BlockByHash(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultBlock, error
 	return &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
 }
 
+// BlockRaw gets the protobuf-encoded block at a given height, optionally
+// compressed with zstd. It avoids the overhead of encoding the whole block to
+// JSON, for tools that process many blocks.
+// If no height is provided, it will fetch the latest block.
+// More: https://docs.tendermint.com/master/rpc/#/Info/block_raw
+func BlockRaw(ctx *rpctypes.Context, heightPtr *int64, compress bool) (*ctypes.ResultBlockRaw, error) {
+	height, err := getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	block := env.BlockStore.LoadBlock(height)
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if block == nil || blockMeta == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+	pb, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	bz, err := pb.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ctypes.ResultBlockRaw{BlockID: blockMeta.BlockID, Block: bz}
+	if compress {
+		result.Block = ctypes.CompressZstd(bz)
+		result.Compression = ctypes.CompressionZstd
+	}
+	return result, nil
+}
+
+// BlockPart gets the protobuf-encoded part with the given index of the block
+// at a given height. The number of parts of the block is given by the part
+// set header of the returned block ID.
+// If no height is provided, it will fetch a part of the latest block.
+// More: https://docs.tendermint.com/master/rpc/#/Info/block_part
+func BlockPart(ctx *rpctypes.Context, heightPtr *int64, index int) (*ctypes.ResultBlockPart, error) {
+	height, err := getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+	total := int(blockMeta.BlockID.PartSetHeader.Total)
+	if index < 0 || index >= total {
+		return nil, fmt.Errorf("part index %d out of range, block at height %d has %d parts", index, height, total)
+	}
+	part := env.BlockStore.LoadBlockPart(height, index)
+	if part == nil {
+		return nil, fmt.Errorf("part %d of block at height %d not found", index, height)
+	}
+	pb, err := part.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	bz, err := pb.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBlockPart{BlockID: blockMeta.BlockID, Part: bz}, nil
+}
+
 // Commit gets block commit at a given height.
 // If no height is provided, it will fetch the commit for the latest block.
+// If signBytes is true, the result includes the canonical bytes signed by each
+// signature, see types.Commit.SignBytes.
 // More: https://docs.tendermint.com/master/rpc/#/Info/commit
-func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, error) {
+func Commit(ctx *rpctypes.Context, heightPtr *int64, signBytes bool) (*ctypes.ResultCommit, error) {
 	height, err := getHeight(env.BlockStore.Height(), heightPtr)
 	if err != nil {
 		return nil, err
@@ -126,14 +196,20 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 
 	// If the next block has not been committed yet,
 	// use a non-canonical commit
+	var res *ctypes.ResultCommit
 	if height == env.BlockStore.Height() {
 		commit := env.BlockStore.LoadSeenCommit(height)
-		return ctypes.NewResultCommit(&header, commit, false), nil
+		res = ctypes.NewResultCommit(&header, commit, false)
+	} else {
+		// Return the canonical commit (comes from the block at height+1)
+		commit := env.BlockStore.LoadBlockCommit(height)
+		res = ctypes.NewResultCommit(&header, commit, true)
 	}
 
-	// Return the canonical commit (comes from the block at height+1)
-	commit := env.BlockStore.LoadBlockCommit(height)
-	return ctypes.NewResultCommit(&header, commit, true), nil
+	if signBytes && res.Commit != nil {
+		res.SignBytes = res.Commit.SignBytes(header.ChainID)
+	}
+	return res, nil
 }
 
 // BlockResults gets ABCIResults at a given height.
@@ -150,6 +226,22 @@ func BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockR
 	}
 
 	results, err := env.StateStore.LoadABCIResponses(height)
+	if _, ok := err.(sm.ErrNoABCIResponsesForHeight); ok {
+		// the results may have been pruned, keeping the exempted ones
+		retained, rErr := env.StateStore.LoadRetainedABCIResponses(height)
+		if rErr != nil {
+			return nil, err
+		}
+		return &ctypes.ResultBlockResults{
+			Height:                height,
+			BeginBlockEvents:      retained.BeginBlockEvents,
+			EndBlockEvents:        retained.EndBlockEvents,
+			ValidatorUpdates:      retained.ValidatorUpdates,
+			ConsensusParamUpdates: retained.ConsensusParamUpdates,
+			Pruned:                true,
+			RetainedTxsEvents:     retained.DeliverTxEvents,
+		}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
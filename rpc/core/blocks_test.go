@@ -14,6 +14,7 @@ import (
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	sm "github.com/tendermint/tendermint/state"
+	smmocks "github.com/tendermint/tendermint/state/mocks"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -115,6 +116,36 @@ func TestBlockResults(t *testing.T) {
 	}
 }
 
+func TestBlockResultsPruned(t *testing.T) {
+	retained := &tmstate.RetainedABCIResponses{
+		DeliverTxEvents:  []abci.Event{{Type: "proposal_passed"}},
+		ValidatorUpdates: []abci.ValidatorUpdate{{Power: 10}},
+	}
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadABCIResponses", int64(99)).Return(nil, sm.ErrNoABCIResponsesForHeight{Height: 99})
+	stateStore.On("LoadRetainedABCIResponses", int64(99)).Return(retained, nil)
+	stateStore.On("LoadABCIResponses", int64(98)).Return(nil, sm.ErrNoABCIResponsesForHeight{Height: 98})
+	stateStore.On("LoadRetainedABCIResponses", int64(98)).Return(nil, sm.ErrNoABCIResponsesForHeight{Height: 98})
+
+	env = &Environment{}
+	env.StateStore = stateStore
+	env.BlockStore = mockBlockStore{height: 100}
+
+	height := int64(99)
+	res, err := BlockResults(&rpctypes.Context{}, &height)
+	require.NoError(t, err)
+	assert.Equal(t, &ctypes.ResultBlockResults{
+		Height:            99,
+		ValidatorUpdates:  retained.ValidatorUpdates,
+		Pruned:            true,
+		RetainedTxsEvents: retained.DeliverTxEvents,
+	}, res)
+
+	height = 98
+	_, err = BlockResults(&rpctypes.Context{}, &height)
+	assert.Equal(t, sm.ErrNoABCIResponsesForHeight{Height: 98}, err)
+}
+
 type mockBlockStore struct {
 	height int64
 }
@@ -1,8 +1,20 @@
 package core
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+
+	abci "github.com/tendermint/tendermint/abci/types"
 	cm "github.com/tendermint/tendermint/consensus"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
 	tmmath "github.com/tendermint/tendermint/libs/math"
+	"github.com/tendermint/tendermint/operator"
+	"github.com/tendermint/tendermint/p2p"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
@@ -45,10 +57,107 @@ func Validators(ctx *rpctypes.Context, heightPtr *int64, pagePtr, perPagePtr *in
 		Total:       totalCount}, nil
 }
 
+// ValidatorInfo returns the operator info (moniker, website, security
+// contact...) gossiped by the validators, signed with their keys. If an
+// address is given, only the info of this validator is returned.
+// More: https://docs.tendermint.com/master/rpc/#/Info/validator_info
+func ValidatorInfo(ctx *rpctypes.Context, address []byte) (*ctypes.ResultValidatorInfo, error) {
+	if env.OperatorReactor == nil {
+		return nil, errors.New("the operator info isn't gossiped (see operator.gossip)")
+	}
+	if len(address) == 0 {
+		return &ctypes.ResultValidatorInfo{Infos: env.OperatorReactor.Infos()}, nil
+	}
+	infos := []*operator.Info{}
+	if info := env.OperatorReactor.Info(address); info != nil {
+		infos = append(infos, info)
+	}
+	return &ctypes.ResultValidatorInfo{Infos: infos}, nil
+}
+
+// NodeAttestation returns the operator info of the validators attesting the
+// node with the given ID as one of their sentries, i.e. whose signed info lists
+// the node ID, if the node attested the validator too.
+// More: https://docs.tendermint.com/master/rpc/#/Info/node_attestation
+func NodeAttestation(ctx *rpctypes.Context, nodeID string) (*ctypes.ResultNodeAttestation, error) {
+	if env.OperatorReactor == nil {
+		return nil, errors.New("the operator info isn't gossiped (see operator.gossip)")
+	}
+	if err := p2p.ValidateID(p2p.ID(nodeID)); err != nil {
+		return nil, fmt.Errorf("invalid node ID: %w", err)
+	}
+	return &ctypes.ResultNodeAttestation{
+		NodeID: p2p.ID(nodeID),
+		Infos:  env.OperatorReactor.Attesting(p2p.ID(nodeID)),
+	}, nil
+}
+
 // DumpConsensusState dumps consensus state.
+//
+// The votes can be filtered by validator address and round, and the peer
+// states summarized. The filtered or summarized dumps use the compact
+// structures of the tendermint.consensus.ConsensusStateDump protobuf message,
+// encoded to JSON, or to protobuf in the proto format. They only include the
+// peers if peerSummary is true, the compact structures having no room for
+// their whole states.
 // UNSTABLE
 // More: https://docs.tendermint.com/master/rpc/#/Info/dump_consensus_state
-func DumpConsensusState(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState, error) {
+func DumpConsensusState(ctx *rpctypes.Context, validator []byte, roundPtr *int32, format string,
+	peerSummary bool) (*ctypes.ResultDumpConsensusState, error) {
+	switch format {
+	case "", ctypes.DumpFormatJSON:
+		if validator == nil && roundPtr == nil && !peerSummary {
+			return dumpConsensusStateJSON()
+		}
+	case ctypes.DumpFormatProto:
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected %q or %q",
+			format, ctypes.DumpFormatJSON, ctypes.DumpFormatProto)
+	}
+
+	roundState, err := env.ConsensusState.GetRoundState().Dump(cstypes.RoundStateFilter{
+		ValidatorAddress: validator,
+		Round:            roundPtr,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dump := &tmcons.ConsensusStateDump{RoundState: roundState}
+	if peerSummary {
+		for _, peer := range env.P2PPeers.Peers().List() {
+			peerState, ok := peer.Get(types.PeerStateKey).(*cm.PeerState)
+			if !ok { // peer does not have a state yet
+				continue
+			}
+			summary := peerState.GetRoundState().Summary()
+			summary.NodeAddress = peer.SocketAddr().String()
+			dump.Peers = append(dump.Peers, summary)
+		}
+	}
+
+	if format == ctypes.DumpFormatProto {
+		bz, err := dump.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		return &ctypes.ResultDumpConsensusState{Dump: bz}, nil
+	}
+	result := &ctypes.ResultDumpConsensusState{Peers: make([]ctypes.PeerStateInfo, len(dump.Peers))}
+	if result.RoundState, err = marshalProtoJSON(dump.RoundState); err != nil {
+		return nil, err
+	}
+	for i := range dump.Peers {
+		result.Peers[i].NodeAddress = dump.Peers[i].NodeAddress
+		if result.Peers[i].PeerState, err = marshalProtoJSON(&dump.Peers[i]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// dumpConsensusStateJSON dumps the whole consensus state, and those of the
+// peers, to JSON.
+func dumpConsensusStateJSON() (*ctypes.ResultDumpConsensusState, error) {
 	// Get Peer consensus states.
 	peers := env.P2PPeers.Peers().List()
 	peerStates := make([]ctypes.PeerStateInfo, len(peers))
@@ -78,6 +187,16 @@ func DumpConsensusState(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState
 		Peers:      peerStates}, nil
 }
 
+var protoJSONMarshaler = jsonpb.Marshaler{OrigName: true, EnumsAsInts: true}
+
+func marshalProtoJSON(pb proto.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := protoJSONMarshaler.Marshal(&buf, pb); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // ConsensusState returns a concise summary of the consensus state.
 // UNSTABLE
 // More: https://docs.tendermint.com/master/rpc/#/Info/consensus_state
@@ -106,3 +225,51 @@ func ConsensusParams(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCon
 		BlockHeight:     height,
 		ConsensusParams: consensusParams}, nil
 }
+
+// SimulateParamChange reports the changes of the consensus params, if the next
+// block returned the given updates from EndBlock, and whether they would be
+// valid.
+// More: https://docs.tendermint.com/master/rpc/#/Info/simulate_param_change
+func SimulateParamChange(ctx *rpctypes.Context, updates *abci.ConsensusParams) (
+	*ctypes.ResultSimulateParamChange, error) {
+	state, err := env.StateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	if state.IsEmpty() {
+		return nil, errors.New("no state")
+	}
+
+	// The updates of the next block apply from the block after it, along
+	// with the next validators.
+	params := types.UpdateConsensusParams(state.ConsensusParams, updates)
+	result := &ctypes.ResultSimulateParamChange{
+		Height:          state.LastBlockHeight + 2,
+		ConsensusParams: params,
+		Changes:         types.DiffConsensusParams(state.ConsensusParams, params),
+		Warnings:        make([]string, 0),
+	}
+	if err := types.ValidateConsensusParams(params); err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	if err := types.ValidateBlockCapacity(params, state.NextValidators.Size()); err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	result.Valid = true
+
+	for _, val := range state.NextValidators.Validators {
+		if keyType := val.PubKey.Type(); !types.IsValidPubkeyType(params.Validator, keyType) {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"validator %v has a %s key, not in validator.pub_key_types: its updates would be rejected",
+				val.Address, keyType))
+		}
+	}
+	if _, size := env.EvidencePool.PendingEvidence(-1); size > params.Evidence.MaxBytes {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"%d bytes of pending evidence exceed evidence.max_bytes (%d): it would take several blocks to commit",
+			size, params.Evidence.MaxBytes))
+	}
+	return result, nil
+}
@@ -6,9 +6,11 @@ import (
 
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/consensus"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/log"
 	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/operator"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
@@ -34,6 +36,9 @@ var (
 // SetEnvironment sets up the given Environment.
 // It will race if multiple Node call SetEnvironment.
 func SetEnvironment(e *Environment) {
+	if e.GenDoc != nil {
+		e.txHasher = e.GenDoc.TxHasher()
+	}
 	env = e
 }
 
@@ -44,6 +49,7 @@ type Consensus interface {
 	GetState() sm.State
 	GetValidators() (int64, []*types.Validator)
 	GetLastHeight() int64
+	GetRoundState() *cstypes.RoundState
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
 }
@@ -59,7 +65,10 @@ type peers interface {
 	AddUnconditionalPeerIDs([]string) error
 	AddPrivatePeerIDs([]string) error
 	DialPeersAsync([]string) error
+	SetPeerIDLists(allowed, denied []string) error
+	PeerIDLists() (allowed, denied []string)
 	Peers() p2p.IPeerSet
+	PeerStats(p2p.ID) (p2p.PeerStats, bool)
 }
 
 //----------------------------------------------
@@ -83,12 +92,18 @@ type Environment struct {
 	GenDoc           *types.GenesisDoc // cache the genesis structure
 	TxIndexer        txindex.TxIndexer
 	ConsensusReactor *consensus.Reactor
-	EventBus         *types.EventBus // thread safe
+	OperatorReactor  *operator.Reactor // nil if the operator info isn't gossiped
+	EventBus         *types.EventBus   // thread safe
 	Mempool          mempl.Mempool
+	MempoolReactor   *mempl.Reactor
+	IdempotencyCache *IdempotencyCache              // nil disables the idempotency keys
+	TimestampAudit   *consensus.TimestampAuditStore // nil if the timestamp audit is disabled
 
 	Logger log.Logger
 
 	Config cfg.RPCConfig
+
+	txHasher types.TxHasher // of GenDoc, set by SetEnvironment
 }
 
 //----------------------------------------------
@@ -158,6 +173,40 @@ func getHeight(latestHeight int64, heightPtr *int64) (int64, error) {
 	return latestHeight, nil
 }
 
+// TxHash returns the hash of the given tx, computed with the TxHasher of the
+// chain.
+func TxHash(tx types.Tx) []byte {
+	if env.txHasher == nil {
+		return tx.Hash()
+	}
+	return env.txHasher.Hash(tx)
+}
+
+// isFinalizedHeight returns true if the first arg is an explicit height which
+// is available in the block store, i.e. the results for it won't change.
+func isFinalizedHeight(args []interface{}) bool {
+	return isHeightBelow(args, env.BlockStore.Height()+1)
+}
+
+// isCanonicalCommitHeight returns true if the first arg is an explicit height
+// whose canonical commit is available in the block store. The commit for the
+// latest height is not canonical and may change.
+func isCanonicalCommitHeight(args []interface{}) bool {
+	return isHeightBelow(args, env.BlockStore.Height())
+}
+
+func isHeightBelow(args []interface{}, maxHeight int64) bool {
+	if len(args) == 0 {
+		return false
+	}
+	heightPtr, ok := args[0].(*int64)
+	if !ok || heightPtr == nil {
+		return false
+	}
+	height := *heightPtr
+	return height > 0 && height >= env.BlockStore.Base() && height < maxHeight
+}
+
 func latestUncommittedHeight() int64 {
 	nodeIsSyncing := env.ConsensusReactor.WaitSync()
 	if nodeIsSyncing {
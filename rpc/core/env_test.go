@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestPaginationPage(t *testing.T) {
@@ -80,3 +83,40 @@ func TestPaginationPerPage(t *testing.T) {
 	p := validatePerPage(nil)
 	assert.Equal(t, defaultPerPage, p)
 }
+
+func TestIsFinalizedHeight(t *testing.T) {
+	env = &Environment{}
+	env.BlockStore = mockBlockStore{height: 100}
+
+	height := func(h int64) *int64 { return &h }
+	cases := []struct {
+		args      []interface{}
+		finalized bool
+		canonical bool
+	}{
+		{nil, false, false},
+		{[]interface{}{(*int64)(nil)}, false, false},
+		{[]interface{}{height(0)}, false, false},
+		{[]interface{}{height(1)}, true, true},
+		{[]interface{}{height(99)}, true, true},
+		{[]interface{}{height(100)}, true, false},
+		{[]interface{}{height(101)}, false, false},
+	}
+
+	for i, c := range cases {
+		assert.Equal(t, c.finalized, isFinalizedHeight(c.args), "#%d", i)
+		assert.Equal(t, c.canonical, isCanonicalCommitHeight(c.args), "#%d", i)
+	}
+}
+
+func TestTxHash(t *testing.T) {
+	tx := types.Tx("tx")
+	SetEnvironment(&Environment{})
+	assert.Equal(t, tx.Hash(), TxHash(tx))
+
+	genDoc := &types.GenesisDoc{TxHash: &types.GenesisTxHash{Algorithm: types.TxHashKeccak256, Domain: "chain"}}
+	hasher, err := types.NewTxHasher(types.TxHashKeccak256, "chain")
+	require.NoError(t, err)
+	SetEnvironment(&Environment{GenDoc: genDoc})
+	assert.Equal(t, hasher.Hash(tx), TxHash(tx))
+}
@@ -2,13 +2,21 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/state/txindex/null"
+	"github.com/tendermint/tendermint/types"
 )
 
 const (
@@ -16,38 +24,104 @@ const (
 	subBufferSize = 100
 )
 
-// Subscribe for events via WebSocket.
+// Subscribe for events via WebSocket. The query and the optional queries are
+// combined with OR into a single subscription.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/subscribe
-func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
-	addr := ctx.RemoteAddr()
+func Subscribe(ctx *rpctypes.Context, query string, queries []string) (*ctypes.ResultSubscribe, error) {
+	q, err := parseSubscriptionQuery(query, queries)
+	if err != nil {
+		return nil, err
+	}
+	if err := subscribe(ctx, q); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultSubscribe{}, nil
+}
 
-	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
-		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
-	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
-		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+// parseSubscriptionQuery parses the query and queries of a subscription,
+// combining them with OR. Each of them can itself be an OR of queries in
+// parentheses (see tmquery.ParseOr), e.g. the query of an event received for
+// a previous subscription.
+func parseSubscriptionQuery(query string, queries []string) (tmpubsub.Query, error) {
+	if query != "" {
+		queries = append([]string{query}, queries...)
+	}
+	if len(queries) == 0 {
+		return nil, errors.New("no query given")
 	}
 
-	env.Logger.Info("Subscribe to query", "remote", addr, "query", query)
+	var (
+		q       tmpubsub.Query
+		parsed  []*tmquery.Query
+		maxSize = env.Config.MaxQueriesPerSubscription
+	)
+	for _, query := range queries {
+		if strings.HasPrefix(strings.TrimSpace(query), "(") {
+			or, err := tmquery.ParseOr(query)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse query: %w", err)
+			}
+			q = or
+			parsed = append(parsed, or.Queries()...)
+		} else {
+			query, err := tmquery.New(query)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse query: %w", err)
+			}
+			q = query
+			parsed = append(parsed, query)
+		}
+	}
+	if maxSize > 0 && len(parsed) > maxSize {
+		return nil, fmt.Errorf("%w: max_queries_per_subscription %d reached", rpctypes.ErrQuotaExceeded, maxSize)
+	}
+	// keep the original string of a single query, which identifies the
+	// subscription
+	if len(queries) == 1 {
+		return q, nil
+	}
+	return tmquery.NewOr(parsed...), nil
+}
 
-	q, err := tmquery.New(query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse query: %w", err)
+// subscribe subscribes the WebSocket connection of ctx to q. Matching events
+// are written to the connection using the ID of ctx's request.
+func subscribe(ctx *rpctypes.Context, q tmpubsub.Query) error {
+	addr := ctx.RemoteAddr()
+	query := q.String()
+
+	if err := checkSubscriptionQuotas(addr); err != nil {
+		return err
 	}
 
+	env.Logger.Info("Subscribe to query", "remote", addr, "query", query)
+
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
 
 	sub, err := env.EventBus.Subscribe(subCtx, addr, q, subBufferSize)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Capture the current ID, since it can change in the future.
 	subscriptionID := ctx.JSONReq.ID
+	limiter := clientEventLimiter(addr, ctx.WSConn.Context().Done())
 	go func() {
 		for {
 			select {
 			case msg := <-sub.Out():
+				if allowed, first := limiter.allow(time.Now()); !allowed {
+					// notify the client once per window that events are being dropped
+					if first {
+						err := fmt.Errorf("%w: max_events_per_second_per_client %d reached, dropping events",
+							rpctypes.ErrQuotaExceeded, env.Config.MaxEventsPerSecondPerClient)
+						if ok := ctx.WSConn.TryWriteRPCResponse(rpctypes.RPCQuotaExceededError(subscriptionID, err)); !ok {
+							env.Logger.Info("Can't write response (slow client)",
+								"to", addr, "subscriptionID", subscriptionID, "err", err)
+						}
+					}
+					continue
+				}
 				var (
 					resultEvent = &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
 					resp        = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
@@ -80,18 +154,32 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 		}
 	}()
 
-	return &ctypes.ResultSubscribe{}, nil
+	return nil
+}
+
+// checkSubscriptionQuotas returns an error wrapping rpctypes.ErrQuotaExceeded
+// if the client can't subscribe to one more query.
+func checkSubscriptionQuotas(addr string) error {
+	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
+		return fmt.Errorf("%w: max_subscription_clients %d reached",
+			rpctypes.ErrQuotaExceeded, env.Config.MaxSubscriptionClients)
+	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
+		return fmt.Errorf("%w: max_subscriptions_per_client %d reached",
+			rpctypes.ErrQuotaExceeded, env.Config.MaxSubscriptionsPerClient)
+	}
+	return nil
 }
 
-// Unsubscribe from events via WebSocket.
+// Unsubscribe from events via WebSocket. The query and queries must be the
+// ones given to subscribe.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/unsubscribe
-func Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe, error) {
+func Unsubscribe(ctx *rpctypes.Context, query string, queries []string) (*ctypes.ResultUnsubscribe, error) {
 	addr := ctx.RemoteAddr()
-	env.Logger.Info("Unsubscribe from query", "remote", addr, "query", query)
-	q, err := tmquery.New(query)
+	q, err := parseSubscriptionQuery(query, queries)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse query: %w", err)
+		return nil, err
 	}
+	env.Logger.Info("Unsubscribe from query", "remote", addr, "query", q)
 	err = env.EventBus.Unsubscribe(context.Background(), addr, q)
 	if err != nil {
 		return nil, err
@@ -110,3 +198,177 @@ func UnsubscribeAll(ctx *rpctypes.Context) (*ctypes.ResultUnsubscribe, error) {
 	}
 	return &ctypes.ResultUnsubscribe{}, nil
 }
+
+// Events returns the historical Tx events matching the subscription query,
+// between the from and to heights (inclusive), from the tx indexer. The events
+// are paginated in the order they were published. Note only the indexed
+// attributes can be queried (see tx_index.index_events).
+// More: https://docs.tendermint.com/master/rpc/#/Info/events
+func Events(ctx *rpctypes.Context, query string, fromHeightPtr, toHeightPtr *int64, pagePtr, perPagePtr *int) (
+	*ctypes.ResultEvents, error) {
+	// if index is disabled, return error
+	if _, ok := env.TxIndexer.(*null.TxIndex); ok {
+		return nil, errors.New("transaction indexing is disabled")
+	}
+
+	parsed, err := tmquery.New(query)
+	if err != nil {
+		return nil, err
+	}
+	conditions, err := parsed.Conditions()
+	if err != nil {
+		return nil, err
+	}
+	// the tm.event conditions aren't indexed: all the indexed events are Tx
+	// events, so they're left out of the search
+	var (
+		conditionStrs = parsed.ConditionStrings()
+		searched      = make([]string, 0, len(conditions)+2)
+	)
+	for i, c := range conditions {
+		if c.CompositeKey != types.EventTypeKey {
+			searched = append(searched, conditionStrs[i])
+			continue
+		}
+		if c.Op != tmquery.OpEqual || c.Operand != types.EventTx {
+			return &ctypes.ResultEvents{Events: []*ctypes.ResultEvent{}, TotalCount: 0}, nil
+		}
+	}
+
+	fromHeight, toHeight := int64(1), env.BlockStore.Height()
+	if fromHeightPtr != nil {
+		fromHeight = *fromHeightPtr
+	}
+	if toHeightPtr != nil {
+		toHeight = *toHeightPtr
+	}
+	if fromHeight <= 0 || toHeight < fromHeight {
+		return nil, fmt.Errorf("invalid height range [%d, %d]", fromHeight, toHeight)
+	}
+	searched = append(searched, fmt.Sprintf("%s >= %d", types.TxHeightKey, fromHeight),
+		fmt.Sprintf("%s <= %d", types.TxHeightKey, toHeight))
+	q, err := tmquery.New(strings.Join(searched, " AND "))
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := env.TxIndexer.Search(ctx.Context(), q)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Height == results[j].Height {
+			return results[i].Index < results[j].Index
+		}
+		return results[i].Height < results[j].Height
+	})
+
+	// paginate results
+	totalCount := len(results)
+	perPage := validatePerPage(perPagePtr)
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+	skipCount := validateSkipCount(page, perPage)
+	pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
+
+	events := make([]*ctypes.ResultEvent, 0, pageSize)
+	for _, r := range results[skipCount : skipCount+pageSize] {
+		events = append(events, &ctypes.ResultEvent{
+			Query:  query,
+			Data:   types.EventDataTx{TxResult: *r},
+			Events: txEvents(r),
+		})
+	}
+
+	return &ctypes.ResultEvents{Events: events, TotalCount: totalCount}, nil
+}
+
+// txEvents returns the events of the tx, as published by the event bus.
+func txEvents(r *abci.TxResult) map[string][]string {
+	events := make(map[string][]string)
+	for _, event := range r.Result.Events {
+		if len(event.Type) == 0 {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if len(attr.Key) == 0 {
+				continue
+			}
+			compositeTag := fmt.Sprintf("%s.%s", event.Type, string(attr.Key))
+			events[compositeTag] = append(events[compositeTag], string(attr.Value))
+		}
+	}
+	events[types.EventTypeKey] = append(events[types.EventTypeKey], types.EventTx)
+	events[types.TxHashKey] = append(events[types.TxHashKey], fmt.Sprintf("%X", TxHash(r.Tx)))
+	events[types.TxHeightKey] = append(events[types.TxHeightKey], fmt.Sprintf("%d", r.Height))
+	return events
+}
+
+// eventLimiter limits the number of events sent to a client per second.
+type eventLimiter struct {
+	limit int // 0 - unlimited
+
+	mtx         tmsync.Mutex
+	windowStart time.Time
+	count       int
+}
+
+// allow returns true if one more event can be sent to the client at the given
+// time. Otherwise, first is true if it's the first event dropped in the
+// current one second window.
+func (l *eventLimiter) allow(now time.Time) (allowed, first bool) {
+	if l == nil || l.limit == 0 {
+		return true, false
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if now.Sub(l.windowStart) >= time.Second {
+		l.windowStart = now
+		l.count = 0
+	}
+	l.count++
+	if l.count <= l.limit {
+		return true, false
+	}
+	return false, l.count == l.limit+1
+}
+
+var (
+	eventLimitersMtx tmsync.Mutex
+	// WebSocket or SSE connection remote address -> limiter
+	eventLimiters = make(map[string]*eventLimiter)
+)
+
+// clientEventLimiter returns the event limiter shared by all the subscriptions
+// of the connection with the given remote address, or nil if events are not
+// limited. The limiter is dropped once done is closed, i.e. when the
+// connection closes.
+func clientEventLimiter(addr string, done <-chan struct{}) *eventLimiter {
+	if env.Config.MaxEventsPerSecondPerClient == 0 {
+		return nil
+	}
+
+	eventLimitersMtx.Lock()
+	defer eventLimitersMtx.Unlock()
+
+	if l, ok := eventLimiters[addr]; ok {
+		return l
+	}
+	l := &eventLimiter{limit: env.Config.MaxEventsPerSecondPerClient}
+	eventLimiters[addr] = l
+
+	go func() {
+		<-done
+		eventLimitersMtx.Lock()
+		if eventLimiters[addr] == l {
+			delete(eventLimiters, addr)
+		}
+		eventLimitersMtx.Unlock()
+	}()
+
+	return l
+}
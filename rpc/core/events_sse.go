@@ -0,0 +1,257 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// sseWriteTimeout is the maximum time to write an event to a SSE client.
+const sseWriteTimeout = 10 * time.Second
+
+// SubscribeSSE subscribes to the events matching the query and queries URL
+// params, with the same semantics and limits as /subscribe, and streams them
+// as Server-Sent Events, for the clients which can't use WebSockets. The
+// subscription lasts until the client disconnects.
+//
+// Each event is a message whose id is its sequence number and whose data is
+// the JSON ResultEvent, as the result of the /subscribe notifications. The
+// errors (e.g. events dropped because of max_events_per_second_per_client, or
+// the subscription being cancelled) are sent as "error" events, whose data is
+// the JSON-RPC error. A keepalive comment is sent every
+// rpc.sse_keepalive_interval.
+//
+// Like the other URI params, the queries can be quoted, e.g.
+// /subscribe_sse?query="tm.event='NewBlock'".
+//
+// NOTE: over HTTP/2 (i.e. with TLS), the stream is closed after the server's
+// write timeout, since the connection can't be taken over.
+func SubscribeSSE(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	params := r.URL.Query()
+	queries := make([]string, len(params["queries"]))
+	for i, query := range params["queries"] {
+		queries[i] = unquoteParam(query)
+	}
+	q, err := parseSubscriptionQuery(unquoteParam(params.Get("query")), queries)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, rpctypes.ErrQuotaExceeded) {
+			status = http.StatusTooManyRequests
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	addr := r.RemoteAddr
+	if err := checkSubscriptionQuotas(addr); err != nil {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+
+	env.Logger.Info("Subscribe to query over SSE", "remote", addr, "query", q)
+
+	subCtx, cancel := context.WithTimeout(r.Context(), SubscribeTimeout)
+	defer cancel()
+	sub, err := env.EventBus.Subscribe(subCtx, addr, q, subBufferSize)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer func() {
+		err := env.EventBus.UnsubscribeAll(context.Background(), addr)
+		if err != nil && err != tmpubsub.ErrSubscriptionNotFound {
+			env.Logger.Error("Failed to unsubscribe addr from events", "addr", addr, "err", err)
+		}
+	}()
+
+	stream, err := newSSEStream(w, r)
+	if err != nil {
+		env.Logger.Error("Failed to open SSE stream", "remote", addr, "err", err)
+		return
+	}
+	defer stream.close()
+
+	var keepAlive <-chan time.Time
+	if env.Config.SSEKeepAliveInterval > 0 {
+		ticker := time.NewTicker(env.Config.SSEKeepAliveInterval)
+		defer ticker.Stop()
+		keepAlive = ticker.C
+	}
+
+	var (
+		query   = q.String()
+		limiter = clientEventLimiter(addr, stream.done)
+		seq     uint64
+	)
+	for {
+		select {
+		case msg := <-sub.Out():
+			if allowed, first := limiter.allow(time.Now()); !allowed {
+				if first {
+					err = stream.writeError(rpctypes.RPCQuotaExceededError(nil, fmt.Errorf(
+						"%w: max_events_per_second_per_client %d reached, dropping events",
+						rpctypes.ErrQuotaExceeded, env.Config.MaxEventsPerSecondPerClient)))
+				}
+				break
+			}
+			seq++
+			err = stream.writeEvent(seq, &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()})
+		case <-keepAlive:
+			err = stream.writeComment("keepalive")
+		case <-sub.Cancelled():
+			if sub.Err() != tmpubsub.ErrUnsubscribed {
+				reason := "Tendermint exited"
+				if sub.Err() != nil {
+					reason = sub.Err().Error()
+				}
+				err := fmt.Errorf("subscription was cancelled (reason: %s)", reason)
+				_ = stream.writeError(rpctypes.RPCServerError(nil, err))
+			}
+			return
+		case <-stream.done:
+			return
+		}
+		if err != nil {
+			env.Logger.Info("Can't write event (slow client)", "to", addr, "err", err)
+			return
+		}
+	}
+}
+
+// unquoteParam returns the URI param without its quotes, if quoted.
+func unquoteParam(param string) string {
+	if unquoted, err := strconv.Unquote(param); err == nil {
+		return unquoted
+	}
+	return param
+}
+
+// sseStream writes the events of a SSE response. The connection is taken over
+// when possible, so that the server's write timeout doesn't apply.
+type sseStream struct {
+	w     *bufio.Writer
+	conn  net.Conn // nil if the connection couldn't be taken over
+	flush func()
+	done  <-chan struct{} // closed once the client disconnected
+}
+
+func newSSEStream(w http.ResponseWriter, r *http.Request) (*sseStream, error) {
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no") // disables the nginx buffering
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok || r.ProtoMajor != 1 {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return nil, errors.New("streaming unsupported")
+		}
+		w.WriteHeader(http.StatusOK)
+		bw := bufio.NewWriter(w)
+		return &sseStream{w: bw, flush: flusher.Flush, done: r.Context().Done()}, nil
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	// the response has no length, so it ends when the connection is closed
+	header.Set("Connection", "close")
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	done := make(chan struct{})
+	go func() {
+		// the client isn't expected to send anything else
+		_, _ = io.Copy(ioutil.Discard, rw.Reader)
+		close(done)
+	}()
+
+	s := &sseStream{w: rw.Writer, conn: conn, flush: func() {}, done: done}
+	if err := s.write(func() error {
+		if _, err := fmt.Fprintf(s.w, "HTTP/1.1 %d %s\r\n", http.StatusOK, http.StatusText(http.StatusOK)); err != nil {
+			return err
+		}
+		if err := header.Write(s.w); err != nil {
+			return err
+		}
+		_, err := s.w.WriteString("\r\n")
+		return err
+	}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// write calls writeFn then flushes the stream, within sseWriteTimeout.
+func (s *sseStream) write(writeFn func() error) error {
+	if s.conn != nil {
+		if err := s.conn.SetWriteDeadline(time.Now().Add(sseWriteTimeout)); err != nil {
+			return err
+		}
+	}
+	if err := writeFn(); err != nil {
+		return err
+	}
+	if err := s.w.Flush(); err != nil {
+		return err
+	}
+	s.flush()
+	return nil
+}
+
+func (s *sseStream) writeEvent(seq uint64, event *ctypes.ResultEvent) error {
+	data, err := tmjson.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.write(func() error {
+		_, err := fmt.Fprintf(s.w, "id: %d\ndata: %s\n\n", seq, data)
+		return err
+	})
+}
+
+func (s *sseStream) writeError(resp rpctypes.RPCResponse) error {
+	data, err := tmjson.Marshal(resp.Error)
+	if err != nil {
+		return err
+	}
+	return s.write(func() error {
+		_, err := fmt.Fprintf(s.w, "event: error\ndata: %s\n\n", data)
+		return err
+	})
+}
+
+func (s *sseStream) writeComment(comment string) error {
+	return s.write(func() error {
+		_, err := fmt.Fprintf(s.w, ": %s\n\n", comment)
+		return err
+	})
+}
+
+func (s *sseStream) close() {
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestSubscribeSSE(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { require.NoError(t, eventBus.Stop()) })
+	env = &Environment{Config: *cfg.TestRPCConfig(), EventBus: eventBus, Logger: log.TestingLogger()}
+	env.Config.SSEKeepAliveInterval = 50 * time.Millisecond
+
+	srv := httptest.NewServer(http.HandlerFunc(SubscribeSSE))
+	t.Cleanup(srv.Close)
+
+	// invalid query
+	resp, err := http.Get(srv.URL + "?query=" + url.QueryEscape(`"tm.event =="`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	query := `"tm.event='Tx' AND app.key='a'"`
+	resp, err = http.Get(srv.URL + "?query=" + url.QueryEscape(query))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	require.Eventually(t, func() bool { return eventBus.NumClients() == 1 }, time.Second, 10*time.Millisecond)
+
+	for _, key := range []string{"b", "a"} {
+		err = eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+			Height: 1,
+			Tx:     types.Tx(key),
+			Result: abci.ResponseDeliverTx{Events: []abci.Event{{
+				Type:       "app",
+				Attributes: []abci.EventAttribute{{Key: []byte("key"), Value: []byte(key), Index: true}},
+			}}},
+		}})
+		require.NoError(t, err)
+	}
+
+	// the keepalives and the only matching event
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 2 {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		if line = strings.TrimSuffix(line, "\n"); line != "" && line != ": keepalive" {
+			lines = append(lines, line)
+		}
+	}
+	require.Equal(t, "id: 1", lines[0])
+	var event ctypes.ResultEvent
+	require.NoError(t, tmjson.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), &event))
+	assert.Equal(t, "tm.event='Tx' AND app.key='a'", event.Query)
+	assert.Equal(t, []string{"a"}, event.Events["app.key"])
+	require.IsType(t, types.EventDataTx{}, event.Data)
+	assert.Equal(t, []byte("a"), event.Data.(types.EventDataTx).Tx)
+
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		if line == ": keepalive\n" {
+			break
+		}
+	}
+
+	// the client is unsubscribed once disconnected
+	resp.Body.Close()
+	require.Eventually(t, func() bool { return eventBus.NumClients() == 0 }, time.Second, 10*time.Millisecond)
+}
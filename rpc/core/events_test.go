@@ -0,0 +1,77 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+func TestEventLimiter(t *testing.T) {
+	l := &eventLimiter{limit: 2}
+	now := time.Now()
+
+	allowed, first := l.allow(now)
+	assert.True(t, allowed)
+	assert.False(t, first)
+	allowed, _ = l.allow(now.Add(100 * time.Millisecond))
+	assert.True(t, allowed)
+
+	// the limit is reached, the client is notified only once
+	allowed, first = l.allow(now.Add(200 * time.Millisecond))
+	assert.False(t, allowed)
+	assert.True(t, first)
+	allowed, first = l.allow(now.Add(300 * time.Millisecond))
+	assert.False(t, allowed)
+	assert.False(t, first)
+
+	// a new window starts
+	allowed, _ = l.allow(now.Add(time.Second))
+	assert.True(t, allowed)
+
+	// nil or zero limit means unlimited
+	var nilLimiter *eventLimiter
+	allowed, _ = nilLimiter.allow(now)
+	assert.True(t, allowed)
+	allowed, _ = (&eventLimiter{}).allow(now)
+	assert.True(t, allowed)
+}
+
+func TestParseSubscriptionQuery(t *testing.T) {
+	env = &Environment{Config: *cfg.TestRPCConfig()}
+	env.Config.MaxQueriesPerSubscription = 3
+
+	testCases := []struct {
+		query   string
+		queries []string
+		want    string
+		wantErr bool
+	}{
+		{"a = 1", nil, "a = 1", false},
+		{" (a = 1) OR (b = 2)", nil, " (a = 1) OR (b = 2)", false},
+		{"", []string{"a = 1"}, "a = 1", false},
+		{"a = 1", []string{"b = 2"}, "(a = 1) OR (b = 2)", false},
+		{"", []string{"a = 1", "(b = 2) OR (c = 3)"}, "(a = 1) OR (b = 2) OR (c = 3)", false},
+		{"a = 1", []string{"b = 2", "c = 3", "d = 4"}, "", true},
+		{"", nil, "", true},
+		{"a ==", nil, "", true},
+		{"a = 1", []string{"(b = 2"}, "", true},
+	}
+	for _, tc := range testCases {
+		q, err := parseSubscriptionQuery(tc.query, tc.queries)
+		if tc.wantErr {
+			assert.Error(t, err, tc.query, tc.queries)
+			continue
+		}
+		require.NoError(t, err, tc.query, tc.queries)
+		assert.Equal(t, tc.want, q.String())
+	}
+
+	_, err := parseSubscriptionQuery("a = 1", []string{"b = 2", "c = 3", "d = 4"})
+	assert.True(t, errors.Is(err, rpctypes.ErrQuotaExceeded))
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// maxIdempotencyKeyLength bounds the memory used by each remembered key.
+const maxIdempotencyKeyLength = 256
+
+// IdempotencyCache remembers the results of the /broadcast_tx_* requests made
+// with an idempotency key, so that the retries of a request (e.g. after a
+// timeout on the client side) get the original CheckTx or DeliverTx result
+// instead of submitting the tx again.
+//
+// Only the requests which succeeded are remembered, for ttl after they
+// completed, and at most size keys are kept, the oldest being forgotten
+// first. Concurrent requests with the same key wait for the first one.
+type IdempotencyCache struct {
+	size int
+	ttl  time.Duration
+
+	mtx     tmsync.Mutex
+	entries map[string]*list.Element
+	list    *list.List // of *idempotencyEntry, completed ones ordered by expiry at the back
+}
+
+type idempotencyEntry struct {
+	key    string
+	txHash []byte // the tmhash, whatever the chain's tx hasher
+	done   chan struct{}
+
+	// set once done is closed
+	expires time.Time
+	result  interface{}
+	err     error
+}
+
+// NewIdempotencyCache returns a cache remembering up to size keys, for ttl.
+func NewIdempotencyCache(size int, ttl time.Duration) *IdempotencyCache {
+	return &IdempotencyCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		list:    list.New(),
+	}
+}
+
+// Do returns the result of the first request made with the given key, calling
+// broadcast if there is none. It returns an error if the key was used for
+// another tx.
+func (c *IdempotencyCache) Do(
+	ctx context.Context,
+	key string,
+	tx types.Tx,
+	broadcast func() (interface{}, error),
+) (interface{}, error) {
+	if c == nil || c.size == 0 {
+		return nil, errors.New("idempotency keys are disabled (see rpc.idempotency_cache_size)")
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, fmt.Errorf("idempotency key is too long: %d bytes, max %d", len(key), maxIdempotencyKeyLength)
+	}
+	txHash := tmhash.Sum(tx)
+
+	c.mtx.Lock()
+	c.pruneExpired(time.Now())
+	if elem, ok := c.entries[key]; ok {
+		c.mtx.Unlock()
+		e := elem.Value.(*idempotencyEntry)
+		if !bytes.Equal(e.txHash, txHash) {
+			return nil, fmt.Errorf("idempotency key %q was already used for another tx", key)
+		}
+		select {
+		case <-e.done:
+			return e.result, e.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	e := &idempotencyEntry{key: key, txHash: txHash, done: make(chan struct{})}
+	elem := c.list.PushBack(e)
+	c.entries[key] = elem
+	for c.list.Len() > c.size {
+		c.remove(c.list.Front())
+	}
+	c.mtx.Unlock()
+
+	result, err := broadcast()
+
+	c.mtx.Lock()
+	e.result, e.err = result, err
+	if err != nil {
+		// forget the failed requests, so that they can be retried
+		if c.entries[key] == elem {
+			c.remove(elem)
+		}
+	} else {
+		e.expires = time.Now().Add(c.ttl)
+		if c.entries[key] == elem {
+			c.list.MoveToBack(elem)
+		}
+	}
+	close(e.done)
+	c.mtx.Unlock()
+	return result, err
+}
+
+// pruneExpired removes the completed entries which expired before now. It
+// must be called with the mutex held.
+func (c *IdempotencyCache) pruneExpired(now time.Time) {
+	for elem := c.list.Front(); elem != nil; {
+		next := elem.Next()
+		e := elem.Value.(*idempotencyEntry)
+		if !e.expires.IsZero() {
+			if e.expires.After(now) {
+				return
+			}
+			c.remove(elem)
+		}
+		elem = next
+	}
+}
+
+func (c *IdempotencyCache) remove(elem *list.Element) {
+	delete(c.entries, elem.Value.(*idempotencyEntry).key)
+	c.list.Remove(elem)
+}
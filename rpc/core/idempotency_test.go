@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestIdempotencyCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewIdempotencyCache(2, time.Hour)
+	calls := 0
+	broadcast := func() (interface{}, error) {
+		calls++
+		return calls, nil
+	}
+
+	// retries get the original result
+	res, err := c.Do(ctx, "a", types.Tx("tx"), broadcast)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res)
+	res, err = c.Do(ctx, "a", types.Tx("tx"), broadcast)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res)
+
+	// a key can't be reused for another tx
+	_, err = c.Do(ctx, "a", types.Tx("other"), broadcast)
+	assert.Error(t, err)
+
+	// failed requests are forgotten
+	_, err = c.Do(ctx, "b", types.Tx("tx"), func() (interface{}, error) { return nil, errors.New("full") })
+	assert.Error(t, err)
+	res, err = c.Do(ctx, "b", types.Tx("tx"), broadcast)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res)
+
+	// the oldest keys are forgotten first
+	_, err = c.Do(ctx, "c", types.Tx("tx"), broadcast)
+	require.NoError(t, err)
+	res, err = c.Do(ctx, "a", types.Tx("tx"), broadcast)
+	require.NoError(t, err)
+	assert.Equal(t, 4, res)
+
+	_, err = NewIdempotencyCache(0, time.Hour).Do(ctx, "a", types.Tx("tx"), broadcast)
+	assert.Error(t, err)
+}
+
+func TestIdempotencyCacheExpiry(t *testing.T) {
+	c := NewIdempotencyCache(10, time.Millisecond)
+	calls := 0
+	broadcast := func() (interface{}, error) {
+		calls++
+		return calls, nil
+	}
+
+	_, err := c.Do(context.Background(), "a", types.Tx("tx"), broadcast)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	res, err := c.Do(context.Background(), "a", types.Tx("tx"), broadcast)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res)
+}
+
+func TestIdempotencyCacheConcurrent(t *testing.T) {
+	c := NewIdempotencyCache(10, time.Hour)
+	release := make(chan struct{})
+	var mtx sync.Mutex
+	calls := 0
+	broadcast := func() (interface{}, error) {
+		mtx.Lock()
+		calls++
+		mtx.Unlock()
+		<-release
+		return "res", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := c.Do(context.Background(), "a", types.Tx("tx"), broadcast)
+			assert.NoError(t, err)
+			assert.Equal(t, "res", res)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, 1, calls)
+}
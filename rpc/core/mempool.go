@@ -7,31 +7,52 @@ import (
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	mempl "github.com/tendermint/tendermint/mempool"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 //-----------------------------------------------------------------------------
 // NOTE: tx should be signed, but this is only checked at the app level (not by Tendermint!)
 
+// The /broadcast_tx_* requests made with an idempotency key are submitted only
+// once: the retries get the result of the first request, see IdempotencyCache.
+
 // BroadcastTxAsync returns right away, with no response. Does not wait for
 // CheckTx nor DeliverTx results.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_async
-func BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+func BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx, idempotencyKey string) (*ctypes.ResultBroadcastTx, error) {
+	if idempotencyKey != "" {
+		res, err := env.IdempotencyCache.Do(ctx.Context(), "async/"+idempotencyKey, tx, func() (interface{}, error) {
+			return BroadcastTxAsync(ctx, tx, "")
+		})
+		r, _ := res.(*ctypes.ResultBroadcastTx)
+		return r, err
+	}
+
 	err := env.Mempool.CheckTx(tx, nil, mempl.TxInfo{Context: ctx.Context()})
 
 	if err != nil {
 		return nil, err
 	}
-	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+	return &ctypes.ResultBroadcastTx{Hash: TxHash(tx)}, nil
 }
 
 // BroadcastTxSync returns with the response from CheckTx. Does not wait for
 // DeliverTx result.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_sync
-func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx, idempotencyKey string) (*ctypes.ResultBroadcastTx, error) {
+	if idempotencyKey != "" {
+		res, err := env.IdempotencyCache.Do(ctx.Context(), "sync/"+idempotencyKey, tx, func() (interface{}, error) {
+			return BroadcastTxSync(ctx, tx, "")
+		})
+		r, _ := res.(*ctypes.ResultBroadcastTx)
+		return r, err
+	}
+
 	resCh := make(chan *abci.Response, 1)
 	err := env.Mempool.CheckTx(tx, func(res *abci.Response) {
 		resCh <- res
@@ -46,13 +67,59 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 		Data:      r.Data,
 		Log:       r.Log,
 		Codespace: r.Codespace,
-		Hash:      tx.Hash(),
+		Hash:      TxHash(tx),
 	}, nil
 }
 
-// BroadcastTxCommit returns with the responses from CheckTx and DeliverTx.
+// BroadcastTxSubscribe subscribes the WebSocket connection to query and then
+// broadcasts tx, returning with the response from CheckTx. Since the
+// subscription is made before the tx enters the mempool, no matching events
+// (e.g. those of tx itself) can be missed, as opposed to calling subscribe and
+// broadcast_tx_sync separately.
+//
+// Events are written to the connection using the ID of this request. The
+// subscription is kept after this call returns, unless tx could not be
+// broadcast, and must be removed with unsubscribe.
+// More: https://docs.tendermint.com/master/rpc/#/Websocket/broadcast_tx_subscribe
+func BroadcastTxSubscribe(ctx *rpctypes.Context, tx types.Tx, query string) (*ctypes.ResultBroadcastTx, error) {
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+	if err := subscribe(ctx, q); err != nil {
+		return nil, err
+	}
+
+	res, err := BroadcastTxSync(ctx, tx, "")
+	if err != nil {
+		if _, uerr := Unsubscribe(ctx, query, nil); uerr != nil {
+			env.Logger.Error("Error unsubscribing from eventBus", "err", uerr)
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// BroadcastTxCommit returns with the responses from CheckTx and DeliverTx. If
+// prove is true, the response also includes the Merkle inclusion proof of tx
+// against the DataHash of the block it was included in, see
+// light.VerifyTxInclusion.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_commit
-func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx, idempotencyKey string,
+	prove bool) (*ctypes.ResultBroadcastTxCommit, error) {
+	if idempotencyKey != "" {
+		// The proved and unproved responses are cached apart.
+		prefix := "commit/"
+		if prove {
+			prefix = "commit-prove/"
+		}
+		res, err := env.IdempotencyCache.Do(ctx.Context(), prefix+idempotencyKey, tx, func() (interface{}, error) {
+			return BroadcastTxCommit(ctx, tx, "", prove)
+		})
+		r, _ := res.(*ctypes.ResultBroadcastTxCommit)
+		return r, err
+	}
+
 	subscriber := ctx.RemoteAddr()
 
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
@@ -64,7 +131,7 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 	// Subscribe to tx being committed in block.
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
-	q := types.EventQueryTxFor(tx)
+	q := types.EventQueryTxForHash(TxHash(tx))
 	deliverTxSub, err := env.EventBus.Subscribe(subCtx, subscriber, q)
 	if err != nil {
 		err = fmt.Errorf("failed to subscribe to tx: %w", err)
@@ -92,7 +159,7 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 		return &ctypes.ResultBroadcastTxCommit{
 			CheckTx:   *checkTxRes,
 			DeliverTx: abci.ResponseDeliverTx{},
-			Hash:      tx.Hash(),
+			Hash:      TxHash(tx),
 		}, nil
 	}
 
@@ -100,12 +167,22 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 	select {
 	case msg := <-deliverTxSub.Out(): // The tx was included in a block.
 		deliverTxRes := msg.Data().(types.EventDataTx)
-		return &ctypes.ResultBroadcastTxCommit{
+		res := &ctypes.ResultBroadcastTxCommit{
 			CheckTx:   *checkTxRes,
 			DeliverTx: deliverTxRes.Result,
-			Hash:      tx.Hash(),
+			Hash:      TxHash(tx),
 			Height:    deliverTxRes.Height,
-		}, nil
+		}
+		if prove {
+			// the block is saved before being executed, so it's available.
+			proof, err := txProof(deliverTxRes.Height, deliverTxRes.Index)
+			if err != nil {
+				env.Logger.Error("Error on broadcastTxCommit", "err", err)
+				return res, err
+			}
+			res.Proof = &proof
+		}
+		return res, nil
 	case <-deliverTxSub.Cancelled():
 		var reason string
 		if deliverTxSub.Err() == nil {
@@ -118,7 +195,7 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 		return &ctypes.ResultBroadcastTxCommit{
 			CheckTx:   *checkTxRes,
 			DeliverTx: abci.ResponseDeliverTx{},
-			Hash:      tx.Hash(),
+			Hash:      TxHash(tx),
 		}, err
 	case <-time.After(env.Config.TimeoutBroadcastTxCommit):
 		err = errors.New("timed out waiting for tx to be included in a block")
@@ -126,7 +203,7 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 		return &ctypes.ResultBroadcastTxCommit{
 			CheckTx:   *checkTxRes,
 			DeliverTx: abci.ResponseDeliverTx{},
-			Hash:      tx.Hash(),
+			Hash:      TxHash(tx),
 		}, err
 	}
 }
@@ -155,11 +232,44 @@ func NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, err
 		TotalBytes: env.Mempool.TxsBytes()}, nil
 }
 
+// MempoolStats returns histograms of the sizes, gas wanted and ages of the
+// unconfirmed transactions, along with eviction counters and their counts by
+// category, to help tune the mempool size limits.
+// More: https://docs.tendermint.com/master/rpc/#/Info/mempool_stats
+func MempoolStats(ctx *rpctypes.Context) (*ctypes.ResultMempoolStats, error) {
+	stats := env.Mempool.Stats()
+	return &ctypes.ResultMempoolStats{
+		Count:          stats.Size,
+		MaxCount:       stats.MaxSize,
+		TotalBytes:     stats.TxsBytes,
+		MaxTotalBytes:  stats.MaxTxsBytes,
+		TotalGasWanted: stats.TotalGasWanted,
+		TxSizes:        mempoolHistogram(stats.TxSizes),
+		GasWanted:      mempoolHistogram(stats.GasWanted),
+		TxAges:         mempoolHistogram(stats.TxAges),
+		OldestTxAge:    stats.OldestTxAge,
+		Categories:     stats.Categories,
+		Evictions: ctypes.MempoolEvictions{
+			Full:        stats.Evictions.Full,
+			Invalidated: stats.Evictions.Invalidated,
+			Removed:     stats.Evictions.Removed,
+			Flushed:     stats.Evictions.Flushed,
+		},
+	}, nil
+}
+
+func mempoolHistogram(h mempl.Histogram) ctypes.MempoolHistogram {
+	return ctypes.MempoolHistogram{Bounds: h.Bounds, Counts: h.Counts}
+}
+
 // CheckTx checks the transaction without executing it. The transaction won't
 // be added to the mempool either.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/check_tx
 func CheckTx(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
-	res, err := env.ProxyAppMempool.CheckTxSync(ctx.Context(), abci.RequestCheckTx{Tx: tx})
+	res, err := env.ProxyAppMempool.CheckTxSync(ctx.Context(), abci.RequestCheckTx{
+		Tx:     tx,
+		Origin: abci.TxOrigin{Source: abci.TxSource_Local, ReceivedAt: tmtime.Now()},
+	})
 	if err != nil {
 		return nil, err
 	}
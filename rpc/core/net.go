@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/tendermint/tendermint/p2p"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
@@ -20,12 +21,19 @@ func NetInfo(ctx *rpctypes.Context) (*ctypes.ResultNetInfo, error) {
 		if !ok {
 			return nil, fmt.Errorf("peer.NodeInfo() is not DefaultNodeInfo")
 		}
-		peers = append(peers, ctypes.Peer{
+		status := peer.Status()
+		p := ctypes.Peer{
 			NodeInfo:         nodeInfo,
 			IsOutbound:       peer.IsOutbound(),
-			ConnectionStatus: peer.Status(),
+			ConnectionStatus: status,
 			RemoteIP:         peer.RemoteIP().String(),
-		})
+		}
+		if stats, ok := env.P2PPeers.PeerStats(peer.ID()); ok {
+			stats.RecordConnection(status)
+			p.Stats = &stats
+			p.Uptime = stats.Uptime(time.Now())
+		}
+		peers = append(peers, p)
 	}
 	// TODO: Should we include PersistentPeers and Seeds in here?
 	// PRO: useful info
@@ -91,6 +99,38 @@ func UnsafeDialPeers(ctx *rpctypes.Context, peers []string, persistent, uncondit
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// UnsafeSetPeerLists replaces the lists of node IDs allowed to connect to the
+// node (all of them are, if the list is empty) and denied, disconnecting the
+// peers no longer allowed.
+func UnsafeSetPeerLists(ctx *rpctypes.Context, allowedPeers, deniedPeers []string) (
+	*ctypes.ResultPeerLists, error) {
+	env.Logger.Info("SetPeerLists", "allowed", allowedPeers, "denied", deniedPeers)
+
+	if err := env.P2PPeers.SetPeerIDLists(allowedPeers, deniedPeers); err != nil {
+		return &ctypes.ResultPeerLists{}, err
+	}
+
+	allowed, denied := env.P2PPeers.PeerIDLists()
+	return &ctypes.ResultPeerLists{AllowedPeers: allowed, DeniedPeers: denied}, nil
+}
+
+// UnsafeSetPeerTxRelay enables or disables the relaying of txs to and from a
+// peer, without disconnecting it, persisting the setting in
+// mempool.tx_relay_file.
+func UnsafeSetPeerTxRelay(ctx *rpctypes.Context, peer string, enable bool) (*ctypes.ResultPeerTxRelay, error) {
+	if env.MempoolReactor == nil {
+		return nil, errors.New("the mempool reactor is not available")
+	}
+	peerID := p2p.ID(peer)
+	if err := p2p.ValidateID(peerID); err != nil {
+		return nil, fmt.Errorf("invalid peer: %w", err)
+	}
+	if err := env.MempoolReactor.SetPeerTxRelay(peerID, enable); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultPeerTxRelay{PeerID: peerID, Enabled: enable}, nil
+}
+
 // Genesis returns genesis file.
 // More: https://docs.tendermint.com/master/rpc/#/Info/genesis
 func Genesis(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
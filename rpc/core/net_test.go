@@ -85,3 +85,26 @@ func TestUnsafeDialPeers(t *testing.T) {
 		}
 	}
 }
+
+func TestUnsafeSetPeerLists(t *testing.T) {
+	sw := p2p.MakeSwitch(cfg.DefaultP2PConfig(), 1, "testing", "123.123.123",
+		func(n int, sw *p2p.Switch) *p2p.Switch { return sw })
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	env.Logger = log.TestingLogger()
+	env.P2PPeers = sw
+
+	res, err := UnsafeSetPeerLists(&rpctypes.Context{}, []string{"d51fb70907db1c6c2d5237e78379b25cf1a37ab4"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d51fb70907db1c6c2d5237e78379b25cf1a37ab4"}, res.AllowedPeers)
+	assert.Empty(t, res.DeniedPeers)
+
+	_, err = UnsafeSetPeerLists(&rpctypes.Context{}, nil, []string{"127.0.0.1:41198"})
+	assert.Error(t, err)
+}
@@ -9,34 +9,44 @@ import (
 // Routes is a map of available routes.
 var Routes = map[string]*rpc.RPCFunc{
 	// subscribe/unsubscribe are reserved for websocket events.
-	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query"),
-	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query"),
+	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query,queries"),
+	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query,queries"),
 	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, ""),
 
+	"broadcast_tx_subscribe": rpc.NewWSRPCFunc(BroadcastTxSubscribe, "tx,query"),
+
 	// info API
-	"health":               rpc.NewRPCFunc(Health, ""),
-	"status":               rpc.NewRPCFunc(Status, ""),
-	"net_info":             rpc.NewRPCFunc(NetInfo, ""),
-	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
-	"genesis":              rpc.NewRPCFunc(Genesis, ""),
-	"block":                rpc.NewRPCFunc(Block, "height"),
-	"block_by_hash":        rpc.NewRPCFunc(BlockByHash, "hash"),
-	"block_results":        rpc.NewRPCFunc(BlockResults, "height"),
-	"commit":               rpc.NewRPCFunc(Commit, "height"),
-	"check_tx":             rpc.NewRPCFunc(CheckTx, "tx"),
-	"tx":                   rpc.NewRPCFunc(Tx, "hash,prove"),
-	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page,order_by"),
-	"validators":           rpc.NewRPCFunc(Validators, "height,page,per_page"),
-	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, ""),
-	"consensus_state":      rpc.NewRPCFunc(ConsensusState, ""),
-	"consensus_params":     rpc.NewRPCFunc(ConsensusParams, "height"),
-	"unconfirmed_txs":      rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
-	"num_unconfirmed_txs":  rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
+	"health":                rpc.NewRPCFunc(Health, ""),
+	"status":                rpc.NewRPCFunc(Status, ""),
+	"net_info":              rpc.NewRPCFunc(NetInfo, ""),
+	"blockchain":            rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
+	"genesis":               rpc.NewRPCFunc(Genesis, ""),
+	"block":                 rpc.NewRPCFunc(Block, "height", rpc.Cacheable(isFinalizedHeight)),
+	"block_by_hash":         rpc.NewRPCFunc(BlockByHash, "hash"),
+	"block_raw":             rpc.NewRPCFunc(BlockRaw, "height,compress", rpc.Cacheable(isFinalizedHeight)),
+	"block_part":            rpc.NewRPCFunc(BlockPart, "height,index", rpc.Cacheable(isFinalizedHeight)),
+	"block_results":         rpc.NewRPCFunc(BlockResults, "height", rpc.Cacheable(isFinalizedHeight)),
+	"commit":                rpc.NewRPCFunc(Commit, "height,sign_bytes", rpc.Cacheable(isCanonicalCommitHeight)),
+	"check_tx":              rpc.NewRPCFunc(CheckTx, "tx"),
+	"tx":                    rpc.NewRPCFunc(Tx, "hash,prove"),
+	"tx_search":             rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page,order_by,count_only"),
+	"events":                rpc.NewRPCFunc(Events, "query,from_height,to_height,page,per_page"),
+	"validators":            rpc.NewRPCFunc(Validators, "height,page,per_page", rpc.Cacheable(isFinalizedHeight)),
+	"validator_info":        rpc.NewRPCFunc(ValidatorInfo, "address"),
+	"node_attestation":      rpc.NewRPCFunc(NodeAttestation, "node_id"),
+	"dump_consensus_state":  rpc.NewRPCFunc(DumpConsensusState, "validator,round,format,peer_summary"),
+	"consensus_state":       rpc.NewRPCFunc(ConsensusState, ""),
+	"consensus_params":      rpc.NewRPCFunc(ConsensusParams, "height"),
+	"simulate_param_change": rpc.NewRPCFunc(SimulateParamChange, "updates"),
+	"timestamp_drift":       rpc.NewRPCFunc(TimestampDrift, "min_height,max_height"),
+	"unconfirmed_txs":       rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
+	"num_unconfirmed_txs":   rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
+	"mempool_stats":         rpc.NewRPCFunc(MempoolStats, ""),
 
 	// tx broadcast API
-	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx"),
-	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx"),
-	"broadcast_tx_async":  rpc.NewRPCFunc(BroadcastTxAsync, "tx"),
+	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx,idempotency_key,prove"),
+	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx,idempotency_key"),
+	"broadcast_tx_async":  rpc.NewRPCFunc(BroadcastTxAsync, "tx,idempotency_key"),
 
 	// abci API
 	"abci_query": rpc.NewRPCFunc(ABCIQuery, "path,data,height,prove"),
@@ -51,5 +61,7 @@ func AddUnsafeRoutes() {
 	// control API
 	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
 	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private")
+	Routes["set_peer_lists"] = rpc.NewRPCFunc(UnsafeSetPeerLists, "allowed_peers,denied_peers")
+	Routes["set_peer_tx_relay"] = rpc.NewRPCFunc(UnsafeSetPeerTxRelay, "peer,enable")
 	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
 }
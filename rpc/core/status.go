@@ -69,6 +69,9 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 			PubKey:      env.PubKey,
 			VotingPower: votingPower,
 		},
+		MempoolInfo: ctypes.MempoolInfo{
+			MinGasPrice: env.Mempool.MinGasPrice(),
+		},
 	}
 
 	return result, nil
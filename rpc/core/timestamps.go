@@ -0,0 +1,133 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	cm "github.com/tendermint/tendermint/consensus"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// maxTimestampDriftHeights is the max number of heights the drift statistics
+// are computed over.
+const maxTimestampDriftHeights = 10000
+
+// TimestampDrift returns the drift statistics of the timestamps of the blocks
+// between minHeight and maxHeight (inclusive) recorded by the timestamp audit
+// (see consensus.timestamp_audit_heights): the difference between the local
+// time at which the blocks were received and the block times, and by
+// proposer, between the timestamps of their proposals and the local receive
+// time and the block times. The validators whose drift stands out have a
+// broken clock. By default, the last 10000 heights are covered, at most.
+// More: https://docs.tendermint.com/master/rpc/#/Info/timestamp_drift
+func TimestampDrift(ctx *rpctypes.Context, minHeightPtr, maxHeightPtr *int64) (*ctypes.ResultTimestampDrift, error) {
+	if env.TimestampAudit == nil {
+		return nil, errors.New("the timestamp audit is disabled (see consensus.timestamp_audit_heights)")
+	}
+	maxHeight, err := env.TimestampAudit.LastHeight()
+	if err != nil {
+		return nil, err
+	}
+	if maxHeight == 0 {
+		return nil, errors.New("no block timestamps recorded yet")
+	}
+	if maxHeightPtr != nil && *maxHeightPtr < maxHeight {
+		maxHeight = *maxHeightPtr
+	}
+	minHeight := maxHeight - maxTimestampDriftHeights + 1
+	if minHeightPtr != nil && *minHeightPtr > minHeight {
+		minHeight = *minHeightPtr
+	}
+	if minHeight < 1 {
+		minHeight = 1
+	}
+	if minHeight > maxHeight {
+		return nil, fmt.Errorf("min height %d can't be greater than max height %d", minHeight, maxHeight)
+	}
+
+	recs, err := env.TimestampAudit.Range(minHeight, maxHeight)
+	if err != nil {
+		return nil, err
+	}
+	return timestampDrift(minHeight, maxHeight, recs), nil
+}
+
+func timestampDrift(minHeight, maxHeight int64, recs []cm.TimestampRecord) *ctypes.ResultTimestampDrift {
+	type proposerDrifts struct {
+		blocks                     int
+		receiveDrifts, blockDrifts []time.Duration
+	}
+	var (
+		localDrifts []time.Duration
+		proposers   = make(map[string]*proposerDrifts)
+	)
+	for _, rec := range recs {
+		localDrifts = append(localDrifts, rec.ReceivedTime.Sub(rec.BlockTime))
+		p, ok := proposers[string(rec.Proposer)]
+		if !ok {
+			p = &proposerDrifts{}
+			proposers[string(rec.Proposer)] = p
+		}
+		p.blocks++
+		if !rec.ProposalTime.IsZero() {
+			p.receiveDrifts = append(p.receiveDrifts, rec.ProposalTime.Sub(rec.ReceivedTime))
+			p.blockDrifts = append(p.blockDrifts, rec.ProposalTime.Sub(rec.BlockTime))
+		}
+	}
+
+	result := &ctypes.ResultTimestampDrift{
+		MinHeight:  minHeight,
+		MaxHeight:  maxHeight,
+		Blocks:     len(recs),
+		LocalDrift: timestampDriftStats(localDrifts),
+		Proposers:  make([]ctypes.ProposerTimestampDrift, 0, len(proposers)),
+	}
+	for address, p := range proposers {
+		result.Proposers = append(result.Proposers, ctypes.ProposerTimestampDrift{
+			Address:        []byte(address),
+			Blocks:         p.blocks,
+			ReceiveDrift:   timestampDriftStats(p.receiveDrifts),
+			BlockTimeDrift: timestampDriftStats(p.blockDrifts),
+		})
+	}
+	abs := func(d time.Duration) time.Duration {
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+	sort.Slice(result.Proposers, func(i, j int) bool {
+		di, dj := abs(result.Proposers[i].ReceiveDrift.Median), abs(result.Proposers[j].ReceiveDrift.Median)
+		if di != dj {
+			return di > dj
+		}
+		return bytes.Compare(result.Proposers[i].Address, result.Proposers[j].Address) < 0
+	})
+	return result
+}
+
+func timestampDriftStats(drifts []time.Duration) ctypes.TimestampDriftStats {
+	if len(drifts) == 0 {
+		return ctypes.TimestampDriftStats{}
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i] < drifts[j] })
+	var sum time.Duration
+	for _, d := range drifts {
+		sum += d
+	}
+	median := drifts[len(drifts)/2]
+	if len(drifts)%2 == 0 {
+		median = (drifts[len(drifts)/2-1] + median) / 2
+	}
+	return ctypes.TimestampDriftStats{
+		Count:  len(drifts),
+		Mean:   sum / time.Duration(len(drifts)),
+		Median: median,
+		Min:    drifts[0],
+		Max:    drifts[len(drifts)-1],
+	}
+}
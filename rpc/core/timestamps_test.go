@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	cm "github.com/tendermint/tendermint/consensus"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTimestampDrift(t *testing.T) {
+	env = &Environment{}
+	_, err := TimestampDrift(&rpctypes.Context{}, nil, nil)
+	assert.Error(t, err, "disabled")
+
+	env.TimestampAudit = cm.NewTimestampAuditStore(dbm.NewMemDB())
+	_, err = TimestampDrift(&rpctypes.Context{}, nil, nil)
+	assert.Error(t, err, "no record")
+
+	// the clock of the second proposer is 2s ahead
+	var (
+		proposers = []types.Address{{0x01}, {0x02}}
+		start     = time.Now().UTC()
+	)
+	for h := int64(1); h <= 4; h++ {
+		blockTime := start.Add(time.Duration(h) * time.Second)
+		rec := cm.TimestampRecord{
+			Height:       h,
+			Proposer:     proposers[h%2],
+			ProposalTime: blockTime.Add(500 * time.Millisecond),
+			ReceivedTime: blockTime.Add(time.Duration(500+h) * time.Millisecond),
+			BlockTime:    blockTime,
+		}
+		if h%2 == 0 {
+			rec.ProposalTime = rec.ProposalTime.Add(2 * time.Second)
+		}
+		if h == 4 {
+			rec.ProposalTime = time.Time{}
+		}
+		require.NoError(t, env.TimestampAudit.Save(rec))
+	}
+
+	result, err := TimestampDrift(&rpctypes.Context{}, nil, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, result.MinHeight)
+	assert.EqualValues(t, 4, result.MaxHeight)
+	assert.Equal(t, 4, result.Blocks)
+	assert.Equal(t, ctypes.TimestampDriftStats{
+		Count:  4,
+		Mean:   502500 * time.Microsecond,
+		Median: 502500 * time.Microsecond,
+		Min:    501 * time.Millisecond,
+		Max:    504 * time.Millisecond,
+	}, result.LocalDrift)
+	require.Len(t, result.Proposers, 2)
+	ahead := result.Proposers[0]
+	assert.Equal(t, proposers[0], ahead.Address)
+	assert.Equal(t, 2, ahead.Blocks)
+	assert.Equal(t, ctypes.TimestampDriftStats{
+		Count:  1,
+		Mean:   1998 * time.Millisecond,
+		Median: 1998 * time.Millisecond,
+		Min:    1998 * time.Millisecond,
+		Max:    1998 * time.Millisecond,
+	}, ahead.ReceiveDrift)
+	assert.Equal(t, 2500*time.Millisecond, ahead.BlockTimeDrift.Median)
+	assert.Equal(t, proposers[1], result.Proposers[1].Address)
+	assert.Equal(t, -2*time.Millisecond, result.Proposers[1].ReceiveDrift.Median)
+
+	minHeight, maxHeight := int64(2), int64(3)
+	result, err = TimestampDrift(&rpctypes.Context{}, &minHeight, &maxHeight)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Blocks)
+	_, err = TimestampDrift(&rpctypes.Context{}, &maxHeight, &minHeight)
+	assert.Error(t, err)
+}
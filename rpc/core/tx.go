@@ -1,14 +1,17 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/null"
 	"github.com/tendermint/tendermint/types"
 )
@@ -37,8 +40,9 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 
 	var proof types.TxProof
 	if prove {
-		block := env.BlockStore.LoadBlock(height)
-		proof = block.Data.Txs.Proof(int(index)) // XXX: overflow on 32-bit machines
+		if proof, err = txProof(height, index); err != nil {
+			return nil, err
+		}
 	}
 
 	return &ctypes.ResultTx{
@@ -52,20 +56,38 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 }
 
 // TxSearch allows you to query for multiple transactions results. It returns a
-// list of transactions (maximum ?per_page entries) and the total count.
+// list of transactions (maximum ?per_page entries) and the total count. The
+// transactions are sorted by height and index ("asc", the default, or
+// "desc"), or by "relevance": the number of event attributes matching the
+// query, the most relevant first. If countOnly is true, only the total count
+// is returned, without loading the transactions.
 // More: https://docs.tendermint.com/master/rpc/#/Info/tx_search
-func TxSearch(ctx *rpctypes.Context, query string, prove bool, pagePtr, perPagePtr *int, orderBy string) (
-	*ctypes.ResultTxSearch, error) {
+func TxSearch(ctx *rpctypes.Context, query string, prove bool, pagePtr, perPagePtr *int, orderBy string,
+	countOnly bool) (*ctypes.ResultTxSearch, error) {
 	// if index is disabled, return error
 	if _, ok := env.TxIndexer.(*null.TxIndex); ok {
 		return nil, errors.New("transaction indexing is disabled")
 	}
 
+	switch orderBy {
+	case "asc", "desc", "relevance", "":
+	default:
+		return nil, errors.New("expected order_by to be either `asc`, `desc`, `relevance` or empty")
+	}
+
 	q, err := tmquery.New(query)
 	if err != nil {
 		return nil, err
 	}
 
+	if countOnly {
+		totalCount, err := countTxs(ctx.Context(), q)
+		if err != nil {
+			return nil, err
+		}
+		return &ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{}, TotalCount: totalCount}, nil
+	}
+
 	results, err := env.TxIndexer.Search(ctx.Context(), q)
 	if err != nil {
 		return nil, err
@@ -87,8 +109,28 @@ func TxSearch(ctx *rpctypes.Context, query string, prove bool, pagePtr, perPageP
 			}
 			return results[i].Height < results[j].Height
 		})
-	default:
-		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
+	case "relevance":
+		conditions, err := q.Conditions()
+		if err != nil {
+			return nil, err
+		}
+		relevances := make(map[*abci.TxResult]int, len(results))
+		for _, r := range results {
+			if relevances[r], err = txRelevance(conditions, r); err != nil {
+				return nil, err
+			}
+		}
+		sort.Slice(results, func(i, j int) bool {
+			ri, rj := relevances[results[i]], relevances[results[j]]
+			switch {
+			case ri != rj:
+				return ri > rj
+			case results[i].Height != results[j].Height:
+				return results[i].Height < results[j].Height
+			default:
+				return results[i].Index < results[j].Index
+			}
+		})
 	}
 
 	// paginate results
@@ -107,12 +149,13 @@ func TxSearch(ctx *rpctypes.Context, query string, prove bool, pagePtr, perPageP
 
 		var proof types.TxProof
 		if prove {
-			block := env.BlockStore.LoadBlock(r.Height)
-			proof = block.Data.Txs.Proof(int(r.Index)) // XXX: overflow on 32-bit machines
+			if proof, err = txProof(r.Height, r.Index); err != nil {
+				return nil, err
+			}
 		}
 
 		apiResults = append(apiResults, &ctypes.ResultTx{
-			Hash:     types.Tx(r.Tx).Hash(),
+			Hash:     TxHash(r.Tx),
 			Height:   r.Height,
 			Index:    r.Index,
 			TxResult: r.Result,
@@ -123,3 +166,51 @@ func TxSearch(ctx *rpctypes.Context, query string, prove bool, pagePtr, perPageP
 
 	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
 }
+
+// countTxs returns the number of txs matching the query, searching them if the
+// indexer isn't a txindex.TxCounter.
+func countTxs(ctx context.Context, q *tmquery.Query) (int, error) {
+	if counter, ok := env.TxIndexer.(txindex.TxCounter); ok {
+		return counter.Count(ctx, q)
+	}
+	results, err := env.TxIndexer.Search(ctx, q)
+	return len(results), err
+}
+
+// txProof returns the Merkle inclusion proof of the tx at the given index in
+// the block at the given height, against the DataHash of the block header. It
+// errors if the block is not stored anymore, e.g. if it was pruned.
+func txProof(height int64, index uint32) (types.TxProof, error) {
+	block := env.BlockStore.LoadBlock(height)
+	if block == nil {
+		return types.TxProof{}, fmt.Errorf("can't prove the tx: block at height %d is not available", height)
+	}
+	if int64(index) >= int64(len(block.Data.Txs)) {
+		return types.TxProof{}, fmt.Errorf("can't prove the tx: index %d out of range in block %d with %d txs",
+			index, height, len(block.Data.Txs))
+	}
+	return block.Data.Txs.Proof(int(index)), nil
+}
+
+// txRelevance returns the number of the event attributes of the tx matching
+// the conditions.
+func txRelevance(conditions []tmquery.Condition, r *abci.TxResult) (int, error) {
+	events := make(map[string][]string)
+	for _, event := range r.Result.Events {
+		for _, attr := range event.Attributes {
+			compositeKey := fmt.Sprintf("%s.%s", event.Type, attr.Key)
+			events[compositeKey] = append(events[compositeKey], string(attr.Value))
+		}
+	}
+	events[types.TxHeightKey] = []string{fmt.Sprintf("%d", r.Height)}
+
+	relevance := 0
+	for _, c := range conditions {
+		n, err := c.CountMatches(events)
+		if err != nil {
+			return 0, err
+		}
+		relevance += n
+	}
+	return relevance, nil
+}
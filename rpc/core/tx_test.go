@@ -0,0 +1,99 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxSearchOrderAndCount(t *testing.T) {
+	indexer := kv.NewTxIndex(dbm.NewMemDB())
+	env = &Environment{TxIndexer: indexer}
+
+	// the number of transfers to alice increases with the height, except for
+	// the last tx
+	recipients := [][]string{{"alice"}, {"alice", "bob", "alice"}, {"alice", "alice"}, {"bob"}}
+	for i, rs := range recipients {
+		var attrs []abci.EventAttribute
+		for _, r := range rs {
+			attrs = append(attrs, abci.EventAttribute{Key: []byte("recipient"), Value: []byte(r), Index: true})
+		}
+		require.NoError(t, indexer.Index(&abci.TxResult{
+			Height: int64(i + 1),
+			Tx:     []byte(fmt.Sprintf("tx%d", i)),
+			Result: abci.ResponseDeliverTx{Events: []abci.Event{{Type: "transfer", Attributes: attrs}}},
+		}))
+	}
+
+	heights := func(result *ctypes.ResultTxSearch) []int64 {
+		hs := make([]int64, 0, len(result.Txs))
+		for _, tx := range result.Txs {
+			hs = append(hs, tx.Height)
+		}
+		return hs
+	}
+
+	result, err := TxSearch(&rpctypes.Context{}, "transfer.recipient = 'alice'", false, nil, nil, "desc", false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 2, 1}, heights(result))
+
+	result, err = TxSearch(&rpctypes.Context{}, "transfer.recipient = 'alice'", false, nil, nil, "relevance", false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3, 1}, heights(result))
+	assert.Equal(t, 3, result.TotalCount)
+
+	result, err = TxSearch(&rpctypes.Context{}, "transfer.recipient EXISTS", false, nil, nil, "", true)
+	require.NoError(t, err)
+	assert.Empty(t, result.Txs)
+	assert.Equal(t, 4, result.TotalCount)
+
+	_, err = TxSearch(&rpctypes.Context{}, "transfer.recipient EXISTS", false, nil, nil, "height", false)
+	assert.Error(t, err)
+}
+
+type txBlockStore struct {
+	mockBlockStore
+	blocks map[int64]*types.Block
+}
+
+func (store txBlockStore) LoadBlock(height int64) *types.Block { return store.blocks[height] }
+
+func TestTxProve(t *testing.T) {
+	txs := types.Txs{types.Tx("tx0"), types.Tx("tx1")}
+	block := types.MakeBlock(2, txs, nil, nil)
+	indexer := kv.NewTxIndex(dbm.NewMemDB())
+	env = &Environment{
+		TxIndexer:  indexer,
+		BlockStore: txBlockStore{mockBlockStore: mockBlockStore{height: 2}, blocks: map[int64]*types.Block{2: block}},
+	}
+	require.NoError(t, indexer.Index(&abci.TxResult{Height: 1, Index: 0, Tx: types.Tx("pruned")}))
+	require.NoError(t, indexer.Index(&abci.TxResult{Height: 2, Index: 1, Tx: txs[1]}))
+
+	res, err := Tx(&rpctypes.Context{}, txs[1].Hash(), true)
+	require.NoError(t, err)
+	assert.EqualValues(t, txs[1], res.Proof.Data)
+	assert.NoError(t, res.Proof.Validate(block.Data.Hash()))
+
+	result, err := TxSearch(&rpctypes.Context{}, "tx.height = 2", true, nil, nil, "", false)
+	require.NoError(t, err)
+	require.Len(t, result.Txs, 1)
+	assert.NoError(t, result.Txs[0].Proof.Validate(block.Data.Hash()))
+
+	// the block of the tx is not available anymore
+	_, err = Tx(&rpctypes.Context{}, types.Tx("pruned").Hash(), true)
+	assert.Error(t, err)
+	_, err = TxSearch(&rpctypes.Context{}, "tx.height = 1", true, nil, nil, "", false)
+	assert.Error(t, err)
+	res, err = Tx(&rpctypes.Context{}, types.Tx("pruned").Hash(), false)
+	require.NoError(t, err)
+	assert.Empty(t, res.Proof.Data)
+}
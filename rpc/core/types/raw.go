@@ -0,0 +1,71 @@
+package coretypes
+
+import (
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// CompressionZstd is the compression of a ResultBlockRaw compressed with
+// zstd.
+const CompressionZstd = "zstd"
+
+// The formats of a ResultDumpConsensusState.
+const (
+	DumpFormatJSON  = "json"
+	DumpFormatProto = "proto"
+)
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// CompressZstd compresses bz with zstd.
+func CompressZstd(bz []byte) []byte {
+	return zstdEncoder.EncodeAll(bz, nil)
+}
+
+// DecodeBlock decompresses and decodes the block.
+func (r *ResultBlockRaw) DecodeBlock() (*types.Block, error) {
+	bz := r.Block
+	switch r.Compression {
+	case "":
+	case CompressionZstd:
+		var err error
+		bz, err = zstdDecoder.DecodeAll(r.Block, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress block: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown compression %q", r.Compression)
+	}
+
+	pb := new(tmproto.Block)
+	if err := pb.Unmarshal(bz); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block: %w", err)
+	}
+	return types.BlockFromProto(pb)
+}
+
+// DecodePart decodes the block part.
+func (r *ResultBlockPart) DecodePart() (*types.Part, error) {
+	pb := new(tmproto.Part)
+	if err := pb.Unmarshal(r.Part); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block part: %w", err)
+	}
+	return types.PartFromProto(pb)
+}
+
+// DecodeDump decodes the consensus state dump of the DumpFormatProto format.
+func (r *ResultDumpConsensusState) DecodeDump() (*tmcons.ConsensusStateDump, error) {
+	pb := new(tmcons.ConsensusStateDump)
+	if err := pb.Unmarshal(r.Dump); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal consensus state dump: %w", err)
+	}
+	return pb, nil
+}
@@ -7,6 +7,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/operator"
 	"github.com/tendermint/tendermint/p2p"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
@@ -29,10 +30,25 @@ type ResultBlock struct {
 	Block   *types.Block  `json:"block"`
 }
 
+// Single protobuf-encoded block (with meta), see DecodeBlock.
+type ResultBlockRaw struct {
+	BlockID     types.BlockID `json:"block_id"`
+	Block       []byte        `json:"block"`
+	Compression string        `json:"compression,omitempty"`
+}
+
+// Single protobuf-encoded block part (with meta), see DecodePart.
+type ResultBlockPart struct {
+	BlockID types.BlockID `json:"block_id"`
+	Part    []byte        `json:"part"`
+}
+
 // Commit and Header
 type ResultCommit struct {
 	types.SignedHeader `json:"signed_header"`
 	CanonicalCommit    bool `json:"canonical"`
+	// canonical bytes signed by each signature, if requested
+	SignBytes [][]byte `json:"sign_bytes,omitempty"`
 }
 
 // ABCI results from a block
@@ -43,6 +59,11 @@ type ResultBlockResults struct {
 	EndBlockEvents        []abci.Event              `json:"end_block_events"`
 	ValidatorUpdates      []abci.ValidatorUpdate    `json:"validator_updates"`
 	ConsensusParamUpdates *abci.ConsensusParams     `json:"consensus_param_updates"`
+	// Pruned is true if the results were pruned, in which case only the
+	// retained events and updates are returned, see the pruning_exemptions
+	// config option. The events of the txs are then in RetainedTxsEvents.
+	Pruned            bool         `json:"pruned,omitempty"`
+	RetainedTxsEvents []abci.Event `json:"retained_txs_events,omitempty"`
 }
 
 // NewResultCommit is a helper to initialize the ResultCommit with
@@ -81,11 +102,17 @@ type ValidatorInfo struct {
 	VotingPower int64          `json:"voting_power"`
 }
 
+// Info about the node's mempool
+type MempoolInfo struct {
+	MinGasPrice int64 `json:"min_gas_price"`
+}
+
 // Node Status
 type ResultStatus struct {
 	NodeInfo      p2p.DefaultNodeInfo `json:"node_info"`
 	SyncInfo      SyncInfo            `json:"sync_info"`
 	ValidatorInfo ValidatorInfo       `json:"validator_info"`
+	MempoolInfo   MempoolInfo         `json:"mempool_info"`
 }
 
 // Is TxIndexing enabled
@@ -114,12 +141,30 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// Node IDs allowed and denied to connect
+type ResultPeerLists struct {
+	AllowedPeers []string `json:"allowed_peers"`
+	DeniedPeers  []string `json:"denied_peers"`
+}
+
+// Tx relay setting of a peer
+type ResultPeerTxRelay struct {
+	PeerID  p2p.ID `json:"peer_id"`
+	Enabled bool   `json:"enabled"`
+}
+
 // A peer
 type Peer struct {
 	NodeInfo         p2p.DefaultNodeInfo  `json:"node_info"`
 	IsOutbound       bool                 `json:"is_outbound"`
 	ConnectionStatus p2p.ConnectionStatus `json:"connection_status"`
 	RemoteIP         string               `json:"remote_ip"`
+	// Historical stats of the peer, including the current connection, if
+	// it's in the address book.
+	Stats *p2p.PeerStats `json:"stats,omitempty"`
+	// Fraction (0 to 1) of the time since we first connected to the peer
+	// during which we've been connected to it.
+	Uptime float64 `json:"uptime,omitempty"`
 }
 
 // Validators for a height.
@@ -138,11 +183,26 @@ type ResultConsensusParams struct {
 	ConsensusParams tmproto.ConsensusParams `json:"consensus_params"`
 }
 
+// Simulation of consensus param updates
+type ResultSimulateParamChange struct {
+	// Height from which the updates would apply, if returned by the next block.
+	Height int64 `json:"height"`
+	// Consensus params after the updates.
+	ConsensusParams tmproto.ConsensusParams      `json:"consensus_params"`
+	Changes         []types.ConsensusParamChange `json:"changes"`
+	Valid           bool                         `json:"valid"`
+	Error           string                       `json:"error,omitempty"`
+	Warnings        []string                     `json:"warnings"`
+}
+
 // Info about the consensus state.
 // UNSTABLE
 type ResultDumpConsensusState struct {
 	RoundState json.RawMessage `json:"round_state"`
 	Peers      []PeerStateInfo `json:"peers"`
+	// The protobuf-encoded tendermint.consensus.ConsensusStateDump, set instead
+	// of the above in the DumpFormatProto format.
+	Dump []byte `json:"dump,omitempty"`
 }
 
 // UNSTABLE
@@ -172,6 +232,8 @@ type ResultBroadcastTxCommit struct {
 	DeliverTx abci.ResponseDeliverTx `json:"deliver_tx"`
 	Hash      bytes.HexBytes         `json:"hash"`
 	Height    int64                  `json:"height"`
+	// Inclusion proof of the tx, only set if requested with prove.
+	Proof *types.TxProof `json:"proof,omitempty"`
 }
 
 // ResultCheckTx wraps abci.ResponseCheckTx.
@@ -195,6 +257,12 @@ type ResultTxSearch struct {
 	TotalCount int         `json:"total_count"`
 }
 
+// Result of the historical events search
+type ResultEvents struct {
+	Events     []*ResultEvent `json:"events"`
+	TotalCount int            `json:"total_count"`
+}
+
 // List of mempool txs
 type ResultUnconfirmedTxs struct {
 	Count      int        `json:"n_txs"`
@@ -203,6 +271,83 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// Histogram of the values of the txs in the mempool. Counts[i] is the number
+// of values in (Bounds[i-1], Bounds[i]], and the last count is the number of
+// values above the last bound.
+type MempoolHistogram struct {
+	Bounds []int64 `json:"bounds"`
+	Counts []int   `json:"counts"`
+}
+
+// Counters of the txs evicted from the mempool, or rejected by it, since the
+// node started
+type MempoolEvictions struct {
+	Full        int64 `json:"full"`
+	Invalidated int64 `json:"invalidated"`
+	Removed     int64 `json:"removed"`
+	Flushed     int64 `json:"flushed"`
+}
+
+// Mempool stats
+type ResultMempoolStats struct {
+	Count          int              `json:"n_txs"`
+	MaxCount       int              `json:"max_txs"`
+	TotalBytes     int64            `json:"total_bytes"`
+	MaxTotalBytes  int64            `json:"max_txs_bytes"`
+	TotalGasWanted int64            `json:"total_gas_wanted"`
+	TxSizes        MempoolHistogram `json:"tx_sizes"`
+	GasWanted      MempoolHistogram `json:"gas_wanted"`
+	TxAges         MempoolHistogram `json:"tx_ages"`
+	OldestTxAge    time.Duration    `json:"oldest_tx_age"`
+	Evictions      MempoolEvictions `json:"evictions"`
+
+	Categories []types.MempoolCategory `json:"categories,omitempty"`
+}
+
+// Operator info of the validators
+type ResultValidatorInfo struct {
+	Infos []*operator.Info `json:"infos"`
+}
+
+// Operator info of the validators attesting a node as their sentry
+type ResultNodeAttestation struct {
+	NodeID p2p.ID           `json:"node_id"`
+	Infos  []*operator.Info `json:"infos"`
+}
+
+// Drift statistics of the timestamps of the blocks recorded by the timestamp
+// audit
+type ResultTimestampDrift struct {
+	MinHeight int64 `json:"min_height"`
+	MaxHeight int64 `json:"max_height"`
+	Blocks    int   `json:"blocks"`
+	// Local receive time - block time of the blocks
+	LocalDrift TimestampDriftStats `json:"local_drift"`
+	// Sorted by decreasing absolute median receive drift
+	Proposers []ProposerTimestampDrift `json:"proposers"`
+}
+
+// Statistics of the differences between two timestamps of the blocks
+type TimestampDriftStats struct {
+	Count  int           `json:"count"`
+	Mean   time.Duration `json:"mean"`
+	Median time.Duration `json:"median"`
+	Min    time.Duration `json:"min"`
+	Max    time.Duration `json:"max"`
+}
+
+// Drift of the timestamps of the proposals of a validator. The blocks
+// completed before their proposal was received are only counted in Blocks.
+type ProposerTimestampDrift struct {
+	Address types.Address `json:"address"`
+	Blocks  int           `json:"blocks"`
+	// Proposal timestamp - local receive time: how far the clock of the
+	// proposer is ahead of the local one, minus the propagation delay
+	ReceiveDrift TimestampDriftStats `json:"receive_drift"`
+	// Proposal timestamp - block time
+	BlockTimeDrift TimestampDriftStats `json:"block_time_drift"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -2,12 +2,21 @@ package coregrpc
 
 import (
 	"context"
+	"io"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	core "github.com/tendermint/tendermint/rpc/core"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
+// healthCheckInterval is how often the status reported by the health service
+// is updated.
+const healthCheckInterval = time.Second
+
 type broadcastAPI struct {
 }
 
@@ -19,7 +28,7 @@ func (bapi *broadcastAPI) Ping(ctx context.Context, req *RequestPing) (*Response
 func (bapi *broadcastAPI) BroadcastTx(ctx context.Context, req *RequestBroadcastTx) (*ResponseBroadcastTx, error) {
 	// NOTE: there's no way to get client's remote address
 	// see https://stackoverflow.com/questions/33684570/session-and-remote-ip-address-in-grpc-go
-	res, err := core.BroadcastTxCommit(&rpctypes.Context{}, req.Tx)
+	res, err := core.BroadcastTxCommit(&rpctypes.Context{}, req.Tx, "", false)
 	if err != nil {
 		return nil, err
 	}
@@ -37,3 +46,56 @@ func (bapi *broadcastAPI) BroadcastTx(ctx context.Context, req *RequestBroadcast
 		},
 	}, nil
 }
+
+func (bapi *broadcastAPI) BroadcastStream(stream BroadcastAPI_BroadcastStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		res := &ResponseBroadcastStream{Hash: core.TxHash(req.Tx)}
+		r, err := core.BroadcastTxSync(&rpctypes.Context{}, req.Tx, "")
+		if err != nil {
+			res.Error = err.Error()
+		} else {
+			res.CheckTx = &abci.ResponseCheckTx{
+				Code:      r.Code,
+				Data:      r.Data,
+				Log:       r.Log,
+				Codespace: r.Codespace,
+			}
+		}
+
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+// healthRoutine periodically sets the status of both the server and the
+// BroadcastAPI in the health service: SERVING, unless the node is catching up.
+// It shuts down the health service and returns once quit is closed.
+func healthRoutine(hs *health.Server, quit <-chan struct{}) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		if res, err := core.Status(&rpctypes.Context{}); err != nil || res.SyncInfo.CatchingUp {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		hs.SetServingStatus("", status)
+		hs.SetServingStatus(_BroadcastAPI_serviceDesc.ServiceName, status)
+
+		select {
+		case <-quit:
+			hs.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
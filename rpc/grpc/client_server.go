@@ -3,8 +3,13 @@ package coregrpc
 import (
 	"context"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 
 	tmnet "github.com/tendermint/tendermint/libs/net"
 )
@@ -12,15 +17,96 @@ import (
 // Config is an gRPC server configuration.
 type Config struct {
 	MaxOpenConnections int
+
+	// Keepalive parameters, see keepalive.ServerParameters and
+	// keepalive.EnforcementPolicy.
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	KeepaliveMinTime time.Duration
+
+	// Register the server reflection service.
+	Reflection bool
+}
+
+// DefaultConfig returns the default gRPC server configuration.
+func DefaultConfig() Config {
+	return Config{
+		MaxOpenConnections: 900,
+		KeepaliveTime:      2 * time.Hour,
+		KeepaliveTimeout:   20 * time.Second,
+		KeepaliveMinTime:   5 * time.Minute,
+		Reflection:         false,
+	}
 }
 
 // StartGRPCServer starts a new gRPC BroadcastAPIServer using the given
-// net.Listener.
+// net.Listener and the default configuration.
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func StartGRPCServer(ln net.Listener) error {
-	grpcServer := grpc.NewServer()
+	return StartGRPCServerWithConfig(ln, DefaultConfig())
+}
+
+// StartGRPCServerWithConfig starts a new gRPC BroadcastAPIServer using the
+// given net.Listener and configuration, see NewServer.
+// NOTE: This function blocks - you may want to call it in a go-routine.
+func StartGRPCServerWithConfig(ln net.Listener, config Config) error {
+	return NewServer(config).Serve(ln)
+}
+
+// Server is a gRPC server exposing the BroadcastAPI, the standard health
+// service (reporting NOT_SERVING while the node is catching up, so client-side
+// load balancers can skip it) and, if enabled, the reflection service.
+type Server struct {
+	grpcServer   *grpc.Server
+	healthServer *health.Server
+}
+
+// NewServer returns a new gRPC server with the given configuration.
+func NewServer(config Config) *Server {
+	grpcServer := grpc.NewServer(
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    config.KeepaliveTime,
+			Timeout: config.KeepaliveTimeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             config.KeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+	)
 	RegisterBroadcastAPIServer(grpcServer, &broadcastAPI{})
-	return grpcServer.Serve(ln)
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	if config.Reflection {
+		reflection.Register(grpcServer)
+	}
+
+	return &Server{grpcServer: grpcServer, healthServer: healthServer}
+}
+
+// Serve accepts the connections on the given listener until it's closed or the
+// server is stopped. It returns once the health service is shut down, so that
+// nothing is served after the node stops.
+// NOTE: This function blocks - you may want to call it in a go-routine.
+func (s *Server) Serve(ln net.Listener) error {
+	quit, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		healthRoutine(s.healthServer, quit)
+	}()
+	defer func() {
+		close(quit)
+		<-done
+	}()
+
+	return s.grpcServer.Serve(ln)
+}
+
+// Stop closes the listeners and the connections of the server, and cancels
+// the pending RPCs.
+func (s *Server) Stop() {
+	s.grpcServer.Stop()
 }
 
 // StartGRPCClient dials the gRPC server using protoAddr and returns a new
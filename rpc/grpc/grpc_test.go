@@ -2,12 +2,18 @@ package coregrpc_test
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
+	tmnet "github.com/tendermint/tendermint/libs/net"
 	core_grpc "github.com/tendermint/tendermint/rpc/grpc"
 	rpctest "github.com/tendermint/tendermint/rpc/test"
 )
@@ -33,3 +39,48 @@ func TestBroadcastTx(t *testing.T) {
 	require.EqualValues(t, 0, res.CheckTx.Code)
 	require.EqualValues(t, 0, res.DeliverTx.Code)
 }
+
+func TestBroadcastStream(t *testing.T) {
+	stream, err := rpctest.GetGRPCClient().BroadcastStream(context.Background())
+	require.NoError(t, err)
+
+	const n = 5
+	for i := 0; i < n; i++ {
+		err := stream.Send(&core_grpc.RequestBroadcastTx{Tx: []byte(fmt.Sprintf("stream-%d", i))})
+		require.NoError(t, err)
+	}
+	// resending a tx fails, as it's already in the cache
+	require.NoError(t, stream.Send(&core_grpc.RequestBroadcastTx{Tx: []byte("stream-0")}))
+	require.NoError(t, stream.CloseSend())
+
+	for i := 0; i < n; i++ {
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		require.Empty(t, res.Error)
+		require.EqualValues(t, 0, res.CheckTx.Code)
+		require.NotEmpty(t, res.Hash)
+	}
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Error)
+	require.Nil(t, res.CheckTx)
+
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestHealth(t *testing.T) {
+	conn, err := grpc.Dial(rpctest.GetConfig().RPC.GRPCListenAddress,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return tmnet.Connect(addr)
+		}),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	res, err := healthpb.NewHealthClient(conn).Check(context.Background(),
+		&healthpb.HealthCheckRequest{Service: "tendermint.rpc.grpc.BroadcastAPI"})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+}
@@ -195,37 +195,105 @@ func (m *ResponseBroadcastTx) GetDeliverTx() *types.ResponseDeliverTx {
 	return nil
 }
 
+// ResponseBroadcastStream is the result of checking one of the txs sent to
+// BroadcastStream. Error is set if the tx could not be checked (e.g. it's
+// already in the mempool's cache).
+type ResponseBroadcastStream struct {
+	Hash    []byte                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	CheckTx *types.ResponseCheckTx `protobuf:"bytes,2,opt,name=check_tx,json=checkTx,proto3" json:"check_tx,omitempty"`
+	Error   string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *ResponseBroadcastStream) Reset()         { *m = ResponseBroadcastStream{} }
+func (m *ResponseBroadcastStream) String() string { return proto.CompactTextString(m) }
+func (*ResponseBroadcastStream) ProtoMessage()    {}
+func (*ResponseBroadcastStream) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{4}
+}
+func (m *ResponseBroadcastStream) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseBroadcastStream) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseBroadcastStream.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseBroadcastStream) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseBroadcastStream.Merge(m, src)
+}
+func (m *ResponseBroadcastStream) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseBroadcastStream) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseBroadcastStream.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseBroadcastStream proto.InternalMessageInfo
+
+func (m *ResponseBroadcastStream) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *ResponseBroadcastStream) GetCheckTx() *types.ResponseCheckTx {
+	if m != nil {
+		return m.CheckTx
+	}
+	return nil
+}
+
+func (m *ResponseBroadcastStream) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*RequestPing)(nil), "tendermint.rpc.grpc.RequestPing")
 	proto.RegisterType((*RequestBroadcastTx)(nil), "tendermint.rpc.grpc.RequestBroadcastTx")
 	proto.RegisterType((*ResponsePing)(nil), "tendermint.rpc.grpc.ResponsePing")
 	proto.RegisterType((*ResponseBroadcastTx)(nil), "tendermint.rpc.grpc.ResponseBroadcastTx")
+	proto.RegisterType((*ResponseBroadcastStream)(nil), "tendermint.rpc.grpc.ResponseBroadcastStream")
 }
 
 func init() { proto.RegisterFile("tendermint/rpc/grpc/types.proto", fileDescriptor_0ffff5682c662b95) }
 
 var fileDescriptor_0ffff5682c662b95 = []byte{
-	// 316 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x2f, 0x49, 0xcd, 0x4b,
-	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x2a, 0x48, 0xd6, 0x4f, 0x07, 0x11, 0x25, 0x95,
-	0x05, 0xa9, 0xc5, 0x7a, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0xc2, 0x08, 0x05, 0x7a, 0x45, 0x05,
-	0xc9, 0x7a, 0x20, 0x05, 0x52, 0xd2, 0x48, 0xba, 0x12, 0x93, 0x92, 0x33, 0x91, 0x75, 0x28, 0xf1,
-	0x72, 0x71, 0x07, 0xa5, 0x16, 0x96, 0xa6, 0x16, 0x97, 0x04, 0x64, 0xe6, 0xa5, 0x2b, 0xa9, 0x70,
-	0x09, 0x41, 0xb9, 0x4e, 0x45, 0xf9, 0x89, 0x29, 0xc9, 0x89, 0xc5, 0x25, 0x21, 0x15, 0x42, 0x7c,
-	0x5c, 0x4c, 0x25, 0x15, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x3c, 0x41, 0x4c, 0x25, 0x15, 0x4a, 0x7c,
-	0x5c, 0x3c, 0x41, 0xa9, 0xc5, 0x05, 0xf9, 0x79, 0xc5, 0xa9, 0x60, 0x5d, 0x53, 0x19, 0xb9, 0x84,
-	0x61, 0x02, 0xc8, 0xfa, 0xac, 0xb9, 0x38, 0x92, 0x33, 0x52, 0x93, 0xb3, 0xe3, 0xa1, 0xba, 0xb9,
-	0x8d, 0x14, 0xf4, 0x90, 0x5c, 0x08, 0x72, 0x8c, 0x1e, 0x4c, 0x9f, 0x33, 0x48, 0x61, 0x48, 0x45,
-	0x10, 0x7b, 0x32, 0x84, 0x21, 0xe4, 0xc8, 0xc5, 0x95, 0x92, 0x9a, 0x93, 0x59, 0x96, 0x5a, 0x04,
-	0xd2, 0xce, 0x04, 0xd6, 0xae, 0x84, 0x53, 0xbb, 0x0b, 0x44, 0x69, 0x48, 0x45, 0x10, 0x67, 0x0a,
-	0x8c, 0x69, 0xb4, 0x97, 0x91, 0x8b, 0x07, 0xee, 0x1e, 0xc7, 0x00, 0x4f, 0x21, 0x6f, 0x2e, 0x16,
-	0x90, 0x83, 0x85, 0x50, 0x9c, 0x01, 0x0b, 0x28, 0x3d, 0xa4, 0x80, 0x90, 0x52, 0xc4, 0xa1, 0x02,
-	0xe1, 0x6b, 0xa1, 0x04, 0x2e, 0x6e, 0x64, 0xcf, 0xaa, 0xe3, 0x33, 0x13, 0x49, 0xa1, 0x94, 0x06,
-	0x5e, 0xa3, 0x91, 0x54, 0x3a, 0xf9, 0x9c, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83,
-	0x47, 0x72, 0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43,
-	0x94, 0x51, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x92, 0x5e, 0x72, 0x7e, 0xae, 0x3e, 0x52, 0xf4, 0x62,
-	0x49, 0x1f, 0xd6, 0xc9, 0xf9, 0x45, 0xa9, 0x20, 0x46, 0x12, 0x1b, 0x38, 0xc6, 0x8d, 0x01, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0xf6, 0x4b, 0x02, 0xd8, 0x46, 0x02, 0x00, 0x00,
+	// 377 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xc1, 0x4e, 0xf2, 0x40,
+	0x14, 0x85, 0x99, 0xfe, 0xfc, 0x2a, 0x17, 0xc4, 0x64, 0x30, 0x91, 0x60, 0x52, 0x6b, 0x63, 0x62,
+	0x17, 0x66, 0x30, 0xb8, 0x64, 0x05, 0xba, 0x31, 0xba, 0x20, 0x95, 0x95, 0x1b, 0x2d, 0xd3, 0x09,
+	0x6d, 0x84, 0xb6, 0x4e, 0x07, 0x53, 0x77, 0x3e, 0x82, 0x1b, 0x9f, 0xc2, 0x17, 0x71, 0xc9, 0xd2,
+	0xa5, 0x81, 0x17, 0x31, 0x6d, 0x41, 0x46, 0x05, 0x82, 0x9b, 0xe6, 0x4e, 0x73, 0xbe, 0x7b, 0x4f,
+	0xcf, 0xdc, 0xc2, 0x9e, 0x60, 0x9e, 0xcd, 0x78, 0xdf, 0xf5, 0x44, 0x95, 0x07, 0xb4, 0xda, 0x8d,
+	0x1f, 0xe2, 0x31, 0x60, 0x21, 0x09, 0xb8, 0x2f, 0x7c, 0x5c, 0x9a, 0x09, 0x08, 0x0f, 0x28, 0x89,
+	0x05, 0x95, 0x5d, 0x89, 0xb2, 0x3a, 0xd4, 0x95, 0x09, 0x7d, 0x13, 0xf2, 0x26, 0xbb, 0x1f, 0xb0,
+	0x50, 0xb4, 0x5c, 0xaf, 0xab, 0x1f, 0x00, 0x9e, 0x1c, 0x9b, 0xdc, 0xb7, 0x6c, 0x6a, 0x85, 0xa2,
+	0x1d, 0xe1, 0x22, 0x28, 0x22, 0x2a, 0x23, 0x0d, 0x19, 0x05, 0x53, 0x11, 0x91, 0x5e, 0x84, 0x82,
+	0xc9, 0xc2, 0xc0, 0xf7, 0x42, 0x96, 0x50, 0x2f, 0x08, 0x4a, 0xd3, 0x17, 0x32, 0x57, 0x87, 0x0d,
+	0xea, 0x30, 0x7a, 0x77, 0x33, 0xa1, 0xf3, 0x35, 0x8d, 0x48, 0x0e, 0x63, 0x33, 0x64, 0xca, 0x9d,
+	0xc6, 0xc2, 0x76, 0x64, 0xae, 0xd3, 0xb4, 0xc0, 0x0d, 0x00, 0x9b, 0xf5, 0xdc, 0x07, 0xc6, 0x63,
+	0x5c, 0x49, 0x70, 0x7d, 0x21, 0x7e, 0x96, 0x4a, 0xdb, 0x91, 0x99, 0xb3, 0xa7, 0xa5, 0xfe, 0x84,
+	0x60, 0xe7, 0x97, 0xaf, 0x2b, 0xc1, 0x99, 0xd5, 0xc7, 0x18, 0xb2, 0x8e, 0x15, 0x3a, 0x93, 0xaf,
+	0x4a, 0xea, 0x6f, 0x7e, 0x95, 0xbf, 0xfa, 0xdd, 0x86, 0xff, 0x8c, 0x73, 0x9f, 0x97, 0xff, 0x69,
+	0xc8, 0xc8, 0x99, 0xe9, 0xa1, 0xf6, 0xaa, 0x40, 0xe1, 0x6b, 0x74, 0xa3, 0x75, 0x8e, 0x2f, 0x20,
+	0x1b, 0x67, 0x86, 0x35, 0x32, 0xe7, 0xae, 0x88, 0x74, 0x17, 0x95, 0xfd, 0x05, 0x8a, 0x59, 0xf0,
+	0xf8, 0x16, 0xf2, 0x72, 0xde, 0x87, 0xcb, 0x7a, 0x4a, 0xc2, 0x8a, 0xb1, 0xb4, 0xb5, 0xdc, 0xb2,
+	0x07, 0x5b, 0x3f, 0x93, 0x5b, 0x79, 0xca, 0xd1, 0x6a, 0x53, 0xd2, 0xb6, 0x06, 0x3a, 0x46, 0xcd,
+	0xcb, 0xb7, 0x91, 0x8a, 0x86, 0x23, 0x15, 0x7d, 0x8c, 0x54, 0xf4, 0x3c, 0x56, 0x33, 0xc3, 0xb1,
+	0x9a, 0x79, 0x1f, 0xab, 0x99, 0xeb, 0x5a, 0xd7, 0x15, 0xce, 0xa0, 0x43, 0xa8, 0xdf, 0xaf, 0x4a,
+	0xfb, 0x3c, 0xe7, 0x87, 0xa8, 0x53, 0x9f, 0xb3, 0xb8, 0xe8, 0xac, 0x25, 0x2b, 0x7e, 0xf2, 0x39,
+	0x00, 0xaa, 0x9e, 0xd6, 0xd3, 0x37, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -242,6 +310,9 @@ const _ = grpc.SupportPackageIsVersion4
 type BroadcastAPIClient interface {
 	Ping(ctx context.Context, in *RequestPing, opts ...grpc.CallOption) (*ResponsePing, error)
 	BroadcastTx(ctx context.Context, in *RequestBroadcastTx, opts ...grpc.CallOption) (*ResponseBroadcastTx, error)
+	// BroadcastStream checks (i.e. broadcast_tx_sync) each of the txs sent on
+	// the stream, sending back the results in the same order.
+	BroadcastStream(ctx context.Context, opts ...grpc.CallOption) (BroadcastAPI_BroadcastStreamClient, error)
 }
 
 type broadcastAPIClient struct {
@@ -270,10 +341,44 @@ func (c *broadcastAPIClient) BroadcastTx(ctx context.Context, in *RequestBroadca
 	return out, nil
 }
 
+func (c *broadcastAPIClient) BroadcastStream(ctx context.Context, opts ...grpc.CallOption) (BroadcastAPI_BroadcastStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BroadcastAPI_serviceDesc.Streams[0], "/tendermint.rpc.grpc.BroadcastAPI/BroadcastStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &broadcastAPIBroadcastStreamClient{stream}
+	return x, nil
+}
+
+type BroadcastAPI_BroadcastStreamClient interface {
+	Send(*RequestBroadcastTx) error
+	Recv() (*ResponseBroadcastStream, error)
+	grpc.ClientStream
+}
+
+type broadcastAPIBroadcastStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *broadcastAPIBroadcastStreamClient) Send(m *RequestBroadcastTx) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *broadcastAPIBroadcastStreamClient) Recv() (*ResponseBroadcastStream, error) {
+	m := new(ResponseBroadcastStream)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BroadcastAPIServer is the server API for BroadcastAPI service.
 type BroadcastAPIServer interface {
 	Ping(context.Context, *RequestPing) (*ResponsePing, error)
 	BroadcastTx(context.Context, *RequestBroadcastTx) (*ResponseBroadcastTx, error)
+	// BroadcastStream checks (i.e. broadcast_tx_sync) each of the txs sent on
+	// the stream, sending back the results in the same order.
+	BroadcastStream(BroadcastAPI_BroadcastStreamServer) error
 }
 
 // UnimplementedBroadcastAPIServer can be embedded to have forward compatible implementations.
@@ -286,6 +391,9 @@ func (*UnimplementedBroadcastAPIServer) Ping(ctx context.Context, req *RequestPi
 func (*UnimplementedBroadcastAPIServer) BroadcastTx(ctx context.Context, req *RequestBroadcastTx) (*ResponseBroadcastTx, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BroadcastTx not implemented")
 }
+func (*UnimplementedBroadcastAPIServer) BroadcastStream(srv BroadcastAPI_BroadcastStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method BroadcastStream not implemented")
+}
 
 func RegisterBroadcastAPIServer(s *grpc.Server, srv BroadcastAPIServer) {
 	s.RegisterService(&_BroadcastAPI_serviceDesc, srv)
@@ -327,6 +435,32 @@ func _BroadcastAPI_BroadcastTx_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BroadcastAPI_BroadcastStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BroadcastAPIServer).BroadcastStream(&broadcastAPIBroadcastStreamServer{stream})
+}
+
+type BroadcastAPI_BroadcastStreamServer interface {
+	Send(*ResponseBroadcastStream) error
+	Recv() (*RequestBroadcastTx, error)
+	grpc.ServerStream
+}
+
+type broadcastAPIBroadcastStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *broadcastAPIBroadcastStreamServer) Send(m *ResponseBroadcastStream) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *broadcastAPIBroadcastStreamServer) Recv() (*RequestBroadcastTx, error) {
+	m := new(RequestBroadcastTx)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _BroadcastAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tendermint.rpc.grpc.BroadcastAPI",
 	HandlerType: (*BroadcastAPIServer)(nil),
@@ -340,7 +474,14 @@ var _BroadcastAPI_serviceDesc = grpc.ServiceDesc{
 			Handler:    _BroadcastAPI_BroadcastTx_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BroadcastStream",
+			Handler:       _BroadcastAPI_BroadcastStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "tendermint/rpc/grpc/types.proto",
 }
 
@@ -467,6 +608,55 @@ func (m *ResponseBroadcastTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ResponseBroadcastStream) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseBroadcastStream) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseBroadcastStream) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.CheckTx != nil {
+		{
+			size, err := m.CheckTx.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -526,6 +716,27 @@ func (m *ResponseBroadcastTx) Size() (n int) {
 	return n
 }
 
+func (m *ResponseBroadcastStream) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.CheckTx != nil {
+		l = m.CheckTx.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -850,6 +1061,161 @@ func (m *ResponseBroadcastTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ResponseBroadcastStream) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseBroadcastStream: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseBroadcastStream: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CheckTx == nil {
+				m.CheckTx = &types.ResponseCheckTx{}
+			}
+			if err := m.CheckTx.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
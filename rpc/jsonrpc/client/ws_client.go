@@ -248,6 +248,12 @@ func (c *WSClient) CallWithArrayParams(ctx context.Context, method string, param
 	return c.Send(ctx, request)
 }
 
+// NextRequestID returns a new request ID, which can be used to send a request
+// with Send and to match its response on ResponsesCh.
+func (c *WSClient) NextRequestID() types.JSONRPCIntID {
+	return c.nextRequestID()
+}
+
 // Private methods
 
 func (c *WSClient) nextRequestID() types.JSONRPCIntID {
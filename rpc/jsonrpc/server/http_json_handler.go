@@ -97,11 +97,10 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				}
 				args = append(args, fnArgs...)
 			}
-			returns := rpcFunc.f.Call(args)
-			logger.Info("HTTPJSONRPC", "method", request.Method, "args", args, "returns", returns)
-			result, err := unreflectResult(returns)
+			result, err := rpcFunc.call(args)
+			logger.Info("HTTPJSONRPC", "method", request.Method, "args", args, "result", result, "err", err)
 			if err != nil {
-				responses = append(responses, types.RPCInternalError(request.ID, err))
+				responses = append(responses, types.RPCFuncError(request.ID, err))
 				continue
 			}
 			responses = append(responses, types.NewRPCSuccessResponse(request.ID, result))
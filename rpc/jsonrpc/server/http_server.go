@@ -45,6 +45,19 @@ func DefaultConfig() *Config {
 	}
 }
 
+// NewServer creates a http.Server serving handler, wrapped with
+// RecoverAndLogHandler and a handler, which limits the max body size to
+// config.MaxBodyBytes, with the timeouts of config. It is useful to shut the
+// server down gracefully, see http#Server#Shutdown.
+func NewServer(handler http.Handler, logger log.Logger, config *Config) *http.Server {
+	return &http.Server{
+		Handler:        RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
+		ReadTimeout:    config.ReadTimeout,
+		WriteTimeout:   config.WriteTimeout,
+		MaxHeaderBytes: config.MaxHeaderBytes,
+	}
+}
+
 // Serve creates a http.Server and calls Serve with the given listener. It
 // wraps handler with RecoverAndLogHandler and a handler, which limits the max
 // body size to config.MaxBodyBytes.
@@ -52,13 +65,7 @@ func DefaultConfig() *Config {
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func Serve(listener net.Listener, handler http.Handler, logger log.Logger, config *Config) error {
 	logger.Info(fmt.Sprintf("Starting RPC HTTP server on %s", listener.Addr()))
-	s := &http.Server{
-		Handler:        RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
-		ReadTimeout:    config.ReadTimeout,
-		WriteTimeout:   config.WriteTimeout,
-		MaxHeaderBytes: config.MaxHeaderBytes,
-	}
-	err := s.Serve(listener)
+	err := NewServer(handler, logger, config).Serve(listener)
 	logger.Info("RPC HTTP server stopped", "err", err)
 	return err
 }
@@ -77,13 +84,7 @@ func ServeTLS(
 ) error {
 	logger.Info(fmt.Sprintf("Starting RPC HTTPS server on %s (cert: %q, key: %q)",
 		listener.Addr(), certFile, keyFile))
-	s := &http.Server{
-		Handler:        RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
-		ReadTimeout:    config.ReadTimeout,
-		WriteTimeout:   config.WriteTimeout,
-		MaxHeaderBytes: config.MaxHeaderBytes,
-	}
-	err := s.ServeTLS(listener, certFile, keyFile)
+	err := NewServer(handler, logger, config).ServeTLS(listener, certFile, keyFile)
 
 	logger.Error("RPC HTTPS server stopped", "err", err)
 	return err
@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -51,13 +52,15 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 		}
 		args = append(args, fnArgs...)
 
-		returns := rpcFunc.f.Call(args)
+		result, err := rpcFunc.call(args)
 
-		logger.Debug("HTTPRestRPC", "method", r.URL.Path, "args", args, "returns", returns)
-		result, err := unreflectResult(returns)
+		logger.Debug("HTTPRestRPC", "method", r.URL.Path, "args", args, "result", result, "err", err)
 		if err != nil {
-			WriteRPCResponseHTTPError(w, http.StatusInternalServerError,
-				types.RPCInternalError(dummyID, err))
+			status := http.StatusInternalServerError
+			if errors.Is(err, types.ErrQuotaExceeded) {
+				status = http.StatusTooManyRequests
+			}
+			WriteRPCResponseHTTPError(w, status, types.RPCFuncError(dummyID, err))
 			return
 		}
 		WriteRPCResponseHTTP(w, types.NewRPCSuccessResponse(dummyID, result))
@@ -0,0 +1,263 @@
+package server
+
+import (
+	"container/list"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "rpc"
+)
+
+// CacheMetrics contains the metrics exposed by ResponseCache.
+type CacheMetrics struct {
+	// Number of responses served from the cache.
+	Hits metrics.Counter
+	// Number of cacheable requests which were not found in the cache.
+	Misses metrics.Counter
+	// Number of responses evicted from the cache to make room for new ones.
+	Evictions metrics.Counter
+	// Number of responses in the cache.
+	Entries metrics.Gauge
+	// Total size of the cached responses, in bytes.
+	SizeBytes metrics.Gauge
+}
+
+// PrometheusCacheMetrics returns CacheMetrics build using Prometheus client
+// library. Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusCacheMetrics(namespace string, labelsAndValues ...string) *CacheMetrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &CacheMetrics{
+		Hits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "response_cache_hits",
+			Help:      "Number of responses served from the cache.",
+		}, labels).With(labelsAndValues...),
+		Misses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "response_cache_misses",
+			Help:      "Number of cacheable requests not found in the cache.",
+		}, labels).With(labelsAndValues...),
+		Evictions: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "response_cache_evictions",
+			Help:      "Number of responses evicted from the cache.",
+		}, labels).With(labelsAndValues...),
+		Entries: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "response_cache_entries",
+			Help:      "Number of responses in the cache.",
+		}, labels).With(labelsAndValues...),
+		SizeBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "response_cache_size_bytes",
+			Help:      "Total size of the cached responses, in bytes.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopCacheMetrics returns no-op CacheMetrics.
+func NopCacheMetrics() *CacheMetrics {
+	return &CacheMetrics{
+		Hits:      discard.NewCounter(),
+		Misses:    discard.NewCounter(),
+		Evictions: discard.NewCounter(),
+		Entries:   discard.NewGauge(),
+		SizeBytes: discard.NewGauge(),
+	}
+}
+
+// ResponseCache is an LRU cache of marshaled results, bounded by their total
+// size in bytes. It's meant for results which never change once they exist
+// (e.g. a block at a given height), so that popular ones are only loaded and
+// marshaled once. See WithResponseCache.
+type ResponseCache struct {
+	maxBytes int64
+	metrics  *CacheMetrics
+
+	mtx   tmsync.Mutex
+	size  int64
+	list  *list.List // front is the most recently used
+	elems map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key   string
+	value json.RawMessage
+}
+
+func (e *cacheEntry) size() int64 {
+	return int64(len(e.key) + len(e.value))
+}
+
+// NewResponseCache returns a cache holding at most maxBytes of responses.
+func NewResponseCache(maxBytes int64, metrics *CacheMetrics) *ResponseCache {
+	return &ResponseCache{
+		maxBytes: maxBytes,
+		metrics:  metrics,
+		list:     list.New(),
+		elems:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the response cached under the given key, if any.
+func (c *ResponseCache) Get(key string) (json.RawMessage, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.elems[key]
+	if !ok {
+		c.metrics.Misses.Add(1)
+		return nil, false
+	}
+	c.list.MoveToFront(e)
+	c.metrics.Hits.Add(1)
+	return e.Value.(*cacheEntry).value, true
+}
+
+// Add caches the response under the given key, evicting the least recently
+// used responses if needed. Responses larger than the cache are ignored.
+func (c *ResponseCache) Add(key string, value json.RawMessage) {
+	entry := &cacheEntry{key: key, value: value}
+	if entry.size() > c.maxBytes {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.elems[key]; ok {
+		c.removeElement(e)
+	}
+	for c.size+entry.size() > c.maxBytes {
+		c.removeElement(c.list.Back())
+		c.metrics.Evictions.Add(1)
+	}
+	c.elems[key] = c.list.PushFront(entry)
+	c.size += entry.size()
+
+	c.metrics.Entries.Set(float64(c.list.Len()))
+	c.metrics.SizeBytes.Set(float64(c.size))
+}
+
+// Size returns the total size of the cached responses, in bytes.
+func (c *ResponseCache) Size() int64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.size
+}
+
+// Len returns the number of cached responses.
+func (c *ResponseCache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.list.Len()
+}
+
+// CONTRACT: c.mtx is held.
+func (c *ResponseCache) removeElement(e *list.Element) {
+	entry := c.list.Remove(e).(*cacheEntry)
+	delete(c.elems, entry.key)
+	c.size -= entry.size()
+}
+
+// WithResponseCache returns a copy of the funcMap in which every function
+// created with the Cacheable option uses the given cache, or no cache if it's
+// nil. The funcMap is left unchanged.
+func WithResponseCache(funcMap map[string]*RPCFunc, cache *ResponseCache) map[string]*RPCFunc {
+	cached := make(map[string]*RPCFunc, len(funcMap))
+	for name, rpcFunc := range funcMap {
+		if rpcFunc.isImmutable != nil {
+			rpcFunc = rpcFunc.withCache(name, cache)
+		}
+		cached[name] = rpcFunc
+	}
+	return cached
+}
+
+func (f *RPCFunc) withCache(name string, cache *ResponseCache) *RPCFunc {
+	c := *f
+	c.name = name
+	c.cache = cache
+	return &c
+}
+
+var contextType = reflect.TypeOf(&types.Context{})
+
+// cacheKey returns the key under which the result of calling the function
+// with the given args is cached, and false if it can't be cached.
+func (f *RPCFunc) cacheKey(args []reflect.Value) (string, bool) {
+	if f.cache == nil {
+		return "", false
+	}
+
+	params := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		if arg.Type() == contextType {
+			continue
+		}
+		params = append(params, arg.Interface())
+	}
+	if !f.isImmutable(params) {
+		return "", false
+	}
+
+	var sb strings.Builder
+	sb.WriteString(f.name)
+	for _, param := range params {
+		bz, err := tmjson.Marshal(param)
+		if err != nil {
+			return "", false
+		}
+		sb.WriteByte(' ')
+		sb.Write(bz)
+	}
+	return sb.String(), true
+}
+
+// call calls the function with the given args and returns its result, which
+// is served from (and stored in) the response cache if the function is
+// cacheable.
+func (f *RPCFunc) call(args []reflect.Value) (interface{}, error) {
+	key, cacheable := f.cacheKey(args)
+	if cacheable {
+		if res, ok := f.cache.Get(key); ok {
+			return res, nil
+		}
+	}
+
+	result, err := unreflectResult(f.f.Call(args))
+	if err != nil || !cacheable {
+		return result, err
+	}
+
+	bz, err := tmjson.Marshal(result)
+	if err != nil {
+		// let the caller fail marshaling the response as usual
+		return result, nil
+	}
+	f.cache.Add(key, bz)
+	return json.RawMessage(bz), nil
+}
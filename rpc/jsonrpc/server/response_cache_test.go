@@ -0,0 +1,114 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+func TestResponseCacheEviction(t *testing.T) {
+	cache := NewResponseCache(10, NopCacheMetrics())
+
+	cache.Add("a", json.RawMessage("123"))
+	cache.Add("b", json.RawMessage("456"))
+	assert.EqualValues(t, 8, cache.Size())
+	assert.Equal(t, 2, cache.Len())
+
+	// mark "a" as the most recently used
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+
+	// "b" gets evicted to make room for "c"
+	cache.Add("c", json.RawMessage("789"))
+	assert.EqualValues(t, 8, cache.Size())
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	v, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, json.RawMessage("123"), v)
+
+	// replacing an entry doesn't change the number of entries
+	cache.Add("c", json.RawMessage("0"))
+	assert.EqualValues(t, 6, cache.Size())
+	assert.Equal(t, 2, cache.Len())
+
+	// entries larger than the cache are ignored
+	cache.Add("d", json.RawMessage("1234567890"))
+	_, ok = cache.Get("d")
+	assert.False(t, ok)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestRPCFuncResponseCache(t *testing.T) {
+	calls := 0
+	funcMap := map[string]*RPCFunc{
+		"c": NewRPCFunc(func(ctx *types.Context, height int64) (string, error) {
+			calls++
+			return "foo", nil
+		}, "height", Cacheable(func(args []interface{}) bool {
+			return args[0].(int64) < 10
+		})),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, WithResponseCache(funcMap, NewResponseCache(1024, NopCacheMetrics())),
+		log.TestingLogger())
+
+	get := func(path string) string {
+		req, _ := http.NewRequest("GET", "http://localhost"+path, nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		res := rec.Result()
+		defer res.Body.Close()
+		blob, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		recv := new(types.RPCResponse)
+		require.NoError(t, json.Unmarshal(blob, recv))
+		require.Nil(t, recv.Error)
+		return string(recv.Result)
+	}
+	post := func(payload string) string {
+		req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(payload))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		res := rec.Result()
+		defer res.Body.Close()
+		blob, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		recv := new(types.RPCResponse)
+		require.NoError(t, json.Unmarshal(blob, recv))
+		require.Nil(t, recv.Error)
+		return string(recv.Result)
+	}
+
+	assert.Equal(t, `"foo"`, get("/c?height=1"))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, `"foo"`, get("/c?height=1"))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, `"foo"`, post(`{"jsonrpc": "2.0", "method": "c", "id": "0", "params": {"height": "1"}}`))
+	assert.Equal(t, 1, calls)
+
+	// different args are cached separately
+	assert.Equal(t, `"foo"`, get("/c?height=2"))
+	assert.Equal(t, 2, calls)
+
+	// results for mutable args are not cached
+	assert.Equal(t, `"foo"`, get("/c?height=10"))
+	assert.Equal(t, `"foo"`, get("/c?height=10"))
+	assert.Equal(t, 4, calls)
+
+	// the funcMap itself doesn't use the cache
+	assert.Nil(t, funcMap["c"].cache)
+	mux = http.NewServeMux()
+	RegisterRPCFuncs(mux, WithResponseCache(funcMap, nil), log.TestingLogger())
+	assert.Equal(t, `"foo"`, get("/c?height=1"))
+	assert.Equal(t, 5, calls)
+}
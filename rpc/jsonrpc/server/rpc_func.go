@@ -1,7 +1,6 @@
 package server
 
 import (
-	"fmt"
 	"net/http"
 	"reflect"
 	"strings"
@@ -32,12 +31,34 @@ type RPCFunc struct {
 	returns  []reflect.Type // type of each return arg
 	argNames []string       // name of each argument
 	ws       bool           // websocket only
+
+	// response caching, see WithResponseCache
+	isImmutable func(args []interface{}) bool
+	name        string
+	cache       *ResponseCache
+}
+
+// Option is an optional setting of an RPCFunc.
+type Option func(*RPCFunc)
+
+// Cacheable marks the function's results as cacheable in a ResponseCache
+// whenever isImmutable returns true for the given args (in order, excluding
+// the *types.Context). It must only do so if the result for these args will
+// never change.
+func Cacheable(isImmutable func(args []interface{}) bool) Option {
+	return func(f *RPCFunc) {
+		f.isImmutable = isImmutable
+	}
 }
 
 // NewRPCFunc wraps a function for introspection.
 // f is the function, args are comma separated argument names
-func NewRPCFunc(f interface{}, args string) *RPCFunc {
-	return newRPCFunc(f, args, false)
+func NewRPCFunc(f interface{}, args string, options ...Option) *RPCFunc {
+	rpcFunc := newRPCFunc(f, args, false)
+	for _, option := range options {
+		option(rpcFunc)
+	}
+	return rpcFunc
 }
 
 // NewWSRPCFunc wraps a function for introspection and use in the websockets.
@@ -86,8 +107,8 @@ func funcReturnTypes(f interface{}) []reflect.Type {
 // NOTE: assume returns is result struct and error. If error is not nil, return it
 func unreflectResult(returns []reflect.Value) (interface{}, error) {
 	errV := returns[1]
-	if errV.Interface() != nil {
-		return nil, fmt.Errorf("%v", errV.Interface())
+	if err, ok := errV.Interface().(error); ok && err != nil {
+		return nil, err
 	}
 	rv := returns[0]
 	// the result is a registered interface,
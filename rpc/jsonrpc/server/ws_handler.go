@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"reflect"
 	"runtime/debug"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -35,6 +36,9 @@ type WebsocketManager struct {
 	funcMap       map[string]*RPCFunc
 	logger        log.Logger
 	wsConnOptions []func(*wsConnection)
+
+	maxConnections int32 // 0 means unlimited
+	numConnections int32 // atomic
 }
 
 // NewWebsocketManager returns a new WebsocketManager that passes a map of
@@ -69,9 +73,32 @@ func (wm *WebsocketManager) SetLogger(l log.Logger) {
 	wm.logger = l
 }
 
+// SetMaxConnections sets the maximum number of concurrent websocket
+// connections (0 - unlimited). Further connections are refused with a quota
+// exceeded error. It should only be called before the manager starts handling
+// connections - not Goroutine-safe.
+func (wm *WebsocketManager) SetMaxConnections(max int) {
+	wm.maxConnections = int32(max)
+}
+
+// NumConnections returns the number of open websocket connections.
+func (wm *WebsocketManager) NumConnections() int {
+	return int(atomic.LoadInt32(&wm.numConnections))
+}
+
 // WebsocketHandler upgrades the request/response (via http.Hijack) and starts
 // the wsConnection.
 func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Request) {
+	n := atomic.AddInt32(&wm.numConnections, 1)
+	defer atomic.AddInt32(&wm.numConnections, -1)
+	if wm.maxConnections > 0 && n > wm.maxConnections {
+		wm.logger.Info("Refusing websocket connection", "remote", r.RemoteAddr, "max", wm.maxConnections)
+		WriteRPCResponseHTTPError(w, http.StatusServiceUnavailable,
+			types.RPCQuotaExceededError(nil,
+				fmt.Errorf("max number of websocket connections (%d) reached", wm.maxConnections)))
+		return
+	}
+
 	wsConn, err := wm.Upgrade(w, r, nil)
 	if err != nil {
 		// TODO - return http error
@@ -378,14 +405,13 @@ func (wsc *wsConnection) readRoutine() {
 				args = append(args, fnArgs...)
 			}
 
-			returns := rpcFunc.f.Call(args)
+			result, err := rpcFunc.call(args)
 
 			// TODO: Need to encode args/returns to string if we want to log them
 			wsc.Logger.Info("WSJSONRPC", "method", request.Method)
 
-			result, err := unreflectResult(returns)
 			if err != nil {
-				if err := wsc.WriteRPCResponse(writeCtx, types.RPCInternalError(request.ID, err)); err != nil {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCFuncError(request.ID, err)); err != nil {
 					wsc.Logger.Error("Error writing RPC response", "err", err)
 				}
 				continue
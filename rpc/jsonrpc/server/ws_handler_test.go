@@ -1,9 +1,12 @@
 package server
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
@@ -42,12 +45,69 @@ func TestWebsocketManagerHandler(t *testing.T) {
 	dialResp.Body.Close()
 }
 
-func newWSServer() *httptest.Server {
+func TestWebsocketManagerMaxConnections(t *testing.T) {
+	s := newWSServer(func(wm *WebsocketManager) { wm.SetMaxConnections(1) })
+	defer s.Close()
+
+	d := websocket.Dialer{}
+	c, dialResp, err := d.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	dialResp.Body.Close()
+
+	// the second connection is refused
+	_, dialResp, err = d.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.Error(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, dialResp.StatusCode)
+	var resp types.RPCResponse
+	require.NoError(t, json.NewDecoder(dialResp.Body).Decode(&resp))
+	require.NotNil(t, resp.Error)
+	require.Equal(t, -32001, resp.Error.Code)
+	dialResp.Body.Close()
+
+	// once the first one is closed, a new one is accepted
+	require.NoError(t, c.Close())
+	require.Eventually(t, func() bool {
+		c, dialResp, err = d.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+		if err != nil {
+			return false
+		}
+		dialResp.Body.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+	c.Close()
+}
+
+func TestWebsocketQuotaExceededError(t *testing.T) {
+	s := newWSServer()
+	defer s.Close()
+
+	d := websocket.Dialer{}
+	c, dialResp, err := d.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+
+	req, err := types.MapToRequest(types.JSONRPCStringID("TestWebsocketQuota"), "quota", map[string]interface{}{})
+	require.NoError(t, err)
+	require.NoError(t, c.WriteJSON(req))
+
+	var resp types.RPCResponse
+	require.NoError(t, c.ReadJSON(&resp))
+	require.NotNil(t, resp.Error)
+	require.Equal(t, -32001, resp.Error.Code)
+}
+
+func newWSServer(options ...func(*WebsocketManager)) *httptest.Server {
 	funcMap := map[string]*RPCFunc{
 		"c": NewWSRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
+		"quota": NewWSRPCFunc(func(ctx *types.Context) (string, error) {
+			return "", fmt.Errorf("%w: test", types.ErrQuotaExceeded)
+		}, ""),
 	}
 	wm := NewWebsocketManager(funcMap)
 	wm.SetLogger(log.TestingLogger())
+	for _, option := range options {
+		option(wm)
+	}
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/websocket", wm.WebsocketHandler)
@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -240,6 +241,24 @@ func RPCServerError(id jsonrpcid, err error) RPCResponse {
 	return NewRPCErrorResponse(id, -32000, "Server error", err.Error())
 }
 
+func RPCQuotaExceededError(id jsonrpcid, err error) RPCResponse {
+	return NewRPCErrorResponse(id, -32001, "Quota exceeded", err.Error())
+}
+
+// ErrQuotaExceeded must be wrapped by the errors returned by RPC functions when
+// the client exceeded one of the server's limits.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// RPCFuncError returns the response to a RPC function call which failed with
+// err: a quota exceeded error if err wraps ErrQuotaExceeded, an internal error
+// otherwise.
+func RPCFuncError(id jsonrpcid, err error) RPCResponse {
+	if errors.Is(err, ErrQuotaExceeded) {
+		return RPCQuotaExceededError(id, err)
+	}
+	return RPCInternalError(id, err)
+}
+
 //----------------------------------------
 
 // WSRPCConnection represents a websocket connection.
@@ -0,0 +1,121 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	rpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	"github.com/tendermint/tendermint/types"
+)
+
+// BuildBlockRequest is the request of the txs of a block proposed by this
+// node.
+type BuildBlockRequest struct {
+	ChainID         string           `json:"chain_id"`
+	Height          int64            `json:"height"`
+	ProposerAddress tmbytes.HexBytes `json:"proposer_address"`
+	// Maximum size of the txs, as computed by types.ComputeProtoSizeForTxs.
+	MaxBytes int64 `json:"max_bytes"`
+	// Maximum gas wanted by the txs (-1 - unlimited). It can't be checked by
+	// the proposer, the app must reject the blocks exceeding it.
+	MaxGas int64 `json:"max_gas"`
+}
+
+// BlockBuilder builds the txs of the blocks proposed by this node, e.g. an
+// external block builder running batch auctions.
+type BlockBuilder interface {
+	BuildBlockTxs(ctx context.Context, req BuildBlockRequest) (types.Txs, error)
+}
+
+// BlockExecutorWithBlockBuilder makes the BlockExecutor request the txs of the
+// proposed blocks from the builder, falling back to the mempool if it doesn't
+// answer within the timeout or returns an invalid payload.
+func BlockExecutorWithBlockBuilder(builder BlockBuilder, timeout time.Duration) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.blockBuilder = builder
+		blockExec.blockBuilderTimeout = timeout
+	}
+}
+
+// HasBlockBuilder returns true if the txs of the proposed blocks are requested
+// from a block builder, i.e. CreateProposalBlock makes a network call.
+func (blockExec *BlockExecutor) HasBlockBuilder() bool {
+	return blockExec.blockBuilder != nil
+}
+
+// buildBlockTxs requests the txs from the block builder, returning an error if
+// it fails or if the txs are invalid.
+func (blockExec *BlockExecutor) buildBlockTxs(req BuildBlockRequest) (types.Txs, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), blockExec.blockBuilderTimeout)
+	defer cancel()
+	txs, err := blockExec.blockBuilder.BuildBlockTxs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("block builder timed out: %w", ctx.Err())
+	}
+	return txs, validateBuiltTxs(txs, req.MaxBytes)
+}
+
+// validateBuiltTxs returns an error if the txs are empty or duplicated, or
+// exceed maxBytes.
+func validateBuiltTxs(txs types.Txs, maxBytes int64) error {
+	if size := types.ComputeProtoSizeForTxs(txs); size > maxBytes {
+		return fmt.Errorf("txs are too big: %d bytes, max %d", size, maxBytes)
+	}
+	seen := make(map[string]struct{}, len(txs))
+	for i, tx := range txs {
+		if len(tx) == 0 {
+			return fmt.Errorf("tx #%d is empty", i)
+		}
+		if _, ok := seen[string(tx)]; ok {
+			return fmt.Errorf("tx #%d (%X) is duplicated", i, tx.Hash())
+		}
+		seen[string(tx)] = struct{}{}
+	}
+	return nil
+}
+
+// HTTPBlockBuilder is a BlockBuilder requesting the txs from an external block
+// builder over JSON-RPC. The build_block method takes the fields of the
+// BuildBlockRequest as params, and returns {"txs": [base64 tx, ...]}.
+type HTTPBlockBuilder struct {
+	client *rpcclient.Client
+}
+
+var _ BlockBuilder = (*HTTPBlockBuilder)(nil)
+
+// NewHTTPBlockBuilder returns a new HTTPBlockBuilder for the builder at the
+// given address (e.g. "http://127.0.0.1:26680").
+func NewHTTPBlockBuilder(remote string) (*HTTPBlockBuilder, error) {
+	client, err := rpcclient.New(remote)
+	if err != nil {
+		return nil, fmt.Errorf("can't create the block builder client: %w", err)
+	}
+	return &HTTPBlockBuilder{client: client}, nil
+}
+
+// BuildBlockTxs implements BlockBuilder.
+func (b *HTTPBlockBuilder) BuildBlockTxs(ctx context.Context, req BuildBlockRequest) (types.Txs, error) {
+	result := new(struct {
+		Txs []types.Tx `json:"txs"`
+	})
+	params := map[string]interface{}{
+		"chain_id":         req.ChainID,
+		"height":           req.Height,
+		"proposer_address": req.ProposerAddress,
+		"max_bytes":        req.MaxBytes,
+		"max_gas":          req.MaxGas,
+	}
+	if _, err := b.client.Call(ctx, "build_block", params, result); err != nil {
+		return nil, err
+	}
+	if result.Txs == nil {
+		return nil, errors.New("no txs in the block builder response")
+	}
+	return result.Txs, nil
+}
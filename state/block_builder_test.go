@@ -0,0 +1,104 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	mmock "github.com/tendermint/tendermint/mempool/mock"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+type blockBuilderFunc func(ctx context.Context, req sm.BuildBlockRequest) (types.Txs, error)
+
+func (f blockBuilderFunc) BuildBlockTxs(ctx context.Context, req sm.BuildBlockRequest) (types.Txs, error) {
+	return f(ctx, req)
+}
+
+func TestCreateProposalBlockWithBlockBuilder(t *testing.T) {
+	state, stateDB, _ := makeState(1, 1)
+	proposer := state.Validators.GetProposer().Address
+	builtTxs := types.Txs{types.Tx("tx1"), types.Tx("tx2")}
+
+	testCases := []struct {
+		name    string
+		builder blockBuilderFunc
+		txs     types.Txs
+	}{
+		{"built", func(ctx context.Context, req sm.BuildBlockRequest) (types.Txs, error) {
+			assert.Equal(t, state.ChainID, req.ChainID)
+			assert.EqualValues(t, 1, req.Height)
+			assert.EqualValues(t, proposer, req.ProposerAddress)
+			assert.True(t, req.MaxBytes > 0)
+			return builtTxs, nil
+		}, builtTxs},
+		{"failed", func(ctx context.Context, req sm.BuildBlockRequest) (types.Txs, error) {
+			return nil, errors.New("builder is down")
+		}, types.Txs{}},
+		{"timed out", func(ctx context.Context, req sm.BuildBlockRequest) (types.Txs, error) {
+			<-ctx.Done()
+			return builtTxs, nil
+		}, types.Txs{}},
+		{"duplicated txs", func(ctx context.Context, req sm.BuildBlockRequest) (types.Txs, error) {
+			return types.Txs{types.Tx("tx1"), types.Tx("tx1")}, nil
+		}, types.Txs{}},
+		{"too big", func(ctx context.Context, req sm.BuildBlockRequest) (types.Txs, error) {
+			return types.Txs{make(types.Tx, req.MaxBytes)}, nil
+		}, types.Txs{}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// the mempool is empty, filling the blocks of the failed builds
+			blockExec := sm.NewBlockExecutor(sm.NewStore(stateDB), log.TestingLogger(), nil,
+				mmock.Mempool{}, sm.EmptyEvidencePool{},
+				sm.BlockExecutorWithBlockBuilder(tc.builder, 10*time.Millisecond))
+			block, _ := blockExec.CreateProposalBlock(1, state, new(types.Commit), proposer)
+			assert.Equal(t, tc.txs, block.Txs)
+		})
+	}
+}
+
+type builtBlock struct {
+	Txs []types.Tx `json:"txs"`
+}
+
+func TestHTTPBlockBuilder(t *testing.T) {
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, map[string]*rpcserver.RPCFunc{
+		"build_block": rpcserver.NewRPCFunc(func(ctx *rpctypes.Context, chainID string, height int64,
+			proposerAddress tmbytes.HexBytes, maxBytes, maxGas int64) (*builtBlock, error) {
+			assert.Equal(t, "test-chain", chainID)
+			assert.EqualValues(t, 2, height)
+			assert.Equal(t, tmbytes.HexBytes{0x01, 0x02}, proposerAddress)
+			assert.EqualValues(t, 1000, maxBytes)
+			assert.EqualValues(t, -1, maxGas)
+			return &builtBlock{Txs: []types.Tx{types.Tx("tx1"), types.Tx("tx2")}}, nil
+		}, "chain_id,height,proposer_address,max_bytes,max_gas"),
+	}, log.TestingLogger())
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	builder, err := sm.NewHTTPBlockBuilder(server.URL)
+	require.NoError(t, err)
+	txs, err := builder.BuildBlockTxs(context.Background(), sm.BuildBlockRequest{
+		ChainID:         "test-chain",
+		Height:          2,
+		ProposerAddress: tmbytes.HexBytes{0x01, 0x02},
+		MaxBytes:        1000,
+		MaxGas:          -1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{types.Tx("tx1"), types.Tx("tx2")}, txs)
+}
@@ -48,6 +48,17 @@ type (
 	ErrNoABCIResponsesForHeight struct {
 		Height int64
 	}
+
+	ErrAppHashMismatch struct {
+		Expected []byte
+		Got      []byte
+	}
+
+	ErrUpgradeRequired struct {
+		Height        int64
+		AppVersion    uint64
+		AppAppVersion uint64
+	}
 )
 
 func (e ErrUnknownBlock) Error() string {
@@ -63,6 +74,10 @@ func (e ErrBlockHashMismatch) Error() string {
 	)
 }
 
+func (e ErrAppHashMismatch) Error() string {
+	return fmt.Sprintf("wrong Block.Header.AppHash.  Expected %X, got %X", e.Expected, e.Got)
+}
+
 func (e ErrAppBlockHeightTooHigh) Error() string {
 	return fmt.Sprintf("app block height (%d) is higher than core (%d)", e.AppHeight, e.CoreHeight)
 }
@@ -99,3 +114,13 @@ func (e ErrNoConsensusParamsForHeight) Error() string {
 func (e ErrNoABCIResponsesForHeight) Error() string {
 	return fmt.Sprintf("could not find results for height #%d", e.Height)
 }
+
+func (e ErrUpgradeRequired) Error() string {
+	return fmt.Sprintf(
+		"upgrade required: the chain switches to app version %d at height %d, but the app only supports "+
+			"version %d; upgrade the app binary and restart the node",
+		e.AppVersion,
+		e.Height,
+		e.AppAppVersion,
+	)
+}
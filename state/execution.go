@@ -30,6 +30,9 @@ type BlockExecutor struct {
 	// execute the app against this
 	proxyApp proxy.AppConnConsensus
 
+	// query the app's version before app version upgrades, if set
+	queryApp proxy.AppConnQuery
+
 	// events
 	eventBus types.BlockEventPublisher
 
@@ -41,6 +44,10 @@ type BlockExecutor struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// requests the txs of the proposed blocks, if set
+	blockBuilder        BlockBuilder
+	blockBuilderTimeout time.Duration
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
@@ -51,6 +58,16 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithQueryApp makes the BlockExecutor check, before applying
+// the block at the height of a scheduled app version upgrade, that the app
+// (as reported by Info) supports the new version, returning
+// ErrUpgradeRequired otherwise.
+func BlockExecutorWithQueryApp(queryApp proxy.AppConnQuery) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.queryApp = queryApp
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -89,9 +106,9 @@ func (blockExec *BlockExecutor) SetEventBus(eventBus types.BlockEventPublisher)
 }
 
 // CreateProposalBlock calls state.MakeBlock with evidence from the evpool
-// and txs from the mempool. The max bytes must be big enough to fit the commit.
-// Up to 1/10th of the block space is allcoated for maximum sized evidence.
-// The rest is given to txs, up to the max gas.
+// and txs from the block builder, if set, or the mempool. The max bytes must be
+// big enough to fit the commit. Up to 1/10th of the block space is allcoated
+// for maximum sized evidence. The rest is given to txs, up to the max gas.
 func (blockExec *BlockExecutor) CreateProposalBlock(
 	height int64,
 	state State, commit *types.Commit,
@@ -106,6 +123,21 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	// Fetch a limited amount of valid txs
 	maxDataBytes := types.MaxDataBytes(maxBytes, evSize, state.Validators.Size())
 
+	if blockExec.blockBuilder != nil {
+		txs, err := blockExec.buildBlockTxs(BuildBlockRequest{
+			ChainID:         state.ChainID,
+			Height:          height,
+			ProposerAddress: proposerAddr,
+			MaxBytes:        maxDataBytes,
+			MaxGas:          maxGas,
+		})
+		if err == nil {
+			return state.MakeBlock(height, txs, commit, evidence, proposerAddr)
+		}
+		blockExec.logger.Error("Failed to build the block, reaping the mempool", "height", height, "err", err)
+		blockExec.metrics.BlockBuilderFallbacks.Add(1)
+	}
+
 	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
 
 	return state.MakeBlock(height, txs, commit, evidence, proposerAddr)
@@ -132,16 +164,32 @@ func (blockExec *BlockExecutor) ValidateBlock(state State, block *types.Block) e
 func (blockExec *BlockExecutor) ApplyBlock(
 	state State, blockID types.BlockID, block *types.Block,
 ) (State, int64, error) {
+	return blockExec.ApplyBlockWithTimings(state, blockID, block, nil)
+}
+
+// ApplyBlockWithTimings is ApplyBlock, additionally setting the execution and
+// commit durations on the given timings, if not nil, which are then attached
+// to the NewBlock event.
+func (blockExec *BlockExecutor) ApplyBlockWithTimings(
+	state State, blockID types.BlockID, block *types.Block, timings *types.BlockTimings,
+) (State, int64, error) {
 
 	if err := validateBlock(state, block); err != nil {
 		return state, 0, ErrInvalidBlock(err)
 	}
 
+	if err := blockExec.checkAppVersionUpgrade(state, block.Height); err != nil {
+		return state, 0, err
+	}
+
 	startTime := time.Now().UnixNano()
 	abciResponses, err := execBlockOnProxyApp(blockExec.logger, blockExec.proxyApp, block,
 		blockExec.store, state.InitialHeight)
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
+	if timings != nil {
+		timings.Execution = time.Duration(endTime - startTime)
+	}
 	if err != nil {
 		return state, 0, ErrProxyAppConn(err)
 	}
@@ -176,10 +224,14 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	}
 
 	// Lock mempool, commit app state, update mempoool.
+	commitStart := time.Now()
 	appHash, retainHeight, err := blockExec.Commit(state, block, abciResponses.DeliverTxs)
 	if err != nil {
 		return state, 0, fmt.Errorf("commit failed for application: %v", err)
 	}
+	if timings != nil {
+		timings.Commit = time.Since(commitStart)
+	}
 
 	// Update evpool with the latest state.
 	blockExec.evpool.Update(state, block.Evidence.Evidence)
@@ -196,11 +248,40 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	// Events are fired after everything else.
 	// NOTE: if we crash between Commit and Save, events wont be fired during replay
-	fireEvents(blockExec.logger, blockExec.eventBus, block, abciResponses, validatorUpdates)
+	fireEvents(blockExec.logger, blockExec.eventBus, block, abciResponses, validatorUpdates, timings)
 
 	return state, retainHeight, nil
 }
 
+// checkAppVersionUpgrade returns ErrUpgradeRequired if the block at the given
+// height is the first one of a scheduled app version upgrade, and the app
+// doesn't support the new version.
+func (blockExec *BlockExecutor) checkAppVersionUpgrade(state State, height int64) error {
+	upgrade := state.AppVersionUpgrade
+	if blockExec.queryApp == nil || upgrade.Height != height {
+		return nil
+	}
+	res, err := blockExec.queryApp.InfoSync(context.Background(), proxy.RequestInfo)
+	if err != nil {
+		return ErrProxyAppConn(err)
+	}
+	return CheckAppVersionUpgrade(upgrade, height, res.AppVersion)
+}
+
+// CheckAppVersionUpgrade returns ErrUpgradeRequired if the given upgrade
+// applies at the given height, and the app version supported by the app is
+// lower than the one required by the upgrade.
+func CheckAppVersionUpgrade(upgrade abci.AppVersionUpgrade, height int64, appVersion uint64) error {
+	if upgrade.Height == 0 || height < upgrade.Height || appVersion >= upgrade.AppVersion {
+		return nil
+	}
+	return ErrUpgradeRequired{
+		Height:        upgrade.Height,
+		AppVersion:    upgrade.AppVersion,
+		AppAppVersion: appVersion,
+	}
+}
+
 // Commit locks the mempool, runs the ABCI Commit message, and updates the
 // mempool.
 // It returns the result of calling abci.Commit (the AppHash) and the height to retain (if any).
@@ -313,6 +394,7 @@ func execBlockOnProxyApp(
 			Header:              *pbh,
 			LastCommitInfo:      commitInfo,
 			ByzantineValidators: byzVals,
+			RandomSeed:          types.RandomSeed(block.ChainID, block.Height, block.LastCommit),
 		})
 	if err != nil {
 		logger.Error("Error in proxyAppConn.BeginBlock", "err", err)
@@ -402,6 +484,20 @@ func validateValidatorUpdates(abciUpdates []abci.ValidatorUpdate,
 	return nil
 }
 
+// validateAppVersionUpgrade checks that the upgrade scheduled by the block at
+// the given height is in the future, and bumps the current app version.
+func validateAppVersionUpgrade(upgrade abci.AppVersionUpgrade, height int64, appVersion uint64) error {
+	if upgrade.Height <= height {
+		return fmt.Errorf("upgrade height %d must be greater than the current height %d",
+			upgrade.Height, height)
+	}
+	if upgrade.AppVersion <= appVersion {
+		return fmt.Errorf("upgrade app version %d must be greater than the current app version %d",
+			upgrade.AppVersion, appVersion)
+	}
+	return nil
+}
+
 // updateState returns a new State updated according to the header and responses.
 func updateState(
 	state State,
@@ -446,6 +542,20 @@ func updateState(
 		lastHeightParamsChanged = header.Height + 1
 	}
 
+	// Schedule the app version upgrade, if any, and switch the app version
+	// once its height is reached.
+	appVersionUpgrade := state.AppVersionUpgrade
+	if upgrade := abciResponses.EndBlock.AppVersionUpgrade; upgrade != nil {
+		err := validateAppVersionUpgrade(*upgrade, header.Height, state.Version.Consensus.App)
+		if err != nil {
+			return state, fmt.Errorf("error scheduling app version upgrade: %v", err)
+		}
+		appVersionUpgrade = *upgrade
+	}
+	if appVersionUpgrade.Height == header.Height+1 {
+		state.Version.Consensus.App = appVersionUpgrade.AppVersion
+	}
+
 	nextVersion := state.Version
 
 	// NOTE: the AppHash has not been populated.
@@ -465,6 +575,7 @@ func updateState(
 		LastHeightConsensusParamsChanged: lastHeightParamsChanged,
 		LastResultsHash:                  ABCIResponsesResultsHash(abciResponses),
 		AppHash:                          nil,
+		AppVersionUpgrade:                appVersionUpgrade,
 	}, nil
 }
 
@@ -477,11 +588,13 @@ func fireEvents(
 	block *types.Block,
 	abciResponses *tmstate.ABCIResponses,
 	validatorUpdates []*types.Validator,
+	timings *types.BlockTimings,
 ) {
 	if err := eventBus.PublishEventNewBlock(types.EventDataNewBlock{
 		Block:            block,
 		ResultBeginBlock: *abciResponses.BeginBlock,
 		ResultEndBlock:   *abciResponses.EndBlock,
+		Timings:          timings,
 	}); err != nil {
 		logger.Error("Error publishing new block", "err", err)
 	}
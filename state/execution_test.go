@@ -57,6 +57,68 @@ func TestApplyBlock(t *testing.T) {
 	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
 }
 
+// upgradeApp schedules the given app version upgrade in EndBlock, and
+// reports the given app version in Info.
+type upgradeApp struct {
+	testApp
+
+	upgrade    *abci.AppVersionUpgrade
+	appVersion uint64
+}
+
+func (app *upgradeApp) Info(req abci.RequestInfo) abci.ResponseInfo {
+	return abci.ResponseInfo{AppVersion: app.appVersion}
+}
+
+func (app *upgradeApp) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
+	upgrade := app.upgrade
+	app.upgrade = nil
+	return abci.ResponseEndBlock{AppVersionUpgrade: upgrade}
+}
+
+func TestApplyBlockAppVersionUpgrade(t *testing.T) {
+	app := &upgradeApp{upgrade: &abci.AppVersionUpgrade{AppVersion: 2, Height: 3}, appVersion: 1}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, sm.BlockExecutorWithQueryApp(proxyApp.Query()))
+	proposer := state.Validators.Validators[0].Address
+
+	// the upgrade is scheduled at height 1, and applies from height 3
+	state, _, lastCommit, err := makeAndCommitGoodBlock(state, 1, new(types.Commit), proposer, blockExec, privVals, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, state.Version.Consensus.App)
+	assert.Equal(t, abci.AppVersionUpgrade{AppVersion: 2, Height: 3}, state.AppVersionUpgrade)
+
+	state, _, lastCommit, err = makeAndCommitGoodBlock(state, 2, lastCommit, proposer, blockExec, privVals, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, state.Version.Consensus.App)
+
+	// the app doesn't support the new version yet
+	_, _, _, err = makeAndCommitGoodBlock(state, 3, lastCommit, proposer, blockExec, privVals, nil)
+	assert.Equal(t, sm.ErrUpgradeRequired{Height: 3, AppVersion: 2, AppAppVersion: 1}, err)
+
+	app.appVersion = 2
+	state, _, lastCommit, err = makeAndCommitGoodBlock(state, 3, lastCommit, proposer, blockExec, privVals, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, state.Version.Consensus.App)
+
+	// upgrades must be in the future, and bump the app version
+	for _, upgrade := range []abci.AppVersionUpgrade{{AppVersion: 3, Height: 4}, {AppVersion: 2, Height: 5}} {
+		upgrade := upgrade
+		app.upgrade = &upgrade
+		_, _, _, err = makeAndCommitGoodBlock(state, 4, lastCommit, proposer, blockExec, privVals, nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error scheduling app version upgrade")
+	}
+}
+
 // TestBeginBlockValidators ensures we send absent validators list.
 func TestBeginBlockValidators(t *testing.T) {
 	app := &testApp{}
@@ -104,6 +166,7 @@ func TestBeginBlockValidators(t *testing.T) {
 
 		_, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, log.TestingLogger(), stateStore, 1)
 		require.Nil(t, err, tc.desc)
+		assert.EqualValues(t, types.RandomSeed(state.ChainID, 2, lastCommit), app.RandomSeed, tc.desc)
 
 		// -> app receives a list of validators with a bool indicating if they signed
 		ctr := 0
@@ -236,6 +236,7 @@ type testApp struct {
 	CommitVotes         []abci.VoteInfo
 	ByzantineValidators []abci.Evidence
 	ValidatorUpdates    []abci.ValidatorUpdate
+	RandomSeed          []byte
 }
 
 var _ abci.Application = (*testApp)(nil)
@@ -247,6 +248,7 @@ func (app *testApp) Info(req abci.RequestInfo) (resInfo abci.ResponseInfo) {
 func (app *testApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlock {
 	app.CommitVotes = req.LastCommitInfo.Votes
 	app.ByzantineValidators = req.ByzantineValidators
+	app.RandomSeed = req.RandomSeed
 	return abci.ResponseBeginBlock{}
 }
 
@@ -17,6 +17,14 @@ const (
 type Metrics struct {
 	// Time between BeginBlock and EndBlock.
 	BlockProcessingTime metrics.Histogram
+	// Number of the state store reads served by the cache, by kind
+	// (validators or consensus_params).
+	StoreCacheHits metrics.Counter
+	// Number of the state store reads missing the cache, by kind.
+	StoreCacheMisses metrics.Counter
+	// Number of the proposed blocks whose txs were reaped from the mempool,
+	// because the block builder failed.
+	BlockBuilderFallbacks metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -35,12 +43,33 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help:      "Time between BeginBlock and EndBlock in ms.",
 			Buckets:   stdprometheus.LinearBuckets(1, 10, 10),
 		}, labels).With(labelsAndValues...),
+		StoreCacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "store_cache_hits",
+			Help:      "Number of the state store reads served by the cache, by kind.",
+		}, append(labels, "kind")).With(labelsAndValues...),
+		StoreCacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "store_cache_misses",
+			Help:      "Number of the state store reads missing the cache, by kind.",
+		}, append(labels, "kind")).With(labelsAndValues...),
+		BlockBuilderFallbacks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_builder_fallbacks",
+			Help:      "Number of the proposed blocks whose txs were reaped from the mempool, the block builder failing.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		BlockProcessingTime: discard.NewHistogram(),
+		BlockProcessingTime:   discard.NewHistogram(),
+		StoreCacheHits:        discard.NewCounter(),
+		StoreCacheMisses:      discard.NewCounter(),
+		BlockBuilderFallbacks: discard.NewCounter(),
 	}
 }
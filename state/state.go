@@ -9,6 +9,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
@@ -78,6 +79,10 @@ type State struct {
 
 	// the latest AppHash we've received from calling abci.Commit()
 	AppHash []byte
+
+	// The last app version upgrade scheduled by the app in EndBlock (zero
+	// if none). Version.Consensus.App is switched once its height is reached.
+	AppVersionUpgrade abci.AppVersionUpgrade
 }
 
 // Copy makes a copy of the State for mutating.
@@ -103,6 +108,8 @@ func (state State) Copy() State {
 		AppHash: state.AppHash,
 
 		LastResultsHash: state.LastResultsHash,
+
+		AppVersionUpgrade: state.AppVersionUpgrade,
 	}
 }
 
@@ -171,6 +178,7 @@ func (state *State) ToProto() (*tmstate.State, error) {
 	sm.LastHeightConsensusParamsChanged = state.LastHeightConsensusParamsChanged
 	sm.LastResultsHash = state.LastResultsHash
 	sm.AppHash = state.AppHash
+	sm.AppVersionUpgrade = state.AppVersionUpgrade
 
 	return sm, nil
 }
@@ -222,6 +230,7 @@ func StateFromProto(pb *tmstate.State) (*State, error) { //nolint:golint
 	state.LastHeightConsensusParamsChanged = pb.LastHeightConsensusParamsChanged
 	state.LastResultsHash = pb.LastResultsHash
 	state.AppHash = pb.AppHash
+	state.AppVersionUpgrade = pb.AppVersionUpgrade
 
 	return state, nil
 }
@@ -23,6 +23,13 @@ const (
 	valSetCheckpointInterval = 100000
 )
 
+// The pruning exemptions retaining the validator and consensus param updates,
+// the others being event types, see StorePruningExemptions.
+const (
+	PruningExemptValidatorUpdates      = "validator_updates"
+	PruningExemptConsensusParamUpdates = "consensus_param_updates"
+)
+
 //------------------------------------------------------------------------
 
 func calcValidatorsKey(height int64) []byte {
@@ -37,6 +44,10 @@ func calcABCIResponsesKey(height int64) []byte {
 	return []byte(fmt.Sprintf("abciResponsesKey:%v", height))
 }
 
+func calcRetainedABCIResponsesKey(height int64) []byte {
+	return []byte(fmt.Sprintf("retainedABCIResponsesKey:%v", height))
+}
+
 //----------------------
 
 //go:generate mockery --case underscore --name Store
@@ -58,6 +69,9 @@ type Store interface {
 	LoadValidators(int64) (*types.ValidatorSet, error)
 	// LoadABCIResponses loads the abciResponse for a given height
 	LoadABCIResponses(int64) (*tmstate.ABCIResponses, error)
+	// LoadRetainedABCIResponses loads the parts of the pruned abciResponse
+	// for a given height which are exempted from pruning
+	LoadRetainedABCIResponses(int64) (*tmstate.RetainedABCIResponses, error)
 	// LoadConsensusParams loads the consensus params for a given height
 	LoadConsensusParams(int64) (tmproto.ConsensusParams, error)
 	// Save overwrites the previous state with the updated one
@@ -73,13 +87,60 @@ type Store interface {
 // dbStore wraps a db (github.com/tendermint/tm-db)
 type dbStore struct {
 	db dbm.DB
+
+	cacheSize int
+	metrics   *Metrics
+	// the validator sets and consensus params by height, nil if disabled
+	validatorsCache *heightCache
+	paramsCache     *heightCache
+	// the parts of the ABCI responses kept when pruning them, see
+	// StorePruningExemptions
+	pruningExemptions map[string]bool
 }
 
 var _ Store = (*dbStore)(nil)
 
+// StoreOption sets an optional parameter on the dbStore.
+type StoreOption func(*dbStore)
+
+// StoreCacheSize sets the number of heights for which the validator sets and
+// the consensus params are cached in memory (0 - disabled, the default).
+func StoreCacheSize(size int) StoreOption {
+	return func(store *dbStore) { store.cacheSize = size }
+}
+
+// StoreMetrics sets the metrics of the store cache.
+func StoreMetrics(metrics *Metrics) StoreOption {
+	return func(store *dbStore) { store.metrics = metrics }
+}
+
+// StorePruningExemptions sets the parts of the ABCI responses which are kept,
+// compactly, when pruning them: the events of the given types, and the
+// validator (PruningExemptValidatorUpdates) and consensus param
+// (PruningExemptConsensusParamUpdates) updates. They can then be loaded with
+// LoadRetainedABCIResponses.
+func StorePruningExemptions(exemptions []string) StoreOption {
+	return func(store *dbStore) {
+		store.pruningExemptions = make(map[string]bool, len(exemptions))
+		for _, exemption := range exemptions {
+			store.pruningExemptions[exemption] = true
+		}
+	}
+}
+
 // NewStore creates the dbStore of the state pkg.
-func NewStore(db dbm.DB) Store {
-	return dbStore{db}
+func NewStore(db dbm.DB, options ...StoreOption) Store {
+	store := dbStore{db: db, metrics: NopMetrics()}
+	for _, option := range options {
+		option(&store)
+	}
+	store.validatorsCache = newHeightCache(store.cacheSize,
+		store.metrics.StoreCacheHits.With("kind", "validators"),
+		store.metrics.StoreCacheMisses.With("kind", "validators"))
+	store.paramsCache = newHeightCache(store.cacheSize,
+		store.metrics.StoreCacheHits.With("kind", "consensus_params"),
+		store.metrics.StoreCacheMisses.With("kind", "consensus_params"))
+	return store
 }
 
 // LoadStateFromDBOrGenesisFile loads the most recent state from the database,
@@ -227,6 +288,10 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 	if from >= to {
 		return fmt.Errorf("from height %v must be lower than to height %v", from, to)
 	}
+	defer func() {
+		store.validatorsCache.RemoveRange(from, to)
+		store.paramsCache.RemoveRange(from, to)
+	}()
 	valInfo, err := loadValidatorsInfo(store.db, to)
 	if err != nil {
 		return fmt.Errorf("validators at height %v not found: %w", to, err)
@@ -318,6 +383,11 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 			}
 		}
 
+		if len(store.pruningExemptions) > 0 {
+			if err := store.retainABCIResponses(batch, h); err != nil {
+				return err
+			}
+		}
 		err = batch.Delete(calcABCIResponsesKey(h))
 		if err != nil {
 			return err
@@ -382,6 +452,75 @@ func (store dbStore) LoadABCIResponses(height int64) (*tmstate.ABCIResponses, er
 	return abciResponses, nil
 }
 
+// LoadRetainedABCIResponses loads the parts of the pruned ABCIResponses for the
+// given height which were exempted from pruning, see StorePruningExemptions.
+// If not found, ErrNoABCIResponsesForHeight is returned.
+func (store dbStore) LoadRetainedABCIResponses(height int64) (*tmstate.RetainedABCIResponses, error) {
+	buf, err := store.db.Get(calcRetainedABCIResponsesKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) == 0 {
+		return nil, ErrNoABCIResponsesForHeight{height}
+	}
+
+	retained := new(tmstate.RetainedABCIResponses)
+	if err := retained.Unmarshal(buf); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal retained ABCI responses for height %d: %w", height, err)
+	}
+	return retained, nil
+}
+
+// retainABCIResponses adds the parts of the ABCIResponses for the given height
+// which are exempted from pruning to the batch, if any.
+func (store dbStore) retainABCIResponses(batch dbm.Batch, height int64) error {
+	buf, err := store.db.Get(calcABCIResponsesKey(height))
+	if err != nil || len(buf) == 0 {
+		return err
+	}
+	abciResponses := new(tmstate.ABCIResponses)
+	if err := abciResponses.Unmarshal(buf); err != nil {
+		return fmt.Errorf("failed to unmarshal ABCI responses for height %d: %w", height, err)
+	}
+
+	retained := new(tmstate.RetainedABCIResponses)
+	if abciResponses.BeginBlock != nil {
+		retained.BeginBlockEvents = store.exemptedEvents(abciResponses.BeginBlock.Events)
+	}
+	for _, deliverTx := range abciResponses.DeliverTxs {
+		retained.DeliverTxEvents = append(retained.DeliverTxEvents, store.exemptedEvents(deliverTx.Events)...)
+	}
+	if abciResponses.EndBlock != nil {
+		retained.EndBlockEvents = store.exemptedEvents(abciResponses.EndBlock.Events)
+		if store.pruningExemptions[PruningExemptValidatorUpdates] {
+			retained.ValidatorUpdates = abciResponses.EndBlock.ValidatorUpdates
+		}
+		if store.pruningExemptions[PruningExemptConsensusParamUpdates] {
+			retained.ConsensusParamUpdates = abciResponses.EndBlock.ConsensusParamUpdates
+		}
+	}
+	if retained.Size() == 0 {
+		return nil
+	}
+
+	bz, err := retained.Marshal()
+	if err != nil {
+		return err
+	}
+	return batch.Set(calcRetainedABCIResponsesKey(height), bz)
+}
+
+// exemptedEvents returns the events of the types exempted from pruning.
+func (store dbStore) exemptedEvents(events []abci.Event) []abci.Event {
+	var exempted []abci.Event
+	for _, event := range events {
+		if store.pruningExemptions[event.Type] {
+			exempted = append(exempted, event)
+		}
+	}
+	return exempted
+}
+
 // SaveABCIResponses persists the ABCIResponses to the database.
 // This is useful in case we crash after app.Commit and before s.Save().
 // Responses are indexed by height so they can also be loaded later to produce
@@ -416,6 +555,19 @@ func (store dbStore) SaveABCIResponses(height int64, abciResponses *tmstate.ABCI
 // LoadValidators loads the ValidatorSet for a given height.
 // Returns ErrNoValSetForHeight if the validator set can't be found for this height.
 func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
+	// the callers may mutate the validator sets, so the cache holds copies
+	if vals, ok := store.validatorsCache.Get(height); ok {
+		return vals.(*types.ValidatorSet).Copy(), nil
+	}
+	vals, err := store.loadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+	store.validatorsCache.Add(height, vals.Copy())
+	return vals, nil
+}
+
+func (store dbStore) loadValidators(height int64) (*types.ValidatorSet, error) {
 	valInfo, err := loadValidatorsInfo(store.db, height)
 	if err != nil {
 		return nil, ErrNoValSetForHeight{height}
@@ -514,6 +666,7 @@ func (store dbStore) saveValidatorsInfo(height, lastHeightChanged int64, valSet
 	if err != nil {
 		return err
 	}
+	store.validatorsCache.RemoveRange(height, height+1)
 
 	return nil
 }
@@ -524,6 +677,18 @@ func (store dbStore) saveValidatorsInfo(height, lastHeightChanged int64, valSet
 
 // LoadConsensusParams loads the ConsensusParams for a given height.
 func (store dbStore) LoadConsensusParams(height int64) (tmproto.ConsensusParams, error) {
+	if params, ok := store.paramsCache.Get(height); ok {
+		return params.(tmproto.ConsensusParams), nil
+	}
+	params, err := store.loadConsensusParams(height)
+	if err != nil {
+		return params, err
+	}
+	store.paramsCache.Add(height, params)
+	return params, nil
+}
+
+func (store dbStore) loadConsensusParams(height int64) (tmproto.ConsensusParams, error) {
 	empty := tmproto.ConsensusParams{}
 
 	paramsInfo, err := store.loadConsensusParamsInfo(height)
@@ -589,6 +754,7 @@ func (store dbStore) saveConsensusParamsInfo(nextHeight, changeHeight int64, par
 	if err != nil {
 		return err
 	}
+	store.paramsCache.RemoveRange(nextHeight, nextHeight+1)
 
 	return nil
 }
@@ -0,0 +1,92 @@
+package state
+
+import (
+	"container/list"
+
+	"github.com/go-kit/kit/metrics"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// heightCache is a size-bounded LRU cache of the values of the state store
+// by height. A nil heightCache caches nothing.
+type heightCache struct {
+	mtx    tmsync.Mutex
+	size   int
+	list   *list.List // of *heightCacheEntry, the most recently used first
+	elems  map[int64]*list.Element
+	hits   metrics.Counter
+	misses metrics.Counter
+}
+
+type heightCacheEntry struct {
+	height int64
+	value  interface{}
+}
+
+func newHeightCache(size int, hits, misses metrics.Counter) *heightCache {
+	if size <= 0 {
+		return nil
+	}
+	return &heightCache{
+		size:   size,
+		list:   list.New(),
+		elems:  make(map[int64]*list.Element, size),
+		hits:   hits,
+		misses: misses,
+	}
+}
+
+// Get returns the value cached for the height, if any.
+func (c *heightCache) Get(height int64) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	e, ok := c.elems[height]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	c.list.MoveToFront(e)
+	return e.Value.(*heightCacheEntry).value, true
+}
+
+// Add caches the value for the height, evicting the least recently used value
+// if the cache is full.
+func (c *heightCache) Add(height int64, value interface{}) {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if e, ok := c.elems[height]; ok {
+		e.Value.(*heightCacheEntry).value = value
+		c.list.MoveToFront(e)
+		return
+	}
+	if c.list.Len() >= c.size {
+		oldest := c.list.Back()
+		c.list.Remove(oldest)
+		delete(c.elems, oldest.Value.(*heightCacheEntry).height)
+	}
+	c.elems[height] = c.list.PushFront(&heightCacheEntry{height: height, value: value})
+}
+
+// RemoveRange removes the values cached for the heights from the from height
+// (inclusive) to the to height (exclusive).
+func (c *heightCache) RemoveRange(from, to int64) {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for height, e := range c.elems {
+		if height >= from && height < to {
+			c.list.Remove(e)
+			delete(c.elems, height)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeightCache(t *testing.T) {
+	hits, misses := generic.NewCounter("hits"), generic.NewCounter("misses")
+	c := newHeightCache(2, hits, misses)
+	c.Add(1, "a")
+	c.Add(2, "b")
+
+	v, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "a", v)
+
+	// the least recently used height is evicted
+	c.Add(3, "c")
+	_, ok = c.Get(2)
+	assert.False(t, ok)
+	_, ok = c.Get(1)
+	assert.True(t, ok)
+
+	c.RemoveRange(1, 3)
+	_, ok = c.Get(1)
+	assert.False(t, ok)
+	v, ok = c.Get(3)
+	assert.True(t, ok)
+	assert.Equal(t, "c", v)
+
+	assert.Equal(t, float64(3), hits.Value())
+	assert.Equal(t, float64(2), misses.Value())
+
+	// a disabled cache caches nothing
+	c = newHeightCache(0, hits, misses)
+	c.Add(1, "a")
+	_, ok = c.Get(1)
+	assert.False(t, ok)
+}
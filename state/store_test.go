@@ -46,6 +46,24 @@ func TestStoreLoadValidators(t *testing.T) {
 	assert.NotZero(t, loadedVals.Size())
 }
 
+func TestStoreCachesValidators(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	stateStore := sm.NewStore(stateDB, sm.StoreCacheSize(10))
+	val, _ := types.RandValidator(true, 10)
+	vals := types.NewValidatorSet([]*types.Validator{val})
+	require.NoError(t, sm.SaveValidatorsInfo(stateDB, 1, 1, vals))
+
+	loadedVals, err := stateStore.LoadValidators(1)
+	require.NoError(t, err)
+	assert.Equal(t, vals.Hash(), loadedVals.Hash())
+
+	// the callers can't mutate the cached validator sets
+	loadedVals.Validators[0].VotingPower = 20
+	loadedVals, err = stateStore.LoadValidators(1)
+	require.NoError(t, err)
+	assert.Equal(t, vals.Hash(), loadedVals.Hash())
+}
+
 func BenchmarkLoadValidators(b *testing.B) {
 	const valSetSize = 100
 
@@ -107,7 +125,7 @@ func TestPruneStates(t *testing.T) {
 		tc := tc
 		t.Run(name, func(t *testing.T) {
 			db := dbm.NewMemDB()
-			stateStore := sm.NewStore(db)
+			stateStore := sm.NewStore(db, sm.StoreCacheSize(int(tc.makeHeights)))
 			pk := ed25519.GenPrivKey().PubKey()
 
 			// Generate a bunch of state data. Validators change for heights ending with 3, and
@@ -157,6 +175,14 @@ func TestPruneStates(t *testing.T) {
 				require.NoError(t, err)
 			}
 
+			// fill the cache, which must be invalidated by the pruning
+			for h := int64(1); h <= tc.makeHeights; h++ {
+				_, err := stateStore.LoadValidators(h)
+				require.NoError(t, err)
+				_, err = stateStore.LoadConsensusParams(h)
+				require.NoError(t, err)
+			}
+
 			// Test assertions
 			err := stateStore.PruneStates(tc.pruneFrom, tc.pruneTo)
 			if tc.expectErr {
@@ -200,6 +226,64 @@ func TestPruneStates(t *testing.T) {
 	}
 }
 
+func TestPruneStatesExemptions(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StorePruningExemptions([]string{
+		sm.PruningExemptValidatorUpdates, "proposal_passed",
+	}))
+	val, _ := types.RandValidator(true, 10)
+	vals := types.NewValidatorSet([]*types.Validator{val})
+	passed := abci.Event{Type: "proposal_passed", Attributes: []abci.EventAttribute{{Key: []byte("id"), Value: []byte("1")}}}
+	transfer := abci.Event{Type: "transfer"}
+	valUpdate := types.TM2PB.NewValidatorUpdate(val.PubKey, 20)
+
+	for h := int64(1); h <= 5; h++ {
+		err := stateStore.Save(sm.State{
+			InitialHeight:                    1,
+			LastBlockHeight:                  h - 1,
+			LastValidators:                   vals,
+			Validators:                       vals,
+			NextValidators:                   vals,
+			ConsensusParams:                  tmproto.ConsensusParams{Block: tmproto.BlockParams{MaxBytes: 10e6}},
+			LastHeightValidatorsChanged:      1,
+			LastHeightConsensusParamsChanged: 1,
+		})
+		require.NoError(t, err)
+
+		responses := &tmstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{Events: []abci.Event{transfer}},
+			DeliverTxs: []*abci.ResponseDeliverTx{{Events: []abci.Event{transfer}}},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}
+		if h == 2 {
+			responses.DeliverTxs = append(responses.DeliverTxs, &abci.ResponseDeliverTx{Events: []abci.Event{passed}})
+			responses.EndBlock.ValidatorUpdates = []abci.ValidatorUpdate{valUpdate}
+			responses.EndBlock.ConsensusParamUpdates = &abci.ConsensusParams{Block: &abci.BlockParams{MaxBytes: 1}}
+		}
+		require.NoError(t, stateStore.SaveABCIResponses(h, responses))
+	}
+
+	require.NoError(t, stateStore.PruneStates(1, 5))
+
+	for h := int64(1); h < 5; h++ {
+		_, err := stateStore.LoadABCIResponses(h)
+		require.Equal(t, sm.ErrNoABCIResponsesForHeight{Height: h}, err)
+
+		retained, err := stateStore.LoadRetainedABCIResponses(h)
+		if h != 2 {
+			require.Equal(t, sm.ErrNoABCIResponsesForHeight{Height: h}, err, "height %v", h)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Empty(t, retained.BeginBlockEvents)
+		assert.Equal(t, []abci.Event{passed}, retained.DeliverTxEvents)
+		assert.Empty(t, retained.EndBlockEvents)
+		assert.Equal(t, []abci.ValidatorUpdate{valUpdate}, retained.ValidatorUpdates)
+		assert.Nil(t, retained.ConsensusParamUpdates)
+	}
+	_, err := stateStore.LoadABCIResponses(5)
+	require.NoError(t, err)
+}
+
 func TestABCIResponsesResultsHash(t *testing.T) {
 	responses := &tmstate.ABCIResponses{
 		BeginBlock: &abci.ResponseBeginBlock{},
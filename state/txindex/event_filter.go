@@ -0,0 +1,72 @@
+package txindex
+
+import (
+	"strings"
+)
+
+// EventFilter selects the event attributes to index by their composite key
+// (`type.key`). The zero value, and a nil *EventFilter, match all keys.
+type EventFilter struct {
+	include []string
+	exclude []string
+}
+
+// NewEventFilter returns an EventFilter matching the composite keys matched
+// by any of the given patterns, except the ones matched by any of the
+// patterns prefixed with "!". If only exclusions are given, all the other
+// keys are matched. A `*` in a pattern matches any sequence of characters,
+// e.g. "transfer.*" matches all the attributes of the transfer events.
+func NewEventFilter(patterns []string) *EventFilter {
+	f := &EventFilter{}
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "!") {
+			f.exclude = append(f.exclude, pattern[1:])
+		} else {
+			f.include = append(f.include, pattern)
+		}
+	}
+	return f
+}
+
+// Match returns true if the attribute with the given composite key should be
+// indexed.
+func (f *EventFilter) Match(compositeKey string) bool {
+	if f == nil {
+		return true
+	}
+	for _, pattern := range f.exclude {
+		if matchPattern(pattern, compositeKey) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, pattern := range f.include {
+		if matchPattern(pattern, compositeKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern reports whether s matches the pattern, where `*` matches any
+// sequence of characters.
+func matchPattern(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
@@ -0,0 +1,37 @@
+package txindex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFilter(t *testing.T) {
+	testCases := []struct {
+		patterns []string
+		key      string
+		match    bool
+	}{
+		{nil, "transfer.amount", true},
+		{[]string{"transfer.amount"}, "transfer.amount", true},
+		{[]string{"transfer.amount"}, "transfer.sender", false},
+		{[]string{"transfer.*"}, "transfer.sender", true},
+		{[]string{"transfer.*"}, "transfers.sender", false},
+		{[]string{"*.sender"}, "message.sender", true},
+		{[]string{"*.sender"}, "message.recipient", false},
+		{[]string{"a*b*c"}, "a.xbyc", true},
+		{[]string{"a*b*c"}, "a.xcyb", false},
+		{[]string{"!debug.*"}, "debug.trace", false},
+		{[]string{"!debug.*"}, "transfer.amount", true},
+		{[]string{"transfer.*", "!transfer.memo"}, "transfer.memo", false},
+		{[]string{"transfer.*", "!transfer.memo"}, "transfer.amount", true},
+		{[]string{"transfer.*", "!transfer.memo"}, "message.sender", false},
+		{[]string{"transfer.*", "message.sender"}, "message.sender", true},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.match, NewEventFilter(tc.patterns).Match(tc.key), "%v %v", tc.patterns, tc.key)
+	}
+
+	var f *EventFilter
+	assert.True(t, f.Match("transfer.amount"))
+}
@@ -0,0 +1,66 @@
+package txindex
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// EventTransformer rewrites the events of a tx before it is indexed, e.g. to
+// normalize (lowercase addresses...), redact or augment them. It gets a copy
+// of the events, which it may modify in place, and returns the events to
+// index. Since the tx results are stored along with their indexes, the stored
+// results get the transformed events too.
+//
+// The transformer must be deterministic, for the txs to be found by the same
+// queries on all the nodes sharing the transformer.
+type EventTransformer func(height int64, events []abci.Event) []abci.Event
+
+// ChainEventTransformers returns an EventTransformer applying the given
+// transformers in order.
+func ChainEventTransformers(transformers ...EventTransformer) EventTransformer {
+	return func(height int64, events []abci.Event) []abci.Event {
+		for _, transform := range transformers {
+			events = transform(height, events)
+		}
+		return events
+	}
+}
+
+// DropLargeAttributes returns an EventTransformer removing the attributes
+// whose value is larger than maxSize bytes.
+func DropLargeAttributes(maxSize int) EventTransformer {
+	return func(height int64, events []abci.Event) []abci.Event {
+		for i, event := range events {
+			attrs := event.Attributes[:0]
+			for _, attr := range event.Attributes {
+				if len(attr.Value) <= maxSize {
+					attrs = append(attrs, attr)
+				}
+			}
+			events[i].Attributes = attrs
+		}
+		return events
+	}
+}
+
+// copyEvents deep copies the events, which are shared with the other
+// subscribers of the event bus.
+func copyEvents(events []abci.Event) []abci.Event {
+	if events == nil {
+		return nil
+	}
+	cp := make([]abci.Event, len(events))
+	for i, event := range events {
+		cp[i] = abci.Event{Type: event.Type}
+		if event.Attributes != nil {
+			cp[i].Attributes = make([]abci.EventAttribute, len(event.Attributes))
+			for j, attr := range event.Attributes {
+				cp[i].Attributes[j] = abci.EventAttribute{
+					Key:   append([]byte(nil), attr.Key...),
+					Value: append([]byte(nil), attr.Value...),
+					Index: attr.Index,
+				}
+			}
+		}
+	}
+	return cp
+}
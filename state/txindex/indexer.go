@@ -25,6 +25,13 @@ type TxIndexer interface {
 	Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error)
 }
 
+// TxCounter is implemented by the TxIndexers which can count the transactions
+// matching a query without loading them.
+type TxCounter interface {
+	// Count returns the number of transactions matching the query.
+	Count(ctx context.Context, q *query.Query) (int, error)
+}
+
 //----------------------------------------------------
 // Txs are written as a batch
 
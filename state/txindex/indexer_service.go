@@ -2,9 +2,17 @@ package txindex
 
 import (
 	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
 
-	"github.com/tendermint/tendermint/libs/service"
+	dbm "github.com/tendermint/tm-db"
 
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -12,25 +20,94 @@ const (
 	subscriber = "IndexerService"
 )
 
+// checkpointKey is the key of the height of the last indexed block.
+var checkpointKey = []byte("indexer.checkpoint")
+
 // IndexerService connects event bus and transaction indexer together in order
 // to index transactions coming from event bus.
+//
+// The blocks are indexed asynchronously, off the commit path, through a
+// bounded queue (see SetQueueSize). The height of the last indexed block is
+// checkpointed (see SetCheckpoint), so that the blocks committed but not
+// indexed before the node stopped are indexed on start.
 type IndexerService struct {
 	service.BaseService
 
 	idr      TxIndexer
 	eventBus *types.EventBus
+	metrics  *Metrics
+
+	queueSize int
+	queue     chan *indexJob
+
+	checkpointDB dbm.DB
+	blockStore   sm.BlockStore
+	stateStore   sm.Store
+
+	mtx         tmsync.RWMutex
+	transformer EventTransformer
+}
+
+// indexJob is a block waiting to be indexed.
+type indexJob struct {
+	height int64
+	batch  *Batch
 }
 
 // NewIndexerService returns a new service instance.
 func NewIndexerService(idr TxIndexer, eventBus *types.EventBus) *IndexerService {
-	is := &IndexerService{idr: idr, eventBus: eventBus}
+	is := &IndexerService{idr: idr, eventBus: eventBus, metrics: NopMetrics()}
 	is.BaseService = *service.NewBaseService(nil, "IndexerService", is)
 	return is
 }
 
+// SetMetrics sets the metrics. It must be called before the service starts.
+func (is *IndexerService) SetMetrics(metrics *Metrics) {
+	is.metrics = metrics
+}
+
+// SetQueueSize sets the number of blocks waiting to be indexed, past which the
+// event bus is blocked until the indexer catches up (0 - the blocks are
+// indexed as their events are published). It must be called before the
+// service starts.
+func (is *IndexerService) SetQueueSize(size int) {
+	is.queueSize = size
+}
+
+// SetCheckpoint sets the database where the height of the last indexed block
+// is saved, and the stores of the blocks and their results, indexed on start
+// if they were committed but not indexed before the node stopped. It must be
+// called before the service starts.
+func (is *IndexerService) SetCheckpoint(db dbm.DB, blockStore sm.BlockStore, stateStore sm.Store) {
+	is.checkpointDB = db
+	is.blockStore = blockStore
+	is.stateStore = stateStore
+}
+
+// SetEventTransformer sets the transformer applied to the events of the txs
+// before they are indexed. It can be called while the service is running, the
+// txs of the following blocks being transformed.
+func (is *IndexerService) SetEventTransformer(transformer EventTransformer) {
+	is.mtx.Lock()
+	defer is.mtx.Unlock()
+	is.transformer = transformer
+}
+
+func (is *IndexerService) eventTransformer() EventTransformer {
+	is.mtx.RLock()
+	defer is.mtx.RUnlock()
+	return is.transformer
+}
+
 // OnStart implements service.Service by subscribing for all transactions
 // and indexing them by events.
 func (is *IndexerService) OnStart() error {
+	if is.checkpointDB != nil {
+		if err := is.indexMissedBlocks(); err != nil {
+			return err
+		}
+	}
+
 	// Use SubscribeUnbuffered here to ensure both subscriptions does not get
 	// cancelled due to not pulling messages fast enough. Cause this might
 	// sometimes happen when there are no other subscribers.
@@ -48,15 +125,29 @@ func (is *IndexerService) OnStart() error {
 		return err
 	}
 
+	if is.queueSize > 0 {
+		is.queue = make(chan *indexJob, is.queueSize)
+		go is.indexRoutine()
+	}
+
 	go func() {
 		for {
-			msg := <-blockHeadersSub.Out()
+			var msg tmpubsub.Message
+			select {
+			case msg = <-blockHeadersSub.Out():
+			case <-is.Quit():
+				return
+			}
 			eventDataHeader := msg.Data().(types.EventDataNewBlockHeader)
 			height := eventDataHeader.Header.Height
 			batch := NewBatch(eventDataHeader.NumTxs)
+			transform := is.eventTransformer()
 			for i := int64(0); i < eventDataHeader.NumTxs; i++ {
 				msg2 := <-txsSub.Out()
 				txResult := msg2.Data().(types.EventDataTx).TxResult
+				if transform != nil {
+					txResult.Result.Events = transform(height, copyEvents(txResult.Result.Events))
+				}
 				if err = batch.Add(&txResult); err != nil {
 					is.Logger.Error("Can't add tx to batch",
 						"height", height,
@@ -64,10 +155,11 @@ func (is *IndexerService) OnStart() error {
 						"err", err)
 				}
 			}
-			if err = is.idr.AddBatch(batch); err != nil {
-				is.Logger.Error("Failed to index block", "height", height, "err", err)
-			} else {
-				is.Logger.Info("Indexed block", "height", height)
+			job := &indexJob{height: height, batch: batch}
+			if is.queue == nil {
+				is.index(job)
+			} else if !is.enqueue(job) {
+				return
 			}
 		}
 	}()
@@ -75,8 +167,114 @@ func (is *IndexerService) OnStart() error {
 }
 
 // OnStop implements service.Service by unsubscribing from all transactions.
+// The queued blocks are indexed on the next start, if the checkpoint is set.
 func (is *IndexerService) OnStop() {
 	if is.eventBus.IsRunning() {
 		_ = is.eventBus.UnsubscribeAll(context.Background(), subscriber)
 	}
 }
+
+// enqueue queues the block, waiting for the indexer to catch up if the queue
+// is full. It returns false if the service stopped.
+func (is *IndexerService) enqueue(job *indexJob) bool {
+	select {
+	case is.queue <- job:
+	default:
+		is.Logger.Info("Indexing queue is full, waiting for the indexer to catch up", "height", job.height)
+		start := time.Now()
+		select {
+		case is.queue <- job:
+		case <-is.Quit():
+			return false
+		}
+		is.metrics.QueueBlockedSeconds.Add(time.Since(start).Seconds())
+	}
+	is.metrics.QueueSize.Set(float64(len(is.queue)))
+	return true
+}
+
+func (is *IndexerService) indexRoutine() {
+	for {
+		select {
+		case job := <-is.queue:
+			is.metrics.QueueSize.Set(float64(len(is.queue)))
+			is.index(job)
+		case <-is.Quit():
+			return
+		}
+	}
+}
+
+func (is *IndexerService) index(job *indexJob) {
+	start := time.Now()
+	if err := is.idr.AddBatch(job.batch); err != nil {
+		is.Logger.Error("Failed to index block", "height", job.height, "err", err)
+		return
+	}
+	is.metrics.BlockIndexingSeconds.Observe(time.Since(start).Seconds())
+	is.metrics.LastIndexedHeight.Set(float64(job.height))
+	is.Logger.Info("Indexed block", "height", job.height)
+	if is.checkpointDB != nil {
+		if err := is.checkpointDB.SetSync(checkpointKey, int64ToBytes(job.height)); err != nil {
+			is.Logger.Error("Failed to save the indexer checkpoint", "height", job.height, "err", err)
+		}
+	}
+}
+
+// indexMissedBlocks indexes the blocks of the block store above the
+// checkpoint. Without a checkpoint, e.g. on the first start, the blocks of the
+// block store are considered indexed.
+func (is *IndexerService) indexMissedBlocks() error {
+	bz, err := is.checkpointDB.Get(checkpointKey)
+	if err != nil {
+		return fmt.Errorf("can't load the indexer checkpoint: %w", err)
+	}
+	storeHeight := is.blockStore.Height()
+	if bz == nil {
+		return is.checkpointDB.SetSync(checkpointKey, int64ToBytes(storeHeight))
+	}
+	checkpoint := bytesToInt64(bz)
+	if checkpoint < storeHeight {
+		is.Logger.Info("Indexing the blocks missed before the node stopped",
+			"from", checkpoint+1, "to", storeHeight)
+	}
+	transform := is.eventTransformer()
+	for height := checkpoint + 1; height <= storeHeight; height++ {
+		block := is.blockStore.LoadBlock(height)
+		abciResponses, err := is.stateStore.LoadABCIResponses(height)
+		if block == nil || err != nil || len(abciResponses.DeliverTxs) != len(block.Txs) {
+			// e.g. the last block wasn't executed yet, its events are published
+			// on replay
+			is.Logger.Info("Can't index the missed block, no block or results", "height", height, "err", err)
+			break
+		}
+		batch := NewBatch(int64(len(block.Txs)))
+		for i, tx := range block.Txs {
+			txResult := &abci.TxResult{
+				Height: height,
+				Index:  uint32(i),
+				Tx:     tx,
+				Result: *abciResponses.DeliverTxs[i],
+			}
+			if transform != nil {
+				txResult.Result.Events = transform(height, copyEvents(txResult.Result.Events))
+			}
+			if err := batch.Add(txResult); err != nil {
+				return err
+			}
+		}
+		is.index(&indexJob{height: height, batch: batch})
+	}
+	return nil
+}
+
+func int64ToBytes(i int64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutVarint(buf, i)
+	return buf[:n]
+}
+
+func bytesToInt64(bz []byte) int64 {
+	i, _ := binary.Varint(bz)
+	return i
+}
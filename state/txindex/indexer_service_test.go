@@ -1,6 +1,9 @@
 package txindex_test
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,6 +14,9 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/kv"
 	"github.com/tendermint/tendermint/types"
@@ -75,3 +81,184 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, txResult2, res)
 }
+
+func TestIndexerServiceEventTransformer(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	txIndexer := kv.NewTxIndex(db.NewMemDB())
+	service := txindex.NewIndexerService(txIndexer, eventBus)
+	service.SetLogger(log.TestingLogger())
+	service.SetEventTransformer(txindex.ChainEventTransformers(
+		func(height int64, events []abci.Event) []abci.Event {
+			for _, event := range events {
+				for i := range event.Attributes {
+					event.Attributes[i].Value = bytes.ToLower(event.Attributes[i].Value)
+				}
+			}
+			return events
+		},
+		txindex.DropLargeAttributes(8),
+	))
+	require.NoError(t, service.Start())
+	t.Cleanup(func() {
+		if err := service.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	events := []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{
+		{Key: []byte("sender"), Value: []byte("ADDR"), Index: true},
+		{Key: []byte("memo"), Value: []byte("a very long memo"), Index: true},
+	}}}
+	txResult := abci.TxResult{
+		Height: 1,
+		Tx:     types.Tx("foo"),
+		Result: abci.ResponseDeliverTx{Events: events},
+	}
+	require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		NumTxs: 1,
+	}))
+	require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: txResult}))
+
+	time.Sleep(100 * time.Millisecond)
+
+	res, err := txIndexer.Search(context.Background(), query.MustParse("transfer.sender = 'addr'"))
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, []abci.EventAttribute{{Key: []byte("sender"), Value: []byte("addr"), Index: true}},
+		res[0].Result.Events[0].Attributes)
+	res, err = txIndexer.Search(context.Background(), query.MustParse("transfer.memo EXISTS"))
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	// the events published to the other subscribers are left untouched
+	assert.Equal(t, []byte("ADDR"), events[0].Attributes[0].Value)
+}
+
+// slowTxIndex blocks the indexing until released.
+type slowTxIndex struct {
+	txindex.TxIndexer
+	release chan struct{}
+}
+
+func (idx slowTxIndex) AddBatch(b *txindex.Batch) error {
+	<-idx.release
+	return idx.TxIndexer.AddBatch(b)
+}
+
+func TestIndexerServiceIndexesAsynchronously(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	txIndexer := slowTxIndex{TxIndexer: kv.NewTxIndex(db.NewMemDB()), release: make(chan struct{})}
+	service := txindex.NewIndexerService(txIndexer, eventBus)
+	service.SetLogger(log.TestingLogger())
+	service.SetQueueSize(1)
+	require.NoError(t, service.Start())
+	t.Cleanup(func() {
+		if err := service.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// the publisher doesn't wait for the indexer
+	for height := int64(1); height <= 2; height++ {
+		require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: height},
+			NumTxs: 1,
+		}))
+		require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+			Height: height,
+			Tx:     types.Tx(fmt.Sprintf("tx%d", height)),
+		}}))
+	}
+	res, err := txIndexer.Get(types.Tx("tx1").Hash())
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	close(txIndexer.release)
+	assert.Eventually(t, func() bool {
+		res, err := txIndexer.Get(types.Tx("tx2").Hash())
+		return err == nil && res != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+// blockStore serves the given blocks.
+type blockStore struct {
+	sm.BlockStore
+	blocks []*types.Block
+}
+
+func (bs blockStore) Height() int64 { return int64(len(bs.blocks)) }
+
+func (bs blockStore) LoadBlock(height int64) *types.Block {
+	if height < 1 || height > int64(len(bs.blocks)) {
+		return nil
+	}
+	return bs.blocks[height-1]
+}
+
+func TestIndexerServiceIndexesMissedBlocks(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	store := db.NewMemDB()
+	txIndexer := kv.NewTxIndex(store)
+	stateStore := sm.NewStore(db.NewMemDB())
+	blocks := blockStore{}
+	for height := int64(1); height <= 3; height++ {
+		tx := types.Tx(fmt.Sprintf("tx%d", height))
+		blocks.blocks = append(blocks.blocks, &types.Block{
+			Header: types.Header{Height: height},
+			Data:   types.Data{Txs: types.Txs{tx}},
+		})
+		require.NoError(t, stateStore.SaveABCIResponses(height, &tmstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{Data: tx}},
+		}))
+	}
+
+	start := func(blocks blockStore) {
+		service := txindex.NewIndexerService(txIndexer, eventBus)
+		service.SetLogger(log.TestingLogger())
+		service.SetCheckpoint(store, blocks, stateStore)
+		require.NoError(t, service.Start())
+		require.NoError(t, service.Stop())
+	}
+
+	// the blocks committed before the first start are considered indexed
+	start(blockStore{blocks: blocks.blocks[:1]})
+	res, err := txIndexer.Get(types.Tx("tx1").Hash())
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	// the blocks committed since are indexed on start
+	start(blocks)
+	for height := int64(2); height <= 3; height++ {
+		tx := types.Tx(fmt.Sprintf("tx%d", height))
+		res, err := txIndexer.Get(tx.Hash())
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		assert.EqualValues(t, tx, res.Result.Data)
+		assert.Equal(t, height, res.Height)
+	}
+}
@@ -22,18 +22,43 @@ const (
 	tagKeySeparator = "/"
 )
 
-var _ txindex.TxIndexer = (*TxIndex)(nil)
+var (
+	_ txindex.TxIndexer = (*TxIndex)(nil)
+	_ txindex.TxCounter = (*TxIndex)(nil)
+)
 
 // TxIndex is the simplest possible indexer, backed by key-value storage (levelDB).
 type TxIndex struct {
-	store dbm.DB
+	store       dbm.DB
+	txHasher    types.TxHasher
+	eventFilter *txindex.EventFilter
+}
+
+// TxIndexOption sets an optional parameter on the TxIndex.
+type TxIndexOption func(*TxIndex)
+
+// WithTxHasher sets the TxHasher computing the hashes txs are indexed by.
+// Defaults to types.DefaultTxHasher.
+func WithTxHasher(hasher types.TxHasher) TxIndexOption {
+	return func(txi *TxIndex) { txi.txHasher = hasher }
+}
+
+// WithEventFilter sets the filter selecting the event attributes to index,
+// among the ones marked for indexing by the app. Defaults to all of them.
+func WithEventFilter(filter *txindex.EventFilter) TxIndexOption {
+	return func(txi *TxIndex) { txi.eventFilter = filter }
 }
 
 // NewTxIndex creates new KV indexer.
-func NewTxIndex(store dbm.DB) *TxIndex {
-	return &TxIndex{
-		store: store,
+func NewTxIndex(store dbm.DB, options ...TxIndexOption) *TxIndex {
+	txi := &TxIndex{
+		store:    store,
+		txHasher: types.DefaultTxHasher,
+	}
+	for _, option := range options {
+		option(txi)
 	}
+	return txi
 }
 
 // Get gets transaction from the TxIndex storage and returns it or nil if the
@@ -69,7 +94,7 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 	defer storeBatch.Close()
 
 	for _, result := range b.Ops {
-		hash := types.Tx(result.Tx).Hash()
+		hash := txi.txHasher.Hash(result.Tx)
 
 		// index tx by events
 		err := txi.indexEvents(result, hash, storeBatch)
@@ -105,7 +130,7 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 	b := txi.store.NewBatch()
 	defer b.Close()
 
-	hash := types.Tx(result.Tx).Hash()
+	hash := txi.txHasher.Hash(result.Tx)
 
 	// index tx by events
 	err := txi.indexEvents(result, hash, b)
@@ -144,9 +169,9 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 				continue
 			}
 
-			// index if `index: true` is set
+			// index if `index: true` is set, and the key isn't filtered out
 			compositeTag := fmt.Sprintf("%s.%s", event.Type, string(attr.Key))
-			if attr.GetIndex() {
+			if attr.GetIndex() && txi.eventFilter.Match(compositeTag) {
 				err := store.Set(keyForEvent(compositeTag, attr.Value, result), hash)
 				if err != nil {
 					return err
@@ -170,11 +195,43 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
 func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	filteredHashes, err := txi.searchHashes(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*abci.TxResult, 0, len(filteredHashes))
+	for _, h := range filteredHashes {
+		res, err := txi.Get(h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
+		}
+		results = append(results, res)
+
+		// Potentially exit early.
+		select {
+		case <-ctx.Done():
+			break
+		default:
+		}
+	}
+
+	return results, nil
+}
+
+// Count returns the number of txs matching the query, like Search, without
+// loading them.
+func (txi *TxIndex) Count(ctx context.Context, q *query.Query) (int, error) {
+	filteredHashes, err := txi.searchHashes(ctx, q)
+	return len(filteredHashes), err
+}
+
+// searchHashes returns the hashes of the txs matching the query, by hash.
+func (txi *TxIndex) searchHashes(ctx context.Context, q *query.Query) (map[string][]byte, error) {
 	// Potentially exit early.
 	select {
 	case <-ctx.Done():
-		results := make([]*abci.TxResult, 0)
-		return results, nil
+		return nil, nil
 	default:
 	}
 
@@ -192,14 +249,14 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	if err != nil {
 		return nil, fmt.Errorf("error during searching for a hash in the query: %w", err)
 	} else if ok {
-		res, err := txi.Get(hash)
+		found, err := txi.store.Has(hash)
 		switch {
 		case err != nil:
-			return []*abci.TxResult{}, fmt.Errorf("error while retrieving the result: %w", err)
-		case res == nil:
-			return []*abci.TxResult{}, nil
+			return nil, fmt.Errorf("error while retrieving the result: %w", err)
+		case !found:
+			return nil, nil
 		default:
-			return []*abci.TxResult{res}, nil
+			return map[string][]byte{string(hash): hash}, nil
 		}
 	}
 
@@ -252,23 +309,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		}
 	}
 
-	results := make([]*abci.TxResult, 0, len(filteredHashes))
-	for _, h := range filteredHashes {
-		res, err := txi.Get(h)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
-		}
-		results = append(results, res)
-
-		// Potentially exit early.
-		select {
-		case <-ctx.Done():
-			break
-		default:
-		}
-	}
-
-	return results, nil
+	return filteredHashes, nil
 }
 
 func lookForHash(conditions []query.Condition) (hash []byte, ok bool, err error) {
@@ -66,6 +66,51 @@ func TestTxIndex(t *testing.T) {
 	assert.True(t, proto.Equal(txResult2, loadedTxResult2))
 }
 
+func TestTxIndexWithTxHasher(t *testing.T) {
+	hasher, err := types.NewTxHasher(types.TxHashSHA3256, "test")
+	require.NoError(t, err)
+	indexer := NewTxIndex(db.NewMemDB(), WithTxHasher(hasher))
+
+	tx := types.Tx("HELLO WORLD")
+	txResult := &abci.TxResult{Height: 1, Index: 0, Tx: tx}
+	require.NoError(t, indexer.Index(txResult))
+
+	loadedTxResult, err := indexer.Get(hasher.Hash(tx))
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(txResult, loadedTxResult))
+
+	loadedTxResult, err = indexer.Get(tx.Hash())
+	require.NoError(t, err)
+	assert.Nil(t, loadedTxResult)
+}
+
+func TestTxIndexWithEventFilter(t *testing.T) {
+	filter := txindex.NewEventFilter([]string{"account.*", "!account.owner"})
+	indexer := NewTxIndex(db.NewMemDB(), WithEventFilter(filter))
+
+	txResult := txResultWithEvents([]abci.Event{
+		{Type: "account", Attributes: []abci.EventAttribute{{Key: []byte("number"), Value: []byte("1"), Index: true}}},
+		{Type: "account", Attributes: []abci.EventAttribute{{Key: []byte("owner"), Value: []byte("Ivan"), Index: true}}},
+		{Type: "debug", Attributes: []abci.EventAttribute{{Key: []byte("trace"), Value: []byte("x"), Index: true}}},
+	})
+	require.NoError(t, indexer.Index(txResult))
+
+	testCases := map[string]int{
+		"account.number = 1":       1,
+		"account.owner = 'Ivan'":   0,
+		"debug.trace = 'x'":        0,
+		"tx.height = 1":            1,
+		"account.number EXISTS":    1,
+		"account.owner EXISTS":     0,
+		"debug.trace CONTAINS 'x'": 0,
+	}
+	for q, resultsLength := range testCases {
+		results, err := indexer.Search(context.Background(), query.MustParse(q))
+		require.NoError(t, err, q)
+		assert.Len(t, results, resultsLength, q)
+	}
+}
+
 func TestTxSearch(t *testing.T) {
 	indexer := NewTxIndex(db.NewMemDB())
 
@@ -136,6 +181,10 @@ func TestTxSearch(t *testing.T) {
 					assert.True(t, proto.Equal(txResult, txr))
 				}
 			}
+
+			count, err := indexer.Count(ctx, query.MustParse(tc.q))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.resultsLength, count)
 		})
 	}
 }
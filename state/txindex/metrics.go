@@ -0,0 +1,74 @@
+package txindex
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "txindex"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Time taken to index the txs of a block.
+	BlockIndexingSeconds metrics.Histogram
+	// Number of blocks waiting to be indexed.
+	QueueSize metrics.Gauge
+	// Time the event bus was blocked by a full queue, waiting for the indexer
+	// to catch up.
+	QueueBlockedSeconds metrics.Counter
+	// Height of the last indexed block.
+	LastIndexedHeight metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		BlockIndexingSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_indexing_seconds",
+			Help:      "Time taken to index the txs of a block.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 15),
+		}, labels).With(labelsAndValues...),
+		QueueSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "queue_size",
+			Help:      "Number of blocks waiting to be indexed.",
+		}, labels).With(labelsAndValues...),
+		QueueBlockedSeconds: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "queue_blocked_seconds",
+			Help:      "Time the event bus was blocked by a full queue, waiting for the indexer to catch up.",
+		}, labels).With(labelsAndValues...),
+		LastIndexedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "last_indexed_height",
+			Help:      "Height of the last indexed block.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		BlockIndexingSeconds: discard.NewHistogram(),
+		QueueSize:            discard.NewGauge(),
+		QueueBlockedSeconds:  discard.NewCounter(),
+		LastIndexedHeight:    discard.NewGauge(),
+	}
+}
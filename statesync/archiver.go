@@ -0,0 +1,419 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	archiverSubscriber = "SnapshotArchiver"
+
+	archivedSnapshotFile        = "snapshot.json"
+	archivedLightBlockFile      = "light_block-%v.pb"
+	archivedConsensusParamsFile = "consensus_params.pb"
+	archivedChunkPrefix         = "chunk-"
+	archivedTmpSuffix           = ".tmp"
+
+	// archivedLightBlocks is the number of light blocks archived with a
+	// snapshot at height H: those at H, H+1 and H+2 are needed to build the
+	// state to restore it with (see StateProvider.State).
+	archivedLightBlocks = 3
+)
+
+// SnapshotArchiver is a service which, every interval heights, copies the
+// state machine snapshots taken by the app (as listed by the ABCI
+// ListSnapshots method) into a local directory, along with the light blocks
+// (signed header and validator set) and consensus params needed to restore
+// them, so they don't depend on the app's own snapshot storage and can be used
+// to bootstrap nodes (see RestoreArchivedSnapshot). Only the keepRecent most
+// recent snapshots are kept.
+//
+// A snapshot at height H is archived once the block H+2 is committed. It is
+// stored in its own <height>-<format> directory, containing the snapshot.json
+// metadata, the light_block-<height>.pb protobuf-encoded light blocks at H,
+// H+1 and H+2, the consensus_params.pb protobuf-encoded consensus params at
+// H+2 and one chunk-<index> file per chunk.
+type SnapshotArchiver struct {
+	service.BaseService
+
+	conn       proxy.AppConnSnapshot
+	stateStore sm.Store
+	blockStore sm.BlockStore
+	eventBus   *types.EventBus
+
+	dir        string
+	interval   uint64
+	keepRecent uint32
+
+	trigger chan struct{}
+
+	mtx tmsync.Mutex // serializes the archiving and pruning
+}
+
+// NewSnapshotArchiver returns a new SnapshotArchiver storing snapshots in dir.
+func NewSnapshotArchiver(
+	dir string,
+	interval uint64,
+	keepRecent uint32,
+	conn proxy.AppConnSnapshot,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	eventBus *types.EventBus,
+) *SnapshotArchiver {
+	sa := &SnapshotArchiver{
+		conn:       conn,
+		stateStore: stateStore,
+		blockStore: blockStore,
+		eventBus:   eventBus,
+		dir:        dir,
+		interval:   interval,
+		keepRecent: keepRecent,
+		trigger:    make(chan struct{}, 1),
+	}
+	sa.BaseService = *service.NewBaseService(nil, "SnapshotArchiver", sa)
+	return sa
+}
+
+// OnStart implements service.Service.
+func (sa *SnapshotArchiver) OnStart() error {
+	if err := os.MkdirAll(sa.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	sub, err := sa.eventBus.Subscribe(context.Background(), archiverSubscriber, types.EventQueryNewBlockHeader)
+	if err != nil {
+		return err
+	}
+
+	go sa.subscriptionRoutine(sub)
+	go sa.archiveRoutine()
+	return nil
+}
+
+// OnStop implements service.Service.
+func (sa *SnapshotArchiver) OnStop() {
+	if sa.eventBus.IsRunning() {
+		_ = sa.eventBus.UnsubscribeAll(context.Background(), archiverSubscriber)
+	}
+}
+
+// subscriptionRoutine triggers archiving every interval heights, two heights
+// after the snapshots are taken so that their light blocks are available. It
+// never blocks, so a slow app doesn't hold back the event bus.
+func (sa *SnapshotArchiver) subscriptionRoutine(sub types.Subscription) {
+	for {
+		select {
+		case msg := <-sub.Out():
+			height := uint64(msg.Data().(types.EventDataNewBlockHeader).Header.Height)
+			if height < archivedLightBlocks-1 || (height-archivedLightBlocks+1)%sa.interval != 0 {
+				continue
+			}
+			select {
+			case sa.trigger <- struct{}{}:
+			default:
+			}
+		case <-sub.Cancelled():
+			if sub.Err() != nil && !errors.Is(sub.Err(), context.Canceled) && sa.IsRunning() {
+				sa.Logger.Error("Snapshot archiver subscription was cancelled", "err", sub.Err())
+			}
+			return
+		case <-sa.Quit():
+			return
+		}
+	}
+}
+
+func (sa *SnapshotArchiver) archiveRoutine() {
+	for {
+		select {
+		case <-sa.trigger:
+			if err := sa.Archive(); err != nil {
+				sa.Logger.Error("Failed to archive snapshots", "err", err)
+			}
+		case <-sa.Quit():
+			return
+		}
+	}
+}
+
+// Archive stores the app's snapshots which were not archived yet, and prunes
+// the old ones.
+func (sa *SnapshotArchiver) Archive() error {
+	sa.mtx.Lock()
+	defer sa.mtx.Unlock()
+
+	resp, err := sa.conn.ListSnapshotsSync(context.Background(), abci.RequestListSnapshots{})
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	archived, err := ListArchivedSnapshots(sa.dir)
+	if err != nil {
+		return err
+	}
+	stored := make(map[string]bool, len(archived))
+	for _, s := range archived {
+		stored[archivedSnapshotDir(s.Height, s.Format)] = true
+	}
+
+	// only keep the keepRecent most recent snapshots, so don't bother storing
+	// the older ones
+	snapshots := resp.Snapshots
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Height > snapshots[j].Height })
+	if len(snapshots) > int(sa.keepRecent) {
+		snapshots = snapshots[:sa.keepRecent]
+	}
+	storeHeight := sa.blockStore.Height()
+	for _, s := range snapshots {
+		if s == nil || stored[archivedSnapshotDir(s.Height, s.Format)] {
+			continue
+		}
+		if int64(s.Height)+archivedLightBlocks-1 > storeHeight {
+			sa.Logger.Debug("Not archiving the snapshot until its light blocks are committed",
+				"height", s.Height, "format", s.Format)
+			continue
+		}
+		if err := sa.archiveSnapshot(s); err != nil {
+			return fmt.Errorf("failed to archive snapshot at height %v, format %v: %w", s.Height, s.Format, err)
+		}
+		sa.Logger.Info("Archived snapshot", "height", s.Height, "format", s.Format, "chunks", s.Chunks)
+	}
+
+	return sa.prune(sa.keepRecent)
+}
+
+// PruneAllButLatest removes all the archived snapshots but the most recent
+// one, e.g. to free disk space.
+func (sa *SnapshotArchiver) PruneAllButLatest() error {
+	sa.mtx.Lock()
+	defer sa.mtx.Unlock()
+	return sa.prune(1)
+}
+
+func (sa *SnapshotArchiver) archiveSnapshot(s *abci.Snapshot) error {
+	files := make(map[string][]byte, archivedLightBlocks+1)
+	for height := int64(s.Height); height < int64(s.Height)+archivedLightBlocks; height++ {
+		lightBlock, err := sa.lightBlock(height)
+		if err != nil {
+			return err
+		}
+		bz, err := proto.Marshal(lightBlock)
+		if err != nil {
+			return err
+		}
+		files[fmt.Sprintf(archivedLightBlockFile, height)] = bz
+	}
+	params, err := sa.stateStore.LoadConsensusParams(int64(s.Height) + archivedLightBlocks - 1)
+	if err != nil {
+		return err
+	}
+	if files[archivedConsensusParamsFile], err = proto.Marshal(&params); err != nil {
+		return err
+	}
+	snapshotBytes, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	// write everything to a temporary directory first, so that incomplete
+	// snapshots are never listed
+	dir := filepath.Join(sa.dir, archivedSnapshotDir(s.Height, s.Format))
+	tmpDir := dir + archivedTmpSuffix
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	if err := os.Mkdir(tmpDir, 0700); err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for i := uint32(0); i < s.Chunks; i++ {
+		resp, err := sa.conn.LoadSnapshotChunkSync(context.Background(), abci.RequestLoadSnapshotChunk{
+			Height: s.Height,
+			Format: s.Format,
+			Chunk:  i,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load chunk %v: %w", i, err)
+		}
+		if resp.Chunk == nil {
+			return fmt.Errorf("app has no chunk %v", i)
+		}
+		if err := ioutil.WriteFile(filepath.Join(tmpDir, archivedChunkPrefix+strconv.Itoa(int(i))), resp.Chunk, 0600); err != nil {
+			return err
+		}
+	}
+	for name, bz := range files {
+		if err := ioutil.WriteFile(filepath.Join(tmpDir, name), bz, 0600); err != nil {
+			return err
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmpDir, archivedSnapshotFile), snapshotBytes, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpDir, dir)
+}
+
+// lightBlock returns the light block at the given height, from the local
+// block and state stores.
+func (sa *SnapshotArchiver) lightBlock(height int64) (*tmproto.LightBlock, error) {
+	meta := sa.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return nil, fmt.Errorf("no block at height %v", height)
+	}
+	commit := sa.blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		commit = sa.blockStore.LoadSeenCommit(height)
+	}
+	if commit == nil {
+		return nil, fmt.Errorf("no commit at height %v", height)
+	}
+	vals, err := sa.stateStore.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+	lb := &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: &meta.Header,
+			Commit: commit,
+		},
+		ValidatorSet: vals,
+	}
+	return lb.ToProto()
+}
+
+// prune removes all but the keep most recent snapshots.
+func (sa *SnapshotArchiver) prune(keep uint32) error {
+	archived, err := ListArchivedSnapshots(sa.dir)
+	if err != nil {
+		return err
+	}
+	if len(archived) <= int(keep) {
+		return nil
+	}
+	for _, s := range archived[keep:] {
+		if err := os.RemoveAll(filepath.Join(sa.dir, archivedSnapshotDir(s.Height, s.Format))); err != nil {
+			return err
+		}
+		sa.Logger.Info("Pruned archived snapshot", "height", s.Height, "format", s.Format)
+	}
+	return nil
+}
+
+func archivedSnapshotDir(height uint64, format uint32) string {
+	return fmt.Sprintf("%v-%v", height, format)
+}
+
+// ListArchivedSnapshots returns the snapshots archived in the given directory
+// by a SnapshotArchiver, most recent first.
+func ListArchivedSnapshots(dir string) ([]*abci.Snapshot, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]*abci.Snapshot, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasSuffix(entry.Name(), archivedTmpSuffix) {
+			continue
+		}
+		bz, err := ioutil.ReadFile(filepath.Join(dir, entry.Name(), archivedSnapshotFile))
+		if err != nil {
+			return nil, err
+		}
+		s := &abci.Snapshot{}
+		if err := json.Unmarshal(bz, s); err != nil {
+			return nil, fmt.Errorf("invalid archived snapshot %v: %w", entry.Name(), err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		if snapshots[i].Height == snapshots[j].Height {
+			return snapshots[i].Format > snapshots[j].Format
+		}
+		return snapshots[i].Height > snapshots[j].Height
+	})
+	return snapshots, nil
+}
+
+// LoadArchivedSnapshot returns the metadata of the snapshot with the given
+// height and format.
+func LoadArchivedSnapshot(dir string, height uint64, format uint32) (*abci.Snapshot, error) {
+	bz, err := ioutil.ReadFile(filepath.Join(dir, archivedSnapshotDir(height, format), archivedSnapshotFile))
+	if err != nil {
+		return nil, err
+	}
+	s := &abci.Snapshot{}
+	if err := json.Unmarshal(bz, s); err != nil {
+		return nil, fmt.Errorf("invalid archived snapshot %v: %w", archivedSnapshotDir(height, format), err)
+	}
+	return s, nil
+}
+
+// LoadArchivedLightBlocks returns the light blocks at the height of the
+// snapshot with the given height and format, and at the next two heights. It
+// returns an error if they don't form a chain.
+func LoadArchivedLightBlocks(dir string, height uint64, format uint32) ([]*types.LightBlock, error) {
+	lightBlocks := make([]*types.LightBlock, 0, archivedLightBlocks)
+	for h := height; h < height+archivedLightBlocks; h++ {
+		bz, err := ioutil.ReadFile(filepath.Join(dir, archivedSnapshotDir(height, format),
+			fmt.Sprintf(archivedLightBlockFile, h)))
+		if err != nil {
+			return nil, err
+		}
+		pblb := &tmproto.LightBlock{}
+		if err := proto.Unmarshal(bz, pblb); err != nil {
+			return nil, err
+		}
+		lb, err := types.LightBlockFromProto(pblb)
+		if err != nil {
+			return nil, fmt.Errorf("invalid archived light block %v: %w", h, err)
+		}
+		if lb.Height != int64(h) {
+			return nil, fmt.Errorf("archived light block %v has height %v", h, lb.Height)
+		}
+		if len(lightBlocks) > 0 {
+			if prev := lightBlocks[len(lightBlocks)-1]; !bytes.Equal(lb.LastBlockID.Hash, prev.Hash()) {
+				return nil, fmt.Errorf("archived light block %v doesn't follow light block %v", h, prev.Height)
+			}
+		}
+		lightBlocks = append(lightBlocks, lb)
+	}
+	return lightBlocks, nil
+}
+
+// LoadArchivedConsensusParams returns the consensus params stored along with
+// the snapshot with the given height and format, i.e. those at height+2.
+func LoadArchivedConsensusParams(dir string, height uint64, format uint32) (tmproto.ConsensusParams, error) {
+	params := tmproto.ConsensusParams{}
+	bz, err := ioutil.ReadFile(filepath.Join(dir, archivedSnapshotDir(height, format), archivedConsensusParamsFile))
+	if err != nil {
+		return params, err
+	}
+	err = proto.Unmarshal(bz, &params)
+	return params, err
+}
+
+// LoadArchivedChunk returns the given chunk of the snapshot with the given
+// height and format.
+func LoadArchivedChunk(dir string, height uint64, format uint32, chunk uint32) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(dir, archivedSnapshotDir(height, format),
+		archivedChunkPrefix+strconv.Itoa(int(chunk))))
+}
@@ -0,0 +1,229 @@
+package statesync
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
+	"github.com/tendermint/tendermint/proxy"
+	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
+	sm "github.com/tendermint/tendermint/state"
+	smmocks "github.com/tendermint/tendermint/state/mocks"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+	"github.com/tendermint/tendermint/version"
+)
+
+// archiverBlockStore only implements the methods used by the SnapshotArchiver.
+type archiverBlockStore struct {
+	sm.BlockStore
+	vals   *types.ValidatorSet
+	height *int64
+	time   time.Time
+}
+
+func newArchiverBlockStore(vals *types.ValidatorSet, height int64) archiverBlockStore {
+	return archiverBlockStore{vals: vals, height: &height, time: tmtime.Now()}
+}
+
+func (bs archiverBlockStore) Height() int64 {
+	return *bs.height
+}
+
+func (bs archiverBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	header := types.Header{
+		Version:            tmversion.Consensus{Block: version.BlockProtocol},
+		ChainID:            "test",
+		Height:             height,
+		Time:               bs.time.Add(time.Duration(height) * time.Second),
+		ValidatorsHash:     bs.vals.Hash(),
+		NextValidatorsHash: bs.vals.Hash(),
+		AppHash:            []byte{byte(height)},
+		ProposerAddress:    bs.vals.Proposer.Address,
+	}
+	if height > 1 {
+		header.LastBlockID = bs.blockID(height - 1)
+	}
+	return &types.BlockMeta{Header: header}
+}
+
+func (bs archiverBlockStore) blockID(height int64) types.BlockID {
+	return types.BlockID{
+		Hash:          bs.LoadBlockMeta(height).Header.Hash(),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))},
+	}
+}
+
+func (bs archiverBlockStore) LoadBlockCommit(height int64) *types.Commit {
+	return nil
+}
+
+func (bs archiverBlockStore) LoadSeenCommit(height int64) *types.Commit {
+	return &types.Commit{
+		Height:     height,
+		BlockID:    bs.blockID(height),
+		Signatures: []types.CommitSig{types.NewCommitSigAbsent()},
+	}
+}
+
+func TestSnapshotArchiver(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot-archiver")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	vals, _ := types.RandValidatorSet(1, 10)
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadValidators", mock.Anything).Return(vals, nil)
+	stateStore.On("LoadConsensusParams", mock.Anything).Return(*types.DefaultConsensusParams(), nil)
+
+	snapshots := []*abci.Snapshot{
+		{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}},
+	}
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshotsSync", mock.Anything, abci.RequestListSnapshots{}).Return(
+		func(context.Context, abci.RequestListSnapshots) *abci.ResponseListSnapshots {
+			return &abci.ResponseListSnapshots{Snapshots: snapshots}
+		}, nil)
+	conn.On("LoadSnapshotChunkSync", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req abci.RequestLoadSnapshotChunk) *abci.ResponseLoadSnapshotChunk {
+			return &abci.ResponseLoadSnapshotChunk{Chunk: []byte{byte(req.Height), byte(req.Chunk)}}
+		}, nil)
+
+	blockStore := newArchiverBlockStore(vals, 2)
+	sa := NewSnapshotArchiver(dir, 1, 2, conn, stateStore, blockStore, nil)
+	sa.SetLogger(log.TestingLogger())
+
+	// the snapshot isn't archived until the block 3 is committed
+	require.NoError(t, sa.Archive())
+	archived, err := ListArchivedSnapshots(dir)
+	require.NoError(t, err)
+	assert.Empty(t, archived)
+
+	*blockStore.height = 3
+	require.NoError(t, sa.Archive())
+	archived, err = ListArchivedSnapshots(dir)
+	require.NoError(t, err)
+	assert.Equal(t, snapshots, archived)
+
+	lbs, err := LoadArchivedLightBlocks(dir, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, lbs, 3)
+	for i, lb := range lbs {
+		assert.EqualValues(t, i+1, lb.Height)
+		assert.Equal(t, vals.Hash(), lb.ValidatorSet.Hash())
+	}
+	params, err := LoadArchivedConsensusParams(dir, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, *types.DefaultConsensusParams(), params)
+	chunk, err := LoadArchivedChunk(dir, 1, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 0}, chunk)
+
+	// snapshots are only archived once, and old ones are pruned
+	snapshots = []*abci.Snapshot{
+		{Height: 3, Format: 1, Chunks: 2, Hash: []byte{3}},
+		{Height: 2, Format: 1, Chunks: 1, Hash: []byte{2}},
+		{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}},
+	}
+	*blockStore.height = 5
+	require.NoError(t, sa.Archive())
+	archived, err = ListArchivedSnapshots(dir)
+	require.NoError(t, err)
+	assert.Equal(t, snapshots[:2], archived)
+	conn.AssertNumberOfCalls(t, "LoadSnapshotChunkSync", 4)
+
+	chunk, err = LoadArchivedChunk(dir, 3, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3, 1}, chunk)
+	_, err = LoadArchivedLightBlocks(dir, 1, 1)
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, sa.PruneAllButLatest())
+	archived, err = ListArchivedSnapshots(dir)
+	require.NoError(t, err)
+	assert.Equal(t, snapshots[:1], archived)
+}
+
+func TestRestoreArchivedSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot-archiver")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	vals, _ := types.RandValidatorSet(1, 10)
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadValidators", mock.Anything).Return(vals, nil)
+	stateStore.On("LoadConsensusParams", mock.Anything).Return(*types.DefaultConsensusParams(), nil)
+	blockStore := newArchiverBlockStore(vals, 4)
+
+	s := &abci.Snapshot{Height: 2, Format: 1, Chunks: 2, Hash: []byte{2}}
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshotsSync", mock.Anything, abci.RequestListSnapshots{}).Return(
+		&abci.ResponseListSnapshots{Snapshots: []*abci.Snapshot{s}}, nil)
+	conn.On("LoadSnapshotChunkSync", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req abci.RequestLoadSnapshotChunk) *abci.ResponseLoadSnapshotChunk {
+			return &abci.ResponseLoadSnapshotChunk{Chunk: []byte{byte(req.Height), byte(req.Chunk)}}
+		}, nil)
+
+	sa := NewSnapshotArchiver(dir, 1, 2, conn, stateStore, blockStore, nil)
+	sa.SetLogger(log.TestingLogger())
+	require.NoError(t, sa.Archive())
+
+	// restore the snapshot into another app
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	connSnapshot.On("OfferSnapshotSync", mock.Anything, abci.RequestOfferSnapshot{
+		Snapshot: s,
+		AppHash:  []byte{3},
+	}).Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+	for i := uint32(0); i < s.Chunks; i++ {
+		connSnapshot.On("ApplySnapshotChunkSync", mock.Anything, abci.RequestApplySnapshotChunk{
+			Index: i,
+			Chunk: []byte{2, byte(i)},
+		}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	}
+	connQuery := &proxymocks.AppConnQuery{}
+	connQuery.On("InfoSync", mock.Anything, proxy.RequestInfo).Return(&abci.ResponseInfo{
+		AppVersion:       9,
+		LastBlockHeight:  2,
+		LastBlockAppHash: []byte{3},
+	}, nil)
+
+	initialState := sm.State{ChainID: "test", InitialHeight: 1}
+	state, commit, err := RestoreArchivedSnapshot(log.TestingLogger(), connSnapshot, connQuery,
+		dir, 2, 1, initialState, "")
+	require.NoError(t, err)
+	connSnapshot.AssertExpectations(t)
+
+	assert.EqualValues(t, 2, state.LastBlockHeight)
+	assert.Equal(t, blockStore.blockID(2), state.LastBlockID)
+	assert.Equal(t, []byte{3}, []byte(state.AppHash))
+	assert.EqualValues(t, 9, state.Version.Consensus.App)
+	assert.EqualValues(t, 4, state.LastHeightValidatorsChanged)
+	assert.Equal(t, vals.Hash(), state.NextValidators.Hash())
+	assert.Equal(t, *types.DefaultConsensusParams(), state.ConsensusParams)
+	assert.Equal(t, blockStore.blockID(2), commit.BlockID)
+
+	// a snapshot whose light blocks were tampered with is not restored
+	lbs, err := LoadArchivedLightBlocks(dir, 2, 1)
+	require.NoError(t, err)
+	lbs[1].AppHash = []byte{4}
+	pblb, err := lbs[1].ToProto()
+	require.NoError(t, err)
+	bz, err := proto.Marshal(pblb)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "2-1", "light_block-3.pb"), bz, 0600))
+	_, _, err = RestoreArchivedSnapshot(log.TestingLogger(), connSnapshot, connQuery,
+		dir, 2, 1, initialState, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package statesync
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "statesync"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of new snapshots discovered from peers.
+	SnapshotsDiscovered metrics.Counter
+	// Number of snapshots rejected, by reason.
+	SnapshotsRejected metrics.Counter
+	// Number of snapshot chunks fetched from peers.
+	ChunksFetched metrics.Counter
+	// Number of snapshot chunk requests that timed out, or chunks that had to
+	// be fetched again.
+	ChunksFailed metrics.Counter
+	// Rate at which snapshot chunks are fetched, in bytes per second.
+	ChunkFetchRate metrics.Gauge
+	// Time taken to restore the last snapshot, in seconds.
+	RestoreDuration metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		SnapshotsDiscovered: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshots_discovered",
+			Help:      "Number of new snapshots discovered from peers.",
+		}, labels).With(labelsAndValues...),
+		SnapshotsRejected: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshots_rejected",
+			Help:      "Number of snapshots rejected, by reason.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+		ChunksFetched: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunks_fetched",
+			Help:      "Number of snapshot chunks fetched from peers.",
+		}, labels).With(labelsAndValues...),
+		ChunksFailed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunks_failed",
+			Help:      "Number of snapshot chunk requests that timed out, or chunks that had to be fetched again.",
+		}, labels).With(labelsAndValues...),
+		ChunkFetchRate: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunk_fetch_rate",
+			Help:      "Rate at which snapshot chunks are fetched, in bytes per second.",
+		}, labels).With(labelsAndValues...),
+		RestoreDuration: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "restore_duration",
+			Help:      "Time taken to restore the last snapshot, in seconds.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		SnapshotsDiscovered: discard.NewCounter(),
+		SnapshotsRejected:   discard.NewCounter(),
+		ChunksFetched:       discard.NewCounter(),
+		ChunksFailed:        discard.NewCounter(),
+		ChunkFetchRate:      discard.NewGauge(),
+		RestoreDuration:     discard.NewGauge(),
+	}
+}
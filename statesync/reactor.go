@@ -7,6 +7,7 @@ import (
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
@@ -32,6 +33,20 @@ type Reactor struct {
 	conn      proxy.AppConnSnapshot
 	connQuery proxy.AppConnQuery
 	tempDir   string
+	metrics   *Metrics
+	eventBus  types.StateSyncEventPublisher
+
+	// Snapshot advertisements to a peer are delayed by a random jitter, and
+	// are at least advertiseInterval apart. Requests received while an
+	// advertisement is scheduled are coalesced into it.
+	advertiseJitter   time.Duration
+	advertiseInterval time.Duration
+	advertiseMtx      tmsync.Mutex
+	advertised        map[p2p.ID]time.Time   // time of the last advertisement
+	advertiseTimers   map[p2p.ID]*time.Timer // scheduled advertisements
+
+	// ranks the discovered snapshots, see WithSnapshotPolicy
+	snapshotPolicy SnapshotPolicy
 
 	// This will only be set when a state sync is in progress. It is used to feed received
 	// snapshots and chunks into the sync.
@@ -39,16 +54,56 @@ type Reactor struct {
 	syncer *syncer
 }
 
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
 // NewReactor creates a new state sync reactor.
-func NewReactor(conn proxy.AppConnSnapshot, connQuery proxy.AppConnQuery, tempDir string) *Reactor {
+func NewReactor(conn proxy.AppConnSnapshot, connQuery proxy.AppConnQuery, tempDir string,
+	options ...ReactorOption) *Reactor {
 	r := &Reactor{
 		conn:      conn,
 		connQuery: connQuery,
+		metrics:   NopMetrics(),
+		eventBus:  types.NopEventBus{},
+
+		advertised:      make(map[p2p.ID]time.Time),
+		advertiseTimers: make(map[p2p.ID]*time.Timer),
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("StateSync", r)
+	for _, option := range options {
+		option(r)
+	}
 	return r
 }
 
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) ReactorOption {
+	return func(r *Reactor) { r.metrics = metrics }
+}
+
+// WithAdvertisePacing delays the snapshot advertisements answering the requests
+// of a peer by a random duration up to jitter, and spaces the advertisements to
+// the same peer by at least interval. By default, snapshots are advertised
+// right away.
+func WithAdvertisePacing(jitter, interval time.Duration) ReactorOption {
+	return func(r *Reactor) {
+		r.advertiseJitter = jitter
+		r.advertiseInterval = interval
+	}
+}
+
+// WithSnapshotPolicy sets the policy ranking the discovered snapshots, i.e.
+// choosing which ones are attempted first. By default, DefaultSnapshotPolicy
+// is used.
+func WithSnapshotPolicy(policy SnapshotPolicy) ReactorOption {
+	return func(r *Reactor) { r.snapshotPolicy = policy }
+}
+
+// SetEventBus sets the event bus, used to publish the state sync progress.
+func (r *Reactor) SetEventBus(b *types.EventBus) {
+	r.eventBus = b
+}
+
 // GetChannels implements p2p.Reactor.
 func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	return []*p2p.ChannelDescriptor{
@@ -72,6 +127,16 @@ func (r *Reactor) OnStart() error {
 	return nil
 }
 
+// OnStop implements p2p.Reactor.
+func (r *Reactor) OnStop() {
+	r.advertiseMtx.Lock()
+	defer r.advertiseMtx.Unlock()
+	for id, timer := range r.advertiseTimers {
+		timer.Stop()
+		delete(r.advertiseTimers, id)
+	}
+}
+
 // AddPeer implements p2p.Reactor.
 func (r *Reactor) AddPeer(peer p2p.Peer) {
 	r.mtx.RLock()
@@ -83,6 +148,14 @@ func (r *Reactor) AddPeer(peer p2p.Peer) {
 
 // RemovePeer implements p2p.Reactor.
 func (r *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
+	r.advertiseMtx.Lock()
+	if timer, ok := r.advertiseTimers[peer.ID()]; ok {
+		timer.Stop()
+		delete(r.advertiseTimers, peer.ID())
+	}
+	delete(r.advertised, peer.ID())
+	r.advertiseMtx.Unlock()
+
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
 	if r.syncer != nil {
@@ -115,22 +188,7 @@ func (r *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	case SnapshotChannel:
 		switch msg := msg.(type) {
 		case *ssproto.SnapshotsRequest:
-			snapshots, err := r.recentSnapshots(recentSnapshots)
-			if err != nil {
-				r.Logger.Error("Failed to fetch snapshots", "err", err)
-				return
-			}
-			for _, snapshot := range snapshots {
-				r.Logger.Debug("Advertising snapshot", "height", snapshot.Height,
-					"format", snapshot.Format, "peer", src.ID())
-				src.Send(chID, mustEncodeMsg(&ssproto.SnapshotsResponse{
-					Height:   snapshot.Height,
-					Format:   snapshot.Format,
-					Chunks:   snapshot.Chunks,
-					Hash:     snapshot.Hash,
-					Metadata: snapshot.Metadata,
-				}))
-			}
+			r.scheduleAdvertisement(src)
 
 		case *ssproto.SnapshotsResponse:
 			r.mtx.RLock()
@@ -213,6 +271,71 @@ func (r *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	}
 }
 
+// scheduleAdvertisement advertises the recent snapshots to the peer, after a
+// random jitter and no sooner than advertiseInterval after the previous
+// advertisement to the peer. It's a no-op if an advertisement to the peer is
+// already scheduled.
+func (r *Reactor) scheduleAdvertisement(peer p2p.Peer) {
+	r.advertiseMtx.Lock()
+	if _, ok := r.advertiseTimers[peer.ID()]; ok {
+		r.advertiseMtx.Unlock()
+		r.Logger.Debug("Snapshot advertisement already scheduled", "peer", peer.ID())
+		return
+	}
+
+	var delay time.Duration
+	if last, ok := r.advertised[peer.ID()]; ok {
+		delay = time.Until(last.Add(r.advertiseInterval))
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	if r.advertiseJitter > 0 {
+		delay += time.Duration(tmrand.Int63n(int64(r.advertiseJitter)))
+	}
+
+	if delay > 0 {
+		r.Logger.Debug("Scheduling snapshot advertisement", "peer", peer.ID(), "delay", delay)
+		r.advertiseTimers[peer.ID()] = time.AfterFunc(delay, func() {
+			r.advertiseMtx.Lock()
+			if _, ok := r.advertiseTimers[peer.ID()]; !ok { // peer removed or reactor stopped
+				r.advertiseMtx.Unlock()
+				return
+			}
+			delete(r.advertiseTimers, peer.ID())
+			r.advertised[peer.ID()] = time.Now()
+			r.advertiseMtx.Unlock()
+			r.advertiseSnapshots(peer)
+		})
+		r.advertiseMtx.Unlock()
+		return
+	}
+
+	r.advertised[peer.ID()] = time.Now()
+	r.advertiseMtx.Unlock()
+	r.advertiseSnapshots(peer)
+}
+
+// advertiseSnapshots sends the recent snapshots to the peer.
+func (r *Reactor) advertiseSnapshots(peer p2p.Peer) {
+	snapshots, err := r.recentSnapshots(recentSnapshots)
+	if err != nil {
+		r.Logger.Error("Failed to fetch snapshots", "err", err)
+		return
+	}
+	for _, snapshot := range snapshots {
+		r.Logger.Debug("Advertising snapshot", "height", snapshot.Height,
+			"format", snapshot.Format, "peer", peer.ID())
+		peer.Send(SnapshotChannel, mustEncodeMsg(&ssproto.SnapshotsResponse{
+			Height:   snapshot.Height,
+			Format:   snapshot.Format,
+			Chunks:   snapshot.Chunks,
+			Hash:     snapshot.Hash,
+			Metadata: snapshot.Metadata,
+		}))
+	}
+}
+
 // recentSnapshots fetches the n most recent snapshots from the app
 func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 	resp, err := r.conn.ListSnapshotsSync(context.Background(), abci.RequestListSnapshots{})
@@ -255,7 +378,11 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 		r.mtx.Unlock()
 		return sm.State{}, nil, errors.New("a state sync is already in progress")
 	}
-	r.syncer = newSyncer(r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir)
+	r.syncer = newSyncer(r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir,
+		r.metrics, r.eventBus)
+	if r.snapshotPolicy != nil {
+		r.syncer.snapshots.policy = r.snapshotPolicy
+	}
 	r.mtx.Unlock()
 
 	// Request snapshots from all currently connected peers
@@ -2,6 +2,7 @@ package statesync
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -128,8 +129,8 @@ func TestReactor_Receive_SnapshotsRequest(t *testing.T) {
 			// Mock peer to catch responses and store them in a slice
 			responses := []*ssproto.SnapshotsResponse{}
 			peer := &p2pmocks.Peer{}
+			peer.On("ID").Return(p2p.ID("id"))
 			if len(tc.expectResponses) > 0 {
-				peer.On("ID").Return(p2p.ID("id"))
 				peer.On("Send", SnapshotChannel, mock.Anything).Run(func(args mock.Arguments) {
 					msg, err := decodeMsg(args[1].([]byte))
 					require.NoError(t, err)
@@ -156,3 +157,89 @@ func TestReactor_Receive_SnapshotsRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestReactor_Receive_SnapshotsRequest_Pacing(t *testing.T) {
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshotsSync", context.Background(), abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}},
+	}, nil)
+
+	var (
+		mtx   sync.Mutex
+		sends []time.Time
+	)
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("id"))
+	peer.On("Send", SnapshotChannel, mock.Anything).Run(func(args mock.Arguments) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		sends = append(sends, time.Now())
+	}).Return(true)
+	numSends := func() int {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(sends)
+	}
+
+	const interval = 200 * time.Millisecond
+	r := NewReactor(conn, nil, "", WithAdvertisePacing(0, interval))
+	require.NoError(t, r.Start())
+	t.Cleanup(func() {
+		if err := r.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// the first request is answered right away, the next ones are coalesced
+	// and answered once the interval has elapsed
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		r.Receive(SnapshotChannel, peer, mustEncodeMsg(&ssproto.SnapshotsRequest{}))
+	}
+	assert.Equal(t, 1, numSends())
+	require.Eventually(t, func() bool { return numSends() == 2 }, time.Second, 10*time.Millisecond)
+	time.Sleep(interval / 2)
+	assert.Equal(t, 2, numSends())
+
+	mtx.Lock()
+	assert.GreaterOrEqual(t, int64(sends[1].Sub(start)), int64(interval))
+	mtx.Unlock()
+
+	// removing the peer cancels the scheduled advertisement, and resets the
+	// pacing for it
+	r.Receive(SnapshotChannel, peer, mustEncodeMsg(&ssproto.SnapshotsRequest{}))
+	r.RemovePeer(peer, nil)
+	time.Sleep(interval + interval/2)
+	assert.Equal(t, 2, numSends())
+	r.Receive(SnapshotChannel, peer, mustEncodeMsg(&ssproto.SnapshotsRequest{}))
+	assert.Equal(t, 3, numSends())
+}
+
+func TestReactor_Receive_SnapshotsRequest_Jitter(t *testing.T) {
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshotsSync", context.Background(), abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}},
+	}, nil)
+
+	sent := make(chan struct{}, 1)
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("id"))
+	peer.On("Send", SnapshotChannel, mock.Anything).Run(func(args mock.Arguments) {
+		sent <- struct{}{}
+	}).Return(true)
+
+	r := NewReactor(conn, nil, "", WithAdvertisePacing(50*time.Millisecond, 0))
+	require.NoError(t, r.Start())
+	t.Cleanup(func() {
+		if err := r.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	r.Receive(SnapshotChannel, peer, mustEncodeMsg(&ssproto.SnapshotsRequest{}))
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("snapshots were not advertised")
+	}
+}
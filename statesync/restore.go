@@ -0,0 +1,133 @@
+package statesync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// archivedStateProvider is a StateProvider using the light blocks and
+// consensus params archived with the snapshots by a SnapshotArchiver. They
+// were stored by the node itself, so they are trusted.
+type archivedStateProvider struct {
+	dir          string
+	format       uint32
+	initialState sm.State
+}
+
+var _ StateProvider = (*archivedStateProvider)(nil)
+
+func (p *archivedStateProvider) lightBlocks(height uint64) ([]*types.LightBlock, error) {
+	lightBlocks, err := LoadArchivedLightBlocks(p.dir, height, p.format)
+	if err != nil {
+		return nil, err
+	}
+	for _, lb := range lightBlocks {
+		if err := lb.ValidateBasic(p.initialState.ChainID); err != nil {
+			return nil, fmt.Errorf("invalid archived light block %v: %w", lb.Height, err)
+		}
+	}
+	return lightBlocks, nil
+}
+
+// AppHash implements StateProvider.
+func (p *archivedStateProvider) AppHash(ctx context.Context, height uint64) ([]byte, error) {
+	lightBlocks, err := p.lightBlocks(height)
+	if err != nil {
+		return nil, err
+	}
+	return lightBlocks[1].AppHash, nil
+}
+
+// Commit implements StateProvider.
+func (p *archivedStateProvider) Commit(ctx context.Context, height uint64) (*types.Commit, error) {
+	lightBlocks, err := p.lightBlocks(height)
+	if err != nil {
+		return nil, err
+	}
+	return lightBlocks[0].Commit, nil
+}
+
+// State implements StateProvider.
+func (p *archivedStateProvider) State(ctx context.Context, height uint64) (sm.State, error) {
+	lightBlocks, err := p.lightBlocks(height)
+	if err != nil {
+		return sm.State{}, err
+	}
+	params, err := LoadArchivedConsensusParams(p.dir, height, p.format)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	state := sm.State{
+		ChainID:         p.initialState.ChainID,
+		Version:         p.initialState.Version,
+		InitialHeight:   p.initialState.InitialHeight,
+		ConsensusParams: params,
+	}
+	if state.InitialHeight == 0 {
+		state.InitialHeight = 1
+	}
+	setStateFromLightBlocks(&state, lightBlocks[0], lightBlocks[1], lightBlocks[2])
+	return state, nil
+}
+
+// RestoreArchivedSnapshot restores the app from the snapshot with the given
+// height and format archived in dir by a SnapshotArchiver, like a state sync
+// would, and returns the state and block commit which the caller must use to
+// bootstrap the node. The chain ID, version and initial height of the state
+// are taken from initialState, e.g. the genesis state.
+func RestoreArchivedSnapshot(
+	logger log.Logger,
+	conn proxy.AppConnSnapshot,
+	connQuery proxy.AppConnQuery,
+	dir string,
+	height uint64,
+	format uint32,
+	initialState sm.State,
+	tempDir string,
+) (sm.State, *types.Commit, error) {
+	s, err := LoadArchivedSnapshot(dir, height, format)
+	if err != nil {
+		return sm.State{}, nil, err
+	}
+	stateProvider := &archivedStateProvider{dir: dir, format: format, initialState: initialState}
+	appHash, err := stateProvider.AppHash(context.Background(), height)
+	if err != nil {
+		return sm.State{}, nil, err
+	}
+	snapshot := &snapshot{
+		Height:         s.Height,
+		Format:         s.Format,
+		Chunks:         s.Chunks,
+		Hash:           s.Hash,
+		Metadata:       s.Metadata,
+		trustedAppHash: appHash,
+	}
+
+	// all the chunks are queued upfront, so none is fetched from peers
+	chunks, err := newChunkQueue(snapshot, tempDir)
+	if err != nil {
+		return sm.State{}, nil, err
+	}
+	defer chunks.Close()
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		bz, err := LoadArchivedChunk(dir, height, format, i)
+		if err != nil {
+			return sm.State{}, nil, fmt.Errorf("failed to load chunk %v: %w", i, err)
+		}
+		if _, err := chunks.Allocate(); err != nil {
+			return sm.State{}, nil, err
+		}
+		if _, err := chunks.Add(&chunk{Height: height, Format: format, Index: i, Chunk: bz}); err != nil {
+			return sm.State{}, nil, err
+		}
+	}
+
+	syncer := newSyncer(logger, conn, connQuery, stateProvider, tempDir, NopMetrics(), types.NopEventBus{})
+	return syncer.Sync(snapshot, chunks)
+}
@@ -0,0 +1,134 @@
+package statesync
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+const (
+	// SnapshotPreferHeight ranks the snapshots by height, the greatest first.
+	SnapshotPreferHeight = "height"
+	// SnapshotPreferFormat ranks the snapshots by format, the preferred first.
+	SnapshotPreferFormat = "format"
+	// SnapshotPreferPeers ranks the snapshots by the number of peers
+	// advertising them, the most first.
+	SnapshotPreferPeers = "peers"
+)
+
+// SnapshotCandidate is a snapshot discovered from the peers, as ranked by a
+// SnapshotPolicy.
+type SnapshotCandidate struct {
+	Height   uint64
+	Format   uint32
+	Chunks   uint32
+	Hash     []byte
+	Metadata []byte
+	// Peers advertising the snapshot, sorted by ID.
+	Peers []p2p.ID
+}
+
+// SnapshotPolicy ranks the snapshots discovered from the peers: they are
+// attempted in order, until one is restored. It's called with the snapshot
+// pool locked, so it must not block.
+type SnapshotPolicy interface {
+	// Less returns whether the snapshot a must be attempted before b.
+	Less(a, b *SnapshotCandidate) bool
+}
+
+// RuleSnapshotPolicy ranks the snapshots by the criterion given by Prefer
+// (SnapshotPreferHeight, SnapshotPreferFormat or SnapshotPreferPeers), and
+// then by the other ones in that order.
+type RuleSnapshotPolicy struct {
+	Prefer string
+	// Formats in order of preference: the formats listed first are preferred
+	// over the ones listed last, which are preferred over the unlisted ones.
+	// The greatest format is preferred among the unlisted ones.
+	Formats []uint32
+}
+
+// DefaultSnapshotPolicy prefers the snapshot with the greatest height, then
+// the greatest format, then the most peers.
+var DefaultSnapshotPolicy SnapshotPolicy = RuleSnapshotPolicy{Prefer: SnapshotPreferHeight}
+
+// NewRuleSnapshotPolicy returns a RuleSnapshotPolicy, or an error if prefer is
+// not a valid criterion.
+func NewRuleSnapshotPolicy(prefer string, formats []uint32) (RuleSnapshotPolicy, error) {
+	switch prefer {
+	case SnapshotPreferHeight, SnapshotPreferFormat, SnapshotPreferPeers:
+		return RuleSnapshotPolicy{Prefer: prefer, Formats: formats}, nil
+	default:
+		return RuleSnapshotPolicy{}, fmt.Errorf("unknown snapshot preference %q (valid: %s, %s, %s)",
+			prefer, SnapshotPreferHeight, SnapshotPreferFormat, SnapshotPreferPeers)
+	}
+}
+
+// Less implements SnapshotPolicy.
+func (p RuleSnapshotPolicy) Less(a, b *SnapshotCandidate) bool {
+	var criteria []func(a, b *SnapshotCandidate) int
+	switch p.Prefer {
+	case SnapshotPreferFormat:
+		criteria = []func(a, b *SnapshotCandidate) int{p.compareFormat, compareHeight, comparePeers}
+	case SnapshotPreferPeers:
+		criteria = []func(a, b *SnapshotCandidate) int{comparePeers, compareHeight, p.compareFormat}
+	default:
+		criteria = []func(a, b *SnapshotCandidate) int{compareHeight, p.compareFormat, comparePeers}
+	}
+	for _, compare := range criteria {
+		if c := compare(a, b); c != 0 {
+			return c < 0
+		}
+	}
+	return false
+}
+
+// compareHeight returns -1 if a has a greater height than b, 1 if it has a
+// lower one, and 0 otherwise. So do the other compare functions.
+func compareHeight(a, b *SnapshotCandidate) int {
+	switch {
+	case a.Height > b.Height:
+		return -1
+	case a.Height < b.Height:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func comparePeers(a, b *SnapshotCandidate) int {
+	switch {
+	case len(a.Peers) > len(b.Peers):
+		return -1
+	case len(a.Peers) < len(b.Peers):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (p RuleSnapshotPolicy) compareFormat(a, b *SnapshotCandidate) int {
+	ra, rb := p.formatRank(a.Format), p.formatRank(b.Format)
+	switch {
+	case ra < rb:
+		return -1
+	case ra > rb:
+		return 1
+	case a.Format > b.Format:
+		return -1
+	case a.Format < b.Format:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// formatRank returns the index of the format in Formats, or len(Formats) if
+// it's not listed.
+func (p RuleSnapshotPolicy) formatRank(format uint32) int {
+	for i, f := range p.Formats {
+		if f == format {
+			return i
+		}
+	}
+	return len(p.Formats)
+}
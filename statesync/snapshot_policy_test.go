@@ -0,0 +1,94 @@
+package statesync
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/p2p"
+	p2pmocks "github.com/tendermint/tendermint/p2p/mocks"
+	"github.com/tendermint/tendermint/statesync/mocks"
+)
+
+func TestRuleSnapshotPolicy(t *testing.T) {
+	peers := func(n int) []p2p.ID { return make([]p2p.ID, n) }
+	var (
+		h2f1p1 = &SnapshotCandidate{Height: 2, Format: 1, Peers: peers(1)}
+		h2f2p1 = &SnapshotCandidate{Height: 2, Format: 2, Peers: peers(1)}
+		h2f3p2 = &SnapshotCandidate{Height: 2, Format: 3, Peers: peers(2)}
+		h1f1p3 = &SnapshotCandidate{Height: 1, Format: 1, Peers: peers(3)}
+		h1f2p1 = &SnapshotCandidate{Height: 1, Format: 2, Peers: peers(1)}
+	)
+
+	testcases := map[string]struct {
+		prefer  string
+		formats []uint32
+		expect  []*SnapshotCandidate
+	}{
+		"height": {SnapshotPreferHeight, nil, []*SnapshotCandidate{h2f3p2, h2f2p1, h2f1p1, h1f2p1, h1f1p3}},
+		"height then preferred format": {SnapshotPreferHeight, []uint32{1},
+			[]*SnapshotCandidate{h2f1p1, h2f3p2, h2f2p1, h1f1p3, h1f2p1}},
+		"format": {SnapshotPreferFormat, []uint32{2, 1},
+			[]*SnapshotCandidate{h2f2p1, h1f2p1, h2f1p1, h1f1p3, h2f3p2}},
+		"peers": {SnapshotPreferPeers, nil, []*SnapshotCandidate{h1f1p3, h2f3p2, h2f2p1, h2f1p1, h1f2p1}},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			policy, err := NewRuleSnapshotPolicy(tc.prefer, tc.formats)
+			require.NoError(t, err)
+			candidates := []*SnapshotCandidate{h1f2p1, h2f1p1, h1f1p3, h2f3p2, h2f2p1}
+			sort.Slice(candidates, func(i, j int) bool { return policy.Less(candidates[i], candidates[j]) })
+			assert.Equal(t, tc.expect, candidates)
+		})
+	}
+
+	_, err := NewRuleSnapshotPolicy("newest", nil)
+	assert.Error(t, err)
+}
+
+// trustedPeerPolicy prefers the snapshots advertised by a trusted peer.
+type trustedPeerPolicy struct {
+	trusted p2p.ID
+}
+
+func (p trustedPeerPolicy) advertised(c *SnapshotCandidate) bool {
+	for _, peerID := range c.Peers {
+		if peerID == p.trusted {
+			return true
+		}
+	}
+	return false
+}
+
+func (p trustedPeerPolicy) Less(a, b *SnapshotCandidate) bool {
+	if ta, tb := p.advertised(a), p.advertised(b); ta != tb {
+		return ta
+	}
+	return DefaultSnapshotPolicy.Less(a, b)
+}
+
+func TestSnapshotPool_Ranked_Policy(t *testing.T) {
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
+	pool := newSnapshotPool(stateProvider)
+	pool.policy = trustedPeerPolicy{trusted: "b"}
+
+	s1 := &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}
+	s2 := &snapshot{Height: 2, Format: 1, Chunks: 1, Hash: []byte{2}}
+	s3 := &snapshot{Height: 3, Format: 1, Chunks: 1, Hash: []byte{3}}
+	for _, add := range []struct {
+		peerID   p2p.ID
+		snapshot *snapshot
+	}{{"a", s3}, {"a", s2}, {"b", s1}, {"c", s2}, {"b", s2}} {
+		peer := &p2pmocks.Peer{}
+		peer.On("ID").Return(add.peerID)
+		_, err := pool.Add(peer, add.snapshot)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []*snapshot{s2, s1, s3}, pool.Ranked())
+}
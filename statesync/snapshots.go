@@ -43,6 +43,7 @@ func (s *snapshot) Key() snapshotKey {
 // snapshotPool discovers and aggregates snapshots across peers.
 type snapshotPool struct {
 	stateProvider StateProvider
+	policy        SnapshotPolicy
 
 	tmsync.Mutex
 	snapshots     map[snapshotKey]*snapshot
@@ -63,6 +64,7 @@ type snapshotPool struct {
 func newSnapshotPool(stateProvider StateProvider) *snapshotPool {
 	return &snapshotPool{
 		stateProvider:     stateProvider,
+		policy:            DefaultSnapshotPolicy,
 		snapshots:         make(map[snapshotKey]*snapshot),
 		snapshotPeers:     make(map[snapshotKey]map[p2p.ID]p2p.Peer),
 		formatIndex:       make(map[uint32]map[snapshotKey]bool),
@@ -165,39 +167,36 @@ func (p *snapshotPool) GetPeers(snapshot *snapshot) []p2p.Peer {
 	return peers
 }
 
-// Ranked returns a list of snapshots ranked by preference. The current heuristic is very naïve,
-// preferring the snapshot with the greatest height, then greatest format, then greatest number of
-// peers. This can be improved quite a lot.
+// Ranked returns a list of snapshots ranked by preference, as given by the
+// SnapshotPolicy of the pool (DefaultSnapshotPolicy by default).
 func (p *snapshotPool) Ranked() []*snapshot {
 	p.Lock()
 	defer p.Unlock()
 
-	candidates := make([]*snapshot, 0, len(p.snapshots))
-	for _, snapshot := range p.snapshots {
-		candidates = append(candidates, snapshot)
+	snapshots := make([]*snapshot, 0, len(p.snapshots))
+	candidates := make(map[*snapshot]*SnapshotCandidate, len(p.snapshots))
+	for key, snapshot := range p.snapshots {
+		peers := make([]p2p.ID, 0, len(p.snapshotPeers[key]))
+		for peerID := range p.snapshotPeers[key] {
+			peers = append(peers, peerID)
+		}
+		sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+		snapshots = append(snapshots, snapshot)
+		candidates[snapshot] = &SnapshotCandidate{
+			Height:   snapshot.Height,
+			Format:   snapshot.Format,
+			Chunks:   snapshot.Chunks,
+			Hash:     snapshot.Hash,
+			Metadata: snapshot.Metadata,
+			Peers:    peers,
+		}
 	}
 
-	sort.Slice(candidates, func(i, j int) bool {
-		a := candidates[i]
-		b := candidates[j]
-
-		switch {
-		case a.Height > b.Height:
-			return true
-		case a.Height < b.Height:
-			return false
-		case a.Format > b.Format:
-			return true
-		case a.Format < b.Format:
-			return false
-		case len(p.snapshotPeers[a.Key()]) > len(p.snapshotPeers[b.Key()]):
-			return true
-		default:
-			return false
-		}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return p.policy.Less(candidates[snapshots[i]], candidates[snapshots[j]])
 	})
 
-	return candidates
+	return snapshots
 }
 
 // Reject rejects a snapshot. Rejected snapshots will never be used again.
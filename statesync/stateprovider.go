@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
@@ -21,6 +22,11 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
+// maximum number of heights whose light blocks are remembered as cross-checked
+// with the witnesses, so that the witnesses aren't queried again for the
+// heights shared by the AppHash, Commit and State calls of a snapshot.
+const maxCrossCheckedHeights = 100
+
 //go:generate mockery --case underscore --name StateProvider
 
 // StateProvider is a provider of trusted state data for bootstrapping a node. This refers
@@ -41,9 +47,42 @@ type lightClientStateProvider struct {
 	version       tmstate.Version
 	initialHeight int64
 	providers     map[lightprovider.Provider]string
+	trustPeriod   time.Duration
+	minWitnesses  int
+	lightOptions  []light.Option
+
+	// hashes of the light blocks cross-checked with the witnesses, by height,
+	// at most maxCrossCheckedHeights
+	crossChecked map[int64][]byte
+}
+
+// StateProviderOption sets an optional parameter on the light client state
+// provider.
+type StateProviderOption func(*lightClientStateProvider)
+
+// MinWitnesses sets the number of witnesses which must return the same light
+// blocks as the primary for the heights of a snapshot, for it to be trusted.
+// Default: 1.
+func MinWitnesses(n int) StateProviderOption {
+	return func(s *lightClientStateProvider) {
+		s.minWitnesses = n
+	}
+}
+
+// MaxClockDrift sets how far into the future the time of the verified headers
+// can be. Default: the light client default.
+func MaxClockDrift(d time.Duration) StateProviderOption {
+	return func(s *lightClientStateProvider) {
+		s.lightOptions = append(s.lightOptions, light.MaxClockDrift(d))
+	}
 }
 
 // NewLightClientStateProvider creates a new StateProvider using a light client and RPC clients.
+//
+// The light blocks of a snapshot's heights are verified by the light client,
+// then cross-checked with all the witnesses, and must be within the trust
+// period, so that a single malicious RPC server can't make the node restore a
+// forged or expired state.
 func NewLightClientStateProvider(
 	ctx context.Context,
 	chainID string,
@@ -52,10 +91,25 @@ func NewLightClientStateProvider(
 	servers []string,
 	trustOptions light.TrustOptions,
 	logger log.Logger,
+	options ...StateProviderOption,
 ) (StateProvider, error) {
 	if len(servers) < 2 {
 		return nil, fmt.Errorf("at least 2 RPC servers are required, got %v", len(servers))
 	}
+	s := &lightClientStateProvider{
+		version:       version,
+		initialHeight: initialHeight,
+		trustPeriod:   trustOptions.Period,
+		minWitnesses:  1,
+		crossChecked:  make(map[int64][]byte),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	if s.minWitnesses < 1 || s.minWitnesses > len(servers)-1 {
+		return nil, fmt.Errorf("the number of witnesses to cross-check with must be between 1 and %d, got %d",
+			len(servers)-1, s.minWitnesses)
+	}
 
 	providers := make([]lightprovider.Provider, 0, len(servers))
 	providerRemotes := make(map[lightprovider.Provider]string)
@@ -71,17 +125,108 @@ func NewLightClientStateProvider(
 		providerRemotes[provider] = server
 	}
 
+	lightOptions := append([]light.Option{light.Logger(logger), light.MaxRetryAttempts(5)}, s.lightOptions...)
 	lc, err := light.NewClient(ctx, chainID, trustOptions, providers[0], providers[1:],
-		lightdb.New(dbm.NewMemDB(), ""), light.Logger(logger), light.MaxRetryAttempts(5))
+		lightdb.New(dbm.NewMemDB(), ""), lightOptions...)
 	if err != nil {
 		return nil, err
 	}
-	return &lightClientStateProvider{
-		lc:            lc,
-		version:       version,
-		initialHeight: initialHeight,
-		providers:     providerRemotes,
-	}, nil
+	s.lc = lc
+	s.providers = providerRemotes
+	return s, nil
+}
+
+// verifyLightBlockAtHeight verifies the light block at the given height with
+// the light client, then checks that it's within the trust period and that
+// enough witnesses have the same one.
+func (s *lightClientStateProvider) verifyLightBlockAtHeight(ctx context.Context, height int64) (
+	*types.LightBlock, error) {
+	now := time.Now()
+	lb, err := s.lc.VerifyLightBlockAtHeight(ctx, height, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWithinTrustPeriod(lb, s.trustPeriod, now); err != nil {
+		return nil, err
+	}
+	if hash, ok := s.crossChecked[height]; ok && bytes.Equal(hash, lb.Hash()) {
+		return lb, nil
+	}
+	if err := crossCheckWitnesses(ctx, lb, s.lc.Witnesses(), s.minWitnesses); err != nil {
+		return nil, err
+	}
+	s.setCrossChecked(height, lb.Hash())
+	return lb, nil
+}
+
+// setCrossChecked remembers the hash of the light block cross-checked at the
+// given height, forgetting the lowest height once there are
+// maxCrossCheckedHeights of them.
+func (s *lightClientStateProvider) setCrossChecked(height int64, hash []byte) {
+	if _, ok := s.crossChecked[height]; !ok && len(s.crossChecked) >= maxCrossCheckedHeights {
+		lowest := height
+		for h := range s.crossChecked {
+			if h < lowest {
+				lowest = h
+			}
+		}
+		delete(s.crossChecked, lowest)
+	}
+	if len(s.crossChecked) < maxCrossCheckedHeights {
+		s.crossChecked[height] = hash
+	}
+}
+
+// checkWithinTrustPeriod returns an error if the light block is older than the
+// trust period, since the validators who signed it may no longer be bonded, and
+// thus be free to sign a conflicting one.
+func checkWithinTrustPeriod(lb *types.LightBlock, trustPeriod time.Duration, now time.Time) error {
+	if expiresAt := lb.Time.Add(trustPeriod); !expiresAt.After(now) {
+		return fmt.Errorf("light block at height %d expired at %v (trust period %v)",
+			lb.Height, expiresAt, trustPeriod)
+	}
+	return nil
+}
+
+// crossCheckWitnesses fetches the light block of the same height from each of
+// the witnesses, returning an error if any of them has a different one, or if
+// less than minWitnesses have the same one.
+func crossCheckWitnesses(ctx context.Context, lb *types.LightBlock, witnesses []lightprovider.Provider,
+	minWitnesses int) error {
+	type result struct {
+		witness lightprovider.Provider
+		lb      *types.LightBlock
+		err     error
+	}
+	results := make(chan result, len(witnesses))
+	for _, witness := range witnesses {
+		go func(witness lightprovider.Provider) {
+			wlb, err := witness.LightBlock(ctx, lb.Height)
+			results <- result{witness: witness, lb: wlb, err: err}
+		}(witness)
+	}
+
+	var (
+		matched int
+		errs    []string
+	)
+	for range witnesses {
+		r := <-results
+		switch {
+		case r.err != nil:
+			errs = append(errs, fmt.Sprintf("%v: %v", r.witness, r.err))
+		case !bytes.Equal(r.lb.Hash(), lb.Hash()):
+			return fmt.Errorf("witness %v has the light block %X at height %d, the primary has %X",
+				r.witness, r.lb.Hash(), lb.Height, lb.Hash())
+		default:
+			matched++
+		}
+	}
+	if matched < minWitnesses {
+		return fmt.Errorf("only %d of %d witnesses confirmed the light block at height %d, %d required (errors: %s)",
+			matched, len(witnesses), lb.Height, minWitnesses, strings.Join(errs, "; "))
+	}
+	return nil
 }
 
 // AppHash implements StateProvider.
@@ -90,7 +235,7 @@ func (s *lightClientStateProvider) AppHash(ctx context.Context, height uint64) (
 	defer s.Unlock()
 
 	// We have to fetch the next height, which contains the app hash for the previous height.
-	header, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+1), time.Now())
+	header, err := s.verifyLightBlockAtHeight(ctx, int64(height+1))
 	if err != nil {
 		return nil, err
 	}
@@ -102,11 +247,11 @@ func (s *lightClientStateProvider) AppHash(ctx context.Context, height uint64) (
 	// breaking it. We should instead have a Has(ctx, height) method which checks
 	// that the state provider has access to the necessary data for the height.
 	// We piggyback on AppHash() since it's called when adding snapshots to the pool.
-	_, err = s.lc.VerifyLightBlockAtHeight(ctx, int64(height+2), time.Now())
+	_, err = s.verifyLightBlockAtHeight(ctx, int64(height+2))
 	if err != nil {
 		return nil, err
 	}
-	_, err = s.lc.VerifyLightBlockAtHeight(ctx, int64(height), time.Now())
+	_, err = s.verifyLightBlockAtHeight(ctx, int64(height))
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +262,7 @@ func (s *lightClientStateProvider) AppHash(ctx context.Context, height uint64) (
 func (s *lightClientStateProvider) Commit(ctx context.Context, height uint64) (*types.Commit, error) {
 	s.Lock()
 	defer s.Unlock()
-	header, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height), time.Now())
+	header, err := s.verifyLightBlockAtHeight(ctx, int64(height))
 	if err != nil {
 		return nil, err
 	}
@@ -146,28 +291,20 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	//
 	// We need to fetch the NextValidators from height+2 because if the application changed
 	// the validator set at the snapshot height then this only takes effect at height+2.
-	lastLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height), time.Now())
+	lastLightBlock, err := s.verifyLightBlockAtHeight(ctx, int64(height))
 	if err != nil {
 		return sm.State{}, err
 	}
-	curLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+1), time.Now())
+	curLightBlock, err := s.verifyLightBlockAtHeight(ctx, int64(height+1))
 	if err != nil {
 		return sm.State{}, err
 	}
-	nextLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+2), time.Now())
+	nextLightBlock, err := s.verifyLightBlockAtHeight(ctx, int64(height+2))
 	if err != nil {
 		return sm.State{}, err
 	}
 
-	state.LastBlockHeight = lastLightBlock.Height
-	state.LastBlockTime = lastLightBlock.Time
-	state.LastBlockID = lastLightBlock.Commit.BlockID
-	state.AppHash = curLightBlock.AppHash
-	state.LastResultsHash = curLightBlock.LastResultsHash
-	state.LastValidators = lastLightBlock.ValidatorSet
-	state.Validators = curLightBlock.ValidatorSet
-	state.NextValidators = nextLightBlock.ValidatorSet
-	state.LastHeightValidatorsChanged = nextLightBlock.Height
+	setStateFromLightBlocks(&state, lastLightBlock, curLightBlock, nextLightBlock)
 
 	// We'll also need to fetch consensus params via RPC, using light client verification.
 	primaryURL, ok := s.providers[s.lc.Primary()]
@@ -189,6 +326,21 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	return state, nil
 }
 
+// setStateFromLightBlocks sets the fields of the state after a snapshot
+// derived from the light blocks at the snapshot height (last), and the next
+// two heights (cur and next).
+func setStateFromLightBlocks(state *sm.State, last, cur, next *types.LightBlock) {
+	state.LastBlockHeight = last.Height
+	state.LastBlockTime = last.Time
+	state.LastBlockID = last.Commit.BlockID
+	state.AppHash = cur.AppHash
+	state.LastResultsHash = cur.LastResultsHash
+	state.LastValidators = last.ValidatorSet
+	state.Validators = cur.ValidatorSet
+	state.NextValidators = next.ValidatorSet
+	state.LastHeightValidatorsChanged = next.Height
+}
+
 // rpcClient sets up a new RPC client
 func rpcClient(server string) (*rpchttp.HTTP, error) {
 	if !strings.Contains(server, "://") {
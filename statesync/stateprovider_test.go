@@ -0,0 +1,86 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	lightprovider "github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/types"
+)
+
+// witness is a light block provider returning a fixed light block, or error.
+type witness struct {
+	lb  *types.LightBlock
+	err error
+}
+
+func (w witness) LightBlock(context.Context, int64) (*types.LightBlock, error) { return w.lb, w.err }
+func (w witness) ReportEvidence(context.Context, types.Evidence) error         { return nil }
+
+func makeLightBlock(height int64, appHash []byte, t time.Time) *types.LightBlock {
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: &types.Header{ChainID: "test", Height: height, Time: t, AppHash: appHash,
+				ValidatorsHash: []byte("validators_hash")},
+			Commit: &types.Commit{Height: height},
+		},
+	}
+}
+
+func TestCrossCheckWitnesses(t *testing.T) {
+	now := time.Now()
+	lb := makeLightBlock(5, []byte("app_hash"), now)
+	same := witness{lb: makeLightBlock(5, []byte("app_hash"), now)}
+	forged := witness{lb: makeLightBlock(5, []byte("forged"), now)}
+	down := witness{err: errors.New("connection refused")}
+
+	testcases := map[string]struct {
+		witnesses    []lightprovider.Provider
+		minWitnesses int
+		expectErr    bool
+	}{
+		"all match":                  {[]lightprovider.Provider{same, same}, 2, false},
+		"enough match":               {[]lightprovider.Provider{same, down}, 1, false},
+		"too few respond":            {[]lightprovider.Provider{same, down}, 2, true},
+		"none respond":               {[]lightprovider.Provider{down}, 1, true},
+		"conflict":                   {[]lightprovider.Provider{same, forged}, 1, true},
+		"conflict with only witness": {[]lightprovider.Provider{forged}, 1, true},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := crossCheckWitnesses(context.Background(), lb, tc.witnesses, tc.minWitnesses)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetCrossChecked(t *testing.T) {
+	s := &lightClientStateProvider{crossChecked: make(map[int64][]byte)}
+	for h := int64(1); h <= maxCrossCheckedHeights+10; h++ {
+		s.setCrossChecked(h, []byte{byte(h)})
+	}
+	require.Len(t, s.crossChecked, maxCrossCheckedHeights)
+	assert.NotContains(t, s.crossChecked, int64(10))
+	assert.Equal(t, []byte{11}, s.crossChecked[11])
+
+	// a height lower than all the remembered ones isn't remembered
+	s.setCrossChecked(1, []byte{1})
+	require.Len(t, s.crossChecked, maxCrossCheckedHeights)
+	assert.NotContains(t, s.crossChecked, int64(1))
+}
+
+func TestCheckWithinTrustPeriod(t *testing.T) {
+	now := time.Now()
+	require.NoError(t, checkWithinTrustPeriod(makeLightBlock(1, nil, now.Add(-time.Hour)), 2*time.Hour, now))
+	require.Error(t, checkWithinTrustPeriod(makeLightBlock(1, nil, now.Add(-3*time.Hour)), 2*time.Hour, now))
+}
@@ -55,14 +55,19 @@ type syncer struct {
 	connQuery     proxy.AppConnQuery
 	snapshots     *snapshotPool
 	tempDir       string
+	metrics       *Metrics
+	eventBus      types.StateSyncEventPublisher
 
-	mtx    tmsync.RWMutex
-	chunks *chunkQueue
+	mtx          tmsync.RWMutex
+	chunks       *chunkQueue
+	restoreStart time.Time // when the restoration of the current snapshot started
+	bytesFetched int64     // chunk bytes fetched for the current snapshot
 }
 
 // newSyncer creates a new syncer.
 func newSyncer(logger log.Logger, conn proxy.AppConnSnapshot, connQuery proxy.AppConnQuery,
-	stateProvider StateProvider, tempDir string) *syncer {
+	stateProvider StateProvider, tempDir string, metrics *Metrics,
+	eventBus types.StateSyncEventPublisher) *syncer {
 	return &syncer{
 		logger:        logger,
 		stateProvider: stateProvider,
@@ -70,14 +75,16 @@ func newSyncer(logger log.Logger, conn proxy.AppConnSnapshot, connQuery proxy.Ap
 		connQuery:     connQuery,
 		snapshots:     newSnapshotPool(stateProvider),
 		tempDir:       tempDir,
+		metrics:       metrics,
+		eventBus:      eventBus,
 	}
 }
 
 // AddChunk adds a chunk to the chunk queue, if any. It returns false if the chunk has already
 // been added to the queue, or an error if there's no sync in progress.
 func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
-	s.mtx.RLock()
-	defer s.mtx.RUnlock()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
 	if s.chunks == nil {
 		return false, errors.New("no state sync in progress")
 	}
@@ -88,6 +95,11 @@ func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
 	if added {
 		s.logger.Debug("Added chunk to queue", "height", chunk.Height, "format", chunk.Format,
 			"chunk", chunk.Index)
+		s.metrics.ChunksFetched.Add(1)
+		s.bytesFetched += int64(len(chunk.Chunk))
+		if elapsed := time.Since(s.restoreStart).Seconds(); elapsed > 0 {
+			s.metrics.ChunkFetchRate.Set(float64(s.bytesFetched) / elapsed)
+		}
 	} else {
 		s.logger.Debug("Ignoring duplicate chunk in queue", "height", chunk.Height, "format", chunk.Format,
 			"chunk", chunk.Index)
@@ -105,6 +117,7 @@ func (s *syncer) AddSnapshot(peer p2p.Peer, snapshot *snapshot) (bool, error) {
 	if added {
 		s.logger.Info("Discovered new snapshot", "height", snapshot.Height, "format", snapshot.Format,
 			"hash", fmt.Sprintf("%X", snapshot.Hash))
+		s.metrics.SnapshotsDiscovered.Add(1)
 	}
 	return added, nil
 }
@@ -128,6 +141,7 @@ func (s *syncer) RemovePeer(peer p2p.Peer) {
 func (s *syncer) SyncAny(discoveryTime time.Duration) (sm.State, *types.Commit, error) {
 	if discoveryTime > 0 {
 		s.logger.Info(fmt.Sprintf("Discovering snapshots for %v", discoveryTime))
+		s.publishStatus(types.EventDataStateSyncStatus{Status: types.StateSyncStatusDiscovering}, nil)
 		time.Sleep(discoveryTime)
 	}
 
@@ -149,6 +163,7 @@ func (s *syncer) SyncAny(discoveryTime time.Duration) (sm.State, *types.Commit,
 				return sm.State{}, nil, errNoSnapshots
 			}
 			s.logger.Info(fmt.Sprintf("Discovering snapshots for %v", discoveryTime))
+			s.publishStatus(types.EventDataStateSyncStatus{Status: types.StateSyncStatusDiscovering}, nil)
 			time.Sleep(discoveryTime)
 			continue
 		}
@@ -166,6 +181,7 @@ func (s *syncer) SyncAny(discoveryTime time.Duration) (sm.State, *types.Commit,
 			return newState, commit, nil
 
 		case errors.Is(err, errAbort):
+			s.publishFailed(snapshot, err)
 			return sm.State{}, nil, err
 
 		case errors.Is(err, errRetrySnapshot):
@@ -176,19 +192,23 @@ func (s *syncer) SyncAny(discoveryTime time.Duration) (sm.State, *types.Commit,
 
 		case errors.Is(err, errTimeout):
 			s.snapshots.Reject(snapshot)
+			s.publishRejected(snapshot, "timeout")
 			s.logger.Error("Timed out waiting for snapshot chunks, rejected snapshot",
 				"height", snapshot.Height, "format", snapshot.Format, "hash", fmt.Sprintf("%X", snapshot.Hash))
 
 		case errors.Is(err, errRejectSnapshot):
 			s.snapshots.Reject(snapshot)
+			s.publishRejected(snapshot, "snapshot")
 			s.logger.Info("Snapshot rejected", "height", snapshot.Height, "format", snapshot.Format,
 				"hash", fmt.Sprintf("%X", snapshot.Hash))
 
 		case errors.Is(err, errRejectFormat):
 			s.snapshots.RejectFormat(snapshot.Format)
+			s.publishRejected(snapshot, "format")
 			s.logger.Info("Snapshot format rejected", "format", snapshot.Format)
 
 		case errors.Is(err, errRejectSender):
+			s.publishRejected(snapshot, "sender")
 			s.logger.Info("Snapshot senders rejected", "height", snapshot.Height, "format", snapshot.Format,
 				"hash", fmt.Sprintf("%X", snapshot.Hash))
 			for _, peer := range s.snapshots.GetPeers(snapshot) {
@@ -197,6 +217,7 @@ func (s *syncer) SyncAny(discoveryTime time.Duration) (sm.State, *types.Commit,
 			}
 
 		default:
+			s.publishFailed(snapshot, err)
 			return sm.State{}, nil, fmt.Errorf("snapshot restoration failed: %w", err)
 		}
 
@@ -219,6 +240,8 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 		return sm.State{}, nil, errors.New("a state sync is already in progress")
 	}
 	s.chunks = chunks
+	s.restoreStart = time.Now()
+	s.bytesFetched = 0
 	s.mtx.Unlock()
 	defer func() {
 		s.mtx.Lock()
@@ -231,6 +254,7 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	if err != nil {
 		return sm.State{}, nil, err
 	}
+	s.publishStatus(types.EventDataStateSyncStatus{Status: types.StateSyncStatusRestoring}, snapshot)
 
 	// Spawn chunk fetchers. They will terminate when the chunk queue is closed or context cancelled.
 	ctx, cancel := context.WithCancel(context.Background())
@@ -253,7 +277,7 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	}
 
 	// Restore snapshot
-	err = s.applyChunks(chunks)
+	err = s.applyChunks(snapshot, chunks)
 	if err != nil {
 		return sm.State{}, nil, err
 	}
@@ -268,6 +292,13 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	// Done! 🎉
 	s.logger.Info("Snapshot restored", "height", snapshot.Height, "format", snapshot.Format,
 		"hash", fmt.Sprintf("%X", snapshot.Hash))
+	s.mtx.RLock()
+	s.metrics.RestoreDuration.Set(time.Since(s.restoreStart).Seconds())
+	s.mtx.RUnlock()
+	s.publishStatus(types.EventDataStateSyncStatus{
+		Status:        types.StateSyncStatusRestored,
+		ChunksApplied: snapshot.Chunks,
+	}, snapshot)
 
 	return state, commit, nil
 }
@@ -310,7 +341,8 @@ func (s *syncer) offerSnapshot(snapshot *snapshot) error {
 
 // applyChunks applies chunks to the app. It returns various errors depending on the app's
 // response, or nil once the snapshot is fully restored.
-func (s *syncer) applyChunks(chunks *chunkQueue) error {
+func (s *syncer) applyChunks(snapshot *snapshot, chunks *chunkQueue) error {
+	applied := make(map[uint32]bool, chunks.Size())
 	for {
 		chunk, err := chunks.Next()
 		if err == errDone {
@@ -336,6 +368,8 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 			if err != nil {
 				return fmt.Errorf("failed to discard chunk %v: %w", index, err)
 			}
+			delete(applied, index)
+			s.metrics.ChunksFailed.Add(1)
 		}
 
 		// Reject any senders as requested by the app
@@ -351,6 +385,11 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 
 		switch resp.Result {
 		case abci.ResponseApplySnapshotChunk_ACCEPT:
+			applied[chunk.Index] = true
+			s.publishStatus(types.EventDataStateSyncStatus{
+				Status:        types.StateSyncStatusRestoring,
+				ChunksApplied: uint32(len(applied)),
+			}, snapshot)
 		case abci.ResponseApplySnapshotChunk_ABORT:
 			return errAbort
 		case abci.ResponseApplySnapshotChunk_RETRY:
@@ -394,6 +433,7 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		select {
 		case <-chunks.WaitFor(index):
 		case <-ticker.C:
+			s.metrics.ChunksFailed.Add(1)
 			s.requestChunk(snapshot, index)
 		case <-ctx.Done():
 			return
@@ -441,3 +481,36 @@ func (s *syncer) verifyApp(snapshot *snapshot) (uint64, error) {
 		"appHash", fmt.Sprintf("%X", snapshot.trustedAppHash))
 	return resp.AppVersion, nil
 }
+
+// publishStatus publishes a StateSyncStatus event, filling in the details of
+// the given snapshot, if any.
+func (s *syncer) publishStatus(data types.EventDataStateSyncStatus, snapshot *snapshot) {
+	if snapshot != nil {
+		data.Height = snapshot.Height
+		data.Format = snapshot.Format
+		data.ChunksTotal = snapshot.Chunks
+		s.mtx.RLock()
+		data.BytesFetched = s.bytesFetched
+		s.mtx.RUnlock()
+	}
+	if err := s.eventBus.PublishEventStateSyncStatus(data); err != nil {
+		s.logger.Error("Failed to publish state sync status", "status", data.Status, "err", err)
+	}
+}
+
+// publishRejected records the rejection of a snapshot for the given reason.
+func (s *syncer) publishRejected(snapshot *snapshot, reason string) {
+	s.metrics.SnapshotsRejected.With("reason", reason).Add(1)
+	s.publishStatus(types.EventDataStateSyncStatus{
+		Status: types.StateSyncStatusRejected,
+		Reason: reason,
+	}, snapshot)
+}
+
+// publishFailed records the failure of the state sync while restoring a snapshot.
+func (s *syncer) publishFailed(snapshot *snapshot, err error) {
+	s.publishStatus(types.EventDataStateSyncStatus{
+		Status: types.StateSyncStatusFailed,
+		Reason: err.Error(),
+	}, snapshot)
+}
@@ -34,7 +34,8 @@ func setupOfferSyncer(t *testing.T) (*syncer, *proxymocks.AppConnSnapshot) {
 	connSnapshot := &proxymocks.AppConnSnapshot{}
 	stateProvider := &mocks.StateProvider{}
 	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
-	syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+	syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "",
+		NopMetrics(), types.NopEventBus{})
 	return syncer, connSnapshot
 }
 
@@ -87,7 +88,8 @@ func TestSyncer_SyncAny(t *testing.T) {
 	connSnapshot := &proxymocks.AppConnSnapshot{}
 	connQuery := &proxymocks.AppConnQuery{}
 
-	syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+	syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "",
+		NopMetrics(), types.NopEventBus{})
 
 	// Adding a chunk should error when no sync is in progress
 	_, err := syncer.AddChunk(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{1}})
@@ -410,10 +412,12 @@ func TestSyncer_applyChunks_Results(t *testing.T) {
 			connSnapshot := &proxymocks.AppConnSnapshot{}
 			stateProvider := &mocks.StateProvider{}
 			stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
-			syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "",
+				NopMetrics(), types.NopEventBus{})
 
 			body := []byte{1, 2, 3}
-			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 1}, "")
+			s := &snapshot{Height: 1, Format: 1, Chunks: 1}
+			chunks, err := newChunkQueue(s, "")
 			require.NoError(t, err)
 			_, err = chunks.Add(&chunk{Height: 1, Format: 1, Index: 0, Chunk: body})
 			require.NoError(t, err)
@@ -428,7 +432,7 @@ func TestSyncer_applyChunks_Results(t *testing.T) {
 					Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
 			}
 
-			err = syncer.applyChunks(chunks)
+			err = syncer.applyChunks(s, chunks)
 			if tc.expectErr == unknownErr {
 				require.Error(t, err)
 			} else {
@@ -443,6 +447,49 @@ func TestSyncer_applyChunks_Results(t *testing.T) {
 	}
 }
 
+// statusRecorder records the published state sync statuses.
+type statusRecorder struct {
+	mtx      tmsync.Mutex
+	statuses []types.EventDataStateSyncStatus
+}
+
+func (r *statusRecorder) PublishEventStateSyncStatus(data types.EventDataStateSyncStatus) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.statuses = append(r.statuses, data)
+	return nil
+}
+
+func TestSyncer_StatusEvents(t *testing.T) {
+	connQuery := &proxymocks.AppConnQuery{}
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
+	recorder := &statusRecorder{}
+	syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "",
+		NopMetrics(), recorder)
+
+	s := &snapshot{Height: 1, Format: 1, Chunks: 2}
+	chunks, err := newChunkQueue(s, "")
+	require.NoError(t, err)
+	syncer.chunks = chunks
+	for i := uint32(0); i < 2; i++ {
+		_, err = syncer.AddChunk(&chunk{Height: 1, Format: 1, Index: i, Chunk: []byte{1, 2}})
+		require.NoError(t, err)
+		connSnapshot.On("ApplySnapshotChunkSync", ctx, abci.RequestApplySnapshotChunk{
+			Index: i, Chunk: []byte{1, 2},
+		}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	}
+
+	require.NoError(t, syncer.applyChunks(s, chunks))
+	syncer.publishRejected(s, "timeout")
+	assert.Equal(t, []types.EventDataStateSyncStatus{
+		{Status: types.StateSyncStatusRestoring, Height: 1, Format: 1, ChunksTotal: 2, ChunksApplied: 1, BytesFetched: 4},
+		{Status: types.StateSyncStatusRestoring, Height: 1, Format: 1, ChunksTotal: 2, ChunksApplied: 2, BytesFetched: 4},
+		{Status: types.StateSyncStatusRejected, Reason: "timeout", Height: 1, Format: 1, ChunksTotal: 2, BytesFetched: 4},
+	}, recorder.statuses)
+}
+
 func TestSyncer_applyChunks_RefetchChunks(t *testing.T) {
 	// Discarding chunks via refetch_chunks should work the same for all results
 	testcases := map[string]struct {
@@ -461,9 +508,11 @@ func TestSyncer_applyChunks_RefetchChunks(t *testing.T) {
 			connSnapshot := &proxymocks.AppConnSnapshot{}
 			stateProvider := &mocks.StateProvider{}
 			stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
-			syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "",
+				NopMetrics(), types.NopEventBus{})
 
-			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 3}, "")
+			s := &snapshot{Height: 1, Format: 1, Chunks: 3}
+			chunks, err := newChunkQueue(s, "")
 			require.NoError(t, err)
 			added, err := chunks.Add(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{0}})
 			require.True(t, added)
@@ -493,7 +542,7 @@ func TestSyncer_applyChunks_RefetchChunks(t *testing.T) {
 			// check the queue contents, and finally close the queue to end the goroutine.
 			// We don't really care about the result of applyChunks, since it has separate test.
 			go func() {
-				syncer.applyChunks(chunks) //nolint:errcheck // purposefully ignore error
+				syncer.applyChunks(s, chunks) //nolint:errcheck // purposefully ignore error
 			}()
 
 			time.Sleep(50 * time.Millisecond)
@@ -524,7 +573,8 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 			connSnapshot := &proxymocks.AppConnSnapshot{}
 			stateProvider := &mocks.StateProvider{}
 			stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
-			syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "",
+				NopMetrics(), types.NopEventBus{})
 
 			// Set up three peers across two snapshots, and ask for one of them to be banned.
 			// It should be banned from all snapshots.
@@ -584,7 +634,7 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 			// However, it will block on e.g. retry result, so we spawn a goroutine that will
 			// be shut down when the chunk queue closes.
 			go func() {
-				syncer.applyChunks(chunks) //nolint:errcheck // purposefully ignore error
+				syncer.applyChunks(s1, chunks) //nolint:errcheck // purposefully ignore error
 			}()
 
 			time.Sleep(50 * time.Millisecond)
@@ -637,7 +687,8 @@ func TestSyncer_verifyApp(t *testing.T) {
 			connQuery := &proxymocks.AppConnQuery{}
 			connSnapshot := &proxymocks.AppConnSnapshot{}
 			stateProvider := &mocks.StateProvider{}
-			syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "",
+				NopMetrics(), types.NopEventBus{})
 
 			connQuery.On("InfoSync", ctx, proxy.RequestInfo).Return(tc.response, tc.err)
 			version, err := syncer.verifyApp(s)
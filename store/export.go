@@ -0,0 +1,234 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/protoio"
+	tmstore "github.com/tendermint/tendermint/proto/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Formats of the exported blocks.
+const (
+	// Length-delimited tendermint.store.ExportedBlock protobuf messages.
+	ExportFormatProto = "proto"
+	// One JSON object per line, with the block and its commit, as returned by
+	// the RPC.
+	ExportFormatJSON = "json"
+)
+
+// maxExportedBlockSize bounds the size of the protobuf messages read by
+// ImportBlocks.
+var maxExportedBlockSize = types.MaxBlockSizeBytes + int(types.MaxCommitBytes(types.MaxVotesCount))
+
+type exportedBlock struct {
+	Block  *types.Block  `json:"block"`
+	Commit *types.Commit `json:"commit"`
+}
+
+// ExportBlocks writes the blocks of the given height range (inclusive), along
+// with the commits for them, to w in the given format. A zero from or to
+// stands for the first complete block (see BlockStore.PartsBase) or the
+// height of the store. It returns the number of blocks written.
+//
+// NOTE: the store must not be in use by a running node.
+func ExportBlocks(bs *BlockStore, w io.Writer, format string, from, to int64) (int64, error) {
+	if from == 0 {
+		from = bs.PartsBase()
+	}
+	if to == 0 {
+		to = bs.Height()
+	}
+	if bs.Size() == 0 {
+		return 0, errors.New("the block store is empty")
+	}
+	if from < bs.PartsBase() || to > bs.Height() || from > to {
+		return 0, fmt.Errorf("invalid height range %d-%d, the block store has the blocks %d-%d",
+			from, to, bs.PartsBase(), bs.Height())
+	}
+
+	write, err := newBlockWriter(w, format)
+	if err != nil {
+		return 0, err
+	}
+	n := int64(0)
+	for height := from; height <= to; height++ {
+		block := bs.LoadBlock(height)
+		if block == nil {
+			return n, fmt.Errorf("block %d not found", height)
+		}
+		// the canonical commit, included in the next block, or the seen commit
+		// for the last block
+		commit := bs.LoadBlockCommit(height)
+		if commit == nil {
+			commit = bs.LoadSeenCommit(height)
+		}
+		if commit == nil {
+			return n, fmt.Errorf("commit for block %d not found", height)
+		}
+		if err := write(block, commit); err != nil {
+			return n, fmt.Errorf("can't write block %d: %w", height, err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+func newBlockWriter(w io.Writer, format string) (func(*types.Block, *types.Commit) error, error) {
+	switch format {
+	case ExportFormatProto:
+		pw := protoio.NewDelimitedWriter(w)
+		return func(block *types.Block, commit *types.Commit) error {
+			pb, err := block.ToProto()
+			if err != nil {
+				return err
+			}
+			_, err = pw.WriteMsg(&tmstore.ExportedBlock{Block: pb, Commit: commit.ToProto()})
+			return err
+		}, nil
+	case ExportFormatJSON:
+		return func(block *types.Block, commit *types.Commit) error {
+			bz, err := tmjson.Marshal(exportedBlock{Block: block, Commit: commit})
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(append(bz, '\n'))
+			return err
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (expected %q or %q)", format, ExportFormatProto, ExportFormatJSON)
+	}
+}
+
+// CommitVerifier verifies the commit for the block with the given ID, e.g.
+// against the validators of its height.
+type CommitVerifier func(block *types.Block, blockID types.BlockID, commit *types.Commit) error
+
+// ImportBlocks reads the blocks written by ExportBlocks in the given format
+// from r, and saves them in the store. The blocks must follow each other and
+// the last block of the store, and each commit must be for its block, and be
+// accepted by verify if not nil. The blocks already in the store are skipped,
+// provided they're the same. Everything read before an invalid block is kept.
+// It returns the number of blocks saved.
+//
+// NOTE: the store must not be in use by a running node.
+func ImportBlocks(bs *BlockStore, r io.Reader, format string, verify CommitVerifier) (int64, error) {
+	read, err := newBlockReader(r, format)
+	if err != nil {
+		return 0, err
+	}
+
+	var lastBlockID *types.BlockID
+	if meta := bs.LoadBlockMeta(bs.Height()); meta != nil {
+		lastBlockID = &meta.BlockID
+	}
+	n := int64(0)
+	for {
+		block, commit, err := read()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, fmt.Errorf("can't read block: %w", err)
+		}
+		if err := block.ValidateBasic(); err != nil {
+			return n, fmt.Errorf("invalid block %d: %w", block.Height, err)
+		}
+		parts := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+
+		if bs.Size() > 0 && block.Height <= bs.Height() {
+			meta := bs.LoadBlockMeta(block.Height)
+			if meta == nil {
+				return n, fmt.Errorf("block %d is below the base %d of the block store", block.Height, bs.Base())
+			}
+			if !meta.BlockID.Equals(blockID) {
+				return n, fmt.Errorf("block %d differs from the stored one: %v, expected %v",
+					block.Height, blockID, meta.BlockID)
+			}
+			continue
+		}
+		if lastBlockID != nil {
+			if block.Height != bs.Height()+1 {
+				return n, fmt.Errorf("expected block %d, got %d", bs.Height()+1, block.Height)
+			}
+			if !block.LastBlockID.Equals(*lastBlockID) {
+				return n, fmt.Errorf("block %d doesn't follow block %d: its last block ID is %v, expected %v",
+					block.Height, bs.Height(), block.LastBlockID, *lastBlockID)
+			}
+		}
+
+		if err := commit.ValidateBasic(); err != nil {
+			return n, fmt.Errorf("invalid commit for block %d: %w", block.Height, err)
+		}
+		if commit.Height != block.Height || !commit.BlockID.Equals(blockID) {
+			return n, fmt.Errorf("commit for block %d is for block %v at height %d, expected %v",
+				block.Height, commit.BlockID, commit.Height, blockID)
+		}
+		if verify != nil {
+			if err := verify(block, blockID, commit); err != nil {
+				return n, fmt.Errorf("invalid commit for block %d: %w", block.Height, err)
+			}
+		}
+
+		bs.SaveBlock(block, parts, commit)
+		lastBlockID = &blockID
+		n++
+	}
+}
+
+func newBlockReader(r io.Reader, format string) (func() (*types.Block, *types.Commit, error), error) {
+	switch format {
+	case ExportFormatProto:
+		pr := protoio.NewDelimitedReader(r, maxExportedBlockSize)
+		return func() (*types.Block, *types.Commit, error) {
+			var pb tmstore.ExportedBlock
+			if err := pr.ReadMsg(&pb); err != nil {
+				return nil, nil, err
+			}
+			if pb.Block == nil || pb.Commit == nil {
+				return nil, nil, errors.New("missing block or commit")
+			}
+			block, err := types.BlockFromProto(pb.Block)
+			if err != nil {
+				return nil, nil, err
+			}
+			commit, err := types.CommitFromProto(pb.Commit)
+			if err != nil {
+				return nil, nil, err
+			}
+			return block, commit, nil
+		}, nil
+	case ExportFormatJSON:
+		br := bufio.NewReader(r)
+		return func() (*types.Block, *types.Commit, error) {
+			var line []byte
+			for len(line) == 0 {
+				var err error
+				line, err = br.ReadBytes('\n')
+				if err == io.EOF && len(bytes.TrimSpace(line)) > 0 {
+					err = nil
+				}
+				if err != nil {
+					return nil, nil, err
+				}
+				line = bytes.TrimSpace(line)
+			}
+			var eb exportedBlock
+			if err := tmjson.Unmarshal(line, &eb); err != nil {
+				return nil, nil, err
+			}
+			if eb.Block == nil || eb.Commit == nil {
+				return nil, nil, errors.New("missing block or commit")
+			}
+			return eb.Block, eb.Commit, nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (expected %q or %q)", format, ExportFormatProto, ExportFormatJSON)
+	}
+}
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+// makeChain saves n contiguous blocks, each with a commit for it, in a new
+// block store.
+func makeChain(t *testing.T, n int64) *BlockStore {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewNopLogger())
+	t.Cleanup(cleanup)
+
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for height := int64(1); height <= n; height++ {
+		block := makeBlock(height, state, lastCommit)
+		parts := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		commit := types.NewCommit(height, 0, blockID, []types.CommitSig{{
+			BlockIDFlag:      types.BlockIDFlagCommit,
+			ValidatorAddress: tmrand.Bytes(crypto.AddressSize),
+			Timestamp:        tmtime.Now(),
+			Signature:        []byte("Signature"),
+		}})
+		bs.SaveBlock(block, parts, commit)
+
+		state.LastBlockHeight = height
+		state.LastBlockID = blockID
+		lastCommit = commit
+	}
+	return bs
+}
+
+func TestExportImportBlocks(t *testing.T) {
+	src := makeChain(t, 5)
+
+	for _, format := range []string{ExportFormatProto, ExportFormatJSON} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := ExportBlocks(src, &buf, format, 0, 0)
+			require.NoError(t, err)
+			assert.EqualValues(t, 5, n)
+			exported := buf.Bytes()
+
+			dst := NewBlockStore(dbm.NewMemDB())
+			n, err = ImportBlocks(dst, bytes.NewReader(exported), format, nil)
+			require.NoError(t, err)
+			assert.EqualValues(t, 5, n)
+			assert.EqualValues(t, 1, dst.Base())
+			assert.EqualValues(t, 5, dst.Height())
+			for height := int64(1); height <= 5; height++ {
+				assert.Equal(t, src.LoadBlockMeta(height).BlockID, dst.LoadBlockMeta(height).BlockID)
+				assert.Equal(t, src.LoadBlockCommit(height), dst.LoadBlockCommit(height))
+			}
+			assert.Equal(t, src.LoadSeenCommit(5).BlockID, dst.LoadSeenCommit(5).BlockID)
+
+			// the blocks already in the store are skipped
+			n, err = ImportBlocks(dst, bytes.NewReader(exported), format, nil)
+			require.NoError(t, err)
+			assert.EqualValues(t, 0, n)
+		})
+	}
+}
+
+func TestExportBlocksRange(t *testing.T) {
+	src := makeChain(t, 5)
+
+	var buf bytes.Buffer
+	n, err := ExportBlocks(src, &buf, ExportFormatProto, 2, 3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+
+	// an empty store accepts any first block
+	dst := NewBlockStore(dbm.NewMemDB())
+	n, err = ImportBlocks(dst, bytes.NewReader(buf.Bytes()), ExportFormatProto, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+	assert.EqualValues(t, 2, dst.Base())
+	assert.EqualValues(t, 3, dst.Height())
+
+	// the next blocks must follow
+	buf.Reset()
+	_, err = ExportBlocks(src, &buf, ExportFormatProto, 5, 5)
+	require.NoError(t, err)
+	_, err = ImportBlocks(dst, bytes.NewReader(buf.Bytes()), ExportFormatProto, nil)
+	assert.Error(t, err)
+	assert.EqualValues(t, 3, dst.Height())
+
+	// and be those of the same chain
+	buf.Reset()
+	_, err = ExportBlocks(makeChain(t, 4), &buf, ExportFormatProto, 4, 4)
+	require.NoError(t, err)
+	_, err = ImportBlocks(dst, bytes.NewReader(buf.Bytes()), ExportFormatProto, nil)
+	assert.Error(t, err)
+	assert.EqualValues(t, 3, dst.Height())
+
+	_, err = ExportBlocks(src, &buf, ExportFormatProto, 4, 6)
+	assert.Error(t, err)
+	_, err = ExportBlocks(src, &buf, "xml", 0, 0)
+	assert.Error(t, err)
+
+	// the blocks whose parts were pruned can't be exported, and are skipped by
+	// default
+	_, err = src.PruneBlockParts(3)
+	require.NoError(t, err)
+	_, err = ExportBlocks(src, &buf, ExportFormatProto, 2, 5)
+	assert.Error(t, err)
+	buf.Reset()
+	n, err = ExportBlocks(src, &buf, ExportFormatProto, 0, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, n)
+}
+
+func TestImportBlocksVerifiesCommits(t *testing.T) {
+	src := makeChain(t, 3)
+	var buf bytes.Buffer
+	_, err := ExportBlocks(src, &buf, ExportFormatJSON, 0, 0)
+	require.NoError(t, err)
+
+	dst := NewBlockStore(dbm.NewMemDB())
+	n, err := ImportBlocks(dst, &buf, ExportFormatJSON,
+		func(block *types.Block, blockID types.BlockID, commit *types.Commit) error {
+			assert.Equal(t, blockID, commit.BlockID)
+			if block.Height == 3 {
+				return errors.New("invalid signature")
+			}
+			return nil
+		})
+	assert.Error(t, err)
+	assert.EqualValues(t, 2, n)
+	assert.EqualValues(t, 2, dst.Height())
+}
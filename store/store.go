@@ -2,7 +2,10 @@ package store
 
 import (
 	"fmt"
+	"runtime"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	dbm "github.com/tendermint/tm-db"
@@ -26,6 +29,9 @@ well as the Commit.  In the future this may change, perhaps by moving
 the Commit data outside the Block. (TODO)
 
 The store can be assumed to contain all contiguous blocks between base and height (inclusive).
+The block parts, which make up most of the data, may be pruned separately from
+the block metas and commits (see PruneBlockParts): only the blocks from the
+parts base are then complete, while the headers and commits are kept from base.
 
 // NOTE: BlockStore methods will panic if they encounter errors
 // deserializing loaded data, indicating probable corruption on disk.
@@ -38,9 +44,16 @@ type BlockStore struct {
 	// database contents. The only reason for keeping these fields in the struct is that the data
 	// can't efficiently be queried from the database since the key encoding we use is not
 	// lexicographically ordered (see https://github.com/tendermint/tendermint/issues/4567).
-	mtx    tmsync.RWMutex
-	base   int64
-	height int64
+	mtx       tmsync.RWMutex
+	base      int64
+	height    int64
+	partsBase int64 // >= base
+
+	// stateMtx serializes the writes of the BlockStoreState, PruneBlockParts
+	// running concurrently with SaveBlock.
+	stateMtx tmsync.Mutex
+
+	metrics *Metrics
 }
 
 // NewBlockStore returns a new BlockStore with the given DB,
@@ -48,12 +61,20 @@ type BlockStore struct {
 func NewBlockStore(db dbm.DB) *BlockStore {
 	bs := LoadBlockStoreState(db)
 	return &BlockStore{
-		base:   bs.Base,
-		height: bs.Height,
-		db:     db,
+		base:      bs.Base,
+		height:    bs.Height,
+		partsBase: bs.PartsBase,
+		db:        db,
+		metrics:   NopMetrics(),
 	}
 }
 
+// SetMetrics sets the metrics. It must be called before the BlockStore is
+// used.
+func (bs *BlockStore) SetMetrics(metrics *Metrics) {
+	bs.metrics = metrics
+}
+
 // Base returns the first known contiguous block height, or 0 for empty block stores.
 func (bs *BlockStore) Base() int64 {
 	bs.mtx.RLock()
@@ -68,6 +89,15 @@ func (bs *BlockStore) Height() int64 {
 	return bs.height
 }
 
+// PartsBase returns the first height whose block parts are stored, i.e. of the
+// first complete block, or 0 for empty block stores. The blocks below it only
+// have their metas and commits.
+func (bs *BlockStore) PartsBase() int64 {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	return bs.partsBase
+}
+
 // Size returns the number of blocks in the block store.
 func (bs *BlockStore) Size() int64 {
 	bs.mtx.RLock()
@@ -89,8 +119,12 @@ func (bs *BlockStore) LoadBaseMeta() *types.BlockMeta {
 }
 
 // LoadBlock returns the block with the given height.
-// If no block is found for that height, it returns nil.
+// If no block is found for that height, or its parts were pruned, it returns
+// nil.
 func (bs *BlockStore) LoadBlock(height int64) *types.Block {
+	if height < bs.PartsBase() {
+		return nil
+	}
 	var blockMeta = bs.LoadBlockMeta(height)
 	if blockMeta == nil {
 		return nil
@@ -269,6 +303,9 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 		// tries to access missing blocks.
 		bs.mtx.Lock()
 		bs.base = base
+		if bs.partsBase < base {
+			bs.partsBase = base
+		}
 		bs.mtx.Unlock()
 		bs.saveState()
 
@@ -322,6 +359,75 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 	return pruned, nil
 }
 
+// PruneBlockParts removes the block parts of the blocks up to (but not
+// including) a height, keeping their metas and commits, so that the headers
+// and commits are still served. It returns the number of blocks whose parts
+// were pruned.
+func (bs *BlockStore) PruneBlockParts(height int64) (uint64, error) {
+	if height <= 0 {
+		return 0, fmt.Errorf("height must be greater than 0")
+	}
+	bs.mtx.RLock()
+	if height > bs.height {
+		bs.mtx.RUnlock()
+		return 0, fmt.Errorf("cannot prune beyond the latest height %v", bs.height)
+	}
+	partsBase := bs.partsBase
+	bs.mtx.RUnlock()
+	if height <= partsBase {
+		return 0, nil
+	}
+
+	pruned := uint64(0)
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	flush := func(batch dbm.Batch, partsBase int64) error {
+		// update the parts base first, so that no one loads the pruned blocks,
+		// unless PruneBlocks raised it meanwhile
+		bs.mtx.Lock()
+		if bs.partsBase < partsBase {
+			bs.partsBase = partsBase
+		}
+		bs.mtx.Unlock()
+		bs.saveState()
+
+		err := batch.WriteSync()
+		if err != nil {
+			return fmt.Errorf("failed to prune block parts up to height %v: %w", partsBase, err)
+		}
+		batch.Close()
+		return nil
+	}
+
+	for h := partsBase; h < height; h++ {
+		meta := bs.LoadBlockMeta(h)
+		if meta == nil { // assume already deleted
+			continue
+		}
+		for p := 0; p < int(meta.BlockID.PartSetHeader.Total); p++ {
+			if err := batch.Delete(calcBlockPartKey(h, p)); err != nil {
+				return 0, err
+			}
+		}
+		pruned++
+
+		// flush every 1000 blocks to avoid batches becoming too large
+		if pruned%1000 == 0 {
+			err := flush(batch, h+1)
+			if err != nil {
+				return 0, err
+			}
+			batch = bs.db.NewBatch()
+			defer batch.Close()
+		}
+	}
+
+	if err := flush(batch, height); err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
+
 // SaveBlock persists the given block, blockParts, and seenCommit to the underlying db.
 // blockParts: Must be parts of the block
 // seenCommit: The +2/3 precommits that were seen which committed at height.
@@ -343,13 +449,19 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 		panic("BlockStore can only save complete block part sets")
 	}
 
-	// Save block parts. This must be done before the block meta, since callers
-	// typically load the block meta first as an indication that the block exists
-	// and then go on to load block parts - we must make sure the block is
-	// complete as soon as the block meta is written.
-	for i := 0; i < int(blockParts.Total()); i++ {
-		part := blockParts.GetPart(i)
-		bs.saveBlockPart(height, i, part)
+	start := time.Now()
+
+	// Everything is written in a single batch, so callers never see the block
+	// meta (which they typically load first as an indication that the block
+	// exists) before the block parts.
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	// Save block parts
+	for i, partBytes := range encodeBlockParts(blockParts, runtime.NumCPU()) {
+		if err := batch.Set(calcBlockPartKey(height, i), partBytes); err != nil {
+			panic(err)
+		}
 	}
 
 	// Save block meta
@@ -359,17 +471,17 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 		panic("nil blockmeta")
 	}
 	metaBytes := mustEncode(pbm)
-	if err := bs.db.Set(calcBlockMetaKey(height), metaBytes); err != nil {
+	if err := batch.Set(calcBlockMetaKey(height), metaBytes); err != nil {
 		panic(err)
 	}
-	if err := bs.db.Set(calcBlockHashKey(hash), []byte(fmt.Sprintf("%d", height))); err != nil {
+	if err := batch.Set(calcBlockHashKey(hash), []byte(fmt.Sprintf("%d", height))); err != nil {
 		panic(err)
 	}
 
 	// Save block commit (duplicate and separate from the Block)
 	pbc := block.LastCommit.ToProto()
 	blockCommitBytes := mustEncode(pbc)
-	if err := bs.db.Set(calcBlockCommitKey(height-1), blockCommitBytes); err != nil {
+	if err := batch.Set(calcBlockCommitKey(height-1), blockCommitBytes); err != nil {
 		panic(err)
 	}
 
@@ -377,7 +489,30 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	// NOTE: we can delete this at a later height
 	pbsc := seenCommit.ToProto()
 	seenCommitBytes := mustEncode(pbsc)
-	if err := bs.db.Set(calcSeenCommitKey(height), seenCommitBytes); err != nil {
+	if err := batch.Set(calcSeenCommitKey(height), seenCommitBytes); err != nil {
+		panic(err)
+	}
+
+	// Save new BlockStoreState descriptor
+	bs.stateMtx.Lock()
+	defer bs.stateMtx.Unlock()
+	bs.mtx.RLock()
+	bss := tmstore.BlockStoreState{
+		Base:      bs.base,
+		Height:    height,
+		PartsBase: bs.partsBase,
+	}
+	bs.mtx.RUnlock()
+	if bss.Base == 0 {
+		bss.Base = height
+		bss.PartsBase = height
+	}
+	if err := batch.Set(blockStoreKey, mustEncode(&bss)); err != nil {
+		panic(err)
+	}
+
+	// Flush the database
+	if err := batch.WriteSync(); err != nil {
 		panic(err)
 	}
 
@@ -386,29 +521,56 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	bs.height = height
 	if bs.base == 0 {
 		bs.base = height
+		bs.partsBase = height
 	}
 	bs.mtx.Unlock()
 
-	// Save new BlockStoreState descriptor. This also flushes the database.
-	bs.saveState()
+	bs.metrics.BlockWriteTime.Observe(float64(time.Since(start)) / float64(time.Millisecond))
 }
 
-func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part) {
-	pbp, err := part.ToProto()
-	if err != nil {
-		panic(fmt.Errorf("unable to make part into proto: %w", err))
+// encodeBlockParts encodes the parts of the given part set concurrently with
+// up to the given number of workers, and returns them in order.
+func encodeBlockParts(blockParts *types.PartSet, workers int) [][]byte {
+	total := int(blockParts.Total())
+	encoded := make([][]byte, total)
+	errs := make([]error, total)
+
+	if workers > total {
+		workers = total
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < total; i += workers {
+				pbp, err := blockParts.GetPart(i).ToProto()
+				if err != nil {
+					errs[i] = fmt.Errorf("unable to make part into proto: %w", err)
+					continue
+				}
+				encoded[i], errs[i] = proto.Marshal(pbp)
+			}
+		}(w)
 	}
-	partBytes := mustEncode(pbp)
-	if err := bs.db.Set(calcBlockPartKey(height, index), partBytes); err != nil {
-		panic(err)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			panic(err)
+		}
 	}
+	return encoded
 }
 
 func (bs *BlockStore) saveState() {
+	bs.stateMtx.Lock()
+	defer bs.stateMtx.Unlock()
 	bs.mtx.RLock()
 	bss := tmstore.BlockStoreState{
-		Base:   bs.base,
-		Height: bs.height,
+		Base:      bs.base,
+		Height:    bs.height,
+		PartsBase: bs.partsBase,
 	}
 	bs.mtx.RUnlock()
 	SaveBlockStoreState(&bss, bs.db)
@@ -485,6 +647,10 @@ func LoadBlockStoreState(db dbm.DB) tmstore.BlockStoreState {
 	if bsj.Height > 0 && bsj.Base == 0 {
 		bsj.Base = 1
 	}
+	// and from before PartsBase existed, the parts being pruned with the blocks
+	if bsj.PartsBase < bsj.Base {
+		bsj.PartsBase = bsj.Base
+	}
 	return bsj
 }
 
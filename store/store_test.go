@@ -77,10 +77,12 @@ func TestLoadBlockStoreState(t *testing.T) {
 	}
 
 	testCases := []blockStoreTest{
-		{"success", &tmstore.BlockStoreState{Base: 100, Height: 1000},
-			tmstore.BlockStoreState{Base: 100, Height: 1000}},
+		{"success", &tmstore.BlockStoreState{Base: 100, Height: 1000, PartsBase: 200},
+			tmstore.BlockStoreState{Base: 100, Height: 1000, PartsBase: 200}},
 		{"empty", &tmstore.BlockStoreState{}, tmstore.BlockStoreState{}},
-		{"no base", &tmstore.BlockStoreState{Height: 1000}, tmstore.BlockStoreState{Base: 1, Height: 1000}},
+		{"no base", &tmstore.BlockStoreState{Height: 1000}, tmstore.BlockStoreState{Base: 1, Height: 1000, PartsBase: 1}},
+		{"no parts base", &tmstore.BlockStoreState{Base: 100, Height: 1000},
+			tmstore.BlockStoreState{Base: 100, Height: 1000, PartsBase: 100}},
 	}
 
 	for _, tc := range testCases {
@@ -463,8 +465,9 @@ func TestPruneBlocks(t *testing.T) {
 	assert.EqualValues(t, 1500, bs.Height())
 	assert.EqualValues(t, 301, bs.Size())
 	assert.EqualValues(t, tmstore.BlockStoreState{
-		Base:   1200,
-		Height: 1500,
+		Base:      1200,
+		Height:    1500,
+		PartsBase: 1200,
 	}, LoadBlockStoreState(db))
 
 	require.NotNil(t, bs.LoadBlock(1200))
@@ -509,6 +512,77 @@ func TestPruneBlocks(t *testing.T) {
 	assert.Nil(t, bs.LoadBlock(1501))
 }
 
+func TestPruneBlockParts(t *testing.T) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db)
+	assert.EqualValues(t, 0, bs.PartsBase())
+
+	_, err = bs.PruneBlockParts(1)
+	require.Error(t, err)
+
+	// make more than 1000 blocks, to test batch deletions
+	for h := int64(1); h <= 1500; h++ {
+		block := makeBlock(h, state, new(types.Commit))
+		partSet := block.MakePartSet(2)
+		seenCommit := makeTestCommit(h, tmtime.Now())
+		bs.SaveBlock(block, partSet, seenCommit)
+	}
+	assert.EqualValues(t, 1, bs.PartsBase())
+
+	// the parts are pruned, but the metas and commits are kept
+	pruned, err := bs.PruneBlockParts(1200)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1199, pruned)
+	assert.EqualValues(t, 1, bs.Base())
+	assert.EqualValues(t, 1200, bs.PartsBase())
+	assert.EqualValues(t, tmstore.BlockStoreState{
+		Base:      1,
+		Height:    1500,
+		PartsBase: 1200,
+	}, LoadBlockStoreState(db))
+
+	require.Nil(t, bs.LoadBlock(1199))
+	require.Nil(t, bs.LoadBlockPart(1199, 0))
+	require.NotNil(t, bs.LoadBlockMeta(1199))
+	require.NotNil(t, bs.LoadBlockCommit(1199))
+	require.NotNil(t, bs.LoadSeenCommit(1199))
+	require.NotNil(t, bs.LoadBlock(1200))
+
+	// pruning below the parts base is a no-op
+	pruned, err = bs.PruneBlockParts(1100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, pruned)
+
+	_, err = bs.PruneBlockParts(1501)
+	require.Error(t, err)
+
+	// pruning the blocks raises the parts base, pruning the blocks below it
+	// deletes the remaining metas and commits
+	_, err = bs.PruneBlocks(1300)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1300, bs.PartsBase())
+	_, err = bs.PruneBlocks(1100)
+	require.Error(t, err)
+	require.Nil(t, bs.LoadBlockMeta(1199))
+
+	// saving a block keeps the parts base
+	_, err = bs.PruneBlockParts(1402)
+	require.NoError(t, err)
+	block := makeBlock(1501, state, new(types.Commit))
+	bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(1501, tmtime.Now()))
+	assert.EqualValues(t, 1402, bs.PartsBase())
+	require.Nil(t, bs.LoadBlock(1401))
+	require.NotNil(t, bs.LoadBlock(1402))
+
+	// the parts base is restored with the store
+	assert.EqualValues(t, 1402, NewBlockStore(db).PartsBase())
+}
+
 func TestLoadBlockMeta(t *testing.T) {
 	bs, db := freshBlockStore()
 	height := int64(10)
@@ -576,6 +650,47 @@ func TestBlockFetchAtHeight(t *testing.T) {
 	require.Nil(t, blockAtHeightPlus2, "expecting an unsuccessful load of Height()+2")
 }
 
+func TestSaveBlockManyParts(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+	block := makeBlock(1, state, new(types.Commit))
+	partSet := block.MakePartSet(2)
+
+	bs.SaveBlock(block, partSet, makeTestCommit(1, tmtime.Now()))
+
+	for i := 0; i < int(partSet.Total()); i++ {
+		part := bs.LoadBlockPart(1, i)
+		require.NotNil(t, part, "part %d", i)
+		assert.Equal(t, partSet.GetPart(i).Bytes, part.Bytes, "part %d", i)
+	}
+	assert.Equal(t, block.Hash(), bs.LoadBlock(1).Hash())
+
+	bss := LoadBlockStoreState(bs.db)
+	assert.EqualValues(t, 1, bss.Base)
+	assert.EqualValues(t, 1, bss.Height)
+}
+
+func TestEncodeBlockParts(t *testing.T) {
+	state, _, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+	block := makeBlock(1, state, new(types.Commit))
+	partSet := block.MakePartSet(2)
+	total := int(partSet.Total())
+
+	// fewer workers than parts, as many, and more
+	for _, workers := range []int{1, 3, total, total + 1} {
+		encoded := encodeBlockParts(partSet, workers)
+		require.Len(t, encoded, total, "workers %d", workers)
+		for i, bz := range encoded {
+			pbp, err := partSet.GetPart(i).ToProto()
+			require.NoError(t, err)
+			expected, err := proto.Marshal(pbp)
+			require.NoError(t, err)
+			assert.Equal(t, expected, bz, "workers %d, part %d", workers, i)
+		}
+	}
+}
+
 func doFn(fn func() (interface{}, error)) (res interface{}, err error, panicErr error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -129,6 +129,36 @@ type ManifestNode struct {
 	// For more information, look at the readme in the maverick folder.
 	// A list of all behaviors can be found in ../maverick/consensus/behavior.go
 	Misbehaviors map[string]string `toml:"misbehaviors"`
+
+	// Chaos injects faults into the messages sent by the node to its peers.
+	// The faults are chosen using a RNG seeded with the given seed, so that
+	// runs can be reproduced. Defaults to disabled.
+	//
+	// An example of chaos configuration
+	//    { seed = 42, max_latency = "200ms", reorder_rate = 0.05, duplicate_rate = 0.05 }
+	Chaos *ManifestChaos `toml:"chaos"`
+}
+
+// ManifestChaos configures the faults injected into the messages sent by a
+// node, see the p2p test_chaos_* config options.
+type ManifestChaos struct {
+	// Seed of the RNG used to choose the faults.
+	Seed int64 `toml:"seed"`
+
+	// Channels is a comma separated list of channel IDs (e.g. "0x20,0x21") to
+	// inject faults into. Defaults to all channels.
+	Channels string `toml:"channels"`
+
+	// MaxLatency is the maximum latency added to every message, e.g. "200ms".
+	// Defaults to none.
+	MaxLatency string `toml:"max_latency"`
+
+	// ReorderRate, DuplicateRate and CorruptRate are the probabilities for a
+	// message to be sent after the next one on its channel, to be sent twice
+	// and to have a random bit flipped, respectively. Default to 0.
+	ReorderRate   float64 `toml:"reorder_rate"`
+	DuplicateRate float64 `toml:"duplicate_rate"`
+	CorruptRate   float64 `toml:"corrupt_rate"`
 }
 
 // Save saves the testnet manifest to a file.
@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
@@ -84,6 +85,18 @@ type Node struct {
 	PersistentPeers  []*Node
 	Perturbations    []Perturbation
 	Misbehaviors     map[int64]string
+	Chaos            *Chaos
+}
+
+// Chaos configures the faults injected into the messages sent by a node to
+// its peers.
+type Chaos struct {
+	Seed          int64
+	Channels      string
+	MaxLatency    time.Duration
+	ReorderRate   float64
+	DuplicateRate float64
+	CorruptRate   float64
 }
 
 // LoadTestnet loads a testnet from a manifest file, using the filename to
@@ -188,6 +201,21 @@ func LoadTestnet(file string) (*Testnet, error) {
 			}
 			node.Misbehaviors[height] = misbehavior
 		}
+		if c := nodeManifest.Chaos; c != nil {
+			node.Chaos = &Chaos{
+				Seed:          c.Seed,
+				Channels:      c.Channels,
+				ReorderRate:   c.ReorderRate,
+				DuplicateRate: c.DuplicateRate,
+				CorruptRate:   c.CorruptRate,
+			}
+			if c.MaxLatency != "" {
+				node.Chaos.MaxLatency, err = time.ParseDuration(c.MaxLatency)
+				if err != nil {
+					return nil, fmt.Errorf("invalid chaos max_latency %q: %w", c.MaxLatency, err)
+				}
+			}
+		}
 		testnet.Nodes = append(testnet.Nodes, node)
 	}
 
@@ -352,6 +380,17 @@ func (n Node) Validate(testnet Testnet) error {
 		}
 	}
 
+	if n.Chaos != nil {
+		if n.Chaos.MaxLatency < 0 {
+			return errors.New("chaos max_latency can't be negative")
+		}
+		for _, rate := range []float64{n.Chaos.ReorderRate, n.Chaos.DuplicateRate, n.Chaos.CorruptRate} {
+			if rate < 0 || rate > 1 {
+				return fmt.Errorf("chaos rate %v must be between 0 and 1", rate)
+			}
+		}
+	}
+
 	if (n.PrivvalProtocol != "file" || n.Mode != "validator") && len(n.Misbehaviors) != 0 {
 		return errors.New("must be using \"file\" privval protocol to implement misbehaviors")
 	}
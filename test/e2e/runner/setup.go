@@ -311,6 +311,16 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 		}
 		cfg.P2P.PersistentPeers += peer.AddressP2P(true)
 	}
+
+	if node.Chaos != nil {
+		cfg.P2P.TestChaos = true
+		cfg.P2P.TestChaosSeed = node.Chaos.Seed
+		cfg.P2P.TestChaosChannels = node.Chaos.Channels
+		cfg.P2P.TestChaosMaxLatency = node.Chaos.MaxLatency
+		cfg.P2P.TestChaosReorderRate = node.Chaos.ReorderRate
+		cfg.P2P.TestChaosDuplicateRate = node.Chaos.DuplicateRate
+		cfg.P2P.TestChaosCorruptRate = node.Chaos.CorruptRate
+	}
 	return cfg, nil
 }
 
@@ -260,6 +260,13 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 		"protocol-version", res.AppVersion,
 	)
 
+	// Halt if the chain reached an app version upgrade not supported by the app.
+	err = sm.CheckAppVersionUpgrade(h.initialState.AppVersionUpgrade, h.initialState.LastBlockHeight+1,
+		res.AppVersion)
+	if err != nil {
+		return err
+	}
+
 	// Only set the version if there is no existing state.
 	if h.initialState.LastBlockHeight == 0 {
 		h.initialState.Version.Consensus.App = res.AppVersion
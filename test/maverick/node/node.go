@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	_ "net/http/pprof" // nolint: gosec // securely exposed on separate, optional port
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -27,6 +28,7 @@ import (
 	"github.com/tendermint/tendermint/evidence"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/light"
@@ -95,7 +97,29 @@ type DBProvider func(*DBContext) (dbm.DB, error)
 // specified in the ctx.Config.
 func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
 	dbType := dbm.BackendType(ctx.Config.DBBackend)
-	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+	dir := ctx.Config.DBDirFor(ctx.ID)
+	if err := checkDBMoved(ctx.ID, ctx.Config.DBDir(), dir); err != nil {
+		return nil, err
+	}
+	return dbm.NewDB(ctx.ID, dbType, dir)
+}
+
+// checkDBMoved returns an error if the database with the given ID, set to be
+// in dir, is still in dbDir, e.g. because its directory was changed in the
+// config without moving it, which would otherwise start it from scratch.
+func checkDBMoved(id, dbDir, dir string) error {
+	if dir == dbDir {
+		return nil
+	}
+	oldPath, newPath := filepath.Join(dbDir, id+".db"), filepath.Join(dir, id+".db")
+	if !tmos.FileExists(oldPath) {
+		return nil
+	}
+	if tmos.FileExists(newPath) {
+		return fmt.Errorf("the %s database is both in %s and %s: remove the stale one", id, oldPath, newPath)
+	}
+	return fmt.Errorf("the %s database is still in %s: move it to %s (e.g. mv %s %s) or unset %s_db_dir",
+		id, dbDir, dir, oldPath, newPath, id)
 }
 
 // GenesisDocProvider returns a GenesisDoc.
@@ -765,6 +789,7 @@ func NewNode(config *cfg.Config,
 		mempool,
 		evidencePool,
 		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.BlockExecutorWithQueryApp(proxyApp.Query()),
 	)
 
 	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
@@ -1296,7 +1321,7 @@ func makeNodeInfo(
 			bcChannel,
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
 			mempl.MempoolChannel,
-			evidence.EvidenceChannel,
+			evidence.EvidenceChannel, evidence.CommittedEvidenceChannel,
 			statesync.SnapshotChannel, statesync.ChunkChannel,
 		},
 		Moniker: config.Moniker,
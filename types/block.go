@@ -817,6 +817,18 @@ func (commit *Commit) VoteSignBytes(chainID string, valIdx int32) []byte {
 	return VoteSignBytes(chainID, v)
 }
 
+// SignBytes returns the canonical bytes signed by each signature of the
+// commit (nil for the absent ones), i.e. VoteSignBytes for each validator.
+func (commit *Commit) SignBytes(chainID string) [][]byte {
+	signBytes := make([][]byte, len(commit.Signatures))
+	for idx, commitSig := range commit.Signatures {
+		if !commitSig.Absent() {
+			signBytes[idx] = commit.VoteSignBytes(chainID, int32(idx))
+		}
+	}
+	return signBytes
+}
+
 // Type returns the vote type of the commit, which is always VoteTypePrecommit
 // Implements VoteSetReader.
 func (commit *Commit) Type() byte {
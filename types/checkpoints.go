@@ -0,0 +1,34 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+)
+
+// Checkpoints are the hashes of known-good blocks by height, pinned by the
+// operator (see config.FastSyncConfig.Checkpoints), which the blocks synced or
+// replayed must match.
+type Checkpoints map[int64][]byte
+
+// VerifyBlock returns ErrCheckpointMismatch if there is a checkpoint at the
+// height which isn't the hash of the block.
+func (c Checkpoints) VerifyBlock(height int64, hash []byte) error {
+	if pinned, ok := c[height]; ok && !bytes.Equal(pinned, hash) {
+		return ErrCheckpointMismatch{Height: height, Hash: hash, Checkpoint: pinned}
+	}
+	return nil
+}
+
+// ErrCheckpointMismatch is returned when a block contradicts the checkpoint at
+// its height.
+type ErrCheckpointMismatch struct {
+	Height     int64
+	Hash       tmbytes.HexBytes
+	Checkpoint tmbytes.HexBytes
+}
+
+func (e ErrCheckpointMismatch) Error() string {
+	return fmt.Sprintf("block #%d %v contradicts the checkpoint %v", e.Height, e.Hash, e.Checkpoint)
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+func TestCheckpointsVerifyBlock(t *testing.T) {
+	hash := tmhash.Sum([]byte("block"))
+	checkpoints := Checkpoints{10: hash}
+
+	assert.NoError(t, checkpoints.VerifyBlock(10, hash))
+	assert.NoError(t, checkpoints.VerifyBlock(11, tmhash.Sum([]byte("other"))))
+	err := checkpoints.VerifyBlock(10, tmhash.Sum([]byte("other")))
+	assert.True(t, errors.As(err, &ErrCheckpointMismatch{}), err)
+
+	assert.NoError(t, Checkpoints(nil).VerifyBlock(10, hash))
+}
@@ -32,7 +32,8 @@ type Subscription interface {
 // EventBus to ensure correct data types.
 type EventBus struct {
 	service.BaseService
-	pubsub *tmpubsub.Server
+	pubsub   *tmpubsub.Server
+	txHasher TxHasher
 }
 
 // NewEventBus returns a new event bus.
@@ -44,7 +45,7 @@ func NewEventBus() *EventBus {
 func NewEventBusWithBufferCapacity(cap int) *EventBus {
 	// capacity could be exposed later if needed
 	pubsub := tmpubsub.NewServer(tmpubsub.BufferCapacity(cap))
-	b := &EventBus{pubsub: pubsub}
+	b := &EventBus{pubsub: pubsub, txHasher: DefaultTxHasher}
 	b.BaseService = *service.NewBaseService(nil, "EventBus", b)
 	return b
 }
@@ -54,6 +55,19 @@ func (b *EventBus) SetLogger(l log.Logger) {
 	b.pubsub.SetLogger(l.With("module", "pubsub"))
 }
 
+// SetTxHasher sets the TxHasher computing the tx.hash of tx events. It must be
+// called before the event bus is started. If not called, it defaults to
+// DefaultTxHasher.
+func (b *EventBus) SetTxHasher(hasher TxHasher) {
+	b.txHasher = hasher
+}
+
+// SetMetrics sets the metrics of the subscriptions (see tmpubsub.Metrics). It
+// must be called before the event bus is started.
+func (b *EventBus) SetMetrics(metrics *tmpubsub.Metrics) {
+	b.pubsub.SetMetrics(metrics)
+}
+
 func (b *EventBus) OnStart() error {
 	return b.pubsub.Start()
 }
@@ -181,7 +195,7 @@ func (b *EventBus) PublishEventTx(data EventDataTx) error {
 
 	// add predefined compositeKeys
 	events[EventTypeKey] = append(events[EventTypeKey], EventTx)
-	events[TxHashKey] = append(events[TxHashKey], fmt.Sprintf("%X", Tx(data.Tx).Hash()))
+	events[TxHashKey] = append(events[TxHashKey], fmt.Sprintf("%X", b.txHasher.Hash(data.Tx)))
 	events[TxHeightKey] = append(events[TxHeightKey], fmt.Sprintf("%d", data.Height))
 
 	return b.pubsub.PublishWithEvents(ctx, data, events)
@@ -227,6 +241,14 @@ func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpd
 	return b.Publish(EventValidatorSetUpdates, data)
 }
 
+func (b *EventBus) PublishEventStateSyncStatus(data EventDataStateSyncStatus) error {
+	return b.Publish(EventStateSyncStatus, data)
+}
+
+func (b *EventBus) PublishEventMempoolCategories(data EventDataMempoolCategories) error {
+	return b.Publish(EventMempoolCategories, data)
+}
+
 //-----------------------------------------------------------------------------
 type NopEventBus struct{}
 
@@ -306,3 +328,11 @@ func (NopEventBus) PublishEventLock(data EventDataRoundState) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventStateSyncStatus(data EventDataStateSyncStatus) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventMempoolCategories(data EventDataMempoolCategories) error {
+	return nil
+}
@@ -65,6 +65,32 @@ func TestEventBusPublishEventTx(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventTxWithTxHasher(t *testing.T) {
+	hasher, err := NewTxHasher(TxHashKeccak256, "")
+	require.NoError(t, err)
+	eventBus := NewEventBus()
+	eventBus.SetTxHasher(hasher)
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	tx := Tx("foo")
+	txsSub, err := eventBus.Subscribe(context.Background(), "test", EventQueryTxForHash(hasher.Hash(tx)))
+	require.NoError(t, err)
+
+	err = eventBus.PublishEventTx(EventDataTx{abci.TxResult{Height: 1, Tx: tx}})
+	require.NoError(t, err)
+
+	select {
+	case msg := <-txsSub.Out():
+		assert.EqualValues(t, tx, msg.Data().(EventDataTx).Tx)
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive a transaction after 1 sec.")
+	}
+}
 func TestEventBusPublishEventNewBlock(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
@@ -0,0 +1,165 @@
+package types
+
+import (
+	"context"
+
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+)
+
+// The SubscribeX functions subscribe to the events of a type, delivering them
+// on a channel of their concrete data type instead of as tmpubsub.Message
+// values whose TMEventData must be type switched. The channel is closed once
+// the subscription is cancelled, e.g. by unsubscribing or because the client
+// didn't read the events fast enough, see SubscriptionStatus.Err.
+
+// SubscriptionStatus reports the cancellation of a subscription whose events
+// are delivered on a typed channel.
+type SubscriptionStatus interface {
+	Cancelled() <-chan struct{}
+	Err() error
+}
+
+// SubscribeNewBlocks subscribes to the EventDataNewBlock events.
+func SubscribeNewBlocks(ctx context.Context, bus EventBusSubscriber, subscriber string,
+	outCapacity ...int) (<-chan EventDataNewBlock, SubscriptionStatus, error) {
+	sub, err := bus.Subscribe(ctx, subscriber, EventQueryNewBlock, outCapacity...)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make(chan EventDataNewBlock, cap(sub.Out()))
+	go forwardEvents(sub, func(data TMEventData) bool {
+		if event, ok := data.(EventDataNewBlock); ok {
+			select {
+			case out <- event:
+			case <-sub.Cancelled():
+				return false
+			}
+		}
+		return true
+	}, func() { close(out) })
+	return out, sub, nil
+}
+
+// SubscribeNewBlockHeaders subscribes to the EventDataNewBlockHeader events.
+func SubscribeNewBlockHeaders(ctx context.Context, bus EventBusSubscriber, subscriber string,
+	outCapacity ...int) (<-chan EventDataNewBlockHeader, SubscriptionStatus, error) {
+	sub, err := bus.Subscribe(ctx, subscriber, EventQueryNewBlockHeader, outCapacity...)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make(chan EventDataNewBlockHeader, cap(sub.Out()))
+	go forwardEvents(sub, func(data TMEventData) bool {
+		if event, ok := data.(EventDataNewBlockHeader); ok {
+			select {
+			case out <- event:
+			case <-sub.Cancelled():
+				return false
+			}
+		}
+		return true
+	}, func() { close(out) })
+	return out, sub, nil
+}
+
+// SubscribeTxs subscribes to the EventDataTx events matching the query, e.g.
+// EventQueryTx or QueryForEvent(EventTx).AND... to select some of the txs.
+// The events of other types matching the query are dropped.
+func SubscribeTxs(ctx context.Context, bus EventBusSubscriber, subscriber string, query tmpubsub.Query,
+	outCapacity ...int) (<-chan EventDataTx, SubscriptionStatus, error) {
+	sub, err := bus.Subscribe(ctx, subscriber, query, outCapacity...)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make(chan EventDataTx, cap(sub.Out()))
+	go forwardEvents(sub, func(data TMEventData) bool {
+		if event, ok := data.(EventDataTx); ok {
+			select {
+			case out <- event:
+			case <-sub.Cancelled():
+				return false
+			}
+		}
+		return true
+	}, func() { close(out) })
+	return out, sub, nil
+}
+
+// SubscribeVotes subscribes to the EventDataVote events.
+func SubscribeVotes(ctx context.Context, bus EventBusSubscriber, subscriber string,
+	outCapacity ...int) (<-chan EventDataVote, SubscriptionStatus, error) {
+	sub, err := bus.Subscribe(ctx, subscriber, EventQueryVote, outCapacity...)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make(chan EventDataVote, cap(sub.Out()))
+	go forwardEvents(sub, func(data TMEventData) bool {
+		if event, ok := data.(EventDataVote); ok {
+			select {
+			case out <- event:
+			case <-sub.Cancelled():
+				return false
+			}
+		}
+		return true
+	}, func() { close(out) })
+	return out, sub, nil
+}
+
+// SubscribeNewEvidence subscribes to the EventDataNewEvidence events.
+func SubscribeNewEvidence(ctx context.Context, bus EventBusSubscriber, subscriber string,
+	outCapacity ...int) (<-chan EventDataNewEvidence, SubscriptionStatus, error) {
+	sub, err := bus.Subscribe(ctx, subscriber, EventQueryNewEvidence, outCapacity...)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make(chan EventDataNewEvidence, cap(sub.Out()))
+	go forwardEvents(sub, func(data TMEventData) bool {
+		if event, ok := data.(EventDataNewEvidence); ok {
+			select {
+			case out <- event:
+			case <-sub.Cancelled():
+				return false
+			}
+		}
+		return true
+	}, func() { close(out) })
+	return out, sub, nil
+}
+
+// SubscribeValidatorSetUpdates subscribes to the EventDataValidatorSetUpdates
+// events.
+func SubscribeValidatorSetUpdates(ctx context.Context, bus EventBusSubscriber, subscriber string,
+	outCapacity ...int) (<-chan EventDataValidatorSetUpdates, SubscriptionStatus, error) {
+	sub, err := bus.Subscribe(ctx, subscriber, EventQueryValidatorSetUpdates, outCapacity...)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make(chan EventDataValidatorSetUpdates, cap(sub.Out()))
+	go forwardEvents(sub, func(data TMEventData) bool {
+		if event, ok := data.(EventDataValidatorSetUpdates); ok {
+			select {
+			case out <- event:
+			case <-sub.Cancelled():
+				return false
+			}
+		}
+		return true
+	}, func() { close(out) })
+	return out, sub, nil
+}
+
+// forwardEvents calls deliver with the data of each message of the
+// subscription, until it is cancelled or deliver returns false, and then done.
+func forwardEvents(sub Subscription, deliver func(TMEventData) bool, done func()) {
+	defer done()
+	for {
+		select {
+		case msg := <-sub.Out():
+			if !deliver(msg.Data()) {
+				return
+			}
+		case <-sub.Cancelled():
+			return
+		}
+	}
+}
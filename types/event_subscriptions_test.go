@@ -0,0 +1,72 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+)
+
+func TestSubscribeNewBlocks(t *testing.T) {
+	eventBus := NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	blocks, status, err := SubscribeNewBlocks(context.Background(), eventBus, "test")
+	require.NoError(t, err)
+
+	block := MakeBlock(1, []Tx{}, nil, []Evidence{})
+	require.NoError(t, eventBus.PublishEventNewBlock(EventDataNewBlock{Block: block}))
+	select {
+	case event := <-blocks:
+		assert.Equal(t, block, event.Block)
+	case <-time.After(time.Second):
+		t.Fatal("did not receive a block after 1 sec.")
+	}
+
+	// the channel is closed once unsubscribed
+	require.NoError(t, eventBus.Unsubscribe(context.Background(), "test", EventQueryNewBlock))
+	select {
+	case _, ok := <-blocks:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("the channel was not closed after 1 sec.")
+	}
+	assert.Equal(t, tmpubsub.ErrUnsubscribed, status.Err())
+}
+
+func TestSubscribeTxs(t *testing.T) {
+	eventBus := NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	tx := Tx("foo")
+	query := tmquery.MustParse(fmt.Sprintf("tm.event='Tx' AND tx.hash='%X'", tx.Hash()))
+	txs, _, err := SubscribeTxs(context.Background(), eventBus, "test", query)
+	require.NoError(t, err)
+
+	for _, tx := range []Tx{Tx("bar"), tx} {
+		require.NoError(t, eventBus.PublishEventTx(EventDataTx{abci.TxResult{Height: 1, Tx: tx}}))
+	}
+	select {
+	case event := <-txs:
+		assert.EqualValues(t, tx, event.Tx)
+	case <-time.After(time.Second):
+		t.Fatal("did not receive a tx after 1 sec.")
+	}
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -22,6 +23,16 @@ const (
 	EventTx                  = "Tx"
 	EventValidatorSetUpdates = "ValidatorSetUpdates"
 
+	// State sync events.
+	// These are published while the node restores a snapshot, to report its
+	// progress.
+	EventStateSyncStatus = "StateSyncStatus"
+
+	// Mempool events.
+	// These are published by the mempool after each block, to report the
+	// txs left in it.
+	EventMempoolCategories = "MempoolCategories"
+
 	// Internal consensus events.
 	// These are used for testing the consensus state machine.
 	// They can also be used to build real-time consensus visualizers.
@@ -56,6 +67,8 @@ func init() {
 	tmjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	tmjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
 	tmjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
+	tmjson.RegisterType(EventDataStateSyncStatus{}, "tendermint/event/StateSyncStatus")
+	tmjson.RegisterType(EventDataMempoolCategories{}, "tendermint/event/MempoolCategories")
 }
 
 // Most event messages are basic types (a block, a transaction)
@@ -66,6 +79,28 @@ type EventDataNewBlock struct {
 
 	ResultBeginBlock abci.ResponseBeginBlock `json:"result_begin_block"`
 	ResultEndBlock   abci.ResponseEndBlock   `json:"result_end_block"`
+
+	// Set for the blocks committed by consensus, nil otherwise (e.g. fast sync).
+	Timings *BlockTimings `json:"timings,omitempty"`
+}
+
+// BlockTimings breaks down the time spent on a block by processing stage. The
+// consensus steps are summed over all the rounds of the height. The indexing
+// of the block, which starts once it's published, isn't included.
+type BlockTimings struct {
+	// Propose step: waiting for the proposal (or proposing).
+	ProposalWait time.Duration `json:"proposal_wait"`
+	// Prevote steps: waiting for +2/3 prevotes.
+	PrevoteWait time.Duration `json:"prevote_wait"`
+	// Precommit steps: waiting for +2/3 precommits.
+	PrecommitWait time.Duration `json:"precommit_wait"`
+	// Commit step: receiving the rest of the block if needed, saving it to the
+	// block store and fsyncing the WAL.
+	Save time.Duration `json:"save"`
+	// BeginBlock, DeliverTx and EndBlock.
+	Execution time.Duration `json:"execution"`
+	// Commit of the app and update of the mempool.
+	Commit time.Duration `json:"commit"`
 }
 
 type EventDataNewBlockHeader struct {
@@ -113,6 +148,11 @@ type EventDataCompleteProposal struct {
 	Step   string `json:"step"`
 
 	BlockID BlockID `json:"block_id"`
+
+	// Timestamp of the proposal, set by the proposer (zero if the proposal
+	// isn't known yet), and local time at which the block was complete.
+	ProposalTimestamp time.Time `json:"proposal_timestamp"`
+	ReceivedAt        time.Time `json:"received_at"`
 }
 
 type EventDataVote struct {
@@ -125,6 +165,50 @@ type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+// State sync statuses, reported by EventDataStateSyncStatus.
+const (
+	// StateSyncStatusDiscovering is reported while waiting to discover snapshots.
+	StateSyncStatusDiscovering = "discovering"
+	// StateSyncStatusRestoring is reported once a snapshot has been accepted by
+	// the app, and after each of its chunks has been applied.
+	StateSyncStatusRestoring = "restoring"
+	// StateSyncStatusRejected is reported when a snapshot is rejected.
+	StateSyncStatusRejected = "rejected"
+	// StateSyncStatusFailed is reported when the state sync fails.
+	StateSyncStatusFailed = "failed"
+	// StateSyncStatusRestored is reported once a snapshot has been restored.
+	StateSyncStatusRestored = "restored"
+)
+
+// EventDataStateSyncStatus reports the progress of a state sync. The snapshot
+// fields are only set if the status refers to a snapshot.
+type EventDataStateSyncStatus struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"` // why the snapshot was rejected, or the sync failed
+
+	Height        uint64 `json:"height"`
+	Format        uint32 `json:"format"`
+	ChunksTotal   uint32 `json:"chunks_total"`
+	ChunksApplied uint32 `json:"chunks_applied"`
+	BytesFetched  int64  `json:"bytes_fetched"`
+}
+
+// MempoolCategory counts the txs of a category in the mempool, as tagged by
+// the app in ResponseCheckTx.Category.
+type MempoolCategory struct {
+	Category string `json:"category"`
+	Txs      int    `json:"n_txs"`
+	Bytes    int64  `json:"bytes"`
+}
+
+// EventDataMempoolCategories reports the txs left in the mempool by category,
+// sorted by category, once the txs of the block at the given height have been
+// removed from it. The uncategorized txs aren't reported.
+type EventDataMempoolCategories struct {
+	Height     int64             `json:"height"`
+	Categories []MempoolCategory `json:"categories"`
+}
+
 // PUBSUB
 
 const (
@@ -141,6 +225,7 @@ const (
 var (
 	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
 	EventQueryLock                = QueryForEvent(EventLock)
+	EventQueryMempoolCategories   = QueryForEvent(EventMempoolCategories)
 	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
 	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeader)
 	EventQueryNewEvidence         = QueryForEvent(EventNewEvidence)
@@ -148,6 +233,7 @@ var (
 	EventQueryNewRoundStep        = QueryForEvent(EventNewRoundStep)
 	EventQueryPolka               = QueryForEvent(EventPolka)
 	EventQueryRelock              = QueryForEvent(EventRelock)
+	EventQueryStateSyncStatus     = QueryForEvent(EventStateSyncStatus)
 	EventQueryTimeoutPropose      = QueryForEvent(EventTimeoutPropose)
 	EventQueryTimeoutWait         = QueryForEvent(EventTimeoutWait)
 	EventQueryTx                  = QueryForEvent(EventTx)
@@ -158,7 +244,13 @@ var (
 )
 
 func EventQueryTxFor(tx Tx) tmpubsub.Query {
-	return tmquery.MustParse(fmt.Sprintf("%s='%s' AND %s='%X'", EventTypeKey, EventTx, TxHashKey, tx.Hash()))
+	return EventQueryTxForHash(tx.Hash())
+}
+
+// EventQueryTxForHash returns a query for the tx event of the tx with the
+// given hash, as computed by the TxHasher of the event bus.
+func EventQueryTxForHash(hash []byte) tmpubsub.Query {
+	return tmquery.MustParse(fmt.Sprintf("%s='%s' AND %s='%X'", EventTypeKey, EventTx, TxHashKey, hash))
 }
 
 func QueryForEvent(eventType string) tmpubsub.Query {
@@ -177,3 +269,13 @@ type BlockEventPublisher interface {
 type TxEventPublisher interface {
 	PublishEventTx(EventDataTx) error
 }
+
+// StateSyncEventPublisher publishes the state sync events.
+type StateSyncEventPublisher interface {
+	PublishEventStateSyncStatus(EventDataStateSyncStatus) error
+}
+
+// MempoolEventPublisher publishes the mempool events.
+type MempoolEventPublisher interface {
+	PublishEventMempoolCategories(EventDataMempoolCategories) error
+}
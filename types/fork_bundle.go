@@ -0,0 +1,162 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// ForkBundle is the validator set, consensus params and app hash of a chain
+// once the block at Height is committed, signed by the key of the node which
+// exported it, to seed the genesis of a fork of the chain starting at
+// Height+1. The app state itself must be exported by the app.
+type ForkBundle struct {
+	ChainID         string                  `json:"chain_id"`
+	Height          int64                   `json:"height"`
+	AppHash         tmbytes.HexBytes        `json:"app_hash"`
+	ValidatorsHash  tmbytes.HexBytes        `json:"validators_hash"`
+	Validators      []GenesisValidator      `json:"validators"`
+	ConsensusParams tmproto.ConsensusParams `json:"consensus_params"`
+
+	PubKey    crypto.PubKey `json:"pub_key"`
+	Signature []byte        `json:"signature"`
+}
+
+// NewForkBundle returns the unsigned bundle of the given validators (for
+// Height+1), consensus params and app hash.
+func NewForkBundle(chainID string, height int64, appHash []byte, vals *ValidatorSet,
+	params tmproto.ConsensusParams) *ForkBundle {
+	genVals := make([]GenesisValidator, len(vals.Validators))
+	for i, val := range vals.Validators {
+		genVals[i] = GenesisValidator{Address: val.Address, PubKey: val.PubKey, Power: val.VotingPower}
+	}
+	return &ForkBundle{
+		ChainID:         chainID,
+		Height:          height,
+		AppHash:         appHash,
+		ValidatorsHash:  vals.Hash(),
+		Validators:      genVals,
+		ConsensusParams: params,
+	}
+}
+
+// SignBytes returns the bytes signed by the exporter: the JSON encoding of the
+// bundle without its public key and signature.
+func (b *ForkBundle) SignBytes() ([]byte, error) {
+	unsigned := *b
+	unsigned.PubKey, unsigned.Signature = nil, nil
+	return tmjson.Marshal(unsigned)
+}
+
+// Sign signs the bundle with the given key.
+func (b *ForkBundle) Sign(privKey crypto.PrivKey) error {
+	signBytes, err := b.SignBytes()
+	if err != nil {
+		return err
+	}
+	sig, err := privKey.Sign(signBytes)
+	if err != nil {
+		return err
+	}
+	b.PubKey, b.Signature = privKey.PubKey(), sig
+	return nil
+}
+
+// ValidateBasic checks that the bundle is consistent and correctly signed. It
+// doesn't tell whether the signer is trusted, see VerifySigner.
+func (b *ForkBundle) ValidateBasic() error {
+	if b.ChainID == "" {
+		return errors.New("missing chain ID")
+	}
+	if b.Height <= 0 {
+		return fmt.Errorf("invalid height %d", b.Height)
+	}
+	if len(b.Validators) == 0 {
+		return errors.New("no validators")
+	}
+	vals := make([]*Validator, len(b.Validators))
+	for i, v := range b.Validators {
+		if v.PubKey == nil {
+			return fmt.Errorf("validator #%d has no public key", i)
+		}
+		if v.Power <= 0 {
+			return fmt.Errorf("validator #%d has an invalid power %d", i, v.Power)
+		}
+		if !bytes.Equal(v.Address, v.PubKey.Address()) {
+			return fmt.Errorf("validator #%d address %v doesn't match its public key", i, v.Address)
+		}
+		vals[i] = NewValidator(v.PubKey, v.Power)
+	}
+	valSet, err := ValidatorSetFromExistingValidators(vals)
+	if err != nil {
+		return fmt.Errorf("invalid validators: %w", err)
+	}
+	if !bytes.Equal(valSet.Hash(), b.ValidatorsHash) {
+		return fmt.Errorf("validators hash %v doesn't match the validators (%X)", b.ValidatorsHash, valSet.Hash())
+	}
+	if err := ValidateConsensusParams(b.ConsensusParams); err != nil {
+		return fmt.Errorf("invalid consensus params: %w", err)
+	}
+
+	if b.PubKey == nil {
+		return errors.New("the bundle isn't signed")
+	}
+	signBytes, err := b.SignBytes()
+	if err != nil {
+		return err
+	}
+	if !b.PubKey.VerifySignature(signBytes, b.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// VerifySigner returns an error if the bundle isn't signed by the key with the
+// given address (e.g. a node ID).
+func (b *ForkBundle) VerifySigner(address []byte) error {
+	if b.PubKey == nil {
+		return errors.New("the bundle isn't signed")
+	}
+	if signer := b.PubKey.Address(); !bytes.Equal(signer, address) {
+		return fmt.Errorf("the bundle is signed by %v, expected %X", signer, address)
+	}
+	return nil
+}
+
+// ApplyToGenesis makes the genesis start after the bundle: at Height+1, with
+// its validators, consensus params and app hash.
+func (b *ForkBundle) ApplyToGenesis(genDoc *GenesisDoc) {
+	params := b.ConsensusParams
+	genDoc.InitialHeight = b.Height + 1
+	genDoc.Validators = b.Validators
+	genDoc.ConsensusParams = &params
+	genDoc.AppHash = b.AppHash
+}
+
+// SaveAs saves the bundle as a JSON file.
+func (b *ForkBundle) SaveAs(file string) error {
+	bz, err := tmjson.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, bz, 0644) // nolint:gosec
+}
+
+// ForkBundleFromFile reads a bundle saved with SaveAs. It isn't validated.
+func ForkBundleFromFile(file string) (*ForkBundle, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var b ForkBundle
+	if err := tmjson.Unmarshal(bz, &b); err != nil {
+		return nil, fmt.Errorf("failed to decode the fork bundle: %w", err)
+	}
+	return &b, nil
+}
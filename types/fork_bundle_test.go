@@ -0,0 +1,55 @@
+package types
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestForkBundle(t *testing.T) {
+	vals, _ := RandValidatorSet(3, 10)
+	params := *DefaultConsensusParams()
+	signer := ed25519.GenPrivKey()
+
+	bundle := NewForkBundle("test-chain", 10, []byte("app_hash"), vals, params)
+	assert.Error(t, bundle.ValidateBasic(), "unsigned")
+	require.NoError(t, bundle.Sign(signer))
+	require.NoError(t, bundle.ValidateBasic())
+	require.NoError(t, bundle.VerifySigner(signer.PubKey().Address()))
+	assert.Error(t, bundle.VerifySigner(ed25519.GenPrivKey().PubKey().Address()))
+
+	file := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(t, bundle.SaveAs(file))
+	loaded, err := ForkBundleFromFile(file)
+	require.NoError(t, err)
+	require.NoError(t, loaded.ValidateBasic())
+	assert.Equal(t, bundle, loaded)
+
+	genDoc := &GenesisDoc{ChainID: "test-chain-fork"}
+	loaded.ApplyToGenesis(genDoc)
+	require.NoError(t, genDoc.ValidateAndComplete())
+	assert.EqualValues(t, 11, genDoc.InitialHeight)
+	assert.Equal(t, vals.Hash(), genDoc.ValidatorHash())
+	assert.Equal(t, params, *genDoc.ConsensusParams)
+	assert.EqualValues(t, []byte("app_hash"), genDoc.AppHash)
+
+	// tampered bundles
+	testCases := map[string]func(b *ForkBundle){
+		"power":     func(b *ForkBundle) { b.Validators[0].Power++ },
+		"app hash":  func(b *ForkBundle) { b.AppHash = []byte("other") },
+		"height":    func(b *ForkBundle) { b.Height = 0 },
+		"params":    func(b *ForkBundle) { b.ConsensusParams.Block.MaxBytes = 0 },
+		"no vals":   func(b *ForkBundle) { b.Validators = nil },
+		"signature": func(b *ForkBundle) { b.Signature[0] ^= 1 },
+	}
+	for name, tamper := range testCases {
+		b, err := ForkBundleFromFile(file)
+		require.NoError(t, err)
+		tamper(b)
+		assert.Error(t, b.ValidateBasic(), name)
+	}
+}
@@ -34,6 +34,13 @@ type GenesisValidator struct {
 	Name    string        `json:"name"`
 }
 
+// GenesisTxHash configures the hash identifying the txs of the chain, see
+// NewTxHasher.
+type GenesisTxHash struct {
+	Algorithm string `json:"algorithm"`
+	Domain    string `json:"domain,omitempty"`
+}
+
 // GenesisDoc defines the initial conditions for a tendermint blockchain, in particular its validator set.
 type GenesisDoc struct {
 	GenesisTime     time.Time                `json:"genesis_time"`
@@ -43,6 +50,7 @@ type GenesisDoc struct {
 	Validators      []GenesisValidator       `json:"validators,omitempty"`
 	AppHash         tmbytes.HexBytes         `json:"app_hash"`
 	AppState        json.RawMessage          `json:"app_state,omitempty"`
+	TxHash          *GenesisTxHash           `json:"tx_hash,omitempty"`
 }
 
 // SaveAs is a utility method for saving GenensisDoc as a JSON file.
@@ -65,6 +73,19 @@ func (genDoc *GenesisDoc) ValidatorHash() []byte {
 	return vset.Hash()
 }
 
+// TxHasher returns the TxHasher configured by the genesis, or DefaultTxHasher
+// if none is. The genesis must have been validated.
+func (genDoc *GenesisDoc) TxHasher() TxHasher {
+	if genDoc.TxHash == nil {
+		return DefaultTxHasher
+	}
+	hasher, err := NewTxHasher(genDoc.TxHash.Algorithm, genDoc.TxHash.Domain)
+	if err != nil {
+		panic(err)
+	}
+	return hasher
+}
+
 // ValidateAndComplete checks that all necessary fields are present
 // and fills in defaults for optional fields left empty
 func (genDoc *GenesisDoc) ValidateAndComplete() error {
@@ -99,6 +120,12 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		}
 	}
 
+	if genDoc.TxHash != nil {
+		if _, err := NewTxHasher(genDoc.TxHash.Algorithm, genDoc.TxHash.Domain); err != nil {
+			return fmt.Errorf("invalid tx_hash in genesis doc: %w", err)
+		}
+	}
+
 	if genDoc.GenesisTime.IsZero() {
 		genDoc.GenesisTime = tmtime.Now()
 	}
@@ -0,0 +1,158 @@
+// Package genesis constructs genesis docs programmatically, for the tooling
+// setting up new chains.
+package genesis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Builder constructs a genesis doc. Its methods can be chained, the first
+// error being returned by Build:
+//
+//	genDoc, err := genesis.NewBuilder("my-chain").
+//		GenesisTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)).
+//		AddValidator(pubKey, 10, "val0").
+//		AppState(appState).
+//		Build()
+//
+// The genesis time must be set explicitly, so that the same inputs always
+// give the same genesis doc.
+type Builder struct {
+	genDoc types.GenesisDoc
+	err    error
+}
+
+// NewBuilder returns a Builder for the chain with the given ID, starting from
+// the default consensus params.
+func NewBuilder(chainID string) *Builder {
+	return &Builder{genDoc: types.GenesisDoc{
+		ChainID:         chainID,
+		ConsensusParams: types.DefaultConsensusParams(),
+	}}
+}
+
+// GenesisTime sets the time of the genesis, i.e. of the first block.
+func (b *Builder) GenesisTime(t time.Time) *Builder {
+	b.genDoc.GenesisTime = t
+	return b
+}
+
+// InitialHeight sets the height of the first block (1 if not set).
+func (b *Builder) InitialHeight(height int64) *Builder {
+	b.genDoc.InitialHeight = height
+	return b
+}
+
+// ConsensusParams replaces the consensus params.
+func (b *Builder) ConsensusParams(params tmproto.ConsensusParams) *Builder {
+	b.genDoc.ConsensusParams = &params
+	return b
+}
+
+// PubKeyTypes sets the types of the validator keys accepted by the chain
+// (types.ABCIPubKeyTypeEd25519 by default).
+func (b *Builder) PubKeyTypes(pubKeyTypes ...string) *Builder {
+	b.genDoc.ConsensusParams.Validator.PubKeyTypes = pubKeyTypes
+	return b
+}
+
+// AddValidator adds a validator with the given key, voting power and name
+// (which may be empty).
+func (b *Builder) AddValidator(pubKey crypto.PubKey, power int64, name string) *Builder {
+	if pubKey == nil {
+		return b.fail(errors.New("validator pub key can't be nil"))
+	}
+	b.genDoc.Validators = append(b.genDoc.Validators, types.GenesisValidator{
+		Address: pubKey.Address(),
+		PubKey:  pubKey,
+		Power:   power,
+		Name:    name,
+	})
+	return b
+}
+
+// AppHash sets the initial app hash.
+func (b *Builder) AppHash(hash []byte) *Builder {
+	b.genDoc.AppHash = hash
+	return b
+}
+
+// AppState sets the initial app state, passed to the app by InitChain, to the
+// JSON encoding of the given value.
+func (b *Builder) AppState(state interface{}) *Builder {
+	bz, err := json.Marshal(state)
+	if err != nil {
+		return b.fail(fmt.Errorf("can't encode the app state: %w", err))
+	}
+	return b.AppStateJSON(bz)
+}
+
+// AppStateJSON sets the initial app state, passed to the app by InitChain.
+func (b *Builder) AppStateJSON(state json.RawMessage) *Builder {
+	if !json.Valid(state) {
+		return b.fail(errors.New("app state isn't valid JSON"))
+	}
+	b.genDoc.AppState = state
+	return b
+}
+
+// TxHash sets the hash identifying the txs of the chain, see
+// types.NewTxHasher.
+func (b *Builder) TxHash(algorithm, domain string) *Builder {
+	b.genDoc.TxHash = &types.GenesisTxHash{Algorithm: algorithm, Domain: domain}
+	return b
+}
+
+// Build validates and returns the genesis doc.
+func (b *Builder) Build() (*types.GenesisDoc, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.genDoc.GenesisTime.IsZero() {
+		return nil, errors.New("genesis time must be set")
+	}
+
+	params := b.genDoc.ConsensusParams
+	seen := make(map[string]bool, len(b.genDoc.Validators))
+	totalPower := int64(0)
+	for _, val := range b.genDoc.Validators {
+		if val.Power <= 0 {
+			return nil, fmt.Errorf("validator %v must have a positive voting power", val.Address)
+		}
+		if seen[string(val.Address)] {
+			return nil, fmt.Errorf("duplicate validator %v", val.Address)
+		}
+		seen[string(val.Address)] = true
+		if !types.IsValidPubkeyType(params.Validator, val.PubKey.Type()) {
+			return nil, fmt.Errorf("validator %v has a %s key, while the chain accepts %v",
+				val.Address, val.PubKey.Type(), params.Validator.PubKeyTypes)
+		}
+		totalPower += val.Power
+		if totalPower > types.MaxTotalVotingPower {
+			return nil, fmt.Errorf("total voting power exceeds the max %d", types.MaxTotalVotingPower)
+		}
+	}
+
+	genDoc := b.genDoc
+	genDoc.Validators = append([]types.GenesisValidator(nil), b.genDoc.Validators...)
+	paramsCopy := *params
+	genDoc.ConsensusParams = &paramsCopy
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+	return &genDoc, nil
+}
+
+func (b *Builder) fail(err error) *Builder {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
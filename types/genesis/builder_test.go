@@ -0,0 +1,81 @@
+package genesis
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestBuilder(t *testing.T) {
+	genesisTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	pubKey1 := ed25519.GenPrivKeyFromSecret([]byte("val1")).PubKey()
+	pubKey2 := ed25519.GenPrivKeyFromSecret([]byte("val2")).PubKey()
+
+	build := func() *Builder {
+		return NewBuilder("test-chain").
+			GenesisTime(genesisTime).
+			InitialHeight(5).
+			AddValidator(pubKey1, 10, "val1").
+			AddValidator(pubKey2, 20, "").
+			AppState(map[string]int{"balance": 100})
+	}
+
+	genDoc, err := build().Build()
+	require.NoError(t, err)
+	assert.Equal(t, "test-chain", genDoc.ChainID)
+	assert.Equal(t, genesisTime, genDoc.GenesisTime)
+	assert.EqualValues(t, 5, genDoc.InitialHeight)
+	assert.Equal(t, types.DefaultConsensusParams(), genDoc.ConsensusParams)
+	assert.Equal(t, []types.GenesisValidator{
+		{Address: pubKey1.Address(), PubKey: pubKey1, Power: 10, Name: "val1"},
+		{Address: pubKey2.Address(), PubKey: pubKey2, Power: 20},
+	}, genDoc.Validators)
+	assert.Equal(t, json.RawMessage(`{"balance":100}`), genDoc.AppState)
+
+	// the same inputs give the same genesis doc
+	other, err := build().Build()
+	require.NoError(t, err)
+	assert.Equal(t, genDoc, other)
+}
+
+func TestBuilderErrors(t *testing.T) {
+	genesisTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	pubKey := ed25519.GenPrivKey().PubKey()
+
+	testCases := map[string]*Builder{
+		"no genesis time": NewBuilder("test-chain"),
+		"no chain ID":     NewBuilder("").GenesisTime(genesisTime),
+		"zero power":      NewBuilder("test-chain").GenesisTime(genesisTime).AddValidator(pubKey, 0, ""),
+		"duplicate validator": NewBuilder("test-chain").GenesisTime(genesisTime).
+			AddValidator(pubKey, 1, "").AddValidator(pubKey, 1, ""),
+		"disallowed key type": NewBuilder("test-chain").GenesisTime(genesisTime).
+			AddValidator(secp256k1.GenPrivKey().PubKey(), 1, ""),
+		"too much power": NewBuilder("test-chain").GenesisTime(genesisTime).
+			AddValidator(pubKey, types.MaxTotalVotingPower, "").
+			AddValidator(ed25519.GenPrivKey().PubKey(), 1, ""),
+		"invalid app state": NewBuilder("test-chain").GenesisTime(genesisTime).
+			AppStateJSON(json.RawMessage("{")),
+		"invalid tx hash": NewBuilder("test-chain").GenesisTime(genesisTime).TxHash("md5", ""),
+	}
+	for name, builder := range testCases {
+		builder := builder
+		t.Run(name, func(t *testing.T) {
+			_, err := builder.Build()
+			assert.Error(t, err)
+		})
+	}
+
+	// the accepted key types can be changed
+	_, err := NewBuilder("test-chain").GenesisTime(genesisTime).
+		PubKeyTypes(types.ABCIPubKeyTypeSecp256k1).
+		AddValidator(secp256k1.GenPrivKey().PubKey(), 1, "").
+		Build()
+	assert.NoError(t, err)
+}
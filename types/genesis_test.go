@@ -49,6 +49,8 @@ func TestGenesisBad(t *testing.T) {
 				`},"power":"10","name":""}` +
 				`]}`,
 		),
+		// unknown tx hash algorithm
+		[]byte(`{"chain_id":"mychain","tx_hash":{"algorithm":"unknown"}}`),
 	}
 
 	for _, testCase := range testCases {
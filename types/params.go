@@ -183,3 +183,44 @@ func UpdateConsensusParams(params tmproto.ConsensusParams, params2 *abci.Consens
 	}
 	return res
 }
+
+// ValidateBlockCapacity returns an error if a block with Evidence.MaxBytes of
+// evidence and the commit of valsCount validators doesn't fit in
+// Block.MaxBytes, i.e. if the proposer couldn't create such a block.
+func ValidateBlockCapacity(params tmproto.ConsensusParams, valsCount int) error {
+	overhead := MaxOverheadForBlock + MaxHeaderBytes + MaxCommitBytes(valsCount) + params.Evidence.MaxBytes
+	if overhead > params.Block.MaxBytes {
+		return fmt.Errorf("block.MaxBytes is too small to fit the header, the commit of %d validators and "+
+			"evidence.MaxBytes of evidence. %d < %d", valsCount, params.Block.MaxBytes, overhead)
+	}
+	return nil
+}
+
+// ConsensusParamChange is the change of a consensus param, with the values
+// formatted as strings.
+type ConsensusParamChange struct {
+	Name string `json:"name"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// DiffConsensusParams returns the params changed from params to params2, named
+// as their JSON fields (e.g. "block.max_bytes").
+func DiffConsensusParams(params, params2 tmproto.ConsensusParams) []ConsensusParamChange {
+	changes := make([]ConsensusParamChange, 0)
+	diff := func(name string, old, new interface{}) {
+		o, n := fmt.Sprint(old), fmt.Sprint(new)
+		if o != n {
+			changes = append(changes, ConsensusParamChange{Name: name, Old: o, New: n})
+		}
+	}
+	diff("block.max_bytes", params.Block.MaxBytes, params2.Block.MaxBytes)
+	diff("block.max_gas", params.Block.MaxGas, params2.Block.MaxGas)
+	diff("block.time_iota_ms", params.Block.TimeIotaMs, params2.Block.TimeIotaMs)
+	diff("evidence.max_age_num_blocks", params.Evidence.MaxAgeNumBlocks, params2.Evidence.MaxAgeNumBlocks)
+	diff("evidence.max_age_duration", params.Evidence.MaxAgeDuration, params2.Evidence.MaxAgeDuration)
+	diff("evidence.max_bytes", params.Evidence.MaxBytes, params2.Evidence.MaxBytes)
+	diff("validator.pub_key_types", params.Validator.PubKeyTypes, params2.Validator.PubKeyTypes)
+	diff("version.app_version", params.Version.AppVersion, params2.Version.AppVersion)
+	return changes
+}
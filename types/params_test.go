@@ -149,3 +149,26 @@ func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 
 	assert.EqualValues(t, 1, updated.Version.AppVersion)
 }
+
+func TestValidateBlockCapacity(t *testing.T) {
+	params := makeParams(MaxOverheadForBlock+MaxHeaderBytes+MaxCommitBytes(4)+100, 0, 10, 2, 100, valEd25519)
+	assert.NoError(t, ValidateBlockCapacity(params, 4))
+	assert.Error(t, ValidateBlockCapacity(params, 5))
+
+	params.Evidence.MaxBytes++
+	assert.Error(t, ValidateBlockCapacity(params, 4))
+}
+
+func TestDiffConsensusParams(t *testing.T) {
+	params := makeParams(1, 2, 10, 3, 0, valEd25519)
+	assert.Empty(t, DiffConsensusParams(params, params))
+
+	updated := UpdateConsensusParams(params, &abci.ConsensusParams{
+		Block:     &abci.BlockParams{MaxBytes: 100, MaxGas: 2},
+		Validator: &tmproto.ValidatorParams{PubKeyTypes: valSecp256k1},
+	})
+	assert.Equal(t, []ConsensusParamChange{
+		{Name: "block.max_bytes", Old: "1", New: "100"},
+		{Name: "validator.pub_key_types", Old: "[ed25519]", New: "[secp256k1]"},
+	}, DiffConsensusParams(params, updated))
+}
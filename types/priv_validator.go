@@ -19,6 +19,15 @@ type PrivValidator interface {
 	SignProposal(chainID string, proposal *tmproto.Proposal) error
 }
 
+// BatchSigner is implemented by the PrivValidators which can sign a proposal
+// and votes together, e.g. with a single round-trip to a remote signer.
+type BatchSigner interface {
+	// SignBatch signs the proposal, if not nil, and then the votes, in order,
+	// like SignProposal and SignVote. It stops at the first error, the
+	// proposal and votes before it being signed.
+	SignBatch(chainID string, proposal *tmproto.Proposal, votes []*tmproto.Vote) error
+}
+
 type PrivValidatorsByAddress []PrivValidator
 
 func (pvs PrivValidatorsByAddress) Len() int {
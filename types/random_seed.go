@@ -0,0 +1,53 @@
+package types
+
+import (
+	"encoding/binary"
+	"hash"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+)
+
+// randomSeedPrefix separates the random seeds from the other hashes, and
+// versions their derivation.
+const randomSeedPrefix = "tendermint/random-seed/v1"
+
+// RandomSeed returns the random seed of the block at the given height, given
+// its last commit (nil at the initial height):
+//
+//	SHA256(prefix || uvarint(len(chainID)) || chainID || int64be(height) ||
+//	       uvarint(len(sig_1)) || sig_1 || ... || uvarint(len(sig_n)) || sig_n)
+//
+// where prefix is "tendermint/random-seed/v1", and sig_1..sig_n are the
+// signatures of the last commit for its block (BlockIDFlagCommit), in the
+// order of the validator set. Since the last commit is part of the block, and
+// hashed into its header, anyone can verify the seed.
+//
+// The seed can't be predicted before the last commit is signed, but it can be
+// biased: the proposer chooses which signatures beyond +2/3 it includes, and
+// the validators can choose not to sign or re-sign with another timestamp.
+// Apps must not use it where validators are incentivized to bias it.
+func RandomSeed(chainID string, height int64, lastCommit *Commit) tmbytes.HexBytes {
+	hasher := tmhash.New()
+	hasher.Write([]byte(randomSeedPrefix))
+	writeRandomSeedBytes(hasher, []byte(chainID))
+	var heightBz [8]byte
+	binary.BigEndian.PutUint64(heightBz[:], uint64(height))
+	hasher.Write(heightBz[:])
+	if lastCommit != nil {
+		for _, commitSig := range lastCommit.Signatures {
+			if commitSig.ForBlock() {
+				writeRandomSeedBytes(hasher, commitSig.Signature)
+			}
+		}
+	}
+	return hasher.Sum(nil)
+}
+
+// writeRandomSeedBytes writes the length-prefixed bytes to the hasher.
+func writeRandomSeedBytes(hasher hash.Hash, bz []byte) {
+	var lenBz [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBz[:], uint64(len(bz)))
+	hasher.Write(lenBz[:n])
+	hasher.Write(bz)
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+func TestRandomSeed(t *testing.T) {
+	commit := randCommit(tmtime.Now())
+	seed := RandomSeed("test-chain", 3, commit)
+	require.Len(t, seed, 32)
+	assert.Equal(t, seed, RandomSeed("test-chain", 3, commit))
+
+	// the seed depends on the chain, the height and the signatures for the block
+	assert.NotEqual(t, seed, RandomSeed("other-chain", 3, commit))
+	assert.NotEqual(t, seed, RandomSeed("test-chain", 4, commit))
+	assert.NotEqual(t, seed, RandomSeed("test-chain", 3, randCommit(tmtime.Now())))
+
+	// the nil votes are ignored
+	withNil := NewCommit(commit.Height, commit.Round, commit.BlockID, append([]CommitSig{}, commit.Signatures...))
+	withNil.Signatures[0].BlockIDFlag = BlockIDFlagNil
+	nilSeed := RandomSeed("test-chain", 3, withNil)
+	assert.NotEqual(t, seed, nilSeed)
+	withNil.Signatures[0].Signature = []byte("other")
+	assert.Equal(t, nilSeed, RandomSeed("test-chain", 3, withNil))
+
+	// at the initial height, it only depends on the chain and the height
+	assert.Equal(t, RandomSeed("test-chain", 1, nil), RandomSeed("test-chain", 1, &Commit{}))
+	assert.NotEqual(t, RandomSeed("test-chain", 1, nil), RandomSeed("test-chain", 2, nil))
+}
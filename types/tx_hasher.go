@@ -0,0 +1,82 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Tx hash algorithms supported out of the box, see RegisterTxHashAlgorithm.
+const (
+	TxHashSHA256    = "sha256"
+	TxHashSHA3256   = "sha3-256"
+	TxHashKeccak256 = "keccak256"
+)
+
+var txHashAlgorithms = map[string]func() hash.Hash{
+	TxHashSHA256:    sha256.New,
+	TxHashSHA3256:   sha3.New256,
+	TxHashKeccak256: sha3.NewLegacyKeccak256,
+}
+
+// RegisterTxHashAlgorithm makes the given hash algorithm available to
+// NewTxHasher (and thus to the genesis tx_hash params) under the given name.
+// It must be called before the genesis is loaded, e.g. in an init function,
+// and is not thread safe.
+func RegisterTxHashAlgorithm(name string, newHash func() hash.Hash) {
+	if _, ok := txHashAlgorithms[name]; ok {
+		panic(fmt.Sprintf("tx hash algorithm %q is already registered", name))
+	}
+	txHashAlgorithms[name] = newHash
+}
+
+// TxHasher computes the hash identifying a transaction in the mempool, the tx
+// indexer, tx events and RPC responses. It doesn't affect the hashes committed
+// to in blocks (Data.Hash) nor tx proofs, which always use Tx.Hash.
+type TxHasher interface {
+	Hash(tx Tx) []byte
+}
+
+// DefaultTxHasher hashes txs with Tx.Hash, i.e. SHA-256.
+var DefaultTxHasher TxHasher = defaultTxHasher{}
+
+type defaultTxHasher struct{}
+
+func (defaultTxHasher) Hash(tx Tx) []byte {
+	return tx.Hash()
+}
+
+// NewTxHasher returns a TxHasher using the given registered hash algorithm.
+// If domain is not empty, the hash is domain separated: the tx is prefixed
+// with the uvarint-encoded length of the domain followed by the domain.
+func NewTxHasher(algorithm, domain string) (TxHasher, error) {
+	newHash, ok := txHashAlgorithms[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unknown tx hash algorithm %q", algorithm)
+	}
+	if algorithm == TxHashSHA256 && domain == "" {
+		return DefaultTxHasher, nil
+	}
+
+	prefix := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(domain))
+	prefix = append(prefix[:binary.PutUvarint(prefix, uint64(len(domain)))], domain...)
+	if domain == "" {
+		prefix = nil
+	}
+	return txHasher{newHash: newHash, prefix: prefix}, nil
+}
+
+type txHasher struct {
+	newHash func() hash.Hash
+	prefix  []byte
+}
+
+func (h txHasher) Hash(tx Tx) []byte {
+	hasher := h.newHash()
+	hasher.Write(h.prefix)
+	hasher.Write(tx)
+	return hasher.Sum(nil)
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"crypto/md5" // nolint: gosec
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestNewTxHasher(t *testing.T) {
+	tx := Tx("tx")
+
+	sha3Sum := sha3.Sum256(tx)
+	keccak := sha3.NewLegacyKeccak256()
+	keccak.Write(tx)
+	domainSum := sha3.Sum256(append([]byte("\x06domain"), tx...))
+
+	testCases := []struct {
+		algorithm string
+		domain    string
+		hash      []byte
+	}{
+		{TxHashSHA256, "", tx.Hash()},
+		{TxHashSHA3256, "", sha3Sum[:]},
+		{TxHashKeccak256, "", keccak.Sum(nil)},
+		{TxHashSHA3256, "domain", domainSum[:]},
+	}
+	for _, tc := range testCases {
+		hasher, err := NewTxHasher(tc.algorithm, tc.domain)
+		require.NoError(t, err)
+		assert.Equal(t, tc.hash, hasher.Hash(tx), "%v %q", tc.algorithm, tc.domain)
+	}
+
+	hasher, err := NewTxHasher(TxHashSHA256, "domain")
+	require.NoError(t, err)
+	assert.NotEqual(t, tx.Hash(), hasher.Hash(tx))
+
+	_, err = NewTxHasher("md5", "")
+	assert.Error(t, err)
+
+	RegisterTxHashAlgorithm("md5", md5.New)
+	hasher, err = NewTxHasher("md5", "")
+	require.NoError(t, err)
+	md5Sum := md5.Sum(tx) // nolint: gosec
+	assert.Equal(t, md5Sum[:], hasher.Hash(tx))
+	assert.Panics(t, func() { RegisterTxHashAlgorithm("md5", md5.New) })
+}
+
+func TestGenesisTxHasher(t *testing.T) {
+	genDoc := &GenesisDoc{ChainID: "mychain"}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	assert.Equal(t, DefaultTxHasher, genDoc.TxHasher())
+
+	genDoc.TxHash = &GenesisTxHash{Algorithm: TxHashKeccak256}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	keccak := sha3.NewLegacyKeccak256()
+	keccak.Write([]byte("tx"))
+	assert.Equal(t, keccak.Sum(nil), genDoc.TxHasher().Hash(Tx("tx")))
+}
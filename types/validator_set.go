@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/tendermint/tendermint/crypto/merkle"
 	tmmath "github.com/tendermint/tendermint/libs/math"
@@ -661,21 +662,8 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
 // with a bonus for including more than +2/3 of the signatures.
 func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 	height int64, commit *Commit) error {
-	if commit == nil {
-		return errors.New("nil commit")
-	}
-
-	if vals.Size() != len(commit.Signatures) {
-		return NewErrInvalidCommitSignatures(vals.Size(), len(commit.Signatures))
-	}
-
-	// Validate Height and BlockID.
-	if height != commit.Height {
-		return NewErrInvalidCommitHeight(height, commit.Height)
-	}
-	if !blockID.Equals(commit.BlockID) {
-		return fmt.Errorf("invalid commit -- wrong block ID: want %v, got %v",
-			blockID, commit.BlockID)
+	if err := vals.verifyCommitBasic(blockID, height, commit); err != nil {
+		return err
 	}
 
 	talliedVotingPower := int64(0)
@@ -711,6 +699,91 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 	return nil
 }
 
+// VerifyCommitConcurrently verifies, like VerifyCommit, that +2/3 of the set
+// signed the given commit, checking all the signatures, but verifies them
+// concurrently with the given number of workers. It's meant for external
+// verifiers, e.g. of the /commit responses: unlike VerifyCommit, it reports
+// all the wrong signatures, as an ErrWrongCommitSignatures, rather than the
+// first one.
+func (vals *ValidatorSet) VerifyCommitConcurrently(chainID string, blockID BlockID,
+	height int64, commit *Commit, workers int) error {
+	if err := vals.verifyCommitBasic(blockID, height, commit); err != nil {
+		return err
+	}
+
+	present := make([]int, 0, len(commit.Signatures))
+	for idx, commitSig := range commit.Signatures {
+		if !commitSig.Absent() {
+			present = append(present, idx)
+		}
+	}
+
+	// The vals and commit have a 1-to-1 correspondance.
+	valid := make([]bool, len(present))
+	if workers > len(present) {
+		workers = len(present)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(present); i += workers {
+				idx := present[i]
+				voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
+				valid[i] = vals.Validators[idx].PubKey.VerifySignature(voteSignBytes, commit.Signatures[idx].Signature)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	var wrong ErrWrongCommitSignatures
+	talliedVotingPower := int64(0)
+	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3
+	for i, idx := range present {
+		if !valid[i] {
+			wrong.Indices = append(wrong.Indices, idx)
+			continue
+		}
+		if commit.Signatures[idx].ForBlock() {
+			talliedVotingPower += vals.Validators[idx].VotingPower
+		}
+	}
+	if len(wrong.Indices) > 0 {
+		return wrong
+	}
+
+	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
+		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+
+	return nil
+}
+
+// verifyCommitBasic checks the size, height and block ID of the commit.
+func (vals *ValidatorSet) verifyCommitBasic(blockID BlockID, height int64, commit *Commit) error {
+	if commit == nil {
+		return errors.New("nil commit")
+	}
+
+	if vals.Size() != len(commit.Signatures) {
+		return NewErrInvalidCommitSignatures(vals.Size(), len(commit.Signatures))
+	}
+
+	// Validate Height and BlockID.
+	if height != commit.Height {
+		return NewErrInvalidCommitHeight(height, commit.Height)
+	}
+	if !blockID.Equals(commit.BlockID) {
+		return fmt.Errorf("invalid commit -- wrong block ID: want %v, got %v",
+			blockID, commit.BlockID)
+	}
+	return nil
+}
+
 // LIGHT CLIENT VERIFICATION METHODS
 
 // VerifyCommitLight verifies +2/3 of the set had signed the given commit.
@@ -866,6 +939,16 @@ func (e ErrNotEnoughVotingPowerSigned) Error() string {
 	return fmt.Sprintf("invalid commit -- insufficient voting power: got %d, needed more than %d", e.Got, e.Needed)
 }
 
+// ErrWrongCommitSignatures is returned by VerifyCommitConcurrently when signatures
+// of a commit are wrong.
+type ErrWrongCommitSignatures struct {
+	Indices []int // of the wrong signatures in the commit
+}
+
+func (e ErrWrongCommitSignatures) Error() string {
+	return fmt.Sprintf("invalid commit -- wrong signatures at indices %v", e.Indices)
+}
+
 //----------------
 
 // String returns a string representation of ValidatorSet.
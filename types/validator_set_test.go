@@ -737,6 +737,9 @@ func TestValidatorSet_VerifyCommit_All(t *testing.T) {
 			} else {
 				assert.NoError(t, err, "VerifyCommitLight")
 			}
+
+			err = vset.VerifyCommitConcurrently(tc.chainID, tc.blockID, tc.height, tc.commit, 2)
+			assert.Equal(t, tc.expErr, err != nil, "VerifyCommitConcurrently")
 		})
 	}
 }
@@ -789,6 +792,43 @@ func TestValidatorSet_VerifyCommitLight_ReturnsAsSoonAsMajorityOfVotingPowerSign
 	assert.NoError(t, err)
 }
 
+func TestValidatorSet_VerifyCommitConcurrently(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, valSet.VerifyCommitConcurrently(chainID, blockID, h, commit, 2))
+
+	// the sign bytes are the ones the validators signed
+	for idx, signBytes := range commit.SignBytes(chainID) {
+		pubKey := valSet.Validators[idx].PubKey
+		assert.True(t, pubKey.VerifySignature(signBytes, commit.Signatures[idx].Signature))
+	}
+
+	// all the wrong signatures are reported, even if +2/3 are valid
+	for _, idx := range []int32{1, 3} {
+		vote := voteSet.GetByIndex(idx)
+		v := vote.ToProto()
+		require.NoError(t, vals[idx].SignVote("CentaurusA", v))
+		vote.Signature = v.Signature
+		commit.Signatures[idx] = vote.CommitSig()
+	}
+	err = valSet.VerifyCommitConcurrently(chainID, blockID, h, commit, 2)
+	if assert.IsType(t, ErrWrongCommitSignatures{}, err) {
+		assert.Equal(t, []int{1, 3}, err.(ErrWrongCommitSignatures).Indices)
+	}
+
+	commit.Signatures[1] = NewCommitSigAbsent()
+	commit.Signatures[3] = NewCommitSigAbsent()
+	assert.IsType(t, ErrNotEnoughVotingPowerSigned{}, valSet.VerifyCommitConcurrently(chainID, blockID, h, commit, 2))
+	assert.Nil(t, commit.SignBytes(chainID)[1])
+}
+
 func TestValidatorSet_VerifyCommitLightTrusting_ReturnsAsSoonAsTrustLevelOfVotingPowerSigned(t *testing.T) {
 	var (
 		chainID = "test_chain_id"
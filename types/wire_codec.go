@@ -0,0 +1,75 @@
+package types
+
+import (
+	"fmt"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/version"
+)
+
+// WireCodec converts the encoded blocks and votes between the current proto
+// schema and the one of a previous version of the p2p protocol, so that a node
+// keeps talking to the peers which haven't been upgraded yet when the schema
+// changes, instead of requiring all the nodes to upgrade at once. The
+// conversions must be lossless: the blocks and votes are verified against
+// their hashes and signatures once converted.
+//
+// The block parts gossiped by consensus aren't converted, as the block hash
+// commits to their encoding: a schema change of the blocks still requires the
+// block protocol version to change.
+type WireCodec interface {
+	// UpgradeBlock converts an encoded tendermint.types.Block from the schema
+	// of the codec to the current one.
+	UpgradeBlock(bz []byte) ([]byte, error)
+	// DowngradeBlock converts an encoded tendermint.types.Block from the
+	// current schema to the one of the codec.
+	DowngradeBlock(bz []byte) ([]byte, error)
+	// UpgradeVote converts an encoded tendermint.types.Vote from the schema of
+	// the codec to the current one.
+	UpgradeVote(bz []byte) ([]byte, error)
+	// DowngradeVote converts an encoded tendermint.types.Vote from the current
+	// schema to the one of the codec.
+	DowngradeVote(bz []byte) ([]byte, error)
+}
+
+var (
+	wireCodecsMtx tmsync.RWMutex
+	wireCodecs    = make(map[uint64]WireCodec)
+)
+
+// RegisterWireCodec registers the codec of the proto schema of the given
+// p2p protocol version, which must be lower than the current one. It panics if
+// a codec is already registered for the version.
+func RegisterWireCodec(p2pVersion uint64, codec WireCodec) {
+	if p2pVersion >= version.P2PProtocol {
+		panic(fmt.Sprintf("wire codec of p2p protocol %d isn't of a previous version (current: %d)",
+			p2pVersion, version.P2PProtocol))
+	}
+	wireCodecsMtx.Lock()
+	defer wireCodecsMtx.Unlock()
+	if _, ok := wireCodecs[p2pVersion]; ok {
+		panic(fmt.Sprintf("wire codec of p2p protocol %d already registered", p2pVersion))
+	}
+	wireCodecs[p2pVersion] = codec
+}
+
+// unregisterWireCodec removes the codec of the given p2p protocol version, so
+// that the tests registering codecs can be run again.
+func unregisterWireCodec(p2pVersion uint64) {
+	wireCodecsMtx.Lock()
+	defer wireCodecsMtx.Unlock()
+	delete(wireCodecs, p2pVersion)
+}
+
+// WireCodecFor returns the codec to talk to a peer of the given p2p protocol
+// version, or nil if the current schema is to be used. Both nodes use the
+// schema of the lowest of their versions, and the current schema if no codec
+// is registered for it, i.e. if the schema didn't change.
+func WireCodecFor(p2pVersion uint64) WireCodec {
+	if p2pVersion >= version.P2PProtocol {
+		return nil
+	}
+	wireCodecsMtx.RLock()
+	defer wireCodecsMtx.RUnlock()
+	return wireCodecs[p2pVersion]
+}
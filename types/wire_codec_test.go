@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/version"
+)
+
+type identityWireCodec struct{}
+
+func (identityWireCodec) UpgradeBlock(bz []byte) ([]byte, error)   { return bz, nil }
+func (identityWireCodec) DowngradeBlock(bz []byte) ([]byte, error) { return bz, nil }
+func (identityWireCodec) UpgradeVote(bz []byte) ([]byte, error)    { return bz, nil }
+func (identityWireCodec) DowngradeVote(bz []byte) ([]byte, error)  { return bz, nil }
+
+func TestWireCodecFor(t *testing.T) {
+	previous := version.P2PProtocol - 1
+	assert.Panics(t, func() { RegisterWireCodec(version.P2PProtocol, identityWireCodec{}) })
+	RegisterWireCodec(previous, identityWireCodec{})
+	t.Cleanup(func() { unregisterWireCodec(previous) })
+	assert.Panics(t, func() { RegisterWireCodec(previous, identityWireCodec{}) })
+
+	assert.Equal(t, identityWireCodec{}, WireCodecFor(previous))
+	// the schema didn't change, or the peer talks the current schema
+	assert.Nil(t, WireCodecFor(previous-1))
+	assert.Nil(t, WireCodecFor(version.P2PProtocol))
+	assert.Nil(t, WireCodecFor(version.P2PProtocol+1))
+}